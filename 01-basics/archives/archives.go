@@ -0,0 +1,285 @@
+// This file demonstrates reading and writing archives in Go
+// The standard library supports two common formats:
+// - archive/zip: random access, each file compressed separately
+// - archive/tar: a sequential stream of files, usually wrapped in compress/gzip
+//
+// Both packages stream data through io.Reader and io.Writer, so archives of any
+// size can be created or extracted without loading whole files into memory
+//
+// Security note: archive entry names come from whoever created the archive.
+// A name like "../../etc/passwd" must never be joined blindly with the
+// destination directory ("zip slip" / path traversal)
+
+package main
+
+import (
+	"archive/tar"
+	"archive/zip"
+	"bytes"
+	"compress/gzip"
+	"errors"
+	"fmt"
+	"io"
+	"io/fs"
+	"os"
+	"path/filepath"
+)
+
+// ErrUnsafePath is returned for archive entries that would escape the destination
+var ErrUnsafePath = errors.New("unsafe path in archive")
+
+// safeJoin joins an archive entry name onto dest, rejecting names that are
+// absolute or that climb out of dest with ".."
+// filepath.IsLocal (Go 1.20+) does the lexical checks for us
+func safeJoin(dest, name string) (string, error) {
+	if !filepath.IsLocal(name) {
+		return "", fmt.Errorf("%w: %q", ErrUnsafePath, name)
+	}
+	return filepath.Join(dest, name), nil
+}
+
+// writeZip adds every regular file under root to a zip archive written to w
+// Entry names are relative to root and always use forward slashes
+func writeZip(w io.Writer, root string) error {
+	zw := zip.NewWriter(w)
+
+	err := filepath.WalkDir(root, func(path string, d fs.DirEntry, err error) error {
+		if err != nil || d.IsDir() {
+			return err
+		}
+		rel, err := filepath.Rel(root, path)
+		if err != nil {
+			return err
+		}
+
+		// Create returns a writer for one entry; it is valid until the next Create
+		entry, err := zw.Create(filepath.ToSlash(rel))
+		if err != nil {
+			return err
+		}
+		f, err := os.Open(path)
+		if err != nil {
+			return err
+		}
+		defer f.Close()
+
+		// Stream the file into the archive
+		_, err = io.Copy(entry, f)
+		return err
+	})
+	if err != nil {
+		return err
+	}
+
+	// Close writes the central directory; the archive is invalid without it
+	return zw.Close()
+}
+
+// extractZip extracts a zip archive into dest
+func extractZip(archive, dest string) ([]string, error) {
+	zr, err := zip.OpenReader(archive)
+	if err != nil {
+		return nil, err
+	}
+	defer zr.Close()
+
+	extracted := []string{}
+	for _, file := range zr.File {
+		target, err := safeJoin(dest, file.Name)
+		if err != nil {
+			return extracted, err
+		}
+		if file.FileInfo().IsDir() {
+			continue
+		}
+		if err := extractFile(file, target); err != nil {
+			return extracted, err
+		}
+		extracted = append(extracted, file.Name)
+	}
+	return extracted, nil
+}
+
+// extractFile copies one zip entry to target
+// It lives in its own function so the deferred Close calls run per file,
+// not at the end of the whole extraction loop
+func extractFile(file *zip.File, target string) error {
+	if err := os.MkdirAll(filepath.Dir(target), 0o755); err != nil {
+		return err
+	}
+	src, err := file.Open()
+	if err != nil {
+		return err
+	}
+	defer src.Close()
+
+	dst, err := os.Create(target)
+	if err != nil {
+		return err
+	}
+	defer dst.Close()
+
+	_, err = io.Copy(dst, src)
+	return err
+}
+
+// writeTarGz writes every regular file under root as a gzip-compressed tar stream
+// The writers are layered: tar -> gzip -> w
+func writeTarGz(w io.Writer, root string) error {
+	gz := gzip.NewWriter(w)
+	tw := tar.NewWriter(gz)
+
+	err := filepath.WalkDir(root, func(path string, d fs.DirEntry, err error) error {
+		if err != nil || d.IsDir() {
+			return err
+		}
+		info, err := d.Info()
+		if err != nil {
+			return err
+		}
+		rel, err := filepath.Rel(root, path)
+		if err != nil {
+			return err
+		}
+
+		// Tar needs the size up front, so write a header first
+		header, err := tar.FileInfoHeader(info, "")
+		if err != nil {
+			return err
+		}
+		header.Name = filepath.ToSlash(rel)
+		if err := tw.WriteHeader(header); err != nil {
+			return err
+		}
+
+		f, err := os.Open(path)
+		if err != nil {
+			return err
+		}
+		defer f.Close()
+		_, err = io.Copy(tw, f)
+		return err
+	})
+	if err != nil {
+		return err
+	}
+
+	// Close in reverse order of creation: tar footer first, then gzip footer
+	if err := tw.Close(); err != nil {
+		return err
+	}
+	return gz.Close()
+}
+
+// listTarGz reads a tar.gz stream entry by entry
+// Unlike zip there is no index: the only way to find a file is to read forward
+func listTarGz(r io.Reader) (map[string]string, error) {
+	gz, err := gzip.NewReader(r)
+	if err != nil {
+		return nil, err
+	}
+	defer gz.Close()
+
+	contents := make(map[string]string)
+	tr := tar.NewReader(gz)
+	for {
+		header, err := tr.Next()
+		if err == io.EOF {
+			break // End of archive
+		}
+		if err != nil {
+			return nil, err
+		}
+		if _, err := safeJoin(".", header.Name); err != nil {
+			return nil, err
+		}
+
+		// tr acts as a reader for the current entry's data
+		data, err := io.ReadAll(tr)
+		if err != nil {
+			return nil, err
+		}
+		contents[header.Name] = string(data)
+	}
+	return contents, nil
+}
+
+func main() {
+	// Work in a temporary directory that is removed at the end
+	workDir, err := os.MkdirTemp("", "archives-example")
+	if err != nil {
+		fmt.Println("Error:", err)
+		return
+	}
+	defer os.RemoveAll(workDir)
+
+	// Create some sample files to archive
+	source := filepath.Join(workDir, "source")
+	files := map[string]string{
+		"readme.txt":        "Sample dataset\n",
+		"data/numbers.csv":  "id,value\n1,10\n2,20\n",
+		"data/nested/a.txt": "nested file\n",
+	}
+	for name, content := range files {
+		path := filepath.Join(source, name)
+		os.MkdirAll(filepath.Dir(path), 0o755)
+		os.WriteFile(path, []byte(content), 0o644)
+	}
+
+	// Example 1: Writing a zip archive
+	fmt.Println("=== Zip Archives ===")
+	zipPath := filepath.Join(workDir, "sample.zip")
+	zipFile, err := os.Create(zipPath)
+	if err != nil {
+		fmt.Println("Error:", err)
+		return
+	}
+	err = writeZip(zipFile, source)
+	zipFile.Close()
+	if err != nil {
+		fmt.Println("Error:", err)
+		return
+	}
+	info, _ := os.Stat(zipPath)
+	fmt.Printf("Created sample.zip (%d bytes)\n", info.Size())
+
+	// Example 2: Extracting a zip archive
+	extracted, err := extractZip(zipPath, filepath.Join(workDir, "unzipped"))
+	fmt.Printf("Extracted %d files: %v (error: %v)\n", len(extracted), extracted, err)
+
+	// Example 3: Writing and reading a tar.gz stream
+	fmt.Println("\n=== Tar + Gzip Archives ===")
+	var buf bytes.Buffer
+	if err := writeTarGz(&buf, source); err != nil {
+		fmt.Println("Error:", err)
+		return
+	}
+	fmt.Printf("Created tar.gz stream (%d bytes)\n", buf.Len())
+	contents, err := listTarGz(&buf)
+	if err != nil {
+		fmt.Println("Error:", err)
+		return
+	}
+	for _, name := range []string{"readme.txt", "data/numbers.csv", "data/nested/a.txt"} {
+		fmt.Printf("%s: %q\n", name, contents[name])
+	}
+
+	// Example 4: Path traversal protection
+	fmt.Println("\n=== Path Traversal Safety ===")
+	evilPath := filepath.Join(workDir, "evil.zip")
+	evilFile, _ := os.Create(evilPath)
+	zw := zip.NewWriter(evilFile)
+	entry, _ := zw.Create("../../outside.txt")
+	entry.Write([]byte("should never be written"))
+	zw.Close()
+	evilFile.Close()
+
+	_, err = extractZip(evilPath, filepath.Join(workDir, "evil"))
+	fmt.Printf("Extracting evil.zip: %v\n", err)
+	fmt.Printf("Is it ErrUnsafePath? %v\n", errors.Is(err, ErrUnsafePath))
+
+	for _, name := range []string{"data/ok.txt", "../escape.txt", "/etc/passwd", "a/../../b"} {
+		_, err := safeJoin("dest", name)
+		fmt.Printf("safeJoin(%q): safe=%v\n", name, err == nil)
+	}
+}
@@ -1,6 +1,7 @@
 // This file demonstrates the usage of arrays and slices in Go
 // Arrays are fixed-size sequences of elements of the same type
 // Slices are dynamic, flexible views into arrays
+// See slices-advanced.go for slice internals, aliasing and performance
 
 package main
 
@@ -0,0 +1,285 @@
+// This file demonstrates advanced channel patterns in Go
+// It builds on concurrency.go, which introduces goroutines, channels and select
+//
+// Topics:
+// - select with default (non-blocking send and receive)
+// - Timeouts with time.After and time.NewTimer
+// - Done channels for cancellation
+// - nil channels to switch select cases off
+// - Directional channel types (chan<- and <-chan)
+// - Graceful worker shutdown
+
+package main
+
+import (
+	"fmt"
+	"sync"
+	"time"
+)
+
+// tryReceive returns immediately instead of blocking when ch is empty
+// select picks default only when no other case is ready
+func tryReceive(ch <-chan int) (int, bool) {
+	select {
+	case v := <-ch:
+		return v, true
+	default:
+		return 0, false
+	}
+}
+
+// trySend drops the value instead of blocking when ch is full
+func trySend(ch chan<- int, v int) bool {
+	select {
+	case ch <- v:
+		return true
+	default:
+		return false
+	}
+}
+
+// slowLookup answers after delay
+// The channel is buffered so the goroutine can still send and exit when the
+// caller has already timed out; an unbuffered channel would leak it
+func slowLookup(key string, delay time.Duration) <-chan string {
+	result := make(chan string, 1)
+	go func() {
+		time.Sleep(delay)
+		result <- "value for " + key
+	}()
+	return result
+}
+
+// lookupWithTimeout waits for a result or gives up after timeout
+func lookupWithTimeout(key string, delay, timeout time.Duration) (string, error) {
+	select {
+	case v := <-slowLookup(key, delay):
+		return v, nil
+	case <-time.After(timeout):
+		return "", fmt.Errorf("lookup %q timed out after %v", key, timeout)
+	}
+}
+
+// ticker sends a tick every interval until done is closed
+// Closing a channel is a broadcast: every receiver sees it at once, which is
+// why done channels carry no values (chan struct{})
+func ticker(done <-chan struct{}, interval time.Duration) <-chan int {
+	ticks := make(chan int)
+	go func() {
+		defer close(ticks)
+		t := time.NewTicker(interval)
+		defer t.Stop()
+		for i := 1; ; i++ {
+			select {
+			case <-done:
+				return
+			case <-t.C:
+				select {
+				case ticks <- i:
+				case <-done:
+					return
+				}
+			}
+		}
+	}()
+	return ticks
+}
+
+// merge combines two channels until both are closed
+// A receive from a nil channel blocks forever, so setting a closed channel to
+// nil switches its case off instead of spinning on zero values
+func merge(a, b <-chan int) <-chan int {
+	out := make(chan int)
+	go func() {
+		defer close(out)
+		for a != nil || b != nil {
+			select {
+			case v, ok := <-a:
+				if !ok {
+					a = nil
+					continue
+				}
+				out <- v
+			case v, ok := <-b:
+				if !ok {
+					b = nil
+					continue
+				}
+				out <- v
+			}
+		}
+	}()
+	return out
+}
+
+// produce only sends; consume only receives
+// The compiler rejects a receive on chan<- or a send on <-chan, so the types
+// document and enforce which side owns the channel (and who may close it)
+func produce(out chan<- int, values ...int) {
+	defer close(out) // Only the sender closes
+	for _, v := range values {
+		out <- v
+	}
+}
+
+func consume(in <-chan int) int {
+	sum := 0
+	for v := range in {
+		sum += v
+	}
+	return sum
+}
+
+// WorkerPool processes jobs until Shutdown is called
+type WorkerPool struct {
+	jobs    chan int
+	results chan string
+	quit    chan struct{}
+	wg      sync.WaitGroup
+}
+
+// NewWorkerPool starts n workers
+func NewWorkerPool(n int) *WorkerPool {
+	p := &WorkerPool{
+		jobs:    make(chan int, 10),
+		results: make(chan string, 100),
+		quit:    make(chan struct{}),
+	}
+	for id := 1; id <= n; id++ {
+		p.wg.Add(1)
+		go p.worker(id)
+	}
+	return p
+}
+
+func (p *WorkerPool) worker(id int) {
+	defer p.wg.Done()
+	for {
+		select {
+		case job, ok := <-p.jobs:
+			if !ok {
+				return // Jobs channel closed and drained: graceful exit
+			}
+			time.Sleep(10 * time.Millisecond)
+			p.results <- fmt.Sprintf("worker %d finished job %d", id, job)
+		case <-p.quit:
+			// Immediate stop: queued jobs are abandoned
+			// When both cases are ready select picks one at random, so a worker
+			// may still take one more job before it notices quit
+			return
+		}
+	}
+}
+
+// Submit queues a job
+func (p *WorkerPool) Submit(job int) {
+	p.jobs <- job
+}
+
+// Shutdown stops accepting jobs and waits for queued jobs to finish
+func (p *WorkerPool) Shutdown() []string {
+	close(p.jobs)
+	p.wg.Wait()
+	return p.collect()
+}
+
+// Stop tells workers to quit without draining the queue
+func (p *WorkerPool) Stop() []string {
+	close(p.quit)
+	p.wg.Wait()
+	return p.collect()
+}
+
+func (p *WorkerPool) collect() []string {
+	close(p.results)
+	done := []string{}
+	for r := range p.results {
+		done = append(done, r)
+	}
+	return done
+}
+
+func main() {
+	// ==================== select with default ====================
+	fmt.Println("=== Non-blocking Operations ===")
+	ch := make(chan int, 1)
+	_, ok := tryReceive(ch)
+	fmt.Printf("Receive from empty channel: ok=%v\n", ok)
+	fmt.Printf("Send to empty buffer: %v\n", trySend(ch, 1))
+	fmt.Printf("Send to full buffer: %v\n", trySend(ch, 2))
+	v, ok := tryReceive(ch)
+	fmt.Printf("Receive: %d, ok=%v\n", v, ok)
+
+	// ==================== Timeouts ====================
+	fmt.Println("\n=== Timeouts ===")
+	result, err := lookupWithTimeout("fast", 10*time.Millisecond, 50*time.Millisecond)
+	fmt.Printf("Fast lookup: %q, err: %v\n", result, err)
+	result, err = lookupWithTimeout("slow", 200*time.Millisecond, 50*time.Millisecond)
+	fmt.Printf("Slow lookup: %q, err: %v\n", result, err)
+
+	// time.After allocates a timer per call; in a loop, reuse one time.Timer
+	timer := time.NewTimer(30 * time.Millisecond)
+	events := slowLookup("event", 10*time.Millisecond)
+	received := 0
+loop:
+	for {
+		select {
+		case <-events:
+			received++
+			events = slowLookup("event", 10*time.Millisecond)
+		case <-timer.C:
+			break loop // A plain break would only leave the select
+		}
+	}
+	fmt.Printf("Events received before the 30ms timer fired: %d\n", received)
+
+	// ==================== Done Channels ====================
+	fmt.Println("\n=== Done Channel Cancellation ===")
+	done := make(chan struct{})
+	ticks := ticker(done, 10*time.Millisecond)
+	for tick := range ticks {
+		fmt.Printf("Tick %d\n", tick)
+		if tick == 3 {
+			close(done) // Every goroutine watching done stops
+		}
+	}
+	fmt.Println("Ticker stopped and closed its channel")
+
+	// ==================== nil Channels ====================
+	fmt.Println("\n=== nil Channels in select ===")
+	evens, odds := make(chan int), make(chan int)
+	go produce(evens, 2, 4, 6)
+	go produce(odds, 1, 3)
+	total := 0
+	count := 0
+	for v := range merge(evens, odds) {
+		total += v
+		count++
+	}
+	fmt.Printf("Merged %d values, sum %d\n", count, total)
+
+	// ==================== Directional Channels ====================
+	fmt.Println("\n=== Directional Channel Types ===")
+	numbers := make(chan int) // Bidirectional; converts implicitly to either direction
+	go produce(numbers, 10, 20, 30)
+	fmt.Printf("Consumer sum: %d\n", consume(numbers))
+	// consume(numbers) could not send, and produce could not receive:
+	// var recvOnly <-chan int = numbers; recvOnly <- 1 // compile error
+
+	// ==================== Graceful Shutdown ====================
+	fmt.Println("\n=== Graceful Worker Shutdown ===")
+	pool := NewWorkerPool(3)
+	for job := 1; job <= 6; job++ {
+		pool.Submit(job)
+	}
+	finished := pool.Shutdown()
+	fmt.Printf("Shutdown (drain queue): %d of 6 jobs finished\n", len(finished))
+
+	pool = NewWorkerPool(2)
+	for job := 1; job <= 6; job++ {
+		pool.Submit(job)
+	}
+	time.Sleep(15 * time.Millisecond)
+	finished = pool.Stop()
+	fmt.Printf("Stop (abandon queue): %d of 6 jobs finished\n", len(finished))
+}
@@ -0,0 +1,233 @@
+// This file demonstrates building command-line programs in Go
+//
+// Topics:
+// - os.Args: the raw command-line arguments
+// - The flag package: typed flags, defaults, usage messages
+// - Custom flag types with the flag.Value interface
+// - Subcommands with flag.NewFlagSet (like "go build", "git commit")
+// - Environment variables with os.Getenv and os.LookupEnv
+// - Exit codes with os.Exit
+//
+// Usage:
+//   go run ./01-basics/cli                          # runs sample invocations
+//   go run ./01-basics/cli greet -name=Somchai -times=2 -tag=a -tag=b
+//   go run ./01-basics/cli sum -level=debug 1 2 3.5
+//   GREETING=Sawasdee go run ./01-basics/cli greet -name=Nok
+//   go run ./01-basics/cli sum x; echo "exit code: $?"
+
+package main
+
+import (
+	"errors"
+	"flag"
+	"fmt"
+	"io"
+	"os"
+	"strconv"
+	"strings"
+)
+
+// Exit codes: 0 means success, anything else is failure
+// By convention 2 means the command was used incorrectly
+const (
+	exitOK    = 0
+	exitError = 1
+	exitUsage = 2
+)
+
+// tagList is a custom flag type collecting repeated flags: -tag=a -tag=b
+// Any type with String and Set methods implements flag.Value
+type tagList []string
+
+func (t *tagList) String() string {
+	return strings.Join(*t, ",")
+}
+
+func (t *tagList) Set(value string) error {
+	if value == "" {
+		return errors.New("tag must not be empty")
+	}
+	*t = append(*t, value)
+	return nil
+}
+
+// logLevel is a custom flag type that only accepts known values
+type logLevel string
+
+func (l *logLevel) String() string {
+	return string(*l)
+}
+
+func (l *logLevel) Set(value string) error {
+	switch value {
+	case "debug", "info", "warn", "error":
+		*l = logLevel(value)
+		return nil
+	}
+	return fmt.Errorf("unknown level %q (want debug, info, warn or error)", value)
+}
+
+// subcommand is one verb of the program, e.g. "greet" in "cli greet -name=x"
+type subcommand struct {
+	name    string
+	summary string
+	run     func(args []string, stdout, stderr io.Writer) error
+}
+
+var subcommands = []subcommand{
+	{"greet", "print a greeting", runGreet},
+	{"sum", "add up numbers", runSum},
+	{"env", "show configuration read from the environment", runEnv},
+}
+
+// errUsage marks errors caused by bad arguments, which exit with code 2
+var errUsage = errors.New("usage error")
+
+// runGreet implements "greet [-name NAME] [-times N] [-tag TAG]..."
+// Each subcommand has its own FlagSet, so its flags don't clash with others
+func runGreet(args []string, stdout, stderr io.Writer) error {
+	fs := flag.NewFlagSet("greet", flag.ContinueOnError)
+	fs.SetOutput(stderr) // Usage and parse errors go to stderr
+	name := fs.String("name", "World", "who to greet")
+	times := fs.Int("times", 1, "how many times to greet")
+	var tags tagList
+	fs.Var(&tags, "tag", "a tag to attach (repeatable)")
+	// ContinueOnError makes Parse return errors instead of exiting the program
+	if err := fs.Parse(args); err != nil {
+		return fmt.Errorf("%w: %w", errUsage, err)
+	}
+	if *times < 1 {
+		return fmt.Errorf("%w: -times must be at least 1", errUsage)
+	}
+
+	// Environment variables configure behavior without flags
+	greeting := os.Getenv("GREETING") // "" when unset
+	if greeting == "" {
+		greeting = "Hello"
+	}
+	for i := 0; i < *times; i++ {
+		fmt.Fprintf(stdout, "%s, %s!\n", greeting, *name)
+	}
+	if len(tags) > 0 {
+		fmt.Fprintf(stdout, "tags: %s\n", tags.String())
+	}
+	return nil
+}
+
+// runSum implements "sum [-level LEVEL] NUMBER..."
+// Positional arguments are whatever is left after the flags
+func runSum(args []string, stdout, stderr io.Writer) error {
+	fs := flag.NewFlagSet("sum", flag.ContinueOnError)
+	fs.SetOutput(stderr) // Usage and parse errors go to stderr
+	level := logLevel("info")
+	fs.Var(&level, "level", "log level: debug, info, warn or error")
+	if err := fs.Parse(args); err != nil {
+		return fmt.Errorf("%w: %w", errUsage, err)
+	}
+	if fs.NArg() == 0 {
+		return fmt.Errorf("%w: sum needs at least one number", errUsage)
+	}
+
+	total := 0.0
+	for _, arg := range fs.Args() {
+		n, err := strconv.ParseFloat(arg, 64)
+		if err != nil {
+			return fmt.Errorf("not a number: %q", arg) // Runtime error: exit code 1
+		}
+		if level == "debug" {
+			fmt.Fprintf(stdout, "debug: adding %v\n", n)
+		}
+		total += n
+	}
+	fmt.Fprintf(stdout, "sum = %v\n", total)
+	return nil
+}
+
+// runEnv shows the difference between Getenv and LookupEnv
+func runEnv(args []string, stdout, stderr io.Writer) error {
+	for _, key := range []string{"HOME", "GREETING", "APP_DEBUG"} {
+		// LookupEnv tells "set to empty" apart from "not set at all"
+		value, ok := os.LookupEnv(key)
+		fmt.Fprintf(stdout, "%-9s set=%-5v value=%q\n", key, ok, value)
+	}
+	// Parse typed values yourself and fall back to a default on bad input
+	debug, err := strconv.ParseBool(os.Getenv("APP_DEBUG"))
+	fmt.Fprintf(stdout, "APP_DEBUG as bool: %v (parse error: %v)\n", debug, err != nil)
+	return nil
+}
+
+// run dispatches to a subcommand and returns the exit code
+// Keeping os.Exit out of run makes the program easy to test: a test can
+// call run with any arguments and check the output and exit code
+func run(args []string, stdout, stderr io.Writer) int {
+	if len(args) == 0 || args[0] == "-h" || args[0] == "help" {
+		fmt.Fprintln(stderr, "Usage: cli <command> [flags] [arguments]")
+		for _, c := range subcommands {
+			fmt.Fprintf(stderr, "  %-6s %s\n", c.name, c.summary)
+		}
+		return exitUsage
+	}
+	for _, c := range subcommands {
+		if c.name != args[0] {
+			continue
+		}
+		err := c.run(args[1:], stdout, stderr)
+		switch {
+		case err == nil:
+			return exitOK
+		case errors.Is(err, flag.ErrHelp):
+			return exitOK // -h was requested and usage was printed
+		case errors.Is(err, errUsage):
+			fmt.Fprintf(stderr, "cli %s: %v\n", c.name, err)
+			return exitUsage
+		default:
+			fmt.Fprintf(stderr, "cli %s: %v\n", c.name, err)
+			return exitError
+		}
+	}
+	fmt.Fprintf(stderr, "cli: unknown command %q\n", args[0])
+	return exitUsage
+}
+
+func main() {
+	// os.Args[0] is the program path; the real arguments start at index 1
+	if len(os.Args) > 1 {
+		// os.Exit ends the program immediately: deferred functions do NOT run,
+		// so call it only from main after everything is cleaned up
+		os.Exit(run(os.Args[1:], os.Stdout, os.Stderr))
+	}
+
+	// No arguments: run some sample invocations instead
+	fmt.Println("=== os.Args ===")
+	fmt.Printf("Program: %s, arguments: %q\n", os.Args[0][strings.LastIndex(os.Args[0], "/")+1:], os.Args[1:])
+
+	samples := [][]string{
+		{"greet"},
+		{"greet", "-name=Somchai", "-times=2", "-tag=go", "-tag=cli"},
+		{"greet", "-times=0"},
+		{"greet", "-tag="},
+		{"sum", "1", "2", "3.5"},
+		{"sum", "-level=debug", "10", "20"},
+		{"sum", "-level=verbose", "1"},
+		{"sum", "1", "two"},
+		{"deploy"},
+	}
+	fmt.Println("\n=== Subcommands and Exit Codes ===")
+	for _, args := range samples {
+		fmt.Printf("\n$ cli %s\n", strings.Join(args, " "))
+		var stdout, stderr strings.Builder
+		code := run(args, &stdout, &stderr)
+		fmt.Print(stdout.String())
+		// The flag package prints its own usage on parse errors; show just the first line
+		if msg, _, _ := strings.Cut(stderr.String(), "\n"); msg != "" {
+			fmt.Printf("stderr: %s\n", msg)
+		}
+		fmt.Printf("exit code: %d\n", code)
+	}
+
+	fmt.Println("\n=== Environment Variables ===")
+	os.Setenv("GREETING", "Sawasdee") // Affects this process and its children only
+	os.Setenv("APP_DEBUG", "")
+	run([]string{"greet", "-name=Nok"}, os.Stdout, os.Stderr)
+	run([]string{"env"}, os.Stdout, os.Stderr)
+}
@@ -5,6 +5,7 @@
 package main
 
 import (
+	"context"
 	"fmt"
 	"sync"
 	"time"
@@ -35,6 +36,23 @@ func generateNumbers(ch chan int) {
 	close(ch) // Always close channels when done sending
 }
 
+// Context-aware channel example
+// generateNumbers above can only stop after sending all 5 numbers.
+// This version also stops as soon as ctx is cancelled, so a consumer that
+// gives up early does not leave the goroutine blocked on a send forever
+// See context.go for more on the context package
+func generateUntilCancelled(ctx context.Context, ch chan<- int) {
+	defer close(ch)
+	for i := 1; ; i++ {
+		select {
+		case ch <- i:
+			time.Sleep(50 * time.Millisecond)
+		case <-ctx.Done():
+			return
+		}
+	}
+}
+
 func main() {
 	// ==================== Goroutines Example ====================
 	fmt.Println("Goroutines Example:")
@@ -112,4 +130,17 @@ func main() {
 			fmt.Println(msg2)
 		}
 	}
+
+	// ==================== Context Cancellation Example ====================
+	fmt.Println("\nContext Cancellation Example:")
+	// The producer runs until the context times out
+	ctx, cancel := context.WithTimeout(context.Background(), 180*time.Millisecond)
+	defer cancel() // Always release the context's resources
+
+	values := make(chan int)
+	go generateUntilCancelled(ctx, values)
+	for v := range values {
+		fmt.Printf("Received before timeout: %d\n", v)
+	}
+	fmt.Println("Producer stopped:", ctx.Err())
 }
@@ -0,0 +1,269 @@
+// This file demonstrates the context package in Go
+// A context.Context carries a cancellation signal, a deadline and
+// request-scoped values across API boundaries and between goroutines
+//
+// Topics:
+// - Cancellation with context.WithCancel
+// - Timeouts and deadlines with context.WithTimeout / WithDeadline
+// - Context values (and why to avoid abusing them)
+// - Passing context through goroutines (worker pools)
+// - Context in HTTP clients and servers
+//
+// Rules of thumb:
+// - Pass ctx as the first parameter, named ctx; never store it in a struct
+// - Always call the cancel function, usually with defer
+// - Never pass a nil context; use context.Background() or context.TODO()
+
+package main
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"sync"
+	"time"
+)
+
+// slowSquare simulates work that takes a while and respects cancellation
+// select waits for whichever happens first: the work finishing or ctx ending
+func slowSquare(ctx context.Context, n int, delay time.Duration) (int, error) {
+	select {
+	case <-time.After(delay):
+		return n * n, nil
+	case <-ctx.Done():
+		// ctx.Err() is context.Canceled or context.DeadlineExceeded
+		return 0, ctx.Err()
+	}
+}
+
+// generate sends increasing numbers until ctx is cancelled
+// Without ctx the goroutine would block forever on the send once the
+// consumer stops reading: a goroutine leak
+func generate(ctx context.Context) <-chan int {
+	out := make(chan int)
+	go func() {
+		defer close(out)
+		for i := 1; ; i++ {
+			select {
+			case out <- i:
+			case <-ctx.Done():
+				return
+			}
+		}
+	}()
+	return out
+}
+
+// Context keys should be an unexported type so other packages cannot collide
+// with them, even if they use the same underlying string
+type ctxKey string
+
+const requestIDKey ctxKey = "requestID"
+
+// WithRequestID returns a copy of ctx carrying a request ID
+func WithRequestID(ctx context.Context, id string) context.Context {
+	return context.WithValue(ctx, requestIDKey, id)
+}
+
+// RequestID extracts the request ID; the type assertion handles a missing value
+func RequestID(ctx context.Context) string {
+	id, ok := ctx.Value(requestIDKey).(string)
+	if !ok {
+		return "unknown"
+	}
+	return id
+}
+
+// logf is the kind of function context values are good for: request-scoped
+// data that crosses API boundaries, such as request IDs or trace IDs
+func logf(ctx context.Context, format string, args ...any) {
+	fmt.Printf("[%s] "+format+"\n", append([]any{RequestID(ctx)}, args...)...)
+}
+
+// Bad: hiding required parameters in the context
+// The signature no longer says what the function needs, the compiler cannot
+// check it, and a missing value is only discovered at runtime
+func chargeBad(ctx context.Context) error {
+	amount, ok := ctx.Value(ctxKey("amount")).(int)
+	if !ok {
+		return errors.New("amount missing from context")
+	}
+	logf(ctx, "charging %d", amount)
+	return nil
+}
+
+// Good: required data is an explicit parameter; ctx only carries cancellation
+// and request-scoped metadata
+func charge(ctx context.Context, amount int) error {
+	if err := ctx.Err(); err != nil {
+		return err // Don't start work for a request that is already gone
+	}
+	logf(ctx, "charging %d", amount)
+	return nil
+}
+
+// processAll runs jobs on a pool of workers and stops early when ctx ends
+func processAll(ctx context.Context, jobs []int, workers int) ([]int, error) {
+	jobCh := make(chan int)
+	results := make(chan int, len(jobs))
+	var wg sync.WaitGroup
+
+	for w := 0; w < workers; w++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			for n := range jobCh {
+				result, err := slowSquare(ctx, n, 30*time.Millisecond)
+				if err != nil {
+					return
+				}
+				results <- result
+			}
+		}()
+	}
+
+	// Feed jobs, but stop as soon as ctx is done
+	sendErr := func() error {
+		defer close(jobCh)
+		for _, n := range jobs {
+			select {
+			case jobCh <- n:
+			case <-ctx.Done():
+				return ctx.Err()
+			}
+		}
+		return nil
+	}()
+
+	wg.Wait()
+	close(results)
+	collected := []int{}
+	for r := range results {
+		collected = append(collected, r)
+	}
+	if sendErr != nil {
+		return collected, sendErr
+	}
+	return collected, ctx.Err()
+}
+
+// slowHandler is an HTTP handler that notices when the client goes away
+// r.Context() is cancelled when the client disconnects or the server shuts down
+func slowHandler(w http.ResponseWriter, r *http.Request) {
+	select {
+	case <-time.After(200 * time.Millisecond):
+		fmt.Fprintln(w, "slow response")
+	case <-r.Context().Done():
+		fmt.Println("Server: client gave up, stopping work:", r.Context().Err())
+	}
+}
+
+func fetch(ctx context.Context, url string) (string, error) {
+	// NewRequestWithContext ties the whole request (dial, headers, body) to ctx
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, url, nil)
+	if err != nil {
+		return "", err
+	}
+	resp, err := http.DefaultClient.Do(req)
+	if err != nil {
+		return "", err
+	}
+	defer resp.Body.Close()
+	body, err := io.ReadAll(resp.Body)
+	return string(body), err
+}
+
+func main() {
+	// ==================== Cancellation ====================
+	fmt.Println("=== Cancellation ===")
+	// Background is the root of every context tree
+	ctx, cancel := context.WithCancel(context.Background())
+	numbers := generate(ctx)
+	for n := range numbers {
+		fmt.Printf("Received %d\n", n)
+		if n == 3 {
+			cancel() // Tell the generator to stop
+			break
+		}
+	}
+	fmt.Printf("ctx.Err() after cancel: %v\n", ctx.Err())
+
+	// Cancelling a parent cancels all of its children, but not the other way round
+	parent, cancelParent := context.WithCancel(context.Background())
+	child, cancelChild := context.WithCancel(parent)
+	cancelChild()
+	fmt.Printf("Child cancelled -> parent err: %v\n", parent.Err())
+	cancelParent()
+	fmt.Printf("Parent cancelled -> child err: %v\n", child.Err())
+
+	// WithCancelCause (Go 1.20+) records why the context was cancelled
+	causeCtx, cancelCause := context.WithCancelCause(context.Background())
+	cancelCause(errors.New("user pressed stop"))
+	fmt.Printf("Err: %v, Cause: %v\n", causeCtx.Err(), context.Cause(causeCtx))
+
+	// ==================== Timeouts and Deadlines ====================
+	fmt.Println("\n=== Timeouts and Deadlines ===")
+	// WithTimeout is shorthand for WithDeadline(ctx, time.Now().Add(d))
+	timeoutCtx, cancelTimeout := context.WithTimeout(context.Background(), 50*time.Millisecond)
+	defer cancelTimeout() // Releases the timer even if the work finishes early
+
+	result, err := slowSquare(timeoutCtx, 4, 10*time.Millisecond)
+	fmt.Printf("Fast work: %d, err: %v\n", result, err)
+	result, err = slowSquare(timeoutCtx, 5, 200*time.Millisecond)
+	fmt.Printf("Slow work: %d, err: %v\n", result, err)
+	fmt.Printf("Is DeadlineExceeded? %v\n", errors.Is(err, context.DeadlineExceeded))
+
+	deadline := time.Now().Add(time.Hour)
+	deadlineCtx, cancelDeadline := context.WithDeadline(context.Background(), deadline)
+	defer cancelDeadline()
+	// A child can shorten its parent's deadline but never extend it
+	shorter, cancelShorter := context.WithTimeout(deadlineCtx, time.Minute)
+	defer cancelShorter()
+	d, ok := shorter.Deadline()
+	fmt.Printf("Child deadline is ~1 minute away: %v (has deadline: %v)\n",
+		time.Until(d).Round(time.Minute), ok)
+
+	// ==================== Context Values ====================
+	fmt.Println("\n=== Context Values ===")
+	reqCtx := WithRequestID(context.Background(), "req-42")
+	logf(reqCtx, "handling request")
+	logf(context.Background(), "no request ID set")
+
+	// Values are looked up through the parent chain, so derived contexts keep them
+	derived, cancelDerived := context.WithTimeout(reqCtx, time.Second)
+	logf(derived, "derived context still has the ID")
+	cancelDerived()
+
+	fmt.Printf("chargeBad without amount: %v\n", chargeBad(reqCtx))
+	charge(reqCtx, 100)
+	fmt.Printf("charge on a cancelled context: %v\n", charge(derived, 100))
+
+	// ==================== Context Through Goroutines ====================
+	fmt.Println("\n=== Worker Pool with Context ===")
+	jobs := []int{1, 2, 3, 4, 5, 6, 7, 8, 9, 10}
+	results, err := processAll(context.Background(), jobs, 3)
+	fmt.Printf("No deadline: %d results, err: %v\n", len(results), err)
+
+	poolCtx, cancelPool := context.WithTimeout(context.Background(), 70*time.Millisecond)
+	defer cancelPool()
+	results, err = processAll(poolCtx, jobs, 3)
+	fmt.Printf("70ms deadline: %d of %d results, err: %v\n", len(results), len(jobs), err)
+
+	// ==================== HTTP ====================
+	fmt.Println("\n=== HTTP Requests ===")
+	// httptest runs a real server on a local port, no network access needed
+	server := httptest.NewServer(http.HandlerFunc(slowHandler))
+	defer server.Close()
+
+	body, err := fetch(context.Background(), server.URL)
+	fmt.Printf("Without timeout: %q, err: %v\n", body, err)
+
+	httpCtx, cancelHTTP := context.WithTimeout(context.Background(), 50*time.Millisecond)
+	defer cancelHTTP()
+	_, err = fetch(httpCtx, server.URL)
+	fmt.Printf("With 50ms timeout: deadline exceeded? %v\n", errors.Is(err, context.DeadlineExceeded))
+	time.Sleep(20 * time.Millisecond) // Give the server a moment to log
+}
@@ -0,0 +1,275 @@
+// This file is a capstone for the concurrency basics: a concurrent web crawler
+// It crawls a simulated web (no network access needed) and combines:
+// - A worker pool reading URLs from a channel
+// - A channel used as a semaphore to bound concurrency
+// - Deduplication with the generic Set type from generics.go
+// - Context cancellation and timeouts
+//
+// The crawl follows the "single owner" rule: only the coordinator goroutine
+// touches the Set, so it needs no mutex. Workers just fetch and report back
+
+package main
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"sort"
+	"sync"
+	"sync/atomic"
+	"time"
+)
+
+// Set is a generic set built on a map (the same type as in generics.go)
+type Set[T comparable] map[T]struct{}
+
+// Add inserts values into the set
+func (s Set[T]) Add(values ...T) {
+	for _, v := range values {
+		s[v] = struct{}{}
+	}
+}
+
+// Has reports whether v is in the set
+func (s Set[T]) Has(v T) bool {
+	_, ok := s[v]
+	return ok
+}
+
+// ErrNotFound is returned for URLs missing from the simulated web
+var ErrNotFound = errors.New("404 not found")
+
+// Fetcher returns the links found on a page
+type Fetcher interface {
+	Fetch(ctx context.Context, url string) ([]string, error)
+}
+
+// page is one document in the simulated web
+type page struct {
+	links   []string
+	latency time.Duration
+}
+
+// fakeWeb implements Fetcher from an in-memory map and tracks how many
+// fetches run at the same time
+type fakeWeb struct {
+	pages    map[string]page
+	inFlight atomic.Int32
+	peak     atomic.Int32
+}
+
+func (w *fakeWeb) Fetch(ctx context.Context, url string) ([]string, error) {
+	current := w.inFlight.Add(1)
+	defer w.inFlight.Add(-1)
+	for {
+		peak := w.peak.Load()
+		if current <= peak || w.peak.CompareAndSwap(peak, current) {
+			break
+		}
+	}
+
+	p, ok := w.pages[url]
+	if !ok {
+		p.latency = 5 * time.Millisecond
+	}
+	// Simulated network latency that gives up when ctx is cancelled
+	select {
+	case <-time.After(p.latency):
+	case <-ctx.Done():
+		return nil, ctx.Err()
+	}
+	if !ok {
+		return nil, fmt.Errorf("%s: %w", url, ErrNotFound)
+	}
+	return p.links, nil
+}
+
+// Result describes one crawled URL
+type Result struct {
+	URL   string
+	Depth int
+	Links int
+	Err   error
+}
+
+// task is a URL waiting to be fetched
+type task struct {
+	url   string
+	depth int
+}
+
+// fetched is what a worker reports back to the coordinator
+type fetched struct {
+	task
+	links []string
+	err   error
+}
+
+// CrawlPool crawls from start up to maxDepth links deep using a fixed pool
+// of workers. The coordinator deduplicates URLs and decides when the crawl
+// is finished: when no fetch is pending any more
+func CrawlPool(ctx context.Context, f Fetcher, start string, maxDepth, workers int) []Result {
+	tasks := make(chan task)
+	reports := make(chan fetched)
+
+	var wg sync.WaitGroup
+	for i := 0; i < workers; i++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			for t := range tasks {
+				links, err := f.Fetch(ctx, t.url)
+				reports <- fetched{t, links, err}
+			}
+		}()
+	}
+
+	seen := Set[string]{}
+	seen.Add(start)
+	queue := []task{{start, 0}}
+	pending := 0
+	results := []Result{}
+
+	for len(queue) > 0 || pending > 0 {
+		// Only enable the send case when there is something to send:
+		// a nil channel blocks forever, which switches the case off
+		var sendCh chan task
+		var next task
+		if len(queue) > 0 && ctx.Err() == nil {
+			sendCh, next = tasks, queue[0]
+		} else if pending == 0 {
+			break // Cancelled with nothing in flight
+		}
+
+		select {
+		case sendCh <- next:
+			queue = queue[1:]
+			pending++
+		case r := <-reports:
+			pending--
+			results = append(results, Result{URL: r.url, Depth: r.depth, Links: len(r.links), Err: r.err})
+			if r.err != nil || r.depth == maxDepth {
+				continue
+			}
+			for _, link := range r.links {
+				if !seen.Has(link) {
+					seen.Add(link)
+					queue = append(queue, task{link, r.depth + 1})
+				}
+			}
+		}
+	}
+	close(tasks) // Lets the workers' range loops end
+	wg.Wait()
+	return results
+}
+
+// CrawlSemaphore starts a goroutine per URL but lets at most limit of them
+// fetch at once. A buffered channel is the semaphore: sending acquires a
+// slot, receiving releases it
+func CrawlSemaphore(ctx context.Context, f Fetcher, start string, maxDepth, limit int) []Result {
+	sem := make(chan struct{}, limit)
+	reports := make(chan fetched)
+
+	spawn := func(t task) {
+		go func() {
+			select {
+			case sem <- struct{}{}: // Acquire
+			case <-ctx.Done():
+				reports <- fetched{t, nil, ctx.Err()}
+				return
+			}
+			links, err := f.Fetch(ctx, t.url)
+			<-sem // Release before reporting, so the slot is free sooner
+			reports <- fetched{t, links, err}
+		}()
+	}
+
+	seen := Set[string]{}
+	seen.Add(start)
+	spawn(task{start, 0})
+	pending := 1
+	results := []Result{}
+
+	for pending > 0 {
+		r := <-reports
+		pending--
+		results = append(results, Result{URL: r.url, Depth: r.depth, Links: len(r.links), Err: r.err})
+		if r.err != nil || r.depth == maxDepth {
+			continue
+		}
+		for _, link := range r.links {
+			if !seen.Has(link) {
+				seen.Add(link)
+				spawn(task{link, r.depth + 1})
+				pending++
+			}
+		}
+	}
+	return results
+}
+
+// summarize prints results sorted by depth and URL
+func summarize(results []Result) {
+	sort.Slice(results, func(i, j int) bool {
+		if results[i].Depth != results[j].Depth {
+			return results[i].Depth < results[j].Depth
+		}
+		return results[i].URL < results[j].URL
+	})
+	for _, r := range results {
+		status := fmt.Sprintf("%d links", r.Links)
+		if r.Err != nil {
+			status = "error: " + r.Err.Error()
+		}
+		fmt.Printf("  depth %d  %-32s %s\n", r.Depth, r.URL, status)
+	}
+}
+
+func newFakeWeb() *fakeWeb {
+	ms := time.Millisecond
+	return &fakeWeb{pages: map[string]page{
+		"https://go.dev/":                 {[]string{"https://go.dev/doc/", "https://go.dev/blog/", "https://pkg.go.dev/"}, 20 * ms},
+		"https://go.dev/doc/":             {[]string{"https://go.dev/", "https://go.dev/doc/tutorial/", "https://go.dev/doc/effective_go"}, 30 * ms},
+		"https://go.dev/blog/":            {[]string{"https://go.dev/", "https://go.dev/blog/go1.22", "https://go.dev/blog/missing"}, 25 * ms},
+		"https://pkg.go.dev/":             {[]string{"https://pkg.go.dev/fmt", "https://pkg.go.dev/net/http", "https://go.dev/"}, 40 * ms},
+		"https://go.dev/doc/tutorial/":    {[]string{"https://go.dev/doc/"}, 15 * ms},
+		"https://go.dev/doc/effective_go": {[]string{"https://go.dev/doc/"}, 35 * ms},
+		"https://go.dev/blog/go1.22":      {[]string{"https://go.dev/blog/"}, 20 * ms},
+		"https://pkg.go.dev/fmt":          {[]string{"https://pkg.go.dev/"}, 10 * ms},
+		"https://pkg.go.dev/net/http":     {[]string{"https://pkg.go.dev/", "https://pkg.go.dev/context"}, 10 * ms},
+		"https://pkg.go.dev/context":      {[]string{}, 10 * ms},
+	}}
+}
+
+func main() {
+	// ==================== Worker Pool ====================
+	fmt.Println("=== Worker Pool Crawl (3 workers, depth 2) ===")
+	web := newFakeWeb()
+	start := time.Now()
+	results := CrawlPool(context.Background(), web, "https://go.dev/", 2, 3)
+	summarize(results)
+	fmt.Printf("Crawled %d URLs in ~%v, peak concurrent fetches: %d\n",
+		len(results), time.Since(start).Round(10*time.Millisecond), web.peak.Load())
+
+	// ==================== Semaphore ====================
+	fmt.Println("\n=== Semaphore-Bounded Crawl (limit 2, depth 3) ===")
+	web = newFakeWeb()
+	results = CrawlSemaphore(context.Background(), web, "https://go.dev/", 3, 2)
+	summarize(results)
+	fmt.Printf("Crawled %d URLs, peak concurrent fetches: %d (limit 2)\n", len(results), web.peak.Load())
+
+	// ==================== Cancellation ====================
+	fmt.Println("\n=== Crawl with a 50ms Timeout ===")
+	ctx, cancel := context.WithTimeout(context.Background(), 50*time.Millisecond)
+	defer cancel()
+	results = CrawlPool(ctx, newFakeWeb(), "https://go.dev/", 3, 2)
+	cancelled := 0
+	for _, r := range results {
+		if errors.Is(r.Err, context.DeadlineExceeded) {
+			cancelled++
+		}
+	}
+	fmt.Printf("Finished %d fetches before the deadline, %d were cut short\n",
+		len(results)-cancelled, cancelled)
+}
@@ -0,0 +1,292 @@
+// This file demonstrates defer, panic and recover in Go
+// These were introduced at the end of error-handling.go; this file goes deeper
+//
+// Topics:
+// - Defer order and when deferred arguments are evaluated
+// - Deferred closures capturing variables and changing named results
+// - Defers inside loops and what they cost
+// - panic and recover, and re-panicking
+// - recover in middleware (HTTP handlers, worker functions)
+// - Panics in other goroutines, and why recover in main can't catch them
+//
+// Rule of thumb: return errors for expected failures. Panic only for
+// programmer mistakes and truly unrecoverable states
+
+package main
+
+import (
+	"errors"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"runtime"
+	"sync"
+	"testing"
+)
+
+// deferOrder shows that deferred calls run last-in, first-out
+func deferOrder() {
+	for i := 1; i <= 3; i++ {
+		defer fmt.Printf("deferred %d\n", i)
+	}
+	fmt.Println("function body done")
+}
+
+// deferArguments shows that arguments are evaluated when defer runs,
+// but a closure reads variables when it is finally called
+func deferArguments() {
+	x := 1
+	defer fmt.Println("defer with argument sees x =", x) // x evaluated now: 1
+	defer func() {
+		fmt.Println("deferred closure sees x =", x) // x read later: 3
+	}()
+	x = 3
+}
+
+// annotate uses a named result so the deferred closure can change the
+// returned error after the return statement has set it
+func annotate(name string) (err error) {
+	defer func() {
+		if err != nil {
+			err = fmt.Errorf("processing %s: %w", name, err)
+		}
+	}()
+	if name == "" {
+		return errors.New("empty name")
+	}
+	return nil
+}
+
+// processFilesLeaky defers Close inside a loop: no file is closed until the
+// whole function returns, so a long loop can run out of file descriptors
+func processFilesLeaky(paths []string) (openAtOnce int) {
+	for _, path := range paths {
+		f, err := os.Open(path)
+		if err != nil {
+			continue
+		}
+		defer f.Close()
+		openAtOnce++
+	}
+	return openAtOnce
+}
+
+// processFiles moves the loop body into a function so each defer runs at the
+// end of its iteration
+func processFiles(paths []string) (maxOpen int) {
+	open := 0
+	for _, path := range paths {
+		func() {
+			f, err := os.Open(path)
+			if err != nil {
+				return
+			}
+			defer func() {
+				f.Close()
+				open--
+			}()
+			open++
+			maxOpen = max(maxOpen, open)
+		}()
+	}
+	return maxOpen
+}
+
+// safeDivide turns a runtime panic into an error
+// recover only works when called directly by a deferred function
+func safeDivide(a, b int) (result int, err error) {
+	defer func() {
+		if r := recover(); r != nil {
+			err = fmt.Errorf("recovered: %v", r)
+		}
+	}()
+	return a / b, nil // Panics with "integer divide by zero" when b == 0
+}
+
+// classify recovers only the panics it understands and re-panics the rest,
+// so unexpected bugs are not silently swallowed
+func classify(fn func()) (kind string) {
+	defer func() {
+		r := recover()
+		if r == nil {
+			return
+		}
+		// Runtime errors (nil map writes, out-of-range indexes...) implement runtime.Error
+		if rtErr, ok := r.(runtime.Error); ok {
+			kind = "runtime error: " + rtErr.Error()
+			return
+		}
+		if err, ok := r.(error); ok {
+			kind = "error value: " + err.Error()
+			return
+		}
+		panic(r) // Not ours to handle
+	}()
+	fn()
+	return "no panic"
+}
+
+// recoverMiddleware keeps one panicking handler from crashing the server and
+// answers 500 instead. net/http already recovers per connection, but only to
+// log and drop the connection; the client gets no response
+func recoverMiddleware(next http.Handler) http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		defer func() {
+			if rec := recover(); rec != nil {
+				// http.ErrAbortHandler is the sanctioned way to abort; let it through
+				if rec == http.ErrAbortHandler {
+					panic(rec)
+				}
+				fmt.Printf("middleware: recovered %q on %s\n", fmt.Sprint(rec), r.URL.Path)
+				http.Error(w, "internal server error", http.StatusInternalServerError)
+			}
+		}()
+		next.ServeHTTP(w, r)
+	})
+}
+
+// safeGo runs fn in a new goroutine and reports a panic as an error
+// A panic unwinds only its own goroutine's stack, so the recover has to be
+// inside that goroutine. An unrecovered panic in any goroutine crashes the
+// whole program, and a recover in main cannot stop it
+func safeGo(wg *sync.WaitGroup, errs chan<- error, fn func()) {
+	wg.Add(1)
+	go func() {
+		defer wg.Done()
+		defer func() {
+			if r := recover(); r != nil {
+				errs <- fmt.Errorf("goroutine panicked: %v", r)
+			}
+		}()
+		fn()
+	}()
+}
+
+func main() {
+	// ==================== Defer Order ====================
+	fmt.Println("=== Defer Order (LIFO) ===")
+	deferOrder()
+
+	fmt.Println("\n=== Deferred Arguments vs Closures ===")
+	deferArguments()
+
+	fmt.Println("\n=== Deferred Closures and Named Results ===")
+	fmt.Printf("annotate(\"\"): %v\n", annotate(""))
+	fmt.Printf("annotate(\"report\"): %v\n", annotate("report"))
+
+	// ==================== Defers in Loops ====================
+	fmt.Println("\n=== Defers in Loops ===")
+	dir, _ := os.MkdirTemp("", "defer-example")
+	defer os.RemoveAll(dir) // Runs when main returns normally
+	paths := []string{}
+	for i := 0; i < 20; i++ {
+		path := filepath.Join(dir, fmt.Sprintf("file%d.txt", i))
+		os.WriteFile(path, []byte("data"), 0o644)
+		paths = append(paths, path)
+	}
+	fmt.Printf("Defer in loop: %d files open at once\n", processFilesLeaky(paths))
+	fmt.Printf("Defer in a function per iteration: %d file open at once\n", processFiles(paths))
+
+	// Since Go 1.14 a defer that runs at most once per call is "open-coded"
+	// and costs about as much as a normal call. Defers in loops can't be
+	// open-coded and need a runtime record for every iteration
+	var mu sync.Mutex
+	direct := testing.Benchmark(func(b *testing.B) {
+		for i := 0; i < b.N; i++ {
+			mu.Lock()
+			mu.Unlock()
+		}
+	})
+	deferred := testing.Benchmark(func(b *testing.B) {
+		for i := 0; i < b.N; i++ {
+			func() {
+				mu.Lock()
+				defer mu.Unlock()
+			}()
+		}
+	})
+	inLoop := testing.Benchmark(func(b *testing.B) {
+		for i := 0; i < b.N; i++ {
+			func() {
+				for j := 0; j < 1; j++ {
+					mu.Lock()
+					defer mu.Unlock()
+				}
+			}()
+		}
+	})
+	fmt.Printf("Unlock directly:      %6.1f ns/op\n", float64(direct.T.Nanoseconds())/float64(direct.N))
+	fmt.Printf("defer (open-coded):   %6.1f ns/op\n", float64(deferred.T.Nanoseconds())/float64(deferred.N))
+	fmt.Printf("defer inside a loop:  %6.1f ns/op\n", float64(inLoop.T.Nanoseconds())/float64(inLoop.N))
+
+	// ==================== Panic and Recover ====================
+	fmt.Println("\n=== Panic and Recover ===")
+	result, err := safeDivide(10, 2)
+	fmt.Printf("safeDivide(10, 2) = %d, err: %v\n", result, err)
+	result, err = safeDivide(10, 0)
+	fmt.Printf("safeDivide(10, 0) = %d, err: %v\n", result, err)
+
+	fmt.Println(classify(func() {}))
+	fmt.Println(classify(func() {
+		var m map[string]int
+		m["x"] = 1
+	}))
+	fmt.Println(classify(func() { panic(errors.New("custom failure")) }))
+	// classify re-panics values it doesn't handle; an outer recover sees them
+	func() {
+		defer func() {
+			fmt.Printf("outer recover caught re-panic: %v\n", recover())
+		}()
+		classify(func() { panic("plain string") })
+	}()
+	// recover returns nil when called outside a deferred function
+	fmt.Printf("recover() outside defer: %v\n", recover())
+
+	// ==================== Recover in Middleware ====================
+	fmt.Println("\n=== Recover in HTTP Middleware ===")
+	mux := http.NewServeMux()
+	mux.HandleFunc("/ok", func(w http.ResponseWriter, r *http.Request) {
+		fmt.Fprint(w, "fine")
+	})
+	mux.HandleFunc("/boom", func(w http.ResponseWriter, r *http.Request) {
+		var items []string
+		fmt.Fprint(w, items[3]) // Index out of range
+	})
+	handler := recoverMiddleware(mux)
+	for _, path := range []string{"/ok", "/boom", "/ok"} {
+		rec := httptest.NewRecorder()
+		handler.ServeHTTP(rec, httptest.NewRequest(http.MethodGet, path, nil))
+		fmt.Printf("GET %-5s -> %d\n", path, rec.Code)
+	}
+
+	// ==================== Panics in Goroutines ====================
+	fmt.Println("\n=== Panics in Goroutines ===")
+	// This would crash the program even though main has a recover:
+	//   defer func() { recover() }()
+	//   go func() { panic("boom") }()
+	// Each goroutine must recover its own panics, as safeGo does
+	var wg sync.WaitGroup
+	errs := make(chan error, 3)
+	for i := 1; i <= 3; i++ {
+		safeGo(&wg, errs, func() {
+			if i == 2 {
+				panic(fmt.Sprintf("worker %d failed", i))
+			}
+		})
+	}
+	wg.Wait()
+	close(errs)
+	for err := range errs {
+		fmt.Println("Error:", err)
+	}
+
+	// ==================== os.Exit Skips Defers ====================
+	fmt.Println("\n=== os.Exit and Defers ===")
+	// os.Exit ends the program immediately: deferred calls (like the
+	// os.RemoveAll above) do not run. log.Fatal calls os.Exit too.
+	// An unrecovered panic does run the deferred calls of its goroutine
+	// before the program crashes
+	fmt.Println("main returns normally, so the temp directory is removed")
+}
@@ -0,0 +1,11 @@
+//go:build !debug
+
+package main
+
+// debugEnabled is false in normal builds
+// Because it is a constant, "if debugEnabled { ... }" blocks are removed
+// by the compiler entirely
+const debugEnabled = false
+
+// debugf does nothing in normal builds
+func debugf(format string, args ...any) {}
@@ -0,0 +1,13 @@
+//go:build debug
+
+package main
+
+import "log"
+
+// debugEnabled is true when building with go build -tags debug
+const debugEnabled = true
+
+// debugf logs a message in debug builds
+func debugf(format string, args ...any) {
+	log.Printf("DEBUG "+format, args...)
+}
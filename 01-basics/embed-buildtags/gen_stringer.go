@@ -0,0 +1,54 @@
+//go:build ignore
+
+// gen_stringer is a tiny code generator run by go generate
+// The ignore build tag keeps it out of the normal build; go run still
+// accepts it because the file is named explicitly
+//
+// It writes a String method for a list of constant names, like a very small
+// version of golang.org/x/tools/cmd/stringer (which reads the constants from
+// the source instead of taking them as a flag)
+package main
+
+import (
+	"bytes"
+	"flag"
+	"fmt"
+	"go/format"
+	"log"
+	"os"
+	"strings"
+)
+
+func main() {
+	typeName := flag.String("type", "", "type name")
+	values := flag.String("values", "", "comma-separated constant names in iota order")
+	output := flag.String("output", "", "output file")
+	flag.Parse()
+	if *typeName == "" || *values == "" || *output == "" {
+		log.Fatal("usage: go run gen_stringer.go -type=T -values=A,B,C -output=file.go")
+	}
+	// go generate sets $GOPACKAGE to the package being generated for; run
+	// by hand, without it, the output would not compile
+	pkg := os.Getenv("GOPACKAGE")
+	if pkg == "" {
+		log.Fatal("$GOPACKAGE is not set: run this through go generate, or set GOPACKAGE to the package name")
+	}
+
+	var buf bytes.Buffer
+	fmt.Fprintf(&buf, "// Code generated by \"go run gen_stringer.go %s\"; DO NOT EDIT.\n\n", strings.Join(os.Args[1:], " "))
+	fmt.Fprintf(&buf, "package %s\n\nimport \"strconv\"\n\n", pkg)
+	fmt.Fprintf(&buf, "func (v %s) String() string {\n\tswitch v {\n", *typeName)
+	for _, name := range strings.Split(*values, ",") {
+		fmt.Fprintf(&buf, "\tcase %s:\n\t\treturn %q\n", name, name)
+	}
+	fmt.Fprintf(&buf, "\t}\n\treturn \"%s(\" + strconv.Itoa(int(v)) + \")\"\n}\n", *typeName)
+
+	// Always gofmt generated code
+	src, err := format.Source(buf.Bytes())
+	if err != nil {
+		log.Fatal(err)
+	}
+	if err := os.WriteFile(*output, src, 0o644); err != nil {
+		log.Fatal(err)
+	}
+}
@@ -0,0 +1,3 @@
+module example.com/embedbuildtags
+
+go 1.22
@@ -0,0 +1,118 @@
+// This program demonstrates three build-time features of the go tool
+//
+//  1. //go:embed puts files into the compiled binary, so a single executable
+//     ships with its templates, config and static assets
+//  2. Build tags (//go:build lines) choose which files are compiled, for
+//     platform-specific code or optional features
+//  3. //go:generate records commands that generate Go code; go generate runs
+//     them, and the output is committed like any other source file
+//
+// Build tags select files per package, so this example is a small module of
+// its own. Run it from this directory:
+//
+//	go run .               # platform_unix.go or platform_windows.go, debug_off.go
+//	go run -tags debug .   # debug_on.go replaces debug_off.go
+//	go generate            # regenerates color_string.go
+//	GOOS=windows go build  # cross-compiles with platform_windows.go
+//
+// The design patterns use the same go:generate setup with the real stringer
+// tool for the LogLevel enum (see 04-design-patterns/behavioral)
+package main
+
+import (
+	"embed"
+	"encoding/json"
+	"fmt"
+	"io/fs"
+	"os"
+	"runtime"
+	"text/template"
+)
+
+// A string or []byte variable receives the contents of a single file
+// The comment must sit directly above the variable, with no blank line
+//
+//go:embed assets/banner.txt
+var banner string
+
+// An embed.FS holds a read-only file tree; patterns may use globs and
+// several patterns or directories may be listed
+//
+//go:embed assets/config.json assets/templates
+var assets embed.FS
+
+// Config mirrors assets/config.json
+type Config struct {
+	AppName    string   `json:"appName"`
+	MaxWorkers int      `json:"maxWorkers"`
+	Features   []string `json:"features"`
+}
+
+//go:generate go run gen_stringer.go -type=Color -values=Red,Green,Blue -output=color_string.go
+
+// Color is an enum whose String method is generated by gen_stringer.go
+type Color int
+
+const (
+	Red Color = iota
+	Green
+	Blue
+)
+
+func main() {
+	// ==================== go:embed ====================
+	fmt.Println("=== go:embed ===")
+	fmt.Print(banner)
+
+	data, err := assets.ReadFile("assets/config.json")
+	if err != nil {
+		fmt.Println("Error:", err)
+		return
+	}
+	var cfg Config
+	if err := json.Unmarshal(data, &cfg); err != nil {
+		fmt.Println("Error:", err)
+		return
+	}
+	fmt.Printf("Embedded config: %+v\n", cfg)
+
+	// embed.FS implements fs.FS, so the io/fs helpers work on it
+	files := []string{}
+	fs.WalkDir(assets, ".", func(path string, d fs.DirEntry, err error) error {
+		if err == nil && !d.IsDir() {
+			files = append(files, path)
+		}
+		return err
+	})
+	fmt.Printf("Files in the embed.FS: %q\n", files)
+
+	// Templates can be parsed straight from the embedded tree
+	tmpl, err := template.ParseFS(assets, "assets/templates/*.tmpl")
+	if err != nil {
+		fmt.Println("Error:", err)
+		return
+	}
+	view := map[string]any{"Name": cfg.AppName, "Platform": platformName(), "Debug": debugEnabled, "Files": files}
+	for _, name := range []string{"greeting.tmpl", "footer.tmpl"} {
+		if err := tmpl.ExecuteTemplate(os.Stdout, name, view); err != nil {
+			fmt.Println("Error:", err)
+		}
+	}
+
+	// ==================== Build Tags ====================
+	fmt.Println("\n=== Build Tags ===")
+	// platformName and configDir have one implementation per platform file
+	fmt.Printf("GOOS=%s GOARCH=%s\n", runtime.GOOS, runtime.GOARCH)
+	fmt.Printf("platformName(): %s\n", platformName())
+	fmt.Printf("configDir(): %s\n", configDir())
+	// debug_on.go or debug_off.go, depending on -tags debug
+	fmt.Printf("debugEnabled: %v\n", debugEnabled)
+	debugf("this line only prints with -tags debug")
+
+	// ==================== go:generate ====================
+	fmt.Println("\n=== go:generate ===")
+	for _, c := range []Color{Red, Green, Blue, Color(7)} {
+		fmt.Printf("%d -> %v\n", int(c), c)
+	}
+	fmt.Println("Regenerate with: go generate (see the //go:generate line in main.go)")
+}
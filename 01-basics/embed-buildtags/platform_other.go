@@ -0,0 +1,13 @@
+//go:build !unix && !windows
+
+package main
+
+// platformName is the fallback for other targets such as js/wasm or plan9
+// Build tags combine with && || ! and parentheses
+func platformName() string {
+	return "another platform"
+}
+
+func configDir() string {
+	return "."
+}
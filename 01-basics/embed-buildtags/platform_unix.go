@@ -0,0 +1,22 @@
+//go:build unix
+
+package main
+
+import (
+	"os"
+	"path/filepath"
+)
+
+// platformName describes the Unix-like system the program was built for
+// unix matches linux, darwin, the BSDs and other Unix-like GOOS values
+func platformName() string {
+	return "a Unix-like system"
+}
+
+// configDir follows the XDG convention used on Linux and BSD
+func configDir() string {
+	if dir := os.Getenv("XDG_CONFIG_HOME"); dir != "" {
+		return filepath.Join(dir, "go-basic")
+	}
+	return filepath.Join("~", ".config", "go-basic")
+}
@@ -0,0 +1,20 @@
+package main
+
+import (
+	"os"
+	"path/filepath"
+)
+
+// The _windows.go file name suffix is an implicit build constraint, so no
+// //go:build line is needed. Suffixes exist for every GOOS and GOARCH,
+// e.g. _linux.go, _darwin_arm64.go
+
+// platformName describes the Windows system the program was built for
+func platformName() string {
+	return "Windows"
+}
+
+// configDir uses the per-user application data folder
+func configDir() string {
+	return filepath.Join(os.Getenv("AppData"), "go-basic")
+}
@@ -0,0 +1,252 @@
+// This file demonstrates error handling patterns in Go
+// Go handles errors explicitly through return values rather than exceptions
+// The error interface is a built-in type that represents error conditions
+//
+// Modern error handling (Go 1.13+) adds wrapping:
+// - fmt.Errorf with %w wraps an error while adding context
+// - errors.Is checks for a specific error anywhere in the chain
+// - errors.As finds an error of a specific type in the chain
+// - errors.Join (Go 1.20+) combines several errors into one
+//
+// For panic, defer and recover see defer-panic.go
+
+package main
+
+import (
+	"errors"
+	"fmt"
+	"strconv"
+	"strings"
+)
+
+// Sentinel errors
+// Package-level error values that callers compare against with errors.Is
+// By convention their names start with Err
+var (
+	ErrDivideByZero = errors.New("division by zero")
+	ErrNotFound     = errors.New("not found")
+)
+
+// Custom error type
+// Implementing the error interface by defining Error() string method
+type DivisionError struct {
+	dividend int
+	divisor  int
+	message  string
+	err      error // The underlying cause, exposed through Unwrap
+}
+
+// Error method implementation
+// This makes DivisionError implement the error interface
+func (e *DivisionError) Error() string {
+	return fmt.Sprintf("%s: %d / %d", e.message, e.dividend, e.divisor)
+}
+
+// Unwrap returns the underlying cause
+// This lets errors.Is(err, ErrDivideByZero) see through the DivisionError
+func (e *DivisionError) Unwrap() error {
+	return e.err
+}
+
+// Function that returns an error
+// In Go, it's common to return (result, error)
+// If error is nil, the operation was successful
+func divide(dividend, divisor int) (int, error) {
+	if divisor == 0 {
+		// Return custom error for division by zero
+		return 0, &DivisionError{
+			dividend: dividend,
+			divisor:  divisor,
+			message:  "cannot divide by zero",
+			err:      ErrDivideByZero,
+		}
+	}
+	return dividend / divisor, nil
+}
+
+// Function demonstrating multiple error cases
+// Shows how to handle negative numbers as an error condition
+func calculateSquareRoot(x float64) (float64, error) {
+	if x < 0 {
+		// errors.New creates a simple error with a message
+		return 0, errors.New("cannot calculate square root of negative number")
+	}
+	return x * x, nil
+}
+
+// Wrapping errors with context
+// %w keeps the original error in the chain; %v would only keep its text
+func average(numbers []int) (int, error) {
+	sum := 0
+	for _, n := range numbers {
+		sum += n
+	}
+	result, err := divide(sum, len(numbers))
+	if err != nil {
+		return 0, fmt.Errorf("average of %d numbers: %w", len(numbers), err)
+	}
+	return result, nil
+}
+
+// Custom error chains
+// Each layer adds context, and callers can still inspect the root cause
+type ParseError struct {
+	Line  int
+	Value string
+	Err   error
+}
+
+func (e *ParseError) Error() string {
+	return fmt.Sprintf("line %d: cannot parse %q: %v", e.Line, e.Value, e.Err)
+}
+
+func (e *ParseError) Unwrap() error {
+	return e.Err
+}
+
+// parseConfig parses "key=number" lines
+func parseConfig(text string) (map[string]int, error) {
+	config := make(map[string]int)
+	for i, line := range strings.Split(text, "\n") {
+		key, value, _ := strings.Cut(line, "=")
+		n, err := strconv.Atoi(value)
+		if err != nil {
+			// strconv returns a *strconv.NumError, which wraps strconv.ErrSyntax
+			return nil, &ParseError{Line: i + 1, Value: value, Err: err}
+		}
+		config[key] = n
+	}
+	return config, nil
+}
+
+// loadSetting adds the outermost layer of context
+func loadSetting(text, key string) (int, error) {
+	config, err := parseConfig(text)
+	if err != nil {
+		return 0, fmt.Errorf("load setting %q: %w", key, err)
+	}
+	value, ok := config[key]
+	if !ok {
+		return 0, fmt.Errorf("load setting %q: %w", key, ErrNotFound)
+	}
+	return value, nil
+}
+
+// Combining errors with errors.Join
+// Validation should report every problem at once, not just the first one
+type User struct {
+	Name  string
+	Email string
+	Age   int
+}
+
+func validateUser(u User) error {
+	var errs []error
+	if u.Name == "" {
+		errs = append(errs, errors.New("name is required"))
+	}
+	if !strings.Contains(u.Email, "@") {
+		errs = append(errs, fmt.Errorf("invalid email %q", u.Email))
+	}
+	if u.Age < 0 {
+		errs = append(errs, fmt.Errorf("invalid age %d", u.Age))
+	}
+	// errors.Join returns nil when every error is nil
+	return errors.Join(errs...)
+}
+
+func main() {
+	// ==================== Basic Error Handling ====================
+	// Basic pattern: check error return value
+	result, err := divide(10, 0)
+	if err != nil {
+		// Error handling: print error message
+		fmt.Printf("Error: %v\n", err)
+	} else {
+		fmt.Printf("Result: %d\n", result)
+	}
+
+	// ==================== Multiple Error Cases ====================
+	// Testing different scenarios that might cause errors
+	numbers := []int{10, 0, 5, 2}
+	for i := 0; i < len(numbers)-1; i++ {
+		result, err := divide(numbers[i], numbers[i+1])
+		if err != nil {
+			// Type assertion to check if it's our custom error
+			if divErr, ok := err.(*DivisionError); ok {
+				fmt.Printf("Custom division error: %v\n", divErr)
+			} else {
+				fmt.Printf("Other error: %v\n", err)
+			}
+			continue // Skip to next iteration on error
+		}
+		fmt.Printf("%d / %d = %d\n", numbers[i], numbers[i+1], result)
+	}
+
+	// ==================== Using errors.New ====================
+	// Testing with both valid and invalid inputs
+	root, err := calculateSquareRoot(-4)
+	if err != nil {
+		fmt.Printf("Square root error: %v\n", err)
+	} else {
+		fmt.Printf("Square: %f\n", root)
+	}
+
+	// ==================== Sentinel Errors and errors.Is ====================
+	fmt.Println("\nSentinel Errors:")
+	_, err = divide(1, 0)
+	// errors.Is follows Unwrap, so it finds ErrDivideByZero inside DivisionError
+	fmt.Printf("errors.Is(err, ErrDivideByZero): %v\n", errors.Is(err, ErrDivideByZero))
+	// Comparing with == only checks the outermost error
+	fmt.Printf("err == ErrDivideByZero: %v\n", err == ErrDivideByZero)
+
+	// ==================== Wrapping with %w ====================
+	fmt.Println("\nWrapping Errors:")
+	_, err = average([]int{})
+	fmt.Printf("Error: %v\n", err)
+	fmt.Printf("Still a division by zero? %v\n", errors.Is(err, ErrDivideByZero))
+
+	// errors.As is the wrapping-aware version of the type assertion above
+	var divErr *DivisionError
+	if errors.As(err, &divErr) {
+		fmt.Printf("Found DivisionError in the chain: dividend=%d divisor=%d\n", divErr.dividend, divErr.divisor)
+	}
+	_, ok := err.(*DivisionError)
+	fmt.Printf("Plain type assertion finds it? %v\n", ok)
+
+	// errors.Unwrap peels off one layer at a time
+	fmt.Println("The error chain:")
+	for e := err; e != nil; e = errors.Unwrap(e) {
+		fmt.Printf("  %T: %v\n", e, e)
+	}
+
+	// ==================== Custom Error Chains ====================
+	fmt.Println("\nCustom Error Chains:")
+	_, err = loadSetting("port=8080\ntimeout=ten", "timeout")
+	fmt.Printf("Error: %v\n", err)
+	var parseErr *ParseError
+	if errors.As(err, &parseErr) {
+		fmt.Printf("Bad value on line %d\n", parseErr.Line)
+	}
+	var numErr *strconv.NumError
+	if errors.As(err, &numErr) {
+		fmt.Printf("strconv failed in %s\n", numErr.Func)
+	}
+	fmt.Printf("Is it a syntax error? %v\n", errors.Is(err, strconv.ErrSyntax))
+
+	_, err = loadSetting("port=8080", "timeout")
+	fmt.Printf("Missing key: %v (ErrNotFound? %v)\n", err, errors.Is(err, ErrNotFound))
+
+	// ==================== errors.Join ====================
+	fmt.Println("\nJoining Errors:")
+	err = validateUser(User{Name: "", Email: "bob.example.com", Age: -1})
+	fmt.Printf("Validation failed:\n%v\n", err)
+	fmt.Printf("Valid user error: %v\n", validateUser(User{Name: "Ann", Email: "ann@example.com", Age: 30}))
+
+	// fmt.Errorf accepts several %w verbs too; errors.Is matches any of them
+	err = fmt.Errorf("request failed: %w, cleanup failed: %w", ErrNotFound, ErrDivideByZero)
+	fmt.Printf("Is ErrNotFound: %v, is ErrDivideByZero: %v\n",
+		errors.Is(err, ErrNotFound), errors.Is(err, ErrDivideByZero))
+
+	// Panics, defer and recover are covered in defer-panic.go
+}
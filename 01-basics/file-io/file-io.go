@@ -0,0 +1,240 @@
+// This file demonstrates file I/O in Go
+// The os package opens and creates files, bufio adds buffering and line
+// scanning, and encoding/csv reads and writes comma-separated values
+//
+// Everything happens inside a temporary directory that is removed at the end,
+// so running the example leaves no files behind
+
+package main
+
+import (
+	"bufio"
+	"encoding/csv"
+	"errors"
+	"fmt"
+	"io"
+	"io/fs"
+	"os"
+	"path/filepath"
+	"sort"
+	"strconv"
+	"strings"
+)
+
+// LogSummary is the result of the log parsing exercise
+type LogSummary struct {
+	Lines     int
+	ByLevel   map[string]int
+	Errors    []string
+	Malformed int
+}
+
+// parseLog reads lines like "2024-05-01T10:00:00Z ERROR payment failed"
+// bufio.Scanner reads one line at a time, so files of any size use little memory
+func parseLog(r io.Reader) (LogSummary, error) {
+	summary := LogSummary{ByLevel: make(map[string]int)}
+	scanner := bufio.NewScanner(r)
+	for scanner.Scan() {
+		line := strings.TrimSpace(scanner.Text())
+		if line == "" {
+			continue
+		}
+		summary.Lines++
+		fields := strings.SplitN(line, " ", 3)
+		if len(fields) < 3 {
+			summary.Malformed++
+			continue
+		}
+		level, message := fields[1], fields[2]
+		summary.ByLevel[level]++
+		if level == "ERROR" {
+			summary.Errors = append(summary.Errors, message)
+		}
+	}
+	// Scan returns false on EOF and on errors; Err tells them apart
+	return summary, scanner.Err()
+}
+
+// countLines counts the lines of a file
+// defer f.Close() runs when the function returns, even on early returns
+func countLines(path string) (int, error) {
+	f, err := os.Open(path)
+	if err != nil {
+		return 0, err
+	}
+	defer f.Close()
+
+	count := 0
+	scanner := bufio.NewScanner(f)
+	for scanner.Scan() {
+		count++
+	}
+	return count, scanner.Err()
+}
+
+func main() {
+	dir, err := os.MkdirTemp("", "file-io-example")
+	if err != nil {
+		fmt.Println("Error:", err)
+		return
+	}
+	defer os.RemoveAll(dir)
+
+	// ==================== Writing and Reading Whole Files ====================
+	fmt.Println("=== Whole Files ===")
+	notes := filepath.Join(dir, "notes.txt")
+	// WriteFile creates or truncates the file; 0o644 is rw-r--r--
+	err = os.WriteFile(notes, []byte("first line\nsecond line\n"), 0o644)
+	if err != nil {
+		fmt.Println("Error:", err)
+		return
+	}
+	content, _ := os.ReadFile(notes)
+	fmt.Printf("ReadFile: %q\n", content)
+
+	// Appending needs OpenFile with explicit flags
+	f, err := os.OpenFile(notes, os.O_APPEND|os.O_WRONLY, 0o644)
+	if err != nil {
+		fmt.Println("Error:", err)
+		return
+	}
+	fmt.Fprintln(f, "third line") // *os.File is an io.Writer
+	f.Close()
+	lines, _ := countLines(notes)
+	fmt.Printf("Lines after append: %d\n", lines)
+
+	// Missing files: check with errors.Is, not by comparing messages
+	_, err = os.ReadFile(filepath.Join(dir, "missing.txt"))
+	fmt.Printf("Missing file: not exist? %v\n", errors.Is(err, fs.ErrNotExist))
+
+	// ==================== Buffered Writing ====================
+	fmt.Println("\n=== Buffered I/O ===")
+	numbersPath := filepath.Join(dir, "numbers.txt")
+	out, _ := os.Create(numbersPath)
+	// bufio.Writer collects small writes and sends them to the file in large chunks
+	w := bufio.NewWriter(out)
+	for i := 1; i <= 1000; i++ {
+		fmt.Fprintf(w, "%d\n", i)
+	}
+	// Flush before closing, or the last buffered bytes are lost
+	if err := w.Flush(); err != nil {
+		fmt.Println("Error:", err)
+	}
+	out.Close()
+
+	in, _ := os.Open(numbersPath)
+	scanner := bufio.NewScanner(in)
+	sum := 0
+	for scanner.Scan() {
+		n, _ := strconv.Atoi(scanner.Text())
+		sum += n
+	}
+	in.Close()
+	fmt.Printf("Sum of 1..1000 read line by line: %d\n", sum)
+
+	// Scanners can split on words instead of lines
+	words := bufio.NewScanner(strings.NewReader("split  these\nwords\tplease"))
+	words.Split(bufio.ScanWords)
+	count := 0
+	for words.Scan() {
+		count++
+	}
+	fmt.Printf("Words: %d\n", count)
+
+	// ==================== Directories ====================
+	fmt.Println("\n=== Directories ===")
+	os.MkdirAll(filepath.Join(dir, "reports", "2024"), 0o755)
+	os.WriteFile(filepath.Join(dir, "reports", "summary.md"), []byte("# Summary\n"), 0o644)
+	// ReadDir returns entries sorted by name
+	entries, _ := os.ReadDir(dir)
+	for _, entry := range entries {
+		info, _ := entry.Info()
+		kind := "file"
+		if entry.IsDir() {
+			kind = "dir "
+		}
+		fmt.Printf("%s %-12s %5d bytes\n", kind, entry.Name(), info.Size())
+	}
+
+	// ==================== Temporary Files ====================
+	fmt.Println("\n=== Temporary Files ===")
+	// The * in the pattern is replaced with a random string
+	tmp, err := os.CreateTemp(dir, "upload-*.tmp")
+	if err != nil {
+		fmt.Println("Error:", err)
+		return
+	}
+	tmp.WriteString("partial upload")
+	tmp.Close()
+	// Write to a temp file, then rename: readers never see a half-written file
+	final := filepath.Join(dir, "upload.dat")
+	os.Rename(tmp.Name(), final)
+	fmt.Printf("Temp file %s renamed to %s\n", filepath.Base(tmp.Name()), filepath.Base(final))
+
+	// ==================== CSV ====================
+	fmt.Println("\n=== CSV ===")
+	csvPath := filepath.Join(dir, "scores.csv")
+	csvFile, _ := os.Create(csvPath)
+	cw := csv.NewWriter(csvFile)
+	records := [][]string{
+		{"name", "score", "comment"},
+		{"Alice", "92", "great work"},
+		{"Bob", "78", `said "hello, world"`}, // quotes and commas are escaped automatically
+		{"Carol", "85", ""},
+	}
+	cw.WriteAll(records) // WriteAll flushes for us
+	csvFile.Close()
+	raw, _ := os.ReadFile(csvPath)
+	fmt.Print(string(raw))
+
+	csvIn, _ := os.Open(csvPath)
+	cr := csv.NewReader(csvIn)
+	header, _ := cr.Read()
+	total, rows := 0, 0
+	for {
+		record, err := cr.Read()
+		if err == io.EOF {
+			break
+		}
+		if err != nil {
+			fmt.Println("Error:", err)
+			break
+		}
+		score, _ := strconv.Atoi(record[1])
+		total += score
+		rows++
+		fmt.Printf("%s=%s %s=%d %s=%q\n", header[0], record[0], header[1], score, header[2], record[2])
+	}
+	csvIn.Close()
+	fmt.Printf("Average score: %.1f\n", float64(total)/float64(rows))
+
+	// ==================== Exercise: Parsing a Log File ====================
+	fmt.Println("\n=== Log File Parsing ===")
+	logPath := filepath.Join(dir, "app.log")
+	os.WriteFile(logPath, []byte(`2024-05-01T10:00:00Z INFO server started
+2024-05-01T10:00:05Z INFO request served
+2024-05-01T10:01:00Z WARN slow query took 2.3s
+2024-05-01T10:02:00Z ERROR payment failed: card declined
+garbage
+2024-05-01T10:03:00Z INFO request served
+2024-05-01T10:04:00Z ERROR database connection lost
+`), 0o644)
+
+	logFile, _ := os.Open(logPath)
+	defer logFile.Close()
+	summary, err := parseLog(logFile)
+	if err != nil {
+		fmt.Println("Error:", err)
+		return
+	}
+	levels := make([]string, 0, len(summary.ByLevel))
+	for level := range summary.ByLevel {
+		levels = append(levels, level)
+	}
+	sort.Strings(levels)
+	fmt.Printf("Lines: %d, malformed: %d\n", summary.Lines, summary.Malformed)
+	for _, level := range levels {
+		fmt.Printf("%-5s %d\n", level, summary.ByLevel[level])
+	}
+	fmt.Printf("Errors: %q\n", summary.Errors)
+}
@@ -0,0 +1,219 @@
+// This file demonstrates string formatting and conversion in Go
+//
+// Topics:
+// - fmt verbs: general, integer, float, string, pointer, width and precision
+// - The Stringer and GoStringer interfaces
+// - strconv conversions: Atoi/Itoa, ParseInt, ParseFloat, ParseBool, Quote
+// - strings.Builder for efficient concatenation
+// - Runes, bytes and UTF-8, with Thai and emoji examples
+
+package main
+
+import (
+	"errors"
+	"fmt"
+	"os"
+	"strconv"
+	"strings"
+	"unicode"
+	"unicode/utf8"
+)
+
+type Point struct {
+	X, Y int
+}
+
+// Temperature implements fmt.Stringer, so %v and %s use String()
+type Temperature float64
+
+func (t Temperature) String() string {
+	return fmt.Sprintf("%.1f°C", float64(t))
+}
+
+// Money implements fmt.GoStringer, used by %#v
+type Money struct {
+	Satang int64 // 100 satang = 1 baht; store money as integers, not floats
+}
+
+func (m Money) String() string {
+	return fmt.Sprintf("฿%d.%02d", m.Satang/100, m.Satang%100)
+}
+
+func (m Money) GoString() string {
+	return fmt.Sprintf("Money{Satang: %d}", m.Satang)
+}
+
+// buildTable returns a fixed-width table using a strings.Builder
+// Builder appends into one growing buffer instead of creating a new string
+// on every +=; fmt.Fprintf works on it because it is an io.Writer
+func buildTable(rows map[string]float64, order []string) string {
+	var sb strings.Builder
+	fmt.Fprintf(&sb, "%-10s|%10s\n", "Item", "Price")
+	sb.WriteString(strings.Repeat("-", 21))
+	sb.WriteByte('\n')
+	for _, name := range order {
+		fmt.Fprintf(&sb, "%-10s|%10.2f\n", name, rows[name])
+	}
+	return sb.String()
+}
+
+// reverseRunes reverses a string by runes so multi-byte characters survive
+func reverseRunes(s string) string {
+	runes := []rune(s)
+	for i, j := 0, len(runes)-1; i < j; i, j = i+1, j-1 {
+		runes[i], runes[j] = runes[j], runes[i]
+	}
+	return string(runes)
+}
+
+// truncate shortens s to at most n runes, never cutting a character in half
+func truncate(s string, n int) string {
+	if utf8.RuneCountInString(s) <= n {
+		return s
+	}
+	return string([]rune(s)[:n]) + "…"
+}
+
+func main() {
+	// ==================== General Verbs ====================
+	fmt.Println("=== General Verbs ===")
+	p := Point{1, 2}
+	fmt.Printf("%%v   %v\n", p)  // Default format
+	fmt.Printf("%%+v  %+v\n", p) // Adds field names
+	fmt.Printf("%%#v  %#v\n", p) // Go syntax
+	fmt.Printf("%%T   %T\n", p)  // Type
+	fmt.Printf("%%v   %v\n", []string{"a", "b"})
+	fmt.Printf("%%q   %q\n", []string{"a", "b"})
+	fmt.Printf("%%v   %v\n", map[string]int{"b": 2, "a": 1}) // Maps print with sorted keys
+	var nilPtr *Point
+	fmt.Printf("%%v   %v (nil pointer), %v (nil error)\n", nilPtr, error(nil))
+
+	// ==================== Integers ====================
+	fmt.Println("\n=== Integers ===")
+	n := 255
+	fmt.Printf("%%d %d | %%b %b | %%o %o | %%O %O | %%x %x | %%X %X | %%#x %#x\n", n, n, n, n, n, n, n)
+	fmt.Printf("%%c %c | %%q %q | %%U %U\n", 'ก', 'ก', 'ก')
+	fmt.Printf("|%%5d|%5d|  |%%-5d|%-5d|  |%%05d|%05d|  |%%+d|%+d|\n", 42, 42, 42, 42)
+
+	// ==================== Floats ====================
+	fmt.Println("\n=== Floats ===")
+	f := 1234.5678
+	fmt.Printf("%%f %f | %%.2f %.2f | %%e %e | %%g %g\n", f, f, f, f)
+	fmt.Printf("|%%10.2f|%10.2f|  |%%-10.2f|%-10.2f|\n", f, f)
+	fmt.Printf("%%g of 1e21: %g, %%f of 0.1+0.2: %.17f\n", 1e21, 0.1+0.2)
+	// Width and precision can come from arguments with *
+	fmt.Printf("|%%*.*f|%*.*f|\n", 8, 3, f)
+
+	// ==================== Strings and Bytes ====================
+	fmt.Println("\n=== Strings and Bytes ===")
+	s := "Go\t\"quoted\""
+	fmt.Printf("%%s %s\n", s)
+	fmt.Printf("%%q %q\n", s)
+	fmt.Printf("%%x %x\n", "Go")
+	fmt.Printf("%% x % x\n", "Go")
+	fmt.Printf("|%%8s|%8s|  |%%-8s|%-8s|  |%%.3s|%.3s|\n", "gopher", "gopher", "gopher")
+
+	// ==================== Pointers and Booleans ====================
+	fmt.Println("\n=== Pointers and Booleans ===")
+	fmt.Printf("%%t %t\n", true)
+	fmt.Printf("%%p is an address like 0xc000012345: %v\n", strings.HasPrefix(fmt.Sprintf("%p", &p), "0x"))
+
+	// ==================== Stringer and GoStringer ====================
+	fmt.Println("\n=== Stringer and GoStringer ===")
+	temp := Temperature(36.6)
+	fmt.Printf("%%v %v | %%s %s | %%.2f %.2f\n", temp, temp, temp) // Numeric verbs skip String()
+	price := Money{Satang: 12950}
+	fmt.Printf("%%v %v | %%#v %#v\n", price, price)
+
+	// Formatting mistakes show up in the output instead of panicking
+	// go vet catches them when the format is a constant, so use a variable here
+	format := "Wrong type: %d | missing argument: %s %s\n"
+	fmt.Printf(format, "x", "one")
+	format = "Extra argument: %s "
+	fmt.Printf(format, "one", "two")
+	fmt.Println()
+	// Sprint, Sprintln and Sprintf return strings; Fprint* write to an io.Writer
+	fmt.Fprintln(os.Stdout, fmt.Sprint("Sprint adds spaces between operands ", 1, 2, " when neither is a string"))
+
+	// ==================== strconv ====================
+	fmt.Println("\n=== strconv ===")
+	i, err := strconv.Atoi("42")
+	fmt.Printf("Atoi(\"42\") = %d, err: %v\n", i, err)
+	_, err = strconv.Atoi("42abc")
+	fmt.Printf("Atoi(\"42abc\") err: %v\n", err)
+	fmt.Printf("Is ErrSyntax? %v\n", errors.Is(err, strconv.ErrSyntax))
+	_, err = strconv.ParseInt("300", 10, 8) // Base 10, must fit in int8
+	fmt.Printf("ParseInt(\"300\", 10, 8) err: %v\n", err)
+	hex, _ := strconv.ParseInt("ff", 16, 64)
+	bin, _ := strconv.ParseInt("0b1010", 0, 64) // Base 0 reads the prefix
+	fmt.Printf("ParseInt hex ff = %d, base 0 \"0b1010\" = %d\n", hex, bin)
+
+	pi, _ := strconv.ParseFloat("3.14159", 64)
+	fmt.Printf("ParseFloat = %v\n", pi)
+	b, _ := strconv.ParseBool("true")
+	fmt.Printf("ParseBool = %v\n", b)
+
+	fmt.Printf("Itoa(99) = %q\n", strconv.Itoa(99))
+	// string(99) would give "c", the character with code point 99; go vet warns about it
+	fmt.Printf("FormatInt(255, 2) = %q\n", strconv.FormatInt(255, 2))
+	fmt.Printf("FormatFloat(pi, 'f', 2, 64) = %q\n", strconv.FormatFloat(pi, 'f', 2, 64))
+
+	fmt.Printf("Quote = %s\n", strconv.Quote("Hello\n\"สวัสดี\""))
+	fmt.Printf("QuoteToASCII = %s\n", strconv.QuoteToASCII("สวัสดี"))
+	unquoted, err := strconv.Unquote(`"tab\there"`)
+	fmt.Printf("Unquote = %q, err: %v\n", unquoted, err)
+	// AppendInt writes into an existing byte slice without allocating a string
+	buf := []byte("id=")
+	buf = strconv.AppendInt(buf, 1234, 10)
+	fmt.Printf("AppendInt = %s\n", buf)
+
+	// ==================== strings.Builder ====================
+	fmt.Println("\n=== strings.Builder ===")
+	var sb strings.Builder
+	sb.Grow(32) // Optional: reserve capacity when the size is known
+	for i := 1; i <= 5; i++ {
+		if i > 1 {
+			sb.WriteString(", ")
+		}
+		sb.WriteString(strconv.Itoa(i))
+	}
+	sb.WriteRune('✓')
+	fmt.Printf("Builder: %q (len %d)\n", sb.String(), sb.Len())
+	fmt.Print(buildTable(map[string]float64{"coffee": 65, "mango": 120.5}, []string{"coffee", "mango"}))
+	// strings.Join is simplest when the parts are already in a slice
+	fmt.Println(strings.Join([]string{"a", "b", "c"}, "-"))
+
+	// ==================== Runes and UTF-8 ====================
+	fmt.Println("\n=== Runes and UTF-8 ===")
+	// A string is a read-only slice of bytes; Go source is UTF-8
+	// Thai characters take 3 bytes each, most emoji take 4
+	for _, text := range []string{"hello", "สวัสดี", "Go🚀", "👍🏽"} {
+		fmt.Printf("%-8q bytes=%-3d runes=%d\n", text, len(text), utf8.RuneCountInString(text))
+	}
+
+	// Indexing gives bytes; range gives runes together with their byte offset
+	thai := "ภาษาไทย"
+	fmt.Printf("thai[0] = %d (a byte, not a character)\n", thai[0])
+	for offset, r := range thai {
+		fmt.Printf("%d:%c(%U) ", offset, r, r)
+	}
+	fmt.Println()
+
+	// Thai vowels and tone marks are separate combining runes, and some emoji
+	// are several runes (👍🏽 is a thumbs up plus a skin tone modifier)
+	// What a reader sees as one character is a grapheme cluster, which the
+	// standard library does not segment
+	for _, r := range "ที่" {
+		fmt.Printf("%c %U mark=%v\n", r, r, unicode.Is(unicode.Mn, r))
+	}
+
+	fmt.Printf("reverseRunes(%q) = %q\n", "Go🚀", reverseRunes("Go🚀"))
+	fmt.Printf("truncate(%q, 3) = %q\n", "สวัสดีครับ", truncate("สวัสดีครับ", 3))
+	fmt.Printf("Byte slicing \"สวัสดี\"[:4] is valid UTF-8? %v\n", utf8.ValidString("สวัสดี"[:4]))
+
+	r, size := utf8.DecodeRuneInString("🚀 launch")
+	fmt.Printf("First rune %c is %d bytes; RuneLen('ก') = %d\n", r, size, utf8.RuneLen('ก'))
+	fmt.Printf("unicode.IsLetter('ก') = %v, unicode.Is(unicode.Thai, 'ก') = %v\n",
+		unicode.IsLetter('ก'), unicode.Is(unicode.Thai, 'ก'))
+	fmt.Printf("strings.ToUpper(\"straße\") = %q\n", strings.ToUpper("straße"))
+}
@@ -0,0 +1,233 @@
+// This file demonstrates generics (type parameters) in Go
+// Since Go 1.18, functions and types can take type parameters, so one
+// implementation works for many types while staying fully type-checked
+//
+// Key ideas:
+// - Type parameters are listed in square brackets: func Map[T, U any](...)
+// - A constraint is an interface that limits which types are allowed
+// - The compiler usually infers type arguments from the function arguments
+
+package main
+
+import (
+	"cmp"
+	"errors"
+	"fmt"
+	"sort"
+	"strings"
+)
+
+// ==================== Generic Functions ====================
+
+// Map applies f to every element and returns the results
+// T and U can be any types: any is an alias for interface{}
+func Map[T, U any](items []T, f func(T) U) []U {
+	result := make([]U, 0, len(items))
+	for _, item := range items {
+		result = append(result, f(item))
+	}
+	return result
+}
+
+// Filter keeps the elements for which keep returns true
+func Filter[T any](items []T, keep func(T) bool) []T {
+	result := []T{}
+	for _, item := range items {
+		if keep(item) {
+			result = append(result, item)
+		}
+	}
+	return result
+}
+
+// Reduce combines all elements into one value, starting from initial
+func Reduce[T, A any](items []T, initial A, combine func(A, T) A) A {
+	acc := initial
+	for _, item := range items {
+		acc = combine(acc, item)
+	}
+	return acc
+}
+
+// ==================== Constraints ====================
+
+// Number is a custom constraint: a union of types
+// The ~ means "any type whose underlying type is", so named types like
+// Celsius (below) are allowed too
+type Number interface {
+	~int | ~int64 | ~float64
+}
+
+// Sum works for every Number type; + is allowed because every type in the union supports it
+func Sum[T Number](numbers []T) T {
+	var total T // zero value of T
+	for _, n := range numbers {
+		total += n
+	}
+	return total
+}
+
+// Celsius is a named type with float64 as its underlying type
+type Celsius float64
+
+// MaxOf returns the largest element
+// cmp.Ordered (Go 1.21+) allows every type supporting < and >: integers, floats and strings.
+// Older code uses constraints.Ordered from golang.org/x/exp, which is the same constraint.
+func MaxOf[T cmp.Ordered](items []T) (T, error) {
+	if len(items) == 0 {
+		var zero T
+		return zero, errors.New("MaxOf of empty slice")
+	}
+	best := items[0]
+	for _, item := range items[1:] {
+		if item > best {
+			best = item
+		}
+	}
+	return best, nil
+}
+
+// Contains uses the built-in comparable constraint, which allows == and !=
+func Contains[T comparable](items []T, target T) bool {
+	for _, item := range items {
+		if item == target {
+			return true
+		}
+	}
+	return false
+}
+
+// Keys returns the keys of any map, sorted
+// Map keys must be comparable; sorting needs cmp.Ordered
+func Keys[K cmp.Ordered, V any](m map[K]V) []K {
+	keys := make([]K, 0, len(m))
+	for k := range m {
+		keys = append(keys, k)
+	}
+	sort.Slice(keys, func(i, j int) bool { return keys[i] < keys[j] })
+	return keys
+}
+
+// ==================== Generic Types ====================
+
+// Stack is a generic LIFO stack
+// Compare with 02-data-structures/datastructures/stack.go, which only holds one element type
+type Stack[T any] struct {
+	items []T
+}
+
+// Push adds an item to the top
+func (s *Stack[T]) Push(item T) {
+	s.items = append(s.items, item)
+}
+
+// Pop removes and returns the top item
+// The bool result is false when the stack is empty
+func (s *Stack[T]) Pop() (T, bool) {
+	var zero T
+	if len(s.items) == 0 {
+		return zero, false
+	}
+	item := s.items[len(s.items)-1]
+	s.items = s.items[:len(s.items)-1]
+	return item, true
+}
+
+// Len returns the number of items
+func (s *Stack[T]) Len() int {
+	return len(s.items)
+}
+
+// Pair holds two values of possibly different types
+type Pair[K comparable, V any] struct {
+	Key   K
+	Value V
+}
+
+// String makes Pair print nicely with %v
+func (p Pair[K, V]) String() string {
+	return fmt.Sprintf("%v=%v", p.Key, p.Value)
+}
+
+// Set is a generic set built on a map
+type Set[T comparable] map[T]struct{}
+
+// Add inserts values into the set
+func (s Set[T]) Add(values ...T) {
+	for _, v := range values {
+		s[v] = struct{}{}
+	}
+}
+
+// Has reports whether v is in the set
+func (s Set[T]) Has(v T) bool {
+	_, ok := s[v]
+	return ok
+}
+
+// MapStack applies f to every item of a stack, producing a stack of another type
+// Methods cannot declare their own type parameters, so a conversion that
+// changes the element type has to be a plain function
+func MapStack[T, U any](s *Stack[T], f func(T) U) *Stack[U] {
+	return &Stack[U]{items: Map(s.items, f)}
+}
+
+func main() {
+	fmt.Println("=== Generic Functions ===")
+	numbers := []int{1, 2, 3, 4, 5, 6}
+	// Type arguments are inferred: Map[int, string]
+	labels := Map(numbers, func(n int) string { return fmt.Sprintf("#%d", n) })
+	evens := Filter(numbers, func(n int) bool { return n%2 == 0 })
+	product := Reduce(numbers, 1, func(acc, n int) int { return acc * n })
+	fmt.Printf("Map: %v\n", labels)
+	fmt.Printf("Filter evens: %v\n", evens)
+	fmt.Printf("Reduce product: %d\n", product)
+
+	// The same functions work for any type
+	words := []string{"go", "generics", "are", "handy"}
+	lengths := Map(words, func(w string) int { return len(w) })
+	long := Filter(words, func(w string) bool { return len(w) > 3 })
+	sentence := Reduce(words, "", func(acc, w string) string { return strings.TrimSpace(acc + " " + w) })
+	fmt.Printf("Lengths: %v, long words: %v, joined: %q\n", lengths, long, sentence)
+
+	fmt.Println("\n=== Constraints ===")
+	fmt.Printf("Sum ints: %d\n", Sum([]int{1, 2, 3}))
+	fmt.Printf("Sum floats: %.2f\n", Sum([]float64{1.5, 2.25}))
+	temps := []Celsius{21.5, 23.0, 19.5}
+	fmt.Printf("Sum Celsius (allowed by ~float64): %.1f\n", Sum(temps))
+
+	maxInt, _ := MaxOf([]int{3, 9, 2})
+	maxWord, _ := MaxOf(words)
+	_, err := MaxOf([]float64{})
+	fmt.Printf("MaxOf ints: %d, MaxOf strings: %q, MaxOf empty: %v\n", maxInt, maxWord, err)
+	fmt.Printf("Contains 4: %v, Contains \"rust\": %v\n", Contains(numbers, 4), Contains(words, "rust"))
+	fmt.Printf("Keys: %v\n", Keys(map[string]int{"b": 2, "a": 1, "c": 3}))
+	// Sum([]string{"a"}) would not compile: string does not satisfy Number
+
+	fmt.Println("\n=== Generic Types ===")
+	// Generic types need explicit type arguments when declared
+	var stack Stack[string]
+	stack.Push("first")
+	stack.Push("second")
+	top, _ := stack.Pop()
+	fmt.Printf("Popped %q, %d left\n", top, stack.Len())
+
+	intStack := &Stack[int]{}
+	for _, n := range []int{1, 2, 3} {
+		intStack.Push(n)
+	}
+	squares := MapStack(intStack, func(n int) float64 { return float64(n * n) })
+	value, _ := squares.Pop()
+	fmt.Printf("Top of mapped stack: %.1f (type %T)\n", value, value)
+
+	pairs := []Pair[string, int]{{"apples", 3}, {"pears", 5}}
+	fmt.Printf("Pairs: %v\n", pairs)
+
+	seen := Set[string]{}
+	seen.Add("go", "rust", "go")
+	fmt.Printf("Set size: %d, has go: %v, has zig: %v\n", len(seen), seen.Has("go"), seen.Has("zig"))
+
+	// Explicit instantiation: a generic function becomes an ordinary function value
+	sumFloats := Sum[float64]
+	fmt.Printf("sumFloats is %T\n", sumFloats)
+}
@@ -0,0 +1,248 @@
+// This file demonstrates goroutine leaks and how to manage goroutine lifecycles
+// A goroutine leaks when it blocks forever: it is never collected, and it
+// keeps everything it references alive. Leaks in a server grow with every
+// request until memory runs out
+//
+// Topics:
+// - Leak 1: a blocked send nobody will ever receive
+// - Leak 2: a receiver waiting for a channel nobody closes
+// - Leak 3: a background loop with no way to stop it
+// - Detecting leaks with runtime.NumGoroutine and a goleak-style stack check
+// - Fixing them with buffered channels, close, context and done channels
+//
+// In real tests, go.uber.org/goleak does the stack check below for you:
+//   func TestMain(m *testing.M) { goleak.VerifyTestMain(m) }
+
+package main
+
+import (
+	"context"
+	"fmt"
+	"runtime"
+	"strings"
+	"sync"
+	"time"
+)
+
+// ==================== Leak 1: Blocked Send ====================
+
+// firstResponseLeaky asks several replicas and returns the fastest answer
+// The channel is unbuffered, so only one sender can ever complete; the
+// slower goroutines block on their send forever
+func firstResponseLeaky(replicas []time.Duration) string {
+	results := make(chan string)
+	for i, delay := range replicas {
+		go func() {
+			time.Sleep(delay)
+			results <- fmt.Sprintf("replica %d", i)
+		}()
+	}
+	return <-results
+}
+
+// firstResponse fixes it with a buffer large enough for every sender
+func firstResponse(replicas []time.Duration) string {
+	results := make(chan string, len(replicas))
+	for i, delay := range replicas {
+		go func() {
+			time.Sleep(delay)
+			results <- fmt.Sprintf("replica %d", i) // Never blocks
+		}()
+	}
+	return <-results
+}
+
+// ==================== Leak 2: Forgotten Receiver ====================
+
+// sumLeaky starts a consumer that ranges over jobs, but returns early on a
+// bad value without closing jobs, so the consumer waits forever
+func sumLeaky(values []int) (int, bool) {
+	jobs := make(chan int)
+	total := make(chan int)
+	go func() {
+		sum := 0
+		for v := range jobs { // Only ends when jobs is closed
+			sum += v
+		}
+		total <- sum
+	}()
+	for _, v := range values {
+		if v < 0 {
+			return 0, false // Forgot close(jobs): the consumer leaks
+		}
+		jobs <- v
+	}
+	close(jobs)
+	return <-total, true
+}
+
+// sum closes jobs on every path: the sending loop runs in a function whose
+// deferred close fires on the early return too. The buffered total lets the
+// consumer finish even when nobody reads the result
+func sum(values []int) (int, bool) {
+	jobs := make(chan int)
+	total := make(chan int, 1)
+	go func() {
+		s := 0
+		for v := range jobs {
+			s += v
+		}
+		total <- s
+	}()
+	ok := func() bool {
+		defer close(jobs)
+		for _, v := range values {
+			if v < 0 {
+				return false
+			}
+			jobs <- v
+		}
+		return true
+	}()
+	if !ok {
+		return 0, false
+	}
+	return <-total, true
+}
+
+// ==================== Leak 3: Unstoppable Background Work ====================
+
+// startPollerLeaky polls forever; there is no way to tell it to stop
+func startPollerLeaky(interval time.Duration, poll func()) {
+	go func() {
+		for {
+			time.Sleep(interval)
+			poll()
+		}
+	}()
+}
+
+// startPoller stops when ctx is cancelled and reports when it has exited
+func startPoller(ctx context.Context, interval time.Duration, poll func()) <-chan struct{} {
+	stopped := make(chan struct{})
+	go func() {
+		defer close(stopped)
+		ticker := time.NewTicker(interval)
+		defer ticker.Stop()
+		for {
+			select {
+			case <-ctx.Done():
+				return
+			case <-ticker.C:
+				poll()
+			}
+		}
+	}()
+	return stopped
+}
+
+// generator sends values until done is closed
+// The done channel is the pre-context way to stop a goroutine
+func generator(done <-chan struct{}) <-chan int {
+	out := make(chan int)
+	go func() {
+		defer close(out)
+		for i := 0; ; i++ {
+			select {
+			case out <- i:
+			case <-done:
+				return
+			}
+		}
+	}()
+	return out
+}
+
+// ==================== Detection ====================
+
+// leakedGoroutines waits up to timeout for the goroutine count to return to
+// baseline and returns how many are still running above it
+// Goroutines need a moment to exit, so a single immediate check is flaky
+func leakedGoroutines(baseline int, timeout time.Duration) int {
+	deadline := time.Now().Add(timeout)
+	for {
+		extra := runtime.NumGoroutine() - baseline
+		if extra <= 0 || time.Now().After(deadline) {
+			return max(extra, 0)
+		}
+		time.Sleep(5 * time.Millisecond)
+	}
+}
+
+// stuckGoroutines is a goleak-style check: it dumps every goroutine's stack
+// and returns the ones that were started from this file's functions
+// The stack shows where each leaked goroutine is blocked, which the bare
+// count from NumGoroutine can't tell you
+func stuckGoroutines() []string {
+	buf := make([]byte, 1<<20)
+	buf = buf[:runtime.Stack(buf, true)]
+	stuck := []string{}
+	for _, g := range strings.Split(string(buf), "\n\n") {
+		lines := strings.Split(g, "\n")
+		// The header looks like "goroutine 7 [chan send]:"; skip main itself
+		if strings.Contains(lines[0], "[running]") || !strings.Contains(g, "main.") {
+			continue
+		}
+		state := lines[0][strings.Index(lines[0], "[")+1 : strings.Index(lines[0], "]")]
+		// Find the function that created the goroutine
+		origin := "unknown"
+		for i, line := range lines {
+			if strings.HasPrefix(line, "created by ") && i > 0 {
+				origin = strings.TrimPrefix(line, "created by ")
+				origin = origin[:strings.Index(origin, " in goroutine")]
+				break
+			}
+		}
+		stuck = append(stuck, fmt.Sprintf("[%s] created by %s", state, origin))
+	}
+	return stuck
+}
+
+// check runs fn and reports how many goroutines it left behind
+func check(name string, fn func()) {
+	baseline := runtime.NumGoroutine()
+	fn()
+	leaked := leakedGoroutines(baseline, 100*time.Millisecond)
+	fmt.Printf("%-30s leaked goroutines: %d\n", name, leaked)
+}
+
+func main() {
+	fmt.Println("=== Leak 1: Blocked Send ===")
+	replicas := []time.Duration{10 * time.Millisecond, 20 * time.Millisecond, 30 * time.Millisecond}
+	check("firstResponseLeaky", func() { firstResponseLeaky(replicas) })
+	check("firstResponse (buffered)", func() { firstResponse(replicas) })
+
+	fmt.Println("\n=== Leak 2: Forgotten Receiver ===")
+	check("sumLeaky", func() { sumLeaky([]int{1, 2, -3}) })
+	check("sum (defer close)", func() { sum([]int{1, 2, -3}) })
+
+	fmt.Println("\n=== Leak 3: Unstoppable Background Work ===")
+	var mu sync.Mutex
+	polls := 0
+	poll := func() {
+		mu.Lock()
+		polls++
+		mu.Unlock()
+	}
+	check("startPollerLeaky", func() { startPollerLeaky(5*time.Millisecond, poll) })
+	check("startPoller (context)", func() {
+		ctx, cancel := context.WithCancel(context.Background())
+		stopped := startPoller(ctx, 5*time.Millisecond, poll)
+		time.Sleep(20 * time.Millisecond)
+		cancel()
+		<-stopped // Wait until it has really exited
+	})
+	check("generator (done channel)", func() {
+		done := make(chan struct{})
+		values := generator(done)
+		fmt.Printf("first values: %d %d %d\n", <-values, <-values, <-values)
+		close(done)
+	})
+
+	// ==================== Finding Where Leaks Are Stuck ====================
+	fmt.Println("\n=== Stack Dump of Leaked Goroutines ===")
+	for _, g := range stuckGoroutines() {
+		fmt.Println(g)
+	}
+	fmt.Printf("Total goroutines still running: %d\n", runtime.NumGoroutine())
+}
@@ -0,0 +1,208 @@
+// This file demonstrates graceful shutdown in Go
+// A long-running program should stop cleanly when asked to: stop taking new
+// work, finish (or give up on) work in flight, then release resources
+//
+// Topics:
+// - Catching SIGINT (Ctrl+C) and SIGTERM (sent by Docker, Kubernetes, systemd)
+//   with signal.NotifyContext
+// - Shutting down an http.Server without dropping in-flight requests
+// - Draining a worker pool, closing channels in the right order
+// - A shutdown deadline so a stuck task can't block exit forever
+//
+// Usage:
+//   go run ./01-basics/graceful-shutdown          # sends itself SIGINT after a moment
+//   go run ./01-basics/graceful-shutdown -wait    # runs until you press Ctrl+C
+
+package main
+
+import (
+	"context"
+	"errors"
+	"flag"
+	"fmt"
+	"io"
+	"net"
+	"net/http"
+	"os"
+	"os/signal"
+	"sync"
+	"syscall"
+	"time"
+)
+
+// Pipeline is producer -> jobs -> workers -> results -> collector
+// Shutdown order matters: each channel is closed by its only sender, after
+// that sender is done, and downstream stages are waited for in turn
+type Pipeline struct {
+	jobs    chan int
+	results chan string
+	workers sync.WaitGroup
+	done    chan struct{} // Closed when the collector has finished
+	handled []string
+}
+
+// NewPipeline starts n workers and a collector
+func NewPipeline(n int) *Pipeline {
+	p := &Pipeline{
+		jobs:    make(chan int, 10),
+		results: make(chan string),
+		done:    make(chan struct{}),
+	}
+	for id := 1; id <= n; id++ {
+		p.workers.Add(1)
+		go func() {
+			defer p.workers.Done()
+			for job := range p.jobs { // Ends when jobs is closed and drained
+				time.Sleep(30 * time.Millisecond) // Simulated work
+				p.results <- fmt.Sprintf("job %d by worker %d", job, id)
+			}
+		}()
+	}
+	go func() {
+		defer close(p.done)
+		for r := range p.results {
+			p.handled = append(p.handled, r)
+		}
+	}()
+	return p
+}
+
+// Produce submits jobs until ctx is cancelled
+// It is the only sender on jobs, so it is the one that closes it
+func (p *Pipeline) Produce(ctx context.Context) {
+	defer close(p.jobs) // 1. No more jobs
+	for job := 1; ; job++ {
+		select {
+		case p.jobs <- job:
+			time.Sleep(10 * time.Millisecond)
+		case <-ctx.Done():
+			return
+		}
+	}
+}
+
+// Wait drains the pipeline in order, or gives up when ctx expires
+func (p *Pipeline) Wait(ctx context.Context) error {
+	go func() {
+		p.workers.Wait() // 2. Workers finish the queued jobs
+		close(p.results) // 3. Only then is it safe to close results
+	}()
+	select {
+	case <-p.done: // 4. The collector has seen every result
+		return nil
+	case <-ctx.Done():
+		return fmt.Errorf("pipeline did not drain: %w", ctx.Err())
+	}
+}
+
+// newServer returns an HTTP server with a fast and a slow endpoint
+func newServer() *http.Server {
+	mux := http.NewServeMux()
+	mux.HandleFunc("/health", func(w http.ResponseWriter, r *http.Request) {
+		fmt.Fprintln(w, "ok")
+	})
+	mux.HandleFunc("/report", func(w http.ResponseWriter, r *http.Request) {
+		time.Sleep(200 * time.Millisecond) // A slow request still in flight at shutdown
+		fmt.Fprintln(w, "report ready")
+	})
+	return &http.Server{
+		Handler:           mux,
+		ReadHeaderTimeout: 5 * time.Second, // Always set timeouts on public servers
+	}
+}
+
+func main() {
+	wait := flag.Bool("wait", false, "wait for a real Ctrl+C instead of signalling ourselves")
+	flag.Parse()
+
+	// NotifyContext cancels ctx on the first SIGINT or SIGTERM
+	// After stop() a second Ctrl+C kills the program the usual way, which is
+	// the escape hatch when shutdown itself hangs
+	ctx, stop := signal.NotifyContext(context.Background(), os.Interrupt, syscall.SIGTERM)
+	defer stop()
+
+	// ==================== Start Everything ====================
+	fmt.Println("=== Starting ===")
+	listener, err := net.Listen("tcp", "127.0.0.1:0") // Port 0 picks a free port
+	if err != nil {
+		fmt.Println("Error:", err)
+		return
+	}
+	server := newServer()
+	serverErr := make(chan error, 1)
+	go func() {
+		// Serve returns http.ErrServerClosed after Shutdown; that is not a failure
+		if err := server.Serve(listener); !errors.Is(err, http.ErrServerClosed) {
+			serverErr <- err
+		}
+		close(serverErr)
+	}()
+	url := "http://" + listener.Addr().String()
+	fmt.Println("HTTP server listening on", url)
+
+	pipeline := NewPipeline(3)
+	go pipeline.Produce(ctx)
+	fmt.Println("Pipeline started with 3 workers")
+
+	// A request that will still be running when the signal arrives
+	slowResponse := make(chan string, 1)
+	go func() {
+		time.Sleep(80 * time.Millisecond)
+		resp, err := http.Get(url + "/report")
+		if err != nil {
+			slowResponse <- "error: " + err.Error()
+			return
+		}
+		defer resp.Body.Close()
+		body, _ := io.ReadAll(resp.Body)
+		slowResponse <- fmt.Sprintf("%d %q", resp.StatusCode, body)
+	}()
+
+	if *wait {
+		fmt.Println("Press Ctrl+C to shut down")
+	} else {
+		go func() {
+			time.Sleep(150 * time.Millisecond)
+			fmt.Println("\n(sending ourselves SIGINT, as if Ctrl+C was pressed)")
+			self, _ := os.FindProcess(os.Getpid())
+			self.Signal(os.Interrupt)
+		}()
+	}
+
+	// ==================== Wait for a Signal ====================
+	select {
+	case <-ctx.Done():
+		stop() // Restore default signal handling
+		fmt.Println("\n=== Shutting Down ===")
+		fmt.Println("Signal received:", context.Cause(ctx))
+	case err := <-serverErr:
+		fmt.Println("Server failed:", err)
+		return
+	}
+
+	// Everything below must finish within this budget
+	shutdownCtx, cancel := context.WithTimeout(context.Background(), 2*time.Second)
+	defer cancel()
+
+	// 1. Stop accepting connections and wait for in-flight requests
+	//    Shutdown closes listeners, then idle connections, then waits for
+	//    active requests to complete or for shutdownCtx to expire
+	start := time.Now()
+	if err := server.Shutdown(shutdownCtx); err != nil {
+		fmt.Println("HTTP shutdown incomplete:", err)
+		server.Close() // Force-close whatever is left
+	}
+	fmt.Printf("HTTP server stopped after %v\n", time.Since(start).Round(10*time.Millisecond))
+	fmt.Println("In-flight request got:", <-slowResponse)
+	_, err = http.Get(url + "/health")
+	fmt.Println("New requests are refused:", err != nil)
+
+	// 2. Drain the pipeline: the producer already stopped because ctx ended
+	if err := pipeline.Wait(shutdownCtx); err != nil {
+		fmt.Println("Error:", err)
+	}
+	fmt.Printf("Pipeline drained: %d jobs finished, none lost mid-way\n", len(pipeline.handled))
+
+	// 3. Release remaining resources (databases, files...) and exit
+	fmt.Println("Shutdown complete")
+}
@@ -0,0 +1,231 @@
+// This file demonstrates interfaces and polymorphism in Go
+// An interface is a set of method signatures. A type satisfies an interface
+// just by having those methods: there is no "implements" keyword
+//
+// Topics:
+// - Implicit interface satisfaction and polymorphism
+// - The empty interface (interface{} / any), type assertions and type switches
+// - Interface embedding
+// - The nil interface pitfall
+// - Composing small interfaces: io.Reader and io.Writer
+
+package main
+
+import (
+	"bytes"
+	"errors"
+	"fmt"
+	"io"
+	"math"
+	"os"
+	"strings"
+)
+
+// ==================== Interface Satisfaction ====================
+
+// Shape is satisfied by any type with Area and Perimeter methods
+type Shape interface {
+	Area() float64
+	Perimeter() float64
+}
+
+type Rectangle struct {
+	Width, Height float64
+}
+
+func (r Rectangle) Area() float64      { return r.Width * r.Height }
+func (r Rectangle) Perimeter() float64 { return 2 * (r.Width + r.Height) }
+
+type Circle struct {
+	Radius float64
+}
+
+func (c Circle) Area() float64      { return math.Pi * c.Radius * c.Radius }
+func (c Circle) Perimeter() float64 { return 2 * math.Pi * c.Radius }
+
+// String makes Circle satisfy fmt.Stringer as well
+func (c Circle) String() string { return fmt.Sprintf("Circle(r=%.1f)", c.Radius) }
+
+// Compile-time check that the types satisfy Shape
+// The blank assignment costs nothing at runtime but fails the build if a method is missing
+var (
+	_ Shape = Rectangle{}
+	_ Shape = Circle{}
+)
+
+// totalArea works with any Shape, including ones written after this function
+func totalArea(shapes ...Shape) float64 {
+	total := 0.0
+	for _, s := range shapes {
+		total += s.Area()
+	}
+	return total
+}
+
+// ==================== Type Switches ====================
+
+// describe uses a type switch to handle values of different dynamic types
+func describe(value any) string {
+	switch v := value.(type) {
+	case nil:
+		return "nil"
+	case int:
+		return fmt.Sprintf("int %d", v)
+	case string:
+		return fmt.Sprintf("string of length %d", len(v))
+	case Shape:
+		// Matches any type that satisfies Shape
+		return fmt.Sprintf("shape with area %.2f", v.Area())
+	case error:
+		return "error: " + v.Error()
+	default:
+		return fmt.Sprintf("something else (%T)", v)
+	}
+}
+
+// ==================== Interface Embedding ====================
+
+// Speaker and Mover are small interfaces
+type Speaker interface {
+	Speak() string
+}
+
+type Mover interface {
+	Move() string
+}
+
+// Robot combines both by embedding them, like io.ReadWriter embeds io.Reader and io.Writer
+type Robot interface {
+	Speaker
+	Mover
+}
+
+type Android struct {
+	Name string
+}
+
+func (a Android) Speak() string { return a.Name + " says beep" }
+func (a Android) Move() string  { return a.Name + " rolls forward" }
+
+// ==================== The Nil Interface Pitfall ====================
+
+// ValidationError is a custom error type
+type ValidationError struct {
+	Field string
+}
+
+func (e *ValidationError) Error() string { return "invalid " + e.Field }
+
+// validateBuggy returns a typed nil pointer inside an error interface
+// An interface is only nil when both its type and value are nil, so the caller's
+// err != nil check is true even though nothing went wrong
+func validateBuggy(name string) error {
+	var err *ValidationError // nil pointer of a concrete type
+	if name == "" {
+		err = &ValidationError{Field: "name"}
+	}
+	return err // BUG: the interface holds (*ValidationError, nil), which is not nil
+}
+
+// validate returns a literal nil on success, which is the correct way
+func validate(name string) error {
+	if name == "" {
+		return &ValidationError{Field: "name"}
+	}
+	return nil
+}
+
+// ==================== io.Reader / io.Writer Composition ====================
+
+// rot13Reader wraps another io.Reader and decodes ROT13 while reading
+// Because it is itself an io.Reader, it can be used anywhere a reader is accepted
+type rot13Reader struct {
+	r io.Reader
+}
+
+func (rr rot13Reader) Read(p []byte) (int, error) {
+	n, err := rr.r.Read(p)
+	for i := 0; i < n; i++ {
+		switch c := p[i]; {
+		case 'a' <= c && c <= 'z':
+			p[i] = 'a' + (c-'a'+13)%26
+		case 'A' <= c && c <= 'Z':
+			p[i] = 'A' + (c-'A'+13)%26
+		}
+	}
+	return n, err
+}
+
+// countingWriter counts the bytes written through it to another io.Writer
+type countingWriter struct {
+	w     io.Writer
+	count int
+}
+
+func (cw *countingWriter) Write(p []byte) (int, error) {
+	n, err := cw.w.Write(p)
+	cw.count += n
+	return n, err
+}
+
+func main() {
+	fmt.Println("=== Interface Satisfaction ===")
+	shapes := []Shape{Rectangle{Width: 3, Height: 4}, Circle{Radius: 1}}
+	for _, s := range shapes {
+		fmt.Printf("%-20s area %.2f, perimeter %.2f\n", fmt.Sprint(s), s.Area(), s.Perimeter())
+	}
+	fmt.Printf("Total area: %.2f\n", totalArea(shapes...))
+
+	fmt.Println("\n=== Empty Interface and any ===")
+	// any is an alias for interface{} (Go 1.18+): every type satisfies it
+	var anything any = 42
+	fmt.Printf("anything = %v (%T)\n", anything, anything)
+	anything = "now a string"
+	fmt.Printf("anything = %v (%T)\n", anything, anything)
+
+	// A type assertion extracts the concrete value; use the comma-ok form to avoid a panic
+	if s, ok := anything.(string); ok {
+		fmt.Printf("It is a string: %q\n", s)
+	}
+	if _, ok := anything.(int); !ok {
+		fmt.Println("It is not an int any more")
+	}
+	// Assertions to interfaces check the methods of the dynamic type
+	var shape any = Circle{Radius: 2}
+	if stringer, ok := shape.(fmt.Stringer); ok {
+		fmt.Printf("Circle is a fmt.Stringer: %s\n", stringer.String())
+	}
+
+	fmt.Println("\n=== Type Switches ===")
+	for _, v := range []any{7, "hello", Rectangle{Width: 2, Height: 2}, errors.New("boom"), 3.14, nil} {
+		fmt.Println(describe(v))
+	}
+
+	fmt.Println("\n=== Interface Embedding ===")
+	var robot Robot = Android{Name: "R2"}
+	fmt.Println(robot.Speak())
+	fmt.Println(robot.Move())
+	// A Robot can be used wherever one of the smaller interfaces is needed
+	var speaker Speaker = robot
+	fmt.Println(speaker.Speak())
+
+	fmt.Println("\n=== The Nil Interface Pitfall ===")
+	err := validateBuggy("gopher")
+	fmt.Printf("validateBuggy: err == nil? %v (type %T, value %v)\n", err == nil, err, err)
+	err = validate("gopher")
+	fmt.Printf("validate:      err == nil? %v\n", err == nil)
+	err = validate("")
+	var ve *ValidationError
+	fmt.Printf("validate(\"\"): %v, is ValidationError? %v\n", err, errors.As(err, &ve))
+
+	fmt.Println("\n=== io.Reader and io.Writer ===")
+	// Small interfaces compose: each piece only knows about Read or Write
+	secret := strings.NewReader("Uryyb, Tbcure! Vagresnprf ner pbzcbfnoyr.")
+	var copyBuf, logBuf bytes.Buffer
+	counter := &countingWriter{w: os.Stdout}
+	// TeeReader copies everything read into copyBuf; MultiWriter writes to several outputs
+	reader := io.TeeReader(rot13Reader{secret}, &copyBuf)
+	io.Copy(io.MultiWriter(counter, &logBuf), reader)
+	fmt.Println()
+	fmt.Printf("Wrote %d bytes, copy holds %q, log holds %d bytes\n", counter.count, copyBuf.String(), logBuf.Len())
+}
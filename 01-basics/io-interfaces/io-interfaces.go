@@ -0,0 +1,206 @@
+// This file demonstrates io.Reader and io.Writer pipelines in Go
+// interfaces.go introduces the two interfaces; this file composes them
+//
+// Topics:
+// - io.Copy and the small Reader/Writer helpers (LimitReader, MultiReader)
+// - io.TeeReader: observe data while it is being read
+// - io.MultiWriter: write the same data to several places
+// - Wrapping writers and readers: gzip, hashing, a custom CountingWriter
+// - io.Pipe: connect a writer in one goroutine to a reader in another
+// - Streaming large inputs with constant memory
+//
+// Because everything speaks io.Reader and io.Writer, files, network
+// connections, buffers, compressors and hashes plug into each other freely
+
+package main
+
+import (
+	"bufio"
+	"bytes"
+	"compress/gzip"
+	"crypto/sha256"
+	"fmt"
+	"io"
+	"os"
+	"runtime"
+	"strings"
+)
+
+// CountingWriter counts the bytes written through it to an underlying writer
+// Wrapping an interface value and adding behavior is the decorator pattern
+type CountingWriter struct {
+	W     io.Writer
+	Count int64
+}
+
+func (cw *CountingWriter) Write(p []byte) (int, error) {
+	n, err := cw.W.Write(p)
+	cw.Count += int64(n)
+	return n, err
+}
+
+// logLines is a reader that generates n fake log lines on demand
+// Nothing is stored: each Read fills p with the next part of the stream,
+// so even a multi-gigabyte input costs only a small buffer
+type logLines struct {
+	n, next int
+	pending []byte
+}
+
+func (l *logLines) Read(p []byte) (int, error) {
+	written := 0
+	for written < len(p) {
+		if len(l.pending) == 0 {
+			if l.next >= l.n {
+				break
+			}
+			level := "INFO"
+			if l.next%100 == 0 {
+				level = "ERROR"
+			}
+			l.pending = fmt.Appendf(l.pending[:0], "line %d %s something happened\n", l.next, level)
+			l.next++
+		}
+		c := copy(p[written:], l.pending)
+		l.pending = l.pending[c:]
+		written += c
+	}
+	if written == 0 {
+		return 0, io.EOF // Signal the end of the stream
+	}
+	return written, nil
+}
+
+// countErrors reads a stream line by line, holding one line at a time
+func countErrors(r io.Reader) (lines, errors int, err error) {
+	scanner := bufio.NewScanner(r)
+	for scanner.Scan() {
+		lines++
+		if strings.Contains(scanner.Text(), " ERROR ") {
+			errors++
+		}
+	}
+	return lines, errors, scanner.Err()
+}
+
+// compressStream gzips r in a background goroutine and returns a reader for
+// the compressed bytes. The compressed data is never held in memory as a whole
+func compressStream(r io.Reader) io.Reader {
+	pr, pw := io.Pipe()
+	go func() {
+		gz := gzip.NewWriter(pw)
+		_, err := io.Copy(gz, r)
+		if err == nil {
+			err = gz.Close() // Writes the gzip footer
+		}
+		// CloseWithError passes the error (or nil for a clean EOF) to the reader
+		pw.CloseWithError(err)
+	}()
+	return pr
+}
+
+func heapMB() float64 {
+	var m runtime.MemStats
+	runtime.ReadMemStats(&m)
+	return float64(m.HeapAlloc) / (1 << 20)
+}
+
+func main() {
+	// ==================== io.Copy and Helpers ====================
+	fmt.Println("=== io.Copy and Helpers ===")
+	// io.Copy moves data from any Reader to any Writer in chunks
+	n, _ := io.Copy(os.Stdout, strings.NewReader("copied straight to stdout\n"))
+	fmt.Printf("io.Copy wrote %d bytes\n", n)
+
+	// LimitReader stops after n bytes, e.g. to cap an untrusted request body
+	limited, _ := io.ReadAll(io.LimitReader(strings.NewReader("0123456789"), 4))
+	fmt.Printf("LimitReader(4): %q\n", limited)
+
+	// MultiReader concatenates readers one after another
+	joined, _ := io.ReadAll(io.MultiReader(
+		strings.NewReader("header\n"), strings.NewReader("body\n"), strings.NewReader("footer\n")))
+	fmt.Printf("MultiReader: %q\n", joined)
+
+	// SectionReader reads a window of an io.ReaderAt
+	section, _ := io.ReadAll(io.NewSectionReader(strings.NewReader("skip[keep]skip"), 4, 6))
+	fmt.Printf("SectionReader: %q\n", section)
+
+	// ==================== TeeReader ====================
+	fmt.Println("\n=== io.TeeReader ===")
+	// Everything read from tee is also written to the hash:
+	// one pass computes the checksum and consumes the data
+	payload := strings.NewReader("important payload")
+	hash := sha256.New()
+	tee := io.TeeReader(payload, hash)
+	var saved bytes.Buffer
+	io.Copy(&saved, tee)
+	fmt.Printf("Saved %q\nsha256 %x\n", saved.String(), hash.Sum(nil)[:8])
+
+	// ==================== MultiWriter ====================
+	fmt.Println("\n=== io.MultiWriter ===")
+	// One write goes to the console, a buffer and a byte counter at once
+	var logCopy bytes.Buffer
+	counter := &CountingWriter{W: io.Discard}
+	out := io.MultiWriter(os.Stdout, &logCopy, counter)
+	fmt.Fprintln(out, "written once, delivered three times")
+	fmt.Printf("Buffer has %d bytes, counter saw %d bytes\n", logCopy.Len(), counter.Count)
+
+	// ==================== Wrapping with gzip ====================
+	fmt.Println("\n=== Wrapping Writers and Readers ===")
+	// Layers: gzip -> CountingWriter -> bytes.Buffer
+	var compressed bytes.Buffer
+	sizeOnDisk := &CountingWriter{W: &compressed}
+	gz := gzip.NewWriter(sizeOnDisk)
+	text := strings.Repeat("Go makes streaming easy. ", 200)
+	io.WriteString(gz, text)
+	gz.Close() // Flushes the remaining data; forgetting it truncates the output
+	fmt.Printf("Original %d bytes -> gzip %d bytes\n", len(text), sizeOnDisk.Count)
+
+	gr, err := gzip.NewReader(&compressed)
+	if err != nil {
+		fmt.Println("Error:", err)
+		return
+	}
+	decompressed, _ := io.ReadAll(gr)
+	fmt.Printf("Round trip intact: %v\n", string(decompressed) == text)
+
+	// ==================== io.Pipe ====================
+	fmt.Println("\n=== io.Pipe ===")
+	// A pipe connects code that wants a Writer with code that wants a Reader
+	// Writes block until the other side reads, so nothing is buffered
+	pr, pw := io.Pipe()
+	go func() {
+		defer pw.Close() // Closing the writer gives the reader io.EOF
+		for i := 1; i <= 3; i++ {
+			fmt.Fprintf(pw, "message %d\n", i)
+		}
+	}()
+	scanner := bufio.NewScanner(pr)
+	for scanner.Scan() {
+		fmt.Println("Received:", scanner.Text())
+	}
+
+	// ==================== Streaming Large Inputs ====================
+	fmt.Println("\n=== Streaming Large Inputs ===")
+	const total = 1_000_000
+	runtime.GC()
+	before := heapMB()
+
+	// Generate ~35 MB of log lines, gzip them through a pipe, decompress and
+	// count errors: a four-stage pipeline where only small buffers exist
+	compressedSize := &CountingWriter{W: io.Discard}
+	stream := io.TeeReader(compressStream(&logLines{n: total}), compressedSize)
+	unzipped, err := gzip.NewReader(stream)
+	if err != nil {
+		fmt.Println("Error:", err)
+		return
+	}
+	lines, errs, err := countErrors(unzipped)
+	fmt.Printf("Lines: %d, errors: %d, err: %v\n", lines, errs, err)
+	fmt.Printf("Compressed stream: %.1f MB\n", float64(compressedSize.Count)/(1<<20))
+	fmt.Printf("Heap grew by less than 5 MB: %v\n", heapMB()-before < 5)
+
+	// The same mistake loaded into memory: io.ReadAll holds the whole input
+	all, _ := io.ReadAll(&logLines{n: total})
+	fmt.Printf("io.ReadAll instead holds %.1f MB at once\n", float64(len(all))/(1<<20))
+}
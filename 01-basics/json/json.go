@@ -0,0 +1,235 @@
+// This file demonstrates JSON encoding and decoding with encoding/json
+// Go maps JSON objects to structs using struct tags, and to map[string]any
+// when the shape isn't known in advance
+//
+// Topics:
+// - Marshal/Unmarshal with struct tags and omitempty
+// - Nested structs, slices and pointers
+// - Custom MarshalJSON/UnmarshalJSON methods
+// - Decoding into map[string]any
+// - Streaming with json.Decoder and json.Encoder
+// - Handling malformed input
+
+package main
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"os"
+	"strings"
+	"time"
+)
+
+// Address is nested inside User
+type Address struct {
+	City    string `json:"city"`
+	Country string `json:"country"`
+}
+
+// User shows the common struct tag options
+type User struct {
+	ID    int    `json:"id"`
+	Name  string `json:"name"`
+	Email string `json:"email,omitempty"` // left out when empty
+	// Only exported (capitalized) fields are encoded; password is ignored
+	password string
+	// "-" skips an exported field
+	Token string `json:"-"`
+	// A pointer distinguishes "missing" (nil) from the zero value
+	Age     *int     `json:"age,omitempty"`
+	Tags    []string `json:"tags"`
+	Address Address  `json:"address"`
+	// Custom type with its own JSON format
+	Joined Date `json:"joined"`
+}
+
+// Date is encoded as "2006-01-02" instead of the full RFC 3339 timestamp
+type Date struct {
+	time.Time
+}
+
+// MarshalJSON implements json.Marshaler
+func (d Date) MarshalJSON() ([]byte, error) {
+	return json.Marshal(d.Format("2006-01-02"))
+}
+
+// UnmarshalJSON implements json.Unmarshaler
+// The pointer receiver lets it modify d
+func (d *Date) UnmarshalJSON(data []byte) error {
+	var s string
+	if err := json.Unmarshal(data, &s); err != nil {
+		return fmt.Errorf("date must be a string: %w", err)
+	}
+	t, err := time.Parse("2006-01-02", s)
+	if err != nil {
+		return err
+	}
+	d.Time = t
+	return nil
+}
+
+// Status is an enum encoded as a string
+type Status int
+
+const (
+	Active Status = iota
+	Suspended
+)
+
+var statusNames = map[Status]string{Active: "active", Suspended: "suspended"}
+
+// MarshalText is used by encoding/json for both values and map keys
+func (s Status) MarshalText() ([]byte, error) {
+	name, ok := statusNames[s]
+	if !ok {
+		return nil, fmt.Errorf("unknown status %d", s)
+	}
+	return []byte(name), nil
+}
+
+// String is used by fmt, so %v prints the name too
+func (s Status) String() string {
+	return statusNames[s]
+}
+
+// UnmarshalText parses the string form
+func (s *Status) UnmarshalText(text []byte) error {
+	for status, name := range statusNames {
+		if name == string(text) {
+			*s = status
+			return nil
+		}
+	}
+	return fmt.Errorf("unknown status %q", text)
+}
+
+// Account combines the custom types
+type Account struct {
+	Owner  string `json:"owner"`
+	Status Status `json:"status"`
+}
+
+// describeJSONError explains the most common decoding errors
+func describeJSONError(err error) string {
+	var syntaxErr *json.SyntaxError
+	var typeErr *json.UnmarshalTypeError
+	switch {
+	case errors.As(err, &syntaxErr):
+		return fmt.Sprintf("syntax error at byte %d: %v", syntaxErr.Offset, err)
+	case errors.As(err, &typeErr):
+		return fmt.Sprintf("field %q: cannot use JSON %s as Go %s", typeErr.Field, typeErr.Value, typeErr.Type)
+	case errors.Is(err, io.ErrUnexpectedEOF):
+		// Returned by json.Decoder when the stream stops mid-value
+		return "input ended too early"
+	default:
+		return err.Error()
+	}
+}
+
+func main() {
+	fmt.Println("=== Marshal ===")
+	age := 30
+	user := User{
+		ID:       1,
+		Name:     "Alice",
+		password: "secret",
+		Token:    "abc123",
+		Age:      &age,
+		Tags:     []string{"admin", "dev"},
+		Address:  Address{City: "Bangkok", Country: "TH"},
+		Joined:   Date{time.Date(2023, 3, 15, 0, 0, 0, 0, time.UTC)},
+	}
+	data, err := json.Marshal(user)
+	if err != nil {
+		fmt.Println("Error:", err)
+		return
+	}
+	fmt.Println(string(data))
+
+	// MarshalIndent produces human-readable output
+	pretty, _ := json.MarshalIndent(Address{City: "Chiang Mai", Country: "TH"}, "", "  ")
+	fmt.Println(string(pretty))
+
+	// A nil slice encodes as null, an empty slice as []
+	empty, _ := json.Marshal(User{ID: 2, Name: "Bob"})
+	fmt.Println(string(empty))
+
+	fmt.Println("\n=== Unmarshal ===")
+	input := `{"id": 3, "name": "Carol", "email": "carol@example.com", "tags": ["ops"],
+		"address": {"city": "Phuket", "country": "TH"}, "joined": "2024-01-02", "extra": true}`
+	var decoded User
+	if err := json.Unmarshal([]byte(input), &decoded); err != nil {
+		fmt.Println("Error:", err)
+		return
+	}
+	// Unknown fields ("extra") are ignored; missing ones keep their zero value
+	fmt.Printf("%s <%s> from %s, joined %s, age set: %v\n",
+		decoded.Name, decoded.Email, decoded.Address.City, decoded.Joined.Format("Jan 2, 2006"), decoded.Age != nil)
+
+	fmt.Println("\n=== Custom Text Marshaling ===")
+	accounts := []Account{{"Alice", Active}, {"Bob", Suspended}}
+	data, _ = json.Marshal(accounts)
+	fmt.Println(string(data))
+	var roundTrip []Account
+	json.Unmarshal(data, &roundTrip)
+	fmt.Printf("Round trip: %+v\n", roundTrip)
+	err = json.Unmarshal([]byte(`{"owner": "Eve", "status": "deleted"}`), &Account{})
+	fmt.Printf("Invalid status: %v\n", err)
+
+	fmt.Println("\n=== Decoding into map[string]any ===")
+	var generic map[string]any
+	json.Unmarshal([]byte(`{"name": "Dave", "age": 41, "langs": ["go", "sql"], "manager": null}`), &generic)
+	for _, key := range []string{"name", "age", "langs", "manager"} {
+		// JSON numbers become float64, arrays []any, objects map[string]any
+		fmt.Printf("%-8s %-12s (%T)\n", key, fmt.Sprint(generic[key]), generic[key])
+	}
+	if langs, ok := generic["langs"].([]any); ok {
+		fmt.Printf("First language: %v\n", langs[0])
+	}
+
+	fmt.Println("\n=== Streaming with Decoder and Encoder ===")
+	// A Decoder reads a stream of JSON values one at a time, e.g. newline-delimited JSON logs
+	stream := strings.NewReader(`{"level": "info", "msg": "started"}
+{"level": "warn", "msg": "disk almost full"}
+{"level": "info", "msg": "request served"}`)
+	decoder := json.NewDecoder(stream)
+	encoder := json.NewEncoder(os.Stdout)
+	for {
+		var entry struct {
+			Level string `json:"level"`
+			Msg   string `json:"msg"`
+		}
+		err := decoder.Decode(&entry)
+		if err == io.EOF {
+			break
+		}
+		if err != nil {
+			fmt.Println("Error:", err)
+			break
+		}
+		if entry.Level == "warn" {
+			encoder.Encode(entry) // Encode writes one JSON value followed by a newline
+		}
+	}
+
+	// DisallowUnknownFields turns typos in config files into errors
+	strict := json.NewDecoder(strings.NewReader(`{"city": "Krabi", "contry": "TH"}`))
+	strict.DisallowUnknownFields()
+	fmt.Printf("Strict decode: %v\n", strict.Decode(&Address{}))
+
+	fmt.Println("\n=== Malformed Input ===")
+	for _, bad := range []string{
+		`{"id": 1, "name": "Frank",}`,
+		`{"id": "one"}`,
+		`{"id": 1, "name": "Gina"`,
+		`{"joined": "yesterday"}`,
+	} {
+		var u User
+		err := json.Unmarshal([]byte(bad), &u)
+		fmt.Printf("%-30s -> %s\n", bad, describeJSONError(err))
+	}
+	// json.Valid checks syntax without decoding
+	fmt.Printf("Valid(`[1, 2]`): %v, Valid(`[1, 2`): %v\n", json.Valid([]byte(`[1, 2]`)), json.Valid([]byte(`[1, 2`)))
+}
@@ -0,0 +1,167 @@
+// This file demonstrates maps in Go
+// A map is an unordered collection of key-value pairs with O(1) average
+// lookup, insert and delete. Keys must be comparable (usable with ==)
+
+package main
+
+import (
+	"fmt"
+	"sort"
+	"strings"
+)
+
+// Employee is used to show maps with struct values
+type Employee struct {
+	Name   string
+	Salary int
+}
+
+// wordFrequency counts how often each word appears, ignoring case and punctuation
+func wordFrequency(text string) map[string]int {
+	counts := make(map[string]int)
+	words := strings.FieldsFunc(strings.ToLower(text), func(r rune) bool {
+		return !('a' <= r && r <= 'z') && r != '\''
+	})
+	for _, word := range words {
+		counts[word]++ // a missing key reads as 0, so no initialization is needed
+	}
+	return counts
+}
+
+// topWords returns the n most frequent words, ties broken alphabetically
+// Maps have no order, so the keys are copied into a slice and sorted
+func topWords(counts map[string]int, n int) []string {
+	words := make([]string, 0, len(counts))
+	for word := range counts {
+		words = append(words, word)
+	}
+	sort.Slice(words, func(i, j int) bool {
+		if counts[words[i]] != counts[words[j]] {
+			return counts[words[i]] > counts[words[j]]
+		}
+		return words[i] < words[j]
+	})
+	if len(words) > n {
+		words = words[:n]
+	}
+	return words
+}
+
+func main() {
+	// ==================== Creating Maps ====================
+	fmt.Println("=== Creating Maps ===")
+	// Map literal
+	ages := map[string]int{
+		"Alice": 30,
+		"Bob":   25,
+	}
+	// make creates an empty map (optionally with a size hint)
+	scores := make(map[string]float64, 10)
+	scores["math"] = 90.5
+
+	// A nil map can be read but not written: writing panics
+	var nilMap map[string]int
+	fmt.Printf("ages: %v, len: %d\n", ages, len(ages))
+	fmt.Printf("scores: %v\n", scores)
+	fmt.Printf("nilMap == nil: %v, nilMap[\"x\"] = %d\n", nilMap == nil, nilMap["x"])
+
+	// ==================== Lookup ====================
+	fmt.Println("\n=== Lookup with the comma-ok idiom ===")
+	// A missing key returns the zero value, which can't be told apart from a stored zero
+	fmt.Printf("ages[\"Carol\"] = %d\n", ages["Carol"])
+	ages["Baby"] = 0
+	if age, ok := ages["Baby"]; ok {
+		fmt.Printf("Baby is in the map with age %d\n", age)
+	}
+	if _, ok := ages["Carol"]; !ok {
+		fmt.Println("Carol is not in the map")
+	}
+
+	// ==================== Updating and Deleting ====================
+	fmt.Println("\n=== Updating and Deleting ===")
+	ages["Alice"]++ // update in place
+	delete(ages, "Baby")
+	delete(ages, "Nobody") // deleting a missing key is a no-op
+	fmt.Printf("After update and delete: %v\n", ages)
+	clear(scores) // Go 1.21+: remove every entry
+	fmt.Printf("After clear: %v\n", scores)
+
+	// ==================== Iteration Order ====================
+	fmt.Println("\n=== Iteration Order ===")
+	// Iteration order is unspecified and deliberately randomized between runs,
+	// so never rely on it. Sort the keys when the order matters.
+	colors := map[string]string{"red": "#f00", "green": "#0f0", "blue": "#00f", "black": "#000"}
+	keys := make([]string, 0, len(colors))
+	for k := range colors {
+		keys = append(keys, k)
+	}
+	sort.Strings(keys)
+	for _, k := range keys {
+		fmt.Printf("%s=%s ", k, colors[k])
+	}
+	fmt.Println()
+	// fmt prints maps with sorted keys, which is convenient for debugging
+	fmt.Println(colors)
+
+	// ==================== Maps of Structs and Slices ====================
+	fmt.Println("\n=== Maps of Structs and Slices ===")
+	staff := map[string]Employee{
+		"e1": {Name: "Alice", Salary: 5000},
+	}
+	// staff["e1"].Salary += 100 does not compile: map values are not addressable
+	// Copy the value out, change it and store it back
+	e := staff["e1"]
+	e.Salary += 100
+	staff["e1"] = e
+	fmt.Printf("Struct value: %+v\n", staff["e1"])
+
+	// With pointer values the struct can be changed in place
+	staffPtr := map[string]*Employee{"e2": {Name: "Bob", Salary: 4000}}
+	staffPtr["e2"].Salary += 100
+	fmt.Printf("Pointer value: %+v\n", *staffPtr["e2"])
+
+	// Grouping with a map of slices: appending to a nil slice just works
+	teams := make(map[string][]string)
+	for _, m := range []struct{ name, team string }{
+		{"Alice", "backend"}, {"Bob", "frontend"}, {"Carol", "backend"},
+	} {
+		teams[m.team] = append(teams[m.team], m.name)
+	}
+	fmt.Printf("Teams: %v\n", teams)
+
+	// Nested maps need the inner map created before use
+	nested := make(map[string]map[string]int)
+	if nested["2024"] == nil {
+		nested["2024"] = make(map[string]int)
+	}
+	nested["2024"]["jan"] = 10
+	fmt.Printf("Nested: %v\n", nested)
+
+	// ==================== Maps as Sets ====================
+	fmt.Println("\n=== Maps as Sets ===")
+	// map[T]struct{} uses no memory for values; map[T]bool reads more naturally
+	visited := make(map[string]struct{})
+	for _, page := range []string{"/home", "/about", "/home", "/contact", "/about"} {
+		visited[page] = struct{}{}
+	}
+	_, seenHome := visited["/home"]
+	fmt.Printf("Unique pages: %d, visited /home: %v\n", len(visited), seenHome)
+
+	allowed := map[string]bool{"GET": true, "HEAD": true}
+	for _, method := range []string{"GET", "POST"} {
+		// A missing key gives false, so no comma-ok is needed
+		fmt.Printf("%s allowed: %v\n", method, allowed[method])
+	}
+
+	// ==================== Exercise: Word Frequency ====================
+	fmt.Println("\n=== Word Frequency ===")
+	text := `Go is expressive, concise, clean, and efficient. Its concurrency
+	mechanisms make it easy to write programs that get the most out of multicore
+	and networked machines. Go compiles quickly to machine code, yet it has the
+	convenience of garbage collection. Go is a fast, statically typed language.`
+	counts := wordFrequency(text)
+	fmt.Printf("Distinct words: %d\n", len(counts))
+	for i, word := range topWords(counts, 5) {
+		fmt.Printf("%d. %-6s %d\n", i+1, word, counts[word])
+	}
+}
@@ -0,0 +1,10 @@
+module example.com/app
+
+go 1.22
+
+require example.com/mathutil v0.0.0
+
+// example.com/mathutil is not published anywhere: replace points the
+// requirement at the copy next to this module instead of downloading it.
+// Delete this line once the module is published and tagged.
+replace example.com/mathutil => ../mathutil
@@ -0,0 +1,13 @@
+// Package report formats results for the app command
+// Being under app/internal, it is private to the example.com/app module
+package report
+
+import (
+	"fmt"
+	"io"
+)
+
+// Line writes one aligned "label: value" line
+func Line(w io.Writer, label string, value any) {
+	fmt.Fprintf(w, "%-24s %v\n", label+":", value)
+}
@@ -0,0 +1,75 @@
+// This program demonstrates Go modules and multi-package projects
+// It is a module of its own (example.com/app) that imports a second module
+// (example.com/mathutil) from the neighbouring directory
+//
+// Layout:
+//
+//	modules/
+//	├── app/                      module example.com/app
+//	│   ├── go.mod                require + replace => ../mathutil
+//	│   ├── main.go               package main (this file)
+//	│   └── internal/report/      importable only inside example.com/app
+//	└── mathutil/                 module example.com/mathutil
+//	    ├── go.mod
+//	    ├── mathutil.go           exported and unexported identifiers
+//	    └── internal/memo/        importable only inside example.com/mathutil
+//
+// Run it from this directory:
+//
+//	cd 01-basics/modules/app && go run .
+//
+// Things to try:
+//   - Call mathutil.isPrimeSlow(7): "undefined: mathutil.isPrimeSlow"
+//     (lower-case identifiers are not exported)
+//   - Import "example.com/mathutil/internal/memo" here: "use of internal
+//     package ... not allowed"
+//   - Remove the replace line from go.mod: go tries to download
+//     example.com/mathutil and fails, because it was never published
+//   - Instead of replace, a go.work file can tie local modules together:
+//     cd .. && go work init ./app ./mathutil
+package main
+
+import (
+	"fmt"
+	"os"
+
+	"example.com/app/internal/report"
+	"example.com/mathutil"
+)
+
+func main() {
+	fmt.Println("=== Using an Imported Package ===")
+	// Exported identifiers are accessed through the package name
+	report.Line(os.Stdout, "mathutil version", mathutil.Version)
+	report.Line(os.Stdout, "Clamp(15, 0, 10)", mathutil.Clamp(15, 0, 10))
+	report.Line(os.Stdout, "GCD(84, 36)", mathutil.GCD(84, 36))
+
+	mean, err := mathutil.Mean([]float64{3, 4, 8})
+	report.Line(os.Stdout, "Mean(3, 4, 8)", fmt.Sprintf("%.2f (err: %v)", mean, err))
+	_, err = mathutil.Mean(nil)
+	report.Line(os.Stdout, "Mean(nil)", err)
+
+	fmt.Println("\n=== Hidden Implementation Details ===")
+	primes := []int{}
+	for n := 1; n <= 30; n++ {
+		if mathutil.IsPrime(n) {
+			primes = append(primes, n)
+		}
+	}
+	mathutil.IsPrime(29) // Cached by the unexported memo cache
+	report.Line(os.Stdout, "Primes up to 30", primes)
+	hits, misses := mathutil.CacheStats()
+	report.Line(os.Stdout, "Cache hits / misses", fmt.Sprintf("%d / %d", hits, misses))
+
+	fmt.Println("\n=== Module Commands ===")
+	for _, cmd := range []struct{ command, purpose string }{
+		{"go mod init example.com/app", "create go.mod for a new module"},
+		{"go mod tidy", "add missing and remove unused requirements"},
+		{"go get example.com/lib@v1.2.3", "add or upgrade a dependency"},
+		{"go mod edit -replace=M=../path", "use a local copy of module M"},
+		{"go list -m all", "list the modules in the build"},
+		{"go work init ./app ./mathutil", "develop several modules together"},
+	} {
+		fmt.Printf("%-32s %s\n", cmd.command, cmd.purpose)
+	}
+}
@@ -0,0 +1,44 @@
+// Package memo caches the results of a function by key
+//
+// It lives under internal/, so the go tool only allows packages rooted at
+// the parent of internal/ (example.com/mathutil/...) to import it. Code in
+// other modules, such as example.com/app, gets a compile error:
+//
+//	use of internal package example.com/mathutil/internal/memo not allowed
+package memo
+
+import "sync"
+
+// Cache is a concurrency-safe memoization table
+type Cache[K comparable, V any] struct {
+	mu     sync.Mutex
+	values map[K]V
+	hits   int
+	misses int
+}
+
+// New creates an empty cache
+func New[K comparable, V any]() *Cache[K, V] {
+	return &Cache[K, V]{values: make(map[K]V)}
+}
+
+// Get returns the cached value for key, computing it with fn on a miss
+func (c *Cache[K, V]) Get(key K, fn func(K) V) V {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	if v, ok := c.values[key]; ok {
+		c.hits++
+		return v
+	}
+	c.misses++
+	v := fn(key)
+	c.values[key] = v
+	return v
+}
+
+// Stats returns the number of hits and misses so far
+func (c *Cache[K, V]) Stats() (hits, misses int) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	return c.hits, c.misses
+}
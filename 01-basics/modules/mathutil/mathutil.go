@@ -0,0 +1,75 @@
+// Package mathutil is a small utility package used by the modules walkthrough
+// in ../app. It lives in its own module (see go.mod in this directory).
+//
+// Identifiers starting with an upper-case letter (Clamp, Mean, IsPrime) are
+// exported and can be used by importers as mathutil.Clamp. Lower-case ones
+// (isPrimeSlow, cache) are unexported and only visible inside this package.
+package mathutil
+
+import (
+	"errors"
+
+	"example.com/mathutil/internal/memo"
+)
+
+// ErrEmpty is returned by Mean for an empty slice
+var ErrEmpty = errors.New("mathutil: empty input")
+
+// Version is an exported package-level variable
+const Version = "v0.1.0"
+
+// cache remembers primality results; it is unexported, so importers can't
+// reach it and can't depend on how IsPrime is implemented
+var cache = memo.New[int, bool]()
+
+// Clamp limits v to the range [low, high]
+func Clamp(v, low, high int) int {
+	return max(low, min(v, high))
+}
+
+// Mean returns the average of nums
+func Mean(nums []float64) (float64, error) {
+	if len(nums) == 0 {
+		return 0, ErrEmpty
+	}
+	sum := 0.0
+	for _, n := range nums {
+		sum += n
+	}
+	return sum / float64(len(nums)), nil
+}
+
+// GCD returns the greatest common divisor of a and b
+func GCD(a, b int) int {
+	for b != 0 {
+		a, b = b, a%b
+	}
+	if a < 0 {
+		return -a
+	}
+	return a
+}
+
+// IsPrime reports whether n is prime, caching results
+func IsPrime(n int) bool {
+	return cache.Get(n, isPrimeSlow)
+}
+
+// CacheStats reports cache hits and misses
+// Exporting a function lets importers observe the cache without touching it
+func CacheStats() (hits, misses int) {
+	return cache.Stats()
+}
+
+// isPrimeSlow is the uncached trial division check
+func isPrimeSlow(n int) bool {
+	if n < 2 {
+		return false
+	}
+	for d := 2; d*d <= n; d++ {
+		if n%d == 0 {
+			return false
+		}
+	}
+	return true
+}
@@ -49,4 +49,5 @@ func main() {
 	fmt.Println("2. Create .go files with 'package packagename'")
 	fmt.Println("3. Initialize module: 'go mod init module-name'")
 	fmt.Println("4. Import your package: 'import \"module-name/packagename\"'")
+	fmt.Println("A runnable example lives in modules/: cd modules/app && go run .")
 }
@@ -0,0 +1,173 @@
+// This file demonstrates pointers and memory in Go
+// A pointer holds the address of a value. Go has pointers but no pointer
+// arithmetic, and the garbage collector frees memory that is no longer reachable
+//
+// Topics:
+// - & (address-of) and * (dereference)
+// - Value vs pointer semantics for function parameters and method receivers
+// - Why slices and maps behave "by reference" even when passed by value
+// - new(T) vs &T{}
+// - Escape analysis: stack vs heap allocation
+//
+// To see the compiler's escape analysis decisions for this file, run:
+//   go build -gcflags=-m pointers.go
+// Lines to look for:
+//   ./pointers.go:<line>: &Point{...} escapes to heap   (newPointOnHeap)
+//   ./pointers.go:<line>: moved to heap: c              (newCounter)
+//   ./pointers.go:<line>: moved to heap: x              (main: its address is printed with %p)
+// sumPoint's Point appears in none of them: it stays on the stack
+// Values passed to fmt.Printf also escape, because they are stored in interfaces
+// Use -gcflags='-m -m' for the reasons behind each decision
+
+package main
+
+import "fmt"
+
+type Point struct {
+	X, Y int
+}
+
+// ==================== Value vs Pointer Parameters ====================
+
+// moveByValue receives a copy: changes are lost when it returns
+func moveByValue(p Point) {
+	p.X += 10
+}
+
+// moveByPointer receives the address: changes are visible to the caller
+func moveByPointer(p *Point) {
+	p.X += 10 // shorthand for (*p).X; Go dereferences struct pointers automatically
+}
+
+// Methods follow the same rule
+// Value receivers work on a copy; pointer receivers can modify the original
+func (p Point) Scaled(factor int) Point {
+	p.X *= factor
+	p.Y *= factor
+	return p
+}
+
+func (p *Point) Scale(factor int) {
+	p.X *= factor
+	p.Y *= factor
+}
+
+// ==================== Slices and Maps ====================
+
+// modifySlice changes an element: the slice header is copied, but it still
+// points at the same backing array
+func modifySlice(s []int) {
+	s[0] = 100
+}
+
+// appendToSlice appends to its own copy of the header
+// The caller's slice keeps its old length, so the new element is not visible
+func appendToSlice(s []int) {
+	s = append(s, 4)
+	_ = s
+}
+
+// appendToSlicePtr shows one fix: pass a pointer to the slice
+// (returning the new slice, like append does, is more idiomatic)
+func appendToSlicePtr(s *[]int) {
+	*s = append(*s, 4)
+}
+
+// modifyMap changes the map: a map value is a pointer to the runtime's map structure
+func modifyMap(m map[string]int) {
+	m["added"] = 1
+}
+
+// ==================== Escape Analysis ====================
+
+// sumPoint keeps p on the stack: nothing refers to it after return
+func sumPoint(x, y int) int {
+	p := Point{x, y}
+	return p.X + p.Y
+}
+
+// newPointOnHeap returns a pointer to a local value
+// This is safe in Go (unlike C): the compiler notices the value "escapes"
+// and allocates it on the heap instead of the stack
+func newPointOnHeap(x, y int) *Point {
+	return &Point{x, y}
+}
+
+// newCounter's closure captures c, so c must outlive the call and moves to the heap
+func newCounter() func() int {
+	c := 0
+	return func() int {
+		c++
+		return c
+	}
+}
+
+func main() {
+	fmt.Println("=== Address-of and Dereference ===")
+	x := 42
+	p := &x // p has type *int
+	fmt.Printf("x = %d, p = %p, *p = %d\n", x, p, *p)
+	*p = 100 // writing through the pointer changes x
+	fmt.Printf("After *p = 100: x = %d\n", x)
+
+	// The zero value of a pointer is nil; dereferencing nil panics
+	var nilPtr *int
+	fmt.Printf("nilPtr == nil: %v\n", nilPtr == nil)
+
+	// Pointers to pointers are possible but rarely needed
+	pp := &p
+	**pp = 7
+	fmt.Printf("After **pp = 7: x = %d\n", x)
+
+	fmt.Println("\n=== Value vs Pointer Parameters ===")
+	pt := Point{1, 2}
+	moveByValue(pt)
+	fmt.Printf("After moveByValue:   %+v\n", pt)
+	moveByPointer(&pt)
+	fmt.Printf("After moveByPointer: %+v\n", pt)
+
+	scaled := pt.Scaled(2) // pt is unchanged
+	fmt.Printf("Scaled copy: %+v, original: %+v\n", scaled, pt)
+	pt.Scale(3) // Go takes &pt automatically for pointer receivers
+	fmt.Printf("After Scale(3): %+v\n", pt)
+
+	// Assigning a struct copies it; assigning a pointer shares it
+	copyOfPt := pt
+	alias := &pt
+	copyOfPt.Y = -1
+	alias.Y = 99
+	fmt.Printf("pt: %+v, copy: %+v\n", pt, copyOfPt)
+
+	fmt.Println("\n=== Slices and Maps ===")
+	nums := []int{1, 2, 3}
+	modifySlice(nums)
+	fmt.Printf("After modifySlice:    %v\n", nums)
+	appendToSlice(nums)
+	fmt.Printf("After appendToSlice:  %v (append not visible)\n", nums)
+	appendToSlicePtr(&nums)
+	fmt.Printf("After appendToSlicePtr: %v\n", nums)
+
+	counts := map[string]int{"start": 0}
+	modifyMap(counts)
+	fmt.Printf("After modifyMap: %v\n", counts)
+
+	fmt.Println("\n=== new(T) vs &T{} ===")
+	// new(T) allocates a zeroed T and returns *T
+	n := new(int)
+	*n = 5
+	a := new(Point)
+	// &T{} does the same for composite types, and allows setting fields
+	b := &Point{X: 1, Y: 2}
+	fmt.Printf("new(int): %d, new(Point): %+v, &Point{...}: %+v\n", *n, *a, *b)
+	// Both may live on the stack or the heap: that is decided by escape analysis,
+	// not by which syntax was used
+
+	fmt.Println("\n=== Escape Analysis ===")
+	fmt.Printf("sumPoint (stack): %d\n", sumPoint(3, 4))
+	hp := newPointOnHeap(5, 6)
+	fmt.Printf("newPointOnHeap (heap): %+v\n", *hp)
+	next := newCounter()
+	next()
+	fmt.Printf("newCounter (captured variable on heap): %d\n", next())
+	fmt.Println("Run `go build -gcflags=-m pointers.go` to see these decisions")
+}
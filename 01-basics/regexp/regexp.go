@@ -0,0 +1,190 @@
+// This file demonstrates regular expressions in Go
+// The regexp package uses RE2 syntax: matching runs in time linear in the
+// input, so there are no backreferences or lookarounds, but also no
+// catastrophic backtracking on malicious input
+//
+// Topics:
+// - regexp.Compile vs regexp.MustCompile
+// - Finding matches, capture groups and named captures
+// - ReplaceAllString, ReplaceAllStringFunc and template expansion
+// - Validation examples: emails and Thai phone numbers
+// - Performance: compile once, and when not to use a regexp at all
+
+package main
+
+import (
+	"fmt"
+	"regexp"
+	"strings"
+	"testing"
+)
+
+// Compile patterns once, at package level
+// MustCompile panics on an invalid pattern, which is fine for constant
+// patterns: the bug shows up as soon as the program starts
+// Raw string literals (backquotes) avoid double-escaping backslashes
+var (
+	// A deliberately simple email check; the full RFC 5322 grammar is not
+	// practical as a regexp. Send a confirmation mail to really validate one
+	emailRe = regexp.MustCompile(`^[a-zA-Z0-9._%+-]+@[a-zA-Z0-9.-]+\.[a-zA-Z]{2,}$`)
+
+	// Thai phone numbers, with optional dashes or spaces:
+	// - Mobile: 10 digits starting with 06, 08 or 09, e.g. 081-234-5678
+	// - Bangkok landline: 02 plus 7 digits, e.g. 02-123-4567
+	// - Other landlines: 03x-07x plus 6 digits, e.g. 053-123-456
+	// - International format replaces the leading 0 with +66
+	thaiMobileRe   = regexp.MustCompile(`^(?:0|\+66[- ]?)([689]\d)[- ]?(\d{3})[- ]?(\d{4})$`)
+	thaiLandlineRe = regexp.MustCompile(`^(?:0|\+66[- ]?)(2|[3-7]\d)[- ]?(\d{3})[- ]?(\d{3,4})$`)
+
+	// Named groups: (?P<name>...) or (?<name>...) since Go 1.22
+	logLineRe = regexp.MustCompile(`^(?P<date>\d{4}-\d{2}-\d{2}) (?P<level>[A-Z]+) (?P<message>.*)$`)
+
+	priceRe = regexp.MustCompile(`\$(\d+(?:\.\d{2})?)`)
+	wordRe  = regexp.MustCompile(`\b\w+\b`)
+)
+
+// IsValidEmail reports whether s looks like an email address
+func IsValidEmail(s string) bool {
+	return emailRe.MatchString(s)
+}
+
+// NormalizeThaiPhone returns the number in local form (0XX-XXX-XXXX) and
+// whether it is a valid mobile or landline number
+func NormalizeThaiPhone(s string) (string, bool) {
+	s = strings.TrimSpace(s)
+	if m := thaiMobileRe.FindStringSubmatch(s); m != nil {
+		return fmt.Sprintf("0%s-%s-%s", m[1], m[2], m[3]), true
+	}
+	if m := thaiLandlineRe.FindStringSubmatch(s); m != nil {
+		// A landline has 9 digits in total including the leading 0
+		if len("0"+m[1]+m[2]+m[3]) == 9 {
+			return fmt.Sprintf("0%s-%s-%s", m[1], m[2], m[3]), true
+		}
+	}
+	return "", false
+}
+
+// parseLogLine returns the named groups of a log line as a map
+func parseLogLine(line string) map[string]string {
+	match := logLineRe.FindStringSubmatch(line)
+	if match == nil {
+		return nil
+	}
+	fields := make(map[string]string)
+	for i, name := range logLineRe.SubexpNames() {
+		if name != "" {
+			fields[name] = match[i]
+		}
+	}
+	return fields
+}
+
+func main() {
+	// ==================== Compile vs MustCompile ====================
+	fmt.Println("=== Compile vs MustCompile ===")
+	// Compile returns an error; use it for patterns that come from users
+	for _, pattern := range []string{`go+gle`, `(unclosed`, `a{2,1}`, `(?=lookahead)`} {
+		re, err := regexp.Compile(pattern)
+		if err != nil {
+			fmt.Printf("%-16q error: %v\n", pattern, err)
+			continue
+		}
+		fmt.Printf("%-16q ok, matches %q? %v\n", pattern, "gooogle", re.MatchString("gooogle"))
+	}
+	// QuoteMeta escapes user input so it matches literally
+	fmt.Printf("QuoteMeta(%q) = %q\n", "1+1=2?", regexp.QuoteMeta("1+1=2?"))
+
+	// ==================== Finding Matches ====================
+	fmt.Println("\n=== Finding Matches ===")
+	text := "Coffee $3.50, cake $4, tip $1.25"
+	fmt.Printf("FindString: %q\n", priceRe.FindString(text))
+	fmt.Printf("FindAllString: %q\n", priceRe.FindAllString(text, -1))
+	fmt.Printf("FindAllString (n=2): %q\n", priceRe.FindAllString(text, 2))
+	fmt.Printf("FindStringIndex: %v\n", priceRe.FindStringIndex(text))
+
+	// ==================== Capture Groups ====================
+	fmt.Println("\n=== Capture Groups ===")
+	// Index 0 is the whole match, 1..n are the groups
+	for _, m := range priceRe.FindAllStringSubmatch(text, -1) {
+		fmt.Printf("match %-7q amount %q\n", m[0], m[1])
+	}
+	// (?:...) groups without capturing; (?i) makes the match case-insensitive
+	caseRe := regexp.MustCompile(`(?i)^go(?:lang)?$`)
+	for _, s := range []string{"Go", "GOLANG", "gopher"} {
+		fmt.Printf("%q matches (?i)^go(?:lang)?$: %v\n", s, caseRe.MatchString(s))
+	}
+
+	// ==================== Named Captures ====================
+	fmt.Println("\n=== Named Captures ===")
+	fields := parseLogLine("2024-05-01 ERROR payment failed: card declined")
+	fmt.Printf("date=%s level=%s message=%q\n", fields["date"], fields["level"], fields["message"])
+	fmt.Printf("SubexpIndex(\"level\") = %d\n", logLineRe.SubexpIndex("level"))
+	fmt.Printf("Unparseable line: %v\n", parseLogLine("not a log line"))
+
+	// ==================== Replacing ====================
+	fmt.Println("\n=== Replacing ===")
+	// $1 or ${name} in the replacement refers to groups; use ${1} before letters
+	dateRe := regexp.MustCompile(`(?P<y>\d{4})-(?P<m>\d{2})-(?P<d>\d{2})`)
+	fmt.Println(dateRe.ReplaceAllString("Due 2024-12-25, paid 2025-01-03", "${d}/${m}/${y}"))
+	// ReplaceAllLiteralString does not expand $
+	fmt.Println(priceRe.ReplaceAllLiteralString(text, "$$$"))
+
+	// ReplaceAllStringFunc computes each replacement in code
+	fmt.Println(wordRe.ReplaceAllStringFunc("the quick brown fox", func(w string) string {
+		return strings.ToUpper(w[:1]) + w[1:]
+	}))
+	// ReplaceAllFunc is the []byte version, handy with data read from files
+	masked := priceRe.ReplaceAllFunc([]byte(text), func(b []byte) []byte {
+		return []byte(strings.Repeat("*", len(b)))
+	})
+	fmt.Println(string(masked))
+
+	// Split on a pattern
+	fmt.Printf("Split: %q\n", regexp.MustCompile(`\s*[,;]\s*`).Split("a , b;c ;  d", -1))
+
+	// ==================== Validation ====================
+	fmt.Println("\n=== Email Validation ===")
+	for _, email := range []string{"somchai@example.co.th", "first.last+tag@mail.com", "no-at-sign.com", "a@b", "spaces in@x.com"} {
+		fmt.Printf("%-26q valid: %v\n", email, IsValidEmail(email))
+	}
+
+	fmt.Println("\n=== Thai Phone Numbers ===")
+	for _, phone := range []string{"081-234-5678", "0812345678", "+66 81 234 5678", "+66812345678", "02-123-4567", "053 123 456", "0112345678", "081-234-567"} {
+		normalized, ok := NormalizeThaiPhone(phone)
+		fmt.Printf("%-18q valid: %-5v normalized: %q\n", phone, ok, normalized)
+	}
+
+	// ==================== Performance ====================
+	fmt.Println("\n=== Performance ===")
+	// Compiling is far more expensive than matching, so never compile inside
+	// a loop or a hot function. A compiled *Regexp is safe for concurrent use
+	inputs := []string{"081-234-5678", "hello", "02-123-4567", "user@example.com"}
+	compileEachTime := testing.Benchmark(func(b *testing.B) {
+		for i := 0; i < b.N; i++ {
+			re := regexp.MustCompile(`^0[689]\d-\d{3}-\d{4}$`)
+			re.MatchString(inputs[i%len(inputs)])
+		}
+	})
+	precompiled := regexp.MustCompile(`^0[689]\d-\d{3}-\d{4}$`)
+	compileOnce := testing.Benchmark(func(b *testing.B) {
+		for i := 0; i < b.N; i++ {
+			precompiled.MatchString(inputs[i%len(inputs)])
+		}
+	})
+	// For fixed substrings the strings package is simpler and faster still
+	containsRe := regexp.MustCompile(`example`)
+	regexContains := testing.Benchmark(func(b *testing.B) {
+		for i := 0; i < b.N; i++ {
+			containsRe.MatchString(inputs[i%len(inputs)])
+		}
+	})
+	stringsContains := testing.Benchmark(func(b *testing.B) {
+		for i := 0; i < b.N; i++ {
+			strings.Contains(inputs[i%len(inputs)], "example")
+		}
+	})
+	fmt.Printf("Compile every call: %8d ns/op\n", compileEachTime.NsPerOp())
+	fmt.Printf("Compile once:       %8d ns/op\n", compileOnce.NsPerOp())
+	fmt.Printf("regexp `example`:   %8d ns/op\n", regexContains.NsPerOp())
+	fmt.Printf("strings.Contains:   %8d ns/op\n", stringsContains.NsPerOp())
+}
@@ -0,0 +1,198 @@
+// This file demonstrates how slices work internally and how to use them efficiently
+// arrays-slices.go introduces slices; this file looks under the hood
+//
+// A slice is a small header of three words: a pointer to a backing array,
+// a length and a capacity. Copying a slice copies the header, not the data,
+// so two slices can share (alias) the same backing array
+//
+// Topics:
+// - Aliasing bugs caused by shared backing arrays
+// - How append grows capacity, and the full slice expression s[low:high:max]
+// - copy semantics and cloning
+// - Pre-allocating with make
+// - The slices package (Go 1.21+)
+// - A benchmark of append with and without pre-allocated capacity
+
+package main
+
+import (
+	"fmt"
+	"slices"
+	"strings"
+	"testing"
+)
+
+// appendTo appends to a slice it received by value
+// The caller's slice header is unchanged, but if there was spare capacity
+// the new element was written into the caller's backing array
+func appendTo(s []int, v int) []int {
+	return append(s, v)
+}
+
+// filterInPlace keeps the even numbers, reusing the input's backing array
+// This avoids an allocation but overwrites the caller's data
+func filterInPlace(nums []int) []int {
+	kept := nums[:0] // Same backing array, length 0
+	for _, n := range nums {
+		if n%2 == 0 {
+			kept = append(kept, n)
+		}
+	}
+	return kept
+}
+
+// buildWithoutCapacity appends to an empty slice, growing it repeatedly
+func buildWithoutCapacity(n int) []int {
+	var s []int
+	for i := 0; i < n; i++ {
+		s = append(s, i)
+	}
+	return s
+}
+
+// buildWithCapacity reserves room for every element up front
+func buildWithCapacity(n int) []int {
+	s := make([]int, 0, n)
+	for i := 0; i < n; i++ {
+		s = append(s, i)
+	}
+	return s
+}
+
+func main() {
+	// ==================== Aliasing ====================
+	fmt.Println("=== Aliasing: Slices Share Backing Arrays ===")
+	original := []int{1, 2, 3, 4, 5}
+	window := original[1:3] // [2 3], shares memory with original
+	window[0] = 99
+	fmt.Printf("original after writing window[0]: %v\n", original)
+
+	// Bug 1: append writes into the shared spare capacity
+	base := make([]int, 3, 10)
+	a := appendTo(base, 1)
+	b := appendTo(base, 2) // Overwrites the element a just appended
+	fmt.Printf("a = %v, b = %v (a changed!)\n", a, b)
+
+	// Bug 2: in-place filtering clobbers the input
+	data := []int{1, 2, 3, 4, 5, 6}
+	evens := filterInPlace(data)
+	fmt.Printf("evens = %v, data is now %v\n", evens, data)
+
+	// Bug 3: a small sub-slice keeps a huge backing array alive
+	big := make([]byte, 10<<20)
+	header := big[:16]
+	fmt.Printf("16-byte header keeps cap %d bytes reachable; clone it: cap %d\n",
+		cap(header), cap(slices.Clone(header)))
+
+	// ==================== append Growth ====================
+	fmt.Println("\n=== How append Grows Capacity ===")
+	var grow []int
+	lastCap := -1
+	for i := 0; i < 2000; i++ {
+		grow = append(grow, i)
+		if cap(grow) != lastCap {
+			fmt.Printf("len %4d -> cap %4d\n", len(grow), cap(grow))
+			lastCap = cap(grow)
+		}
+	}
+	// Small slices double; from 256 elements growth slows towards 1.25x,
+	// then rounds up to allocator size classes. Every growth copies everything
+
+	// ==================== Full Slice Expression ====================
+	fmt.Println("\n=== Full Slice Expression s[low:high:max] ===")
+	// Limiting capacity to the length forces append to copy instead of
+	// writing into memory the caller still uses
+	base = make([]int, 3, 10)
+	safe := base[:3:3] // len 3, cap 3
+	a = append(safe, 1)
+	b = append(safe, 2)
+	fmt.Printf("a = %v, b = %v (independent)\n", a, b)
+	fmt.Printf("base[1:2:4]: len %d, cap %d\n", len(base[1:2:4]), cap(base[1:2:4]))
+
+	// ==================== copy ====================
+	fmt.Println("\n=== copy Semantics ===")
+	src := []int{1, 2, 3, 4, 5}
+	dst := make([]int, 3)
+	n := copy(dst, src) // Copies min(len(dst), len(src)) elements
+	fmt.Printf("copied %d: dst = %v\n", n, dst)
+	var empty []int
+	fmt.Printf("copy into a nil slice copies %d elements\n", copy(empty, src))
+	// copy handles overlapping ranges correctly, e.g. shifting left
+	shift := []int{1, 2, 3, 4, 5}
+	copy(shift, shift[1:])
+	fmt.Printf("shifted left: %v\n", shift[:len(shift)-1])
+	// Three ways to make an independent copy
+	c1 := make([]int, len(src))
+	copy(c1, src)
+	c2 := append([]int(nil), src...)
+	c3 := slices.Clone(src)
+	src[0] = 100
+	fmt.Printf("copies unaffected by src[0] = 100: %v %v %v\n", c1, c2, c3)
+
+	// nil vs empty: both have length 0 and work with append and range
+	var nilSlice []int
+	emptySlice := []int{}
+	fmt.Printf("nil slice == nil: %v, empty slice == nil: %v\n", nilSlice == nil, emptySlice == nil)
+
+	// ==================== make and Pre-allocation ====================
+	fmt.Println("\n=== Pre-allocating with make ===")
+	// make([]T, n) creates n zero values; make([]T, 0, n) reserves room only
+	// Mixing them up is a common bug: append then adds after the zeros
+	wrong := make([]string, 3)
+	wrong = append(wrong, "a", "b", "c")
+	right := make([]string, 0, 3)
+	right = append(right, "a", "b", "c")
+	fmt.Printf("make(len 3)+append: %q\nmake(cap 3)+append: %q\n", wrong, right)
+
+	// ==================== slices Package ====================
+	fmt.Println("\n=== The slices Package ===")
+	nums := []int{5, 2, 8, 2, 9, 1}
+	sorted := slices.Clone(nums)
+	slices.Sort(sorted)
+	fmt.Printf("Sort: %v (input untouched: %v)\n", sorted, nums)
+	idx, found := slices.BinarySearch(sorted, 8)
+	fmt.Printf("BinarySearch(8): index %d, found %v\n", idx, found)
+	fmt.Printf("Contains(9): %v, Index(2): %d, Max: %d, Min: %d\n",
+		slices.Contains(nums, 9), slices.Index(nums, 2), slices.Max(nums), slices.Min(nums))
+	fmt.Printf("Compact(sorted): %v\n", slices.Compact(slices.Clone(sorted)))
+	fmt.Printf("Insert: %v\n", slices.Insert([]int{1, 4}, 1, 2, 3))
+	fmt.Printf("Delete [1:3): %v\n", slices.Delete([]int{0, 1, 2, 3}, 1, 3))
+	fmt.Printf("Equal: %v\n", slices.Equal([]int{1, 2}, []int{1, 2}))
+	rev := slices.Clone(sorted)
+	slices.Reverse(rev)
+	fmt.Printf("Reverse: %v\n", rev)
+
+	words := []string{"banana", "Apple", "cherry", "apple"}
+	slices.SortFunc(words, func(x, y string) int {
+		return strings.Compare(strings.ToLower(x), strings.ToLower(y))
+	})
+	fmt.Printf("SortFunc (case-insensitive): %q\n", words)
+	longWords := slices.DeleteFunc(slices.Clone(words), func(w string) bool { return len(w) < 6 })
+	fmt.Printf("DeleteFunc(len < 6): %q\n", longWords)
+	// Iterator helpers (Go 1.23+)
+	fmt.Printf("Sorted(Values(nums)): %v\n", slices.Sorted(slices.Values(nums)))
+	for chunk := range slices.Chunk([]int{1, 2, 3, 4, 5}, 2) {
+		fmt.Printf("chunk %v ", chunk)
+	}
+	fmt.Println()
+
+	// ==================== Benchmark ====================
+	fmt.Println("\n=== Benchmark: append With vs Without Capacity ===")
+	for _, size := range []int{100, 10_000, 1_000_000} {
+		without := testing.Benchmark(func(b *testing.B) {
+			b.ReportAllocs()
+			for i := 0; i < b.N; i++ {
+				buildWithoutCapacity(size)
+			}
+		})
+		with := testing.Benchmark(func(b *testing.B) {
+			b.ReportAllocs()
+			for i := 0; i < b.N; i++ {
+				buildWithCapacity(size)
+			}
+		})
+		fmt.Printf("n=%-9d without cap: %10d ns/op %3d allocs | with cap: %10d ns/op %d allocs | %.1fx faster\n",
+			size, without.NsPerOp(), without.AllocsPerOp(), with.NsPerOp(), with.AllocsPerOp(),
+			float64(without.NsPerOp())/float64(max(with.NsPerOp(), 1)))
+	}
+}
@@ -0,0 +1,152 @@
+// This file demonstrates how to test and benchmark Go code
+// The functions below are the code under test; testing_test.go holds the tests
+//
+// Go's testing tools are built in:
+// - go test runs functions named TestXxx(t *testing.T) in _test.go files
+// - Table-driven tests and subtests (t.Run) cover many cases with one loop
+// - t.Parallel lets independent tests run at the same time
+// - BenchmarkXxx(b *testing.B) measures speed; b.ReportAllocs adds allocations
+// - ExampleXxx functions are tests whose "// Output:" comment is checked
+// - FuzzXxx(f *testing.F) generates random inputs to find edge cases
+//
+// Run the tests from the repository root:
+//   go test ./01-basics/testing -v
+//   go test ./01-basics/testing -run=TestReverse/unicode -v
+//   go test ./01-basics/testing -bench=. -benchmem -run=^$
+//   go test ./01-basics/testing -fuzz=FuzzReverse -fuzztime=10s
+//   go test ./01-basics/testing -cover
+
+package main
+
+import (
+	"errors"
+	"fmt"
+	"strings"
+	"testing"
+	"unicode/utf8"
+)
+
+// ErrEmptyInput is returned by Average for an empty slice
+var ErrEmptyInput = errors.New("empty input")
+
+// Reverse reverses a string rune by rune, so multi-byte characters survive
+// Reversing bytes instead would corrupt "héllo"; a fuzz test catches that
+func Reverse(s string) string {
+	runes := []rune(s)
+	for i, j := 0, len(runes)-1; i < j; i, j = i+1, j-1 {
+		runes[i], runes[j] = runes[j], runes[i]
+	}
+	return string(runes)
+}
+
+// IsPalindrome reports whether s reads the same forwards and backwards,
+// ignoring case and anything that is not a letter or digit
+func IsPalindrome(s string) bool {
+	cleaned := strings.Map(func(r rune) rune {
+		if r >= 'a' && r <= 'z' || r >= '0' && r <= '9' {
+			return r
+		}
+		return -1 // Drop the rune
+	}, strings.ToLower(s))
+	return cleaned == Reverse(cleaned)
+}
+
+// BinarySearch returns the index of target in sorted nums, or -1
+func BinarySearch(nums []int, target int) int {
+	low, high := 0, len(nums)-1
+	for low <= high {
+		mid := low + (high-low)/2 // Avoids overflow of (low + high)
+		switch {
+		case nums[mid] == target:
+			return mid
+		case nums[mid] < target:
+			low = mid + 1
+		default:
+			high = mid - 1
+		}
+	}
+	return -1
+}
+
+// Average returns the mean of nums, or ErrEmptyInput
+func Average(nums []float64) (float64, error) {
+	if len(nums) == 0 {
+		return 0, ErrEmptyInput
+	}
+	sum := 0.0
+	for _, n := range nums {
+		sum += n
+	}
+	return sum / float64(len(nums)), nil
+}
+
+// JoinNaive builds a string with +=, copying the result on every iteration
+func JoinNaive(words []string) string {
+	result := ""
+	for i, w := range words {
+		if i > 0 {
+			result += ","
+		}
+		result += w
+	}
+	return result
+}
+
+// JoinBuilder builds the same string with a strings.Builder sized up front
+func JoinBuilder(words []string) string {
+	size := max(len(words)-1, 0)
+	for _, w := range words {
+		size += len(w)
+	}
+	var sb strings.Builder
+	sb.Grow(size)
+	for i, w := range words {
+		if i > 0 {
+			sb.WriteByte(',')
+		}
+		sb.WriteString(w)
+	}
+	return sb.String()
+}
+
+func main() {
+	// ==================== Code Under Test ====================
+	fmt.Println("=== Code Under Test ===")
+	fmt.Printf("Reverse(%q) = %q\n", "héllo, 世界", Reverse("héllo, 世界"))
+	fmt.Printf("IsPalindrome(%q) = %v\n", "A man, a plan, a canal: Panama", IsPalindrome("A man, a plan, a canal: Panama"))
+	fmt.Printf("BinarySearch([1 3 5 7], 5) = %d\n", BinarySearch([]int{1, 3, 5, 7}, 5))
+	_, err := Average(nil)
+	fmt.Printf("Average(nil) error: %v\n", err)
+
+	// Why Reverse works on runes: reversing bytes breaks multi-byte characters
+	s := "héllo"
+	b := []byte(s)
+	for i, j := 0, len(b)-1; i < j; i, j = i+1, j-1 {
+		b[i], b[j] = b[j], b[i]
+	}
+	fmt.Printf("Byte-reversed %q is valid UTF-8? %v\n", s, utf8.Valid(b))
+	fmt.Printf("Rune-reversed %q is valid UTF-8? %v\n", s, utf8.ValidString(Reverse(s)))
+
+	// ==================== Benchmarks from main ====================
+	fmt.Println("\n=== Benchmarks ===")
+	// testing.Benchmark runs a benchmark function outside of go test
+	// go test -bench does the same thing and is what you normally use
+	words := strings.Fields(strings.Repeat("gopher ", 500))
+	for _, bench := range []struct {
+		name string
+		join func([]string) string
+	}{
+		{"JoinNaive", JoinNaive},
+		{"JoinBuilder", JoinBuilder},
+	} {
+		result := testing.Benchmark(func(b *testing.B) {
+			b.ReportAllocs()
+			for i := 0; i < b.N; i++ {
+				bench.join(words)
+			}
+		})
+		fmt.Printf("%-12s %10d ns/op %5d allocs/op\n", bench.name, result.NsPerOp(), result.AllocsPerOp())
+	}
+
+	fmt.Println("\nRun the tests with: go test ./01-basics/testing -v")
+}
@@ -0,0 +1,180 @@
+package main
+
+import (
+	"errors"
+	"fmt"
+	"math"
+	"strings"
+	"testing"
+	"unicode/utf8"
+)
+
+// Table-driven test: each case is a row, and t.Run gives every row its own
+// name so one case can be run with -run=TestReverse/unicode
+func TestReverse(t *testing.T) {
+	tests := []struct {
+		name  string
+		input string
+		want  string
+	}{
+		{"empty", "", ""},
+		{"single", "a", "a"},
+		{"ascii", "hello", "olleh"},
+		{"unicode", "héllo, 世界", "界世 ,olléh"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := Reverse(tt.input); got != tt.want {
+				t.Errorf("Reverse(%q) = %q, want %q", tt.input, got, tt.want)
+			}
+		})
+	}
+}
+
+// t.Parallel marks subtests that may run at the same time as each other
+// Since Go 1.22 each loop iteration has its own tt, so capturing it is safe
+func TestIsPalindrome(t *testing.T) {
+	tests := []struct {
+		input string
+		want  bool
+	}{
+		{"", true},
+		{"racecar", true},
+		{"A man, a plan, a canal: Panama", true},
+		{"No 'x' in Nixon", true},
+		{"gopher", false},
+		{"12321", true},
+	}
+	for _, tt := range tests {
+		t.Run(tt.input, func(t *testing.T) {
+			t.Parallel()
+			if got := IsPalindrome(tt.input); got != tt.want {
+				t.Errorf("IsPalindrome(%q) = %v, want %v", tt.input, got, tt.want)
+			}
+		})
+	}
+}
+
+func TestBinarySearch(t *testing.T) {
+	nums := []int{1, 3, 5, 7, 9, 11}
+	// Every element must be found at its own index
+	for i, n := range nums {
+		if got := BinarySearch(nums, n); got != i {
+			t.Errorf("BinarySearch(%d) = %d, want %d", n, got, i)
+		}
+	}
+	// Values between and outside the elements must not be found
+	for _, missing := range []int{0, 2, 4, 10, 12} {
+		if got := BinarySearch(nums, missing); got != -1 {
+			t.Errorf("BinarySearch(%d) = %d, want -1", missing, got)
+		}
+	}
+	if got := BinarySearch(nil, 1); got != -1 {
+		t.Errorf("BinarySearch on nil slice = %d, want -1", got)
+	}
+}
+
+// assertClose is a test helper; t.Helper makes failures point at the caller
+func assertClose(t *testing.T, got, want float64) {
+	t.Helper()
+	if math.Abs(got-want) > 1e-9 {
+		t.Errorf("got %v, want %v", got, want)
+	}
+}
+
+func TestAverage(t *testing.T) {
+	got, err := Average([]float64{1, 2, 3, 4})
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err) // Fatalf stops this test right away
+	}
+	assertClose(t, got, 2.5)
+
+	// Check error identity with errors.Is, not by comparing messages
+	if _, err := Average(nil); !errors.Is(err, ErrEmptyInput) {
+		t.Errorf("Average(nil) error = %v, want ErrEmptyInput", err)
+	}
+}
+
+func TestJoinVariantsAgree(t *testing.T) {
+	inputs := [][]string{nil, {"a"}, {"a", "b", "c"}, {"", "", ""}}
+	for _, words := range inputs {
+		if naive, builder := JoinNaive(words), JoinBuilder(words); naive != builder {
+			t.Errorf("JoinNaive(%q) = %q but JoinBuilder = %q", words, naive, builder)
+		}
+	}
+}
+
+// Benchmarks run with go test -bench=.
+// b.ReportAllocs adds allocs/op and B/op to the output
+func BenchmarkJoinNaive(b *testing.B) {
+	words := strings.Fields(strings.Repeat("gopher ", 500))
+	b.ReportAllocs()
+	for i := 0; i < b.N; i++ {
+		JoinNaive(words)
+	}
+}
+
+func BenchmarkJoinBuilder(b *testing.B) {
+	words := strings.Fields(strings.Repeat("gopher ", 500))
+	b.ReportAllocs()
+	b.ResetTimer() // Don't count the setup above
+	for i := 0; i < b.N; i++ {
+		JoinBuilder(words)
+	}
+}
+
+// Sub-benchmarks compare the same code at different input sizes
+func BenchmarkBinarySearch(b *testing.B) {
+	for _, size := range []int{100, 10_000, 1_000_000} {
+		nums := make([]int, size)
+		for i := range nums {
+			nums[i] = i * 2
+		}
+		b.Run(fmt.Sprintf("n=%d", size), func(b *testing.B) {
+			for i := 0; i < b.N; i++ {
+				BinarySearch(nums, size) // Found halfway through
+			}
+		})
+	}
+}
+
+// Examples are compiled, shown in documentation and run by go test:
+// the printed output must match the "Output:" comment exactly
+func ExampleReverse() {
+	fmt.Println(Reverse("stressed"))
+	// Output: desserts
+}
+
+func ExampleAverage() {
+	avg, err := Average([]float64{2, 4, 9})
+	fmt.Println(avg, err)
+	_, err = Average(nil)
+	fmt.Println(err)
+	// Output:
+	// 5 <nil>
+	// empty input
+}
+
+// Fuzz tests check properties that must hold for any input
+// go test runs just the seed corpus; go test -fuzz=FuzzReverse generates more
+// Inputs that fail are saved under testdata/fuzz/FuzzReverse and rerun every time
+func FuzzReverse(f *testing.F) {
+	for _, seed := range []string{"", "hello", "héllo, 世界", "!12345"} {
+		f.Add(seed)
+	}
+	f.Fuzz(func(t *testing.T, s string) {
+		if !utf8.ValidString(s) {
+			t.Skip("Reverse works on runes; invalid UTF-8 is not round-trippable")
+		}
+		reversed := Reverse(s)
+		if !utf8.ValidString(reversed) {
+			t.Errorf("Reverse(%q) produced invalid UTF-8 %q", s, reversed)
+		}
+		if twice := Reverse(reversed); twice != s {
+			t.Errorf("Reverse(Reverse(%q)) = %q", s, twice)
+		}
+		if utf8.RuneCountInString(reversed) != utf8.RuneCountInString(s) {
+			t.Errorf("Reverse(%q) changed the rune count", s)
+		}
+	})
+}
@@ -0,0 +1,224 @@
+// This file demonstrates time handling, timers and tickers in Go
+// packages.go shows time.Now, Format and Add; this file goes further
+//
+// Topics:
+// - time.Timer, time.Ticker and time.AfterFunc
+// - Wall clock vs monotonic clock
+// - Parsing and formatting with reference layouts
+// - Time zones with time.LoadLocation
+// - Debounce and throttle helpers built on timers
+
+package main
+
+import (
+	"fmt"
+	"sync"
+	"time"
+	_ "time/tzdata" // Embeds the time zone database so LoadLocation works anywhere
+)
+
+// Debouncer runs fn only after calls have stopped for the given delay
+// Typical use: search-as-you-type, where only the last keystroke matters
+type Debouncer struct {
+	mu    sync.Mutex
+	delay time.Duration
+	timer *time.Timer
+}
+
+// NewDebouncer creates a debouncer with the given quiet period
+func NewDebouncer(delay time.Duration) *Debouncer {
+	return &Debouncer{delay: delay}
+}
+
+// Call schedules fn, cancelling any call still waiting
+func (d *Debouncer) Call(fn func()) {
+	d.mu.Lock()
+	defer d.mu.Unlock()
+	if d.timer != nil {
+		d.timer.Stop()
+	}
+	// AfterFunc runs fn in its own goroutine when the timer fires
+	d.timer = time.AfterFunc(d.delay, fn)
+}
+
+// Throttler runs fn at most once per interval and drops calls in between
+// Typical use: handling scroll or resize events, rate-limited logging
+type Throttler struct {
+	mu       sync.Mutex
+	interval time.Duration
+	last     time.Time
+}
+
+// NewThrottler creates a throttler with the given interval
+func NewThrottler(interval time.Duration) *Throttler {
+	return &Throttler{interval: interval}
+}
+
+// Call runs fn now if the interval has passed since the last run
+// It reports whether fn ran
+func (t *Throttler) Call(fn func()) bool {
+	t.mu.Lock()
+	now := time.Now()
+	if !t.last.IsZero() && now.Sub(t.last) < t.interval {
+		t.mu.Unlock()
+		return false
+	}
+	t.last = now
+	t.mu.Unlock()
+	fn()
+	return true
+}
+
+func main() {
+	// ==================== Timers ====================
+	fmt.Println("=== Timers ===")
+	// A Timer sends the current time on its channel once, after the duration
+	timer := time.NewTimer(30 * time.Millisecond)
+	start := time.Now()
+	<-timer.C
+	fmt.Printf("Timer fired after ~%v\n", time.Since(start).Round(10*time.Millisecond))
+
+	// Stop reports whether it prevented the timer from firing
+	timer = time.NewTimer(time.Hour)
+	fmt.Printf("Stopped before firing: %v\n", timer.Stop())
+	// Reset reuses a timer; since Go 1.23 no channel draining is needed first
+	timer.Reset(10 * time.Millisecond)
+	<-timer.C
+	fmt.Println("Reset timer fired")
+
+	// ==================== AfterFunc ====================
+	fmt.Println("\n=== time.AfterFunc ===")
+	var wg sync.WaitGroup
+	wg.Add(1)
+	time.AfterFunc(20*time.Millisecond, func() {
+		defer wg.Done()
+		fmt.Println("AfterFunc callback ran in its own goroutine")
+	})
+	wg.Wait()
+	cancelled := time.AfterFunc(time.Hour, func() { fmt.Println("never printed") })
+	fmt.Printf("Cancelled AfterFunc: %v\n", cancelled.Stop())
+
+	// ==================== Tickers ====================
+	fmt.Println("\n=== Tickers ===")
+	// A Ticker sends repeatedly; always Stop it to release its resources
+	ticker := time.NewTicker(20 * time.Millisecond)
+	deadline := time.After(110 * time.Millisecond)
+	ticks := 0
+loop:
+	for {
+		select {
+		case <-ticker.C:
+			ticks++
+		case <-deadline:
+			break loop
+		}
+	}
+	ticker.Stop()
+	fmt.Printf("Ticks in 110ms at a 20ms interval: %d\n", ticks)
+	// A slow receiver does not queue ticks: the channel holds at most one,
+	// and missed ticks are dropped to make up for the slowness
+
+	// ==================== Monotonic Clock ====================
+	fmt.Println("\n=== Wall Clock vs Monotonic Clock ===")
+	// time.Now returns both a wall clock reading (for telling the time) and a
+	// monotonic reading (for measuring time). The wall clock can jump when the
+	// system clock is adjusted; the monotonic clock never goes backwards.
+	// Sub, Since and Until use the monotonic reading when both times have one
+	t1 := time.Now()
+	time.Sleep(15 * time.Millisecond)
+	fmt.Printf("Elapsed (monotonic): ~%v\n", time.Since(t1).Round(5*time.Millisecond))
+	// The monotonic reading shows up as "m=+0.123" when printing a time
+	// Round(0) strips it, e.g. before comparing or serializing times
+	stripped := t1.Round(0)
+	fmt.Printf("t1 == stripped: %v, t1.Equal(stripped): %v\n", t1 == stripped, t1.Equal(stripped))
+	// Compare times with Equal, Before and After, not ==
+
+	// ==================== Formatting and Parsing ====================
+	fmt.Println("\n=== Formatting and Parsing ===")
+	// Layouts are written using the reference time Mon Jan 2 15:04:05 MST 2006
+	// (1 2 3 4 5 6 7: month, day, hour, minute, second, year, zone -0700)
+	moment := time.Date(2024, time.March, 9, 14, 5, 7, 0, time.UTC)
+	layouts := []struct{ name, layout string }{
+		{"RFC3339", time.RFC3339},
+		{"DateTime", time.DateTime},
+		{"DateOnly", time.DateOnly},
+		{"Kitchen", time.Kitchen},
+		{"custom", "Mon, 02 Jan 2006 at 3:04PM"},
+		{"day/month", "02/01/2006"},
+	}
+	for _, l := range layouts {
+		fmt.Printf("%-10s %s\n", l.name, moment.Format(l.layout))
+	}
+
+	parsed, err := time.Parse("02/01/2006 15:04", "25/12/2024 18:30")
+	fmt.Printf("Parsed: %v, err: %v\n", parsed, err)
+	_, err = time.Parse(time.DateOnly, "2024-02-30")
+	fmt.Printf("Invalid date: %v\n", err)
+	// Layout mistakes are silent: "YYYY-MM-DD" is copied as literal text,
+	// and swapping 01 and 02 swaps month and day
+	// go vet flags constant layouts like "2006-02-01", so use a variable here
+	swapped := "2006-02-01"
+	fmt.Printf("Layout %q gives %q, layout %q gives %q\n",
+		"YYYY-MM-DD", moment.Format("YYYY-MM-DD"), swapped, moment.Format(swapped))
+
+	d, _ := time.ParseDuration("1h15m30.5s")
+	fmt.Printf("ParseDuration: %v = %.1f minutes\n", d, d.Minutes())
+	fmt.Printf("Truncate to the hour: %s\n", moment.Truncate(time.Hour).Format(time.DateTime))
+
+	// ==================== Time Zones ====================
+	fmt.Println("\n=== Time Zones ===")
+	// A time.Time is an instant; the location only changes how it is displayed
+	for _, name := range []string{"Asia/Bangkok", "Europe/London", "America/New_York"} {
+		loc, err := time.LoadLocation(name)
+		if err != nil {
+			fmt.Println("Error:", err)
+			continue
+		}
+		local := moment.In(loc)
+		fmt.Printf("%-17s %s\n", name, local.Format("2006-01-02 15:04 MST (-07:00)"))
+	}
+	_, err = time.LoadLocation("Mars/Olympus_Mons")
+	fmt.Printf("Unknown zone: %v\n", err)
+
+	// ParseInLocation interprets a time without offset in the given zone
+	bangkok, _ := time.LoadLocation("Asia/Bangkok")
+	meeting, _ := time.ParseInLocation(time.DateTime, "2024-03-09 09:00:00", bangkok)
+	fmt.Printf("09:00 in Bangkok is %s UTC\n", meeting.UTC().Format("15:04"))
+	// Daylight saving time: adding 24h is not always "the same time tomorrow"
+	newYork, _ := time.LoadLocation("America/New_York")
+	beforeDST := time.Date(2024, time.March, 9, 12, 0, 0, 0, newYork)
+	fmt.Printf("Add(24h): %s | AddDate(0,0,1): %s\n",
+		beforeDST.Add(24*time.Hour).Format("Jan 2 15:04 MST"),
+		beforeDST.AddDate(0, 0, 1).Format("Jan 2 15:04 MST"))
+
+	// ==================== Debounce ====================
+	fmt.Println("\n=== Debounce ===")
+	var mu sync.Mutex
+	searches := []string{}
+	debouncer := NewDebouncer(40 * time.Millisecond)
+	query := ""
+	for _, ch := range "gopher" {
+		query += string(ch)
+		q := query
+		debouncer.Call(func() {
+			mu.Lock()
+			searches = append(searches, q)
+			mu.Unlock()
+		})
+		time.Sleep(10 * time.Millisecond) // Typing faster than the delay
+	}
+	time.Sleep(80 * time.Millisecond)
+	mu.Lock()
+	fmt.Printf("6 keystrokes -> searches run: %q\n", searches)
+	mu.Unlock()
+
+	// ==================== Throttle ====================
+	fmt.Println("\n=== Throttle ===")
+	throttler := NewThrottler(50 * time.Millisecond)
+	ran := 0
+	for i := 0; i < 12; i++ {
+		throttler.Call(func() { ran++ })
+		time.Sleep(10 * time.Millisecond)
+	}
+	fmt.Printf("12 events over ~120ms with a 50ms throttle -> %d handled\n", ran)
+}
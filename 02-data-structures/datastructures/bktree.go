@@ -0,0 +1,216 @@
+// This file implements a BK-tree (Burkhard-Keller tree) data structure in Go
+// A BK-tree indexes words by their distance to each other under a metric,
+// which makes "find everything within distance d of this word" queries fast
+//
+// Every node stores a word, and each child edge is labelled with the distance
+// between the child's word and its parent's word. Thanks to the triangle
+// inequality, a search only needs to follow edges whose label lies within
+// [d(query, node) - radius, d(query, node) + radius]
+//
+// Time Complexity:
+// - Add: O(h) distance computations where h is the height of the tree
+// - RangeSearch: O(n) worst case, typically far fewer distance computations
+//   than a brute-force scan for small radii
+//
+// Use Cases:
+// - Spell checkers (suggest words within edit distance 1 or 2)
+// - Fuzzy search and autocomplete
+// - Near-duplicate detection (Hamming distance between hashes)
+// - DNA sequence matching
+
+package datastructures
+
+import "sort"
+
+// Metric computes the distance between two words
+// A valid metric must satisfy:
+// - d(a, b) >= 0 and d(a, b) == 0 only when a == b
+// - d(a, b) == d(b, a) (symmetry)
+// - d(a, c) <= d(a, b) + d(b, c) (triangle inequality)
+type Metric func(a, b string) int
+
+// BKNode represents a node in the BK-tree
+// Each node contains:
+// - word: the value stored in the node
+// - children: child nodes keyed by their distance to word
+type BKNode struct {
+	word     string
+	children map[int]*BKNode
+}
+
+// BKTree represents a BK-tree over strings
+// It contains the root node, the metric used to compare words
+// and the number of words stored in the tree
+type BKTree struct {
+	root   *BKNode
+	metric Metric
+	size   int
+}
+
+// NewBKTree creates a new BK-tree that uses the given metric
+func NewBKTree(metric Metric) *BKTree {
+	return &BKTree{metric: metric}
+}
+
+// Add inserts a word into the tree
+// Duplicate words (distance 0 to an existing node) are ignored
+// Time Complexity: O(h) distance computations
+func (t *BKTree) Add(word string) {
+	// Case 1: Empty tree
+	if t.root == nil {
+		t.root = &BKNode{word: word, children: make(map[int]*BKNode)}
+		t.size++
+		return
+	}
+
+	// Case 2: Walk down the edge labelled with the distance to each node
+	// until we find a free slot
+	current := t.root
+	for {
+		distance := t.metric(word, current.word)
+		if distance == 0 {
+			// Word already present
+			return
+		}
+		child, exists := current.children[distance]
+		if !exists {
+			current.children[distance] = &BKNode{word: word, children: make(map[int]*BKNode)}
+			t.size++
+			return
+		}
+		current = child
+	}
+}
+
+// RangeSearch returns all words within radius of query
+// Results are returned in ascending order of distance, ties broken alphabetically
+// Time Complexity: O(n) worst case
+func (t *BKTree) RangeSearch(query string, radius int) []string {
+	type match struct {
+		word     string
+		distance int
+	}
+	matches := []match{}
+
+	if t.root != nil {
+		// Iterative traversal using a slice as a stack
+		stack := []*BKNode{t.root}
+		for len(stack) > 0 {
+			node := stack[len(stack)-1]
+			stack = stack[:len(stack)-1]
+
+			distance := t.metric(query, node.word)
+			if distance <= radius {
+				matches = append(matches, match{node.word, distance})
+			}
+
+			// Only children whose edge label is within [distance-radius, distance+radius]
+			// can contain matches (triangle inequality)
+			for edge, child := range node.children {
+				if edge >= distance-radius && edge <= distance+radius {
+					stack = append(stack, child)
+				}
+			}
+		}
+	}
+
+	sort.Slice(matches, func(i, j int) bool {
+		if matches[i].distance != matches[j].distance {
+			return matches[i].distance < matches[j].distance
+		}
+		return matches[i].word < matches[j].word
+	})
+
+	result := make([]string, len(matches))
+	for i, m := range matches {
+		result[i] = m.word
+	}
+	return result
+}
+
+// Size returns the number of words in the tree
+// Time Complexity: O(1)
+func (t *BKTree) Size() int {
+	return t.size
+}
+
+// Words returns every word stored in the tree, in no particular order
+// Time Complexity: O(n)
+func (t *BKTree) Words() []string {
+	result := []string{}
+	if t.root == nil {
+		return result
+	}
+	stack := []*BKNode{t.root}
+	for len(stack) > 0 {
+		node := stack[len(stack)-1]
+		stack = stack[:len(stack)-1]
+		result = append(result, node.word)
+		for _, child := range node.children {
+			stack = append(stack, child)
+		}
+	}
+	return result
+}
+
+// EditDistance is a Metric computing the Levenshtein distance between two words
+// It counts insertions, deletions and substitutions of runes
+// Time Complexity: O(mn)
+// Space Complexity: O(n) using two rolling rows
+func EditDistance(a, b string) int {
+	s1, s2 := []rune(a), []rune(b)
+	prev := make([]int, len(s2)+1)
+	curr := make([]int, len(s2)+1)
+	for j := range prev {
+		prev[j] = j
+	}
+
+	for i := 1; i <= len(s1); i++ {
+		curr[0] = i
+		for j := 1; j <= len(s2); j++ {
+			cost := 1
+			if s1[i-1] == s2[j-1] {
+				cost = 0
+			}
+			curr[j] = minOf3(
+				prev[j]+1,      // deletion
+				curr[j-1]+1,    // insertion
+				prev[j-1]+cost, // substitution
+			)
+		}
+		prev, curr = curr, prev
+	}
+
+	return prev[len(s2)]
+}
+
+// HammingDistance is a Metric counting positions at which two words differ
+// Hamming distance is normally only defined for equal-length words,
+// so every extra rune in the longer word counts as one more difference
+// This keeps it a valid metric over all strings
+// Time Complexity: O(n)
+func HammingDistance(a, b string) int {
+	s1, s2 := []rune(a), []rune(b)
+	if len(s1) > len(s2) {
+		s1, s2 = s2, s1
+	}
+
+	distance := len(s2) - len(s1)
+	for i := range s1 {
+		if s1[i] != s2[i] {
+			distance++
+		}
+	}
+	return distance
+}
+
+// Helper function to find minimum of three integers
+func minOf3(a, b, c int) int {
+	if a > b {
+		a = b
+	}
+	if a > c {
+		a = c
+	}
+	return a
+}
@@ -0,0 +1,75 @@
+package datastructures
+
+import (
+	"cmp"
+	"slices"
+	"testing"
+
+	"github.com/NutProhmpiriya/go-basic/testdata/generator"
+)
+
+// linearRangeSearch is RangeSearch by brute force: every word compared with
+// the query, in the order RangeSearch promises
+func linearRangeSearch(words []string, metric Metric, query string, radius int) []string {
+	type match struct {
+		word     string
+		distance int
+	}
+	var matches []match
+	for _, w := range words {
+		if d := metric(query, w); d <= radius {
+			matches = append(matches, match{w, d})
+		}
+	}
+	slices.SortFunc(matches, func(a, b match) int {
+		return cmp.Or(cmp.Compare(a.distance, b.distance), cmp.Compare(a.word, b.word))
+	})
+	result := []string{}
+	for _, m := range matches {
+		result = append(result, m.word)
+	}
+	return result
+}
+
+// The triangle inequality only prunes branches that cannot match, so the
+// tree must find exactly what a scan of every word finds
+func TestBKTreeMatchesLinearScan(t *testing.T) {
+	for _, m := range []struct {
+		name   string
+		metric Metric
+	}{
+		{"edit distance", EditDistance},
+		{"hamming distance", HammingDistance},
+	} {
+		t.Run(m.name, func(t *testing.T) {
+			gen := generator.New(1)
+			rng := gen.Rand()
+			// Short words over three letters: many are close to each other,
+			// and some repeat
+			var words []string
+			tree := NewBKTree(m.metric)
+			for range 500 {
+				w := gen.String(1+rng.Intn(7), "abc")
+				words = append(words, w)
+				tree.Add(w)
+			}
+			slices.Sort(words)
+			words = slices.Compact(words)
+			if tree.Size() != len(words) {
+				t.Fatalf("Size() = %d, want %d distinct words", tree.Size(), len(words))
+			}
+			if got := slices.Sorted(slices.Values(tree.Words())); !slices.Equal(got, words) {
+				t.Fatalf("Words() holds %d words, want the %d added", len(got), len(words))
+			}
+
+			for range 200 {
+				query := gen.String(rng.Intn(9), "abcd")
+				radius := rng.Intn(5)
+				got := tree.RangeSearch(query, radius)
+				if want := linearRangeSearch(words, m.metric, query, radius); !slices.Equal(got, want) {
+					t.Fatalf("RangeSearch(%q, %d) = %v\nlinear scan finds %v", query, radius, got, want)
+				}
+			}
+		})
+	}
+}
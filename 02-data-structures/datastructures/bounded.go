@@ -0,0 +1,231 @@
+// This file implements stacks and queues with a fixed capacity
+// An unbounded stack or queue grows until memory runs out when producers
+// are faster than consumers. A bounded one either refuses new items when it
+// is full or makes the producer wait for a consumer to make room, which is
+// called backpressure. For queues between goroutines a buffered channel
+// already blocks this way; these types add the stack order, the error mode
+// and the ContainsFunc and ToSlice helpers
+//
+// Both types are safe for concurrent use
+//
+// Time Complexity: the same as StackOf and QueueOf
+
+package datastructures
+
+import (
+	"errors"
+	"fmt"
+	"sync"
+)
+
+// ErrFull is returned when adding to a full bounded stack or queue in
+// ErrorWhenFull mode
+var ErrFull = errors.New("capacity reached")
+
+// FullPolicy says what a bounded stack or queue does when it is full
+type FullPolicy int
+
+const (
+	// ErrorWhenFull makes Push and Enqueue return ErrFull
+	ErrorWhenFull FullPolicy = iota
+	// BlockWhenFull makes Push and Enqueue wait until another goroutine
+	// removes an item
+	BlockWhenFull
+)
+
+// bounds is the capacity check shared by the bounded types
+type bounds struct {
+	mu       sync.Mutex
+	notFull  sync.Cond
+	capacity int
+	policy   FullPolicy
+}
+
+func (b *bounds) init(capacity int, policy FullPolicy) {
+	if capacity < 1 {
+		panic(fmt.Sprintf("datastructures: capacity %d, need at least 1", capacity))
+	}
+	b.capacity = capacity
+	b.policy = policy
+	b.notFull.L = &b.mu
+}
+
+// waitForRoom returns once size() is below the capacity, or ErrFull
+// The caller must hold b.mu
+func (b *bounds) waitForRoom(size func() int) error {
+	for size() >= b.capacity {
+		if b.policy == ErrorWhenFull {
+			return fmt.Errorf("%w: %d items", ErrFull, b.capacity)
+		}
+		b.notFull.Wait()
+	}
+	return nil
+}
+
+// BoundedStack is a stack that holds at most a fixed number of items
+type BoundedStack[T any] struct {
+	bounds
+	stack StackOf[T]
+}
+
+// NewBoundedStack creates a stack for up to capacity items
+// It panics if capacity is less than 1
+func NewBoundedStack[T any](capacity int, policy FullPolicy) *BoundedStack[T] {
+	s := &BoundedStack[T]{}
+	s.init(capacity, policy)
+	return s
+}
+
+// Push adds an item to the top of the stack
+// When the stack is full it returns ErrFull or waits, depending on the policy
+func (s *BoundedStack[T]) Push(item T) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	if err := s.waitForRoom(s.stack.Size); err != nil {
+		return err
+	}
+	s.stack.Push(item)
+	return nil
+}
+
+// Pop removes and returns the top item from the stack
+func (s *BoundedStack[T]) Pop() (T, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	item, err := s.stack.Pop()
+	if err == nil {
+		s.notFull.Signal()
+	}
+	return item, err
+}
+
+// Peek returns the top item without removing it
+func (s *BoundedStack[T]) Peek() (T, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	return s.stack.Peek()
+}
+
+// IsEmpty returns true if the stack is empty
+func (s *BoundedStack[T]) IsEmpty() bool {
+	return s.Size() == 0
+}
+
+// Size returns the number of items in the stack
+func (s *BoundedStack[T]) Size() int {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	return s.stack.Size()
+}
+
+// Cap returns the most items the stack holds
+func (s *BoundedStack[T]) Cap() int {
+	return s.capacity
+}
+
+// Clear removes every item, waking every blocked Push
+func (s *BoundedStack[T]) Clear() {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.stack.Clear()
+	s.notFull.Broadcast()
+}
+
+// ContainsFunc reports whether any item in the stack satisfies match
+// match runs under the stack's lock, so it must not use the stack
+func (s *BoundedStack[T]) ContainsFunc(match func(T) bool) bool {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	return s.stack.ContainsFunc(match)
+}
+
+// ToSlice returns a copy of the items, bottom first
+func (s *BoundedStack[T]) ToSlice() []T {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	return s.stack.ToSlice()
+}
+
+// BoundedQueue is a queue that holds at most a fixed number of items
+type BoundedQueue[T any] struct {
+	bounds
+	queue QueueOf[T]
+}
+
+// NewBoundedQueue creates a queue for up to capacity items
+// It panics if capacity is less than 1
+func NewBoundedQueue[T any](capacity int, policy FullPolicy) *BoundedQueue[T] {
+	q := &BoundedQueue[T]{}
+	q.init(capacity, policy)
+	return q
+}
+
+// Enqueue adds an item to the end of the queue
+// When the queue is full it returns ErrFull or waits, depending on the policy
+func (q *BoundedQueue[T]) Enqueue(item T) error {
+	q.mu.Lock()
+	defer q.mu.Unlock()
+	if err := q.waitForRoom(q.queue.Size); err != nil {
+		return err
+	}
+	q.queue.Enqueue(item)
+	return nil
+}
+
+// Dequeue removes and returns the first item in the queue
+func (q *BoundedQueue[T]) Dequeue() (T, error) {
+	q.mu.Lock()
+	defer q.mu.Unlock()
+	item, err := q.queue.Dequeue()
+	if err == nil {
+		q.notFull.Signal()
+	}
+	return item, err
+}
+
+// Peek returns the first item without removing it
+func (q *BoundedQueue[T]) Peek() (T, error) {
+	q.mu.Lock()
+	defer q.mu.Unlock()
+	return q.queue.Peek()
+}
+
+// IsEmpty returns true if the queue is empty
+func (q *BoundedQueue[T]) IsEmpty() bool {
+	return q.Size() == 0
+}
+
+// Size returns the number of items in the queue
+func (q *BoundedQueue[T]) Size() int {
+	q.mu.Lock()
+	defer q.mu.Unlock()
+	return q.queue.Size()
+}
+
+// Cap returns the most items the queue holds
+func (q *BoundedQueue[T]) Cap() int {
+	return q.capacity
+}
+
+// Clear removes every item, waking every blocked Enqueue
+func (q *BoundedQueue[T]) Clear() {
+	q.mu.Lock()
+	defer q.mu.Unlock()
+	q.queue.Clear()
+	q.notFull.Broadcast()
+}
+
+// ContainsFunc reports whether any item in the queue satisfies match
+// match runs under the queue's lock, so it must not use the queue
+func (q *BoundedQueue[T]) ContainsFunc(match func(T) bool) bool {
+	q.mu.Lock()
+	defer q.mu.Unlock()
+	return q.queue.ContainsFunc(match)
+}
+
+// ToSlice returns a copy of the items, front first
+func (q *BoundedQueue[T]) ToSlice() []T {
+	q.mu.Lock()
+	defer q.mu.Unlock()
+	return q.queue.ToSlice()
+}
@@ -0,0 +1,137 @@
+package datastructures
+
+import (
+	"errors"
+	"slices"
+	"testing"
+	"time"
+)
+
+// boundedImpls adapts both bounded types to one API, in ErrorWhenFull or
+// BlockWhenFull mode; add is Push or Enqueue and remove Pop or Dequeue
+var boundedImpls = []struct {
+	name string
+	new  func(capacity int, policy FullPolicy) (add func(int) error, remove func() (int, error), items func() []int)
+}{
+	{"BoundedStack", func(capacity int, policy FullPolicy) (func(int) error, func() (int, error), func() []int) {
+		s := NewBoundedStack[int](capacity, policy)
+		return s.Push, s.Pop, s.ToSlice
+	}},
+	{"BoundedQueue", func(capacity int, policy FullPolicy) (func(int) error, func() (int, error), func() []int) {
+		q := NewBoundedQueue[int](capacity, policy)
+		return q.Enqueue, q.Dequeue, q.ToSlice
+	}},
+}
+
+func TestBoundedErrorWhenFull(t *testing.T) {
+	for _, impl := range boundedImpls {
+		add, remove, items := impl.new(2, ErrorWhenFull)
+		if err := add(1); err != nil {
+			t.Fatalf("%s: add(1) = %v", impl.name, err)
+		}
+		if err := add(2); err != nil {
+			t.Fatalf("%s: add(2) = %v", impl.name, err)
+		}
+		if err := add(3); !errors.Is(err, ErrFull) {
+			t.Errorf("%s: add to a full container = %v, want ErrFull", impl.name, err)
+		}
+		if got := items(); !slices.Equal(got, []int{1, 2}) {
+			t.Errorf("%s: items = %v, want [1 2]", impl.name, got)
+		}
+		// Removing one makes room again
+		remove()
+		if err := add(3); err != nil {
+			t.Errorf("%s: add after remove = %v", impl.name, err)
+		}
+	}
+}
+
+func TestBoundedBlockWhenFull(t *testing.T) {
+	for _, impl := range boundedImpls {
+		add, remove, items := impl.new(1, BlockWhenFull)
+		add(1)
+
+		added := make(chan error)
+		go func() { added <- add(2) }()
+		select {
+		case err := <-added:
+			t.Fatalf("%s: add to a full container returned %v instead of blocking", impl.name, err)
+		case <-time.After(20 * time.Millisecond):
+		}
+
+		if got, err := remove(); err != nil || got != 1 {
+			t.Fatalf("%s: remove() = %d, %v; want 1, nil", impl.name, got, err)
+		}
+		select {
+		case err := <-added:
+			if err != nil {
+				t.Errorf("%s: blocked add returned %v", impl.name, err)
+			}
+		case <-time.After(5 * time.Second):
+			t.Fatalf("%s: add still blocked after remove", impl.name)
+		}
+		if got := items(); !slices.Equal(got, []int{2}) {
+			t.Errorf("%s: items = %v, want [2]", impl.name, got)
+		}
+	}
+}
+
+func TestBoundedClearWakesWriters(t *testing.T) {
+	q := NewBoundedQueue[string](2, BlockWhenFull)
+	q.Enqueue("a")
+	q.Enqueue("b")
+	done := make(chan struct{})
+	for _, v := range []string{"c", "d"} {
+		go func() {
+			q.Enqueue(v)
+			done <- struct{}{}
+		}()
+	}
+	time.Sleep(10 * time.Millisecond)
+	q.Clear()
+	for range 2 {
+		select {
+		case <-done:
+		case <-time.After(5 * time.Second):
+			t.Fatal("Enqueue still blocked after Clear")
+		}
+	}
+	if q.Size() != 2 || Contains(q, "a") || !Contains(q, "c") || !q.ContainsFunc(is("d")) || q.Cap() != 2 {
+		t.Errorf("queue after Clear and two Enqueues = %v", q.ToSlice())
+	}
+}
+
+func TestBoundedRejectsZeroCapacity(t *testing.T) {
+	defer func() {
+		if recover() == nil {
+			t.Error("NewBoundedStack(0) did not panic")
+		}
+	}()
+	NewBoundedStack[int](0, ErrorWhenFull)
+}
+
+// The bounded types hold any type too, and Contains works on every
+// collection of comparable items
+func TestContains(t *testing.T) {
+	var s StackOf[int]
+	var q QueueOf[int]
+	bs := NewBoundedStack[int](4, ErrorWhenFull)
+	bq := NewBoundedQueue[int](4, ErrorWhenFull)
+	for _, v := range []int{1, 2, 3} {
+		s.Push(v)
+		q.Enqueue(v)
+		bs.Push(v)
+		bq.Enqueue(v)
+	}
+	for _, c := range []Searchable[int]{&s, &q, bs, bq} {
+		if !Contains(c, 2) || Contains(c, 4) {
+			t.Errorf("Contains on %T gives the wrong answer", c)
+		}
+	}
+
+	nested := NewBoundedStack[[]int](1, ErrorWhenFull)
+	nested.Push([]int{1, 2})
+	if !nested.ContainsFunc(func(v []int) bool { return len(v) == 2 }) {
+		t.Errorf("ContainsFunc misses [1 2] in %v", nested.ToSlice())
+	}
+}
@@ -0,0 +1,8 @@
+// Package datastructures implements the classic data structures covered in
+// 02-data-structures: stack, queue, heap, multiset, linked list, LRU cache,
+// binary search tree, order-statistics tree, trie, graph and BK-tree, plus an
+// expression evaluator and a bracket matcher built on the stack
+//
+// The examples that used to live in each file's main function are in the
+// runner one directory up: go run ./02-data-structures -list
+package datastructures
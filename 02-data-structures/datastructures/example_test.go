@@ -0,0 +1,137 @@
+package datastructures_test
+
+import (
+	"errors"
+	"fmt"
+	"slices"
+
+	"github.com/NutProhmpiriya/go-basic/02-data-structures/datastructures"
+)
+
+func ExampleStack() {
+	var s datastructures.Stack
+	s.Push(1)
+	s.Push(2)
+	top, _ := s.Pop()
+	fmt.Println(top, s.Size())
+	s.Pop()
+	_, err := s.Pop()
+	fmt.Println(err)
+	// Output:
+	// 2 1
+	// stack is empty
+}
+
+func ExampleIsValidBrackets() {
+	fmt.Println(datastructures.IsValidBrackets("f(a[i], {b})"), datastructures.IsValidBrackets("(]"))
+	fmt.Println(datastructures.IsValidBrackets(`print(")")`), datastructures.IsValidBrackets(`"unclosed (`))
+	// Output:
+	// true false
+	// true false
+}
+
+func ExampleMatcher_Check() {
+	src := "f(a[0)] // ]"
+	fmt.Println(datastructures.NewMatcher().Check(src))
+
+	err := datastructures.GoMatcher().Check("x := []int{1, 2\n")
+	var mismatch *datastructures.MismatchError
+	if errors.As(err, &mismatch) {
+		fmt.Println(mismatch.Line, mismatch.Col, mismatch.Msg)
+	}
+	// Output:
+	// 1:6: ')' does not close '['
+	// 1 11 '{' is not closed
+}
+
+func ExampleEvaluate() {
+	postfix, _ := datastructures.ToPostfix("(1 + 2) * -3 ^ 2")
+	fmt.Println(datastructures.FormatPostfix(postfix))
+	fmt.Println(datastructures.Evaluate("(1 + 2) * -3 ^ 2"))
+	fmt.Println(datastructures.Evaluate("(1 + 2"))
+	// Output:
+	// 1 2 + 3 2 ^ neg *
+	// -27 <nil>
+	// 0 invalid expression at position 0: unmatched "("
+}
+
+func ExampleBinaryTree_Layered() {
+	var tree datastructures.BinaryTree
+	for _, v := range []int{20, 10, 30, 25} {
+		tree.Insert(v)
+	}
+	fmt.Print(tree.Layered())
+	fmt.Println(tree.Validate())
+	// Output:
+	//    20
+	// ┌──┴─────┐
+	// 10       30
+	//       ┌──┘
+	//       25
+	// <nil>
+}
+
+func ExampleBinaryTreeOf_Range() {
+	ages := datastructures.NewBinaryTreeOf[int, string]()
+	for age, name := range map[int]string{34: "Ann", 19: "Bo", 52: "Cy", 27: "Di", 41: "Ed"} {
+		ages.Put(age, name)
+	}
+	for age, name := range ages.Range(20, 45) {
+		fmt.Print(name, " ", age, "; ")
+	}
+	fmt.Println()
+	// Output: Di 27; Ann 34; Ed 41;
+}
+
+func ExampleBKTree_RangeSearch() {
+	tree := datastructures.NewBKTree(datastructures.EditDistance)
+	for _, w := range []string{"book", "books", "cake", "boo", "cook"} {
+		tree.Add(w)
+	}
+	fmt.Println(tree.RangeSearch("bok", 1))
+	// Output: [boo book]
+}
+
+func ExampleHeap() {
+	// The three smallest values, without sorting them all
+	h := datastructures.NewMinHeap(42, 7, 19, 3, 25, 11)
+	for range 3 {
+		v, _ := h.Pop()
+		fmt.Print(v, " ")
+	}
+	fmt.Println(h.Size())
+	// Output: 3 7 11 3
+}
+
+func ExampleOrderStatTree() {
+	tree := datastructures.NewOrderedStatTree[int]()
+	for _, v := range []int{40, 10, 30, 20, 50} {
+		tree.Insert(v)
+	}
+	median, _ := tree.Select((tree.Len() + 1) / 2)
+	fmt.Println(median, tree.Rank(30), tree.Rank(35))
+	tree.Delete(10)
+	fmt.Println(tree.Rank(30))
+	// Output:
+	// 30 3 3
+	// 2
+}
+
+func ExampleTrie_WithPrefix() {
+	var trie datastructures.Trie
+	for _, w := range []string{"tea", "ten", "to", "tee", "inn"} {
+		trie.Insert(w)
+	}
+	fmt.Println(trie.WithPrefix("te"), trie.Contains("te"))
+	// Output: [tea tee ten] false
+}
+
+func ExampleLRU() {
+	cache := datastructures.NewLRU[string, int](2)
+	cache.Put("a", 1)
+	cache.Put("b", 2)
+	cache.Get("a") // a is now the most recently used
+	evicted, _ := cache.Put("c", 3)
+	fmt.Println(evicted, slices.Collect(cache.Keys()))
+	// Output: b [c a]
+}
@@ -0,0 +1,307 @@
+// This file implements an arithmetic expression evaluator built on stacks
+// It works in two steps, the way calculators and compilers do:
+//
+//  1. ToPostfix converts infix notation, 3 + 4 * 2, to postfix notation,
+//     3 4 2 * +, with Dijkstra's shunting-yard algorithm. Operators wait on
+//     a stack until an operator of lower precedence or a closing parenthesis
+//     pushes them out, so the postfix order already encodes precedence and
+//     parentheses
+//  2. EvaluatePostfix computes the result with a second stack: numbers are
+//     pushed, and each operator pops its operands and pushes the result
+//
+// Supported: decimal numbers, + - * / ^, parentheses and unary minus
+// Precedence from lowest to highest: + -, then * /, then unary minus, then ^
+// ^ and unary minus group right to left, so 2^3^2 is 2^9 and -2^2 is -4
+//
+// Time Complexity: O(n) for both steps, where n is the length of the expression
+
+package datastructures
+
+import (
+	"errors"
+	"fmt"
+	"math"
+	"strconv"
+	"strings"
+)
+
+var (
+	// ErrInvalidExpression is wrapped by every SyntaxError
+	ErrInvalidExpression = errors.New("invalid expression")
+	// ErrDivisionByZero is returned when evaluating x / 0
+	ErrDivisionByZero = errors.New("division by zero")
+	// ErrNotFinite is returned when an operation overflows or has no real
+	// result, like 10^400 or (-8)^0.5
+	ErrNotFinite = errors.New("result is not a finite number")
+)
+
+// SyntaxError reports where an expression is malformed
+type SyntaxError struct {
+	Pos int // byte offset in the expression
+	Msg string
+}
+
+func (e *SyntaxError) Error() string {
+	return fmt.Sprintf("invalid expression at position %d: %s", e.Pos, e.Msg)
+}
+
+// Unwrap lets errors.Is match ErrInvalidExpression
+func (e *SyntaxError) Unwrap() error {
+	return ErrInvalidExpression
+}
+
+// TokenKind is the kind of a Token
+type TokenKind int
+
+const (
+	NumberToken TokenKind = iota
+	OperatorToken
+	LeftParenToken
+	RightParenToken
+)
+
+// Token is a number, an operator or a parenthesis of an expression
+type Token struct {
+	Kind TokenKind
+	// Text is the token as written, except that unary minus is "neg"
+	// to tell it apart from subtraction
+	Text  string
+	Value float64 // for NumberToken
+	Pos   int     // byte offset in the expression
+}
+
+func (t Token) String() string {
+	return t.Text
+}
+
+// operator describes how an operator binds
+type operator struct {
+	precedence int
+	rightAssoc bool
+	unary      bool
+}
+
+var operators = map[string]operator{
+	"+":   {precedence: 1},
+	"-":   {precedence: 1},
+	"*":   {precedence: 2},
+	"/":   {precedence: 2},
+	"neg": {precedence: 3, rightAssoc: true, unary: true},
+	"^":   {precedence: 4, rightAssoc: true},
+}
+
+// Evaluate computes the value of an infix expression like "-(1 + 2) * 3^2"
+// Malformed expressions return a *SyntaxError
+func Evaluate(expr string) (float64, error) {
+	postfix, err := ToPostfix(expr)
+	if err != nil {
+		return 0, err
+	}
+	return EvaluatePostfix(postfix)
+}
+
+// ToPostfix converts an infix expression to postfix order with the
+// shunting-yard algorithm
+// Malformed expressions return a *SyntaxError
+func ToPostfix(expr string) ([]Token, error) {
+	tokens, err := tokenize(expr)
+	if err != nil {
+		return nil, err
+	}
+
+	output := []Token{}
+	ops := &StackOf[Token]{} // operators and left parentheses waiting for output
+	// expectOperand is true where a number, "(" or a unary minus may come
+	// next, and false where an operator or ")" must. It is how a minus is
+	// told to be unary or binary, and how most mistakes are caught
+	expectOperand := true
+
+	for _, tok := range tokens {
+		switch {
+		case tok.Kind == NumberToken:
+			if !expectOperand {
+				return nil, &SyntaxError{tok.Pos, fmt.Sprintf("missing operator before %s", tok.Text)}
+			}
+			output = append(output, tok)
+			expectOperand = false
+
+		case tok.Kind == LeftParenToken:
+			if !expectOperand {
+				return nil, &SyntaxError{tok.Pos, `missing operator before "("`}
+			}
+			ops.Push(tok)
+
+		case tok.Kind == RightParenToken:
+			if expectOperand {
+				return nil, &SyntaxError{tok.Pos, `expected a number before ")"`}
+			}
+			// Output operators until the matching "(" and drop both parentheses
+			for {
+				top, err := ops.Pop()
+				if err != nil {
+					return nil, &SyntaxError{tok.Pos, `unmatched ")"`}
+				}
+				if top.Kind == LeftParenToken {
+					break
+				}
+				output = append(output, top)
+			}
+
+		case expectOperand && tok.Text == "-":
+			// A prefix operator has no left operand, so nothing on the
+			// stack can be finished yet
+			tok.Text = "neg"
+			ops.Push(tok)
+
+		case expectOperand:
+			return nil, &SyntaxError{tok.Pos, fmt.Sprintf("expected a number before %q", tok.Text)}
+
+		default:
+			op := operators[tok.Text]
+			// Operators on the stack that bind tighter are finished first;
+			// equal ones too unless the new operator groups right to left
+			for {
+				top, err := ops.Peek()
+				if err != nil || top.Kind == LeftParenToken {
+					break
+				}
+				prev := operators[top.Text]
+				if prev.precedence < op.precedence || prev.precedence == op.precedence && op.rightAssoc {
+					break
+				}
+				ops.Pop()
+				output = append(output, top)
+			}
+			ops.Push(tok)
+			expectOperand = true
+		}
+	}
+
+	if expectOperand {
+		if len(tokens) == 0 {
+			return nil, &SyntaxError{0, "empty expression"}
+		}
+		return nil, &SyntaxError{len(expr), "expression ends without a number"}
+	}
+	for !ops.IsEmpty() {
+		top, _ := ops.Pop()
+		if top.Kind == LeftParenToken {
+			return nil, &SyntaxError{top.Pos, `unmatched "("`}
+		}
+		output = append(output, top)
+	}
+	return output, nil
+}
+
+// tokenize splits an expression into tokens, skipping whitespace
+func tokenize(expr string) ([]Token, error) {
+	var tokens []Token
+	for i := 0; i < len(expr); {
+		ch := expr[i]
+		switch {
+		case ch == ' ' || ch == '\t' || ch == '\n' || ch == '\r':
+			i++
+		case ch >= '0' && ch <= '9' || ch == '.':
+			start := i
+			for i < len(expr) && (expr[i] >= '0' && expr[i] <= '9' || expr[i] == '.') {
+				i++
+			}
+			text := expr[start:i]
+			value, err := strconv.ParseFloat(text, 64)
+			if err != nil {
+				return nil, &SyntaxError{start, fmt.Sprintf("bad number %q", text)}
+			}
+			tokens = append(tokens, Token{Kind: NumberToken, Text: text, Value: value, Pos: start})
+		case ch == '(':
+			tokens = append(tokens, Token{Kind: LeftParenToken, Text: "(", Pos: i})
+			i++
+		case ch == ')':
+			tokens = append(tokens, Token{Kind: RightParenToken, Text: ")", Pos: i})
+			i++
+		case strings.IndexByte("+-*/^", ch) >= 0:
+			tokens = append(tokens, Token{Kind: OperatorToken, Text: string(ch), Pos: i})
+			i++
+		default:
+			r := []rune(expr[i:])[0]
+			return nil, &SyntaxError{i, fmt.Sprintf("unexpected character %q", r)}
+		}
+	}
+	return tokens, nil
+}
+
+// FormatPostfix joins tokens with spaces, e.g. "3 4 2 * +"
+func FormatPostfix(tokens []Token) string {
+	texts := make([]string, len(tokens))
+	for i, tok := range tokens {
+		texts[i] = tok.Text
+	}
+	return strings.Join(texts, " ")
+}
+
+// EvaluatePostfix computes the value of tokens in postfix order, as
+// returned by ToPostfix
+func EvaluatePostfix(tokens []Token) (float64, error) {
+	values := &StackOf[float64]{}
+	for _, tok := range tokens {
+		if tok.Kind == NumberToken {
+			values.Push(tok.Value)
+			continue
+		}
+		op, ok := operators[tok.Text]
+		if tok.Kind != OperatorToken || !ok {
+			return 0, &SyntaxError{tok.Pos, fmt.Sprintf("unexpected %q in postfix expression", tok.Text)}
+		}
+
+		// The right operand was pushed last, so it comes off first
+		right, err := values.Pop()
+		if err != nil {
+			return 0, &SyntaxError{tok.Pos, fmt.Sprintf("missing operand for %s", tok.Text)}
+		}
+		if op.unary {
+			values.Push(-right)
+			continue
+		}
+		left, err := values.Pop()
+		if err != nil {
+			return 0, &SyntaxError{tok.Pos, fmt.Sprintf("missing operand for %s", tok.Text)}
+		}
+		result, err := apply(tok.Text, left, right)
+		if err != nil {
+			return 0, fmt.Errorf("%w at position %d", err, tok.Pos)
+		}
+		values.Push(result)
+	}
+
+	result, err := values.Pop()
+	if err != nil {
+		return 0, &SyntaxError{0, "empty expression"}
+	}
+	if !values.IsEmpty() {
+		return 0, &SyntaxError{0, fmt.Sprintf("%d values left without an operator", values.Size())}
+	}
+	return result, nil
+}
+
+// apply computes left op right for a binary operator
+func apply(op string, left, right float64) (float64, error) {
+	var result float64
+	switch op {
+	case "+":
+		result = left + right
+	case "-":
+		result = left - right
+	case "*":
+		result = left * right
+	case "/":
+		if right == 0 {
+			return 0, ErrDivisionByZero
+		}
+		result = left / right
+	case "^":
+		result = math.Pow(left, right)
+	}
+	if math.IsInf(result, 0) || math.IsNaN(result) {
+		return 0, ErrNotFinite
+	}
+	return result, nil
+}
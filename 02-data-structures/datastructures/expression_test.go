@@ -0,0 +1,252 @@
+package datastructures
+
+import (
+	"errors"
+	"math"
+	"strconv"
+	"testing"
+)
+
+func TestToPostfix(t *testing.T) {
+	tests := []struct {
+		expr string
+		want string
+	}{
+		{"42", "42"},
+		{"3 + 4 * 2", "3 4 2 * +"},
+		{"(3 + 4) * 2", "3 4 + 2 *"},
+		{"1 - 2 - 3", "1 2 - 3 -"}, // left to right
+		{"2 ^ 3 ^ 2", "2 3 2 ^ ^"}, // right to left
+		{"-2 ^ 2", "2 2 ^ neg"},
+		{"2 ^ -1", "2 1 neg ^"},
+		{"-3 * 2", "3 neg 2 *"},
+		{"4 / -(1 + 1)", "4 1 1 + neg /"},
+		{"--1", "1 neg neg"},
+		{"((1))", "1"},
+	}
+	for _, tt := range tests {
+		postfix, err := ToPostfix(tt.expr)
+		if err != nil {
+			t.Errorf("ToPostfix(%q) error = %v", tt.expr, err)
+			continue
+		}
+		if got := FormatPostfix(postfix); got != tt.want {
+			t.Errorf("ToPostfix(%q) = %q, want %q", tt.expr, got, tt.want)
+		}
+	}
+}
+
+func TestEvaluate(t *testing.T) {
+	tests := []struct {
+		expr string
+		want float64
+	}{
+		{"1 + 2", 3},
+		{"3 + 4 * 2", 11},
+		{"(3 + 4) * 2", 14},
+		{"10 / 4", 2.5},
+		{"8 - 3 - 2", 3},
+		{"64 / 4 / 2", 8},
+		{"2 ^ 3 ^ 2", 512},
+		{"-2 ^ 2", -4},
+		{"(-2) ^ 2", 4},
+		{"2 ^ -1", 0.5},
+		{"-(1 + 2) * 3", -9},
+		{"1.5 * .5", 0.75},
+		{"\t1+\n2 ", 3},
+	}
+	for _, tt := range tests {
+		if got, err := Evaluate(tt.expr); err != nil || got != tt.want {
+			t.Errorf("Evaluate(%q) = %v, %v; want %v, nil", tt.expr, got, err, tt.want)
+		}
+	}
+}
+
+func TestEvaluateErrors(t *testing.T) {
+	tests := []struct {
+		expr string
+		want error
+		pos  int // for syntax errors
+	}{
+		{"", ErrInvalidExpression, 0},
+		{"   ", ErrInvalidExpression, 0},
+		{"1 +", ErrInvalidExpression, 3},
+		{"* 2", ErrInvalidExpression, 0},
+		{"1 2", ErrInvalidExpression, 2},
+		{"2(3)", ErrInvalidExpression, 1},
+		{"(1 + 2", ErrInvalidExpression, 0},
+		{"1 + 2)", ErrInvalidExpression, 5},
+		{"()", ErrInvalidExpression, 1},
+		{"1 + x", ErrInvalidExpression, 4},
+		{"1.2.3", ErrInvalidExpression, 0},
+		{"1 / 0", ErrDivisionByZero, 0},
+		{"1 / (2 - 2)", ErrDivisionByZero, 0},
+		{"10 ^ 400", ErrNotFinite, 0},
+		{"(-8) ^ 0.5", ErrNotFinite, 0},
+	}
+	for _, tt := range tests {
+		_, err := Evaluate(tt.expr)
+		if !errors.Is(err, tt.want) {
+			t.Errorf("Evaluate(%q) error = %v, want %v", tt.expr, err, tt.want)
+			continue
+		}
+		var syntaxErr *SyntaxError
+		if errors.As(err, &syntaxErr) && syntaxErr.Pos != tt.pos {
+			t.Errorf("Evaluate(%q) error at position %d, want %d", tt.expr, syntaxErr.Pos, tt.pos)
+		}
+	}
+}
+
+func TestEvaluatePostfixRejectsBadInput(t *testing.T) {
+	num := func(v float64) Token {
+		return Token{Kind: NumberToken, Text: strconv.FormatFloat(v, 'g', -1, 64), Value: v}
+	}
+	plus := Token{Kind: OperatorToken, Text: "+"}
+	for _, tokens := range [][]Token{
+		nil,
+		{plus},
+		{num(1), plus},
+		{num(1), num(2)},
+		{num(1), {Kind: LeftParenToken, Text: "("}},
+	} {
+		if _, err := EvaluatePostfix(tokens); !errors.Is(err, ErrInvalidExpression) {
+			t.Errorf("EvaluatePostfix(%v) error = %v, want ErrInvalidExpression", tokens, err)
+		}
+	}
+}
+
+// refParser is the reference for Evaluate: a recursive descent parser that
+// evaluates while it parses, following the grammar
+//
+//	expr    = term { ("+" | "-") term }
+//	term    = unary { ("*" | "/") unary }
+//	unary   = "-" unary | power
+//	power   = primary [ "^" unary ]
+//	primary = number | "(" expr ")"
+type refParser struct {
+	s   string
+	pos int
+}
+
+var errRef = errors.New("reference: invalid")
+
+func (p *refParser) peek() byte {
+	for p.pos < len(p.s) && (p.s[p.pos] == ' ' || p.s[p.pos] == '\t' || p.s[p.pos] == '\n' || p.s[p.pos] == '\r') {
+		p.pos++
+	}
+	if p.pos == len(p.s) {
+		return 0
+	}
+	return p.s[p.pos]
+}
+
+func (p *refParser) expr() (float64, error) {
+	v, err := p.term()
+	for err == nil && (p.peek() == '+' || p.peek() == '-') {
+		op := p.s[p.pos]
+		p.pos++
+		var r float64
+		if r, err = p.term(); err == nil {
+			v, err = apply(string(op), v, r)
+		}
+	}
+	return v, err
+}
+
+func (p *refParser) term() (float64, error) {
+	v, err := p.unary()
+	for err == nil && (p.peek() == '*' || p.peek() == '/') {
+		op := p.s[p.pos]
+		p.pos++
+		var r float64
+		if r, err = p.unary(); err == nil {
+			v, err = apply(string(op), v, r)
+		}
+	}
+	return v, err
+}
+
+func (p *refParser) unary() (float64, error) {
+	if p.peek() == '-' {
+		p.pos++
+		v, err := p.unary()
+		return -v, err
+	}
+	return p.power()
+}
+
+func (p *refParser) power() (float64, error) {
+	v, err := p.primary()
+	if err == nil && p.peek() == '^' {
+		p.pos++
+		var r float64
+		if r, err = p.unary(); err == nil {
+			v, err = apply("^", v, r)
+		}
+	}
+	return v, err
+}
+
+func (p *refParser) primary() (float64, error) {
+	switch ch := p.peek(); {
+	case ch == '(':
+		p.pos++
+		v, err := p.expr()
+		if err != nil {
+			return 0, err
+		}
+		if p.peek() != ')' {
+			return 0, errRef
+		}
+		p.pos++
+		return v, nil
+	case ch >= '0' && ch <= '9' || ch == '.':
+		start := p.pos
+		for p.pos < len(p.s) && (p.s[p.pos] >= '0' && p.s[p.pos] <= '9' || p.s[p.pos] == '.') {
+			p.pos++
+		}
+		v, err := strconv.ParseFloat(p.s[start:p.pos], 64)
+		if err != nil {
+			return 0, errRef
+		}
+		return v, nil
+	}
+	return 0, errRef
+}
+
+func refEvaluate(s string) (float64, error) {
+	p := &refParser{s: s}
+	v, err := p.expr()
+	// Anything left over, even a NUL byte, is an error
+	if p.peek(); err == nil && p.pos != len(p.s) {
+		err = errRef
+	}
+	return v, err
+}
+
+// go test runs the seeds; go test ./02-data-structures/datastructures -fuzz=FuzzEvaluate
+// generates new inputs, including invalid UTF-8
+func FuzzEvaluate(f *testing.F) {
+	for _, seed := range []string{"", "1+2*3", "-(2^-1)^2", "2^3^2", "((1)", "1/0", "1e5", "1.2.3", "10^400-10^400", ".5*-.5", "2 ( 3", "0\x00", "ก+1", "\xff"} {
+		f.Add(seed)
+	}
+	f.Fuzz(func(t *testing.T, s string) {
+		got, err := Evaluate(s)
+		want, refErr := refEvaluate(s)
+		if (err == nil) != (refErr == nil) {
+			t.Fatalf("Evaluate(%q) = %v, %v; reference = %v, %v", s, got, err, want, refErr)
+		}
+		if err != nil {
+			// Every failure is one of the documented errors
+			if !errors.Is(err, ErrInvalidExpression) && !errors.Is(err, ErrDivisionByZero) && !errors.Is(err, ErrNotFinite) {
+				t.Errorf("Evaluate(%q) error = %v", s, err)
+			}
+			return
+		}
+		// Both evaluate the same operations in the same order, so the
+		// floating point results are identical, not just close
+		if got != want && !(got == 0 && want == 0) || math.IsNaN(got) {
+			t.Errorf("Evaluate(%q) = %v, reference = %v", s, got, want)
+		}
+	})
+}
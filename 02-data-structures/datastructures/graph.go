@@ -16,9 +16,9 @@
 // - Recommendation systems
 // - Game development (map navigation)
 
-package main
+package datastructures
 
-import "fmt"
+import "sort"
 
 // Graph represents an adjacency list graph
 // vertices is a map where:
@@ -61,6 +61,40 @@ func (g *Graph) GetNeighbors(vertex int) []int {
 	return g.vertices[vertex]
 }
 
+// Vertices returns the IDs of all vertices in ascending order
+// Time Complexity: O(V log V)
+func (g *Graph) Vertices() []int {
+	vertices := make([]int, 0, len(g.vertices))
+	for vertex := range g.vertices {
+		vertices = append(vertices, vertex)
+	}
+	sort.Ints(vertices)
+	return vertices
+}
+
+// Edges returns every edge once, as [lower vertex, higher vertex], ordered by
+// the lower vertex and then by when the edge was added
+// An edge added twice is returned twice, like GetNeighbors lists it twice
+// Time Complexity: O(V log V + E)
+func (g *Graph) Edges() [][2]int {
+	edges := [][2]int{}
+	for _, vertex := range g.Vertices() {
+		loops := 0
+		for _, neighbor := range g.vertices[vertex] {
+			switch {
+			case neighbor > vertex:
+				edges = append(edges, [2]int{vertex, neighbor})
+			case neighbor == vertex:
+				// AddEdge(v, v) puts v in its own list twice
+				if loops++; loops%2 == 0 {
+					edges = append(edges, [2]int{vertex, vertex})
+				}
+			}
+		}
+	}
+	return edges
+}
+
 // BFS performs breadth-first search starting from a vertex
 // BFS explores all vertices at current depth before moving to next depth
 // Time Complexity: O(V + E)
@@ -104,6 +138,36 @@ func (g *Graph) DFS(start int) []int {
 	return result
 }
 
+// DFSIterative performs the same search as DFS without recursion
+// A path of a million vertices would need a million nested dfsUtil calls;
+// this version keeps the vertices still to visit on a Stack instead
+// Neighbors are pushed in reverse so they are popped, and visited, in the
+// same order as DFS visits them
+// Time Complexity: O(V + E)
+func (g *Graph) DFSIterative(start int) []int {
+	visited := make(map[int]bool)
+	result := []int{}
+	stack := &Stack{}
+	stack.Push(start)
+	for !stack.IsEmpty() {
+		vertex, _ := stack.Pop()
+		// A vertex can be pushed by several neighbors before it is visited
+		if visited[vertex] {
+			continue
+		}
+		visited[vertex] = true
+		result = append(result, vertex)
+
+		neighbors := g.vertices[vertex]
+		for i := len(neighbors) - 1; i >= 0; i-- {
+			if !visited[neighbors[i]] {
+				stack.Push(neighbors[i])
+			}
+		}
+	}
+	return result
+}
+
 // dfsUtil is a helper function for DFS
 // Uses recursion to traverse the graph
 func (g *Graph) dfsUtil(vertex int, visited map[int]bool, result *[]int) {
@@ -119,51 +183,3 @@ func (g *Graph) dfsUtil(vertex int, visited map[int]bool, result *[]int) {
 		}
 	}
 }
-
-func main() {
-	// Create a new graph
-	graph := NewGraph()
-
-	// Example 1: Adding vertices
-	fmt.Println("Example 1: Adding vertices 0-5")
-	for i := 0; i < 6; i++ {
-		graph.AddVertex(i)
-	}
-
-	// Example 2: Adding edges to create this graph:
-	// 0 -- 1 -- 2
-	// |    |    |
-	// 3 -- 4 -- 5
-	fmt.Println("\nExample 2: Adding edges")
-	edges := [][2]int{
-		{0, 1}, {1, 2}, // Top row
-		{0, 3}, {1, 4}, {2, 5}, // Vertical connections
-		{3, 4}, {4, 5}, // Bottom row
-	}
-	for _, edge := range edges {
-		graph.AddEdge(edge[0], edge[1])
-		fmt.Printf("Added edge: %d -- %d\n", edge[0], edge[1])
-	}
-
-	// Example 3: Print adjacency list
-	fmt.Println("\nExample 3: Graph Adjacency List:")
-	for vertex, neighbors := range graph.vertices {
-		fmt.Printf("Vertex %d: %v\n", vertex, neighbors)
-	}
-
-	// Example 4: BFS traversal
-	fmt.Println("\nExample 4: BFS starting from vertex 0:")
-	bfsResult := graph.BFS(0)
-	fmt.Printf("BFS path: %v\n", bfsResult)
-
-	// Example 5: DFS traversal
-	fmt.Println("\nExample 5: DFS starting from vertex 0:")
-	dfsResult := graph.DFS(0)
-	fmt.Printf("DFS path: %v\n", dfsResult)
-
-	// Example 6: Finding neighbors
-	vertex := 1
-	fmt.Printf("\nExample 6: Neighbors of vertex %d:\n", vertex)
-	neighbors := graph.GetNeighbors(vertex)
-	fmt.Printf("Neighbors: %v\n", neighbors)
-}
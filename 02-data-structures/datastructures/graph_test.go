@@ -0,0 +1,136 @@
+package datastructures
+
+import (
+	"slices"
+	"testing"
+)
+
+func newTestGraph(edges [][2]int) *Graph {
+	g := NewGraph()
+	for _, e := range edges {
+		g.AddEdge(e[0], e[1])
+	}
+	return g
+}
+
+func TestGraphAddVertexAndEdge(t *testing.T) {
+	g := NewGraph()
+	g.AddVertex(1)
+	g.AddVertex(1) // Adding twice must not reset the adjacency list
+	if got := g.GetNeighbors(1); len(got) != 0 {
+		t.Fatalf("GetNeighbors(1) = %v, want none", got)
+	}
+
+	g.AddEdge(1, 2)
+	g.AddVertex(1)
+	if got := g.GetNeighbors(1); !slices.Equal(got, []int{2}) {
+		t.Errorf("GetNeighbors(1) = %v, want [2]", got)
+	}
+	// Edges are undirected
+	if got := g.GetNeighbors(2); !slices.Equal(got, []int{1}) {
+		t.Errorf("GetNeighbors(2) = %v, want [1]", got)
+	}
+	if got := g.GetNeighbors(99); got != nil {
+		t.Errorf("GetNeighbors of unknown vertex = %v, want nil", got)
+	}
+}
+
+func TestGraphTraversals(t *testing.T) {
+	tests := []struct {
+		name  string
+		edges [][2]int
+		start int
+		bfs   []int
+		dfs   []int
+	}{
+		// 0 - 1 - 3
+		// |   |
+		// 2 - 4
+		{"cycle", [][2]int{{0, 1}, {0, 2}, {1, 3}, {1, 4}, {2, 4}}, 0, []int{0, 1, 2, 3, 4}, []int{0, 1, 3, 4, 2}},
+		{"path from the end", [][2]int{{1, 2}, {2, 3}, {3, 4}}, 4, []int{4, 3, 2, 1}, []int{4, 3, 2, 1}},
+		{"star", [][2]int{{0, 1}, {0, 2}, {0, 3}}, 0, []int{0, 1, 2, 3}, []int{0, 1, 2, 3}},
+		{"disconnected component is not reached", [][2]int{{0, 1}, {5, 6}}, 0, []int{0, 1}, []int{0, 1}},
+		{"self loop", [][2]int{{1, 1}, {1, 2}}, 1, []int{1, 2}, []int{1, 2}},
+		{"parallel edges", [][2]int{{1, 2}, {1, 2}}, 2, []int{2, 1}, []int{2, 1}},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			g := newTestGraph(tt.edges)
+			if got := g.BFS(tt.start); !slices.Equal(got, tt.bfs) {
+				t.Errorf("BFS(%d) = %v, want %v", tt.start, got, tt.bfs)
+			}
+			if got := g.DFS(tt.start); !slices.Equal(got, tt.dfs) {
+				t.Errorf("DFS(%d) = %v, want %v", tt.start, got, tt.dfs)
+			}
+			if got := g.DFSIterative(tt.start); !slices.Equal(got, tt.dfs) {
+				t.Errorf("DFSIterative(%d) = %v, want %v", tt.start, got, tt.dfs)
+			}
+		})
+	}
+}
+
+func TestGraphEdges(t *testing.T) {
+	g := newTestGraph([][2]int{{3, 1}, {1, 2}, {2, 2}, {1, 3}})
+	g.AddVertex(9)
+	// Each edge once, lower vertex first; the repeated 1 -- 3 twice
+	want := [][2]int{{1, 3}, {1, 2}, {1, 3}, {2, 2}}
+	if got := g.Edges(); !slices.Equal(got, want) {
+		t.Errorf("Edges() = %v, want %v", got, want)
+	}
+	if got := NewGraph().Edges(); len(got) != 0 {
+		t.Errorf("Edges() of an empty graph = %v", got)
+	}
+}
+
+func TestGraphIsolatedVertex(t *testing.T) {
+	g := NewGraph()
+	g.AddVertex(7)
+	if got := g.BFS(7); !slices.Equal(got, []int{7}) {
+		t.Errorf("BFS(7) = %v, want [7]", got)
+	}
+	if got := g.DFS(7); !slices.Equal(got, []int{7}) {
+		t.Errorf("DFS(7) = %v, want [7]", got)
+	}
+}
+
+// BFS visits vertices in order of distance from the start
+func TestGraphBFSOrdersByDistance(t *testing.T) {
+	// A 4x4 grid; vertex r*4+c
+	g := NewGraph()
+	for r := 0; r < 4; r++ {
+		for c := 0; c < 4; c++ {
+			if c < 3 {
+				g.AddEdge(r*4+c, r*4+c+1)
+			}
+			if r < 3 {
+				g.AddEdge(r*4+c, (r+1)*4+c)
+			}
+		}
+	}
+	order := g.BFS(0)
+	if len(order) != 16 {
+		t.Fatalf("BFS visited %d vertices, want 16", len(order))
+	}
+	distance := func(v int) int { return v/4 + v%4 } // Manhattan distance from 0
+	for i := 1; i < len(order); i++ {
+		if distance(order[i]) < distance(order[i-1]) {
+			t.Fatalf("BFS order %v visits %d before %d", order, order[i-1], order[i])
+		}
+	}
+	if got := len(g.DFS(0)); got != 16 {
+		t.Errorf("DFS visited %d vertices, want 16", got)
+	}
+}
+
+// A path of a million vertices is a million levels deep for DFS
+func TestDFSIterativeLongPath(t *testing.T) {
+	const n = 1_000_000
+	g := NewGraph()
+	for i := 0; i < n-1; i++ {
+		g.AddEdge(i, i+1)
+	}
+	got := g.DFSIterative(0)
+	if len(got) != n || got[0] != 0 || got[n-1] != n-1 || !slices.IsSorted(got) {
+		t.Errorf("DFSIterative(0) on a path visited %d vertices, want 0..%d in order", len(got), n-1)
+	}
+}
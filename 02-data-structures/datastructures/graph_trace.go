@@ -0,0 +1,53 @@
+package datastructures
+
+import "github.com/NutProhmpiriya/go-basic/trace"
+
+// BFSTrace is BFS reporting its steps to t: a Visit event for every vertex
+// in the order BFS returns them, and for every edge it follows an Edge event
+// followed by an Enqueue event for the neighbor it adds to the queue
+// Tracing with trace.NewDOTFrames(g.Vertices(), g.Edges()) gives a picture
+// of the graph per step
+func (g *Graph) BFSTrace(start int, t trace.Tracer) []int {
+	visited := map[int]bool{start: true}
+	queue := []int{start}
+	result := []int{}
+
+	for len(queue) > 0 {
+		vertex := queue[0]
+		queue = queue[1:]
+		result = append(result, vertex)
+		t.Step(trace.Event{Op: trace.Visit, I: vertex})
+
+		for _, neighbor := range g.vertices[vertex] {
+			if !visited[neighbor] {
+				t.Step(trace.Event{Op: trace.Edge, I: vertex, J: neighbor})
+				visited[neighbor] = true
+				queue = append(queue, neighbor)
+				t.Step(trace.Event{Op: trace.Enqueue, I: neighbor})
+			}
+		}
+	}
+	return result
+}
+
+// DFSTrace is DFS reporting its steps to t, like BFSTrace
+// It has no Enqueue events: the vertices DFS will come back to are on the
+// call stack, not in a frontier of found but unvisited vertices
+func (g *Graph) DFSTrace(start int, t trace.Tracer) []int {
+	visited := make(map[int]bool)
+	result := []int{}
+	var visit func(vertex int)
+	visit = func(vertex int) {
+		visited[vertex] = true
+		result = append(result, vertex)
+		t.Step(trace.Event{Op: trace.Visit, I: vertex})
+		for _, neighbor := range g.vertices[vertex] {
+			if !visited[neighbor] {
+				t.Step(trace.Event{Op: trace.Edge, I: vertex, J: neighbor})
+				visit(neighbor)
+			}
+		}
+	}
+	visit(start)
+	return result
+}
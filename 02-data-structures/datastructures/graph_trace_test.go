@@ -0,0 +1,87 @@
+package datastructures
+
+import (
+	"slices"
+	"strings"
+	"testing"
+
+	"github.com/NutProhmpiriya/go-basic/trace"
+)
+
+func TestTracedTraversals(t *testing.T) {
+	// 0 -- 1 -- 2
+	// |    |    |
+	// 3 -- 4 -- 5
+	g := NewGraph()
+	for _, edge := range [][2]int{{0, 1}, {1, 2}, {0, 3}, {1, 4}, {2, 5}, {3, 4}, {4, 5}} {
+		g.AddEdge(edge[0], edge[1])
+	}
+	traversals := map[string]struct {
+		traced   func(int, trace.Tracer) []int
+		plain    func(int) []int
+		enqueues bool
+	}{
+		"BFSTrace": {g.BFSTrace, g.BFS, true},
+		"DFSTrace": {g.DFSTrace, g.DFS, false},
+	}
+	for name, traversal := range traversals {
+		var rec trace.Recorder
+		got := traversal.traced(0, &rec)
+		if want := traversal.plain(0); !slices.Equal(got, want) {
+			t.Errorf("%s(0) = %v, want %v", name, got, want)
+		}
+
+		// Visit events come in result order, and every vertex but the start
+		// is reached over exactly one followed edge
+		var visits []int
+		for _, e := range rec.Events {
+			if e.Op == trace.Visit {
+				visits = append(visits, e.I)
+			}
+		}
+		if !slices.Equal(visits, got) {
+			t.Errorf("%s visit events %v, want %v", name, visits, got)
+		}
+		if edges := rec.Count(trace.Edge); edges != len(got)-1 {
+			t.Errorf("%s followed %d edges, want %d", name, edges, len(got)-1)
+		}
+
+		// BFS enqueues each neighbor right after following the edge to it,
+		// and visits it later
+		if !traversal.enqueues {
+			if n := rec.Count(trace.Enqueue); n != 0 {
+				t.Errorf("%s has %d enqueue events, want none", name, n)
+			}
+			continue
+		}
+		for i, e := range rec.Events {
+			if e.Op != trace.Edge {
+				continue
+			}
+			if i+1 == len(rec.Events) || rec.Events[i+1] != (trace.Event{Op: trace.Enqueue, I: e.J}) {
+				t.Errorf("%s: %v is not followed by enqueue %d", name, e, e.J)
+			}
+		}
+	}
+}
+
+func TestTracedBFSFrames(t *testing.T) {
+	g := NewGraph()
+	for _, edge := range [][2]int{{0, 1}, {1, 2}, {0, 2}} {
+		g.AddEdge(edge[0], edge[1])
+	}
+	frames := trace.NewDOTFrames(g.Vertices(), g.Edges())
+	g.BFSTrace(0, frames)
+	got := frames.Frames()
+	// visit 0, then edge and enqueue for 1 and 2, then visit 1 and 2
+	if len(got) != 7 {
+		t.Fatalf("got %d frames, want 7", len(got))
+	}
+	// In the last frame every vertex is visited and the edge 1 -- 2 was
+	// never followed, since 2 was already in the queue
+	for _, line := range []string{"\t0 [fillcolor=gray];", "\t2 [fillcolor=gold, penwidth=2];", "\t0 -- 2 [penwidth=3];", "\t1 -- 2;"} {
+		if !strings.Contains(got[6], line) {
+			t.Errorf("last frame is missing %q:\n%s", line, got[6])
+		}
+	}
+}
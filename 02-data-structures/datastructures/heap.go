@@ -0,0 +1,152 @@
+// This file implements a binary heap data structure in Go
+// A heap keeps its smallest item (by the order it is given) at the top,
+// ready to be taken, without keeping everything sorted
+// It is a complete binary tree stored in a slice: the children of the item
+// at index i are at 2i+1 and 2i+2, and its parent at (i-1)/2. Every item
+// is no greater than its children, which is all it takes for the smallest
+// to be at index 0
+//
+// Time Complexity:
+// - Push: O(log n): the new item moves up until its parent is smaller
+// - Pop: O(log n): the last item moves to the top and sinks down
+// - Peek: O(1)
+// - NewHeap with n items: O(n), not O(n log n), see heapify
+//
+// Use Cases:
+// - Priority queues and schedulers
+// - Dijkstra's shortest paths and A* search
+// - Merging sorted sequences and running medians
+// - The k largest or most frequent items of a stream
+
+package datastructures
+
+import (
+	"cmp"
+	"fmt"
+
+	"github.com/NutProhmpiriya/go-basic/ordering"
+)
+
+// Heap is a binary heap of items of type T
+// less decides the order: the item that is less than all others is on top,
+// so cmp.Less gives a min-heap and a reversed comparison a max-heap
+type Heap[T any] struct {
+	items []T
+	less  func(a, b T) bool
+}
+
+// NewHeap returns a heap ordered by less holding the given items
+// The items are copied, so the caller's slice is left alone
+// Time Complexity: O(n)
+func NewHeap[T any](less func(a, b T) bool, items ...T) *Heap[T] {
+	h := &Heap[T]{items: append([]T(nil), items...), less: less}
+	h.heapify()
+	return h
+}
+
+// NewHeapFunc returns a heap ordered by compare, the item that compares
+// before all others on top, so it takes the same ordering.Comparator as
+// OrderStatTree and the sorts
+// Time Complexity: O(n)
+func NewHeapFunc[T any](compare func(a, b T) int, items ...T) *Heap[T] {
+	return NewHeap(ordering.Comparator[T](compare).Less, items...)
+}
+
+// NewMinHeap returns a heap with the smallest item on top
+func NewMinHeap[T cmp.Ordered](items ...T) *Heap[T] {
+	return NewHeapFunc(ordering.Natural[T](), items...)
+}
+
+// NewMaxHeap returns a heap with the largest item on top
+func NewMaxHeap[T cmp.Ordered](items ...T) *Heap[T] {
+	return NewHeapFunc(ordering.Natural[T]().Reversed(), items...)
+}
+
+// heapify puts the items in heap order by sinking every item that has
+// children, from the last one back to the root
+// Most items are near the bottom and sink only a level or two, which is why
+// this takes O(n) while pushing the items one by one takes O(n log n)
+func (h *Heap[T]) heapify() {
+	for i := len(h.items)/2 - 1; i >= 0; i-- {
+		h.down(i)
+	}
+}
+
+// Push adds an item to the heap
+// Time Complexity: O(log n)
+func (h *Heap[T]) Push(item T) {
+	h.items = append(h.items, item)
+	h.up(len(h.items) - 1)
+}
+
+// Pop removes and returns the top item
+// Time Complexity: O(log n)
+func (h *Heap[T]) Pop() (T, error) {
+	var zero T
+	if len(h.items) == 0 {
+		return zero, fmt.Errorf("heap is empty")
+	}
+	top := h.items[0]
+	last := len(h.items) - 1
+	// Move the last item to the top and let it sink to its place, clearing
+	// its old slot so a popped pointer doesn't keep its target alive
+	h.items[0] = h.items[last]
+	h.items[last] = zero
+	h.items = h.items[:last]
+	h.down(0)
+	return top, nil
+}
+
+// Peek returns the top item without removing it
+// Time Complexity: O(1)
+func (h *Heap[T]) Peek() (T, error) {
+	if len(h.items) == 0 {
+		var zero T
+		return zero, fmt.Errorf("heap is empty")
+	}
+	return h.items[0], nil
+}
+
+// IsEmpty returns true if the heap is empty
+// Time Complexity: O(1)
+func (h *Heap[T]) IsEmpty() bool {
+	return len(h.items) == 0
+}
+
+// Size returns the number of items in the heap
+// Time Complexity: O(1)
+func (h *Heap[T]) Size() int {
+	return len(h.items)
+}
+
+// up moves the item at i towards the root while it is less than its parent
+func (h *Heap[T]) up(i int) {
+	for i > 0 {
+		parent := (i - 1) / 2
+		if !h.less(h.items[i], h.items[parent]) {
+			return
+		}
+		h.items[i], h.items[parent] = h.items[parent], h.items[i]
+		i = parent
+	}
+}
+
+// down moves the item at i towards the leaves while a child is less than it,
+// swapping it with the lesser child so that child can be the new parent
+func (h *Heap[T]) down(i int) {
+	n := len(h.items)
+	for {
+		least := i
+		if left := 2*i + 1; left < n && h.less(h.items[left], h.items[least]) {
+			least = left
+		}
+		if right := 2*i + 2; right < n && h.less(h.items[right], h.items[least]) {
+			least = right
+		}
+		if least == i {
+			return
+		}
+		h.items[i], h.items[least] = h.items[least], h.items[i]
+		i = least
+	}
+}
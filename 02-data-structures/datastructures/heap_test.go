@@ -0,0 +1,151 @@
+package datastructures
+
+import (
+	"slices"
+	"testing"
+
+	"github.com/NutProhmpiriya/go-basic/ordering"
+	"github.com/NutProhmpiriya/go-basic/testdata/generator"
+)
+
+// checkHeap checks the heap property: no item is less than its parent
+func checkHeap[T any](t *testing.T, h *Heap[T]) {
+	t.Helper()
+	for i := 1; i < len(h.items); i++ {
+		if h.less(h.items[i], h.items[(i-1)/2]) {
+			t.Fatalf("item %d is less than its parent: %v", i, h.items)
+		}
+	}
+}
+
+// drain pops every item
+func drain[T any](t *testing.T, h *Heap[T]) []T {
+	t.Helper()
+	var out []T
+	for !h.IsEmpty() {
+		item, err := h.Pop()
+		if err != nil {
+			t.Fatal(err)
+		}
+		out = append(out, item)
+		checkHeap(t, h)
+	}
+	return out
+}
+
+func TestHeapOrder(t *testing.T) {
+	g := generator.New(1)
+	inputs := map[string][]int{
+		"empty":      nil,
+		"single":     {4},
+		"sorted":     generator.Sorted(50),
+		"reversed":   generator.Reversed(50),
+		"few unique": g.FewUnique(200, 3),
+		"random":     g.Ints(301, 1000),
+	}
+	for name, values := range inputs {
+		t.Run(name, func(t *testing.T) {
+			ascending := slices.Sorted(slices.Values(values))
+			descending := slices.Clone(ascending)
+			slices.Reverse(descending)
+
+			// Built all at once with heapify
+			min := NewMinHeap(values...)
+			checkHeap(t, min)
+			if got := drain(t, min); !slices.Equal(got, ascending) {
+				t.Errorf("NewMinHeap popped %v, want %v", got, ascending)
+			}
+
+			// Built one Push at a time
+			max := NewMaxHeap[int]()
+			for _, v := range values {
+				max.Push(v)
+				checkHeap(t, max)
+			}
+			if max.Size() != len(values) {
+				t.Errorf("Size() = %d, want %d", max.Size(), len(values))
+			}
+			if got := drain(t, max); !slices.Equal(got, descending) {
+				t.Errorf("NewMaxHeap popped %v, want %v", got, descending)
+			}
+		})
+	}
+}
+
+func TestHeapPushPopInterleaved(t *testing.T) {
+	h := NewMinHeap(5, 3)
+	h.Push(4)
+	if top, _ := h.Peek(); top != 3 {
+		t.Errorf("Peek() = %d, want 3", top)
+	}
+	if got, _ := h.Pop(); got != 3 {
+		t.Errorf("Pop() = %d, want 3", got)
+	}
+	h.Push(1)
+	h.Push(6)
+	if got := drain(t, h); !slices.Equal(got, []int{1, 4, 5, 6}) {
+		t.Errorf("popped %v, want [1 4 5 6]", got)
+	}
+
+	if _, err := h.Pop(); err == nil {
+		t.Error("Pop() on an empty heap did not fail")
+	}
+	if _, err := h.Peek(); err == nil {
+		t.Error("Peek() on an empty heap did not fail")
+	}
+}
+
+func TestHeapCustomOrder(t *testing.T) {
+	type task struct {
+		name     string
+		priority int
+	}
+	// Highest priority first, then by name
+	h := NewHeap(func(a, b task) bool {
+		if a.priority != b.priority {
+			return a.priority > b.priority
+		}
+		return a.name < b.name
+	}, task{"write", 1}, task{"deploy", 3}, task{"test", 3}, task{"lint", 2})
+
+	var order []string
+	for _, task := range drain(t, h) {
+		order = append(order, task.name)
+	}
+	if want := []string{"deploy", "test", "lint", "write"}; !slices.Equal(order, want) {
+		t.Errorf("order = %v, want %v", order, want)
+	}
+}
+
+// NewHeapFunc takes the Comparator that orders a sort or an OrderStatTree
+// the same way
+func TestHeapFunc(t *testing.T) {
+	type task struct {
+		name     string
+		priority int
+	}
+	byPriority := ordering.By(func(t task) int { return t.priority }).Reversed().
+		ThenComparing(ordering.By(func(t task) string { return t.name }))
+	tasks := []task{{"write", 1}, {"deploy", 3}, {"test", 3}, {"lint", 2}}
+	got := drain(t, NewHeapFunc(byPriority, tasks...))
+	slices.SortFunc(tasks, byPriority)
+	if !slices.Equal(got, tasks) {
+		t.Errorf("heap order = %v, sorted order = %v", got, tasks)
+	}
+}
+
+func TestHeapCopiesItsInput(t *testing.T) {
+	values := []int{3, 1, 2}
+	h := NewMinHeap(values...)
+	h.Pop()
+	if !slices.Equal(values, []int{3, 1, 2}) {
+		t.Errorf("NewMinHeap changed its input to %v", values)
+	}
+
+	// Pop clears the slot it frees
+	p := NewHeap(func(a, b *int) bool { return *a < *b }, &values[0], &values[1])
+	p.Pop()
+	if spare := p.items[:2][1]; spare != nil {
+		t.Error("Pop left a pointer in the freed slot")
+	}
+}
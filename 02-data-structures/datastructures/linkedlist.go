@@ -0,0 +1,303 @@
+// This file implements a singly linked list data structure in Go
+// A linked list is a linear data structure where elements are stored in nodes,
+// and each node points to the next node in the sequence
+//
+// Time Complexity:
+// - Insert at end: O(1) thanks to the tail pointer
+// - Insert at or get an index: O(index)
+// - Delete: O(n)
+// - Search, Reverse, FindMiddle, RemoveNthFromEnd: O(n)
+// - Len, Front, TakeNodes: O(1)
+// where n is the number of nodes in the list
+//
+// Use Cases:
+// - When frequent insertions and deletions are needed
+// - When the size of the data is unknown in advance
+// - When memory needs to be allocated dynamically
+
+package datastructures
+
+import (
+	"errors"
+	"fmt"
+)
+
+var (
+	// ErrIndexOutOfRange is returned for an index outside the list
+	ErrIndexOutOfRange = errors.New("index out of range")
+	// ErrCycle is returned by SetNodes for a chain of nodes that loops back
+	// on itself instead of ending
+	ErrCycle = errors.New("nodes form a cycle")
+)
+
+// Node represents a node in the linked list
+// Each node contains:
+// - data: the value stored in the node
+// - next: pointer to the next node in the sequence
+type Node struct {
+	data int
+	next *Node
+}
+
+// LinkedList represents the linked list
+// It keeps pointers to the head (first node) and the tail (last node),
+// and counts its nodes. Head being nil indicates an empty list
+// The zero value is an empty list ready to use
+type LinkedList struct {
+	head *Node
+	tail *Node
+	size int
+}
+
+// Len returns the number of nodes in the list
+// Time Complexity: O(1)
+func (l *LinkedList) Len() int {
+	return l.size
+}
+
+// Insert adds a new node at the end of the list
+// It is the same as InsertBack
+// Time Complexity: O(1)
+func (l *LinkedList) Insert(data int) {
+	l.InsertBack(data)
+}
+
+// InsertBack adds a new node at the end of the list
+// Time Complexity: O(1): the tail pointer saves walking to the last node
+func (l *LinkedList) InsertBack(data int) {
+	newNode := &Node{data: data}
+	if l.tail == nil {
+		// Empty list: the new node is both head and tail
+		l.head = newNode
+	} else {
+		l.tail.next = newNode
+	}
+	l.tail = newNode
+	l.size++
+}
+
+// InsertAt adds a new node so it ends up at position index
+// Index 0 inserts at the front and index Len() at the end
+// Time Complexity: O(index)
+func (l *LinkedList) InsertAt(index, data int) error {
+	if index < 0 || index > l.size {
+		return fmt.Errorf("%w: insert at %d in a list of %d", ErrIndexOutOfRange, index, l.size)
+	}
+	if index == l.size {
+		l.InsertBack(data)
+		return nil
+	}
+	if index == 0 {
+		l.head = &Node{data: data, next: l.head}
+		l.size++
+		return nil
+	}
+
+	// Link the new node in after the node before index
+	prev := l.nodeAt(index - 1)
+	prev.next = &Node{data: data, next: prev.next}
+	l.size++
+	return nil
+}
+
+// Get returns the value at position index
+// Time Complexity: O(index)
+func (l *LinkedList) Get(index int) (int, error) {
+	if index < 0 || index >= l.size {
+		return 0, fmt.Errorf("%w: get %d in a list of %d", ErrIndexOutOfRange, index, l.size)
+	}
+	return l.nodeAt(index).data, nil
+}
+
+// nodeAt walks to the node at a valid index
+func (l *LinkedList) nodeAt(index int) *Node {
+	current := l.head
+	for range index {
+		current = current.next
+	}
+	return current
+}
+
+// Delete removes the first occurrence of data in the list
+// Returns true if the element was found and deleted, false otherwise
+// Time Complexity: O(n) where n is the number of nodes
+func (l *LinkedList) Delete(data int) bool {
+	// Case 1: Empty list
+	if l.head == nil {
+		return false
+	}
+
+	// Case 2: Data is in head node
+	if l.head.data == data {
+		l.removeAfter(nil)
+		return true
+	}
+
+	// Case 3: Data is in other nodes
+	// Traverse the list looking for the node to delete
+	current := l.head
+	for current.next != nil {
+		if current.next.data == data {
+			l.removeAfter(current)
+			return true
+		}
+		current = current.next
+	}
+	return false
+}
+
+// removeAfter unlinks the node after prev, or the head if prev is nil,
+// keeping the tail and the size up to date
+func (l *LinkedList) removeAfter(prev *Node) *Node {
+	var removed *Node
+	if prev == nil {
+		removed = l.head
+		l.head = removed.next
+	} else {
+		removed = prev.next
+		// Update the next pointer to skip over it
+		prev.next = removed.next
+	}
+	if removed == l.tail {
+		l.tail = prev
+	}
+	l.size--
+	return removed
+}
+
+// Reverse reverses the list in place
+// Each node's next pointer is turned around while walking the list once,
+// so the old head becomes the tail
+// Time Complexity: O(n)
+// Space Complexity: O(1)
+func (l *LinkedList) Reverse() {
+	var prev *Node
+	current := l.head
+	l.tail = l.head
+	for current != nil {
+		next := current.next
+		current.next = prev
+		prev = current
+		current = next
+	}
+	l.head = prev
+}
+
+// FindMiddle returns the value in the middle of the list, the second of the
+// two middle values when the length is even
+// It uses the fast and slow pointer technique: the fast pointer moves two
+// nodes for every one of the slow pointer, so the slow one is halfway when
+// the fast one reaches the end. That works without knowing the length
+// Time Complexity: O(n)
+func (l *LinkedList) FindMiddle() (int, error) {
+	if l.head == nil {
+		return 0, fmt.Errorf("list is empty")
+	}
+	slow, fast := l.head, l.head
+	for fast != nil && fast.next != nil {
+		slow = slow.next
+		fast = fast.next.next
+	}
+	return slow.data, nil
+}
+
+// RemoveNthFromEnd removes the nth node counting from the end, where 1 is the
+// last node, and returns its value
+// Like FindMiddle it uses two pointers and no length: the lead pointer
+// starts n nodes ahead, so when it falls off the end the other one is just
+// before the node to remove
+// Time Complexity: O(n)
+func (l *LinkedList) RemoveNthFromEnd(n int) (int, error) {
+	if n < 1 {
+		return 0, fmt.Errorf("%w: remove %d from the end", ErrIndexOutOfRange, n)
+	}
+	lead := l.head
+	for i := 0; i < n; i++ {
+		if lead == nil {
+			return 0, fmt.Errorf("%w: remove %d from the end of a list of %d", ErrIndexOutOfRange, n, i)
+		}
+		lead = lead.next
+	}
+
+	// prev trails lead by n+1 nodes; nil stands for "before the head"
+	var prev *Node
+	for lead != nil {
+		lead = lead.next
+		if prev == nil {
+			prev = l.head
+		} else {
+			prev = prev.next
+		}
+	}
+	return l.removeAfter(prev).data, nil
+}
+
+// Front returns the first node, or nil for an empty list
+// Together with Node.Next it lets code outside the package walk the list
+// Time Complexity: O(1)
+func (l *LinkedList) Front() *Node {
+	return l.head
+}
+
+// Value returns the value stored in the node
+func (n *Node) Value() int {
+	return n.data
+}
+
+// Next returns the node after n, or nil at the end of the list
+func (n *Node) Next() *Node {
+	return n.next
+}
+
+// SetNext links next after n
+// Only relink nodes taken out with TakeNodes: a list doesn't notice when
+// its nodes change, so its tail and length would be wrong
+func (n *Node) SetNext(next *Node) {
+	n.next = next
+}
+
+// TakeNodes removes every node from the list and returns the first one,
+// still linked together. The list is empty afterwards
+// With SetNodes it lets algorithms outside the package relink the nodes
+// themselves, the way merge sort or reordering a list work
+// Time Complexity: O(1)
+func (l *LinkedList) TakeNodes() *Node {
+	head := l.head
+	l.head, l.tail, l.size = nil, nil, 0
+	return head
+}
+
+// SetNodes makes the chain of nodes starting at head the contents of the
+// list, replacing what it held. It walks the chain to find the tail and the
+// length, and returns ErrCycle, leaving the list unchanged, if the chain
+// never ends. A nil head empties the list
+// Time Complexity: O(n)
+func (l *LinkedList) SetNodes(head *Node) error {
+	// Like FindMiddle, but a fast pointer that catches up with the slow
+	// one has gone round a loop (Floyd's cycle detection)
+	slow, fast := head, head
+	for fast != nil && fast.next != nil {
+		slow, fast = slow.next, fast.next.next
+		if slow == fast {
+			return ErrCycle
+		}
+	}
+
+	l.head, l.tail, l.size = head, nil, 0
+	for n := head; n != nil; n = n.next {
+		l.tail = n
+		l.size++
+	}
+	return nil
+}
+
+// Print displays all elements in the list
+// Format: value1 -> value2 -> value3 -> nil
+func (l *LinkedList) Print() {
+	current := l.head
+	for current != nil {
+		fmt.Printf("%d -> ", current.data)
+		current = current.next
+	}
+	fmt.Println("nil")
+}
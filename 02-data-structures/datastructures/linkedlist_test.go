@@ -0,0 +1,298 @@
+package datastructures
+
+import (
+	"errors"
+	"io"
+	"os"
+	"slices"
+	"strings"
+	"testing"
+)
+
+// values walks the list from head to tail
+func values(l *LinkedList) []int {
+	result := []int{}
+	for n := l.head; n != nil; n = n.next {
+		result = append(result, n.data)
+	}
+	return result
+}
+
+func newList(items ...int) *LinkedList {
+	l := &LinkedList{}
+	for _, v := range items {
+		l.Insert(v)
+	}
+	return l
+}
+
+func TestLinkedListInsert(t *testing.T) {
+	tests := []struct {
+		name  string
+		items []int
+	}{
+		{"empty", nil},
+		{"single", []int{1}},
+		{"keeps insertion order", []int{3, 1, 2}},
+		{"duplicates", []int{4, 4}},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got := values(newList(tt.items...))
+			want := append([]int{}, tt.items...)
+			if !slices.Equal(got, want) {
+				t.Errorf("list = %v, want %v", got, want)
+			}
+		})
+	}
+}
+
+func TestLinkedListDelete(t *testing.T) {
+	tests := []struct {
+		name    string
+		items   []int
+		delete  int
+		deleted bool
+		want    []int
+	}{
+		{"empty list", nil, 1, false, []int{}},
+		{"only element", []int{1}, 1, true, []int{}},
+		{"head", []int{1, 2, 3}, 1, true, []int{2, 3}},
+		{"middle", []int{1, 2, 3}, 2, true, []int{1, 3}},
+		{"tail", []int{1, 2, 3}, 3, true, []int{1, 2}},
+		{"missing", []int{1, 2, 3}, 4, false, []int{1, 2, 3}},
+		{"first duplicate only", []int{1, 2, 1}, 1, true, []int{2, 1}},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			l := newList(tt.items...)
+			if got := l.Delete(tt.delete); got != tt.deleted {
+				t.Errorf("Delete(%d) = %v, want %v", tt.delete, got, tt.deleted)
+			}
+			checkList(t, l, tt.want)
+		})
+	}
+}
+
+func TestLinkedListInsertAfterDeletingTail(t *testing.T) {
+	l := newList(1, 2)
+	l.Delete(2)
+	l.Insert(3)
+	if got := values(l); !slices.Equal(got, []int{1, 3}) {
+		t.Errorf("list = %v, want [1 3]", got)
+	}
+}
+
+// checkList compares the list with want, and checks that the length and
+// the tail pointer agree with the nodes
+func checkList(t *testing.T, l *LinkedList, want []int) {
+	t.Helper()
+	if got := values(l); !slices.Equal(got, want) {
+		t.Errorf("list = %v, want %v", got, want)
+	}
+	if l.Len() != len(want) {
+		t.Errorf("Len() = %d, want %d", l.Len(), len(want))
+	}
+	var last *Node
+	for n := l.head; n != nil; n = n.next {
+		last = n
+	}
+	if l.tail != last {
+		t.Errorf("tail is not the last node")
+	}
+}
+
+func TestLinkedListInsertAt(t *testing.T) {
+	tests := []struct {
+		name  string
+		items []int
+		index int
+		want  []int
+	}{
+		{"into empty", nil, 0, []int{9}},
+		{"front", []int{1, 2}, 0, []int{9, 1, 2}},
+		{"middle", []int{1, 2}, 1, []int{1, 9, 2}},
+		{"end", []int{1, 2}, 2, []int{1, 2, 9}},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			l := newList(tt.items...)
+			if err := l.InsertAt(tt.index, 9); err != nil {
+				t.Fatalf("InsertAt(%d) = %v", tt.index, err)
+			}
+			checkList(t, l, tt.want)
+			// The tail must still be right for the next InsertBack
+			l.InsertBack(10)
+			checkList(t, l, append(tt.want, 10))
+		})
+	}
+
+	l := newList(1, 2)
+	for _, index := range []int{-1, 3} {
+		if err := l.InsertAt(index, 9); !errors.Is(err, ErrIndexOutOfRange) {
+			t.Errorf("InsertAt(%d) error = %v, want ErrIndexOutOfRange", index, err)
+		}
+	}
+	checkList(t, l, []int{1, 2})
+}
+
+func TestLinkedListGet(t *testing.T) {
+	l := newList(10, 20, 30)
+	for i, want := range []int{10, 20, 30} {
+		if got, err := l.Get(i); err != nil || got != want {
+			t.Errorf("Get(%d) = %d, %v; want %d, nil", i, got, err, want)
+		}
+	}
+	for _, index := range []int{-1, 3} {
+		if _, err := l.Get(index); !errors.Is(err, ErrIndexOutOfRange) {
+			t.Errorf("Get(%d) error = %v, want ErrIndexOutOfRange", index, err)
+		}
+	}
+}
+
+func TestLinkedListReverse(t *testing.T) {
+	tests := []struct {
+		items []int
+		want  []int
+	}{
+		{nil, []int{}},
+		{[]int{1}, []int{1}},
+		{[]int{1, 2}, []int{2, 1}},
+		{[]int{1, 2, 3, 4, 5}, []int{5, 4, 3, 2, 1}},
+	}
+	for _, tt := range tests {
+		l := newList(tt.items...)
+		l.Reverse()
+		checkList(t, l, tt.want)
+		l.Insert(0)
+		checkList(t, l, append(tt.want, 0))
+	}
+}
+
+func TestLinkedListFindMiddle(t *testing.T) {
+	tests := []struct {
+		items []int
+		want  int
+	}{
+		{[]int{1}, 1},
+		{[]int{1, 2}, 2},
+		{[]int{1, 2, 3}, 2},
+		{[]int{1, 2, 3, 4}, 3},
+		{[]int{1, 2, 3, 4, 5}, 3},
+	}
+	for _, tt := range tests {
+		if got, err := newList(tt.items...).FindMiddle(); err != nil || got != tt.want {
+			t.Errorf("FindMiddle() of %v = %d, %v; want %d, nil", tt.items, got, err, tt.want)
+		}
+	}
+	if _, err := newList().FindMiddle(); err == nil {
+		t.Error("FindMiddle() of an empty list returned no error")
+	}
+}
+
+func TestLinkedListRemoveNthFromEnd(t *testing.T) {
+	tests := []struct {
+		name    string
+		items   []int
+		n       int
+		removed int
+		want    []int
+	}{
+		{"only element", []int{1}, 1, 1, []int{}},
+		{"last", []int{1, 2, 3}, 1, 3, []int{1, 2}},
+		{"middle", []int{1, 2, 3}, 2, 2, []int{1, 3}},
+		{"head", []int{1, 2, 3}, 3, 1, []int{2, 3}},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			l := newList(tt.items...)
+			got, err := l.RemoveNthFromEnd(tt.n)
+			if err != nil || got != tt.removed {
+				t.Fatalf("RemoveNthFromEnd(%d) = %d, %v; want %d, nil", tt.n, got, err, tt.removed)
+			}
+			checkList(t, l, tt.want)
+		})
+	}
+
+	l := newList(1, 2, 3)
+	for _, n := range []int{0, 4} {
+		if _, err := l.RemoveNthFromEnd(n); !errors.Is(err, ErrIndexOutOfRange) {
+			t.Errorf("RemoveNthFromEnd(%d) error = %v, want ErrIndexOutOfRange", n, err)
+		}
+	}
+	checkList(t, l, []int{1, 2, 3})
+}
+
+// capturePrint redirects stdout while Print runs
+func capturePrint(t *testing.T, l *LinkedList) string {
+	t.Helper()
+	r, w, err := os.Pipe()
+	if err != nil {
+		t.Fatal(err)
+	}
+	stdout := os.Stdout
+	os.Stdout = w
+	defer func() { os.Stdout = stdout }()
+	l.Print()
+	w.Close()
+	out, _ := io.ReadAll(r)
+	return strings.TrimSpace(string(out))
+}
+
+func TestLinkedListPrint(t *testing.T) {
+	if got := capturePrint(t, newList()); got != "nil" {
+		t.Errorf("empty Print() = %q, want %q", got, "nil")
+	}
+	if got := capturePrint(t, newList(1, 2)); got != "1 -> 2 -> nil" {
+		t.Errorf("Print() = %q, want %q", got, "1 -> 2 -> nil")
+	}
+}
+
+func TestLinkedListNodes(t *testing.T) {
+	l := newList(1, 2, 3)
+	var walked []int
+	for n := l.Front(); n != nil; n = n.Next() {
+		walked = append(walked, n.Value())
+	}
+	if !slices.Equal(walked, []int{1, 2, 3}) {
+		t.Errorf("walking Front and Next gave %v, want [1 2 3]", walked)
+	}
+
+	// Take the nodes out, put the first one last, and hand them back
+	head := l.TakeNodes()
+	checkList(t, l, nil)
+	second := head.Next()
+	head.Next().Next().SetNext(head)
+	head.SetNext(nil)
+	if err := l.SetNodes(second); err != nil {
+		t.Fatalf("SetNodes() = %v", err)
+	}
+	checkList(t, l, []int{2, 3, 1})
+
+	if err := l.SetNodes(nil); err != nil {
+		t.Fatalf("SetNodes(nil) = %v", err)
+	}
+	checkList(t, l, nil)
+	if (&LinkedList{}).Front() != nil {
+		t.Error("Front() of an empty list is not nil")
+	}
+}
+
+func TestLinkedListSetNodesRejectsCycles(t *testing.T) {
+	for _, n := range []int{1, 2, 5} {
+		// A chain of n nodes whose last node points back to the first
+		head := newList(1, 2, 3, 4, 5)
+		nodes := head.TakeNodes()
+		last := nodes
+		for range n - 1 {
+			last = last.Next()
+		}
+		last.SetNext(nodes)
+
+		l := newList(9)
+		if err := l.SetNodes(nodes); !errors.Is(err, ErrCycle) {
+			t.Errorf("SetNodes of a %d-node cycle = %v, want ErrCycle", n, err)
+		}
+		checkList(t, l, []int{9})
+	}
+}
@@ -0,0 +1,159 @@
+// This file implements an LRU (least recently used) cache in Go
+// The cache holds at most a fixed number of entries. When a new key would
+// go over the limit, the entry that was used longest ago is dropped, on the
+// bet that what was used recently will be used again soon
+//
+// Two structures work together:
+// - a map from key to list node finds an entry in O(1)
+// - a doubly linked list keeps the entries in order of use, most recent at
+//   the front; moving a node to the front or dropping the back is O(1)
+//
+// Time Complexity:
+// - Get, Peek, Oldest, Put, Remove: O(1)
+// - Keys: O(n) to walk all n entries
+//
+// Use Cases:
+// - Caching database rows or HTTP responses in front of a slower store
+// - Keeping the decoded form of recently opened files or images
+// - Page replacement in operating systems and buffer pools
+
+package datastructures
+
+import (
+	"fmt"
+	"iter"
+)
+
+// lruNode is an entry of an LRU, linked in order of use
+type lruNode[K comparable, V any] struct {
+	key        K
+	value      V
+	prev, next *lruNode[K, V]
+}
+
+// LRU is a cache of at most a fixed number of entries that drops the least
+// recently used entry to make room
+// It is not safe for concurrent use: Get changes the order of the entries,
+// so even readers need a mutex
+type LRU[K comparable, V any] struct {
+	capacity int
+	items    map[K]*lruNode[K, V]
+	// head is a sentinel: head.next is the most recently used entry and
+	// head.prev the least, so there are no nil checks at the ends
+	head lruNode[K, V]
+}
+
+// NewLRU creates an empty cache holding at most capacity entries
+// It panics if capacity is less than 1
+func NewLRU[K comparable, V any](capacity int) *LRU[K, V] {
+	if capacity < 1 {
+		panic(fmt.Sprintf("datastructures: capacity %d, need at least 1", capacity))
+	}
+	c := &LRU[K, V]{capacity: capacity, items: make(map[K]*lruNode[K, V], capacity)}
+	c.head.prev, c.head.next = &c.head, &c.head
+	return c
+}
+
+// unlink takes a node out of the list
+func (c *LRU[K, V]) unlink(n *lruNode[K, V]) {
+	n.prev.next = n.next
+	n.next.prev = n.prev
+}
+
+// pushFront links a node in as the most recently used
+func (c *LRU[K, V]) pushFront(n *lruNode[K, V]) {
+	n.prev, n.next = &c.head, c.head.next
+	c.head.next.prev = n
+	c.head.next = n
+}
+
+// Get returns the value for key and marks it as the most recently used
+// Time Complexity: O(1)
+func (c *LRU[K, V]) Get(key K) (V, bool) {
+	n, ok := c.items[key]
+	if !ok {
+		var zero V
+		return zero, false
+	}
+	c.unlink(n)
+	c.pushFront(n)
+	return n.value, true
+}
+
+// Peek returns the value for key without changing the order of use
+// Time Complexity: O(1)
+func (c *LRU[K, V]) Peek(key K) (V, bool) {
+	n, ok := c.items[key]
+	if !ok {
+		var zero V
+		return zero, false
+	}
+	return n.value, true
+}
+
+// Oldest returns the least recently used entry, the one Put would evict,
+// without changing the order of use
+// Time Complexity: O(1)
+func (c *LRU[K, V]) Oldest() (key K, value V, ok bool) {
+	if len(c.items) == 0 {
+		return key, value, false
+	}
+	return c.head.prev.key, c.head.prev.value, true
+}
+
+// Put sets the value for key and marks it as the most recently used
+// If the cache was full and key is new, the least recently used entry is
+// dropped and returned with evicted set
+// Time Complexity: O(1)
+func (c *LRU[K, V]) Put(key K, value V) (evictedKey K, evicted bool) {
+	if n, ok := c.items[key]; ok {
+		n.value = value
+		c.unlink(n)
+		c.pushFront(n)
+		return evictedKey, false
+	}
+	if len(c.items) == c.capacity {
+		oldest := c.head.prev
+		c.unlink(oldest)
+		delete(c.items, oldest.key)
+		evictedKey, evicted = oldest.key, true
+	}
+	n := &lruNode[K, V]{key: key, value: value}
+	c.items[key] = n
+	c.pushFront(n)
+	return evictedKey, evicted
+}
+
+// Remove deletes key and reports whether it was there
+// Time Complexity: O(1)
+func (c *LRU[K, V]) Remove(key K) bool {
+	n, ok := c.items[key]
+	if !ok {
+		return false
+	}
+	c.unlink(n)
+	delete(c.items, key)
+	return true
+}
+
+// Len returns the number of entries
+func (c *LRU[K, V]) Len() int {
+	return len(c.items)
+}
+
+// Cap returns the largest number of entries the cache holds
+func (c *LRU[K, V]) Cap() int {
+	return c.capacity
+}
+
+// Keys yields the keys from the most to the least recently used
+// The cache must not change during the iteration
+func (c *LRU[K, V]) Keys() iter.Seq[K] {
+	return func(yield func(K) bool) {
+		for n := c.head.next; n != &c.head; n = n.next {
+			if !yield(n.key) {
+				return
+			}
+		}
+	}
+}
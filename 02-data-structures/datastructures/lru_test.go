@@ -0,0 +1,130 @@
+package datastructures
+
+import (
+	"math/rand"
+	"slices"
+	"testing"
+)
+
+func TestLRU(t *testing.T) {
+	c := NewLRU[string, int](2)
+	if _, evicted := c.Put("a", 1); evicted {
+		t.Error("Put into an empty cache evicted")
+	}
+	c.Put("b", 2)
+	// Reading a makes b the least recently used
+	if v, ok := c.Get("a"); !ok || v != 1 {
+		t.Errorf("Get(a) = %d, %v, want 1, true", v, ok)
+	}
+	if key, evicted := c.Put("c", 3); !evicted || key != "b" {
+		t.Errorf("Put(c) evicted %q, %v, want b", key, evicted)
+	}
+	if _, ok := c.Get("b"); ok {
+		t.Error("b is still cached after it was evicted")
+	}
+	if got := slices.Collect(c.Keys()); !slices.Equal(got, []string{"c", "a"}) {
+		t.Errorf("Keys() = %v, want [c a]", got)
+	}
+
+	// Updating a key refreshes it and never evicts
+	if _, evicted := c.Put("a", 10); evicted {
+		t.Error("updating a key evicted")
+	}
+	// Peek leaves the order alone, so c is still evicted next
+	if v, ok := c.Peek("c"); !ok || v != 3 {
+		t.Errorf("Peek(c) = %d, %v, want 3, true", v, ok)
+	}
+	if key, v, ok := c.Oldest(); !ok || key != "c" || v != 3 {
+		t.Errorf("Oldest() = %q, %d, %v, want c, 3, true", key, v, ok)
+	}
+	if key, _ := c.Put("d", 4); key != "c" {
+		t.Errorf("Put(d) evicted %q, want c", key)
+	}
+	if v, _ := c.Get("a"); v != 10 {
+		t.Errorf("Get(a) = %d, want 10", v)
+	}
+
+	if !c.Remove("a") || c.Remove("a") {
+		t.Error("Remove(a) twice did not succeed once")
+	}
+	if c.Len() != 1 || c.Cap() != 2 {
+		t.Errorf("Len() = %d, Cap() = %d, want 1 and 2", c.Len(), c.Cap())
+	}
+	if got := slices.Collect(c.Keys()); !slices.Equal(got, []string{"d"}) {
+		t.Errorf("Keys() = %v, want [d]", got)
+	}
+	c.Remove("d")
+	if _, _, ok := c.Oldest(); ok {
+		t.Error("Oldest() of an empty cache reported an entry")
+	}
+}
+
+func TestLRUPanicsOnZeroCapacity(t *testing.T) {
+	defer func() {
+		if recover() == nil {
+			t.Error("NewLRU(0) did not panic")
+		}
+	}()
+	NewLRU[int, int](0)
+}
+
+// lruReference is a slice kept in order of use, most recent first
+type lruReference struct {
+	keys   []int
+	values map[int]int
+}
+
+func (r *lruReference) touch(key int) {
+	r.keys = slices.DeleteFunc(r.keys, func(k int) bool { return k == key })
+	r.keys = slices.Insert(r.keys, 0, key)
+}
+
+// Random operations agree with the slow slice-based reference
+func TestLRURandom(t *testing.T) {
+	rng := rand.New(rand.NewSource(1))
+	for _, capacity := range []int{1, 3, 8} {
+		c := NewLRU[int, int](capacity)
+		ref := &lruReference{values: make(map[int]int)}
+		for i := range 2000 {
+			key := rng.Intn(12)
+			switch rng.Intn(3) {
+			case 0:
+				v, ok := c.Get(key)
+				want, wantOK := ref.values[key]
+				if wantOK {
+					ref.touch(key)
+				}
+				if v != want || ok != wantOK {
+					t.Fatalf("cap %d, op %d: Get(%d) = %d, %v, want %d, %v", capacity, i, key, v, ok, want, wantOK)
+				}
+			case 1:
+				evictedKey, evicted := c.Put(key, i)
+				_, exists := ref.values[key]
+				wantEvicted := !exists && len(ref.keys) == capacity
+				if wantEvicted {
+					oldest := ref.keys[len(ref.keys)-1]
+					ref.keys = ref.keys[:len(ref.keys)-1]
+					delete(ref.values, oldest)
+					if evictedKey != oldest {
+						t.Fatalf("cap %d, op %d: Put(%d) evicted %d, want %d", capacity, i, key, evictedKey, oldest)
+					}
+				}
+				if evicted != wantEvicted {
+					t.Fatalf("cap %d, op %d: Put(%d) evicted = %v, want %v", capacity, i, key, evicted, wantEvicted)
+				}
+				ref.values[key] = i
+				ref.touch(key)
+			default:
+				_, want := ref.values[key]
+				if got := c.Remove(key); got != want {
+					t.Fatalf("cap %d, op %d: Remove(%d) = %v, want %v", capacity, i, key, got, want)
+				}
+				delete(ref.values, key)
+				ref.keys = slices.DeleteFunc(ref.keys, func(k int) bool { return k == key })
+			}
+			if got := slices.Collect(c.Keys()); !slices.Equal(got, ref.keys) {
+				t.Fatalf("cap %d, op %d: Keys() = %v, want %v", capacity, i, got, ref.keys)
+			}
+		}
+	}
+}
@@ -0,0 +1,223 @@
+// This file implements a language-aware bracket matcher, the stack-based
+// check behind IsValidBrackets grown into a small linter
+// A Matcher is configured with the bracket pairs, string quotes, escape
+// character and comment syntax of a language, and reports where the first
+// mismatch is instead of only whether there is one
+//
+// The idea is the same as IsValidBrackets: every opening bracket pushes the
+// closing bracket it expects, together with its position, and every closing
+// bracket must match the top of the stack. Strings and comments are skipped
+// by a small state machine around that loop
+//
+// Time Complexity: O(n × m) where n is the length of the input and m the
+// length of the longest comment marker, so O(n) for real languages
+
+package datastructures
+
+import (
+	"errors"
+	"fmt"
+	"strings"
+	"unicode/utf8"
+)
+
+// ErrUnbalanced is wrapped by every MismatchError
+var ErrUnbalanced = errors.New("unbalanced brackets")
+
+// MismatchError reports the first place where brackets, quotes or comments
+// don't match up
+type MismatchError struct {
+	Pos  int // byte offset of the offending character
+	Line int // 1-based line of Pos
+	Col  int // 1-based column of Pos, counted in runes
+	// OpenPos is the byte offset of the opening bracket that a wrong closing
+	// bracket was checked against, or -1 when there is none
+	OpenPos int
+	Msg     string
+}
+
+func (e *MismatchError) Error() string {
+	return fmt.Sprintf("%d:%d: %s", e.Line, e.Col, e.Msg)
+}
+
+// Unwrap lets errors.Is match ErrUnbalanced
+func (e *MismatchError) Unwrap() error {
+	return ErrUnbalanced
+}
+
+// Matcher checks that the brackets in a piece of source code are balanced
+// The zero Matcher knows no brackets and accepts everything; start from
+// NewMatcher or one of the language presets and adjust the fields
+type Matcher struct {
+	// Pairs lists the bracket pairs as opening and closing rune, like "()"
+	Pairs []string
+	// Quotes are the characters that start and end a string literal
+	Quotes string
+	// RawQuotes start and end a string literal in which Escape has no effect,
+	// like Go's `raw strings`
+	RawQuotes string
+	// Escape makes the next character plain, inside quotes or not, or is 0
+	// for no escape character
+	Escape rune
+	// LineComment starts a comment that runs to the end of the line, like
+	// "//" or "#", or is empty for none
+	LineComment string
+	// BlockComment holds the start and end of a block comment, like
+	// {"/*", "*/"}, or is empty for none. Block comments don't nest
+	BlockComment [2]string
+}
+
+// NewMatcher returns the matcher used by IsValidBrackets: (), [] and {},
+// "..." and '...' strings, and backslash escapes, with no comments
+func NewMatcher() *Matcher {
+	return &Matcher{
+		Pairs:  []string{"()", "[]", "{}"},
+		Quotes: `"'`,
+		Escape: '\\',
+	}
+}
+
+// GoMatcher returns a matcher for Go source: it adds `raw strings`,
+// // line comments and /* block comments */ to NewMatcher
+func GoMatcher() *Matcher {
+	m := NewMatcher()
+	m.RawQuotes = "`"
+	m.LineComment = "//"
+	m.BlockComment = [2]string{"/*", "*/"}
+	return m
+}
+
+// PythonMatcher returns a matcher for Python source: NewMatcher with #
+// comments. A triple-quoted string reads as an empty string, a string
+// and another empty string, which is right unless it contains quotes
+func PythonMatcher() *Matcher {
+	m := NewMatcher()
+	m.LineComment = "#"
+	return m
+}
+
+// opening is a bracket waiting on the stack for its partner
+type opening struct {
+	close rune // the closing bracket it expects
+	pos   int  // where it was opened
+}
+
+// Check returns nil if every bracket, quote and block comment in s is
+// closed in the right order, or a *MismatchError for the first one that
+// isn't. Unclosed brackets are reported at the innermost one, since that
+// is the one the end of the input should have closed
+func (m *Matcher) Check(s string) error {
+	expected := &StackOf[opening]{}
+	// start is where the open string literal or block comment began
+	var quote rune
+	raw := false
+	comment := ""
+	start := 0
+
+	for i := 0; i < len(s); {
+		ch, size := utf8.DecodeRuneInString(s[i:])
+
+		switch {
+		case comment != "":
+			// Inside a comment only its end matters, and an escape doesn't
+			// count. A line comment ends at, but doesn't consume, the newline
+			if strings.HasPrefix(s[i:], comment) {
+				if comment != "\n" {
+					size = len(comment)
+				}
+				comment = ""
+			}
+		case m.Escape != 0 && ch == m.Escape && !raw:
+			if i+size == len(s) {
+				return newMismatch(s, i, -1, fmt.Sprintf("%q escapes nothing", ch))
+			}
+			// Skip the escaped character along with the escape
+			_, next := utf8.DecodeRuneInString(s[i+size:])
+			size += next
+		case quote != 0:
+			// Inside a string literal only the closing quote matters
+			if ch == quote {
+				quote = 0
+				raw = false
+			}
+		case m.LineComment != "" && strings.HasPrefix(s[i:], m.LineComment):
+			comment = "\n"
+			size = len(m.LineComment)
+		case m.BlockComment[0] != "" && strings.HasPrefix(s[i:], m.BlockComment[0]):
+			comment = m.BlockComment[1]
+			start = i
+			size = len(m.BlockComment[0])
+		case strings.ContainsRune(m.Quotes, ch):
+			quote, start = ch, i
+		case strings.ContainsRune(m.RawQuotes, ch):
+			quote, start, raw = ch, i, true
+		default:
+			if closing, ok := m.closerFor(ch); ok {
+				expected.Push(opening{close: closing, pos: i})
+				break
+			}
+			if !m.isCloser(ch) {
+				break
+			}
+			// A closing bracket must match the most recent unclosed opening one
+			top, err := expected.Pop()
+			if err != nil {
+				return newMismatch(s, i, -1, fmt.Sprintf("unexpected %q", ch))
+			}
+			if top.close != ch {
+				open, _ := utf8.DecodeRuneInString(s[top.pos:])
+				return newMismatch(s, i, top.pos, fmt.Sprintf("%q does not close %q", ch, open))
+			}
+		}
+		i += size
+	}
+
+	switch {
+	case quote != 0:
+		return newMismatch(s, start, -1, fmt.Sprintf("string starting with %q is not closed", quote))
+	case comment != "" && comment != "\n":
+		return newMismatch(s, start, -1, fmt.Sprintf("comment is not closed with %q", comment))
+	}
+	if top, err := expected.Peek(); err == nil {
+		open, _ := utf8.DecodeRuneInString(s[top.pos:])
+		return newMismatch(s, top.pos, -1, fmt.Sprintf("%q is not closed", open))
+	}
+	return nil
+}
+
+// Valid reports whether Check finds no mismatch
+func (m *Matcher) Valid(s string) bool {
+	return m.Check(s) == nil
+}
+
+// closerFor returns the closing bracket for ch if ch opens a pair
+func (m *Matcher) closerFor(ch rune) (rune, bool) {
+	for _, pair := range m.Pairs {
+		open, size := utf8.DecodeRuneInString(pair)
+		if open == ch {
+			closing, _ := utf8.DecodeRuneInString(pair[size:])
+			return closing, true
+		}
+	}
+	return 0, false
+}
+
+// isCloser reports whether ch closes one of the pairs
+func (m *Matcher) isCloser(ch rune) bool {
+	for _, pair := range m.Pairs {
+		_, size := utf8.DecodeRuneInString(pair)
+		if closing, _ := utf8.DecodeRuneInString(pair[size:]); closing == ch {
+			return true
+		}
+	}
+	return false
+}
+
+// newMismatch builds a MismatchError for byte offset pos, working out the line
+// and column the way an editor would show them
+func newMismatch(s string, pos, openPos int, msg string) *MismatchError {
+	before := s[:pos]
+	line := strings.Count(before, "\n") + 1
+	col := utf8.RuneCountInString(before[strings.LastIndexByte(before, '\n')+1:]) + 1
+	return &MismatchError{Pos: pos, Line: line, Col: col, OpenPos: openPos, Msg: msg}
+}
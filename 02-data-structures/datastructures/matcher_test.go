@@ -0,0 +1,116 @@
+package datastructures
+
+import (
+	"errors"
+	"strings"
+	"testing"
+)
+
+func TestMatcherCheck(t *testing.T) {
+	tests := []struct {
+		name    string
+		matcher *Matcher
+		input   string
+		wantPos int // -1 when the input is valid
+		wantMsg string
+	}{
+		{"empty", NewMatcher(), "", -1, ""},
+		{"nested", NewMatcher(), "f(a[i], {b})", -1, ""},
+		{"unexpected closer", NewMatcher(), "a)", 1, `unexpected ')'`},
+		{"wrong closer", NewMatcher(), "([)]", 2, `')' does not close '['`},
+		{"innermost unclosed", NewMatcher(), "(a [b", 3, `'[' is not closed`},
+		{"unclosed string", NewMatcher(), `x("abc`, 2, `string starting with '"' is not closed`},
+		{"trailing escape", NewMatcher(), `()\`, 2, `'\\' escapes nothing`},
+		{"no comments by default", NewMatcher(), "// (", 3, `'(' is not closed`},
+
+		{"go line comment", GoMatcher(), "f() // )\n", -1, ""},
+		{"go comment ends at newline", GoMatcher(), "f( // x\n)", -1, ""},
+		{"go block comment", GoMatcher(), "f(/* ) */)", -1, ""},
+		{"go unclosed comment", GoMatcher(), "f() /* (", 4, `comment is not closed with "*/"`},
+		{"go raw string", GoMatcher(), "f(`\\`)", -1, ""},
+		{"go comment in string", GoMatcher(), `f("//")`, -1, ""},
+		{"go string in comment", GoMatcher(), `f() // "`, -1, ""},
+		{"go mismatch after comment", GoMatcher(), "/* ( */ ]", 8, `unexpected ']'`},
+
+		{"python comment", PythonMatcher(), "print(1)  # :)", -1, ""},
+		{"python no block comment", PythonMatcher(), "/* ( */", 3, `'(' is not closed`},
+
+		{"custom pairs", &Matcher{Pairs: []string{"<>", "«»"}}, "<«>»", 3, `'>' does not close '«'`},
+		{"zero matcher", &Matcher{}, ")(", -1, ""},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			err := tt.matcher.Check(tt.input)
+			if tt.wantPos < 0 {
+				if err != nil {
+					t.Errorf("Check(%q) = %v, want nil", tt.input, err)
+				}
+				return
+			}
+			var mismatch *MismatchError
+			if !errors.As(err, &mismatch) || !errors.Is(err, ErrUnbalanced) {
+				t.Fatalf("Check(%q) = %v, want a MismatchError", tt.input, err)
+			}
+			if mismatch.Pos != tt.wantPos || mismatch.Msg != tt.wantMsg {
+				t.Errorf("Check(%q) at %d: %s, want at %d: %s", tt.input, mismatch.Pos, mismatch.Msg, tt.wantPos, tt.wantMsg)
+			}
+		})
+	}
+}
+
+func TestMismatchErrorPosition(t *testing.T) {
+	src := "func f() {\n\tg(x[0)]\n}\n"
+	err := GoMatcher().Check(src)
+	var mismatch *MismatchError
+	if !errors.As(err, &mismatch) {
+		t.Fatalf("Check() = %v, want a MismatchError", err)
+	}
+	if got, want := err.Error(), `2:7: ')' does not close '['`; got != want {
+		t.Errorf("Error() = %q, want %q", got, want)
+	}
+	if got, want := mismatch.OpenPos, strings.Index(src, "["); got != want {
+		t.Errorf("OpenPos = %d, want %d", got, want)
+	}
+
+	// Columns count runes, not bytes
+	err = NewMatcher().Check("ก(ข]")
+	if !errors.As(err, &mismatch) || mismatch.Col != 4 || mismatch.Pos != 7 {
+		t.Errorf(`Check("ก(ข]") = %+v, want column 4 at byte 7`, err)
+	}
+}
+
+// go test ./02-data-structures/datastructures -fuzz=FuzzMatcherCheck
+func FuzzMatcherCheck(f *testing.F) {
+	for _, seed := range []string{"", "(]", "f(/* ) */)", "// (\n)", "`\\`(", "ก\n(ข]", "/*", "\\"} {
+		f.Add(seed)
+	}
+	matchers := map[string]*Matcher{"default": NewMatcher(), "go": GoMatcher(), "python": PythonMatcher()}
+	f.Fuzz(func(t *testing.T, s string) {
+		for name, m := range matchers {
+			err := m.Check(s)
+			if err == nil {
+				continue
+			}
+			var mismatch *MismatchError
+			if !errors.As(err, &mismatch) {
+				t.Fatalf("%s: Check(%q) = %v, want a MismatchError", name, s, err)
+			}
+			// The position points into s, at the line and column it claims
+			if mismatch.Pos < 0 || mismatch.Pos >= len(s) {
+				t.Fatalf("%s: Check(%q) Pos = %d, out of range", name, s, mismatch.Pos)
+			}
+			lines := strings.Split(s[:mismatch.Pos], "\n")
+			if mismatch.Line != len(lines) || mismatch.Col != len([]rune(lines[len(lines)-1]))+1 {
+				t.Errorf("%s: Check(%q) at %d:%d, want %d:%d", name, s, mismatch.Line, mismatch.Col,
+					len(lines), len([]rune(lines[len(lines)-1]))+1)
+			}
+			if mismatch.OpenPos >= mismatch.Pos {
+				t.Errorf("%s: Check(%q) OpenPos %d is not before Pos %d", name, s, mismatch.OpenPos, mismatch.Pos)
+			}
+		}
+		// Without comments configured, Check agrees with IsValidBrackets
+		if got, want := NewMatcher().Valid(s), referenceValid(s); got != want {
+			t.Errorf("NewMatcher().Valid(%q) = %v, referenceValid = %v", s, got, want)
+		}
+	})
+}
@@ -0,0 +1,92 @@
+// This file implements a multiset (a bag) in Go
+// A multiset is a set that remembers how many times each item was added,
+// so it is the natural home for counts: words in a text, votes, items in a
+// shopping cart. It is a map from item to count that keeps the total too,
+// and never stores a count of zero
+//
+// Time Complexity:
+// - Add, Remove, Count: O(1) on average
+// - Len, Distinct: O(1)
+// - All: O(u) for u distinct items
+//
+// Use Cases:
+// - Word and event frequencies
+// - Comparing two collections while ignoring order (anagrams)
+// - Inventories and shopping carts
+
+package datastructures
+
+import "iter"
+
+// Multiset is a collection of items of type T that may repeat
+// The zero value is an empty multiset ready to use
+type Multiset[T comparable] struct {
+	counts map[T]int
+	size   int
+}
+
+// Add adds one occurrence of an item
+// Time Complexity: O(1) on average
+func (m *Multiset[T]) Add(item T) {
+	m.AddN(item, 1)
+}
+
+// AddN adds n occurrences of an item; n <= 0 adds nothing
+// Time Complexity: O(1) on average
+func (m *Multiset[T]) AddN(item T, n int) {
+	if n <= 0 {
+		return
+	}
+	if m.counts == nil {
+		m.counts = make(map[T]int)
+	}
+	m.counts[item] += n
+	m.size += n
+}
+
+// Remove removes one occurrence of an item and reports whether there was one
+// Time Complexity: O(1) on average
+func (m *Multiset[T]) Remove(item T) bool {
+	count := m.counts[item]
+	if count == 0 {
+		return false
+	}
+	if count == 1 {
+		// Deleting rather than storing 0 keeps Distinct and All right
+		delete(m.counts, item)
+	} else {
+		m.counts[item] = count - 1
+	}
+	m.size--
+	return true
+}
+
+// Count returns how many times an item occurs, 0 if it doesn't
+// Time Complexity: O(1) on average
+func (m *Multiset[T]) Count(item T) int {
+	return m.counts[item]
+}
+
+// Len returns the number of items, counting every occurrence
+// Time Complexity: O(1)
+func (m *Multiset[T]) Len() int {
+	return m.size
+}
+
+// Distinct returns the number of different items
+// Time Complexity: O(1)
+func (m *Multiset[T]) Distinct() int {
+	return len(m.counts)
+}
+
+// All returns an iterator over the distinct items and their counts, in no
+// particular order, like ranging over a map
+func (m *Multiset[T]) All() iter.Seq2[T, int] {
+	return func(yield func(T, int) bool) {
+		for item, count := range m.counts {
+			if !yield(item, count) {
+				return
+			}
+		}
+	}
+}
@@ -0,0 +1,44 @@
+package datastructures
+
+import (
+	"maps"
+	"testing"
+)
+
+func TestMultiset(t *testing.T) {
+	var m Multiset[string]
+	if m.Len() != 0 || m.Distinct() != 0 || m.Count("a") != 0 || m.Remove("a") {
+		t.Fatal("the zero value is not an empty multiset")
+	}
+
+	for _, w := range []string{"a", "b", "a", "c", "a"} {
+		m.Add(w)
+	}
+	m.AddN("b", 2)
+	m.AddN("d", 0)
+	m.AddN("e", -3)
+
+	want := map[string]int{"a": 3, "b": 3, "c": 1}
+	if got := maps.Collect(m.All()); !maps.Equal(got, want) {
+		t.Errorf("All() = %v, want %v", got, want)
+	}
+	if m.Len() != 7 || m.Distinct() != 3 {
+		t.Errorf("Len() = %d, Distinct() = %d, want 7 and 3", m.Len(), m.Distinct())
+	}
+
+	// Removing the last occurrence removes the item
+	if !m.Remove("c") || m.Remove("c") {
+		t.Error("Remove(c) twice did not succeed once")
+	}
+	if !m.Remove("a") || m.Count("a") != 2 {
+		t.Errorf("after Remove(a), Count(a) = %d, want 2", m.Count("a"))
+	}
+	if m.Len() != 5 || m.Distinct() != 2 {
+		t.Errorf("Len() = %d, Distinct() = %d, want 5 and 2", m.Len(), m.Distinct())
+	}
+	for item, count := range m.All() {
+		if count == 0 {
+			t.Errorf("All() yielded %q with a count of 0", item)
+		}
+	}
+}
@@ -0,0 +1,291 @@
+// This file implements an order-statistics tree in Go
+// It is a self-balancing binary search tree (an AVL tree) whose nodes also
+// record the size of their subtree. The sizes answer two questions that a
+// plain BST can only answer by walking it in order:
+// - Select(k): which item is the kth smallest?
+// - Rank(x): how many items are at most x?
+//
+// An AVL tree keeps the heights of every node's two subtrees within one of
+// each other, rotating nodes after an insert or delete when they drift
+// further apart. That bounds its height by about 1.44 log₂ n, so unlike
+// BinaryTree it never degenerates into a path on sorted input
+//
+// Time Complexity:
+// - Insert, Delete, Contains: O(log n)
+// - Select, Rank: O(log n)
+// - Len: O(1)
+// where n is the number of items in the tree
+//
+// Use Cases:
+// - Leaderboards: a player's position, or who is in 10th place
+// - Percentiles and medians of a changing set
+// - Counting items in a range: Rank(hi) - Rank(lo)
+
+package datastructures
+
+import (
+	"cmp"
+	"fmt"
+	"iter"
+
+	"github.com/NutProhmpiriya/go-basic/ordering"
+)
+
+// OrderStatTree is a balanced binary search tree of distinct items of type
+// T that can find items by their position in sorted order
+// compare orders the items as cmp.Compare does; items that compare equal
+// are the same item, so a leaderboard, where scores tie, orders by score
+// and then by name, e.g. with an ordering.Comparator and ThenComparing
+type OrderStatTree[T any] struct {
+	root    *osNode[T]
+	compare func(a, b T) int
+}
+
+// osNode is a node of an OrderStatTree
+// height is 1 for a leaf and size counts the node and all its descendants
+type osNode[T any] struct {
+	item        T
+	left, right *osNode[T]
+	height      int
+	size        int
+}
+
+// NewOrderStatTree creates an empty tree ordered by compare
+func NewOrderStatTree[T any](compare func(a, b T) int) *OrderStatTree[T] {
+	return &OrderStatTree[T]{compare: compare}
+}
+
+// NewOrderedStatTree creates an empty tree of items in their natural order
+func NewOrderedStatTree[T cmp.Ordered]() *OrderStatTree[T] {
+	return NewOrderStatTree(ordering.Natural[T]())
+}
+
+// Len returns the number of items in the tree
+// Time Complexity: O(1)
+func (t *OrderStatTree[T]) Len() int {
+	return osSize(t.root)
+}
+
+// Insert adds an item and reports whether it was added; an item that is
+// already in the tree is left alone
+// Time Complexity: O(log n)
+func (t *OrderStatTree[T]) Insert(item T) bool {
+	var added bool
+	t.root, added = t.insert(t.root, item)
+	return added
+}
+
+func (t *OrderStatTree[T]) insert(n *osNode[T], item T) (*osNode[T], bool) {
+	if n == nil {
+		return &osNode[T]{item: item, height: 1, size: 1}, true
+	}
+	var added bool
+	switch c := t.compare(item, n.item); {
+	case c < 0:
+		n.left, added = t.insert(n.left, item)
+	case c > 0:
+		n.right, added = t.insert(n.right, item)
+	default:
+		return n, false
+	}
+	return rebalance(n), added
+}
+
+// Delete removes an item and reports whether it was in the tree
+// Time Complexity: O(log n)
+func (t *OrderStatTree[T]) Delete(item T) bool {
+	var removed bool
+	t.root, removed = t.delete(t.root, item)
+	return removed
+}
+
+func (t *OrderStatTree[T]) delete(n *osNode[T], item T) (*osNode[T], bool) {
+	if n == nil {
+		return nil, false
+	}
+	var removed bool
+	switch c := t.compare(item, n.item); {
+	case c < 0:
+		n.left, removed = t.delete(n.left, item)
+	case c > 0:
+		n.right, removed = t.delete(n.right, item)
+	default:
+		// With at most one child, the child takes the node's place
+		if n.left == nil {
+			return n.right, true
+		}
+		if n.right == nil {
+			return n.left, true
+		}
+		// Otherwise the smallest item of the right subtree, the next item in
+		// order, takes its place
+		var next *osNode[T]
+		n.right, next = removeMin(n.right)
+		next.left, next.right = n.left, n.right
+		return rebalance(next), true
+	}
+	return rebalance(n), removed
+}
+
+// removeMin unlinks the leftmost node of the subtree at n and returns the
+// new subtree and the unlinked node
+func removeMin[T any](n *osNode[T]) (*osNode[T], *osNode[T]) {
+	if n.left == nil {
+		return n.right, n
+	}
+	var least *osNode[T]
+	n.left, least = removeMin(n.left)
+	return rebalance(n), least
+}
+
+// Contains reports whether an item is in the tree
+// Time Complexity: O(log n)
+func (t *OrderStatTree[T]) Contains(item T) bool {
+	for n := t.root; n != nil; {
+		switch c := t.compare(item, n.item); {
+		case c < 0:
+			n = n.left
+		case c > 0:
+			n = n.right
+		default:
+			return true
+		}
+	}
+	return false
+}
+
+// Select returns the kth smallest item, counting from 1
+// At each node the size of the left subtree says whether the kth item is
+// on the left, is the node itself, or is on the right, in which case the
+// left subtree and the node are skipped
+// Time Complexity: O(log n)
+func (t *OrderStatTree[T]) Select(k int) (T, error) {
+	if k < 1 || k > t.Len() {
+		var zero T
+		return zero, fmt.Errorf("%w: select %d of %d items", ErrIndexOutOfRange, k, t.Len())
+	}
+	n := t.root
+	for {
+		left := osSize(n.left)
+		switch {
+		case k <= left:
+			n = n.left
+		case k == left+1:
+			return n.item, nil
+		default:
+			k -= left + 1
+			n = n.right
+		}
+	}
+}
+
+// Rank returns the number of items in the tree that are at most item, so
+// for an item in the tree it is its position counting from 1, and
+// Select(Rank(x)) is x
+// Every time the search goes right, the left subtree and the node are
+// smaller, so their count is added
+// Time Complexity: O(log n)
+func (t *OrderStatTree[T]) Rank(item T) int {
+	rank := 0
+	for n := t.root; n != nil; {
+		switch c := t.compare(item, n.item); {
+		case c < 0:
+			n = n.left
+		case c > 0:
+			rank += osSize(n.left) + 1
+			n = n.right
+		default:
+			return rank + osSize(n.left) + 1
+		}
+	}
+	return rank
+}
+
+// All returns an iterator over the items in ascending order
+func (t *OrderStatTree[T]) All() iter.Seq[T] {
+	return func(yield func(T) bool) {
+		// The height is O(log n), so the stack stays small
+		var stack []*osNode[T]
+		for n := t.root; n != nil || len(stack) > 0; n = n.right {
+			for ; n != nil; n = n.left {
+				stack = append(stack, n)
+			}
+			n = stack[len(stack)-1]
+			stack = stack[:len(stack)-1]
+			if !yield(n.item) {
+				return
+			}
+		}
+	}
+}
+
+func osSize[T any](n *osNode[T]) int {
+	if n == nil {
+		return 0
+	}
+	return n.size
+}
+
+func osHeight[T any](n *osNode[T]) int {
+	if n == nil {
+		return 0
+	}
+	return n.height
+}
+
+// osUpdate recomputes the height and size of n from its children
+func osUpdate[T any](n *osNode[T]) {
+	n.height = 1 + max(osHeight(n.left), osHeight(n.right))
+	n.size = 1 + osSize(n.left) + osSize(n.right)
+}
+
+// rebalance updates n after one of its subtrees changed and, if their
+// heights now differ by two, rotates to restore the balance
+// It returns the node that takes n's place
+//
+// Left-left, fixed by rotating n right:
+//
+//	    n            l
+//	   /            / \
+//	  l     →      a   n
+//	 /
+//	a
+//
+// Left-right first rotates l left, which turns it into left-left; the
+// right cases mirror these
+func rebalance[T any](n *osNode[T]) *osNode[T] {
+	osUpdate(n)
+	switch balance := osHeight(n.left) - osHeight(n.right); {
+	case balance > 1:
+		if osHeight(n.left.left) < osHeight(n.left.right) {
+			n.left = rotateLeft(n.left)
+		}
+		return rotateRight(n)
+	case balance < -1:
+		if osHeight(n.right.right) < osHeight(n.right.left) {
+			n.right = rotateRight(n.right)
+		}
+		return rotateLeft(n)
+	}
+	return n
+}
+
+// rotateRight lifts the left child of n above it and returns the child
+// The child's right subtree, whose items lie between the two, moves across
+// to become n's left subtree
+func rotateRight[T any](n *osNode[T]) *osNode[T] {
+	l := n.left
+	n.left, l.right = l.right, n
+	osUpdate(n)
+	osUpdate(l)
+	return l
+}
+
+// rotateLeft is the mirror image of rotateRight
+func rotateLeft[T any](n *osNode[T]) *osNode[T] {
+	r := n.right
+	n.right, r.left = r.left, n
+	osUpdate(n)
+	osUpdate(r)
+	return r
+}
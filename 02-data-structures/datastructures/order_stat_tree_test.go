@@ -0,0 +1,220 @@
+package datastructures
+
+import (
+	"cmp"
+	"errors"
+	"math"
+	"slices"
+	"testing"
+
+	"github.com/NutProhmpiriya/go-basic/testdata/generator"
+)
+
+// checkOrderStat checks the tree against want, its items in ascending
+// order: the order of the items, the AVL balance, and the heights and sizes
+// stored in every node
+func checkOrderStat(t *testing.T, tree *OrderStatTree[int], want []int) {
+	t.Helper()
+	var check func(n *osNode[int]) (height, size int)
+	check = func(n *osNode[int]) (int, int) {
+		if n == nil {
+			return 0, 0
+		}
+		lh, ls := check(n.left)
+		rh, rs := check(n.right)
+		if lh-rh > 1 || rh-lh > 1 {
+			t.Fatalf("node %d is unbalanced: heights %d and %d", n.item, lh, rh)
+		}
+		if n.height != 1+max(lh, rh) || n.size != 1+ls+rs {
+			t.Fatalf("node %d stores height %d and size %d, want %d and %d", n.item, n.height, n.size, 1+max(lh, rh), 1+ls+rs)
+		}
+		return n.height, n.size
+	}
+	check(tree.root)
+
+	if got := slices.Collect(tree.All()); !slices.Equal(got, want) {
+		t.Fatalf("items = %v, want %v", got, want)
+	}
+	if tree.Len() != len(want) {
+		t.Fatalf("Len() = %d, want %d", tree.Len(), len(want))
+	}
+	// An AVL tree of n nodes is at most about 1.44 log₂ n high
+	if limit := 1.45 * math.Log2(float64(len(want)+2)); float64(osHeight(tree.root)) > limit {
+		t.Fatalf("height %d for %d items is over %.1f", osHeight(tree.root), len(want), limit)
+	}
+}
+
+func TestOrderStatTreeInsertDelete(t *testing.T) {
+	tests := []struct {
+		name   string
+		values []int
+	}{
+		{"empty", nil},
+		{"single", []int{1}},
+		// Each rotation case: left-left, right-right, left-right, right-left
+		{"left-left", []int{3, 2, 1}},
+		{"right-right", []int{1, 2, 3}},
+		{"left-right", []int{3, 1, 2}},
+		{"right-left", []int{1, 3, 2}},
+		{"sorted", generator.Sorted(1000)},
+		{"reversed", generator.Reversed(1000)},
+		{"random", generator.New(1).Perm(1000)},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			tree := NewOrderedStatTree[int]()
+			for _, v := range tt.values {
+				if !tree.Insert(v) {
+					t.Fatalf("Insert(%d) reported a duplicate", v)
+				}
+			}
+			want := slices.Sorted(slices.Values(tt.values))
+			checkOrderStat(t, tree, want)
+
+			// Deleting in insertion order empties the tree one item at a time
+			for _, v := range tt.values {
+				if !tree.Delete(v) {
+					t.Fatalf("Delete(%d) did not find it", v)
+				}
+				i, _ := slices.BinarySearch(want, v)
+				want = slices.Delete(want, i, i+1)
+				checkOrderStat(t, tree, want)
+			}
+		})
+	}
+}
+
+func TestOrderStatTreeDuplicatesAndMissing(t *testing.T) {
+	tree := NewOrderedStatTree[int]()
+	for _, v := range []int{5, 3, 8} {
+		tree.Insert(v)
+	}
+	if tree.Insert(3) {
+		t.Error("Insert(3) of an item in the tree reported it added")
+	}
+	if tree.Delete(4) {
+		t.Error("Delete(4) of a missing item reported it removed")
+	}
+	if !tree.Contains(8) || tree.Contains(4) {
+		t.Error("Contains() is wrong for 8 or 4")
+	}
+	checkOrderStat(t, tree, []int{3, 5, 8})
+}
+
+func TestOrderStatTreeSelectRank(t *testing.T) {
+	// Random inserts and deletes of even numbers, checked against a sorted
+	// slice of the same items
+	rng := generator.New(1).Rand()
+	tree := NewOrderedStatTree[int]()
+	var want []int
+	for range 3000 {
+		v := 2 * rng.Intn(500)
+		i, found := slices.BinarySearch(want, v)
+		if rng.Intn(3) == 0 {
+			if tree.Delete(v) != found {
+				t.Fatalf("Delete(%d) = %v, want %v", v, !found, found)
+			}
+			if found {
+				want = slices.Delete(want, i, i+1)
+			}
+		} else {
+			if tree.Insert(v) == found {
+				t.Fatalf("Insert(%d) = %v, want %v", v, found, !found)
+			}
+			if !found {
+				want = slices.Insert(want, i, v)
+			}
+		}
+	}
+	checkOrderStat(t, tree, want)
+
+	for k := 1; k <= len(want); k++ {
+		if got, err := tree.Select(k); err != nil || got != want[k-1] {
+			t.Fatalf("Select(%d) = %d, %v, want %d", k, got, err, want[k-1])
+		}
+		if got := tree.Rank(want[k-1]); got != k {
+			t.Fatalf("Rank(%d) = %d, want %d", want[k-1], got, k)
+		}
+	}
+	// Odd numbers are never in the tree: their rank counts the items below
+	for x := -1; x <= 1001; x += 2 {
+		below, _ := slices.BinarySearch(want, x)
+		if got := tree.Rank(x); got != below {
+			t.Fatalf("Rank(%d) = %d, want %d", x, got, below)
+		}
+	}
+	for _, k := range []int{0, -1, len(want) + 1} {
+		if _, err := tree.Select(k); !errors.Is(err, ErrIndexOutOfRange) {
+			t.Errorf("Select(%d) error = %v, want ErrIndexOutOfRange", k, err)
+		}
+	}
+	if _, err := NewOrderedStatTree[int]().Select(1); !errors.Is(err, ErrIndexOutOfRange) {
+		t.Errorf("Select(1) on an empty tree error = %v, want ErrIndexOutOfRange", err)
+	}
+}
+
+func TestOrderStatTreeCustomOrder(t *testing.T) {
+	type score struct {
+		name   string
+		points int
+	}
+	// Most points first, ties by name
+	tree := NewOrderStatTree(func(a, b score) int {
+		if c := cmp.Compare(b.points, a.points); c != 0 {
+			return c
+		}
+		return cmp.Compare(a.name, b.name)
+	})
+	for _, s := range []score{{"ann", 30}, {"bob", 50}, {"cat", 30}, {"dan", 10}} {
+		tree.Insert(s)
+	}
+	if got := tree.Rank(score{"cat", 30}); got != 3 {
+		t.Errorf("Rank(cat) = %d, want 3", got)
+	}
+	if got, _ := tree.Select(1); got.name != "bob" {
+		t.Errorf("Select(1) = %v, want bob", got)
+	}
+	// Same name, different points: a different item
+	if !tree.Insert(score{"ann", 40}) || tree.Len() != 5 {
+		t.Error("Insert of ann with new points did not add an item")
+	}
+
+	// Stopping an iteration early
+	var first []string
+	for s := range tree.All() {
+		if len(first) == 2 {
+			break
+		}
+		first = append(first, s.name)
+	}
+	if !slices.Equal(first, []string{"bob", "ann"}) {
+		t.Errorf("first two = %v, want [bob ann]", first)
+	}
+}
+
+func BenchmarkOrderStatTree(b *testing.B) {
+	values := generator.New(1).Perm(100_000)
+	tree := NewOrderedStatTree[int]()
+	for _, v := range values {
+		tree.Insert(v)
+	}
+	b.Run("op=Insert/n=1000", func(b *testing.B) {
+		b.ReportAllocs()
+		for range b.N {
+			tree := NewOrderedStatTree[int]()
+			for _, v := range values[:1000] {
+				tree.Insert(v)
+			}
+		}
+	})
+	b.Run("op=Select", func(b *testing.B) {
+		for i := range b.N {
+			tree.Select(1 + i%len(values))
+		}
+	})
+	b.Run("op=Rank", func(b *testing.B) {
+		for i := range b.N {
+			tree.Rank(values[i%len(values)])
+		}
+	})
+}
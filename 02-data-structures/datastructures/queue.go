@@ -0,0 +1,104 @@
+// This file implements a queue data structure in Go
+// A queue is a First-In-First-Out (FIFO) data structure
+// Elements are added at the end (enqueue) and removed from the front (dequeue)
+//
+// Time Complexity:
+// - Enqueue: O(1) amortized
+// - Dequeue: O(1)
+// - Peek: O(1)
+//
+// Use Cases:
+// - Task scheduling
+// - Print queue management
+// - Breadth-first search in graphs
+// - Request handling in web servers
+
+package datastructures
+
+import (
+	"fmt"
+	"slices"
+)
+
+// Queue represents a queue of ints
+// It is the QueueOf type for the common case
+type Queue = QueueOf[int]
+
+// QueueOf represents a queue data structure holding items of type T
+// This implementation uses a slice as the underlying storage
+// The first element in the slice is the front of the queue
+// Dequeued slots are never reused; RingQueue is the version for queues
+// that live long or drain after a burst
+// T can be any type; like StackOf it has ContainsFunc, and Contains for
+// comparable types
+type QueueOf[T any] struct {
+	items []T
+}
+
+// Enqueue adds an item to the end of the queue
+// Time Complexity: O(1) amortized
+// Note: While append is O(1) amortized, it may occasionally need to
+// reallocate and copy the underlying array
+func (q *QueueOf[T]) Enqueue(item T) {
+	q.items = append(q.items, item)
+}
+
+// Dequeue removes and returns the first item in the queue
+// Time Complexity: O(1)
+// Note: While removing from the front is O(n) with slices,
+// we optimize this by not shrinking the slice capacity
+func (q *QueueOf[T]) Dequeue() (T, error) {
+	var zero T
+	if len(q.items) == 0 {
+		return zero, fmt.Errorf("queue is empty")
+	}
+	
+	// Get the first item
+	item := q.items[0]
+	// Remove it from the queue, clearing the slot so a dequeued pointer
+	// doesn't keep its target alive
+	// This creates a new slice without the first element
+	q.items[0] = zero
+	q.items = q.items[1:]
+	return item, nil
+}
+
+// Peek returns the first item without removing it
+// Time Complexity: O(1)
+func (q *QueueOf[T]) Peek() (T, error) {
+	if len(q.items) == 0 {
+		var zero T
+		return zero, fmt.Errorf("queue is empty")
+	}
+	return q.items[0], nil
+}
+
+// IsEmpty returns true if the queue is empty
+// Time Complexity: O(1)
+func (q *QueueOf[T]) IsEmpty() bool {
+	return len(q.items) == 0
+}
+
+// Size returns the number of items in the queue
+// Time Complexity: O(1)
+func (q *QueueOf[T]) Size() int {
+	return len(q.items)
+}
+
+// Clear removes every item and releases the underlying array
+// Time Complexity: O(1)
+func (q *QueueOf[T]) Clear() {
+	q.items = nil
+}
+
+// ContainsFunc reports whether any item in the queue satisfies match
+// Time Complexity: O(n)
+func (q *QueueOf[T]) ContainsFunc(match func(T) bool) bool {
+	return slices.ContainsFunc(q.items, match)
+}
+
+// ToSlice returns a copy of the items, front first
+// Time Complexity: O(n)
+func (q *QueueOf[T]) ToSlice() []T {
+	return slices.Clone(q.items)
+}
@@ -0,0 +1,186 @@
+package datastructures
+
+import (
+	"fmt"
+	"slices"
+	"testing"
+)
+
+// intQueue is the API Queue and RingQueue share
+type intQueue interface {
+	Enqueue(item int)
+	Dequeue() (int, error)
+	Peek() (int, error)
+	IsEmpty() bool
+	Size() int
+}
+
+// queueImpls returns a new empty queue of each implementation
+var queueImpls = []struct {
+	name string
+	new  func() intQueue
+}{
+	{"Queue", func() intQueue { return &Queue{} }},
+	{"RingQueue", func() intQueue { return &RingQueue{} }},
+}
+
+func TestQueueFIFO(t *testing.T) {
+	tests := []struct {
+		name  string
+		items []int
+	}{
+		{"single", []int{1}},
+		{"fifo order", []int{1, 2, 3, 4}},
+		{"duplicates", []int{9, 9, 1, 9}},
+		{"negative and zero", []int{-5, 0, 5}},
+	}
+	for _, impl := range queueImpls {
+		for _, tt := range tests {
+			t.Run(impl.name+"/"+tt.name, func(t *testing.T) {
+				q := impl.new()
+				for _, v := range tt.items {
+					q.Enqueue(v)
+				}
+				if got := q.Size(); got != len(tt.items) {
+					t.Fatalf("Size() = %d, want %d", got, len(tt.items))
+				}
+				for i, want := range tt.items {
+					front, err := q.Peek()
+					if err != nil || front != want {
+						t.Fatalf("Peek() #%d = %d, %v; want %d, nil", i, front, err, want)
+					}
+					got, err := q.Dequeue()
+					if err != nil || got != want {
+						t.Fatalf("Dequeue() #%d = %d, %v; want %d, nil", i, got, err, want)
+					}
+				}
+				if !q.IsEmpty() {
+					t.Error("IsEmpty() = false after dequeuing everything")
+				}
+			})
+		}
+	}
+}
+
+func TestQueueEmpty(t *testing.T) {
+	for _, impl := range queueImpls {
+		q := impl.new()
+		if !q.IsEmpty() || q.Size() != 0 {
+			t.Fatalf("zero %s: IsEmpty() = %v, Size() = %d", impl.name, q.IsEmpty(), q.Size())
+		}
+		if _, err := q.Dequeue(); err == nil {
+			t.Errorf("%s: Dequeue() on empty queue returned no error", impl.name)
+		}
+		if _, err := q.Peek(); err == nil {
+			t.Errorf("%s: Peek() on empty queue returned no error", impl.name)
+		}
+	}
+}
+
+// Interleaving enqueues and dequeues exercises the slice re-slicing in
+// Queue.Dequeue and the wrap-around in RingQueue
+func TestQueueInterleaved(t *testing.T) {
+	for _, impl := range queueImpls {
+		q := impl.new()
+		next, expected := 0, 0
+		for round := 0; round < 100; round++ {
+			for i := 0; i < 3; i++ {
+				q.Enqueue(next)
+				next++
+			}
+			for i := 0; i < 2; i++ {
+				got, err := q.Dequeue()
+				if err != nil || got != expected {
+					t.Fatalf("%s round %d: Dequeue() = %d, %v; want %d, nil", impl.name, round, got, err, expected)
+				}
+				expected++
+			}
+		}
+		if got := q.Size(); got != 100 {
+			t.Errorf("%s: Size() = %d, want 100", impl.name, got)
+		}
+	}
+}
+
+func TestQueueClearContainsToSlice(t *testing.T) {
+	q := &QueueOf[string]{}
+	for _, v := range []string{"a", "b", "c"} {
+		q.Enqueue(v)
+	}
+	q.Dequeue()
+	if got := q.ToSlice(); !slices.Equal(got, []string{"b", "c"}) {
+		t.Errorf("ToSlice() = %v, want [b c]", got)
+	}
+	if q.ContainsFunc(is("a")) || !q.ContainsFunc(is("b")) || !q.ContainsFunc(is("c")) {
+		t.Errorf("ContainsFunc gives the wrong answer for %v", q.ToSlice())
+	}
+
+	q.Clear()
+	if !q.IsEmpty() || q.ContainsFunc(is("b")) || len(q.ToSlice()) != 0 {
+		t.Errorf("queue not empty after Clear: %v", q.ToSlice())
+	}
+	q.Enqueue("d")
+	if got, _ := q.Dequeue(); got != "d" {
+		t.Errorf("Dequeue() after Clear = %q, want d", got)
+	}
+}
+
+// Items need not be comparable: a queue of funcs
+func TestQueueOfFuncs(t *testing.T) {
+	var q QueueOf[func() int]
+	for i := range 3 {
+		q.Enqueue(func() int { return i })
+	}
+	if q.ContainsFunc(func(f func() int) bool { return f() == 3 }) {
+		t.Error("ContainsFunc found a func returning 3")
+	}
+	for want := range 3 {
+		if f, _ := q.Dequeue(); f() != want {
+			t.Errorf("Dequeue() returned a func giving %d, want %d", f(), want)
+		}
+	}
+}
+
+// A drained RingQueue gives its memory back
+func TestRingQueueShrinks(t *testing.T) {
+	var q RingQueue
+	for i := range 1000 {
+		q.Enqueue(i)
+	}
+	if q.Cap() < 1000 {
+		t.Fatalf("Cap() = %d with 1000 items", q.Cap())
+	}
+	for i := range 999 {
+		if got, _ := q.Dequeue(); got != i {
+			t.Fatalf("Dequeue() = %d, want %d", got, i)
+		}
+	}
+	if q.Cap() != minRingCapacity {
+		t.Errorf("Cap() = %d with one item left, want %d", q.Cap(), minRingCapacity)
+	}
+	if got, _ := q.Peek(); got != 999 {
+		t.Errorf("Peek() = %d, want 999", got)
+	}
+}
+
+// BenchmarkQueue compares the queues on a queue that stays about n long,
+// the pattern of a BFS frontier or a work queue. Queue keeps walking
+// forward through memory and reallocates as it goes; RingQueue reuses
+// its array and stops allocating
+func BenchmarkQueue(b *testing.B) {
+	for _, impl := range queueImpls {
+		for _, n := range []int{16, 1024} {
+			b.Run(fmt.Sprintf("impl=%s/n=%d", impl.name, n), func(b *testing.B) {
+				b.ReportAllocs()
+				q := impl.new()
+				for i := range n {
+					q.Enqueue(i)
+				}
+				for i := 0; i < b.N; i++ {
+					q.Enqueue(i)
+					q.Dequeue()
+				}
+			})
+		}
+	}
+}
@@ -0,0 +1,90 @@
+// This file implements a queue on a circular buffer
+// Queue dequeues by re-slicing, so the slots in front of its first item are
+// never used again: a long-lived queue keeps reallocating as it moves through
+// memory, and a burst of items keeps its large array alive after it drains.
+// RingQueue wraps around inside one array instead, grows it only when it is
+// full and shrinks it when it is mostly empty
+//
+// Time Complexity:
+// - Enqueue: O(1) amortized
+// - Dequeue: O(1) amortized
+// - Peek: O(1)
+
+package datastructures
+
+import "fmt"
+
+// minRingCapacity is the smallest array a non-empty RingQueue keeps
+const minRingCapacity = 8
+
+// RingQueue is a FIFO queue on a circular buffer
+// The zero value is an empty queue ready to use
+type RingQueue struct {
+	items []int
+	head  int // index of the front item
+	size  int
+}
+
+// Enqueue adds an item to the end of the queue
+// Time Complexity: O(1) amortized; the array doubles when it is full
+func (q *RingQueue) Enqueue(item int) {
+	if q.size == len(q.items) {
+		q.resize(max(2*len(q.items), minRingCapacity))
+	}
+	q.items[(q.head+q.size)%len(q.items)] = item
+	q.size++
+}
+
+// Dequeue removes and returns the first item in the queue
+// Time Complexity: O(1) amortized; the array halves when it is a quarter full,
+// so memory is given back once a burst has drained
+func (q *RingQueue) Dequeue() (int, error) {
+	if q.size == 0 {
+		return 0, fmt.Errorf("queue is empty")
+	}
+	item := q.items[q.head]
+	q.head = (q.head + 1) % len(q.items)
+	q.size--
+	// Shrinking at a quarter rather than a half means a queue hovering around
+	// one size doesn't resize on every other call
+	if len(q.items) > minRingCapacity && q.size <= len(q.items)/4 {
+		q.resize(len(q.items) / 2)
+	}
+	return item, nil
+}
+
+// Peek returns the first item without removing it
+// Time Complexity: O(1)
+func (q *RingQueue) Peek() (int, error) {
+	if q.size == 0 {
+		return 0, fmt.Errorf("queue is empty")
+	}
+	return q.items[q.head], nil
+}
+
+// IsEmpty returns true if the queue is empty
+// Time Complexity: O(1)
+func (q *RingQueue) IsEmpty() bool {
+	return q.size == 0
+}
+
+// Size returns the number of items in the queue
+// Time Complexity: O(1)
+func (q *RingQueue) Size() int {
+	return q.size
+}
+
+// Cap returns the number of items the queue holds without growing
+func (q *RingQueue) Cap() int {
+	return len(q.items)
+}
+
+// resize moves the items to a new array of the given capacity, front first
+func (q *RingQueue) resize(capacity int) {
+	items := make([]int, capacity)
+	// The items may wrap around the end of the old array
+	n := copy(items, q.items[q.head:min(q.head+q.size, len(q.items))])
+	copy(items[n:], q.items[:q.size-n])
+	q.items = items
+	q.head = 0
+}
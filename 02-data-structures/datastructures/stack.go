@@ -0,0 +1,139 @@
+// This file implements a stack data structure in Go
+// A stack is a Last-In-First-Out (LIFO) data structure
+// Elements are added (pushed) and removed (popped) from the same end
+//
+// Time Complexity:
+// - Push: O(1) amortized
+// - Pop: O(1)
+// - Peek: O(1)
+//
+// Use Cases:
+// - Function call management (call stack)
+// - Expression evaluation
+// - Undo/Redo operations
+// - Depth-first search implementation
+// - Parentheses matching
+
+package datastructures
+
+import (
+	"fmt"
+	"slices"
+)
+
+// Stack represents a stack of ints
+// It is the StackOf type for the common case; the iterative tree
+// traversals use a StackOf[*TreeNode]
+type Stack = StackOf[int]
+
+// StackOf represents a stack data structure holding items of type T
+// This implementation uses a slice as the underlying storage
+// The last element in the slice is the top of the stack
+// T can be any type, slices and funcs included, so ContainsFunc takes the
+// comparison; Contains compares with == for the types that allow it
+type StackOf[T any] struct {
+	items []T
+}
+
+// Push adds an item to the top of the stack
+// Time Complexity: O(1) amortized
+// Note: While append is O(1) amortized, it may occasionally need to
+// reallocate and copy the underlying array
+func (s *StackOf[T]) Push(item T) {
+	s.items = append(s.items, item)
+}
+
+// Pop removes and returns the top item from the stack
+// Time Complexity: O(1)
+func (s *StackOf[T]) Pop() (T, error) {
+	var zero T
+	if len(s.items) == 0 {
+		return zero, fmt.Errorf("stack is empty")
+	}
+
+	// Get the index of the last item
+	index := len(s.items) - 1
+	// Get the last item
+	item := s.items[index]
+	// Remove it from the stack, clearing the slot so a popped pointer
+	// doesn't keep its target alive
+	s.items[index] = zero
+	s.items = s.items[:index]
+	return item, nil
+}
+
+// Peek returns the top item without removing it
+// Time Complexity: O(1)
+func (s *StackOf[T]) Peek() (T, error) {
+	if len(s.items) == 0 {
+		var zero T
+		return zero, fmt.Errorf("stack is empty")
+	}
+	return s.items[len(s.items)-1], nil
+}
+
+// IsEmpty returns true if the stack is empty
+// Time Complexity: O(1)
+func (s *StackOf[T]) IsEmpty() bool {
+	return len(s.items) == 0
+}
+
+// Size returns the number of items in the stack
+// Time Complexity: O(1)
+func (s *StackOf[T]) Size() int {
+	return len(s.items)
+}
+
+// Clear removes every item and releases the underlying array
+// Time Complexity: O(1)
+func (s *StackOf[T]) Clear() {
+	s.items = nil
+}
+
+// ContainsFunc reports whether any item in the stack satisfies match
+// Time Complexity: O(n)
+func (s *StackOf[T]) ContainsFunc(match func(T) bool) bool {
+	return slices.ContainsFunc(s.items, match)
+}
+
+// Searchable is a collection that ContainsFunc searches: StackOf, QueueOf
+// and their bounded versions
+type Searchable[T any] interface {
+	ContainsFunc(match func(T) bool) bool
+}
+
+// Contains reports whether item is anywhere in c, comparing with ==
+// It is a function rather than a method because it needs T comparable,
+// which the collections themselves don't
+// Time Complexity: O(n)
+func Contains[T comparable](c Searchable[T], item T) bool {
+	return c.ContainsFunc(func(v T) bool { return v == item })
+}
+
+// ToSlice returns a copy of the items, bottom first, so the last element is
+// the one Pop would return next
+// Time Complexity: O(n)
+func (s *StackOf[T]) ToSlice() []T {
+	return slices.Clone(s.items)
+}
+
+// IsValidBrackets checks that the brackets (), [] and {} in s are balanced
+// and properly nested, as in code: "f(a[i], {b})" is valid, "(]" is not.
+// This is a classic use of a stack: every opening bracket pushes the closing
+// bracket it expects, and every closing bracket must match the top
+//
+// Quotes and escapes are understood, so brackets in string literals don't count:
+//   - Text between double quotes "..." or single quotes '...' is skipped
+//   - A backslash escapes the next character, inside quotes or not, so \" and
+//     \( are plain characters
+//   - A quote left open or a backslash at the very end make s invalid
+//
+// It is NewMatcher().Valid(s); use a Matcher to find where a mismatch is, or
+// to skip comments too
+// Time Complexity: O(n) where n is the length of the input string
+func IsValidBrackets(s string) bool {
+	return defaultMatcher.Valid(s)
+}
+
+// defaultMatcher is never modified, so IsValidBrackets can share it
+var defaultMatcher = NewMatcher()
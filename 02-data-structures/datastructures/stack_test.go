@@ -0,0 +1,217 @@
+package datastructures
+
+import (
+	"slices"
+	"strings"
+	"testing"
+)
+
+func TestStackPushPop(t *testing.T) {
+	tests := []struct {
+		name   string
+		pushes []int
+		want   []int // Expected Pop results, top first
+	}{
+		{"single", []int{7}, []int{7}},
+		{"lifo order", []int{1, 2, 3}, []int{3, 2, 1}},
+		{"duplicates", []int{5, 5, 5}, []int{5, 5, 5}},
+		{"negative and zero", []int{-1, 0, 1}, []int{1, 0, -1}},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			s := &Stack{}
+			for _, v := range tt.pushes {
+				s.Push(v)
+			}
+			if got := s.Size(); got != len(tt.pushes) {
+				t.Fatalf("Size() = %d, want %d", got, len(tt.pushes))
+			}
+			for i, want := range tt.want {
+				top, err := s.Peek()
+				if err != nil || top != want {
+					t.Fatalf("Peek() #%d = %d, %v; want %d, nil", i, top, err, want)
+				}
+				got, err := s.Pop()
+				if err != nil || got != want {
+					t.Fatalf("Pop() #%d = %d, %v; want %d, nil", i, got, err, want)
+				}
+			}
+			if !s.IsEmpty() {
+				t.Errorf("IsEmpty() = false after popping everything")
+			}
+		})
+	}
+}
+
+func TestStackEmpty(t *testing.T) {
+	var s Stack // The zero value is a usable empty stack
+	if !s.IsEmpty() || s.Size() != 0 {
+		t.Fatalf("zero Stack: IsEmpty() = %v, Size() = %d", s.IsEmpty(), s.Size())
+	}
+	if _, err := s.Pop(); err == nil {
+		t.Error("Pop() on empty stack returned no error")
+	}
+	if _, err := s.Peek(); err == nil {
+		t.Error("Peek() on empty stack returned no error")
+	}
+
+	// Popping past the bottom must not corrupt the stack
+	s.Push(1)
+	s.Pop()
+	s.Pop()
+	s.Push(2)
+	if got, err := s.Pop(); err != nil || got != 2 {
+		t.Errorf("Pop() after underflow = %d, %v; want 2, nil", got, err)
+	}
+}
+
+func TestStackPeekDoesNotRemove(t *testing.T) {
+	s := &Stack{}
+	s.Push(42)
+	for i := 0; i < 3; i++ {
+		s.Peek()
+	}
+	if s.Size() != 1 {
+		t.Errorf("Size() after Peek = %d, want 1", s.Size())
+	}
+}
+
+// is returns a match for ContainsFunc that compares with want
+func is(want string) func(string) bool {
+	return func(s string) bool { return s == want }
+}
+
+func TestStackClearContainsToSlice(t *testing.T) {
+	s := &StackOf[string]{}
+	for _, v := range []string{"a", "b", "c"} {
+		s.Push(v)
+	}
+	if got := s.ToSlice(); !slices.Equal(got, []string{"a", "b", "c"}) {
+		t.Errorf("ToSlice() = %v, want [a b c]", got)
+	}
+	// ToSlice is a copy
+	s.ToSlice()[0] = "changed"
+	if !s.ContainsFunc(is("a")) || !s.ContainsFunc(is("c")) || s.ContainsFunc(is("changed")) || s.ContainsFunc(is("d")) {
+		t.Errorf("ContainsFunc gives the wrong answer for %v", s.ToSlice())
+	}
+
+	s.Clear()
+	if !s.IsEmpty() || s.ContainsFunc(is("a")) || len(s.ToSlice()) != 0 {
+		t.Errorf("stack not empty after Clear: %v", s.ToSlice())
+	}
+	s.Push("d")
+	if got, _ := s.Pop(); got != "d" {
+		t.Errorf("Pop() after Clear = %q, want d", got)
+	}
+}
+
+// Items need not be comparable: a stack of slices
+func TestStackOfSlices(t *testing.T) {
+	var s StackOf[[]int]
+	s.Push([]int{1, 2})
+	s.Push([]int{3})
+	if !s.ContainsFunc(func(v []int) bool { return slices.Equal(v, []int{1, 2}) }) {
+		t.Errorf("ContainsFunc misses [1 2] in %v", s.ToSlice())
+	}
+	if top, _ := s.Pop(); !slices.Equal(top, []int{3}) {
+		t.Errorf("Pop() = %v, want [3]", top)
+	}
+}
+
+func TestIsValidBrackets(t *testing.T) {
+	tests := []struct {
+		input string
+		want  bool
+	}{
+		{"", true},
+		{"()", true},
+		{"(())()", true},
+		{"(a + b) * (c - d)", true},
+		{"no brackets", true},
+		{"(", false},
+		{")", false},
+		{")(", false},
+		{"(()", false},
+		{"())(", false},
+		// All three kinds, properly nested
+		{"f(a[i], {b})", true},
+		{"{[()()]}", true},
+		{"(]", false},
+		{"([)]", false},
+		{"{", false},
+		// Brackets inside quotes don't count
+		{`print(")")`, true},
+		{`x = '('`, true},
+		{`"(" + ")"`, true},
+		{`'"' (`, false},
+		{`"it's ("`, true},
+		{`"unterminated (`, false},
+		// Escapes
+		{`"a \" ("`, true},
+		{`\(`, true},
+		{`(\)`, false},
+		{`"\\" ()`, true},
+		{`()\`, false},
+	}
+	for _, tt := range tests {
+		if got := IsValidBrackets(tt.input); got != tt.want {
+			t.Errorf("IsValidBrackets(%q) = %v, want %v", tt.input, got, tt.want)
+		}
+	}
+}
+
+// referenceValid is the reference for IsValidBrackets. It keeps only the
+// brackets outside quotes, then deletes adjacent matching pairs until none
+// are left: the brackets are valid if that empties the string
+func referenceValid(s string) bool {
+	var brackets []rune
+	var quote rune
+	escaped := false
+	for _, ch := range s {
+		switch {
+		case escaped:
+			escaped = false
+		case ch == '\\':
+			escaped = true
+		case quote != 0:
+			if ch == quote {
+				quote = 0
+			}
+		case ch == '"' || ch == '\'':
+			quote = ch
+		case strings.ContainsRune("()[]{}", ch):
+			brackets = append(brackets, ch)
+		}
+	}
+	if quote != 0 || escaped {
+		return false
+	}
+
+	rest := string(brackets)
+	for {
+		shorter := strings.NewReplacer("()", "", "[]", "", "{}", "").Replace(rest)
+		if shorter == rest {
+			return rest == ""
+		}
+		rest = shorter
+	}
+}
+
+// go test runs the seeds; go test ./02-data-structures/datastructures -fuzz=FuzzIsValidBrackets
+// generates new inputs, including invalid UTF-8
+func FuzzIsValidBrackets(f *testing.F) {
+	for _, seed := range []string{"", "()", "(()", ")(", "([)]", `f("(", {a[0]})`, `'\''`, "(ก + ข) * 😀", "\xff(\xfe)"} {
+		f.Add(seed)
+	}
+	f.Fuzz(func(t *testing.T, s string) {
+		got := IsValidBrackets(s)
+		if want := referenceValid(s); got != want {
+			t.Errorf("IsValidBrackets(%q) = %v, referenceValid = %v", s, got, want)
+		}
+		// Two valid strings in a row are valid too: each one closes every
+		// bracket and quote it opens
+		if got && !IsValidBrackets(s+s) {
+			t.Errorf("IsValidBrackets(%q) = true but false repeated twice", s)
+		}
+	})
+}
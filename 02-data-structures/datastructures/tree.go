@@ -16,9 +16,7 @@
 // - Expression parsing
 // - Priority queues
 
-package main
-
-import "fmt"
+package datastructures
 
 // TreeNode represents a node in a binary tree
 // Each node contains:
@@ -158,35 +156,52 @@ func (t *BinaryTree) postorderRecursive(node *TreeNode, result *[]int) {
 	}
 }
 
-func main() {
-	// Create a binary search tree
-	tree := &BinaryTree{}
-
-	// Example 1: Inserting values
-	fmt.Println("Example 1: Building the tree")
-	fmt.Println("Inserting: 5, 3, 7, 1, 4, 6, 8")
-	values := []int{5, 3, 7, 1, 4, 6, 8}
-	// This creates the following tree:
-	//       5
-	//      / \
-	//     3   7
-	//    / \  / \
-	//   1   4 6  8
-	for _, value := range values {
-		tree.Insert(value)
+// The Append traversals add the values to dst and return the extended slice,
+// like strconv.AppendInt. The traversals above start from an empty slice and
+// grow it about log2(n) times; passing a slice with room for every value,
+// e.g. one reused from the last traversal as dst[:0], allocates nothing
+
+// AppendInorder appends the values in inorder to dst
+// Time Complexity: O(n)
+func (t *BinaryTree) AppendInorder(dst []int) []int {
+	return appendInorder(dst, t.Root)
+}
+
+func appendInorder(dst []int, node *TreeNode) []int {
+	if node == nil {
+		return dst
+	}
+	dst = appendInorder(dst, node.Left)
+	dst = append(dst, node.Value)
+	return appendInorder(dst, node.Right)
+}
+
+// AppendPreorder appends the values in preorder to dst
+// Time Complexity: O(n)
+func (t *BinaryTree) AppendPreorder(dst []int) []int {
+	return appendPreorder(dst, t.Root)
+}
+
+func appendPreorder(dst []int, node *TreeNode) []int {
+	if node == nil {
+		return dst
 	}
+	dst = append(dst, node.Value)
+	dst = appendPreorder(dst, node.Left)
+	return appendPreorder(dst, node.Right)
+}
 
-	// Example 2: Different traversals
-	fmt.Println("\nExample 2: Tree Traversals")
-	fmt.Println("Inorder (sorted):", tree.InorderTraversal())
-	fmt.Println("Preorder:", tree.PreorderTraversal())
-	fmt.Println("Postorder:", tree.PostorderTraversal())
-
-	// Example 3: Searching
-	fmt.Println("\nExample 3: Searching for values")
-	searchValues := []int{4, 9}
-	for _, value := range searchValues {
-		exists := tree.Search(value)
-		fmt.Printf("Is %d in the tree? %v\n", value, exists)
+// AppendPostorder appends the values in postorder to dst
+// Time Complexity: O(n)
+func (t *BinaryTree) AppendPostorder(dst []int) []int {
+	return appendPostorder(dst, t.Root)
+}
+
+func appendPostorder(dst []int, node *TreeNode) []int {
+	if node == nil {
+		return dst
 	}
+	dst = appendPostorder(dst, node.Left)
+	dst = appendPostorder(dst, node.Right)
+	return append(dst, node.Value)
 }
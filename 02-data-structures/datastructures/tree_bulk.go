@@ -0,0 +1,237 @@
+// This file implements bulk operations on the binary search trees, the
+// int BinaryTree and the generic BinaryTreeOf: building a tree from sorted
+// values, merging two trees, and reading one back out as a sorted slice
+// Inserting n values one at a time costs O(n log n) on random input and
+// O(n²) on sorted input, which makes a path n levels deep. Working on the
+// whole sorted slice at once does better on both counts: the middle value
+// becomes the root and each half becomes a subtree, so every node is
+// created once, in O(n) in total, and distinct values make a tree as low as
+// it can be, ⌈log₂(n+1)⌉ levels
+// Duplicates can't all be balanced away: equal values go right of each
+// other, so k copies of a value make a path at least k levels deep
+//
+// Time Complexity:
+// - BuildBalanced: O(n) for distinct values, O(n log n) at worst with
+//   duplicates
+// - ToSortedSlice: O(n)
+// - Merge: O(n + m) for trees of n and m values
+// - Height: O(n)
+//
+// Use Cases:
+// - Loading a tree from data that is already sorted, such as a file or a
+//   database index
+// - Rebalancing a tree that has grown lopsided: BuildBalanced(t.ToSortedSlice())
+// - Combining two sets kept in trees
+//
+// BuildBalancedFunc, BuildBalancedOf and MergeOf do the same for a
+// BinaryTreeOf, whose keys are unique: of equal keys the last one given
+// wins, as with Put
+
+package datastructures
+
+import (
+	"cmp"
+	"sort"
+
+	"github.com/NutProhmpiriya/go-basic/ordering"
+)
+
+// BuildBalanced returns a tree holding the values of sorted, which must be
+// in ascending order, with the least possible height
+// Equal values follow the rule of Insert and go right: the root of every
+// subtree is the first of the values equal to the middle one, so k copies
+// of a value make a path k levels deep
+// Time Complexity: O(n) for distinct values; O(n log n) at worst with
+// duplicates, which are skipped with a binary search
+func BuildBalanced(sorted []int) *BinaryTree {
+	return &BinaryTree{Root: buildBalanced(sorted)}
+}
+
+// buildBalanced makes the middle value the root of the values and builds
+// its subtrees from the halves either side. It recurses log₂ n deep for
+// distinct values, and as deep as the tree for duplicates
+func buildBalanced(sorted []int) *TreeNode {
+	if len(sorted) == 0 {
+		return nil
+	}
+	// The first copy of the middle value, found by binary search: a linear
+	// scan back over a long run of duplicates would cost O(n) per level
+	mid := len(sorted) / 2
+	mid = sort.SearchInts(sorted[:mid], sorted[mid])
+	return &TreeNode{
+		Value: sorted[mid],
+		Left:  buildBalanced(sorted[:mid]),
+		Right: buildBalanced(sorted[mid+1:]),
+	}
+}
+
+// ToSortedSlice returns the values of the tree in ascending order
+// It is InorderTraversal, named for its part in the bulk operations; a nil
+// tree is empty
+// Time Complexity: O(n)
+func (t *BinaryTree) ToSortedSlice() []int {
+	if t == nil {
+		return nil
+	}
+	return t.AppendInorder(nil)
+}
+
+// Merge returns a balanced tree holding the values of both trees, which
+// are left as they were; a nil tree counts as empty
+// Inserting the values of one tree into the other would cost O(m log n),
+// or worse if the tree is lopsided. Merge reads both out in order, merges
+// the sorted slices as merge sort does, and builds the result from that
+// Time Complexity: O(n + m)
+func Merge(a, b *BinaryTree) *BinaryTree {
+	x, y := a.ToSortedSlice(), b.ToSortedSlice()
+	merged := make([]int, 0, len(x)+len(y))
+	i, j := 0, 0
+	for i < len(x) && j < len(y) {
+		if y[j] < x[i] {
+			merged = append(merged, y[j])
+			j++
+		} else {
+			merged = append(merged, x[i])
+			i++
+		}
+	}
+	merged = append(merged, x[i:]...)
+	merged = append(merged, y[j:]...)
+	return BuildBalanced(merged)
+}
+
+// Height returns the number of levels in the tree: 0 when it is empty, 1
+// for the root alone
+// It counts level by level rather than recursing, so a tree that is a long
+// path is fine
+// Time Complexity: O(n)
+func (t *BinaryTree) Height() int {
+	if t == nil {
+		return 0
+	}
+	height := 0
+	for level := []*TreeNode{t.Root}; ; height++ {
+		var next []*TreeNode
+		for _, n := range level {
+			if n != nil {
+				next = append(next, n.Left, n.Right)
+			}
+		}
+		if len(next) == 0 {
+			return height
+		}
+		level = next
+	}
+}
+
+// Entry is a key and its value, the element of a BinaryTreeOf's sorted slice
+type Entry[K, V any] struct {
+	Key   K
+	Value V
+}
+
+// BuildBalancedFunc returns a tree holding sorted, which must be in
+// ascending key order by compare, with the least possible height
+// The keys of a BinaryTreeOf are unique, so of a run of equal keys only
+// the last entry is kept, as if they were Put in order
+// Time Complexity: O(n)
+func BuildBalancedFunc[K, V any](sorted []Entry[K, V], compare func(a, b K) int) *BinaryTreeOf[K, V] {
+	unique := make([]Entry[K, V], 0, len(sorted))
+	for _, e := range sorted {
+		if n := len(unique); n > 0 && compare(unique[n-1].Key, e.Key) == 0 {
+			unique[n-1] = e
+			continue
+		}
+		unique = append(unique, e)
+	}
+	return &BinaryTreeOf[K, V]{root: buildBalancedOf(unique), compare: compare, size: len(unique)}
+}
+
+// BuildBalancedOf is BuildBalancedFunc for keys in their natural order
+func BuildBalancedOf[K cmp.Ordered, V any](sorted []Entry[K, V]) *BinaryTreeOf[K, V] {
+	return BuildBalancedFunc(sorted, ordering.Natural[K]())
+}
+
+// buildBalancedOf is buildBalanced for distinct keys: the middle one is
+// the root, and the recursion is log₂ n deep
+func buildBalancedOf[K, V any](sorted []Entry[K, V]) *bstNode[K, V] {
+	if len(sorted) == 0 {
+		return nil
+	}
+	mid := len(sorted) / 2
+	return &bstNode[K, V]{
+		key:   sorted[mid].Key,
+		value: sorted[mid].Value,
+		left:  buildBalancedOf(sorted[:mid]),
+		right: buildBalancedOf(sorted[mid+1:]),
+	}
+}
+
+// ToSortedSlice returns the entries of the tree in ascending key order; a
+// nil tree is empty
+// Time Complexity: O(n)
+func (t *BinaryTreeOf[K, V]) ToSortedSlice() []Entry[K, V] {
+	if t == nil {
+		return nil
+	}
+	entries := make([]Entry[K, V], 0, t.size)
+	for k, v := range t.All() {
+		entries = append(entries, Entry[K, V]{k, v})
+	}
+	return entries
+}
+
+// MergeOf returns a balanced tree holding the entries of both trees, which
+// are left as they were. For a key in both, b's value wins
+// Both trees must order their keys the same way; the result uses the
+// comparator of a, or of b when a is nil. A nil tree counts as empty, and
+// merging two nil trees gives nil
+// Time Complexity: O(n + m)
+func MergeOf[K, V any](a, b *BinaryTreeOf[K, V]) *BinaryTreeOf[K, V] {
+	if a == nil && b == nil {
+		return nil
+	}
+	var compare func(a, b K) int
+	if a != nil {
+		compare = a.compare
+	} else {
+		compare = b.compare
+	}
+	x, y := a.ToSortedSlice(), b.ToSortedSlice()
+	merged := make([]Entry[K, V], 0, len(x)+len(y))
+	i, j := 0, 0
+	for i < len(x) && j < len(y) {
+		// Equal keys take a's entry first, so BuildBalancedFunc keeps b's
+		if compare(y[j].Key, x[i].Key) < 0 {
+			merged = append(merged, y[j])
+			j++
+		} else {
+			merged = append(merged, x[i])
+			i++
+		}
+	}
+	merged = append(merged, x[i:]...)
+	merged = append(merged, y[j:]...)
+	return BuildBalancedFunc(merged, compare)
+}
+
+// Height returns the number of levels in the tree, like BinaryTree.Height
+// Time Complexity: O(n)
+func (t *BinaryTreeOf[K, V]) Height() int {
+	if t == nil {
+		return 0
+	}
+	height := 0
+	for level := []*bstNode[K, V]{t.root}; ; height++ {
+		var next []*bstNode[K, V]
+		for _, n := range level {
+			if n != nil {
+				next = append(next, n.left, n.right)
+			}
+		}
+		if len(next) == 0 {
+			return height
+		}
+		level = next
+	}
+}
@@ -0,0 +1,231 @@
+package datastructures
+
+import (
+	"fmt"
+	"math/bits"
+	"slices"
+	"strings"
+	"testing"
+
+	"github.com/NutProhmpiriya/go-basic/testdata/generator"
+)
+
+func TestBuildBalanced(t *testing.T) {
+	g := generator.New(1)
+	tests := []struct {
+		name   string
+		values []int
+	}{
+		{"empty", nil},
+		{"single", []int{1}},
+		{"two", []int{1, 2}},
+		{"full", generator.Sorted(15)},
+		{"one short of full", generator.Sorted(14)},
+		{"large", generator.Sorted(100_000)},
+		{"duplicates", slices.Sorted(slices.Values(g.FewUnique(200, 5)))},
+		{"all equal", []int{7, 7, 7, 7}},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			tree := BuildBalanced(tt.values)
+			if err := tree.Validate(); err != nil {
+				t.Fatal(err)
+			}
+			if got := tree.ToSortedSlice(); !slices.Equal(got, tt.values) {
+				t.Fatalf("ToSortedSlice() = %v, want %v", got, tt.values)
+			}
+			for _, v := range tt.values {
+				if !tree.Search(v) {
+					t.Fatalf("Search(%d) = false", v)
+				}
+			}
+			// Distinct values make the lowest tree there is
+			if want := bits.Len(uint(len(tt.values))); !slices.Contains([]string{"duplicates", "all equal"}, tt.name) && tree.Height() != want {
+				t.Errorf("Height() = %d for %d values, want %d", tree.Height(), len(tt.values), want)
+			}
+		})
+	}
+}
+
+func TestBuildBalancedDuplicates(t *testing.T) {
+	// Every copy of a value is on one path, so 50000 equal values make a
+	// tree 50000 levels deep; finding each root by binary search keeps the
+	// build O(n log n) rather than quadratic
+	same := slices.Repeat([]int{7}, 50_000)
+	tree := BuildBalanced(same)
+	if h := tree.Height(); h != len(same) {
+		t.Errorf("Height() = %d for %d equal values", h, len(same))
+	}
+	if got := tree.ToSortedSlice(); !slices.Equal(got, same) {
+		t.Error("ToSortedSlice() lost values")
+	}
+
+	// Runs of equal values only add their length to the height
+	g := generator.New(2)
+	values := slices.Sorted(slices.Values(g.FewUnique(100_000, 1000)))
+	tree = BuildBalanced(values)
+	if err := tree.Validate(); err != nil {
+		t.Fatal(err)
+	}
+	if got := tree.ToSortedSlice(); !slices.Equal(got, values) {
+		t.Error("ToSortedSlice() differs from the input")
+	}
+	longest := 0
+	for i := 0; i < len(values); {
+		j := i
+		for j < len(values) && values[j] == values[i] {
+			j++
+		}
+		longest = max(longest, j-i)
+		i = j
+	}
+	if h, limit := tree.Height(), longest+bits.Len(uint(len(values))); h > limit {
+		t.Errorf("Height() = %d, more than the longest run plus log₂ n, %d", h, limit)
+	}
+}
+
+func TestMerge(t *testing.T) {
+	a := newTree(5, 1, 9, 3)
+	b := newTree(4, 3, 8, 10, 0)
+	merged := Merge(a, b)
+	if got := merged.ToSortedSlice(); !slices.Equal(got, []int{0, 1, 3, 3, 4, 5, 8, 9, 10}) {
+		t.Errorf("Merge = %v", got)
+	}
+	if err := merged.Validate(); err != nil || merged.Height() != 4 {
+		t.Errorf("merged tree: %v, height %d", err, merged.Height())
+	}
+	if got := a.ToSortedSlice(); !slices.Equal(got, []int{1, 3, 5, 9}) {
+		t.Errorf("Merge changed a to %v", got)
+	}
+	if got := Merge(&BinaryTree{}, b).ToSortedSlice(); !slices.Equal(got, b.ToSortedSlice()) {
+		t.Errorf("Merge with an empty tree = %v", got)
+	}
+	// A nil tree is empty
+	if got := Merge(nil, b).ToSortedSlice(); !slices.Equal(got, b.ToSortedSlice()) {
+		t.Errorf("Merge(nil, b) = %v", got)
+	}
+	if got := Merge(a, nil).ToSortedSlice(); !slices.Equal(got, a.ToSortedSlice()) {
+		t.Errorf("Merge(a, nil) = %v", got)
+	}
+}
+
+func TestHeight(t *testing.T) {
+	for _, tt := range []struct {
+		tree *BinaryTree
+		want int
+	}{
+		{nil, 0},
+		{&BinaryTree{}, 0},
+		{newTree(1), 1},
+		{newTree(2, 1, 3), 2},
+		{newTree(1, 2, 3), 3},
+		{chain(1_000_000), 1_000_000},
+	} {
+		if got := tt.tree.Height(); got != tt.want {
+			t.Errorf("Height() = %d, want %d", got, tt.want)
+		}
+	}
+}
+
+// entries pairs each key with its index, so the value tells which of
+// equal keys was kept
+func entries[K any](keys ...K) []Entry[K, int] {
+	out := make([]Entry[K, int], len(keys))
+	for i, k := range keys {
+		out[i] = Entry[K, int]{k, i}
+	}
+	return out
+}
+
+func TestBuildBalancedOf(t *testing.T) {
+	for _, n := range []int{0, 1, 2, 3, 7, 8, 1000} {
+		keys := generator.Sorted(n)
+		tree := BuildBalancedOf(entries(keys...))
+		if got := tree.ToSortedSlice(); !slices.Equal(got, entries(keys...)) {
+			t.Fatalf("n=%d: ToSortedSlice() = %v", n, got)
+		}
+		if tree.Len() != n || tree.Height() != bits.Len(uint(n)) {
+			t.Errorf("n=%d: Len() = %d, Height() = %d, want %d", n, tree.Len(), tree.Height(), bits.Len(uint(n)))
+		}
+		// The tree still takes Put and Delete like one built by Put
+		tree.Put(n, n)
+		if v, ok := tree.Get(n); !ok || v != n {
+			t.Errorf("n=%d: Get after Put = %d, %v", n, v, ok)
+		}
+	}
+
+	// Of equal keys the last one wins, as with Put
+	tree := BuildBalancedOf(entries("a", "b", "b", "b", "c"))
+	want := []Entry[string, int]{{"a", 0}, {"b", 3}, {"c", 4}}
+	if got := tree.ToSortedSlice(); !slices.Equal(got, want) || tree.Len() != 3 {
+		t.Errorf("ToSortedSlice() = %v, Len() = %d, want %v", got, tree.Len(), want)
+	}
+}
+
+func TestBuildBalancedFunc(t *testing.T) {
+	// Sorted case-insensitively, descending
+	compare := func(a, b string) int { return strings.Compare(strings.ToLower(b), strings.ToLower(a)) }
+	tree := BuildBalancedFunc(entries("c", "B", "b", "a"), compare)
+	want := []Entry[string, int]{{"c", 0}, {"b", 2}, {"a", 3}}
+	if got := tree.ToSortedSlice(); !slices.Equal(got, want) {
+		t.Errorf("ToSortedSlice() = %v, want %v", got, want)
+	}
+	if !tree.Contains("A") || tree.Contains("d") {
+		t.Error("the built tree does not search with its comparator")
+	}
+}
+
+func TestMergeOf(t *testing.T) {
+	a := BuildBalancedOf([]Entry[string, int]{{"apple", 1}, {"fig", 2}, {"pear", 3}})
+	b := BuildBalancedOf([]Entry[string, int]{{"banana", 10}, {"fig", 20}})
+	merged := MergeOf(a, b)
+	want := []Entry[string, int]{{"apple", 1}, {"banana", 10}, {"fig", 20}, {"pear", 3}}
+	if got := merged.ToSortedSlice(); !slices.Equal(got, want) {
+		t.Errorf("MergeOf = %v, want %v (b's value wins)", got, want)
+	}
+	if merged.Len() != 4 || merged.Height() != 3 {
+		t.Errorf("merged tree: Len() = %d, Height() = %d", merged.Len(), merged.Height())
+	}
+	if v, _ := a.Get("fig"); v != 2 {
+		t.Errorf("MergeOf changed a's fig to %d", v)
+	}
+
+	// A nil tree is empty
+	if got := MergeOf(nil, b).ToSortedSlice(); !slices.Equal(got, b.ToSortedSlice()) {
+		t.Errorf("MergeOf(nil, b) = %v", got)
+	}
+	if got := MergeOf(a, nil).ToSortedSlice(); !slices.Equal(got, a.ToSortedSlice()) {
+		t.Errorf("MergeOf(a, nil) = %v", got)
+	}
+	var empty *BinaryTreeOf[string, int]
+	if MergeOf(empty, empty) != nil || empty.Height() != 0 || empty.ToSortedSlice() != nil {
+		t.Error("two nil trees do not merge into nil")
+	}
+}
+
+// BenchmarkBuild compares building a tree from sorted values with
+// BuildBalanced and by inserting them one by one, which makes a path
+// Even in random order, where Insert takes O(n log n), it stays behind
+func BenchmarkBuild(b *testing.B) {
+	const n = 5000
+	sorted := generator.Sorted(n)
+	shuffled := generator.New(1).Perm(n)
+	b.Run(fmt.Sprintf("impl=BuildBalanced/n=%d", n), func(b *testing.B) {
+		for range b.N {
+			BuildBalanced(sorted)
+		}
+	})
+	b.Run(fmt.Sprintf("impl=InsertShuffled/n=%d", n), func(b *testing.B) {
+		for range b.N {
+			newTree(shuffled...)
+		}
+	})
+	b.Run(fmt.Sprintf("impl=InsertSorted/n=%d", n), func(b *testing.B) {
+		for range b.N {
+			tree := &BinaryTree{}
+			for _, v := range sorted {
+				tree.InsertIterative(v)
+			}
+		}
+	})
+}
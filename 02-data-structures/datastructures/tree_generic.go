@@ -0,0 +1,200 @@
+// This file implements the binary search tree of tree.go for keys of any
+// type, each with a value: a sorted map
+// The keys are ordered by a comparator, so they can be anything it knows
+// how to order, such as strings, times, or structs ordered by a field
+// Like BinaryTree it does no balancing, so sorted input still makes a path;
+// OrderStatTree is the balanced tree
+//
+// Time Complexity:
+// - Put, Get, Delete: O(h), where h is the height: O(log n) average, O(n)
+//   worst case
+// - Range: O(h + m) for m keys in the range
+// - All, Keys: O(n)
+// - Len: O(1)
+//
+// Use Cases:
+// - Sorted maps: a dictionary printed in order, an index by date
+// - Range queries: every event between two times, every word from "ca" to "cb"
+
+package datastructures
+
+import (
+	"cmp"
+	"iter"
+
+	"github.com/NutProhmpiriya/go-basic/ordering"
+)
+
+// BinaryTreeOf is a binary search tree mapping keys of type K to values of
+// type V
+// compare orders the keys as cmp.Compare does; keys that compare equal are
+// the same key. BinaryTree stays the int tree, because the algorithms in
+// 03-algorithms work on its TreeNode
+type BinaryTreeOf[K, V any] struct {
+	root    *bstNode[K, V]
+	compare func(a, b K) int
+	size    int
+}
+
+// bstNode is a node of a BinaryTreeOf
+type bstNode[K, V any] struct {
+	key         K
+	value       V
+	left, right *bstNode[K, V]
+}
+
+// NewBinaryTreeFunc creates an empty tree with keys ordered by compare
+func NewBinaryTreeFunc[K, V any](compare func(a, b K) int) *BinaryTreeOf[K, V] {
+	return &BinaryTreeOf[K, V]{compare: compare}
+}
+
+// NewBinaryTreeOf creates an empty tree with keys in their natural order,
+// using cmp.Compare
+func NewBinaryTreeOf[K cmp.Ordered, V any]() *BinaryTreeOf[K, V] {
+	return NewBinaryTreeFunc[K, V](ordering.Natural[K]())
+}
+
+// Len returns the number of keys in the tree
+// Time Complexity: O(1)
+func (t *BinaryTreeOf[K, V]) Len() int {
+	return t.size
+}
+
+// find returns the link that points to the node with key, or the nil link
+// where it would go
+func (t *BinaryTreeOf[K, V]) find(key K) **bstNode[K, V] {
+	link := &t.root
+	for *link != nil {
+		switch c := t.compare(key, (*link).key); {
+		case c < 0:
+			link = &(*link).left
+		case c > 0:
+			link = &(*link).right
+		default:
+			return link
+		}
+	}
+	return link
+}
+
+// Put maps key to value, replacing the value of a key already in the tree,
+// and reports whether the key is new
+// Time Complexity: O(log n) average, O(n) worst case
+func (t *BinaryTreeOf[K, V]) Put(key K, value V) bool {
+	link := t.find(key)
+	if *link != nil {
+		(*link).value = value
+		return false
+	}
+	*link = &bstNode[K, V]{key: key, value: value}
+	t.size++
+	return true
+}
+
+// Get returns the value of key, and whether the key is in the tree
+// Time Complexity: O(log n) average, O(n) worst case
+func (t *BinaryTreeOf[K, V]) Get(key K) (V, bool) {
+	if n := *t.find(key); n != nil {
+		return n.value, true
+	}
+	var zero V
+	return zero, false
+}
+
+// Contains reports whether key is in the tree
+// Time Complexity: O(log n) average, O(n) worst case
+func (t *BinaryTreeOf[K, V]) Contains(key K) bool {
+	return *t.find(key) != nil
+}
+
+// Delete removes key and its value, and reports whether it was there
+// Time Complexity: O(log n) average, O(n) worst case
+func (t *BinaryTreeOf[K, V]) Delete(key K) bool {
+	link := t.find(key)
+	n := *link
+	if n == nil {
+		return false
+	}
+	switch {
+	case n.left == nil:
+		*link = n.right
+	case n.right == nil:
+		*link = n.left
+	default:
+		// Two children: the smallest key on the right, the successor, takes
+		// the node's place. It has no left child, so its right child takes
+		// its own place
+		succ := &n.right
+		for (*succ).left != nil {
+			succ = &(*succ).left
+		}
+		s := *succ
+		*succ = s.right
+		s.left, s.right = n.left, n.right
+		*link = s
+	}
+	t.size--
+	return true
+}
+
+// All returns an iterator over the keys and values in ascending key order
+// Time Complexity: O(n)
+func (t *BinaryTreeOf[K, V]) All() iter.Seq2[K, V] {
+	return t.ascend(nil, nil)
+}
+
+// Keys returns an iterator over the keys in ascending order
+// Time Complexity: O(n)
+func (t *BinaryTreeOf[K, V]) Keys() iter.Seq[K] {
+	return func(yield func(K) bool) {
+		for k := range t.All() {
+			if !yield(k) {
+				return
+			}
+		}
+	}
+}
+
+// Range returns an iterator over the keys from lo up to but not including
+// hi, and their values, in ascending order, like a slice expression
+// Subtrees entirely outside the range are never visited
+// Time Complexity: O(h + m) for a tree of height h and m keys in the range
+func (t *BinaryTreeOf[K, V]) Range(lo, hi K) iter.Seq2[K, V] {
+	return t.ascend(
+		func(k K) bool { return t.compare(k, lo) < 0 },
+		func(k K) bool { return t.compare(k, hi) >= 0 },
+	)
+}
+
+// ascend walks the tree in order with a stack rather than recursion, so a
+// tree that has become a long path can't overflow the goroutine stack
+// A key for which below is true is skipped along with its left subtree,
+// and the first key for which beyond is true ends the walk; nil means no
+// bound
+func (t *BinaryTreeOf[K, V]) ascend(below, beyond func(K) bool) iter.Seq2[K, V] {
+	return func(yield func(K, V) bool) {
+		var stack []*bstNode[K, V]
+		for n := t.root; n != nil || len(stack) > 0; n = n.right {
+			for n != nil {
+				if below != nil && below(n.key) {
+					n = n.right
+					continue
+				}
+				stack = append(stack, n)
+				n = n.left
+			}
+			if len(stack) == 0 {
+				// Every key left was below the range
+				return
+			}
+			n = stack[len(stack)-1]
+			stack = stack[:len(stack)-1]
+			if beyond != nil && beyond(n.key) {
+				return
+			}
+			if !yield(n.key, n.value) {
+				return
+			}
+		}
+	}
+}
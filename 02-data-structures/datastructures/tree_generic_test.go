@@ -0,0 +1,167 @@
+package datastructures
+
+import (
+	"maps"
+	"slices"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/NutProhmpiriya/go-basic/testdata/generator"
+)
+
+// checkBinaryTreeOf checks the tree against want: the keys in order, the
+// values, the BST property and the size
+func checkBinaryTreeOf(t *testing.T, tree *BinaryTreeOf[int, string], want map[int]string) {
+	t.Helper()
+	var keys []int
+	for k, v := range tree.All() {
+		if want[k] != v {
+			t.Fatalf("key %d has value %q, want %q", k, v, want[k])
+		}
+		keys = append(keys, k)
+	}
+	if wantKeys := slices.Sorted(maps.Keys(want)); !slices.Equal(keys, wantKeys) {
+		t.Fatalf("keys = %v, want %v", keys, wantKeys)
+	}
+	if tree.Len() != len(want) {
+		t.Fatalf("Len() = %d, want %d", tree.Len(), len(want))
+	}
+}
+
+func TestBinaryTreeOfPutGetDelete(t *testing.T) {
+	// Random puts and deletes, checked against a map
+	rng := generator.New(1).Rand()
+	tree := NewBinaryTreeOf[int, string]()
+	want := make(map[int]string)
+	for i := range 3000 {
+		k := rng.Intn(300)
+		_, found := want[k]
+		if rng.Intn(3) == 0 {
+			if tree.Delete(k) != found {
+				t.Fatalf("Delete(%d) = %v, want %v", k, !found, found)
+			}
+			delete(want, k)
+		} else {
+			v := string(rune('a' + i%26))
+			if tree.Put(k, v) == found {
+				t.Fatalf("Put(%d) = %v, want %v", k, found, !found)
+			}
+			want[k] = v
+		}
+		if i%100 == 0 {
+			checkBinaryTreeOf(t, tree, want)
+		}
+	}
+	checkBinaryTreeOf(t, tree, want)
+	for k := range 300 {
+		v, ok := tree.Get(k)
+		if wv, wok := want[k]; v != wv || ok != wok || tree.Contains(k) != wok {
+			t.Fatalf("Get(%d) = %q, %v, want %q, %v", k, v, ok, wv, wok)
+		}
+	}
+
+	// Deleting the root of every shape: a leaf, one child, two children
+	for _, keys := range [][]int{{1}, {1, 2}, {2, 1}, {2, 1, 3}, {2, 1, 4, 3}} {
+		tree := NewBinaryTreeOf[int, string]()
+		want := make(map[int]string)
+		for _, k := range keys {
+			tree.Put(k, "")
+			want[k] = ""
+		}
+		tree.Delete(keys[0])
+		delete(want, keys[0])
+		checkBinaryTreeOf(t, tree, want)
+	}
+}
+
+func TestBinaryTreeOfRange(t *testing.T) {
+	tree := NewBinaryTreeOf[int, int]()
+	// The even keys from 0 to 198
+	for _, k := range generator.New(2).Perm(100) {
+		tree.Put(2*(k-1), k-1)
+	}
+	tests := []struct {
+		lo, hi int
+		want   []int
+	}{
+		{10, 20, []int{10, 12, 14, 16, 18}},
+		{9, 13, []int{10, 12}},
+		{-5, 3, []int{0, 2}},
+		{195, 500, []int{196, 198}},
+		{50, 50, nil},
+		{60, 40, nil},
+		{1000, 2000, nil},
+	}
+	for _, tt := range tests {
+		var got []int
+		for k, v := range tree.Range(tt.lo, tt.hi) {
+			if v != k/2 {
+				t.Fatalf("key %d has value %d", k, v)
+			}
+			got = append(got, k)
+		}
+		if !slices.Equal(got, tt.want) {
+			t.Errorf("Range(%d, %d) = %v, want %v", tt.lo, tt.hi, got, tt.want)
+		}
+	}
+
+	// Breaking out of the loop stops the walk
+	var first []int
+	for k := range tree.Range(0, 200) {
+		if len(first) == 3 {
+			break
+		}
+		first = append(first, k)
+	}
+	if !slices.Equal(first, []int{0, 2, 4}) {
+		t.Errorf("first three = %v", first)
+	}
+}
+
+func TestBinaryTreeOfComparator(t *testing.T) {
+	// Keys that aren't cmp.Ordered: times, and strings ignoring case
+	day := func(d int) time.Time { return time.Date(2024, 3, d, 0, 0, 0, 0, time.UTC) }
+	events := NewBinaryTreeFunc[time.Time, string](func(a, b time.Time) int { return a.Compare(b) })
+	events.Put(day(9), "deploy")
+	events.Put(day(2), "planning")
+	events.Put(day(5), "review")
+	events.Put(day(20), "retro")
+	var got []string
+	for _, e := range events.Range(day(1), day(10)) {
+		got = append(got, e)
+	}
+	if !slices.Equal(got, []string{"planning", "review", "deploy"}) {
+		t.Errorf("first ten days = %v", got)
+	}
+
+	words := NewBinaryTreeFunc[string, int](func(a, b string) int {
+		return strings.Compare(strings.ToLower(a), strings.ToLower(b))
+	})
+	words.Put("Go", 1)
+	if words.Put("go", 2) {
+		t.Error("go was a new key next to Go")
+	}
+	if v, _ := words.Get("GO"); v != 2 || words.Len() != 1 {
+		t.Errorf("Get(GO) = %d with %d keys", v, words.Len())
+	}
+}
+
+func TestBinaryTreeOfSortedInput(t *testing.T) {
+	// Sorted input makes a path as long as the tree; walking it must not
+	// recurse that deep
+	tree := NewBinaryTreeOf[int, struct{}]()
+	for _, k := range generator.Sorted(10000) {
+		tree.Put(k, struct{}{})
+	}
+	n := 0
+	for k := range tree.Keys() {
+		if k != n {
+			t.Fatalf("key %d at position %d", k, n)
+		}
+		n++
+	}
+	if n != 10000 {
+		t.Errorf("walked %d keys", n)
+	}
+}
@@ -0,0 +1,127 @@
+// This file implements the binary search tree operations without recursion
+// A recursive call per level needs a stack frame per level, and a BST built
+// from sorted input is a linked list with one level per value. Go grows a
+// goroutine's stack up to 1 GB, so the recursive versions survive deep
+// trees for longer than in most languages, but they still use memory in
+// proportion to the depth. The iterative versions walk down with a loop
+// and keep the nodes still to visit on an explicit Stack
+//
+// Time Complexity: the same as the recursive versions
+// Space Complexity: O(1) for Insert and Search, O(h) for the traversals,
+// where h is the height of the tree, held on the heap instead of the call stack
+
+package datastructures
+
+// InsertIterative adds a value like Insert, walking down with a loop
+// Time Complexity: O(log n) average, O(n) worst case
+func (t *BinaryTree) InsertIterative(value int) {
+	node := &TreeNode{Value: value}
+	if t.Root == nil {
+		t.Root = node
+		return
+	}
+	current := t.Root
+	for {
+		// Same rule as insertRecursive: equal values go right
+		if value < current.Value {
+			if current.Left == nil {
+				current.Left = node
+				return
+			}
+			current = current.Left
+		} else {
+			if current.Right == nil {
+				current.Right = node
+				return
+			}
+			current = current.Right
+		}
+	}
+}
+
+// SearchIterative looks for a value like Search, walking down with a loop
+// Time Complexity: O(log n) average, O(n) worst case
+func (t *BinaryTree) SearchIterative(value int) bool {
+	current := t.Root
+	for current != nil {
+		switch {
+		case value == current.Value:
+			return true
+		case value < current.Value:
+			current = current.Left
+		default:
+			current = current.Right
+		}
+	}
+	return false
+}
+
+// InorderIterative returns the same values as InorderTraversal
+// It goes left as far as possible, stacking the nodes it passes, then
+// visits the top node and continues with its right subtree
+// Time Complexity: O(n)
+func (t *BinaryTree) InorderIterative() []int {
+	result := []int{}
+	stack := &StackOf[*TreeNode]{}
+	current := t.Root
+	for current != nil || !stack.IsEmpty() {
+		for current != nil {
+			stack.Push(current)
+			current = current.Left
+		}
+		current, _ = stack.Pop()
+		result = append(result, current.Value)
+		current = current.Right
+	}
+	return result
+}
+
+// PreorderIterative returns the same values as PreorderTraversal
+// Each node is visited when popped; its right child is pushed before its
+// left one so the left subtree comes off the stack first
+// Time Complexity: O(n)
+func (t *BinaryTree) PreorderIterative() []int {
+	result := []int{}
+	if t.Root == nil {
+		return result
+	}
+	stack := &StackOf[*TreeNode]{}
+	stack.Push(t.Root)
+	for !stack.IsEmpty() {
+		node, _ := stack.Pop()
+		result = append(result, node.Value)
+		if node.Right != nil {
+			stack.Push(node.Right)
+		}
+		if node.Left != nil {
+			stack.Push(node.Left)
+		}
+	}
+	return result
+}
+
+// PostorderIterative returns the same values as PostorderTraversal
+// A node on top of the stack is only visited once its right subtree is
+// done: when it has none, or when that subtree's root was the last visit
+// Time Complexity: O(n)
+func (t *BinaryTree) PostorderIterative() []int {
+	result := []int{}
+	stack := &StackOf[*TreeNode]{}
+	var lastVisited *TreeNode
+	current := t.Root
+	for current != nil || !stack.IsEmpty() {
+		for current != nil {
+			stack.Push(current)
+			current = current.Left
+		}
+		top, _ := stack.Peek()
+		if top.Right != nil && top.Right != lastVisited {
+			current = top.Right
+			continue
+		}
+		stack.Pop()
+		result = append(result, top.Value)
+		lastVisited = top
+	}
+	return result
+}
@@ -0,0 +1,250 @@
+// This file lets learners see the binary search tree they built
+// It has two text pictures, a DOT export for Graphviz and a check of the
+// ordering invariant
+//
+// Sideways prints the tree rotated a quarter turn, right subtree on top:
+//
+//	    ┌── 8
+//	┌── 7
+//	│   └── 6
+//	5
+//	│   ┌── 4
+//	└── 3
+//	    └── 1
+//
+// Layered prints it top-down, one line per level. Each node gets its own
+// column range in inorder, so no two labels can overlap:
+//
+//	      5
+//	  ┌───┴───┐
+//	  3       7
+//	┌─┴─┐   ┌─┴─┐
+//	1   4   6   8
+//
+// Time Complexity: O(n) for Sideways, ExportDOT and Validate, and
+// O(h × w) for Layered, where h is the height and w the width of the picture
+
+package datastructures
+
+import (
+	"errors"
+	"fmt"
+	"io"
+	"strconv"
+	"strings"
+)
+
+// ErrNotBST is returned by Validate when the tree is not a binary search tree
+var ErrNotBST = errors.New("not a binary search tree")
+
+// Sideways returns the tree drawn rotated counterclockwise, root on the left
+// and larger values higher up, one node per line
+// Reading it from the bottom up gives the values in sorted order
+func (t *BinaryTree) Sideways() string {
+	var b strings.Builder
+	writeSideways(&b, t.Root, "", "")
+	return b.String()
+}
+
+// writeSideways draws node after the indent and edge, with its right subtree
+// above it and its left subtree below. The vertical line continues through
+// a subtree that sits between a node and its parent
+func writeSideways(b *strings.Builder, node *TreeNode, indent, edge string) {
+	if node == nil {
+		return
+	}
+	// Children of the root line up with it; deeper ones are indented, with
+	// a line down to the parent if they are on its side
+	above, below := indent, indent
+	switch edge {
+	case "┌── ":
+		above, below = indent+"    ", indent+"│   "
+	case "└── ":
+		above, below = indent+"│   ", indent+"    "
+	}
+	writeSideways(b, node.Right, above, "┌── ")
+	b.WriteString(indent + edge + strconv.Itoa(node.Value) + "\n")
+	writeSideways(b, node.Left, below, "└── ")
+}
+
+// placed is where Layered draws a node
+type placed struct {
+	node   *TreeNode
+	label  string
+	column int // column of the first character of the label
+	center int // column the edges to the parent attach to
+}
+
+// Layered returns the tree drawn top-down, with each level on one line and
+// a line of edges between levels
+func (t *BinaryTree) Layered() string {
+	var levels [][]placed
+	centers := make(map[*TreeNode]int)
+	column := 0
+	layoutLayered(t.Root, 0, &column, &levels, centers)
+
+	var b strings.Builder
+	for depth, level := range levels {
+		line := blankLine(column)
+		for _, p := range level {
+			copy(line[p.column:], []rune(p.label))
+		}
+		writeTrimmed(&b, line)
+
+		if depth == len(levels)-1 {
+			break
+		}
+		edges := blankLine(column)
+		for _, p := range level {
+			left, right := p.center, p.center
+			if p.node.Left != nil {
+				left = centers[p.node.Left]
+			}
+			if p.node.Right != nil {
+				right = centers[p.node.Right]
+			}
+			if left == right {
+				continue // a leaf
+			}
+			for i := left; i <= right; i++ {
+				edges[i] = '─'
+			}
+			switch {
+			case p.node.Left == nil:
+				edges[p.center] = '└'
+			case p.node.Right == nil:
+				edges[p.center] = '┘'
+			default:
+				edges[p.center] = '┴'
+			}
+			if p.node.Left != nil {
+				edges[left] = '┌'
+			}
+			if p.node.Right != nil {
+				edges[right] = '┐'
+			}
+		}
+		writeTrimmed(&b, edges)
+	}
+	return b.String()
+}
+
+// layoutLayered gives the nodes columns in inorder, so every node is to the
+// right of its left subtree and to the left of its right subtree
+func layoutLayered(node *TreeNode, depth int, column *int, levels *[][]placed, centers map[*TreeNode]int) {
+	if node == nil {
+		return
+	}
+	layoutLayered(node.Left, depth+1, column, levels, centers)
+
+	label := strconv.Itoa(node.Value)
+	p := placed{node: node, label: label, column: *column, center: *column + (len(label)-1)/2}
+	centers[node] = p.center
+	*column += len(label) + 1
+	for len(*levels) <= depth {
+		*levels = append(*levels, nil)
+	}
+	(*levels)[depth] = append((*levels)[depth], p)
+
+	layoutLayered(node.Right, depth+1, column, levels, centers)
+}
+
+// blankLine returns a line of width spaces
+func blankLine(width int) []rune {
+	return []rune(strings.Repeat(" ", width))
+}
+
+// writeTrimmed writes line without its trailing spaces, and a newline
+func writeTrimmed(b *strings.Builder, line []rune) {
+	b.WriteString(strings.TrimRight(string(line), " "))
+	b.WriteByte('\n')
+}
+
+// ExportDOT writes the tree in the DOT language of Graphviz; save it as
+// tree.dot and draw it with dot -Tsvg tree.dot > tree.svg
+// Nodes are named by their preorder position, so duplicate values get a
+// node each. A node with a single child gets an invisible sibling for it,
+// so Graphviz still draws left children to the left and right to the right
+func (t *BinaryTree) ExportDOT(w io.Writer) error {
+	var b strings.Builder
+	b.WriteString("digraph BinaryTree {\n\tnode [shape=circle];\n")
+	if t.Root != nil {
+		next := 0
+		writeDOT(&b, t.Root, &next)
+	}
+	b.WriteString("}\n")
+	_, err := io.WriteString(w, b.String())
+	return err
+}
+
+// writeDOT writes node and its subtrees, and returns the name given to node
+func writeDOT(b *strings.Builder, node *TreeNode, next *int) string {
+	name := "n" + strconv.Itoa(*next)
+	*next++
+	if node == nil {
+		fmt.Fprintf(b, "\t%s [style=invis];\n", name)
+		return name
+	}
+	fmt.Fprintf(b, "\t%s [label=\"%d\"];\n", name, node.Value)
+	if node.Left == nil && node.Right == nil {
+		return name
+	}
+	for _, child := range []*TreeNode{node.Left, node.Right} {
+		childName := writeDOT(b, child, next)
+		if child == nil {
+			fmt.Fprintf(b, "\t%s -> %s [style=invis];\n", name, childName)
+		} else {
+			fmt.Fprintf(b, "\t%s -> %s;\n", name, childName)
+		}
+	}
+	return name
+}
+
+// bounded is a node on the Validate stack with the ancestors whose values
+// bound its own, or nil for no bound
+type bounded struct {
+	node, low, high *TreeNode
+}
+
+// Validate checks the binary search tree invariant: every value in a node's
+// left subtree is smaller than it, and every value in its right subtree is
+// at least as big, since Insert puts duplicates on the right
+// It is useful after building or changing a tree by hand through the
+// exported Left and Right fields. It also rejects a node reachable twice,
+// which would make the traversals loop forever
+// It returns an error wrapping ErrNotBST that names the first offending node
+// in preorder, or nil. It uses its own stack, so deep trees are fine
+// Time Complexity: O(n), Space Complexity: O(n)
+func (t *BinaryTree) Validate() error {
+	if t.Root == nil {
+		return nil
+	}
+	seen := make(map[*TreeNode]bool)
+	stack := &StackOf[bounded]{}
+	stack.Push(bounded{node: t.Root})
+
+	for !stack.IsEmpty() {
+		b, _ := stack.Pop()
+		n := b.node
+		if seen[n] {
+			return fmt.Errorf("%w: node %d is reachable twice", ErrNotBST, n.Value)
+		}
+		seen[n] = true
+
+		switch {
+		case b.low != nil && n.Value < b.low.Value:
+			return fmt.Errorf("%w: %d is in the right subtree of %d", ErrNotBST, n.Value, b.low.Value)
+		case b.high != nil && n.Value >= b.high.Value:
+			return fmt.Errorf("%w: %d is in the left subtree of %d", ErrNotBST, n.Value, b.high.Value)
+		}
+
+		// Push right first so the left subtree is checked first
+		if n.Right != nil {
+			stack.Push(bounded{node: n.Right, low: n, high: b.high})
+		}
+		if n.Left != nil {
+			stack.Push(bounded{node: n.Left, low: b.low, high: n})
+		}
+	}
+	return nil
+}
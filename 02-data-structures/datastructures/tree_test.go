@@ -0,0 +1,344 @@
+package datastructures
+
+import (
+	"errors"
+	"fmt"
+	"slices"
+	"sort"
+	"strings"
+	"testing"
+
+	"github.com/NutProhmpiriya/go-basic/testdata/generator"
+)
+
+func newTree(values ...int) *BinaryTree {
+	t := &BinaryTree{}
+	for _, v := range values {
+		t.Insert(v)
+	}
+	return t
+}
+
+// isBST checks the ordering invariant: left < node <= right
+// Duplicates go to the right subtree, matching insertRecursive
+func isBST(node *TreeNode, low, high *int) bool {
+	if node == nil {
+		return true
+	}
+	if low != nil && node.Value < *low || high != nil && node.Value >= *high {
+		return false
+	}
+	return isBST(node.Left, low, &node.Value) && isBST(node.Right, &node.Value, high)
+}
+
+func TestBinaryTreeTraversals(t *testing.T) {
+	tests := []struct {
+		name      string
+		values    []int
+		preorder  []int
+		postorder []int
+	}{
+		{"empty", nil, []int{}, []int{}},
+		{"single", []int{5}, []int{5}, []int{5}},
+		//       5
+		//     3   8
+		//    1 4 7 9
+		{"balanced", []int{5, 3, 8, 1, 4, 7, 9}, []int{5, 3, 1, 4, 8, 7, 9}, []int{1, 4, 3, 7, 9, 8, 5}},
+		// Sorted input degenerates into a linked list leaning right
+		{"ascending", []int{1, 2, 3}, []int{1, 2, 3}, []int{3, 2, 1}},
+		{"descending", []int{3, 2, 1}, []int{3, 2, 1}, []int{1, 2, 3}},
+		{"duplicates", []int{2, 2, 1}, []int{2, 1, 2}, []int{1, 2, 2}},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			tree := newTree(tt.values...)
+			if !isBST(tree.Root, nil, nil) {
+				t.Fatal("tree violates the BST property")
+			}
+			// Inorder traversal of a BST is always the sorted input
+			wantInorder := append([]int{}, tt.values...)
+			sort.Ints(wantInorder)
+			if got := tree.InorderTraversal(); !slices.Equal(got, wantInorder) {
+				t.Errorf("InorderTraversal() = %v, want %v", got, wantInorder)
+			}
+			if got := tree.PreorderTraversal(); !slices.Equal(got, tt.preorder) {
+				t.Errorf("PreorderTraversal() = %v, want %v", got, tt.preorder)
+			}
+			if got := tree.PostorderTraversal(); !slices.Equal(got, tt.postorder) {
+				t.Errorf("PostorderTraversal() = %v, want %v", got, tt.postorder)
+			}
+
+			if got := tree.InorderIterative(); !slices.Equal(got, wantInorder) {
+				t.Errorf("InorderIterative() = %v, want %v", got, wantInorder)
+			}
+			if got := tree.PreorderIterative(); !slices.Equal(got, tt.preorder) {
+				t.Errorf("PreorderIterative() = %v, want %v", got, tt.preorder)
+			}
+			if got := tree.PostorderIterative(); !slices.Equal(got, tt.postorder) {
+				t.Errorf("PostorderIterative() = %v, want %v", got, tt.postorder)
+			}
+			// InsertIterative builds the same shape as Insert
+			iterative := &BinaryTree{}
+			for _, v := range tt.values {
+				iterative.InsertIterative(v)
+			}
+			if got := iterative.PreorderTraversal(); !slices.Equal(got, tt.preorder) {
+				t.Errorf("tree from InsertIterative has preorder %v, want %v", got, tt.preorder)
+			}
+
+			// The Append versions keep what dst already holds
+			prefix := []int{-1}
+			for _, tr := range []struct {
+				name   string
+				append func([]int) []int
+				want   []int
+			}{
+				{"AppendInorder", tree.AppendInorder, wantInorder},
+				{"AppendPreorder", tree.AppendPreorder, tt.preorder},
+				{"AppendPostorder", tree.AppendPostorder, tt.postorder},
+			} {
+				if got, want := tr.append(prefix), append([]int{-1}, tr.want...); !slices.Equal(got, want) {
+					t.Errorf("%s([-1]) = %v, want %v", tr.name, got, want)
+				}
+			}
+		})
+	}
+}
+
+func TestBinaryTreeSearch(t *testing.T) {
+	tree := newTree(50, 30, 70, 20, 40, 60, 80)
+	for _, v := range []int{50, 20, 40, 60, 80} {
+		if !tree.Search(v) {
+			t.Errorf("Search(%d) = false, want true", v)
+		}
+	}
+	for _, v := range []int{0, 25, 45, 65, 100} {
+		if tree.Search(v) {
+			t.Errorf("Search(%d) = true, want false", v)
+		}
+	}
+	if (&BinaryTree{}).Search(1) {
+		t.Error("Search on empty tree = true")
+	}
+}
+
+func TestBinaryTreeLargeInput(t *testing.T) {
+	// Insert a shuffled permutation; every value must be found afterwards
+	values := make([]int, 1000)
+	for i := range values {
+		values[i] = (i * 7919) % 1000 // 7919 is prime, so this permutes 0..999
+	}
+	tree := newTree(values...)
+	if !isBST(tree.Root, nil, nil) {
+		t.Fatal("tree violates the BST property")
+	}
+	for i := 0; i < 1000; i++ {
+		if !tree.Search(i) {
+			t.Fatalf("Search(%d) = false", i)
+		}
+	}
+	if got := len(tree.InorderTraversal()); got != 1000 {
+		t.Errorf("InorderTraversal() has %d values, want 1000", got)
+	}
+}
+
+func TestBinaryTreeSearchIterative(t *testing.T) {
+	tree := newTree(generator.New(1).Perm(500)...)
+	for v := -1; v <= 501; v++ {
+		if got, want := tree.SearchIterative(v), tree.Search(v); got != want {
+			t.Errorf("SearchIterative(%d) = %v, Search = %v", v, got, want)
+		}
+	}
+	if (&BinaryTree{}).SearchIterative(1) {
+		t.Error("SearchIterative on empty tree = true")
+	}
+}
+
+// chain builds the tree that inserting 0..n-1 in order produces, a single
+// right-leaning path n levels deep, in O(n) instead of O(n²)
+func chain(n int) *BinaryTree {
+	tree := &BinaryTree{}
+	link := &tree.Root
+	for v := range n {
+		*link = &TreeNode{Value: v}
+		link = &(*link).Right
+	}
+	return tree
+}
+
+// The iterative versions handle a tree a million levels deep
+func TestBinaryTreeIterativeDeep(t *testing.T) {
+	const n = 1_000_000
+	tree := chain(n)
+	tree.InsertIterative(n)
+	if !tree.SearchIterative(n) || !tree.SearchIterative(0) || tree.SearchIterative(n+1) {
+		t.Error("SearchIterative is wrong on the deep tree")
+	}
+
+	ascending := func(values []int) bool {
+		return len(values) == n+1 && slices.IsSorted(values) && values[n] == n
+	}
+	if !ascending(tree.InorderIterative()) {
+		t.Error("InorderIterative() is not 0..n")
+	}
+	// Every node only has a right child, so preorder is ascending too and
+	// postorder the reverse
+	if !ascending(tree.PreorderIterative()) {
+		t.Error("PreorderIterative() is not 0..n")
+	}
+	post := tree.PostorderIterative()
+	slices.Reverse(post)
+	if !ascending(post) {
+		t.Error("PostorderIterative() is not n..0")
+	}
+}
+
+func TestBinaryTreeSideways(t *testing.T) {
+	tests := []struct {
+		name   string
+		values []int
+		want   string
+	}{
+		{"empty", nil, ""},
+		{"balanced", []int{5, 3, 7, 1, 4, 6, 8}, `    ┌── 8
+┌── 7
+│   └── 6
+5
+│   ┌── 4
+└── 3
+    └── 1
+`},
+		{"zigzag", []int{1, 10, 5, 7}, `┌── 10
+│   │   ┌── 7
+│   └── 5
+1
+`},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := newTree(tt.values...).Sideways(); got != tt.want {
+				t.Errorf("Sideways() =\n%s\nwant\n%s", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestBinaryTreeLayered(t *testing.T) {
+	tests := []struct {
+		name   string
+		values []int
+		want   string
+	}{
+		{"empty", nil, ""},
+		{"single", []int{42}, "42\n"},
+		{"balanced", []int{5, 3, 7, 1, 4, 6, 8}, `      5
+  ┌───┴───┐
+  3       7
+┌─┴─┐   ┌─┴─┐
+1   4   6   8
+`},
+		// Wide labels get wide columns, and single children hang to one side
+		{"uneven", []int{50, 3, 700, 1000, -1}, `     50
+   ┌─┴───┐
+   3    700
+┌──┘     └───┐
+-1          1000
+`},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := newTree(tt.values...).Layered(); got != tt.want {
+				t.Errorf("Layered() =\n%s\nwant\n%s", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestBinaryTreeExportDOT(t *testing.T) {
+	var b strings.Builder
+	if err := newTree(2, 3, 2).ExportDOT(&b); err != nil {
+		t.Fatal(err)
+	}
+	// The duplicate 2 gets its own node, and 3 an invisible left sibling
+	// for it so the 2 is drawn on the right
+	want := `digraph BinaryTree {
+	node [shape=circle];
+	n0 [label="2"];
+	n1 [style=invis];
+	n0 -> n1 [style=invis];
+	n2 [label="3"];
+	n3 [label="2"];
+	n2 -> n3;
+	n4 [style=invis];
+	n2 -> n4 [style=invis];
+	n0 -> n2;
+}
+`
+	if got := b.String(); got != want {
+		t.Errorf("ExportDOT() =\n%s\nwant\n%s", got, want)
+	}
+}
+
+func TestBinaryTreeValidate(t *testing.T) {
+	leaf := func(v int) *TreeNode { return &TreeNode{Value: v} }
+	shared := leaf(1)
+	cyclic := &TreeNode{Value: 1}
+	cyclic.Right = &TreeNode{Value: 2, Left: cyclic}
+
+	tests := []struct {
+		name    string
+		root    *TreeNode
+		wantErr string // "" for a valid tree
+	}{
+		{"empty", nil, ""},
+		{"inserted", newTree(5, 3, 7, 1, 4, 6, 8, 5, 3).Root, ""},
+		{"duplicate on the right", &TreeNode{Value: 2, Right: leaf(2)}, ""},
+		{"duplicate on the left", &TreeNode{Value: 2, Left: leaf(2)}, "2 is in the left subtree of 2"},
+		{"wrong child", &TreeNode{Value: 5, Right: leaf(4)}, "4 is in the right subtree of 5"},
+		// 6 is fine next to its parent 3, but not under the root 5
+		{"wrong grandchild", &TreeNode{Value: 5, Left: &TreeNode{Value: 3, Right: leaf(6)}}, "6 is in the left subtree of 5"},
+		{"shared node", &TreeNode{Value: 1, Right: &TreeNode{Value: 2, Left: shared, Right: shared}}, "node 1 is reachable twice"},
+		{"cycle", cyclic, "node 1 is reachable twice"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			err := (&BinaryTree{Root: tt.root}).Validate()
+			switch {
+			case tt.wantErr == "" && err != nil:
+				t.Errorf("Validate() = %v, want nil", err)
+			case tt.wantErr != "" && (!errors.Is(err, ErrNotBST) || !strings.HasSuffix(err.Error(), tt.wantErr)):
+				t.Errorf("Validate() = %v, want ErrNotBST: %s", err, tt.wantErr)
+			}
+		})
+	}
+
+	// Validate agrees with isBST, and keeps its own stack for deep trees
+	if err := chain(1_000_000).Validate(); err != nil {
+		t.Errorf("Validate() on a deep chain = %v", err)
+	}
+	tree := newTree(generator.New(3).Perm(1000)...)
+	if err := tree.Validate(); err != nil || !isBST(tree.Root, nil, nil) {
+		t.Errorf("Validate() = %v on a random tree", err)
+	}
+}
+
+// BenchmarkTraversal compares growing a fresh slice with appending to a
+// reused one; with the buffer reused AppendInorder makes no allocations
+func BenchmarkTraversal(b *testing.B) {
+	for _, n := range []int{100, 10_000} {
+		tree := newTree(generator.New(1).Perm(n)...)
+		b.Run(fmt.Sprintf("impl=InorderTraversal/n=%d", n), func(b *testing.B) {
+			b.ReportAllocs()
+			for i := 0; i < b.N; i++ {
+				tree.InorderTraversal()
+			}
+		})
+		b.Run(fmt.Sprintf("impl=AppendInorder/n=%d", n), func(b *testing.B) {
+			b.ReportAllocs()
+			var buf []int
+			for i := 0; i < b.N; i++ {
+				buf = tree.AppendInorder(buf[:0])
+			}
+		})
+	}
+}
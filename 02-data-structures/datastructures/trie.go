@@ -0,0 +1,122 @@
+// This file implements a trie (prefix tree) data structure in Go
+// A trie stores a set of words as paths from the root: each edge is a
+// letter (a rune here, so any script works), and a node marks whether the
+// path leading to it spells a whole word. Words that share a prefix share
+// the nodes of that prefix
+//
+// Time Complexity:
+// - Insert, Contains, HasPrefix: O(m) where m is the length of the word,
+//   however many words the trie holds
+// - WithPrefix: O(m + k) for k nodes below the prefix, plus the words found
+// - Len: O(1)
+//
+// Use Cases:
+// - Dictionaries for spell checkers
+// - Autocomplete and search suggestions
+// - IP routing tables (longest prefix match)
+// - Word games: is this a word, can any word start like this?
+
+package datastructures
+
+import "slices"
+
+// trieNode is a node of a Trie
+// end marks that the path from the root to this node is a word
+type trieNode struct {
+	children map[rune]*trieNode
+	end      bool
+}
+
+// Trie is a set of words stored as a prefix tree
+// The zero value is an empty trie ready to use
+type Trie struct {
+	root trieNode
+	size int
+}
+
+// Insert adds a word and reports whether it was new
+// The empty string is a word like any other: the root marks it
+// Time Complexity: O(m)
+func (t *Trie) Insert(word string) bool {
+	node := &t.root
+	for _, r := range word {
+		child, ok := node.children[r]
+		if !ok {
+			if node.children == nil {
+				node.children = make(map[rune]*trieNode)
+			}
+			child = &trieNode{}
+			node.children[r] = child
+		}
+		node = child
+	}
+	if node.end {
+		return false
+	}
+	node.end = true
+	t.size++
+	return true
+}
+
+// find returns the node at the end of the path spelling prefix, or nil
+func (t *Trie) find(prefix string) *trieNode {
+	node := &t.root
+	for _, r := range prefix {
+		node = node.children[r]
+		if node == nil {
+			return nil
+		}
+	}
+	return node
+}
+
+// Contains reports whether word was inserted
+// Time Complexity: O(m)
+func (t *Trie) Contains(word string) bool {
+	node := t.find(word)
+	return node != nil && node.end
+}
+
+// HasPrefix reports whether any word starts with prefix
+// Every node has a word below it, so reaching the node is enough
+// Time Complexity: O(m)
+func (t *Trie) HasPrefix(prefix string) bool {
+	return t.find(prefix) != nil
+}
+
+// WithPrefix returns the words that start with prefix, in sorted order
+// Time Complexity: O(m + k + w) for k nodes below the prefix and w runes in
+// the words returned, plus sorting each node's children
+func (t *Trie) WithPrefix(prefix string) []string {
+	node := t.find(prefix)
+	if node == nil {
+		return nil
+	}
+	var words []string
+	collectWords(node, []rune(prefix), &words)
+	return words
+}
+
+// collectWords appends the words below node to words, depth first with the
+// children in rune order, so the words come out sorted
+// path holds the runes from the root to node; the recursion goes as deep
+// as the longest word
+func collectWords(node *trieNode, path []rune, words *[]string) {
+	if node.end {
+		*words = append(*words, string(path))
+	}
+	keys := make([]rune, 0, len(node.children))
+	for r := range node.children {
+		keys = append(keys, r)
+	}
+	slices.Sort(keys)
+	for _, r := range keys {
+		collectWords(node.children[r], append(path, r), words)
+	}
+}
+
+// Len returns the number of words in the trie
+// Time Complexity: O(1)
+func (t *Trie) Len() int {
+	return t.size
+}
@@ -0,0 +1,100 @@
+package datastructures
+
+import (
+	"slices"
+	"testing"
+
+	"github.com/NutProhmpiriya/go-basic/testdata/generator"
+)
+
+func TestTrie(t *testing.T) {
+	var trie Trie
+	if trie.Contains("") || trie.HasPrefix("a") || trie.WithPrefix("") != nil || trie.Len() != 0 {
+		t.Fatal("the zero value is not an empty trie")
+	}
+	if !trie.HasPrefix("") {
+		t.Error("HasPrefix(\"\") is false: every word starts with the empty prefix")
+	}
+
+	words := []string{"car", "cart", "care", "cat", "dog", "do", "ไทย", "ไท", "naïve"}
+	for _, w := range words {
+		if !trie.Insert(w) {
+			t.Errorf("Insert(%q) reported a duplicate", w)
+		}
+	}
+	if trie.Insert("cat") || trie.Len() != len(words) {
+		t.Errorf("after inserting cat again, Len() = %d, want %d", trie.Len(), len(words))
+	}
+
+	tests := []struct {
+		word             string
+		contains, prefix bool
+	}{
+		{"car", true, true},
+		{"ca", false, true},
+		{"cars", false, false},
+		{"d", false, true},
+		{"do", true, true},
+		{"ไท", true, true},
+		{"ไ", false, true},
+		{"naï", false, true},
+		{"nai", false, false},
+		{"", false, true},
+	}
+	for _, tt := range tests {
+		if got := trie.Contains(tt.word); got != tt.contains {
+			t.Errorf("Contains(%q) = %v, want %v", tt.word, got, tt.contains)
+		}
+		if got := trie.HasPrefix(tt.word); got != tt.prefix {
+			t.Errorf("HasPrefix(%q) = %v, want %v", tt.word, got, tt.prefix)
+		}
+	}
+
+	prefixes := []struct {
+		prefix string
+		want   []string
+	}{
+		{"car", []string{"car", "care", "cart"}},
+		{"ca", []string{"car", "care", "cart", "cat"}},
+		{"do", []string{"do", "dog"}},
+		{"ไ", []string{"ไท", "ไทย"}},
+		{"x", nil},
+	}
+	for _, tt := range prefixes {
+		if got := trie.WithPrefix(tt.prefix); !slices.Equal(got, tt.want) {
+			t.Errorf("WithPrefix(%q) = %q, want %q", tt.prefix, got, tt.want)
+		}
+	}
+	// All the words, sorted as sort.Strings sorts them
+	if got, want := trie.WithPrefix(""), slices.Sorted(slices.Values(words)); !slices.Equal(got, want) {
+		t.Errorf("WithPrefix(\"\") = %q, want %q", got, want)
+	}
+
+	// The empty string is a word too once inserted
+	if !trie.Insert("") || !trie.Contains("") || trie.WithPrefix("")[0] != "" {
+		t.Error("the empty word was not stored")
+	}
+}
+
+func TestTrieRandomWords(t *testing.T) {
+	g := generator.New(1)
+	var trie Trie
+	set := make(map[string]bool)
+	for range 2000 {
+		w := g.String(1+g.Rand().Intn(6), "abcd")
+		if trie.Insert(w) == set[w] {
+			t.Fatalf("Insert(%q) disagrees with the set", w)
+		}
+		set[w] = true
+	}
+	var want []string
+	for w := range set {
+		if len(w) >= 2 && w[:2] == "ab" {
+			want = append(want, w)
+		}
+	}
+	slices.Sort(want)
+	if got := trie.WithPrefix("ab"); !slices.Equal(got, want) || trie.Len() != len(set) {
+		t.Errorf("WithPrefix(ab) has %d words, want %d; Len() = %d, want %d", len(got), len(want), trie.Len(), len(set))
+	}
+}
@@ -0,0 +1,639 @@
+package main
+
+import (
+	"errors"
+	"fmt"
+	"os"
+	"slices"
+	"sort"
+	"strings"
+
+	"github.com/NutProhmpiriya/go-basic/02-data-structures/datastructures"
+	"github.com/NutProhmpiriya/go-basic/ordering"
+)
+
+// runStack demonstrates the stack and bracket matching
+func runStack() {
+	// Create a new stack
+	stack := &datastructures.Stack{}
+
+	// Example 1: Pushing elements
+	fmt.Println("Example 1: Pushing elements")
+	fmt.Println("Pushing: 1, 2, 3")
+	stack.Push(1) // Stack: [1]
+	stack.Push(2) // Stack: [1, 2]
+	stack.Push(3) // Stack: [1, 2, 3]
+
+	// Example 2: Stack information
+	fmt.Printf("\nExample 2: Stack Status\n")
+	fmt.Printf("Stack size: %d\n", stack.Size())
+	if top, err := stack.Peek(); err == nil {
+		fmt.Printf("Top element: %d\n", top)
+	}
+
+	// Example 3: Popping elements
+	fmt.Println("\nExample 3: Popping elements")
+	fmt.Println("Popping all elements:")
+	for !stack.IsEmpty() {
+		if item, err := stack.Pop(); err == nil {
+			fmt.Printf("Popped: %d\n", item)
+		}
+	}
+
+	// Example 4: Error handling
+	fmt.Println("\nExample 4: Error handling")
+	fmt.Println("Trying to pop from empty stack:")
+	_, err := stack.Pop()
+	fmt.Printf("Error: %v\n", err)
+
+	// Example 5: Bracket matching application
+	fmt.Println("\nExample 5: Bracket Matching Example")
+	testCases := []string{"((()))", "(()())", "(()", ")(", "{[()]}", "([)]", `print(")")`}
+	for _, test := range testCases {
+		fmt.Printf("Is '%s' valid? %v\n", test, datastructures.IsValidBrackets(test))
+	}
+}
+
+// runExpression demonstrates the stack-based expression evaluator
+func runExpression() {
+	// Example 1: Infix to postfix with the shunting-yard algorithm
+	// Operators wait on a stack until precedence or a ")" lets them out
+	fmt.Println("Example 1: Converting infix to postfix")
+	for _, expr := range []string{"3 + 4 * 2", "(3 + 4) * 2", "2 ^ 3 ^ 2", "-2 ^ 2"} {
+		postfix, _ := datastructures.ToPostfix(expr)
+		fmt.Printf("%-12s => %s\n", expr, datastructures.FormatPostfix(postfix))
+	}
+
+	// Example 2: Evaluating the postfix form with a stack of values
+	fmt.Println("\nExample 2: Evaluating")
+	for _, expr := range []string{"3 + 4 * 2", "(3 + 4) * 2", "2 ^ 3 ^ 2", "-2 ^ 2", "-(1.5 + 2.5) / 2 ^ -1"} {
+		result, _ := datastructures.Evaluate(expr)
+		fmt.Printf("%s = %g\n", expr, result)
+	}
+
+	// Example 3: Malformed expressions report what is wrong and where
+	fmt.Println("\nExample 3: Errors")
+	for _, expr := range []string{"1 +", "2 (3)", "(1 + 2", "4 / (2 - 2)"} {
+		_, err := datastructures.Evaluate(expr)
+		fmt.Printf("%q: %v\n", expr, err)
+	}
+}
+
+// runBrackets demonstrates the Matcher as a small bracket linter
+func runBrackets() {
+	// Example 1: The stack of expected closers, with positions
+	// Each mismatch is reported as line:column, the way compilers do
+	fmt.Println("Example 1: Finding the first mismatch")
+	matcher := datastructures.NewMatcher()
+	for _, src := range []string{"f(a[i], {b})", "f(a[i)]", "f(a, b", "x)", `print(")"`} {
+		if err := matcher.Check(src); err != nil {
+			fmt.Printf("%-14q %v\n", src, err)
+		} else {
+			fmt.Printf("%-14q ok\n", src)
+		}
+	}
+
+	// Example 2: Comments and raw strings depend on the language
+	fmt.Println("\nExample 2: Language-aware matching")
+	src := "f(x) // :)"
+	fmt.Printf("%q plain: %v\n", src, matcher.Check(src))
+	fmt.Printf("%q as Go: %v\n", src, datastructures.GoMatcher().Check(src))
+	fmt.Printf("%q as Python: %v\n", "f(x)  # :)", datastructures.PythonMatcher().Check("f(x)  # :)"))
+
+	// Example 3: Linting a Go file
+	fmt.Println("\nExample 3: Linting main.go")
+	file := `package main
+
+/* Brackets in comments don't count: ( */
+func main() {
+	s := "strings neither: ]"
+	if len(s) > 0 {
+		fmt.Println(s[0)]
+	}
+}
+`
+	if err := datastructures.GoMatcher().Check(file); err != nil {
+		fmt.Printf("main.go:%v\n", err)
+		var mismatch *datastructures.MismatchError
+		if errors.As(err, &mismatch) && mismatch.OpenPos >= 0 {
+			line := file[strings.LastIndexByte(file[:mismatch.Pos], '\n')+1:]
+			line = line[:strings.IndexByte(line, '\n')]
+			fmt.Printf("    %s\n", strings.TrimSpace(line))
+		}
+	}
+}
+
+// runQueue demonstrates the FIFO queue
+func runQueue() {
+	// Create a new queue
+	queue := &datastructures.Queue{}
+
+	// Example 1: Enqueuing elements
+	fmt.Println("Example 1: Enqueuing elements")
+	fmt.Println("Enqueuing: 1, 2, 3")
+	queue.Enqueue(1) // Queue: [1]
+	queue.Enqueue(2) // Queue: [1, 2]
+	queue.Enqueue(3) // Queue: [1, 2, 3]
+
+	// Example 2: Queue information
+	fmt.Printf("\nExample 2: Queue Status\n")
+	fmt.Printf("Queue size: %d\n", queue.Size())
+	if first, err := queue.Peek(); err == nil {
+		fmt.Printf("Front element: %d\n", first)
+	}
+
+	// Example 3: Dequeuing elements
+	fmt.Println("\nExample 3: Dequeuing elements")
+	fmt.Println("Dequeuing all elements:")
+	for !queue.IsEmpty() {
+		if item, err := queue.Dequeue(); err == nil {
+			fmt.Printf("Dequeued: %d\n", item)
+		}
+	}
+
+	// Example 4: Error handling
+	fmt.Println("\nExample 4: Error handling")
+	fmt.Println("Trying to dequeue from empty queue:")
+	_, err := queue.Dequeue()
+	fmt.Printf("Error: %v\n", err)
+
+	// Example 5: Mixing operations
+	fmt.Println("\nExample 5: Mixed operations")
+	queue.Enqueue(10) // Queue: [10]
+	queue.Enqueue(20) // Queue: [10, 20]
+	if item, err := queue.Dequeue(); err == nil {
+		fmt.Printf("Dequeued: %d\n", item) // Queue: [20]
+	}
+	queue.Enqueue(30) // Queue: [20, 30]
+	fmt.Printf("Final queue size: %d\n", queue.Size())
+}
+
+// runHeap demonstrates the binary heap as a priority queue
+func runHeap() {
+	// Example 1: Building a heap from unordered values
+	fmt.Println("Example 1: Building a min-heap")
+	values := []int{7, 2, 9, 4, 1, 8}
+	minHeap := datastructures.NewMinHeap(values...)
+	fmt.Printf("Values: %v\n", values)
+	if top, err := minHeap.Peek(); err == nil {
+		fmt.Printf("Smallest: %d, heap size: %d\n", top, minHeap.Size())
+	}
+
+	// Example 2: Popping always gives the smallest remaining value
+	fmt.Println("\nExample 2: Popping in order")
+	fmt.Print("Popped:")
+	for !minHeap.IsEmpty() {
+		if v, err := minHeap.Pop(); err == nil {
+			fmt.Printf(" %d", v)
+		}
+	}
+	fmt.Println()
+
+	// Example 3: A max-heap keeps the largest on top
+	fmt.Println("\nExample 3: Max-heap")
+	maxHeap := datastructures.NewMaxHeap(values...)
+	maxHeap.Push(12)
+	if top, err := maxHeap.Peek(); err == nil {
+		fmt.Printf("After pushing 12, largest: %d\n", top)
+	}
+
+	// Example 4: A custom order, tasks by priority
+	fmt.Println("\nExample 4: Priority queue of tasks")
+	type task struct {
+		name     string
+		priority int
+	}
+	byPriority := ordering.By(func(t task) int { return t.priority }).Reversed()
+	tasks := datastructures.NewHeapFunc(byPriority)
+	tasks.Push(task{"write docs", 1})
+	tasks.Push(task{"fix outage", 5})
+	tasks.Push(task{"review PR", 3})
+	for !tasks.IsEmpty() {
+		if t, err := tasks.Pop(); err == nil {
+			fmt.Printf("Doing %q (priority %d)\n", t.name, t.priority)
+		}
+	}
+
+	// Example 5: Error handling
+	fmt.Println("\nExample 5: Error handling")
+	_, err := tasks.Pop()
+	fmt.Printf("Error: %v\n", err)
+}
+
+// runLinkedList demonstrates the singly linked list
+func runLinkedList() {
+	// Create a new linked list
+	list := &datastructures.LinkedList{}
+
+	// Example 1: Insert elements
+	fmt.Println("Example 1: Inserting elements")
+	list.Insert(1) // List: 1 -> nil
+	list.Insert(2) // List: 1 -> 2 -> nil
+	list.Insert(3) // List: 1 -> 2 -> 3 -> nil
+	list.Insert(4) // List: 1 -> 2 -> 3 -> 4 -> nil
+	fmt.Print("Original List: ")
+	list.Print()
+
+	// Example 2: Delete an element
+	fmt.Println("\nExample 2: Deleting element 2")
+	list.Delete(2) // List: 1 -> 3 -> 4 -> nil
+	fmt.Print("After deleting 2: ")
+	list.Print()
+
+	// Example 3: Insert after deletion
+	fmt.Println("\nExample 3: Inserting element 5")
+	list.Insert(5) // List: 1 -> 3 -> 4 -> 5 -> nil
+	fmt.Print("After inserting 5: ")
+	list.Print()
+
+	// Example 4: Insert at an index and read by index
+	fmt.Println("\nExample 4: Inserting 2 back at index 1")
+	list.InsertAt(1, 2) // List: 1 -> 2 -> 3 -> 4 -> 5 -> nil
+	fmt.Print("After InsertAt(1, 2): ")
+	list.Print()
+	third, _ := list.Get(2)
+	middle, _ := list.FindMiddle()
+	fmt.Printf("Length: %d, Get(2): %d, middle: %d\n", list.Len(), third, middle)
+
+	// Example 5: Reverse and remove from the end
+	fmt.Println("\nExample 5: Reversing and removing the 2nd node from the end")
+	list.Reverse() // List: 5 -> 4 -> 3 -> 2 -> 1 -> nil
+	fmt.Print("Reversed: ")
+	list.Print()
+	removed, _ := list.RemoveNthFromEnd(2) // List: 5 -> 4 -> 3 -> 1 -> nil
+	fmt.Printf("Removed %d: ", removed)
+	list.Print()
+}
+
+// runLRU demonstrates the LRU cache in front of a slow lookup
+func runLRU() {
+	cache := datastructures.NewLRU[string, int](3)
+
+	// Example 1: Filling the cache
+	fmt.Println("Example 1: Filling a cache of 3 entries")
+	for i, key := range []string{"a", "b", "c"} {
+		cache.Put(key, i+1)
+	}
+	fmt.Printf("Keys, most recent first: %v\n", slices.Collect(cache.Keys()))
+
+	// Example 2: A read refreshes a key, so the eviction skips it
+	fmt.Println("\nExample 2: Eviction")
+	cache.Get("a")
+	evicted, _ := cache.Put("d", 4)
+	fmt.Printf("Get(a), then Put(d) evicts %q\n", evicted)
+	fmt.Printf("Keys, most recent first: %v\n", slices.Collect(cache.Keys()))
+
+	// Example 3: Read-through caching of a slow lookup
+	fmt.Println("\nExample 3: Read-through cache")
+	squares := datastructures.NewLRU[int, int](2)
+	slowSquare := func(n int) int { return n * n }
+	hits, misses := 0, 0
+	for _, n := range []int{2, 3, 2, 4, 2, 3} {
+		v, ok := squares.Get(n)
+		if ok {
+			hits++
+		} else {
+			misses++
+			v = slowSquare(n)
+			squares.Put(n, v)
+		}
+		fmt.Printf("square(%d) = %-2d cached: %v\n", n, v, ok)
+	}
+	fmt.Printf("Hits: %d, misses: %d\n", hits, misses)
+}
+
+// runTree demonstrates the binary search tree and its traversals
+func runTree() {
+	// Create a binary search tree
+	tree := &datastructures.BinaryTree{}
+
+	// Example 1: Inserting values
+	fmt.Println("Example 1: Building the tree")
+	fmt.Println("Inserting: 5, 3, 7, 1, 4, 6, 8")
+	values := []int{5, 3, 7, 1, 4, 6, 8}
+	// This creates the following tree:
+	//       5
+	//      / \
+	//     3   7
+	//    / \  / \
+	//   1   4 6  8
+	for _, value := range values {
+		tree.Insert(value)
+	}
+
+	// Example 2: Different traversals
+	fmt.Println("\nExample 2: Tree Traversals")
+	fmt.Println("Inorder (sorted):", tree.InorderTraversal())
+	fmt.Println("Preorder:", tree.PreorderTraversal())
+	fmt.Println("Postorder:", tree.PostorderTraversal())
+
+	// Example 3: Searching
+	fmt.Println("\nExample 3: Searching for values")
+	searchValues := []int{4, 9}
+	for _, value := range searchValues {
+		exists := tree.Search(value)
+		fmt.Printf("Is %d in the tree? %v\n", value, exists)
+	}
+
+	// Example 4: Drawing the tree
+	// Sideways reads like a directory listing, Layered like a textbook
+	fmt.Println("\nExample 4: Seeing the structure")
+	fmt.Print("Sideways:\n", tree.Sideways())
+	fmt.Print("Layered:\n", tree.Layered())
+	fmt.Println("As DOT for Graphviz:")
+	small := &datastructures.BinaryTree{}
+	small.Insert(2)
+	small.Insert(1)
+	small.ExportDOT(os.Stdout)
+
+	// Example 5: Checking the invariant after editing the tree by hand
+	fmt.Println("\nExample 5: Validating")
+	fmt.Println("Inserted tree:", tree.Validate())
+	tree.Root.Left.Right.Value = 9 // the 4 under 3 becomes a 9
+	fmt.Println("After changing 4 to 9:", tree.Validate())
+	fmt.Println("Search(9) finds it?", tree.Search(9))
+
+	// Example 6: Any keys, each with a value
+	// BinaryTreeOf is the same tree with a comparator for its keys, here
+	// words in alphabetical order, so it works as a sorted map
+	fmt.Println("\nExample 6: A sorted map")
+	glossary := datastructures.NewBinaryTreeOf[string, string]()
+	glossary.Put("stack", "last in, first out")
+	glossary.Put("heap", "smallest on top")
+	glossary.Put("queue", "first in, first out")
+	glossary.Put("graph", "vertices and edges")
+	glossary.Put("trie", "a tree of prefixes")
+	glossary.Put("heap", "smallest item on top") // replaces the value
+	for word, meaning := range glossary.All() {
+		fmt.Printf("  %-6s %s\n", word, meaning)
+	}
+	fmt.Print("From h up to s:")
+	for word := range glossary.Range("h", "s") {
+		fmt.Print(" ", word)
+	}
+	fmt.Println()
+
+	// Example 7: Bulk operations
+	// Inserting sorted values one by one makes a path; BuildBalanced takes
+	// them all at once and makes the lowest tree there is, in O(n)
+	fmt.Println("\nExample 7: Building from sorted values")
+	sorted := []int{1, 2, 3, 4, 5, 6, 7}
+	path := &datastructures.BinaryTree{}
+	for _, v := range sorted {
+		path.Insert(v)
+	}
+	balanced := datastructures.BuildBalanced(sorted)
+	fmt.Printf("Inserted one by one: height %d\n", path.Height())
+	fmt.Printf("BuildBalanced: height %d\n%s", balanced.Height(), balanced.Layered())
+	merged := datastructures.Merge(balanced, datastructures.BuildBalanced([]int{0, 4, 8}))
+	fmt.Printf("Merged with 0, 4, 8: %v, height %d\n", merged.ToSortedSlice(), merged.Height())
+	fmt.Println("Path rebalanced: height", datastructures.BuildBalanced(path.ToSortedSlice()).Height())
+}
+
+// leaderboard ranks players by points, most first, with ties going to the
+// name that sorts first. The tree holds the entries; the map finds a
+// player's current entry so an update can replace it
+type leaderboard struct {
+	entries *datastructures.OrderStatTree[score]
+	points  map[string]int
+}
+
+type score struct {
+	name   string
+	points int
+}
+
+func newLeaderboard() *leaderboard {
+	return &leaderboard{
+		entries: datastructures.NewOrderStatTree(
+			ordering.By(func(s score) int { return s.points }).Reversed().
+				ThenComparing(ordering.By(func(s score) string { return s.name })),
+		),
+		points: make(map[string]int),
+	}
+}
+
+// set records a player's points, replacing any earlier score
+func (lb *leaderboard) set(name string, points int) {
+	if old, ok := lb.points[name]; ok {
+		lb.entries.Delete(score{name, old})
+	}
+	lb.points[name] = points
+	lb.entries.Insert(score{name, points})
+}
+
+// position returns a player's place, counting from 1
+func (lb *leaderboard) position(name string) (int, bool) {
+	points, ok := lb.points[name]
+	if !ok {
+		return 0, false
+	}
+	return lb.entries.Rank(score{name, points}), true
+}
+
+// runOrderStatTree demonstrates the order-statistics tree with a leaderboard
+func runOrderStatTree() {
+	// Example 1: Select and Rank on numbers
+	fmt.Println("Example 1: Select and Rank")
+	tree := datastructures.NewOrderedStatTree[int]()
+	for _, v := range []int{50, 20, 80, 10, 30, 70, 90} {
+		tree.Insert(v)
+	}
+	third, _ := tree.Select(3)
+	fmt.Printf("3rd smallest: %d\n", third)
+	fmt.Printf("Rank(70): %d, values up to 60: %d\n", tree.Rank(70), tree.Rank(60))
+	fmt.Printf("Values in [25, 75]: %d\n", tree.Rank(75)-tree.Rank(24))
+
+	// Example 2: A leaderboard
+	fmt.Println("\nExample 2: Leaderboard")
+	lb := newLeaderboard()
+	for _, s := range []score{{"alice", 320}, {"bob", 180}, {"carol", 450}, {"dave", 180}, {"erin", 275}} {
+		lb.set(s.name, s.points)
+	}
+	printTop := func(n int) {
+		for k := 1; k <= n; k++ {
+			if s, err := lb.entries.Select(k); err == nil {
+				fmt.Printf("%d. %-6s %d\n", k, s.name, s.points)
+			}
+		}
+	}
+	printTop(3)
+	// bob and dave tie on 180; bob's name sorts first
+	if pos, ok := lb.position("bob"); ok {
+		fmt.Printf("bob is %d of %d\n", pos, lb.entries.Len())
+	}
+
+	// Example 3: A new score moves a player up
+	fmt.Println("\nExample 3: Updating a score")
+	lb.set("bob", 400)
+	printTop(3)
+	if pos, ok := lb.position("bob"); ok {
+		fmt.Printf("bob is now %d of %d\n", pos, lb.entries.Len())
+	}
+
+	// Example 4: Error handling
+	fmt.Println("\nExample 4: Error handling")
+	_, err := lb.entries.Select(10)
+	fmt.Printf("Error: %v\n", err)
+}
+
+// runTrie demonstrates the trie with word lookups and autocomplete
+func runTrie() {
+	var trie datastructures.Trie
+	for _, w := range []string{"go", "golang", "goroutine", "gopher", "graph", "grep", "heap"} {
+		trie.Insert(w)
+	}
+
+	// Example 1: Whole words and prefixes are different questions
+	fmt.Println("Example 1: Lookups")
+	for _, w := range []string{"go", "gor", "gorilla"} {
+		fmt.Printf("%-8s word: %-5v prefix of a word: %v\n", w, trie.Contains(w), trie.HasPrefix(w))
+	}
+
+	// Example 2: Autocomplete lists every word below a prefix, sorted
+	fmt.Println("\nExample 2: Autocomplete")
+	for _, prefix := range []string{"go", "gr", "x"} {
+		fmt.Printf("%q -> %v\n", prefix, trie.WithPrefix(prefix))
+	}
+
+	// Example 3: Duplicates are stored once
+	fmt.Println("\nExample 3: Duplicates")
+	fmt.Printf("Insert(\"heap\") again: %v, words: %d\n", trie.Insert("heap"), trie.Len())
+}
+
+// runGraph demonstrates the adjacency list graph with BFS and DFS
+func runGraph() {
+	// Create a new graph
+	graph := datastructures.NewGraph()
+
+	// Example 1: Adding vertices
+	fmt.Println("Example 1: Adding vertices 0-5")
+	for i := 0; i < 6; i++ {
+		graph.AddVertex(i)
+	}
+
+	// Example 2: Adding edges to create this graph:
+	// 0 -- 1 -- 2
+	// |    |    |
+	// 3 -- 4 -- 5
+	fmt.Println("\nExample 2: Adding edges")
+	edges := [][2]int{
+		{0, 1}, {1, 2}, // Top row
+		{0, 3}, {1, 4}, {2, 5}, // Vertical connections
+		{3, 4}, {4, 5}, // Bottom row
+	}
+	for _, edge := range edges {
+		graph.AddEdge(edge[0], edge[1])
+		fmt.Printf("Added edge: %d -- %d\n", edge[0], edge[1])
+	}
+
+	// Example 3: Print adjacency list
+	fmt.Println("\nExample 3: Graph Adjacency List:")
+	for _, vertex := range graph.Vertices() {
+		fmt.Printf("Vertex %d: %v\n", vertex, graph.GetNeighbors(vertex))
+	}
+
+	// Example 4: BFS traversal
+	fmt.Println("\nExample 4: BFS starting from vertex 0:")
+	bfsResult := graph.BFS(0)
+	fmt.Printf("BFS path: %v\n", bfsResult)
+
+	// Example 5: DFS traversal
+	fmt.Println("\nExample 5: DFS starting from vertex 0:")
+	dfsResult := graph.DFS(0)
+	fmt.Printf("DFS path: %v\n", dfsResult)
+
+	// Example 6: Finding neighbors
+	vertex := 1
+	fmt.Printf("\nExample 6: Neighbors of vertex %d:\n", vertex)
+	neighbors := graph.GetNeighbors(vertex)
+	fmt.Printf("Neighbors: %v\n", neighbors)
+}
+
+// runBKTree demonstrates the BK-tree range search under two metrics
+func runBKTree() {
+	words := []string{
+		"book", "books", "cake", "boo", "boon", "cook", "cape", "cart",
+		"hook", "look", "nook", "back", "bake", "brook", "took", "take",
+		"rook", "bock", "bank", "cook", "care", "core", "cord", "word",
+	}
+
+	// Example 1: Building a tree with edit distance
+	fmt.Println("Example 1: Building a BK-tree with edit distance")
+	tree := datastructures.NewBKTree(datastructures.EditDistance)
+	for _, word := range words {
+		tree.Add(word)
+	}
+	// "cook" appears twice in the list but is only stored once
+	fmt.Printf("Added %d words, tree size: %d\n", len(words), tree.Size())
+
+	// Example 2: Range search
+	fmt.Println("\nExample 2: Words within distance 1 of 'bok'")
+	fmt.Printf("Matches: %v\n", tree.RangeSearch("bok", 1))
+	fmt.Println("Words within distance 2 of 'cakes':")
+	fmt.Printf("Matches: %v\n", tree.RangeSearch("cakes", 2))
+
+	// Example 3: Hamming distance
+	fmt.Println("\nExample 3: BK-tree with Hamming distance")
+	hashes := datastructures.NewBKTree(datastructures.HammingDistance)
+	for _, h := range []string{"10110", "10011", "11110", "00000", "10111"} {
+		hashes.Add(h)
+	}
+	fmt.Printf("Hashes within distance 1 of '10110': %v\n", hashes.RangeSearch("10110", 1))
+
+	// Example 4: Comparing against a brute-force scan
+	fmt.Println("\nExample 4: Verifying results against brute force")
+	queries := []string{"bok", "cakes", "wrd", "xyz", "brook", ""}
+	for _, metricCase := range []struct {
+		name   string
+		metric datastructures.Metric
+	}{
+		{"edit distance", datastructures.EditDistance},
+		{"hamming", datastructures.HammingDistance},
+	} {
+		t := datastructures.NewBKTree(metricCase.metric)
+		for _, word := range words {
+			t.Add(word)
+		}
+		allMatch := true
+		for _, query := range queries {
+			for radius := 0; radius <= 3; radius++ {
+				got := t.RangeSearch(query, radius)
+				want := bruteForceSearch(t.Words(), metricCase.metric, query, radius)
+				if !sameWords(got, want) {
+					allMatch = false
+					fmt.Printf("Mismatch for %q (radius %d): got %v, want %v\n", query, radius, got, want)
+				}
+			}
+		}
+		fmt.Printf("%s: BK-tree matches brute force? %v\n", metricCase.name, allMatch)
+	}
+}
+
+// bruteForceSearch checks every word against the query
+// Used to verify the BK-tree returns exactly the same words
+func bruteForceSearch(words []string, metric datastructures.Metric, query string, radius int) []string {
+	result := []string{}
+	for _, word := range words {
+		if metric(query, word) <= radius {
+			result = append(result, word)
+		}
+	}
+	sort.Strings(result)
+	return result
+}
+
+// sameWords reports whether two word lists contain the same words in any order
+func sameWords(a, b []string) bool {
+	if len(a) != len(b) {
+		return false
+	}
+	sortedA := append([]string{}, a...)
+	sort.Strings(sortedA)
+	for i := range sortedA {
+		if sortedA[i] != b[i] {
+			return false
+		}
+	}
+	return true
+}
@@ -0,0 +1,322 @@
+package main
+
+// The examples run every demo and compare what it prints with the Output
+// comment, so a demo that stops printing what it claims fails go test
+
+func Example_stack() {
+	runStack()
+	// Output:
+	// Example 1: Pushing elements
+	// Pushing: 1, 2, 3
+	//
+	// Example 2: Stack Status
+	// Stack size: 3
+	// Top element: 3
+	//
+	// Example 3: Popping elements
+	// Popping all elements:
+	// Popped: 3
+	// Popped: 2
+	// Popped: 1
+	//
+	// Example 4: Error handling
+	// Trying to pop from empty stack:
+	// Error: stack is empty
+	//
+	// Example 5: Bracket Matching Example
+	// Is '((()))' valid? true
+	// Is '(()())' valid? true
+	// Is '(()' valid? false
+	// Is ')(' valid? false
+	// Is '{[()]}' valid? true
+	// Is '([)]' valid? false
+	// Is 'print(")")' valid? true
+}
+
+func Example_expression() {
+	runExpression()
+	// Output:
+	// Example 1: Converting infix to postfix
+	// 3 + 4 * 2    => 3 4 2 * +
+	// (3 + 4) * 2  => 3 4 + 2 *
+	// 2 ^ 3 ^ 2    => 2 3 2 ^ ^
+	// -2 ^ 2       => 2 2 ^ neg
+	//
+	// Example 2: Evaluating
+	// 3 + 4 * 2 = 11
+	// (3 + 4) * 2 = 14
+	// 2 ^ 3 ^ 2 = 512
+	// -2 ^ 2 = -4
+	// -(1.5 + 2.5) / 2 ^ -1 = -8
+	//
+	// Example 3: Errors
+	// "1 +": invalid expression at position 3: expression ends without a number
+	// "2 (3)": invalid expression at position 2: missing operator before "("
+	// "(1 + 2": invalid expression at position 0: unmatched "("
+	// "4 / (2 - 2)": division by zero at position 2
+}
+
+func Example_brackets() {
+	runBrackets()
+	// Output:
+	// Example 1: Finding the first mismatch
+	// "f(a[i], {b})" ok
+	// "f(a[i)]"      1:6: ')' does not close '['
+	// "f(a, b"       1:2: '(' is not closed
+	// "x)"           1:2: unexpected ')'
+	// "print(\")\""  1:6: '(' is not closed
+	//
+	// Example 2: Language-aware matching
+	// "f(x) // :)" plain: 1:10: unexpected ')'
+	// "f(x) // :)" as Go: <nil>
+	// "f(x)  # :)" as Python: <nil>
+	//
+	// Example 3: Linting main.go
+	// main.go:7:18: ')' does not close '['
+	//     fmt.Println(s[0)]
+}
+
+func Example_queue() {
+	runQueue()
+	// Output:
+	// Example 1: Enqueuing elements
+	// Enqueuing: 1, 2, 3
+	//
+	// Example 2: Queue Status
+	// Queue size: 3
+	// Front element: 1
+	//
+	// Example 3: Dequeuing elements
+	// Dequeuing all elements:
+	// Dequeued: 1
+	// Dequeued: 2
+	// Dequeued: 3
+	//
+	// Example 4: Error handling
+	// Trying to dequeue from empty queue:
+	// Error: queue is empty
+	//
+	// Example 5: Mixed operations
+	// Dequeued: 10
+	// Final queue size: 2
+}
+
+func Example_heap() {
+	runHeap()
+	// Output:
+	// Example 1: Building a min-heap
+	// Values: [7 2 9 4 1 8]
+	// Smallest: 1, heap size: 6
+	//
+	// Example 2: Popping in order
+	// Popped: 1 2 4 7 8 9
+	//
+	// Example 3: Max-heap
+	// After pushing 12, largest: 12
+	//
+	// Example 4: Priority queue of tasks
+	// Doing "fix outage" (priority 5)
+	// Doing "review PR" (priority 3)
+	// Doing "write docs" (priority 1)
+	//
+	// Example 5: Error handling
+	// Error: heap is empty
+}
+
+func Example_linkedlist() {
+	runLinkedList()
+	// Output:
+	// Example 1: Inserting elements
+	// Original List: 1 -> 2 -> 3 -> 4 -> nil
+	//
+	// Example 2: Deleting element 2
+	// After deleting 2: 1 -> 3 -> 4 -> nil
+	//
+	// Example 3: Inserting element 5
+	// After inserting 5: 1 -> 3 -> 4 -> 5 -> nil
+	//
+	// Example 4: Inserting 2 back at index 1
+	// After InsertAt(1, 2): 1 -> 2 -> 3 -> 4 -> 5 -> nil
+	// Length: 5, Get(2): 3, middle: 3
+	//
+	// Example 5: Reversing and removing the 2nd node from the end
+	// Reversed: 5 -> 4 -> 3 -> 2 -> 1 -> nil
+	// Removed 2: 5 -> 4 -> 3 -> 1 -> nil
+}
+
+func Example_lru() {
+	runLRU()
+	// Output:
+	// Example 1: Filling a cache of 3 entries
+	// Keys, most recent first: [c b a]
+	//
+	// Example 2: Eviction
+	// Get(a), then Put(d) evicts "b"
+	// Keys, most recent first: [d a c]
+	//
+	// Example 3: Read-through cache
+	// square(2) = 4  cached: false
+	// square(3) = 9  cached: false
+	// square(2) = 4  cached: true
+	// square(4) = 16 cached: false
+	// square(2) = 4  cached: true
+	// square(3) = 9  cached: false
+	// Hits: 2, misses: 4
+}
+
+func Example_tree() {
+	runTree()
+	// Output:
+	// Example 1: Building the tree
+	// Inserting: 5, 3, 7, 1, 4, 6, 8
+	//
+	// Example 2: Tree Traversals
+	// Inorder (sorted): [1 3 4 5 6 7 8]
+	// Preorder: [5 3 1 4 7 6 8]
+	// Postorder: [1 4 3 6 8 7 5]
+	//
+	// Example 3: Searching for values
+	// Is 4 in the tree? true
+	// Is 9 in the tree? false
+	//
+	// Example 4: Seeing the structure
+	// Sideways:
+	//     ┌── 8
+	// ┌── 7
+	// │   └── 6
+	// 5
+	// │   ┌── 4
+	// └── 3
+	//     └── 1
+	// Layered:
+	//       5
+	//   ┌───┴───┐
+	//   3       7
+	// ┌─┴─┐   ┌─┴─┐
+	// 1   4   6   8
+	// As DOT for Graphviz:
+	// digraph BinaryTree {
+	// 	node [shape=circle];
+	// 	n0 [label="2"];
+	// 	n1 [label="1"];
+	// 	n0 -> n1;
+	// 	n2 [style=invis];
+	// 	n0 -> n2 [style=invis];
+	// }
+	//
+	// Example 5: Validating
+	// Inserted tree: <nil>
+	// After changing 4 to 9: not a binary search tree: 9 is in the left subtree of 5
+	// Search(9) finds it? false
+	//
+	// Example 6: A sorted map
+	//   graph  vertices and edges
+	//   heap   smallest item on top
+	//   queue  first in, first out
+	//   stack  last in, first out
+	//   trie   a tree of prefixes
+	// From h up to s: heap queue
+	//
+	// Example 7: Building from sorted values
+	// Inserted one by one: height 7
+	// BuildBalanced: height 3
+	//       4
+	//   ┌───┴───┐
+	//   2       6
+	// ┌─┴─┐   ┌─┴─┐
+	// 1   3   5   7
+	// Merged with 0, 4, 8: [0 1 2 3 4 4 5 6 7 8], height 4
+	// Path rebalanced: height 3
+}
+
+func Example_orderstat() {
+	runOrderStatTree()
+	// Output:
+	// Example 1: Select and Rank
+	// 3rd smallest: 30
+	// Rank(70): 5, values up to 60: 4
+	// Values in [25, 75]: 3
+	//
+	// Example 2: Leaderboard
+	// 1. carol  450
+	// 2. alice  320
+	// 3. erin   275
+	// bob is 4 of 5
+	//
+	// Example 3: Updating a score
+	// 1. carol  450
+	// 2. bob    400
+	// 3. alice  320
+	// bob is now 2 of 5
+	//
+	// Example 4: Error handling
+	// Error: index out of range: select 10 of 5 items
+}
+
+func Example_trie() {
+	runTrie()
+	// Output:
+	// Example 1: Lookups
+	// go       word: true  prefix of a word: true
+	// gor      word: false prefix of a word: true
+	// gorilla  word: false prefix of a word: false
+	//
+	// Example 2: Autocomplete
+	// "go" -> [go golang gopher goroutine]
+	// "gr" -> [graph grep]
+	// "x" -> []
+	//
+	// Example 3: Duplicates
+	// Insert("heap") again: false, words: 7
+}
+
+func Example_graph() {
+	runGraph()
+	// Output:
+	// Example 1: Adding vertices 0-5
+	//
+	// Example 2: Adding edges
+	// Added edge: 0 -- 1
+	// Added edge: 1 -- 2
+	// Added edge: 0 -- 3
+	// Added edge: 1 -- 4
+	// Added edge: 2 -- 5
+	// Added edge: 3 -- 4
+	// Added edge: 4 -- 5
+	//
+	// Example 3: Graph Adjacency List:
+	// Vertex 0: [1 3]
+	// Vertex 1: [0 2 4]
+	// Vertex 2: [1 5]
+	// Vertex 3: [0 4]
+	// Vertex 4: [1 3 5]
+	// Vertex 5: [2 4]
+	//
+	// Example 4: BFS starting from vertex 0:
+	// BFS path: [0 1 3 2 4 5]
+	//
+	// Example 5: DFS starting from vertex 0:
+	// DFS path: [0 1 2 5 4 3]
+	//
+	// Example 6: Neighbors of vertex 1:
+	// Neighbors: [0 2 4]
+}
+
+func Example_bktree() {
+	runBKTree()
+	// Output:
+	// Example 1: Building a BK-tree with edit distance
+	// Added 24 words, tree size: 23
+	//
+	// Example 2: Words within distance 1 of 'bok'
+	// Matches: [bock boo book]
+	// Words within distance 2 of 'cakes':
+	// Matches: [cake bake cape care take]
+	//
+	// Example 3: BK-tree with Hamming distance
+	// Hashes within distance 1 of '10110': [10110 10111 11110]
+	//
+	// Example 4: Verifying results against brute force
+	// edit distance: BK-tree matches brute force? true
+	// hamming: BK-tree matches brute force? true
+}
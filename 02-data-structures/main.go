@@ -0,0 +1,86 @@
+// Command 02-data-structures runs the data structure demos
+//
+// Usage:
+//
+//	go run ./02-data-structures                 # run every demo
+//	go run ./02-data-structures -list           # list the demos
+//	go run ./02-data-structures -demo=stack     # run one demo
+//	go run ./02-data-structures -demo=tree,graph
+//
+// The data structures themselves live in the datastructures package
+package main
+
+import (
+	"flag"
+	"fmt"
+	"os"
+	"strings"
+)
+
+// Demo is a runnable example of one data structure
+type Demo struct {
+	// Name is the identifier used with -demo
+	Name  string
+	Title string
+	Run   func()
+}
+
+// demos is the registry of all demos, in the order they run
+var demos = []Demo{
+	{"stack", "Stack", runStack},
+	{"expression", "Expression Evaluator", runExpression},
+	{"brackets", "Bracket Matcher", runBrackets},
+	{"queue", "Queue", runQueue},
+	{"heap", "Heap", runHeap},
+	{"linkedlist", "Linked List", runLinkedList},
+	{"lru", "LRU Cache", runLRU},
+	{"tree", "Binary Search Tree", runTree},
+	{"orderstat", "Order-statistics Tree", runOrderStatTree},
+	{"trie", "Trie", runTrie},
+	{"graph", "Graph", runGraph},
+	{"bktree", "BK-tree", runBKTree},
+}
+
+// findDemo looks a demo up by name
+func findDemo(name string) (Demo, bool) {
+	for _, d := range demos {
+		if d.Name == name {
+			return d, true
+		}
+	}
+	return Demo{}, false
+}
+
+func main() {
+	names := flag.String("demo", "", "comma-separated demos to run (default: all)")
+	list := flag.Bool("list", false, "list the available demos")
+	flag.Parse()
+
+	if *list {
+		for _, d := range demos {
+			fmt.Printf("  %-12s %s\n", d.Name, d.Title)
+		}
+		return
+	}
+
+	selected := demos
+	if *names != "" {
+		selected = nil
+		for _, name := range strings.Split(*names, ",") {
+			d, ok := findDemo(strings.TrimSpace(name))
+			if !ok {
+				fmt.Fprintf(os.Stderr, "unknown demo %q; run with -list to see the demos\n", name)
+				os.Exit(2)
+			}
+			selected = append(selected, d)
+		}
+	}
+
+	for i, d := range selected {
+		if i > 0 {
+			fmt.Println()
+		}
+		fmt.Printf("=== %s ===\n", d.Title)
+		d.Run()
+	}
+}
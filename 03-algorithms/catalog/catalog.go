@@ -0,0 +1,15 @@
+// Package catalog imports every package that registers algorithms with the
+// registry, so importing catalog fills the registry:
+//
+//	import _ "github.com/NutProhmpiriya/go-basic/03-algorithms/catalog"
+//
+// A new algorithm package only needs its init to call registry.Register
+// and a line here; the learn examples, the complexity checks and the
+// benchmarks of this package pick it up from then on.
+package catalog
+
+import (
+	_ "github.com/NutProhmpiriya/go-basic/03-algorithms/searching"
+	_ "github.com/NutProhmpiriya/go-basic/03-algorithms/sorting"
+	_ "github.com/NutProhmpiriya/go-basic/03-algorithms/stringalgo"
+)
@@ -0,0 +1,59 @@
+package catalog
+
+import (
+	"fmt"
+	"slices"
+	"strings"
+	"testing"
+
+	"github.com/NutProhmpiriya/go-basic/03-algorithms/registry"
+	"github.com/NutProhmpiriya/go-basic/testdata/generator"
+)
+
+// Every registered algorithm gives the same answer as the others of its
+// category on the same input, and leaves the input alone
+func TestRegisteredAlgorithms(t *testing.T) {
+	if len(registry.Categories()) < 3 {
+		t.Fatalf("categories = %v, want sorting, searching and strings", registry.Categories())
+	}
+	for _, a := range registry.All() {
+		for _, n := range []int{0, 1, 17, 200} {
+			in := registry.NewInput(a.Input, n, generator.New(int64(n)))
+			before := slices.Clone(in.Ints)
+			got := a.Run(in)
+			if !slices.Equal(in.Ints, before) {
+				t.Errorf("%s changed its input", a.ID())
+			}
+			switch a.Category {
+			case "sorting":
+				if ints := got.([]int); !slices.IsSorted(ints) || len(ints) != n {
+					t.Errorf("%s(n=%d) = %v, not sorted", a.ID(), n, ints)
+				}
+			case "searching":
+				if i := got.(int); n > 0 && in.Ints[i] != in.Target {
+					t.Errorf("%s(n=%d) found %d at %d, want %d", a.ID(), n, in.Ints[i], i, in.Target)
+				}
+			}
+		}
+	}
+}
+
+// BenchmarkAlgorithms times every registered algorithm at a few sizes, so
+// gobasic bench covers a new algorithm as soon as it is registered
+// The sub-benchmark names work with benchstat -col /algo
+func BenchmarkAlgorithms(b *testing.B) {
+	for _, a := range registry.All() {
+		sizes := []int{100, 1_000, 10_000}
+		if strings.HasPrefix(a.Time, "O(n²)") || strings.HasPrefix(a.Time, "O(mn)") {
+			sizes = sizes[:2]
+		}
+		for _, n := range sizes {
+			in := registry.NewInput(a.Input, n, generator.New(1))
+			b.Run(fmt.Sprintf("category=%s/n=%d/algo=%s", a.Category, n, a.Name), func(b *testing.B) {
+				for range b.N {
+					a.Run(in)
+				}
+			})
+		}
+	}
+}
@@ -0,0 +1,54 @@
+package main
+
+import (
+	"fmt"
+	"os"
+	"strings"
+
+	"github.com/NutProhmpiriya/go-basic/03-algorithms/graph"
+)
+
+// runBuildResolver resolves the dependency file given by -deps
+func runBuildResolver() {
+	f, err := os.Open(*depsFile)
+	if err != nil {
+		fmt.Fprintln(os.Stderr, "Error:", err)
+		return
+	}
+	defer f.Close()
+
+	// Example 1: Parsing the dependency file
+	deps, err := graph.ParseDependencies(f)
+	if err != nil {
+		fmt.Fprintln(os.Stderr, "Error:", err)
+		return
+	}
+	fmt.Printf("Example 1: Dependencies in %s\n", *depsFile)
+	for _, pkg := range deps.Packages() {
+		fmt.Printf("%-10s -> %v\n", pkg, deps.Deps(pkg))
+	}
+
+	// Example 2: Cycle detection
+	fmt.Println("\nExample 2: Checking for cycles")
+	if cycle := deps.FindCycle(); cycle != nil {
+		fmt.Println("Cannot build:", cycle)
+		return
+	}
+	fmt.Println("No cycles found")
+
+	// Example 3: Sequential build order
+	order, _ := deps.BuildOrder()
+	fmt.Println("\nExample 3: Build order")
+	fmt.Println(strings.Join(order, " -> "))
+
+	// Example 4: Parallel schedule
+	levels, _ := deps.BuildLevels()
+	fmt.Println("\nExample 4: Parallel build schedule")
+	maxParallel := 0
+	for i, level := range levels {
+		fmt.Printf("Step %d: %v\n", i+1, level)
+		maxParallel = max(maxParallel, len(level))
+	}
+	fmt.Printf("Sequential steps: %d, parallel steps: %d, max parallelism: %d\n",
+		len(order), len(levels), maxParallel)
+}
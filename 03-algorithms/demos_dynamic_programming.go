@@ -0,0 +1,39 @@
+package main
+
+import (
+	"fmt"
+
+	"github.com/NutProhmpiriya/go-basic/03-algorithms/dp"
+)
+
+// runDynamicProgramming demonstrates the dynamic programming solutions
+func runDynamicProgramming() {
+	// Example 1: Fibonacci Numbers
+	n := 10
+	fmt.Printf("Fibonacci(%d) using recursion: %d\n", n, dp.FibonacciRecursive(n))
+	fmt.Printf("Fibonacci(%d) using DP: %d\n\n", n, dp.FibonacciDP(n))
+
+	// Example 2: Longest Common Subsequence
+	text1 := "abcde"
+	text2 := "ace"
+	lcs := dp.LongestCommonSubsequence(text1, text2)
+	fmt.Printf("Length of Longest Common Subsequence between '%s' and '%s': %d\n\n",
+		text1, text2, lcs)
+
+	// Example 3: 0/1 Knapsack Problem
+	values := []int{60, 100, 120} // Values of items
+	weights := []int{10, 20, 30}  // Weights of items
+	capacity := 50                // Knapsack capacity
+	maxValue := dp.KnapsackProblem(values, weights, capacity)
+	fmt.Printf("Maximum value in Knapsack: %d\n\n", maxValue)
+
+	// Example 4: Coin Change Problem
+	coins := []int{1, 2, 5}
+	amount := 11
+	minCoins := dp.CoinChange(coins, amount)
+	if minCoins != -1 {
+		fmt.Printf("Minimum coins needed for amount %d: %d\n", amount, minCoins)
+	} else {
+		fmt.Printf("Cannot make amount %d with given coins\n", amount)
+	}
+}
@@ -0,0 +1,52 @@
+package main
+
+import (
+	"fmt"
+
+	"github.com/NutProhmpiriya/go-basic/03-algorithms/greedy"
+)
+
+// runGreedy demonstrates the greedy algorithms
+func runGreedy() {
+	// Example 1: Activity Selection
+	activities := []greedy.Activity{
+		{Start: 1, End: 4}, {Start: 3, End: 5}, {Start: 0, End: 6}, {Start: 5, End: 7},
+		{Start: 3, End: 9}, {Start: 5, End: 9}, {Start: 6, End: 10}, {Start: 8, End: 11},
+		{Start: 8, End: 12}, {Start: 2, End: 14}, {Start: 12, End: 16},
+	}
+
+	selected := greedy.ActivitySelection(activities)
+	fmt.Println("Activity Selection Problem:")
+	fmt.Printf("Selected activities: %+v\n\n", selected)
+
+	// Example 2: Fractional Knapsack
+	items := []greedy.Item{
+		{Value: 60, Weight: 10},
+		{Value: 100, Weight: 20},
+		{Value: 120, Weight: 30},
+	}
+	capacity := 50.0
+
+	maxValue := greedy.FractionalKnapsack(items, capacity)
+	fmt.Println("Fractional Knapsack Problem:")
+	fmt.Printf("Maximum value: %.2f\n\n", maxValue)
+
+	// Example 3: Huffman Coding
+	text := "this is an example for huffman encoding"
+	huffmanTree := greedy.BuildHuffmanTree(text)
+	fmt.Println("Huffman Coding:")
+	fmt.Printf("Huffman tree root frequency: %d\n\n", huffmanTree.Freq)
+
+	// Example 4: Dijkstra's Shortest Path
+	graph := [][]greedy.Edge{
+		{{To: 1, Weight: 4}, {To: 2, Weight: 1}}, // Edges from vertex 0
+		{{To: 3, Weight: 1}},                     // Edges from vertex 1
+		{{To: 1, Weight: 2}, {To: 3, Weight: 5}}, // Edges from vertex 2
+		{{To: 4, Weight: 3}},                     // Edges from vertex 3
+		{},                                       // Edges from vertex 4
+	}
+
+	distances := greedy.DijkstraShortestPath(graph, 0)
+	fmt.Println("Dijkstra's Shortest Path:")
+	fmt.Printf("Shortest distances from vertex 0: %v\n", distances)
+}
@@ -0,0 +1,38 @@
+package main
+
+import (
+	"fmt"
+
+	"github.com/NutProhmpiriya/go-basic/03-algorithms/heapalgo"
+)
+
+// runHeapAlgorithms demonstrates the heap algorithms
+func runHeapAlgorithms() {
+	// Example 1: K-way merge, as when combining sorted log files
+	fmt.Println("Example 1: K-way Merge")
+	lists := [][]int{{1, 4, 9}, {2, 6}, {3, 5, 7, 8}, {}}
+	for i, list := range lists {
+		fmt.Printf("list %d: %v\n", i, list)
+	}
+	fmt.Println("merged:", heapalgo.MergeSorted(lists))
+
+	// Example 2: Running median, updated as each value arrives
+	fmt.Println("\nExample 2: Running Median")
+	m := heapalgo.NewRunningMedian()
+	if _, err := m.Median(); err != nil {
+		fmt.Println("before any values:", err)
+	}
+	for _, x := range []int{12, 4, 5, 3, 8, 7} {
+		m.Add(x)
+		median, _ := m.Median()
+		fmt.Printf("add %2d -> median %.1f\n", x, median)
+	}
+
+	// Example 3: The most frequent values of a stream
+	fmt.Println("\nExample 3: Top-k Frequent")
+	requests := []int{404, 200, 200, 500, 200, 404, 301, 404, 200}
+	fmt.Println("status codes:", requests)
+	for _, k := range []int{1, 2, 10} {
+		fmt.Printf("top %d: %v\n", k, heapalgo.TopKFrequent(requests, k))
+	}
+}
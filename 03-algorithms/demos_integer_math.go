@@ -0,0 +1,112 @@
+package main
+
+import (
+	"fmt"
+	"math"
+	"math/bits"
+	"math/rand"
+	"testing"
+	"time"
+
+	"github.com/NutProhmpiriya/go-basic/03-algorithms/intmath"
+)
+
+// runIntegerMath demonstrates the integer math routines and checks them against the naive versions
+func runIntegerMath() {
+	// Example 1: GCD
+	fmt.Println("Example 1: Greatest Common Divisor")
+	pairs := [][2]uint64{{48, 18}, {1071, 462}, {17, 5}, {0, 9}, {1 << 40, 1 << 20}}
+	for _, p := range pairs {
+		fmt.Printf("gcd(%d, %d): Euclid=%d Binary=%d\n", p[0], p[1], intmath.GCD(p[0], p[1]), intmath.BinaryGCD(p[0], p[1]))
+	}
+
+	// Example 2: Integer square root
+	fmt.Println("\nExample 2: Integer Square Root")
+	for _, n := range []uint64{0, 1, 15, 16, 17, 1000000, math.MaxUint64} {
+		fmt.Printf("isqrt(%d) = %d\n", n, intmath.ISqrt(n))
+	}
+
+	// Example 3: Overflow-checked and saturating arithmetic
+	fmt.Println("\nExample 3: Overflow-safe Arithmetic")
+	if _, ok := intmath.AddChecked(math.MaxInt64, 1); !ok {
+		fmt.Println("MaxInt64 + 1 overflows")
+	}
+	if _, ok := intmath.MulChecked(math.MinInt64, -1); !ok {
+		fmt.Println("MinInt64 * -1 overflows")
+	}
+	// float64(MaxInt64) rounds up to 2^63, so the naive check misses this overflow
+	_, fastOK := intmath.MulChecked(1<<31, 1<<32)
+	_, naiveOK := intmath.NaiveMulChecked(1<<31, 1<<32)
+	fmt.Printf("2^31 * 2^32 fits? checked=%v naive=%v\n", fastOK, naiveOK)
+	fmt.Printf("SaturatingAdd(MaxInt64, 10) = %d\n", intmath.SaturatingAdd(math.MaxInt64, 10))
+	fmt.Printf("SaturatingSub(MinInt64, 10) = %d\n", intmath.SaturatingSub(math.MinInt64, 10))
+	fmt.Printf("SaturatingMul(-1<<40, 1<<40) = %d\n", intmath.SaturatingMul(-1<<40, 1<<40))
+
+	// Example 4: Bit tricks
+	fmt.Println("\nExample 4: Bit Tricks")
+	for _, n := range []uint64{0, 1, 6, 64, 100} {
+		fmt.Printf("n=%3d isPow2=%-5v nextPow2=%3d lowestBit=%2d popcount=%d\n",
+			n, intmath.IsPowerOfTwo(n), intmath.NextPowerOfTwo(n), intmath.LowestSetBit(n), intmath.PopCount(n))
+	}
+
+	// Example 5: Verify against the naive versions on random inputs
+	fmt.Println("\nExample 5: Verifying against naive versions")
+	rng := rand.New(rand.NewSource(1))
+	allMatch := true
+	for i := 0; i < 10000; i++ {
+		a, b := uint64(rng.Intn(100000)), uint64(rng.Intn(100000))
+		if intmath.BinaryGCD(a, b) != intmath.NaiveGCD(a, b) || intmath.GCD(a, b) != intmath.NaiveGCD(a, b) {
+			allMatch = false
+		}
+		if intmath.ISqrt(a) != intmath.NaiveISqrt(a) {
+			allMatch = false
+		}
+		n := rng.Uint64()
+		if intmath.PopCount(n) != intmath.NaivePopCount(n) || intmath.PopCount(n) != bits.OnesCount64(n) {
+			allMatch = false
+		}
+	}
+	fmt.Printf("All results match? %v\n", allMatch)
+}
+
+// integerMathTimings benchmarks the fast routines against the naive versions
+func integerMathTimings() {
+	fmt.Println("Example 6: Benchmarks (fast vs naive)")
+	rng := rand.New(rand.NewSource(1))
+	inputs := make([]uint64, 1024)
+	for i := range inputs {
+		inputs[i] = uint64(rng.Intn(1 << 20))
+	}
+	compare("BinaryGCD",
+		func(i int) { sink += intmath.BinaryGCD(inputs[i%1024], inputs[(i+1)%1024]) },
+		func(i int) { sink += intmath.NaiveGCD(inputs[i%1024], inputs[(i+1)%1024]) })
+	compare("EuclidGCD",
+		func(i int) { sink += intmath.GCD(inputs[i%1024], inputs[(i+1)%1024]) },
+		func(i int) { sink += intmath.NaiveGCD(inputs[i%1024], inputs[(i+1)%1024]) })
+	compare("ISqrt",
+		func(i int) { sink += intmath.ISqrt(inputs[i%1024] << 20) },
+		func(i int) { sink += intmath.NaiveISqrt(inputs[i%1024] << 20) })
+	compare("MulChecked",
+		func(i int) { v, _ := intmath.MulChecked(int64(inputs[i%1024]), int64(i)); sink += uint64(v) },
+		func(i int) { v, _ := intmath.NaiveMulChecked(int64(inputs[i%1024]), int64(i)); sink += uint64(v) })
+	compare("PopCount",
+		func(i int) { sink += uint64(intmath.PopCount(inputs[i%1024])) },
+		func(i int) { sink += uint64(intmath.NaivePopCount(inputs[i%1024])) })
+}
+
+// sink prevents the compiler from optimizing away benchmarked calls
+var sink uint64
+
+// compare benchmarks a fast and a naive implementation and prints both timings
+func compare(name string, fast, naive func(i int)) {
+	run := func(f func(i int)) time.Duration {
+		result := testing.Benchmark(func(b *testing.B) {
+			for i := 0; i < b.N; i++ {
+				f(i)
+			}
+		})
+		return time.Duration(result.NsPerOp())
+	}
+	fastTime, naiveTime := run(fast), run(naive)
+	fmt.Printf("%-12s fast: %10v/op  naive: %10v/op\n", name, fastTime, naiveTime)
+}
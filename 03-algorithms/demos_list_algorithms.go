@@ -0,0 +1,85 @@
+package main
+
+import (
+	"fmt"
+
+	"github.com/NutProhmpiriya/go-basic/02-data-structures/datastructures"
+	"github.com/NutProhmpiriya/go-basic/03-algorithms/listalgo"
+)
+
+// newList builds a linked list holding values in order
+func newList(values ...int) *datastructures.LinkedList {
+	l := &datastructures.LinkedList{}
+	for _, v := range values {
+		l.InsertBack(v)
+	}
+	return l
+}
+
+// runListAlgorithms demonstrates the linked list algorithms
+func runListAlgorithms() {
+	// Example 1: Merging two sorted lists relinks their nodes
+	fmt.Println("Example 1: Merge Sorted Lists")
+	a, b := newList(1, 4, 6), newList(2, 3, 5, 7)
+	fmt.Print("a: ")
+	a.Print()
+	fmt.Print("b: ")
+	b.Print()
+	merged := listalgo.Merge(a, b)
+	fmt.Print("merged: ")
+	merged.Print()
+	fmt.Println("a and b are now empty:", a.Len(), b.Len())
+
+	// Example 2: Merge sort, split at the middle with fast and slow pointers
+	fmt.Println("\nExample 2: Merge Sort")
+	l := newList(5, 2, 9, 1, 5, 6, 3)
+	fmt.Print("before: ")
+	l.Print()
+	listalgo.Sort(l)
+	fmt.Print("after:  ")
+	l.Print()
+
+	// Example 3: Two lists that share their tail
+	// a: 1 → 2 ↘
+	//            7 → 8 → 9
+	// b:     4 ↗
+	fmt.Println("\nExample 3: Intersection")
+	shared := newList(7, 8, 9)
+	first, second := newList(1, 2), newList(4)
+	for _, l := range []*datastructures.LinkedList{first, second} {
+		head := l.TakeNodes()
+		last := head
+		for last.Next() != nil {
+			last = last.Next()
+		}
+		last.SetNext(shared.Front())
+		if err := l.SetNodes(head); err != nil {
+			fmt.Println("Error:", err)
+			return
+		}
+	}
+	fmt.Print("first:  ")
+	first.Print()
+	fmt.Print("second: ")
+	second.Print()
+	if node := listalgo.Intersection(first, second); node != nil {
+		fmt.Println("They join at the node holding", node.Value())
+	}
+	fmt.Println("Lists with equal values but separate nodes:",
+		listalgo.Intersection(newList(7, 8, 9), newList(7, 8, 9)))
+
+	// Example 4: Palindromes, checked by reversing the second half in place
+	fmt.Println("\nExample 4: Palindrome Check")
+	for _, values := range [][]int{{1, 2, 3, 2, 1}, {1, 2, 2, 1}, {1, 2, 3}} {
+		fmt.Printf("%v palindrome? %v\n", values, listalgo.IsPalindrome(newList(values...)))
+	}
+
+	// Example 5: Reorder L0 → Ln → L1 → Ln-1 → ...
+	fmt.Println("\nExample 5: Reorder")
+	l = newList(1, 2, 3, 4, 5, 6)
+	fmt.Print("before: ")
+	l.Print()
+	listalgo.Reorder(l)
+	fmt.Print("after:  ")
+	l.Print()
+}
@@ -0,0 +1,70 @@
+package main
+
+import (
+	"fmt"
+	"runtime"
+	"slices"
+	"testing"
+	"time"
+
+	"github.com/NutProhmpiriya/go-basic/03-algorithms/graph"
+	"github.com/NutProhmpiriya/go-basic/testdata/generator"
+)
+
+// runParallelGraph runs the parallel BFS and connected components on a
+// random graph and checks them against the sequential versions
+func runParallelGraph() {
+	gen := generator.New(*seed)
+
+	// Example 1: Level-synchronous BFS
+	// Every level is the frontier the workers share out for the next one
+	const n = 100_000
+	a := graph.NewAdjacency(n, gen.Edges(n, 2*n))
+	fmt.Printf("Example 1: BFS from vertex 0 of a random graph, %d vertices and %d edges\n", n, 2*n)
+	dist := a.ParallelBFS(0, 4)
+	levels := make(map[int32]int)
+	deepest := int32(0)
+	for _, d := range dist {
+		levels[d]++
+		deepest = max(deepest, d)
+	}
+	for d := int32(0); d <= deepest; d++ {
+		fmt.Printf("  level %2d: %6d vertices\n", d, levels[d])
+	}
+	fmt.Printf("  unreachable: %d vertices\n", levels[-1])
+	fmt.Println("Same distances as the sequential BFS:", slices.Equal(dist, a.BFS(0)))
+
+	// Example 2: Connected components with a concurrent union-find
+	labels := a.ParallelComponents(4)
+	sizes := make(map[int32]int)
+	for _, l := range labels {
+		sizes[l]++
+	}
+	largest := 0
+	for _, size := range sizes {
+		largest = max(largest, size)
+	}
+	fmt.Println("\nExample 2: Connected components")
+	fmt.Printf("%d components, the largest with %d vertices\n", len(sizes), largest)
+	fmt.Println("Same labels as the sequential version:", slices.Equal(labels, a.Components()))
+}
+
+func parallelGraphTimings() {
+	// Example 3: Timings on a graph with millions of edges
+	// The parallel versions only win with several cores to run on
+	const n = 1 << 20
+	a := graph.NewAdjacency(n, generator.New(*seed).Edges(n, 4*n))
+	fmt.Printf("Example 3: %d vertices, %d edges, GOMAXPROCS=%d\n", n, 4*n, runtime.GOMAXPROCS(0))
+	measure := func(name string, f func()) {
+		result := testing.Benchmark(func(b *testing.B) {
+			for i := 0; i < b.N; i++ {
+				f()
+			}
+		})
+		fmt.Printf("%-24s %v/op\n", name, time.Duration(result.NsPerOp()).Round(time.Millisecond))
+	}
+	measure("BFS", func() { a.BFS(0) })
+	measure("ParallelBFS", func() { a.ParallelBFS(0, 0) })
+	measure("Components", func() { a.Components() })
+	measure("ParallelComponents", func() { a.ParallelComponents(0) })
+}
@@ -0,0 +1,121 @@
+package main
+
+import (
+	"errors"
+	"fmt"
+	"io/fs"
+	"math"
+	"os"
+	"path/filepath"
+	"slices"
+	"strings"
+
+	"github.com/NutProhmpiriya/go-basic/03-algorithms/graph"
+	"github.com/NutProhmpiriya/go-basic/ordering"
+)
+
+// loadRoads reads a road network from a .geojson or .csv file
+func loadRoads(path string) (*graph.WeightedGraph, error) {
+	f, err := os.Open(path)
+	if err != nil {
+		return nil, err
+	}
+	defer f.Close()
+	if filepath.Ext(path) == ".csv" {
+		return graph.LoadEdgeCSV(f, false)
+	}
+	return graph.LoadGeoJSON(f)
+}
+
+// printPath prints a path found by an algorithm
+func printPath(algorithm string, p graph.Path) {
+	fmt.Printf("%-9s %6.1f km, %2d cities settled: %s\n", algorithm, p.Length, p.Settled, strings.Join(p.Vertices, " -> "))
+}
+
+// runRoadNetwork runs the weighted graph algorithms on the road network
+// given by -roads
+func runRoadNetwork() {
+	// Example 1: Loading the dataset
+	roads, err := loadRoads(*roadsFile)
+	if err != nil {
+		fmt.Fprintln(os.Stderr, "Error:", err)
+		return
+	}
+	fmt.Printf("Example 1: Roads in %s\n", *roadsFile)
+	fmt.Printf("%d cities, %d roads, %.1f km of road\n", roads.Len(), roads.EdgeCount(), roads.TotalWeight())
+
+	// Example 2: Dijkstra's algorithm from one city to all of them
+	const from = "Bangkok"
+	distances, err := roads.Distances(from)
+	if err != nil {
+		fmt.Fprintln(os.Stderr, "Error:", err)
+		return
+	}
+	fmt.Printf("\nExample 2: Shortest distances from %s (Dijkstra)\n", from)
+	distance := func(city string) float64 {
+		if d, ok := distances[city]; ok {
+			return d
+		}
+		return math.Inf(1)
+	}
+	cities := roads.Vertices()
+	slices.SortStableFunc(cities, ordering.By(distance))
+	for _, city := range cities {
+		if city != from {
+			fmt.Printf("  %-18s %6.1f km\n", city, distance(city))
+		}
+	}
+
+	// Example 3: A* against Dijkstra's algorithm
+	// Both find the same shortest routes; the straight-line distance steers
+	// A* away from the cities in the wrong direction
+	fmt.Println("\nExample 3: Routes by Dijkstra and A*")
+	for _, to := range []string{"Chiang Mai", "Ubon Ratchathani", "Hua Hin"} {
+		dijkstra, err := roads.ShortestPath(from, to)
+		if err != nil {
+			fmt.Printf("%s: %v\n", to, err)
+			continue
+		}
+		astar, _ := roads.AStar(from, to)
+		printPath("Dijkstra", dijkstra)
+		printPath("A*", astar)
+	}
+
+	// Example 4: The minimum spanning tree
+	// The shortest set of roads that still connects every city, e.g. the
+	// roads to keep open after a flood
+	tree := roads.MinimumSpanningTree()
+	length := 0.0
+	for _, e := range tree {
+		length += e.Weight
+	}
+	fmt.Println("\nExample 4: Minimum spanning tree (Kruskal)")
+	fmt.Printf("%d of %d roads, %.1f of %.1f km, connect all %d cities\n",
+		len(tree), roads.EdgeCount(), length, roads.TotalWeight(), roads.Len())
+	slices.SortFunc(tree, ordering.By(func(e graph.WeightedEdge) float64 { return e.Weight }).Reversed())
+	fmt.Println("Longest roads kept:")
+	for _, e := range tree[:min(3, len(tree))] {
+		fmt.Printf("  %s - %s %.1f km\n", e.From, e.To, e.Weight)
+	}
+
+	// Example 5: The same roads as a CSV edge list
+	// Without the locations of the cities, A* has nothing to estimate with
+	// and settles as many cities as Dijkstra's algorithm
+	csvFile := strings.TrimSuffix(*roadsFile, filepath.Ext(*roadsFile)) + ".csv"
+	if csvFile == *roadsFile {
+		return
+	}
+	edgeList, err := loadRoads(csvFile)
+	if errors.Is(err, fs.ErrNotExist) {
+		return
+	}
+	if err != nil {
+		fmt.Fprintln(os.Stderr, "Error:", err)
+		return
+	}
+	fmt.Printf("\nExample 5: The edge list in %s, without locations\n", csvFile)
+	fmt.Printf("%d cities, %d roads, %.1f km of road\n", edgeList.Len(), edgeList.EdgeCount(), edgeList.TotalWeight())
+	if astar, err := edgeList.AStar(from, "Chiang Mai"); err == nil {
+		printPath("A*", astar)
+	}
+}
@@ -0,0 +1,59 @@
+package main
+
+import (
+	"fmt"
+
+	"github.com/NutProhmpiriya/go-basic/03-algorithms/searching"
+)
+
+// runSearching demonstrates the search algorithms on a sorted array
+func runSearching() {
+	// Test array (sorted for binary, jump, and interpolation search)
+	arr := []int{1, 3, 5, 7, 9, 11, 13, 15, 17, 19}
+	target := 13
+	fmt.Printf("Searching for %d in array: %v\n\n", target, arr)
+
+	// Example 1: Linear Search
+	fmt.Println("Example 1: Linear Search")
+	result := searching.LinearSearch(arr, target)
+	if result != -1 {
+		fmt.Printf("Element found at index: %d\n", result)
+	} else {
+		fmt.Println("Element not found")
+	}
+
+	// Example 2: Binary Search
+	fmt.Println("\nExample 2: Binary Search")
+	result = searching.BinarySearch(arr, target)
+	if result != -1 {
+		fmt.Printf("Element found at index: %d\n", result)
+	} else {
+		fmt.Println("Element not found")
+	}
+
+	// Example 3: Jump Search
+	fmt.Println("\nExample 3: Jump Search")
+	result = searching.JumpSearch(arr, target)
+	if result != -1 {
+		fmt.Printf("Element found at index: %d\n", result)
+	} else {
+		fmt.Println("Element not found")
+	}
+
+	// Example 4: Interpolation Search
+	fmt.Println("\nExample 4: Interpolation Search")
+	result = searching.InterpolationSearch(arr, target)
+	if result != -1 {
+		fmt.Printf("Element found at index: %d\n", result)
+	} else {
+		fmt.Println("Element not found")
+	}
+
+	// Example 5: Searching for non-existent element
+	target = 10
+	fmt.Printf("\nSearching for non-existent element %d:\n", target)
+	fmt.Printf("Linear Search: %d\n", searching.LinearSearch(arr, target))
+	fmt.Printf("Binary Search: %d\n", searching.BinarySearch(arr, target))
+	fmt.Printf("Jump Search: %d\n", searching.JumpSearch(arr, target))
+	fmt.Printf("Interpolation Search: %d\n", searching.InterpolationSearch(arr, target))
+}
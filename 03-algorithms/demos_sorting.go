@@ -0,0 +1,154 @@
+package main
+
+import (
+	"fmt"
+	"testing"
+	"time"
+
+	"github.com/NutProhmpiriya/go-basic/03-algorithms/sorting"
+	"github.com/NutProhmpiriya/go-basic/testdata/generator"
+)
+
+// runSorting demonstrates the sorting algorithms and measures how presortedness and duplicates affect them
+func runSorting() {
+	gen := generator.New(*seed)
+
+	// Example 1: Bubble Sort
+	fmt.Println("Example 1: Bubble Sort")
+	arr1 := gen.Ints(10, 100)
+	fmt.Printf("Original array: %v\n", arr1)
+	sorting.BubbleSort(arr1)
+	fmt.Printf("Sorted array: %v\n", arr1)
+	fmt.Printf("Is sorted? %v\n\n", sorting.IsSorted(arr1))
+
+	// Example 2: Quick Sort
+	fmt.Println("Example 2: Quick Sort")
+	arr2 := gen.Ints(10, 100)
+	fmt.Printf("Original array: %v\n", arr2)
+	sorting.QuickSort(arr2)
+	fmt.Printf("Sorted array: %v\n", arr2)
+	fmt.Printf("Is sorted? %v\n\n", sorting.IsSorted(arr2))
+
+	// Example 3: Merge Sort
+	fmt.Println("Example 3: Merge Sort")
+	arr3 := gen.Ints(10, 100)
+	fmt.Printf("Original array: %v\n", arr3)
+	arr3 = sorting.MergeSort(arr3)
+	fmt.Printf("Sorted array: %v\n", arr3)
+	fmt.Printf("Is sorted? %v\n\n", sorting.IsSorted(arr3))
+
+	// Example 4: Insertion Sort
+	fmt.Println("Example 4: Insertion Sort")
+	arr4 := gen.Ints(10, 100)
+	fmt.Printf("Original array: %v\n", arr4)
+	sorting.InsertionSort(arr4)
+	fmt.Printf("Sorted array: %v\n", arr4)
+	fmt.Printf("Is sorted? %v\n", sorting.IsSorted(arr4))
+
+	// Example 5: Three-way partitioning
+	fmt.Println("\nExample 5: Three-way Partitioning")
+	colors := []int{2, 0, 2, 1, 1, 0, 0, 2, 1}
+	fmt.Printf("Colors: %v\n", colors)
+	sorting.SortColors(colors)
+	fmt.Printf("Sorted colors: %v\n", colors)
+
+	arr5 := []int{5, 1, 8, 5, 3, 5, 9, 2, 5}
+	lt, gt := sorting.ThreeWayPartition(arr5, 5)
+	fmt.Printf("Partition around 5: %v < %v < %v\n", arr5[:lt], arr5[lt:gt], arr5[gt:])
+
+	isEven := func(x int) bool { return x%2 == 0 }
+	arr6 := []int{1, 2, 3, 4, 5, 6, 7, 8}
+	n := sorting.PartitionFunc(arr6, isEven)
+	fmt.Printf("PartitionFunc (evens first): %v, %v\n", arr6[:n], arr6[n:])
+	arr7 := []int{1, 2, 3, 4, 5, 6, 7, 8}
+	n = sorting.StablePartition(arr7, isEven)
+	fmt.Printf("StablePartition (evens first, order kept): %v, %v\n", arr7[:n], arr7[n:])
+
+	// Example 6: Presortedness measures
+	fmt.Println("\nExample 6: Measuring presortedness")
+	for _, sample := range []struct {
+		name string
+		arr  []int
+	}{
+		{"sorted", []int{1, 2, 3, 4, 5, 6}},
+		{"one swap", []int{1, 2, 5, 4, 3, 6}},
+		{"reversed", []int{6, 5, 4, 3, 2, 1}},
+	} {
+		fmt.Printf("%-9s %v: runs=%d inversions=%d natural merge sort=%v\n", sample.name, sample.arr,
+			sorting.CountRuns(sample.arr), sorting.CountInversions(sample.arr), sorting.NaturalMergeSort(sample.arr))
+	}
+}
+
+// sortingTimings benchmarks the sorts on inputs that favour or defeat them
+func sortingTimings() {
+	gen := generator.New(*seed)
+
+	// Example 7: Benchmark on input with many duplicates
+	// Two-way partitioning puts every duplicate of the pivot on one side,
+	// so it slows down dramatically as the number of distinct values shrinks
+	fmt.Println("Example 7: QuickSort on 10,000 elements with 10 distinct values")
+	input := gen.FewUnique(10000, 10)
+	work := make([]int, len(input))
+	twoWay := testing.Benchmark(func(b *testing.B) {
+		for i := 0; i < b.N; i++ {
+			copy(work, input)
+			sorting.LomutoQuickSort(work)
+		}
+	})
+	threeWay := testing.Benchmark(func(b *testing.B) {
+		for i := 0; i < b.N; i++ {
+			copy(work, input)
+			sorting.QuickSort(work)
+		}
+	})
+	fmt.Printf("Two-way partitioning:   %v/op\n", time.Duration(twoWay.NsPerOp()))
+	fmt.Printf("Three-way partitioning: %v/op\n", time.Duration(threeWay.NsPerOp()))
+
+	// Example 8: Adaptive vs non-adaptive merge sort
+	// MergeSort always does O(n log n) work; NaturalMergeSort does O(n log r)
+	fmt.Println("\nExample 8: MergeSort vs NaturalMergeSort")
+	for _, size := range []int{10000, 100000, 1000000} {
+		for _, input := range []struct {
+			name string
+			arr  []int
+		}{
+			{"nearly sorted", gen.NearlySorted(size, 10)},
+			{"random", gen.Ints(size, size)},
+		} {
+			mergeResult := testing.Benchmark(func(b *testing.B) {
+				for i := 0; i < b.N; i++ {
+					sorting.MergeSort(input.arr)
+				}
+			})
+			naturalResult := testing.Benchmark(func(b *testing.B) {
+				for i := 0; i < b.N; i++ {
+					sorting.NaturalMergeSort(input.arr)
+				}
+			})
+			fmt.Printf("n=%-8d %-14s runs=%-7d MergeSort: %-13v NaturalMergeSort: %v\n",
+				size, input.name, sorting.CountRuns(input.arr),
+				time.Duration(mergeResult.NsPerOp()), time.Duration(naturalResult.NsPerOp()))
+		}
+	}
+	// Example 9: Adversarial input
+	// A quicksort is only O(n log n) on average: an input built against its
+	// pivot rule makes every partition split off a single element
+	fmt.Println("\nExample 9: QuickSort on a random permutation vs a middle-pivot killer")
+	const killerSize = 5000
+	for _, input := range []struct {
+		name string
+		arr  []int
+	}{
+		{"random", gen.Perm(killerSize)},
+		{"killer", generator.MiddlePivotKiller(killerSize)},
+	} {
+		work := make([]int, killerSize)
+		result := testing.Benchmark(func(b *testing.B) {
+			for i := 0; i < b.N; i++ {
+				copy(work, input.arr)
+				sorting.QuickSort(work)
+			}
+		})
+		fmt.Printf("n=%d %-7s QuickSort: %v\n", killerSize, input.name, time.Duration(result.NsPerOp()))
+	}
+}
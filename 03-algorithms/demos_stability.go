@@ -0,0 +1,102 @@
+package main
+
+import (
+	"fmt"
+	"slices"
+	"strings"
+
+	"github.com/NutProhmpiriya/go-basic/03-algorithms/sorting"
+	"github.com/NutProhmpiriya/go-basic/ordering"
+)
+
+// player is a record with two keys to sort by
+type player struct {
+	name  string
+	score int
+}
+
+func (p player) String() string {
+	return fmt.Sprintf("%-5s %3d", p.name, p.score)
+}
+
+// byScore puts the higher scores first; players with the same score are
+// equal to it, whatever their names
+var byScore = ordering.By(func(p player) int { return p.score }).Reversed()
+
+var byName = ordering.By(func(p player) string { return p.name })
+
+// inNameOrder reports whether every group of equal scores in ps is still
+// in alphabetical order
+func inNameOrder(ps []player) bool {
+	for i := 1; i < len(ps); i++ {
+		if ps[i-1].score == ps[i].score && ps[i-1].name > ps[i].name {
+			return false
+		}
+	}
+	return true
+}
+
+// printColumns prints slices of players side by side under their titles,
+// marking with * the players that are out of alphabetical order among those
+// with the same score
+func printColumns(titles []string, columns ...[]player) {
+	row := func(cells []string) {
+		line := ""
+		for _, cell := range cells {
+			line += fmt.Sprintf("  %-18s", cell)
+		}
+		fmt.Println(strings.TrimRight(line, " "))
+	}
+	row(titles)
+	for i := range columns[0] {
+		cells := make([]string, len(columns))
+		for c, col := range columns {
+			mark := ""
+			if i+1 < len(col) && col[i].score == col[i+1].score && col[i].name > col[i+1].name ||
+				i > 0 && col[i-1].score == col[i].score && col[i-1].name > col[i].name {
+				mark = " *"
+			}
+			cells[c] = col[i].String() + mark
+		}
+		row(cells)
+	}
+}
+
+// runStability shows what a stable sort keeps that an unstable one loses
+func runStability() {
+	// Example 1: sorting records by one key
+	// The players are in alphabetical order, and several share a score
+	players := []player{
+		{"Ava", 90}, {"Ben", 85}, {"Cleo", 90}, {"Dan", 70},
+		{"Eve", 85}, {"Finn", 90}, {"Gus", 70}, {"Hana", 85},
+	}
+	fmt.Println("Example 1: Sorting players by score only")
+	merged := sorting.MergeSortFunc(players, byScore)
+	quick := slices.Clone(players)
+	sorting.QuickSortFunc(quick, byScore)
+	printColumns([]string{"input (by name)", "MergeSortFunc", "QuickSortFunc"}, players, merged, quick)
+	fmt.Printf("Equal scores still in name order? MergeSortFunc: %v, QuickSortFunc: %v\n",
+		inNameOrder(merged), inNameOrder(quick))
+	fmt.Println("(* marks players out of name order among equal scores)")
+
+	// Example 2: sorting by several keys
+	// A stable sort can sort by the least important key first and then by
+	// the most important one; each pass keeps the order of the one before
+	// among its ties. An unstable sort only gets it right with a single
+	// comparison that looks at every key
+	fmt.Println("\nExample 2: Score first, then name, from an unsorted list")
+	arrivals := []player{
+		{"Gus", 70}, {"Cleo", 90}, {"Hana", 85}, {"Ava", 90},
+		{"Eve", 85}, {"Dan", 70}, {"Finn", 90}, {"Ben", 85},
+	}
+	stablePasses := sorting.MergeSortFunc(sorting.MergeSortFunc(arrivals, byName), byScore)
+	unstablePasses := slices.Clone(arrivals)
+	sorting.QuickSortFunc(unstablePasses, byName)
+	sorting.QuickSortFunc(unstablePasses, byScore)
+	oneComparison := slices.Clone(arrivals)
+	sorting.QuickSortFunc(oneComparison, byScore.ThenComparing(byName))
+	printColumns([]string{"merge: name,score", "quick: name,score", "quick: then name"},
+		stablePasses, unstablePasses, oneComparison)
+	fmt.Printf("Correct? two stable passes: %v, two unstable passes: %v, one combined comparison: %v\n",
+		slices.Equal(stablePasses, oneComparison), slices.Equal(unstablePasses, oneComparison), inNameOrder(oneComparison))
+}
@@ -0,0 +1,94 @@
+package main
+
+import (
+	"fmt"
+	"math/rand"
+	"sort"
+	"time"
+
+	"github.com/NutProhmpiriya/go-basic/03-algorithms/stats"
+)
+
+// runStreamingStats demonstrates the streaming estimators
+func runStreamingStats() {
+	rng := rand.New(rand.NewSource(42))
+
+	// Example 1: Welford's algorithm vs the naive formula
+	fmt.Println("Example 1: Running mean and variance")
+	samples := []float64{}
+	var running stats.RunningStats
+	for i := 0; i < 1000; i++ {
+		// Large offset with a small spread: variance should be about 1
+		x := 1e9 + rng.NormFloat64()
+		samples = append(samples, x)
+		running.Add(x)
+	}
+	fmt.Printf("Count: %d, Mean: %.4f, StdDev: %.4f\n", running.Count(), running.Mean(), running.StdDev())
+	fmt.Printf("Welford variance: %.4f\n", running.Variance())
+	fmt.Printf("Naive variance:   %.4f (precision lost)\n", stats.NaiveVariance(samples))
+
+	// Example 2: Merging partial results
+	fmt.Println("\nExample 2: Merging statistics from two workers")
+	var left, right stats.RunningStats
+	for i, x := range samples {
+		if i%2 == 0 {
+			left.Add(x)
+		} else {
+			right.Add(x)
+		}
+	}
+	left.Merge(right)
+	fmt.Printf("Merged mean: %.4f, variance: %.4f\n", left.Mean(), left.Variance())
+
+	// Example 3: P² percentile estimates vs exact values
+	fmt.Println("\nExample 3: P² percentile estimation (exponential distribution)")
+	expSamples := make([]float64, 100000)
+	median, p90, p99 := stats.NewP2Quantile(0.5), stats.NewP2Quantile(0.9), stats.NewP2Quantile(0.99)
+	for i := range expSamples {
+		x := rng.ExpFloat64()
+		expSamples[i] = x
+		median.Add(x)
+		p90.Add(x)
+		p99.Add(x)
+	}
+	fmt.Printf("p50: estimate %.4f, exact %.4f\n", median.Value(), stats.ExactQuantile(expSamples, 0.5))
+	fmt.Printf("p90: estimate %.4f, exact %.4f\n", p90.Value(), stats.ExactQuantile(expSamples, 0.9))
+	fmt.Printf("p99: estimate %.4f, exact %.4f\n", p99.Value(), stats.ExactQuantile(expSamples, 0.99))
+
+	// Example 4: Histogram of a simulation
+	// Sum of 10 dice rolls, repeated 100,000 times
+	fmt.Println("\nExample 4: Histogram of the sum of 10 dice")
+	dice := stats.NewSummary(10, 61, 17)
+	for i := 0; i < 100000; i++ {
+		sum := 0
+		for j := 0; j < 10; j++ {
+			sum += rng.Intn(6) + 1
+		}
+		dice.Add(float64(sum))
+	}
+	fmt.Print(dice.Histogram)
+	fmt.Printf("Mean: %.2f (expected 35), StdDev: %.2f (expected 5.40)\n",
+		dice.Stats.Mean(), dice.Stats.StdDev())
+}
+
+// streamingStatsTimings summarizes real timings with the streaming estimators
+func streamingStatsTimings() {
+	fmt.Println("Example 5: Benchmark harness (sorting 10,000 ints, 200 runs)")
+	rng := rand.New(rand.NewSource(42))
+	input := make([]int, 10000)
+	for i := range input {
+		input[i] = rng.Int()
+	}
+	work := make([]int, len(input))
+	// Timings up to 5ms in 20 buckets; slower runs count as overflow
+	timings := stats.NewSummary(0, 5000, 20)
+	for range 200 {
+		start := time.Now()
+		copy(work, input)
+		sort.Ints(work)
+		timings.Add(float64(time.Since(start).Microseconds()))
+	}
+	fmt.Printf("mean %.1fµs, stddev %.1fµs, min %.0fµs, p50 %.0fµs, p99 %.0fµs, max %.0fµs\n",
+		timings.Stats.Mean(), timings.Stats.StdDev(), timings.Stats.Min(),
+		timings.Median.Value(), timings.P99.Value(), timings.Stats.Max())
+}
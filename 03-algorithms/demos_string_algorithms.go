@@ -0,0 +1,41 @@
+package main
+
+import (
+	"fmt"
+
+	"github.com/NutProhmpiriya/go-basic/03-algorithms/stringalgo"
+)
+
+// runStringAlgorithms demonstrates string matching, edit distance and palindromes
+func runStringAlgorithms() {
+	// Example 1: KMP String Matching
+	text := "AABAACAADAABAAABAA"
+	pattern := "AABA"
+	fmt.Println("KMP String Matching:")
+	fmt.Printf("Text: %s\nPattern: %s\n", text, pattern)
+	matches := stringalgo.KMPSearch(text, pattern)
+	fmt.Printf("Pattern found at indices: %v\n\n", matches)
+
+	// Example 2: Rabin-Karp String Matching
+	text2 := "GEEKS FOR GEEKS"
+	pattern2 := "GEEK"
+	fmt.Println("Rabin-Karp String Matching:")
+	fmt.Printf("Text: %s\nPattern: %s\n", text2, pattern2)
+	matches2 := stringalgo.RabinKarp(text2, pattern2)
+	fmt.Printf("Pattern found at indices: %v\n\n", matches2)
+
+	// Example 3: Levenshtein Distance
+	str1 := "kitten"
+	str2 := "sitting"
+	fmt.Println("Levenshtein Distance:")
+	fmt.Printf("String 1: %s\nString 2: %s\n", str1, str2)
+	distance := stringalgo.LevenshteinDistance(str1, str2)
+	fmt.Printf("Edit distance: %d\n\n", distance)
+
+	// Example 4: Longest Palindromic Substring
+	text3 := "babad"
+	fmt.Println("Longest Palindromic Substring:")
+	fmt.Printf("Text: %s\n", text3)
+	palindrome := stringalgo.LongestPalindromicSubstring(text3)
+	fmt.Printf("Longest palindrome: %s\n", palindrome)
+}
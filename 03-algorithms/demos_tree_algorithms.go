@@ -0,0 +1,55 @@
+package main
+
+import (
+	"fmt"
+
+	"github.com/NutProhmpiriya/go-basic/02-data-structures/datastructures"
+	"github.com/NutProhmpiriya/go-basic/03-algorithms/treealgo"
+)
+
+// runTreeAlgorithms demonstrates the binary tree algorithms
+func runTreeAlgorithms() {
+	tree := &datastructures.BinaryTree{}
+	for _, v := range []int{8, 3, 10, 1, 6, 14, 4, 7, 13} {
+		tree.Insert(v)
+	}
+	fmt.Println("The tree:")
+	fmt.Print(tree.Layered())
+
+	// Example 1: Lowest common ancestor
+	// In a BST the values say which way to go, so no search is needed
+	fmt.Println("\nExample 1: Lowest Common Ancestor")
+	for _, pair := range [][2]int{{4, 7}, {1, 7}, {13, 14}, {3, 4}} {
+		lca := treealgo.LowestCommonAncestorBST(tree.Root, pair[0], pair[1])
+		fmt.Printf("LCA(%d, %d) = %d\n", pair[0], pair[1], lca.Value)
+	}
+
+	// Example 2: Diameter and shape
+	fmt.Println("\nExample 2: Diameter and Shape")
+	fmt.Println("Diameter (edges on the longest path):", treealgo.Diameter(tree.Root))
+	fmt.Println("Height-balanced?", treealgo.IsBalanced(tree.Root))
+	fmt.Println("Symmetric?", treealgo.IsSymmetric(tree.Root))
+
+	// Example 3: Kth smallest with an inorder walk that stops early
+	fmt.Println("\nExample 3: Kth Smallest")
+	for _, k := range []int{1, 3, 9, 10} {
+		if v, err := treealgo.KthSmallest(tree.Root, k); err != nil {
+			fmt.Printf("k=%d: %v\n", k, err)
+		} else {
+			fmt.Printf("k=%d: %d\n", k, v)
+		}
+	}
+
+	// Example 4: Serialization round trip
+	fmt.Println("\nExample 4: Serialization")
+	s := treealgo.Serialize(tree.Root)
+	fmt.Println("Serialized:", s)
+	root, err := treealgo.Deserialize(s)
+	fmt.Println("Same tree after Deserialize?", err == nil && treealgo.Serialize(root) == s)
+
+	// Example 5: Inverting the tree mirrors it
+	fmt.Println("\nExample 5: Invert")
+	treealgo.Invert(tree.Root)
+	fmt.Print(tree.Layered())
+	fmt.Println("Inorder is now descending:", tree.InorderTraversal())
+}
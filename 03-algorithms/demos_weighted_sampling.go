@@ -0,0 +1,96 @@
+package main
+
+import (
+	"fmt"
+	"math/rand"
+
+	"github.com/NutProhmpiriya/go-basic/03-algorithms/sampling"
+)
+
+// runWeightedSampling demonstrates weighted sampling and its applications
+func runWeightedSampling() {
+	rng := rand.New(rand.NewSource(7))
+
+	// Example 1: Alias method
+	fmt.Println("Example 1: Alias method sampling")
+	weights := []float64{1, 2, 3, 4}
+	table, err := sampling.NewAliasTable(weights, rng)
+	if err != nil {
+		fmt.Println("Error:", err)
+		return
+	}
+	const trials = 100000
+	counts := make([]int, len(weights))
+	for i := 0; i < trials; i++ {
+		counts[table.Sample()]++
+	}
+	expected := sampling.ExpectedCounts(weights, trials)
+	for i := range weights {
+		fmt.Printf("index %d (weight %.0f): observed %6d, expected %8.1f\n", i, weights[i], counts[i], expected[i])
+	}
+	stat := sampling.ChiSquared(counts, expected)
+	critical := sampling.ChiSquaredCritical(len(weights) - 1)
+	fmt.Printf("Chi-squared: %.2f (critical value %.2f) -> fits weights? %v\n", stat, critical, stat < critical)
+
+	// Example 2: Invalid input
+	fmt.Println("\nExample 2: Invalid weights")
+	for _, bad := range [][]float64{{}, {0, 0}, {1, -1}} {
+		_, err := sampling.NewAliasTable(bad, rng)
+		fmt.Printf("%v: %v\n", bad, err)
+	}
+
+	// Example 3: Weighted reservoir sampling over a stream
+	fmt.Println("\nExample 3: Weighted reservoir sampling (k = 3)")
+	stream := []struct {
+		name   string
+		weight float64
+	}{
+		{"alpha", 1}, {"beta", 10}, {"gamma", 1}, {"delta", 5},
+		{"epsilon", 1}, {"zeta", 20}, {"eta", 1}, {"theta", 0},
+	}
+	reservoir := sampling.NewWeightedReservoir(3, rng)
+	for _, item := range stream {
+		reservoir.Add(item.name, item.weight)
+	}
+	fmt.Printf("Sample: %v\n", reservoir.Items())
+
+	// With k = 1, A-Res picks each item with probability weight / total
+	streamWeights := make([]float64, len(stream)-1)
+	index := make(map[string]int)
+	for i, item := range stream[:len(stream)-1] {
+		streamWeights[i] = item.weight
+		index[item.name] = i
+	}
+	reservoirCounts := make([]int, len(streamWeights))
+	for i := 0; i < trials; i++ {
+		r := sampling.NewWeightedReservoir(1, rng)
+		for _, item := range stream {
+			r.Add(item.name, item.weight)
+		}
+		reservoirCounts[index[r.Items()[0]]]++
+	}
+	stat = sampling.ChiSquared(reservoirCounts, sampling.ExpectedCounts(streamWeights, trials))
+	critical = sampling.ChiSquaredCritical(len(streamWeights) - 1)
+	fmt.Printf("k=1 chi-squared over %d runs: %.2f (critical value %.2f) -> fits weights? %v\n",
+		trials, stat, critical, stat < critical)
+
+	// Example 4: Weighted load balancer
+	fmt.Println("\nExample 4: Weighted load balancer")
+	servers := []string{"server-a", "server-b", "server-c", "server-d"}
+	capacities := []float64{5, 3, 1, 1}
+	lb, _ := sampling.NewLoadBalancer(servers, capacities, rng)
+	routed := make(map[string]int)
+	for i := 0; i < 10000; i++ {
+		routed[lb.Next()]++
+	}
+	for i, server := range servers {
+		fmt.Printf("%s (capacity %.0f): %d requests\n", server, capacities[i], routed[server])
+	}
+
+	// Example 5: Markov chain text generator
+	fmt.Println("\nExample 5: Markov chain text generator")
+	corpus := `the cat sat on the mat and the dog sat on the rug
+		the cat saw the dog and the dog saw the cat on the mat`
+	chain := sampling.NewMarkovChain(corpus, rng)
+	fmt.Println(chain.Generate("the", 12))
+}
@@ -5,11 +5,8 @@
 // 2. The solution to the problem can be constructed from solutions to its subproblems
 // 3. The subproblems overlap
 
-package main
-
-import (
-	"fmt"
-)
+// Package dp solves classic dynamic programming problems
+package dp
 
 // FibonacciRecursive calculates the nth Fibonacci number using recursion
 // Time Complexity: O(2^n)
@@ -133,34 +130,3 @@ func min(a, b int) int {
 	}
 	return b
 }
-
-func main() {
-	// Example 1: Fibonacci Numbers
-	n := 10
-	fmt.Printf("Fibonacci(%d) using recursion: %d\n", n, FibonacciRecursive(n))
-	fmt.Printf("Fibonacci(%d) using DP: %d\n\n", n, FibonacciDP(n))
-
-	// Example 2: Longest Common Subsequence
-	text1 := "abcde"
-	text2 := "ace"
-	lcs := LongestCommonSubsequence(text1, text2)
-	fmt.Printf("Length of Longest Common Subsequence between '%s' and '%s': %d\n\n", 
-		text1, text2, lcs)
-
-	// Example 3: 0/1 Knapsack Problem
-	values := []int{60, 100, 120}    // Values of items
-	weights := []int{10, 20, 30}     // Weights of items
-	capacity := 50                    // Knapsack capacity
-	maxValue := KnapsackProblem(values, weights, capacity)
-	fmt.Printf("Maximum value in Knapsack: %d\n\n", maxValue)
-
-	// Example 4: Coin Change Problem
-	coins := []int{1, 2, 5}
-	amount := 11
-	minCoins := CoinChange(coins, amount)
-	if minCoins != -1 {
-		fmt.Printf("Minimum coins needed for amount %d: %d\n", amount, minCoins)
-	} else {
-		fmt.Printf("Cannot make amount %d with given coins\n", amount)
-	}
-}
@@ -0,0 +1,190 @@
+package dp
+
+import (
+	"testing"
+	"testing/quick"
+)
+
+func TestFibonacci(t *testing.T) {
+	want := []int{0, 1, 1, 2, 3, 5, 8, 13, 21, 34, 55}
+	for n, w := range want {
+		if got := FibonacciRecursive(n); got != w {
+			t.Errorf("FibonacciRecursive(%d) = %d, want %d", n, got, w)
+		}
+		if got := FibonacciDP(n); got != w {
+			t.Errorf("FibonacciDP(%d) = %d, want %d", n, got, w)
+		}
+	}
+	if got := FibonacciDP(90); got != 2880067194370816120 {
+		t.Errorf("FibonacciDP(90) = %d, want 2880067194370816120", got)
+	}
+}
+
+// Property: the two implementations agree (n is kept small for the recursive one)
+func TestFibonacciProperty(t *testing.T) {
+	property := func(n uint8) bool {
+		n %= 25
+		return FibonacciRecursive(int(n)) == FibonacciDP(int(n))
+	}
+	if err := quick.Check(property, nil); err != nil {
+		t.Error(err)
+	}
+}
+
+func TestLongestCommonSubsequence(t *testing.T) {
+	tests := []struct {
+		a, b string
+		want int
+	}{
+		{"", "", 0},
+		{"abc", "", 0},
+		{"abcde", "ace", 3},
+		{"abc", "abc", 3},
+		{"abc", "def", 0},
+		{"AGGTAB", "GXTXAYB", 4},
+	}
+	for _, tt := range tests {
+		if got := LongestCommonSubsequence(tt.a, tt.b); got != tt.want {
+			t.Errorf("LongestCommonSubsequence(%q, %q) = %d, want %d", tt.a, tt.b, got, tt.want)
+		}
+	}
+}
+
+func TestLongestCommonSubsequenceProperties(t *testing.T) {
+	lcs := LongestCommonSubsequence
+	symmetry := func(a, b string) bool {
+		return lcs(a, b) == lcs(b, a)
+	}
+	self := func(a string) bool {
+		return lcs(a, a) == len(a)
+	}
+	bounded := func(a, b string) bool {
+		return lcs(a, b) <= min(len(a), len(b))
+	}
+	// Appending the same byte to both strings extends the LCS by exactly one
+	extend := func(a, b string, c byte) bool {
+		suffix := string([]byte{c})
+		return lcs(a+suffix, b+suffix) == lcs(a, b)+1
+	}
+	for name, property := range map[string]any{
+		"symmetry": symmetry,
+		"self":     self,
+		"bounded":  bounded,
+		"extend":   extend,
+	} {
+		if err := quick.Check(property, nil); err != nil {
+			t.Errorf("%s: %v", name, err)
+		}
+	}
+}
+
+// bruteForceKnapsack tries every subset of items
+func bruteForceKnapsack(values, weights []int, capacity int) int {
+	best := 0
+	for mask := 0; mask < 1<<len(values); mask++ {
+		value, weight := 0, 0
+		for i := range values {
+			if mask&(1<<i) != 0 {
+				value += values[i]
+				weight += weights[i]
+			}
+		}
+		if weight <= capacity {
+			best = max(best, value)
+		}
+	}
+	return best
+}
+
+func TestKnapsackProblem(t *testing.T) {
+	tests := []struct {
+		values, weights []int
+		capacity        int
+		want            int
+	}{
+		{[]int{60, 100, 120}, []int{10, 20, 30}, 50, 220},
+		{[]int{60, 100, 120}, []int{10, 20, 30}, 0, 0},
+		{[]int{}, []int{}, 10, 0},
+		{[]int{10}, []int{5}, 4, 0},
+		{[]int{10}, []int{5}, 5, 10},
+	}
+	for _, tt := range tests {
+		if got := KnapsackProblem(tt.values, tt.weights, tt.capacity); got != tt.want {
+			t.Errorf("KnapsackProblem(%v, %v, %d) = %d, want %d",
+				tt.values, tt.weights, tt.capacity, got, tt.want)
+		}
+	}
+
+	property := func(rawValues, rawWeights [8]uint8, rawCapacity uint8) bool {
+		values, weights := make([]int, 8), make([]int, 8)
+		for i := range values {
+			values[i] = int(rawValues[i])
+			weights[i] = int(rawWeights[i]%20) + 1
+		}
+		capacity := int(rawCapacity % 60)
+		return KnapsackProblem(values, weights, capacity) == bruteForceKnapsack(values, weights, capacity)
+	}
+	if err := quick.Check(property, nil); err != nil {
+		t.Error(err)
+	}
+}
+
+// bfsCoinChange counts coins level by level: the first level that reaches
+// amount uses the fewest coins
+func bfsCoinChange(coins []int, amount int) int {
+	seen := map[int]bool{0: true}
+	level := []int{0}
+	for count := 0; len(level) > 0; count++ {
+		var next []int
+		for _, total := range level {
+			if total == amount {
+				return count
+			}
+			for _, c := range coins {
+				if sum := total + c; sum <= amount && !seen[sum] {
+					seen[sum] = true
+					next = append(next, sum)
+				}
+			}
+		}
+		level = next
+	}
+	return -1
+}
+
+func TestCoinChange(t *testing.T) {
+	tests := []struct {
+		coins  []int
+		amount int
+		want   int
+	}{
+		{[]int{1, 2, 5}, 11, 3},
+		{[]int{2}, 3, -1},
+		{[]int{1}, 0, 0},
+		{[]int{}, 7, -1},
+		{[]int{1, 3, 4}, 6, 2}, // Greedy would pick 4+1+1
+	}
+	for _, tt := range tests {
+		if got := CoinChange(tt.coins, tt.amount); got != tt.want {
+			t.Errorf("CoinChange(%v, %d) = %d, want %d", tt.coins, tt.amount, got, tt.want)
+		}
+	}
+
+	property := func(rawCoins []uint8, rawAmount uint8) bool {
+		coins := make([]int, len(rawCoins))
+		for i, c := range rawCoins {
+			coins[i] = int(c%25) + 1
+		}
+		amount := int(rawAmount)
+		return CoinChange(coins, amount) == bfsCoinChange(coins, amount)
+	}
+	if err := quick.Check(property, nil); err != nil {
+		t.Error(err)
+	}
+}
+
+func TestMinMax(t *testing.T) {
+	if min(1, 2) != 1 || min(2, 1) != 1 || max(1, 2) != 2 || max(2, 1) != 2 {
+		t.Error("min/max helpers returned the wrong value")
+	}
+}
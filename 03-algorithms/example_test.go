@@ -0,0 +1,468 @@
+package main
+
+// The examples run every demo and compare what it prints with the Output
+// comment, so a demo that stops printing what it claims fails go test.
+// The -seed flag defaults to 1, so the generated inputs are the same as in
+// go run ./03-algorithms. Timings are not checked, they differ on every run
+
+func Example_sorting() {
+	runSorting()
+	// Output:
+	// Example 1: Bubble Sort
+	// Original array: [81 87 47 59 81 18 25 40 56 0]
+	// Sorted array: [0 18 25 40 47 56 59 81 81 87]
+	// Is sorted? true
+	//
+	// Example 2: Quick Sort
+	// Original array: [94 11 62 89 28 74 11 45 37 6]
+	// Sorted array: [6 11 11 28 37 45 62 74 89 94]
+	// Is sorted? true
+	//
+	// Example 3: Merge Sort
+	// Original array: [95 66 28 58 47 47 87 88 90 15]
+	// Sorted array: [15 28 47 47 58 66 87 88 90 95]
+	// Is sorted? true
+	//
+	// Example 4: Insertion Sort
+	// Original array: [41 8 87 31 29 56 37 31 85 26]
+	// Sorted array: [8 26 29 31 31 37 41 56 85 87]
+	// Is sorted? true
+	//
+	// Example 5: Three-way Partitioning
+	// Colors: [2 0 2 1 1 0 0 2 1]
+	// Sorted colors: [0 0 0 1 1 1 2 2 2]
+	// Partition around 5: [1 3 2] < [5 5 5 5] < [9 8]
+	// PartitionFunc (evens first): [8 2 6 4], [5 3 7 1]
+	// StablePartition (evens first, order kept): [2 4 6 8], [1 3 5 7]
+	//
+	// Example 6: Measuring presortedness
+	// sorted    [1 2 3 4 5 6]: runs=1 inversions=0 natural merge sort=[1 2 3 4 5 6]
+	// one swap  [1 2 5 4 3 6]: runs=3 inversions=3 natural merge sort=[1 2 3 4 5 6]
+	// reversed  [6 5 4 3 2 1]: runs=6 inversions=15 natural merge sort=[1 2 3 4 5 6]
+}
+
+func Example_stability() {
+	runStability()
+	// Output:
+	// Example 1: Sorting players by score only
+	//   input (by name)     MergeSortFunc       QuickSortFunc
+	//   Ava    90           Ava    90           Ava    90
+	//   Ben    85           Cleo   90           Cleo   90
+	//   Cleo   90           Finn   90           Finn   90
+	//   Dan    70           Ben    85           Hana   85 *
+	//   Eve    85           Eve    85           Eve    85 *
+	//   Finn   90           Hana   85           Ben    85 *
+	//   Gus    70           Dan    70           Gus    70 *
+	//   Hana   85           Gus    70           Dan    70 *
+	// Equal scores still in name order? MergeSortFunc: true, QuickSortFunc: false
+	// (* marks players out of name order among equal scores)
+	//
+	// Example 2: Score first, then name, from an unsorted list
+	//   merge: name,score   quick: name,score   quick: then name
+	//   Ava    90           Ava    90           Ava    90
+	//   Cleo   90           Cleo   90           Cleo   90
+	//   Finn   90           Finn   90           Finn   90
+	//   Ben    85           Hana   85 *         Ben    85
+	//   Eve    85           Eve    85 *         Eve    85
+	//   Hana   85           Ben    85 *         Hana   85
+	//   Dan    70           Gus    70 *         Dan    70
+	//   Gus    70           Dan    70 *         Gus    70
+	// Correct? two stable passes: true, two unstable passes: false, one combined comparison: true
+}
+
+func Example_searching() {
+	runSearching()
+	// Output:
+	// Searching for 13 in array: [1 3 5 7 9 11 13 15 17 19]
+	//
+	// Example 1: Linear Search
+	// Element found at index: 6
+	//
+	// Example 2: Binary Search
+	// Element found at index: 6
+	//
+	// Example 3: Jump Search
+	// Element found at index: 6
+	//
+	// Example 4: Interpolation Search
+	// Element found at index: 6
+	//
+	// Searching for non-existent element 10:
+	// Linear Search: -1
+	// Binary Search: -1
+	// Jump Search: -1
+	// Interpolation Search: -1
+}
+
+func Example_strings() {
+	runStringAlgorithms()
+	// Output:
+	// KMP String Matching:
+	// Text: AABAACAADAABAAABAA
+	// Pattern: AABA
+	// Pattern found at indices: [0 9 13]
+	//
+	// Rabin-Karp String Matching:
+	// Text: GEEKS FOR GEEKS
+	// Pattern: GEEK
+	// Pattern found at indices: [0 10]
+	//
+	// Levenshtein Distance:
+	// String 1: kitten
+	// String 2: sitting
+	// Edit distance: 3
+	//
+	// Longest Palindromic Substring:
+	// Text: babad
+	// Longest palindrome: bab
+}
+
+func Example_dp() {
+	runDynamicProgramming()
+	// Output:
+	// Fibonacci(10) using recursion: 55
+	// Fibonacci(10) using DP: 55
+	//
+	// Length of Longest Common Subsequence between 'abcde' and 'ace': 3
+	//
+	// Maximum value in Knapsack: 220
+	//
+	// Minimum coins needed for amount 11: 3
+}
+
+func Example_greedy() {
+	runGreedy()
+	// Output:
+	// Activity Selection Problem:
+	// Selected activities: [{Start:1 End:4} {Start:5 End:7} {Start:8 End:11} {Start:12 End:16}]
+	//
+	// Fractional Knapsack Problem:
+	// Maximum value: 240.00
+	//
+	// Huffman Coding:
+	// Huffman tree root frequency: 39
+	//
+	// Dijkstra's Shortest Path:
+	// Shortest distances from vertex 0: [0 3 1 4 7]
+}
+
+func Example_trees() {
+	runTreeAlgorithms()
+	// Output:
+	// The tree:
+	//           8
+	//   ┌───────┴─┐
+	//   3         10
+	// ┌─┴───┐     └─────┐
+	// 1     6           14
+	//     ┌─┴─┐      ┌──┘
+	//     4   7      13
+	//
+	// Example 1: Lowest Common Ancestor
+	// LCA(4, 7) = 6
+	// LCA(1, 7) = 3
+	// LCA(13, 14) = 14
+	// LCA(3, 4) = 3
+	//
+	// Example 2: Diameter and Shape
+	// Diameter (edges on the longest path): 6
+	// Height-balanced? false
+	// Symmetric? false
+	//
+	// Example 3: Kth Smallest
+	// k=1: 1
+	// k=3: 4
+	// k=9: 14
+	// k=10: k is out of range: the tree has fewer nodes
+	//
+	// Example 4: Serialization
+	// Serialized: 8,3,1,#,#,6,4,#,#,7,#,#,10,#,14,13,#,#,#
+	// Same tree after Deserialize? true
+	//
+	// Example 5: Invert
+	//          8
+	//       ┌──┴───────┐
+	//       10         3
+	// ┌─────┘      ┌───┴─┐
+	// 14           6     1
+	// └──┐       ┌─┴─┐
+	//    13      7   4
+	// Inorder is now descending: [14 13 10 8 7 6 4 3 1]
+}
+
+func Example_lists() {
+	runListAlgorithms()
+	// Output:
+	// Example 1: Merge Sorted Lists
+	// a: 1 -> 4 -> 6 -> nil
+	// b: 2 -> 3 -> 5 -> 7 -> nil
+	// merged: 1 -> 2 -> 3 -> 4 -> 5 -> 6 -> 7 -> nil
+	// a and b are now empty: 0 0
+	//
+	// Example 2: Merge Sort
+	// before: 5 -> 2 -> 9 -> 1 -> 5 -> 6 -> 3 -> nil
+	// after:  1 -> 2 -> 3 -> 5 -> 5 -> 6 -> 9 -> nil
+	//
+	// Example 3: Intersection
+	// first:  1 -> 2 -> 7 -> 8 -> 9 -> nil
+	// second: 4 -> 7 -> 8 -> 9 -> nil
+	// They join at the node holding 7
+	// Lists with equal values but separate nodes: <nil>
+	//
+	// Example 4: Palindrome Check
+	// [1 2 3 2 1] palindrome? true
+	// [1 2 2 1] palindrome? true
+	// [1 2 3] palindrome? false
+	//
+	// Example 5: Reorder
+	// before: 1 -> 2 -> 3 -> 4 -> 5 -> 6 -> nil
+	// after:  1 -> 6 -> 2 -> 5 -> 3 -> 4 -> nil
+}
+
+func Example_heaps() {
+	runHeapAlgorithms()
+	// Output:
+	// Example 1: K-way Merge
+	// list 0: [1 4 9]
+	// list 1: [2 6]
+	// list 2: [3 5 7 8]
+	// list 3: []
+	// merged: [1 2 3 4 5 6 7 8 9]
+	//
+	// Example 2: Running Median
+	// before any values: no values
+	// add 12 -> median 12.0
+	// add  4 -> median 8.0
+	// add  5 -> median 5.0
+	// add  3 -> median 4.5
+	// add  8 -> median 5.0
+	// add  7 -> median 6.0
+	//
+	// Example 3: Top-k Frequent
+	// status codes: [404 200 200 500 200 404 301 404 200]
+	// top 1: [200]
+	// top 2: [200 404]
+	// top 10: [200 404 301 500]
+}
+
+func Example_intmath() {
+	runIntegerMath()
+	// Output:
+	// Example 1: Greatest Common Divisor
+	// gcd(48, 18): Euclid=6 Binary=6
+	// gcd(1071, 462): Euclid=21 Binary=21
+	// gcd(17, 5): Euclid=1 Binary=1
+	// gcd(0, 9): Euclid=9 Binary=9
+	// gcd(1099511627776, 1048576): Euclid=1048576 Binary=1048576
+	//
+	// Example 2: Integer Square Root
+	// isqrt(0) = 0
+	// isqrt(1) = 1
+	// isqrt(15) = 3
+	// isqrt(16) = 4
+	// isqrt(17) = 4
+	// isqrt(1000000) = 1000
+	// isqrt(18446744073709551615) = 4294967295
+	//
+	// Example 3: Overflow-safe Arithmetic
+	// MaxInt64 + 1 overflows
+	// MinInt64 * -1 overflows
+	// 2^31 * 2^32 fits? checked=false naive=true
+	// SaturatingAdd(MaxInt64, 10) = 9223372036854775807
+	// SaturatingSub(MinInt64, 10) = -9223372036854775808
+	// SaturatingMul(-1<<40, 1<<40) = -9223372036854775808
+	//
+	// Example 4: Bit Tricks
+	// n=  0 isPow2=false nextPow2=  1 lowestBit= 0 popcount=0
+	// n=  1 isPow2=true  nextPow2=  1 lowestBit= 1 popcount=1
+	// n=  6 isPow2=false nextPow2=  8 lowestBit= 2 popcount=2
+	// n= 64 isPow2=true  nextPow2= 64 lowestBit=64 popcount=1
+	// n=100 isPow2=false nextPow2=128 lowestBit= 4 popcount=3
+	//
+	// Example 5: Verifying against naive versions
+	// All results match? true
+}
+
+func Example_stats() {
+	runStreamingStats()
+	// Output:
+	// Example 1: Running mean and variance
+	// Count: 1000, Mean: 1000000000.0377, StdDev: 0.9506
+	// Welford variance: 0.9036
+	// Naive variance:   -2230.4545 (precision lost)
+	//
+	// Example 2: Merging statistics from two workers
+	// Merged mean: 1000000000.0377, variance: 0.9036
+	//
+	// Example 3: P² percentile estimation (exponential distribution)
+	// p50: estimate 0.6912, exact 0.6912
+	// p90: estimate 2.3144, exact 2.3109
+	// p99: estimate 4.6231, exact 4.5983
+	//
+	// Example 4: Histogram of the sum of 10 dice
+	// [   10.00,    13.00)      0
+	// [   13.00,    16.00)      3
+	// [   16.00,    19.00)     62
+	// [   19.00,    22.00)    430
+	// [   22.00,    25.00)   2035 ###
+	// [   25.00,    28.00)   5906 ##########
+	// [   28.00,    31.00)  12186 ######################
+	// [   31.00,    34.00)  18520 ##################################
+	// [   34.00,    37.00)  21631 ########################################
+	// [   37.00,    40.00)  18917 ##################################
+	// [   40.00,    43.00)  12008 ######################
+	// [   43.00,    46.00)   5851 ##########
+	// [   46.00,    49.00)   1916 ###
+	// [   49.00,    52.00)    467
+	// [   52.00,    55.00)     64
+	// [   55.00,    58.00)      4
+	// [   58.00,    61.00)      0
+	// underflow: 0, overflow: 0
+	// Mean: 34.99 (expected 35), StdDev: 5.40 (expected 5.40)
+}
+
+func Example_sampling() {
+	runWeightedSampling()
+	// Output:
+	// Example 1: Alias method sampling
+	// index 0 (weight 1): observed   9835, expected  10000.0
+	// index 1 (weight 2): observed  19832, expected  20000.0
+	// index 2 (weight 3): observed  30032, expected  30000.0
+	// index 3 (weight 4): observed  40301, expected  40000.0
+	// Chi-squared: 6.43 (critical value 16.55) -> fits weights? true
+	//
+	// Example 2: Invalid weights
+	// []: alias table needs at least one weight
+	// [0 0]: weights must not all be zero
+	// [1 -1]: invalid weight -1
+	//
+	// Example 3: Weighted reservoir sampling (k = 3)
+	// Sample: [zeta epsilon beta]
+	// k=1 chi-squared over 100000 runs: 7.86 (critical value 22.67) -> fits weights? true
+	//
+	// Example 4: Weighted load balancer
+	// server-a (capacity 5): 5069 requests
+	// server-b (capacity 3): 2981 requests
+	// server-c (capacity 1): 958 requests
+	// server-d (capacity 1): 992 requests
+	//
+	// Example 5: Markov chain text generator
+	// the dog saw the dog sat on the dog and the mat
+}
+
+func Example_buildResolver() {
+	// The default -deps path is relative to the repository root
+	*depsFile = "data/build_deps.txt"
+	runBuildResolver()
+	// Output:
+	// Example 1: Dependencies in data/build_deps.txt
+	// api        -> [auth storage logging config]
+	// app        -> [api worker cli]
+	// auth       -> [crypto storage]
+	// cli        -> [config logging]
+	// config     -> []
+	// crypto     -> []
+	// logging    -> [config]
+	// queue      -> [config]
+	// storage    -> [config logging]
+	// worker     -> [queue storage logging]
+	//
+	// Example 2: Checking for cycles
+	// No cycles found
+	//
+	// Example 3: Build order
+	// config -> crypto -> logging -> queue -> cli -> storage -> auth -> worker -> api -> app
+	//
+	// Example 4: Parallel build schedule
+	// Step 1: [config crypto]
+	// Step 2: [logging queue]
+	// Step 3: [cli storage]
+	// Step 4: [auth worker]
+	// Step 5: [api]
+	// Step 6: [app]
+	// Sequential steps: 10, parallel steps: 6, max parallelism: 2
+}
+
+func Example_roadNetwork() {
+	// The default -roads path is relative to the repository root
+	*roadsFile = "data/roads.geojson"
+	runRoadNetwork()
+	// Output:
+	// Example 1: Roads in data/roads.geojson
+	// 29 cities, 39 roads, 3857.1 km of road
+	//
+	// Example 2: Shortest distances from Bangkok (Dijkstra)
+	//   Nonthaburi           12.1 km
+	//   Nakhon Pathom        48.9 km
+	//   Chachoengsao         63.9 km
+	//   Ayutthaya            67.9 km
+	//   Chon Buri            69.5 km
+	//   Ratchaburi           91.8 km
+	//   Saraburi            110.4 km
+	//   Kanchanaburi        111.6 km
+	//   Prachin Buri        116.0 km
+	//   Pattaya             120.3 km
+	//   Phetchaburi         141.0 km
+	//   Lop Buri            152.0 km
+	//   Rayong              153.1 km
+	//   Hua Hin             202.5 km
+	//   Nakhon Sawan        228.0 km
+	//   Nakhon Ratchasima   247.9 km
+	//   Phitsanulok         355.0 km
+	//   Buriram             358.0 km
+	//   Tak                 400.9 km
+	//   Sukhothai           407.5 km
+	//   Khon Kaen           430.3 km
+	//   Roi Et              530.1 km
+	//   Udon Thani          541.3 km
+	//   Ubon Ratchathani    551.1 km
+	//   Lampang             556.8 km
+	//   Nong Khai           594.1 km
+	//   Chiang Mai          635.2 km
+	//   Chiang Rai          743.9 km
+	//
+	// Example 3: Routes by Dijkstra and A*
+	// Dijkstra   635.2 km, 27 cities settled: Bangkok -> Ayutthaya -> Nakhon Sawan -> Phitsanulok -> Sukhothai -> Lampang -> Chiang Mai
+	// A*         635.2 km, 11 cities settled: Bangkok -> Ayutthaya -> Nakhon Sawan -> Phitsanulok -> Sukhothai -> Lampang -> Chiang Mai
+	// Dijkstra   551.1 km, 24 cities settled: Bangkok -> Chachoengsao -> Prachin Buri -> Nakhon Ratchasima -> Buriram -> Ubon Ratchathani
+	// A*         551.1 km,  9 cities settled: Bangkok -> Chachoengsao -> Prachin Buri -> Nakhon Ratchasima -> Buriram -> Ubon Ratchathani
+	// Dijkstra   202.5 km, 14 cities settled: Bangkok -> Nakhon Pathom -> Ratchaburi -> Phetchaburi -> Hua Hin
+	// A*         202.5 km,  5 cities settled: Bangkok -> Nakhon Pathom -> Ratchaburi -> Phetchaburi -> Hua Hin
+	//
+	// Example 4: Minimum spanning tree (Kruskal)
+	// 28 of 39 roads, 2291.6 of 3857.1 km, connect all 29 cities
+	// Longest roads kept:
+	//   Nakhon Ratchasima - Khon Kaen 182.4 km
+	//   Ubon Ratchathani - Roi Et 159.5 km
+	//   Chiang Mai - Chiang Rai 156.5 km
+	//
+	// Example 5: The edge list in data/roads.csv, without locations
+	// 29 cities, 39 roads, 3856.8 km of road
+	// A*         635.1 km, 27 cities settled: Bangkok -> Ayutthaya -> Nakhon Sawan -> Phitsanulok -> Sukhothai -> Lampang -> Chiang Mai
+}
+
+func Example_parallelGraph() {
+	runParallelGraph()
+	// Output:
+	// Example 1: BFS from vertex 0 of a random graph, 100000 vertices and 200000 edges
+	//   level  0:      1 vertices
+	//   level  1:      6 vertices
+	//   level  2:     25 vertices
+	//   level  3:    109 vertices
+	//   level  4:    427 vertices
+	//   level  5:   1643 vertices
+	//   level  6:   6252 vertices
+	//   level  7:  20487 vertices
+	//   level  8:  39636 vertices
+	//   level  9:  25104 vertices
+	//   level 10:   4023 vertices
+	//   level 11:    369 vertices
+	//   level 12:     34 vertices
+	//   level 13:      3 vertices
+	//   unreachable: 1881 vertices
+	// Same distances as the sequential BFS: true
+	//
+	// Example 2: Connected components
+	// 1813 components, the largest with 98119 vertices
+	// Same labels as the sequential version: true
+}
@@ -0,0 +1,214 @@
+// This file implements a build dependency resolver in Go
+// It is an applied example of DAG (directed acyclic graph) algorithms:
+// packages are vertices and "A depends on B" is an edge from A to B
+//
+// Steps:
+// 1. Parse a dependency file into an adjacency list
+// 2. Detect cycles with a depth-first search and report the cycle path
+// 3. Compute a build order with a topological sort (Kahn's algorithm)
+// 4. Group packages into levels that can be built in parallel
+//
+// Time Complexity: O(V + E) for every step
+// where V is the number of packages and E the number of dependencies
+//
+// Try it with the runner from the repository root:
+//   go run ./03-algorithms -demo=build-resolver                  # uses 03-algorithms/data/build_deps.txt
+//   go run ./03-algorithms -demo=build-resolver -deps 03-algorithms/data/cyclic_deps.txt
+
+// Package graph applies graph algorithms to real problems: resolving build
+// dependencies with DAG algorithms, and shortest paths and spanning trees
+// on weighted graphs such as road networks
+package graph
+
+import (
+	"bufio"
+	"fmt"
+	"io"
+	"sort"
+	"strings"
+)
+
+// DependencyGraph maps every package to the packages it depends on
+type DependencyGraph struct {
+	deps map[string][]string
+}
+
+// CycleError reports a dependency cycle
+// Path starts and ends with the same package, e.g. [a b c a]
+type CycleError struct {
+	Path []string
+}
+
+func (e *CycleError) Error() string {
+	return "dependency cycle: " + strings.Join(e.Path, " -> ")
+}
+
+// ParseDependencies reads lines of the form "target: dep1 dep2"
+// Blank lines and lines starting with # are ignored
+// Time Complexity: O(V + E)
+func ParseDependencies(r io.Reader) (*DependencyGraph, error) {
+	g := &DependencyGraph{deps: make(map[string][]string)}
+	scanner := bufio.NewScanner(r)
+	lineNumber := 0
+
+	for scanner.Scan() {
+		lineNumber++
+		line := strings.TrimSpace(scanner.Text())
+		if line == "" || strings.HasPrefix(line, "#") {
+			continue
+		}
+
+		target, depList, found := strings.Cut(line, ":")
+		target = strings.TrimSpace(target)
+		if !found || target == "" {
+			return nil, fmt.Errorf("line %d: expected \"target: deps...\", got %q", lineNumber, line)
+		}
+
+		g.addPackage(target)
+		for _, dep := range strings.Fields(depList) {
+			g.addPackage(dep)
+			g.deps[target] = append(g.deps[target], dep)
+		}
+	}
+
+	if err := scanner.Err(); err != nil {
+		return nil, err
+	}
+	return g, nil
+}
+
+// addPackage makes sure a package exists even if it has no dependencies
+func (g *DependencyGraph) addPackage(name string) {
+	if _, exists := g.deps[name]; !exists {
+		g.deps[name] = []string{}
+	}
+}
+
+// Packages returns all package names in sorted order
+// Sorting keeps the output deterministic despite map iteration order
+func (g *DependencyGraph) Packages() []string {
+	names := make([]string, 0, len(g.deps))
+	for name := range g.deps {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+	return names
+}
+
+// Deps returns the direct dependencies of a package, in file order
+func (g *DependencyGraph) Deps(name string) []string {
+	return g.deps[name]
+}
+
+// FindCycle returns the first dependency cycle found, or nil
+// Uses DFS with three colors:
+// - white: not visited yet
+// - gray: on the current DFS path
+// - black: fully explored
+// Reaching a gray package means we followed an edge back into the current path
+// Time Complexity: O(V + E)
+func (g *DependencyGraph) FindCycle() *CycleError {
+	const (
+		white = iota
+		gray
+		black
+	)
+	color := make(map[string]int)
+	path := []string{}
+
+	var visit func(pkg string) *CycleError
+	visit = func(pkg string) *CycleError {
+		color[pkg] = gray
+		path = append(path, pkg)
+
+		for _, dep := range g.deps[pkg] {
+			switch color[dep] {
+			case gray:
+				// Cut the path at the first occurrence of dep to get just the cycle
+				for i, p := range path {
+					if p == dep {
+						cycle := append(append([]string{}, path[i:]...), dep)
+						return &CycleError{Path: cycle}
+					}
+				}
+			case white:
+				if err := visit(dep); err != nil {
+					return err
+				}
+			}
+		}
+
+		path = path[:len(path)-1]
+		color[pkg] = black
+		return nil
+	}
+
+	for _, pkg := range g.Packages() {
+		if color[pkg] == white {
+			if err := visit(pkg); err != nil {
+				return err
+			}
+		}
+	}
+	return nil
+}
+
+// BuildLevels groups packages into levels using Kahn's algorithm
+// Level 0 has no dependencies; every package in level i only depends on
+// packages in earlier levels, so each level can be built fully in parallel
+// Time Complexity: O(V + E)
+func (g *DependencyGraph) BuildLevels() ([][]string, error) {
+	if err := g.FindCycle(); err != nil {
+		return nil, err
+	}
+
+	// remaining[p] is the number of dependencies of p not built yet
+	// dependents[d] lists the packages waiting for d
+	remaining := make(map[string]int)
+	dependents := make(map[string][]string)
+	for _, pkg := range g.Packages() {
+		remaining[pkg] = len(g.deps[pkg])
+		for _, dep := range g.deps[pkg] {
+			dependents[dep] = append(dependents[dep], pkg)
+		}
+	}
+
+	current := []string{}
+	for _, pkg := range g.Packages() {
+		if remaining[pkg] == 0 {
+			current = append(current, pkg)
+		}
+	}
+
+	levels := [][]string{}
+	for len(current) > 0 {
+		levels = append(levels, current)
+		next := []string{}
+		for _, pkg := range current {
+			for _, dependent := range dependents[pkg] {
+				remaining[dependent]--
+				if remaining[dependent] == 0 {
+					next = append(next, dependent)
+				}
+			}
+		}
+		sort.Strings(next)
+		current = next
+	}
+
+	return levels, nil
+}
+
+// BuildOrder returns a valid sequential build order (a topological order)
+// Time Complexity: O(V + E)
+func (g *DependencyGraph) BuildOrder() ([]string, error) {
+	levels, err := g.BuildLevels()
+	if err != nil {
+		return nil, err
+	}
+	order := []string{}
+	for _, level := range levels {
+		order = append(order, level...)
+	}
+	return order, nil
+}
@@ -0,0 +1,240 @@
+package graph
+
+import (
+	"errors"
+	"fmt"
+	"os"
+	"slices"
+	"strings"
+	"testing"
+	"testing/quick"
+)
+
+func parse(t *testing.T, input string) *DependencyGraph {
+	t.Helper()
+	g, err := ParseDependencies(strings.NewReader(input))
+	if err != nil {
+		t.Fatalf("ParseDependencies: %v", err)
+	}
+	return g
+}
+
+func TestParseDependencies(t *testing.T) {
+	g := parse(t, `
+# comment
+app: lib util
+lib: util
+
+util:
+`)
+	want := map[string][]string{
+		"app":  {"lib", "util"},
+		"lib":  {"util"},
+		"util": {},
+	}
+	if got := g.Packages(); !slices.Equal(got, []string{"app", "lib", "util"}) {
+		t.Errorf("Packages() = %v, want [app lib util]", got)
+	}
+	for pkg, deps := range want {
+		if !slices.Equal(g.deps[pkg], deps) {
+			t.Errorf("deps[%s] = %v, want %v", pkg, g.deps[pkg], deps)
+		}
+	}
+
+	// A dependency that is never listed as a target still becomes a package
+	g = parse(t, "a: b")
+	if got := g.Packages(); !slices.Equal(got, []string{"a", "b"}) {
+		t.Errorf("Packages() = %v, want [a b]", got)
+	}
+}
+
+func TestParseDependenciesErrors(t *testing.T) {
+	for _, input := range []string{"no colon here", ": missing target", "ok: a\n  : b"} {
+		if _, err := ParseDependencies(strings.NewReader(input)); err == nil {
+			t.Errorf("ParseDependencies(%q) returned no error", input)
+		} else if !strings.Contains(err.Error(), "line ") {
+			t.Errorf("error %q does not mention the line number", err)
+		}
+	}
+}
+
+func TestFindCycle(t *testing.T) {
+	tests := []struct {
+		name  string
+		input string
+		want  []string // nil when there is no cycle
+	}{
+		{"acyclic", "a: b c\nb: c\nc:", nil},
+		{"self loop", "a: a", []string{"a", "a"}},
+		{"two nodes", "a: b\nb: a", []string{"a", "b", "a"}},
+		{"cycle after a tail", "a: b\nb: c\nc: d\nd: b", []string{"b", "c", "d", "b"}},
+		{"diamond", "a: b c\nb: d\nc: d\nd:", nil},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			cycle := parse(t, tt.input).FindCycle()
+			if tt.want == nil {
+				if cycle != nil {
+					t.Errorf("FindCycle() = %v, want nil", cycle)
+				}
+				return
+			}
+			if cycle == nil || !slices.Equal(cycle.Path, tt.want) {
+				t.Errorf("FindCycle() = %v, want path %v", cycle, tt.want)
+			}
+		})
+	}
+}
+
+func TestCycleErrorMessage(t *testing.T) {
+	err := &CycleError{Path: []string{"a", "b", "a"}}
+	if got, want := err.Error(), "dependency cycle: a -> b -> a"; got != want {
+		t.Errorf("Error() = %q, want %q", got, want)
+	}
+}
+
+func TestBuildLevels(t *testing.T) {
+	g := parse(t, "app: api cli\napi: lib\ncli: lib\nlib:")
+	levels, err := g.BuildLevels()
+	want := [][]string{{"lib"}, {"api", "cli"}, {"app"}}
+	if err != nil || !slices.EqualFunc(levels, want, slices.Equal) {
+		t.Errorf("BuildLevels() = %v, %v; want %v, nil", levels, err, want)
+	}
+
+	order, err := g.BuildOrder()
+	if wantOrder := []string{"lib", "api", "cli", "app"}; err != nil || !slices.Equal(order, wantOrder) {
+		t.Errorf("BuildOrder() = %v, %v; want %v, nil", order, err, wantOrder)
+	}
+
+	empty := parse(t, "")
+	if levels, err := empty.BuildLevels(); err != nil || len(levels) != 0 {
+		t.Errorf("empty graph: BuildLevels() = %v, %v; want no levels", levels, err)
+	}
+}
+
+func TestBuildOrderCycle(t *testing.T) {
+	g := parse(t, "a: b\nb: a")
+	var cycle *CycleError
+	if _, err := g.BuildOrder(); !errors.As(err, &cycle) {
+		t.Errorf("BuildOrder() error = %v, want a *CycleError", err)
+	}
+	if _, err := g.BuildLevels(); !errors.As(err, &cycle) {
+		t.Errorf("BuildLevels() error = %v, want a *CycleError", err)
+	}
+}
+
+func TestDataFiles(t *testing.T) {
+	tests := []struct {
+		file      string
+		wantCycle bool
+	}{
+		{"../data/build_deps.txt", false},
+		{"../data/cyclic_deps.txt", true},
+	}
+	for _, tt := range tests {
+		f, err := os.Open(tt.file)
+		if err != nil {
+			t.Fatal(err)
+		}
+		g, err := ParseDependencies(f)
+		f.Close()
+		if err != nil {
+			t.Fatalf("%s: %v", tt.file, err)
+		}
+		if cycle := g.FindCycle(); (cycle != nil) != tt.wantCycle {
+			t.Errorf("%s: FindCycle() = %v, want cycle: %v", tt.file, cycle, tt.wantCycle)
+		}
+	}
+}
+
+// randomGraph builds a graph from raw edges over n packages
+// With acyclic set, edges only point from a higher to a lower index,
+// which can never form a cycle
+func randomGraph(raw [][2]uint8, n int, acyclic bool) *DependencyGraph {
+	var sb strings.Builder
+	for i := 0; i < n; i++ {
+		fmt.Fprintf(&sb, "p%d:\n", i)
+	}
+	for _, e := range raw {
+		from, to := int(e[0])%n, int(e[1])%n
+		if acyclic && from <= to {
+			continue
+		}
+		fmt.Fprintf(&sb, "p%d: p%d\n", from, to)
+	}
+	g, _ := ParseDependencies(strings.NewReader(sb.String()))
+	return g
+}
+
+// Property: on a DAG every package appears once and after all its
+// dependencies, and every package sits one level above its deepest dependency
+func TestBuildOrderProperty(t *testing.T) {
+	property := func(raw [][2]uint8) bool {
+		g := randomGraph(raw, 12, true)
+		if g.FindCycle() != nil {
+			return false
+		}
+		order, err := g.BuildOrder()
+		if err != nil || len(order) != 12 {
+			return false
+		}
+		position := make(map[string]int)
+		for i, pkg := range order {
+			position[pkg] = i
+		}
+		for pkg, deps := range g.deps {
+			for _, dep := range deps {
+				if position[dep] >= position[pkg] {
+					return false
+				}
+			}
+		}
+
+		levels, _ := g.BuildLevels()
+		level := make(map[string]int)
+		for i, pkgs := range levels {
+			for _, pkg := range pkgs {
+				level[pkg] = i
+			}
+		}
+		for pkg, deps := range g.deps {
+			want := 0
+			for _, dep := range deps {
+				want = max(want, level[dep]+1)
+			}
+			if level[pkg] != want {
+				return false
+			}
+		}
+		return true
+	}
+	if err := quick.Check(property, nil); err != nil {
+		t.Error(err)
+	}
+}
+
+// Property: any reported cycle is a real path of dependency edges, and a
+// graph without a reported cycle can be ordered
+func TestFindCycleProperty(t *testing.T) {
+	property := func(raw [][2]uint8) bool {
+		g := randomGraph(raw, 6, false)
+		cycle := g.FindCycle()
+		if cycle == nil {
+			_, err := g.BuildOrder()
+			return err == nil
+		}
+		path := cycle.Path
+		if len(path) < 2 || path[0] != path[len(path)-1] {
+			return false
+		}
+		for i := 0; i+1 < len(path); i++ {
+			if !slices.Contains(g.deps[path[i]], path[i+1]) {
+				return false
+			}
+		}
+		return true
+	}
+	if err := quick.Check(property, nil); err != nil {
+		t.Error(err)
+	}
+}
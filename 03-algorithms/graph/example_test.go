@@ -0,0 +1,43 @@
+package graph_test
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/NutProhmpiriya/go-basic/03-algorithms/graph"
+)
+
+func ExampleDependencyGraph_BuildLevels() {
+	g, err := graph.ParseDependencies(strings.NewReader(`
+app: lib util
+lib: util
+cli: util
+util:
+`))
+	if err != nil {
+		panic(err)
+	}
+	levels, _ := g.BuildLevels()
+	fmt.Println(levels)
+	// Output: [[util] [cli lib] [app]]
+}
+
+func ExampleDependencyGraph_FindCycle() {
+	g, _ := graph.ParseDependencies(strings.NewReader("a: b\nb: c\nc: a\n"))
+	fmt.Println(g.FindCycle())
+	// Output: dependency cycle: a -> b -> c -> a
+}
+
+func ExampleLoadEdgeCSV() {
+	g, err := graph.LoadEdgeCSV(strings.NewReader(`from,to,weight
+home,shop,2
+shop,school,1.5
+home,school,4
+`), false)
+	if err != nil {
+		panic(err)
+	}
+	p, _ := g.ShortestPath("home", "school")
+	fmt.Println(p.Vertices, p.Length)
+	// Output: [home shop school] 3.5
+}
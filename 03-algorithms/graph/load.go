@@ -0,0 +1,152 @@
+// This file loads a WeightedGraph from a dataset, so the shortest path and
+// spanning tree algorithms can run on more than a handful of vertices
+//
+// Two formats are supported:
+// - a CSV edge list, one edge per row under a header naming the columns:
+//
+//     from,to,weight
+//     Bangkok,Ayutthaya,76.4
+//
+// - GeoJSON, the format of most open map data: Point features are the
+//   vertices, with their name and location, and LineString features are the
+//   edges, with the names of their ends in "from" and "to". The weight of an
+//   edge is the length of its line in kilometres
+//
+// 03-algorithms/data/roads.geojson is a road network of Thai cities in the
+// second format, and roads.csv has the same roads as an edge list
+
+package graph
+
+import (
+	"encoding/csv"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"strconv"
+	"strings"
+)
+
+// LoadEdgeCSV reads a graph from a CSV edge list
+// The header must have the columns from, to and weight, in any order;
+// other columns, like a road's name, are ignored. Lines starting with #
+// are comments
+func LoadEdgeCSV(r io.Reader, directed bool) (*WeightedGraph, error) {
+	cr := csv.NewReader(r)
+	cr.Comment = '#'
+	cr.TrimLeadingSpace = true
+	header, err := cr.Read()
+	if err == io.EOF {
+		return nil, errors.New("csv: no header")
+	}
+	if err != nil {
+		return nil, err
+	}
+	column := make(map[string]int)
+	for i, name := range header {
+		column[strings.ToLower(strings.TrimSpace(name))] = i
+	}
+	for _, name := range []string{"from", "to", "weight"} {
+		if _, ok := column[name]; !ok {
+			return nil, fmt.Errorf("csv: the header has no %q column", name)
+		}
+	}
+
+	g := NewWeightedGraph(directed)
+	for {
+		record, err := cr.Read()
+		if err == io.EOF {
+			return g, nil
+		}
+		if err != nil {
+			return nil, err
+		}
+		line, _ := cr.FieldPos(0)
+		from, to := record[column["from"]], record[column["to"]]
+		if from == "" || to == "" {
+			return nil, fmt.Errorf("csv: line %d: an edge needs both ends", line)
+		}
+		weight, err := strconv.ParseFloat(record[column["weight"]], 64)
+		if err != nil {
+			return nil, fmt.Errorf("csv: line %d: weight %q is not a number", line, record[column["weight"]])
+		}
+		if err := g.AddEdge(from, to, weight); err != nil {
+			return nil, fmt.Errorf("csv: line %d: %w", line, err)
+		}
+	}
+}
+
+// featureCollection is the part of GeoJSON (RFC 7946) the loader reads
+// Positions are [longitude, latitude], the other way round from how
+// coordinates are usually written
+type featureCollection struct {
+	Type     string `json:"type"`
+	Features []struct {
+		Geometry struct {
+			Type        string          `json:"type"`
+			Coordinates json.RawMessage `json:"coordinates"`
+		} `json:"geometry"`
+		Properties struct {
+			Name string `json:"name"`
+			From string `json:"from"`
+			To   string `json:"to"`
+		} `json:"properties"`
+	} `json:"features"`
+}
+
+// LoadGeoJSON reads an undirected graph from a GeoJSON FeatureCollection
+// Every Point with a "name" property is a vertex, and every LineString
+// with "from" and "to" properties an edge between the named points, with
+// the length of the line in kilometres as its weight. Other features are
+// ignored
+func LoadGeoJSON(r io.Reader) (*WeightedGraph, error) {
+	var fc featureCollection
+	if err := json.NewDecoder(r).Decode(&fc); err != nil {
+		return nil, fmt.Errorf("geojson: %w", err)
+	}
+	if fc.Type != "FeatureCollection" {
+		return nil, fmt.Errorf("geojson: expected a FeatureCollection, got %q", fc.Type)
+	}
+
+	g := NewWeightedGraph(false)
+	// The points go first, so the lines can check that their ends exist
+	// whatever order the features are in
+	for i, f := range fc.Features {
+		if f.Geometry.Type != "Point" || f.Properties.Name == "" {
+			continue
+		}
+		var pos []float64
+		if err := json.Unmarshal(f.Geometry.Coordinates, &pos); err != nil || len(pos) < 2 {
+			return nil, fmt.Errorf("geojson: feature %d: bad Point coordinates", i)
+		}
+		if _, ok := g.Location(f.Properties.Name); ok {
+			return nil, fmt.Errorf("geojson: feature %d: duplicate point %q", i, f.Properties.Name)
+		}
+		g.Locate(f.Properties.Name, Point{Lat: pos[1], Lon: pos[0]})
+	}
+	for i, f := range fc.Features {
+		if f.Geometry.Type != "LineString" || f.Properties.From == "" && f.Properties.To == "" {
+			continue
+		}
+		var line [][]float64
+		if err := json.Unmarshal(f.Geometry.Coordinates, &line); err != nil || len(line) < 2 {
+			return nil, fmt.Errorf("geojson: feature %d: bad LineString coordinates", i)
+		}
+		length := 0.0
+		for j := 1; j < len(line); j++ {
+			if len(line[j-1]) < 2 || len(line[j]) < 2 {
+				return nil, fmt.Errorf("geojson: feature %d: bad LineString coordinates", i)
+			}
+			length += Haversine(Point{Lat: line[j-1][1], Lon: line[j-1][0]}, Point{Lat: line[j][1], Lon: line[j][0]})
+		}
+		for _, end := range []string{f.Properties.From, f.Properties.To} {
+			if _, ok := g.Location(end); !ok {
+				return nil, fmt.Errorf("geojson: feature %d: %w %q", i, ErrUnknownVertex, end)
+			}
+		}
+		if err := g.AddEdge(f.Properties.From, f.Properties.To, length); err != nil {
+			return nil, fmt.Errorf("geojson: feature %d: %w", i, err)
+		}
+	}
+	return g, nil
+}
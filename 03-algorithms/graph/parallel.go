@@ -0,0 +1,273 @@
+// This file implements breadth-first search and connected components for
+// graphs too big for one core, with sequential versions to compare against
+//
+// The graphs are Adjacency lists over the vertices 0..n-1, the compact form
+// a graph with millions of edges needs; a map per vertex, like
+// datastructures.Graph uses, would take several times the memory
+//
+// - ParallelBFS is level-synchronous: the vertices at distance d, the
+//   frontier, are split among worker goroutines, which find the vertices
+//   at distance d+1 together. A vertex is claimed with a compare-and-swap
+//   on its distance, so exactly one worker adds it to the next frontier.
+//   The workers meet after every level, which costs little when the graph
+//   has few levels, like most real networks
+// - ParallelComponents runs a union-find over the edges from all workers
+//   at once. A root is only ever linked under a smaller root, with a
+//   compare-and-swap, so the workers never undo each other's links and the
+//   root of every component ends up its smallest vertex
+//
+// Time Complexity: O(V + E) work for all four, split over the workers by
+// the parallel versions
+
+package graph
+
+import (
+	"runtime"
+	"sync"
+	"sync/atomic"
+)
+
+// Adjacency is a graph of the vertices 0..len-1: Adjacency[v] lists the
+// neighbours of v
+type Adjacency [][]int
+
+// NewAdjacency returns the undirected graph of n vertices with the given
+// edges
+// Time Complexity: O(V + E)
+func NewAdjacency(n int, edges [][2]int) Adjacency {
+	// Counting the degrees first lets every list be a slice of one array,
+	// rather than V slices grown by append
+	degree := make([]int, n)
+	for _, e := range edges {
+		degree[e[0]]++
+		if e[0] != e[1] {
+			degree[e[1]]++
+		}
+	}
+	all := make([]int, 0, 2*len(edges))
+	adj := make(Adjacency, n)
+	for v, d := range degree {
+		adj[v] = all[len(all) : len(all) : len(all)+d]
+		all = all[:len(all)+d]
+	}
+	for _, e := range edges {
+		adj[e[0]] = append(adj[e[0]], e[1])
+		if e[0] != e[1] {
+			adj[e[1]] = append(adj[e[1]], e[0])
+		}
+	}
+	return adj
+}
+
+// workerCount returns the number of goroutines to use for workers, where 0
+// or less means one per CPU
+func workerCount(workers int) int {
+	if workers <= 0 {
+		return runtime.GOMAXPROCS(0)
+	}
+	return workers
+}
+
+// BFS returns the distance of every vertex from start, or -1 for the
+// vertices it can't reach
+// The distances are int32 because that is what ParallelBFS can update
+// atomically, and it halves their memory
+// Time Complexity: O(V + E)
+func (a Adjacency) BFS(start int) []int32 {
+	dist := make([]int32, len(a))
+	for i := range dist {
+		dist[i] = -1
+	}
+	dist[start] = 0
+	queue := []int{start}
+	for i := 0; i < len(queue); i++ {
+		u := queue[i]
+		for _, v := range a[u] {
+			if dist[v] == -1 {
+				dist[v] = dist[u] + 1
+				queue = append(queue, v)
+			}
+		}
+	}
+	return dist
+}
+
+// chunk is the number of vertices a worker takes at a time. Handing out
+// small chunks from a shared counter, rather than one big slice per
+// worker, keeps all of them busy when a few vertices have most of the edges
+const chunk = 256
+
+// ParallelBFS returns the same distances as BFS, computed by workers
+// goroutines (0 for one per CPU)
+func (a Adjacency) ParallelBFS(start, workers int) []int32 {
+	workers = workerCount(workers)
+	dist := make([]int32, len(a))
+	for i := range dist {
+		dist[i] = -1
+	}
+	dist[start] = 0
+	frontier := []int{start}
+	next := make([][]int, workers)
+	for level := int32(1); len(frontier) > 0; level++ {
+		// A small frontier isn't worth starting goroutines for
+		if len(frontier) < chunk {
+			var found []int
+			for _, u := range frontier {
+				for _, v := range a[u] {
+					if dist[v] == -1 {
+						dist[v] = level
+						found = append(found, v)
+					}
+				}
+			}
+			frontier = found
+			continue
+		}
+
+		var taken atomic.Int64
+		var wg sync.WaitGroup
+		for w := range workers {
+			wg.Add(1)
+			go func() {
+				defer wg.Done()
+				found := next[w][:0]
+				for {
+					lo := int(taken.Add(chunk)) - chunk
+					if lo >= len(frontier) {
+						break
+					}
+					for _, u := range frontier[lo:min(lo+chunk, len(frontier))] {
+						for _, v := range a[u] {
+							// The load skips the vertices that are claimed
+							// already without the cost of a failed swap
+							if atomic.LoadInt32(&dist[v]) == -1 && atomic.CompareAndSwapInt32(&dist[v], -1, level) {
+								found = append(found, v)
+							}
+						}
+					}
+				}
+				next[w] = found
+			}()
+		}
+		wg.Wait()
+
+		size := 0
+		for _, found := range next {
+			size += len(found)
+		}
+		frontier = make([]int, 0, size)
+		for _, found := range next {
+			frontier = append(frontier, found...)
+		}
+	}
+	return dist
+}
+
+// Components labels every vertex with the smallest vertex of its connected
+// component, so two vertices are connected exactly when their labels are
+// equal
+// Time Complexity: O(V + E)
+func (a Adjacency) Components() []int32 {
+	label := make([]int32, len(a))
+	for i := range label {
+		label[i] = -1
+	}
+	var queue []int
+	// Going through the vertices in order, the first one of every
+	// component found is its smallest
+	for s := range a {
+		if label[s] != -1 {
+			continue
+		}
+		label[s] = int32(s)
+		queue = append(queue[:0], s)
+		for i := 0; i < len(queue); i++ {
+			for _, v := range a[queue[i]] {
+				if label[v] == -1 {
+					label[v] = int32(s)
+					queue = append(queue, v)
+				}
+			}
+		}
+	}
+	return label
+}
+
+// ParallelComponents returns the same labels as Components, computed by
+// workers goroutines (0 for one per CPU)
+func (a Adjacency) ParallelComponents(workers int) []int32 {
+	workers = workerCount(workers)
+	parent := make([]int32, len(a))
+	for i := range parent {
+		parent[i] = int32(i)
+	}
+	// each runs f on every vertex, split among the workers
+	each := func(f func(v int)) {
+		var taken atomic.Int64
+		var wg sync.WaitGroup
+		for range workers {
+			wg.Add(1)
+			go func() {
+				defer wg.Done()
+				for {
+					lo := int(taken.Add(chunk)) - chunk
+					if lo >= len(a) {
+						return
+					}
+					for v := lo; v < min(lo+chunk, len(a)); v++ {
+						f(v)
+					}
+				}
+			}()
+		}
+		wg.Wait()
+	}
+	each(func(u int) {
+		for _, v := range a[u] {
+			// Every undirected edge is in two lists; one union is enough
+			if u < v {
+				union(parent, int32(u), int32(v))
+			}
+		}
+	})
+	each(func(v int) {
+		atomic.StoreInt32(&parent[v], find(parent, int32(v)))
+	})
+	return parent
+}
+
+// find returns the root of x's tree in a union-find forest that other
+// goroutines may be changing, halving the path on the way
+// Parents only ever get smaller, so a stale read still points at a vertex
+// of the same tree
+func find(parent []int32, x int32) int32 {
+	for {
+		p := atomic.LoadInt32(&parent[x])
+		if p == x {
+			return x
+		}
+		gp := atomic.LoadInt32(&parent[p])
+		if gp != p {
+			atomic.CompareAndSwapInt32(&parent[x], p, gp)
+		}
+		x = gp
+	}
+}
+
+// union joins the trees of x and y by linking the larger root under the
+// smaller one. The link fails if another goroutine gave that root a parent
+// in the meantime, and then union starts over from the new roots
+func union(parent []int32, x, y int32) {
+	for {
+		x, y = find(parent, x), find(parent, y)
+		if x == y {
+			return
+		}
+		if x > y {
+			x, y = y, x
+		}
+		if atomic.CompareAndSwapInt32(&parent[y], y, x) {
+			return
+		}
+	}
+}
@@ -0,0 +1,95 @@
+package graph
+
+import (
+	"fmt"
+	"slices"
+	"sync"
+	"testing"
+
+	"github.com/NutProhmpiriya/go-basic/testdata/generator"
+)
+
+func TestNewAdjacency(t *testing.T) {
+	a := NewAdjacency(4, [][2]int{{0, 1}, {1, 2}, {2, 2}, {0, 1}})
+	want := Adjacency{{1, 1}, {0, 2, 0}, {1, 2}, {}}
+	for v := range want {
+		if !slices.Equal(a[v], want[v]) {
+			t.Errorf("neighbours of %d = %v, want %v", v, a[v], want[v])
+		}
+	}
+	// Appending to one list must not overwrite the next one
+	a[0] = append(a[0], 3)
+	if !slices.Equal(a[1], want[1]) {
+		t.Errorf("appending to a[0] changed a[1] to %v", a[1])
+	}
+}
+
+func TestBFSAndComponents(t *testing.T) {
+	// Two paths, 0-1-2-3 and 4-5, and the lone vertex 6
+	a := NewAdjacency(7, [][2]int{{2, 3}, {0, 1}, {1, 2}, {5, 4}})
+	if got, want := a.BFS(1), []int32{1, 0, 1, 2, -1, -1, -1}; !slices.Equal(got, want) {
+		t.Errorf("BFS(1) = %v, want %v", got, want)
+	}
+	if got, want := a.Components(), []int32{0, 0, 0, 0, 4, 4, 6}; !slices.Equal(got, want) {
+		t.Errorf("Components = %v, want %v", got, want)
+	}
+}
+
+// TestParallelAgreesWithSequential runs the parallel versions on random
+// graphs with several worker counts, from a few components to one giant
+// component, with the race detector in mind: go test -race
+func TestParallelAgreesWithSequential(t *testing.T) {
+	gen := generator.New(1)
+	for _, size := range []struct{ n, m int }{{1, 0}, {50, 20}, {2000, 1500}, {20000, 80000}} {
+		a := NewAdjacency(size.n, gen.Edges(size.n, size.m))
+		bfs, components := a.BFS(0), a.Components()
+		for _, workers := range []int{1, 3, 0} {
+			name := fmt.Sprintf("n=%d/m=%d/workers=%d", size.n, size.m, workers)
+			if got := a.ParallelBFS(0, workers); !slices.Equal(got, bfs) {
+				t.Errorf("%s: ParallelBFS differs from BFS", name)
+			}
+			if got := a.ParallelComponents(workers); !slices.Equal(got, components) {
+				t.Errorf("%s: ParallelComponents differs from Components", name)
+			}
+		}
+	}
+}
+
+// bigGraph is the graph of the benchmarks: a million vertices and four
+// million random edges, built once
+var bigGraph = sync.OnceValue(func() Adjacency {
+	const n = 1 << 20
+	return NewAdjacency(n, generator.New(1).Edges(n, 4*n))
+})
+
+func BenchmarkBFS(b *testing.B) {
+	a := bigGraph()
+	b.Run("sequential", func(b *testing.B) {
+		for range b.N {
+			a.BFS(0)
+		}
+	})
+	for _, workers := range []int{2, 4, 8} {
+		b.Run(fmt.Sprintf("workers=%d", workers), func(b *testing.B) {
+			for range b.N {
+				a.ParallelBFS(0, workers)
+			}
+		})
+	}
+}
+
+func BenchmarkComponents(b *testing.B) {
+	a := bigGraph()
+	b.Run("sequential", func(b *testing.B) {
+		for range b.N {
+			a.Components()
+		}
+	})
+	for _, workers := range []int{2, 4, 8} {
+		b.Run(fmt.Sprintf("workers=%d", workers), func(b *testing.B) {
+			for range b.N {
+				a.ParallelComponents(workers)
+			}
+		})
+	}
+}
@@ -0,0 +1,209 @@
+// This file implements shortest paths and minimum spanning trees on a
+// WeightedGraph
+//
+// - Dijkstra's algorithm settles the vertices in order of their distance
+//   from the start, taking the closest unsettled one from a heap
+// - A* orders the heap by the distance so far plus an estimate of the
+//   distance left, the straight line to the target, so it settles the
+//   vertices in the target's direction first and stops sooner
+// - Kruskal's algorithm builds a minimum spanning tree by taking the
+//   shortest edges first, skipping those that would close a cycle
+//
+// Time Complexity: O((V + E) log V) for Dijkstra and A*, O(E log E) for
+// Kruskal
+
+package graph
+
+import (
+	"errors"
+	"math"
+	"slices"
+
+	"github.com/NutProhmpiriya/go-basic/02-data-structures/datastructures"
+	"github.com/NutProhmpiriya/go-basic/ordering"
+)
+
+// ErrNoPath is returned when no path leads from one vertex to another
+var ErrNoPath = errors.New("no path")
+
+// Path is a shortest path found by ShortestPath or AStar
+type Path struct {
+	Vertices []string // from the start to the target, both included
+	Length   float64  // the sum of the weights along the path
+	Settled  int      // the vertices whose distance was final before the target's
+}
+
+// Distances returns the length of the shortest path from a vertex to every
+// vertex it reaches, with Dijkstra's algorithm
+// Time Complexity: O((V + E) log V)
+func (g *WeightedGraph) Distances(from string) (map[string]float64, error) {
+	start, err := g.lookup(from)
+	if err != nil {
+		return nil, err
+	}
+	dist, _, _ := g.search(start, -1, nil)
+	distances := make(map[string]float64)
+	for v, d := range dist {
+		if !math.IsInf(d, 1) {
+			distances[g.names[v]] = d
+		}
+	}
+	return distances, nil
+}
+
+// ShortestPath returns a shortest path between two vertices with Dijkstra's
+// algorithm, which stops once the target is settled
+// Time Complexity: O((V + E) log V)
+func (g *WeightedGraph) ShortestPath(from, to string) (Path, error) {
+	return g.path(from, to, false)
+}
+
+// AStar returns a shortest path between two vertices with the A* search.
+// The estimate of the distance left is the great-circle distance to the
+// target, which no road beats, so A* finds the same length as Dijkstra's
+// algorithm while settling fewer vertices. The estimate needs every vertex
+// on the map; without that AStar is Dijkstra's algorithm
+// Time Complexity: O((V + E) log V), usually far less
+func (g *WeightedGraph) AStar(from, to string) (Path, error) {
+	return g.path(from, to, true)
+}
+
+func (g *WeightedGraph) path(from, to string, astar bool) (Path, error) {
+	start, err := g.lookup(from)
+	if err != nil {
+		return Path{}, err
+	}
+	target, err := g.lookup(to)
+	if err != nil {
+		return Path{}, err
+	}
+	var estimate func(v int) float64
+	// An estimate of 0 for the vertices off the map could overestimate
+	// how much further their located neighbours are, so it is all or nothing
+	if astar && !slices.Contains(g.located, false) {
+		estimate = func(v int) float64 {
+			return Haversine(g.location[v], g.location[target])
+		}
+	}
+	dist, parent, settled := g.search(start, target, estimate)
+	if math.IsInf(dist[target], 1) {
+		return Path{}, ErrNoPath
+	}
+	var vertices []string
+	for v := target; v != start; v = parent[v] {
+		vertices = append(vertices, g.names[v])
+	}
+	vertices = append(vertices, g.names[start])
+	slices.Reverse(vertices)
+	return Path{Vertices: vertices, Length: dist[target], Settled: settled}, nil
+}
+
+// queued is a vertex waiting in the heap, with the priority it was pushed
+// with: its distance, plus the estimate for A*
+type queued struct {
+	v        int
+	priority float64
+}
+
+// search runs Dijkstra's algorithm from start, or A* with the estimate
+// if it isn't nil, until target is settled; a target of -1 settles every
+// vertex. It returns the distances, with +Inf for the vertices it didn't
+// reach, the vertex each one was reached from, and the number of vertices
+// settled before the target
+// A vertex is pushed again whenever a shorter way to it is found, instead
+// of decreasing its key in place; the stale entries are skipped when they
+// come out of the heap
+func (g *WeightedGraph) search(start, target int, estimate func(v int) float64) ([]float64, []int, int) {
+	if estimate == nil {
+		estimate = func(int) float64 { return 0 }
+	}
+	dist := make([]float64, len(g.names))
+	for i := range dist {
+		dist[i] = math.Inf(1)
+	}
+	parent := make([]int, len(g.names))
+	done := make([]bool, len(g.names))
+	dist[start] = 0
+	heap := datastructures.NewHeapFunc(ordering.By(func(q queued) float64 { return q.priority }),
+		queued{start, estimate(start)})
+	settled := 0
+	for !heap.IsEmpty() {
+		q, _ := heap.Pop()
+		if done[q.v] {
+			continue
+		}
+		if q.v == target {
+			break
+		}
+		done[q.v] = true
+		settled++
+		for _, a := range g.adj[q.v] {
+			if d := dist[q.v] + a.weight; d < dist[a.to] {
+				dist[a.to], parent[a.to] = d, q.v
+				heap.Push(queued{a.to, d + estimate(a.to)})
+			}
+		}
+	}
+	return dist, parent, settled
+}
+
+// MinimumSpanningTree returns the edges of a minimum spanning tree of an
+// undirected graph, the cheapest set of edges that keeps every vertex
+// connected to the ones it was connected to, with Kruskal's algorithm. A
+// graph in several pieces gets a tree for each piece, a spanning forest
+// Kruskal's algorithm takes the edges from the lightest up and keeps an
+// edge unless its ends are connected already. A disjoint-set forest
+// answers that in nearly constant time
+// Time Complexity: O(E log E) for sorting the edges
+func (g *WeightedGraph) MinimumSpanningTree() []WeightedEdge {
+	edges := g.Edges()
+	slices.SortStableFunc(edges, ordering.By(func(e WeightedEdge) float64 { return e.Weight }))
+	sets := newDisjointSets(len(g.names))
+	var tree []WeightedEdge
+	for _, e := range edges {
+		if sets.union(g.index[e.From], g.index[e.To]) {
+			tree = append(tree, e)
+		}
+	}
+	return tree
+}
+
+// disjointSets is a union-find forest: every set is a tree whose root
+// stands for the set
+type disjointSets struct {
+	parent []int
+	size   []int
+}
+
+func newDisjointSets(n int) *disjointSets {
+	s := &disjointSets{parent: make([]int, n), size: make([]int, n)}
+	for i := range s.parent {
+		s.parent[i], s.size[i] = i, 1
+	}
+	return s
+}
+
+// find returns the root of x's set, pointing every other vertex on the way
+// at its grandparent so later finds take fewer steps
+func (s *disjointSets) find(x int) int {
+	for s.parent[x] != x {
+		s.parent[x] = s.parent[s.parent[x]]
+		x = s.parent[x]
+	}
+	return x
+}
+
+// union merges the sets of x and y, hanging the smaller tree under the
+// larger, and reports whether they were different sets
+func (s *disjointSets) union(x, y int) bool {
+	x, y = s.find(x), s.find(y)
+	if x == y {
+		return false
+	}
+	if s.size[x] < s.size[y] {
+		x, y = y, x
+	}
+	s.parent[y] = x
+	s.size[x] += s.size[y]
+	return true
+}
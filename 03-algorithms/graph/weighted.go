@@ -0,0 +1,189 @@
+// This file implements a weighted graph for shortest paths and spanning
+// trees on real data, like a road network where the vertices are cities
+// and the edges are roads with their length in kilometres
+//
+// Vertices have names, and may have a location on the map. Dijkstra's
+// algorithm only needs the weights; A* also uses the locations to head
+// towards the target, and falls back to Dijkstra where they are missing
+//
+// See load.go for reading a graph from a CSV edge list or GeoJSON
+
+package graph
+
+import (
+	"errors"
+	"fmt"
+	"math"
+)
+
+// ErrUnknownVertex is returned for a vertex name that isn't in the graph
+var ErrUnknownVertex = errors.New("unknown vertex")
+
+// Point is a location on Earth in degrees
+type Point struct {
+	Lat, Lon float64
+}
+
+// earthRadius is the mean radius of the Earth in kilometres
+const earthRadius = 6371.0
+
+// Haversine returns the great-circle distance between two points in
+// kilometres, the length of the shortest way over the surface of the Earth
+// No road between two places is shorter, which makes it a safe estimate for
+// A*
+func Haversine(a, b Point) float64 {
+	const rad = math.Pi / 180
+	dLat := (b.Lat - a.Lat) * rad
+	dLon := (b.Lon - a.Lon) * rad
+	h := math.Sin(dLat/2)*math.Sin(dLat/2) +
+		math.Cos(a.Lat*rad)*math.Cos(b.Lat*rad)*math.Sin(dLon/2)*math.Sin(dLon/2)
+	return 2 * earthRadius * math.Asin(math.Sqrt(h))
+}
+
+// WeightedEdge is an edge with its weight
+type WeightedEdge struct {
+	From, To string
+	Weight   float64
+}
+
+// arc is an edge as the adjacency list stores it, from the vertex whose
+// list it is in
+type arc struct {
+	to     int
+	weight float64
+}
+
+// WeightedGraph is a graph whose edges have non-negative weights
+// The vertices are numbered in the order they were added, and the
+// adjacency lists hold the numbers, so the algorithms can keep their
+// distances in slices rather than maps
+type WeightedGraph struct {
+	directed bool
+	names    []string
+	index    map[string]int
+	location []Point
+	located  []bool
+	adj      [][]arc
+	edges    int
+}
+
+// NewWeightedGraph returns an empty graph. In an undirected graph every
+// edge can be followed both ways
+func NewWeightedGraph(directed bool) *WeightedGraph {
+	return &WeightedGraph{directed: directed, index: make(map[string]int)}
+}
+
+// Directed reports whether the edges only go one way
+func (g *WeightedGraph) Directed() bool {
+	return g.directed
+}
+
+// AddVertex adds a vertex unless the graph has it already
+func (g *WeightedGraph) AddVertex(name string) {
+	g.vertex(name)
+}
+
+func (g *WeightedGraph) vertex(name string) int {
+	if v, ok := g.index[name]; ok {
+		return v
+	}
+	v := len(g.names)
+	g.index[name] = v
+	g.names = append(g.names, name)
+	g.location = append(g.location, Point{})
+	g.located = append(g.located, false)
+	g.adj = append(g.adj, nil)
+	return v
+}
+
+// Locate adds a vertex if needed and puts it on the map
+func (g *WeightedGraph) Locate(name string, p Point) {
+	v := g.vertex(name)
+	g.location[v], g.located[v] = p, true
+}
+
+// Location returns where a vertex is, if it has a location
+func (g *WeightedGraph) Location(name string) (Point, bool) {
+	v, ok := g.index[name]
+	if !ok || !g.located[v] {
+		return Point{}, false
+	}
+	return g.location[v], true
+}
+
+// AddEdge adds an edge, and the vertices it joins if they are new
+// Dijkstra's algorithm and A* are only correct without negative weights,
+// so they are refused
+func (g *WeightedGraph) AddEdge(from, to string, weight float64) error {
+	if weight < 0 || math.IsNaN(weight) || math.IsInf(weight, 0) {
+		return fmt.Errorf("edge %s-%s: weight %v is not a non-negative number", from, to, weight)
+	}
+	u, v := g.vertex(from), g.vertex(to)
+	g.adj[u] = append(g.adj[u], arc{v, weight})
+	if !g.directed && u != v {
+		g.adj[v] = append(g.adj[v], arc{u, weight})
+	}
+	g.edges++
+	return nil
+}
+
+// Len returns the number of vertices
+func (g *WeightedGraph) Len() int {
+	return len(g.names)
+}
+
+// EdgeCount returns the number of edges added, each undirected edge once
+func (g *WeightedGraph) EdgeCount() int {
+	return g.edges
+}
+
+// Vertices returns the vertex names in the order they were added
+func (g *WeightedGraph) Vertices() []string {
+	return append([]string(nil), g.names...)
+}
+
+// Neighbors returns the edges leaving a vertex
+func (g *WeightedGraph) Neighbors(name string) []WeightedEdge {
+	v, ok := g.index[name]
+	if !ok {
+		return nil
+	}
+	edges := make([]WeightedEdge, len(g.adj[v]))
+	for i, a := range g.adj[v] {
+		edges[i] = WeightedEdge{name, g.names[a.to], a.weight}
+	}
+	return edges
+}
+
+// Edges returns every edge, each undirected edge once, in the order they
+// were added from each vertex
+func (g *WeightedGraph) Edges() []WeightedEdge {
+	edges := make([]WeightedEdge, 0, g.edges)
+	for u, arcs := range g.adj {
+		for _, a := range arcs {
+			// An undirected edge is in the lists of both its ends; keep the
+			// copy in the list of the vertex added first
+			if g.directed || u <= a.to {
+				edges = append(edges, WeightedEdge{g.names[u], g.names[a.to], a.weight})
+			}
+		}
+	}
+	return edges
+}
+
+// TotalWeight returns the sum of the weights of every edge
+func (g *WeightedGraph) TotalWeight() float64 {
+	total := 0.0
+	for _, e := range g.Edges() {
+		total += e.Weight
+	}
+	return total
+}
+
+func (g *WeightedGraph) lookup(name string) (int, error) {
+	v, ok := g.index[name]
+	if !ok {
+		return 0, fmt.Errorf("%w %q", ErrUnknownVertex, name)
+	}
+	return v, nil
+}
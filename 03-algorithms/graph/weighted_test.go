@@ -0,0 +1,267 @@
+package graph
+
+import (
+	"errors"
+	"math"
+	"math/rand"
+	"os"
+	"slices"
+	"strconv"
+	"strings"
+	"testing"
+)
+
+func loadRoads(t *testing.T) *WeightedGraph {
+	t.Helper()
+	f, err := os.Open("../data/roads.geojson")
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer f.Close()
+	g, err := LoadGeoJSON(f)
+	if err != nil {
+		t.Fatal(err)
+	}
+	return g
+}
+
+func TestHaversine(t *testing.T) {
+	// Bangkok to Chiang Mai is about 585 km as the crow flies
+	bangkok, chiangMai := Point{13.7563, 100.5018}, Point{18.7883, 98.9853}
+	if d := Haversine(bangkok, chiangMai); math.Abs(d-585) > 5 {
+		t.Errorf("Haversine(Bangkok, Chiang Mai) = %.1f km, want about 585", d)
+	}
+	if d := Haversine(bangkok, bangkok); d != 0 {
+		t.Errorf("Haversine(p, p) = %v", d)
+	}
+	// A quarter of the equator
+	if d := Haversine(Point{0, 0}, Point{0, 90}); math.Abs(d-math.Pi*earthRadius/2) > 1e-6 {
+		t.Errorf("Haversine(0°, 90°E) = %v", d)
+	}
+}
+
+func TestWeightedGraph(t *testing.T) {
+	g := NewWeightedGraph(false)
+	for _, e := range []WeightedEdge{{"a", "b", 1}, {"b", "c", 2}, {"a", "c", 5}} {
+		if err := g.AddEdge(e.From, e.To, e.Weight); err != nil {
+			t.Fatal(err)
+		}
+	}
+	g.AddVertex("lonely")
+	if got := g.Vertices(); !slices.Equal(got, []string{"a", "b", "c", "lonely"}) {
+		t.Errorf("Vertices = %v", got)
+	}
+	if got := g.Neighbors("c"); !slices.Equal(got, []WeightedEdge{{"c", "b", 2}, {"c", "a", 5}}) {
+		t.Errorf("Neighbors(c) = %v", got)
+	}
+	if g.EdgeCount() != 3 || len(g.Edges()) != 3 || g.TotalWeight() != 8 {
+		t.Errorf("EdgeCount = %d, Edges = %v, TotalWeight = %v", g.EdgeCount(), g.Edges(), g.TotalWeight())
+	}
+
+	p, err := g.ShortestPath("a", "c")
+	if err != nil || p.Length != 3 || !slices.Equal(p.Vertices, []string{"a", "b", "c"}) {
+		t.Errorf("ShortestPath(a, c) = %+v, %v", p, err)
+	}
+	if _, err := g.ShortestPath("a", "lonely"); !errors.Is(err, ErrNoPath) {
+		t.Errorf("ShortestPath to an isolated vertex: %v", err)
+	}
+	if _, err := g.AStar("a", "nowhere"); !errors.Is(err, ErrUnknownVertex) {
+		t.Errorf("AStar to an unknown vertex: %v", err)
+	}
+	for _, w := range []float64{-1, math.NaN(), math.Inf(1)} {
+		if err := g.AddEdge("a", "b", w); err == nil {
+			t.Errorf("AddEdge accepted weight %v", w)
+		}
+	}
+}
+
+func TestDirectedWeightedGraph(t *testing.T) {
+	g := NewWeightedGraph(true)
+	g.AddEdge("a", "b", 1)
+	g.AddEdge("b", "c", 1)
+	if _, err := g.ShortestPath("c", "a"); !errors.Is(err, ErrNoPath) {
+		t.Errorf("ShortestPath against the edges: %v", err)
+	}
+	d, _ := g.Distances("a")
+	if len(d) != 3 || d["c"] != 2 {
+		t.Errorf("Distances(a) = %v", d)
+	}
+}
+
+// randomRoads returns a connected undirected graph of n located vertices,
+// with every edge as long as the great circle between its ends or longer
+func randomRoads(r *rand.Rand, n, extra int) *WeightedGraph {
+	g := NewWeightedGraph(false)
+	name := func(i int) string { return "v" + strconv.Itoa(i) }
+	for i := range n {
+		g.Locate(name(i), Point{r.Float64()*10 + 10, r.Float64()*10 + 95})
+	}
+	road := func(i, j int) {
+		a, _ := g.Location(name(i))
+		b, _ := g.Location(name(j))
+		g.AddEdge(name(i), name(j), Haversine(a, b)*(1+r.Float64()))
+	}
+	for i := 1; i < n; i++ {
+		road(i, r.Intn(i))
+	}
+	for range extra {
+		road(r.Intn(n), r.Intn(n))
+	}
+	return g
+}
+
+// Property: A* finds paths as short as Dijkstra's, settling no more vertices
+func TestAStarAgreesWithDijkstra(t *testing.T) {
+	r := rand.New(rand.NewSource(1))
+	for range 50 {
+		g := randomRoads(r, 60, 120)
+		from, to := "v"+strconv.Itoa(r.Intn(60)), "v"+strconv.Itoa(r.Intn(60))
+		d, err := g.ShortestPath(from, to)
+		if err != nil {
+			t.Fatal(err)
+		}
+		a, err := g.AStar(from, to)
+		if err != nil {
+			t.Fatal(err)
+		}
+		if math.Abs(a.Length-d.Length) > 1e-9 || a.Settled > d.Settled {
+			t.Fatalf("%s to %s: A* %+v, Dijkstra %+v", from, to, a, d)
+		}
+		dist, _ := g.Distances(from)
+		if math.Abs(dist[to]-d.Length) > 1e-9 {
+			t.Fatalf("Distances[%s] = %v, ShortestPath length %v", to, dist[to], d.Length)
+		}
+	}
+}
+
+// bruteForceMST returns the weight of the lightest spanning tree of a small
+// connected graph by trying every subset of V-1 edges
+func bruteForceMST(g *WeightedGraph) float64 {
+	edges := g.Edges()
+	best := math.Inf(1)
+	for mask := 0; mask < 1<<len(edges); mask++ {
+		sets := newDisjointSets(g.Len())
+		weight, joined := 0.0, 0
+		for i, e := range edges {
+			if mask&(1<<i) != 0 && sets.union(g.index[e.From], g.index[e.To]) {
+				weight += e.Weight
+				joined++
+			}
+		}
+		if joined == g.Len()-1 {
+			best = min(best, weight)
+		}
+	}
+	return best
+}
+
+// Property: Kruskal's tree spans the graph and is as light as any other
+func TestMinimumSpanningTree(t *testing.T) {
+	r := rand.New(rand.NewSource(2))
+	for range 30 {
+		g := randomRoads(r, 6, 6)
+		tree := g.MinimumSpanningTree()
+		weight := 0.0
+		for _, e := range tree {
+			weight += e.Weight
+		}
+		if len(tree) != g.Len()-1 || math.Abs(weight-bruteForceMST(g)) > 1e-9 {
+			t.Fatalf("tree %v of weight %v, want %v", tree, weight, bruteForceMST(g))
+		}
+	}
+
+	// Two pieces get a spanning forest
+	g := NewWeightedGraph(false)
+	g.AddEdge("a", "b", 1)
+	g.AddEdge("c", "d", 1)
+	if tree := g.MinimumSpanningTree(); len(tree) != 2 {
+		t.Errorf("forest = %v", tree)
+	}
+}
+
+func TestLoadRoads(t *testing.T) {
+	geo := loadRoads(t)
+	f, err := os.Open("../data/roads.csv")
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer f.Close()
+	csv, err := LoadEdgeCSV(f, false)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if geo.Len() != csv.Len() || geo.EdgeCount() != csv.EdgeCount() {
+		t.Fatalf("GeoJSON has %d cities and %d roads, CSV %d and %d", geo.Len(), geo.EdgeCount(), csv.Len(), csv.EdgeCount())
+	}
+	// The CSV has the lengths of the GeoJSON lines, rounded to 0.1 km
+	lengths := make(map[[2]string]float64)
+	for _, e := range csv.Edges() {
+		lengths[[2]string{min(e.From, e.To), max(e.From, e.To)}] = e.Weight
+	}
+	for _, e := range geo.Edges() {
+		c, ok := lengths[[2]string{min(e.From, e.To), max(e.From, e.To)}]
+		if !ok || math.Abs(e.Weight-c) > 0.05 {
+			t.Errorf("GeoJSON road %v, CSV length %v", e, c)
+		}
+	}
+	// Every road is at least as long as the straight line, or A* would be
+	// wrong
+	for _, e := range geo.Edges() {
+		a, _ := geo.Location(e.From)
+		b, _ := geo.Location(e.To)
+		if e.Weight < Haversine(a, b) {
+			t.Errorf("%v is shorter than the straight line", e)
+		}
+	}
+	if tree := geo.MinimumSpanningTree(); len(tree) != geo.Len()-1 {
+		t.Errorf("the roads are not connected: the spanning tree has %d edges", len(tree))
+	}
+}
+
+func TestLoadEdgeCSV(t *testing.T) {
+	g, err := LoadEdgeCSV(strings.NewReader("# comment\nWeight, to, from, note\n2.5, b, a, x\n1, c, b, y\n"), true)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if got := g.Edges(); !slices.Equal(got, []WeightedEdge{{"a", "b", 2.5}, {"b", "c", 1}}) {
+		t.Errorf("Edges = %v", got)
+	}
+	for input, want := range map[string]string{
+		"":                         "no header",
+		"from,to\na,b\n":           `no "weight" column`,
+		"from,to,weight\na,b,x\n":  "line 2: weight",
+		"from,to,weight\na,,1\n":   "line 2: an edge needs both ends",
+		"from,to,weight\na,b,-1\n": "line 2: edge a-b",
+		"from,to,weight\na,b\n":    "wrong number of fields",
+	} {
+		if _, err := LoadEdgeCSV(strings.NewReader(input), false); err == nil || !strings.Contains(err.Error(), want) {
+			t.Errorf("LoadEdgeCSV(%q) = %v, want an error with %q", input, err, want)
+		}
+	}
+}
+
+func TestLoadGeoJSONErrors(t *testing.T) {
+	point := func(name string) string {
+		return `{"type": "Feature", "geometry": {"type": "Point", "coordinates": [100, 13]}, "properties": {"name": "` + name + `"}}`
+	}
+	line := `{"type": "Feature", "geometry": {"type": "LineString", "coordinates": [[100, 13], [101, 14]]}, "properties": {"from": "a", "to": "b"}}`
+	collection := func(features ...string) string {
+		return `{"type": "FeatureCollection", "features": [` + strings.Join(features, ",") + `]}`
+	}
+	for input, want := range map[string]string{
+		`{"type": "Feature"}`:                                            "expected a FeatureCollection",
+		collection(point("a"), line):                                     `unknown vertex "b"`,
+		collection(point("a"), point("a")):                               `duplicate point "a"`,
+		collection(strings.Replace(point("a"), "[100, 13]", "[100]", 1)): "bad Point",
+		`{"type": `: "geojson: unexpected EOF",
+	} {
+		if _, err := LoadGeoJSON(strings.NewReader(input)); err == nil || !strings.Contains(err.Error(), want) {
+			t.Errorf("LoadGeoJSON(%.40q) = %v, want an error with %q", input, err, want)
+		}
+	}
+	// A line's ends may come before the points
+	g, err := LoadGeoJSON(strings.NewReader(collection(line, point("b"), point("a"))))
+	if err != nil || g.EdgeCount() != 1 {
+		t.Errorf("line before its points: %v", err)
+	}
+}
@@ -7,12 +7,10 @@
 // 2. Never reconsiders its choices
 // 3. Works well for optimization problems
 
-package main
+// Package greedy solves classic problems with greedy algorithms
+package greedy
 
-import (
-	"fmt"
-	"sort"
-)
+import "sort"
 
 // ActivitySelection solves the activity selection problem
 // Given a set of activities with start and end times,
@@ -118,19 +116,38 @@ func (h *HuffmanHeap) Pop() interface{} {
 	return x
 }
 
+// BuildHuffmanTree returns nil for empty text, which has nothing to encode
 func BuildHuffmanTree(text string) *HuffmanNode {
 	// Count frequency of characters
 	freq := make(map[rune]int)
 	for _, c := range text {
 		freq[c]++
 	}
+	return BuildHuffmanTreeFreq(freq)
+}
+
+// BuildHuffmanTreeFreq builds the tree from counts that were already taken,
+// such as of the bytes of a file, which aren't text. It returns nil when
+// there are no counts
+// Symbols with the same count are taken in the order of their Char, so the
+// same counts always make the same tree; cmd/huff relies on it to write
+// the same file every time
+func BuildHuffmanTreeFreq(freq map[rune]int) *HuffmanNode {
+	if len(freq) == 0 {
+		return nil
+	}
 
 	// Create heap
 	var heap HuffmanHeap
 	for char, f := range freq {
 		heap = append(heap, &HuffmanNode{Char: char, Freq: f})
 	}
-	sort.Sort(heap)
+	sort.Slice(heap, func(i, j int) bool {
+		if heap[i].Freq != heap[j].Freq {
+			return heap[i].Freq < heap[j].Freq
+		}
+		return heap[i].Char < heap[j].Char
+	})
 
 	// Build Huffman tree
 	for len(heap) > 1 {
@@ -207,47 +224,3 @@ func DijkstraShortestPath(graph [][]Edge, start int) []int {
 
 	return dist
 }
-
-func main() {
-	// Example 1: Activity Selection
-	activities := []Activity{
-		{1, 4}, {3, 5}, {0, 6}, {5, 7},
-		{3, 9}, {5, 9}, {6, 10}, {8, 11},
-		{8, 12}, {2, 14}, {12, 16},
-	}
-
-	selected := ActivitySelection(activities)
-	fmt.Println("Activity Selection Problem:")
-	fmt.Printf("Selected activities: %+v\n\n", selected)
-
-	// Example 2: Fractional Knapsack
-	items := []Item{
-		{60, 10},  // Value: 60, Weight: 10
-		{100, 20}, // Value: 100, Weight: 20
-		{120, 30}, // Value: 120, Weight: 30
-	}
-	capacity := 50.0
-
-	maxValue := FractionalKnapsack(items, capacity)
-	fmt.Println("Fractional Knapsack Problem:")
-	fmt.Printf("Maximum value: %.2f\n\n", maxValue)
-
-	// Example 3: Huffman Coding
-	text := "this is an example for huffman encoding"
-	huffmanTree := BuildHuffmanTree(text)
-	fmt.Println("Huffman Coding:")
-	fmt.Printf("Huffman tree root frequency: %d\n\n", huffmanTree.Freq)
-
-	// Example 4: Dijkstra's Shortest Path
-	graph := [][]Edge{
-		{{1, 4}, {2, 1}},           // Edges from vertex 0
-		{{3, 1}},                   // Edges from vertex 1
-		{{1, 2}, {3, 5}},          // Edges from vertex 2
-		{{4, 3}},                   // Edges from vertex 3
-		{},                         // Edges from vertex 4
-	}
-
-	distances := DijkstraShortestPath(graph, 0)
-	fmt.Println("Dijkstra's Shortest Path:")
-	fmt.Printf("Shortest distances from vertex 0: %v\n", distances)
-}
@@ -0,0 +1,314 @@
+package greedy
+
+import (
+	"math"
+	"slices"
+	"sort"
+	"testing"
+	"testing/quick"
+)
+
+// compatible reports whether no two activities overlap
+// Activities that touch (one ends when the next starts) do not overlap
+func compatible(activities []Activity) bool {
+	sorted := slices.Clone(activities)
+	sort.Slice(sorted, func(i, j int) bool { return sorted[i].End < sorted[j].End })
+	for i := 1; i < len(sorted); i++ {
+		if sorted[i].Start < sorted[i-1].End {
+			return false
+		}
+	}
+	return true
+}
+
+// bruteForceActivities returns the size of the largest compatible subset
+func bruteForceActivities(activities []Activity) int {
+	best := 0
+	for mask := 0; mask < 1<<len(activities); mask++ {
+		var subset []Activity
+		for i, a := range activities {
+			if mask&(1<<i) != 0 {
+				subset = append(subset, a)
+			}
+		}
+		if len(subset) > best && compatible(subset) {
+			best = len(subset)
+		}
+	}
+	return best
+}
+
+func TestActivitySelection(t *testing.T) {
+	activities := []Activity{
+		{1, 4}, {3, 5}, {0, 6}, {5, 7},
+		{3, 9}, {5, 9}, {6, 10}, {8, 11},
+		{8, 12}, {2, 14}, {12, 16},
+	}
+	want := []Activity{{1, 4}, {5, 7}, {8, 11}, {12, 16}}
+	if got := ActivitySelection(activities); !slices.Equal(got, want) {
+		t.Errorf("ActivitySelection = %v, want %v", got, want)
+	}
+	if got := ActivitySelection(nil); len(got) != 0 {
+		t.Errorf("ActivitySelection(nil) = %v, want empty", got)
+	}
+}
+
+// Property: the greedy choice is compatible and as large as any other choice
+func TestActivitySelectionProperty(t *testing.T) {
+	property := func(raw [][2]uint8) bool {
+		if len(raw) > 10 {
+			raw = raw[:10] // Keep the brute force at most 2^10 subsets
+		}
+		activities := make([]Activity, len(raw))
+		for i, r := range raw {
+			start := int(r[0] % 20)
+			activities[i] = Activity{start, start + int(r[1]%6) + 1}
+		}
+		want := bruteForceActivities(activities)
+		got := ActivitySelection(activities)
+		return compatible(got) && len(got) == want
+	}
+	if err := quick.Check(property, nil); err != nil {
+		t.Error(err)
+	}
+}
+
+func TestFractionalKnapsack(t *testing.T) {
+	items := []Item{{60, 10}, {100, 20}, {120, 30}}
+	tests := []struct {
+		capacity float64
+		want     float64
+	}{
+		{50, 240},
+		{0, 0},
+		{10, 60},
+		{15, 85},   // All of item 1 and half of item 2
+		{100, 280}, // Everything fits
+	}
+	for _, tt := range tests {
+		if got := FractionalKnapsack(items, tt.capacity); math.Abs(got-tt.want) > 1e-9 {
+			t.Errorf("FractionalKnapsack(%v, %v) = %v, want %v", items, tt.capacity, got, tt.want)
+		}
+	}
+	if got := FractionalKnapsack(nil, 10); got != 0 {
+		t.Errorf("FractionalKnapsack(nil, 10) = %v, want 0", got)
+	}
+}
+
+// Property: the fractional optimum is never below the 0/1 optimum of the
+// same items, and never above the total value of all items
+func TestFractionalKnapsackProperty(t *testing.T) {
+	property := func(rawValues, rawWeights [6]uint8, rawCapacity uint8) bool {
+		items := make([]Item, 6)
+		values, weights := make([]int, 6), make([]int, 6)
+		total := 0.0
+		for i := range items {
+			values[i] = int(rawValues[i])
+			weights[i] = int(rawWeights[i]%20) + 1
+			items[i] = Item{float64(values[i]), float64(weights[i])}
+			total += items[i].Value
+		}
+		capacity := int(rawCapacity % 80)
+		got := FractionalKnapsack(items, float64(capacity))
+		return got >= float64(bruteForceKnapsack(values, weights, capacity))-1e-9 && got <= total+1e-9
+	}
+	if err := quick.Check(property, nil); err != nil {
+		t.Error(err)
+	}
+}
+
+// bruteForceKnapsack duplicates the helper in dp/dynamic_programming_test.go,
+// since test helpers are not shared between packages
+func bruteForceKnapsack(values, weights []int, capacity int) int {
+	best := 0
+	for mask := 0; mask < 1<<len(values); mask++ {
+		value, weight := 0, 0
+		for i := range values {
+			if mask&(1<<i) != 0 {
+				value += values[i]
+				weight += weights[i]
+			}
+		}
+		if weight <= capacity && value > best {
+			best = value
+		}
+	}
+	return best
+}
+
+// huffmanCost returns the total encoded length in bits: the sum over leaves
+// of frequency times depth. It also collects the leaves' characters
+func huffmanCost(node *HuffmanNode, depth int, leaves map[rune]int) int {
+	if node.Left == nil && node.Right == nil {
+		leaves[node.Char] = node.Freq
+		return node.Freq * depth
+	}
+	return huffmanCost(node.Left, depth+1, leaves) + huffmanCost(node.Right, depth+1, leaves)
+}
+
+// optimalCost computes the optimal Huffman cost directly: every merge of the
+// two smallest weights adds their sum to the total
+func optimalCost(text string) int {
+	freq := make(map[rune]int)
+	for _, c := range text {
+		freq[c]++
+	}
+	var weights []int
+	for _, f := range freq {
+		weights = append(weights, f)
+	}
+	cost := 0
+	for len(weights) > 1 {
+		slices.Sort(weights)
+		merged := weights[0] + weights[1]
+		cost += merged
+		weights = append(weights[2:], merged)
+	}
+	return cost
+}
+
+func TestBuildHuffmanTree(t *testing.T) {
+	if got := BuildHuffmanTree(""); got != nil {
+		t.Errorf("BuildHuffmanTree(\"\") = %+v, want nil", got)
+	}
+
+	root := BuildHuffmanTree("aaaa")
+	if root == nil || root.Char != 'a' || root.Freq != 4 || root.Left != nil || root.Right != nil {
+		t.Errorf("BuildHuffmanTree(\"aaaa\") = %+v, want a single leaf", root)
+	}
+
+	// a:5 b:2 c:1 d:1 → codes of length 1, 2, 3, 3 → 5 + 4 + 3 + 3 bits
+	root = BuildHuffmanTree("aaaaabbcd")
+	leaves := make(map[rune]int)
+	if cost := huffmanCost(root, 0, leaves); cost != 15 {
+		t.Errorf("encoded length = %d bits, want 15", cost)
+	}
+}
+
+// Property: the tree covers every character once with its frequency,
+// the root holds the total count, and the encoding is optimal
+func TestBuildHuffmanTreeProperty(t *testing.T) {
+	property := func(raw []byte) bool {
+		// A small alphabet so characters repeat
+		text := make([]rune, len(raw))
+		for i, b := range raw {
+			text[i] = 'a' + rune(b%6)
+		}
+		s := string(text)
+		root := BuildHuffmanTree(s)
+		if s == "" {
+			return root == nil
+		}
+
+		freq := make(map[rune]int)
+		for _, c := range s {
+			freq[c]++
+		}
+		leaves := make(map[rune]int)
+		cost := huffmanCost(root, 0, leaves)
+		return root.Freq == len(text) &&
+			len(leaves) == len(freq) &&
+			equalMaps(leaves, freq) &&
+			cost == optimalCost(s)
+	}
+	if err := quick.Check(property, nil); err != nil {
+		t.Error(err)
+	}
+}
+
+func TestBuildHuffmanTreeFreq(t *testing.T) {
+	if BuildHuffmanTreeFreq(nil) != nil {
+		t.Error("BuildHuffmanTreeFreq(nil) is not nil")
+	}
+	// Every count is the same, so only the order of the symbols decides
+	// the shape; it must come out the same every time
+	freq := map[rune]int{0: 1, 1: 1, 2: 1, 3: 1, 4: 1, 255: 1}
+	var shape func(n *HuffmanNode) string
+	shape = func(n *HuffmanNode) string {
+		if n.Left == nil {
+			return string(rune('A' + n.Char%26))
+		}
+		return "(" + shape(n.Left) + shape(n.Right) + ")"
+	}
+	want := shape(BuildHuffmanTreeFreq(freq))
+	for range 20 {
+		if got := shape(BuildHuffmanTreeFreq(freq)); got != want {
+			t.Fatalf("shape %s, then %s", want, got)
+		}
+	}
+}
+
+func equalMaps(a, b map[rune]int) bool {
+	for k, v := range a {
+		if b[k] != v {
+			return false
+		}
+	}
+	return len(a) == len(b)
+}
+
+func TestHuffmanHeap(t *testing.T) {
+	h := HuffmanHeap{{Freq: 3}, {Freq: 1}}
+	if h.Len() != 2 || h.Less(0, 1) {
+		t.Fatalf("Len() = %d, Less(0, 1) = %v", h.Len(), h.Less(0, 1))
+	}
+	h.Swap(0, 1)
+	h.Push(&HuffmanNode{Freq: 2})
+	if got := h.Pop().(*HuffmanNode).Freq; got != 2 || h.Len() != 2 || h[0].Freq != 1 {
+		t.Errorf("Pop() = %d after Swap and Push, heap = %v", got, h)
+	}
+}
+
+const unreachable = int(1e9)
+
+// bellmanFord relaxes every edge n-1 times: slow but obviously correct
+func bellmanFord(graph [][]Edge, start int) []int {
+	dist := make([]int, len(graph))
+	for i := range dist {
+		dist[i] = unreachable
+	}
+	dist[start] = 0
+	for range graph {
+		for u, edges := range graph {
+			if dist[u] == unreachable {
+				continue
+			}
+			for _, e := range edges {
+				dist[e.To] = min(dist[e.To], dist[u]+e.Weight)
+			}
+		}
+	}
+	return dist
+}
+
+func TestDijkstraShortestPath(t *testing.T) {
+	graph := [][]Edge{
+		{{1, 4}, {2, 1}},
+		{{3, 1}},
+		{{1, 2}, {3, 5}},
+		{{4, 3}},
+		{},
+		{}, // Unreachable
+	}
+	want := []int{0, 3, 1, 4, 7, unreachable}
+	if got := DijkstraShortestPath(graph, 0); !slices.Equal(got, want) {
+		t.Errorf("DijkstraShortestPath = %v, want %v", got, want)
+	}
+}
+
+// Property: Dijkstra agrees with Bellman-Ford on random non-negative graphs
+func TestDijkstraShortestPathProperty(t *testing.T) {
+	property := func(raw [][3]uint8, rawStart uint8) bool {
+		const n = 8
+		graph := make([][]Edge, n)
+		for _, r := range raw {
+			from, to := int(r[0]%n), int(r[1]%n)
+			graph[from] = append(graph[from], Edge{to, int(r[2] % 10)})
+		}
+		start := int(rawStart % n)
+		return slices.Equal(DijkstraShortestPath(graph, start), bellmanFord(graph, start))
+	}
+	if err := quick.Check(property, nil); err != nil {
+		t.Error(err)
+	}
+}
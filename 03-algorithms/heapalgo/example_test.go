@@ -0,0 +1,22 @@
+package heapalgo_test
+
+import (
+	"fmt"
+
+	"github.com/NutProhmpiriya/go-basic/03-algorithms/heapalgo"
+)
+
+func ExampleMergeSorted() {
+	fmt.Println(heapalgo.MergeSorted([][]int{{1, 5, 9}, {2, 3}, {4, 8, 10}}))
+	// Output: [1 2 3 4 5 8 9 10]
+}
+
+func ExampleRunningMedian() {
+	m := heapalgo.NewRunningMedian()
+	for _, x := range []int{4, 10, 2, 7} {
+		m.Add(x)
+		median, _ := m.Median()
+		fmt.Print(median, " ")
+	}
+	// Output: 4 7 4 5.5
+}
@@ -0,0 +1,197 @@
+// This file implements algorithms built on a binary heap in Go
+// Each keeps a small heap of candidates so that the next one to take is
+// always at the top, rather than sorting everything up front
+//
+// Algorithms:
+// 1. K-way merge of sorted slices, with a heap of one cursor per slice
+// 2. Running median of a stream, with a max-heap for the lower half and a
+//    min-heap for the upper half
+// 3. Top-k: the k best items of a sequence, with a min-heap of the k best
+//    seen so far, and with it the k most frequent values
+//
+// Use Cases:
+// - Merging sorted runs in external sorting, or sorted log files by time
+// - Medians of latencies or prices as they arrive
+// - Trending items: the most searched terms, the busiest endpoints
+
+// Package heapalgo implements algorithms that use a binary heap
+package heapalgo
+
+import (
+	"errors"
+	"iter"
+
+	"github.com/NutProhmpiriya/go-basic/02-data-structures/datastructures"
+	"github.com/NutProhmpiriya/go-basic/ordering"
+)
+
+// ErrEmpty is returned for the median of a stream with no values yet
+var ErrEmpty = errors.New("no values")
+
+// cursor is the position of the next value to take from one of the lists
+type cursor struct {
+	list, index int
+}
+
+// MergeSorted merges k sorted slices into one sorted slice
+// A min-heap holds one cursor per slice, ordered by the value it points at;
+// the top is the smallest value not yet taken. Taking it advances its
+// cursor, which goes back in the heap until its slice runs out
+// Equal values keep the order of their slices, so the merge is stable
+// Time Complexity: O(N log k) for N values in k slices, where merging them
+// pairwise one after another would take O(N k)
+// Space Complexity: O(k) besides the result
+func MergeSorted(lists [][]int) []int {
+	total := 0
+	var cursors []cursor
+	for i, list := range lists {
+		total += len(list)
+		if len(list) > 0 {
+			cursors = append(cursors, cursor{list: i})
+		}
+	}
+
+	value := func(c cursor) int { return lists[c.list][c.index] }
+	h := datastructures.NewHeapFunc(
+		ordering.By(value).ThenComparing(ordering.By(func(c cursor) int { return c.list })),
+		cursors...)
+
+	merged := make([]int, 0, total)
+	for !h.IsEmpty() {
+		c, _ := h.Pop()
+		merged = append(merged, value(c))
+		if c.index++; c.index < len(lists[c.list]) {
+			h.Push(c)
+		}
+	}
+	return merged
+}
+
+// RunningMedian gives the exact median of a stream of values after each one
+// The lower half of the values is in a max-heap and the upper half in a
+// min-heap, so the middle values are the two tops. The lower half is kept
+// the same size as the upper half or one larger
+// Unlike stats.P2Quantile, which estimates the median in O(1) space, it is
+// exact but keeps every value
+type RunningMedian struct {
+	low  *datastructures.Heap[int] // max-heap of the smaller half
+	high *datastructures.Heap[int] // min-heap of the larger half
+}
+
+// NewRunningMedian creates a RunningMedian with no values
+func NewRunningMedian() *RunningMedian {
+	return &RunningMedian{
+		low:  datastructures.NewMaxHeap[int](),
+		high: datastructures.NewMinHeap[int](),
+	}
+}
+
+// Add includes a value
+// Time Complexity: O(log n)
+func (m *RunningMedian) Add(x int) {
+	// Push through the lower half so its largest value moves up when x
+	// belongs in the upper half, then even out the sizes
+	m.low.Push(x)
+	top, _ := m.low.Pop()
+	m.high.Push(top)
+	if m.high.Size() > m.low.Size() {
+		top, _ = m.high.Pop()
+		m.low.Push(top)
+	}
+}
+
+// Median returns the median of the values added so far: the middle value,
+// or the mean of the two middle values for an even count
+// Time Complexity: O(1)
+func (m *RunningMedian) Median() (float64, error) {
+	lowTop, err := m.low.Peek()
+	if err != nil {
+		return 0, ErrEmpty
+	}
+	if m.low.Size() > m.high.Size() {
+		return float64(lowTop), nil
+	}
+	highTop, _ := m.high.Peek()
+	return (float64(lowTop) + float64(highTop)) / 2, nil
+}
+
+// Len returns the number of values added
+func (m *RunningMedian) Len() int {
+	return m.low.Size() + m.high.Size()
+}
+
+// frequency is a value and how often it occurs
+type frequency struct {
+	value, count int
+}
+
+// more orders frequencies from most to least common, breaking ties by the
+// smaller value so the result does not depend on map iteration order
+func more(a, b frequency) bool {
+	if a.count != b.count {
+		return a.count > b.count
+	}
+	return a.value < b.value
+}
+
+// TopK returns the k best items of a sequence, best first, where
+// better(a, b) reports whether a ranks above b. It returns them all when
+// there are fewer than k, and nil when k <= 0
+// It keeps the best k so far in a heap whose top is the worst of them: an
+// item that beats the top replaces it. Only k items are held at a time, so
+// the sequence can be far larger than memory
+// Time Complexity: O(n log k), where sorting everything would take O(n log n)
+// Space Complexity: O(k)
+func TopK[T any](items iter.Seq[T], k int, better func(a, b T) bool) []T {
+	if k <= 0 {
+		return nil
+	}
+	// The heap's "less" is "worse", so its top is the one to drop
+	worst := datastructures.NewHeap(func(a, b T) bool { return better(b, a) })
+	for item := range items {
+		if worst.Size() < k {
+			worst.Push(item)
+		} else if top, _ := worst.Peek(); better(item, top) {
+			worst.Pop()
+			worst.Push(item)
+		}
+	}
+
+	// Popping gives the worst first, so fill the result from the back
+	best := make([]T, worst.Size())
+	for i := len(best) - 1; i >= 0; i-- {
+		best[i], _ = worst.Pop()
+	}
+	return best
+}
+
+// TopKFrequent returns the k most frequent values, most frequent first,
+// with ties going to the smaller value. It returns every distinct value
+// when there are fewer than k, and nil when k <= 0
+// It counts the values in a Multiset, then picks the best k with TopK
+// Time Complexity: O(n + u log k) for u distinct values, where sorting all
+// the counts would take O(n + u log u)
+// Space Complexity: O(u)
+func TopKFrequent(values []int, k int) []int {
+	if k <= 0 {
+		return nil
+	}
+	var counts datastructures.Multiset[int]
+	for _, v := range values {
+		counts.Add(v)
+	}
+	frequencies := func(yield func(frequency) bool) {
+		for v, c := range counts.All() {
+			if !yield(frequency{v, c}) {
+				return
+			}
+		}
+	}
+
+	top := TopK(frequencies, k, more)
+	result := make([]int, len(top))
+	for i, f := range top {
+		result[i] = f.value
+	}
+	return result
+}
@@ -0,0 +1,208 @@
+package heapalgo
+
+import (
+	"errors"
+	"fmt"
+	"slices"
+	"testing"
+
+	"github.com/NutProhmpiriya/go-basic/testdata/generator"
+)
+
+func TestMergeSorted(t *testing.T) {
+	tests := []struct {
+		name  string
+		lists [][]int
+		want  []int
+	}{
+		{"no lists", nil, []int{}},
+		{"empty lists", [][]int{{}, nil, {}}, []int{}},
+		{"one list", [][]int{{1, 2, 3}}, []int{1, 2, 3}},
+		{"interleaved", [][]int{{1, 4, 7}, {2, 5, 8}, {3, 6, 9}}, []int{1, 2, 3, 4, 5, 6, 7, 8, 9}},
+		{"different lengths", [][]int{{5}, {}, {1, 2, 3, 10}, {4, 6}}, []int{1, 2, 3, 4, 5, 6, 10}},
+		{"duplicates", [][]int{{1, 1, 3}, {1, 3}, {-2, 3}}, []int{-2, 1, 1, 1, 3, 3, 3}},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := MergeSorted(tt.lists); !slices.Equal(got, tt.want) {
+				t.Errorf("MergeSorted() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+
+	// Random lists against sorting them all together
+	g := generator.New(1)
+	var lists [][]int
+	var all []int
+	for range 50 {
+		list := g.Ints(g.Rand().Intn(40), 100)
+		slices.Sort(list)
+		lists = append(lists, list)
+		all = append(all, list...)
+	}
+	slices.Sort(all)
+	if got := MergeSorted(lists); !slices.Equal(got, all) {
+		t.Error("MergeSorted of 50 random lists is not their sorted values")
+	}
+}
+
+func TestRunningMedian(t *testing.T) {
+	m := NewRunningMedian()
+	if _, err := m.Median(); !errors.Is(err, ErrEmpty) {
+		t.Errorf("Median() with no values error = %v, want ErrEmpty", err)
+	}
+
+	steps := []struct {
+		add  int
+		want float64
+	}{
+		{5, 5},
+		{15, 10},
+		{1, 5},
+		{3, 4},
+		{8, 5},
+		{-4, 4},
+		{3, 3},
+	}
+	for i, s := range steps {
+		m.Add(s.add)
+		if got, err := m.Median(); err != nil || got != s.want {
+			t.Errorf("after adding %d: Median() = %v, %v, want %v", s.add, got, err, s.want)
+		}
+		if m.Len() != i+1 {
+			t.Errorf("Len() = %d, want %d", m.Len(), i+1)
+		}
+	}
+
+	// Random streams against sorting the prefix
+	rng := generator.New(1).Rand()
+	m = NewRunningMedian()
+	var seen []int
+	for range 500 {
+		x := rng.Intn(200) - 100
+		m.Add(x)
+		seen = append(seen, x)
+		sorted := slices.Sorted(slices.Values(seen))
+		n := len(sorted)
+		want := float64(sorted[n/2])
+		if n%2 == 0 {
+			want = float64(sorted[n/2-1]+sorted[n/2]) / 2
+		}
+		if got, _ := m.Median(); got != want {
+			t.Fatalf("after %d values: Median() = %v, want %v", n, got, want)
+		}
+	}
+}
+
+func TestTopKFrequent(t *testing.T) {
+	tests := []struct {
+		name   string
+		values []int
+		k      int
+		want   []int
+	}{
+		{"empty", nil, 2, []int{}},
+		{"k is zero", []int{1, 1, 2}, 0, nil},
+		{"k is negative", []int{1, 1, 2}, -1, nil},
+		{"top one", []int{1, 1, 1, 2, 2, 3}, 1, []int{1}},
+		{"top two", []int{1, 1, 1, 2, 2, 3}, 2, []int{1, 2}},
+		{"k above distinct values", []int{3, 1, 3}, 5, []int{3, 1}},
+		{"ties go to the smaller value", []int{9, 4, 7, 4, 9, 7}, 2, []int{4, 7}},
+		{"negative values", []int{-1, -1, 2, -3, -3, -3}, 2, []int{-3, -1}},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := TopKFrequent(tt.values, tt.k); !slices.Equal(got, tt.want) {
+				t.Errorf("TopKFrequent(%v, %d) = %v, want %v", tt.values, tt.k, got, tt.want)
+			}
+		})
+	}
+
+	// Random values against sorting every count
+	values := generator.New(2).FewUnique(5000, 60)
+	counts := make(map[int]int)
+	for _, v := range values {
+		counts[v]++
+	}
+	var all []frequency
+	for v, c := range counts {
+		all = append(all, frequency{v, c})
+	}
+	slices.SortFunc(all, func(a, b frequency) int {
+		if more(a, b) {
+			return -1
+		}
+		return 1
+	})
+	for _, k := range []int{1, 10, 60, 100} {
+		var want []int
+		for _, f := range all[:min(k, len(all))] {
+			want = append(want, f.value)
+		}
+		if got := TopKFrequent(values, k); !slices.Equal(got, want) {
+			t.Errorf("TopKFrequent(k=%d) = %v, want %v", k, got, want)
+		}
+	}
+}
+
+// BenchmarkMergeSorted compares the heap merge with appending every list
+// and sorting the lot, which ignores that the lists are already sorted
+func BenchmarkMergeSorted(b *testing.B) {
+	for _, k := range []int{4, 64} {
+		g := generator.New(1)
+		lists := make([][]int, k)
+		for i := range lists {
+			lists[i] = g.Ints(100_000/k, 1_000_000)
+			slices.Sort(lists[i])
+		}
+		b.Run(fmt.Sprintf("impl=heap/k=%d", k), func(b *testing.B) {
+			b.ReportAllocs()
+			for range b.N {
+				MergeSorted(lists)
+			}
+		})
+		b.Run(fmt.Sprintf("impl=sort/k=%d", k), func(b *testing.B) {
+			b.ReportAllocs()
+			for range b.N {
+				var all []int
+				for _, list := range lists {
+					all = append(all, list...)
+				}
+				slices.Sort(all)
+			}
+		})
+	}
+}
+
+func TestTopK(t *testing.T) {
+	larger := func(a, b int) bool { return a > b }
+	tests := []struct {
+		name  string
+		items []int
+		k     int
+		want  []int
+	}{
+		{"empty", nil, 3, []int{}},
+		{"k is zero", []int{1, 2}, 0, nil},
+		{"fewer than k", []int{2, 9, 4}, 5, []int{9, 4, 2}},
+		{"top three", []int{5, 1, 9, 3, 7, 9, 2}, 3, []int{9, 9, 7}},
+		{"top one", []int{-5, -1, -9}, 1, []int{-1}},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := TopK(slices.Values(tt.items), tt.k, larger); !slices.Equal(got, tt.want) {
+				t.Errorf("TopK(%v, %d) = %v, want %v", tt.items, tt.k, got, tt.want)
+			}
+		})
+	}
+
+	// Random values against sorting them all, for a k around every size
+	values := generator.New(3).Ints(1000, 500)
+	sorted := slices.Sorted(slices.Values(values))
+	slices.Reverse(sorted)
+	for _, k := range []int{1, 10, 999, 1000, 1001} {
+		if got := TopK(slices.Values(values), k, larger); !slices.Equal(got, sorted[:min(k, len(sorted))]) {
+			t.Errorf("TopK(k=%d) is not the %d largest values", k, k)
+		}
+	}
+}
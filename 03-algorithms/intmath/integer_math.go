@@ -0,0 +1,236 @@
+// This file implements low-level integer math algorithms in Go
+// These routines show how bit manipulation and careful reasoning about
+// overflow lead to faster and safer code than the obvious approach
+//
+// Algorithms:
+// 1. Binary GCD (Stein's algorithm): GCD using shifts and subtraction only
+// 2. Integer square root via Newton's method
+// 3. Overflow-checked and saturating arithmetic
+// 4. Bit tricks: powers of two, population count, lowest set bit
+//
+// Each algorithm is benchmarked against a naive version in the 03-algorithms demo
+
+// Package intmath implements integer math with bit tricks and overflow checks
+package intmath
+
+import (
+	"math"
+	"math/bits"
+)
+
+// GCD computes the greatest common divisor using Euclid's algorithm
+// Time Complexity: O(log min(a, b)) divisions
+// Space Complexity: O(1)
+func GCD(a, b uint64) uint64 {
+	for b != 0 {
+		a, b = b, a%b
+	}
+	return a
+}
+
+// BinaryGCD computes the greatest common divisor using Stein's algorithm
+// Replaces division with shifts and subtraction:
+// - gcd(2a, 2b) = 2 * gcd(a, b)
+// - gcd(2a, b) = gcd(a, b) when b is odd
+// - gcd(a, b) = gcd(|a - b|, min(a, b)) when both are odd
+// Time Complexity: O(log a + log b)
+// Space Complexity: O(1)
+func BinaryGCD(a, b uint64) uint64 {
+	if a == 0 {
+		return b
+	}
+	if b == 0 {
+		return a
+	}
+
+	// Common factors of two
+	shift := bits.TrailingZeros64(a | b)
+	a >>= bits.TrailingZeros64(a)
+
+	for b != 0 {
+		// Remove factors of two from b; a is always odd here
+		b >>= bits.TrailingZeros64(b)
+		if a > b {
+			a, b = b, a
+		}
+		b -= a
+	}
+
+	return a << shift
+}
+
+// NaiveGCD finds the GCD by trying every candidate from min(a, b) down
+// Time Complexity: O(min(a, b))
+func NaiveGCD(a, b uint64) uint64 {
+	if a == 0 {
+		return b
+	}
+	if b == 0 {
+		return a
+	}
+	for d := min(a, b); d > 1; d-- {
+		if a%d == 0 && b%d == 0 {
+			return d
+		}
+	}
+	return 1
+}
+
+// ISqrt returns floor(sqrt(n)) using Newton's method on integers
+// Starting from a guess >= sqrt(n), each step x = (x + n/x) / 2 decreases
+// monotonically until it reaches floor(sqrt(n))
+// Time Complexity: O(log log n) iterations after the initial guess
+// Space Complexity: O(1)
+func ISqrt(n uint64) uint64 {
+	if n < 2 {
+		return n
+	}
+
+	// 2^ceil(bitlen/2) is always >= sqrt(n)
+	x := uint64(1) << ((bits.Len64(n) + 1) / 2)
+	for {
+		y := (x + n/x) / 2
+		if y >= x {
+			return x
+		}
+		x = y
+	}
+}
+
+// NaiveISqrt counts upwards until the next square exceeds n
+// Time Complexity: O(sqrt(n))
+func NaiveISqrt(n uint64) uint64 {
+	var i uint64
+	for (i+1)*(i+1) <= n {
+		i++
+	}
+	return i
+}
+
+// AddChecked returns a + b and whether the result fits in an int64
+func AddChecked(a, b int64) (int64, bool) {
+	sum := a + b
+	// Overflow happened if both operands have the same sign
+	// and the result has a different sign
+	if (a >= 0) == (b >= 0) && (sum >= 0) != (a >= 0) {
+		return sum, false
+	}
+	return sum, true
+}
+
+// SubChecked returns a - b and whether the result fits in an int64
+func SubChecked(a, b int64) (int64, bool) {
+	diff := a - b
+	// Overflow happened if the operands have different signs
+	// and the result's sign differs from a
+	if (a >= 0) != (b >= 0) && (diff >= 0) != (a >= 0) {
+		return diff, false
+	}
+	return diff, true
+}
+
+// MulChecked returns a * b and whether the result fits in an int64
+func MulChecked(a, b int64) (int64, bool) {
+	if a == 0 || b == 0 {
+		return 0, true
+	}
+	product := a * b
+	// MinInt64 * -1 overflows but the division check below can't detect it
+	if (a == -1 && b == math.MinInt64) || (b == -1 && a == math.MinInt64) {
+		return product, false
+	}
+	if product/b != a {
+		return product, false
+	}
+	return product, true
+}
+
+// NaiveMulChecked detects overflow by redoing the multiplication in float64
+// It is about as fast as MulChecked but wrong near the limits because
+// float64 has only 53 bits of precision
+func NaiveMulChecked(a, b int64) (int64, bool) {
+	exact := float64(a) * float64(b)
+	return a * b, exact >= math.MinInt64 && exact <= math.MaxInt64
+}
+
+// SaturatingAdd returns a + b clamped to [MinInt64, MaxInt64]
+func SaturatingAdd(a, b int64) int64 {
+	if sum, ok := AddChecked(a, b); ok {
+		return sum
+	}
+	if a > 0 {
+		return math.MaxInt64
+	}
+	return math.MinInt64
+}
+
+// SaturatingSub returns a - b clamped to [MinInt64, MaxInt64]
+func SaturatingSub(a, b int64) int64 {
+	if diff, ok := SubChecked(a, b); ok {
+		return diff
+	}
+	if a >= 0 {
+		return math.MaxInt64
+	}
+	return math.MinInt64
+}
+
+// SaturatingMul returns a * b clamped to [MinInt64, MaxInt64]
+func SaturatingMul(a, b int64) int64 {
+	if product, ok := MulChecked(a, b); ok {
+		return product
+	}
+	if (a < 0) != (b < 0) {
+		return math.MinInt64
+	}
+	return math.MaxInt64
+}
+
+// IsPowerOfTwo reports whether n is a power of two
+// A power of two has a single set bit, and n-1 flips exactly the bits below it
+func IsPowerOfTwo(n uint64) bool {
+	return n != 0 && n&(n-1) == 0
+}
+
+// NextPowerOfTwo returns the smallest power of two >= n
+// Returns 0 if the result does not fit in a uint64
+func NextPowerOfTwo(n uint64) uint64 {
+	if n <= 1 {
+		return 1
+	}
+	shift := bits.Len64(n - 1)
+	if shift == 64 {
+		return 0
+	}
+	return 1 << shift
+}
+
+// LowestSetBit isolates the lowest set bit of n
+// In two's complement, -n flips every bit above the lowest set bit
+func LowestSetBit(n uint64) uint64 {
+	return n & -n
+}
+
+// PopCount counts the set bits using Kernighan's trick
+// n & (n-1) clears the lowest set bit, so the loop runs once per set bit
+// Time Complexity: O(number of set bits)
+func PopCount(n uint64) int {
+	count := 0
+	for n != 0 {
+		n &= n - 1
+		count++
+	}
+	return count
+}
+
+// NaivePopCount tests every one of the 64 bits
+// Time Complexity: O(64)
+func NaivePopCount(n uint64) int {
+	count := 0
+	for i := 0; i < 64; i++ {
+		if n&(1<<i) != 0 {
+			count++
+		}
+	}
+	return count
+}
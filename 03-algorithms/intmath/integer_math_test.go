@@ -0,0 +1,233 @@
+package intmath
+
+import (
+	"math"
+	"math/big"
+	"math/bits"
+	"testing"
+	"testing/quick"
+)
+
+func TestGCD(t *testing.T) {
+	tests := []struct {
+		a, b, want uint64
+	}{
+		{0, 0, 0},
+		{0, 9, 9},
+		{9, 0, 9},
+		{48, 18, 6},
+		{1071, 462, 21},
+		{17, 5, 1},
+		{1 << 40, 1 << 20, 1 << 20},
+		{math.MaxUint64, math.MaxUint64, math.MaxUint64},
+	}
+	for _, tt := range tests {
+		if got := GCD(tt.a, tt.b); got != tt.want {
+			t.Errorf("GCD(%d, %d) = %d, want %d", tt.a, tt.b, got, tt.want)
+		}
+		if got := BinaryGCD(tt.a, tt.b); got != tt.want {
+			t.Errorf("BinaryGCD(%d, %d) = %d, want %d", tt.a, tt.b, got, tt.want)
+		}
+	}
+}
+
+// Property: both fast versions agree with each other on the full range,
+// and with NaiveGCD on inputs small enough for its linear scan
+func TestGCDProperty(t *testing.T) {
+	agree := func(a, b uint64) bool {
+		g := GCD(a, b)
+		return BinaryGCD(a, b) == g && (g == 0 || a%g == 0 && b%g == 0)
+	}
+	naive := func(a, b uint16) bool {
+		return GCD(uint64(a), uint64(b)) == NaiveGCD(uint64(a), uint64(b))
+	}
+	if err := quick.Check(agree, nil); err != nil {
+		t.Error(err)
+	}
+	if err := quick.Check(naive, nil); err != nil {
+		t.Error(err)
+	}
+}
+
+func TestISqrt(t *testing.T) {
+	tests := []struct {
+		n, want uint64
+	}{
+		{0, 0}, {1, 1}, {2, 1}, {3, 1}, {4, 2},
+		{15, 3}, {16, 4}, {17, 4},
+		{1 << 62, 1 << 31},
+		{math.MaxUint64, math.MaxUint32},
+	}
+	for _, tt := range tests {
+		if got := ISqrt(tt.n); got != tt.want {
+			t.Errorf("ISqrt(%d) = %d, want %d", tt.n, got, tt.want)
+		}
+	}
+	for n := uint64(0); n < 10000; n++ {
+		if got, want := ISqrt(n), NaiveISqrt(n); got != want {
+			t.Fatalf("ISqrt(%d) = %d, NaiveISqrt = %d", n, got, want)
+		}
+	}
+}
+
+// Property: r = ISqrt(n) satisfies r² <= n < (r+1)²
+// The squares are computed in 128 bits so they cannot overflow
+func TestISqrtProperty(t *testing.T) {
+	property := func(n uint64) bool {
+		r := ISqrt(n)
+		hi, lo := bits.Mul64(r, r)
+		if hi != 0 || lo > n {
+			return false
+		}
+		hi, lo = bits.Mul64(r+1, r+1)
+		return hi != 0 || lo > n
+	}
+	if err := quick.Check(property, nil); err != nil {
+		t.Error(err)
+	}
+}
+
+var (
+	minInt64 = big.NewInt(math.MinInt64)
+	maxInt64 = big.NewInt(math.MaxInt64)
+)
+
+// exact computes op(a, b) with arbitrary precision and reports
+// whether it fits in an int64
+func exact(op func(z, x, y *big.Int) *big.Int, a, b int64) (*big.Int, bool) {
+	z := op(new(big.Int), big.NewInt(a), big.NewInt(b))
+	return z, z.Cmp(minInt64) >= 0 && z.Cmp(maxInt64) <= 0
+}
+
+// clamp saturates an arbitrary-precision result to the int64 range
+func clamp(z *big.Int) int64 {
+	switch {
+	case z.Cmp(maxInt64) > 0:
+		return math.MaxInt64
+	case z.Cmp(minInt64) < 0:
+		return math.MinInt64
+	}
+	return z.Int64()
+}
+
+var checkedOps = []struct {
+	name       string
+	checked    func(a, b int64) (int64, bool)
+	saturating func(a, b int64) int64
+	exact      func(z, x, y *big.Int) *big.Int
+}{
+	{"Add", AddChecked, SaturatingAdd, (*big.Int).Add},
+	{"Sub", SubChecked, SaturatingSub, (*big.Int).Sub},
+	{"Mul", MulChecked, SaturatingMul, (*big.Int).Mul},
+}
+
+func TestCheckedArithmetic(t *testing.T) {
+	edges := []int64{0, 1, -1, 2, -2, 1 << 31, 1 << 32, -1 << 32, math.MaxInt64, math.MinInt64, math.MaxInt64 - 1, math.MinInt64 + 1}
+	for _, op := range checkedOps {
+		for _, a := range edges {
+			for _, b := range edges {
+				want, fits := exact(op.exact, a, b)
+				got, ok := op.checked(a, b)
+				if ok != fits || (fits && got != want.Int64()) {
+					t.Errorf("%sChecked(%d, %d) = %d, %v; want %v, %v", op.name, a, b, got, ok, want, fits)
+				}
+				if got, want := op.saturating(a, b), clamp(want); got != want {
+					t.Errorf("Saturating%s(%d, %d) = %d, want %d", op.name, a, b, got, want)
+				}
+			}
+		}
+	}
+}
+
+// Property: checked and saturating arithmetic agree with math/big
+// quick draws int64s from the full range, so overflow is common
+func TestCheckedArithmeticProperty(t *testing.T) {
+	for _, op := range checkedOps {
+		t.Run(op.name, func(t *testing.T) {
+			property := func(a, b int64) bool {
+				want, fits := exact(op.exact, a, b)
+				got, ok := op.checked(a, b)
+				if ok != fits || (fits && got != want.Int64()) {
+					return false
+				}
+				return op.saturating(a, b) == clamp(want)
+			}
+			if err := quick.Check(property, nil); err != nil {
+				t.Error(err)
+			}
+		})
+	}
+}
+
+// NaiveMulChecked is documented as wrong near the limits; pin that down
+// so the demo keeps demonstrating a real difference
+func TestNaiveMulCheckedMissesOverflow(t *testing.T) {
+	if _, ok := NaiveMulChecked(1<<31, 1<<32); !ok {
+		t.Error("NaiveMulChecked(2^31, 2^32) detected the overflow; the demo relies on it missing it")
+	}
+	if _, ok := NaiveMulChecked(3, 4); !ok {
+		t.Error("NaiveMulChecked(3, 4) reported overflow")
+	}
+}
+
+func TestPowerOfTwo(t *testing.T) {
+	tests := []struct {
+		n      uint64
+		isPow2 bool
+		next   uint64
+		lowest uint64
+	}{
+		{0, false, 1, 0},
+		{1, true, 1, 1},
+		{2, true, 2, 2},
+		{3, false, 4, 1},
+		{6, false, 8, 2},
+		{64, true, 64, 64},
+		{100, false, 128, 4},
+		{1 << 63, true, 1 << 63, 1 << 63},
+		{1<<63 + 1, false, 0, 1}, // Next power of two overflows
+	}
+	for _, tt := range tests {
+		if got := IsPowerOfTwo(tt.n); got != tt.isPow2 {
+			t.Errorf("IsPowerOfTwo(%d) = %v, want %v", tt.n, got, tt.isPow2)
+		}
+		if got := NextPowerOfTwo(tt.n); got != tt.next {
+			t.Errorf("NextPowerOfTwo(%d) = %d, want %d", tt.n, got, tt.next)
+		}
+		if got := LowestSetBit(tt.n); got != tt.lowest {
+			t.Errorf("LowestSetBit(%d) = %d, want %d", tt.n, got, tt.lowest)
+		}
+	}
+}
+
+func TestBitTricksProperty(t *testing.T) {
+	isPow2 := func(n uint64) bool {
+		return IsPowerOfTwo(n) == (bits.OnesCount64(n) == 1)
+	}
+	next := func(n uint64) bool {
+		p := NextPowerOfTwo(n)
+		if p == 0 {
+			return n > 1<<63
+		}
+		return IsPowerOfTwo(p) && p >= n && (p == 1 || p/2 < n)
+	}
+	lowest := func(n uint64) bool {
+		if n == 0 {
+			return LowestSetBit(n) == 0
+		}
+		return LowestSetBit(n) == 1<<bits.TrailingZeros64(n)
+	}
+	popCount := func(n uint64) bool {
+		return PopCount(n) == bits.OnesCount64(n) && NaivePopCount(n) == bits.OnesCount64(n)
+	}
+	for name, property := range map[string]any{
+		"IsPowerOfTwo":   isPow2,
+		"NextPowerOfTwo": next,
+		"LowestSetBit":   lowest,
+		"PopCount":       popCount,
+	} {
+		if err := quick.Check(property, nil); err != nil {
+			t.Errorf("%s: %v", name, err)
+		}
+	}
+}
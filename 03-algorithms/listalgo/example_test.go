@@ -0,0 +1,20 @@
+package listalgo_test
+
+import (
+	"github.com/NutProhmpiriya/go-basic/02-data-structures/datastructures"
+	"github.com/NutProhmpiriya/go-basic/03-algorithms/listalgo"
+)
+
+func ExampleSort() {
+	var l datastructures.LinkedList
+	for _, v := range []int{4, 1, 3, 2} {
+		l.InsertBack(v)
+	}
+	listalgo.Sort(&l)
+	l.Print()
+	listalgo.Reorder(&l)
+	l.Print()
+	// Output:
+	// 1 -> 2 -> 3 -> 4 -> nil
+	// 1 -> 4 -> 2 -> 3 -> nil
+}
@@ -0,0 +1,192 @@
+// This file implements classic singly linked list algorithms in Go
+// They work on the LinkedList of 02-data-structures, taking its nodes out
+// with TakeNodes, relinking them and handing them back with SetNodes, so
+// no values are copied and no nodes are allocated
+//
+// Algorithms:
+// 1. Merge two sorted lists into one
+// 2. Merge sort, which suits lists better than quicksort: splitting at the
+//    middle and merging only follow next pointers, with no random access
+// 3. Intersection: the first node two lists share
+// 4. Palindrome check in O(1) extra space
+// 5. Reorder L0 → Ln → L1 → Ln-1 → ...
+//
+// Most of them combine the same three moves: find the middle with a fast
+// and a slow pointer, reverse a half, and weave two chains together
+
+// Package listalgo implements algorithms on singly linked lists
+package listalgo
+
+import "github.com/NutProhmpiriya/go-basic/02-data-structures/datastructures"
+
+type (
+	// LinkedList is the singly linked list of the datastructures package
+	LinkedList = datastructures.LinkedList
+	// Node is a node of a LinkedList
+	Node = datastructures.Node
+)
+
+// relink makes head the contents of l
+// The chains the algorithms build come from lists and end in nil, so
+// SetNodes cannot find a cycle
+func relink(l *LinkedList, head *Node) {
+	if err := l.SetNodes(head); err != nil {
+		panic("listalgo: " + err.Error())
+	}
+}
+
+// Merge moves the nodes of two sorted lists into a new sorted list and
+// returns it; a and b are empty afterwards
+// Equal values keep their order, those from a first, so the merge is stable
+// Time Complexity: O(n + m)
+// Space Complexity: O(1): the nodes are relinked, not copied
+func Merge(a, b *LinkedList) *LinkedList {
+	merged := &LinkedList{}
+	relink(merged, mergeNodes(a.TakeNodes(), b.TakeNodes()))
+	return merged
+}
+
+// mergeNodes merges two sorted chains by always taking the smaller head
+// A dummy node in front saves treating the first node as a special case
+func mergeNodes(a, b *Node) *Node {
+	var dummy Node
+	tail := &dummy
+	for a != nil && b != nil {
+		if b.Value() < a.Value() {
+			tail.SetNext(b)
+			b = b.Next()
+		} else {
+			tail.SetNext(a)
+			a = a.Next()
+		}
+		tail = tail.Next()
+	}
+	// One chain is used up; the rest of the other is already sorted
+	if a != nil {
+		tail.SetNext(a)
+	} else {
+		tail.SetNext(b)
+	}
+	return dummy.Next()
+}
+
+// Sort sorts the list in ascending order with merge sort
+// It is stable, and unlike sorting an array it needs no buffer: merging
+// relinks nodes instead of copying values
+// Time Complexity: O(n log n)
+// Space Complexity: O(log n) for the recursion
+func Sort(l *LinkedList) {
+	relink(l, mergeSort(l.TakeNodes()))
+}
+
+// mergeSort sorts the chain starting at head and returns its new head
+func mergeSort(head *Node) *Node {
+	if head == nil || head.Next() == nil {
+		return head
+	}
+	// Cut the chain after its middle node, so a chain of two splits in one
+	// and one rather than two and none
+	mid := middle(head)
+	second := mid.Next()
+	mid.SetNext(nil)
+	return mergeNodes(mergeSort(head), mergeSort(second))
+}
+
+// middle returns the last node of the first half: the middle node for an
+// odd length, the first of the two middle nodes for an even one
+// The fast pointer starts one ahead, which is what picks the first of two
+func middle(head *Node) *Node {
+	slow, fast := head, head.Next()
+	for fast != nil && fast.Next() != nil {
+		slow, fast = slow.Next(), fast.Next().Next()
+	}
+	return slow
+}
+
+// reverse reverses the chain starting at head and returns its new head
+func reverse(head *Node) *Node {
+	var prev *Node
+	for head != nil {
+		next := head.Next()
+		head.SetNext(prev)
+		prev, head = head, next
+	}
+	return prev
+}
+
+// Intersection returns the first node that a and b share, where their
+// chains join into one, or nil if they never do. Nodes are compared, not
+// values: two lists can hold equal values without sharing nodes
+// Two pointers walk a then b and b then a. Both cover the same distance,
+// len(a) + len(b), so they arrive at the shared part together, or reach
+// the end together when there is none
+// Time Complexity: O(n + m)
+// Space Complexity: O(1)
+func Intersection(a, b *LinkedList) *Node {
+	pa, pb := a.Front(), b.Front()
+	for pa != pb {
+		if pa == nil {
+			pa = b.Front()
+		} else {
+			pa = pa.Next()
+		}
+		if pb == nil {
+			pb = a.Front()
+		} else {
+			pb = pb.Next()
+		}
+	}
+	return pa
+}
+
+// IsPalindrome reports whether the list reads the same forwards and
+// backwards. It reverses the second half in place to compare it with the
+// first, then reverses it back, so the list is unchanged afterwards
+// Time Complexity: O(n)
+// Space Complexity: O(1), where copying the values out would take O(n)
+func IsPalindrome(l *LinkedList) bool {
+	head := l.Front()
+	if head == nil {
+		return true
+	}
+	mid := middle(head)
+	second := reverse(mid.Next())
+
+	palindrome := true
+	// The second half is never longer than the first
+	for a, b := head, second; b != nil; a, b = a.Next(), b.Next() {
+		if a.Value() != b.Value() {
+			palindrome = false
+			break
+		}
+	}
+
+	mid.SetNext(reverse(second))
+	return palindrome
+}
+
+// Reorder rearranges the list from L0 → L1 → ... → Ln into
+// L0 → Ln → L1 → Ln-1 → L2 → ..., alternating between the front and the
+// back. It splits the list at the middle, reverses the second half and
+// weaves the two halves together
+// Time Complexity: O(n)
+// Space Complexity: O(1)
+func Reorder(l *LinkedList) {
+	head := l.TakeNodes()
+	if head == nil {
+		return
+	}
+	mid := middle(head)
+	second := reverse(mid.Next())
+	mid.SetNext(nil)
+
+	// The first half is as long as the second, or one longer, so it keeps
+	// the last node when the length is odd
+	for first := head; second != nil; {
+		nextFirst, nextSecond := first.Next(), second.Next()
+		first.SetNext(second)
+		second.SetNext(nextFirst)
+		first, second = nextFirst, nextSecond
+	}
+	relink(l, head)
+}
@@ -0,0 +1,242 @@
+package listalgo
+
+import (
+	"fmt"
+	"slices"
+	"testing"
+
+	"github.com/NutProhmpiriya/go-basic/testdata/generator"
+)
+
+func newList(values ...int) *LinkedList {
+	l := &LinkedList{}
+	for _, v := range values {
+		l.InsertBack(v)
+	}
+	return l
+}
+
+// checkList compares the list with want, walking it through its nodes, and
+// checks that the length and the tail agree, so relinking kept them up to date
+func checkList(t *testing.T, l *LinkedList, want []int) {
+	t.Helper()
+	got := []int{}
+	for n := l.Front(); n != nil; n = n.Next() {
+		got = append(got, n.Value())
+	}
+	if !slices.Equal(got, want) {
+		t.Errorf("list = %v, want %v", got, want)
+	}
+	if l.Len() != len(want) {
+		t.Errorf("Len() = %d, want %d", l.Len(), len(want))
+	}
+	// Appending goes through the tail pointer
+	l.InsertBack(-1)
+	if v, err := l.Get(len(want)); err != nil || v != -1 {
+		t.Errorf("the tail is stale: appended -1 but Get(%d) = %d, %v", len(want), v, err)
+	}
+}
+
+func TestMerge(t *testing.T) {
+	tests := []struct {
+		name string
+		a, b []int
+		want []int
+	}{
+		{"both empty", nil, nil, []int{}},
+		{"one empty", []int{1, 2}, nil, []int{1, 2}},
+		{"other empty", nil, []int{1, 2}, []int{1, 2}},
+		{"interleaved", []int{1, 4, 6}, []int{2, 3, 5, 7}, []int{1, 2, 3, 4, 5, 6, 7}},
+		{"one after the other", []int{5, 6}, []int{1, 2}, []int{1, 2, 5, 6}},
+		{"duplicates", []int{1, 1, 3}, []int{1, 3}, []int{1, 1, 1, 3, 3}},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			a, b := newList(tt.a...), newList(tt.b...)
+			merged := Merge(a, b)
+			checkList(t, merged, tt.want)
+			if a.Len() != 0 || b.Len() != 0 {
+				t.Errorf("inputs not emptied: lengths %d and %d", a.Len(), b.Len())
+			}
+		})
+	}
+
+	// Stable: on equal values the node from a comes first
+	a, b := newList(1, 2), newList(1, 2)
+	firstA := a.Front()
+	if Merge(a, b).Front() != firstA {
+		t.Error("Merge put b's 1 before a's")
+	}
+}
+
+func TestSort(t *testing.T) {
+	g := generator.New(1)
+	tests := []struct {
+		name   string
+		values []int
+	}{
+		{"empty", nil},
+		{"single", []int{1}},
+		{"two swapped", []int{2, 1}},
+		{"sorted", generator.Sorted(100)},
+		{"reversed", generator.Reversed(100)},
+		{"few unique", g.FewUnique(1000, 5)},
+		{"random", g.Ints(1001, 1000)},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			l := newList(tt.values...)
+			Sort(l)
+			want := slices.Sorted(slices.Values(tt.values))
+			if want == nil {
+				want = []int{}
+			}
+			checkList(t, l, want)
+		})
+	}
+
+	// A long input sorts without deep recursion: only log n levels
+	const n = 200_000
+	l := newList(generator.Reversed(n)...)
+	Sort(l)
+	last, _ := l.Get(n - 1)
+	if first := l.Front().Value(); l.Len() != n || first != 0 || last != n-1 {
+		t.Errorf("Sort of %d reversed values gave %d nodes from %d to %d", n, l.Len(), first, last)
+	}
+}
+
+func TestIntersection(t *testing.T) {
+	// a: 1 → 2 ↘
+	//            7 → 8 → 9
+	// b:     4 ↗
+	shared := newList(7, 8, 9)
+	join := func(prefix []int, tail *LinkedList) *LinkedList {
+		l := newList(prefix...)
+		head := l.TakeNodes()
+		if head == nil {
+			head = tail.Front()
+		} else {
+			last := head
+			for last.Next() != nil {
+				last = last.Next()
+			}
+			last.SetNext(tail.Front())
+		}
+		if err := l.SetNodes(head); err != nil {
+			t.Fatal(err)
+		}
+		return l
+	}
+
+	tests := []struct {
+		name string
+		a, b *LinkedList
+		want *Node
+	}{
+		{"different lengths", join([]int{1, 2}, shared), join([]int{4}, shared), shared.Front()},
+		{"same length", join([]int{1}, shared), join([]int{4}, shared), shared.Front()},
+		{"one is the tail of the other", join([]int{1, 2}, shared), shared, shared.Front()},
+		{"same list", shared, shared, shared.Front()},
+		// Equal values are not shared nodes
+		{"equal values only", newList(1, 7, 8, 9), newList(7, 8, 9), nil},
+		{"disjoint", newList(1, 2, 3), newList(4, 5), nil},
+		{"one empty", newList(1), newList(), nil},
+		{"both empty", newList(), newList(), nil},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := Intersection(tt.a, tt.b); got != tt.want {
+				t.Errorf("Intersection() = %v, want %v", got, tt.want)
+			}
+			if got := Intersection(tt.b, tt.a); got != tt.want {
+				t.Errorf("Intersection() with the lists swapped = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestIsPalindrome(t *testing.T) {
+	tests := []struct {
+		values []int
+		want   bool
+	}{
+		{nil, true},
+		{[]int{1}, true},
+		{[]int{1, 1}, true},
+		{[]int{1, 2}, false},
+		{[]int{1, 2, 1}, true},
+		{[]int{1, 2, 2, 1}, true},
+		{[]int{1, 2, 3, 1}, false},
+		{[]int{1, 2, 3, 2, 1}, true},
+		{[]int{1, 2, 3, 3, 1}, false},
+		{[]int{2, 1, 1, 1}, false},
+	}
+	for _, tt := range tests {
+		l := newList(tt.values...)
+		if got := IsPalindrome(l); got != tt.want {
+			t.Errorf("IsPalindrome(%v) = %v, want %v", tt.values, got, tt.want)
+		}
+		// The list is put back the way it was
+		want := slices.Clone(tt.values)
+		if want == nil {
+			want = []int{}
+		}
+		checkList(t, l, want)
+	}
+}
+
+func TestReorder(t *testing.T) {
+	tests := []struct {
+		values []int
+		want   []int
+	}{
+		{nil, []int{}},
+		{[]int{1}, []int{1}},
+		{[]int{1, 2}, []int{1, 2}},
+		{[]int{1, 2, 3}, []int{1, 3, 2}},
+		{[]int{1, 2, 3, 4}, []int{1, 4, 2, 3}},
+		{[]int{1, 2, 3, 4, 5}, []int{1, 5, 2, 4, 3}},
+		{[]int{1, 2, 3, 4, 5, 6}, []int{1, 6, 2, 5, 3, 4}},
+	}
+	for _, tt := range tests {
+		l := newList(tt.values...)
+		Reorder(l)
+		checkList(t, l, tt.want)
+	}
+}
+
+// BenchmarkSort compares merge sorting the nodes with copying the values
+// into a slice, sorting that and building a new list. The slice is faster,
+// since its values sit next to each other in memory, but it allocates the
+// slice and a node per value where Sort allocates nothing
+func BenchmarkSort(b *testing.B) {
+	for _, n := range []int{1_000, 100_000} {
+		values := generator.New(1).Perm(n)
+		b.Run(fmt.Sprintf("impl=Sort/n=%d", n), func(b *testing.B) {
+			b.ReportAllocs()
+			for range b.N {
+				b.StopTimer()
+				l := newList(values...)
+				b.StartTimer()
+				Sort(l)
+			}
+		})
+		b.Run(fmt.Sprintf("impl=slice/n=%d", n), func(b *testing.B) {
+			b.ReportAllocs()
+			for range b.N {
+				b.StopTimer()
+				l := newList(values...)
+				b.StartTimer()
+				sorted := make([]int, 0, l.Len())
+				for node := l.Front(); node != nil; node = node.Next() {
+					sorted = append(sorted, node.Value())
+				}
+				slices.Sort(sorted)
+				l = &LinkedList{}
+				for _, v := range sorted {
+					l.InsertBack(v)
+				}
+			}
+		})
+	}
+}
@@ -0,0 +1,110 @@
+// Command 03-algorithms runs the algorithm demos
+//
+// Usage (from the repository root, so the default -deps and -roads paths resolve):
+//
+//	go run ./03-algorithms                          # run every demo
+//	go run ./03-algorithms -list                    # list the demos
+//	go run ./03-algorithms -demo=sorting            # run one demo
+//	go run ./03-algorithms -demo=sorting -seed=7    # with other generated inputs
+//	go run ./03-algorithms -timings=false           # skip the benchmarks
+//	go run ./03-algorithms -demo=build-resolver -deps 03-algorithms/data/cyclic_deps.txt
+//	go run ./03-algorithms -demo=road-network -roads 03-algorithms/data/roads.csv
+//
+// Each algorithm family lives in its own package below this directory
+package main
+
+import (
+	"flag"
+	"fmt"
+	"os"
+	"strings"
+)
+
+// Demo is a runnable example of one algorithm family
+type Demo struct {
+	// Name is the identifier used with -demo
+	Name  string
+	Title string
+	Run   func()
+	// Timings, if set, measures the algorithms after Run
+	// It is kept apart because its output changes from run to run, while
+	// the output of Run is checked by the examples in example_test.go
+	Timings func()
+}
+
+// demos is the registry of all demos, in the order they run
+var demos = []Demo{
+	{"sorting", "Sorting", runSorting, sortingTimings},
+	{"stability", "Sorting Stability", runStability, nil},
+	{"searching", "Searching", runSearching, nil},
+	{"strings", "String Algorithms", runStringAlgorithms, nil},
+	{"dp", "Dynamic Programming", runDynamicProgramming, nil},
+	{"greedy", "Greedy Algorithms", runGreedy, nil},
+	{"trees", "Tree Algorithms", runTreeAlgorithms, nil},
+	{"lists", "Linked List Algorithms", runListAlgorithms, nil},
+	{"heaps", "Heap Algorithms", runHeapAlgorithms, nil},
+	{"intmath", "Integer Math", runIntegerMath, integerMathTimings},
+	{"stats", "Streaming Statistics", runStreamingStats, streamingStatsTimings},
+	{"sampling", "Weighted Sampling", runWeightedSampling, nil},
+	{"build-resolver", "Build Dependency Resolver", runBuildResolver, nil},
+	{"road-network", "Road Network", runRoadNetwork, nil},
+	{"parallel-graph", "Parallel Graph Processing", runParallelGraph, parallelGraphTimings},
+}
+
+// depsFile is the dependency file read by the build-resolver demo
+var depsFile = flag.String("deps", "03-algorithms/data/build_deps.txt", "dependency file for the build-resolver demo")
+
+// roadsFile is the road network read by the road-network demo
+var roadsFile = flag.String("roads", "03-algorithms/data/roads.geojson", "road network (.geojson or .csv) for the road-network demo")
+
+// seed makes the generated inputs of the demos reproducible
+var seed = flag.Int64("seed", 1, "seed for the generated inputs")
+
+// findDemo looks a demo up by name
+func findDemo(name string) (Demo, bool) {
+	for _, d := range demos {
+		if d.Name == name {
+			return d, true
+		}
+	}
+	return Demo{}, false
+}
+
+func main() {
+	names := flag.String("demo", "", "comma-separated demos to run (default: all)")
+	list := flag.Bool("list", false, "list the available demos")
+	timings := flag.Bool("timings", true, "measure the algorithms after the demos that have timings")
+	flag.Parse()
+
+	if *list {
+		for _, d := range demos {
+			fmt.Printf("  %-15s %s\n", d.Name, d.Title)
+		}
+		return
+	}
+
+	selected := demos
+	if *names != "" {
+		selected = nil
+		for _, name := range strings.Split(*names, ",") {
+			d, ok := findDemo(strings.TrimSpace(name))
+			if !ok {
+				fmt.Fprintf(os.Stderr, "unknown demo %q; run with -list to see the demos\n", name)
+				os.Exit(2)
+			}
+			selected = append(selected, d)
+		}
+	}
+
+	for i, d := range selected {
+		if i > 0 {
+			fmt.Println()
+		}
+		fmt.Printf("=== %s ===\n", d.Title)
+		d.Run()
+		if *timings && d.Timings != nil {
+			fmt.Println()
+			d.Timings()
+		}
+	}
+}
@@ -0,0 +1,185 @@
+// Package registry is a catalog of the algorithms in this repository, with
+// what their doc comments say about them and a way to run any of them
+//
+// Each algorithm package registers its algorithms from init, so a program
+// that imports the package can find them without a list of its own:
+//
+//	import _ "github.com/NutProhmpiriya/go-basic/03-algorithms/catalog"
+//
+//	for _, a := range registry.All() {
+//		in := registry.NewInput(a.Input, 1000, generator.New(1))
+//		fmt.Println(a.ID(), a.Time, a.Run(in))
+//	}
+//
+// The catalog package imports every package that registers algorithms.
+// The learn command runs each of them as an example, the gobasic complexity
+// command checks their claimed time complexities, and the catalog
+// benchmarks time every one of them at several sizes.
+package registry
+
+import (
+	"errors"
+	"fmt"
+	"slices"
+	"strings"
+
+	"github.com/NutProhmpiriya/go-basic/testdata/generator"
+)
+
+// ErrUnknownAlgorithm is returned when looking up an algorithm that was never registered
+var ErrUnknownAlgorithm = errors.New("unknown algorithm")
+
+// Kind is the kind of input an algorithm takes
+type Kind int
+
+const (
+	// Ints is unsorted values in Input.Ints, e.g. for a sort
+	// They are below max(100, 10n), so they stay readable and few repeat
+	Ints Kind = iota
+	// SortedInts is sorted values in Input.Ints and one of them in
+	// Input.Target, e.g. for a search
+	SortedInts
+	// Text is a text in Input.Text and a short pattern to find in it in
+	// Input.Pattern
+	Text
+	// StringPair is two strings of the same length in Input.Text and
+	// Input.Pattern, e.g. for an edit distance
+	StringPair
+)
+
+var kindNames = []string{"ints", "sorted ints", "text", "string pair"}
+
+func (k Kind) String() string {
+	if k < 0 || int(k) >= len(kindNames) {
+		return fmt.Sprintf("Kind(%d)", int(k))
+	}
+	return kindNames[k]
+}
+
+// Input is what an algorithm runs on. It holds every kind of input, and
+// an algorithm reads the fields its Kind fills in
+type Input struct {
+	Ints    []int
+	Target  int
+	Text    string
+	Pattern string
+}
+
+// alphabet keeps the generated strings to four letters, so patterns occur
+// in the text and edit distances aren't all n
+const alphabet = "ACGT"
+
+// NewInput generates an input of size n for an algorithm of kind k
+// The same generator seed gives the same input
+func NewInput(k Kind, n int, gen *generator.Generator) Input {
+	switch k {
+	case SortedInts:
+		in := Input{Ints: generator.Sorted(n)}
+		if n > 0 {
+			in.Target = in.Ints[gen.Rand().Intn(n)]
+		}
+		return in
+	case Text:
+		return Input{Text: gen.String(n, alphabet), Pattern: gen.String(min(n, 4), alphabet)}
+	case StringPair:
+		return Input{Text: gen.String(n, alphabet), Pattern: gen.String(n, alphabet)}
+	default:
+		return Input{Ints: gen.Ints(n, max(100, 10*n))}
+	}
+}
+
+// Algorithm is a registered algorithm
+type Algorithm struct {
+	// Name is unique within its Category, e.g. "quicksort"
+	Name string
+	// Category is the topic, usually the package, e.g. "sorting"
+	Category string
+	// Summary is one line about what the algorithm does
+	Summary string
+	// Time and Space are the complexities its doc comment claims, e.g. "O(n log n)"
+	Time  string
+	Space string
+	// Input is the kind of input Run expects
+	Input Kind
+	// Run runs the algorithm on in and returns its result
+	// It must leave in as it found it, so an in-place sort works on a copy
+	Run func(in Input) any
+}
+
+// ID is "category/name", the name Lookup finds the algorithm by
+func (a Algorithm) ID() string {
+	return a.Category + "/" + a.Name
+}
+
+var (
+	registry = make(map[string]Algorithm)
+	order    []string
+)
+
+// Register adds an algorithm to the registry
+// It is meant to be called from init and panics on a duplicate or a missing
+// field, like complexity.Register does
+func Register(a Algorithm) {
+	switch {
+	case a.Name == "" || a.Category == "" || strings.Contains(a.Name+a.Category, "/"):
+		panic(fmt.Sprintf("registry: bad name %q or category %q", a.Name, a.Category))
+	case a.Run == nil || a.Time == "":
+		panic("registry: " + a.ID() + " has no Run or Time")
+	}
+	if _, dup := registry[a.ID()]; dup {
+		panic("registry: Register called twice for " + a.ID())
+	}
+	registry[a.ID()] = a
+	order = append(order, a.ID())
+}
+
+// All returns the registered algorithms in registration order
+func All() []Algorithm {
+	all := make([]Algorithm, len(order))
+	for i, id := range order {
+		all[i] = registry[id]
+	}
+	return all
+}
+
+// Category returns the algorithms of one category in registration order
+func Category(category string) []Algorithm {
+	var found []Algorithm
+	for _, a := range All() {
+		if a.Category == category {
+			found = append(found, a)
+		}
+	}
+	return found
+}
+
+// Categories returns the categories in alphabetical order
+func Categories() []string {
+	var categories []string
+	for _, a := range All() {
+		if !slices.Contains(categories, a.Category) {
+			categories = append(categories, a.Category)
+		}
+	}
+	slices.Sort(categories)
+	return categories
+}
+
+// Lookup returns the algorithm registered as "category/name"
+func Lookup(id string) (Algorithm, error) {
+	a, ok := registry[id]
+	if !ok {
+		return Algorithm{}, fmt.Errorf("%w %q", ErrUnknownAlgorithm, id)
+	}
+	return a, nil
+}
+
+// InPlace adapts a sort that works in place to Run: it sorts a copy of
+// in.Ints and returns it
+func InPlace(sort func([]int)) func(in Input) any {
+	return func(in Input) any {
+		out := slices.Clone(in.Ints)
+		sort(out)
+		return out
+	}
+}
@@ -0,0 +1,93 @@
+package registry
+
+import (
+	"errors"
+	"slices"
+	"testing"
+
+	"github.com/NutProhmpiriya/go-basic/testdata/generator"
+)
+
+// register adds an algorithm for the duration of a test
+func register(t *testing.T, a Algorithm) {
+	t.Helper()
+	Register(a)
+	t.Cleanup(func() {
+		delete(registry, a.ID())
+		order = slices.DeleteFunc(order, func(id string) bool { return id == a.ID() })
+	})
+}
+
+func TestRegisterAndLookup(t *testing.T) {
+	reverse := func(in Input) any {
+		out := slices.Clone(in.Ints)
+		slices.Reverse(out)
+		return out
+	}
+	register(t, Algorithm{Name: "reverse", Category: "test", Time: "O(n)", Run: reverse})
+	register(t, Algorithm{Name: "sort", Category: "test", Time: "O(n log n)", Run: InPlace(slices.Sort[[]int])})
+
+	a, err := Lookup("test/reverse")
+	if err != nil || a.Name != "reverse" {
+		t.Fatalf("Lookup(test/reverse) = %v, %v", a, err)
+	}
+	if got := a.Run(Input{Ints: []int{1, 2, 3}}); !slices.Equal(got.([]int), []int{3, 2, 1}) {
+		t.Errorf("Run = %v", got)
+	}
+	if _, err := Lookup("test/shuffle"); !errors.Is(err, ErrUnknownAlgorithm) {
+		t.Errorf("Lookup(test/shuffle) error = %v, want ErrUnknownAlgorithm", err)
+	}
+	if got := Category("test"); len(got) != 2 || got[0].Name != "reverse" || got[1].Name != "sort" {
+		t.Errorf("Category(test) = %v, want reverse and sort in registration order", got)
+	}
+	if !slices.Contains(Categories(), "test") {
+		t.Errorf("Categories() = %v, want test among them", Categories())
+	}
+
+	in := Input{Ints: []int{3, 1, 2}}
+	if got := a.Run(in); !slices.Equal(in.Ints, []int{3, 1, 2}) {
+		t.Errorf("InPlace sort changed its input to %v, returned %v", in.Ints, got)
+	}
+}
+
+func TestRegisterPanics(t *testing.T) {
+	run := func(Input) any { return nil }
+	register(t, Algorithm{Name: "dup", Category: "test", Time: "O(1)", Run: run})
+	for name, a := range map[string]Algorithm{
+		"duplicate":   {Name: "dup", Category: "test", Time: "O(1)", Run: run},
+		"no name":     {Category: "test", Time: "O(1)", Run: run},
+		"no category": {Name: "x", Time: "O(1)", Run: run},
+		"slash":       {Name: "a/b", Category: "test", Time: "O(1)", Run: run},
+		"no Run":      {Name: "x", Category: "test", Time: "O(1)"},
+		"no Time":     {Name: "x", Category: "test", Run: run},
+	} {
+		func() {
+			defer func() {
+				if recover() == nil {
+					t.Errorf("Register of an algorithm with %s did not panic", name)
+				}
+			}()
+			Register(a)
+		}()
+	}
+}
+
+func TestNewInput(t *testing.T) {
+	gen := generator.New(1)
+	if in := NewInput(Ints, 50, gen); len(in.Ints) != 50 {
+		t.Errorf("Ints: %d values, want 50", len(in.Ints))
+	}
+	in := NewInput(SortedInts, 50, gen)
+	if !slices.IsSorted(in.Ints) || !slices.Contains(in.Ints, in.Target) {
+		t.Errorf("SortedInts: %v with target %d, want sorted values containing it", in.Ints, in.Target)
+	}
+	if in := NewInput(Text, 50, gen); len(in.Text) != 50 || len(in.Pattern) != 4 {
+		t.Errorf("Text: %q and %q, want 50 and 4 letters", in.Text, in.Pattern)
+	}
+	if in := NewInput(StringPair, 50, gen); len(in.Text) != 50 || len(in.Pattern) != 50 {
+		t.Errorf("StringPair: %q and %q, want 50 letters each", in.Text, in.Pattern)
+	}
+	if in := NewInput(SortedInts, 0, gen); len(in.Ints) != 0 {
+		t.Errorf("SortedInts of size 0 = %v", in)
+	}
+}
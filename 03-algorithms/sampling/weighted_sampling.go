@@ -0,0 +1,278 @@
+// This file implements weighted random sampling algorithms in Go
+// Weighted sampling picks item i with probability proportional to its weight
+//
+// Algorithms:
+// 1. Vose's alias method: O(n) setup, then O(1) per sample from a fixed distribution
+// 2. A-Res weighted reservoir sampling (Efraimidis & Spirakis): picks k items
+//    from a stream of unknown length in one pass with O(k) memory
+//
+// Applications shown in the 03-algorithms demo:
+// - A weighted load balancer choosing servers by capacity
+// - A Markov chain text generator choosing the next word by frequency
+//
+// A chi-squared goodness-of-fit test checks that observed counts match the weights
+
+// Package sampling implements weighted random sampling
+package sampling
+
+import (
+	"container/heap"
+	"errors"
+	"fmt"
+	"math"
+	"math/rand"
+	"sort"
+	"strings"
+)
+
+// AliasTable samples indexes 0..n-1 with probability proportional to their weights
+// The table splits the distribution into n columns of equal height 1/n.
+// Each column holds at most two outcomes: its own index (with probability prob[i])
+// and one "alias" that fills the rest of the column. Sampling picks a column
+// uniformly, then flips a biased coin between the index and its alias.
+type AliasTable struct {
+	prob  []float64
+	alias []int
+	rng   *rand.Rand
+}
+
+// NewAliasTable builds an alias table using Vose's algorithm
+// Time Complexity: O(n)
+// Space Complexity: O(n)
+func NewAliasTable(weights []float64, rng *rand.Rand) (*AliasTable, error) {
+	n := len(weights)
+	if n == 0 {
+		return nil, errors.New("alias table needs at least one weight")
+	}
+	total := 0.0
+	for _, w := range weights {
+		if w < 0 || math.IsNaN(w) || math.IsInf(w, 0) {
+			return nil, fmt.Errorf("invalid weight %v", w)
+		}
+		total += w
+	}
+	if total == 0 {
+		return nil, errors.New("weights must not all be zero")
+	}
+
+	t := &AliasTable{prob: make([]float64, n), alias: make([]int, n), rng: rng}
+
+	// Scale weights so the average column height is 1
+	scaled := make([]float64, n)
+	small, large := []int{}, []int{}
+	for i, w := range weights {
+		scaled[i] = w * float64(n) / total
+		if scaled[i] < 1 {
+			small = append(small, i)
+		} else {
+			large = append(large, i)
+		}
+	}
+
+	// Fill each short column with probability mass from a tall one
+	for len(small) > 0 && len(large) > 0 {
+		s, l := small[len(small)-1], large[len(large)-1]
+		small = small[:len(small)-1]
+		large = large[:len(large)-1]
+
+		t.prob[s] = scaled[s]
+		t.alias[s] = l
+
+		// The tall column gave away (1 - scaled[s])
+		scaled[l] -= 1 - scaled[s]
+		if scaled[l] < 1 {
+			small = append(small, l)
+		} else {
+			large = append(large, l)
+		}
+	}
+
+	// Leftovers are full columns (up to floating point error)
+	for _, i := range append(small, large...) {
+		t.prob[i] = 1
+	}
+	return t, nil
+}
+
+// Sample returns a random index chosen according to the weights
+// Time Complexity: O(1)
+func (t *AliasTable) Sample() int {
+	column := t.rng.Intn(len(t.prob))
+	if t.rng.Float64() < t.prob[column] {
+		return column
+	}
+	return t.alias[column]
+}
+
+// reservoirItem is an item in the reservoir together with its random key
+type reservoirItem struct {
+	value string
+	key   float64
+}
+
+// reservoirHeap is a min-heap by key, so the item to evict is at the top
+type reservoirHeap []reservoirItem
+
+func (h reservoirHeap) Len() int           { return len(h) }
+func (h reservoirHeap) Less(i, j int) bool { return h[i].key < h[j].key }
+func (h reservoirHeap) Swap(i, j int)      { h[i], h[j] = h[j], h[i] }
+func (h *reservoirHeap) Push(x interface{}) {
+	*h = append(*h, x.(reservoirItem))
+}
+func (h *reservoirHeap) Pop() interface{} {
+	old := *h
+	n := len(old)
+	x := old[n-1]
+	*h = old[0 : n-1]
+	return x
+}
+
+// WeightedReservoir keeps a weighted random sample of k items from a stream
+// A-Res gives every item the key u^(1/w) with u uniform in (0, 1) and keeps the
+// k items with the largest keys. Heavier items get keys closer to 1.
+type WeightedReservoir struct {
+	k     int
+	items reservoirHeap
+	rng   *rand.Rand
+}
+
+// NewWeightedReservoir creates a reservoir holding at most k items
+func NewWeightedReservoir(k int, rng *rand.Rand) *WeightedReservoir {
+	return &WeightedReservoir{k: k, rng: rng}
+}
+
+// Add offers an item from the stream to the reservoir
+// Items with weight <= 0 are never selected
+// Time Complexity: O(log k)
+func (r *WeightedReservoir) Add(value string, weight float64) {
+	if weight <= 0 || r.k <= 0 {
+		return
+	}
+	key := math.Pow(r.rng.Float64(), 1/weight)
+
+	if r.items.Len() < r.k {
+		heap.Push(&r.items, reservoirItem{value, key})
+	} else if key > r.items[0].key {
+		// Replace the item with the smallest key
+		r.items[0] = reservoirItem{value, key}
+		heap.Fix(&r.items, 0)
+	}
+}
+
+// Items returns the sampled items, highest key first
+func (r *WeightedReservoir) Items() []string {
+	sorted := append(reservoirHeap{}, r.items...)
+	sort.Slice(sorted, func(i, j int) bool { return sorted[i].key > sorted[j].key })
+	result := make([]string, len(sorted))
+	for i, item := range sorted {
+		result[i] = item.value
+	}
+	return result
+}
+
+// ChiSquared computes the chi-squared statistic sum((observed - expected)² / expected)
+func ChiSquared(observed []int, expected []float64) float64 {
+	stat := 0.0
+	for i := range observed {
+		diff := float64(observed[i]) - expected[i]
+		stat += diff * diff / expected[i]
+	}
+	return stat
+}
+
+// ChiSquaredCritical approximates the chi-squared critical value at the 0.1%
+// significance level using the Wilson-Hilferty transformation
+// A statistic above this value means the sampler is almost certainly biased
+func ChiSquaredCritical(degreesOfFreedom int) float64 {
+	const z = 3.090 // standard normal quantile for p = 0.999
+	k := float64(degreesOfFreedom)
+	return k * math.Pow(1-2/(9*k)+z*math.Sqrt(2/(9*k)), 3)
+}
+
+// ExpectedCounts converts weights into expected counts for n trials
+func ExpectedCounts(weights []float64, n int) []float64 {
+	total := 0.0
+	for _, w := range weights {
+		total += w
+	}
+	expected := make([]float64, len(weights))
+	for i, w := range weights {
+		expected[i] = w / total * float64(n)
+	}
+	return expected
+}
+
+// LoadBalancer routes requests to servers in proportion to their capacity
+type LoadBalancer struct {
+	servers []string
+	table   *AliasTable
+}
+
+// NewLoadBalancer creates a weighted load balancer
+// capacities[i] is the capacity of servers[i], so both need the same length
+func NewLoadBalancer(servers []string, capacities []float64, rng *rand.Rand) (*LoadBalancer, error) {
+	if len(servers) != len(capacities) {
+		return nil, fmt.Errorf("%d servers but %d capacities", len(servers), len(capacities))
+	}
+	table, err := NewAliasTable(capacities, rng)
+	if err != nil {
+		return nil, err
+	}
+	return &LoadBalancer{servers: servers, table: table}, nil
+}
+
+// Next picks the server for the next request in O(1)
+func (lb *LoadBalancer) Next() string {
+	return lb.servers[lb.table.Sample()]
+}
+
+// MarkovChain generates text where each word is chosen based on the previous one
+type MarkovChain struct {
+	next map[string][]string
+	// tables[w] samples from next[w] weighted by how often each word followed w
+	tables map[string]*AliasTable
+}
+
+// NewMarkovChain learns word transitions from text
+func NewMarkovChain(text string, rng *rand.Rand) *MarkovChain {
+	words := strings.Fields(strings.ToLower(text))
+	counts := make(map[string]map[string]int)
+	for i := 0; i+1 < len(words); i++ {
+		if counts[words[i]] == nil {
+			counts[words[i]] = make(map[string]int)
+		}
+		counts[words[i]][words[i+1]]++
+	}
+
+	m := &MarkovChain{next: make(map[string][]string), tables: make(map[string]*AliasTable)}
+	for word, followers := range counts {
+		candidates := make([]string, 0, len(followers))
+		for follower := range followers {
+			candidates = append(candidates, follower)
+		}
+		// Sort for reproducible output with a seeded rng
+		sort.Strings(candidates)
+		weights := make([]float64, len(candidates))
+		for i, c := range candidates {
+			weights[i] = float64(followers[c])
+		}
+		m.next[word] = candidates
+		m.tables[word], _ = NewAliasTable(weights, rng)
+	}
+	return m
+}
+
+// Generate produces up to n words starting from start
+func (m *MarkovChain) Generate(start string, n int) string {
+	words := []string{start}
+	current := start
+	for len(words) < n {
+		table, ok := m.tables[current]
+		if !ok {
+			break // Dead end: this word was never followed by anything
+		}
+		current = m.next[current][table.Sample()]
+		words = append(words, current)
+	}
+	return strings.Join(words, " ")
+}
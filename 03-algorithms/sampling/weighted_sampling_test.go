@@ -0,0 +1,275 @@
+package sampling
+
+import (
+	"math"
+	"math/rand"
+	"slices"
+	"strings"
+	"testing"
+	"testing/quick"
+)
+
+func TestNewAliasTableErrors(t *testing.T) {
+	rng := rand.New(rand.NewSource(1))
+	tests := map[string][]float64{
+		"empty":    {},
+		"negative": {1, -1},
+		"NaN":      {1, math.NaN()},
+		"infinite": {math.Inf(1), 1},
+		"all zero": {0, 0, 0},
+	}
+	for name, weights := range tests {
+		if table, err := NewAliasTable(weights, rng); err == nil {
+			t.Errorf("%s: NewAliasTable(%v) = %+v, want an error", name, weights, table)
+		}
+	}
+}
+
+// Property: the columns of the table add back up to the original distribution
+// Column i gives prob[i]/n to index i and the rest of its 1/n to alias[i]
+func TestAliasTableReconstructsWeights(t *testing.T) {
+	property := func(raw []uint8) bool {
+		weights := make([]float64, len(raw))
+		total := 0.0
+		for i, w := range raw {
+			weights[i] = float64(w)
+			total += weights[i]
+		}
+		table, err := NewAliasTable(weights, rand.New(rand.NewSource(1)))
+		if total == 0 {
+			return err != nil
+		}
+		if err != nil {
+			return false
+		}
+
+		n := float64(len(weights))
+		mass := make([]float64, len(weights))
+		for i, p := range table.prob {
+			mass[i] += p / n
+			mass[table.alias[i]] += (1 - p) / n
+		}
+		for i, w := range weights {
+			if math.Abs(mass[i]-w/total) > 1e-9 {
+				return false
+			}
+		}
+		return true
+	}
+	if err := quick.Check(property, nil); err != nil {
+		t.Error(err)
+	}
+}
+
+// sampleCounts draws n samples and counts how often each index came up
+func sampleCounts(sample func() int, size, n int) []int {
+	counts := make([]int, size)
+	for i := 0; i < n; i++ {
+		counts[sample()]++
+	}
+	return counts
+}
+
+func TestAliasTableGoodnessOfFit(t *testing.T) {
+	tests := map[string][]float64{
+		"uniform":   {1, 1, 1, 1},
+		"skewed":    {1, 2, 4, 8, 16, 32},
+		"one heavy": {1000, 1, 1},
+		"fractions": {0.1, 0.25, 0.65},
+	}
+	const n = 100000
+	for name, weights := range tests {
+		table, err := NewAliasTable(weights, rand.New(rand.NewSource(3)))
+		if err != nil {
+			t.Fatalf("%s: %v", name, err)
+		}
+		counts := sampleCounts(table.Sample, len(weights), n)
+		stat := ChiSquared(counts, ExpectedCounts(weights, n))
+		if critical := ChiSquaredCritical(len(weights) - 1); stat > critical {
+			t.Errorf("%s: chi-squared %.2f > critical %.2f, counts %v", name, stat, critical, counts)
+		}
+	}
+}
+
+func TestAliasTableZeroWeightNeverSampled(t *testing.T) {
+	weights := []float64{0, 3, 0, 1}
+	table, err := NewAliasTable(weights, rand.New(rand.NewSource(5)))
+	if err != nil {
+		t.Fatal(err)
+	}
+	counts := sampleCounts(table.Sample, len(weights), 10000)
+	if counts[0] != 0 || counts[2] != 0 {
+		t.Errorf("zero-weight indexes were sampled: %v", counts)
+	}
+}
+
+func TestWeightedReservoir(t *testing.T) {
+	rng := rand.New(rand.NewSource(1))
+
+	r := NewWeightedReservoir(3, rng)
+	for _, v := range []string{"a", "b"} {
+		r.Add(v, 1)
+	}
+	if got := r.Items(); len(got) != 2 {
+		t.Errorf("Items() with fewer than k items = %v, want both", got)
+	}
+
+	r = NewWeightedReservoir(3, rng)
+	r.Add("zero", 0)
+	r.Add("negative", -1)
+	if got := r.Items(); len(got) != 0 {
+		t.Errorf("Items() = %v, want non-positive weights skipped", got)
+	}
+
+	r = NewWeightedReservoir(0, rng)
+	r.Add("x", 1)
+	if got := r.Items(); len(got) != 0 {
+		t.Errorf("k = 0: Items() = %v, want empty", got)
+	}
+
+	// Property: at most k distinct items, all from the stream
+	property := func(raw []uint8, rawK uint8) bool {
+		k := int(rawK%5) + 1
+		r := NewWeightedReservoir(k, rng)
+		stream := make([]string, len(raw))
+		for i, w := range raw {
+			stream[i] = strings.Repeat("x", i+1) // Distinct values
+			r.Add(stream[i], float64(w%10)+1)
+		}
+		items := r.Items()
+		if len(items) != min(k, len(raw)) {
+			return false
+		}
+		for _, item := range items {
+			if !slices.Contains(stream, item) {
+				return false
+			}
+		}
+		slices.Sort(items)
+		return len(slices.Compact(items)) == len(items)
+	}
+	if err := quick.Check(property, nil); err != nil {
+		t.Error(err)
+	}
+}
+
+// With k = 1, A-Res picks each item with probability weight / total
+func TestWeightedReservoirGoodnessOfFit(t *testing.T) {
+	weights := []float64{1, 2, 3, 4}
+	values := []string{"a", "b", "c", "d"}
+	rng := rand.New(rand.NewSource(9))
+
+	const n = 40000
+	counts := make([]int, len(weights))
+	for i := 0; i < n; i++ {
+		r := NewWeightedReservoir(1, rng)
+		for j, v := range values {
+			r.Add(v, weights[j])
+		}
+		counts[slices.Index(values, r.Items()[0])]++
+	}
+	stat := ChiSquared(counts, ExpectedCounts(weights, n))
+	if critical := ChiSquaredCritical(len(weights) - 1); stat > critical {
+		t.Errorf("chi-squared %.2f > critical %.2f, counts %v", stat, critical, counts)
+	}
+}
+
+func TestReservoirHeap(t *testing.T) {
+	h := reservoirHeap{{"a", 0.9}, {"b", 0.1}}
+	if h.Len() != 2 || h.Less(0, 1) {
+		t.Fatalf("Len() = %d, Less(0, 1) = %v", h.Len(), h.Less(0, 1))
+	}
+	h.Swap(0, 1)
+	h.Push(reservoirItem{"c", 0.5})
+	if got := h.Pop().(reservoirItem); got.value != "c" || h[0].value != "b" {
+		t.Errorf("Pop() = %v after Swap and Push, heap = %v", got, h)
+	}
+}
+
+func TestChiSquared(t *testing.T) {
+	if got := ChiSquared([]int{10, 20}, []float64{10, 20}); got != 0 {
+		t.Errorf("ChiSquared of a perfect fit = %v, want 0", got)
+	}
+	// (12-10)²/10 + (18-20)²/20 = 0.4 + 0.2
+	if got := ChiSquared([]int{12, 18}, []float64{10, 20}); math.Abs(got-0.6) > 1e-12 {
+		t.Errorf("ChiSquared = %v, want 0.6", got)
+	}
+}
+
+func TestChiSquaredCritical(t *testing.T) {
+	// Exact values from a chi-squared table at p = 0.001
+	table := map[int]float64{1: 10.828, 3: 16.266, 5: 20.515, 10: 29.588, 30: 59.703}
+	for df, want := range table {
+		if got := ChiSquaredCritical(df); math.Abs(got-want)/want > 0.05 {
+			t.Errorf("ChiSquaredCritical(%d) = %.3f, want about %.3f", df, got, want)
+		}
+	}
+}
+
+func TestExpectedCounts(t *testing.T) {
+	got := ExpectedCounts([]float64{1, 3}, 100)
+	if !slices.Equal(got, []float64{25, 75}) {
+		t.Errorf("ExpectedCounts = %v, want [25 75]", got)
+	}
+}
+
+func TestLoadBalancer(t *testing.T) {
+	rng := rand.New(rand.NewSource(11))
+	if _, err := NewLoadBalancer([]string{"a"}, []float64{0}, rng); err == nil {
+		t.Error("NewLoadBalancer with zero capacity returned no error")
+	}
+	// A capacity without a server would make Next index past the servers
+	if _, err := NewLoadBalancer([]string{"a"}, []float64{1, 1}, rng); err == nil {
+		t.Error("NewLoadBalancer with more capacities than servers returned no error")
+	}
+	if _, err := NewLoadBalancer([]string{"a", "b"}, []float64{1}, rng); err == nil {
+		t.Error("NewLoadBalancer with fewer capacities than servers returned no error")
+	}
+
+	servers := []string{"small", "medium", "large"}
+	capacities := []float64{1, 3, 6}
+	lb, err := NewLoadBalancer(servers, capacities, rng)
+	if err != nil {
+		t.Fatal(err)
+	}
+	const n = 30000
+	counts := sampleCounts(func() int { return slices.Index(servers, lb.Next()) }, len(servers), n)
+	stat := ChiSquared(counts, ExpectedCounts(capacities, n))
+	if critical := ChiSquaredCritical(len(servers) - 1); stat > critical {
+		t.Errorf("chi-squared %.2f > critical %.2f, counts %v", stat, critical, counts)
+	}
+}
+
+func TestMarkovChain(t *testing.T) {
+	rng := rand.New(rand.NewSource(1))
+
+	// Every word has a single follower, so generation is deterministic
+	m := NewMarkovChain("The cat sat on the mat", rng)
+	if got, want := m.Generate("cat", 10), "cat sat on the mat"; got != want {
+		t.Errorf("Generate(cat) = %q, want %q (stopping at the dead end)", got, want)
+	}
+	if got := m.Generate("unknown", 5); got != "unknown" {
+		t.Errorf("Generate(unknown) = %q, want just the start word", got)
+	}
+	if got := m.Generate("the", 2); got != "the cat" && got != "the mat" {
+		t.Errorf("Generate(the, 2) = %q, want two words", got)
+	}
+
+	// Property: every generated transition appears in the training text
+	text := "a b a c a b b c c a"
+	words := strings.Fields(text)
+	seen := make(map[[2]string]bool)
+	for i := 0; i+1 < len(words); i++ {
+		seen[[2]string{words[i], words[i+1]}] = true
+	}
+	m = NewMarkovChain(text, rng)
+	out := strings.Fields(m.Generate("a", 200))
+	if len(out) != 200 {
+		t.Fatalf("Generate produced %d words, want 200 (no dead ends in this text)", len(out))
+	}
+	for i := 0; i+1 < len(out); i++ {
+		if !seen[[2]string{out[i], out[i+1]}] {
+			t.Fatalf("transition %q -> %q not in the text", out[i], out[i+1])
+		}
+	}
+}
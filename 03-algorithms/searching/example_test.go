@@ -0,0 +1,13 @@
+package searching_test
+
+import (
+	"fmt"
+
+	"github.com/NutProhmpiriya/go-basic/03-algorithms/searching"
+)
+
+func ExampleBinarySearch() {
+	arr := []int{1, 3, 5, 7, 9, 11}
+	fmt.Println(searching.BinarySearch(arr, 7), searching.BinarySearch(arr, 4))
+	// Output: 3 -1
+}
@@ -0,0 +1,28 @@
+package searching
+
+import "github.com/NutProhmpiriya/go-basic/03-algorithms/registry"
+
+// The searches register themselves with the complexities claimed in their
+// doc comments, so the learn examples, the complexity checks and the
+// catalog benchmarks find them
+func init() {
+	for _, a := range []struct {
+		name, summary, time string
+		search              func([]int, int) int
+	}{
+		{"linear", "checks every element in turn", "O(n)", LinearSearch},
+		{"binary", "halves the sorted range at every step", "O(log n)", BinarySearch},
+		{"jump", "jumps √n ahead, then searches back linearly", "O(√n)", JumpSearch},
+		{"interpolation", "guesses the position from the values", "O(log log n) average, O(n) worst", InterpolationSearch},
+	} {
+		registry.Register(registry.Algorithm{
+			Name:     a.name,
+			Category: "searching",
+			Summary:  a.summary,
+			Time:     a.time,
+			Space:    "O(1)",
+			Input:    registry.SortedInts,
+			Run:      func(in registry.Input) any { return a.search(in.Ints, in.Target) },
+		})
+	}
+}
@@ -8,12 +8,10 @@
 // 3. Jump Search: Balance between linear and binary search
 // 4. Interpolation Search: Improved binary search for uniformly distributed data
 
-package main
+// Package searching implements linear, binary, jump and interpolation search
+package searching
 
-import (
-	"fmt"
-	"math"
-)
+import "math"
 
 // LinearSearch implements the linear search algorithm
 // Time Complexity: O(n)
@@ -79,6 +77,9 @@ func BinarySearch(arr []int, target int) int {
 // - Searching in arrays where jumping back is expensive
 func JumpSearch(arr []int, target int) int {
 	n := len(arr)
+	if n == 0 {
+		return -1
+	}
 	// Finding optimal jump size
 	step := int(math.Sqrt(float64(n)))
 
@@ -129,6 +130,14 @@ func InterpolationSearch(arr []int, target int) int {
 			return -1
 		}
 
+		// All values in the range are equal: probing would divide by zero
+		if arr[high] == arr[low] {
+			if arr[low] == target {
+				return low
+			}
+			return -1
+		}
+
 		// Probing the position with keeping uniform distribution in mind
 		pos := low + ((high-low)*(target-arr[low]))/(arr[high]-arr[low])
 
@@ -155,54 +164,3 @@ func min(a, b int) int {
 	}
 	return b
 }
-
-func main() {
-	// Test array (sorted for binary, jump, and interpolation search)
-	arr := []int{1, 3, 5, 7, 9, 11, 13, 15, 17, 19}
-	target := 13
-	fmt.Printf("Searching for %d in array: %v\n\n", target, arr)
-
-	// Example 1: Linear Search
-	fmt.Println("Example 1: Linear Search")
-	result := LinearSearch(arr, target)
-	if result != -1 {
-		fmt.Printf("Element found at index: %d\n", result)
-	} else {
-		fmt.Println("Element not found")
-	}
-
-	// Example 2: Binary Search
-	fmt.Println("\nExample 2: Binary Search")
-	result = BinarySearch(arr, target)
-	if result != -1 {
-		fmt.Printf("Element found at index: %d\n", result)
-	} else {
-		fmt.Println("Element not found")
-	}
-
-	// Example 3: Jump Search
-	fmt.Println("\nExample 3: Jump Search")
-	result = JumpSearch(arr, target)
-	if result != -1 {
-		fmt.Printf("Element found at index: %d\n", result)
-	} else {
-		fmt.Println("Element not found")
-	}
-
-	// Example 4: Interpolation Search
-	fmt.Println("\nExample 4: Interpolation Search")
-	result = InterpolationSearch(arr, target)
-	if result != -1 {
-		fmt.Printf("Element found at index: %d\n", result)
-	} else {
-		fmt.Println("Element not found")
-	}
-
-	// Example 5: Searching for non-existent element
-	target = 10
-	fmt.Printf("\nSearching for non-existent element %d:\n", target)
-	fmt.Printf("Linear Search: %d\n", LinearSearch(arr, target))
-	fmt.Printf("Binary Search: %d\n", BinarySearch(arr, target))
-	fmt.Printf("Jump Search: %d\n", JumpSearch(arr, target))
-	fmt.Printf("Interpolation Search: %d\n", InterpolationSearch(arr, target))
-}
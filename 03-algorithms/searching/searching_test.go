@@ -0,0 +1,132 @@
+package searching
+
+import (
+	"fmt"
+	"math/rand"
+	"slices"
+	"testing"
+	"testing/quick"
+)
+
+// sortedSearches need a sorted slice; LinearSearch works on any slice
+var sortedSearches = map[string]func([]int, int) int{
+	"LinearSearch":        LinearSearch,
+	"BinarySearch":        BinarySearch,
+	"JumpSearch":          JumpSearch,
+	"InterpolationSearch": InterpolationSearch,
+}
+
+func TestSearches(t *testing.T) {
+	tests := []struct {
+		name   string
+		arr    []int
+		target int
+		want   int // -1 when absent
+	}{
+		{"empty", []int{}, 5, -1},
+		{"single hit", []int{5}, 5, 0},
+		{"single miss", []int{5}, 4, -1},
+		{"first", []int{1, 3, 5, 7, 9}, 1, 0},
+		{"last", []int{1, 3, 5, 7, 9}, 9, 4},
+		{"middle", []int{1, 3, 5, 7, 9}, 5, 2},
+		{"between", []int{1, 3, 5, 7, 9}, 4, -1},
+		{"below", []int{1, 3, 5, 7, 9}, 0, -1},
+		{"above", []int{1, 3, 5, 7, 9}, 10, -1},
+		{"negatives", []int{-9, -4, 0, 2}, -4, 1},
+		{"all equal hit", []int{3, 3, 3, 3}, 3, 0},
+		{"all equal miss", []int{3, 3, 3, 3}, 2, -1},
+	}
+	for name, search := range sortedSearches {
+		for _, tt := range tests {
+			t.Run(name+"/"+tt.name, func(t *testing.T) {
+				got := search(tt.arr, tt.target)
+				if tt.want == -1 {
+					if got != -1 {
+						t.Errorf("%s(%v, %d) = %d, want -1", name, tt.arr, tt.target, got)
+					}
+					return
+				}
+				// With duplicates any matching index is correct
+				if got < 0 || got >= len(tt.arr) || tt.arr[got] != tt.target {
+					t.Errorf("%s(%v, %d) = %d, not an index of the target", name, tt.arr, tt.target, got)
+				}
+			})
+		}
+	}
+}
+
+func TestLinearSearchUnsorted(t *testing.T) {
+	arr := []int{9, 2, 7, 2}
+	if got := LinearSearch(arr, 2); got != 1 {
+		t.Errorf("LinearSearch(%v, 2) = %d, want the first match 1", arr, got)
+	}
+}
+
+// Property: on a sorted slice every search finds the target exactly when
+// LinearSearch does, and returns an index holding the target
+// Values are kept small so targets are often present and duplicates common
+func TestSearchesAgreeWithLinearSearch(t *testing.T) {
+	for name, search := range sortedSearches {
+		t.Run(name, func(t *testing.T) {
+			property := func(raw []int8, target int8) bool {
+				arr := make([]int, len(raw))
+				for i, v := range raw {
+					arr[i] = int(v % 16)
+				}
+				slices.Sort(arr)
+				want := LinearSearch(arr, int(target%16))
+				got := search(arr, int(target%16))
+				if want == -1 {
+					return got == -1
+				}
+				return got >= 0 && got < len(arr) && arr[got] == arr[want]
+			}
+			if err := quick.Check(property, nil); err != nil {
+				t.Error(err)
+			}
+		})
+	}
+}
+
+// BenchmarkSearch compares the searches on sorted inputs whose values are
+// spread differently. Interpolation search shines on evenly spaced values
+// and degrades towards linear time when they are skewed
+func BenchmarkSearch(b *testing.B) {
+	distributions := []struct {
+		name  string
+		value func(i int) int
+	}{
+		{"uniform", func(i int) int { return i * 2 }},
+		{"skewed", func(i int) int { return i * i }},
+		{"duplicates", func(i int) int { return i / 100 }},
+	}
+	names := make([]string, 0, len(sortedSearches))
+	for name := range sortedSearches {
+		names = append(names, name)
+	}
+	slices.Sort(names)
+
+	for _, name := range names {
+		search := sortedSearches[name]
+		for _, dist := range distributions {
+			for _, n := range []int{1_000, 100_000, 1_000_000} {
+				arr := make([]int, n)
+				for i := range arr {
+					arr[i] = dist.value(i)
+				}
+				// Cycle through targets spread over the whole slice, so no
+				// search benefits from always hitting the same position
+				rng := rand.New(rand.NewSource(1))
+				targets := make([]int, 1024)
+				for i := range targets {
+					targets[i] = arr[rng.Intn(n)]
+				}
+				b.Run(fmt.Sprintf("algo=%s/dist=%s/n=%d", name, dist.name, n), func(b *testing.B) {
+					for i := 0; i < b.N; i++ {
+						search(arr, targets[i%len(targets)])
+					}
+				})
+			}
+		}
+	}
+}
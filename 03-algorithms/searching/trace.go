@@ -0,0 +1,42 @@
+package searching
+
+import "github.com/NutProhmpiriya/go-basic/trace"
+
+// The functions in this file are the traced twins of the searches above
+// They return the same index as the plain versions and report every probe
+// to a trace.Tracer; the last event is always Found, with I = -1 on a miss
+
+// LinearSearchTrace is LinearSearch reporting its steps to t
+func LinearSearchTrace(arr []int, target int, t trace.Tracer) int {
+	for i, value := range arr {
+		t.Step(trace.Event{Op: trace.Compare, I: i, J: -1})
+		if value == target {
+			t.Step(trace.Event{Op: trace.Found, I: i})
+			return i
+		}
+	}
+	t.Step(trace.Event{Op: trace.Found, I: -1})
+	return -1
+}
+
+// BinarySearchTrace is BinarySearch reporting its steps to t
+// Each halving shows up as a Range event for the part still searched
+func BinarySearchTrace(arr []int, target int, t trace.Tracer) int {
+	left, right := 0, len(arr)-1
+	for left <= right {
+		t.Step(trace.Event{Op: trace.Range, I: left, J: right})
+		mid := left + (right-left)/2
+		t.Step(trace.Event{Op: trace.Compare, I: mid, J: -1})
+		if arr[mid] == target {
+			t.Step(trace.Event{Op: trace.Found, I: mid})
+			return mid
+		}
+		if arr[mid] < target {
+			left = mid + 1
+		} else {
+			right = mid - 1
+		}
+	}
+	t.Step(trace.Event{Op: trace.Found, I: -1})
+	return -1
+}
@@ -0,0 +1,64 @@
+package searching
+
+import (
+	"slices"
+	"testing"
+	"testing/quick"
+
+	"github.com/NutProhmpiriya/go-basic/trace"
+)
+
+// Property: the traced searches return what the plain ones do, probe only
+// indexes inside the slice and end with a Found event for their result
+func TestTracedSearches(t *testing.T) {
+	searches := map[string]struct {
+		traced func([]int, int, trace.Tracer) int
+		plain  func([]int, int) int
+	}{
+		"LinearSearchTrace": {LinearSearchTrace, LinearSearch},
+		"BinarySearchTrace": {BinarySearchTrace, BinarySearch},
+	}
+	for name, search := range searches {
+		t.Run(name, func(t *testing.T) {
+			property := func(raw []int8, target int8) bool {
+				arr := make([]int, len(raw))
+				for i, v := range raw {
+					arr[i] = int(v % 16)
+				}
+				slices.Sort(arr)
+
+				var rec trace.Recorder
+				got := search.traced(arr, int(target%16), &rec)
+				if got != search.plain(arr, int(target%16)) || len(rec.Events) == 0 {
+					return false
+				}
+				last := rec.Events[len(rec.Events)-1]
+				if last.Op != trace.Found || last.I != got {
+					return false
+				}
+				for _, e := range rec.Events {
+					if e.Op == trace.Compare && (e.I < 0 || e.I >= len(arr)) {
+						return false
+					}
+				}
+				return true
+			}
+			if err := quick.Check(property, nil); err != nil {
+				t.Error(err)
+			}
+		})
+	}
+}
+
+func TestBinarySearchTraceHalves(t *testing.T) {
+	arr := make([]int, 1024)
+	for i := range arr {
+		arr[i] = i
+	}
+	var rec trace.Recorder
+	BinarySearchTrace(arr, 1023, &rec)
+	// 1024 values halve to one in 10 steps, and the 11th probe finds it
+	if got := rec.Count(trace.Compare); got != 11 {
+		t.Errorf("%d probes to find the last of 1024 values, want 11", got)
+	}
+}
@@ -0,0 +1,42 @@
+package sorting_test
+
+import (
+	"cmp"
+	"fmt"
+
+	"github.com/NutProhmpiriya/go-basic/03-algorithms/sorting"
+)
+
+func ExampleQuickSort() {
+	arr := []int{5, 2, 8, 2, 9, 1}
+	sorting.QuickSort(arr)
+	fmt.Println(arr)
+	// Output: [1 2 2 5 8 9]
+}
+
+func ExampleMergeSortFunc() {
+	// Sorting by length keeps words of the same length in their order
+	words := []string{"pear", "fig", "plum", "kiwi", "date", "yam"}
+	byLength := func(a, b string) int { return cmp.Compare(len(a), len(b)) }
+	fmt.Println(sorting.MergeSortFunc(words, byLength))
+	// Output: [fig yam pear plum kiwi date]
+}
+
+func ExampleNaturalMergeSort() {
+	// Two ascending runs: one merge pass sorts them
+	arr := []int{1, 4, 7, 2, 3, 9}
+	fmt.Println(sorting.NaturalMergeSort(arr), sorting.CountRuns(arr))
+	// Output: [1 2 3 4 7 9] 2
+}
+
+func ExampleThreeWayPartition() {
+	arr := []int{5, 1, 9, 5, 3, 8, 5}
+	lt, gt := sorting.ThreeWayPartition(arr, 5)
+	fmt.Println(arr[:lt], arr[lt:gt], arr[gt:])
+	// Output: [1 3] [5 5 5] [8 9]
+}
+
+func ExampleCountInversions() {
+	fmt.Println(sorting.CountInversions([]int{3, 1, 2}))
+	// Output: 2
+}
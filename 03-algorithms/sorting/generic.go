@@ -0,0 +1,73 @@
+package sorting
+
+// This file has generic versions of MergeSort and QuickSort for sorting
+// records rather than ints. They take a comparison function like
+// slices.SortFunc: cmp(a, b) is negative when a goes before b, positive
+// when it goes after, and zero when the two are equal as far as the sort
+// is concerned, even if they differ in other fields
+//
+// That is where stability matters. A stable sort keeps equal elements in
+// the order they had, so sorting records by score keeps the records with
+// the same score in their previous order, say by name. An unstable sort
+// may shuffle them
+
+// MergeSortFunc sorts arr by cmp like MergeSort and returns the result in a
+// new slice, leaving arr as it was
+// Time Complexity: O(n log n) for all cases
+// Space Complexity: O(n)
+// Stable: Yes, because merging takes from the left half on ties
+func MergeSortFunc[T any](arr []T, cmp func(a, b T) int) []T {
+	if len(arr) <= 1 {
+		return append([]T(nil), arr...)
+	}
+	mid := len(arr) / 2
+	return mergeFunc(MergeSortFunc(arr[:mid], cmp), MergeSortFunc(arr[mid:], cmp), cmp)
+}
+
+func mergeFunc[T any](left, right []T, cmp func(a, b T) int) []T {
+	result := make([]T, 0, len(left)+len(right))
+	i, j := 0, 0
+	for i < len(left) && j < len(right) {
+		// <= rather than <: an element of the left half came first in the
+		// input, so it goes first when the two are equal
+		if cmp(left[i], right[j]) <= 0 {
+			result = append(result, left[i])
+			i++
+		} else {
+			result = append(result, right[j])
+			j++
+		}
+	}
+	result = append(result, left[i:]...)
+	return append(result, right[j:]...)
+}
+
+// QuickSortFunc sorts arr in place by cmp like QuickSort, with three-way
+// partitioning around the middle element
+// Time Complexity: O(n log n) average, O(n²) worst case
+// Space Complexity: O(log n) average
+// Stable: No, partitioning swaps elements over long distances, past others
+// that are equal to them
+func QuickSortFunc[T any](arr []T, cmp func(a, b T) int) {
+	if len(arr) <= 1 {
+		return
+	}
+	pivot := arr[len(arr)/2]
+	// arr[:lt] < pivot, arr[lt:i] == pivot, arr[gt:] > pivot
+	lt, i, gt := 0, 0, len(arr)
+	for i < gt {
+		switch c := cmp(arr[i], pivot); {
+		case c < 0:
+			arr[lt], arr[i] = arr[i], arr[lt]
+			lt++
+			i++
+		case c > 0:
+			gt--
+			arr[i], arr[gt] = arr[gt], arr[i]
+		default:
+			i++
+		}
+	}
+	QuickSortFunc(arr[:lt], cmp)
+	QuickSortFunc(arr[gt:], cmp)
+}
@@ -0,0 +1,29 @@
+package sorting
+
+import "github.com/NutProhmpiriya/go-basic/03-algorithms/registry"
+
+// The sorts register themselves with the complexities claimed in their doc
+// comments, so the learn examples, the complexity checks and the catalog
+// benchmarks find them
+func init() {
+	for _, a := range []registry.Algorithm{
+		{Name: "bubblesort", Summary: "swaps neighbours until nothing moves", Time: "O(n²)", Space: "O(1)", Run: registry.InPlace(BubbleSort)},
+		{Name: "insertionsort", Summary: "inserts each element into the sorted prefix", Time: "O(n²), O(n) if nearly sorted", Space: "O(1)", Run: registry.InPlace(InsertionSort)},
+		{Name: "quicksort", Summary: "three-way partitions around a middle pivot", Time: "O(n log n) average, O(n²) worst", Space: "O(log n)", Run: registry.InPlace(QuickSort)},
+		{Name: "quicksort-iterative", Summary: "QuickSort with an explicit stack of ranges", Time: "O(n log n) average, O(n²) worst", Space: "O(log n)", Run: registry.InPlace(QuickSortIterative)},
+		{Name: "lomuto-quicksort", Summary: "the classic two-way quicksort", Time: "O(n log n) average, O(n²) worst", Space: "O(log n)", Run: registry.InPlace(LomutoQuickSort)},
+		{Name: "mergesort", Summary: "sorts both halves and merges them, stable", Time: "O(n log n)", Space: "O(n)", Run: copySort(MergeSort)},
+		{Name: "mergesort-bottomup", Summary: "merges runs of width 1, 2, 4, ... without recursion", Time: "O(n log n)", Space: "O(n)", Run: copySort(MergeSortBottomUp)},
+		{Name: "mergesort-buffered", Summary: "MergeSort with one buffer for every merge", Time: "O(n log n)", Space: "O(n)", Run: copySort(MergeSortBuffered)},
+		{Name: "natural-mergesort", Summary: "merges the runs already in the input", Time: "O(n log r) for r runs", Space: "O(n)", Run: copySort(NaturalMergeSort)},
+	} {
+		a.Category = "sorting"
+		a.Input = registry.Ints
+		registry.Register(a)
+	}
+}
+
+// copySort adapts a sort that returns a new slice to registry.Algorithm.Run
+func copySort(sort func([]int) []int) func(in registry.Input) any {
+	return func(in registry.Input) any { return sort(in.Ints) }
+}
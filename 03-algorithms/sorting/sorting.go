@@ -0,0 +1,466 @@
+// This file implements common sorting algorithms in Go
+// Each algorithm has different characteristics making them suitable for different scenarios
+//
+// Common Sorting Algorithms:
+// 1. Bubble Sort: Simple but inefficient
+// 2. Quick Sort: Efficient and widely used
+// 3. Merge Sort: Stable and predictable performance
+//    Natural Merge Sort: adaptive variant that is near-linear on nearly sorted data
+// 4. Insertion Sort: Efficient for small or nearly sorted data
+//
+// Partitioning helpers (three-way, stable and predicate-based partitions)
+// are included because they are the building blocks of QuickSort
+
+// Package sorting implements comparison sorts and the partitioning helpers they build on
+package sorting
+
+// BubbleSort implements the bubble sort algorithm
+// Time Complexity: O(n²) for all cases
+// Space Complexity: O(1)
+// Stable: Yes
+// Best for: Small datasets or nearly sorted arrays
+func BubbleSort(arr []int) {
+	n := len(arr)
+	for i := 0; i < n-1; i++ {
+		// Flag to optimize if array is already sorted
+		swapped := false
+		
+		// Compare adjacent elements
+		for j := 0; j < n-i-1; j++ {
+			if arr[j] > arr[j+1] {
+				// Swap if they are in wrong order
+				arr[j], arr[j+1] = arr[j+1], arr[j]
+				swapped = true
+			}
+		}
+		
+		// If no swapping occurred, array is sorted
+		if !swapped {
+			break
+		}
+	}
+}
+
+// QuickSort implements the quicksort algorithm
+// Time Complexity: O(n log n) average, O(n²) worst case
+// Space Complexity: O(log n)
+// Stable: No
+// Best for: General purpose sorting, works well with random data
+// Uses three-way partitioning, so inputs with many duplicates stay O(n log n)
+// (all keys equal to the pivot are excluded from both recursive calls)
+func QuickSort(arr []int) {
+	quickSortHelper(arr, 0, len(arr)-1)
+}
+
+func quickSortHelper(arr []int, low, high int) {
+	if low < high {
+		// Partition into < pivot, == pivot and > pivot
+		// Choosing the middle element avoids the O(n²) case on sorted input
+		pivot := arr[low+(high-low)/2]
+		lt, gt := ThreeWayPartition(arr[low:high+1], pivot)
+
+		// Recursively sort the part smaller than the pivot
+		quickSortHelper(arr, low, low+lt-1)
+		// Recursively sort the part larger than the pivot
+		quickSortHelper(arr, low+gt, high)
+	}
+}
+
+// QuickSortIterative sorts like QuickSort with an explicit stack of ranges
+// instead of recursion
+// After each partition the larger side goes on the stack and the loop
+// carries on with the smaller one, so the stack never holds more than
+// log2(n) ranges, even when a bad pivot makes the recursion of QuickSort n deep
+// Time Complexity: O(n log n) average, O(n²) worst case
+// Space Complexity: O(log n) guaranteed
+// Stable: No
+func QuickSortIterative(arr []int) {
+	type span struct{ low, high int }
+	stack := []span{{0, len(arr) - 1}}
+	for len(stack) > 0 {
+		r := stack[len(stack)-1]
+		stack = stack[:len(stack)-1]
+
+		for low, high := r.low, r.high; low < high; {
+			pivot := arr[low+(high-low)/2]
+			lt, gt := ThreeWayPartition(arr[low:high+1], pivot)
+			left := span{low, low + lt - 1}
+			right := span{low + gt, high}
+			if left.high-left.low > right.high-right.low {
+				left, right = right, left
+			}
+			// Defer the larger side and keep going with the smaller one
+			stack = append(stack, right)
+			low, high = left.low, left.high
+		}
+	}
+}
+
+// LomutoQuickSort is the classic two-way quicksort
+// Kept to compare against QuickSort: every element equal to the pivot
+// lands on one side, so an array of duplicates degrades to O(n²)
+func LomutoQuickSort(arr []int) {
+	lomutoQuickSort(arr, 0, len(arr)-1)
+}
+
+func lomutoQuickSort(arr []int, low, high int) {
+	if low < high {
+		pi := partition(arr, low, high)
+		lomutoQuickSort(arr, low, pi-1)
+		lomutoQuickSort(arr, pi+1, high)
+	}
+}
+
+func partition(arr []int, low, high int) int {
+	// Choose the rightmost element as pivot
+	pivot := arr[high]
+	i := low - 1 // Index of smaller element
+	
+	// Move elements smaller than pivot to the left
+	for j := low; j < high; j++ {
+		if arr[j] <= pivot {
+			i++
+			arr[i], arr[j] = arr[j], arr[i]
+		}
+	}
+	
+	// Place pivot in its correct position
+	arr[i+1], arr[high] = arr[high], arr[i+1]
+	return i + 1
+}
+
+// ThreeWayPartition rearranges arr around pivot (Dijkstra's Dutch national flag)
+// After the call:
+// - arr[:lt] < pivot
+// - arr[lt:gt] == pivot
+// - arr[gt:] > pivot
+// Time Complexity: O(n)
+// Space Complexity: O(1)
+// Stable: No
+func ThreeWayPartition(arr []int, pivot int) (lt, gt int) {
+	lt, i, gt := 0, 0, len(arr)
+	for i < gt {
+		switch {
+		case arr[i] < pivot:
+			arr[lt], arr[i] = arr[i], arr[lt]
+			lt++
+			i++
+		case arr[i] > pivot:
+			// Don't advance i: the swapped-in element hasn't been examined yet
+			gt--
+			arr[i], arr[gt] = arr[gt], arr[i]
+		default:
+			i++
+		}
+	}
+	return lt, gt
+}
+
+// SortColors sorts a slice containing only 0s, 1s and 2s in a single pass
+// This is the "Dutch national flag" problem (red, white, blue)
+// Time Complexity: O(n)
+// Space Complexity: O(1)
+func SortColors(colors []int) {
+	ThreeWayPartition(colors, 1)
+}
+
+// PartitionFunc moves every element satisfying pred to the front of arr
+// Returns the number of elements that satisfy pred
+// Time Complexity: O(n)
+// Space Complexity: O(1)
+// Stable: No
+func PartitionFunc(arr []int, pred func(int) bool) int {
+	i, j := 0, len(arr)-1
+	for {
+		// Find the first element from the left that belongs on the right
+		for i <= j && pred(arr[i]) {
+			i++
+		}
+		// Find the first element from the right that belongs on the left
+		for i <= j && !pred(arr[j]) {
+			j--
+		}
+		if i >= j {
+			return i
+		}
+		arr[i], arr[j] = arr[j], arr[i]
+		i++
+		j--
+	}
+}
+
+// StablePartition moves every element satisfying pred to the front of arr,
+// keeping the relative order of elements within each group
+// Returns the number of elements that satisfy pred
+// Time Complexity: O(n)
+// Space Complexity: O(n) for the buffer of rejected elements
+// Stable: Yes
+func StablePartition(arr []int, pred func(int) bool) int {
+	rejected := make([]int, 0, len(arr))
+	n := 0
+	for _, value := range arr {
+		if pred(value) {
+			arr[n] = value
+			n++
+		} else {
+			rejected = append(rejected, value)
+		}
+	}
+	copy(arr[n:], rejected)
+	return n
+}
+
+// MergeSort implements the merge sort algorithm
+// Time Complexity: O(n log n) for all cases
+// Space Complexity: O(n)
+// Stable: Yes
+// Best for: When stable sorting is needed and memory is not a constraint
+func MergeSort(arr []int) []int {
+	if len(arr) <= 1 {
+		return arr
+	}
+
+	// Split array into two halves
+	mid := len(arr) / 2
+	left := MergeSort(arr[:mid])
+	right := MergeSort(arr[mid:])
+
+	// Merge the sorted halves
+	return merge(left, right)
+}
+
+func merge(left, right []int) []int {
+	result := make([]int, 0, len(left)+len(right))
+	i, j := 0, 0
+
+	// Compare and merge elements
+	for i < len(left) && j < len(right) {
+		if left[i] <= right[j] {
+			result = append(result, left[i])
+			i++
+		} else {
+			result = append(result, right[j])
+			j++
+		}
+	}
+
+	// Add remaining elements
+	result = append(result, left[i:]...)
+	result = append(result, right[j:]...)
+	return result
+}
+
+// MergeSortBottomUp sorts like MergeSort without recursion
+// Instead of splitting the array top-down it starts from runs of one element
+// and merges neighbouring runs of width 1, 2, 4, ... until one run is left,
+// switching between two buffers each pass
+// Time Complexity: O(n log n) for all cases
+// Space Complexity: O(n)
+// Stable: Yes
+func MergeSortBottomUp(arr []int) []int {
+	n := len(arr)
+	src := make([]int, n)
+	copy(src, arr)
+	dst := make([]int, n)
+	for width := 1; width < n; width *= 2 {
+		for lo := 0; lo < n; lo += 2 * width {
+			mid := min(lo+width, n)
+			hi := min(lo+2*width, n)
+			mergeInto(dst[lo:hi], src[lo:mid], src[mid:hi])
+		}
+		src, dst = dst, src
+	}
+	return src
+}
+
+// MergeSortBuffered is MergeSort without an allocation per merge
+// MergeSort allocates a new slice for every merge, about n of them in all;
+// this version copies the input once and merges back and forth between the
+// copy and a single buffer of the same size, so it allocates twice
+// Time Complexity: O(n log n) for all cases
+// Space Complexity: O(n)
+// Stable: Yes
+func MergeSortBuffered(arr []int) []int {
+	result := make([]int, len(arr))
+	copy(result, arr)
+	buf := make([]int, len(arr))
+	copy(buf, arr)
+	mergeSortInto(result, buf)
+	return result
+}
+
+// mergeSortInto sorts the values of src into dst
+// Both must hold the same values on entry; src is used as scratch space.
+// Each level sorts the halves into the other slice and merges them back,
+// so no level needs memory of its own
+func mergeSortInto(dst, src []int) {
+	if len(dst) <= 1 {
+		return
+	}
+	mid := len(dst) / 2
+	mergeSortInto(src[:mid], dst[:mid])
+	mergeSortInto(src[mid:], dst[mid:])
+	mergeInto(dst, src[:mid], src[mid:])
+}
+
+// NaturalMergeSort is an adaptive merge sort that takes advantage of existing order
+// Instead of always splitting in half, it detects the runs already present in
+// the input (reversing strictly descending ones) and only merges those runs
+// Time Complexity: O(n log r) where r is the number of runs
+// - O(n) for sorted or reverse-sorted input (r = 1)
+// - O(n log n) for random input (r ≈ n/2)
+// Space Complexity: O(n)
+// Stable: Yes (only strictly descending runs are reversed)
+// Best for: Nearly sorted data, e.g. appending a few items to a sorted log
+func NaturalMergeSort(arr []int) []int {
+	n := len(arr)
+	src := make([]int, n)
+	copy(src, arr)
+
+	// Find run boundaries: run k is src[bounds[k]:bounds[k+1]]
+	bounds := []int{0}
+	for i := 0; i < n; {
+		j := i + 1
+		if j < n && src[j] < src[i] {
+			// Strictly descending run: reverse it into an ascending one
+			for j < n && src[j] < src[j-1] {
+				j++
+			}
+			for lo, hi := i, j-1; lo < hi; lo, hi = lo+1, hi-1 {
+				src[lo], src[hi] = src[hi], src[lo]
+			}
+		} else {
+			for j < n && src[j] >= src[j-1] {
+				j++
+			}
+		}
+		bounds = append(bounds, j)
+		i = j
+	}
+
+	// Merge neighbouring runs pairwise until one run is left
+	// src and dst swap roles each pass, so only one extra buffer is needed
+	dst := make([]int, n)
+	for len(bounds) > 2 {
+		merged := []int{0}
+		for k := 0; k+1 < len(bounds); k += 2 {
+			lo := bounds[k]
+			if k+2 < len(bounds) {
+				mid, hi := bounds[k+1], bounds[k+2]
+				mergeInto(dst[lo:hi], src[lo:mid], src[mid:hi])
+				merged = append(merged, hi)
+			} else {
+				// Odd run out: carry it to the next pass
+				hi := bounds[k+1]
+				copy(dst[lo:hi], src[lo:hi])
+				merged = append(merged, hi)
+			}
+		}
+		src, dst = dst, src
+		bounds = merged
+	}
+
+	return src
+}
+
+// mergeInto merges two sorted slices into dst, which must have room for both
+func mergeInto(dst, left, right []int) {
+	i, j, k := 0, 0, 0
+	for i < len(left) && j < len(right) {
+		if left[i] <= right[j] {
+			dst[k] = left[i]
+			i++
+		} else {
+			dst[k] = right[j]
+			j++
+		}
+		k++
+	}
+	k += copy(dst[k:], left[i:])
+	copy(dst[k:], right[j:])
+}
+
+// CountRuns returns the number of maximal non-decreasing runs in arr
+// A measure of presortedness: 1 for sorted input, about n/2 for random input
+// Time Complexity: O(n)
+func CountRuns(arr []int) int {
+	if len(arr) == 0 {
+		return 0
+	}
+	runs := 1
+	for i := 1; i < len(arr); i++ {
+		if arr[i] < arr[i-1] {
+			runs++
+		}
+	}
+	return runs
+}
+
+// CountInversions returns the number of pairs i < j with arr[i] > arr[j]
+// A measure of presortedness: 0 for sorted input, n(n-1)/2 for reversed input
+// Counted while merge sorting a copy: when an element from the right half is
+// placed before the remaining elements of the left half, each of those is an inversion
+// Time Complexity: O(n log n)
+// Space Complexity: O(n)
+func CountInversions(arr []int) int64 {
+	work := make([]int, len(arr))
+	copy(work, arr)
+	buffer := make([]int, len(arr))
+	return countInversions(work, buffer)
+}
+
+func countInversions(arr, buffer []int) int64 {
+	if len(arr) <= 1 {
+		return 0
+	}
+	mid := len(arr) / 2
+	count := countInversions(arr[:mid], buffer[:mid]) + countInversions(arr[mid:], buffer[mid:])
+
+	left, right := arr[:mid], arr[mid:]
+	i, j, k := 0, 0, 0
+	for i < len(left) && j < len(right) {
+		if left[i] <= right[j] {
+			buffer[k] = left[i]
+			i++
+		} else {
+			buffer[k] = right[j]
+			j++
+			// right[j] jumps ahead of every element left in the left half
+			count += int64(len(left) - i)
+		}
+		k++
+	}
+	k += copy(buffer[k:], left[i:])
+	copy(buffer[k:], right[j:])
+	copy(arr, buffer[:len(arr)])
+	return count
+}
+
+// InsertionSort implements the insertion sort algorithm
+// Time Complexity: O(n²) worst/average case, O(n) best case
+// Space Complexity: O(1)
+// Stable: Yes
+// Best for: Small datasets or nearly sorted arrays
+func InsertionSort(arr []int) {
+	for i := 1; i < len(arr); i++ {
+		key := arr[i]
+		j := i - 1
+
+		// Move elements greater than key ahead
+		for j >= 0 && arr[j] > key {
+			arr[j+1] = arr[j]
+			j--
+		}
+		arr[j+1] = key
+	}
+}
+
+// Helper function to check if array is sorted
+func IsSorted(arr []int) bool {
+	for i := 1; i < len(arr); i++ {
+		if arr[i] < arr[i-1] {
+			return false
+		}
+	}
+	return true
+}
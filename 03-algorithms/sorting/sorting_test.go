@@ -0,0 +1,429 @@
+package sorting
+
+import (
+	"cmp"
+	"fmt"
+	"slices"
+	"testing"
+	"testing/quick"
+
+	"github.com/NutProhmpiriya/go-basic/testdata/generator"
+	"github.com/NutProhmpiriya/go-basic/trace"
+)
+
+// inPlaceSorts and copySorts list every sorting function under one signature
+var inPlaceSorts = map[string]func([]int){
+	"BubbleSort":         BubbleSort,
+	"QuickSort":          QuickSort,
+	"QuickSortIterative": QuickSortIterative,
+	"InsertionSort":      InsertionSort,
+	"LomutoQuickSort":    LomutoQuickSort,
+	"QuickSortFunc":      func(arr []int) { QuickSortFunc(arr, cmp.Compare[int]) },
+}
+
+var copySorts = map[string]func([]int) []int{
+	"MergeSort":         MergeSort,
+	"MergeSortBottomUp": MergeSortBottomUp,
+	"MergeSortBuffered": MergeSortBuffered,
+	"NaturalMergeSort":  NaturalMergeSort,
+	"MergeSortFunc":     func(arr []int) []int { return MergeSortFunc(arr, cmp.Compare[int]) },
+}
+
+// allSorts returns every sort as a function that leaves its input untouched
+func allSorts() map[string]func([]int) []int {
+	sorts := make(map[string]func([]int) []int)
+	for name, sort := range inPlaceSorts {
+		sorts[name] = func(arr []int) []int {
+			out := slices.Clone(arr)
+			sort(out)
+			return out
+		}
+	}
+	for name, sort := range copySorts {
+		sorts[name] = sort
+	}
+	return sorts
+}
+
+var sortCases = []struct {
+	name string
+	in   []int
+}{
+	{"empty", []int{}},
+	{"single", []int{42}},
+	{"sorted", []int{1, 2, 3, 4, 5}},
+	{"reversed", []int{5, 4, 3, 2, 1}},
+	{"duplicates", []int{3, 1, 3, 1, 2, 2}},
+	{"all equal", []int{7, 7, 7, 7}},
+	{"negatives", []int{0, -3, 5, -1, -3}},
+	{"descending runs", []int{9, 8, 1, 2, 7, 6, 5}},
+}
+
+func TestSorts(t *testing.T) {
+	for name, sort := range allSorts() {
+		for _, tc := range sortCases {
+			t.Run(name+"/"+tc.name, func(t *testing.T) {
+				in := slices.Clone(tc.in)
+				want := slices.Clone(tc.in)
+				slices.Sort(want)
+
+				got := sort(in)
+				if !slices.Equal(got, want) {
+					t.Errorf("%s(%v) = %v, want %v", name, tc.in, got, want)
+				}
+				if !IsSorted(got) {
+					t.Errorf("IsSorted(%v) = false", got)
+				}
+			})
+		}
+	}
+}
+
+func TestCopySortsLeaveInputUntouched(t *testing.T) {
+	for name, sort := range copySorts {
+		in := []int{3, 1, 2}
+		sort(in)
+		if !slices.Equal(in, []int{3, 1, 2}) {
+			t.Errorf("%s modified its input: %v", name, in)
+		}
+	}
+}
+
+// Property: every sort returns a sorted permutation of its input
+// Comparing against slices.Sort checks both at once: same multiset, sorted order
+func TestSortsProperty(t *testing.T) {
+	for name, sort := range allSorts() {
+		t.Run(name, func(t *testing.T) {
+			property := func(in []int) bool {
+				want := slices.Clone(in)
+				slices.Sort(want)
+				return slices.Equal(sort(in), want)
+			}
+			if err := quick.Check(property, nil); err != nil {
+				t.Error(err)
+			}
+		})
+	}
+}
+
+// quick generates uniformly random ints, which almost never repeat
+// This property uses small values so runs of duplicates are common
+func TestSortsPropertyDuplicates(t *testing.T) {
+	for name, sort := range allSorts() {
+		t.Run(name, func(t *testing.T) {
+			property := func(raw []uint8) bool {
+				in := make([]int, len(raw))
+				for i, b := range raw {
+					in[i] = int(b % 4)
+				}
+				want := slices.Clone(in)
+				slices.Sort(want)
+				return slices.Equal(sort(in), want)
+			}
+			if err := quick.Check(property, nil); err != nil {
+				t.Error(err)
+			}
+		})
+	}
+}
+
+// Property: MergeSortFunc keeps elements with equal keys in their input
+// order, like slices.SortStableFunc
+// Few distinct keys make ties common; the index tells the tied ones apart
+func TestMergeSortFuncStable(t *testing.T) {
+	type item struct{ key, index int }
+	byKey := func(a, b item) int { return cmp.Compare(a.key, b.key) }
+	property := func(keys []uint8) bool {
+		items := make([]item, len(keys))
+		for i, k := range keys {
+			items[i] = item{int(k % 4), i}
+		}
+		want := slices.Clone(items)
+		slices.SortStableFunc(want, byKey)
+		return slices.Equal(MergeSortFunc(items, byKey), want)
+	}
+	if err := quick.Check(property, nil); err != nil {
+		t.Error(err)
+	}
+}
+
+// QuickSortFunc is not stable: partitioning around {2 2} swaps {2 0} to the
+// end, past it
+func TestQuickSortFuncUnstable(t *testing.T) {
+	type item struct{ key, index int }
+	items := []item{{2, 0}, {1, 1}, {2, 2}, {1, 3}}
+	QuickSortFunc(items, func(a, b item) int { return cmp.Compare(a.key, b.key) })
+	want := []item{{1, 1}, {1, 3}, {2, 2}, {2, 0}}
+	if !slices.Equal(items, want) {
+		t.Errorf("QuickSortFunc = %v, want %v", items, want)
+	}
+}
+
+func TestThreeWayPartition(t *testing.T) {
+	property := func(raw []int8, pivot int8) bool {
+		arr := make([]int, len(raw))
+		for i, v := range raw {
+			arr[i] = int(v % 8)
+		}
+		p := int(pivot % 8)
+		before := slices.Clone(arr)
+
+		lt, gt := ThreeWayPartition(arr, p)
+		if lt < 0 || lt > gt || gt > len(arr) {
+			return false
+		}
+		for i, v := range arr {
+			switch {
+			case i < lt && v >= p, i >= lt && i < gt && v != p, i >= gt && v <= p:
+				return false
+			}
+		}
+		return samePermutation(before, arr)
+	}
+	if err := quick.Check(property, nil); err != nil {
+		t.Error(err)
+	}
+}
+
+func TestSortColors(t *testing.T) {
+	tests := []struct {
+		in, want []int
+	}{
+		{[]int{}, []int{}},
+		{[]int{2, 0, 2, 1, 1, 0}, []int{0, 0, 1, 1, 2, 2}},
+		{[]int{2, 2, 2}, []int{2, 2, 2}},
+		{[]int{1, 0}, []int{0, 1}},
+	}
+	for _, tt := range tests {
+		got := slices.Clone(tt.in)
+		SortColors(got)
+		if !slices.Equal(got, tt.want) {
+			t.Errorf("SortColors(%v) = %v, want %v", tt.in, got, tt.want)
+		}
+	}
+}
+
+func isEven(n int) bool { return n%2 == 0 }
+
+func TestPartitionFunc(t *testing.T) {
+	property := func(in []int) bool {
+		arr := slices.Clone(in)
+		n := PartitionFunc(arr, isEven)
+		for i, v := range arr {
+			if isEven(v) != (i < n) {
+				return false
+			}
+		}
+		return samePermutation(in, arr)
+	}
+	if err := quick.Check(property, nil); err != nil {
+		t.Error(err)
+	}
+}
+
+func TestStablePartition(t *testing.T) {
+	arr := []int{1, 2, 3, 4, 5, 6, 7}
+	n := StablePartition(arr, isEven)
+	if want := []int{2, 4, 6, 1, 3, 5, 7}; n != 3 || !slices.Equal(arr, want) {
+		t.Errorf("StablePartition = %d, %v; want 3, %v", n, arr, want)
+	}
+
+	// Property: same result as filtering twice, which is stable by construction
+	property := func(in []int) bool {
+		var want []int
+		for _, v := range in {
+			if isEven(v) {
+				want = append(want, v)
+			}
+		}
+		evens := len(want)
+		for _, v := range in {
+			if !isEven(v) {
+				want = append(want, v)
+			}
+		}
+		arr := slices.Clone(in)
+		return StablePartition(arr, isEven) == evens && slices.Equal(arr, want)
+	}
+	if err := quick.Check(property, nil); err != nil {
+		t.Error(err)
+	}
+}
+
+func TestCountRuns(t *testing.T) {
+	tests := []struct {
+		in   []int
+		want int
+	}{
+		{nil, 0},
+		{[]int{1}, 1},
+		{[]int{1, 2, 2, 3}, 1},
+		{[]int{3, 2, 1}, 3},
+		{[]int{1, 3, 2, 4, 0}, 3},
+	}
+	for _, tt := range tests {
+		if got := CountRuns(tt.in); got != tt.want {
+			t.Errorf("CountRuns(%v) = %d, want %d", tt.in, got, tt.want)
+		}
+	}
+}
+
+// naiveInversions is the O(n²) definition CountInversions must agree with
+func naiveInversions(arr []int) int64 {
+	var count int64
+	for i := range arr {
+		for j := i + 1; j < len(arr); j++ {
+			if arr[i] > arr[j] {
+				count++
+			}
+		}
+	}
+	return count
+}
+
+func TestCountInversions(t *testing.T) {
+	tests := []struct {
+		in   []int
+		want int64
+	}{
+		{nil, 0},
+		{[]int{1, 2, 3}, 0},
+		{[]int{3, 2, 1}, 3},
+		{[]int{2, 2, 2}, 0}, // Equal elements are not inversions
+		{[]int{2, 4, 1, 3, 5}, 3},
+	}
+	for _, tt := range tests {
+		if got := CountInversions(tt.in); got != tt.want {
+			t.Errorf("CountInversions(%v) = %d, want %d", tt.in, got, tt.want)
+		}
+	}
+
+	property := func(in []int8) bool {
+		arr := make([]int, len(in))
+		for i, v := range in {
+			arr[i] = int(v)
+		}
+		before := slices.Clone(arr)
+		return CountInversions(arr) == naiveInversions(arr) && slices.Equal(arr, before)
+	}
+	if err := quick.Check(property, nil); err != nil {
+		t.Error(err)
+	}
+}
+
+func TestIsSorted(t *testing.T) {
+	if !IsSorted(nil) || !IsSorted([]int{1, 1, 2}) {
+		t.Error("IsSorted rejected a sorted slice")
+	}
+	if IsSorted([]int{2, 1}) {
+		t.Error("IsSorted accepted an unsorted slice")
+	}
+}
+
+// The killer must actually defeat QuickSort: every partition splits off one
+// element, so it compares about n²/2 times instead of about 2n ln n
+func TestMiddlePivotKiller(t *testing.T) {
+	const n = 200
+	compares := func(arr []int) int {
+		var rec trace.Recorder
+		QuickSortTrace(arr, &rec)
+		if !IsSorted(arr) {
+			t.Fatalf("QuickSortTrace did not sort %v", arr)
+		}
+		return rec.Count(trace.Compare)
+	}
+	killer := compares(generator.MiddlePivotKiller(n))
+	random := compares(generator.New(1).Perm(n))
+	if killer < n*(n-1)/2 || random > n*n/8 {
+		t.Errorf("QuickSort compared %d times on the killer and %d on random input", killer, random)
+	}
+}
+
+// The iterative sorts on a million elements, in the shapes that make the
+// recursive versions go deepest
+func TestIterativeSortsLargeInput(t *testing.T) {
+	const n = 1_000_000
+	gen := generator.New(1)
+	inputs := map[string][]int{
+		"random":     gen.Ints(n, 0),
+		"sorted":     generator.Sorted(n),
+		"reversed":   generator.Reversed(n),
+		"duplicates": gen.FewUnique(n, 3),
+	}
+	for name, in := range inputs {
+		arr := slices.Clone(in)
+		QuickSortIterative(arr)
+		if !IsSorted(arr) {
+			t.Errorf("QuickSortIterative left %s input unsorted", name)
+		}
+		if !IsSorted(MergeSortBottomUp(in)) {
+			t.Errorf("MergeSortBottomUp left %s input unsorted", name)
+		}
+	}
+}
+
+// samePermutation reports whether a and b hold the same multiset of values
+func samePermutation(a, b []int) bool {
+	a, b = slices.Clone(a), slices.Clone(b)
+	slices.Sort(a)
+	slices.Sort(b)
+	return slices.Equal(a, b)
+}
+
+// benchDistributions generate inputs that favour or defeat different sorts
+// A fixed seed keeps the inputs identical across runs, so benchstat
+// compares like with like
+var benchDistributions = []struct {
+	name     string
+	generate func(gen *generator.Generator, n int) []int
+}{
+	{"random", func(gen *generator.Generator, n int) []int { return gen.Ints(n, 0) }},
+	{"sorted", func(gen *generator.Generator, n int) []int { return generator.Sorted(n) }},
+	{"reversed", func(gen *generator.Generator, n int) []int { return generator.Reversed(n) }},
+	{"duplicates", func(gen *generator.Generator, n int) []int { return gen.FewUnique(n, 4) }},
+}
+
+var benchSizes = []int{100, 1_000, 10_000}
+
+// BenchmarkSort runs every sort on every distribution and size
+// Names use key=value parts so benchstat can pivot on them, e.g.
+//
+//	benchstat -col /algo bench.txt
+//
+// puts the algorithms side by side. Going from n=1000 to n=10000 multiplies
+// the time of the O(n²) sorts by about 100 and of the O(n log n) sorts by about 13
+func BenchmarkSort(b *testing.B) {
+	sorts := map[string]func([]int) []int{
+		// The standard library's pattern-defeating quicksort as a baseline
+		"slices.Sort": func(arr []int) []int { slices.Sort(arr); return arr },
+	}
+	for name, sort := range inPlaceSorts {
+		sorts[name] = func(arr []int) []int { sort(arr); return arr }
+	}
+	for name, sort := range copySorts {
+		sorts[name] = sort
+	}
+	names := make([]string, 0, len(sorts))
+	for name := range sorts {
+		names = append(names, name)
+	}
+	slices.Sort(names) // Map order is random; keep the output order stable
+
+	for _, name := range names {
+		for _, dist := range benchDistributions {
+			for _, n := range benchSizes {
+				input := dist.generate(generator.New(1), n)
+				work := make([]int, n)
+				b.Run(fmt.Sprintf("algo=%s/dist=%s/n=%d", name, dist.name, n), func(b *testing.B) {
+					b.ReportAllocs()
+					for i := 0; i < b.N; i++ {
+						// Every sort gets a fresh unsorted copy; the O(n)
+						// copy is small next to the sort itself
+						copy(work, input)
+						sorts[name](work)
+					}
+				})
+			}
+		}
+	}
+}
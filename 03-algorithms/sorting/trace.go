@@ -0,0 +1,82 @@
+package sorting
+
+import "github.com/NutProhmpiriya/go-basic/trace"
+
+// The functions in this file are the traced twins of the sorts above
+// They sort exactly like the plain versions but report every comparison
+// and swap to a trace.Tracer, e.g. trace.NewBars to watch the array change
+
+// BubbleSortTrace is BubbleSort reporting its steps to t
+func BubbleSortTrace(arr []int, t trace.Tracer) {
+	n := len(arr)
+	for i := 0; i < n-1; i++ {
+		swapped := false
+		for j := 0; j < n-i-1; j++ {
+			t.Step(trace.Event{Op: trace.Compare, I: j, J: j + 1})
+			if arr[j] > arr[j+1] {
+				arr[j], arr[j+1] = arr[j+1], arr[j]
+				t.Step(trace.Event{Op: trace.Swap, I: j, J: j + 1})
+				swapped = true
+			}
+		}
+		if !swapped {
+			break
+		}
+	}
+}
+
+// InsertionSortTrace is InsertionSort reporting its steps to t
+// It moves each key left by swapping it with its neighbour, so every move
+// shows up as a step; the plain version shifts the larger elements instead
+func InsertionSortTrace(arr []int, t trace.Tracer) {
+	for i := 1; i < len(arr); i++ {
+		for j := i; j > 0; j-- {
+			t.Step(trace.Event{Op: trace.Compare, I: j - 1, J: j})
+			if arr[j-1] <= arr[j] {
+				break
+			}
+			arr[j-1], arr[j] = arr[j], arr[j-1]
+			t.Step(trace.Event{Op: trace.Swap, I: j - 1, J: j})
+		}
+	}
+}
+
+// QuickSortTrace is QuickSort reporting its steps to t
+// Every partition starts with a Range event for the part being sorted
+func QuickSortTrace(arr []int, t trace.Tracer) {
+	quickSortTrace(arr, 0, len(arr)-1, t)
+}
+
+func quickSortTrace(arr []int, low, high int, t trace.Tracer) {
+	if low >= high {
+		return
+	}
+	t.Step(trace.Event{Op: trace.Range, I: low, J: high})
+
+	// The same three-way partition as ThreeWayPartition, on arr[low:high+1]
+	pivot := arr[low+(high-low)/2]
+	lt, i, gt := low, low, high+1
+	for i < gt {
+		t.Step(trace.Event{Op: trace.Compare, I: i, J: -1})
+		switch {
+		case arr[i] < pivot:
+			arr[lt], arr[i] = arr[i], arr[lt]
+			if lt != i {
+				t.Step(trace.Event{Op: trace.Swap, I: lt, J: i})
+			}
+			lt++
+			i++
+		case arr[i] > pivot:
+			gt--
+			arr[i], arr[gt] = arr[gt], arr[i]
+			if i != gt {
+				t.Step(trace.Event{Op: trace.Swap, I: i, J: gt})
+			}
+		default:
+			i++
+		}
+	}
+
+	quickSortTrace(arr, low, lt-1, t)
+	quickSortTrace(arr, gt, high, t)
+}
@@ -0,0 +1,77 @@
+package sorting
+
+import (
+	"io"
+	"slices"
+	"testing"
+	"testing/quick"
+
+	"github.com/NutProhmpiriya/go-basic/trace"
+)
+
+var tracedSorts = map[string]struct {
+	traced func([]int, trace.Tracer)
+	plain  func([]int)
+}{
+	"BubbleSortTrace":    {BubbleSortTrace, BubbleSort},
+	"InsertionSortTrace": {InsertionSortTrace, InsertionSort},
+	"QuickSortTrace":     {QuickSortTrace, QuickSort},
+}
+
+// Property: a traced sort gives the same result as its plain twin, and
+// replaying its swaps on a copy of the input gives that result too, so the
+// trace shows everything the sort did to the array
+func TestTracedSorts(t *testing.T) {
+	for name, sort := range tracedSorts {
+		t.Run(name, func(t *testing.T) {
+			property := func(arr []int8) bool {
+				input := make([]int, len(arr))
+				for i, v := range arr {
+					input[i] = int(v % 8) // Small values, so duplicates are common
+				}
+				want := slices.Clone(input)
+				sort.plain(want)
+
+				got := slices.Clone(input)
+				var rec trace.Recorder
+				sort.traced(got, &rec)
+
+				replay := slices.Clone(input)
+				for _, e := range rec.Events {
+					if e.Op == trace.Swap {
+						replay[e.I], replay[e.J] = replay[e.J], replay[e.I]
+					}
+				}
+				return slices.Equal(got, want) && slices.Equal(replay, want)
+			}
+			if err := quick.Check(property, nil); err != nil {
+				t.Error(err)
+			}
+		})
+	}
+}
+
+func TestBubbleSortTraceSteps(t *testing.T) {
+	// Sorted input: one pass of n-1 comparisons and no swaps
+	var rec trace.Recorder
+	BubbleSortTrace([]int{1, 2, 3, 4}, &rec)
+	if rec.Count(trace.Compare) != 3 || rec.Count(trace.Swap) != 0 {
+		t.Errorf("sorted input: %d compares, %d swaps; want 3, 0", rec.Count(trace.Compare), rec.Count(trace.Swap))
+	}
+
+	// Reversed input: every pair is an inversion, and each swap fixes one
+	rec = trace.Recorder{}
+	arr := []int{4, 3, 2, 1}
+	BubbleSortTrace(arr, &rec)
+	if got := int64(rec.Count(trace.Swap)); got != CountInversions([]int{4, 3, 2, 1}) {
+		t.Errorf("reversed input: %d swaps, want 6 (the inversions)", got)
+	}
+
+	// The bars tracer follows the array along
+	arr = []int{3, 1, 2}
+	bars := trace.NewBars(io.Discard, arr)
+	QuickSortTrace(arr, bars)
+	if !slices.Equal(bars.Values(), arr) {
+		t.Errorf("Bars.Values() = %v, want %v", bars.Values(), arr)
+	}
+}
@@ -0,0 +1,316 @@
+// This file implements online (streaming) statistics algorithms in Go
+// Online algorithms see each sample once and keep O(1) state, so they can
+// summarize millions of benchmark timings or simulation results without
+// storing every sample in memory
+//
+// Algorithms:
+// 1. Welford's algorithm: numerically stable running mean and variance
+// 2. P² (P-square) algorithm: running estimate of a percentile with 5 markers
+// 3. Fixed-bucket histogram: counts samples per equal-width bucket
+//
+// Time Complexity: O(1) per sample for all three
+// Space Complexity: O(1) for Welford and P², O(buckets) for the histogram
+
+// Package stats computes statistics over a stream in constant memory
+package stats
+
+import (
+	"fmt"
+	"math"
+	"sort"
+	"strings"
+)
+
+// RunningStats tracks count, mean, variance, min and max using Welford's algorithm
+// The naive formula Var = E[x²] - E[x]² subtracts two huge, almost equal numbers
+// and loses precision; Welford updates the mean and the sum of squared
+// deviations (m2) incrementally instead
+type RunningStats struct {
+	count int
+	mean  float64
+	m2    float64
+	min   float64
+	max   float64
+}
+
+// Add includes a sample in the statistics
+// Time Complexity: O(1)
+func (s *RunningStats) Add(x float64) {
+	s.count++
+	if s.count == 1 {
+		s.min, s.max = x, x
+	} else {
+		s.min = math.Min(s.min, x)
+		s.max = math.Max(s.max, x)
+	}
+
+	delta := x - s.mean
+	s.mean += delta / float64(s.count)
+	// Uses the old and the new mean, which keeps the update stable
+	s.m2 += delta * (x - s.mean)
+}
+
+// Merge combines statistics gathered separately, e.g. by parallel workers
+// Uses Chan et al.'s parallel variant of Welford's algorithm
+// Time Complexity: O(1)
+func (s *RunningStats) Merge(other RunningStats) {
+	if other.count == 0 {
+		return
+	}
+	if s.count == 0 {
+		*s = other
+		return
+	}
+
+	total := s.count + other.count
+	delta := other.mean - s.mean
+	s.mean += delta * float64(other.count) / float64(total)
+	s.m2 += other.m2 + delta*delta*float64(s.count)*float64(other.count)/float64(total)
+	s.min = math.Min(s.min, other.min)
+	s.max = math.Max(s.max, other.max)
+	s.count = total
+}
+
+// Count returns the number of samples
+func (s *RunningStats) Count() int {
+	return s.count
+}
+
+// Mean returns the average of the samples
+func (s *RunningStats) Mean() float64 {
+	return s.mean
+}
+
+// Variance returns the sample variance (divides by n-1)
+func (s *RunningStats) Variance() float64 {
+	if s.count < 2 {
+		return 0
+	}
+	return s.m2 / float64(s.count-1)
+}
+
+// StdDev returns the sample standard deviation
+func (s *RunningStats) StdDev() float64 {
+	return math.Sqrt(s.Variance())
+}
+
+// Min returns the smallest sample
+func (s *RunningStats) Min() float64 {
+	return s.min
+}
+
+// Max returns the largest sample
+func (s *RunningStats) Max() float64 {
+	return s.max
+}
+
+// P2Quantile estimates a single quantile (e.g. the median or p99) of a stream
+// using the P² algorithm by Jain and Chlamtac
+// It keeps five markers: the minimum, the p/2, p and (1+p)/2 quantiles and the
+// maximum, and nudges the middle markers towards their ideal positions using
+// piecewise-parabolic interpolation as samples arrive
+type P2Quantile struct {
+	p       float64
+	count   int
+	heights [5]float64 // marker heights (estimated quantile values)
+	pos     [5]int     // actual marker positions (1-based ranks)
+	desired [5]float64 // desired marker positions
+	incr    [5]float64 // desired position increments per sample
+}
+
+// NewP2Quantile creates an estimator for quantile p in (0, 1)
+func NewP2Quantile(p float64) *P2Quantile {
+	return &P2Quantile{p: p}
+}
+
+// Add includes a sample in the estimate
+// Time Complexity: O(1)
+func (q *P2Quantile) Add(x float64) {
+	// The first five samples initialize the markers
+	if q.count < 5 {
+		q.heights[q.count] = x
+		q.count++
+		if q.count == 5 {
+			sort.Float64s(q.heights[:])
+			p := q.p
+			q.pos = [5]int{1, 2, 3, 4, 5}
+			q.desired = [5]float64{1, 1 + 2*p, 1 + 4*p, 3 + 2*p, 5}
+			q.incr = [5]float64{0, p / 2, p, (1 + p) / 2, 1}
+		}
+		return
+	}
+	q.count++
+
+	// Find the cell k such that heights[k] <= x < heights[k+1],
+	// extending the extreme markers if needed
+	var k int
+	switch {
+	case x < q.heights[0]:
+		q.heights[0] = x
+		k = 0
+	case x >= q.heights[4]:
+		q.heights[4] = x
+		k = 3
+	default:
+		for k = 0; k < 3; k++ {
+			if x < q.heights[k+1] {
+				break
+			}
+		}
+	}
+
+	// Shift the positions of the markers above the new sample
+	for i := k + 1; i < 5; i++ {
+		q.pos[i]++
+	}
+	for i := range q.desired {
+		q.desired[i] += q.incr[i]
+	}
+
+	// Adjust the middle markers if they drifted by at least one position
+	for i := 1; i <= 3; i++ {
+		d := q.desired[i] - float64(q.pos[i])
+		if (d >= 1 && q.pos[i+1]-q.pos[i] > 1) || (d <= -1 && q.pos[i-1]-q.pos[i] < -1) {
+			step := 1
+			if d < 0 {
+				step = -1
+			}
+			height := q.parabolic(i, step)
+			if q.heights[i-1] < height && height < q.heights[i+1] {
+				q.heights[i] = height
+			} else {
+				q.heights[i] = q.linear(i, step)
+			}
+			q.pos[i] += step
+		}
+	}
+}
+
+// parabolic predicts a marker's new height with the P² formula
+func (q *P2Quantile) parabolic(i, step int) float64 {
+	d := float64(step)
+	n0, n1, n2 := float64(q.pos[i-1]), float64(q.pos[i]), float64(q.pos[i+1])
+	h0, h1, h2 := q.heights[i-1], q.heights[i], q.heights[i+1]
+	return h1 + d/(n2-n0)*((n1-n0+d)*(h2-h1)/(n2-n1)+(n2-n1-d)*(h1-h0)/(n1-n0))
+}
+
+// linear is the fallback when the parabolic prediction is out of order
+func (q *P2Quantile) linear(i, step int) float64 {
+	return q.heights[i] + float64(step)*(q.heights[i+step]-q.heights[i])/float64(q.pos[i+step]-q.pos[i])
+}
+
+// Value returns the current estimate of the quantile
+func (q *P2Quantile) Value() float64 {
+	if q.count == 0 {
+		return 0
+	}
+	if q.count < 5 {
+		// Not enough samples for the markers yet: compute it exactly
+		samples := append([]float64{}, q.heights[:q.count]...)
+		sort.Float64s(samples)
+		index := int(math.Round(q.p * float64(q.count-1)))
+		return samples[index]
+	}
+	return q.heights[2]
+}
+
+// Histogram counts samples in equal-width buckets over [min, max)
+// Samples outside the range are counted as underflow or overflow
+type Histogram struct {
+	min       float64
+	width     float64
+	buckets   []int
+	underflow int
+	overflow  int
+}
+
+// NewHistogram creates a histogram with the given number of buckets over [min, max)
+func NewHistogram(min, max float64, buckets int) *Histogram {
+	return &Histogram{
+		min:     min,
+		width:   (max - min) / float64(buckets),
+		buckets: make([]int, buckets),
+	}
+}
+
+// Add counts a sample in its bucket
+// Time Complexity: O(1)
+func (h *Histogram) Add(x float64) {
+	index := int(math.Floor((x - h.min) / h.width))
+	switch {
+	case index < 0:
+		h.underflow++
+	case index >= len(h.buckets):
+		h.overflow++
+	default:
+		h.buckets[index]++
+	}
+}
+
+// Counts returns the number of samples in each bucket
+func (h *Histogram) Counts() []int {
+	return append([]int{}, h.buckets...)
+}
+
+// String renders the histogram as ASCII bars
+func (h *Histogram) String() string {
+	const barWidth = 40
+	largest := 1
+	for _, count := range h.buckets {
+		largest = max(largest, count)
+	}
+
+	var sb strings.Builder
+	for i, count := range h.buckets {
+		low := h.min + float64(i)*h.width
+		line := fmt.Sprintf("[%8.2f, %8.2f) %6d %s", low, low+h.width, count, strings.Repeat("#", count*barWidth/largest))
+		sb.WriteString(strings.TrimRight(line, " ") + "\n") // No trailing space after an empty bar
+	}
+	fmt.Fprintf(&sb, "underflow: %d, overflow: %d\n", h.underflow, h.overflow)
+	return sb.String()
+}
+
+// NaiveVariance uses the textbook one-pass formula E[x²] - E[x]²
+// It is shown only to demonstrate catastrophic cancellation
+func NaiveVariance(samples []float64) float64 {
+	sum, sumSquares := 0.0, 0.0
+	for _, x := range samples {
+		sum += x
+		sumSquares += x * x
+	}
+	n := float64(len(samples))
+	return (sumSquares - sum*sum/n) / (n - 1)
+}
+
+// ExactQuantile sorts the samples and picks the quantile
+// Used to check the P² estimate; needs O(n) memory
+func ExactQuantile(samples []float64, p float64) float64 {
+	sorted := append([]float64{}, samples...)
+	sort.Float64s(sorted)
+	return sorted[int(p*float64(len(sorted)-1))]
+}
+
+// Summary combines the streaming estimators for a benchmark or simulation
+type Summary struct {
+	Stats     RunningStats
+	Median    *P2Quantile
+	P99       *P2Quantile
+	Histogram *Histogram
+}
+
+// NewSummary creates a summary whose histogram covers [min, max)
+func NewSummary(min, max float64, buckets int) *Summary {
+	return &Summary{
+		Median:    NewP2Quantile(0.5),
+		P99:       NewP2Quantile(0.99),
+		Histogram: NewHistogram(min, max, buckets),
+	}
+}
+
+// Add feeds a sample to every estimator
+func (s *Summary) Add(x float64) {
+	s.Stats.Add(x)
+	s.Median.Add(x)
+	s.P99.Add(x)
+	s.Histogram.Add(x)
+}
@@ -0,0 +1,259 @@
+package stats
+
+import (
+	"math"
+	"math/rand"
+	"slices"
+	"strings"
+	"testing"
+	"testing/quick"
+)
+
+// twoPassVariance is the textbook reference: compute the mean first, then
+// the squared deviations from it
+func twoPassVariance(samples []float64) float64 {
+	mean := 0.0
+	for _, x := range samples {
+		mean += x
+	}
+	mean /= float64(len(samples))
+	sum := 0.0
+	for _, x := range samples {
+		sum += (x - mean) * (x - mean)
+	}
+	return sum / float64(len(samples)-1)
+}
+
+// closeTo reports whether got is within a relative tolerance of want
+func closeTo(got, want, tolerance float64) bool {
+	return math.Abs(got-want) <= tolerance*math.Max(1, math.Abs(want))
+}
+
+func TestRunningStats(t *testing.T) {
+	var empty RunningStats
+	if empty.Count() != 0 || empty.Mean() != 0 || empty.Variance() != 0 || empty.StdDev() != 0 {
+		t.Errorf("zero RunningStats = %+v, want all zero", empty)
+	}
+
+	var s RunningStats
+	for _, x := range []float64{2, 4, 4, 4, 5, 5, 7, 9} {
+		s.Add(x)
+	}
+	if s.Count() != 8 || s.Mean() != 5 || s.Min() != 2 || s.Max() != 9 {
+		t.Errorf("Count, Mean, Min, Max = %d, %v, %v, %v; want 8, 5, 2, 9",
+			s.Count(), s.Mean(), s.Min(), s.Max())
+	}
+	if want := 32.0 / 7; !closeTo(s.Variance(), want, 1e-12) {
+		t.Errorf("Variance() = %v, want %v", s.Variance(), want)
+	}
+	if want := math.Sqrt(32.0 / 7); !closeTo(s.StdDev(), want, 1e-12) {
+		t.Errorf("StdDev() = %v, want %v", s.StdDev(), want)
+	}
+
+	var single RunningStats
+	single.Add(-3)
+	if single.Variance() != 0 || single.Min() != -3 || single.Max() != -3 {
+		t.Errorf("one sample: Variance, Min, Max = %v, %v, %v", single.Variance(), single.Min(), single.Max())
+	}
+}
+
+// Property: Welford matches the two-pass formula on arbitrary data
+func TestRunningStatsProperty(t *testing.T) {
+	property := func(raw []int16) bool {
+		if len(raw) < 2 {
+			return true
+		}
+		samples := make([]float64, len(raw))
+		var s RunningStats
+		for i, v := range raw {
+			samples[i] = float64(v)
+			s.Add(samples[i])
+		}
+		return closeTo(s.Variance(), twoPassVariance(samples), 1e-9) &&
+			s.Min() == slices.Min(samples) && s.Max() == slices.Max(samples)
+	}
+	if err := quick.Check(property, nil); err != nil {
+		t.Error(err)
+	}
+}
+
+// The reason Welford exists: a large offset ruins the naive formula
+func TestRunningStatsStability(t *testing.T) {
+	rng := rand.New(rand.NewSource(1))
+	samples := make([]float64, 1000)
+	var s RunningStats
+	for i := range samples {
+		samples[i] = 1e9 + rng.NormFloat64()
+		s.Add(samples[i])
+	}
+	want := twoPassVariance(samples)
+	if !closeTo(s.Variance(), want, 1e-6) {
+		t.Errorf("Welford variance = %v, want %v", s.Variance(), want)
+	}
+	if closeTo(NaiveVariance(samples), want, 1e-2) {
+		t.Errorf("NaiveVariance = %v is accurate; the demo expects it to lose precision", NaiveVariance(samples))
+	}
+}
+
+// Property: splitting a stream anywhere and merging gives the same result
+// as one pass over the whole stream
+func TestRunningStatsMerge(t *testing.T) {
+	property := func(raw []int16, split uint8) bool {
+		cut := 0
+		if len(raw) > 0 {
+			cut = int(split) % (len(raw) + 1)
+		}
+		var whole, left, right RunningStats
+		for i, v := range raw {
+			whole.Add(float64(v))
+			if i < cut {
+				left.Add(float64(v))
+			} else {
+				right.Add(float64(v))
+			}
+		}
+		left.Merge(right)
+		return left.Count() == whole.Count() &&
+			closeTo(left.Mean(), whole.Mean(), 1e-9) &&
+			closeTo(left.Variance(), whole.Variance(), 1e-9) &&
+			left.Min() == whole.Min() && left.Max() == whole.Max()
+	}
+	if err := quick.Check(property, nil); err != nil {
+		t.Error(err)
+	}
+}
+
+func TestP2QuantileFewSamples(t *testing.T) {
+	q := NewP2Quantile(0.5)
+	if got := q.Value(); got != 0 {
+		t.Errorf("Value() with no samples = %v, want 0", got)
+	}
+	// Below five samples the estimate is exact
+	for _, x := range []float64{9, 1, 5} {
+		q.Add(x)
+	}
+	if got := q.Value(); got != 5 {
+		t.Errorf("median of {9, 1, 5} = %v, want 5", got)
+	}
+}
+
+func TestP2QuantileAccuracy(t *testing.T) {
+	distributions := map[string]func(*rand.Rand) float64{
+		"uniform":     (*rand.Rand).Float64,
+		"normal":      (*rand.Rand).NormFloat64,
+		"exponential": (*rand.Rand).ExpFloat64,
+	}
+	for name, draw := range distributions {
+		for _, p := range []float64{0.1, 0.5, 0.9, 0.99} {
+			rng := rand.New(rand.NewSource(7))
+			samples := make([]float64, 50000)
+			q := NewP2Quantile(p)
+			for i := range samples {
+				samples[i] = draw(rng)
+				q.Add(samples[i])
+			}
+			want := ExactQuantile(samples, p)
+			// Tolerance relative to the spread of the data
+			spread := ExactQuantile(samples, 0.99) - ExactQuantile(samples, 0.01)
+			if math.Abs(q.Value()-want) > 0.02*spread {
+				t.Errorf("%s p%v: estimate %v, exact %v", name, p*100, q.Value(), want)
+			}
+		}
+	}
+}
+
+// Property: the estimate always lies between the smallest and largest sample
+func TestP2QuantileProperty(t *testing.T) {
+	property := func(raw []int16, rawP uint8) bool {
+		if len(raw) == 0 {
+			return true
+		}
+		p := (float64(rawP%99) + 1) / 100
+		q := NewP2Quantile(p)
+		samples := make([]float64, len(raw))
+		for i, v := range raw {
+			samples[i] = float64(v)
+			q.Add(samples[i])
+		}
+		return q.Value() >= slices.Min(samples) && q.Value() <= slices.Max(samples)
+	}
+	if err := quick.Check(property, nil); err != nil {
+		t.Error(err)
+	}
+}
+
+func TestExactQuantile(t *testing.T) {
+	samples := []float64{5, 1, 4, 2, 3}
+	for p, want := range map[float64]float64{0: 1, 0.5: 3, 1: 5} {
+		if got := ExactQuantile(samples, p); got != want {
+			t.Errorf("ExactQuantile(%v, %v) = %v, want %v", samples, p, got, want)
+		}
+	}
+	if !slices.Equal(samples, []float64{5, 1, 4, 2, 3}) {
+		t.Errorf("ExactQuantile modified its input: %v", samples)
+	}
+}
+
+func TestHistogram(t *testing.T) {
+	h := NewHistogram(0, 10, 5)
+	for _, x := range []float64{-1, 0, 1.9, 2, 5, 9.99, 10, 42} {
+		h.Add(x)
+	}
+	if got, want := h.Counts(), []int{2, 1, 1, 0, 1}; !slices.Equal(got, want) {
+		t.Errorf("Counts() = %v, want %v", got, want)
+	}
+	if h.underflow != 1 || h.overflow != 2 {
+		t.Errorf("underflow, overflow = %d, %d; want 1, 2", h.underflow, h.overflow)
+	}
+
+	// Counts returns a copy
+	h.Counts()[0] = 100
+	if h.Counts()[0] != 2 {
+		t.Error("modifying Counts() changed the histogram")
+	}
+
+	out := h.String()
+	if lines := strings.Count(out, "\n"); lines != 6 {
+		t.Errorf("String() has %d lines, want 6:\n%s", lines, out)
+	}
+	if !strings.Contains(out, "underflow: 1, overflow: 2") {
+		t.Errorf("String() is missing the underflow/overflow line:\n%s", out)
+	}
+}
+
+// Property: every sample is counted exactly once
+func TestHistogramProperty(t *testing.T) {
+	property := func(raw []int16) bool {
+		h := NewHistogram(-1000, 1000, 16)
+		for _, v := range raw {
+			h.Add(float64(v))
+		}
+		total := h.underflow + h.overflow
+		for _, c := range h.Counts() {
+			total += c
+		}
+		return total == len(raw)
+	}
+	if err := quick.Check(property, nil); err != nil {
+		t.Error(err)
+	}
+}
+
+func TestSummary(t *testing.T) {
+	s := NewSummary(0, 100, 10)
+	for i := 1; i <= 100; i++ {
+		s.Add(float64(i))
+	}
+	if s.Stats.Count() != 100 || s.Stats.Mean() != 50.5 {
+		t.Errorf("Stats Count, Mean = %d, %v; want 100, 50.5", s.Stats.Count(), s.Stats.Mean())
+	}
+	if got := s.Median.Value(); math.Abs(got-50.5) > 2 {
+		t.Errorf("Median = %v, want about 50.5", got)
+	}
+	if got := s.P99.Value(); got < 95 || got > 100 {
+		t.Errorf("P99 = %v, want between 95 and 100", got)
+	}
+	if got, want := s.Histogram.Counts(), []int{9, 10, 10, 10, 10, 10, 10, 10, 10, 10}; !slices.Equal(got, want) {
+		t.Errorf("Histogram.Counts() = %v, want %v", got, want)
+	}
+}
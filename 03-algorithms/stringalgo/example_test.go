@@ -0,0 +1,27 @@
+package stringalgo_test
+
+import (
+	"fmt"
+
+	"github.com/NutProhmpiriya/go-basic/03-algorithms/stringalgo"
+)
+
+func ExampleKMPSearch() {
+	fmt.Println(stringalgo.KMPSearch("abababc", "abab"))
+	// Output: [0 2]
+}
+
+func ExampleLevenshteinDistance() {
+	fmt.Println(stringalgo.LevenshteinDistance("kitten", "sitting"))
+	// Output: 3
+}
+
+func ExampleTokenize() {
+	fmt.Printf("%q\n", stringalgo.Tokenize(`"Don't panic," said the naïve e-mail.`))
+	// Output: ["Don't" "panic" "said" "the" "naïve" "e-mail"]
+}
+
+func ExampleDamerauLevenshteinDistance() {
+	fmt.Println(stringalgo.LevenshteinDistance("teh", "the"), stringalgo.DamerauLevenshteinDistance("teh", "the"))
+	// Output: 2 1
+}
@@ -0,0 +1,39 @@
+package stringalgo
+
+import "github.com/NutProhmpiriya/go-basic/03-algorithms/registry"
+
+// The string algorithms register themselves with the complexities claimed
+// in their doc comments, so the learn examples, the complexity checks and
+// the catalog benchmarks find them
+func init() {
+	for _, a := range []registry.Algorithm{
+		{
+			Name: "kmp", Summary: "finds every match of a pattern using its failure table",
+			Time: "O(n + m)", Space: "O(m)", Input: registry.Text,
+			Run: func(in registry.Input) any { return KMPSearch(in.Text, in.Pattern) },
+		},
+		{
+			Name: "rabin-karp", Summary: "finds every match of a pattern with a rolling hash",
+			Time: "O(n + m) average, O(nm) worst", Space: "O(1)", Input: registry.Text,
+			Run: func(in registry.Input) any { return RabinKarp(in.Text, in.Pattern) },
+		},
+		{
+			Name: "levenshtein", Summary: "edit distance with insertions, deletions and substitutions",
+			Time: "O(mn)", Space: "O(mn)", Input: registry.StringPair,
+			Run: func(in registry.Input) any { return LevenshteinDistance(in.Text, in.Pattern) },
+		},
+		{
+			Name: "damerau-levenshtein", Summary: "edit distance that also counts swapped neighbours as one edit",
+			Time: "O(mn)", Space: "O(n)", Input: registry.StringPair,
+			Run: func(in registry.Input) any { return DamerauLevenshteinDistance(in.Text, in.Pattern) },
+		},
+		{
+			Name: "longest-palindrome", Summary: "the longest palindromic substring, by dynamic programming",
+			Time: "O(n²)", Space: "O(n²)", Input: registry.Text,
+			Run: func(in registry.Input) any { return LongestPalindromicSubstring(in.Text) },
+		},
+	} {
+		a.Category = "strings"
+		registry.Register(a)
+	}
+}
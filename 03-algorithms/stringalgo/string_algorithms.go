@@ -2,16 +2,18 @@
 // String algorithms are fundamental in text processing, pattern matching,
 // and many other applications
 
-package main
-
-import (
-	"fmt"
-)
+// Package stringalgo implements string matching, edit distance and palindrome
+// algorithms, and a word tokenizer for Unicode text
+package stringalgo
 
 // KMPSearch implements the Knuth-Morris-Pratt string matching algorithm
 // Time Complexity: O(n + m) where n is text length and m is pattern length
 // Space Complexity: O(m) for the LPS array
 func KMPSearch(text, pattern string) []int {
+	if pattern == "" {
+		return emptyPatternMatches(text)
+	}
+
 	// Compute LPS (Longest Proper Prefix which is also Suffix) array
 	lps := computeLPSArray(pattern)
 	matches := []int{}
@@ -61,10 +63,25 @@ func computeLPSArray(pattern string) []int {
 	return lps
 }
 
+// emptyPatternMatches returns every byte offset in text, including len(text)
+// The empty pattern occurs at every position, so every offset is a match
+// Both matchers need this special case: KMP would index pattern[0] and
+// Rabin-Karp would roll its window hash past the empty window
+func emptyPatternMatches(text string) []int {
+	matches := make([]int, len(text)+1)
+	for i := range matches {
+		matches[i] = i
+	}
+	return matches
+}
+
 // RabinKarp implements the Rabin-Karp string matching algorithm
 // Time Complexity: O(n + m) average case, O(nm) worst case
 // Space Complexity: O(1)
 func RabinKarp(text, pattern string) []int {
+	if pattern == "" {
+		return emptyPatternMatches(text)
+	}
 	if len(pattern) > len(text) {
 		return []int{}
 	}
@@ -153,6 +170,53 @@ func LevenshteinDistance(s1, s2 string) int {
 	return dp[m][n]
 }
 
+// DamerauLevenshteinDistance is LevenshteinDistance with one more edit:
+// swapping two adjacent characters counts as one edit instead of two, so
+// "teh" is one edit from "the". Swaps are the most common typing mistake,
+// which makes it the better distance for ranking spelling suggestions
+// This is the restricted variant, also called optimal string alignment: a
+// substring is never edited again once swapped, so "ca" is three edits from
+// "abc" rather than two. That keeps it a simple extension of the Levenshtein
+// table, but it breaks the triangle inequality, so unlike
+// LevenshteinDistance it is not a metric a BK-tree can index by
+// It compares runes rather than bytes, so an accented letter is one character
+// Time Complexity: O(mn)
+// Space Complexity: O(n) using three rolling rows
+func DamerauLevenshteinDistance(s1, s2 string) int {
+	a, b := []rune(s1), []rune(s2)
+	m, n := len(a), len(b)
+
+	// prev2, prev and curr are the rows for i-2, i-1 and i
+	prev2 := make([]int, n+1)
+	prev := make([]int, n+1)
+	curr := make([]int, n+1)
+	for j := range prev {
+		prev[j] = j
+	}
+
+	for i := 1; i <= m; i++ {
+		curr[0] = i
+		for j := 1; j <= n; j++ {
+			cost := 1
+			if a[i-1] == b[j-1] {
+				cost = 0
+			}
+			curr[j] = min(
+				prev[j]+1,      // deletion
+				curr[j-1]+1,    // insertion
+				prev[j-1]+cost, // substitution or match
+			)
+			// The last two characters are swapped
+			if i > 1 && j > 1 && a[i-1] == b[j-2] && a[i-2] == b[j-1] && prev2[j-2]+1 < curr[j] {
+				curr[j] = prev2[j-2] + 1
+			}
+		}
+		prev2, prev, curr = prev, curr, prev2
+	}
+
+	return prev[n]
+}
+
 // LongestPalindromicSubstring finds the longest palindromic substring
 // using dynamic programming
 // Time Complexity: O(n²)
@@ -213,36 +277,3 @@ func min(a, b, c int) int {
 	}
 	return c
 }
-
-func main() {
-	// Example 1: KMP String Matching
-	text := "AABAACAADAABAAABAA"
-	pattern := "AABA"
-	fmt.Println("KMP String Matching:")
-	fmt.Printf("Text: %s\nPattern: %s\n", text, pattern)
-	matches := KMPSearch(text, pattern)
-	fmt.Printf("Pattern found at indices: %v\n\n", matches)
-
-	// Example 2: Rabin-Karp String Matching
-	text2 := "GEEKS FOR GEEKS"
-	pattern2 := "GEEK"
-	fmt.Println("Rabin-Karp String Matching:")
-	fmt.Printf("Text: %s\nPattern: %s\n", text2, pattern2)
-	matches2 := RabinKarp(text2, pattern2)
-	fmt.Printf("Pattern found at indices: %v\n\n", matches2)
-
-	// Example 3: Levenshtein Distance
-	str1 := "kitten"
-	str2 := "sitting"
-	fmt.Println("Levenshtein Distance:")
-	fmt.Printf("String 1: %s\nString 2: %s\n", str1, str2)
-	distance := LevenshteinDistance(str1, str2)
-	fmt.Printf("Edit distance: %d\n\n", distance)
-
-	// Example 4: Longest Palindromic Substring
-	text3 := "babad"
-	fmt.Println("Longest Palindromic Substring:")
-	fmt.Printf("Text: %s\n", text3)
-	palindrome := LongestPalindromicSubstring(text3)
-	fmt.Printf("Longest palindrome: %s\n", palindrome)
-}
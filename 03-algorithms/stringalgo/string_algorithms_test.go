@@ -0,0 +1,412 @@
+package stringalgo
+
+import (
+	"fmt"
+	"slices"
+	"strings"
+	"testing"
+	"testing/quick"
+
+	"github.com/NutProhmpiriya/go-basic/testdata/generator"
+)
+
+// naiveSearch checks every alignment byte by byte; the matchers must agree with it
+// Time Complexity: O(nm)
+func naiveSearch(text, pattern string) []int {
+	matches := []int{}
+	for i := 0; i+len(pattern) <= len(text); i++ {
+		j := 0
+		for j < len(pattern) && text[i+j] == pattern[j] {
+			j++
+		}
+		if j == len(pattern) {
+			matches = append(matches, i)
+		}
+	}
+	return matches
+}
+
+var matchers = map[string]func(text, pattern string) []int{
+	"KMPSearch": KMPSearch,
+	"RabinKarp": RabinKarp,
+}
+
+func TestMatchers(t *testing.T) {
+	tests := []struct {
+		text, pattern string
+		want          []int
+	}{
+		{"AABAACAADAABAAABAA", "AABA", []int{0, 9, 13}},
+		{"GEEKS FOR GEEKS", "GEEK", []int{0, 10}},
+		{"aaaa", "aa", []int{0, 1, 2}}, // Overlapping matches
+		{"abc", "abc", []int{0}},
+		{"abc", "abcd", []int{}},
+		{"abc", "x", []int{}},
+		{"", "a", []int{}},
+		{"abc", "", []int{0, 1, 2, 3}}, // The empty pattern matches at every offset
+		{"", "", []int{0}},
+		{"สวัสดีครับ", "ครับ", []int{18}}, // Byte offsets, not rune offsets
+	}
+	for name, match := range matchers {
+		for _, tt := range tests {
+			if got := match(tt.text, tt.pattern); !slices.Equal(got, tt.want) {
+				t.Errorf("%s(%q, %q) = %v, want %v", name, tt.text, tt.pattern, got, tt.want)
+			}
+		}
+	}
+}
+
+// Property: both matchers report exactly the positions naiveSearch finds
+// Text and pattern are drawn from a two-letter alphabet so matches are frequent
+func TestMatchersProperty(t *testing.T) {
+	toAB := func(raw []byte) string {
+		var b strings.Builder
+		for _, c := range raw {
+			b.WriteByte('a' + c%2)
+		}
+		return b.String()
+	}
+	for name, match := range matchers {
+		t.Run(name, func(t *testing.T) {
+			property := func(rawText []byte, rawPattern []byte) bool {
+				text := toAB(rawText)
+				// Short non-empty patterns, so they actually occur in the text
+				if len(rawPattern) > 4 {
+					rawPattern = rawPattern[:4]
+				}
+				pattern := "a" + toAB(rawPattern)
+				return slices.Equal(match(text, pattern), naiveSearch(text, pattern))
+			}
+			if err := quick.Check(property, nil); err != nil {
+				t.Error(err)
+			}
+		})
+	}
+}
+
+func TestComputeLPSArray(t *testing.T) {
+	tests := []struct {
+		pattern string
+		want    []int
+	}{
+		{"", []int{}},
+		{"a", []int{0}},
+		{"aaaa", []int{0, 1, 2, 3}},
+		{"abab", []int{0, 0, 1, 2}},
+		{"aabaaab", []int{0, 1, 0, 1, 2, 2, 3}},
+	}
+	for _, tt := range tests {
+		if got := computeLPSArray(tt.pattern); !slices.Equal(got, tt.want) {
+			t.Errorf("computeLPSArray(%q) = %v, want %v", tt.pattern, got, tt.want)
+		}
+	}
+}
+
+func TestLevenshteinDistance(t *testing.T) {
+	tests := []struct {
+		s1, s2 string
+		want   int
+	}{
+		{"", "", 0},
+		{"abc", "", 3},
+		{"", "abc", 3},
+		{"kitten", "sitting", 3},
+		{"flaw", "lawn", 2},
+		{"same", "same", 0},
+		{"abc", "cba", 2},
+	}
+	for _, tt := range tests {
+		if got := LevenshteinDistance(tt.s1, tt.s2); got != tt.want {
+			t.Errorf("LevenshteinDistance(%q, %q) = %d, want %d", tt.s1, tt.s2, got, tt.want)
+		}
+	}
+}
+
+func TestDamerauLevenshteinDistance(t *testing.T) {
+	tests := []struct {
+		s1, s2 string
+		want   int
+	}{
+		{"", "", 0},
+		{"abc", "", 3},
+		{"", "abc", 3},
+		{"kitten", "sitting", 3},
+		{"teh", "the", 1},
+		{"recieve", "receive", 1},
+		{"abcd", "badc", 2},
+		// Restricted: the swapped pair is not edited again
+		{"ca", "abc", 3},
+		// Runes, not bytes
+		{"naïve", "naive", 1},
+		{"ไทย", "ทไย", 1},
+	}
+	for _, tt := range tests {
+		if got := DamerauLevenshteinDistance(tt.s1, tt.s2); got != tt.want {
+			t.Errorf("DamerauLevenshteinDistance(%q, %q) = %d, want %d", tt.s1, tt.s2, got, tt.want)
+		}
+	}
+
+	// It never exceeds the Levenshtein distance, is symmetric, and a single
+	// swap of two different characters costs one edit
+	g := generator.New(1)
+	for range 2000 {
+		a, b := g.String(g.Rand().Intn(8), "abc"), g.String(g.Rand().Intn(8), "abc")
+		d := DamerauLevenshteinDistance(a, b)
+		if lev := LevenshteinDistance(a, b); d > lev || d != DamerauLevenshteinDistance(b, a) {
+			t.Fatalf("DamerauLevenshteinDistance(%q, %q) = %d, Levenshtein %d", a, b, d, lev)
+		}
+		if i := len(a) - 2; i >= 0 && a[i] != a[i+1] {
+			swapped := a[:i] + string(a[i+1]) + string(a[i]) + a[i+2:]
+			if got := DamerauLevenshteinDistance(a, swapped); got != 1 {
+				t.Fatalf("DamerauLevenshteinDistance(%q, %q) = %d, want 1", a, swapped, got)
+			}
+		}
+	}
+}
+
+// LevenshteinDistance is a metric, so it must satisfy the metric axioms
+func TestLevenshteinDistanceProperties(t *testing.T) {
+	d := LevenshteinDistance
+
+	identity := func(s string) bool {
+		return d(s, s) == 0
+	}
+	symmetry := func(a, b string) bool {
+		return d(a, b) == d(b, a)
+	}
+	triangle := func(a, b, c string) bool {
+		return d(a, c) <= d(a, b)+d(b, c)
+	}
+	// At least the length difference, at most the longer length
+	bounds := func(a, b string) bool {
+		got := d(a, b)
+		diff := len(a) - len(b)
+		if diff < 0 {
+			diff = -diff
+		}
+		return got >= diff && got <= max(len(a), len(b))
+	}
+	// Each single-byte append costs exactly one insertion
+	insertion := func(a string, c byte) bool {
+		return d(a, a+string([]byte{c})) == 1
+	}
+
+	for name, property := range map[string]any{
+		"identity":  identity,
+		"symmetry":  symmetry,
+		"triangle":  triangle,
+		"bounds":    bounds,
+		"insertion": insertion,
+	} {
+		if err := quick.Check(property, nil); err != nil {
+			t.Errorf("%s: %v", name, err)
+		}
+	}
+}
+
+func isPalindrome(s string) bool {
+	for i, j := 0, len(s)-1; i < j; i, j = i+1, j-1 {
+		if s[i] != s[j] {
+			return false
+		}
+	}
+	return true
+}
+
+func TestLongestPalindromicSubstring(t *testing.T) {
+	tests := []struct {
+		in   string
+		want []string // Any of these is a correct answer
+	}{
+		{"", []string{""}},
+		{"a", []string{"a"}},
+		{"babad", []string{"bab", "aba"}},
+		{"cbbd", []string{"bb"}},
+		{"racecar", []string{"racecar"}},
+		{"abc", []string{"a", "b", "c"}},
+	}
+	for _, tt := range tests {
+		if got := LongestPalindromicSubstring(tt.in); !slices.Contains(tt.want, got) {
+			t.Errorf("LongestPalindromicSubstring(%q) = %q, want one of %q", tt.in, got, tt.want)
+		}
+	}
+}
+
+// Property: the result is a palindromic substring, and no longer one exists
+func TestLongestPalindromicSubstringProperty(t *testing.T) {
+	property := func(raw []byte) bool {
+		for i := range raw {
+			raw[i] = 'a' + raw[i]%3
+		}
+		s := string(raw)
+
+		got := LongestPalindromicSubstring(s)
+		if !strings.Contains(s, got) || !isPalindrome(got) {
+			return false
+		}
+		for i := range s {
+			for j := i + len(got) + 1; j <= len(s); j++ {
+				if isPalindrome(s[i:j]) {
+					return false
+				}
+			}
+		}
+		return true
+	}
+	if err := quick.Check(property, nil); err != nil {
+		t.Error(err)
+	}
+}
+
+// benchTexts builds texts where the naive matcher does well (random letters,
+// mismatches come early) and where it does badly (long runs of 'a' that match
+// almost all of the pattern before failing)
+func benchTexts(n int) map[string]string {
+	return map[string]string{
+		"random":     generator.New(1).String(n, "abcdefghijklmnopqrstuvwxyz"),
+		"repetitive": strings.Repeat("a", n),
+	}
+}
+
+// BenchmarkStringSearch compares the matchers with the naive O(nm) search
+// On repetitive text the naive search compares almost the whole pattern at
+// every position, while KMP never moves backwards in the text
+func BenchmarkStringSearch(b *testing.B) {
+	algos := []struct {
+		name  string
+		match func(text, pattern string) []int
+	}{
+		{"KMPSearch", KMPSearch},
+		{"RabinKarp", RabinKarp},
+		{"naive", naiveSearch},
+	}
+	pattern := strings.Repeat("a", 63) + "b"
+	for _, algo := range algos {
+		for _, n := range []int{1_000, 100_000} {
+			texts := benchTexts(n)
+			for _, dist := range []string{"random", "repetitive"} {
+				b.Run(fmt.Sprintf("algo=%s/dist=%s/n=%d", algo.name, dist, n), func(b *testing.B) {
+					b.ReportAllocs()
+					for i := 0; i < b.N; i++ {
+						algo.match(texts[dist], pattern)
+					}
+				})
+			}
+		}
+	}
+}
+
+// BenchmarkLevenshteinDistance shows O(mn) growth: each 10x increase in
+// length costs about 100x the time
+func BenchmarkLevenshteinDistance(b *testing.B) {
+	for _, n := range []int{10, 100, 1_000} {
+		texts := benchTexts(2 * n)
+		s1, s2 := texts["random"][:n], texts["random"][n:]
+		b.Run(fmt.Sprintf("n=%d", n), func(b *testing.B) {
+			b.ReportAllocs()
+			for i := 0; i < b.N; i++ {
+				LevenshteinDistance(s1, s2)
+			}
+		})
+	}
+}
+
+// BenchmarkLongestPalindromicSubstring is O(n²) in both time and memory
+func BenchmarkLongestPalindromicSubstring(b *testing.B) {
+	for _, n := range []int{10, 100, 1_000} {
+		texts := benchTexts(n)
+		for _, dist := range []string{"random", "repetitive"} {
+			b.Run(fmt.Sprintf("dist=%s/n=%d", dist, n), func(b *testing.B) {
+				b.ReportAllocs()
+				for i := 0; i < b.N; i++ {
+					LongestPalindromicSubstring(texts[dist])
+				}
+			})
+		}
+	}
+}
+
+// Fuzz targets: go test runs only the seeds below; to search for new
+// failing inputs run e.g.
+//
+//	go test ./03-algorithms/stringalgo -fuzz=FuzzKMPSearch
+//
+// Failing inputs are saved under testdata/fuzz and rerun by every go test
+var fuzzSeeds = [][2]string{
+	{"", ""},
+	{"abc", ""},
+	{"", "a"},
+	{"AABAACAADAABAAABAA", "AABA"},
+	{"aaaaaaaa", "aaa"},
+	{"สวัสดีครับ สวัสดีค่ะ", "สวัสดี"},
+	{"héllo wörld", "ö"},
+	{"🙂🙃🙂", "🙂"},
+	{"\xff\xfe\x00", "\xfe"}, // Invalid UTF-8 is just bytes to these functions
+}
+
+// fuzzMatcher checks a matcher against naiveSearch on any text and pattern
+func fuzzMatcher(f *testing.F, match func(text, pattern string) []int) {
+	for _, seed := range fuzzSeeds {
+		f.Add(seed[0], seed[1])
+	}
+	f.Fuzz(func(t *testing.T, text, pattern string) {
+		got := match(text, pattern)
+		if want := naiveSearch(text, pattern); !slices.Equal(got, want) {
+			t.Errorf("match(%q, %q) = %v, naiveSearch = %v", text, pattern, got, want)
+		}
+	})
+}
+
+func FuzzKMPSearch(f *testing.F) {
+	fuzzMatcher(f, KMPSearch)
+}
+
+func FuzzRabinKarp(f *testing.F) {
+	fuzzMatcher(f, RabinKarp)
+}
+
+// naiveLevenshtein follows the recursive definition directly, memoizing on
+// the lengths of the remaining prefixes
+func naiveLevenshtein(a, b string) int {
+	memo := make(map[[2]int]int)
+	var d func(i, j int) int
+	d = func(i, j int) int {
+		if i == 0 {
+			return j
+		}
+		if j == 0 {
+			return i
+		}
+		if v, ok := memo[[2]int{i, j}]; ok {
+			return v
+		}
+		cost := 1
+		if a[i-1] == b[j-1] {
+			cost = 0
+		}
+		v := d(i-1, j-1) + cost
+		v = min(v, d(i-1, j)+1, d(i, j-1)+1)
+		memo[[2]int{i, j}] = v
+		return v
+	}
+	return d(len(a), len(b))
+}
+
+func FuzzLevenshteinDistance(f *testing.F) {
+	for _, seed := range fuzzSeeds {
+		f.Add(seed[0], seed[1])
+	}
+	f.Add("kitten", "sitting")
+	f.Fuzz(func(t *testing.T, a, b string) {
+		if len(a) > 64 || len(b) > 64 {
+			t.Skip("long inputs only slow the fuzzer down")
+		}
+		got := LevenshteinDistance(a, b)
+		if want := naiveLevenshtein(a, b); got != want {
+			t.Errorf("LevenshteinDistance(%q, %q) = %d, naiveLevenshtein = %d", a, b, got, want)
+		}
+		if back := LevenshteinDistance(b, a); back != got {
+			t.Errorf("LevenshteinDistance is not symmetric: %d one way, %d the other", got, back)
+		}
+	})
+}
@@ -0,0 +1,117 @@
+// This file implements a word tokenizer for Unicode text in Go
+// bufio.ScanWords splits on white space only, so "end." and "end" are
+// different words and a quote sticks to the word it opens. ScanTokens
+// splits on anything that is not part of a word instead, and decodes
+// UTF-8 as it goes, so "naïve", "東京" and "ภาษาไทย" come out whole:
+// - letters, digits and combining marks make up words. The marks matter:
+//   the accent of a decomposed "é" and most Thai vowels and tone marks
+//   are marks, not letters, and would otherwise cut a word in pieces
+// - an apostrophe or hyphen joins two words into one ("don't", "e-mail")
+//   but is dropped at the start or end of a word
+// - everything else, punctuation, symbols, spaces and invalid UTF-8,
+//   separates words
+//
+// It does not segment languages written without spaces, such as Thai or
+// Chinese, into words: a run of Thai text is a single token. That needs a
+// dictionary, which is out of scope here
+
+package stringalgo
+
+import (
+	"bufio"
+	"strings"
+	"unicode"
+	"unicode/utf8"
+)
+
+// isWordRune reports whether r is part of a word
+func isWordRune(r rune) bool {
+	return unicode.IsLetter(r) || unicode.IsNumber(r) || unicode.IsMark(r)
+}
+
+// isJoiner reports whether r joins two words into one when it is between them
+func isJoiner(r rune) bool {
+	switch r {
+	case '\'', '’', '-', '‐':
+		return true
+	}
+	return false
+}
+
+// ScanTokens is a bufio.SplitFunc that returns each word of UTF-8 text,
+// as described at the top of this file
+// Like bufio.ScanRunes it never splits a multi-byte rune: when the buffer
+// ends partway through one it asks the Scanner for more
+// Time Complexity: O(n) over the whole input
+func ScanTokens(data []byte, atEOF bool) (advance int, token []byte, err error) {
+	// decode returns the rune at i, or ok == false if more data is needed
+	decode := func(i int) (r rune, width int, ok bool) {
+		if !atEOF && !utf8.FullRune(data[i:]) {
+			return 0, 0, false
+		}
+		r, width = utf8.DecodeRune(data[i:])
+		return r, width, true
+	}
+
+	// Skip the separators before the word
+	start := 0
+	for start < len(data) {
+		r, width, ok := decode(start)
+		if !ok {
+			return start, nil, nil
+		}
+		if isWordRune(r) {
+			break
+		}
+		start += width
+	}
+
+	// Take word runes up to the next separator
+	for i := start; i < len(data); {
+		r, width, ok := decode(i)
+		if !ok {
+			return start, nil, nil
+		}
+		if isWordRune(r) {
+			i += width
+			continue
+		}
+		if isJoiner(r) {
+			// Keep the joiner only if a word continues after it
+			next := i + width
+			if next == len(data) && !atEOF {
+				return start, nil, nil
+			}
+			if next < len(data) {
+				r, _, ok := decode(next)
+				if !ok {
+					return start, nil, nil
+				}
+				if isWordRune(r) {
+					i = next
+					continue
+				}
+			}
+		}
+		return i + width, data[start:i], nil
+	}
+
+	// The data ends inside a word: it is complete only at the end of input
+	if atEOF && start < len(data) {
+		return len(data), data[start:], nil
+	}
+	return start, nil, nil
+}
+
+// Tokenize returns the words of s, split by ScanTokens
+func Tokenize(s string) []string {
+	var words []string
+	scanner := bufio.NewScanner(strings.NewReader(s))
+	// A single word can be as long as s
+	scanner.Buffer(nil, max(len(s)+1, bufio.MaxScanTokenSize))
+	scanner.Split(ScanTokens)
+	for scanner.Scan() {
+		words = append(words, scanner.Text())
+	}
+	return words
+}
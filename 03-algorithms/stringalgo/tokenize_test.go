@@ -0,0 +1,87 @@
+package stringalgo
+
+import (
+	"bufio"
+	"slices"
+	"strings"
+	"testing"
+	"testing/iotest"
+	"unicode/utf8"
+)
+
+func TestTokenize(t *testing.T) {
+	tests := []struct {
+		name string
+		text string
+		want []string
+	}{
+		{"empty", "", nil},
+		{"separators only", " .,;!? \n\t", nil},
+		{"punctuation", `"Hello, world!" she said.`, []string{"Hello", "world", "she", "said"}},
+		{"digits", "route 66 in 1926", []string{"route", "66", "in", "1926"}},
+		{"apostrophes", "don't 'quoted' rock'n'roll o’clock", []string{"don't", "quoted", "rock'n'roll", "o’clock"}},
+		{"hyphens", "e-mail -dash- well--known end-", []string{"e-mail", "dash", "well", "known", "end"}},
+		{"accents", "naïve café résumé", []string{"naïve", "café", "résumé"}},
+		// e followed by a combining acute accent
+		{"combining marks", "café olé", []string{"café", "olé"}},
+		{"thai", "ภาษาไทย ง่าย", []string{"ภาษาไทย", "ง่าย"}},
+		{"cjk", "東京、大阪", []string{"東京", "大阪"}},
+		{"symbols", "a+b=c 5% $3 #tag", []string{"a", "b", "c", "5", "3", "tag"}},
+		{"invalid utf-8", "ab\xffcd", []string{"ab", "cd"}},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := Tokenize(tt.text); !slices.Equal(got, tt.want) {
+				t.Errorf("Tokenize(%q) = %q, want %q", tt.text, got, tt.want)
+			}
+		})
+	}
+}
+
+// scanOneByte tokenizes text read one byte at a time, so every rune and
+// joiner ends up split across reads
+func scanOneByte(text string) ([]string, error) {
+	var words []string
+	scanner := bufio.NewScanner(iotest.OneByteReader(strings.NewReader(text)))
+	scanner.Buffer(nil, len(text)+1)
+	scanner.Split(ScanTokens)
+	for scanner.Scan() {
+		words = append(words, scanner.Text())
+	}
+	return words, scanner.Err()
+}
+
+func TestScanTokensAcrossReads(t *testing.T) {
+	text := "Ça va? It's a well-known ภาษาไทย phrase — 東京'; é-"
+	got, err := scanOneByte(text)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if want := Tokenize(text); !slices.Equal(got, want) {
+		t.Errorf("one byte at a time = %q, want %q", got, want)
+	}
+}
+
+func FuzzScanTokens(f *testing.F) {
+	for _, seed := range []string{"", "don't stop", "naïve-ish", "ภาษา ไทย", "a'-'b", "\xe0\xb8"} {
+		f.Add(seed)
+	}
+	f.Fuzz(func(t *testing.T, text string) {
+		words := Tokenize(text)
+		for _, w := range words {
+			if w == "" {
+				t.Fatalf("empty token in %q", words)
+			}
+			if first, _ := utf8.DecodeRuneInString(w); !isWordRune(first) {
+				t.Fatalf("token %q does not start with a word rune", w)
+			}
+		}
+		got, err := scanOneByte(text)
+		if err != nil {
+			t.Fatal(err)
+		}
+		if !slices.Equal(got, words) {
+			t.Fatalf("one byte at a time = %q, all at once = %q", got, words)
+		}
+	})
+}
@@ -0,0 +1,26 @@
+package treealgo_test
+
+import (
+	"fmt"
+
+	"github.com/NutProhmpiriya/go-basic/02-data-structures/datastructures"
+	"github.com/NutProhmpiriya/go-basic/03-algorithms/treealgo"
+)
+
+func ExampleSerialize() {
+	var tree datastructures.BinaryTree
+	for _, v := range []int{2, 1, 3} {
+		tree.Insert(v)
+	}
+	s := treealgo.Serialize(tree.Root)
+	fmt.Println(s)
+
+	root, _ := treealgo.Deserialize(s)
+	fmt.Println(treealgo.Diameter(root), treealgo.IsBalanced(root))
+	_, err := treealgo.Deserialize("2,1,#")
+	fmt.Println(err)
+	// Output:
+	// 2,1,#,#,3,#,#
+	// 2 true
+	// malformed serialized tree: ends after 3 values
+}
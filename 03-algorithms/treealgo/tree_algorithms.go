@@ -0,0 +1,280 @@
+// This file implements classic binary tree algorithms in Go
+// They work on the TreeNode of 02-data-structures, so any tree built with
+// datastructures.BinaryTree can be passed in through its Root
+//
+// Algorithms:
+// 1. Lowest common ancestor, in any binary tree and faster in a BST
+// 2. Diameter: the longest path between two nodes
+// 3. Shape checks: height-balanced and symmetric
+// 4. Invert: mirror the tree in place
+// 5. Kth smallest value of a BST with an inorder walk that stops early
+// 6. Serialize and Deserialize: a tree to a string and back
+//
+// Most of them are a single post-order pass that combines what the two
+// subtrees report, which is why they run in O(n)
+
+// Package treealgo implements algorithms on binary trees
+package treealgo
+
+import (
+	"errors"
+	"fmt"
+	"strconv"
+	"strings"
+
+	"github.com/NutProhmpiriya/go-basic/02-data-structures/datastructures"
+)
+
+// TreeNode is the binary tree node of the datastructures package
+type TreeNode = datastructures.TreeNode
+
+var (
+	// ErrOutOfRange is returned by KthSmallest when the tree has fewer than k nodes
+	ErrOutOfRange = errors.New("k is out of range")
+	// ErrBadSerialization is returned by Deserialize for malformed input
+	ErrBadSerialization = errors.New("malformed serialized tree")
+)
+
+// LowestCommonAncestor returns the deepest node that has both p and q in its
+// subtree, where a node counts as being in its own subtree
+// It works on any binary tree, comparing nodes rather than values, and
+// returns nil unless both p and q are in the tree
+// Time Complexity: O(n)
+// Space Complexity: O(h) for the recursion, where h is the height
+func LowestCommonAncestor(root, p, q *TreeNode) *TreeNode {
+	var lca *TreeNode
+	// search returns how many of p and q are in node's subtree; the first
+	// node to see both is the lowest, since children return before parents
+	var search func(node *TreeNode) int
+	search = func(node *TreeNode) int {
+		if node == nil || lca != nil {
+			return 0
+		}
+		n := search(node.Left) + search(node.Right)
+		if node == p {
+			n++
+		}
+		if node == q {
+			n++
+		}
+		if n == 2 && lca == nil {
+			lca = node
+		}
+		return n
+	}
+	search(root)
+	return lca
+}
+
+// LowestCommonAncestorBST returns the lowest common ancestor of the nodes
+// holding a and b in a binary search tree, or nil if either is missing
+// The ordering tells which way to go: while both values are on the same
+// side of a node the ancestor is on that side too, and the first node that
+// splits them, or holds one of them, is the answer
+// Time Complexity: O(h)
+// Space Complexity: O(1)
+func LowestCommonAncestorBST(root *TreeNode, a, b int) *TreeNode {
+	if a > b {
+		a, b = b, a
+	}
+	node := root
+	for node != nil {
+		switch {
+		case b < node.Value:
+			node = node.Left
+		case a > node.Value:
+			node = node.Right
+		default:
+			// a <= node.Value <= b: the paths to a and b split here
+			if contains(node, a) && contains(node, b) {
+				return node
+			}
+			return nil
+		}
+	}
+	return nil
+}
+
+// contains reports whether the BST rooted at node holds value
+func contains(node *TreeNode, value int) bool {
+	for node != nil {
+		switch {
+		case value == node.Value:
+			return true
+		case value < node.Value:
+			node = node.Left
+		default:
+			node = node.Right
+		}
+	}
+	return false
+}
+
+// Diameter returns the number of edges on the longest path between any two
+// nodes. The path doesn't have to pass through the root, but it does bend
+// at some node, going down into both of its subtrees
+// An empty tree and a single node both have diameter 0
+// Time Complexity: O(n)
+// Space Complexity: O(h)
+func Diameter(root *TreeNode) int {
+	diameter := 0
+	// height returns the number of nodes on the longest downward path
+	var height func(node *TreeNode) int
+	height = func(node *TreeNode) int {
+		if node == nil {
+			return 0
+		}
+		left, right := height(node.Left), height(node.Right)
+		// The longest path bending at node
+		diameter = max(diameter, left+right)
+		return 1 + max(left, right)
+	}
+	height(root)
+	return diameter
+}
+
+// IsBalanced reports whether the tree is height-balanced: at every node the
+// heights of the two subtrees differ by at most one
+// Checking each node's height separately would take O(n²) on a degenerate
+// tree; here each call returns its height, or -1 as soon as a subtree is
+// unbalanced, so every node is looked at once
+// Time Complexity: O(n)
+// Space Complexity: O(h)
+func IsBalanced(root *TreeNode) bool {
+	var height func(node *TreeNode) int
+	height = func(node *TreeNode) int {
+		if node == nil {
+			return 0
+		}
+		left := height(node.Left)
+		if left < 0 {
+			return -1
+		}
+		right := height(node.Right)
+		if right < 0 || left-right > 1 || right-left > 1 {
+			return -1
+		}
+		return 1 + max(left, right)
+	}
+	return height(root) >= 0
+}
+
+// IsSymmetric reports whether the tree is a mirror image of itself around
+// its root, in both shape and values
+// Time Complexity: O(n)
+// Space Complexity: O(h)
+func IsSymmetric(root *TreeNode) bool {
+	if root == nil {
+		return true
+	}
+	return isMirror(root.Left, root.Right)
+}
+
+// isMirror reports whether a and b are mirror images: the outer children
+// must mirror each other, and so must the inner ones
+func isMirror(a, b *TreeNode) bool {
+	if a == nil || b == nil {
+		return a == b
+	}
+	return a.Value == b.Value && isMirror(a.Left, b.Right) && isMirror(a.Right, b.Left)
+}
+
+// Invert mirrors the tree in place by swapping the children of every node,
+// and returns root. Inverting a BST gives one sorted in descending order
+// Time Complexity: O(n)
+// Space Complexity: O(h)
+func Invert(root *TreeNode) *TreeNode {
+	if root == nil {
+		return nil
+	}
+	root.Left, root.Right = Invert(root.Right), Invert(root.Left)
+	return root
+}
+
+// KthSmallest returns the kth smallest value in a binary search tree,
+// counting from 1. It walks the tree in order with its own stack and stops
+// at the kth node, so it only looks at the nodes it needs
+// Time Complexity: O(h + k)
+// Space Complexity: O(h)
+func KthSmallest(root *TreeNode, k int) (int, error) {
+	if k < 1 {
+		return 0, fmt.Errorf("%w: %d", ErrOutOfRange, k)
+	}
+	stack := &datastructures.StackOf[*TreeNode]{}
+	node := root
+	for node != nil || !stack.IsEmpty() {
+		// Go as far left as possible; the smallest unvisited value is there
+		for node != nil {
+			stack.Push(node)
+			node = node.Left
+		}
+		node, _ = stack.Pop()
+		if k--; k == 0 {
+			return node.Value, nil
+		}
+		node = node.Right
+	}
+	return 0, fmt.Errorf("%w: the tree has fewer nodes", ErrOutOfRange)
+}
+
+// Serialize writes the tree in preorder as comma-separated values, with #
+// for a missing child: the tree 2 with children 1 and 3 is "2,1,#,#,3,#,#"
+// Marking the missing children makes the string describe the shape exactly,
+// so Deserialize can rebuild any tree, not just a BST
+// Time Complexity: O(n)
+func Serialize(root *TreeNode) string {
+	var parts []string
+	var write func(node *TreeNode)
+	write = func(node *TreeNode) {
+		if node == nil {
+			parts = append(parts, "#")
+			return
+		}
+		parts = append(parts, strconv.Itoa(node.Value))
+		write(node.Left)
+		write(node.Right)
+	}
+	write(root)
+	return strings.Join(parts, ",")
+}
+
+// Deserialize rebuilds a tree from the output of Serialize
+// It returns an error wrapping ErrBadSerialization if a value is not a
+// number, or if the string ends early or goes on after the tree is complete
+// Time Complexity: O(n)
+func Deserialize(s string) (*TreeNode, error) {
+	parts := strings.Split(s, ",")
+	pos := 0
+	var read func() (*TreeNode, error)
+	read = func() (*TreeNode, error) {
+		if pos == len(parts) {
+			return nil, fmt.Errorf("%w: ends after %d values", ErrBadSerialization, pos)
+		}
+		part := parts[pos]
+		pos++
+		if part == "#" {
+			return nil, nil
+		}
+		value, err := strconv.Atoi(part)
+		if err != nil {
+			return nil, fmt.Errorf("%w: value %d is %q", ErrBadSerialization, pos, part)
+		}
+		node := &TreeNode{Value: value}
+		if node.Left, err = read(); err != nil {
+			return nil, err
+		}
+		if node.Right, err = read(); err != nil {
+			return nil, err
+		}
+		return node, nil
+	}
+
+	root, err := read()
+	if err != nil {
+		return nil, err
+	}
+	if pos != len(parts) {
+		return nil, fmt.Errorf("%w: %d values left after the tree", ErrBadSerialization, len(parts)-pos)
+	}
+	return root, nil
+}
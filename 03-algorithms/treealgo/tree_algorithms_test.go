@@ -0,0 +1,282 @@
+package treealgo
+
+import (
+	"errors"
+	"slices"
+	"testing"
+
+	"github.com/NutProhmpiriya/go-basic/02-data-structures/datastructures"
+	"github.com/NutProhmpiriya/go-basic/testdata/generator"
+)
+
+// bst builds a binary search tree by inserting values in order
+func bst(values ...int) *TreeNode {
+	var t datastructures.BinaryTree
+	for _, v := range values {
+		t.InsertIterative(v)
+	}
+	return t.Root
+}
+
+// chain returns the degenerate BST of n nodes valued 0..n-1 that inserting
+// them in ascending order builds, a path of right children, or in descending
+// order builds, a path of left children
+func chain(n int, left bool) *TreeNode {
+	var root *TreeNode
+	link := &root
+	for v := range n {
+		if left {
+			*link = &TreeNode{Value: n - 1 - v}
+			link = &(*link).Left
+		} else {
+			*link = &TreeNode{Value: v}
+			link = &(*link).Right
+		}
+	}
+	return root
+}
+
+// find returns the node holding value in a BST
+func find(root *TreeNode, value int) *TreeNode {
+	for root != nil && root.Value != value {
+		if value < root.Value {
+			root = root.Left
+		} else {
+			root = root.Right
+		}
+	}
+	return root
+}
+
+// The degenerate trees: empty, a single node, and paths leaning each way
+const deep = 10_000
+
+func TestLowestCommonAncestor(t *testing.T) {
+	//       5
+	//    3     8
+	//   1 4   7 9
+	root := bst(5, 3, 8, 1, 4, 7, 9)
+	tests := []struct {
+		a, b int
+		want int
+	}{
+		{1, 4, 3},
+		{1, 9, 5},
+		{7, 9, 8},
+		{3, 4, 3}, // a node is its own ancestor
+		{4, 4, 4},
+		{4, 7, 5},
+	}
+	for _, tt := range tests {
+		got := LowestCommonAncestor(root, find(root, tt.a), find(root, tt.b))
+		if got == nil || got.Value != tt.want {
+			t.Errorf("LowestCommonAncestor(%d, %d) = %v, want %d", tt.a, tt.b, got, tt.want)
+		}
+		got = LowestCommonAncestorBST(root, tt.b, tt.a)
+		if got == nil || got.Value != tt.want {
+			t.Errorf("LowestCommonAncestorBST(%d, %d) = %v, want %d", tt.b, tt.a, got, tt.want)
+		}
+	}
+
+	// Missing nodes or values give nil
+	if got := LowestCommonAncestor(root, find(root, 1), &TreeNode{Value: 4}); got != nil {
+		t.Errorf("LowestCommonAncestor with a node from another tree = %v, want nil", got.Value)
+	}
+	if got := LowestCommonAncestor(root, nil, find(root, 1)); got != nil {
+		t.Errorf("LowestCommonAncestor with nil = %v, want nil", got.Value)
+	}
+	if got := LowestCommonAncestorBST(root, 1, 6); got != nil {
+		t.Errorf("LowestCommonAncestorBST(1, 6) = %v, want nil", got.Value)
+	}
+	if got := LowestCommonAncestorBST(nil, 1, 1); got != nil {
+		t.Errorf("LowestCommonAncestorBST on an empty tree = %v, want nil", got.Value)
+	}
+
+	// On a path the ancestor is the shallower node
+	right := chain(deep, false)
+	deepest := right
+	for deepest.Right != nil {
+		deepest = deepest.Right
+	}
+	if got := LowestCommonAncestor(right, deepest, right.Right); got != right.Right {
+		t.Errorf("LowestCommonAncestor on a path = %v, want node 1", got)
+	}
+	if got := LowestCommonAncestorBST(right, deep-1, 2); got == nil || got.Value != 2 {
+		t.Errorf("LowestCommonAncestorBST on a path = %v, want 2", got)
+	}
+}
+
+func TestDiameter(t *testing.T) {
+	tests := []struct {
+		name string
+		root *TreeNode
+		want int
+	}{
+		{"empty", nil, 0},
+		{"single", bst(1), 0},
+		{"balanced", bst(5, 3, 8, 1, 4, 7, 9), 4},
+		// The longest path, 1-2-3-4-6-7-8 avoiding the root 9, misses the root
+		{"off the root", bst(9, 4, 3, 2, 1, 6, 7, 8), 6},
+		{"left path", chain(deep, true), deep - 1},
+		{"right path", chain(deep, false), deep - 1},
+	}
+	for _, tt := range tests {
+		if got := Diameter(tt.root); got != tt.want {
+			t.Errorf("%s: Diameter() = %d, want %d", tt.name, got, tt.want)
+		}
+	}
+}
+
+func TestIsBalanced(t *testing.T) {
+	tests := []struct {
+		name string
+		root *TreeNode
+		want bool
+	}{
+		{"empty", nil, true},
+		{"single", bst(1), true},
+		{"two nodes", bst(1, 2), true},
+		{"three in a row", bst(1, 2, 3), false},
+		{"full", bst(5, 3, 8, 1, 4, 7, 9), true},
+		{"heights differ by one", bst(5, 3, 8, 1), true},
+		// Both children of the root have height 2, but 3 is unbalanced
+		{"unbalanced below the root", bst(5, 3, 8, 1, 0, 7), false},
+		{"left path", chain(deep, true), false},
+		{"right path", chain(deep, false), false},
+	}
+	for _, tt := range tests {
+		if got := IsBalanced(tt.root); got != tt.want {
+			t.Errorf("%s: IsBalanced() = %v, want %v", tt.name, got, tt.want)
+		}
+	}
+}
+
+func TestIsSymmetric(t *testing.T) {
+	node := func(v int, left, right *TreeNode) *TreeNode {
+		return &TreeNode{Value: v, Left: left, Right: right}
+	}
+	leaf := func(v int) *TreeNode { return node(v, nil, nil) }
+	tests := []struct {
+		name string
+		root *TreeNode
+		want bool
+	}{
+		{"empty", nil, true},
+		{"single", leaf(1), true},
+		{"mirrored", node(1, node(2, leaf(3), leaf(4)), node(2, leaf(4), leaf(3))), true},
+		{"same not mirrored", node(1, node(2, leaf(3), leaf(4)), node(2, leaf(3), leaf(4))), false},
+		{"different values", node(1, leaf(2), leaf(3)), false},
+		{"one-sided", node(1, leaf(2), nil), false},
+		{"shape only", node(1, node(2, nil, leaf(3)), node(2, nil, leaf(3))), false},
+		{"path", chain(deep, false), false},
+	}
+	for _, tt := range tests {
+		if got := IsSymmetric(tt.root); got != tt.want {
+			t.Errorf("%s: IsSymmetric() = %v, want %v", tt.name, got, tt.want)
+		}
+	}
+}
+
+func TestInvert(t *testing.T) {
+	if Invert(nil) != nil {
+		t.Error("Invert(nil) is not nil")
+	}
+
+	values := generator.New(1).Perm(200)
+	root := bst(values...)
+	if got := Invert(root); got != root {
+		t.Fatal("Invert did not return the root")
+	}
+	// The inorder walk of the mirror is the sorted values, descending
+	tree := datastructures.BinaryTree{Root: root}
+	want := slices.Sorted(slices.Values(values))
+	slices.Reverse(want)
+	if got := tree.InorderTraversal(); !slices.Equal(got, want) {
+		t.Errorf("inorder after Invert = %v, want descending", got[:10])
+	}
+	// Inverting twice gives the original tree back
+	Invert(root)
+	if err := tree.Validate(); err != nil {
+		t.Errorf("Validate() after inverting twice = %v", err)
+	}
+
+	// A right path becomes a left path
+	path := Invert(chain(deep, false))
+	if Diameter(path) != deep-1 || path.Right != nil || path.Left == nil {
+		t.Error("Invert of a right path is not a left path")
+	}
+}
+
+func TestKthSmallest(t *testing.T) {
+	values := generator.New(2).Perm(100) // 1..100
+	root := bst(values...)
+	for k := 1; k <= len(values); k++ {
+		if got, err := KthSmallest(root, k); err != nil || got != k {
+			t.Fatalf("KthSmallest(%d) = %d, %v, want %d", k, got, err, k)
+		}
+	}
+	for _, k := range []int{0, -1, len(values) + 1} {
+		if _, err := KthSmallest(root, k); !errors.Is(err, ErrOutOfRange) {
+			t.Errorf("KthSmallest(%d) error = %v, want ErrOutOfRange", k, err)
+		}
+	}
+	if _, err := KthSmallest(nil, 1); !errors.Is(err, ErrOutOfRange) {
+		t.Errorf("KthSmallest on an empty tree error = %v, want ErrOutOfRange", err)
+	}
+
+	// Duplicates count once per node
+	if got, _ := KthSmallest(bst(2, 2, 1), 3); got != 2 {
+		t.Errorf("KthSmallest(3) with duplicates = %d, want 2", got)
+	}
+	// A left path pushes every node before returning the smallest; a right
+	// path only walks down to the kth node
+	for _, left := range []bool{true, false} {
+		root := chain(deep, left)
+		if got, err := KthSmallest(root, 1); err != nil || got != 0 {
+			t.Errorf("KthSmallest(1) on a path (left %v) = %d, %v, want 0", left, got, err)
+		}
+		if got, err := KthSmallest(root, deep); err != nil || got != deep-1 {
+			t.Errorf("KthSmallest(%d) on a path (left %v) = %d, %v, want %d", deep, left, got, err, deep-1)
+		}
+	}
+}
+
+func TestSerialize(t *testing.T) {
+	tests := []struct {
+		name string
+		root *TreeNode
+		want string
+	}{
+		{"empty", nil, "#"},
+		{"single", bst(-7), "-7,#,#"},
+		{"small", bst(2, 1, 3), "2,1,#,#,3,#,#"},
+		{"one-sided", bst(1, 2), "1,#,2,#,#"},
+	}
+	for _, tt := range tests {
+		got := Serialize(tt.root)
+		if got != tt.want {
+			t.Errorf("%s: Serialize() = %q, want %q", tt.name, got, tt.want)
+		}
+		back, err := Deserialize(got)
+		if err != nil || Serialize(back) != got {
+			t.Errorf("%s: Deserialize(%q) = %q, %v", tt.name, got, Serialize(back), err)
+		}
+	}
+
+	// Round trips keep the shape of any tree, including deep paths
+	for _, root := range []*TreeNode{bst(generator.New(3).Perm(500)...), chain(deep, true), chain(deep, false)} {
+		s := Serialize(root)
+		back, err := Deserialize(s)
+		if err != nil || Serialize(back) != s || Diameter(back) != Diameter(root) {
+			t.Errorf("round trip of a %d-character tree failed: %v", len(s), err)
+		}
+	}
+}
+
+func TestDeserializeErrors(t *testing.T) {
+	for _, s := range []string{"", "1", "1,#", "x,#,#", "1,#,#,#", "#,1", "1,,#", "1.5,#,#"} {
+		if root, err := Deserialize(s); !errors.Is(err, ErrBadSerialization) {
+			t.Errorf("Deserialize(%q) = %q, %v, want ErrBadSerialization", s, Serialize(root), err)
+		}
+	}
+}
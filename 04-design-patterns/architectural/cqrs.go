@@ -0,0 +1,180 @@
+// CQRS (Command Query Responsibility Segregation) splits writes from reads.
+// Commands change state through the event-sourced aggregates and return only
+// an error; queries read from a separate read model that a projection keeps up
+// to date from the event stream. Each side can then be shaped for its own job:
+// the write side for validation, the read side for fast, denormalized lookups.
+//
+// Use cases:
+// - Read-heavy systems where queries need a different shape than the domain model
+// - Dashboards and reports derived from an event log
+
+package architectural
+
+import (
+	"fmt"
+	"sort"
+	"sync"
+)
+
+// Command is a request to change state
+type Command interface {
+	AggregateID() string
+}
+
+// Inventory commands
+type (
+	CreateItem struct {
+		ID, Name string
+	}
+	AddStock struct {
+		ID       string
+		Quantity int
+	}
+	RemoveStock struct {
+		ID       string
+		Quantity int
+	}
+	DeactivateItem struct {
+		ID string
+	}
+)
+
+func (c CreateItem) AggregateID() string     { return c.ID }
+func (c AddStock) AggregateID() string       { return c.ID }
+func (c RemoveStock) AggregateID() string    { return c.ID }
+func (c DeactivateItem) AggregateID() string { return c.ID }
+
+// InventoryCommandHandler is the write side
+type InventoryCommandHandler struct {
+	repo *InventoryRepository
+}
+
+// NewInventoryCommandHandler creates a command handler using repo
+func NewInventoryCommandHandler(repo *InventoryRepository) *InventoryCommandHandler {
+	return &InventoryCommandHandler{repo: repo}
+}
+
+// Handle executes a command: load, decide, save
+func (h *InventoryCommandHandler) Handle(cmd Command) error {
+	if c, ok := cmd.(CreateItem); ok {
+		if _, err := h.repo.Load(c.ID); err == nil {
+			return fmt.Errorf("item %s already exists", c.ID)
+		}
+		item, err := NewInventoryItem(c.ID, c.Name)
+		if err != nil {
+			return err
+		}
+		return h.repo.Save(item)
+	}
+
+	item, err := h.repo.Load(cmd.AggregateID())
+	if err != nil {
+		return err
+	}
+	switch c := cmd.(type) {
+	case AddStock:
+		err = item.AddStock(c.Quantity)
+	case RemoveStock:
+		err = item.RemoveStock(c.Quantity)
+	case DeactivateItem:
+		err = item.Deactivate()
+	default:
+		err = fmt.Errorf("unknown command %T", cmd)
+	}
+	if err != nil {
+		return err
+	}
+	return h.repo.Save(item)
+}
+
+// ItemView is the read model's flattened view of an item
+type ItemView struct {
+	ID      string
+	Name    string
+	Stock   int
+	Active  bool
+	Updates int
+}
+
+// InventoryReadModel is the query side, kept current by a projection
+type InventoryReadModel struct {
+	mu    sync.RWMutex
+	store *EventStore
+	items map[string]*ItemView
+	// versions[id] is the version of the last event projected for item id
+	versions map[string]int
+}
+
+// NewInventoryReadModel creates a read model and subscribes it to store
+// The events of existingIDs already in the store are replayed, so a new read
+// model can be added to a running system at any time. It subscribes before
+// replaying: an event appended in between then arrives twice, and is
+// projected once
+func NewInventoryReadModel(store *EventStore, existingIDs ...string) *InventoryReadModel {
+	m := &InventoryReadModel{store: store, items: make(map[string]*ItemView), versions: make(map[string]int)}
+	store.Subscribe(func(r RecordedEvent) { m.catchUp(r.AggregateID) })
+	for _, id := range existingIDs {
+		m.catchUp(id)
+	}
+	return m
+}
+
+// catchUp projects the events of one item the view hasn't seen, in order
+// It reads them from the store instead of taking the event a notification
+// carries, since the store notifies outside its lock: two appends to the
+// same item can be delivered out of order
+func (m *InventoryReadModel) catchUp(id string) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	for _, r := range m.store.Load(id, m.versions[id]) {
+		m.project(r)
+		m.versions[id] = r.Version
+	}
+}
+
+// project updates the view for one event
+// The caller must hold m.mu
+func (m *InventoryReadModel) project(r RecordedEvent) {
+	view, ok := m.items[r.AggregateID]
+	if !ok {
+		view = &ItemView{ID: r.AggregateID}
+		m.items[r.AggregateID] = view
+	}
+	view.Updates++
+	switch e := r.Event.(type) {
+	case ItemCreated:
+		view.Name = e.Name
+		view.Active = true
+	case StockAdded:
+		view.Stock += e.Quantity
+	case StockRemoved:
+		view.Stock -= e.Quantity
+	case ItemDeactivated:
+		view.Active = false
+	}
+}
+
+// Get returns the view of one item
+func (m *InventoryReadModel) Get(id string) (ItemView, bool) {
+	m.mu.RLock()
+	defer m.mu.RUnlock()
+	view, ok := m.items[id]
+	if !ok {
+		return ItemView{}, false
+	}
+	return *view, true
+}
+
+// LowStock returns active items with less than threshold in stock, sorted by ID
+func (m *InventoryReadModel) LowStock(threshold int) []ItemView {
+	m.mu.RLock()
+	defer m.mu.RUnlock()
+	result := []ItemView{}
+	for _, view := range m.items {
+		if view.Active && view.Stock < threshold {
+			result = append(result, *view)
+		}
+	}
+	sort.Slice(result, func(i, j int) bool { return result[i].ID < result[j].ID })
+	return result
+}
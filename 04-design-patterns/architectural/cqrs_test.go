@@ -0,0 +1,81 @@
+package architectural
+
+import (
+	"sync"
+	"testing"
+)
+
+// A read model created while items are being written still sees every
+// event exactly once, whether it was replayed, delivered or both
+func TestReadModelJoinsRunningSystem(t *testing.T) {
+	for range 50 {
+		store := NewEventStore()
+		handler := NewInventoryCommandHandler(NewInventoryRepository(store, 4))
+		if err := handler.Handle(CreateItem{ID: "widget", Name: "Widget"}); err != nil {
+			t.Fatal(err)
+		}
+
+		var wg sync.WaitGroup
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			for range 20 {
+				if err := handler.Handle(AddStock{ID: "widget", Quantity: 1}); err != nil {
+					t.Error(err)
+				}
+			}
+		}()
+		model := NewInventoryReadModel(store, "widget")
+		wg.Wait()
+
+		view, ok := model.Get("widget")
+		if !ok || view.Stock != 20 || view.Updates != 21 {
+			t.Fatalf("view %+v, want a stock of 20 after 21 events", view)
+		}
+	}
+}
+
+// A subscriber that appends while being notified makes the store deliver
+// the new event before the one being notified about
+func TestReadModelOrdersEvents(t *testing.T) {
+	store := NewEventStore()
+	handler := NewInventoryCommandHandler(NewInventoryRepository(store, 4))
+	store.Subscribe(func(r RecordedEvent) {
+		if _, ok := r.Event.(ItemCreated); ok {
+			if err := handler.Handle(DeactivateItem{ID: r.AggregateID}); err != nil {
+				t.Error(err)
+			}
+		}
+	})
+	model := NewInventoryReadModel(store)
+	if err := handler.Handle(CreateItem{ID: "a", Name: "Apples"}); err != nil {
+		t.Fatal(err)
+	}
+	if a, _ := model.Get("a"); a.Active || a.Updates != 2 {
+		t.Errorf("Get(a) = %+v, want inactive after 2 events", a)
+	}
+}
+
+func TestReadModelProjects(t *testing.T) {
+	store := NewEventStore()
+	model := NewInventoryReadModel(store)
+	handler := NewInventoryCommandHandler(NewInventoryRepository(store, 4))
+	for _, cmd := range []Command{
+		CreateItem{ID: "a", Name: "Apples"},
+		CreateItem{ID: "b", Name: "Bread"},
+		AddStock{ID: "a", Quantity: 10},
+		AddStock{ID: "b", Quantity: 2},
+		RemoveStock{ID: "a", Quantity: 3},
+		DeactivateItem{ID: "b"},
+	} {
+		if err := handler.Handle(cmd); err != nil {
+			t.Fatalf("%T: %v", cmd, err)
+		}
+	}
+	if a, _ := model.Get("a"); a != (ItemView{ID: "a", Name: "Apples", Stock: 7, Active: true, Updates: 3}) {
+		t.Errorf("Get(a) = %+v", a)
+	}
+	if low := model.LowStock(10); len(low) != 1 || low[0].ID != "a" {
+		t.Errorf("LowStock(10) = %+v, want only a: b is inactive", low)
+	}
+}
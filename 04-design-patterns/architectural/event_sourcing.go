@@ -0,0 +1,296 @@
+// Event Sourcing Pattern stores every change to an object as an event in an
+// append-only log, instead of overwriting the current state. The current state is
+// rebuilt by replaying the events (Apply/Replay), so the full history is always
+// available for auditing, debugging or building new views later.
+//
+// It reuses ideas from other patterns in this repository:
+// - Observer: the event store notifies subscribers (projections) of new events
+// - Memento: snapshots capture an aggregate's state so replay can start midway
+// - Repository: aggregates are loaded and saved by ID, hiding the event log
+//
+// Use cases:
+// - Financial and inventory systems that need a complete audit trail
+// - Systems where several read models are derived from the same history
+
+package architectural
+
+import (
+	"errors"
+	"fmt"
+	"sync"
+)
+
+var (
+	// ErrConcurrencyConflict is returned when an aggregate changed since it was loaded
+	ErrConcurrencyConflict = errors.New("concurrency conflict")
+	// ErrNotFound is returned when loading an aggregate without events
+	ErrNotFound = errors.New("aggregate not found")
+)
+
+// DomainEvent is something that happened to an aggregate
+type DomainEvent interface {
+	EventName() string
+}
+
+// RecordedEvent is an event as stored in the log
+// Version numbers start at 1 and have no gaps per aggregate
+type RecordedEvent struct {
+	AggregateID string
+	Version     int
+	Event       DomainEvent
+}
+
+// EventStore is an in-memory append-only event log
+type EventStore struct {
+	mu          sync.RWMutex
+	streams     map[string][]RecordedEvent
+	subscribers []func(RecordedEvent)
+}
+
+// NewEventStore creates an empty event store
+func NewEventStore() *EventStore {
+	return &EventStore{streams: make(map[string][]RecordedEvent)}
+}
+
+// Subscribe registers fn to be called for every event appended from now on
+func (s *EventStore) Subscribe(fn func(RecordedEvent)) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.subscribers = append(s.subscribers, fn)
+}
+
+// Append adds events to an aggregate's stream
+// expectedVersion is the version the caller loaded; if someone else appended
+// in the meantime the write is rejected (optimistic concurrency control)
+func (s *EventStore) Append(aggregateID string, expectedVersion int, events ...DomainEvent) error {
+	s.mu.Lock()
+	stream := s.streams[aggregateID]
+	if len(stream) != expectedVersion {
+		s.mu.Unlock()
+		return fmt.Errorf("%w: %s is at version %d, expected %d",
+			ErrConcurrencyConflict, aggregateID, len(stream), expectedVersion)
+	}
+	recorded := make([]RecordedEvent, len(events))
+	for i, e := range events {
+		recorded[i] = RecordedEvent{AggregateID: aggregateID, Version: expectedVersion + i + 1, Event: e}
+	}
+	s.streams[aggregateID] = append(stream, recorded...)
+	subscribers := append([]func(RecordedEvent){}, s.subscribers...)
+	s.mu.Unlock()
+
+	// Notify outside the lock so subscribers may read from the store
+	for _, r := range recorded {
+		for _, fn := range subscribers {
+			fn(r)
+		}
+	}
+	return nil
+}
+
+// Load returns the events of an aggregate with a version greater than afterVersion
+func (s *EventStore) Load(aggregateID string, afterVersion int) []RecordedEvent {
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+	stream := s.streams[aggregateID]
+	if afterVersion >= len(stream) {
+		return nil
+	}
+	return append([]RecordedEvent{}, stream[afterVersion:]...)
+}
+
+// Inventory events
+type (
+	ItemCreated struct {
+		Name string
+	}
+	StockAdded struct {
+		Quantity int
+	}
+	StockRemoved struct {
+		Quantity int
+	}
+	ItemDeactivated struct{}
+)
+
+func (ItemCreated) EventName() string     { return "ItemCreated" }
+func (StockAdded) EventName() string      { return "StockAdded" }
+func (StockRemoved) EventName() string    { return "StockRemoved" }
+func (ItemDeactivated) EventName() string { return "ItemDeactivated" }
+
+// InventoryItem is an event-sourced aggregate
+// Its fields are never set directly: command methods validate the request,
+// then raise an event, and apply is the only place that changes state
+type InventoryItem struct {
+	id      string
+	name    string
+	stock   int
+	active  bool
+	version int           // version of the last stored event
+	changes []DomainEvent // raised but not yet saved
+}
+
+// NewInventoryItem creates a new item
+func NewInventoryItem(id, name string) (*InventoryItem, error) {
+	if name == "" {
+		return nil, errors.New("item name is required")
+	}
+	item := &InventoryItem{id: id}
+	item.raise(ItemCreated{Name: name})
+	return item, nil
+}
+
+// ReplayInventoryItem rebuilds an item from its history
+func ReplayInventoryItem(id string, history []RecordedEvent) *InventoryItem {
+	item := &InventoryItem{id: id}
+	item.replay(history)
+	return item
+}
+
+func (i *InventoryItem) replay(history []RecordedEvent) {
+	for _, r := range history {
+		i.apply(r.Event)
+		i.version = r.Version
+	}
+}
+
+// apply changes state according to an event; it never fails,
+// because events describe things that already happened
+func (i *InventoryItem) apply(e DomainEvent) {
+	switch e := e.(type) {
+	case ItemCreated:
+		i.name = e.Name
+		i.active = true
+	case StockAdded:
+		i.stock += e.Quantity
+	case StockRemoved:
+		i.stock -= e.Quantity
+	case ItemDeactivated:
+		i.active = false
+	}
+}
+
+// raise applies a new event and remembers it for saving
+func (i *InventoryItem) raise(e DomainEvent) {
+	i.apply(e)
+	i.changes = append(i.changes, e)
+}
+
+// AddStock records received goods
+func (i *InventoryItem) AddStock(quantity int) error {
+	if !i.active {
+		return fmt.Errorf("item %s is deactivated", i.id)
+	}
+	if quantity <= 0 {
+		return fmt.Errorf("quantity must be positive, got %d", quantity)
+	}
+	i.raise(StockAdded{Quantity: quantity})
+	return nil
+}
+
+// RemoveStock records shipped goods
+func (i *InventoryItem) RemoveStock(quantity int) error {
+	if quantity <= 0 {
+		return fmt.Errorf("quantity must be positive, got %d", quantity)
+	}
+	if quantity > i.stock {
+		return fmt.Errorf("cannot remove %d from %s: only %d in stock", quantity, i.id, i.stock)
+	}
+	i.raise(StockRemoved{Quantity: quantity})
+	return nil
+}
+
+// Deactivate stops the item from receiving stock
+func (i *InventoryItem) Deactivate() error {
+	if !i.active {
+		return fmt.Errorf("item %s is already deactivated", i.id)
+	}
+	i.raise(ItemDeactivated{})
+	return nil
+}
+
+// ID returns the item's ID
+func (i *InventoryItem) ID() string { return i.id }
+
+// Stock returns the current stock level
+func (i *InventoryItem) Stock() int { return i.stock }
+
+// Version returns the version of the last saved event
+func (i *InventoryItem) Version() int { return i.version }
+
+// InventorySnapshot is a memento of an item's state at a version
+type InventorySnapshot struct {
+	ID      string
+	Name    string
+	Stock   int
+	Active  bool
+	Version int
+}
+
+// Snapshot captures the item's saved state
+func (i *InventoryItem) Snapshot() InventorySnapshot {
+	return InventorySnapshot{ID: i.id, Name: i.name, Stock: i.stock, Active: i.active, Version: i.version}
+}
+
+// RestoreInventoryItem rebuilds an item from a snapshot plus the events after it
+func RestoreInventoryItem(s InventorySnapshot, later []RecordedEvent) *InventoryItem {
+	item := &InventoryItem{id: s.ID, name: s.Name, stock: s.Stock, active: s.Active, version: s.Version}
+	item.replay(later)
+	return item
+}
+
+// InventoryRepository loads and saves items through the event store
+// Every snapshotEvery events it keeps a snapshot so loading doesn't replay
+// the whole history
+type InventoryRepository struct {
+	store         *EventStore
+	mu            sync.Mutex
+	snapshots     map[string]InventorySnapshot
+	snapshotEvery int
+	// Replayed counts events replayed by Load, to show the effect of snapshots
+	Replayed int
+}
+
+// NewInventoryRepository creates a repository over store
+func NewInventoryRepository(store *EventStore, snapshotEvery int) *InventoryRepository {
+	return &InventoryRepository{store: store, snapshots: make(map[string]InventorySnapshot), snapshotEvery: snapshotEvery}
+}
+
+// Load rebuilds an item from its latest snapshot and the events after it
+func (r *InventoryRepository) Load(id string) (*InventoryItem, error) {
+	r.mu.Lock()
+	snapshot, ok := r.snapshots[id]
+	r.mu.Unlock()
+	if !ok {
+		snapshot = InventorySnapshot{ID: id}
+	}
+
+	later := r.store.Load(id, snapshot.Version)
+	if snapshot.Version == 0 && len(later) == 0 {
+		return nil, fmt.Errorf("%w: %s", ErrNotFound, id)
+	}
+	r.mu.Lock()
+	r.Replayed += len(later)
+	r.mu.Unlock()
+	return RestoreInventoryItem(snapshot, later), nil
+}
+
+// Save appends the item's new events and takes a snapshot when due
+func (r *InventoryRepository) Save(item *InventoryItem) error {
+	if len(item.changes) == 0 {
+		return nil
+	}
+	if err := r.store.Append(item.id, item.version, item.changes...); err != nil {
+		return err
+	}
+	before := item.version
+	item.version += len(item.changes)
+	item.changes = nil
+
+	// Snapshot whenever the version crosses a multiple of snapshotEvery
+	if r.snapshotEvery > 0 && item.version/r.snapshotEvery > before/r.snapshotEvery {
+		r.mu.Lock()
+		r.snapshots[item.id] = item.Snapshot()
+		r.mu.Unlock()
+	}
+	return nil
+}
@@ -0,0 +1,350 @@
+// Unit of Work Pattern keeps track of everything a business operation changes
+// (new, dirty and removed entities) and writes all of it in one atomic commit.
+// Either every change is stored or none is, so a failure halfway through an
+// operation can't leave the data half-updated.
+//
+// Transaction Script is the simplest way to organize the business logic on
+// top: one procedure per operation (e.g. TransferFunds) that loads what it
+// needs, checks the rules, registers changes and commits.
+//
+// In Go these are usually written with database/sql transactions; the in-memory
+// store below shows what the pattern does without needing a database.
+//
+// Use cases:
+// - Operations touching several entities, like a money transfer
+// - Batching writes so the store is hit once per operation
+// - Keeping validation failures from leaving partial updates
+
+package architectural
+
+import (
+	"errors"
+	"fmt"
+	"slices"
+	"sort"
+	"sync"
+)
+
+var (
+	// ErrEntityNotFound is returned for updates or deletes of missing entities
+	ErrEntityNotFound = errors.New("entity not found")
+	// ErrDuplicateEntity is returned when inserting an entity whose ID is taken
+	ErrDuplicateEntity = errors.New("duplicate entity")
+	// ErrSameAccount is returned by TransferFunds for a transfer from an
+	// account to itself
+	ErrSameAccount = errors.New("cannot transfer to the same account")
+)
+
+// Entity is anything stored by ID
+type Entity interface {
+	EntityID() string
+}
+
+// MemoryStore is an in-memory database made of named tables
+type MemoryStore struct {
+	mu      sync.RWMutex
+	tables  map[string]map[string]Entity
+	commits int
+}
+
+// NewMemoryStore creates an empty store
+func NewMemoryStore() *MemoryStore {
+	return &MemoryStore{tables: make(map[string]map[string]Entity)}
+}
+
+// Commits returns how many units of work were committed
+func (s *MemoryStore) Commits() int {
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+	return s.commits
+}
+
+// MemoryRepository gives typed access to one table of a MemoryStore
+// Reads see committed data; writes go through a UnitOfWork
+type MemoryRepository[T Entity] struct {
+	store *MemoryStore
+	table string
+}
+
+// NewMemoryRepository creates a repository for table
+func NewMemoryRepository[T Entity](store *MemoryStore, table string) *MemoryRepository[T] {
+	return &MemoryRepository[T]{store: store, table: table}
+}
+
+// Get returns the committed entity with id
+func (r *MemoryRepository[T]) Get(id string) (T, error) {
+	r.store.mu.RLock()
+	defer r.store.mu.RUnlock()
+	e, ok := r.store.tables[r.table][id]
+	if !ok {
+		var zero T
+		return zero, fmt.Errorf("%w: %s/%s", ErrEntityNotFound, r.table, id)
+	}
+	return e.(T), nil
+}
+
+// List returns all committed entities sorted by ID
+func (r *MemoryRepository[T]) List() []T {
+	r.store.mu.RLock()
+	defer r.store.mu.RUnlock()
+	ids := make([]string, 0, len(r.store.tables[r.table]))
+	for id := range r.store.tables[r.table] {
+		ids = append(ids, id)
+	}
+	sort.Strings(ids)
+	result := make([]T, len(ids))
+	for i, id := range ids {
+		result[i] = r.store.tables[r.table][id].(T)
+	}
+	return result
+}
+
+// Add registers e as new in uow
+func (r *MemoryRepository[T]) Add(uow *UnitOfWork, e T) {
+	uow.register(r.table, e, stateNew)
+}
+
+// Update registers e as changed in uow
+func (r *MemoryRepository[T]) Update(uow *UnitOfWork, e T) {
+	uow.register(r.table, e, stateDirty)
+}
+
+// Remove registers e for deletion in uow
+func (r *MemoryRepository[T]) Remove(uow *UnitOfWork, e T) {
+	uow.register(r.table, e, stateRemoved)
+}
+
+// entityState is what a unit of work will do with an entity
+type entityState int
+
+const (
+	stateNew entityState = iota
+	stateDirty
+	stateRemoved
+)
+
+// trackedKey identifies an entity across tables
+type trackedKey struct {
+	table, id string
+}
+
+// tracked is an entity registered in a unit of work
+type tracked struct {
+	key    trackedKey
+	entity Entity
+	state  entityState
+}
+
+// UnitOfWork collects changes until Commit or Rollback
+// It is used by one goroutine at a time
+type UnitOfWork struct {
+	store   *MemoryStore
+	order   []trackedKey // registration order, so commits are deterministic
+	changes map[trackedKey]*tracked
+	done    bool
+}
+
+// Begin starts a unit of work on the store
+func (s *MemoryStore) Begin() *UnitOfWork {
+	return &UnitOfWork{store: s, changes: make(map[trackedKey]*tracked)}
+}
+
+// register records a change, merging it with earlier changes to the same entity:
+//   - new then dirty stays new (it is inserted with the latest data)
+//   - new then removed cancels out (nothing to write)
+//   - dirty then removed becomes removed
+//   - removed then new becomes dirty (the entity is still stored, so it is
+//     written over rather than inserted again)
+//
+// It panics once the unit of work is committed or rolled back: the change
+// could never be written, and dropping it silently would hide the bug
+func (u *UnitOfWork) register(table string, e Entity, state entityState) {
+	if u.done {
+		panic("architectural: unit of work already finished, begin a new one")
+	}
+	key := trackedKey{table, e.EntityID()}
+	t, ok := u.changes[key]
+	if !ok {
+		u.changes[key] = &tracked{key: key, entity: e, state: state}
+		u.order = append(u.order, key)
+		return
+	}
+	switch {
+	case t.state == stateNew && state == stateDirty:
+		t.entity = e
+	case t.state == stateNew && state == stateRemoved:
+		delete(u.changes, key)
+		u.order = slices.DeleteFunc(u.order, func(k trackedKey) bool { return k == key })
+	case t.state == stateRemoved && state == stateNew:
+		t.entity, t.state = e, stateDirty
+	default:
+		t.entity, t.state = e, state
+	}
+}
+
+// Pending returns the number of changes waiting to be committed
+func (u *UnitOfWork) Pending() (inserts, updates, deletes int) {
+	for _, t := range u.changes {
+		switch t.state {
+		case stateNew:
+			inserts++
+		case stateDirty:
+			updates++
+		case stateRemoved:
+			deletes++
+		}
+	}
+	return inserts, updates, deletes
+}
+
+// Commit writes every change atomically
+// All changes are checked first while holding the store lock; if any of them
+// is invalid nothing is written and the error lists every problem
+func (u *UnitOfWork) Commit() error {
+	if u.done {
+		return errors.New("unit of work already finished")
+	}
+	u.done = true
+
+	s := u.store
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	// Phase 1: validate
+	var errs []error
+	for _, key := range u.order {
+		t, ok := u.changes[key]
+		if !ok {
+			continue
+		}
+		_, exists := s.tables[key.table][key.id]
+		switch {
+		case t.state == stateNew && exists:
+			errs = append(errs, fmt.Errorf("%w: %s/%s", ErrDuplicateEntity, key.table, key.id))
+		case t.state != stateNew && !exists:
+			errs = append(errs, fmt.Errorf("%w: %s/%s", ErrEntityNotFound, key.table, key.id))
+		}
+	}
+	if len(errs) > 0 {
+		return fmt.Errorf("commit rolled back: %w", errors.Join(errs...))
+	}
+
+	// Phase 2: apply (cannot fail)
+	for _, key := range u.order {
+		t, ok := u.changes[key]
+		if !ok {
+			continue
+		}
+		if s.tables[key.table] == nil {
+			s.tables[key.table] = make(map[string]Entity)
+		}
+		if t.state == stateRemoved {
+			delete(s.tables[key.table], key.id)
+		} else {
+			s.tables[key.table][key.id] = t.entity
+		}
+	}
+	s.commits++
+	return nil
+}
+
+// Rollback discards all registered changes
+func (u *UnitOfWork) Rollback() {
+	u.done = true
+	clear(u.changes)
+	u.order = nil
+}
+
+// BankAccount is an entity used by the transaction script example
+type BankAccount struct {
+	ID      string
+	Owner   string
+	Balance int
+}
+
+// EntityID implements Entity
+func (a BankAccount) EntityID() string { return a.ID }
+
+// TransferRecord is an audit entry written by TransferFunds
+type TransferRecord struct {
+	ID       string
+	From, To string
+	Amount   int
+}
+
+// EntityID implements Entity
+func (t TransferRecord) EntityID() string { return t.ID }
+
+// Bank groups the repositories used by the transaction scripts
+type Bank struct {
+	Store     *MemoryStore
+	Accounts  *MemoryRepository[BankAccount]
+	Transfers *MemoryRepository[TransferRecord]
+}
+
+// NewBank creates a bank over an empty store
+func NewBank() *Bank {
+	store := NewMemoryStore()
+	return &Bank{
+		Store:     store,
+		Accounts:  NewMemoryRepository[BankAccount](store, "accounts"),
+		Transfers: NewMemoryRepository[TransferRecord](store, "transfers"),
+	}
+}
+
+// OpenAccounts is a transaction script creating several accounts at once
+func (b *Bank) OpenAccounts(accounts ...BankAccount) error {
+	uow := b.Store.Begin()
+	for _, a := range accounts {
+		b.Accounts.Add(uow, a)
+	}
+	return uow.Commit()
+}
+
+// TransferFunds is a transaction script: load, check rules, register changes, commit
+// The two balance updates and the audit record are committed together or not at all
+// Two transfers running at the same time could both read the old balances;
+// a real database would prevent that with row locks or version checks
+func (b *Bank) TransferFunds(transferID, from, to string, amount int) error {
+	if amount <= 0 {
+		return fmt.Errorf("transfer amount must be positive, got %d", amount)
+	}
+	if from == to {
+		// source and target would be two copies of one account, and the
+		// second Update would overwrite the first
+		return fmt.Errorf("%w: %s", ErrSameAccount, from)
+	}
+	source, err := b.Accounts.Get(from)
+	if err != nil {
+		return err
+	}
+	target, err := b.Accounts.Get(to)
+	if err != nil {
+		return err
+	}
+	if source.Balance < amount {
+		return fmt.Errorf("insufficient funds in %s: balance %d, transfer %d", from, source.Balance, amount)
+	}
+
+	uow := b.Store.Begin()
+	source.Balance -= amount
+	target.Balance += amount
+	b.Accounts.Update(uow, source)
+	b.Accounts.Update(uow, target)
+	b.Transfers.Add(uow, TransferRecord{ID: transferID, From: from, To: to, Amount: amount})
+	return uow.Commit()
+}
+
+// CloseAccount is a transaction script that removes an empty account
+func (b *Bank) CloseAccount(id string) error {
+	account, err := b.Accounts.Get(id)
+	if err != nil {
+		return err
+	}
+	if account.Balance != 0 {
+		return fmt.Errorf("account %s still holds %d", id, account.Balance)
+	}
+	uow := b.Store.Begin()
+	b.Accounts.Remove(uow, account)
+	return uow.Commit()
+}
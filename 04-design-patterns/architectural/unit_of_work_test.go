@@ -0,0 +1,87 @@
+package architectural
+
+import (
+	"errors"
+	"testing"
+)
+
+func TestTransferToSameAccount(t *testing.T) {
+	bank := NewBank()
+	if err := bank.OpenAccounts(BankAccount{ID: "a", Balance: 100}); err != nil {
+		t.Fatal(err)
+	}
+	if err := bank.TransferFunds("t1", "a", "a", 50); !errors.Is(err, ErrSameAccount) {
+		t.Errorf("TransferFunds(a, a) = %v, want ErrSameAccount", err)
+	}
+	if a, _ := bank.Accounts.Get("a"); a.Balance != 100 {
+		t.Errorf("balance = %d, want 100", a.Balance)
+	}
+	if n := len(bank.Transfers.List()); n != 0 {
+		t.Errorf("%d transfers recorded, want 0", n)
+	}
+}
+
+func TestRemoveThenAdd(t *testing.T) {
+	bank := NewBank()
+	if err := bank.OpenAccounts(BankAccount{ID: "a", Owner: "Ann", Balance: 10}); err != nil {
+		t.Fatal(err)
+	}
+	uow := bank.Store.Begin()
+	bank.Accounts.Remove(uow, BankAccount{ID: "a"})
+	bank.Accounts.Add(uow, BankAccount{ID: "a", Owner: "Bob", Balance: 20})
+	if ins, upd, del := uow.Pending(); ins != 0 || upd != 1 || del != 0 {
+		t.Errorf("Pending() = %d, %d, %d, want 0, 1, 0", ins, upd, del)
+	}
+	if err := uow.Commit(); err != nil {
+		t.Fatalf("Commit() = %v", err)
+	}
+	if a, _ := bank.Accounts.Get("a"); a.Owner != "Bob" || a.Balance != 20 {
+		t.Errorf("account = %+v, want Bob's with 20", a)
+	}
+}
+
+func TestAddRemoveAdd(t *testing.T) {
+	// New then removed cancels out, and adding again is new once more
+	bank := NewBank()
+	uow := bank.Store.Begin()
+	bank.Accounts.Add(uow, BankAccount{ID: "a", Balance: 1})
+	bank.Accounts.Remove(uow, BankAccount{ID: "a"})
+	bank.Accounts.Add(uow, BankAccount{ID: "a", Balance: 2})
+	if ins, upd, del := uow.Pending(); ins != 1 || upd != 0 || del != 0 {
+		t.Errorf("Pending() = %d, %d, %d, want 1, 0, 0", ins, upd, del)
+	}
+	if err := uow.Commit(); err != nil {
+		t.Fatalf("Commit() = %v", err)
+	}
+	if a, _ := bank.Accounts.Get("a"); a.Balance != 2 {
+		t.Errorf("balance = %d, want 2", a.Balance)
+	}
+}
+
+// A finished unit of work can't take more changes: they would never be
+// written
+func TestRegisterAfterFinish(t *testing.T) {
+	bank := NewBank()
+	for name, finish := range map[string]func(*UnitOfWork){
+		"Rollback": (*UnitOfWork).Rollback,
+		"Commit":   func(u *UnitOfWork) { u.Commit() },
+	} {
+		uow := bank.Store.Begin()
+		bank.Accounts.Add(uow, BankAccount{ID: name})
+		finish(uow)
+		func() {
+			defer func() {
+				if recover() == nil {
+					t.Errorf("Add after %s did not panic", name)
+				}
+			}()
+			bank.Accounts.Add(uow, BankAccount{ID: "late"})
+		}()
+		if ins, upd, del := uow.Pending(); name == "Rollback" && ins+upd+del != 0 {
+			t.Errorf("%d changes pending after Rollback", ins+upd+del)
+		}
+	}
+	if _, err := bank.Accounts.Get("late"); err == nil {
+		t.Error("the late account was stored")
+	}
+}
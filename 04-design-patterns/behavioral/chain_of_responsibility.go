@@ -9,111 +9,173 @@
 
 package behavioral
 
-// LogLevel represents different logging levels
-type LogLevel int
-
-const (
-	INFO LogLevel = iota
-	DEBUG
-	ERROR
+import (
+	"errors"
+	"fmt"
+	"io"
+	"sync"
 )
 
-// LogEntry represents a log message
-type LogEntry struct {
-	Message string
-	Level   LogLevel
-}
+// ErrUnhandled is returned when a request reaches the end of a chain
+// that has no fallback handler
+var ErrUnhandled = errors.New("request was not handled by any handler")
 
-// Logger interface defines the contract for loggers
-type Logger interface {
-	SetNext(logger Logger)
-	Log(entry LogEntry) string
+// Handler processes a request of type T
+// A handler either handles the request itself or passes it on by calling next,
+// in the same way HTTP middleware calls the next handler
+type Handler[T any] interface {
+	Handle(req T, next func(T) error) error
 }
 
-// BaseLogger provides common functionality for all loggers
-type BaseLogger struct {
-	next Logger
-}
+// HandlerFunc adapts an ordinary function to the Handler interface
+type HandlerFunc[T any] func(req T, next func(T) error) error
 
-func (l *BaseLogger) SetNext(logger Logger) {
-	l.next = logger
+// Handle calls f(req, next)
+func (f HandlerFunc[T]) Handle(req T, next func(T) error) error {
+	return f(req, next)
 }
 
-// InfoLogger handles INFO level logs
-type InfoLogger struct {
-	BaseLogger
+// namedHandler keeps the name a handler was registered with
+// so it can be removed later
+type namedHandler[T any] struct {
+	name    string
+	handler Handler[T]
 }
 
-func NewInfoLogger() *InfoLogger {
-	return &InfoLogger{}
-}
-
-func (l *InfoLogger) Log(entry LogEntry) string {
-	if entry.Level == INFO {
-		return "Info: " + entry.Message
-	}
-	if l.next != nil {
-		return l.next.Log(entry)
-	}
-	return ""
+// Chain is an ordered list of handlers for requests of type T
+// Handlers can be registered and removed at runtime, even while
+// other goroutines are sending requests through the chain
+type Chain[T any] struct {
+	mu       sync.RWMutex
+	handlers []namedHandler[T]
+	fallback func(T) error
 }
 
-// DebugLogger handles DEBUG level logs
-type DebugLogger struct {
-	BaseLogger
+// NewChain creates an empty chain
+func NewChain[T any]() *Chain[T] {
+	return &Chain[T]{}
 }
 
-func NewDebugLogger() *DebugLogger {
-	return &DebugLogger{}
+// Use appends a handler to the end of the chain
+func (c *Chain[T]) Use(name string, h Handler[T]) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	c.handlers = append(c.handlers, namedHandler[T]{name: name, handler: h})
 }
 
-func (l *DebugLogger) Log(entry LogEntry) string {
-	if entry.Level == DEBUG {
-		return "Debug: " + entry.Message
-	}
-	if l.next != nil {
-		return l.next.Log(entry)
+// Remove removes the first handler registered under name
+// Returns true if a handler was removed
+func (c *Chain[T]) Remove(name string) bool {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	for i, h := range c.handlers {
+		if h.name == name {
+			c.handlers = append(c.handlers[:i:i], c.handlers[i+1:]...)
+			return true
+		}
 	}
-	return ""
+	return false
 }
 
-// ErrorLogger handles ERROR level logs
-type ErrorLogger struct {
-	BaseLogger
-}
-
-func NewErrorLogger() *ErrorLogger {
-	return &ErrorLogger{}
-}
-
-func (l *ErrorLogger) Log(entry LogEntry) string {
-	if entry.Level == ERROR {
-		return "Error: " + entry.Message
+// Names returns the names of the registered handlers in chain order
+func (c *Chain[T]) Names() []string {
+	c.mu.RLock()
+	defer c.mu.RUnlock()
+	names := make([]string, len(c.handlers))
+	for i, h := range c.handlers {
+		names[i] = h.name
 	}
-	if l.next != nil {
-		return l.next.Log(entry)
+	return names
+}
+
+// SetFallback sets the function called when every handler passed the request on
+// Without a fallback such requests fail with ErrUnhandled instead of being dropped
+func (c *Chain[T]) SetFallback(fallback func(T) error) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	c.fallback = fallback
+}
+
+// Handle sends a request through the chain
+func (c *Chain[T]) Handle(req T) error {
+	// Take a snapshot so handlers registered during this call don't affect it
+	c.mu.RLock()
+	handlers := append([]namedHandler[T](nil), c.handlers...)
+	fallback := c.fallback
+	c.mu.RUnlock()
+
+	var next func(index int, req T) error
+	next = func(index int, req T) error {
+		if index == len(handlers) {
+			if fallback == nil {
+				return ErrUnhandled
+			}
+			return fallback(req)
+		}
+		return handlers[index].handler.Handle(req, func(req T) error {
+			return next(index+1, req)
+		})
 	}
-	return ""
+	return next(0, req)
 }
 
-// LoggerChain sets up the chain of responsibility
-type LoggerChain struct {
-	firstLogger Logger
-}
-
-func NewLoggerChain() *LoggerChain {
-	infoLogger := NewInfoLogger()
-	debugLogger := NewDebugLogger()
-	errorLogger := NewErrorLogger()
+// LogLevel represents different logging levels
+// Its String method is generated by stringer; after changing the constants run
+//
+//	go generate ./04-design-patterns/behavioral
+//
+// (install the tool with go install golang.org/x/tools/cmd/stringer@latest)
+//
+//go:generate stringer -type=LogLevel
+type LogLevel int
 
-	infoLogger.SetNext(debugLogger)
-	debugLogger.SetNext(errorLogger)
+const (
+	INFO LogLevel = iota
+	DEBUG
+	ERROR
+	WARN
+)
 
-	return &LoggerChain{
-		firstLogger: infoLogger,
-	}
+// LogEntry represents a log message
+type LogEntry struct {
+	Message string
+	Level   LogLevel
 }
 
-func (c *LoggerChain) Log(entry LogEntry) string {
-	return c.firstLogger.Log(entry)
+// LoggerChain is a chain of level-specific loggers built on Chain
+// Each registered level handles its own entries and passes the rest on;
+// entries nobody handles are still written by the fallback
+type LoggerChain struct {
+	chain *Chain[LogEntry]
+	out   io.Writer
+}
+
+// NewLoggerChain creates a logger chain with INFO, DEBUG and ERROR handlers
+func NewLoggerChain(out io.Writer) *LoggerChain {
+	l := &LoggerChain{chain: NewChain[LogEntry](), out: out}
+	l.RegisterLevel(INFO, "Info")
+	l.RegisterLevel(DEBUG, "Debug")
+	l.RegisterLevel(ERROR, "Error")
+
+	l.chain.SetFallback(func(entry LogEntry) error {
+		_, err := fmt.Fprintf(l.out, "Unhandled(%s): %s\n", entry.Level, entry.Message)
+		return err
+	})
+	return l
+}
+
+// RegisterLevel adds a handler for another level at runtime
+func (l *LoggerChain) RegisterLevel(level LogLevel, prefix string) {
+	l.chain.Use(prefix, HandlerFunc[LogEntry](func(entry LogEntry, next func(LogEntry) error) error {
+		if entry.Level != level {
+			return next(entry)
+		}
+		_, err := fmt.Fprintf(l.out, "%s: %s\n", prefix, entry.Message)
+		return err
+	}))
+}
+
+// Log sends an entry through the chain
+func (l *LoggerChain) Log(entry LogEntry) error {
+	return l.chain.Handle(entry)
 }
@@ -0,0 +1,237 @@
+// Event Bus is an Observer variant where publishers and subscribers only share a topic
+// name. Instead of a subject keeping a list of observers, a central bus routes each
+// event to the subscribers of its topic, either synchronously or through buffered
+// channels so slow subscribers don't hold up the publisher.
+//
+// Use cases:
+// - Decoupling modules that react to the same domain events
+// - Fan-out of notifications to many independent consumers
+// - Background processing of events without blocking the caller
+
+package behavioral
+
+import (
+	"context"
+	"errors"
+	"sync"
+)
+
+// ErrBusClosed is returned when publishing to a closed bus
+var ErrBusClosed = errors.New("event bus is closed")
+
+// Event is a message published on a topic
+type Event struct {
+	Topic   string
+	Payload any
+}
+
+// DispatchMode controls how events are delivered to subscribers
+type DispatchMode int
+
+const (
+	// SyncDispatch calls every handler in the publisher's goroutine
+	// before Publish returns
+	SyncDispatch DispatchMode = iota
+	// AsyncDispatch queues events on each subscriber's buffered channel
+	// and a goroutine per subscriber calls its handler
+	AsyncDispatch
+)
+
+// Subscription is a handle returned by Subscribe
+// It is used to stop receiving events
+type Subscription struct {
+	id      uint64
+	topic   string
+	handler func(Event)
+	events  chan Event
+	done    chan struct{}
+	once    sync.Once
+	stop    func() bool
+	bus     *EventBus
+}
+
+// Topic returns the topic this subscription listens to
+func (s *Subscription) Topic() string {
+	return s.topic
+}
+
+// Unsubscribe stops delivery to this subscription
+// Events already queued are still handled
+func (s *Subscription) Unsubscribe() {
+	s.bus.Unsubscribe(s)
+}
+
+// closeDone marks the subscription as finished exactly once
+func (s *Subscription) closeDone() {
+	s.once.Do(func() {
+		close(s.done)
+	})
+}
+
+// isDone reports whether the subscription has been cancelled
+func (s *Subscription) isDone() bool {
+	select {
+	case <-s.done:
+		return true
+	default:
+		return false
+	}
+}
+
+// run is the delivery loop used in AsyncDispatch mode
+func (s *Subscription) run(wg *sync.WaitGroup) {
+	defer wg.Done()
+	for {
+		select {
+		case ev := <-s.events:
+			s.handler(ev)
+		case <-s.done:
+			// Deliver whatever was queued before unsubscribing
+			for {
+				select {
+				case ev := <-s.events:
+					s.handler(ev)
+				default:
+					return
+				}
+			}
+		}
+	}
+}
+
+// EventBus routes events to subscribers by topic
+type EventBus struct {
+	mu         sync.RWMutex
+	topics     map[string]map[uint64]*Subscription
+	nextID     uint64
+	mode       DispatchMode
+	bufferSize int
+	closed     bool
+	wg         sync.WaitGroup
+}
+
+// NewEventBus creates an event bus
+// bufferSize is the capacity of each subscriber's channel in AsyncDispatch mode
+func NewEventBus(mode DispatchMode, bufferSize int) *EventBus {
+	return &EventBus{
+		topics:     make(map[string]map[uint64]*Subscription),
+		mode:       mode,
+		bufferSize: bufferSize,
+	}
+}
+
+// Subscribe registers handler for events on topic
+// The subscription ends when Unsubscribe is called, when ctx is cancelled
+// or when the bus is closed
+func (b *EventBus) Subscribe(ctx context.Context, topic string, handler func(Event)) *Subscription {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+
+	b.nextID++
+	sub := &Subscription{
+		id:      b.nextID,
+		topic:   topic,
+		handler: handler,
+		events:  make(chan Event, b.bufferSize),
+		done:    make(chan struct{}),
+		bus:     b,
+	}
+	if b.closed {
+		sub.closeDone()
+		return sub
+	}
+
+	if b.topics[topic] == nil {
+		b.topics[topic] = make(map[uint64]*Subscription)
+	}
+	b.topics[topic][sub.id] = sub
+
+	if b.mode == AsyncDispatch {
+		b.wg.Add(1)
+		go sub.run(&b.wg)
+	}
+	sub.stop = context.AfterFunc(ctx, func() {
+		b.Unsubscribe(sub)
+	})
+	return sub
+}
+
+// Unsubscribe removes a subscription from the bus
+func (b *EventBus) Unsubscribe(sub *Subscription) {
+	b.mu.Lock()
+	if subs, ok := b.topics[sub.topic]; ok {
+		delete(subs, sub.id)
+		if len(subs) == 0 {
+			delete(b.topics, sub.topic)
+		}
+	}
+	b.mu.Unlock()
+
+	if sub.stop != nil {
+		sub.stop()
+	}
+	sub.closeDone()
+}
+
+// Publish sends an event to every subscriber of topic
+// In AsyncDispatch mode Publish blocks while a subscriber's buffer is full,
+// until there is room, the subscriber goes away or ctx is cancelled
+func (b *EventBus) Publish(ctx context.Context, topic string, payload any) error {
+	b.mu.RLock()
+	if b.closed {
+		b.mu.RUnlock()
+		return ErrBusClosed
+	}
+	subs := make([]*Subscription, 0, len(b.topics[topic]))
+	for _, sub := range b.topics[topic] {
+		subs = append(subs, sub)
+	}
+	b.mu.RUnlock()
+
+	ev := Event{Topic: topic, Payload: payload}
+	for _, sub := range subs {
+		if b.mode == SyncDispatch {
+			if !sub.isDone() {
+				sub.handler(ev)
+			}
+			continue
+		}
+
+		select {
+		case sub.events <- ev:
+		case <-sub.done:
+		case <-ctx.Done():
+			return ctx.Err()
+		}
+	}
+	return nil
+}
+
+// SubscriberCount returns the number of subscribers of topic
+func (b *EventBus) SubscriberCount(topic string) int {
+	b.mu.RLock()
+	defer b.mu.RUnlock()
+	return len(b.topics[topic])
+}
+
+// Close unsubscribes everyone and waits until queued events are handled
+func (b *EventBus) Close() {
+	b.mu.Lock()
+	b.closed = true
+	subs := []*Subscription{}
+	for _, topicSubs := range b.topics {
+		for _, sub := range topicSubs {
+			subs = append(subs, sub)
+		}
+	}
+	b.topics = make(map[string]map[uint64]*Subscription)
+	b.mu.Unlock()
+
+	for _, sub := range subs {
+		if sub.stop != nil {
+			sub.stop()
+		}
+		sub.closeDone()
+	}
+	b.wg.Wait()
+}
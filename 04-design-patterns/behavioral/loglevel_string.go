@@ -0,0 +1,26 @@
+// Code generated by "stringer -type=LogLevel"; DO NOT EDIT.
+
+package behavioral
+
+import "strconv"
+
+func _() {
+	// An "invalid array index" compiler error signifies that the constant values have changed.
+	// Re-run the stringer command to generate them again.
+	var x [1]struct{}
+	_ = x[INFO-0]
+	_ = x[DEBUG-1]
+	_ = x[ERROR-2]
+	_ = x[WARN-3]
+}
+
+const _LogLevel_name = "INFODEBUGERRORWARN"
+
+var _LogLevel_index = [...]uint8{0, 4, 9, 14, 18}
+
+func (i LogLevel) String() string {
+	if i < 0 || i >= LogLevel(len(_LogLevel_index)-1) {
+		return "LogLevel(" + strconv.FormatInt(int64(i), 10) + ")"
+	}
+	return _LogLevel_name[_LogLevel_index[i]:_LogLevel_index[i+1]]
+}
@@ -0,0 +1,228 @@
+// Null Object Pattern replaces "no object" (nil) with an object that does nothing.
+// Callers can use the result without a nil check, because the null object
+// implements the same interface with harmless default behaviour.
+//
+// Option[T] and Result[T] attack the same problem from the type side: the
+// possibility of "no value" or "failure" is part of the type, and helpers like
+// Map and OrElse chain operations without an if after every step. Idiomatic Go
+// usually prefers the (value, ok) and (value, error) returns shown in
+// 01-basics/error-handling/error-handling.go; Get converts back to that form so both styles mix.
+//
+// Use cases:
+// - Optional collaborators such as loggers or notifiers (Null Object)
+// - Lookups that may find nothing, e.g. a guest instead of a missing customer
+// - Pipelines of transformations where any step may fail (Result)
+
+package behavioral
+
+import (
+	"fmt"
+	"strings"
+)
+
+// Customer is a shop customer
+type Customer interface {
+	Name() string
+	Discount() float64
+	IsGuest() bool
+}
+
+// RegisteredCustomer is a customer found in the directory
+type RegisteredCustomer struct {
+	name     string
+	discount float64
+}
+
+func (c RegisteredCustomer) Name() string      { return c.name }
+func (c RegisteredCustomer) Discount() float64 { return c.discount }
+func (c RegisteredCustomer) IsGuest() bool     { return false }
+
+// GuestCustomer is the null object returned for unknown customers
+// It has a neutral name and no discount, so pricing code needs no special case
+type GuestCustomer struct{}
+
+func (GuestCustomer) Name() string      { return "Guest" }
+func (GuestCustomer) Discount() float64 { return 0 }
+func (GuestCustomer) IsGuest() bool     { return true }
+
+// CustomerDirectory looks customers up by ID
+type CustomerDirectory struct {
+	customers map[string]RegisteredCustomer
+}
+
+// NewCustomerDirectory creates a directory with some sample customers
+func NewCustomerDirectory() *CustomerDirectory {
+	return &CustomerDirectory{customers: map[string]RegisteredCustomer{
+		"c1": {name: "Alice", discount: 0.10},
+		"c2": {name: "Bob", discount: 0.05},
+	}}
+}
+
+// Find never returns nil: unknown IDs get a GuestCustomer
+func (d *CustomerDirectory) Find(id string) Customer {
+	if c, ok := d.customers[id]; ok {
+		return c
+	}
+	return GuestCustomer{}
+}
+
+// Lookup returns the customer as an Option instead of substituting a guest
+func (d *CustomerDirectory) Lookup(id string) Option[Customer] {
+	if c, ok := d.customers[id]; ok {
+		return Some[Customer](c)
+	}
+	return None[Customer]()
+}
+
+// Price applies the customer's discount; it works the same for guests
+func Price(c Customer, amount float64) string {
+	return fmt.Sprintf("%s pays %.2f", c.Name(), amount*(1-c.Discount()))
+}
+
+// Option holds either a value (Some) or nothing (None)
+// The zero value is None
+type Option[T any] struct {
+	value T
+	ok    bool
+}
+
+// Some wraps a present value
+func Some[T any](value T) Option[T] {
+	return Option[T]{value: value, ok: true}
+}
+
+// None returns an empty option
+func None[T any]() Option[T] {
+	return Option[T]{}
+}
+
+// IsSome reports whether the option holds a value
+func (o Option[T]) IsSome() bool { return o.ok }
+
+// Get returns the value in Go's usual (value, ok) form
+func (o Option[T]) Get() (T, bool) { return o.value, o.ok }
+
+// Unwrap returns the value and panics on None
+// Only use it when None would be a bug
+func (o Option[T]) Unwrap() T {
+	if !o.ok {
+		panic("Unwrap called on None")
+	}
+	return o.value
+}
+
+// OrElse returns the value, or fallback() for None
+// fallback is only called when it is needed
+func (o Option[T]) OrElse(fallback func() T) T {
+	if o.ok {
+		return o.value
+	}
+	return fallback()
+}
+
+// String formats the option as Some(value) or None
+func (o Option[T]) String() string {
+	if !o.ok {
+		return "None"
+	}
+	return fmt.Sprintf("Some(%v)", o.value)
+}
+
+// MapOption applies f to the value if there is one
+// Go methods cannot have their own type parameters, so Map is a function
+func MapOption[T, U any](o Option[T], f func(T) U) Option[U] {
+	if !o.ok {
+		return None[U]()
+	}
+	return Some(f(o.value))
+}
+
+// Result holds either a value (Ok) or an error (Err)
+type Result[T any] struct {
+	value T
+	err   error
+}
+
+// Ok wraps a successful value
+func Ok[T any](value T) Result[T] {
+	return Result[T]{value: value}
+}
+
+// Err wraps a failure
+// It panics if err is nil: a Result without an error is Ok, and Ok(zero)
+// should say so rather than pass for a failure
+func Err[T any](err error) Result[T] {
+	if err == nil {
+		panic("Err called with a nil error")
+	}
+	return Result[T]{err: err}
+}
+
+// ResultOf converts a (value, error) pair into a Result
+func ResultOf[T any](value T, err error) Result[T] {
+	if err != nil {
+		return Err[T](err)
+	}
+	return Ok(value)
+}
+
+// IsOk reports whether the result holds a value
+func (r Result[T]) IsOk() bool { return r.err == nil }
+
+// Err returns the error, or nil for Ok
+// It is not called Error, so a Result is not mistaken for an error value
+func (r Result[T]) Err() error { return r.err }
+
+// Get converts back to Go's (value, error) form
+func (r Result[T]) Get() (T, error) { return r.value, r.err }
+
+// Unwrap returns the value and panics on Err
+func (r Result[T]) Unwrap() T {
+	if r.err != nil {
+		panic("Unwrap called on Err: " + r.err.Error())
+	}
+	return r.value
+}
+
+// OrElse returns the value, or fallback(err) for Err
+func (r Result[T]) OrElse(fallback func(err error) T) T {
+	if r.err != nil {
+		return fallback(r.err)
+	}
+	return r.value
+}
+
+// String formats the result as Ok(value) or Err(message)
+func (r Result[T]) String() string {
+	if r.err != nil {
+		return fmt.Sprintf("Err(%v)", r.err)
+	}
+	return fmt.Sprintf("Ok(%v)", r.value)
+}
+
+// MapResult applies f to the value of an Ok result; errors pass through unchanged
+func MapResult[T, U any](r Result[T], f func(T) U) Result[U] {
+	if r.err != nil {
+		return Err[U](r.err)
+	}
+	return Ok(f(r.value))
+}
+
+// AndThen chains a step that can itself fail
+func AndThen[T, U any](r Result[T], f func(T) Result[U]) Result[U] {
+	if r.err != nil {
+		return Err[U](r.err)
+	}
+	return f(r.value)
+}
+
+// ParseQuantity is a small example step for Result pipelines
+// It accepts strings like " 3 items" and returns the number
+func ParseQuantity(s string) Result[int] {
+	var n int
+	_, err := fmt.Sscanf(strings.TrimSpace(s), "%d", &n)
+	if err != nil {
+		return Err[int](fmt.Errorf("parse quantity %q: %w", s, err))
+	}
+	return Ok(n)
+}
@@ -0,0 +1,121 @@
+package behavioral
+
+import (
+	"errors"
+	"strconv"
+	"testing"
+)
+
+func TestCustomerDirectory(t *testing.T) {
+	d := NewCustomerDirectory()
+	tests := []struct {
+		id    string
+		price string
+		guest bool
+	}{
+		{"c1", "Alice pays 90.00", false},
+		{"c2", "Bob pays 95.00", false},
+		{"nobody", "Guest pays 100.00", true},
+	}
+	for _, tt := range tests {
+		c := d.Find(tt.id)
+		if got := Price(c, 100); got != tt.price || c.IsGuest() != tt.guest {
+			t.Errorf("Find(%s): %q, guest %v", tt.id, got, c.IsGuest())
+		}
+		if got := d.Lookup(tt.id).IsSome(); got == tt.guest {
+			t.Errorf("Lookup(%s).IsSome() = %v", tt.id, got)
+		}
+	}
+}
+
+func TestOption(t *testing.T) {
+	double := func(n int) string { return strconv.Itoa(2 * n) }
+	tests := []struct {
+		name   string
+		option Option[int]
+		mapped string
+		orElse int
+	}{
+		{"some", Some(21), "Some(42)", 21},
+		{"some zero", Some(0), "Some(0)", 0},
+		{"none", None[int](), "None", -1},
+		{"zero value", Option[int]{}, "None", -1},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := MapOption(tt.option, double).String(); got != tt.mapped {
+				t.Errorf("MapOption = %s, want %s", got, tt.mapped)
+			}
+			if got := tt.option.OrElse(func() int { return -1 }); got != tt.orElse {
+				t.Errorf("OrElse = %d, want %d", got, tt.orElse)
+			}
+			if v, ok := tt.option.Get(); ok != tt.option.IsSome() || ok && v != tt.orElse {
+				t.Errorf("Get = %d, %v", v, ok)
+			}
+			if panicked := panics(func() { tt.option.Unwrap() }); panicked == tt.option.IsSome() {
+				t.Errorf("Unwrap panicked: %v", panicked)
+			}
+		})
+	}
+}
+
+func TestResult(t *testing.T) {
+	errBad := errors.New("bad")
+	double := func(n int) int { return 2 * n }
+	tests := []struct {
+		name   string
+		result Result[int]
+		mapped string
+		orElse int
+		err    error
+	}{
+		{"ok", Ok(21), "Ok(42)", 21, nil},
+		{"err", Err[int](errBad), "Err(bad)", -1, errBad},
+		{"from pair", ResultOf(5, nil), "Ok(10)", 5, nil},
+		{"from failed pair", ResultOf(5, errBad), "Err(bad)", -1, errBad},
+		{"parsed", ParseQuantity(" 3 items"), "Ok(6)", 3, nil},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := MapResult(tt.result, double).String(); got != tt.mapped {
+				t.Errorf("MapResult = %s, want %s", got, tt.mapped)
+			}
+			if got := tt.result.OrElse(func(error) int { return -1 }); got != tt.orElse {
+				t.Errorf("OrElse = %d, want %d", got, tt.orElse)
+			}
+			if err := tt.result.Err(); err != tt.err || tt.result.IsOk() != (err == nil) {
+				t.Errorf("Err = %v, IsOk = %v, want %v", err, tt.result.IsOk(), tt.err)
+			}
+			if panicked := panics(func() { tt.result.Unwrap() }); panicked == tt.result.IsOk() {
+				t.Errorf("Unwrap panicked: %v", panicked)
+			}
+		})
+	}
+
+	// A failing step stops the chain and its error comes through
+	half := func(n int) Result[int] {
+		if n%2 != 0 {
+			return Err[int](errors.New("odd"))
+		}
+		return Ok(n / 2)
+	}
+	if got := AndThen(AndThen(Ok(12), half), half).String(); got != "Ok(3)" {
+		t.Errorf("12 halved twice = %s", got)
+	}
+	if got := AndThen(AndThen(Ok(6), half), half).String(); got != "Err(odd)" {
+		t.Errorf("6 halved twice = %s", got)
+	}
+	if _, err := ParseQuantity("x").Get(); err == nil {
+		t.Error(`ParseQuantity("x") has no error`)
+	}
+	if !panics(func() { Err[int](nil) }) {
+		t.Error("Err(nil) did not panic")
+	}
+}
+
+// panics reports whether f panics
+func panics(f func()) (panicked bool) {
+	defer func() { panicked = recover() != nil }()
+	f()
+	return false
+}
@@ -5,53 +5,192 @@
 // - When a change to one object requires changing others, and you don't know how many objects need to be changed
 // - When an object should be able to notify other objects without making assumptions about who these objects are
 // - When you need to maintain consistency between related objects without making them tightly coupled
+//
+// Subject[T] is the subject for events of any type T. Event is taken by
+// the event bus, whose events carry an untyped payload; a Subject[T] is
+// typed, so an observer of temperatures can't be sent a string
+//
+// - Registering returns a Token, and the Token is what removes the
+//   observer. Removing by the observer itself would need == on interface
+//   values, which panics for observers that aren't comparable, such as an
+//   ObserverFunc, and removes the wrong one when an observer is registered
+//   twice
+// - An observer registered with a higher priority is told first; equal
+//   priorities are told in the order they registered
+// - A panicking observer doesn't stop the others: Notify recovers, tells
+//   the rest, and returns the panics as an error
 
 package behavioral
 
-// Observer interface defines the method that should be implemented by observers
-type Observer interface {
-	Update(temperature float64)
+import (
+	"errors"
+	"fmt"
+	"slices"
+	"sync"
+)
+
+// Observer is notified of events of type T
+type Observer[T any] interface {
+	Update(event T)
+}
+
+// ObserverFunc lets an ordinary function be an Observer
+type ObserverFunc[T any] func(event T)
+
+// Update calls f(event)
+func (f ObserverFunc[T]) Update(event T) {
+	f(event)
+}
+
+// Token identifies one registration with a Subject
+type Token uint64
+
+// ObserverPanic is the error Notify returns for an observer that panicked
+type ObserverPanic struct {
+	Token Token
+	Value any
+}
+
+func (e *ObserverPanic) Error() string {
+	return fmt.Sprintf("observer %d panicked: %v", e.Token, e.Value)
+}
+
+// registration is an observer with its token and priority
+type registration[T any] struct {
+	token    Token
+	priority int
+	observer Observer[T]
+}
+
+// Subject notifies its observers of events of type T
+// It is safe for concurrent use; observers are called without the lock held,
+// so an Update may register or remove observers
+type Subject[T any] struct {
+	mu   sync.RWMutex
+	last Token
+	// observers is sorted by priority, highest first. It is never changed
+	// in place, only replaced, so Notify can use it without copying
+	observers []registration[T]
+}
+
+// NewSubject creates a subject with no observers
+func NewSubject[T any]() *Subject[T] {
+	return &Subject[T]{}
+}
+
+// Register adds an observer with priority 0 and returns its token
+func (s *Subject[T]) Register(o Observer[T]) Token {
+	return s.RegisterPriority(o, 0)
+}
+
+// RegisterPriority adds an observer that is told before those with a lower
+// priority and after those with a higher or equal one that registered
+// earlier
+// Time Complexity: O(n)
+func (s *Subject[T]) RegisterPriority(o Observer[T], priority int) Token {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.last++
+	i := len(s.observers)
+	for i > 0 && s.observers[i-1].priority < priority {
+		i--
+	}
+	s.observers = slices.Insert(slices.Clip(s.observers), i, registration[T]{s.last, priority, o})
+	return s.last
+}
+
+// Remove removes the observer registered with token, and reports whether
+// there was one
+// Time Complexity: O(n)
+func (s *Subject[T]) Remove(token Token) bool {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	i := slices.IndexFunc(s.observers, func(r registration[T]) bool { return r.token == token })
+	if i < 0 {
+		return false
+	}
+	s.observers = slices.Delete(slices.Clone(s.observers), i, i+1)
+	return true
+}
+
+// Len returns the number of observers
+func (s *Subject[T]) Len() int {
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+	return len(s.observers)
+}
+
+// Notify tells every observer registered right now about event
+// An observer that panics is skipped; the others are still told, and the
+// panics come back joined in the error, each as an *ObserverPanic
+func (s *Subject[T]) Notify(event T) error {
+	s.mu.RLock()
+	observers := s.observers
+	s.mu.RUnlock()
+	var errs []error
+	for _, r := range observers {
+		if err := r.update(event); err != nil {
+			errs = append(errs, err)
+		}
+	}
+	return errors.Join(errs...)
+}
+
+// update calls the observer, turning a panic into an error
+func (r registration[T]) update(event T) (err error) {
+	defer func() {
+		if p := recover(); p != nil {
+			err = &ObserverPanic{Token: r.token, Value: p}
+		}
+	}()
+	r.observer.Update(event)
+	return nil
 }
 
 // WeatherStation is the subject that observers are watching
+// It is safe for concurrent use, like the Subject it notifies through
 type WeatherStation struct {
-	observers   []Observer
+	subject     Subject[float64]
+	mu          sync.RWMutex
 	temperature float64
 }
 
 // NewWeatherStation creates a new weather station
 func NewWeatherStation() *WeatherStation {
-	return &WeatherStation{
-		observers: make([]Observer, 0),
-	}
+	return &WeatherStation{}
 }
 
-// RegisterObserver adds an observer to the list
-func (w *WeatherStation) RegisterObserver(o Observer) {
-	w.observers = append(w.observers, o)
+// RegisterObserver adds an observer and returns the token that removes it
+func (w *WeatherStation) RegisterObserver(o Observer[float64]) Token {
+	return w.subject.Register(o)
 }
 
-// RemoveObserver removes an observer from the list
-func (w *WeatherStation) RemoveObserver(o Observer) {
-	for i, observer := range w.observers {
-		if observer == o {
-			w.observers = append(w.observers[:i], w.observers[i+1:]...)
-			break
-		}
-	}
+// RegisterObserverPriority adds an observer that is told before those
+// with a lower priority
+func (w *WeatherStation) RegisterObserverPriority(o Observer[float64], priority int) Token {
+	return w.subject.RegisterPriority(o, priority)
 }
 
-// NotifyObservers notifies all observers of the temperature change
-func (w *WeatherStation) NotifyObservers() {
-	for _, observer := range w.observers {
-		observer.Update(w.temperature)
-	}
+// RemoveObserver removes the observer registered with token
+func (w *WeatherStation) RemoveObserver(token Token) bool {
+	return w.subject.Remove(token)
+}
+
+// NotifyObservers notifies all observers of the temperature
+func (w *WeatherStation) NotifyObservers() error {
+	w.mu.RLock()
+	temperature := w.temperature
+	w.mu.RUnlock()
+	return w.subject.Notify(temperature)
 }
 
 // SetTemperature changes the temperature and notifies observers
-func (w *WeatherStation) SetTemperature(temp float64) {
+// The error holds the panics of observers, which didn't stop the others
+func (w *WeatherStation) SetTemperature(temp float64) error {
+	w.mu.Lock()
 	w.temperature = temp
-	w.NotifyObservers()
+	w.mu.Unlock()
+	return w.subject.Notify(temp)
 }
 
 // TemperatureDisplay is a concrete observer
@@ -67,10 +206,10 @@ func NewTemperatureDisplay(name string) *TemperatureDisplay {
 // Update implements the Observer interface
 func (d *TemperatureDisplay) Update(temperature float64) {
 	// In a real application, this would update a display
-	// For this example, we'll just store the temperature
-	d.display(temperature)
+	// For this example, we'll just print the reading
+	fmt.Println(d.display(temperature))
 }
 
 func (d *TemperatureDisplay) display(temperature float64) string {
-	return "Display " + d.name + " shows temperature: " + string(rune(temperature))
+	return fmt.Sprintf("%s shows temperature: %.1f°C", d.name, temperature)
 }
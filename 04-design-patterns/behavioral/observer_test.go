@@ -0,0 +1,78 @@
+package behavioral
+
+import (
+	"errors"
+	"slices"
+	"testing"
+)
+
+func TestSubjectPriority(t *testing.T) {
+	s := NewSubject[string]()
+	var got []string
+	record := func(name string) Observer[string] {
+		return ObserverFunc[string](func(string) { got = append(got, name) })
+	}
+	s.Register(record("a"))
+	s.RegisterPriority(record("high"), 5)
+	s.Register(record("b"))
+	s.RegisterPriority(record("low"), -1)
+	s.RegisterPriority(record("high2"), 5)
+	if err := s.Notify("x"); err != nil {
+		t.Fatal(err)
+	}
+	if want := []string{"high", "high2", "a", "b", "low"}; !slices.Equal(got, want) {
+		t.Errorf("order = %v, want %v", got, want)
+	}
+}
+
+func TestSubjectRemoveByToken(t *testing.T) {
+	s := NewSubject[int]()
+	count := 0
+	o := ObserverFunc[int](func(int) { count++ })
+	// The same observer twice: its tokens tell the registrations apart,
+	// where == on a func would panic
+	first := s.Register(o)
+	s.Register(o)
+	if !s.Remove(first) {
+		t.Fatal("Remove(first) = false")
+	}
+	if s.Remove(first) {
+		t.Error("second Remove(first) = true")
+	}
+	s.Notify(1)
+	if count != 1 || s.Len() != 1 {
+		t.Errorf("count = %d, Len = %d, want 1, 1", count, s.Len())
+	}
+}
+
+func TestSubjectIsolatesPanics(t *testing.T) {
+	s := NewSubject[int]()
+	told := 0
+	s.Register(ObserverFunc[int](func(int) { told++ }))
+	bad := s.Register(ObserverFunc[int](func(int) { panic("boom") }))
+	s.Register(ObserverFunc[int](func(int) { told++ }))
+
+	err := s.Notify(1)
+	if told != 2 {
+		t.Errorf("%d observers told, want 2", told)
+	}
+	var p *ObserverPanic
+	if !errors.As(err, &p) || p.Token != bad || p.Value != "boom" {
+		t.Errorf("Notify error = %v, want a panic from observer %d", err, bad)
+	}
+}
+
+// An observer that removes itself while being notified must not stop the
+// others being told
+func TestSubjectRemoveDuringNotify(t *testing.T) {
+	s := NewSubject[int]()
+	told := 0
+	var self Token
+	self = s.Register(ObserverFunc[int](func(int) { s.Remove(self) }))
+	s.Register(ObserverFunc[int](func(int) { told++ }))
+	s.Notify(1)
+	s.Notify(2)
+	if told != 2 || s.Len() != 1 {
+		t.Errorf("told = %d, Len = %d, want 2, 1", told, s.Len())
+	}
+}
@@ -0,0 +1,235 @@
+// Publish-Subscribe Pattern decouples senders from receivers through a broker.
+// Unlike the Observer pattern, where the subject calls every observer directly,
+// publishers hand messages to the broker and each subscriber reads them from its
+// own buffered channel at its own pace. The broker decides what happens when a
+// subscriber falls behind and its buffer fills up.
+//
+// Use cases:
+// - Streaming updates (prices, metrics) to consumers of different speeds
+// - Fan-out of work notifications between goroutines
+// - Systems where losing a stale update is better than stalling everyone
+
+package behavioral
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"sync"
+	"sync/atomic"
+)
+
+var (
+	// ErrBrokerClosed is returned when publishing to or subscribing on a closed broker
+	ErrBrokerClosed = errors.New("broker is closed")
+	// ErrInvalidBuffer is returned by Subscribe for a buffer size the policy can't use
+	ErrInvalidBuffer = errors.New("invalid subscriber buffer")
+)
+
+// OverflowPolicy decides what Publish does when a subscriber's buffer is full
+type OverflowPolicy int
+
+const (
+	// Block makes the publisher wait until the subscriber has room
+	// No message is lost, but one slow subscriber slows down every publisher
+	Block OverflowPolicy = iota
+	// DropNewest discards the message being published
+	DropNewest
+	// DropOldest discards the oldest buffered message to make room,
+	// so the subscriber always sees the latest messages
+	DropOldest
+)
+
+// Message is a payload published on a topic
+type Message struct {
+	Topic   string
+	Payload any
+}
+
+// Subscriber receives the messages of one topic on a buffered channel
+type Subscriber struct {
+	topic   string
+	policy  OverflowPolicy
+	ch      chan Message
+	done    chan struct{}
+	once    sync.Once
+	dropped atomic.Int64
+	broker  *Broker
+
+	// mu serializes sends and guards closed, so ch is never closed mid-send
+	mu     sync.Mutex
+	closed bool
+}
+
+// Messages returns the channel to read messages from
+// It is closed after Unsubscribe or Broker.Close, once buffered messages are read
+func (s *Subscriber) Messages() <-chan Message {
+	return s.ch
+}
+
+// Topic returns the topic this subscriber listens to
+func (s *Subscriber) Topic() string {
+	return s.topic
+}
+
+// Dropped returns how many messages were discarded because the buffer was full
+func (s *Subscriber) Dropped() int64 {
+	return s.dropped.Load()
+}
+
+// Unsubscribe stops delivery and closes the Messages channel
+func (s *Subscriber) Unsubscribe() {
+	s.broker.remove(s)
+	s.close()
+}
+
+// close closes the channel exactly once
+// done is closed first to wake up a publisher blocked in send,
+// which then releases mu so the channel can be closed safely
+func (s *Subscriber) close() {
+	s.once.Do(func() {
+		close(s.done)
+		s.mu.Lock()
+		defer s.mu.Unlock()
+		s.closed = true
+		close(s.ch)
+	})
+}
+
+// send delivers msg according to the subscriber's overflow policy
+func (s *Subscriber) send(ctx context.Context, msg Message) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	if s.closed {
+		return nil
+	}
+
+	switch s.policy {
+	case DropNewest:
+		select {
+		case s.ch <- msg:
+		default:
+			s.dropped.Add(1)
+		}
+	case DropOldest:
+		for {
+			select {
+			case s.ch <- msg:
+				return nil
+			default:
+			}
+			// Full: evict the oldest message (unless the reader just took it) and retry
+			select {
+			case <-s.ch:
+				s.dropped.Add(1)
+			default:
+			}
+		}
+	default:
+		select {
+		case s.ch <- msg:
+		case <-s.done:
+		case <-ctx.Done():
+			return ctx.Err()
+		}
+	}
+	return nil
+}
+
+// Broker routes published messages to the subscribers of each topic
+type Broker struct {
+	mu     sync.RWMutex
+	topics map[string]map[*Subscriber]struct{}
+	closed bool
+}
+
+// NewBroker creates an empty broker
+func NewBroker() *Broker {
+	return &Broker{topics: make(map[string]map[*Subscriber]struct{})}
+}
+
+// Subscribe creates a subscriber for topic with room for buffer messages
+// Block works unbuffered, handing each message straight to the reader, but
+// the dropping policies need room for at least one message: without it
+// every message would be dropped, or DropOldest would have nothing to evict
+func (b *Broker) Subscribe(topic string, buffer int, policy OverflowPolicy) (*Subscriber, error) {
+	if buffer < 0 || buffer < 1 && policy != Block {
+		return nil, fmt.Errorf("%w: %d for policy %d", ErrInvalidBuffer, buffer, policy)
+	}
+	b.mu.Lock()
+	defer b.mu.Unlock()
+	if b.closed {
+		return nil, ErrBrokerClosed
+	}
+
+	s := &Subscriber{
+		topic:  topic,
+		policy: policy,
+		ch:     make(chan Message, buffer),
+		done:   make(chan struct{}),
+		broker: b,
+	}
+	if b.topics[topic] == nil {
+		b.topics[topic] = make(map[*Subscriber]struct{})
+	}
+	b.topics[topic][s] = struct{}{}
+	return s, nil
+}
+
+// remove detaches a subscriber from its topic
+func (b *Broker) remove(s *Subscriber) {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+	if subs, ok := b.topics[s.topic]; ok {
+		delete(subs, s)
+		if len(subs) == 0 {
+			delete(b.topics, s.topic)
+		}
+	}
+}
+
+// Publish sends payload to every subscriber of topic
+// It only blocks for subscribers using the Block policy, and gives up
+// when ctx is cancelled
+func (b *Broker) Publish(ctx context.Context, topic string, payload any) error {
+	// Copy the subscribers so a blocked send doesn't hold the broker lock
+	b.mu.RLock()
+	if b.closed {
+		b.mu.RUnlock()
+		return ErrBrokerClosed
+	}
+	subs := make([]*Subscriber, 0, len(b.topics[topic]))
+	for s := range b.topics[topic] {
+		subs = append(subs, s)
+	}
+	b.mu.RUnlock()
+
+	msg := Message{Topic: topic, Payload: payload}
+	for _, s := range subs {
+		if err := s.send(ctx, msg); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// Close shuts the broker down
+// Further Publish and Subscribe calls fail with ErrBrokerClosed; every
+// subscriber channel is closed, but messages already buffered can still be read
+func (b *Broker) Close() {
+	b.mu.Lock()
+	if b.closed {
+		b.mu.Unlock()
+		return
+	}
+	b.closed = true
+	topics := b.topics
+	b.topics = make(map[string]map[*Subscriber]struct{})
+	b.mu.Unlock()
+
+	for _, subs := range topics {
+		for s := range subs {
+			s.close()
+		}
+	}
+}
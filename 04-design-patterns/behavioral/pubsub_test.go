@@ -0,0 +1,82 @@
+package behavioral
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+)
+
+func TestSubscribeBufferSize(t *testing.T) {
+	tests := []struct {
+		policy OverflowPolicy
+		buffer int
+		ok     bool
+	}{
+		{Block, -1, false},
+		{Block, 0, true},
+		{Block, 1, true},
+		{DropNewest, -1, false},
+		{DropNewest, 0, false},
+		{DropNewest, 1, true},
+		{DropOldest, -1, false},
+		{DropOldest, 0, false},
+		{DropOldest, 1, true},
+	}
+	b := NewBroker()
+	defer b.Close()
+	for _, tt := range tests {
+		s, err := b.Subscribe("t", tt.buffer, tt.policy)
+		if tt.ok && err != nil {
+			t.Errorf("Subscribe(buffer %d, policy %d) = %v", tt.buffer, tt.policy, err)
+		}
+		if !tt.ok && (!errors.Is(err, ErrInvalidBuffer) || s != nil) {
+			t.Errorf("Subscribe(buffer %d, policy %d) = %v, want ErrInvalidBuffer", tt.buffer, tt.policy, err)
+		}
+		if s != nil {
+			s.Unsubscribe()
+		}
+	}
+}
+
+func TestPublishUnbufferedBlock(t *testing.T) {
+	b := NewBroker()
+	defer b.Close()
+	s, err := b.Subscribe("t", 0, Block)
+	if err != nil {
+		t.Fatal(err)
+	}
+	got := make(chan any)
+	go func() { got <- (<-s.Messages()).Payload }()
+	if err := b.Publish(context.Background(), "t", 1); err != nil {
+		t.Fatal(err)
+	}
+	if p := <-got; p != 1 {
+		t.Errorf("payload = %v, want 1", p)
+	}
+
+	// With nobody reading, the publisher waits until the context ends
+	ctx, cancel := context.WithTimeout(context.Background(), 10*time.Millisecond)
+	defer cancel()
+	if err := b.Publish(ctx, "t", 2); !errors.Is(err, context.DeadlineExceeded) {
+		t.Errorf("Publish with no reader = %v, want DeadlineExceeded", err)
+	}
+}
+
+func TestPublishDropPolicies(t *testing.T) {
+	b := NewBroker()
+	defer b.Close()
+	newest, _ := b.Subscribe("t", 1, DropNewest)
+	oldest, _ := b.Subscribe("t", 1, DropOldest)
+	for i := range 3 {
+		if err := b.Publish(context.Background(), "t", i); err != nil {
+			t.Fatal(err)
+		}
+	}
+	if p := (<-newest.Messages()).Payload; p != 0 || newest.Dropped() != 2 {
+		t.Errorf("DropNewest kept %v and dropped %d, want 0 and 2", p, newest.Dropped())
+	}
+	if p := (<-oldest.Messages()).Payload; p != 2 || oldest.Dropped() != 2 {
+		t.Errorf("DropOldest kept %v and dropped %d, want 2 and 2", p, oldest.Dropped())
+	}
+}
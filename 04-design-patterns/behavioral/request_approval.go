@@ -0,0 +1,112 @@
+// Request approval is a practical Chain of Responsibility: an incoming HTTP request
+// must pass authentication, then validation, then rate limiting before it is approved.
+// Each step either rejects the request with an error or calls next.
+//
+// Use cases:
+// - API gateways and HTTP middleware stacks
+// - Multi-step approval workflows (purchase orders, expense claims)
+// - Request filtering where steps can be added or removed at runtime
+
+package behavioral
+
+import (
+	"errors"
+	"fmt"
+	"strings"
+	"sync"
+)
+
+// Errors returned by the approval steps
+var (
+	ErrUnauthorized   = errors.New("unauthorized")
+	ErrInvalidRequest = errors.New("invalid request")
+	ErrRateLimited    = errors.New("rate limit exceeded")
+)
+
+// ApprovalRequest is a simplified incoming HTTP request
+type ApprovalRequest struct {
+	ClientID string
+	Token    string
+	Method   string
+	Path     string
+	Body     string
+}
+
+// AuthHandler rejects requests whose token is not in the allowed set
+func AuthHandler(tokens ...string) Handler[ApprovalRequest] {
+	allowed := make(map[string]bool, len(tokens))
+	for _, token := range tokens {
+		allowed[token] = true
+	}
+
+	return HandlerFunc[ApprovalRequest](func(req ApprovalRequest, next func(ApprovalRequest) error) error {
+		if !allowed[req.Token] {
+			return fmt.Errorf("%w: client %q", ErrUnauthorized, req.ClientID)
+		}
+		return next(req)
+	})
+}
+
+// ValidationHandler rejects malformed requests
+func ValidationHandler() Handler[ApprovalRequest] {
+	return HandlerFunc[ApprovalRequest](func(req ApprovalRequest, next func(ApprovalRequest) error) error {
+		switch req.Method {
+		case "GET", "DELETE":
+		case "POST", "PUT":
+			if strings.TrimSpace(req.Body) == "" {
+				return fmt.Errorf("%w: %s %s requires a body", ErrInvalidRequest, req.Method, req.Path)
+			}
+		default:
+			return fmt.Errorf("%w: unsupported method %q", ErrInvalidRequest, req.Method)
+		}
+		if !strings.HasPrefix(req.Path, "/") {
+			return fmt.Errorf("%w: path %q must start with /", ErrInvalidRequest, req.Path)
+		}
+		return next(req)
+	})
+}
+
+// RateLimitHandler allows at most limit requests per client
+type RateLimitHandler struct {
+	mu     sync.Mutex
+	limit  int
+	counts map[string]int
+}
+
+// NewRateLimitHandler creates a rate limiter allowing limit requests per client
+func NewRateLimitHandler(limit int) *RateLimitHandler {
+	return &RateLimitHandler{limit: limit, counts: make(map[string]int)}
+}
+
+// Handle implements Handler
+func (r *RateLimitHandler) Handle(req ApprovalRequest, next func(ApprovalRequest) error) error {
+	r.mu.Lock()
+	r.counts[req.ClientID]++
+	count := r.counts[req.ClientID]
+	r.mu.Unlock()
+
+	if count > r.limit {
+		return fmt.Errorf("%w: client %q made %d requests (limit %d)", ErrRateLimited, req.ClientID, count, r.limit)
+	}
+	return next(req)
+}
+
+// Reset clears all request counts, e.g. at the start of a new time window
+func (r *RateLimitHandler) Reset() {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	r.counts = make(map[string]int)
+}
+
+// NewApprovalChain builds the auth -> validation -> rate limit pipeline
+// A request that passes every step reaches the fallback and is approved
+func NewApprovalChain(limit int, tokens ...string) *Chain[ApprovalRequest] {
+	chain := NewChain[ApprovalRequest]()
+	chain.Use("auth", AuthHandler(tokens...))
+	chain.Use("validation", ValidationHandler())
+	chain.Use("ratelimit", NewRateLimitHandler(limit))
+	chain.SetFallback(func(ApprovalRequest) error {
+		return nil
+	})
+	return chain
+}
@@ -8,6 +8,13 @@
 
 package behavioral
 
+import (
+	"errors"
+	"fmt"
+	"sort"
+	"sync"
+)
+
 // PaymentStrategy defines the interface for payment strategies
 type PaymentStrategy interface {
 	Pay(amount float64) string
@@ -27,7 +34,7 @@ func NewCreditCardStrategy(cardNumber, cvv string) PaymentStrategy {
 }
 
 func (c *CreditCardStrategy) Pay(amount float64) string {
-	return "Paid " + string(rune(amount)) + " using Credit Card"
+	return fmt.Sprintf("Paid %.2f using Credit Card", amount)
 }
 
 // PayPalStrategy implements PaymentStrategy for PayPal payments
@@ -44,7 +51,7 @@ func NewPayPalStrategy(email, password string) PaymentStrategy {
 }
 
 func (p *PayPalStrategy) Pay(amount float64) string {
-	return "Paid " + string(rune(amount)) + " using PayPal"
+	return fmt.Sprintf("Paid %.2f using PayPal", amount)
 }
 
 // BitcoinStrategy implements PaymentStrategy for Bitcoin payments
@@ -59,7 +66,7 @@ func NewBitcoinStrategy(address string) PaymentStrategy {
 }
 
 func (b *BitcoinStrategy) Pay(amount float64) string {
-	return "Paid " + string(rune(amount)) + " using Bitcoin"
+	return fmt.Sprintf("Paid %.2f using Bitcoin", amount)
 }
 
 // ShoppingCart is the context that uses the payment strategy
@@ -82,3 +89,107 @@ func (c *ShoppingCart) SetPaymentStrategy(strategy PaymentStrategy) {
 func (c *ShoppingCart) Checkout(amount float64) string {
 	return c.paymentStrategy.Pay(amount)
 }
+
+// StrategyConstructor creates a payment strategy from configuration options
+type StrategyConstructor func(options map[string]string) (PaymentStrategy, error)
+
+// ErrUnknownStrategy is returned when no strategy is registered under a name
+var ErrUnknownStrategy = errors.New("unknown payment strategy")
+
+// PaymentConfig selects a payment strategy by name, e.g. from a JSON config file
+type PaymentConfig struct {
+	Method  string            `json:"method"`
+	Options map[string]string `json:"options"`
+}
+
+// StrategyRegistry maps names to strategy constructors
+// New strategies can be registered without touching the code that selects them
+type StrategyRegistry struct {
+	mu           sync.RWMutex
+	constructors map[string]StrategyConstructor
+}
+
+// NewStrategyRegistry creates an empty registry
+func NewStrategyRegistry() *StrategyRegistry {
+	return &StrategyRegistry{constructors: make(map[string]StrategyConstructor)}
+}
+
+// NewDefaultStrategyRegistry creates a registry with the built-in strategies:
+// "credit_card" (card_number, cvv), "paypal" (email, password) and "bitcoin" (address)
+func NewDefaultStrategyRegistry() *StrategyRegistry {
+	r := NewStrategyRegistry()
+	r.Register("credit_card", func(options map[string]string) (PaymentStrategy, error) {
+		if err := requireOptions(options, "card_number", "cvv"); err != nil {
+			return nil, err
+		}
+		return NewCreditCardStrategy(options["card_number"], options["cvv"]), nil
+	})
+	r.Register("paypal", func(options map[string]string) (PaymentStrategy, error) {
+		if err := requireOptions(options, "email", "password"); err != nil {
+			return nil, err
+		}
+		return NewPayPalStrategy(options["email"], options["password"]), nil
+	})
+	r.Register("bitcoin", func(options map[string]string) (PaymentStrategy, error) {
+		if err := requireOptions(options, "address"); err != nil {
+			return nil, err
+		}
+		return NewBitcoinStrategy(options["address"]), nil
+	})
+	return r
+}
+
+// Register adds a strategy constructor under name
+// Returns an error if the name is already taken
+func (r *StrategyRegistry) Register(name string, constructor StrategyConstructor) error {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	if _, exists := r.constructors[name]; exists {
+		return fmt.Errorf("payment strategy %q is already registered", name)
+	}
+	r.constructors[name] = constructor
+	return nil
+}
+
+// New creates the strategy registered under name
+func (r *StrategyRegistry) New(name string, options map[string]string) (PaymentStrategy, error) {
+	r.mu.RLock()
+	constructor, ok := r.constructors[name]
+	r.mu.RUnlock()
+	if !ok {
+		return nil, fmt.Errorf("%w: %q", ErrUnknownStrategy, name)
+	}
+
+	strategy, err := constructor(options)
+	if err != nil {
+		return nil, fmt.Errorf("creating payment strategy %q: %w", name, err)
+	}
+	return strategy, nil
+}
+
+// FromConfig creates the strategy selected by cfg
+func (r *StrategyRegistry) FromConfig(cfg PaymentConfig) (PaymentStrategy, error) {
+	return r.New(cfg.Method, cfg.Options)
+}
+
+// Names returns the registered strategy names in sorted order
+func (r *StrategyRegistry) Names() []string {
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+	names := make([]string, 0, len(r.constructors))
+	for name := range r.constructors {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+	return names
+}
+
+// requireOptions checks that every key is present and non-empty
+func requireOptions(options map[string]string, keys ...string) error {
+	for _, key := range keys {
+		if options[key] == "" {
+			return fmt.Errorf("missing option %q", key)
+		}
+	}
+	return nil
+}
@@ -0,0 +1,187 @@
+//go:build stress
+
+// Stress tests for the subjects and brokers that claim to be safe for
+// concurrent use. Run them under the race detector:
+//
+//	go test -race -tags stress ./04-design-patterns/...
+package behavioral
+
+import (
+	"context"
+	"sync"
+	"sync/atomic"
+	"testing"
+)
+
+const (
+	// publishers and subscribers are how many goroutines hammer each subject
+	publishers  = 200
+	subscribers = 200
+	// perPublisher is how many events each publisher sends
+	perPublisher = 50
+)
+
+// hammer runs fn from n goroutines that all start together and waits for them
+func hammer(n int, fn func(i int)) {
+	start := make(chan struct{})
+	var wg sync.WaitGroup
+	for i := range n {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			<-start
+			fn(i)
+		}()
+	}
+	close(start)
+	wg.Wait()
+}
+
+// countingObserver counts its updates
+type countingObserver struct {
+	updates atomic.Int64
+}
+
+func (o *countingObserver) Update(float64) { o.updates.Add(1) }
+
+// Observers registered before the storm see every update, while others
+// register and leave in the middle of it
+func TestWeatherStationStress(t *testing.T) {
+	station := NewWeatherStation()
+	stable := make([]*countingObserver, 10)
+	for i := range stable {
+		stable[i] = &countingObserver{}
+		station.RegisterObserver(stable[i])
+	}
+
+	hammer(publishers+subscribers, func(i int) {
+		if i < publishers {
+			for j := range perPublisher {
+				station.SetTemperature(float64(j))
+			}
+			return
+		}
+		o := &countingObserver{}
+		token := station.RegisterObserver(o)
+		station.NotifyObservers()
+		station.RemoveObserver(token)
+	})
+
+	for i, o := range stable {
+		if got, min := o.updates.Load(), int64(publishers*perPublisher); got < min {
+			t.Errorf("observer %d got %d updates, want at least %d", i, got, min)
+		}
+	}
+}
+
+func TestEventBusStress(t *testing.T) {
+	for _, mode := range []struct {
+		name string
+		mode DispatchMode
+	}{
+		{"sync", SyncDispatch},
+		{"async", AsyncDispatch},
+	} {
+		t.Run(mode.name, func(t *testing.T) {
+			bus := NewEventBus(mode.mode, 8)
+			ctx := context.Background()
+
+			const stableCount = 10
+			counts := make([]atomic.Int64, stableCount)
+			for i := range counts {
+				bus.Subscribe(ctx, "temperature", func(Event) { counts[i].Add(1) })
+			}
+
+			hammer(publishers+subscribers, func(i int) {
+				if i < publishers {
+					for j := range perPublisher {
+						if err := bus.Publish(ctx, "temperature", j); err != nil {
+							t.Errorf("Publish: %v", err)
+							return
+						}
+					}
+					return
+				}
+				// Churn: subscriptions that come and go, some by cancelling
+				// their context
+				subCtx, cancel := context.WithCancel(ctx)
+				sub := bus.Subscribe(subCtx, "temperature", func(Event) {})
+				_ = bus.SubscriberCount("temperature")
+				if i%2 == 0 {
+					cancel()
+				} else {
+					sub.Unsubscribe()
+					cancel()
+				}
+			})
+			// Close waits for the async subscribers to handle their queues
+			bus.Close()
+
+			for i := range counts {
+				if got, want := counts[i].Load(), int64(publishers*perPublisher); got != want {
+					t.Errorf("subscriber %d handled %d events, want %d", i, got, want)
+				}
+			}
+			if err := bus.Publish(ctx, "temperature", 0); err != ErrBusClosed {
+				t.Errorf("Publish after Close = %v, want ErrBusClosed", err)
+			}
+		})
+	}
+}
+
+// Blocking subscribers receive every message; dropping ones account for
+// every message they did not receive
+func TestBrokerStress(t *testing.T) {
+	broker := NewBroker()
+	ctx := context.Background()
+
+	policies := []OverflowPolicy{Block, DropNewest, DropOldest}
+	subs := make([]*Subscriber, 3*len(policies))
+	received := make([]atomic.Int64, len(subs))
+	var readers sync.WaitGroup
+	for i := range subs {
+		s, err := broker.Subscribe("orders", 4, policies[i%len(policies)])
+		if err != nil {
+			t.Fatal(err)
+		}
+		subs[i] = s
+		readers.Add(1)
+		go func() {
+			defer readers.Done()
+			for range s.Messages() {
+				received[i].Add(1)
+			}
+		}()
+	}
+
+	hammer(publishers+subscribers, func(i int) {
+		if i < publishers {
+			for j := range perPublisher {
+				if err := broker.Publish(ctx, "orders", j); err != nil {
+					t.Errorf("Publish: %v", err)
+					return
+				}
+			}
+			return
+		}
+		s, err := broker.Subscribe("orders", 1, policies[i%len(policies)])
+		if err != nil {
+			t.Errorf("Subscribe: %v", err)
+			return
+		}
+		s.Unsubscribe()
+	})
+	broker.Close()
+	readers.Wait()
+
+	want := int64(publishers * perPublisher)
+	for i, s := range subs {
+		got := received[i].Load()
+		if s.policy == Block && got != want {
+			t.Errorf("blocking subscriber %d received %d messages, want %d", i, got, want)
+		}
+		if got+s.Dropped() != want {
+			t.Errorf("subscriber %d received %d and dropped %d messages, want %d in total", i, got, s.Dropped(), want)
+		}
+	}
+}
@@ -0,0 +1,160 @@
+// Actor Pattern gives every piece of mutable state its own goroutine.
+// Other goroutines never touch the state directly: they send messages to the
+// actor's mailbox (a channel) and the actor handles them one at a time, so no
+// locks are needed. When handling a message panics, a supervisor restarts the
+// actor with fresh state instead of letting the whole program crash
+// ("let it crash", as popularized by Erlang).
+//
+// Use cases:
+// - Per-entity state such as bank accounts, game players or chat rooms
+// - Serializing access to a resource without mutexes
+// - Isolating failures so one bad message doesn't take down the system
+
+package concurrency
+
+import (
+	"errors"
+	"sync"
+	"sync/atomic"
+)
+
+// ErrActorStopped is returned when sending to an actor that is no longer running
+var ErrActorStopped = errors.New("actor stopped")
+
+// Receiver handles an actor's messages and owns its state
+// Receive is only ever called from the actor's goroutine
+type Receiver[M any] interface {
+	Receive(msg M)
+}
+
+// ReceiverFunc adapts a function to the Receiver interface
+type ReceiverFunc[M any] func(msg M)
+
+// Receive calls f(msg)
+func (f ReceiverFunc[M]) Receive(msg M) { f(msg) }
+
+// Supervision decides what happens when Receive panics
+type Supervision struct {
+	// MaxRestarts is how many times the actor may be restarted;
+	// one more failure stops it for good
+	MaxRestarts int
+	// OnFailure is called with the panic value after every failure;
+	// restarted is false when the actor gave up and stopped
+	OnFailure func(actor string, reason any, restarted bool)
+}
+
+// Actor is a goroutine processing messages of type M from its mailbox
+type Actor[M any] struct {
+	name        string
+	mailbox     chan M
+	newReceiver func() Receiver[M]
+	supervision Supervision
+	restarts    atomic.Int32
+
+	quit     chan struct{} // closed to ask the actor to stop
+	stopped  chan struct{} // closed when the goroutine has exited
+	stopOnce sync.Once
+}
+
+// Spawn starts an actor with a mailbox of the given capacity
+// newReceiver is called at start and again after every restart, so each
+// restart begins with fresh state (loaded from wherever the receiver keeps it)
+func Spawn[M any](name string, mailboxSize int, supervision Supervision, newReceiver func() Receiver[M]) *Actor[M] {
+	a := &Actor[M]{
+		name:        name,
+		mailbox:     make(chan M, mailboxSize),
+		newReceiver: newReceiver,
+		supervision: supervision,
+		quit:        make(chan struct{}),
+		stopped:     make(chan struct{}),
+	}
+	go a.run()
+	return a
+}
+
+// Name returns the actor's name
+func (a *Actor[M]) Name() string {
+	return a.name
+}
+
+// Restarts returns how many times the supervisor restarted the actor
+func (a *Actor[M]) Restarts() int {
+	return int(a.restarts.Load())
+}
+
+// Send puts msg in the actor's mailbox, waiting while the mailbox is full
+func (a *Actor[M]) Send(msg M) error {
+	select {
+	case <-a.stopped:
+		return ErrActorStopped
+	case <-a.quit:
+		return ErrActorStopped
+	default:
+	}
+	select {
+	case a.mailbox <- msg:
+		return nil
+	case <-a.stopped:
+		return ErrActorStopped
+	case <-a.quit:
+		return ErrActorStopped
+	}
+}
+
+// Stop asks the actor to finish the messages already in its mailbox,
+// then waits for it to exit
+func (a *Actor[M]) Stop() {
+	a.stopOnce.Do(func() { close(a.quit) })
+	<-a.stopped
+}
+
+// Done returns a channel that is closed when the actor has stopped
+func (a *Actor[M]) Done() <-chan struct{} {
+	return a.stopped
+}
+
+// run is the actor's goroutine: it restarts the receive loop after every
+// failure until the restart budget is used up
+func (a *Actor[M]) run() {
+	defer close(a.stopped)
+	for {
+		reason, failed := a.receiveLoop(a.newReceiver())
+		if !failed {
+			return
+		}
+		restart := a.Restarts() < a.supervision.MaxRestarts
+		if a.supervision.OnFailure != nil {
+			a.supervision.OnFailure(a.name, reason, restart)
+		}
+		if !restart {
+			return
+		}
+		a.restarts.Add(1)
+	}
+}
+
+// receiveLoop handles messages until Stop is called or Receive panics
+// The message that caused the panic is dropped
+func (a *Actor[M]) receiveLoop(r Receiver[M]) (reason any, failed bool) {
+	defer func() {
+		if p := recover(); p != nil {
+			reason, failed = p, true
+		}
+	}()
+	for {
+		select {
+		case msg := <-a.mailbox:
+			r.Receive(msg)
+		case <-a.quit:
+			// Drain what was already queued before stopping
+			for {
+				select {
+				case msg := <-a.mailbox:
+					r.Receive(msg)
+				default:
+					return nil, false
+				}
+			}
+		}
+	}
+}
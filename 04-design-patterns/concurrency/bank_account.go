@@ -0,0 +1,182 @@
+package concurrency
+
+import (
+	"errors"
+	"fmt"
+	"sync"
+)
+
+// ErrInsufficientFunds is returned when a withdrawal exceeds the balance
+var ErrInsufficientFunds = errors.New("insufficient funds")
+
+// Ledger is durable storage for account balances
+// It stands in for a database: it survives actor restarts
+type Ledger struct {
+	mu       sync.Mutex
+	balances map[string]int
+}
+
+// NewLedger creates an empty ledger
+func NewLedger() *Ledger {
+	return &Ledger{balances: make(map[string]int)}
+}
+
+func (l *Ledger) load(account string) int {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+	return l.balances[account]
+}
+
+func (l *Ledger) save(account string, balance int) {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+	l.balances[account] = balance
+}
+
+// AccountMessage is a message understood by a bank account actor
+// Only the message types in this file implement it
+type AccountMessage interface {
+	accountMessage()
+}
+
+// Deposit adds Amount to the balance
+type Deposit struct {
+	Amount int
+	Reply  chan error
+}
+
+// Withdraw removes Amount from the balance if there is enough money
+type Withdraw struct {
+	Amount int
+	Reply  chan error
+}
+
+// GetBalance asks for the current balance
+type GetBalance struct {
+	Reply chan int
+}
+
+// Crash simulates a bug in the actor so supervision can be demonstrated
+type Crash struct{}
+
+func (Deposit) accountMessage()    {}
+func (Withdraw) accountMessage()   {}
+func (GetBalance) accountMessage() {}
+func (Crash) accountMessage()      {}
+
+// accountState is the actor's private state
+type accountState struct {
+	name    string
+	balance int
+	ledger  *Ledger
+}
+
+// Receive handles one message; no locking is needed because only
+// the actor's goroutine ever calls it
+func (s *accountState) Receive(msg AccountMessage) {
+	switch m := msg.(type) {
+	case Deposit:
+		if m.Amount <= 0 {
+			m.Reply <- fmt.Errorf("invalid deposit amount %d", m.Amount)
+			return
+		}
+		s.balance += m.Amount
+		s.ledger.save(s.name, s.balance)
+		m.Reply <- nil
+	case Withdraw:
+		if m.Amount <= 0 {
+			m.Reply <- fmt.Errorf("invalid withdrawal amount %d", m.Amount)
+			return
+		}
+		if m.Amount > s.balance {
+			m.Reply <- fmt.Errorf("%w: balance %d, requested %d", ErrInsufficientFunds, s.balance, m.Amount)
+			return
+		}
+		s.balance -= m.Amount
+		s.ledger.save(s.name, s.balance)
+		m.Reply <- nil
+	case GetBalance:
+		m.Reply <- s.balance
+	case Crash:
+		panic("account " + s.name + " crashed")
+	}
+}
+
+// BankAccount is a typed client for a bank account actor
+// Its methods send a message and wait for the actor's reply
+type BankAccount struct {
+	actor *Actor[AccountMessage]
+}
+
+// NewBankAccount spawns the actor for account
+// After a crash the actor is restarted and reloads its balance from the ledger
+func NewBankAccount(account string, ledger *Ledger, supervision Supervision) *BankAccount {
+	actor := Spawn(account, 16, supervision, func() Receiver[AccountMessage] {
+		return &accountState{name: account, balance: ledger.load(account), ledger: ledger}
+	})
+	return &BankAccount{actor: actor}
+}
+
+// Deposit adds amount to the account
+func (b *BankAccount) Deposit(amount int) error {
+	reply := make(chan error, 1)
+	if err := b.actor.Send(Deposit{Amount: amount, Reply: reply}); err != nil {
+		return err
+	}
+	return b.await(reply)
+}
+
+// Withdraw removes amount from the account
+func (b *BankAccount) Withdraw(amount int) error {
+	reply := make(chan error, 1)
+	if err := b.actor.Send(Withdraw{Amount: amount, Reply: reply}); err != nil {
+		return err
+	}
+	return b.await(reply)
+}
+
+// Balance returns the current balance
+func (b *BankAccount) Balance() (int, error) {
+	reply := make(chan int, 1)
+	if err := b.actor.Send(GetBalance{Reply: reply}); err != nil {
+		return 0, err
+	}
+	select {
+	case balance := <-reply:
+		return balance, nil
+	case <-b.actor.Done():
+		// The actor may have replied just before stopping
+		select {
+		case balance := <-reply:
+			return balance, nil
+		default:
+			return 0, ErrActorStopped
+		}
+	}
+}
+
+// Crash sends a message that makes the actor panic
+func (b *BankAccount) Crash() error {
+	return b.actor.Send(Crash{})
+}
+
+// Stop stops the actor after it handles the queued messages
+func (b *BankAccount) Stop() {
+	b.actor.Stop()
+}
+
+// await waits for a reply, or fails if the actor stops before answering
+func (b *BankAccount) await(reply chan error) error {
+	select {
+	case err := <-reply:
+		return err
+	case <-b.actor.Done():
+		// The actor may have replied just before stopping
+		select {
+		case err := <-reply:
+			return err
+		default:
+			return ErrActorStopped
+		}
+	}
+}
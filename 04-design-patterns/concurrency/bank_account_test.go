@@ -0,0 +1,31 @@
+package concurrency
+
+import (
+	"errors"
+	"testing"
+)
+
+func TestBankAccountAmounts(t *testing.T) {
+	account := NewBankAccount("alice", NewLedger(), Supervision{})
+	defer account.Stop()
+	if err := account.Deposit(100); err != nil {
+		t.Fatal(err)
+	}
+	for _, amount := range []int{0, -50} {
+		if err := account.Deposit(amount); err == nil {
+			t.Errorf("Deposit(%d) succeeded", amount)
+		}
+		if err := account.Withdraw(amount); err == nil {
+			t.Errorf("Withdraw(%d) succeeded", amount)
+		}
+	}
+	if err := account.Withdraw(150); !errors.Is(err, ErrInsufficientFunds) {
+		t.Errorf("Withdraw(150) = %v, want ErrInsufficientFunds", err)
+	}
+	if err := account.Withdraw(30); err != nil {
+		t.Errorf("Withdraw(30) = %v", err)
+	}
+	if balance, err := account.Balance(); balance != 70 || err != nil {
+		t.Errorf("Balance() = %d, %v, want 70", balance, err)
+	}
+}
@@ -0,0 +1,123 @@
+// Future/Promise Pattern represents a value that will be available later.
+// Starting a computation returns a Future immediately; the caller keeps working
+// and only blocks when it actually needs the result. A Promise is the writing
+// side: whoever holds it completes the Future exactly once.
+//
+// Use cases:
+// - Starting several independent remote calls and waiting for all of them
+// - Handing a result from a callback-based API to a caller that wants to block
+// - Bounding how long a caller waits for a slow computation
+
+package concurrency
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"sync"
+	"time"
+)
+
+// ErrTimeout is returned by GetWithTimeout when the value isn't ready in time
+var ErrTimeout = errors.New("future: timed out waiting for result")
+
+// Future holds the result of an asynchronous computation
+type Future[T any] struct {
+	done  chan struct{}
+	value T
+	err   error
+}
+
+// Promise completes a Future
+type Promise[T any] struct {
+	future *Future[T]
+	once   sync.Once
+}
+
+// NewPromise creates a promise and its pending future
+func NewPromise[T any]() *Promise[T] {
+	return &Promise[T]{future: &Future[T]{done: make(chan struct{})}}
+}
+
+// Future returns the read side of the promise
+func (p *Promise[T]) Future() *Future[T] {
+	return p.future
+}
+
+// Complete sets the result and wakes up every waiter
+// Only the first call has an effect; it reports whether this call completed the future
+func (p *Promise[T]) Complete(value T, err error) bool {
+	completed := false
+	p.once.Do(func() {
+		p.future.value, p.future.err = value, err
+		close(p.future.done)
+		completed = true
+	})
+	return completed
+}
+
+// Async runs fn in a new goroutine and returns a future for its result
+// A panic in fn is turned into an error instead of crashing the program
+func Async[T any](ctx context.Context, fn func(ctx context.Context) (T, error)) *Future[T] {
+	p := NewPromise[T]()
+	go func() {
+		defer func() {
+			if r := recover(); r != nil {
+				var zero T
+				p.Complete(zero, fmt.Errorf("future: task panicked: %v", r))
+			}
+		}()
+		p.Complete(fn(ctx))
+	}()
+	return p.Future()
+}
+
+// Done returns a channel that is closed when the result is ready
+func (f *Future[T]) Done() <-chan struct{} {
+	return f.done
+}
+
+// Get blocks until the result is ready
+func (f *Future[T]) Get() (T, error) {
+	<-f.done
+	return f.value, f.err
+}
+
+// GetContext blocks until the result is ready or ctx is done
+func (f *Future[T]) GetContext(ctx context.Context) (T, error) {
+	select {
+	case <-f.done:
+		return f.value, f.err
+	case <-ctx.Done():
+		var zero T
+		return zero, ctx.Err()
+	}
+}
+
+// GetWithTimeout waits at most d for the result
+// The computation keeps running after a timeout; a later Get still sees its result
+func (f *Future[T]) GetWithTimeout(d time.Duration) (T, error) {
+	timer := time.NewTimer(d)
+	defer timer.Stop()
+	select {
+	case <-f.done:
+		return f.value, f.err
+	case <-timer.C:
+		var zero T
+		return zero, ErrTimeout
+	}
+}
+
+// AwaitAll waits for every future and returns their values in order
+// It returns the first error encountered (in argument order)
+func AwaitAll[T any](ctx context.Context, futures ...*Future[T]) ([]T, error) {
+	values := make([]T, len(futures))
+	for i, f := range futures {
+		v, err := f.GetContext(ctx)
+		if err != nil {
+			return nil, err
+		}
+		values[i] = v
+	}
+	return values, nil
+}
@@ -0,0 +1,79 @@
+package concurrency
+
+import (
+	"context"
+	"errors"
+	"strings"
+	"testing"
+	"time"
+)
+
+func TestPromiseCompletesOnce(t *testing.T) {
+	p := NewPromise[int]()
+	f := p.Future()
+	select {
+	case <-f.Done():
+		t.Fatal("future done before Complete")
+	default:
+	}
+	if !p.Complete(1, nil) {
+		t.Error("first Complete = false")
+	}
+	if p.Complete(2, errors.New("late")) {
+		t.Error("second Complete = true")
+	}
+	if v, err := f.Get(); v != 1 || err != nil {
+		t.Errorf("Get() = %d, %v, want 1", v, err)
+	}
+}
+
+func TestAsync(t *testing.T) {
+	f := Async(context.Background(), func(context.Context) (string, error) { return "done", nil })
+	if v, err := f.Get(); v != "done" || err != nil {
+		t.Errorf("Get() = %q, %v", v, err)
+	}
+
+	f = Async(context.Background(), func(context.Context) (string, error) { panic("boom") })
+	if _, err := f.Get(); err == nil || !strings.Contains(err.Error(), "boom") {
+		t.Errorf("Get() of a panicking task = %v", err)
+	}
+}
+
+func TestFutureWaiting(t *testing.T) {
+	p := NewPromise[int]()
+	f := p.Future()
+	if _, err := f.GetWithTimeout(time.Millisecond); err != ErrTimeout {
+		t.Errorf("GetWithTimeout = %v, want ErrTimeout", err)
+	}
+	ctx, cancel := context.WithCancel(context.Background())
+	cancel()
+	if _, err := f.GetContext(ctx); err != context.Canceled {
+		t.Errorf("GetContext = %v, want Canceled", err)
+	}
+	// A timeout doesn't stop the computation from completing later
+	p.Complete(3, nil)
+	if v, err := f.GetWithTimeout(time.Second); v != 3 || err != nil {
+		t.Errorf("GetWithTimeout after Complete = %d, %v", v, err)
+	}
+}
+
+func TestAwaitAll(t *testing.T) {
+	ctx := context.Background()
+	futures := make([]*Future[int], 3)
+	for i := range futures {
+		futures[i] = Async(ctx, func(context.Context) (int, error) {
+			time.Sleep(time.Duration(3-i) * time.Millisecond)
+			return i * i, nil
+		})
+	}
+	values, err := AwaitAll(ctx, futures...)
+	if err != nil || len(values) != 3 || values[0] != 0 || values[1] != 1 || values[2] != 4 {
+		t.Errorf("AwaitAll = %v, %v, want [0 1 4]", values, err)
+	}
+
+	boom := errors.New("boom")
+	failed := Async(ctx, func(context.Context) (int, error) { return 0, boom })
+	if _, err := AwaitAll(ctx, futures[0], failed); err != boom {
+		t.Errorf("AwaitAll with a failure = %v, want boom", err)
+	}
+}
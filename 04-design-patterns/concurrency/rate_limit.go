@@ -0,0 +1,147 @@
+// Rate Limiting Pattern controls how often work may start.
+// A token bucket holds up to burst tokens and refills at a steady rate; every
+// task spends one token, so short bursts are allowed but the long-run rate is
+// capped. Combined with a semaphore, the executor below limits both how fast
+// tasks start and how many run at once.
+//
+// Use cases:
+// - Respecting API quotas ("at most 10 requests per second")
+// - Crawlers that must be polite to the servers they visit
+// - Smoothing bursts of background jobs
+
+package concurrency
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"sync"
+	"time"
+
+	"github.com/NutProhmpiriya/go-basic/04-design-patterns/resilience"
+)
+
+// TokenBucket is a rate limiter allowing rate events per second with bursts up to burst
+type TokenBucket struct {
+	mu     sync.Mutex
+	rate   float64
+	burst  float64
+	tokens float64
+	last   time.Time
+	clock  resilience.Clock
+}
+
+// NewTokenBucket creates a full bucket
+// clock may be nil to use the real time. It panics unless rate is above 0
+// and burst at least 1: such a bucket would never refill, or never hold a
+// whole token, and Wait would block forever
+func NewTokenBucket(rate float64, burst int, clock resilience.Clock) *TokenBucket {
+	if !(rate > 0) || burst < 1 {
+		panic(fmt.Sprintf("concurrency: token bucket rate %v and burst %d, need a rate above 0 and a burst of at least 1", rate, burst))
+	}
+	if clock == nil {
+		clock = resilience.RealClock{}
+	}
+	return &TokenBucket{
+		rate:   rate,
+		burst:  float64(burst),
+		tokens: float64(burst),
+		last:   clock.Now(),
+		clock:  clock,
+	}
+}
+
+// refill adds the tokens earned since the last call
+// The caller must hold b.mu
+func (b *TokenBucket) refill() {
+	now := b.clock.Now()
+	elapsed := now.Sub(b.last).Seconds()
+	b.last = now
+	b.tokens = min(b.burst, b.tokens+elapsed*b.rate)
+}
+
+// Allow takes a token if one is available, without waiting
+func (b *TokenBucket) Allow() bool {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+	b.refill()
+	if b.tokens >= 1 {
+		b.tokens--
+		return true
+	}
+	return false
+}
+
+// Wait blocks until a token is available or ctx is done
+func (b *TokenBucket) Wait(ctx context.Context) error {
+	for {
+		b.mu.Lock()
+		b.refill()
+		if b.tokens >= 1 {
+			b.tokens--
+			b.mu.Unlock()
+			return nil
+		}
+		// Time until the bucket holds one whole token
+		wait := time.Duration((1 - b.tokens) / b.rate * float64(time.Second))
+		b.mu.Unlock()
+
+		select {
+		case <-ctx.Done():
+			return ctx.Err()
+		case <-b.clock.After(wait):
+			// Another goroutine may take the token first, so check again
+		}
+	}
+}
+
+// Task is a unit of work run by an executor
+type Task func(ctx context.Context) error
+
+// RateLimitedExecutor runs tasks concurrently while limiting both
+// the start rate (token bucket) and the number of running tasks (semaphore)
+type RateLimitedExecutor struct {
+	limiter *TokenBucket
+	slots   *Weighted
+	wg      sync.WaitGroup
+	mu      sync.Mutex
+	errs    []error
+}
+
+// NewRateLimitedExecutor creates an executor running at most maxConcurrent tasks
+// at once and starting them no faster than limiter allows
+func NewRateLimitedExecutor(maxConcurrent int64, limiter *TokenBucket) *RateLimitedExecutor {
+	return &RateLimitedExecutor{limiter: limiter, slots: NewWeighted(maxConcurrent)}
+}
+
+// Submit waits for a token and a free slot, then runs task in a new goroutine
+// It returns an error without running the task if ctx is done while waiting
+func (e *RateLimitedExecutor) Submit(ctx context.Context, task Task) error {
+	if err := e.limiter.Wait(ctx); err != nil {
+		return err
+	}
+	if err := e.slots.Acquire(ctx, 1); err != nil {
+		return err
+	}
+
+	e.wg.Add(1)
+	go func() {
+		defer e.wg.Done()
+		defer e.slots.Release(1)
+		if err := task(ctx); err != nil {
+			e.mu.Lock()
+			e.errs = append(e.errs, err)
+			e.mu.Unlock()
+		}
+	}()
+	return nil
+}
+
+// Wait blocks until every submitted task has finished
+// and returns their errors joined together (nil if all succeeded)
+func (e *RateLimitedExecutor) Wait() error {
+	e.wg.Wait()
+	e.mu.Lock()
+	defer e.mu.Unlock()
+	return errors.Join(e.errs...)
+}
@@ -0,0 +1,52 @@
+package concurrency
+
+import (
+	"context"
+	"math"
+	"slices"
+	"testing"
+	"time"
+
+	"github.com/NutProhmpiriya/go-basic/04-design-patterns/resilience"
+)
+
+func TestNewTokenBucketPanics(t *testing.T) {
+	for _, tt := range []struct {
+		rate  float64
+		burst int
+	}{
+		{0, 1},
+		{-1, 1},
+		{math.NaN(), 1},
+		{1, 0},
+		{1, -1},
+	} {
+		func() {
+			defer func() {
+				if recover() == nil {
+					t.Errorf("NewTokenBucket(%v, %d) did not panic", tt.rate, tt.burst)
+				}
+			}()
+			NewTokenBucket(tt.rate, tt.burst, nil)
+		}()
+	}
+}
+
+func TestTokenBucket(t *testing.T) {
+	clock := resilience.NewFakeClock(time.Time{})
+	b := NewTokenBucket(4, 2, clock)
+	// A full bucket allows a burst, then makes callers wait a quarter
+	// second per token
+	if !b.Allow() || !b.Allow() || b.Allow() {
+		t.Fatal("a bucket of 2 did not allow exactly 2 events at once")
+	}
+	for range 2 {
+		if err := b.Wait(context.Background()); err != nil {
+			t.Fatal(err)
+		}
+	}
+	want := []time.Duration{250 * time.Millisecond, 250 * time.Millisecond}
+	if got := clock.Waits(); !slices.Equal(got, want) {
+		t.Errorf("waited %v, want %v", got, want)
+	}
+}
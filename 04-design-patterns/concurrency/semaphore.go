@@ -0,0 +1,123 @@
+// Semaphore Pattern limits how many goroutines use a resource at the same time.
+// A buffered channel works as a simple semaphore when every user needs one slot;
+// a weighted semaphore lets each caller take several units at once, e.g. a large
+// download can reserve more of the bandwidth budget than a small one.
+//
+// Use cases:
+// - Limiting concurrent downloads or database connections
+// - Bounding memory use by weighting work by its size
+// - Protecting a downstream service from too many parallel calls
+
+package concurrency
+
+import (
+	"container/list"
+	"context"
+	"fmt"
+	"sync"
+)
+
+// waiter is a goroutine blocked in Acquire
+type waiter struct {
+	n     int64
+	ready chan struct{} // closed when the units have been granted
+}
+
+// Weighted is a semaphore with a fixed capacity of units
+// Waiters are served in FIFO order, so a large request is not starved
+// by a stream of small ones
+type Weighted struct {
+	size    int64
+	cur     int64
+	mu      sync.Mutex
+	waiters list.List
+}
+
+// NewWeighted creates a semaphore with the given capacity
+func NewWeighted(size int64) *Weighted {
+	return &Weighted{size: size}
+}
+
+// Acquire takes n units, blocking until they are available or ctx is done
+// On failure it returns ctx.Err() and leaves the semaphore unchanged
+func (s *Weighted) Acquire(ctx context.Context, n int64) error {
+	s.mu.Lock()
+	if n > s.size {
+		s.mu.Unlock()
+		return fmt.Errorf("semaphore: acquire %d exceeds capacity %d", n, s.size)
+	}
+	// Fast path: enough room and nobody queued ahead of us
+	if s.size-s.cur >= n && s.waiters.Len() == 0 {
+		s.cur += n
+		s.mu.Unlock()
+		return nil
+	}
+
+	w := waiter{n: n, ready: make(chan struct{})}
+	elem := s.waiters.PushBack(w)
+	s.mu.Unlock()
+
+	select {
+	case <-w.ready:
+		return nil
+	case <-ctx.Done():
+		s.mu.Lock()
+		defer s.mu.Unlock()
+		select {
+		case <-w.ready:
+			// Granted just as ctx was cancelled: give the units back
+			s.cur -= n
+			s.notifyWaiters()
+		default:
+			isFront := s.waiters.Front() == elem
+			s.waiters.Remove(elem)
+			// If we were blocking the queue, the waiters behind us may fit now
+			if isFront && s.size > s.cur {
+				s.notifyWaiters()
+			}
+		}
+		return ctx.Err()
+	}
+}
+
+// TryAcquire takes n units without blocking and reports whether it succeeded
+func (s *Weighted) TryAcquire(n int64) bool {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	if s.size-s.cur >= n && s.waiters.Len() == 0 {
+		s.cur += n
+		return true
+	}
+	return false
+}
+
+// Release returns n units to the semaphore
+// Releasing more than was acquired is a programming error and panics
+func (s *Weighted) Release(n int64) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.cur -= n
+	if s.cur < 0 {
+		panic("semaphore: released more than held")
+	}
+	s.notifyWaiters()
+}
+
+// notifyWaiters grants units to queued waiters in order while they fit
+// The caller must hold s.mu
+func (s *Weighted) notifyWaiters() {
+	for {
+		front := s.waiters.Front()
+		if front == nil {
+			return
+		}
+		w := front.Value.(waiter)
+		if s.size-s.cur < w.n {
+			// Stop at the first waiter that doesn't fit to keep FIFO order
+			return
+		}
+		s.cur += w.n
+		s.waiters.Remove(front)
+		close(w.ready)
+	}
+}
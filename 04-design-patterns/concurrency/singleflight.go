@@ -0,0 +1,84 @@
+// Single-flight Pattern collapses concurrent requests for the same key into one call.
+// When many goroutines ask for the same missing cache entry at once, only the
+// first one does the expensive work; the rest wait and share its result instead
+// of stampeding the database (the "thundering herd" / cache stampede problem).
+// This is a generic version of golang.org/x/sync/singleflight.
+//
+// Use cases:
+// - Filling a cache on a miss
+// - Deduplicating identical in-flight API or database requests
+// - Loading configuration or credentials that many goroutines need at once
+
+package concurrency
+
+import (
+	"errors"
+	"sync"
+)
+
+// ErrCallPanicked is returned to the callers waiting on a call that panicked
+// The caller that made the call panics as usual
+var ErrCallPanicked = errors.New("singleflight: call panicked")
+
+// flight is an in-progress or completed call
+type flight[V any] struct {
+	wg     sync.WaitGroup
+	value  V
+	err    error
+	shared int
+}
+
+// Group deduplicates calls by key
+// The zero value is ready to use
+type Group[K comparable, V any] struct {
+	mu      sync.Mutex
+	flights map[K]*flight[V]
+}
+
+// Do runs fn for key unless a call for key is already in flight,
+// in which case it waits for that call and returns its result
+// shared reports whether the result was given to more than one caller
+func (g *Group[K, V]) Do(key K, fn func() (V, error)) (value V, err error, shared bool) {
+	g.mu.Lock()
+	if g.flights == nil {
+		g.flights = make(map[K]*flight[V])
+	}
+	if f, ok := g.flights[key]; ok {
+		f.shared++
+		g.mu.Unlock()
+		f.wg.Wait()
+		return f.value, f.err, true
+	}
+
+	f := &flight[V]{}
+	f.wg.Add(1)
+	g.flights[key] = f
+	g.mu.Unlock()
+
+	// Finish the flight even if fn panics, so waiters are not stuck forever,
+	// and tell them it failed rather than hand them a zero value
+	returned := false
+	defer func() {
+		if !returned {
+			f.err = ErrCallPanicked
+		}
+		g.mu.Lock()
+		if g.flights[key] == f {
+			delete(g.flights, key)
+		}
+		shared = f.shared > 0
+		g.mu.Unlock()
+		f.wg.Done()
+	}()
+	f.value, f.err = fn()
+	returned = true
+	return f.value, f.err, false
+}
+
+// Forget makes the next Do for key start a new call
+// instead of joining the one in flight
+func (g *Group[K, V]) Forget(key K) {
+	g.mu.Lock()
+	defer g.mu.Unlock()
+	delete(g.flights, key)
+}
@@ -0,0 +1,121 @@
+package concurrency
+
+import (
+	"errors"
+	"sync"
+	"sync/atomic"
+	"testing"
+	"time"
+)
+
+// join starts n callers of g.Do for key and waits until all but the first
+// are waiting on its call, which blocks until release is closed
+func join(g *Group[string, int], key string, n int, calls *atomic.Int32, release chan struct{}, fn func() (int, error)) []chan result {
+	started := make(chan struct{})
+	results := make([]chan result, n)
+	for i := range results {
+		results[i] = make(chan result, 1)
+		go func() {
+			defer func() { recover() }() // the caller of a panicking fn panics
+			v, err, shared := g.Do(key, func() (int, error) {
+				calls.Add(1)
+				close(started)
+				<-release
+				return fn()
+			})
+			results[i] <- result{v, err, shared}
+		}()
+		if i == 0 {
+			<-started
+		}
+	}
+	// Wait until the others have joined the call in flight
+	for {
+		g.mu.Lock()
+		shared := g.flights[key].shared
+		g.mu.Unlock()
+		if shared == n-1 {
+			return results
+		}
+		time.Sleep(time.Millisecond)
+	}
+}
+
+type result struct {
+	v      int
+	err    error
+	shared bool
+}
+
+func TestGroupOneCall(t *testing.T) {
+	var g Group[string, int]
+	var calls atomic.Int32
+	release := make(chan struct{})
+	results := join(&g, "k", 10, &calls, release, func() (int, error) { return 42, nil })
+	close(release)
+	for i, ch := range results {
+		if r := <-ch; r.v != 42 || r.err != nil || !r.shared {
+			t.Errorf("caller %d got %+v, want 42 shared", i, r)
+		}
+	}
+	if n := calls.Load(); n != 1 {
+		t.Errorf("fn ran %d times, want 1", n)
+	}
+
+	// Once the call is over, the next Do starts a new one
+	v, _, shared := g.Do("k", func() (int, error) { return 7, nil })
+	if v != 7 || shared {
+		t.Errorf("Do after the flight = %d, shared %v", v, shared)
+	}
+}
+
+func TestGroupError(t *testing.T) {
+	var g Group[string, int]
+	boom := errors.New("boom")
+	if _, err, _ := g.Do("k", func() (int, error) { return 0, boom }); err != boom {
+		t.Errorf("err = %v, want boom", err)
+	}
+}
+
+func TestGroupPanic(t *testing.T) {
+	var g Group[string, int]
+	var calls atomic.Int32
+	release := make(chan struct{})
+	results := join(&g, "k", 5, &calls, release, func() (int, error) { panic("boom") })
+	close(release)
+	// The first caller panicked and sent nothing; the waiters see the failure
+	for _, ch := range results[1:] {
+		if r := <-ch; !errors.Is(r.err, ErrCallPanicked) {
+			t.Errorf("waiter got %+v, want ErrCallPanicked", r)
+		}
+	}
+	if len(results[0]) != 0 {
+		t.Error("the panicking caller returned")
+	}
+}
+
+func TestGroupForget(t *testing.T) {
+	var g Group[string, int]
+	var calls atomic.Int32
+	release := make(chan struct{})
+	first := join(&g, "k", 1, &calls, release, func() (int, error) { return 1, nil })
+	g.Forget("k")
+
+	// With the flight forgotten, a second caller starts its own call
+	var wg sync.WaitGroup
+	wg.Add(1)
+	go func() {
+		defer wg.Done()
+		if v, _, _ := g.Do("k", func() (int, error) { calls.Add(1); return 2, nil }); v != 2 {
+			t.Errorf("Do after Forget = %d, want 2", v)
+		}
+	}()
+	wg.Wait()
+	close(release)
+	if r := <-first[0]; r.v != 1 {
+		t.Errorf("forgotten call = %d, want 1", r.v)
+	}
+	if n := calls.Load(); n != 2 {
+		t.Errorf("fn ran %d times, want 2", n)
+	}
+}
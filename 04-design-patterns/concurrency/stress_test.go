@@ -0,0 +1,116 @@
+//go:build stress
+
+// Stress tests for the synchronization primitives of this package
+// Run them under the race detector:
+//
+//	go test -race -tags stress ./04-design-patterns/...
+package concurrency
+
+import (
+	"context"
+	"sync"
+	"sync/atomic"
+	"testing"
+	"time"
+)
+
+// goroutines is how many goroutines each stress test starts at once
+const goroutines = 500
+
+// hammer runs fn from n goroutines that all start together and waits for them
+func hammer(n int, fn func(i int)) {
+	start := make(chan struct{})
+	var wg sync.WaitGroup
+	for i := range n {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			<-start
+			fn(i)
+		}()
+	}
+	close(start)
+	wg.Wait()
+}
+
+// The units in use never exceed the capacity, and cancelled waiters give
+// back everything they were granted
+func TestWeightedStress(t *testing.T) {
+	const size = 10
+	sem := NewWeighted(size)
+	var inUse, peak atomic.Int64
+
+	hammer(goroutines, func(i int) {
+		n := int64(i%size + 1)
+		ctx := context.Background()
+		if i%7 == 0 {
+			var cancel context.CancelFunc
+			ctx, cancel = context.WithTimeout(ctx, time.Microsecond)
+			defer cancel()
+		}
+		if i%11 == 0 {
+			if !sem.TryAcquire(n) {
+				return
+			}
+		} else if err := sem.Acquire(ctx, n); err != nil {
+			return
+		}
+
+		cur := inUse.Add(n)
+		for {
+			p := peak.Load()
+			if cur <= p || peak.CompareAndSwap(p, cur) {
+				break
+			}
+		}
+		inUse.Add(-n)
+		sem.Release(n)
+	})
+
+	if p := peak.Load(); p > size {
+		t.Errorf("%d units in use at once, capacity %d", p, size)
+	}
+	if !sem.TryAcquire(size) {
+		t.Error("units still held after every goroutine released them")
+	}
+}
+
+// Every caller gets the result of the call it ran or joined, and a result
+// is only reported as shared when more than one caller received it
+func TestGroupStress(t *testing.T) {
+	var g Group[string, int64]
+	var calls atomic.Int64
+
+	type result struct {
+		value  int64
+		shared bool
+	}
+	results := make([]result, goroutines)
+	hammer(goroutines, func(i int) {
+		if i%25 == 0 {
+			g.Forget("key")
+		}
+		v, err, shared := g.Do("key", func() (int64, error) {
+			n := calls.Add(1)
+			time.Sleep(time.Millisecond)
+			return n, nil
+		})
+		if err != nil {
+			t.Errorf("Do: %v", err)
+		}
+		results[i] = result{v, shared}
+	})
+
+	callers := make(map[int64]int)
+	for _, r := range results {
+		callers[r.value]++
+	}
+	if int64(len(callers)) != calls.Load() {
+		t.Errorf("%d calls but %d distinct results", calls.Load(), len(callers))
+	}
+	for i, r := range results {
+		if r.shared != (callers[r.value] > 1) {
+			t.Errorf("caller %d: shared = %v, but %d callers got result %d", i, r.shared, callers[r.value], r.value)
+		}
+	}
+}
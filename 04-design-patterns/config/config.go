@@ -0,0 +1,292 @@
+// Package config loads an application's settings from several sources and
+// keeps them current while it runs. It puts the Builder and the Singleton
+// patterns to work together
+//
+//   - Builder: a Builder collects settings from the defaults, a JSON or YAML
+//     file, environment variables and explicit overrides, and Build
+//     validates all of them at once into a Config
+//   - Singleton: Global is the Store the whole program reads its Config
+//     from, made on first use with creational.LazySingleton. A Store swaps
+//     in a new Config atomically when its file changes, so readers never
+//     see half of an update
+//
+// The sources take precedence in a fixed order, whatever order the Builder
+// was given them in: defaults, then the file, then the environment, then
+// overrides from code. Every setting remembers where it came from; see
+// Config.Origin
+package config
+
+import (
+	"errors"
+	"fmt"
+	"maps"
+	"net"
+	"os"
+	"path/filepath"
+	"slices"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// ErrInvalidConfig is returned by Build for settings that can't be used
+var ErrInvalidConfig = errors.New("invalid config")
+
+// Config is the application's configuration
+// Build hands out copies, so a Config never changes once a caller has it
+type Config struct {
+	Server   ServerConfig
+	Database DatabaseConfig
+	Log      LogConfig
+
+	// origins maps every key to the source its value came from
+	origins map[string]string
+}
+
+// ServerConfig configures the HTTP server
+type ServerConfig struct {
+	Addr        string
+	ReadTimeout time.Duration
+	Debug       bool
+}
+
+// DatabaseConfig configures the database connection
+type DatabaseConfig struct {
+	URL      string
+	MaxConns int
+}
+
+// LogConfig configures logging
+type LogConfig struct {
+	Level  string
+	Format string
+}
+
+// Origin returns where the value of key came from, such as "default",
+// "file app.yaml" or "env APP_SERVER_ADDR"
+func (c Config) Origin(key string) string {
+	return c.origins[key]
+}
+
+// setting is one key of the configuration: its default and how to store a
+// value in a Config
+type setting struct {
+	key string
+	def string
+	set func(c *Config, value string) error
+}
+
+// settings is the schema: every key a source may set
+var settings = []setting{
+	{"server.addr", "localhost:8080", setString(func(c *Config) *string { return &c.Server.Addr })},
+	{"server.read_timeout", "5s", setDuration(func(c *Config) *time.Duration { return &c.Server.ReadTimeout })},
+	{"server.debug", "false", setBool(func(c *Config) *bool { return &c.Server.Debug })},
+	{"database.url", "memory://", setString(func(c *Config) *string { return &c.Database.URL })},
+	{"database.max_conns", "10", setInt(func(c *Config) *int { return &c.Database.MaxConns })},
+	{"log.level", "info", setString(func(c *Config) *string { return &c.Log.Level })},
+	{"log.format", "text", setString(func(c *Config) *string { return &c.Log.Format })},
+}
+
+func setString(field func(*Config) *string) func(*Config, string) error {
+	return func(c *Config, v string) error {
+		*field(c) = v
+		return nil
+	}
+}
+
+func setInt(field func(*Config) *int) func(*Config, string) error {
+	return func(c *Config, v string) error {
+		n, err := strconv.Atoi(v)
+		if err != nil {
+			return fmt.Errorf("%q is not a whole number", v)
+		}
+		*field(c) = n
+		return nil
+	}
+}
+
+func setBool(field func(*Config) *bool) func(*Config, string) error {
+	return func(c *Config, v string) error {
+		b, err := strconv.ParseBool(v)
+		if err != nil {
+			return fmt.Errorf("%q is not true or false", v)
+		}
+		*field(c) = b
+		return nil
+	}
+}
+
+func setDuration(field func(*Config) *time.Duration) func(*Config, string) error {
+	return func(c *Config, v string) error {
+		d, err := time.ParseDuration(v)
+		if err != nil {
+			return fmt.Errorf("%q is not a duration like 5s", v)
+		}
+		*field(c) = d
+		return nil
+	}
+}
+
+// Keys returns every key the configuration has, in schema order
+func Keys() []string {
+	keys := make([]string, len(settings))
+	for i, s := range settings {
+		keys[i] = s.key
+	}
+	return keys
+}
+
+// source is where a value came from; later sources take precedence
+type source int
+
+const (
+	fromDefault source = iota
+	fromFile
+	fromEnv
+	fromOverride
+	sourceCount
+)
+
+// value is a setting's raw text and where it came from
+type value struct {
+	text   string
+	origin string
+}
+
+// Builder collects settings from several sources and builds a Config
+// The methods record problems instead of failing, so Build can report all
+// of them at once
+type Builder struct {
+	layers   [sourceCount]map[string]value
+	problems []string
+}
+
+// NewBuilder returns a builder holding the defaults
+func NewBuilder() *Builder {
+	b := &Builder{}
+	for i := range b.layers {
+		b.layers[i] = make(map[string]value)
+	}
+	for _, s := range settings {
+		b.layers[fromDefault][s.key] = value{s.def, "default"}
+	}
+	return b
+}
+
+// File reads settings from a JSON file (.json) or a YAML file (.yaml, .yml)
+func (b *Builder) File(path string) *Builder {
+	data, err := os.ReadFile(path)
+	if err != nil {
+		b.problems = append(b.problems, err.Error())
+		return b
+	}
+	var values map[string]string
+	switch ext := strings.ToLower(filepath.Ext(path)); ext {
+	case ".json":
+		values, err = parseJSON(data)
+	case ".yaml", ".yml":
+		values, err = parseYAML(data)
+	default:
+		err = fmt.Errorf("unknown config format %q, want .json, .yaml or .yml", ext)
+	}
+	if err != nil {
+		b.problems = append(b.problems, fmt.Sprintf("%s: %v", path, err))
+		return b
+	}
+	origin := "file " + path
+	for _, key := range slices.Sorted(maps.Keys(values)) {
+		text := values[key]
+		if !known(key) {
+			b.problems = append(b.problems, fmt.Sprintf("%s: unknown key %s", path, key))
+			continue
+		}
+		b.layers[fromFile][key] = value{text, origin}
+	}
+	return b
+}
+
+// Env reads settings from the environment variables named after the keys:
+// with prefix APP, server.read_timeout is APP_SERVER_READ_TIMEOUT
+func (b *Builder) Env(prefix string) *Builder {
+	return b.EnvFrom(prefix, os.LookupEnv)
+}
+
+// EnvFrom is Env with another source of variables than the process
+// environment, such as a map in a test
+func (b *Builder) EnvFrom(prefix string, lookup func(name string) (string, bool)) *Builder {
+	for _, s := range settings {
+		name := EnvName(prefix, s.key)
+		if text, ok := lookup(name); ok {
+			b.layers[fromEnv][s.key] = value{text, "env " + name}
+		}
+	}
+	return b
+}
+
+// EnvName returns the environment variable for key
+func EnvName(prefix, key string) string {
+	return prefix + "_" + strings.ToUpper(strings.ReplaceAll(key, ".", "_"))
+}
+
+// Set overrides a setting from code, above every other source
+func (b *Builder) Set(key, text string) *Builder {
+	if !known(key) {
+		b.problems = append(b.problems, "unknown key "+key)
+		return b
+	}
+	b.layers[fromOverride][key] = value{text, "override"}
+	return b
+}
+
+func known(key string) bool {
+	return slices.ContainsFunc(settings, func(s setting) bool { return s.key == key })
+}
+
+// Build merges the sources and validates the result, reporting every
+// problem in one error
+func (b *Builder) Build() (Config, error) {
+	c := Config{origins: make(map[string]string, len(settings))}
+	problems := slices.Clone(b.problems)
+	for _, s := range settings {
+		var v value
+		for _, layer := range b.layers {
+			if lv, ok := layer[s.key]; ok {
+				v = lv
+			}
+		}
+		if err := s.set(&c, strings.TrimSpace(v.text)); err != nil {
+			problems = append(problems, fmt.Sprintf("%s (from %s): %v", s.key, v.origin, err))
+		}
+		c.origins[s.key] = v.origin
+	}
+	problems = append(problems, c.validate()...)
+	if problems != nil {
+		return Config{}, fmt.Errorf("%w: %s", ErrInvalidConfig, strings.Join(problems, "; "))
+	}
+	return c, nil
+}
+
+// validate checks the values against each other and the ranges they must
+// be in
+func (c Config) validate() []string {
+	var problems []string
+	if _, port, err := net.SplitHostPort(c.Server.Addr); err != nil || port == "" {
+		problems = append(problems, fmt.Sprintf("server.addr %q needs a host:port", c.Server.Addr))
+	}
+	if c.Server.ReadTimeout <= 0 {
+		problems = append(problems, fmt.Sprintf("server.read_timeout must be positive, got %v", c.Server.ReadTimeout))
+	}
+	if c.Database.URL == "" {
+		problems = append(problems, "database.url is required")
+	}
+	if c.Database.MaxConns < 1 || c.Database.MaxConns > 1000 {
+		problems = append(problems, fmt.Sprintf("database.max_conns must be 1 to 1000, got %d", c.Database.MaxConns))
+	}
+	if levels := []string{"debug", "info", "warn", "error"}; !slices.Contains(levels, c.Log.Level) {
+		problems = append(problems, fmt.Sprintf("log.level %q is not one of %v", c.Log.Level, levels))
+	}
+	if formats := []string{"text", "json"}; !slices.Contains(formats, c.Log.Format) {
+		problems = append(problems, fmt.Sprintf("log.format %q is not one of %v", c.Log.Format, formats))
+	}
+	return problems
+}
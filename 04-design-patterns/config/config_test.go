@@ -0,0 +1,229 @@
+package config
+
+import (
+	"context"
+	"errors"
+	"os"
+	"path/filepath"
+	"strings"
+	"sync"
+	"testing"
+	"time"
+)
+
+// writeFile writes a config file in a test's temporary directory
+func writeFile(t *testing.T, name, content string) string {
+	t.Helper()
+	path := filepath.Join(t.TempDir(), name)
+	if err := os.WriteFile(path, []byte(content), 0o644); err != nil {
+		t.Fatal(err)
+	}
+	return path
+}
+
+// replaceFile replaces a file in one step, the way editors and deployment
+// tools do, so a watcher sees one change. The modification time is moved
+// on a minute because some file systems keep it in whole seconds
+func replaceFile(t *testing.T, path, content string) {
+	t.Helper()
+	tmp := path + ".tmp"
+	later := time.Now().Add(time.Minute)
+	if err := os.WriteFile(tmp, []byte(content), 0o644); err != nil {
+		t.Fatal(err)
+	}
+	if err := os.Chtimes(tmp, later, later); err != nil {
+		t.Fatal(err)
+	}
+	if err := os.Rename(tmp, path); err != nil {
+		t.Fatal(err)
+	}
+}
+
+// env returns a lookup function over a map, for EnvFrom
+func env(vars map[string]string) func(string) (string, bool) {
+	return func(name string) (string, bool) {
+		v, ok := vars[name]
+		return v, ok
+	}
+}
+
+func TestDefaults(t *testing.T) {
+	c, err := NewBuilder().Build()
+	if err != nil {
+		t.Fatal(err)
+	}
+	if c.Server.Addr != "localhost:8080" || c.Server.ReadTimeout != 5*time.Second || c.Database.MaxConns != 10 || c.Log.Level != "info" {
+		t.Errorf("defaults = %+v", c)
+	}
+	for _, key := range Keys() {
+		if c.Origin(key) != "default" {
+			t.Errorf("Origin(%s) = %q, want default", key, c.Origin(key))
+		}
+	}
+}
+
+const yamlConfig = `
+# The settings for production
+server:
+  addr: "0.0.0.0:9000"   # quoted, though it needn't be
+  read_timeout: 30s
+  debug: false
+database:
+  url: 'postgres://db/app?sslmode=disable#x'
+  max_conns: 50
+log:
+  level: warn
+`
+
+func TestPrecedence(t *testing.T) {
+	path := writeFile(t, "app.yaml", yamlConfig)
+	vars := map[string]string{"APP_DATABASE_MAX_CONNS": "80", "APP_LOG_LEVEL": "debug"}
+	// Set and EnvFrom come before File, yet still win over it
+	c, err := NewBuilder().Set("log.level", "error").EnvFrom("APP", env(vars)).File(path).Build()
+	if err != nil {
+		t.Fatal(err)
+	}
+	for _, tt := range []struct {
+		key, origin string
+		got, want   any
+	}{
+		{"server.addr", "file " + path, c.Server.Addr, "0.0.0.0:9000"},
+		{"server.read_timeout", "file " + path, c.Server.ReadTimeout, 30 * time.Second},
+		{"database.url", "file " + path, c.Database.URL, "postgres://db/app?sslmode=disable#x"},
+		{"database.max_conns", "env APP_DATABASE_MAX_CONNS", c.Database.MaxConns, 80},
+		{"log.level", "override", c.Log.Level, "error"},
+		{"log.format", "default", c.Log.Format, "text"},
+	} {
+		if tt.got != tt.want || c.Origin(tt.key) != tt.origin {
+			t.Errorf("%s = %v from %q, want %v from %q", tt.key, tt.got, c.Origin(tt.key), tt.want, tt.origin)
+		}
+	}
+}
+
+func TestJSONFile(t *testing.T) {
+	path := writeFile(t, "app.json", `{"server": {"addr": ":80", "debug": true}, "database": {"max_conns": 3}}`)
+	c, err := NewBuilder().File(path).Build()
+	if err != nil {
+		t.Fatal(err)
+	}
+	if c.Server.Addr != ":80" || !c.Server.Debug || c.Database.MaxConns != 3 {
+		t.Errorf("config = %+v", c)
+	}
+}
+
+func TestBuildReportsEveryProblem(t *testing.T) {
+	path := writeFile(t, "bad.yaml", "server:\n  addr: nowhere\n  timeout: 3s\ndatabase:\n  max_conns: lots\n")
+	_, err := NewBuilder().File(path).Set("log.format", "xml").Set("log.colour", "red").Build()
+	if !errors.Is(err, ErrInvalidConfig) {
+		t.Fatalf("err = %v, want ErrInvalidConfig", err)
+	}
+	for _, want := range []string{
+		"unknown key server.timeout",
+		"unknown key log.colour",
+		`database.max_conns (from file ` + path + `): "lots" is not a whole number`,
+		`server.addr "nowhere" needs a host:port`,
+		`log.format "xml" is not one of [text json]`,
+	} {
+		if !strings.Contains(err.Error(), want) {
+			t.Errorf("error %q\nlacks %q", err, want)
+		}
+	}
+}
+
+func TestParseYAMLErrors(t *testing.T) {
+	for _, tt := range []struct{ doc, want string }{
+		{"servers:\n  - a\n", "line 2: lists are not supported"},
+		{"server:\nlog: x\n", "line 2: server has no value"},
+		{"a:\n    b: 1\n  c: 2\n", "line 3: indentation"},
+		{"a: 1\na: 2\n", "line 2: a is set twice"},
+		{"a: [1, 2]\n", "only plain and quoted scalars"},
+		{"just text\n", "want key: value"},
+		{"a:\n", "a has no value"},
+		{"a:\n\tb: 1\n", "line 2: indent with spaces"},
+	} {
+		if _, err := parseYAML([]byte(tt.doc)); err == nil || !strings.Contains(err.Error(), tt.want) {
+			t.Errorf("parseYAML(%q) = %v, want %q", tt.doc, err, tt.want)
+		}
+	}
+}
+
+func TestStoreReload(t *testing.T) {
+	path := writeFile(t, "app.yaml", "log:\n  level: info\n")
+	store, err := NewStore(func() (Config, error) { return NewBuilder().File(path).Build() })
+	if err != nil {
+		t.Fatal(err)
+	}
+	os.WriteFile(path, []byte("log:\n  level: debug\n"), 0o644)
+	if err := store.Reload(); err != nil || store.Config().Log.Level != "debug" {
+		t.Fatalf("after reload: %v, level %s", err, store.Config().Log.Level)
+	}
+	// A bad file is reported, and the last good config stays
+	os.WriteFile(path, []byte("log:\n  level: loud\n"), 0o644)
+	if err := store.Reload(); !errors.Is(err, ErrInvalidConfig) || store.Config().Log.Level != "debug" {
+		t.Errorf("after bad reload: %v, level %s", err, store.Config().Log.Level)
+	}
+}
+
+func TestWatch(t *testing.T) {
+	path := writeFile(t, "app.yaml", "log:\n  level: info\n")
+	store, err := NewStore(func() (Config, error) { return NewBuilder().File(path).Build() })
+	if err != nil {
+		t.Fatal(err)
+	}
+	reloaded := make(chan string, 1)
+	store.OnReload(func(c Config, err error) { reloaded <- c.Log.Level })
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+	store.Watch(ctx, path, 5*time.Millisecond)
+
+	// Readers never block or see a torn value while the file changes
+	var wg sync.WaitGroup
+	wg.Add(1)
+	go func() {
+		defer wg.Done()
+		for ctx.Err() == nil {
+			if l := store.Config().Log.Level; l != "info" && l != "warn" {
+				t.Errorf("read level %q", l)
+				return
+			}
+		}
+	}()
+
+	replaceFile(t, path, "log:\n  level: warn\n")
+	select {
+	case level := <-reloaded:
+		if level != "warn" {
+			t.Errorf("reloaded level %q, want warn", level)
+		}
+	case <-time.After(5 * time.Second):
+		t.Fatal("no reload after the file changed")
+	}
+	cancel()
+	wg.Wait()
+}
+
+func TestGlobal(t *testing.T) {
+	path := writeFile(t, "app.json", `{"log": {"format": "json"}}`)
+	t.Setenv(EnvFile, path)
+	t.Setenv("APP_SERVER_DEBUG", "true")
+	ResetForTesting()
+	defer ResetForTesting()
+
+	a, err := Global()
+	if err != nil {
+		t.Fatal(err)
+	}
+	b, _ := Global()
+	if a != b {
+		t.Error("Global returned two stores")
+	}
+	if c := a.Config(); c.Log.Format != "json" || !c.Server.Debug {
+		t.Errorf("global config = %+v", c)
+	}
+
+	t.Setenv("APP_DATABASE_MAX_CONNS", "0")
+	ResetForTesting()
+	if _, err := Global(); !errors.Is(err, ErrInvalidConfig) {
+		t.Errorf("Global with a bad variable = %v, want ErrInvalidConfig", err)
+	}
+}
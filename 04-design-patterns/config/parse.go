@@ -0,0 +1,158 @@
+package config
+
+import (
+	"bytes"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"strconv"
+	"strings"
+)
+
+// parseJSON flattens a JSON object into dotted keys:
+// {"server": {"addr": ":80"}} is server.addr = ":80"
+func parseJSON(data []byte) (map[string]string, error) {
+	var doc map[string]any
+	dec := json.NewDecoder(bytes.NewReader(data))
+	dec.UseNumber()
+	if err := dec.Decode(&doc); err != nil {
+		return nil, err
+	}
+	values := make(map[string]string)
+	return values, flatten("", doc, values)
+}
+
+func flatten(prefix string, obj map[string]any, values map[string]string) error {
+	for k, v := range obj {
+		key := prefix + k
+		switch v := v.(type) {
+		case map[string]any:
+			if err := flatten(key+".", v, values); err != nil {
+				return err
+			}
+		case []any:
+			return fmt.Errorf("%s: lists are not supported", key)
+		case nil:
+			values[key] = ""
+		default:
+			// Strings, json.Numbers and bools all print as what they were
+			values[key] = fmt.Sprint(v)
+		}
+	}
+	return nil
+}
+
+// parseYAML flattens the part of YAML a settings file needs: nested
+// mappings of scalars, with comments
+//
+//	server:
+//	  addr: ":8080"       # quoted because of the colon
+//	  read_timeout: 10s
+//
+// Lists, anchors, multi-line strings and the rest of YAML are rejected
+// rather than misread
+func parseYAML(data []byte) (map[string]string, error) {
+	type level struct {
+		indent int
+		prefix string
+	}
+	values := make(map[string]string)
+	// stack holds the mappings the current line is in, from the top level
+	var stack []level
+	// open is the key of a mapping whose first child hasn't been seen
+	open := ""
+	for n, line := range strings.Split(string(data), "\n") {
+		line = strings.TrimRight(stripComment(line), " \r")
+		if strings.TrimSpace(line) == "" {
+			continue
+		}
+		content := strings.TrimLeft(line, " ")
+		indent := len(line) - len(content)
+		fail := func(format string, args ...any) error {
+			return fmt.Errorf("line %d: %s", n+1, fmt.Sprintf(format, args...))
+		}
+		if strings.HasPrefix(content, "\t") {
+			return nil, fail("indent with spaces, not tabs")
+		}
+		if strings.HasPrefix(content, "- ") || content == "-" {
+			return nil, fail("lists are not supported")
+		}
+
+		switch {
+		case stack == nil:
+			stack = []level{{indent, ""}}
+		case open != "":
+			if indent <= stack[len(stack)-1].indent {
+				return nil, fail("%s has no value", open)
+			}
+			stack = append(stack, level{indent, open + "."})
+			open = ""
+		default:
+			for len(stack) > 1 && indent < stack[len(stack)-1].indent {
+				stack = stack[:len(stack)-1]
+			}
+			if indent != stack[len(stack)-1].indent {
+				return nil, fail("indentation doesn't match any mapping above")
+			}
+		}
+
+		k, v, ok := strings.Cut(content, ":")
+		if !ok || strings.TrimSpace(k) == "" {
+			return nil, fail("want key: value, got %q", content)
+		}
+		key := stack[len(stack)-1].prefix + strings.TrimSpace(k)
+		v = strings.TrimSpace(v)
+		if v == "" {
+			open = key
+			continue
+		}
+		scalar, err := unquote(v)
+		if err != nil {
+			return nil, fail("%s: %v", key, err)
+		}
+		if _, dup := values[key]; dup {
+			return nil, fail("%s is set twice", key)
+		}
+		values[key] = scalar
+	}
+	if open != "" {
+		return nil, fmt.Errorf("%s has no value", open)
+	}
+	return values, nil
+}
+
+// stripComment removes a # comment that isn't inside quotes
+func stripComment(line string) string {
+	var quote byte
+	for i := 0; i < len(line); i++ {
+		switch c := line[i]; {
+		case quote != 0:
+			if c == quote {
+				quote = 0
+			}
+		case c == '"' || c == '\'':
+			quote = c
+		case c == '#' && (i == 0 || line[i-1] == ' '):
+			return line[:i]
+		}
+	}
+	return line
+}
+
+// unquote returns a scalar's text: a double-quoted string with Go escapes,
+// a single-quoted one, in which a doubled quote stands for one, or the
+// plain text
+func unquote(v string) (string, error) {
+	switch v[0] {
+	case '"':
+		return strconv.Unquote(v)
+	case '\'':
+		if len(v) < 2 || v[len(v)-1] != '\'' {
+			return "", errors.New("unterminated quote")
+		}
+		return strings.ReplaceAll(v[1:len(v)-1], "''", "'"), nil
+	case '[', '{', '&', '*', '|', '>':
+		return "", fmt.Errorf("%q: only plain and quoted scalars are supported", v)
+	}
+	return v, nil
+}
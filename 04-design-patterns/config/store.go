@@ -0,0 +1,152 @@
+package config
+
+import (
+	"context"
+	"os"
+	"sync"
+	"sync/atomic"
+	"time"
+
+	"github.com/NutProhmpiriya/go-basic/04-design-patterns/creational"
+)
+
+// Store holds the current Config and replaces it on Reload
+// It is safe for concurrent use: Config is one atomic load, and a reload
+// swaps in a whole new Config, so a reader gets either the old settings or
+// the new ones, never a mix
+type Store struct {
+	build   func() (Config, error)
+	current atomic.Pointer[Config]
+
+	mu        sync.Mutex
+	listeners []func(Config, error)
+}
+
+// NewStore builds the first Config with build, and keeps build to reload
+func NewStore(build func() (Config, error)) (*Store, error) {
+	c, err := build()
+	if err != nil {
+		return nil, err
+	}
+	s := &Store{build: build}
+	s.current.Store(&c)
+	return s, nil
+}
+
+// Config returns the current configuration
+func (s *Store) Config() Config {
+	return *s.current.Load()
+}
+
+// OnReload registers a function called after every reload, with the new
+// Config, or with the error and the Config still in use
+func (s *Store) OnReload(f func(Config, error)) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.listeners = append(s.listeners, f)
+}
+
+// Reload builds the configuration again and swaps it in if it is valid
+// An invalid configuration is not used: the Store keeps the last good one,
+// so a typo in the file can't take down a running program
+func (s *Store) Reload() error {
+	// Reloads are serialized so that listeners see them in order
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	c, err := s.build()
+	if err == nil {
+		s.current.Store(&c)
+	} else {
+		c = *s.current.Load()
+	}
+	for _, f := range s.listeners {
+		f(c, err)
+	}
+	return err
+}
+
+// Watch reloads the Store whenever the file at path changes, until ctx is
+// done. Like fsnotify, but by polling: every interval it compares the
+// file's modification time and size with what it saw last, which works on
+// every file system
+// Watch returns at once, after it has looked at the file for the first
+// time, so a change made after it returns is never missed
+func (s *Store) Watch(ctx context.Context, path string, interval time.Duration) {
+	last := stat(path)
+	go func() {
+		ticker := time.NewTicker(interval)
+		defer ticker.Stop()
+		for {
+			select {
+			case <-ctx.Done():
+				return
+			case <-ticker.C:
+				if now := stat(path); !now.same(last) {
+					last = now
+					s.Reload()
+				}
+			}
+		}
+	}()
+}
+
+// fileState is what Watch compares to notice a change
+type fileState struct {
+	modTime time.Time
+	size    int64
+	exists  bool
+}
+
+func (f fileState) same(g fileState) bool {
+	return f.modTime.Equal(g.modTime) && f.size == g.size && f.exists == g.exists
+}
+
+func stat(path string) fileState {
+	info, err := os.Stat(path)
+	if err != nil {
+		return fileState{}
+	}
+	return fileState{info.ModTime(), info.Size(), true}
+}
+
+// EnvPrefix is the prefix of the environment variables Global reads, and
+// EnvFile names the variable with the path of its config file
+const (
+	EnvPrefix = "APP"
+	EnvFile   = "APP_CONFIG"
+)
+
+// globalStore is what the Singleton holds: the Store, or why there is none
+type globalStore struct {
+	store *Store
+	err   error
+}
+
+// global is the Singleton behind Global
+var global = creational.NewLazySingleton(func() globalStore {
+	s, err := NewStore(DefaultBuilder)
+	return globalStore{s, err}
+})
+
+// DefaultBuilder builds the Config that Global loads: the defaults, the
+// file named by APP_CONFIG if it is set, and the APP_* variables
+func DefaultBuilder() (Config, error) {
+	b := NewBuilder()
+	if path := os.Getenv(EnvFile); path != "" {
+		b.File(path)
+	}
+	return b.Env(EnvPrefix).Build()
+}
+
+// Global returns the Store shared by the whole program, loaded on first use
+// If that first load fails, every call returns the same error
+func Global() (*Store, error) {
+	g := global.Get()
+	return g.store, g.err
+}
+
+// ResetForTesting discards the global Store so the next Global loads again
+// Only tests should call it
+func ResetForTesting() {
+	global.ResetForTesting()
+}
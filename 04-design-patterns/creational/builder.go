@@ -4,10 +4,27 @@
 // - When object needs to be created with lots of optional parameters
 // - When you need different representations of the same construction process
 // - When you want to build objects step by step
+//
+// Build checks the configuration as a whole, which single setters can't,
+// and returns the product by value: a copy that later changes to the
+// builder don't reach, so one builder can make many computers
 
 package creational
 
+import (
+	"errors"
+	"fmt"
+	"strings"
+)
+
+// ErrInvalidComputer is returned by Build for a configuration that can't
+// be built
+var ErrInvalidComputer = errors.New("invalid computer")
+
 // Computer represents the complex object we're building
+// Build hands out copies, and every field is a value, so a Computer can't
+// be changed through another one. A slice or map field would have to be
+// copied in Build to keep it that way
 type Computer struct {
 	CPU       string
 	RAM       int
@@ -23,17 +40,24 @@ type ComputerBuilder interface {
 	SetStorage(storage int) ComputerBuilder
 	SetGPU(gpu string) ComputerBuilder
 	SetBluetooth(hasBluetooth bool) ComputerBuilder
-	Build() *Computer
+	// Clone returns a new builder with the same settings, so a base
+	// configuration can be varied without changing it
+	Clone() ComputerBuilder
+	// Build validates the settings and returns the computer
+	Build() (Computer, error)
 }
 
 // concreteComputerBuilder implements ComputerBuilder
+// It keeps the settings by value, so Build and Clone copy them
 type concreteComputerBuilder struct {
-	computer *Computer
+	computer Computer
 }
 
 // NewComputerBuilder creates a new builder instance
+// The GPU defaults to integrated graphics; the CPU, RAM and storage must
+// be set
 func NewComputerBuilder() ComputerBuilder {
-	return &concreteComputerBuilder{computer: &Computer{}}
+	return &concreteComputerBuilder{computer: Computer{GPU: "Integrated"}}
 }
 
 func (b *concreteComputerBuilder) SetCPU(cpu string) ComputerBuilder {
@@ -61,11 +85,36 @@ func (b *concreteComputerBuilder) SetBluetooth(hasBluetooth bool) ComputerBuilde
 	return b
 }
 
-func (b *concreteComputerBuilder) Build() *Computer {
-	return b.computer
+func (b *concreteComputerBuilder) Clone() ComputerBuilder {
+	clone := *b
+	return &clone
+}
+
+// Build reports every problem at once, so a caller can fix them in one go
+func (b *concreteComputerBuilder) Build() (Computer, error) {
+	c := b.computer
+	var problems []string
+	if strings.TrimSpace(c.CPU) == "" {
+		problems = append(problems, "CPU is required")
+	}
+	if c.RAM <= 0 {
+		problems = append(problems, fmt.Sprintf("RAM must be positive, got %d GB", c.RAM))
+	}
+	if c.Storage <= 0 {
+		problems = append(problems, fmt.Sprintf("storage must be positive, got %d GB", c.Storage))
+	}
+	if c.GPU == "" {
+		problems = append(problems, "GPU is required")
+	}
+	if problems != nil {
+		return Computer{}, fmt.Errorf("%w: %s", ErrInvalidComputer, strings.Join(problems, "; "))
+	}
+	return c, nil
 }
 
 // Director helps in using the builder
+// Its builder is the base configuration every recipe starts from; the
+// recipes work on clones, so one recipe's settings never leak into the next
 type Director struct {
 	builder ComputerBuilder
 }
@@ -76,8 +125,8 @@ func NewDirector(builder ComputerBuilder) *Director {
 }
 
 // BuildGamingPC builds a gaming PC configuration
-func (d *Director) BuildGamingPC() *Computer {
-	return d.builder.
+func (d *Director) BuildGamingPC() (Computer, error) {
+	return d.builder.Clone().
 		SetCPU("Intel i9").
 		SetRAM(32).
 		SetStorage(2000).
@@ -87,12 +136,11 @@ func (d *Director) BuildGamingPC() *Computer {
 }
 
 // BuildOfficePC builds a basic office PC configuration
-func (d *Director) BuildOfficePC() *Computer {
-	return d.builder.
+// It leaves the GPU and Bluetooth to the base configuration
+func (d *Director) BuildOfficePC() (Computer, error) {
+	return d.builder.Clone().
 		SetCPU("Intel i5").
 		SetRAM(16).
 		SetStorage(512).
-		SetGPU("Integrated").
-		SetBluetooth(true).
 		Build()
 }
@@ -0,0 +1,81 @@
+package creational_test
+
+import (
+	"errors"
+	"strings"
+	"testing"
+
+	"github.com/NutProhmpiriya/go-basic/04-design-patterns/creational"
+)
+
+func TestBuildReportsEveryProblem(t *testing.T) {
+	_, err := creational.NewComputerBuilder().
+		SetCPU("  ").
+		SetRAM(0).
+		SetStorage(-1).
+		SetGPU("").
+		Build()
+	if !errors.Is(err, creational.ErrInvalidComputer) {
+		t.Fatalf("Build() = %v, want ErrInvalidComputer", err)
+	}
+	for _, want := range []string{"CPU is required", "RAM must be positive, got 0 GB", "storage must be positive, got -1 GB", "GPU is required"} {
+		if !strings.Contains(err.Error(), want) {
+			t.Errorf("error %q doesn't mention %q", err, want)
+		}
+	}
+
+	c, err := creational.NewComputerBuilder().SetCPU("Ryzen 7").SetRAM(16).SetStorage(1000).Build()
+	if err != nil {
+		t.Fatal(err)
+	}
+	if want := (creational.Computer{CPU: "Ryzen 7", RAM: 16, Storage: 1000, GPU: "Integrated"}); c != want {
+		t.Errorf("Build() = %+v, want %+v", c, want)
+	}
+}
+
+func TestCloneLeavesBaseAlone(t *testing.T) {
+	base := creational.NewComputerBuilder().SetCPU("Intel i5").SetRAM(8).SetStorage(256)
+	upgraded, err := base.Clone().SetRAM(64).SetGPU("RTX 4090").Build()
+	if err != nil {
+		t.Fatal(err)
+	}
+	plain, err := base.Build()
+	if err != nil {
+		t.Fatal(err)
+	}
+	if plain.RAM != 8 || plain.GPU != "Integrated" {
+		t.Errorf("base changed by its clone: %+v", plain)
+	}
+	if upgraded.RAM != 64 || upgraded.GPU != "RTX 4090" || upgraded.CPU != "Intel i5" {
+		t.Errorf("clone = %+v", upgraded)
+	}
+
+	// A computer already built doesn't change with its builder
+	base.SetRAM(4)
+	if plain.RAM != 8 {
+		t.Errorf("built computer changed with its builder: %+v", plain)
+	}
+}
+
+func TestDirectorRecipesIndependent(t *testing.T) {
+	director := creational.NewDirector(creational.NewComputerBuilder())
+	gaming, err := director.BuildGamingPC()
+	if err != nil {
+		t.Fatal(err)
+	}
+	// The office PC is built after the gaming PC from the same director,
+	// and must not inherit its GPU or Bluetooth
+	office, err := director.BuildOfficePC()
+	if err != nil {
+		t.Fatal(err)
+	}
+	if want := (creational.Computer{CPU: "Intel i5", RAM: 16, Storage: 512, GPU: "Integrated"}); office != want {
+		t.Errorf("BuildOfficePC() = %+v, want %+v", office, want)
+	}
+	if gaming.GPU != "RTX 4080" || !gaming.Bluetooth || gaming.RAM != 32 {
+		t.Errorf("BuildGamingPC() = %+v", gaming)
+	}
+	if again, _ := director.BuildGamingPC(); again != gaming {
+		t.Errorf("second BuildGamingPC() = %+v, want %+v", again, gaming)
+	}
+}
@@ -8,6 +8,13 @@
 
 package creational
 
+import (
+	"errors"
+	"fmt"
+	"slices"
+	"sync"
+)
+
 // PaymentMethod interface defines the contract for different payment methods
 type PaymentMethod interface {
 	Pay(amount float64) string
@@ -34,25 +41,70 @@ func (p *PayPal) Pay(amount float64) string {
 	return "Paid using PayPal"
 }
 
-// PaymentType represents different types of payment methods
-type PaymentType int
+// ErrUnknownPaymentMethod is returned by PaymentFactory for a type nobody
+// registered
+var ErrUnknownPaymentMethod = errors.New("unknown payment method")
 
+// PaymentType names a payment method
+type PaymentType string
+
+// The built-in payment methods
 const (
-	CreditCardType PaymentType = iota
-	DebitCardType
-	PayPalType
+	CreditCardType PaymentType = "credit-card"
+	DebitCardType  PaymentType = "debit-card"
+	PayPalType     PaymentType = "paypal"
 )
 
+var (
+	paymentMethodsMu sync.RWMutex
+	// paymentMethods maps every payment type to its constructor
+	// A map instead of a switch lets other packages add methods without
+	// editing this file
+	paymentMethods = map[PaymentType]func() PaymentMethod{
+		CreditCardType: func() PaymentMethod { return &CreditCard{} },
+		DebitCardType:  func() PaymentMethod { return &DebitCard{} },
+		PayPalType:     func() PaymentMethod { return &PayPal{} },
+	}
+)
+
+// RegisterPaymentMethod makes PaymentFactory create a payment method with
+// constructor for name, usually from the init function of the package
+// that implements it
+// It panics if constructor is nil or name is already taken, like
+// plugins.Register: both are programming errors that should fail at startup
+func RegisterPaymentMethod(name PaymentType, constructor func() PaymentMethod) {
+	paymentMethodsMu.Lock()
+	defer paymentMethodsMu.Unlock()
+	if constructor == nil {
+		panic("creational: RegisterPaymentMethod constructor is nil")
+	}
+	if _, dup := paymentMethods[name]; dup {
+		panic("creational: RegisterPaymentMethod called twice for " + string(name))
+	}
+	paymentMethods[name] = constructor
+}
+
+// PaymentMethods returns the registered payment types, sorted
+func PaymentMethods() []PaymentType {
+	paymentMethodsMu.RLock()
+	defer paymentMethodsMu.RUnlock()
+	names := make([]PaymentType, 0, len(paymentMethods))
+	for name := range paymentMethods {
+		names = append(names, name)
+	}
+	slices.Sort(names)
+	return names
+}
+
 // PaymentFactory creates payment methods based on the type
-func PaymentFactory(paymentType PaymentType) PaymentMethod {
-	switch paymentType {
-	case CreditCardType:
-		return &CreditCard{}
-	case DebitCardType:
-		return &DebitCard{}
-	case PayPalType:
-		return &PayPal{}
-	default:
-		return nil
+// An unknown type is an error rather than a nil PaymentMethod, which would
+// only fail later, when something calls Pay on it
+func PaymentFactory(paymentType PaymentType) (PaymentMethod, error) {
+	paymentMethodsMu.RLock()
+	constructor, ok := paymentMethods[paymentType]
+	paymentMethodsMu.RUnlock()
+	if !ok {
+		return nil, fmt.Errorf("%w %q", ErrUnknownPaymentMethod, paymentType)
 	}
+	return constructor(), nil
 }
@@ -0,0 +1,77 @@
+// The tests are in an external package, so they can only use the factory
+// the way another package would
+package creational_test
+
+import (
+	"errors"
+	"fmt"
+	"slices"
+	"testing"
+
+	"github.com/NutProhmpiriya/go-basic/04-design-patterns/creational"
+)
+
+// voucher is a payment method the creational package knows nothing about
+type voucher struct {
+	code string
+}
+
+func (v *voucher) Pay(amount float64) string {
+	return fmt.Sprintf("Paid %.2f with voucher %s", amount, v.code)
+}
+
+const voucherType creational.PaymentType = "voucher"
+
+func init() {
+	creational.RegisterPaymentMethod(voucherType, func() creational.PaymentMethod {
+		return &voucher{code: "GIFT-100"}
+	})
+}
+
+func TestPaymentFactory(t *testing.T) {
+	for _, tt := range []struct {
+		paymentType creational.PaymentType
+		want        string
+	}{
+		{creational.CreditCardType, "Paid using Credit Card"},
+		{creational.DebitCardType, "Paid using Debit Card"},
+		{creational.PayPalType, "Paid using PayPal"},
+		{voucherType, "Paid 25.00 with voucher GIFT-100"},
+	} {
+		method, err := creational.PaymentFactory(tt.paymentType)
+		if err != nil {
+			t.Fatalf("PaymentFactory(%q): %v", tt.paymentType, err)
+		}
+		if got := method.Pay(25); got != tt.want {
+			t.Errorf("%s: Pay(25) = %q, want %q", tt.paymentType, got, tt.want)
+		}
+	}
+	if !slices.Contains(creational.PaymentMethods(), voucherType) {
+		t.Errorf("PaymentMethods() = %v, want voucher in it", creational.PaymentMethods())
+	}
+}
+
+func TestPaymentFactoryUnknown(t *testing.T) {
+	method, err := creational.PaymentFactory("bitcoin")
+	if !errors.Is(err, creational.ErrUnknownPaymentMethod) || method != nil {
+		t.Errorf("PaymentFactory(bitcoin) = %v, %v, want ErrUnknownPaymentMethod", method, err)
+	}
+}
+
+func TestRegisterPaymentMethodPanics(t *testing.T) {
+	for name, register := range map[string]func(){
+		"duplicate": func() {
+			creational.RegisterPaymentMethod(creational.PayPalType, func() creational.PaymentMethod { return nil })
+		},
+		"nil constructor": func() { creational.RegisterPaymentMethod("nothing", nil) },
+	} {
+		func() {
+			defer func() {
+				if recover() == nil {
+					t.Errorf("%s: RegisterPaymentMethod did not panic", name)
+				}
+			}()
+			register()
+		}()
+	}
+}
@@ -10,33 +10,81 @@ package creational
 
 import (
 	"sync"
+	"sync/atomic"
 )
 
+// Singleton holds shared state
+// count is an atomic counter, so the instance is safe to use from many goroutines,
+// not only safe to create
 type Singleton struct {
-	count int
+	count atomic.Int64
 }
 
-var (
-	instance *Singleton
-	once     sync.Once
-)
+var instance = NewLazySingleton(func() *Singleton {
+	return &Singleton{}
+})
 
 // GetInstance returns the single instance of Singleton
-// Thread-safe due to sync.Once
+// Thread-safe: the instance is created exactly once even when
+// many goroutines call GetInstance at the same time
 func GetInstance() *Singleton {
-	once.Do(func() {
-		instance = &Singleton{count: 0}
-	})
-	return instance
+	return instance.Get()
+}
+
+// ResetForTesting discards the current instance so the next GetInstance
+// creates a fresh one
+// Only tests should call this: code still holding the old instance keeps using it
+func ResetForTesting() {
+	instance.ResetForTesting()
 }
 
-// IncrementCount increases the counter
+// IncrementCount increases the counter and returns the new value
+// Thread-safe: uses an atomic add instead of count++
 func (s *Singleton) IncrementCount() int {
-	s.count++
-	return s.count
+	return int(s.count.Add(1))
 }
 
 // GetCount returns the current count
 func (s *Singleton) GetCount() int {
-	return s.count
+	return int(s.count.Load())
+}
+
+// LazySingleton creates a value of type T on first use and then
+// returns the same value forever (until ResetForTesting)
+// It uses double-checked locking: after initialization Get is a single
+// atomic load, and the mutex is only taken while the value is missing
+type LazySingleton[T any] struct {
+	mu    sync.Mutex
+	value atomic.Pointer[T]
+	init  func() T
+}
+
+// NewLazySingleton creates a lazy singleton that calls init on first use
+func NewLazySingleton[T any](init func() T) *LazySingleton[T] {
+	return &LazySingleton[T]{init: init}
+}
+
+// Get returns the value, creating it on the first call
+func (l *LazySingleton[T]) Get() T {
+	// Fast path: already initialized
+	if v := l.value.Load(); v != nil {
+		return *v
+	}
+
+	l.mu.Lock()
+	defer l.mu.Unlock()
+	// Another goroutine may have initialized it while we waited for the lock
+	if v := l.value.Load(); v != nil {
+		return *v
+	}
+	v := l.init()
+	l.value.Store(&v)
+	return v
+}
+
+// ResetForTesting forgets the value so the next Get calls init again
+func (l *LazySingleton[T]) ResetForTesting() {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+	l.value.Store(nil)
 }
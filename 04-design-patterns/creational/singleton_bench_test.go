@@ -0,0 +1,114 @@
+// Benchmarks for the singleton strategies in singleton_strategies.go
+//
+//	go test -bench Singleton -benchmem ./04-design-patterns/creational
+//
+// The cold benchmarks create a singleton and make the first Get, the cost
+// a program pays once; the hot ones call Get on a singleton that has its
+// value, the cost of every call after that, from one goroutine and from
+// all of them at once
+package creational
+
+import (
+	"sync"
+	"testing"
+)
+
+// config stands for the kind of value a singleton holds
+type config struct {
+	name    string
+	retries int
+}
+
+func newConfig() *config {
+	return &config{name: "app", retries: 3}
+}
+
+// mutexSingleton takes the lock on every Get, the baseline the other
+// strategies avoid
+type mutexSingleton struct {
+	mu    sync.Mutex
+	value *config
+}
+
+func (m *mutexSingleton) Get() *config {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	if m.value == nil {
+		m.value = newConfig()
+	}
+	return m.value
+}
+
+// onceValue adapts sync.OnceValue to Lazy
+type onceValue func() *config
+
+func (f onceValue) Get() *config { return f() }
+
+// eagerConfig is made before main, like any package-level var
+var eagerConfig = NewEagerSingleton(newConfig)
+
+var strategies = []struct {
+	name string
+	new  func() Lazy[*config]
+}{
+	{"Eager", func() Lazy[*config] { return NewEagerSingleton(newConfig) }},
+	{"SyncOnce", func() Lazy[*config] { return NewOnceSingleton(newConfig) }},
+	{"SyncOnceValue", func() Lazy[*config] { return onceValue(sync.OnceValue(newConfig)) }},
+	{"AtomicPointer", func() Lazy[*config] { return NewAtomicSingleton(newConfig) }},
+	{"DoubleChecked", func() Lazy[*config] { return NewLazySingleton(newConfig) }},
+	{"Mutex", func() Lazy[*config] { return &mutexSingleton{} }},
+}
+
+func TestSingletonStrategies(t *testing.T) {
+	for _, s := range strategies {
+		lazy := s.new()
+		if first := lazy.Get(); first == nil || lazy.Get() != first {
+			t.Errorf("%s: Get returned %p, then %p", s.name, first, lazy.Get())
+		}
+	}
+	if eagerConfig.Get() == nil {
+		t.Error("the package-level EagerSingleton has no value")
+	}
+}
+
+// sink keeps the compiler from dropping the benchmarked calls
+var sink *config
+
+func BenchmarkSingletonCold(b *testing.B) {
+	for _, s := range strategies {
+		b.Run(s.name, func(b *testing.B) {
+			b.ReportAllocs()
+			for range b.N {
+				sink = s.new().Get()
+			}
+		})
+	}
+}
+
+func BenchmarkSingletonHot(b *testing.B) {
+	for _, s := range strategies {
+		lazy := s.new()
+		lazy.Get()
+		b.Run(s.name, func(b *testing.B) {
+			for range b.N {
+				sink = lazy.Get()
+			}
+		})
+	}
+}
+
+func BenchmarkSingletonHotParallel(b *testing.B) {
+	for _, s := range strategies {
+		lazy := s.new()
+		lazy.Get()
+		b.Run(s.name, func(b *testing.B) {
+			b.RunParallel(func(pb *testing.PB) {
+				var c *config
+				for pb.Next() {
+					c = lazy.Get()
+				}
+				_ = c
+			})
+		})
+	}
+}
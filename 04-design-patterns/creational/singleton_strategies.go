@@ -0,0 +1,114 @@
+// Singleton strategies compares the ways Go code creates a value once and
+// shares it. They all hand out the same value from then on; they differ
+// in when the value is made, what the first call costs, what every later
+// call costs, and what happens when the creation fails or runs twice
+//
+// - EagerSingleton: made up front, by a package-level var or init().
+//   Get is a plain read, but the program pays at startup even if it never
+//   uses the value, and a failure there can only panic
+// - OnceSingleton: sync.Once runs init on the first Get, and the others
+//   wait for it. Later calls cost one atomic load. The standard library
+//   wraps this as sync.OnceValue and sync.OnceValues
+// - AtomicSingleton: the first Get calls init and publishes the result with
+//   a compare-and-swap on an atomic.Pointer, without a lock. Goroutines
+//   that race on the first Get may each run init, and all but one result
+//   are thrown away, so init must be cheap and free of side effects
+// - LazySingleton (singleton.go): double-checked locking with a mutex and
+//   an atomic.Pointer. Like sync.Once, but it can be reset
+//
+// The idiomatic choice is sync.OnceValue: it is correct without thought,
+// its hot path is a few nanoseconds like the other lazy strategies, where
+// a mutex taken on every Get costs several times more, and it passes a
+// panic in init on to every caller. Pick eager initialization when the
+// value is cheap and always needed, and double-checked locking when tests
+// must reset it
+//
+// Measure them with:
+//
+//	go test -bench Singleton -benchmem ./04-design-patterns/creational
+
+package creational
+
+import (
+	"sync"
+	"sync/atomic"
+)
+
+// Lazy is what every singleton strategy provides: Get returns the one value
+type Lazy[T any] interface {
+	Get() T
+}
+
+// EagerSingleton holds a value made when the singleton is created
+// Declared as a package-level var, it is made before main runs
+type EagerSingleton[T any] struct {
+	value T
+}
+
+// NewEagerSingleton calls init at once and keeps the result
+func NewEagerSingleton[T any](init func() T) *EagerSingleton[T] {
+	return &EagerSingleton[T]{value: init()}
+}
+
+// Get returns the value
+// Safe for concurrent use because the value never changes after creation
+func (e *EagerSingleton[T]) Get() T {
+	return e.value
+}
+
+// OnceSingleton creates its value with sync.Once on the first Get
+type OnceSingleton[T any] struct {
+	once  sync.Once
+	value T
+	init  func() T
+}
+
+// NewOnceSingleton creates a singleton that calls init on first use
+func NewOnceSingleton[T any](init func() T) *OnceSingleton[T] {
+	return &OnceSingleton[T]{init: init}
+}
+
+// Get returns the value, creating it on the first call
+// Every other caller blocks until the first one's init returns, and
+// sync.Once makes its writes visible to them
+func (o *OnceSingleton[T]) Get() T {
+	o.once.Do(func() {
+		o.value = o.init()
+	})
+	return o.value
+}
+
+// AtomicSingleton creates its value on the first Get without a lock
+type AtomicSingleton[T any] struct {
+	value atomic.Pointer[T]
+	init  func() T
+	// inits counts the calls to init, to show the wasted ones
+	inits atomic.Int64
+}
+
+// NewAtomicSingleton creates a singleton that calls init on first use,
+// possibly more than once
+func NewAtomicSingleton[T any](init func() T) *AtomicSingleton[T] {
+	return &AtomicSingleton[T]{init: init}
+}
+
+// Get returns the value, creating it if there is none yet
+// Only the first compare-and-swap stores its value; a goroutine that loses
+// the race drops its own and returns the winner's
+func (a *AtomicSingleton[T]) Get() T {
+	if v := a.value.Load(); v != nil {
+		return *v
+	}
+	a.inits.Add(1)
+	v := a.init()
+	if a.value.CompareAndSwap(nil, &v) {
+		return v
+	}
+	return *a.value.Load()
+}
+
+// Inits returns how many times init ran, which can be more than once when
+// goroutines race on the first Get
+func (a *AtomicSingleton[T]) Inits() int {
+	return int(a.inits.Load())
+}
@@ -0,0 +1,90 @@
+//go:build stress
+
+// Stress tests for the thread-safety claims in singleton.go
+// Run them under the race detector:
+//
+//	go test -race -tags stress ./04-design-patterns/...
+package creational
+
+import (
+	"sync"
+	"sync/atomic"
+	"testing"
+)
+
+// goroutines is how many goroutines each stress test starts at once
+const goroutines = 500
+
+// hammer runs fn from n goroutines that all start together and waits for them
+func hammer(n int, fn func(i int)) {
+	start := make(chan struct{})
+	var wg sync.WaitGroup
+	for i := range n {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			<-start
+			fn(i)
+		}()
+	}
+	close(start)
+	wg.Wait()
+}
+
+func TestGetInstanceStress(t *testing.T) {
+	ResetForTesting()
+	defer ResetForTesting()
+
+	const increments = 100
+	instances := make([]*Singleton, goroutines)
+	hammer(goroutines, func(i int) {
+		instances[i] = GetInstance()
+		for range increments {
+			instances[i].IncrementCount()
+		}
+	})
+
+	for i, s := range instances {
+		if s != instances[0] {
+			t.Fatalf("goroutine %d got a different instance", i)
+		}
+	}
+	if got, want := GetInstance().GetCount(), goroutines*increments; got != want {
+		t.Errorf("count = %d, want %d", got, want)
+	}
+}
+
+// Every round races a fresh batch of goroutines to the first Get, while a
+// few of them reset the value again
+func TestLazySingletonStress(t *testing.T) {
+	for round := range 20 {
+		var inits atomic.Int64
+		lazy := NewLazySingleton(func() *int {
+			n := int(inits.Add(1))
+			return &n
+		})
+
+		values := make([]*int, goroutines)
+		hammer(goroutines, func(i int) {
+			values[i] = lazy.Get()
+		})
+		for i, v := range values {
+			if v != values[0] {
+				t.Fatalf("round %d: goroutine %d got a different value", round, i)
+			}
+		}
+		if n := inits.Load(); n != 1 {
+			t.Fatalf("round %d: init ran %d times, want 1", round, n)
+		}
+
+		// Resetting while others read only ever hands out complete values
+		hammer(goroutines, func(i int) {
+			if i%50 == 0 {
+				lazy.ResetForTesting()
+			}
+			if v := lazy.Get(); v == nil || *v < 1 {
+				t.Errorf("round %d: Get returned %v", round, v)
+			}
+		})
+	}
+}
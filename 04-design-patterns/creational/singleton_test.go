@@ -0,0 +1,73 @@
+// These tests are meant for the race detector:
+//
+//	go test -race ./04-design-patterns/creational
+//
+// singleton_stress_test.go hammers the same code much harder
+package creational
+
+import (
+	"sync"
+	"sync/atomic"
+	"testing"
+	"time"
+)
+
+// concurrently calls fn from n goroutines released at the same moment
+func concurrently(n int, fn func(i int)) {
+	start := make(chan struct{})
+	var wg sync.WaitGroup
+	wg.Add(n)
+	for i := range n {
+		go func() {
+			defer wg.Done()
+			<-start
+			fn(i)
+		}()
+	}
+	close(start)
+	wg.Wait()
+}
+
+func TestLazySingletonInitOnce(t *testing.T) {
+	var inits atomic.Int32
+	lazy := NewLazySingleton(func() *int {
+		inits.Add(1)
+		// A slow init keeps the others waiting on the lock
+		time.Sleep(time.Millisecond)
+		n := 42
+		return &n
+	})
+	values := make([]*int, 100)
+	concurrently(len(values), func(i int) { values[i] = lazy.Get() })
+	if n := inits.Load(); n != 1 {
+		t.Errorf("init ran %d times, want 1", n)
+	}
+	for i, v := range values {
+		if v != values[0] || *v != 42 {
+			t.Fatalf("goroutine %d got %p, want %p", i, v, values[0])
+		}
+	}
+
+	lazy.ResetForTesting()
+	if lazy.Get() == values[0] || inits.Load() != 2 {
+		t.Error("Get after ResetForTesting returned the old value")
+	}
+}
+
+func TestGetInstanceConcurrent(t *testing.T) {
+	ResetForTesting()
+	defer ResetForTesting()
+	instances := make([]*Singleton, 100)
+	concurrently(len(instances), func(i int) {
+		instances[i] = GetInstance()
+		instances[i].IncrementCount()
+	})
+	for i, s := range instances {
+		if s != instances[0] {
+			t.Fatalf("goroutine %d got a different instance", i)
+		}
+	}
+	if got := GetInstance().GetCount(); got != len(instances) {
+		t.Errorf("count = %d, want %d", got, len(instances))
+	}
+}
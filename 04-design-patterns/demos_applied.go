@@ -0,0 +1,203 @@
+package main
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"os"
+	"path/filepath"
+	"strings"
+	"time"
+
+	"github.com/NutProhmpiriya/go-basic/04-design-patterns/config"
+	"github.com/NutProhmpiriya/go-basic/04-design-patterns/fsm"
+	"github.com/NutProhmpiriya/go-basic/04-design-patterns/logging"
+	"github.com/NutProhmpiriya/go-basic/04-design-patterns/report"
+)
+
+// runLogging demonstrates a structured logger built from several patterns
+func runLogging() {
+	// A fixed clock keeps the output the same on every run
+	clock := func() time.Time { return time.Date(2024, 4, 15, 14, 30, 0, 0, time.UTC) }
+
+	// Example 1: Text for people on the console, and JSON for machines in a
+	// file that only gets warnings and errors, written in the background
+	dir, _ := os.MkdirTemp("", "logging-*")
+	defer os.RemoveAll(dir)
+	file, err := logging.NewFileSink(filepath.Join(dir, "app.log"), logging.JSONFormatter{})
+	if err != nil {
+		fmt.Println("Error:", err)
+		return
+	}
+	background := logging.NewAsyncSink(logging.MinLevel(logging.LevelWarn, file), 64)
+	logger := logging.New(logging.MultiSink{
+		logging.NewWriterSink(os.Stdout, logging.TextFormatter{}),
+		background,
+	}, logging.LevelDebug)
+	logger.SetClock(clock)
+	logger.Use("redact", logging.Redact("password"))
+
+	api := logger.With(logging.F("service", "api"))
+	api.Debug("starting", logging.F("port", 8080))
+	api.Info("user logged in", logging.F("user", "alice"), logging.F("password", "hunter2"))
+	api.Warn("slow request", logging.F("path", "/orders"), logging.F("took", 1200*time.Millisecond))
+	api.Error("payment failed", logging.F("err", errors.New("card declined")))
+
+	// Closing the async sink writes what is still queued
+	background.Close()
+	data, _ := os.ReadFile(filepath.Join(dir, "app.log"))
+	fmt.Printf("app.log:\n%s", data)
+
+	// Example 2: The default logger is one Logger for the whole program,
+	// which the package-level functions write to
+	std := logging.Default()
+	old := std.SetSink(logging.NewWriterSink(os.Stdout, logging.TextFormatter{TimeFormat: time.Kitchen}))
+	std.SetClock(clock)
+	defer func() {
+		std.SetSink(old)
+		std.SetClock(time.Now)
+	}()
+	logging.Info("shutting down", logging.F("singleton", logging.Default() == std))
+}
+
+// runConfig demonstrates a configuration loader built from the Builder and
+// the Singleton
+func runConfig() {
+	dir, _ := os.MkdirTemp("", "config-*")
+	defer os.RemoveAll(dir)
+	path := filepath.Join(dir, "app.yaml")
+	os.WriteFile(path, []byte("server:\n  addr: 0.0.0.0:9000\n  read_timeout: 30s\nlog:\n  level: warn\n"), 0o644)
+
+	// Example 1: Every source in one Builder. The environment beats the
+	// file and Set beats both, whatever order they were given in
+	env := map[string]string{"APP_DATABASE_MAX_CONNS": "50", "APP_LOG_LEVEL": "info"}
+	cfg, err := config.NewBuilder().
+		EnvFrom("APP", func(name string) (string, bool) { v, ok := env[name]; return v, ok }).
+		File(path).
+		Set("log.format", "json").
+		Build()
+	if err != nil {
+		fmt.Println("Error:", err)
+		return
+	}
+	fmt.Printf("Config: %+v %+v %+v\n", cfg.Server, cfg.Database, cfg.Log)
+	for _, key := range config.Keys() {
+		fmt.Printf("  %-20s from %s\n", key, strings.ReplaceAll(cfg.Origin(key), dir+string(filepath.Separator), ""))
+	}
+
+	// Example 2: Build reports every problem at once
+	_, err = config.NewBuilder().Set("database.max_conns", "0").Set("log.level", "loud").Set("server.port", "80").Build()
+	fmt.Println("Error:", err)
+
+	// Example 3: A Store reloads the file when it changes and keeps the
+	// last good Config when the new one is invalid
+	store, _ := config.NewStore(func() (config.Config, error) { return config.NewBuilder().File(path).Build() })
+	reloaded := make(chan error)
+	store.OnReload(func(c config.Config, err error) {
+		fmt.Printf("Reloaded: log level %s, error: %v\n", c.Log.Level, err)
+		reloaded <- err
+	})
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+	store.Watch(ctx, path, 10*time.Millisecond)
+	for i, level := range []string{"debug", "loud"} {
+		// The new file is written next to the old one and renamed over it,
+		// so the watcher sees one change. Its modification time is moved on
+		// in case the file system only keeps whole seconds
+		tmp := path + ".tmp"
+		os.WriteFile(tmp, []byte("log:\n  level: "+level+"\n"), 0o644)
+		later := time.Now().Add(time.Duration(i+1) * time.Minute)
+		os.Chtimes(tmp, later, later)
+		os.Rename(tmp, path)
+		<-reloaded
+	}
+	fmt.Println("Current log level:", store.Config().Log.Level)
+}
+
+// runReport demonstrates a report generator built from the Template Method
+// and the Strategy
+func runReport() {
+	// Example 1: One template, three kinds of report. Only the Aggregator
+	// and the Format change; Generate runs the steps in the same order
+	sales := func() report.Loader { return report.CSVLoader{R: strings.NewReader(report.SampleSales)} }
+	for _, tt := range []struct {
+		by     report.Field
+		format string
+	}{
+		{report.ByRegion, "markdown"},
+		{report.ByMonth, "csv"},
+		{report.ByProduct, "json"},
+	} {
+		format, err := report.FormatByName(tt.format)
+		if err != nil {
+			fmt.Println("Error:", err)
+			return
+		}
+		g := report.Generator{Loader: sales(), Aggregator: report.SalesBy(tt.by), Format: format}
+		fmt.Printf("--- by %s, as %s ---\n", strings.ToLower(tt.by.String()), format.Name())
+		if err := g.Generate(os.Stdout); err != nil {
+			fmt.Println("Error:", err)
+		}
+	}
+
+	// Example 2: A failing step is named in the error, and nothing is written
+	bad := "date,region,product,units,price\n2024-01-05,North,Latte,a dozen,3.50\n"
+	g := report.Generator{Loader: report.CSVLoader{R: strings.NewReader(bad)}, Aggregator: report.SalesBy(report.ByRegion), Format: report.CSV{}}
+	fmt.Println("Error:", g.Generate(os.Stdout))
+	_, err := report.FormatByName("pdf")
+	fmt.Println("Error:", err)
+}
+
+// runFSM demonstrates a state machine described as a table of transitions
+func runFSM() {
+	// Example 1: An order, built with the DSL. Paying needs enough money,
+	// and charges it on the way
+	balance := 120
+	order, err := fsm.New().
+		From("pending").On("pay").To("paid").
+		When("funds", func(fsm.Transition) bool { return balance >= 100 }).
+		Do(func(fsm.Transition) error {
+			balance -= 100
+			fmt.Printf("  charged 100, balance %d\n", balance)
+			return nil
+		}).
+		From("paid").On("ship").To("shipped").
+		From("shipped").On("deliver").To("delivered").
+		From("pending", "paid").On("cancel").To("cancelled").
+		Build("pending")
+	if err != nil {
+		fmt.Println("Error:", err)
+		return
+	}
+	start := time.Date(2024, 5, 1, 9, 0, 0, 0, time.UTC)
+	clock := start
+	order.SetClock(func() time.Time { clock = clock.Add(90 * time.Minute); return clock })
+
+	for _, ev := range []fsm.Event{"ship", "pay", "ship", "cancel", "deliver"} {
+		if err := order.Fire(ev); err != nil {
+			fmt.Printf("%-8s Error: %v\n", ev, err)
+			continue
+		}
+		fmt.Printf("%-8s now %s, next %v\n", ev, order.Current(), order.Events())
+	}
+	fmt.Println("History:")
+	for _, r := range order.History() {
+		fmt.Printf("  %s  %v\n", r.At.Format("15:04"), r.Transition)
+	}
+
+	// Example 2: A guard that says no leaves the state alone
+	balance = 50
+	order.Reset()
+	fmt.Println("Can pay with 50:", order.Can("pay"))
+	fmt.Println("Error:", order.Fire("pay"))
+
+	// Example 3: The same table drawn with Graphviz
+	fmt.Print(order.DOT())
+
+	// Example 4: Build checks the table before anything runs
+	_, err = fsm.New().
+		From("idle").On("start").To("running").
+		From("idle").On("start").To("stopped").
+		Build("off")
+	fmt.Println("Error:", err)
+}
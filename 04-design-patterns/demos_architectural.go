@@ -0,0 +1,96 @@
+package main
+
+import (
+	"errors"
+	"fmt"
+
+	"github.com/NutProhmpiriya/go-basic/04-design-patterns/architectural"
+)
+
+// runCQRS demonstrates CQRS with an event-sourced aggregate
+func runCQRS() {
+	store := architectural.NewEventStore()
+	repo := architectural.NewInventoryRepository(store, 4)
+	commands := architectural.NewInventoryCommandHandler(repo)
+	readModel := architectural.NewInventoryReadModel(store)
+
+	for _, cmd := range []architectural.Command{
+		architectural.CreateItem{ID: "sku-1", Name: "Keyboard"},
+		architectural.CreateItem{ID: "sku-2", Name: "Mouse"},
+		architectural.AddStock{ID: "sku-1", Quantity: 10},
+		architectural.AddStock{ID: "sku-2", Quantity: 3},
+		architectural.RemoveStock{ID: "sku-1", Quantity: 4},
+		architectural.RemoveStock{ID: "sku-2", Quantity: 5}, // rejected: not enough stock
+		architectural.AddStock{ID: "sku-9", Quantity: 1},    // rejected: unknown item
+		architectural.RemoveStock{ID: "sku-1", Quantity: 1},
+		architectural.AddStock{ID: "sku-1", Quantity: 2},
+		architectural.DeactivateItem{ID: "sku-2"},
+	} {
+		if err := commands.Handle(cmd); err != nil {
+			fmt.Printf("%T rejected: %v\n", cmd, err)
+		}
+	}
+
+	// The log keeps every change; state is derived by replaying it
+	fmt.Println("Event log for sku-1:")
+	for _, e := range store.Load("sku-1", 0) {
+		fmt.Printf("  v%d %s %+v\n", e.Version, e.Event.EventName(), e.Event)
+	}
+	replayed := architectural.ReplayInventoryItem("sku-1", store.Load("sku-1", 0))
+	fmt.Printf("Replayed sku-1: stock %d at version %d\n", replayed.Stock(), replayed.Version())
+
+	// The repository took a snapshot at version 4, so loading replays only newer events
+	repo.Replayed = 0
+	loaded, _ := repo.Load("sku-1")
+	fmt.Printf("Loaded sku-1 via snapshot: stock %d, replayed %d event(s)\n", loaded.Stock(), repo.Replayed)
+
+	// Queries go to the read model, never to the aggregates
+	view, _ := readModel.Get("sku-1")
+	fmt.Printf("Read model sku-1: %+v\n", view)
+	fmt.Printf("Low stock (< 8): %+v\n", readModel.LowStock(8))
+
+	// Two writers loaded the same version: the second save is rejected
+	first, _ := repo.Load("sku-1")
+	second, _ := repo.Load("sku-1")
+	first.RemoveStock(1)
+	second.RemoveStock(1)
+	fmt.Printf("First save: %v\n", repo.Save(first))
+	err := repo.Save(second)
+	fmt.Printf("Second save: %v (conflict? %v)\n", err, errors.Is(err, architectural.ErrConcurrencyConflict))
+}
+
+// runUnitOfWork demonstrates the Unit of Work pattern
+func runUnitOfWork() {
+	bank := architectural.NewBank()
+	bank.OpenAccounts(
+		architectural.BankAccount{ID: "acc-1", Owner: "Alice", Balance: 100},
+		architectural.BankAccount{ID: "acc-2", Owner: "Bob", Balance: 20},
+		architectural.BankAccount{ID: "acc-3", Owner: "Carol"},
+	)
+	fmt.Printf("Transfer 30 acc-1 -> acc-2: %v\n", bank.TransferFunds("t1", "acc-1", "acc-2", 30))
+	fmt.Printf("Transfer 500 acc-2 -> acc-1: %v\n", bank.TransferFunds("t2", "acc-2", "acc-1", 500))
+	// The transfer ID t1 is already used: the audit insert fails, so neither
+	// balance update is written either
+	err := bank.TransferFunds("t1", "acc-1", "acc-2", 10)
+	fmt.Printf("Transfer reusing ID t1: %v (duplicate? %v)\n", err, errors.Is(err, architectural.ErrDuplicateEntity))
+	fmt.Printf("Close acc-3: %v, close acc-1: %v\n", bank.CloseAccount("acc-3"), bank.CloseAccount("acc-1"))
+	for _, a := range bank.Accounts.List() {
+		fmt.Printf("  %s %-5s %d\n", a.ID, a.Owner, a.Balance)
+	}
+	fmt.Printf("Transfers: %+v, commits: %d\n", bank.Transfers.List(), bank.Store.Commits())
+
+	// Changes to the same entity are merged before commit
+	uow := bank.Store.Begin()
+	temp := architectural.BankAccount{ID: "acc-tmp", Owner: "Temp"}
+	bank.Accounts.Add(uow, temp)
+	temp.Balance = 5
+	bank.Accounts.Update(uow, temp) // still one insert, with the latest data
+	alice, _ := bank.Accounts.Get("acc-1")
+	bank.Accounts.Update(uow, alice)
+	bank.Accounts.Remove(uow, alice) // update then remove: just a delete
+	inserts, updates, deletes := uow.Pending()
+	fmt.Printf("Pending: %d insert(s), %d update(s), %d delete(s)\n", inserts, updates, deletes)
+	uow.Rollback()
+	_, err = bank.Accounts.Get("acc-tmp")
+	fmt.Printf("After rollback: %v\n", err)
+}
@@ -0,0 +1,233 @@
+package main
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"os"
+	"sync"
+
+	"github.com/NutProhmpiriya/go-basic/04-design-patterns/behavioral"
+)
+
+// runObserver demonstrates the Observer pattern
+func runObserver() {
+	weatherStation := behavioral.NewWeatherStation()
+	display1 := behavioral.NewTemperatureDisplay("Display 1")
+	display2 := behavioral.NewTemperatureDisplay("Display 2")
+
+	weatherStation.RegisterObserver(display1)
+	token2 := weatherStation.RegisterObserver(display2)
+	weatherStation.SetTemperature(25.0)
+
+	// Example 1: The alarm registered last, but its priority tells it first.
+	// The faulty sensor panics, and the others are still told
+	weatherStation.RegisterObserverPriority(behavioral.ObserverFunc[float64](func(t float64) {
+		if t > 35 {
+			fmt.Printf("Alarm: %.1f°C is too hot\n", t)
+		}
+	}), 10)
+	weatherStation.RegisterObserver(behavioral.ObserverFunc[float64](func(t float64) {
+		if t > 40 {
+			panic("sensor overheated")
+		}
+	}))
+	weatherStation.RegisterObserver(behavioral.NewTemperatureDisplay("Display 3"))
+	weatherStation.RemoveObserver(token2) // display2 leaves by its token
+	err := weatherStation.SetTemperature(41.5)
+	fmt.Println("Error:", err)
+
+	// The error carries the token of the observer that panicked, which is
+	// enough to remove it
+	var p *behavioral.ObserverPanic
+	if errors.As(err, &p) {
+		weatherStation.RemoveObserver(p.Token)
+	}
+	fmt.Println("Error after removing it:", weatherStation.SetTemperature(42.0))
+
+	// Example 2: A subject of any event type
+	type alert struct {
+		Level   string
+		Message string
+	}
+	alerts := behavioral.NewSubject[alert]()
+	alerts.Register(behavioral.ObserverFunc[alert](func(a alert) {
+		fmt.Printf("[%s] %s\n", a.Level, a.Message)
+	}))
+	alerts.Notify(alert{"warning", "storm expected tonight"})
+}
+
+// runEventBus demonstrates the event bus variant of the Observer pattern
+func runEventBus() {
+	// Event bus: observers subscribe to topics instead of to a subject
+	bus := behavioral.NewEventBus(behavioral.SyncDispatch, 0)
+	sub := bus.Subscribe(context.Background(), "temperature", func(e behavioral.Event) {
+		fmt.Printf("Sync subscriber got %.1f°C\n", e.Payload)
+	})
+	bus.Publish(context.Background(), "temperature", 26.5)
+	sub.Unsubscribe()
+	bus.Publish(context.Background(), "temperature", 27.0) // nobody is listening
+
+	asyncBus := behavioral.NewEventBus(behavioral.AsyncDispatch, 8)
+	ctx, cancel := context.WithCancel(context.Background())
+	var received []string
+	asyncBus.Subscribe(ctx, "alerts", func(e behavioral.Event) {
+		received = append(received, e.Payload.(string))
+	})
+	for _, alert := range []string{"storm", "flood", "heatwave"} {
+		asyncBus.Publish(ctx, "alerts", alert)
+	}
+	asyncBus.Close() // waits for queued events to be handled
+	cancel()
+	fmt.Printf("Async subscriber got %v\n", received)
+}
+
+// runPubSub demonstrates the Publish-Subscribe pattern
+func runPubSub() {
+	// Pub/Sub broker: subscribers read from their own buffered channels,
+	// and a policy decides what happens when a slow subscriber's buffer is full
+	broker := behavioral.NewBroker()
+	blocking, _ := broker.Subscribe("prices", 2, behavioral.Block)
+	dropNewest, _ := broker.Subscribe("prices", 2, behavioral.DropNewest)
+	dropOldest, _ := broker.Subscribe("prices", 2, behavioral.DropOldest)
+
+	// Only the blocking subscriber reads while prices are published;
+	// the other two fall behind
+	var readerWG sync.WaitGroup
+	var blockingGot []any
+	readerWG.Add(1)
+	go func() {
+		defer readerWG.Done()
+		for msg := range blocking.Messages() {
+			blockingGot = append(blockingGot, msg.Payload)
+		}
+	}()
+	for price := 100; price <= 105; price++ {
+		broker.Publish(context.Background(), "prices", price)
+	}
+	broker.Close() // buffered messages can still be read after Close
+	readerWG.Wait()
+
+	drain := func(s *behavioral.Subscriber) []any {
+		var got []any
+		for msg := range s.Messages() {
+			got = append(got, msg.Payload)
+		}
+		return got
+	}
+	fmt.Printf("Block:      got %v\n", blockingGot)
+	fmt.Printf("DropNewest: got %v, dropped %d\n", drain(dropNewest), dropNewest.Dropped())
+	fmt.Printf("DropOldest: got %v, dropped %d\n", drain(dropOldest), dropOldest.Dropped())
+	fmt.Printf("Publish after Close: %v\n", broker.Publish(context.Background(), "prices", 106))
+}
+
+// runStrategy demonstrates the Strategy pattern
+func runStrategy() {
+	cart := behavioral.NewShoppingCart(behavioral.NewCreditCardStrategy("1234", "123"))
+	fmt.Println(cart.Checkout(100.0))
+
+	cart.SetPaymentStrategy(behavioral.NewPayPalStrategy("test@test.com", "password"))
+	fmt.Println(cart.Checkout(50.0))
+
+	// Select the strategy by name from configuration at runtime
+	registry := behavioral.NewDefaultStrategyRegistry()
+	registry.Register("cash", func(map[string]string) (behavioral.PaymentStrategy, error) {
+		return cashStrategy{}, nil
+	})
+	fmt.Printf("Registered strategies: %v\n", registry.Names())
+
+	configJSON := `{"method": "bitcoin", "options": {"address": "bc1qexample"}}`
+	var cfg behavioral.PaymentConfig
+	if err := json.Unmarshal([]byte(configJSON), &cfg); err != nil {
+		fmt.Println("Invalid config:", err)
+	}
+	for _, c := range []behavioral.PaymentConfig{cfg, {Method: "cash"}, {Method: "paypal"}, {Method: "gold"}} {
+		strategy, err := registry.FromConfig(c)
+		if err != nil {
+			fmt.Println("Error:", err)
+			continue
+		}
+		cart.SetPaymentStrategy(strategy)
+		fmt.Println(cart.Checkout(75.5))
+	}
+}
+
+// runChainOfResponsibility demonstrates the Chain of Responsibility pattern
+func runChainOfResponsibility() {
+	loggerChain := behavioral.NewLoggerChain(os.Stdout)
+
+	loggerChain.Log(behavioral.LogEntry{
+		Message: "This is an information.",
+		Level:   behavioral.INFO,
+	})
+
+	loggerChain.Log(behavioral.LogEntry{
+		Message: "This is a debug information.",
+		Level:   behavioral.DEBUG,
+	})
+
+	loggerChain.Log(behavioral.LogEntry{
+		Message: "This is an error information.",
+		Level:   behavioral.ERROR,
+	})
+
+	// WARN has no handler yet, so the fallback reports it instead of dropping it
+	warning := behavioral.LogEntry{Message: "This is a warning.", Level: behavioral.WARN}
+	loggerChain.Log(warning)
+	loggerChain.RegisterLevel(behavioral.WARN, "Warn")
+	loggerChain.Log(warning)
+
+	// The same generic chain approves HTTP requests: auth -> validation -> rate limit
+	approval := behavioral.NewApprovalChain(2, "token-123")
+	requests := []behavioral.ApprovalRequest{
+		{ClientID: "alice", Token: "token-123", Method: "GET", Path: "/orders"},
+		{ClientID: "bob", Token: "wrong", Method: "GET", Path: "/orders"},
+		{ClientID: "alice", Token: "token-123", Method: "POST", Path: "/orders"},
+		{ClientID: "alice", Token: "token-123", Method: "POST", Path: "/orders", Body: "{}"},
+		{ClientID: "alice", Token: "token-123", Method: "GET", Path: "/orders"},
+	}
+	for _, req := range requests {
+		if err := approval.Handle(req); err != nil {
+			fmt.Printf("%s %s from %s: rejected (%v)\n", req.Method, req.Path, req.ClientID, err)
+		} else {
+			fmt.Printf("%s %s from %s: approved\n", req.Method, req.Path, req.ClientID)
+		}
+	}
+	fmt.Printf("Approval steps: %v\n", approval.Names())
+}
+
+// runNullObject demonstrates the Null Object pattern
+func runNullObject() {
+	directory := behavioral.NewCustomerDirectory()
+	for _, id := range []string{"c1", "unknown"} {
+		// No nil check needed: unknown IDs get a GuestCustomer
+		fmt.Println(behavioral.Price(directory.Find(id), 100))
+	}
+
+	name := behavioral.MapOption(directory.Lookup("c2"), behavioral.Customer.Name)
+	fmt.Printf("Lookup c2: %v\n", name)
+	missing := directory.Lookup("c9")
+	fmt.Printf("Lookup c9: %v, OrElse: %s\n", missing,
+		behavioral.MapOption(missing, behavioral.Customer.Name).OrElse(func() string { return "nobody" }))
+
+	// Result chains steps that may fail without an if err != nil after each one
+	for _, input := range []string{" 3 ", "three"} {
+		total := behavioral.MapResult(behavioral.ParseQuantity(input), func(n int) float64 {
+			return float64(n) * 2.50
+		})
+		fmt.Printf("Quantity %q -> %v, or 0: %.2f\n", input, total,
+			total.OrElse(func(error) float64 { return 0 }))
+	}
+	// Get turns a Result back into the (value, error) idiom
+	if _, err := behavioral.ParseQuantity("x").Get(); err != nil {
+		fmt.Println("As (value, error):", err)
+	}
+}
+
+// cashStrategy is a payment strategy registered from outside the behavioral package
+type cashStrategy struct{}
+
+func (cashStrategy) Pay(amount float64) string {
+	return fmt.Sprintf("Paid %.2f in cash", amount)
+}
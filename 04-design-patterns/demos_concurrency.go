@@ -0,0 +1,179 @@
+package main
+
+import (
+	"context"
+	"fmt"
+	"strings"
+	"sync"
+	"sync/atomic"
+	"time"
+
+	"github.com/NutProhmpiriya/go-basic/04-design-patterns/concurrency"
+)
+
+// runSemaphore demonstrates the Semaphore pattern
+func runSemaphore() {
+	// Downloads share a budget of 4 units; large files take 2 units
+	downloads := []struct {
+		name   string
+		sizeMB int
+	}{
+		{"small-1.zip", 5}, {"movie.mp4", 40}, {"small-2.zip", 8}, {"backup.tar", 60},
+		{"small-3.zip", 3}, {"image.iso", 50}, {"small-4.zip", 6},
+	}
+	sem := concurrency.NewWeighted(4)
+	var inUse sync.Mutex
+	current, maxInUse := int64(0), int64(0)
+	var downloadWG sync.WaitGroup
+	for _, d := range downloads {
+		weight := int64(1)
+		if d.sizeMB > 20 {
+			weight = 2
+		}
+		if err := sem.Acquire(context.Background(), weight); err != nil {
+			fmt.Println("Error:", err)
+			continue
+		}
+		inUse.Lock()
+		current += weight
+		maxInUse = max(maxInUse, current)
+		inUse.Unlock()
+
+		downloadWG.Add(1)
+		go func() {
+			defer downloadWG.Done()
+			time.Sleep(time.Duration(d.sizeMB) * time.Millisecond / 2) // simulated download
+			inUse.Lock()
+			current -= weight
+			inUse.Unlock()
+			sem.Release(weight)
+		}()
+	}
+	downloadWG.Wait()
+	fmt.Printf("Downloaded %d files, peak units in use: %d of 4\n", len(downloads), maxInUse)
+	fmt.Printf("TryAcquire(4) on idle semaphore: %v\n", sem.TryAcquire(4))
+	fmt.Printf("TryAcquire(1) while full: %v\n", sem.TryAcquire(1))
+	sem.Release(4)
+}
+
+// runRateLimit demonstrates the Rate Limiting pattern
+func runRateLimit() {
+	bucket := concurrency.NewTokenBucket(1, 3, nil)
+	allowed := 0
+	for i := 0; i < 5; i++ {
+		if bucket.Allow() {
+			allowed++
+		}
+	}
+	fmt.Printf("Allow() 5 times on a bucket with burst 3: %d allowed\n", allowed)
+
+	// 10 tasks, at most 20 starts per second (burst 2), at most 3 running at once
+	executor := concurrency.NewRateLimitedExecutor(3, concurrency.NewTokenBucket(20, 2, nil))
+	start := time.Now()
+	for i := 1; i <= 10; i++ {
+		executor.Submit(context.Background(), func(ctx context.Context) error {
+			time.Sleep(10 * time.Millisecond)
+			if i%5 == 0 {
+				return fmt.Errorf("task %d failed", i)
+			}
+			return nil
+		})
+	}
+	err := executor.Wait()
+	// 2 tasks start from the burst, the other 8 wait 50ms each for a token
+	fmt.Printf("Ran 10 tasks in ~%v (expected ~400ms)\n", time.Since(start).Round(100*time.Millisecond))
+	fmt.Printf("Task errors: %v\n", strings.ReplaceAll(err.Error(), "\n", "; "))
+}
+
+// runFuture demonstrates the Future pattern
+func runFuture() {
+	fetchPrice := func(symbol string, delay time.Duration) *concurrency.Future[float64] {
+		return concurrency.Async(context.Background(), func(ctx context.Context) (float64, error) {
+			time.Sleep(delay)
+			return float64(len(symbol)) * 10.5, nil
+		})
+	}
+	// Both lookups run at the same time
+	start := time.Now()
+	prices, err := concurrency.AwaitAll(context.Background(),
+		fetchPrice("GOOG", 50*time.Millisecond), fetchPrice("AAPL", 50*time.Millisecond))
+	fmt.Printf("Prices %v (err %v) in ~%v\n", prices, err, time.Since(start).Round(50*time.Millisecond))
+
+	slow := fetchPrice("SLOW", 200*time.Millisecond)
+	_, err = slow.GetWithTimeout(20 * time.Millisecond)
+	fmt.Printf("Waiting 20ms for a 200ms task: %v\n", err)
+	price, _ := slow.Get()
+	fmt.Printf("Waiting until done: %.1f\n", price)
+
+	broken := concurrency.Async(context.Background(), func(context.Context) (int, error) {
+		var m map[string]int
+		m["boom"] = 1 // panics: assignment to a nil map
+		return 0, nil
+	})
+	_, err = broken.Get()
+	fmt.Printf("Panicking task: %v\n", err)
+
+	promise := concurrency.NewPromise[string]()
+	go promise.Complete("resolved by another goroutine", nil)
+	message, _ := promise.Future().Get()
+	fmt.Printf("Promise: %s (second Complete accepted? %v)\n", message, promise.Complete("too late", nil))
+}
+
+// runSingleflight demonstrates the Single-flight pattern
+func runSingleflight() {
+	var group concurrency.Group[string, string]
+	var dbCalls, sharedResults atomic.Int32
+	var flightWG sync.WaitGroup
+	for i := 0; i < 10; i++ {
+		flightWG.Add(1)
+		go func() {
+			defer flightWG.Done()
+			_, _, shared := group.Do("user:42", func() (string, error) {
+				dbCalls.Add(1)
+				time.Sleep(50 * time.Millisecond) // slow database query
+				return "Alice", nil
+			})
+			if shared {
+				sharedResults.Add(1)
+			}
+		}()
+	}
+	flightWG.Wait()
+	fmt.Printf("10 concurrent lookups -> %d database call(s), %d callers got a shared result\n",
+		dbCalls.Load(), sharedResults.Load())
+}
+
+// runActor demonstrates the Actor pattern
+func runActor() {
+	ledger := concurrency.NewLedger()
+	account := concurrency.NewBankAccount("alice", ledger, concurrency.Supervision{
+		MaxRestarts: 1,
+		OnFailure: func(actor string, reason any, restarted bool) {
+			fmt.Printf("Supervisor: %s failed (%v), restarted: %v\n", actor, reason, restarted)
+		},
+	})
+
+	// 50 goroutines deposit concurrently; the actor handles them one by one without locks
+	var depositWG sync.WaitGroup
+	for i := 0; i < 50; i++ {
+		depositWG.Add(1)
+		go func() {
+			defer depositWG.Done()
+			account.Deposit(10)
+		}()
+	}
+	depositWG.Wait()
+	balance, _ := account.Balance()
+	fmt.Printf("Balance after 50 deposits of 10: %d\n", balance)
+	fmt.Printf("Withdraw 1000: %v\n", account.Withdraw(1000))
+
+	// The first crash is supervised: the actor restarts and reloads its balance
+	account.Crash()
+	balance, err := account.Balance()
+	fmt.Printf("Balance after restart: %d (err %v)\n", balance, err)
+
+	// The second crash uses up the restart budget and the actor stops
+	account.Crash()
+	fmt.Printf("Deposit after giving up: %v\n", account.Deposit(10))
+	account.Stop()
+}
@@ -0,0 +1,212 @@
+package main
+
+import (
+	"errors"
+	"fmt"
+	"os"
+	"sync"
+	"sync/atomic"
+
+	"github.com/NutProhmpiriya/go-basic/04-design-patterns/creational"
+	"github.com/NutProhmpiriya/go-basic/04-design-patterns/plugins"
+	_ "github.com/NutProhmpiriya/go-basic/04-design-patterns/plugins/filestore" // registers "file"
+	_ "github.com/NutProhmpiriya/go-basic/04-design-patterns/plugins/memory"    // registers "memory"
+)
+
+// runSingleton demonstrates the Singleton pattern
+func runSingleton() {
+	singleton1 := creational.GetInstance()
+	singleton2 := creational.GetInstance()
+
+	singleton1.IncrementCount()
+	fmt.Printf("Singleton1 count: %d\n", singleton1.GetCount())
+	fmt.Printf("Singleton2 count: %d\n", singleton2.GetCount())
+
+	// Hammer the singleton from many goroutines
+	// Run with `go run -race .` to let the race detector confirm it is safe
+	var wg sync.WaitGroup
+	for i := 0; i < 100; i++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			for j := 0; j < 100; j++ {
+				creational.GetInstance().IncrementCount()
+			}
+		}()
+	}
+	wg.Wait()
+	fmt.Printf("Count after 100 goroutines x 100 increments: %d (expected 10001)\n", singleton1.GetCount())
+
+	creational.ResetForTesting()
+	fmt.Printf("Same instance after reset? %v\n", creational.GetInstance() == singleton1)
+}
+
+// runSingletonStrategies compares when and how often the singleton
+// strategies create their value; go test -bench Singleton in creational
+// compares what they cost
+func runSingletonStrategies() {
+	// appConfig stands for an expensive value; made counts the calls
+	type appConfig struct{ name string }
+	var made atomic.Int64
+	newConfig := func() *appConfig {
+		made.Add(1)
+		return &appConfig{name: "app"}
+	}
+
+	// Example 1: Eager initialization runs before anyone asks
+	eager := creational.NewEagerSingleton(newConfig)
+	fmt.Printf("Eager: made %d before the first Get\n", made.Load())
+	eager.Get()
+
+	// Example 2: The lazy strategies wait for the first Get, and sync.Once
+	// and double-checked locking make the value once however many
+	// goroutines ask at the same time
+	for _, s := range []struct {
+		name string
+		lazy creational.Lazy[*appConfig]
+	}{
+		{"sync.Once", creational.NewOnceSingleton(newConfig)},
+		{"double-checked locking", creational.NewLazySingleton(newConfig)},
+	} {
+		made.Store(0)
+		var wg sync.WaitGroup
+		var different atomic.Int64
+		first := s.lazy.Get()
+		for range 100 {
+			wg.Add(1)
+			go func() {
+				defer wg.Done()
+				if s.lazy.Get() != first {
+					different.Add(1)
+				}
+			}()
+		}
+		wg.Wait()
+		fmt.Printf("%s: made %d for 101 Gets, always the same value: %v\n", s.name, made.Load(), different.Load() == 0)
+	}
+
+	// Example 3: The lock-free atomic.Pointer strategy can make the value
+	// more than once. The barrier in init holds the first two goroutines
+	// until both are inside, the race that sync.Once would have prevented
+	var inside sync.WaitGroup
+	inside.Add(2)
+	atomicCfg := creational.NewAtomicSingleton(func() *appConfig {
+		inside.Done()
+		inside.Wait()
+		return newConfig()
+	})
+	made.Store(0)
+	results := make([]*appConfig, 2)
+	var wg sync.WaitGroup
+	for i := range results {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			results[i] = atomicCfg.Get()
+		}()
+	}
+	wg.Wait()
+	fmt.Printf("atomic.Pointer: made %d for 2 racing Gets, both got the winner: %v\n",
+		atomicCfg.Inits(), results[0] == results[1] && results[0] == atomicCfg.Get())
+}
+
+// runFactory demonstrates the Factory pattern
+func runFactory() {
+	for _, paymentType := range []creational.PaymentType{creational.CreditCardType, creational.PayPalType, "bitcoin"} {
+		method, err := creational.PaymentFactory(paymentType)
+		if err != nil {
+			fmt.Printf("Error: %v (unknown? %v)\n", err, errors.Is(err, creational.ErrUnknownPaymentMethod))
+			continue
+		}
+		fmt.Println(method.Pay(100.0))
+	}
+
+	// bank-transfer registered itself, without an edit to the factory
+	transfer, _ := creational.PaymentFactory("bank-transfer")
+	fmt.Println(transfer.Pay(50.0))
+	fmt.Println("Payment methods:", creational.PaymentMethods())
+}
+
+// bankTransfer is a payment method from outside the creational package
+type bankTransfer struct{}
+
+func init() {
+	creational.RegisterPaymentMethod("bank-transfer", func() creational.PaymentMethod {
+		return bankTransfer{}
+	})
+}
+
+func (bankTransfer) Pay(amount float64) string {
+	return fmt.Sprintf("Paid %.2f by bank transfer", amount)
+}
+
+// runBuilder demonstrates the Builder pattern
+func runBuilder() {
+	builder := creational.NewComputerBuilder()
+	director := creational.NewDirector(builder)
+
+	// Example 1: Two recipes from one director
+	// Each recipe works on a clone of the base builder and gets its own
+	// copy of the product
+	gamingPC, _ := director.BuildGamingPC()
+	officePC, _ := director.BuildOfficePC()
+	fmt.Printf("Gaming PC: %+v\n", gamingPC)
+	fmt.Printf("Office PC: %+v\n", officePC)
+
+	// Example 2: A product is a copy, so changing it changes nothing else
+	gamingPC.RAM = 64
+	again, _ := director.BuildGamingPC()
+	fmt.Printf("Changed copy: %d GB, next gaming PC: %d GB\n", gamingPC.RAM, again.RAM)
+
+	// Example 3: Build validates the whole configuration
+	_, err := creational.NewComputerBuilder().SetCPU("AMD Ryzen 5").SetRAM(0).Build()
+	fmt.Println("Error:", err)
+	fmt.Println("Invalid computer?", errors.Is(err, creational.ErrInvalidComputer))
+
+	// Example 4: Variations of a base configuration
+	// The clones start from the base and leave it as it was
+	base := creational.NewComputerBuilder().SetCPU("AMD Ryzen 7").SetRAM(32).SetStorage(1000)
+	workstation, _ := base.Clone().SetGPU("RTX 4000 Ada").Build()
+	laptop, _ := base.Clone().SetRAM(16).SetBluetooth(true).Build()
+	plain, _ := base.Build()
+	fmt.Printf("Workstation: %+v\n", workstation)
+	fmt.Printf("Laptop: %+v\n", laptop)
+	fmt.Printf("Base: %+v\n", plain)
+
+	// Example 5: A director with a different base changes every recipe
+	wireless := creational.NewDirector(creational.NewComputerBuilder().SetBluetooth(true))
+	officePC, _ = wireless.BuildOfficePC()
+	fmt.Printf("Office PC from a Bluetooth base: %+v\n", officePC)
+}
+
+// runPluginRegistry demonstrates the Plugin Registration pattern
+func runPluginRegistry() {
+	// The blank imports above ran each driver's init, which registered it
+	fmt.Printf("Registered drivers: %v\n", plugins.Drivers())
+
+	dataFile, _ := os.CreateTemp("", "plugins-*.txt")
+	dataFile.Close()
+	defer os.Remove(dataFile.Name())
+
+	// Driver names would normally come from configuration
+	for _, cfg := range []struct{ driver, dsn string }{
+		{"memory", ""},
+		{"file", dataFile.Name()},
+		{"redis", "localhost:6379"},
+	} {
+		kv, err := plugins.Open(cfg.driver, cfg.dsn)
+		if err != nil {
+			fmt.Printf("%s: %v (unknown? %v)\n", cfg.driver, err, errors.Is(err, plugins.ErrUnknownDriver))
+			continue
+		}
+		kv.Set("greeting", "hello from "+cfg.driver)
+		greeting, _ := kv.Get("greeting")
+		_, err = kv.Get("missing")
+		fmt.Printf("%s: %q, missing key: %v\n", cfg.driver, greeting, err)
+		kv.Close()
+	}
+	// The file driver persists data across Open calls
+	reopened, _ := plugins.Open("file", dataFile.Name())
+	greeting, _ := reopened.Get("greeting")
+	fmt.Printf("file after reopen: %q\n", greeting)
+}
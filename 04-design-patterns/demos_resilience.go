@@ -0,0 +1,64 @@
+package main
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"math/rand"
+	"time"
+
+	"github.com/NutProhmpiriya/go-basic/04-design-patterns/resilience"
+)
+
+// runRetry demonstrates the Retry pattern
+func runRetry() {
+	// flaky fails the first `failures` calls, then succeeds
+	flaky := func(failures int) func(context.Context) error {
+		calls := 0
+		return func(context.Context) error {
+			calls++
+			if calls <= failures {
+				return fmt.Errorf("call %d: connection refused", calls)
+			}
+			return nil
+		}
+	}
+
+	// A fake clock records the waits instead of sleeping
+	clock := resilience.NewFakeClock(time.Time{})
+	retrier := resilience.Retrier{
+		MaxAttempts: 5,
+		Backoff:     resilience.ExponentialBackoff{Initial: 100 * time.Millisecond, Multiplier: 2, Max: time.Second},
+		Clock:       clock,
+		OnRetry: func(attempt int, err error, delay time.Duration) {
+			fmt.Printf("Attempt %d failed (%v), retrying in %v\n", attempt, err, delay)
+		},
+	}
+	err := retrier.Do(context.Background(), flaky(3))
+	fmt.Printf("Result: %v, waited %v\n", err, clock.Waits())
+
+	err = retrier.Do(context.Background(), flaky(10))
+	fmt.Printf("Result: %v (exhausted? %v)\n", err, errors.Is(err, resilience.ErrRetriesExhausted))
+
+	// Jitter spreads the waits of many clients apart
+	jitterClock := resilience.NewFakeClock(time.Time{})
+	retrier.Backoff = resilience.FullJitter(retrier.Backoff, rand.New(rand.NewSource(1)))
+	retrier.Clock = jitterClock
+	retrier.OnRetry = nil
+	retrier.Do(context.Background(), flaky(4))
+	fmt.Printf("Waits with full jitter: %v\n", jitterClock.Waits())
+
+	// Permanent errors are not retried
+	attempts := 0
+	err = retrier.Do(context.Background(), func(context.Context) error {
+		attempts++
+		return resilience.Permanent(errors.New("invalid API key"))
+	})
+	fmt.Printf("Permanent error: %v after %d attempt(s)\n", err, attempts)
+
+	// Cancelling the context stops waiting for the next attempt (real clock here)
+	timeoutCtx, cancelTimeout := context.WithTimeout(context.Background(), 50*time.Millisecond)
+	defer cancelTimeout()
+	err = resilience.Retry(timeoutCtx, 5, resilience.ConstantBackoff{Interval: time.Second}, flaky(10))
+	fmt.Printf("With timeout: %v (deadline exceeded? %v)\n", err, errors.Is(err, context.DeadlineExceeded))
+}
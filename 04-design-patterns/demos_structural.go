@@ -0,0 +1,137 @@
+package main
+
+import (
+	"errors"
+	"fmt"
+	"os"
+	"time"
+
+	"github.com/NutProhmpiriya/go-basic/04-design-patterns/structural"
+)
+
+// runAdapter demonstrates the Adapter pattern
+func runAdapter() {
+	// Example 1: The adapter turns the legacy XML into JSON, and its error
+	// documents into Go errors
+	legacy := structural.NewLegacyWeatherClient()
+	var api structural.WeatherAPI = structural.NewLegacyAdapter(legacy)
+	for _, station := range []string{"BKK", "DMK", "XYZ"} {
+		data, err := api.Current(station)
+		if err != nil {
+			fmt.Printf("%s: %v (not found? %v)\n", station, err, errors.Is(err, structural.ErrStationNotFound))
+			continue
+		}
+		fmt.Printf("%s: %s\n", station, data)
+	}
+	legacy.Offline = true
+	_, err := api.Current("BKK")
+	fmt.Printf("Offline: %v (unavailable? %v)\n", err, errors.Is(err, structural.ErrServiceUnavailable))
+
+	// Example 2: A two-way adapter over a new JSON service still answers
+	// the old callers in XML
+	store := structural.NewWeatherStore(structural.Observation{
+		Station: "BKK", TemperatureC: 33, Humidity: 70,
+		ObservedAt: time.Date(2024, 4, 15, 14, 30, 0, 0, time.UTC),
+	})
+	both := structural.TwoWayFromAPI(store)
+	data, _ := both.Current("BKK")
+	fmt.Println("JSON:", string(data))
+	for _, station := range []string{"BKK", "XYZ"} {
+		doc, _ := both.Fetch(station)
+		fmt.Println("XML:", doc)
+	}
+}
+
+// runDecorator demonstrates the Decorator pattern
+func runDecorator() {
+	coffee := &structural.SimpleCoffee{}
+	coffeeWithMilk := structural.NewMilkDecorator(coffee)
+	coffeeWithMilkAndSugar := structural.NewSugarDecorator(coffeeWithMilk)
+
+	fmt.Printf("Cost: %.2f, Description: %s\n",
+		coffeeWithMilkAndSugar.GetCost(),
+		coffeeWithMilkAndSugar.GetDescription())
+
+	// Decorators as functions: the same composition used by HTTP middleware
+	latte := structural.Decorate[structural.Coffee](&structural.SimpleCoffee{},
+		structural.NewMilkDecorator, structural.NewWhipDecorator)
+	fmt.Printf("Cost: %.2f, Description: %s\n", latte.GetCost(), latte.GetDescription())
+
+	// Order matters: the same decorators in the order a customer lists
+	// them, and in the order of their priorities
+	listed := []structural.Decorator[structural.Coffee]{
+		structural.NewDiscountDecorator(10), structural.NewWhipDecorator, structural.NewSizeDecorator(structural.Large),
+	}
+	asListed := structural.Decorate[structural.Coffee](&structural.SimpleCoffee{}, listed...)
+	fmt.Printf("As listed: %.2f, Description: %s\n", asListed.GetCost(), asListed.GetDescription())
+
+	// Orders name their extras in any order; the priorities put the size
+	// inside the toppings and the discount outside. The coupon is good for
+	// two coffees, so the third pays full price
+	coupon := structural.NewCoupon(10, 2)
+	menu := map[string]structural.Ordered[structural.Coffee]{
+		"small":  {Name: "small", Priority: structural.PrioritySize, Decorate: structural.NewSizeDecorator(structural.Small)},
+		"large":  {Name: "large", Priority: structural.PrioritySize, Decorate: structural.NewSizeDecorator(structural.Large)},
+		"milk":   {Name: "milk", Priority: structural.PriorityTopping, Decorate: structural.NewMilkDecorator},
+		"sugar":  {Name: "sugar", Priority: structural.PriorityTopping, Decorate: structural.NewSugarDecorator},
+		"whip":   {Name: "whip", Priority: structural.PriorityTopping, Decorate: structural.NewWhipDecorator},
+		"coupon": {Name: "coupon", Priority: structural.PriorityDiscount, Decorate: coupon.Apply},
+	}
+	for _, order := range [][]string{
+		{"coupon", "whip", "large"},
+		{"milk", "coupon", "small"},
+		{"coupon", "sugar", "large", "milk"},
+	} {
+		extras := make([]structural.Ordered[structural.Coffee], len(order))
+		for i, name := range order {
+			extras[i] = menu[name]
+		}
+		coffee := structural.DecorateOrdered[structural.Coffee](&structural.SimpleCoffee{}, extras...)
+		fmt.Printf("Cost: %.2f, Description: %s\n", coffee.GetCost(), coffee.GetDescription())
+	}
+	fmt.Println("Coupon uses left:", coupon.Remaining())
+}
+
+// runMiddleware demonstrates HTTP-style middleware built from decorators
+func runMiddleware() {
+	hello := func(r structural.Request) structural.Response {
+		return structural.Response{Status: 200, Body: "Hello from " + r.Path}
+	}
+	handler := structural.Chain(hello,
+		structural.LoggingMiddleware(os.Stdout),
+		structural.TimingMiddleware(os.Stdout),
+		structural.AuthMiddleware("secret"),
+	)
+	resp := handler(structural.Request{Method: "GET", Path: "/coffee",
+		Headers: map[string]string{"Authorization": "Bearer secret"}})
+	fmt.Printf("Response: %d %s\n", resp.Status, resp.Body)
+	resp = handler(structural.Request{Method: "GET", Path: "/coffee"})
+	fmt.Printf("Response: %d %s\n", resp.Status, resp.Body)
+}
+
+// runFacade demonstrates the Facade pattern
+func runFacade() {
+	// Example 1: Start and Shutdown hide the order the parts must run in
+	computer := structural.NewComputerFacade()
+	printSteps(computer.Start())
+	printSteps(computer.Start())
+	printSteps(computer.Shutdown())
+
+	// Example 2: A failing part makes Start undo what it did before it
+	faulty := structural.NewComputerFacadeWith(&structural.CPU{}, structural.NewMemory(64<<10),
+		structural.NewHardDrive(nil))
+	steps, err := faulty.Start()
+	printSteps(steps, err)
+	fmt.Println("Disk error?", errors.Is(err, structural.ErrDiskRead))
+	printSteps(faulty.Shutdown())
+}
+
+// printSteps prints what the facade did, then its error if there is one
+func printSteps(steps []string, err error) {
+	for _, step := range steps {
+		fmt.Println(step)
+	}
+	if err != nil {
+		fmt.Println("Error:", err)
+	}
+}
@@ -0,0 +1,371 @@
+package main
+
+// The examples run every demo and compare what it prints with the Output
+// comment, so a demo that stops printing what it claims fails go test
+
+func Example_singleton() {
+	runSingleton()
+	// Output:
+	// Singleton1 count: 1
+	// Singleton2 count: 1
+	// Count after 100 goroutines x 100 increments: 10001 (expected 10001)
+	// Same instance after reset? false
+}
+
+func Example_factory() {
+	runFactory()
+	// Output:
+	// Paid using Credit Card
+	// Paid using PayPal
+	// Error: unknown payment method "bitcoin" (unknown? true)
+	// Paid 50.00 by bank transfer
+	// Payment methods: [bank-transfer credit-card debit-card paypal]
+}
+
+func Example_singletonStrategies() {
+	runSingletonStrategies()
+	// Output:
+	// Eager: made 1 before the first Get
+	// sync.Once: made 1 for 101 Gets, always the same value: true
+	// double-checked locking: made 1 for 101 Gets, always the same value: true
+	// atomic.Pointer: made 2 for 2 racing Gets, both got the winner: true
+}
+
+func Example_builder() {
+	runBuilder()
+	// Output:
+	// Gaming PC: {CPU:Intel i9 RAM:32 Storage:2000 GPU:RTX 4080 Bluetooth:true}
+	// Office PC: {CPU:Intel i5 RAM:16 Storage:512 GPU:Integrated Bluetooth:false}
+	// Changed copy: 64 GB, next gaming PC: 32 GB
+	// Error: invalid computer: RAM must be positive, got 0 GB; storage must be positive, got 0 GB
+	// Invalid computer? true
+	// Workstation: {CPU:AMD Ryzen 7 RAM:32 Storage:1000 GPU:RTX 4000 Ada Bluetooth:false}
+	// Laptop: {CPU:AMD Ryzen 7 RAM:16 Storage:1000 GPU:Integrated Bluetooth:true}
+	// Base: {CPU:AMD Ryzen 7 RAM:32 Storage:1000 GPU:Integrated Bluetooth:false}
+	// Office PC from a Bluetooth base: {CPU:Intel i5 RAM:16 Storage:512 GPU:Integrated Bluetooth:true}
+}
+
+func Example_plugin() {
+	runPluginRegistry()
+	// Output:
+	// Registered drivers: [file memory]
+	// memory: "hello from memory", missing key: key not found: missing
+	// file: "hello from file", missing key: key not found: missing
+	// redis: unknown driver "redis" (forgotten import?) (unknown? true)
+	// file after reopen: "hello from file"
+}
+
+func Example_adapter() {
+	runAdapter()
+	// Output:
+	// BKK: {"station":"BKK","temperature_c":33,"humidity_percent":70,"observed_at":"2024-04-15T14:30:00Z"}
+	// DMK: weather service unavailable: DMK: station under maintenance (not found? false)
+	// XYZ: station not found: XYZ (not found? true)
+	// Offline: weather service unavailable: legacy weather: connection refused (unavailable? true)
+	// JSON: {"station":"BKK","temperature_c":33,"humidity_percent":70,"observed_at":"2024-04-15T14:30:00Z"}
+	// XML: <reading id="BKK"><tempF>91.4</tempF><rh>70%</rh><time>202404151430</time></reading>
+	// XML: <error code="404">unknown station</error>
+}
+
+func Example_decorator() {
+	runDecorator()
+	// Output:
+	// Cost: 1.70, Description: Simple coffee, milk, sugar
+	// Cost: 2.20, Description: Simple coffee, milk, whip
+	// As listed: 2.40, Description: Simple coffee, 10% off, whip (large)
+	// Cost: 1.98, Description: Simple coffee (large), whip, 10% off
+	// Cost: 1.17, Description: Simple coffee (small), milk, 10% off
+	// Cost: 2.20, Description: Simple coffee (large), sugar, milk
+	// Coupon uses left: 0
+}
+
+// The timing middleware prints a duration that changes on every run, so
+// this example is compiled but not run
+func Example_middleware() {
+	runMiddleware()
+}
+
+func Example_facade() {
+	runFacade()
+	// Output:
+	// CPU: Freezing...
+	// HardDrive: Spinning up
+	// HardDrive: Reading 512 B from BOOT_SECTOR
+	// Memory: Loading 512 B to 0x00
+	// CPU: Jumping to 0x00
+	// CPU: Executing...
+	// Error: computer is already running
+	// CPU: Halting
+	// Memory: Freeing 0x00
+	// HardDrive: Parking heads
+	// CPU: Freezing...
+	// HardDrive: Spinning up
+	// Start failed: disk read error: BOOT_SECTOR: bad sector
+	// HardDrive: Parking heads
+	// CPU: Thawing
+	// Error: start: disk read error: BOOT_SECTOR: bad sector
+	// Disk error? true
+	// Error: computer is not running
+}
+
+func Example_observer() {
+	runObserver()
+	// Output:
+	// Display 1 shows temperature: 25.0°C
+	// Display 2 shows temperature: 25.0°C
+	// Alarm: 41.5°C is too hot
+	// Display 1 shows temperature: 41.5°C
+	// Display 3 shows temperature: 41.5°C
+	// Error: observer 4 panicked: sensor overheated
+	// Alarm: 42.0°C is too hot
+	// Display 1 shows temperature: 42.0°C
+	// Display 3 shows temperature: 42.0°C
+	// Error after removing it: <nil>
+	// [warning] storm expected tonight
+}
+
+func Example_eventBus() {
+	runEventBus()
+	// Output:
+	// Sync subscriber got 26.5°C
+	// Async subscriber got [storm flood heatwave]
+}
+
+func Example_pubsub() {
+	runPubSub()
+	// Output:
+	// Block:      got [100 101 102 103 104 105]
+	// DropNewest: got [100 101], dropped 4
+	// DropOldest: got [104 105], dropped 4
+	// Publish after Close: broker is closed
+}
+
+func Example_strategy() {
+	runStrategy()
+	// Output:
+	// Paid 100.00 using Credit Card
+	// Paid 50.00 using PayPal
+	// Registered strategies: [bitcoin cash credit_card paypal]
+	// Paid 75.50 using Bitcoin
+	// Paid 75.50 in cash
+	// Error: creating payment strategy "paypal": missing option "email"
+	// Error: unknown payment strategy: "gold"
+}
+
+func Example_chain() {
+	runChainOfResponsibility()
+	// Output:
+	// Info: This is an information.
+	// Debug: This is a debug information.
+	// Error: This is an error information.
+	// Unhandled(WARN): This is a warning.
+	// Warn: This is a warning.
+	// GET /orders from alice: approved
+	// GET /orders from bob: rejected (unauthorized: client "bob")
+	// POST /orders from alice: rejected (invalid request: POST /orders requires a body)
+	// POST /orders from alice: approved
+	// GET /orders from alice: rejected (rate limit exceeded: client "alice" made 3 requests (limit 2))
+	// Approval steps: [auth validation ratelimit]
+}
+
+func Example_nullObject() {
+	runNullObject()
+	// Output:
+	// Alice pays 90.00
+	// Guest pays 100.00
+	// Lookup c2: Some(Bob)
+	// Lookup c9: None, OrElse: nobody
+	// Quantity " 3 " -> Ok(7.5), or 0: 7.50
+	// Quantity "three" -> Err(parse quantity "three": expected integer), or 0: 0.00
+	// As (value, error): parse quantity "x": expected integer
+}
+
+func Example_retry() {
+	runRetry()
+	// Output:
+	// Attempt 1 failed (call 1: connection refused), retrying in 100ms
+	// Attempt 2 failed (call 2: connection refused), retrying in 200ms
+	// Attempt 3 failed (call 3: connection refused), retrying in 400ms
+	// Result: <nil>, waited [100ms 200ms 400ms]
+	// Attempt 1 failed (call 1: connection refused), retrying in 100ms
+	// Attempt 2 failed (call 2: connection refused), retrying in 200ms
+	// Attempt 3 failed (call 3: connection refused), retrying in 400ms
+	// Attempt 4 failed (call 4: connection refused), retrying in 800ms
+	// Result: retries exhausted after 5 attempts: call 5: connection refused (exhausted? true)
+	// Waits with full jitter: [77.712049ms 108.827653ms 342.434331ms 388.509065ms]
+	// Permanent error: invalid API key after 1 attempt(s)
+	// With timeout: context deadline exceeded (last error: call 1: connection refused) (deadline exceeded? true)
+}
+
+func Example_semaphore() {
+	runSemaphore()
+	// Output:
+	// Downloaded 7 files, peak units in use: 4 of 4
+	// TryAcquire(4) on idle semaphore: true
+	// TryAcquire(1) while full: false
+}
+
+func Example_rateLimit() {
+	runRateLimit()
+	// Output:
+	// Allow() 5 times on a bucket with burst 3: 3 allowed
+	// Ran 10 tasks in ~400ms (expected ~400ms)
+	// Task errors: task 5 failed; task 10 failed
+}
+
+func Example_future() {
+	runFuture()
+	// Output:
+	// Prices [42 42] (err <nil>) in ~50ms
+	// Waiting 20ms for a 200ms task: future: timed out waiting for result
+	// Waiting until done: 42.0
+	// Panicking task: future: task panicked: assignment to entry in nil map
+	// Promise: resolved by another goroutine (second Complete accepted? false)
+}
+
+func Example_singleflight() {
+	runSingleflight()
+	// Output:
+	// 10 concurrent lookups -> 1 database call(s), 10 callers got a shared result
+}
+
+func Example_actor() {
+	runActor()
+	// Output:
+	// Balance after 50 deposits of 10: 500
+	// Withdraw 1000: insufficient funds: balance 500, requested 1000
+	// Supervisor: alice failed (account alice crashed), restarted: true
+	// Balance after restart: 500 (err <nil>)
+	// Supervisor: alice failed (account alice crashed), restarted: false
+	// Deposit after giving up: actor stopped
+}
+
+func Example_cqrs() {
+	runCQRS()
+	// Output:
+	// architectural.RemoveStock rejected: cannot remove 5 from sku-2: only 3 in stock
+	// architectural.AddStock rejected: aggregate not found: sku-9
+	// Event log for sku-1:
+	//   v1 ItemCreated {Name:Keyboard}
+	//   v2 StockAdded {Quantity:10}
+	//   v3 StockRemoved {Quantity:4}
+	//   v4 StockRemoved {Quantity:1}
+	//   v5 StockAdded {Quantity:2}
+	// Replayed sku-1: stock 7 at version 5
+	// Loaded sku-1 via snapshot: stock 7, replayed 1 event(s)
+	// Read model sku-1: {ID:sku-1 Name:Keyboard Stock:7 Active:true Updates:5}
+	// Low stock (< 8): [{ID:sku-1 Name:Keyboard Stock:7 Active:true Updates:5}]
+	// First save: <nil>
+	// Second save: concurrency conflict: sku-1 is at version 6, expected 5 (conflict? true)
+}
+
+func Example_unitOfWork() {
+	runUnitOfWork()
+	// Output:
+	// Transfer 30 acc-1 -> acc-2: <nil>
+	// Transfer 500 acc-2 -> acc-1: insufficient funds in acc-2: balance 50, transfer 500
+	// Transfer reusing ID t1: commit rolled back: duplicate entity: transfers/t1 (duplicate? true)
+	// Close acc-3: <nil>, close acc-1: account acc-1 still holds 70
+	//   acc-1 Alice 70
+	//   acc-2 Bob   50
+	// Transfers: [{ID:t1 From:acc-1 To:acc-2 Amount:30}], commits: 3
+	// Pending: 1 insert(s), 0 update(s), 1 delete(s)
+	// After rollback: entity not found: accounts/acc-tmp
+}
+
+func Example_logging() {
+	runLogging()
+	// Output:
+	// 2024-04-15T14:30:00Z DEBUG starting service=api port=8080
+	// 2024-04-15T14:30:00Z INFO  user logged in service=api user=alice password=[REDACTED]
+	// 2024-04-15T14:30:00Z WARN  slow request service=api path=/orders took=1.2s
+	// 2024-04-15T14:30:00Z ERROR payment failed service=api err="card declined"
+	// app.log:
+	// {"time":"2024-04-15T14:30:00Z","level":"WARN","msg":"slow request","service":"api","path":"/orders","took":"1.2s"}
+	// {"time":"2024-04-15T14:30:00Z","level":"ERROR","msg":"payment failed","service":"api","err":"card declined"}
+	// 2:30PM INFO  shutting down singleton=true
+}
+
+func Example_config() {
+	runConfig()
+	// Output:
+	// Config: {Addr:0.0.0.0:9000 ReadTimeout:30s Debug:false} {URL:memory:// MaxConns:50} {Level:info Format:json}
+	//   server.addr          from file app.yaml
+	//   server.read_timeout  from file app.yaml
+	//   server.debug         from default
+	//   database.url         from default
+	//   database.max_conns   from env APP_DATABASE_MAX_CONNS
+	//   log.level            from env APP_LOG_LEVEL
+	//   log.format           from override
+	// Error: invalid config: unknown key server.port; database.max_conns must be 1 to 1000, got 0; log.level "loud" is not one of [debug info warn error]
+	// Reloaded: log level debug, error: <nil>
+	// Reloaded: log level debug, error: invalid config: log.level "loud" is not one of [debug info warn error]
+	// Current log level: debug
+}
+
+func Example_report() {
+	runReport()
+	// Output:
+	// --- by region, as markdown ---
+	// ## Sales by region
+	//
+	// | Region  | Orders | Units | Revenue |
+	// | ------- | -----: | ----: | ------: |
+	// | Central |      5 |   620 | 1637.50 |
+	// | South   |      5 |   520 | 1270.00 |
+	// | North   |      5 |   475 | 1205.00 |
+	// | East    |      4 |   275 |  715.00 |
+	// | Total   |     19 |  1890 | 4827.50 |
+	// --- by month, as csv ---
+	// Month,Orders,Units,Revenue
+	// 2024-01,6,535,1305.00
+	// 2024-02,6,615,1525.00
+	// 2024-03,7,740,1997.50
+	// Total,19,1890,4827.50
+	// --- by product, as json ---
+	// {
+	//   "title": "Sales by product",
+	//   "rows": [
+	//     {"Product": "Coffee", "Orders": 9, "Units": 1300, "Revenue": 3402.5},
+	//     {"Product": "Cocoa", "Orders": 5, "Units": 245, "Revenue": 735},
+	//     {"Product": "Tea", "Orders": 5, "Units": 345, "Revenue": 690},
+	//     {"Product": "Total", "Orders": 19, "Units": 1890, "Revenue": 4827.5}
+	//   ]
+	// }
+	// Error: report: load: line 2: units "a dozen" is not a count
+	// Error: report: unknown format "pdf", want one of [csv json markdown]
+}
+
+func Example_fsm() {
+	runFSM()
+	// Output:
+	// ship     Error: no transition for ship in pending
+	//   charged 100, balance 20
+	// pay      now paid, next [ship cancel]
+	// ship     now shipped, next [deliver]
+	// cancel   Error: no transition for cancel in shipped
+	// deliver  now delivered, next []
+	// History:
+	//   10:30  pending --pay--> paid
+	//   12:00  paid --ship--> shipped
+	//   13:30  shipped --deliver--> delivered
+	// Can pay with 50: false
+	// Error: pay in pending: rejected by guard "funds"
+	// digraph fsm {
+	// 	rankdir=LR;
+	// 	node [shape=circle, style=filled, fillcolor=white];
+	// 	start [shape=point, fillcolor=black];
+	// 	"pending" [fillcolor=gold];
+	// 	"paid";
+	// 	"shipped";
+	// 	"delivered" [shape=doublecircle];
+	// 	"cancelled" [shape=doublecircle];
+	// 	start -> "pending";
+	// 	"pending" -> "paid" [label="pay [funds]"];
+	// 	"paid" -> "shipped" [label="ship"];
+	// 	"shipped" -> "delivered" [label="deliver"];
+	// 	"pending" -> "cancelled" [label="cancel"];
+	// 	"paid" -> "cancelled" [label="cancel"];
+	// }
+	// Error: invalid state machine: rule idle --start--> stopped is never tried: the rule before it for start in idle has no guard; initial state off has no transitions
+}
@@ -0,0 +1,57 @@
+package fsm
+
+import (
+	"cmp"
+	"fmt"
+	"strings"
+)
+
+// DOT draws the machine in the DOT language of Graphviz, for
+// documentation:
+//
+//	go run . > order.dot && dot -Tsvg order.dot -o order.svg
+//
+// An arrow from a dot marks the initial state, the current state is
+// filled, and states with no way out have a double circle. Each arrow is
+// labelled with its event, and with its guard in brackets
+func (m *Machine) DOT() string {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	final := make(map[State]bool)
+	for _, s := range m.states {
+		final[s] = true
+	}
+	for _, r := range m.rules {
+		final[r.From] = false
+	}
+
+	var sb strings.Builder
+	sb.WriteString("digraph fsm {\n")
+	sb.WriteString("\trankdir=LR;\n")
+	sb.WriteString("\tnode [shape=circle, style=filled, fillcolor=white];\n")
+	sb.WriteString("\tstart [shape=point, fillcolor=black];\n")
+	for _, s := range m.states {
+		var attrs []string
+		if final[s] {
+			attrs = append(attrs, "shape=doublecircle")
+		}
+		if s == m.current {
+			attrs = append(attrs, "fillcolor=gold")
+		}
+		if len(attrs) == 0 {
+			fmt.Fprintf(&sb, "\t%q;\n", s)
+		} else {
+			fmt.Fprintf(&sb, "\t%q [%s];\n", s, strings.Join(attrs, ", "))
+		}
+	}
+	fmt.Fprintf(&sb, "\tstart -> %q;\n", m.initial)
+	for _, r := range m.rules {
+		label := string(r.Event)
+		if r.Guard != nil {
+			label += " [" + cmp.Or(r.GuardName, "guard") + "]"
+		}
+		fmt.Fprintf(&sb, "\t%q -> %q [label=%q];\n", r.From, r.To, label)
+	}
+	sb.WriteString("}\n")
+	return sb.String()
+}
@@ -0,0 +1,375 @@
+// Package fsm describes finite state machines as a table of transitions,
+// built with a small DSL:
+//
+//	m, err := fsm.New().
+//		From("pending").On("pay").To("paid").When("paid in full", paidInFull).Do(charge).
+//		From("paid").On("ship").To("shipped").
+//		From("pending", "paid").On("cancel").To("cancelled").
+//		Build("pending")
+//
+// It is the State pattern turned into data. The classic pattern gives every
+// state a type whose methods handle the events and pick the next state;
+// here the states are names and the behaviour is one table, so the whole
+// machine can be read in one place, checked when it is built and drawn
+// with Graphviz (see Machine.DOT)
+//
+// A transition may have a guard, which must allow it, and an action, which
+// runs as it happens. Every transition made is kept in the history
+package fsm
+
+import (
+	"cmp"
+	"errors"
+	"fmt"
+	"slices"
+	"strings"
+	"sync"
+	"time"
+)
+
+var (
+	// ErrInvalidMachine is returned by Build for a table it can't use
+	ErrInvalidMachine = errors.New("invalid state machine")
+	// ErrNoTransition is returned by Fire for an event the current state
+	// has no transition for
+	ErrNoTransition = errors.New("no transition")
+	// ErrGuardRejected is returned by Fire when the state has transitions
+	// for the event but every one of their guards said no
+	ErrGuardRejected = errors.New("rejected by guard")
+)
+
+// State is the name of a state
+type State string
+
+// Event is the name of an event
+type Event string
+
+// Transition is a move from one state to another on an event
+type Transition struct {
+	From  State
+	Event Event
+	To    State
+}
+
+func (t Transition) String() string {
+	return fmt.Sprintf("%s --%s--> %s", t.From, t.Event, t.To)
+}
+
+// Guard decides whether a transition may happen
+type Guard func(t Transition) bool
+
+// Action runs while a transition happens. If it returns an error the
+// machine stays in the state it was in
+type Action func(t Transition) error
+
+// Rule is one row of the transition table: in state From, the event Event
+// moves the machine to To, if Guard allows it, running Action on the way
+// Guard and Action are optional; GuardName describes the guard in errors
+// and drawings
+type Rule struct {
+	From      State
+	Event     Event
+	To        State
+	GuardName string
+	Guard     Guard
+	Action    Action
+}
+
+// Builder collects the transition table. From starts a rule, and On, To,
+// When and Do fill it in; mistakes are collected and reported by Build
+type Builder struct {
+	rules []Rule
+	// current indexes the rules the last From started, one per state
+	current  []int
+	problems []string
+}
+
+// New returns an empty Builder
+func New() *Builder {
+	return &Builder{}
+}
+
+// Add appends rules written out as a table
+func (b *Builder) Add(rules ...Rule) *Builder {
+	b.rules = append(b.rules, rules...)
+	b.current = nil
+	return b
+}
+
+// From starts a rule for each of the states, which the calls after it fill
+// in together
+func (b *Builder) From(states ...State) *Builder {
+	b.current = b.current[:0]
+	if len(states) == 0 {
+		b.problems = append(b.problems, "From needs a state")
+	}
+	for _, s := range states {
+		b.current = append(b.current, len(b.rules))
+		b.rules = append(b.rules, Rule{From: s})
+	}
+	return b
+}
+
+// edit applies f to the rules From started
+func (b *Builder) edit(call string, f func(r *Rule)) *Builder {
+	if len(b.current) == 0 {
+		b.problems = append(b.problems, call+" before From")
+	}
+	for _, i := range b.current {
+		f(&b.rules[i])
+	}
+	return b
+}
+
+// On sets the event of the rule
+func (b *Builder) On(event Event) *Builder {
+	return b.edit(fmt.Sprintf("On(%s)", event), func(r *Rule) { r.Event = event })
+}
+
+// To sets the state the rule moves to
+func (b *Builder) To(state State) *Builder {
+	return b.edit(fmt.Sprintf("To(%s)", state), func(r *Rule) { r.To = state })
+}
+
+// When sets the guard of the rule, and the name it is shown by
+func (b *Builder) When(name string, guard Guard) *Builder {
+	return b.edit(fmt.Sprintf("When(%s)", name), func(r *Rule) { r.GuardName, r.Guard = name, guard })
+}
+
+// Do sets the action of the rule
+func (b *Builder) Do(action Action) *Builder {
+	return b.edit("Do", func(r *Rule) { r.Action = action })
+}
+
+// Build checks the table and returns a Machine in the state initial
+// A state may have several rules for one event, which Fire tries in the
+// order they were added; all but the last of them need a guard, or the
+// ones after would never be tried
+func (b *Builder) Build(initial State) (*Machine, error) {
+	problems := slices.Clone(b.problems)
+	m := &Machine{
+		current: initial,
+		initial: initial,
+		table:   make(map[key][]Rule),
+		clock:   time.Now,
+	}
+	m.addState(initial)
+	for _, r := range b.rules {
+		name := fmt.Sprintf("rule %s --%s--> %s", r.From, r.Event, r.To)
+		switch {
+		case r.From == "" || r.Event == "" || r.To == "":
+			problems = append(problems, name+" needs a state, an event and a target")
+			continue
+		case r.Guard == nil && r.GuardName != "":
+			problems = append(problems, fmt.Sprintf("%s has a guard name, %q, but no guard", name, r.GuardName))
+		}
+		k := key{r.From, r.Event}
+		if rules := m.table[k]; len(rules) > 0 && rules[len(rules)-1].Guard == nil {
+			problems = append(problems, fmt.Sprintf("%s is never tried: the rule before it for %s in %s has no guard", name, r.Event, r.From))
+		}
+		m.table[k] = append(m.table[k], r)
+		m.rules = append(m.rules, r)
+		m.addState(r.From)
+		m.addState(r.To)
+	}
+	if len(m.rules) == 0 {
+		problems = append(problems, "no transitions")
+	} else if !slices.ContainsFunc(m.rules, func(r Rule) bool { return r.From == initial }) {
+		problems = append(problems, fmt.Sprintf("initial state %s has no transitions", initial))
+	}
+	if len(problems) > 0 {
+		return nil, fmt.Errorf("%w: %s", ErrInvalidMachine, strings.Join(problems, "; "))
+	}
+	return m, nil
+}
+
+// key finds the rules of a state for an event
+type key struct {
+	state State
+	event Event
+}
+
+// Record is a transition in the history, and when it happened
+type Record struct {
+	Transition
+	At time.Time
+}
+
+// Machine is a state machine built from a table. It is safe for
+// concurrent use: events are handled one at a time
+// Guards and actions run without the machine locked, so they may read it
+// with Current, Can, Events and History. An action that wants to fire a
+// follow-up event must Post it: Fire would wait for the transition in
+// progress, which is the one calling it
+type Machine struct {
+	// firing serializes Fire, and is held while guards and actions run; mu
+	// guards the fields below it and is only held briefly
+	firing sync.Mutex
+	mu     sync.Mutex
+	// busy is set while a Fire is handling events, and queue holds the
+	// events posted meanwhile
+	busy    bool
+	queue   []Event
+	current State
+	initial State
+	// rules and states are kept in the order they were added, so that
+	// listings and drawings come out the same every time
+	rules   []Rule
+	states  []State
+	table   map[key][]Rule
+	history []Record
+	clock   func() time.Time
+}
+
+func (m *Machine) addState(s State) {
+	if !slices.Contains(m.states, s) {
+		m.states = append(m.states, s)
+	}
+}
+
+// SetClock replaces the clock the history is timed with, for tests
+func (m *Machine) SetClock(clock func() time.Time) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	m.clock = clock
+}
+
+// Current returns the state the machine is in
+func (m *Machine) Current() State {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	return m.current
+}
+
+// States returns every state, in the order the table first names them
+func (m *Machine) States() []State {
+	return slices.Clone(m.states)
+}
+
+// Events returns the events the current state has transitions for,
+// whether or not their guards would allow them now
+func (m *Machine) Events() []Event {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	var events []Event
+	for _, r := range m.rules {
+		if r.From == m.current && !slices.Contains(events, r.Event) {
+			events = append(events, r.Event)
+		}
+	}
+	return events
+}
+
+// Can reports whether Fire(event) would find a transition now
+// Actions are not run, so Fire may still fail
+func (m *Machine) Can(event Event) bool {
+	_, err := m.find(m.Current(), event)
+	return err == nil
+}
+
+// find returns the first rule for event in state whose guard allows it
+// The table doesn't change after Build, so it is read without the lock,
+// and the guards run without it
+func (m *Machine) find(state State, event Event) (Rule, error) {
+	rules := m.table[key{state, event}]
+	if len(rules) == 0 {
+		return Rule{}, fmt.Errorf("%w for %s in %s", ErrNoTransition, event, state)
+	}
+	var rejected []string
+	for _, r := range rules {
+		if r.Guard == nil || r.Guard(Transition{r.From, r.Event, r.To}) {
+			return r, nil
+		}
+		rejected = append(rejected, fmt.Sprintf("%q", cmp.Or(r.GuardName, "guard")))
+	}
+	return Rule{}, fmt.Errorf("%s in %s: %w %s", event, state, ErrGuardRejected, strings.Join(rejected, ", "))
+}
+
+// Fire handles an event: it takes the first transition for it whose guard
+// allows it, runs its action and moves to its target
+// The state doesn't change if there is no such transition or the action
+// fails. Events posted by the action are handled next, in order, before
+// Fire returns; the first of them to fail stops the rest, and its error is
+// returned. A failed action's posted events are dropped
+func (m *Machine) Fire(event Event) error {
+	m.firing.Lock()
+	defer m.firing.Unlock()
+	m.mu.Lock()
+	m.busy = true
+	m.mu.Unlock()
+	defer func() {
+		m.mu.Lock()
+		m.busy, m.queue = false, nil
+		m.mu.Unlock()
+	}()
+
+	if err := m.fire(event); err != nil {
+		return err
+	}
+	for {
+		m.mu.Lock()
+		if len(m.queue) == 0 {
+			m.mu.Unlock()
+			return nil
+		}
+		next := m.queue[0]
+		m.queue = m.queue[1:]
+		m.mu.Unlock()
+		if err := m.fire(next); err != nil {
+			return fmt.Errorf("posted event %s: %w", next, err)
+		}
+	}
+}
+
+// fire makes one transition. The caller holds m.firing, so the state
+// can't change while the guards and the action run
+func (m *Machine) fire(event Event) error {
+	r, err := m.find(m.Current(), event)
+	if err != nil {
+		return err
+	}
+	t := Transition{r.From, r.Event, r.To}
+	if r.Action != nil {
+		if err := r.Action(t); err != nil {
+			m.mu.Lock()
+			m.queue = nil
+			m.mu.Unlock()
+			return fmt.Errorf("%v: %w", t, err)
+		}
+	}
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	m.current = r.To
+	m.history = append(m.history, Record{t, m.clock()})
+	return nil
+}
+
+// Post fires an event once the transition in progress is over: it is how
+// an action fires a follow-up event. The Fire that runs the action
+// handles it and reports its error
+// With no transition in progress, Post is Fire
+func (m *Machine) Post(event Event) error {
+	m.mu.Lock()
+	if m.busy {
+		m.queue = append(m.queue, event)
+		m.mu.Unlock()
+		return nil
+	}
+	m.mu.Unlock()
+	return m.Fire(event)
+}
+
+// History returns the transitions made so far, oldest first
+func (m *Machine) History() []Record {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	return slices.Clone(m.history)
+}
+
+// Reset puts the machine back in its initial state and clears the history
+func (m *Machine) Reset() {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	m.current = m.initial
+	m.history = nil
+}
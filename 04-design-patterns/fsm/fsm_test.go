@@ -0,0 +1,273 @@
+package fsm
+
+import (
+	"errors"
+	"fmt"
+	"strings"
+	"sync"
+	"testing"
+	"time"
+)
+
+// order returns the machine of an online order, paid for with *balance
+func order(t *testing.T, balance *int) *Machine {
+	t.Helper()
+	m, err := New().
+		From("pending").On("pay").To("paid").When("funds", func(Transition) bool { return *balance >= 100 }).
+		Do(func(Transition) error { *balance -= 100; return nil }).
+		From("paid").On("ship").To("shipped").
+		From("shipped").On("deliver").To("delivered").
+		From("pending", "paid").On("cancel").To("cancelled").
+		Build("pending")
+	if err != nil {
+		t.Fatal(err)
+	}
+	return m
+}
+
+func TestFire(t *testing.T) {
+	balance := 150
+	m := order(t, &balance)
+	start := time.Date(2024, 5, 1, 9, 0, 0, 0, time.UTC)
+	now := start
+	m.SetClock(func() time.Time { now = now.Add(time.Minute); return now })
+
+	for _, ev := range []Event{"pay", "ship", "deliver"} {
+		if err := m.Fire(ev); err != nil {
+			t.Fatalf("Fire(%s): %v", ev, err)
+		}
+	}
+	if m.Current() != "delivered" || balance != 50 {
+		t.Errorf("state %s, balance %d, want delivered and 50", m.Current(), balance)
+	}
+	want := []string{"pending --pay--> paid", "paid --ship--> shipped", "shipped --deliver--> delivered"}
+	h := m.History()
+	for i, r := range h {
+		if r.String() != want[i] || !r.At.Equal(start.Add(time.Duration(i+1)*time.Minute)) {
+			t.Errorf("history[%d] = %v at %v", i, r, r.At)
+		}
+	}
+	if len(h) != len(want) {
+		t.Errorf("history has %d records, want %d", len(h), len(want))
+	}
+	if err := m.Fire("cancel"); !errors.Is(err, ErrNoTransition) {
+		t.Errorf("cancel when delivered = %v, want ErrNoTransition", err)
+	}
+
+	m.Reset()
+	if m.Current() != "pending" || len(m.History()) != 0 {
+		t.Errorf("after Reset: %s with %d records", m.Current(), len(m.History()))
+	}
+}
+
+func TestGuards(t *testing.T) {
+	balance := 20
+	m := order(t, &balance)
+	if m.Can("pay") {
+		t.Error("Can(pay) with too little money")
+	}
+	err := m.Fire("pay")
+	if !errors.Is(err, ErrGuardRejected) || !strings.Contains(err.Error(), `"funds"`) || m.Current() != "pending" {
+		t.Errorf("Fire(pay) = %v in %s", err, m.Current())
+	}
+	if got := m.Events(); len(got) != 2 || got[0] != "pay" || got[1] != "cancel" {
+		t.Errorf("Events() = %v, want [pay cancel]", got)
+	}
+
+	// The rules for an event are tried in order, up to the first a guard
+	// allows; the last one may go without a guard
+	vip := false
+	m, err = New().Add(
+		Rule{From: "queued", Event: "next", To: "priority", GuardName: "vip", Guard: func(Transition) bool { return vip }},
+		Rule{From: "queued", Event: "next", To: "served"},
+	).Build("queued")
+	if err != nil {
+		t.Fatal(err)
+	}
+	m.Fire("next")
+	if m.Current() != "served" {
+		t.Errorf("went to %s, want served", m.Current())
+	}
+	vip = true
+	m.Reset()
+	m.Fire("next")
+	if m.Current() != "priority" {
+		t.Errorf("vip went to %s, want priority", m.Current())
+	}
+}
+
+func TestFailedAction(t *testing.T) {
+	declined := errors.New("card declined")
+	m, err := New().From("pending").On("pay").To("paid").Do(func(Transition) error { return declined }).Build("pending")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if err := m.Fire("pay"); !errors.Is(err, declined) || m.Current() != "pending" || len(m.History()) != 0 {
+		t.Errorf("Fire = %v, state %s, %d records", err, m.Current(), len(m.History()))
+	}
+}
+
+func TestBuildProblems(t *testing.T) {
+	_, err := New().
+		On("early").
+		From("a").On("go").To("b").
+		From("a").On("go").To("c").
+		From("b").To("a").
+		Add(Rule{From: "c", Event: "x", To: "a", GuardName: "never"}).
+		Build("z")
+	if !errors.Is(err, ErrInvalidMachine) {
+		t.Fatalf("err = %v, want ErrInvalidMachine", err)
+	}
+	for _, want := range []string{
+		"On(early) before From",
+		"rule a --go--> c is never tried",
+		"rule b ---->",
+		`has a guard name, "never", but no guard`,
+		"initial state z has no transitions",
+	} {
+		if !strings.Contains(err.Error(), want) {
+			t.Errorf("error %q\nlacks %q", err, want)
+		}
+	}
+}
+
+func TestDOT(t *testing.T) {
+	balance := 100
+	m := order(t, &balance)
+	m.Fire("pay")
+	want := `digraph fsm {
+	rankdir=LR;
+	node [shape=circle, style=filled, fillcolor=white];
+	start [shape=point, fillcolor=black];
+	"pending";
+	"paid" [fillcolor=gold];
+	"shipped";
+	"delivered" [shape=doublecircle];
+	"cancelled" [shape=doublecircle];
+	start -> "pending";
+	"pending" -> "paid" [label="pay [funds]"];
+	"paid" -> "shipped" [label="ship"];
+	"shipped" -> "delivered" [label="deliver"];
+	"pending" -> "cancelled" [label="cancel"];
+	"paid" -> "cancelled" [label="cancel"];
+}
+`
+	if got := m.DOT(); got != want {
+		t.Errorf("DOT() =\n%s\nwant\n%s", got, want)
+	}
+}
+
+func TestConcurrentFire(t *testing.T) {
+	m, err := New().From("off").On("toggle").To("on").From("on").On("toggle").To("off").Build("off")
+	if err != nil {
+		t.Fatal(err)
+	}
+	var wg sync.WaitGroup
+	for range 8 {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			for range 125 {
+				m.Fire("toggle")
+				m.Current()
+			}
+		}()
+	}
+	wg.Wait()
+	// An even number of toggles ends where it started
+	if m.Current() != "off" || len(m.History()) != 1000 {
+		t.Errorf("state %s after %d toggles", m.Current(), len(m.History()))
+	}
+}
+
+func TestActionReadsMachine(t *testing.T) {
+	var m *Machine
+	var seen []string
+	m, err := New().
+		From("idle").On("start").To("running").
+		When("idle", func(Transition) bool { return m.Current() == "idle" }).
+		Do(func(tr Transition) error {
+			seen = append(seen, string(m.Current()), fmt.Sprint(m.Can("start")), fmt.Sprint(len(m.History())))
+			return nil
+		}).
+		From("running").On("stop").To("idle").
+		Build("idle")
+	if err != nil {
+		t.Fatal(err)
+	}
+	done := make(chan error)
+	go func() { done <- m.Fire("start") }()
+	select {
+	case err := <-done:
+		if err != nil {
+			t.Fatal(err)
+		}
+	case <-time.After(5 * time.Second):
+		t.Fatal("Fire deadlocked on an action reading the machine")
+	}
+	// The action sees the state it is leaving
+	if got := strings.Join(seen, " "); got != "idle true 0" {
+		t.Errorf("action saw %q, want %q", got, "idle true 0")
+	}
+	if m.Current() != "running" {
+		t.Errorf("state %s, want running", m.Current())
+	}
+}
+
+func TestPost(t *testing.T) {
+	var m *Machine
+	m, err := New().
+		From("pending").On("pay").To("paid").Do(func(Transition) error { return m.Post("ship") }).
+		From("paid").On("ship").To("shipped").Do(func(Transition) error { return m.Post("deliver") }).
+		From("shipped").On("deliver").To("delivered").
+		Build("pending")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if err := m.Fire("pay"); err != nil {
+		t.Fatal(err)
+	}
+	var got []string
+	for _, r := range m.History() {
+		got = append(got, r.String())
+	}
+	want := "pending --pay--> paid, paid --ship--> shipped, shipped --deliver--> delivered"
+	if strings.Join(got, ", ") != want {
+		t.Errorf("history %s, want %s", strings.Join(got, ", "), want)
+	}
+
+	// With nothing in progress, Post fires at once
+	m.Reset()
+	if err := m.Post("ship"); !errors.Is(err, ErrNoTransition) {
+		t.Errorf("Post(ship) when pending = %v, want ErrNoTransition", err)
+	}
+}
+
+func TestPostErrors(t *testing.T) {
+	var m *Machine
+	declined := errors.New("declined")
+	m, err := New().
+		From("a").On("go").To("b").Do(func(Transition) error { m.Post("go"); return nil }).
+		From("b").On("go").To("c").Do(func(Transition) error { return declined }).
+		From("a").On("fail").To("b").When("never", func(Transition) bool { return false }).
+		From("a").On("fail").To("c").Do(func(Transition) error { m.Post("go"); return declined }).
+		Build("a")
+	if err != nil {
+		t.Fatal(err)
+	}
+	// The posted event fails: the first transition stands
+	if err := m.Fire("go"); !errors.Is(err, declined) || !strings.Contains(err.Error(), "posted event go") {
+		t.Errorf("Fire(go) = %v, want the posted event's error", err)
+	}
+	if m.Current() != "b" {
+		t.Errorf("state %s, want b", m.Current())
+	}
+	// A failed action's posted events are dropped
+	m.Reset()
+	if err := m.Fire("fail"); !errors.Is(err, declined) {
+		t.Errorf("Fire(fail) = %v", err)
+	}
+	if m.Current() != "a" || len(m.History()) != 0 {
+		t.Errorf("state %s with %d records, want a and none", m.Current(), len(m.History()))
+	}
+}
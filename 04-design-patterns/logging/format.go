@@ -0,0 +1,102 @@
+package logging
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// Formatter turns an entry into one line of output, newline included
+// It is the Strategy of a sink: the same sink writes text or JSON depending
+// on the Formatter it is given
+type Formatter interface {
+	Format(e Entry) ([]byte, error)
+}
+
+// TextFormatter writes entries for people to read:
+//
+//	2024-04-15T14:30:00Z INFO  user logged in user=alice note="two words"
+type TextFormatter struct {
+	// TimeFormat is the layout of the time; empty means time.RFC3339
+	TimeFormat string
+}
+
+// Format implements Formatter
+func (f TextFormatter) Format(e Entry) ([]byte, error) {
+	layout := f.TimeFormat
+	if layout == "" {
+		layout = time.RFC3339
+	}
+	var b bytes.Buffer
+	fmt.Fprintf(&b, "%s %-5s %s", e.Time.Format(layout), e.Level, e.Message)
+	for _, field := range e.Fields {
+		b.WriteByte(' ')
+		b.WriteString(field.Key)
+		b.WriteByte('=')
+		b.WriteString(quoteIfNeeded(fmt.Sprint(plain(field.Value))))
+	}
+	b.WriteByte('\n')
+	return b.Bytes(), nil
+}
+
+// quoteIfNeeded quotes a value that would otherwise be hard to tell apart
+// from the fields around it
+func quoteIfNeeded(s string) string {
+	if s == "" || strings.ContainsAny(s, " =\"\t\n") {
+		return strconv.Quote(s)
+	}
+	return s
+}
+
+// JSONFormatter writes every entry as a JSON object on one line, with the
+// fields after time, level and msg in the order they were given:
+//
+//	{"time":"2024-04-15T14:30:00Z","level":"INFO","msg":"user logged in","user":"alice"}
+type JSONFormatter struct{}
+
+// Format implements Formatter
+// A map would lose the order of the fields, so the object is written key
+// by key
+func (JSONFormatter) Format(e Entry) ([]byte, error) {
+	var b bytes.Buffer
+	b.WriteByte('{')
+	write := func(key string, value any) error {
+		if b.Len() > 1 {
+			b.WriteByte(',')
+		}
+		k, _ := json.Marshal(key)
+		v, err := json.Marshal(plain(value))
+		if err != nil {
+			return fmt.Errorf("logging: field %s: %w", key, err)
+		}
+		b.Write(k)
+		b.WriteByte(':')
+		b.Write(v)
+		return nil
+	}
+	write("time", e.Time.Format(time.RFC3339Nano))
+	write("level", e.Level.String())
+	write("msg", e.Message)
+	for _, f := range e.Fields {
+		if err := write(f.Key, f.Value); err != nil {
+			return nil, err
+		}
+	}
+	b.WriteString("}\n")
+	return b.Bytes(), nil
+}
+
+// plain turns the values that encode badly into strings: an error would be
+// {} in JSON, and a duration a number of nanoseconds
+func plain(v any) any {
+	switch v := v.(type) {
+	case error:
+		return v.Error()
+	case time.Duration:
+		return v.String()
+	}
+	return v
+}
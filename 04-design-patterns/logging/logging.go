@@ -0,0 +1,233 @@
+// Package logging is a small structured logger that puts several patterns
+// to work together, the way a real logging library does
+//
+//   - Chain of Responsibility: every entry goes through a behavioral.Chain
+//     of handlers before it is written, which can change it (Redact) or
+//     drop it by not passing it on
+//   - Strategy: a Formatter decides how an entry looks, TextFormatter for
+//     people and JSONFormatter for machines, and each sink has its own
+//   - Composite: MultiSink writes to several sinks and is a Sink itself, so
+//     the logger never knows how many there are
+//   - Decorator: MinLevel and AsyncSink wrap a sink to filter its entries or
+//     to write them from a goroutine of their own
+//   - Singleton: Default is the logger shared by the whole program, made on
+//     first use with creational.LazySingleton
+//
+// A Logger made by With shares everything but its fields with its parent,
+// so changing the level or the sink of one changes it for all of them
+package logging
+
+import (
+	"fmt"
+	"os"
+	"strings"
+	"sync"
+	"sync/atomic"
+	"time"
+
+	"github.com/NutProhmpiriya/go-basic/04-design-patterns/behavioral"
+	"github.com/NutProhmpiriya/go-basic/04-design-patterns/creational"
+)
+
+// Level is the severity of an entry
+type Level int32
+
+const (
+	LevelDebug Level = iota
+	LevelInfo
+	LevelWarn
+	LevelError
+)
+
+var levelNames = [...]string{"DEBUG", "INFO", "WARN", "ERROR"}
+
+func (l Level) String() string {
+	if l < 0 || int(l) >= len(levelNames) {
+		return fmt.Sprintf("Level(%d)", int(l))
+	}
+	return levelNames[l]
+}
+
+// ParseLevel returns the level with the given name, in any case
+func ParseLevel(name string) (Level, error) {
+	for l, n := range levelNames {
+		if strings.EqualFold(name, n) {
+			return Level(l), nil
+		}
+	}
+	return 0, fmt.Errorf("logging: unknown level %q", name)
+}
+
+// Field is a key-value pair attached to an entry
+type Field struct {
+	Key   string
+	Value any
+}
+
+// F returns the field key=value
+func F(key string, value any) Field {
+	return Field{Key: key, Value: value}
+}
+
+// Entry is one log message with everything known about it
+type Entry struct {
+	Time    time.Time
+	Level   Level
+	Message string
+	Fields  []Field
+}
+
+// core is what a Logger shares with the loggers made from it by With
+type core struct {
+	level atomic.Int32
+	mu    sync.RWMutex
+	sink  Sink
+	now   func() time.Time
+	chain *behavioral.Chain[Entry]
+}
+
+// Logger writes entries at or above its level to its sink
+// It is safe for concurrent use
+type Logger struct {
+	core   *core
+	fields []Field
+}
+
+// New creates a logger that writes entries at level or above to sink
+func New(sink Sink, level Level) *Logger {
+	c := &core{sink: sink, now: time.Now, chain: behavioral.NewChain[Entry]()}
+	c.level.Store(int32(level))
+	c.chain.SetFallback(func(e Entry) error {
+		c.mu.RLock()
+		sink := c.sink
+		c.mu.RUnlock()
+		return sink.Write(e)
+	})
+	return &Logger{core: c}
+}
+
+// With returns a logger that adds fields to every entry
+func (l *Logger) With(fields ...Field) *Logger {
+	return &Logger{core: l.core, fields: append(l.fields[:len(l.fields):len(l.fields)], fields...)}
+}
+
+// Level returns the lowest level that is written
+func (l *Logger) Level() Level {
+	return Level(l.core.level.Load())
+}
+
+// SetLevel changes the lowest level that is written
+func (l *Logger) SetLevel(level Level) {
+	l.core.level.Store(int32(level))
+}
+
+// Enabled reports whether entries at level are written, so that callers can
+// skip working out fields nobody will see
+func (l *Logger) Enabled(level Level) bool {
+	return level >= l.Level()
+}
+
+// SetSink replaces the sink and returns the old one, which the caller
+// should close
+func (l *Logger) SetSink(sink Sink) Sink {
+	l.core.mu.Lock()
+	defer l.core.mu.Unlock()
+	old := l.core.sink
+	l.core.sink = sink
+	return old
+}
+
+// SetClock replaces time.Now as the source of entry times, for tests
+func (l *Logger) SetClock(now func() time.Time) {
+	l.core.mu.Lock()
+	defer l.core.mu.Unlock()
+	l.core.now = now
+}
+
+// Use appends a handler to the chain every entry goes through before it is
+// written. A handler passes the entry on by calling next, changed or not,
+// and drops it by returning without calling next
+func (l *Logger) Use(name string, h behavioral.Handler[Entry]) {
+	l.core.chain.Use(name, h)
+}
+
+// Log writes an entry if level is enabled, and returns the error of the
+// chain or the sink
+func (l *Logger) Log(level Level, msg string, fields ...Field) error {
+	if !l.Enabled(level) {
+		return nil
+	}
+	l.core.mu.RLock()
+	now := l.core.now
+	l.core.mu.RUnlock()
+	all := make([]Field, 0, len(l.fields)+len(fields))
+	all = append(append(all, l.fields...), fields...)
+	return l.core.chain.Handle(Entry{Time: now(), Level: level, Message: msg, Fields: all})
+}
+
+// Debug, Info, Warn and Error log at their level. Like the log package they
+// don't return the error; call Log for it
+func (l *Logger) Debug(msg string, fields ...Field) { l.Log(LevelDebug, msg, fields...) }
+func (l *Logger) Info(msg string, fields ...Field)  { l.Log(LevelInfo, msg, fields...) }
+func (l *Logger) Warn(msg string, fields ...Field)  { l.Log(LevelWarn, msg, fields...) }
+func (l *Logger) Error(msg string, fields ...Field) { l.Log(LevelError, msg, fields...) }
+
+// Redact returns a handler that hides the values of the given keys
+func Redact(keys ...string) behavioral.Handler[Entry] {
+	return behavioral.HandlerFunc[Entry](func(e Entry, next func(Entry) error) error {
+		// The fields may be shared with other entries, so they are copied
+		// before they are changed
+		var fields []Field
+		for i, f := range e.Fields {
+			for _, k := range keys {
+				if f.Key != k {
+					continue
+				}
+				if fields == nil {
+					fields = append([]Field(nil), e.Fields...)
+				}
+				fields[i].Value = "[REDACTED]"
+			}
+		}
+		if fields != nil {
+			e.Fields = fields
+		}
+		return next(e)
+	})
+}
+
+// std is the Singleton behind Default
+var std = creational.NewLazySingleton(newDefault)
+
+// newDefault makes the default logger: text on standard error at the level
+// in LOG_LEVEL, or JSON if LOG_FORMAT is json
+func newDefault() *Logger {
+	level, err := ParseLevel(os.Getenv("LOG_LEVEL"))
+	if err != nil {
+		level = LevelInfo
+	}
+	var f Formatter = TextFormatter{}
+	if strings.EqualFold(os.Getenv("LOG_FORMAT"), "json") {
+		f = JSONFormatter{}
+	}
+	return New(NewWriterSink(os.Stderr, f), level)
+}
+
+// Default returns the logger shared by the whole program, made on first
+// use from the LOG_LEVEL and LOG_FORMAT environment variables
+// Configure it with SetLevel, SetSink and Use
+func Default() *Logger {
+	return std.Get()
+}
+
+// ResetForTesting discards the default logger so the next Default makes a
+// new one. Only tests should call it
+func ResetForTesting() {
+	std.ResetForTesting()
+}
+
+// Debug, Info, Warn and Error log with the default logger
+func Debug(msg string, fields ...Field) { Default().Debug(msg, fields...) }
+func Info(msg string, fields ...Field)  { Default().Info(msg, fields...) }
+func Warn(msg string, fields ...Field)  { Default().Warn(msg, fields...) }
+func Error(msg string, fields ...Field) { Default().Error(msg, fields...) }
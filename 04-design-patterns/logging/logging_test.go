@@ -0,0 +1,248 @@
+package logging
+
+import (
+	"bytes"
+	"encoding/json"
+	"errors"
+	"os"
+	"path/filepath"
+	"strings"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/NutProhmpiriya/go-basic/04-design-patterns/behavioral"
+)
+
+var testTime = time.Date(2024, 4, 15, 14, 30, 0, 0, time.UTC)
+
+// newTestLogger returns a logger writing text to a buffer at a fixed time
+func newTestLogger(level Level, f Formatter) (*Logger, *bytes.Buffer) {
+	var buf bytes.Buffer
+	l := New(NewWriterSink(&buf, f), level)
+	l.SetClock(func() time.Time { return testTime })
+	return l, &buf
+}
+
+// recordSink keeps the entries written to it
+type recordSink struct {
+	mu      sync.Mutex
+	entries []Entry
+	closed  bool
+	err     error
+}
+
+func (s *recordSink) Write(e Entry) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.entries = append(s.entries, e)
+	return s.err
+}
+
+func (s *recordSink) Close() error {
+	s.closed = true
+	return nil
+}
+
+func (s *recordSink) messages() []string {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	var msgs []string
+	for _, e := range s.entries {
+		msgs = append(msgs, e.Message)
+	}
+	return msgs
+}
+
+func TestTextFormat(t *testing.T) {
+	l, buf := newTestLogger(LevelDebug, TextFormatter{})
+	l.Info("user logged in", F("user", "alice"), F("note", "two words"), F("empty", ""),
+		F("err", errors.New("bad")), F("took", 1500*time.Millisecond))
+	want := `2024-04-15T14:30:00Z INFO  user logged in user=alice note="two words" empty="" err=bad took=1.5s` + "\n"
+	if buf.String() != want {
+		t.Errorf("got  %q\nwant %q", buf.String(), want)
+	}
+}
+
+func TestJSONFormat(t *testing.T) {
+	l, buf := newTestLogger(LevelDebug, JSONFormatter{})
+	l.Warn("disk almost full", F("free", 0.05), F("err", errors.New("bad")), F("mount", "/var"))
+	want := `{"time":"2024-04-15T14:30:00Z","level":"WARN","msg":"disk almost full","free":0.05,"err":"bad","mount":"/var"}` + "\n"
+	if buf.String() != want {
+		t.Errorf("got  %s\nwant %s", buf.String(), want)
+	}
+	if !json.Valid(buf.Bytes()) {
+		t.Error("output is not valid JSON")
+	}
+	if _, err := (JSONFormatter{}).Format(Entry{Fields: []Field{F("ch", make(chan int))}}); err == nil {
+		t.Error("Format of a channel succeeded")
+	}
+}
+
+func TestLevels(t *testing.T) {
+	sink := &recordSink{}
+	l := New(sink, LevelWarn)
+	l.Debug("debug")
+	l.Info("info")
+	l.Warn("warn")
+	l.Error("error")
+	l.SetLevel(LevelDebug)
+	l.Debug("debug again")
+	if got, want := strings.Join(sink.messages(), ","), "warn,error,debug again"; got != want {
+		t.Errorf("written %s, want %s", got, want)
+	}
+	for _, name := range []string{"debug", "INFO", "Warn", "error"} {
+		if _, err := ParseLevel(name); err != nil {
+			t.Errorf("ParseLevel(%q): %v", name, err)
+		}
+	}
+	if _, err := ParseLevel("verbose"); err == nil {
+		t.Error("ParseLevel(verbose) succeeded")
+	}
+}
+
+func TestWith(t *testing.T) {
+	sink := &recordSink{}
+	root := New(sink, LevelInfo)
+	a := root.With(F("service", "api"))
+	b := a.With(F("request", 1))
+	c := a.With(F("request", 2)) // must not overwrite b's field
+	b.Info("b")
+	c.Info("c", F("extra", true))
+	a.SetLevel(LevelError) // shared with root, b and c
+	root.Info("dropped")
+
+	if len(sink.entries) != 2 {
+		t.Fatalf("%d entries, want 2", len(sink.entries))
+	}
+	if got := sink.entries[0].Fields; len(got) != 2 || got[1] != F("request", 1) {
+		t.Errorf("b fields = %v", got)
+	}
+	if got := sink.entries[1].Fields; len(got) != 3 || got[1] != F("request", 2) || got[2] != F("extra", true) {
+		t.Errorf("c fields = %v", got)
+	}
+}
+
+func TestChain(t *testing.T) {
+	l, buf := newTestLogger(LevelInfo, TextFormatter{})
+	l.Use("redact", Redact("password", "token"))
+	l.Use("skip-health", behavioral.HandlerFunc[Entry](func(e Entry, next func(Entry) error) error {
+		if e.Message == "GET /healthz" {
+			return nil
+		}
+		return next(e)
+	}))
+	shared := []Field{F("user", "bob"), F("password", "hunter2")}
+	l.Info("login", shared...)
+	l.Info("GET /healthz")
+	if want := "2024-04-15T14:30:00Z INFO  login user=bob password=[REDACTED]\n"; buf.String() != want {
+		t.Errorf("got  %q\nwant %q", buf.String(), want)
+	}
+	if shared[1].Value != "hunter2" {
+		t.Error("Redact changed the caller's fields")
+	}
+}
+
+func TestMultiSinkAndMinLevel(t *testing.T) {
+	all, errs := &recordSink{}, &recordSink{}
+	failing := &recordSink{err: errors.New("disk full")}
+	l := New(MultiSink{all, MinLevel(LevelError, errs), failing}, LevelDebug)
+	l.Info("info")
+	err := l.Log(LevelError, "error")
+	if len(all.entries) != 2 || len(errs.entries) != 1 || errs.entries[0].Message != "error" {
+		t.Errorf("all got %v, errors got %v", all.messages(), errs.messages())
+	}
+	if err == nil || !strings.Contains(err.Error(), "disk full") {
+		t.Errorf("Log error = %v, want the failing sink's", err)
+	}
+	l.SetSink(nil).Close()
+	if !all.closed || !errs.closed {
+		t.Error("MultiSink.Close didn't close every sink")
+	}
+}
+
+func TestAsyncSink(t *testing.T) {
+	inner := &recordSink{}
+	async := NewAsyncSink(inner, 4)
+	l := New(async, LevelInfo)
+	for i := range 100 {
+		l.Info(string(rune('a' + i%26)))
+	}
+	if err := async.Close(); err != nil {
+		t.Fatal(err)
+	}
+	msgs := inner.messages()
+	if len(msgs) != 100 || msgs[0] != "a" || msgs[27] != "b" {
+		t.Errorf("got %d entries starting %v, want 100 in order", len(msgs), msgs[:min(3, len(msgs))])
+	}
+	if !inner.closed {
+		t.Error("Close didn't close the wrapped sink")
+	}
+	if err := l.Log(LevelInfo, "late"); !errors.Is(err, ErrClosed) {
+		t.Errorf("Log after Close = %v, want ErrClosed", err)
+	}
+}
+
+func TestAsyncSinkConcurrent(t *testing.T) {
+	inner := &recordSink{err: errors.New("flaky")}
+	async := NewAsyncSink(inner, 8)
+	l := New(async, LevelInfo)
+	var wg sync.WaitGroup
+	for range 8 {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			for range 50 {
+				l.With(F("worker", 1)).Info("tick")
+			}
+		}()
+	}
+	wg.Wait()
+	if err := async.Close(); err == nil || err.Error() != "flaky" {
+		t.Errorf("Close = %v, want the first write error", err)
+	}
+	if n := len(inner.messages()); n != 400 {
+		t.Errorf("%d entries written, want 400", n)
+	}
+}
+
+func TestFileSink(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "app.log")
+	for _, msg := range []string{"first", "second"} {
+		sink, err := NewFileSink(path, JSONFormatter{})
+		if err != nil {
+			t.Fatal(err)
+		}
+		l := New(sink, LevelInfo)
+		l.Info(msg)
+		sink.Close()
+	}
+	data, err := os.ReadFile(path)
+	if err != nil {
+		t.Fatal(err)
+	}
+	// The second open appends to the file
+	lines := strings.Split(strings.TrimSpace(string(data)), "\n")
+	if len(lines) != 2 || !strings.Contains(lines[1], `"msg":"second"`) {
+		t.Errorf("file has %q", lines)
+	}
+}
+
+func TestDefault(t *testing.T) {
+	t.Setenv("LOG_LEVEL", "debug")
+	t.Setenv("LOG_FORMAT", "json")
+	ResetForTesting()
+	defer ResetForTesting()
+	if Default() != Default() {
+		t.Fatal("Default returned two loggers")
+	}
+	if Default().Level() != LevelDebug {
+		t.Errorf("level = %v, want DEBUG from LOG_LEVEL", Default().Level())
+	}
+	var buf bytes.Buffer
+	Default().SetSink(NewWriterSink(&buf, JSONFormatter{}))
+	Error("from the package", F("n", 1))
+	if !strings.Contains(buf.String(), `"msg":"from the package","n":1`) {
+		t.Errorf("default logger wrote %q", buf.String())
+	}
+}
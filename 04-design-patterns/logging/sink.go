@@ -0,0 +1,178 @@
+package logging
+
+import (
+	"errors"
+	"io"
+	"os"
+	"sync"
+)
+
+// ErrClosed is returned when writing to a sink that was closed
+var ErrClosed = errors.New("logging: sink is closed")
+
+// Sink is where entries end up
+type Sink interface {
+	Write(e Entry) error
+	// Close flushes what the sink buffered and releases what it holds
+	Close() error
+}
+
+// WriterSink formats entries and writes them to an io.Writer, one at a time
+type WriterSink struct {
+	mu     sync.Mutex
+	w      io.Writer
+	format Formatter
+}
+
+// NewWriterSink creates a sink writing to w in the given format
+func NewWriterSink(w io.Writer, format Formatter) *WriterSink {
+	return &WriterSink{w: w, format: format}
+}
+
+// Write formats e and writes it as one call to the writer, so entries from
+// different goroutines never interleave
+func (s *WriterSink) Write(e Entry) error {
+	line, err := s.format.Format(e)
+	if err != nil {
+		return err
+	}
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	_, err = s.w.Write(line)
+	return err
+}
+
+// Close does nothing: the writer belongs to the caller, and may be
+// standard error
+func (s *WriterSink) Close() error {
+	return nil
+}
+
+// FileSink is a WriterSink that owns the file it appends to
+type FileSink struct {
+	*WriterSink
+	file *os.File
+}
+
+// NewFileSink opens path for appending, creating it if needed
+func NewFileSink(path string, format Formatter) (*FileSink, error) {
+	f, err := os.OpenFile(path, os.O_WRONLY|os.O_APPEND|os.O_CREATE, 0o644)
+	if err != nil {
+		return nil, err
+	}
+	return &FileSink{WriterSink: NewWriterSink(f, format), file: f}, nil
+}
+
+// Close closes the file
+func (s *FileSink) Close() error {
+	return s.file.Close()
+}
+
+// MultiSink is a Composite: a Sink made of other sinks, which gets every
+// entry to all of them
+type MultiSink []Sink
+
+// Write writes e to every sink, even after one fails, and returns their
+// errors joined
+func (m MultiSink) Write(e Entry) error {
+	var errs []error
+	for _, s := range m {
+		if err := s.Write(e); err != nil {
+			errs = append(errs, err)
+		}
+	}
+	return errors.Join(errs...)
+}
+
+// Close closes every sink and returns their errors joined
+func (m MultiSink) Close() error {
+	var errs []error
+	for _, s := range m {
+		if err := s.Close(); err != nil {
+			errs = append(errs, err)
+		}
+	}
+	return errors.Join(errs...)
+}
+
+// levelSink passes on the entries at or above its level
+type levelSink struct {
+	min  Level
+	sink Sink
+}
+
+// MinLevel wraps a sink so it only gets entries at level or above, such as
+// a file of errors next to a console that shows everything
+func MinLevel(level Level, sink Sink) Sink {
+	return levelSink{min: level, sink: sink}
+}
+
+func (s levelSink) Write(e Entry) error {
+	if e.Level < s.min {
+		return nil
+	}
+	return s.sink.Write(e)
+}
+
+func (s levelSink) Close() error {
+	return s.sink.Close()
+}
+
+// AsyncSink writes to another sink from a goroutine of its own, so a slow
+// disk doesn't slow down the code that logs
+// Entries wait in a buffer; when it is full, Write blocks until there is
+// room rather than lose entries
+type AsyncSink struct {
+	sink    Sink
+	entries chan Entry
+	done    chan struct{}
+
+	// mu keeps Close from closing entries while a Write sends on it
+	mu     sync.RWMutex
+	closed bool
+	// err is the first error of the wrapped sink, returned by Close
+	err error
+}
+
+// NewAsyncSink starts writing to sink in the background, with room for
+// buffer entries in between
+func NewAsyncSink(sink Sink, buffer int) *AsyncSink {
+	a := &AsyncSink{sink: sink, entries: make(chan Entry, buffer), done: make(chan struct{})}
+	go a.run()
+	return a
+}
+
+func (a *AsyncSink) run() {
+	defer close(a.done)
+	for e := range a.entries {
+		if err := a.sink.Write(e); err != nil && a.err == nil {
+			a.err = err
+		}
+	}
+}
+
+// Write queues e. The wrapped sink's errors can't be returned here, as the
+// entry isn't written yet; the first of them is returned by Close
+func (a *AsyncSink) Write(e Entry) error {
+	a.mu.RLock()
+	defer a.mu.RUnlock()
+	if a.closed {
+		return ErrClosed
+	}
+	a.entries <- e
+	return nil
+}
+
+// Close writes the entries still queued, then closes the wrapped sink
+func (a *AsyncSink) Close() error {
+	a.mu.Lock()
+	if a.closed {
+		a.mu.Unlock()
+		return ErrClosed
+	}
+	a.closed = true
+	close(a.entries)
+	a.mu.Unlock()
+	<-a.done
+	return errors.Join(a.err, a.sink.Close())
+}
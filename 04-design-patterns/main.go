@@ -1,112 +1,123 @@
+// Command 04-design-patterns runs the design pattern demos
+//
+// Usage:
+//
+//	go run ./04-design-patterns                     # run every demo
+//	go run ./04-design-patterns -list               # list the demos
+//	go run ./04-design-patterns -pattern=observer   # run one demo
+//	go run ./04-design-patterns -pattern=future,actor
 package main
 
 import (
+	"flag"
 	"fmt"
-	"github.com/your-username/golang-basic/04-design-patterns/behavioral"
-	"github.com/your-username/golang-basic/04-design-patterns/creational"
-	"github.com/your-username/golang-basic/04-design-patterns/structural"
+	"os"
+	"strings"
 )
 
-func main() {
-	// Creational Patterns
-
-	// 1. Singleton
-	fmt.Println("=== Singleton Pattern ===")
-	singleton1 := creational.GetInstance()
-	singleton2 := creational.GetInstance()
-	
-	singleton1.IncrementCount()
-	fmt.Printf("Singleton1 count: %d\n", singleton1.GetCount())
-	fmt.Printf("Singleton2 count: %d\n", singleton2.GetCount())
-	fmt.Println()
-
-	// 2. Factory
-	fmt.Println("=== Factory Pattern ===")
-	creditCard := creational.PaymentFactory(creational.CreditCardType)
-	paypal := creational.PaymentFactory(creational.PayPalType)
-	
-	fmt.Println(creditCard.Pay(100.0))
-	fmt.Println(paypal.Pay(50.0))
-	fmt.Println()
+// Demo is a runnable example of one pattern
+// Every pattern in this directory provides one and is listed in demos
+type Demo interface {
+	// Name is the identifier used with -pattern
+	Name() string
+	Title() string
+	// Category groups demos in -list: creational, structural, behavioral, ...
+	Category() string
+	Run()
+}
 
-	// 3. Builder
-	fmt.Println("=== Builder Pattern ===")
-	builder := creational.NewComputerBuilder()
-	director := creational.NewDirector(builder)
-	
-	gamingPC := director.BuildGamingPC()
-	officePC := director.BuildOfficePC()
-	
-	fmt.Printf("Gaming PC: %+v\n", gamingPC)
-	fmt.Printf("Office PC: %+v\n", officePC)
-	fmt.Println()
+// demo implements Demo with a plain function
+type demo struct {
+	name, title, category string
+	run                   func()
+}
 
-	// Structural Patterns
+func (d demo) Name() string     { return d.name }
+func (d demo) Title() string    { return d.title }
+func (d demo) Category() string { return d.category }
+func (d demo) Run()             { d.run() }
 
-	// 4. Adapter
-	fmt.Println("=== Adapter Pattern ===")
-	adapter := structural.NewAdapter()
-	fmt.Println(adapter.Request())
-	fmt.Println()
+// demos is the registry of all demos, in the order they run
+var demos = []Demo{
+	demo{"singleton", "Singleton Pattern", "creational", runSingleton},
+	demo{"singleton-strategies", "Singleton Strategies", "creational", runSingletonStrategies},
+	demo{"factory", "Factory Pattern", "creational", runFactory},
+	demo{"builder", "Builder Pattern", "creational", runBuilder},
+	demo{"plugin", "Plugin Registration Pattern", "creational", runPluginRegistry},
+	demo{"adapter", "Adapter Pattern", "structural", runAdapter},
+	demo{"decorator", "Decorator Pattern", "structural", runDecorator},
+	demo{"middleware", "Middleware (Decorator) Pattern", "structural", runMiddleware},
+	demo{"facade", "Facade Pattern", "structural", runFacade},
+	demo{"observer", "Observer Pattern", "behavioral", runObserver},
+	demo{"event-bus", "Event Bus (Observer) Pattern", "behavioral", runEventBus},
+	demo{"pubsub", "Publish-Subscribe Pattern", "behavioral", runPubSub},
+	demo{"strategy", "Strategy Pattern", "behavioral", runStrategy},
+	demo{"chain", "Chain of Responsibility Pattern", "behavioral", runChainOfResponsibility},
+	demo{"null-object", "Null Object Pattern", "behavioral", runNullObject},
+	demo{"retry", "Retry Pattern", "resilience", runRetry},
+	demo{"semaphore", "Semaphore Pattern", "concurrency", runSemaphore},
+	demo{"rate-limit", "Rate Limiting Pattern", "concurrency", runRateLimit},
+	demo{"future", "Future Pattern", "concurrency", runFuture},
+	demo{"singleflight", "Single-flight Pattern", "concurrency", runSingleflight},
+	demo{"actor", "Actor Pattern", "concurrency", runActor},
+	demo{"cqrs", "CQRS + Event Sourcing", "architectural", runCQRS},
+	demo{"unit-of-work", "Unit of Work Pattern", "architectural", runUnitOfWork},
+	demo{"logging", "Structured Logger (Chain + Strategy + Singleton)", "applied", runLogging},
+	demo{"config", "Configuration Loader (Builder + Singleton)", "applied", runConfig},
+	demo{"report", "Report Generator (Template Method + Strategy)", "applied", runReport},
+	demo{"fsm", "State Machine DSL (State as a table)", "applied", runFSM},
+}
 
-	// 5. Decorator
-	fmt.Println("=== Decorator Pattern ===")
-	coffee := &structural.SimpleCoffee{}
-	coffeeWithMilk := structural.NewMilkDecorator(coffee)
-	coffeeWithMilkAndSugar := structural.NewSugarDecorator(coffeeWithMilk)
-	
-	fmt.Printf("Cost: %.2f, Description: %s\n", 
-		coffeeWithMilkAndSugar.GetCost(), 
-		coffeeWithMilkAndSugar.GetDescription())
-	fmt.Println()
+// findDemo looks a demo up by name
+func findDemo(name string) (Demo, bool) {
+	for _, d := range demos {
+		if d.Name() == name {
+			return d, true
+		}
+	}
+	return nil, false
+}
 
-	// 6. Facade
-	fmt.Println("=== Facade Pattern ===")
-	computer := structural.NewComputerFacade()
-	startupSteps := computer.Start()
-	for _, step := range startupSteps {
-		fmt.Println(step)
+// listDemos prints the demos grouped by category
+func listDemos() {
+	category := ""
+	for _, d := range demos {
+		if d.Category() != category {
+			category = d.Category()
+			fmt.Printf("%s:\n", category)
+		}
+		fmt.Printf("  %-14s %s\n", d.Name(), d.Title())
 	}
-	fmt.Println()
+}
 
-	// Behavioral Patterns
+func main() {
+	pattern := flag.String("pattern", "", "comma-separated demos to run (default: all)")
+	list := flag.Bool("list", false, "list the available demos")
+	flag.Parse()
 
-	// 7. Observer
-	fmt.Println("=== Observer Pattern ===")
-	weatherStation := behavioral.NewWeatherStation()
-	display1 := behavioral.NewTemperatureDisplay("Display 1")
-	display2 := behavioral.NewTemperatureDisplay("Display 2")
-	
-	weatherStation.RegisterObserver(display1)
-	weatherStation.RegisterObserver(display2)
-	weatherStation.SetTemperature(25.0)
-	fmt.Println()
+	if *list {
+		listDemos()
+		return
+	}
 
-	// 8. Strategy
-	fmt.Println("=== Strategy Pattern ===")
-	cart := behavioral.NewShoppingCart(behavioral.NewCreditCardStrategy("1234", "123"))
-	fmt.Println(cart.Checkout(100.0))
-	
-	cart.SetPaymentStrategy(behavioral.NewPayPalStrategy("test@test.com", "password"))
-	fmt.Println(cart.Checkout(50.0))
-	fmt.Println()
+	selected := demos
+	if *pattern != "" {
+		selected = nil
+		for _, name := range strings.Split(*pattern, ",") {
+			d, ok := findDemo(strings.TrimSpace(name))
+			if !ok {
+				fmt.Fprintf(os.Stderr, "unknown pattern %q; run with -list to see the demos\n", name)
+				os.Exit(2)
+			}
+			selected = append(selected, d)
+		}
+	}
 
-	// 9. Chain of Responsibility
-	fmt.Println("=== Chain of Responsibility Pattern ===")
-	loggerChain := behavioral.NewLoggerChain()
-	
-	fmt.Println(loggerChain.Log(behavioral.LogEntry{
-		Message: "This is an information.",
-		Level:   behavioral.INFO,
-	}))
-	
-	fmt.Println(loggerChain.Log(behavioral.LogEntry{
-		Message: "This is a debug information.",
-		Level:   behavioral.DEBUG,
-	}))
-	
-	fmt.Println(loggerChain.Log(behavioral.LogEntry{
-		Message: "This is an error information.",
-		Level:   behavioral.ERROR,
-	}))
+	for i, d := range selected {
+		if i > 0 {
+			fmt.Println()
+		}
+		fmt.Printf("=== %s ===\n", d.Title())
+		d.Run()
+	}
 }
@@ -0,0 +1,91 @@
+// Package filestore registers the "file" driver, which keeps data in a text file
+// of key=value lines. The dsn is the file path; the file is created if needed.
+//
+// Import it for its side effect:
+//
+//	import _ "github.com/NutProhmpiriya/go-basic/04-design-patterns/plugins/filestore"
+package filestore
+
+import (
+	"bufio"
+	"errors"
+	"fmt"
+	"io/fs"
+	"os"
+	"sort"
+	"strings"
+	"sync"
+
+	"github.com/NutProhmpiriya/go-basic/04-design-patterns/plugins"
+)
+
+func init() {
+	plugins.Register("file", driver{})
+}
+
+type driver struct{}
+
+// Open loads the existing file at dsn, if any
+func (driver) Open(dsn string) (plugins.Store, error) {
+	if dsn == "" {
+		return nil, errors.New("file driver: dsn must be a file path")
+	}
+	s := &store{path: dsn, data: make(map[string]string)}
+
+	f, err := os.Open(dsn)
+	if errors.Is(err, fs.ErrNotExist) {
+		return s, nil
+	}
+	if err != nil {
+		return nil, err
+	}
+	defer f.Close()
+
+	scanner := bufio.NewScanner(f)
+	for scanner.Scan() {
+		key, value, ok := strings.Cut(scanner.Text(), "=")
+		if ok {
+			s.data[key] = value
+		}
+	}
+	return s, scanner.Err()
+}
+
+type store struct {
+	mu   sync.RWMutex
+	path string
+	data map[string]string
+}
+
+func (s *store) Get(key string) (string, error) {
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+	value, ok := s.data[key]
+	if !ok {
+		return "", fmt.Errorf("%w: %s", plugins.ErrKeyNotFound, key)
+	}
+	return value, nil
+}
+
+// Set updates the value and rewrites the file
+func (s *store) Set(key, value string) error {
+	if strings.ContainsAny(key, "=\n") || strings.Contains(value, "\n") {
+		return fmt.Errorf("file driver: invalid key or value %q=%q", key, value)
+	}
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.data[key] = value
+
+	keys := make([]string, 0, len(s.data))
+	for k := range s.data {
+		keys = append(keys, k)
+	}
+	sort.Strings(keys)
+	var b strings.Builder
+	for _, k := range keys {
+		fmt.Fprintf(&b, "%s=%s\n", k, s.data[k])
+	}
+	return os.WriteFile(s.path, []byte(b.String()), 0o644)
+}
+
+func (s *store) Close() error { return nil }
@@ -0,0 +1,48 @@
+// Package memory registers the "memory" driver, which keeps data in a map
+//
+// Import it for its side effect:
+//
+//	import _ "github.com/NutProhmpiriya/go-basic/04-design-patterns/plugins/memory"
+package memory
+
+import (
+	"fmt"
+	"sync"
+
+	"github.com/NutProhmpiriya/go-basic/04-design-patterns/plugins"
+)
+
+func init() {
+	plugins.Register("memory", driver{})
+}
+
+type driver struct{}
+
+// Open ignores dsn: every memory store starts empty
+func (driver) Open(dsn string) (plugins.Store, error) {
+	return &store{data: make(map[string]string)}, nil
+}
+
+type store struct {
+	mu   sync.RWMutex
+	data map[string]string
+}
+
+func (s *store) Get(key string) (string, error) {
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+	value, ok := s.data[key]
+	if !ok {
+		return "", fmt.Errorf("%w: %s", plugins.ErrKeyNotFound, key)
+	}
+	return value, nil
+}
+
+func (s *store) Set(key, value string) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.data[key] = value
+	return nil
+}
+
+func (s *store) Close() error { return nil }
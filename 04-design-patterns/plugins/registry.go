@@ -0,0 +1,87 @@
+// Package plugins demonstrates the registration pattern used by database/sql,
+// image and encoding packages in the standard library.
+//
+// The registry knows nothing about concrete drivers. Each driver lives in its own
+// package and registers itself from an init function, so a program chooses the
+// drivers it wants just by importing them, usually with a blank import:
+//
+//	import _ "github.com/NutProhmpiriya/go-basic/04-design-patterns/plugins/memory"
+//
+// Open then acts as a factory that creates a Store from a driver name, which can
+// come from configuration at runtime. New drivers can be added without changing
+// this package.
+//
+// Use cases:
+// - Database drivers (database/sql), image formats (image.RegisterFormat)
+// - Optional features compiled in only when their package is imported
+// - Selecting an implementation by name from a config file
+package plugins
+
+import (
+	"errors"
+	"fmt"
+	"sort"
+	"sync"
+)
+
+var (
+	// ErrUnknownDriver is returned by Open for names nobody registered
+	ErrUnknownDriver = errors.New("unknown driver")
+	// ErrKeyNotFound is returned by Store.Get for missing keys
+	ErrKeyNotFound = errors.New("key not found")
+)
+
+// Store is a simple key-value store created by a driver
+type Store interface {
+	Get(key string) (string, error)
+	Set(key, value string) error
+	Close() error
+}
+
+// Driver creates stores; dsn (data source name) is driver specific
+type Driver interface {
+	Open(dsn string) (Store, error)
+}
+
+var (
+	driversMu sync.RWMutex
+	drivers   = make(map[string]Driver)
+)
+
+// Register makes a driver available under name
+// It panics if driver is nil or name is already taken: both are programming
+// errors that should fail at startup, not when Open is called later
+func Register(name string, driver Driver) {
+	driversMu.Lock()
+	defer driversMu.Unlock()
+	if driver == nil {
+		panic("plugins: Register driver is nil")
+	}
+	if _, dup := drivers[name]; dup {
+		panic("plugins: Register called twice for driver " + name)
+	}
+	drivers[name] = driver
+}
+
+// Drivers returns the names of the registered drivers, sorted
+func Drivers() []string {
+	driversMu.RLock()
+	defer driversMu.RUnlock()
+	names := make([]string, 0, len(drivers))
+	for name := range drivers {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+	return names
+}
+
+// Open creates a store with the named driver
+func Open(driverName, dsn string) (Store, error) {
+	driversMu.RLock()
+	driver, ok := drivers[driverName]
+	driversMu.RUnlock()
+	if !ok {
+		return nil, fmt.Errorf("%w %q (forgotten import?)", ErrUnknownDriver, driverName)
+	}
+	return driver.Open(dsn)
+}
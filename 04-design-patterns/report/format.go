@@ -0,0 +1,161 @@
+package report
+
+import (
+	"encoding/csv"
+	"encoding/json"
+	"fmt"
+	"io"
+	"strings"
+)
+
+// formats are the Format strategies FormatByName knows
+var formats = []Format{CSV{}, JSON{}, Markdown{}}
+
+// FormatByName returns the format called name: csv, json or markdown
+func FormatByName(name string) (Format, error) {
+	for _, f := range formats {
+		if f.Name() == name {
+			return f, nil
+		}
+	}
+	return nil, fmt.Errorf("report: unknown format %q, want one of %v", name, FormatNames())
+}
+
+// FormatNames returns the names FormatByName accepts
+func FormatNames() []string {
+	names := make([]string, len(formats))
+	for i, f := range formats {
+		names[i] = f.Name()
+	}
+	return names
+}
+
+// cell returns the text of a cell; amounts of money get two decimals
+func cell(v any) string {
+	if f, ok := v.(float64); ok {
+		return fmt.Sprintf("%.2f", f)
+	}
+	return fmt.Sprint(v)
+}
+
+// CSV writes the columns as a header row and then the rows, for
+// spreadsheets. The title is left out, as CSV has no place for it
+type CSV struct{}
+
+func (CSV) Name() string { return "csv" }
+
+func (CSV) Write(w io.Writer, t Table) error {
+	cw := csv.NewWriter(w)
+	cw.Write(t.Columns)
+	for _, row := range t.Rows {
+		record := make([]string, len(row))
+		for i, v := range row {
+			record[i] = cell(v)
+		}
+		cw.Write(record)
+	}
+	cw.Flush()
+	return cw.Error()
+}
+
+// JSON writes the table as an object with the title and a list of rows,
+// each an object keyed by column, for other programs
+// Numbers stay numbers, so the reader needn't parse them
+type JSON struct{}
+
+func (JSON) Name() string { return "json" }
+
+func (JSON) Write(w io.Writer, t Table) error {
+	// Rows are written key by key to keep the order of the columns, which a
+	// map would lose
+	var b strings.Builder
+	title, _ := json.Marshal(t.Title)
+	fmt.Fprintf(&b, "{\n  \"title\": %s,\n  \"rows\": [", title)
+	for r, row := range t.Rows {
+		if len(row) != len(t.Columns) {
+			return fmt.Errorf("row %d has %d cells for %d columns", r+1, len(row), len(t.Columns))
+		}
+		if r > 0 {
+			b.WriteString(",")
+		}
+		b.WriteString("\n    {")
+		for i, v := range row {
+			key, _ := json.Marshal(t.Columns[i])
+			value, err := json.Marshal(v)
+			if err != nil {
+				return err
+			}
+			if i > 0 {
+				b.WriteString(", ")
+			}
+			fmt.Fprintf(&b, "%s: %s", key, value)
+		}
+		b.WriteString("}")
+	}
+	if len(t.Rows) > 0 {
+		b.WriteString("\n  ")
+	}
+	b.WriteString("]\n}\n")
+	_, err := io.WriteString(w, b.String())
+	return err
+}
+
+// Markdown writes the table as a Markdown table under its title, with the
+// columns padded to line up in plain text too and the numbers aligned right
+type Markdown struct{}
+
+func (Markdown) Name() string { return "markdown" }
+
+func (Markdown) Write(w io.Writer, t Table) error {
+	width := make([]int, len(t.Columns))
+	numeric := make([]bool, len(t.Columns))
+	for i, c := range t.Columns {
+		width[i] = max(len(c), 3)
+	}
+	text := make([][]string, len(t.Rows))
+	for r, row := range t.Rows {
+		if len(row) != len(t.Columns) {
+			return fmt.Errorf("row %d has %d cells for %d columns", r+1, len(row), len(t.Columns))
+		}
+		text[r] = make([]string, len(row))
+		for i, v := range row {
+			text[r][i] = strings.ReplaceAll(cell(v), "|", `\|`)
+			width[i] = max(width[i], len(text[r][i]))
+			switch v.(type) {
+			case int, float64:
+				numeric[i] = true
+			}
+		}
+	}
+
+	var b strings.Builder
+	line := func(cells []string) {
+		for i, c := range cells {
+			pad := strings.Repeat(" ", width[i]-len(c))
+			if numeric[i] {
+				c = pad + c
+			} else {
+				c += pad
+			}
+			fmt.Fprintf(&b, "| %s ", c)
+		}
+		b.WriteString("|\n")
+	}
+	if t.Title != "" {
+		fmt.Fprintf(&b, "## %s\n\n", t.Title)
+	}
+	line(t.Columns)
+	for i := range t.Columns {
+		if numeric[i] {
+			fmt.Fprintf(&b, "| %s: ", strings.Repeat("-", width[i]-1))
+		} else {
+			fmt.Fprintf(&b, "| %s ", strings.Repeat("-", width[i]))
+		}
+	}
+	b.WriteString("|\n")
+	for _, row := range text {
+		line(row)
+	}
+	_, err := io.WriteString(w, b.String())
+	return err
+}
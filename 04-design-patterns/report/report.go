@@ -0,0 +1,247 @@
+// Package report generates reports from a dataset of sales, putting the
+// Template Method and the Strategy patterns to work together
+//
+//   - Template Method: Generator.Generate is the skeleton every report
+//     follows, load → aggregate → format → write. The steps that vary, where
+//     the records come from and how they are summed up, are filled in by
+//     the Loader and the Aggregator; the order of the steps, and what
+//     happens when one fails, is decided in one place
+//   - Strategy: a Format renders the finished Table, as CSV, JSON or a
+//     Markdown table, and can be swapped without touching the other steps
+//
+// Go has no abstract methods to override, so the varying steps are
+// interfaces the skeleton calls, which is how the standard library does it
+// too: sort.Sort is the template method and sort.Interface its steps
+package report
+
+import (
+	"bytes"
+	_ "embed"
+	"encoding/csv"
+	"errors"
+	"fmt"
+	"io"
+	"slices"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// SampleSales is a quarter of sales of a coffee shop chain, as CSV
+//
+//go:embed data/sales.csv
+var SampleSales string
+
+// Record is one sale
+type Record struct {
+	Date    time.Time
+	Region  string
+	Product string
+	Units   int
+	Price   float64
+}
+
+// Revenue returns what the sale brought in
+func (r Record) Revenue() float64 {
+	return float64(r.Units) * r.Price
+}
+
+// Table is an aggregated report, ready to be formatted
+// A cell is a string, an int or a float64; floats are amounts of money
+type Table struct {
+	Title   string
+	Columns []string
+	Rows    [][]any
+}
+
+// Loader is the step that reads the records
+type Loader interface {
+	Load() ([]Record, error)
+}
+
+// Aggregator is the step that sums the records up into a table
+type Aggregator interface {
+	Aggregate(records []Record) (Table, error)
+}
+
+// Format is the Strategy that renders a table
+type Format interface {
+	// Name is what FormatByName knows the format by
+	Name() string
+	Write(w io.Writer, t Table) error
+}
+
+// StepError is returned by Generate when a step fails, and names the step
+type StepError struct {
+	Step string
+	Err  error
+}
+
+func (e *StepError) Error() string {
+	return fmt.Sprintf("report: %s: %v", e.Step, e.Err)
+}
+
+func (e *StepError) Unwrap() error {
+	return e.Err
+}
+
+// Generator holds the steps of one kind of report
+type Generator struct {
+	Loader     Loader
+	Aggregator Aggregator
+	Format     Format
+}
+
+// Generate is the template method: it loads the records, aggregates them,
+// formats the table and writes it to w, in that order
+// The report is formatted in memory first, so a format that fails half way
+// leaves nothing on w
+func (g Generator) Generate(w io.Writer) error {
+	records, err := g.Loader.Load()
+	if err != nil {
+		return &StepError{"load", err}
+	}
+	table, err := g.Aggregator.Aggregate(records)
+	if err != nil {
+		return &StepError{"aggregate", err}
+	}
+	var buf bytes.Buffer
+	if err := g.Format.Write(&buf, table); err != nil {
+		return &StepError{"format", err}
+	}
+	if _, err := buf.WriteTo(w); err != nil {
+		return &StepError{"write", err}
+	}
+	return nil
+}
+
+// CSVLoader reads records from CSV with the columns date, region,
+// product, units and price in any order. Lines starting with # are
+// comments
+type CSVLoader struct {
+	R io.Reader
+}
+
+// Load implements Loader
+func (l CSVLoader) Load() ([]Record, error) {
+	r := csv.NewReader(l.R)
+	r.Comment = '#'
+	header, err := r.Read()
+	if err != nil {
+		return nil, fmt.Errorf("reading header: %w", err)
+	}
+	col := make(map[string]int)
+	for i, name := range header {
+		col[strings.ToLower(strings.TrimSpace(name))] = i
+	}
+	for _, name := range []string{"date", "region", "product", "units", "price"} {
+		if _, ok := col[name]; !ok {
+			return nil, fmt.Errorf("header has no %s column", name)
+		}
+	}
+
+	var records []Record
+	for {
+		row, err := r.Read()
+		if err == io.EOF {
+			return records, nil
+		}
+		if err != nil {
+			return nil, err
+		}
+		line, _ := r.FieldPos(0)
+		date, err := time.Parse(time.DateOnly, row[col["date"]])
+		if err != nil {
+			return nil, fmt.Errorf("line %d: date %q is not YYYY-MM-DD", line, row[col["date"]])
+		}
+		units, err := strconv.Atoi(row[col["units"]])
+		if err != nil || units < 0 {
+			return nil, fmt.Errorf("line %d: units %q is not a count", line, row[col["units"]])
+		}
+		price, err := strconv.ParseFloat(row[col["price"]], 64)
+		if err != nil || price < 0 {
+			return nil, fmt.Errorf("line %d: price %q is not an amount", line, row[col["price"]])
+		}
+		records = append(records, Record{date, row[col["region"]], row[col["product"]], units, price})
+	}
+}
+
+// Field is what SalesBy groups the records by
+type Field int
+
+const (
+	ByRegion Field = iota
+	ByProduct
+	ByMonth
+)
+
+var fieldNames = [...]string{"Region", "Product", "Month"}
+
+func (f Field) String() string {
+	return fieldNames[f]
+}
+
+// key returns the group of a record
+func (f Field) key(r Record) string {
+	switch f {
+	case ByRegion:
+		return r.Region
+	case ByProduct:
+		return r.Product
+	}
+	return r.Date.Format("2006-01")
+}
+
+// SalesBy is an Aggregator that sums units and revenue per region,
+// product or month, with a total at the end
+// Months are listed in order, the other groups by revenue, highest first
+type SalesBy Field
+
+// Aggregate implements Aggregator
+func (s SalesBy) Aggregate(records []Record) (Table, error) {
+	if len(records) == 0 {
+		return Table{}, errors.New("no records")
+	}
+	field := Field(s)
+	type group struct {
+		name    string
+		orders  int
+		units   int
+		revenue float64
+	}
+	index := make(map[string]int)
+	var groups []group
+	var total group
+	for _, r := range records {
+		k := field.key(r)
+		i, ok := index[k]
+		if !ok {
+			i = len(groups)
+			index[k] = i
+			groups = append(groups, group{name: k})
+		}
+		for _, g := range []*group{&groups[i], &total} {
+			g.orders++
+			g.units += r.Units
+			g.revenue += r.Revenue()
+		}
+	}
+	slices.SortStableFunc(groups, func(a, b group) int {
+		if field == ByMonth || a.revenue == b.revenue {
+			return strings.Compare(a.name, b.name)
+		}
+		if a.revenue > b.revenue {
+			return -1
+		}
+		return 1
+	})
+
+	t := Table{
+		Title:   "Sales by " + strings.ToLower(field.String()),
+		Columns: []string{field.String(), "Orders", "Units", "Revenue"},
+	}
+	for _, g := range append(groups, group{"Total", total.orders, total.units, total.revenue}) {
+		t.Rows = append(t.Rows, []any{g.name, g.orders, g.units, g.revenue})
+	}
+	return t, nil
+}
@@ -0,0 +1,113 @@
+package report
+
+import (
+	"bytes"
+	"errors"
+	"flag"
+	"io"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+)
+
+var update = flag.Bool("update", false, "rewrite the golden files in testdata")
+
+// TestGolden compares every report with its golden file in testdata
+// After a deliberate change to the output, rewrite them with
+//
+//	go test ./04-design-patterns/report -update
+func TestGolden(t *testing.T) {
+	for _, by := range []Field{ByRegion, ByProduct, ByMonth} {
+		for _, name := range FormatNames() {
+			format, _ := FormatByName(name)
+			ext := map[string]string{"csv": ".csv", "json": ".json", "markdown": ".md"}[name]
+			golden := filepath.Join("testdata", "sales_by_"+strings.ToLower(by.String())+ext)
+			t.Run(filepath.Base(golden), func(t *testing.T) {
+				var got bytes.Buffer
+				g := Generator{CSVLoader{strings.NewReader(SampleSales)}, SalesBy(by), format}
+				if err := g.Generate(&got); err != nil {
+					t.Fatal(err)
+				}
+				if *update {
+					if err := os.WriteFile(golden, got.Bytes(), 0o644); err != nil {
+						t.Fatal(err)
+					}
+					return
+				}
+				want, err := os.ReadFile(golden)
+				if err != nil {
+					t.Fatalf("%v (run with -update to create it)", err)
+				}
+				if !bytes.Equal(got.Bytes(), want) {
+					t.Errorf("%s differs from the report:\n%s", golden, got.Bytes())
+				}
+			})
+		}
+	}
+}
+
+func TestLoadErrors(t *testing.T) {
+	for _, tt := range []struct{ csv, want string }{
+		{"", "reading header"},
+		{"date,region,product,units\n", "no price column"},
+		{"date,region,product,units,price\n2024-13-01,N,Tea,1,2\n", `line 2: date "2024-13-01"`},
+		{"date,region,product,units,price\n2024-01-01,N,Tea,-1,2\n", `line 2: units "-1"`},
+		{"date,region,product,units,price\n2024-01-01,N,Tea,1,free\n", `line 2: price "free"`},
+		{"date,region,product,units,price\n2024-01-01,N,Tea\n", "wrong number of fields"},
+	} {
+		_, err := CSVLoader{strings.NewReader(tt.csv)}.Load()
+		if err == nil || !strings.Contains(err.Error(), tt.want) {
+			t.Errorf("Load(%q) = %v, want %q", tt.csv, err, tt.want)
+		}
+	}
+}
+
+// failingWriter fails every write
+type failingWriter struct{}
+
+func (failingWriter) Write([]byte) (int, error) { return 0, errors.New("disk full") }
+
+func TestStepErrors(t *testing.T) {
+	header := "date,region,product,units,price\n"
+	for _, tt := range []struct {
+		step string
+		data string
+		w    io.Writer
+	}{
+		{"load", "nonsense", new(bytes.Buffer)},
+		{"aggregate", header, new(bytes.Buffer)},
+		{"write", header + "2024-01-01,N,Tea,1,2\n", failingWriter{}},
+	} {
+		err := Generator{CSVLoader{strings.NewReader(tt.data)}, SalesBy(ByRegion), Markdown{}}.Generate(tt.w)
+		var se *StepError
+		if !errors.As(err, &se) || se.Step != tt.step {
+			t.Errorf("err = %v, want a StepError from %s", err, tt.step)
+		}
+	}
+
+	// A format that fails leaves nothing behind
+	var buf bytes.Buffer
+	table := Table{Columns: []string{"a"}, Rows: [][]any{{1, 2}}}
+	err := Generator{tableLoader{}, fixed(table), JSON{}}.Generate(&buf)
+	var se *StepError
+	if !errors.As(err, &se) || se.Step != "format" || buf.Len() != 0 {
+		t.Errorf("Generate = %v with %q written, want a format error and nothing written", err, buf.String())
+	}
+}
+
+// tableLoader loads one record, for steps that ignore the records
+type tableLoader struct{}
+
+func (tableLoader) Load() ([]Record, error) { return []Record{{}}, nil }
+
+// fixed is an Aggregator that returns the same table whatever the records
+type fixed Table
+
+func (f fixed) Aggregate([]Record) (Table, error) { return Table(f), nil }
+
+func TestFormatByName(t *testing.T) {
+	if _, err := FormatByName("pdf"); err == nil || !strings.Contains(err.Error(), "[csv json markdown]") {
+		t.Errorf("FormatByName(pdf) = %v", err)
+	}
+}
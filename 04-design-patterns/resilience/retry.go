@@ -0,0 +1,191 @@
+// Retry Pattern re-runs an operation that failed because of a transient problem
+// (a dropped connection, a busy server) instead of failing the whole request.
+// Waiting longer after every failure (exponential backoff) gives the other side
+// time to recover, and random jitter stops many clients from retrying in lockstep.
+//
+// Use cases:
+// - Calling remote services and databases over an unreliable network
+// - Acquiring a lock or resource that is briefly unavailable
+// - Background jobs that should survive temporary outages
+
+package resilience
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"math"
+	"math/rand"
+	"sync"
+	"time"
+)
+
+// ErrRetriesExhausted is returned when every attempt failed
+// The error from the last attempt is wrapped as well
+var ErrRetriesExhausted = errors.New("retries exhausted")
+
+// Backoff decides how long to wait before the next attempt
+// attempt is the number of attempts made so far, starting at 1
+type Backoff interface {
+	Delay(attempt int) time.Duration
+}
+
+// ConstantBackoff waits the same interval after every failure
+type ConstantBackoff struct {
+	Interval time.Duration
+}
+
+// Delay returns the fixed interval
+func (b ConstantBackoff) Delay(attempt int) time.Duration {
+	return b.Interval
+}
+
+// ExponentialBackoff waits Initial, Initial*Multiplier, Initial*Multiplier², ...
+// never more than Max (if Max > 0), nor than the longest time.Duration
+type ExponentialBackoff struct {
+	Initial    time.Duration
+	Multiplier float64
+	Max        time.Duration
+}
+
+// Delay returns the exponentially growing delay for attempt
+func (b ExponentialBackoff) Delay(attempt int) time.Duration {
+	multiplier := b.Multiplier
+	if multiplier <= 0 {
+		multiplier = 2
+	}
+	ceiling := b.Max
+	if ceiling <= 0 {
+		ceiling = math.MaxInt64
+	}
+	// Stop at the ceiling, so the float can't overflow time.Duration however
+	// many attempts there are
+	delay := float64(b.Initial)
+	for i := 1; i < attempt && delay < float64(ceiling); i++ {
+		delay *= multiplier
+	}
+	if delay >= float64(ceiling) {
+		return ceiling
+	}
+	return time.Duration(delay)
+}
+
+// jitterBackoff decorates another Backoff with randomness
+type jitterBackoff struct {
+	backoff Backoff
+	full    bool
+	mu      sync.Mutex // *rand.Rand is not safe for concurrent use
+	rng     *rand.Rand
+}
+
+// FullJitter picks a random delay between 0 and the wrapped delay
+// This spreads retries out the most ("Full Jitter" in the AWS architecture blog)
+func FullJitter(b Backoff, rng *rand.Rand) Backoff {
+	return &jitterBackoff{backoff: b, full: true, rng: rng}
+}
+
+// EqualJitter keeps half of the wrapped delay and randomizes the other half,
+// so there is always some minimum wait
+func EqualJitter(b Backoff, rng *rand.Rand) Backoff {
+	return &jitterBackoff{backoff: b, rng: rng}
+}
+
+// Delay returns the wrapped delay with jitter applied
+func (j *jitterBackoff) Delay(attempt int) time.Duration {
+	delay := j.backoff.Delay(attempt)
+	if delay <= 0 {
+		return 0
+	}
+	fixed := delay / 2
+	if j.full {
+		fixed = 0
+	}
+	// The span includes the full delay, except when that is the longest
+	// Duration, as an uncapped ExponentialBackoff reaches: one more would
+	// overflow
+	span := int64(delay - fixed)
+	if span < math.MaxInt64 {
+		span++
+	}
+	j.mu.Lock()
+	defer j.mu.Unlock()
+	return fixed + time.Duration(j.rng.Int63n(span))
+}
+
+// permanentError marks an error that must not be retried
+type permanentError struct {
+	err error
+}
+
+func (e *permanentError) Error() string { return e.err.Error() }
+func (e *permanentError) Unwrap() error { return e.err }
+
+// Permanent wraps err so Retry gives up immediately
+// Use it for failures that will not go away, e.g. invalid input or a 404
+func Permanent(err error) error {
+	if err == nil {
+		return nil
+	}
+	return &permanentError{err: err}
+}
+
+// Retrier runs operations with retries
+// The zero value makes a single attempt with no delay
+type Retrier struct {
+	// MaxAttempts is the total number of attempts, including the first one
+	MaxAttempts int
+	// Backoff decides the wait between attempts (no wait if nil)
+	Backoff Backoff
+	// Clock is used to wait between attempts (RealClock if nil)
+	Clock Clock
+	// OnRetry is called before every wait, e.g. for logging
+	OnRetry func(attempt int, err error, delay time.Duration)
+}
+
+// Do calls op until it succeeds, returns a Permanent error,
+// runs out of attempts or ctx is cancelled
+func (r Retrier) Do(ctx context.Context, op func(ctx context.Context) error) error {
+	clock := r.Clock
+	if clock == nil {
+		clock = RealClock{}
+	}
+	attempts := max(r.MaxAttempts, 1)
+
+	var err error
+	for attempt := 1; ; attempt++ {
+		if err = op(ctx); err == nil {
+			return nil
+		}
+
+		var permanent *permanentError
+		if errors.As(err, &permanent) {
+			return permanent.err
+		}
+		if attempt >= attempts {
+			return fmt.Errorf("%w after %d attempts: %w", ErrRetriesExhausted, attempt, err)
+		}
+
+		var delay time.Duration
+		if r.Backoff != nil {
+			delay = r.Backoff.Delay(attempt)
+		}
+		if r.OnRetry != nil {
+			r.OnRetry(attempt, err, delay)
+		}
+
+		// Wait for the delay, but stop as soon as the caller gives up
+		if ctx.Err() != nil {
+			return fmt.Errorf("%w (last error: %w)", ctx.Err(), err)
+		}
+		select {
+		case <-ctx.Done():
+			return fmt.Errorf("%w (last error: %w)", ctx.Err(), err)
+		case <-clock.After(delay):
+		}
+	}
+}
+
+// Retry is a shortcut for a Retrier with the given attempts and backoff
+func Retry(ctx context.Context, maxAttempts int, backoff Backoff, op func(ctx context.Context) error) error {
+	return Retrier{MaxAttempts: maxAttempts, Backoff: backoff}.Do(ctx, op)
+}
@@ -0,0 +1,187 @@
+package resilience
+
+import (
+	"context"
+	"errors"
+	"math"
+	"math/rand"
+	"slices"
+	"testing"
+	"time"
+)
+
+var errFlaky = errors.New("flaky")
+
+// failing returns an op that fails n times and then succeeds, counting calls
+func failing(n int, calls *int) func(context.Context) error {
+	return func(context.Context) error {
+		*calls++
+		if *calls <= n {
+			return errFlaky
+		}
+		return nil
+	}
+}
+
+func TestBackoffSequences(t *testing.T) {
+	ms := time.Millisecond
+	tests := []struct {
+		name    string
+		backoff Backoff
+		want    []time.Duration
+	}{
+		{"constant", ConstantBackoff{Interval: 50 * ms}, []time.Duration{50 * ms, 50 * ms, 50 * ms, 50 * ms}},
+		{"exponential", ExponentialBackoff{Initial: 10 * ms, Multiplier: 2}, []time.Duration{10 * ms, 20 * ms, 40 * ms, 80 * ms}},
+		{"default multiplier", ExponentialBackoff{Initial: 10 * ms}, []time.Duration{10 * ms, 20 * ms, 40 * ms, 80 * ms}},
+		{"capped", ExponentialBackoff{Initial: 10 * ms, Multiplier: 3, Max: 100 * ms}, []time.Duration{10 * ms, 30 * ms, 90 * ms, 100 * ms}},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			// Five attempts wait four times, and the fake clock records the waits
+			clock := NewFakeClock(time.Unix(0, 0))
+			calls := 0
+			r := Retrier{MaxAttempts: 5, Backoff: tt.backoff, Clock: clock}
+			if err := r.Do(context.Background(), failing(4, &calls)); err != nil {
+				t.Fatalf("Do = %v", err)
+			}
+			if got := clock.Waits(); !slices.Equal(got, tt.want) {
+				t.Errorf("waits = %v, want %v", got, tt.want)
+			}
+			var total time.Duration
+			for _, d := range tt.want {
+				total += d
+			}
+			if got := clock.Now().Sub(time.Unix(0, 0)); got != total {
+				t.Errorf("clock moved %v, want %v", got, total)
+			}
+		})
+	}
+}
+
+func TestExponentialBackoffOverflow(t *testing.T) {
+	b := ExponentialBackoff{Initial: time.Second, Multiplier: 10}
+	prev := time.Duration(0)
+	for attempt := 1; attempt <= 1000; attempt++ {
+		d := b.Delay(attempt)
+		if d < prev {
+			t.Fatalf("Delay(%d) = %v, less than %v", attempt, d, prev)
+		}
+		prev = d
+	}
+	if prev != math.MaxInt64 {
+		t.Errorf("Delay(1000) = %v, want the longest Duration", prev)
+	}
+	capped := ExponentialBackoff{Initial: time.Second, Max: time.Minute}
+	if d := capped.Delay(math.MaxInt32); d != time.Minute {
+		t.Errorf("capped Delay(MaxInt32) = %v, want 1m", d)
+	}
+}
+
+func TestJitterBounds(t *testing.T) {
+	base := ExponentialBackoff{Initial: 100 * time.Millisecond, Max: 2 * time.Second}
+	rng := rand.New(rand.NewSource(1))
+	full, equal := FullJitter(base, rng), EqualJitter(base, rng)
+	for attempt := 1; attempt <= 8; attempt++ {
+		limit := base.Delay(attempt)
+		for range 1000 {
+			if d := full.Delay(attempt); d < 0 || d > limit {
+				t.Fatalf("FullJitter.Delay(%d) = %v, outside [0, %v]", attempt, d, limit)
+			}
+			if d := equal.Delay(attempt); d < limit/2 || d > limit {
+				t.Fatalf("EqualJitter.Delay(%d) = %v, outside [%v, %v]", attempt, d, limit/2, limit)
+			}
+		}
+	}
+	if d := FullJitter(ConstantBackoff{}, rng).Delay(1); d != 0 {
+		t.Errorf("jitter of no delay = %v", d)
+	}
+}
+
+// Without a Max, the delay reaches the longest Duration after enough
+// attempts, and the jitter must still stay within it
+func TestJitterUncapped(t *testing.T) {
+	base := ExponentialBackoff{Initial: time.Second}
+	rng := rand.New(rand.NewSource(1))
+	for _, attempt := range []int{40, 100, math.MaxInt32} {
+		if base.Delay(attempt) != math.MaxInt64 {
+			t.Fatalf("Delay(%d) = %v, want the longest Duration", attempt, base.Delay(attempt))
+		}
+		if d := FullJitter(base, rng).Delay(attempt); d < 0 {
+			t.Errorf("FullJitter.Delay(%d) = %v", attempt, d)
+		}
+		if d := EqualJitter(base, rng).Delay(attempt); d < math.MaxInt64/2 {
+			t.Errorf("EqualJitter.Delay(%d) = %v, below half the delay", attempt, d)
+		}
+	}
+}
+
+func TestPermanentStopsRetrying(t *testing.T) {
+	clock := NewFakeClock(time.Unix(0, 0))
+	invalid := errors.New("invalid input")
+	calls := 0
+	r := Retrier{MaxAttempts: 5, Backoff: ConstantBackoff{Interval: time.Second}, Clock: clock}
+	err := r.Do(context.Background(), func(context.Context) error {
+		calls++
+		return Permanent(invalid)
+	})
+	if err != invalid || calls != 1 || len(clock.Waits()) != 0 {
+		t.Errorf("Do = %v after %d calls and %d waits, want invalid input after 1 and 0", err, calls, len(clock.Waits()))
+	}
+	if Permanent(nil) != nil {
+		t.Error("Permanent(nil) != nil")
+	}
+}
+
+func TestRetriesExhausted(t *testing.T) {
+	clock := NewFakeClock(time.Unix(0, 0))
+	calls := 0
+	var retried []int
+	r := Retrier{
+		MaxAttempts: 3,
+		Backoff:     ConstantBackoff{Interval: time.Second},
+		Clock:       clock,
+		OnRetry:     func(attempt int, err error, delay time.Duration) { retried = append(retried, attempt) },
+	}
+	err := r.Do(context.Background(), failing(10, &calls))
+	if !errors.Is(err, ErrRetriesExhausted) || !errors.Is(err, errFlaky) {
+		t.Errorf("Do = %v, want ErrRetriesExhausted wrapping the last error", err)
+	}
+	if calls != 3 || len(clock.Waits()) != 2 || !slices.Equal(retried, []int{1, 2}) {
+		t.Errorf("%d calls, %d waits, OnRetry for %v; want 3, 2, [1 2]", calls, len(clock.Waits()), retried)
+	}
+
+	// The zero Retrier makes one attempt
+	calls = 0
+	if err := (Retrier{}).Do(context.Background(), failing(1, &calls)); !errors.Is(err, ErrRetriesExhausted) || calls != 1 {
+		t.Errorf("zero Retrier: %v after %d calls", err, calls)
+	}
+}
+
+// stoppedClock is a Clock whose waits never end
+type stoppedClock struct{ FakeClock }
+
+func (*stoppedClock) After(time.Duration) <-chan time.Time { return nil }
+
+func TestCancelEndsWait(t *testing.T) {
+	// Cancelled before the wait: the fake clock isn't waited on at all
+	clock := NewFakeClock(time.Unix(0, 0))
+	ctx, cancel := context.WithCancel(context.Background())
+	r := Retrier{MaxAttempts: 5, Backoff: ConstantBackoff{Interval: time.Hour}, Clock: clock}
+	err := r.Do(ctx, func(context.Context) error {
+		cancel()
+		return errFlaky
+	})
+	if !errors.Is(err, context.Canceled) || !errors.Is(err, errFlaky) || len(clock.Waits()) != 0 {
+		t.Errorf("Do = %v after %d waits, want Canceled wrapping the last error", err, len(clock.Waits()))
+	}
+
+	// Cancelled during a wait that would never end
+	ctx, cancel = context.WithCancel(context.Background())
+	r = Retrier{MaxAttempts: 5, Clock: &stoppedClock{}, OnRetry: func(int, error, time.Duration) {
+		time.AfterFunc(time.Millisecond, cancel)
+	}}
+	calls := 0
+	if err := r.Do(ctx, failing(10, &calls)); !errors.Is(err, context.Canceled) || calls != 1 {
+		t.Errorf("Do = %v after %d calls, want Canceled after 1", err, calls)
+	}
+}
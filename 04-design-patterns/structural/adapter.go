@@ -5,34 +5,276 @@
 // - When you want to use an existing class that doesn't fit your interface
 // - When you need to reuse existing classes with incompatible interfaces
 // - When you want to create a reusable class that cooperates with classes that don't have compatible interfaces
+//
+// The example is the one adapters are usually written for: a legacy
+// weather client that speaks XML, in Fahrenheit, with its own time format
+// and error documents, and new code that wants a JSON API in Celsius with
+// Go errors. An adapter does more than rename methods: it transforms the
+// data and translates the errors, so nothing of the legacy API leaks
+// through
+//
+// - LegacyAdapter makes a LegacyWeather usable as a WeatherAPI
+// - XMLAdapter goes the other way, for old callers of a new service
+// - TwoWayAdapter is both at once, which lets old and new callers share
+//   one backend while code moves from one API to the other
 
 package structural
 
-// Target defines the domain-specific interface used by the client code
-type Target interface {
-	Request() string
+import (
+	"encoding/json"
+	"encoding/xml"
+	"errors"
+	"fmt"
+	"math"
+	"strconv"
+	"strings"
+	"time"
+)
+
+var (
+	// ErrStationNotFound is returned for a station the service doesn't know
+	ErrStationNotFound = errors.New("station not found")
+	// ErrServiceUnavailable is returned when the service can't answer now
+	ErrServiceUnavailable = errors.New("weather service unavailable")
+	// ErrBadResponse is returned for a response that can't be understood
+	ErrBadResponse = errors.New("bad response from weather service")
+)
+
+// Observation is a weather reading as the new code models it
+type Observation struct {
+	Station      string    `json:"station"`
+	TemperatureC float64   `json:"temperature_c"`
+	Humidity     int       `json:"humidity_percent"`
+	ObservedAt   time.Time `json:"observed_at"`
+}
+
+// WeatherAPI is the Target: the interface new client code is written
+// against. Current returns the latest Observation of a station as JSON
+type WeatherAPI interface {
+	Current(station string) ([]byte, error)
+}
+
+// LegacyWeather is the interface of the legacy client, the Adaptee
+// Fetch returns an XML document, either a reading:
+//
+//	<reading id="BKK"><tempF>91.4</tempF><rh>70%</rh><time>202404151430</time></reading>
+//
+// or an error with an HTTP-like code:
+//
+//	<error code="404">unknown station</error>
+//
+// A Go error means the service couldn't be reached at all
+type LegacyWeather interface {
+	Fetch(stationID string) (string, error)
+}
+
+// legacyTimeFormat is how the legacy service writes times, always in UTC
+const legacyTimeFormat = "200601021504"
+
+// legacyReading is the XML the legacy service sends, a reading or an error
+type legacyReading struct {
+	XMLName  xml.Name
+	ID       string `xml:"id,attr,omitempty"`
+	TempF    string `xml:"tempF,omitempty"`
+	Humidity string `xml:"rh,omitempty"`
+	Time     string `xml:"time,omitempty"`
+	Code     int    `xml:"code,attr,omitempty"`
+	Message  string `xml:",chardata"`
+}
+
+// LegacyWeatherClient stands for the third-party client that can't be
+// changed. Its readings are canned; Offline makes every Fetch fail the way
+// a lost connection would
+type LegacyWeatherClient struct {
+	readings map[string]string
+	// maintenance lists the stations that answer with a 503
+	maintenance map[string]bool
+	Offline     bool
+}
+
+// NewLegacyWeatherClient returns a client with readings for a few Thai
+// airports; DMK is down for maintenance
+func NewLegacyWeatherClient() *LegacyWeatherClient {
+	return &LegacyWeatherClient{
+		readings: map[string]string{
+			"BKK": `<reading id="BKK"><tempF>91.4</tempF><rh>70%</rh><time>202404151430</time></reading>`,
+			"CNX": `<reading id="CNX"><tempF>86.0</tempF><rh>45%</rh><time>202404151430</time></reading>`,
+			"HKT": `<reading id="HKT"><tempF>89.6</tempF><rh>78%</rh><time>202404151400</time></reading>`,
+		},
+		maintenance: map[string]bool{"DMK": true},
+	}
+}
+
+// Fetch returns the XML reading of a station
+func (c *LegacyWeatherClient) Fetch(stationID string) (string, error) {
+	switch {
+	case c.Offline:
+		return "", errors.New("legacy weather: connection refused")
+	case c.maintenance[stationID]:
+		return `<error code="503">station under maintenance</error>`, nil
+	}
+	reading, ok := c.readings[stationID]
+	if !ok {
+		return `<error code="404">unknown station</error>`, nil
+	}
+	return reading, nil
+}
+
+// LegacyAdapter makes a LegacyWeather client compatible with WeatherAPI
+type LegacyAdapter struct {
+	legacy LegacyWeather
 }
 
-// Adaptee contains some useful behavior, but its interface is incompatible
-// with the existing client code
-type Adaptee struct{}
+// NewLegacyAdapter creates a WeatherAPI backed by a legacy client
+func NewLegacyAdapter(legacy LegacyWeather) *LegacyAdapter {
+	return &LegacyAdapter{legacy: legacy}
+}
+
+// Current fetches the XML reading and returns it as an Observation in JSON
+// The legacy errors become ErrStationNotFound, ErrServiceUnavailable or
+// ErrBadResponse, so callers never have to know the XML error codes
+func (a *LegacyAdapter) Current(station string) ([]byte, error) {
+	obs, err := a.observation(station)
+	if err != nil {
+		return nil, err
+	}
+	return json.Marshal(obs)
+}
+
+func (a *LegacyAdapter) observation(station string) (Observation, error) {
+	doc, err := a.legacy.Fetch(station)
+	if err != nil {
+		return Observation{}, fmt.Errorf("%w: %w", ErrServiceUnavailable, err)
+	}
+	var r legacyReading
+	if err := xml.Unmarshal([]byte(doc), &r); err != nil {
+		return Observation{}, fmt.Errorf("%w: %w", ErrBadResponse, err)
+	}
+	if r.XMLName.Local == "error" {
+		return Observation{}, translateLegacyError(station, r)
+	}
+
+	tempF, err := strconv.ParseFloat(r.TempF, 64)
+	if err != nil {
+		return Observation{}, fmt.Errorf("%w: temperature %q", ErrBadResponse, r.TempF)
+	}
+	humidity, err := strconv.Atoi(strings.TrimSuffix(r.Humidity, "%"))
+	if err != nil {
+		return Observation{}, fmt.Errorf("%w: humidity %q", ErrBadResponse, r.Humidity)
+	}
+	observed, err := time.Parse(legacyTimeFormat, r.Time)
+	if err != nil {
+		return Observation{}, fmt.Errorf("%w: time %q", ErrBadResponse, r.Time)
+	}
+	return Observation{
+		Station:      r.ID,
+		TemperatureC: roundTenth((tempF - 32) * 5 / 9),
+		Humidity:     humidity,
+		ObservedAt:   observed,
+	}, nil
+}
+
+// translateLegacyError turns an XML error document into a Go error
+func translateLegacyError(station string, r legacyReading) error {
+	switch {
+	case r.Code == 404:
+		return fmt.Errorf("%w: %s", ErrStationNotFound, station)
+	case r.Code >= 500:
+		return fmt.Errorf("%w: %s: %s", ErrServiceUnavailable, station, strings.TrimSpace(r.Message))
+	}
+	return fmt.Errorf("%w: error %d: %s", ErrBadResponse, r.Code, strings.TrimSpace(r.Message))
+}
+
+// roundTenth rounds to one decimal, the precision of the readings
+func roundTenth(x float64) float64 {
+	return math.Round(x*10) / 10
+}
+
+// XMLAdapter makes a WeatherAPI compatible with LegacyWeather, for the old
+// callers of a service that has moved to the new API
+type XMLAdapter struct {
+	api WeatherAPI
+}
+
+// NewXMLAdapter creates a LegacyWeather backed by a WeatherAPI
+func NewXMLAdapter(api WeatherAPI) *XMLAdapter {
+	return &XMLAdapter{api: api}
+}
+
+// Fetch returns the Observation of a station as a legacy XML document
+// ErrStationNotFound and ErrServiceUnavailable become the error documents
+// old callers expect; other errors are passed on
+func (a *XMLAdapter) Fetch(stationID string) (string, error) {
+	data, err := a.api.Current(stationID)
+	switch {
+	case errors.Is(err, ErrStationNotFound):
+		return legacyError(404, "unknown station")
+	case errors.Is(err, ErrServiceUnavailable):
+		return legacyError(503, err.Error())
+	case err != nil:
+		return "", err
+	}
+	var obs Observation
+	if err := json.Unmarshal(data, &obs); err != nil {
+		return "", fmt.Errorf("%w: %w", ErrBadResponse, err)
+	}
+	out, err := xml.Marshal(legacyReading{
+		XMLName:  xml.Name{Local: "reading"},
+		ID:       obs.Station,
+		TempF:    strconv.FormatFloat(roundTenth(obs.TemperatureC*9/5+32), 'f', 1, 64),
+		Humidity: strconv.Itoa(obs.Humidity) + "%",
+		Time:     obs.ObservedAt.UTC().Format(legacyTimeFormat),
+	})
+	return string(out), err
+}
+
+// legacyError returns an XML error document with the message escaped
+func legacyError(code int, message string) (string, error) {
+	out, err := xml.Marshal(legacyReading{XMLName: xml.Name{Local: "error"}, Code: code, Message: message})
+	return string(out), err
+}
+
+// TwoWayAdapter implements both WeatherAPI and LegacyWeather over one
+// backend, so old and new callers can share it during a migration
+// Calls in the backend's own API go straight through; the others are
+// converted
+type TwoWayAdapter struct {
+	WeatherAPI
+	LegacyWeather
+}
+
+// TwoWayFromLegacy wraps a legacy client: Fetch goes straight to it and
+// Current converts its XML
+func TwoWayFromLegacy(legacy LegacyWeather) *TwoWayAdapter {
+	return &TwoWayAdapter{WeatherAPI: NewLegacyAdapter(legacy), LegacyWeather: legacy}
+}
 
-// SpecificRequest provides the existing behavior with a different interface
-func (a *Adaptee) SpecificRequest() string {
-	return "Specific request from Adaptee"
+// TwoWayFromAPI wraps a new service: Current goes straight to it and Fetch
+// converts its JSON
+func TwoWayFromAPI(api WeatherAPI) *TwoWayAdapter {
+	return &TwoWayAdapter{WeatherAPI: api, LegacyWeather: NewXMLAdapter(api)}
 }
 
-// Adapter makes Adaptee compatible with the Target interface
-type Adapter struct {
-	*Adaptee
+// WeatherStore is a service written for the new API, which keeps its
+// observations in memory
+type WeatherStore struct {
+	observations map[string]Observation
 }
 
-// NewAdapter creates a new Adapter
-func NewAdapter() Target {
-	return &Adapter{&Adaptee{}}
+// NewWeatherStore returns a store holding the given observations
+func NewWeatherStore(observations ...Observation) *WeatherStore {
+	s := &WeatherStore{observations: make(map[string]Observation)}
+	for _, o := range observations {
+		s.observations[o.Station] = o
+	}
+	return s
 }
 
-// Request is compatible with the Target interface
-func (a *Adapter) Request() string {
-	return "Adapter: " + a.SpecificRequest()
+// Current returns the observation of a station as JSON
+func (s *WeatherStore) Current(station string) ([]byte, error) {
+	obs, ok := s.observations[station]
+	if !ok {
+		return nil, fmt.Errorf("%w: %s", ErrStationNotFound, station)
+	}
+	return json.Marshal(obs)
 }
@@ -0,0 +1,67 @@
+package structural
+
+import (
+	"errors"
+	"testing"
+)
+
+// legacyFunc adapts a function to LegacyWeather, for canned responses
+type legacyFunc func(string) (string, error)
+
+func (f legacyFunc) Fetch(stationID string) (string, error) { return f(stationID) }
+
+func TestLegacyAdapterBadResponses(t *testing.T) {
+	for _, tt := range []struct {
+		name string
+		doc  string
+		want error
+	}{
+		{"not xml", "<reading", ErrBadResponse},
+		{"bad temperature", `<reading id="X"><tempF>hot</tempF><rh>1%</rh><time>202401010000</time></reading>`, ErrBadResponse},
+		{"bad humidity", `<reading id="X"><tempF>1</tempF><rh>damp</rh><time>202401010000</time></reading>`, ErrBadResponse},
+		{"bad time", `<reading id="X"><tempF>1</tempF><rh>1%</rh><time>yesterday</time></reading>`, ErrBadResponse},
+		{"unknown code", `<error code="418">teapot</error>`, ErrBadResponse},
+		{"server error", `<error code="500">boom</error>`, ErrServiceUnavailable},
+		{"not found", `<error code="404">unknown station</error>`, ErrStationNotFound},
+	} {
+		t.Run(tt.name, func(t *testing.T) {
+			api := NewLegacyAdapter(legacyFunc(func(string) (string, error) { return tt.doc, nil }))
+			if _, err := api.Current("X"); !errors.Is(err, tt.want) {
+				t.Errorf("Current error = %v, want %v", err, tt.want)
+			}
+		})
+	}
+}
+
+func TestAdaptersRoundTrip(t *testing.T) {
+	legacy := NewLegacyWeatherClient()
+	for _, station := range []string{"BKK", "CNX", "HKT", "DMK", "XYZ"} {
+		// Legacy XML -> JSON -> legacy XML should give back what the client sent
+		want, _ := legacy.Fetch(station)
+		got, err := NewXMLAdapter(NewLegacyAdapter(legacy)).Fetch(station)
+		if err != nil {
+			t.Fatalf("%s: %v", station, err)
+		}
+		if station == "DMK" {
+			// The message of an error document is rewritten, but its code stays
+			want, got = want[:len(`<error code="503">`)], got[:len(`<error code="503">`)]
+		}
+		if got != want {
+			t.Errorf("%s: round trip = %s, want %s", station, got, want)
+		}
+	}
+}
+
+func TestTwoWayFromLegacy(t *testing.T) {
+	legacy := NewLegacyWeatherClient()
+	both := TwoWayFromLegacy(legacy)
+	doc, _ := both.Fetch("CNX")
+	want, _ := legacy.Fetch("CNX")
+	if doc != want {
+		t.Errorf("Fetch = %s, want the legacy document %s", doc, want)
+	}
+	data, err := both.Current("CNX")
+	if want := `{"station":"CNX","temperature_c":30,"humidity_percent":45,"observed_at":"2024-04-15T14:30:00Z"}`; err != nil || string(data) != want {
+		t.Errorf("Current = %s, %v, want %s", data, err, want)
+	}
+}
@@ -5,9 +5,22 @@
 // - When you need to add responsibilities to objects dynamically
 // - When extension by subclassing is impractical
 // - When you want to add behaviors without altering existing code
+//
+// With many decorators, two things matter beyond wrapping one object in
+// another: composing a list of them, which Decorate does for any type, and
+// the order they wrap in. A discount taken before the milk is added costs
+// less than one taken after, so decorators can declare a priority and
+// DecorateOrdered sorts them. SizeDecorator, DiscountDecorator and Coupon
+// carry state of their own, unlike the toppings
 
 package structural
 
+import (
+	"cmp"
+	"fmt"
+	"slices"
+)
+
 // Coffee defines the interface for coffee types
 type Coffee interface {
 	GetCost() float64
@@ -80,3 +93,138 @@ func (w *WhipDecorator) GetCost() float64 {
 func (w *WhipDecorator) GetDescription() string {
 	return w.coffee.GetDescription() + ", whip"
 }
+
+// Decorator is the decorator pattern for any type T: a function that wraps
+// a T in another T. NewMilkDecorator is a Decorator[Coffee] and Middleware
+// is a Decorator[Handler] in all but name
+type Decorator[T any] func(T) T
+
+// Decorate applies decorators to base in order, so the first is the
+// innermost. Chain puts the first outermost instead, which reads better
+// for middleware
+func Decorate[T any](base T, decorators ...Decorator[T]) T {
+	for _, decorate := range decorators {
+		base = decorate(base)
+	}
+	return base
+}
+
+// Ordered is a decorator that knows where it belongs: the ones with a
+// lower Priority go inside the ones with a higher Priority
+type Ordered[T any] struct {
+	Name     string
+	Priority int
+	Decorate Decorator[T]
+}
+
+// DecorateOrdered applies decorators by priority, lowest innermost, and
+// those with the same priority in the order given
+// With many decorators from many places, the order they were listed in
+// says little; the priorities keep the result right regardless
+func DecorateOrdered[T any](base T, decorators ...Ordered[T]) T {
+	sorted := slices.Clone(decorators)
+	slices.SortStableFunc(sorted, func(a, b Ordered[T]) int {
+		return cmp.Compare(a.Priority, b.Priority)
+	})
+	for _, d := range sorted {
+		base = d.Decorate(base)
+	}
+	return base
+}
+
+// Priorities of the coffee decorators: the size scales the plain coffee,
+// the toppings cost the same on every size, and a discount comes off the
+// whole price
+const (
+	PrioritySize     = 0
+	PriorityTopping  = 10
+	PriorityDiscount = 100
+)
+
+// Size is the size of a coffee
+type Size int
+
+const (
+	Small Size = iota
+	Medium
+	Large
+)
+
+var sizeNames = [...]string{"small", "medium", "large"}
+
+// sizeFactors are the price of each size relative to a medium
+var sizeFactors = [...]float64{0.8, 1, 1.5}
+
+func (s Size) String() string {
+	return sizeNames[s]
+}
+
+// SizeDecorator scales the price of a coffee to its size
+// Unlike milk or sugar it has state of its own, the size
+type SizeDecorator struct {
+	CoffeeDecorator
+	size Size
+}
+
+// NewSizeDecorator returns a decorator that makes a coffee the given size
+func NewSizeDecorator(size Size) Decorator[Coffee] {
+	return func(c Coffee) Coffee {
+		return &SizeDecorator{CoffeeDecorator{coffee: c}, size}
+	}
+}
+
+func (s *SizeDecorator) GetCost() float64 {
+	return s.coffee.GetCost() * sizeFactors[s.size]
+}
+
+func (s *SizeDecorator) GetDescription() string {
+	return s.coffee.GetDescription() + " (" + s.size.String() + ")"
+}
+
+// DiscountDecorator takes a percentage off the price of a coffee
+type DiscountDecorator struct {
+	CoffeeDecorator
+	percent float64
+}
+
+// NewDiscountDecorator returns a decorator that takes percent off
+func NewDiscountDecorator(percent float64) Decorator[Coffee] {
+	return func(c Coffee) Coffee {
+		return &DiscountDecorator{CoffeeDecorator{coffee: c}, percent}
+	}
+}
+
+func (d *DiscountDecorator) GetCost() float64 {
+	return d.coffee.GetCost() * (1 - d.percent/100)
+}
+
+func (d *DiscountDecorator) GetDescription() string {
+	return fmt.Sprintf("%s, %g%% off", d.coffee.GetDescription(), d.percent)
+}
+
+// Coupon is a discount for a limited number of coffees
+// Its state outlives any one coffee: every coffee it decorates uses it up
+// a little, and once it is used up it leaves coffees as they are
+type Coupon struct {
+	percent   float64
+	remaining int
+}
+
+// NewCoupon returns a coupon for percent off, good for uses coffees
+func NewCoupon(percent float64, uses int) *Coupon {
+	return &Coupon{percent: percent, remaining: uses}
+}
+
+// Apply is the coupon's Decorator[Coffee]
+func (c *Coupon) Apply(coffee Coffee) Coffee {
+	if c.remaining == 0 {
+		return coffee
+	}
+	c.remaining--
+	return NewDiscountDecorator(c.percent)(coffee)
+}
+
+// Remaining returns how many more coffees the coupon is good for
+func (c *Coupon) Remaining() int {
+	return c.remaining
+}
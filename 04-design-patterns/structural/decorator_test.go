@@ -0,0 +1,43 @@
+package structural
+
+import (
+	"strings"
+	"testing"
+)
+
+func TestDecorate(t *testing.T) {
+	exclaim := func(s string) string { return s + "!" }
+	got := Decorate("hi", strings.ToUpper, exclaim, exclaim)
+	if got != "HI!!" {
+		t.Errorf("Decorate = %q, want %q", got, "HI!!")
+	}
+	if got := Decorate(3); got != 3 {
+		t.Errorf("Decorate with no decorators = %d, want 3", got)
+	}
+}
+
+func TestDecorateOrdered(t *testing.T) {
+	tag := func(name string, priority int) Ordered[string] {
+		return Ordered[string]{Name: name, Priority: priority, Decorate: func(s string) string { return s + " " + name }}
+	}
+	decorators := []Ordered[string]{tag("c", 5), tag("a", 1), tag("d", 5), tag("b", 1)}
+	// Lowest priority innermost, ties in the order given
+	if got, want := DecorateOrdered("x", decorators...), "x a b c d"; got != want {
+		t.Errorf("DecorateOrdered = %q, want %q", got, want)
+	}
+	if decorators[0].Name != "c" {
+		t.Errorf("DecorateOrdered reordered its argument: %v", decorators[0].Name)
+	}
+}
+
+func TestCoupon(t *testing.T) {
+	coupon := NewCoupon(50, 1)
+	first := coupon.Apply(&SimpleCoffee{})
+	second := coupon.Apply(&SimpleCoffee{})
+	if first.GetCost() != 0.5 || second.GetCost() != 1 {
+		t.Errorf("costs = %.2f, %.2f, want 0.50, 1.00", first.GetCost(), second.GetCost())
+	}
+	if coupon.Remaining() != 0 {
+		t.Errorf("Remaining = %d, want 0", coupon.Remaining())
+	}
+}
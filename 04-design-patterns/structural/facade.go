@@ -5,34 +5,144 @@
 // - When you need to provide a simple interface to a complex subsystem
 // - When there are many dependencies between clients and implementation classes
 // - When you want to layer your subsystems
+//
+// A facade earns its keep when the subsystem is hard to drive correctly:
+// the parts have to be called in the right order, and when one fails the
+// ones that already did their work have to be undone. ComputerFacade.Start
+// boots the computer in order and rolls back on failure, and Shutdown does
+// the same steps the other way round, so callers never see a half-started
+// computer
 
 package structural
 
+import (
+	"errors"
+	"fmt"
+)
+
+var (
+	// ErrDiskRead is returned when the hard drive can't read a sector
+	ErrDiskRead = errors.New("disk read error")
+	// ErrOutOfMemory is returned when data doesn't fit in memory
+	ErrOutOfMemory = errors.New("out of memory")
+	// ErrAlreadyRunning is returned by Start on a running computer
+	ErrAlreadyRunning = errors.New("computer is already running")
+	// ErrNotRunning is returned by Shutdown on a computer that is off
+	ErrNotRunning = errors.New("computer is not running")
+)
+
+// formatSize formats a number of bytes for people: 512 B, 4 KiB, 1.5 MiB
+func formatSize(bytes int) string {
+	const unit = 1024
+	if bytes < unit {
+		return fmt.Sprintf("%d B", bytes)
+	}
+	size, prefix := float64(bytes)/unit, 0
+	for size >= unit && prefix < len("KMGT")-1 {
+		size /= unit
+		prefix++
+	}
+	return fmt.Sprintf("%.3g %ciB", size, "KMGT"[prefix])
+}
+
 // Complex subsystem components
-type CPU struct{}
+
+// CPU runs the code loaded into memory
+type CPU struct {
+	frozen, running bool
+}
 
 func (c *CPU) Freeze() string {
+	c.frozen = true
 	return "CPU: Freezing..."
 }
 
+// Thaw undoes Freeze
+func (c *CPU) Thaw() string {
+	c.frozen = false
+	return "CPU: Thawing"
+}
+
 func (c *CPU) Jump(position string) string {
 	return "CPU: Jumping to " + position
 }
 
 func (c *CPU) Execute() string {
+	c.frozen, c.running = false, true
 	return "CPU: Executing..."
 }
 
-type Memory struct{}
+// Halt stops the CPU
+func (c *CPU) Halt() string {
+	c.running = false
+	return "CPU: Halting"
+}
+
+// Memory holds data at addresses, up to its capacity in bytes
+type Memory struct {
+	capacity int
+	used     int
+	loaded   map[string]int // address to size
+}
+
+// NewMemory returns an empty memory of capacity bytes
+func NewMemory(capacity int) *Memory {
+	return &Memory{capacity: capacity, loaded: make(map[string]int)}
+}
+
+// Load puts data at position, or fails if it doesn't fit
+func (m *Memory) Load(position string, data []byte) (string, error) {
+	if m.used+len(data) > m.capacity {
+		return "", fmt.Errorf("%w: %s needed, %s free", ErrOutOfMemory, formatSize(len(data)), formatSize(m.capacity-m.used))
+	}
+	m.used += len(data)
+	m.loaded[position] = len(data)
+	return fmt.Sprintf("Memory: Loading %s to %s", formatSize(len(data)), position), nil
+}
+
+// Free undoes Load
+func (m *Memory) Free(position string) string {
+	m.used -= m.loaded[position]
+	delete(m.loaded, position)
+	return "Memory: Freeing " + position
+}
+
+// HardDrive reads sectors, which it has to spin up for
+type HardDrive struct {
+	sectors  map[string][]byte
+	spinning bool
+}
+
+// NewHardDrive returns a drive holding the given sectors; reading any
+// other sector fails
+func NewHardDrive(sectors map[string][]byte) *HardDrive {
+	return &HardDrive{sectors: sectors}
+}
 
-func (m *Memory) Load(position string, data string) string {
-	return "Memory: Loading " + data + " to " + position
+// SpinUp starts the drive
+func (h *HardDrive) SpinUp() string {
+	h.spinning = true
+	return "HardDrive: Spinning up"
 }
 
-type HardDrive struct{}
+// Park stops the drive, undoing SpinUp
+func (h *HardDrive) Park() string {
+	h.spinning = false
+	return "HardDrive: Parking heads"
+}
 
-func (h *HardDrive) Read(position string, size int) string {
-	return "HardDrive: Reading data of size " + string(rune(size)) + " from " + position
+// Read returns size bytes of a sector
+func (h *HardDrive) Read(position string, size int) ([]byte, string, error) {
+	data, ok := h.sectors[position]
+	switch {
+	case !h.spinning:
+		return nil, "", fmt.Errorf("%w: %s: drive is not spinning", ErrDiskRead, position)
+	case !ok:
+		return nil, "", fmt.Errorf("%w: %s: bad sector", ErrDiskRead, position)
+	case size > len(data):
+		return nil, "", fmt.Errorf("%w: %s: %s asked, sector has %s", ErrDiskRead, position, formatSize(size), formatSize(len(data)))
+	}
+	return data[:size], fmt.Sprintf("HardDrive: Reading %s from %s", formatSize(size), position), nil
 }
 
 // ComputerFacade provides a unified interface to a set of interfaces in the subsystem
@@ -40,25 +150,89 @@ type ComputerFacade struct {
 	cpu       *CPU
 	memory    *Memory
 	hardDrive *HardDrive
+	running   bool
 }
 
-// NewComputerFacade creates a new facade
+// bootSector is where the boot loader is on the drive, and bootAddress
+// where it goes in memory
+const (
+	bootSector  = "BOOT_SECTOR"
+	bootAddress = "0x00"
+	bootSize    = 512
+)
+
+// NewComputerFacade creates a new facade for a computer with 64 KiB of
+// memory and a drive with a boot sector
 func NewComputerFacade() *ComputerFacade {
-	return &ComputerFacade{
-		cpu:       &CPU{},
-		memory:    &Memory{},
-		hardDrive: &HardDrive{},
-	}
+	return NewComputerFacadeWith(&CPU{}, NewMemory(64<<10),
+		NewHardDrive(map[string][]byte{bootSector: make([]byte, bootSize)}))
+}
+
+// NewComputerFacadeWith creates a facade over the given parts, such as a
+// faulty drive or a small memory
+func NewComputerFacadeWith(cpu *CPU, memory *Memory, hardDrive *HardDrive) *ComputerFacade {
+	return &ComputerFacade{cpu: cpu, memory: memory, hardDrive: hardDrive}
+}
+
+// step is one part of starting the computer, with the way to undo it
+type step struct {
+	do   func() (string, error)
+	undo func() string // nil if there is nothing to undo
 }
 
 // Start provides a simple interface to the complex subsystem
-func (c *ComputerFacade) Start() []string {
-	results := make([]string, 0)
-	
-	results = append(results, c.cpu.Freeze())
-	results = append(results, c.memory.Load("0x00", "BOOT_SECTOR"))
-	results = append(results, c.cpu.Jump("0x00"))
-	results = append(results, c.cpu.Execute())
-	
-	return results
+// It returns what every part did. If a part fails, Start undoes the steps
+// before it in reverse order, so the computer is off again, and returns
+// the error with the log of the rollback
+func (c *ComputerFacade) Start() ([]string, error) {
+	if c.running {
+		return nil, ErrAlreadyRunning
+	}
+	var bootLoader []byte
+	steps := []step{
+		{func() (string, error) { return c.cpu.Freeze(), nil }, c.cpu.Thaw},
+		{func() (string, error) { return c.hardDrive.SpinUp(), nil }, c.hardDrive.Park},
+		{func() (string, error) {
+			data, msg, err := c.hardDrive.Read(bootSector, bootSize)
+			bootLoader = data
+			return msg, err
+		}, nil},
+		{func() (string, error) { return c.memory.Load(bootAddress, bootLoader) },
+			func() string { return c.memory.Free(bootAddress) }},
+		{func() (string, error) { return c.cpu.Jump(bootAddress), nil }, nil},
+		{func() (string, error) { return c.cpu.Execute(), nil }, nil},
+	}
+
+	results := make([]string, 0, len(steps))
+	for i, s := range steps {
+		msg, err := s.do()
+		if err == nil {
+			results = append(results, msg)
+			continue
+		}
+		results = append(results, "Start failed: "+err.Error())
+		for j := i - 1; j >= 0; j-- {
+			if steps[j].undo != nil {
+				results = append(results, steps[j].undo())
+			}
+		}
+		return results, fmt.Errorf("start: %w", err)
+	}
+	c.running = true
+	return results, nil
+}
+
+// Shutdown stops a running computer, the steps of Start in reverse order:
+// the CPU stops before the memory it runs from is freed, and the drive is
+// parked last
+func (c *ComputerFacade) Shutdown() ([]string, error) {
+	if !c.running {
+		return nil, ErrNotRunning
+	}
+	c.running = false
+	return []string{
+		c.cpu.Halt(),
+		c.memory.Free(bootAddress),
+		c.hardDrive.Park(),
+	}, nil
 }
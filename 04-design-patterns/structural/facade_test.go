@@ -0,0 +1,66 @@
+package structural
+
+import (
+	"errors"
+	"slices"
+	"testing"
+)
+
+func TestFormatSize(t *testing.T) {
+	for _, tt := range []struct {
+		bytes int
+		want  string
+	}{
+		{0, "0 B"},
+		{512, "512 B"},
+		{1023, "1023 B"},
+		{1024, "1 KiB"},
+		{1536, "1.5 KiB"},
+		{64 << 10, "64 KiB"},
+		{3 << 20, "3 MiB"},
+		{5 << 40, "5 TiB"},
+	} {
+		if got := formatSize(tt.bytes); got != tt.want {
+			t.Errorf("formatSize(%d) = %q, want %q", tt.bytes, got, tt.want)
+		}
+	}
+}
+
+func TestFacadeRollsBackInReverseOrder(t *testing.T) {
+	// The boot loader doesn't fit, so the drive and CPU have to be undone
+	memory := NewMemory(256)
+	drive := NewHardDrive(map[string][]byte{bootSector: make([]byte, bootSize)})
+	cpu := &CPU{}
+	computer := NewComputerFacadeWith(cpu, memory, drive)
+
+	steps, err := computer.Start()
+	if !errors.Is(err, ErrOutOfMemory) {
+		t.Fatalf("Start error = %v, want ErrOutOfMemory", err)
+	}
+	want := []string{"HardDrive: Parking heads", "CPU: Thawing"}
+	if got := steps[len(steps)-2:]; !slices.Equal(got, want) {
+		t.Errorf("rollback = %q, want %q", got, want)
+	}
+	if cpu.frozen || drive.spinning || memory.used != 0 {
+		t.Errorf("after rollback: frozen %v, spinning %v, memory used %d", cpu.frozen, drive.spinning, memory.used)
+	}
+	if _, err := computer.Shutdown(); !errors.Is(err, ErrNotRunning) {
+		t.Errorf("Shutdown after failed Start = %v, want ErrNotRunning", err)
+	}
+}
+
+func TestFacadeRestart(t *testing.T) {
+	computer := NewComputerFacade()
+	for i := range 3 {
+		if _, err := computer.Start(); err != nil {
+			t.Fatalf("Start %d: %v", i, err)
+		}
+		if _, err := computer.Shutdown(); err != nil {
+			t.Fatalf("Shutdown %d: %v", i, err)
+		}
+	}
+	if computer.memory.used != 0 || computer.cpu.running || computer.hardDrive.spinning {
+		t.Errorf("after shutdown: memory used %d, running %v, spinning %v",
+			computer.memory.used, computer.cpu.running, computer.hardDrive.spinning)
+	}
+}
@@ -0,0 +1,114 @@
+// Functional Decorators generalize the Decorator Pattern from structs to functions.
+// Instead of wrapping a Coffee in a MilkDecorator struct, we wrap a handler function
+// in another function with the same signature. This is exactly how HTTP middleware
+// is composed in Go: func(http.Handler) http.Handler.
+//
+// Mapping the coffee example onto middleware:
+// - Coffee               -> Handler (the thing being decorated)
+// - SimpleCoffee         -> the final handler that produces a Response
+// - NewMilkDecorator     -> a Middleware: takes a Handler, returns a Handler
+// - milk + sugar + whip  -> Chain(handler, Logging, Timing, Auth)
+//
+// Use cases:
+// - Cross-cutting concerns around request handling (logging, metrics, auth)
+// - Adding behavior to functions without changing their signature
+// - Composing small, reusable steps into a pipeline
+
+package structural
+
+import (
+	"fmt"
+	"io"
+	"net/http"
+	"time"
+)
+
+// Request is a simplified HTTP request
+type Request struct {
+	Method  string
+	Path    string
+	Headers map[string]string
+}
+
+// Response is a simplified HTTP response
+type Response struct {
+	Status int
+	Body   string
+}
+
+// Handler handles a Request and produces a Response
+type Handler func(Request) Response
+
+// Middleware decorates a Handler with extra behavior
+// It plays the same role as NewMilkDecorator does for Coffee
+type Middleware func(Handler) Handler
+
+// Chain wraps handler with the given middlewares
+// The first middleware is the outermost one, so it runs first on the way in
+// and last on the way out:
+//
+//	Chain(h, A, B) == A(B(h))
+func Chain(handler Handler, middlewares ...Middleware) Handler {
+	for i := len(middlewares) - 1; i >= 0; i-- {
+		handler = middlewares[i](handler)
+	}
+	return handler
+}
+
+// LoggingMiddleware writes a line before and after each request
+func LoggingMiddleware(w io.Writer) Middleware {
+	return func(next Handler) Handler {
+		return func(r Request) Response {
+			fmt.Fprintf(w, "--> %s %s\n", r.Method, r.Path)
+			resp := next(r)
+			fmt.Fprintf(w, "<-- %d %s\n", resp.Status, r.Path)
+			return resp
+		}
+	}
+}
+
+// TimingMiddleware reports how long the wrapped handler took
+func TimingMiddleware(w io.Writer) Middleware {
+	return func(next Handler) Handler {
+		return func(r Request) Response {
+			start := time.Now()
+			resp := next(r)
+			fmt.Fprintf(w, "%s took %v\n", r.Path, time.Since(start).Round(time.Microsecond))
+			return resp
+		}
+	}
+}
+
+// AuthMiddleware rejects requests without a known bearer token
+// Unlike the other middlewares it may short-circuit the chain and never call next
+func AuthMiddleware(validTokens ...string) Middleware {
+	allowed := make(map[string]bool, len(validTokens))
+	for _, token := range validTokens {
+		allowed["Bearer "+token] = true
+	}
+
+	return func(next Handler) Handler {
+		return func(r Request) Response {
+			if !allowed[r.Headers["Authorization"]] {
+				return Response{Status: http.StatusUnauthorized, Body: "unauthorized"}
+			}
+			return next(r)
+		}
+	}
+}
+
+// ToHTTPHandler adapts a Handler to the standard library's http.Handler
+// Real Go middleware has the signature func(http.Handler) http.Handler and is
+// composed in exactly the same way as Chain composes Middleware
+func ToHTTPHandler(h Handler) http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		headers := make(map[string]string, len(r.Header))
+		for key := range r.Header {
+			headers[key] = r.Header.Get(key)
+		}
+
+		resp := h(Request{Method: r.Method, Path: r.URL.Path, Headers: headers})
+		w.WriteHeader(resp.Status)
+		io.WriteString(w, resp.Body)
+	})
+}
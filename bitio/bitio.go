@@ -0,0 +1,152 @@
+// Package bitio reads and writes streams of bits, for formats whose codes
+// don't line up with bytes, like Huffman and LZW codes:
+//
+//	w := bitio.NewWriter(f)
+//	w.WriteBits(0b101, 3) // a 3-bit code
+//	w.WriteBits(1000, 12) // a 12-bit code
+//	w.Flush()             // 15 bits, padded to 2 bytes
+//
+// Bits are packed most significant first: the first bit written is the
+// high bit of the first byte, and the highest bit of a value is written
+// first. JPEG and the LZW of TIFF and PDF use this order; DEFLATE and GIF
+// pack the other way round
+//
+// Like bufio.Writer and fastio.Reader, a Writer and a Reader keep the first
+// error they meet and return it from every call after it, so a loop can
+// write or read everything and check once
+package bitio
+
+import (
+	"bufio"
+	"errors"
+	"fmt"
+	"io"
+)
+
+// ErrCount is returned for a number of bits outside 0..64
+var ErrCount = errors.New("bitio: bit count out of range")
+
+// countError reports n as out of range
+func countError(n int) error {
+	return fmt.Errorf("%w: %d", ErrCount, n)
+}
+
+// Writer writes bits to an io.Writer
+type Writer struct {
+	w *bufio.Writer
+	// acc holds the n bits written since the last whole byte, in its low bits
+	acc byte
+	n   int
+	err error
+}
+
+// NewWriter returns a Writer that buffers w
+func NewWriter(w io.Writer) *Writer {
+	return &Writer{w: bufio.NewWriter(w)}
+}
+
+// WriteBits writes the low n bits of value, the highest of them first; the
+// bits above them are ignored. n may be 0, which writes nothing, up to 64
+func (w *Writer) WriteBits(value uint64, n int) error {
+	if n < 0 || n > 64 {
+		return countError(n)
+	}
+	for n > 0 && w.err == nil {
+		// Fill the current byte with as many of the bits as fit
+		k := min(n, 8-w.n)
+		n -= k
+		w.acc = w.acc<<k | byte(value>>n&(1<<k-1))
+		w.n += k
+		if w.n == 8 {
+			w.err = w.w.WriteByte(w.acc)
+			w.acc, w.n = 0, 0
+		}
+	}
+	return w.err
+}
+
+// WriteBit writes one bit, 1 if bit is true
+func (w *Writer) WriteBit(bit bool) error {
+	var v uint64
+	if bit {
+		v = 1
+	}
+	return w.WriteBits(v, 1)
+}
+
+// Flush pads the last byte with zeros if it is not whole, and writes the
+// buffered bytes to the underlying io.Writer
+// Writing can go on after a Flush, starting a new byte
+func (w *Writer) Flush() error {
+	if w.n > 0 && w.err == nil {
+		w.err = w.w.WriteByte(w.acc << (8 - w.n))
+		w.acc, w.n = 0, 0
+	}
+	if w.err == nil {
+		w.err = w.w.Flush()
+	}
+	return w.err
+}
+
+// Reader reads bits from an io.Reader
+type Reader struct {
+	r *bufio.Reader
+	// acc holds the n bits of the current byte not yet read, in its low bits
+	acc byte
+	n   int
+	err error
+}
+
+// NewReader returns a Reader that buffers r
+// A *bufio.Reader is used as it is, so a format can read a header from it
+// byte by byte and then the bits that follow
+func NewReader(r io.Reader) *Reader {
+	return &Reader{r: bufio.NewReader(r)}
+}
+
+// ReadBits reads n bits, 0 to 64, and returns them as the low bits of a
+// value, the first bit read highest
+// At the end of the input it returns io.EOF if no bit was left, and
+// io.ErrUnexpectedEOF if some were but fewer than n
+func (r *Reader) ReadBits(n int) (uint64, error) {
+	if n < 0 || n > 64 {
+		return 0, countError(n)
+	}
+	var v uint64
+	for want := n; want > 0; {
+		if r.err != nil {
+			return 0, r.err
+		}
+		if r.n == 0 {
+			c, err := r.r.ReadByte()
+			if err != nil {
+				if err == io.EOF && want < n {
+					err = io.ErrUnexpectedEOF
+				}
+				r.err = err
+				continue
+			}
+			r.acc, r.n = c, 8
+		}
+		// Take as many bits as are wanted from the top of the current byte
+		k := min(want, r.n)
+		r.n -= k
+		v = v<<k | uint64(r.acc>>r.n&(1<<k-1))
+		want -= k
+	}
+	return v, r.err
+}
+
+// ReadBit reads one bit and reports whether it is 1
+func (r *Reader) ReadBit() (bool, error) {
+	v, err := r.ReadBits(1)
+	return v == 1, err
+}
+
+// Align skips the rest of the current byte, the padding Flush writes, so
+// the next read starts at a byte boundary. It returns the bits skipped
+func (r *Reader) Align() int {
+	skipped := r.n
+	r.acc, r.n = 0, 0
+	return skipped
+}
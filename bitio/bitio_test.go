@@ -0,0 +1,237 @@
+package bitio
+
+import (
+	"bufio"
+	"bytes"
+	"errors"
+	"io"
+	"math/rand"
+	"strings"
+	"testing"
+)
+
+// bitString returns the low n bits of v as 0s and 1s, highest first: the
+// reference the packed bits are checked against
+func bitString(v uint64, n int) string {
+	var sb strings.Builder
+	for i := n - 1; i >= 0; i-- {
+		sb.WriteByte('0' + byte(v>>i&1))
+	}
+	return sb.String()
+}
+
+// unpack returns the bits of data as 0s and 1s
+func unpack(data []byte) string {
+	var sb strings.Builder
+	for _, b := range data {
+		sb.WriteString(bitString(uint64(b), 8))
+	}
+	return sb.String()
+}
+
+// mask returns the low n bits of v
+func mask(v uint64, n int) uint64 {
+	if n == 64 {
+		return v
+	}
+	return v & (1<<n - 1)
+}
+
+// TestEveryWidthAndOffset writes a value of every width from 0 to 64
+// starting at every bit of a byte, and reads it back
+func TestEveryWidthAndOffset(t *testing.T) {
+	values := []uint64{0, 1, ^uint64(0), 0xaaaa_aaaa_aaaa_aaaa, 0x8000_0000_0000_0001, 0x0123_4567_89ab_cdef}
+	for offset := range 8 {
+		for n := 0; n <= 64; n++ {
+			for _, v := range values {
+				var buf bytes.Buffer
+				w := NewWriter(&buf)
+				w.WriteBits(0b1011011, offset) // the low offset bits of a marker
+				w.WriteBits(v, n)
+				if err := w.Flush(); err != nil {
+					t.Fatal(err)
+				}
+				want := bitString(0b1011011, offset) + bitString(v, n)
+				want += strings.Repeat("0", (8-len(want)%8)%8)
+				if got := unpack(buf.Bytes()); got != want {
+					t.Fatalf("offset %d, %d bits of %#x: wrote %s, want %s", offset, n, v, got, want)
+				}
+
+				r := NewReader(&buf)
+				if got, err := r.ReadBits(offset); err != nil || got != mask(0b1011011, offset) {
+					t.Fatalf("offset %d: read marker %b, %v", offset, got, err)
+				}
+				if got, err := r.ReadBits(n); err != nil || got != mask(v, n) {
+					t.Fatalf("offset %d, %d bits of %#x: read %#x, %v", offset, n, v, got, err)
+				}
+			}
+		}
+	}
+}
+
+// TestRandomStream writes a long stream of values of random widths and
+// checks the bytes against the reference and the values read back
+func TestRandomStream(t *testing.T) {
+	rng := rand.New(rand.NewSource(1))
+	type code struct {
+		v uint64
+		n int
+	}
+	codes := make([]code, 10_000)
+	var want strings.Builder
+	var buf bytes.Buffer
+	w := NewWriter(&buf)
+	for i := range codes {
+		c := code{rng.Uint64(), rng.Intn(65)}
+		codes[i] = c
+		w.WriteBits(c.v, c.n) // the bits above n must be ignored
+		want.WriteString(bitString(c.v, c.n))
+	}
+	w.Flush()
+	if got := unpack(buf.Bytes()); !strings.HasPrefix(got, want.String()) || len(got)-want.Len() >= 8 {
+		t.Fatal("packed bits differ from the reference")
+	}
+
+	r := NewReader(&buf)
+	for i, c := range codes {
+		if got, err := r.ReadBits(c.n); err != nil || got != mask(c.v, c.n) {
+			t.Fatalf("code %d: read %#x, %v, want %#x", i, got, err, mask(c.v, c.n))
+		}
+	}
+	if padding := r.Align(); padding != (8-want.Len()%8)%8 {
+		t.Errorf("Align skipped %d bits", padding)
+	}
+	if _, err := r.ReadBits(1); err != io.EOF {
+		t.Errorf("read after the end = %v, want io.EOF", err)
+	}
+}
+
+func TestSingleBits(t *testing.T) {
+	var buf bytes.Buffer
+	w := NewWriter(&buf)
+	for _, bit := range []bool{true, false, true, true, false, false, false, true, true} {
+		w.WriteBit(bit)
+	}
+	w.Flush()
+	if !bytes.Equal(buf.Bytes(), []byte{0b1011_0001, 0b1000_0000}) {
+		t.Fatalf("bytes = %08b", buf.Bytes())
+	}
+	r := NewReader(&buf)
+	var got strings.Builder
+	for {
+		bit, err := r.ReadBit()
+		if err != nil {
+			break
+		}
+		if bit {
+			got.WriteByte('1')
+		} else {
+			got.WriteByte('0')
+		}
+	}
+	if got.String() != "1011000110000000" {
+		t.Errorf("read %s", got.String())
+	}
+}
+
+func TestBadCounts(t *testing.T) {
+	w := NewWriter(io.Discard)
+	r := NewReader(strings.NewReader("data"))
+	for _, n := range []int{-1, 65, 1000} {
+		if err := w.WriteBits(0, n); !errors.Is(err, ErrCount) {
+			t.Errorf("WriteBits(0, %d) = %v, want ErrCount", n, err)
+		}
+		if _, err := r.ReadBits(n); !errors.Is(err, ErrCount) {
+			t.Errorf("ReadBits(%d) = %v, want ErrCount", n, err)
+		}
+	}
+	// A bad count is the caller's mistake, not the stream's: it doesn't stick
+	if err := w.WriteBits(1, 1); err != nil {
+		t.Errorf("WriteBits after a bad count = %v", err)
+	}
+	if v, err := r.ReadBits(8); v != 'd' || err != nil {
+		t.Errorf("ReadBits after a bad count = %q, %v", v, err)
+	}
+}
+
+func TestEndOfInput(t *testing.T) {
+	r := NewReader(bytes.NewReader(nil))
+	if v, err := r.ReadBits(0); v != 0 || err != nil {
+		t.Errorf("ReadBits(0) of nothing = %d, %v", v, err)
+	}
+	if _, err := r.ReadBits(1); err != io.EOF {
+		t.Errorf("ReadBits(1) of nothing = %v, want io.EOF", err)
+	}
+
+	// Twelve bits of a two-byte input leave four, too few for eight
+	r = NewReader(bytes.NewReader([]byte{0xab, 0xcd}))
+	if v, err := r.ReadBits(12); v != 0xabc || err != nil {
+		t.Fatalf("ReadBits(12) = %#x, %v", v, err)
+	}
+	if _, err := r.ReadBits(8); err != io.ErrUnexpectedEOF {
+		t.Errorf("ReadBits(8) of 4 bits = %v, want io.ErrUnexpectedEOF", err)
+	}
+	// The error sticks
+	if _, err := r.ReadBits(1); err != io.ErrUnexpectedEOF {
+		t.Errorf("ReadBits after the error = %v", err)
+	}
+}
+
+// failingWriter accepts limit bytes and then fails
+type failingWriter struct{ limit int }
+
+var errFull = errors.New("disk full")
+
+func (f *failingWriter) Write(p []byte) (int, error) {
+	if len(p) > f.limit {
+		n := f.limit
+		f.limit = 0
+		return n, errFull
+	}
+	f.limit -= len(p)
+	return len(p), nil
+}
+
+func TestWriteError(t *testing.T) {
+	w := NewWriter(&failingWriter{limit: 10})
+	var err error
+	for i := 0; i < 100_000 && err == nil; i++ {
+		err = w.WriteBits(uint64(i), 17)
+	}
+	if !errors.Is(err, errFull) {
+		t.Fatalf("err = %v, want the writer's error", err)
+	}
+	if err := w.Flush(); !errors.Is(err, errFull) {
+		t.Errorf("Flush after the error = %v", err)
+	}
+}
+
+func TestSharedBuffer(t *testing.T) {
+	// A header read a byte at a time, then bits, then a byte after them
+	br := bufio.NewReader(bytes.NewReader([]byte{'H', 0b1110_0000, '!'}))
+	if c, _ := br.ReadByte(); c != 'H' {
+		t.Fatalf("header %q", c)
+	}
+	r := NewReader(br)
+	if v, err := r.ReadBits(3); v != 0b111 || err != nil {
+		t.Fatalf("ReadBits(3) = %b, %v", v, err)
+	}
+	r.Align()
+	if c, _ := br.ReadByte(); c != '!' {
+		t.Errorf("byte after the bits = %q, want !", c)
+	}
+}
+
+func TestFlushTwice(t *testing.T) {
+	// Writing after a Flush starts a new byte; a second Flush adds nothing
+	var buf bytes.Buffer
+	w := NewWriter(&buf)
+	w.WriteBits(1, 1)
+	w.Flush()
+	w.Flush()
+	w.WriteBits(0b11, 2)
+	w.Flush()
+	if !bytes.Equal(buf.Bytes(), []byte{0x80, 0xc0}) {
+		t.Errorf("bytes = %x, want 80c0", buf.Bytes())
+	}
+}
@@ -0,0 +1,28 @@
+package bitio_test
+
+import (
+	"bytes"
+	"fmt"
+
+	"github.com/NutProhmpiriya/go-basic/bitio"
+)
+
+func Example() {
+	// Three codes of 3, 12 and 1 bits take two bytes
+	var buf bytes.Buffer
+	w := bitio.NewWriter(&buf)
+	w.WriteBits(0b101, 3)
+	w.WriteBits(1000, 12)
+	w.WriteBit(true)
+	w.Flush()
+	fmt.Printf("%08b\n", buf.Bytes())
+
+	r := bitio.NewReader(&buf)
+	a, _ := r.ReadBits(3)
+	b, _ := r.ReadBits(12)
+	c, _ := r.ReadBit()
+	fmt.Println(a, b, c)
+	// Output:
+	// [10100111 11010001]
+	// 5 1000 true
+}
@@ -0,0 +1,193 @@
+package main
+
+import (
+	"errors"
+	"fmt"
+	"slices"
+	"strconv"
+	"strings"
+)
+
+// The sentinel errors of the bank. The error types below match them with
+// errors.Is, so callers can test for the kind of failure and still use
+// errors.As to get at the details
+var (
+	ErrAccountNotFound   = errors.New("account not found")
+	ErrAccountExists     = errors.New("account already exists")
+	ErrInsufficientFunds = errors.New("insufficient funds")
+	ErrInvalidAmount     = errors.New("invalid amount")
+)
+
+// AccountNotFoundError reports an operation on an account that isn't open
+type AccountNotFoundError struct {
+	ID string
+}
+
+func (e *AccountNotFoundError) Error() string {
+	return fmt.Sprintf("account %q not found", e.ID)
+}
+
+// Is makes errors.Is(err, ErrAccountNotFound) true
+func (e *AccountNotFoundError) Is(target error) bool {
+	return target == ErrAccountNotFound
+}
+
+// InsufficientFundsError reports a withdrawal larger than the balance
+type InsufficientFundsError struct {
+	ID      string
+	Balance Money
+	Amount  Money
+}
+
+func (e *InsufficientFundsError) Error() string {
+	return fmt.Sprintf("insufficient funds: %s has %s, cannot take %s", e.ID, e.Balance, e.Amount)
+}
+
+// Is makes errors.Is(err, ErrInsufficientFunds) true
+func (e *InsufficientFundsError) Is(target error) bool {
+	return target == ErrInsufficientFunds
+}
+
+// Shortfall is how much more money the withdrawal needed
+func (e *InsufficientFundsError) Shortfall() Money {
+	return e.Amount - e.Balance
+}
+
+// Money is an amount in cents. Floating point can't hold 0.10 exactly, so
+// money is counted in whole cents
+type Money int64
+
+// String formats m with two decimals, like 12.50
+func (m Money) String() string {
+	sign := ""
+	if m < 0 {
+		sign, m = "-", -m
+	}
+	return fmt.Sprintf("%s%d.%02d", sign, m/100, m%100)
+}
+
+// ParseMoney parses a positive amount with at most two decimals, like 12,
+// 12.5 or 12.50
+func ParseMoney(s string) (Money, error) {
+	whole, frac, hasFrac := strings.Cut(s, ".")
+	if whole == "" || len(frac) > 2 || hasFrac && frac == "" {
+		return 0, fmt.Errorf("%w: %q", ErrInvalidAmount, s)
+	}
+	units, err := strconv.ParseUint(whole, 10, 40)
+	if err != nil {
+		return 0, fmt.Errorf("%w: %q", ErrInvalidAmount, s)
+	}
+	var cents uint64
+	if frac != "" {
+		frac += strings.Repeat("0", 2-len(frac))
+		if cents, err = strconv.ParseUint(frac, 10, 8); err != nil {
+			return 0, fmt.Errorf("%w: %q", ErrInvalidAmount, s)
+		}
+	}
+	m := Money(units*100 + cents)
+	if m == 0 {
+		return 0, fmt.Errorf("%w: %q must be more than zero", ErrInvalidAmount, s)
+	}
+	return m, nil
+}
+
+// Account is one bank account
+type Account struct {
+	ID      string `json:"id"`
+	Owner   string `json:"owner"`
+	Balance Money  `json:"balance"`
+}
+
+// Bank holds the accounts. Its methods are the primitive operations the
+// transactions are built from; they check their arguments and leave the
+// bank unchanged when they fail
+// A Bank is not safe for concurrent use
+type Bank struct {
+	accounts map[string]*Account
+}
+
+// NewBank creates a bank without accounts
+func NewBank() *Bank {
+	return &Bank{accounts: make(map[string]*Account)}
+}
+
+// Open opens an empty account
+func (b *Bank) Open(id, owner string) error {
+	if id == "" {
+		return errors.New("account id must not be empty")
+	}
+	if _, ok := b.accounts[id]; ok {
+		return fmt.Errorf("%w: %q", ErrAccountExists, id)
+	}
+	b.accounts[id] = &Account{ID: id, Owner: owner}
+	return nil
+}
+
+// Close closes an account, which must be empty
+func (b *Bank) Close(id string) error {
+	a, err := b.account(id)
+	if err != nil {
+		return err
+	}
+	if a.Balance != 0 {
+		return fmt.Errorf("account %q still holds %s", id, a.Balance)
+	}
+	delete(b.accounts, id)
+	return nil
+}
+
+// Deposit adds amount to the balance of an account
+func (b *Bank) Deposit(id string, amount Money) error {
+	if amount <= 0 {
+		return fmt.Errorf("%w: %s", ErrInvalidAmount, amount)
+	}
+	a, err := b.account(id)
+	if err != nil {
+		return err
+	}
+	a.Balance += amount
+	return nil
+}
+
+// Withdraw takes amount from the balance of an account
+func (b *Bank) Withdraw(id string, amount Money) error {
+	if amount <= 0 {
+		return fmt.Errorf("%w: %s", ErrInvalidAmount, amount)
+	}
+	a, err := b.account(id)
+	if err != nil {
+		return err
+	}
+	if a.Balance < amount {
+		return &InsufficientFundsError{ID: id, Balance: a.Balance, Amount: amount}
+	}
+	a.Balance -= amount
+	return nil
+}
+
+// Balance returns the balance of an account
+func (b *Bank) Balance(id string) (Money, error) {
+	a, err := b.account(id)
+	if err != nil {
+		return 0, err
+	}
+	return a.Balance, nil
+}
+
+// Accounts returns a copy of every account, sorted by ID
+func (b *Bank) Accounts() []Account {
+	accounts := make([]Account, 0, len(b.accounts))
+	for _, a := range b.accounts {
+		accounts = append(accounts, *a)
+	}
+	slices.SortFunc(accounts, func(x, y Account) int { return strings.Compare(x.ID, y.ID) })
+	return accounts
+}
+
+func (b *Bank) account(id string) (*Account, error) {
+	a, ok := b.accounts[id]
+	if !ok {
+		return nil, &AccountNotFoundError{ID: id}
+	}
+	return a, nil
+}
@@ -0,0 +1,130 @@
+package main
+
+import (
+	"errors"
+	"slices"
+	"testing"
+)
+
+func TestParseMoney(t *testing.T) {
+	tests := []struct {
+		in   string
+		want Money
+	}{
+		{"12", 1200},
+		{"12.5", 1250},
+		{"12.50", 1250},
+		{"0.07", 7},
+		{"1000000", 100000000},
+	}
+	for _, tt := range tests {
+		if got, err := ParseMoney(tt.in); err != nil || got != tt.want {
+			t.Errorf("ParseMoney(%q) = %v, %v, want %v", tt.in, got, err, tt.want)
+		}
+	}
+	for _, in := range []string{"", "0", "0.00", "-5", "1.999", "1.", ".5", "1.-5", "ten", "1e3"} {
+		if _, err := ParseMoney(in); !errors.Is(err, ErrInvalidAmount) {
+			t.Errorf("ParseMoney(%q) error = %v, want ErrInvalidAmount", in, err)
+		}
+	}
+}
+
+func TestMoneyString(t *testing.T) {
+	for m, want := range map[Money]string{0: "0.00", 7: "0.07", 1250: "12.50", -1250: "-12.50"} {
+		if got := m.String(); got != want {
+			t.Errorf("Money(%d).String() = %q, want %q", int64(m), got, want)
+		}
+	}
+}
+
+// The error types match their sentinels with errors.Is and give their
+// details to errors.As, also through wrapping
+func TestErrorTypes(t *testing.T) {
+	b := NewBank()
+	b.Open("alice", "Alice")
+	b.Deposit("alice", 1000)
+
+	err := b.Withdraw("alice", 2500)
+	if !errors.Is(err, ErrInsufficientFunds) {
+		t.Fatalf("Withdraw error = %v, want ErrInsufficientFunds", err)
+	}
+	var insufficient *InsufficientFundsError
+	if !errors.As(err, &insufficient) || insufficient.Shortfall() != 1500 {
+		t.Errorf("errors.As gave %+v, want a shortfall of 15.00", insufficient)
+	}
+
+	err = NewTeller(b).Do(Deposit{ID: "bob", Amount: 100})
+	var notFound *AccountNotFoundError
+	if !errors.Is(err, ErrAccountNotFound) || !errors.As(err, &notFound) || notFound.ID != "bob" {
+		t.Errorf("Deposit to bob error = %v, want an AccountNotFoundError for bob", err)
+	}
+	if errors.Is(err, ErrInsufficientFunds) {
+		t.Errorf("%v matches ErrInsufficientFunds", err)
+	}
+}
+
+// A failed transaction leaves the bank and the history as they were
+func TestFailedTransactionChangesNothing(t *testing.T) {
+	teller := NewTeller(NewBank())
+	for _, tx := range []Transaction{
+		OpenAccount{ID: "alice", Owner: "Alice"},
+		OpenAccount{ID: "bob", Owner: "Bob"},
+		Deposit{ID: "alice", Amount: 1000},
+	} {
+		if err := teller.Do(tx); err != nil {
+			t.Fatal(err)
+		}
+	}
+	for _, tx := range []Transaction{
+		Transfer{From: "alice", To: "carol", Amount: 500},
+		Transfer{From: "alice", To: "bob", Amount: 5000},
+		Transfer{From: "alice", To: "alice", Amount: 500},
+		Withdraw{ID: "bob", Amount: 1},
+		OpenAccount{ID: "alice", Owner: "Someone"},
+	} {
+		if err := teller.Do(tx); err == nil {
+			t.Errorf("%s succeeded", tx)
+		}
+	}
+	want := []Account{{"alice", "Alice", 1000}, {"bob", "Bob", 0}}
+	if got := teller.Bank().Accounts(); !slices.Equal(got, want) {
+		t.Errorf("accounts = %v, want %v", got, want)
+	}
+	if n := len(teller.History()); n != 3 {
+		t.Errorf("history has %d transactions, want 3", n)
+	}
+}
+
+// Undoing every transaction, newest first, gets back to an empty bank
+func TestUndo(t *testing.T) {
+	teller := NewTeller(NewBank())
+	txs := []Transaction{
+		OpenAccount{ID: "alice", Owner: "Alice"},
+		OpenAccount{ID: "bob", Owner: "Bob"},
+		Deposit{ID: "alice", Amount: 1000},
+		Transfer{From: "alice", To: "bob", Amount: 400},
+		Withdraw{ID: "bob", Amount: 150},
+	}
+	var states [][]Account
+	for _, tx := range txs {
+		states = append(states, teller.Bank().Accounts())
+		if err := teller.Do(tx); err != nil {
+			t.Fatal(err)
+		}
+	}
+	for i := len(txs) - 1; i >= 0; i-- {
+		tx, err := teller.Undo()
+		if err != nil {
+			t.Fatal(err)
+		}
+		if tx != txs[i] {
+			t.Errorf("undid %s, want %s", tx, txs[i])
+		}
+		if got := teller.Bank().Accounts(); !slices.Equal(got, states[i]) {
+			t.Errorf("after undoing %s: %v, want %v", tx, got, states[i])
+		}
+	}
+	if _, err := teller.Undo(); !errors.Is(err, ErrNothingToUndo) {
+		t.Errorf("Undo on an empty history error = %v, want ErrNothingToUndo", err)
+	}
+}
@@ -0,0 +1,294 @@
+// Command atm is a text-based bank teller, an applied example of error
+// handling and the Command pattern:
+//   - the bank's errors are types, AccountNotFoundError and
+//     InsufficientFundsError, that also match the sentinels
+//     ErrAccountNotFound and ErrInsufficientFunds, so the program checks
+//     the kind of a failure with errors.Is and reads its details with
+//     errors.As
+//   - every change is a Transaction with Execute and Undo, kept on a
+//     datastructures.StackOf by the Teller, so "undo" takes back the last one
+//   - with -data, the accounts and the history are saved as JSON after
+//     every change and loaded on startup
+//
+// Usage:
+//
+//	go run ./cmd/atm [-data bank.json]                  interactive
+//	go run ./cmd/atm [-data bank.json] script...        run command files, - for stdin
+//
+// For example:
+//
+//	$ go run ./cmd/atm
+//	> open alice Alice Smith
+//	opened alice for Alice Smith
+//	> deposit alice 100
+//	alice: 100.00
+//	> withdraw alice 250.50
+//	error: insufficient funds: alice has 100.00, cannot take 250.50 (short by 150.50)
+package main
+
+import (
+	"bufio"
+	"errors"
+	"flag"
+	"fmt"
+	"io"
+	"os"
+	"strings"
+)
+
+// errUsage marks errors caused by bad arguments, which exit with code 2
+var errUsage = errors.New("usage error")
+
+// errQuit ends a session
+var errQuit = errors.New("quit")
+
+const help = `Commands:
+  open <id> <owner>                 open an empty account
+  deposit <id> <amount>             put money into an account
+  withdraw <id> <amount>            take money out of an account
+  transfer <from> <to> <amount>     move money between accounts
+  balance <id>                      show the balance of an account
+  accounts                          list every account
+  history                           list the transactions that can be undone
+  undo                              take back the last transaction
+  help                              show this help
+  quit                              leave`
+
+// atm runs the commands of one session against a teller
+type atm struct {
+	teller *Teller
+	out    io.Writer
+	data   string // the file to save to after each change, if any
+}
+
+// userError reports whether err is the user's mistake, which the session
+// reports and carries on from, rather than a failure that ends it
+func userError(err error) bool {
+	for _, target := range []error{
+		errUsage, ErrAccountNotFound, ErrAccountExists,
+		ErrInsufficientFunds, ErrInvalidAmount, ErrSameAccount, ErrNothingToUndo,
+	} {
+		if errors.Is(err, target) {
+			return true
+		}
+	}
+	return false
+}
+
+// explain adds a hint to the message of err where the error type carries
+// the details for one
+func explain(err error) string {
+	var insufficient *InsufficientFundsError
+	if errors.As(err, &insufficient) {
+		return fmt.Sprintf("%v (short by %s)", err, insufficient.Shortfall())
+	}
+	var notFound *AccountNotFoundError
+	if errors.As(err, &notFound) {
+		return fmt.Sprintf("%v (\"accounts\" lists the open ones)", err)
+	}
+	return err.Error()
+}
+
+// do runs tx and saves the result
+func (a *atm) do(tx Transaction) error {
+	if err := a.teller.Do(tx); err != nil {
+		return err
+	}
+	return a.save()
+}
+
+func (a *atm) save() error {
+	if a.data == "" {
+		return nil
+	}
+	if err := Save(a.data, a.teller); err != nil {
+		return fmt.Errorf("saving: %w", err)
+	}
+	return nil
+}
+
+// printBalance prints the balance of account id
+func (a *atm) printBalance(id string) error {
+	balance, err := a.teller.Bank().Balance(id)
+	if err != nil {
+		return err
+	}
+	fmt.Fprintf(a.out, "%s: %s\n", id, balance)
+	return nil
+}
+
+// execute runs one command line
+func (a *atm) execute(fields []string) error {
+	name, args := strings.ToLower(fields[0]), fields[1:]
+	argc := map[string]int{
+		"open": 2, "deposit": 2, "withdraw": 2, "transfer": 3, "balance": 1,
+		"accounts": 0, "history": 0, "undo": 0, "help": 0, "quit": 0, "exit": 0,
+	}
+	want, ok := argc[name]
+	switch {
+	case !ok:
+		return fmt.Errorf("%w: unknown command %q, try \"help\"", errUsage, name)
+	case name == "open" && len(args) < want, name != "open" && len(args) != want:
+		return fmt.Errorf("%w: wrong number of arguments for %s, try \"help\"", errUsage, name)
+	}
+
+	switch name {
+	case "open":
+		tx := OpenAccount{ID: args[0], Owner: strings.Join(args[1:], " ")}
+		if err := a.do(tx); err != nil {
+			return err
+		}
+		fmt.Fprintf(a.out, "opened %s for %s\n", tx.ID, tx.Owner)
+	case "deposit", "withdraw":
+		amount, err := ParseMoney(args[1])
+		if err != nil {
+			return err
+		}
+		var tx Transaction = Deposit{ID: args[0], Amount: amount}
+		if name == "withdraw" {
+			tx = Withdraw{ID: args[0], Amount: amount}
+		}
+		if err := a.do(tx); err != nil {
+			return err
+		}
+		return a.printBalance(args[0])
+	case "transfer":
+		amount, err := ParseMoney(args[2])
+		if err != nil {
+			return err
+		}
+		if err := a.do(Transfer{From: args[0], To: args[1], Amount: amount}); err != nil {
+			return err
+		}
+		if err := a.printBalance(args[0]); err != nil {
+			return err
+		}
+		return a.printBalance(args[1])
+	case "balance":
+		return a.printBalance(args[0])
+	case "accounts":
+		accounts := a.teller.Bank().Accounts()
+		if len(accounts) == 0 {
+			fmt.Fprintln(a.out, "no accounts")
+		}
+		for _, acc := range accounts {
+			fmt.Fprintf(a.out, "%-10s %12s  %s\n", acc.ID, acc.Balance, acc.Owner)
+		}
+	case "history":
+		history := a.teller.History()
+		if len(history) == 0 {
+			fmt.Fprintln(a.out, "no transactions")
+		}
+		for i, tx := range history {
+			fmt.Fprintf(a.out, "%3d. %s\n", i+1, tx)
+		}
+	case "undo":
+		tx, err := a.teller.Undo()
+		if err != nil {
+			return err
+		}
+		if err := a.save(); err != nil {
+			return err
+		}
+		fmt.Fprintf(a.out, "undid %s\n", tx)
+	case "help":
+		fmt.Fprintln(a.out, help)
+	case "quit", "exit":
+		return errQuit
+	}
+	return nil
+}
+
+// session reads commands from in until it ends or a "quit". A script is
+// echoed line by line so the output reads as a transcript; otherwise the
+// user gets a prompt
+func (a *atm) session(in io.Reader, script bool) error {
+	scanner := bufio.NewScanner(in)
+	for {
+		if !script {
+			fmt.Fprint(a.out, "> ")
+		}
+		if !scanner.Scan() {
+			if !script {
+				fmt.Fprintln(a.out)
+			}
+			return scanner.Err()
+		}
+		line := strings.TrimSpace(scanner.Text())
+		if line == "" || strings.HasPrefix(line, "#") {
+			continue
+		}
+		if script {
+			fmt.Fprintf(a.out, "> %s\n", line)
+		}
+		err := a.execute(strings.Fields(line))
+		switch {
+		case err == nil:
+		case errors.Is(err, errQuit):
+			return nil
+		case userError(err):
+			fmt.Fprintf(a.out, "error: %s\n", explain(err))
+		default:
+			return err
+		}
+	}
+}
+
+// run parses the flags, loads the bank and runs the sessions
+// Keeping os.Exit out of run makes it easy to test
+func run(args []string, stdin io.Reader, stdout, stderr io.Writer) error {
+	fs := flag.NewFlagSet("atm", flag.ContinueOnError)
+	fs.SetOutput(stderr)
+	fs.Usage = func() {
+		fmt.Fprintln(stderr, "Usage: atm [-data file] [script ...]")
+		fs.PrintDefaults()
+	}
+	data := fs.String("data", "", "JSON file to load the bank from and save it to (default: memory only)")
+	if err := fs.Parse(args); err != nil {
+		return fmt.Errorf("%w: %w", errUsage, err)
+	}
+
+	teller := NewTeller(NewBank())
+	if *data != "" {
+		var err error
+		if teller, err = Load(*data); err != nil {
+			return err
+		}
+	}
+	a := &atm{teller: teller, out: stdout, data: *data}
+
+	if fs.NArg() == 0 {
+		return a.session(stdin, false)
+	}
+	for _, path := range fs.Args() {
+		if path == "-" {
+			if err := a.session(stdin, true); err != nil {
+				return err
+			}
+			continue
+		}
+		f, err := os.Open(path)
+		if err != nil {
+			return err
+		}
+		err = a.session(f, true)
+		f.Close()
+		if err != nil {
+			return fmt.Errorf("%s: %w", path, err)
+		}
+	}
+	return nil
+}
+
+func main() {
+	err := run(os.Args[1:], os.Stdin, os.Stdout, os.Stderr)
+	switch {
+	case err == nil, errors.Is(err, flag.ErrHelp):
+	case errors.Is(err, errUsage):
+		fmt.Fprintln(os.Stderr, "atm:", err)
+		os.Exit(2)
+	default:
+		fmt.Fprintln(os.Stderr, "atm:", err)
+		os.Exit(1)
+	}
+}
@@ -0,0 +1,105 @@
+package main
+
+import (
+	"bytes"
+	"errors"
+	"io"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+)
+
+// The sample script reports its mistakes and carries on to the end
+func TestSessionScript(t *testing.T) {
+	var out bytes.Buffer
+	if err := run([]string{"testdata/session.txt"}, nil, &out, io.Discard); err != nil {
+		t.Fatal(err)
+	}
+	for _, want := range []string{
+		"> transfer alice bob 50\nalice: 19.75\nbob: 70.50\n",
+		"> withdraw bob 500\nerror: insufficient funds: bob has 70.50, cannot take 500.00 (short by 429.50)\n",
+		"> deposit carol 10\nerror: account \"carol\" not found",
+		"> undo\nundid transfer 50.00 from alice to bob\n",
+		"> accounts\nalice            100.00  Alice Smith\nbob               20.50  Bob Jones\n",
+	} {
+		if !strings.Contains(out.String(), want) {
+			t.Errorf("transcript is missing %q:\n%s", want, out.String())
+		}
+	}
+}
+
+// A second run with the same -data file sees the first run's accounts and
+// can undo its transactions
+func TestPersistence(t *testing.T) {
+	data := filepath.Join(t.TempDir(), "bank.json")
+	first := "open alice Alice\nopen bob Bob\ndeposit alice 80\ntransfer alice bob 30\n"
+	if err := run([]string{"-data", data, "-"}, strings.NewReader(first), io.Discard, io.Discard); err != nil {
+		t.Fatal(err)
+	}
+
+	var out bytes.Buffer
+	second := "balance bob\nundo\nbalance bob\nhistory\n"
+	if err := run([]string{"-data", data, "-"}, strings.NewReader(second), &out, io.Discard); err != nil {
+		t.Fatal(err)
+	}
+	want := `> balance bob
+bob: 30.00
+> undo
+undid transfer 30.00 from alice to bob
+> balance bob
+bob: 0.00
+> history
+  1. open alice for Alice
+  2. open bob for Bob
+  3. deposit 80.00 into alice
+`
+	if out.String() != want {
+		t.Errorf("second run:\n%s\nwant:\n%s", out.String(), want)
+	}
+
+	teller, err := Load(data)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if balance, _ := teller.Bank().Balance("alice"); balance != 8000 {
+		t.Errorf("saved balance of alice = %s, want 80.00", balance)
+	}
+}
+
+func TestLoadErrors(t *testing.T) {
+	dir := t.TempDir()
+	for name, content := range map[string]string{
+		"syntax":    `{"accounts": [`,
+		"duplicate": `{"accounts": [{"id": "a"}, {"id": "a"}]}`,
+		"negative":  `{"accounts": [{"id": "a", "balance": -1}]}`,
+		"unknown":   `{"history": [{"type": "loan", "id": "a"}]}`,
+	} {
+		path := filepath.Join(dir, name+".json")
+		if err := os.WriteFile(path, []byte(content), 0o644); err != nil {
+			t.Fatal(err)
+		}
+		if _, err := Load(path); err == nil {
+			t.Errorf("Load of %s succeeded", name)
+		}
+	}
+	if teller, err := Load(filepath.Join(dir, "missing.json")); err != nil || len(teller.Bank().Accounts()) != 0 {
+		t.Errorf("Load of a missing file = %v, %v, want an empty bank", teller, err)
+	}
+}
+
+func TestRunErrors(t *testing.T) {
+	if err := run([]string{"-nope"}, nil, io.Discard, io.Discard); !errors.Is(err, errUsage) {
+		t.Errorf("bad flag error = %v, want errUsage", err)
+	}
+	if err := run([]string{"testdata/missing.txt"}, nil, io.Discard, io.Discard); err == nil {
+		t.Error("missing script succeeded")
+	}
+	// A failure to save ends the session rather than being reported as a
+	// mistake of the user
+	data := filepath.Join(t.TempDir(), "no-such-dir", "bank.json")
+	err := run([]string{"-data", data, "-"}, strings.NewReader("open a A\n"), io.Discard, io.Discard)
+	if err == nil || userError(err) {
+		t.Errorf("saving into a missing directory error = %v, want a fatal error", err)
+	}
+}
@@ -0,0 +1,125 @@
+package main
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io/fs"
+	"os"
+	"path/filepath"
+)
+
+// snapshot is the JSON form of a teller: the accounts as they are now and
+// the history that can still be undone
+type snapshot struct {
+	Accounts []Account `json:"accounts"`
+	History  []record  `json:"history"`
+}
+
+// record is the JSON form of a transaction. JSON can't say which concrete
+// type an interface value had, so Type names it and the other fields are
+// the union of the transactions' fields
+type record struct {
+	Type   string `json:"type"`
+	ID     string `json:"id,omitempty"`
+	Owner  string `json:"owner,omitempty"`
+	From   string `json:"from,omitempty"`
+	To     string `json:"to,omitempty"`
+	Amount Money  `json:"amount,omitempty"`
+}
+
+func toRecord(tx Transaction) (record, error) {
+	switch tx := tx.(type) {
+	case OpenAccount:
+		return record{Type: "open", ID: tx.ID, Owner: tx.Owner}, nil
+	case Deposit:
+		return record{Type: "deposit", ID: tx.ID, Amount: tx.Amount}, nil
+	case Withdraw:
+		return record{Type: "withdraw", ID: tx.ID, Amount: tx.Amount}, nil
+	case Transfer:
+		return record{Type: "transfer", From: tx.From, To: tx.To, Amount: tx.Amount}, nil
+	}
+	return record{}, fmt.Errorf("cannot save transaction of type %T", tx)
+}
+
+func (r record) transaction() (Transaction, error) {
+	switch r.Type {
+	case "open":
+		return OpenAccount{ID: r.ID, Owner: r.Owner}, nil
+	case "deposit":
+		return Deposit{ID: r.ID, Amount: r.Amount}, nil
+	case "withdraw":
+		return Withdraw{ID: r.ID, Amount: r.Amount}, nil
+	case "transfer":
+		return Transfer{From: r.From, To: r.To, Amount: r.Amount}, nil
+	}
+	return nil, fmt.Errorf("unknown transaction type %q", r.Type)
+}
+
+// Save writes the accounts and history of t to path as JSON
+// It writes a temporary file and renames it over path, so a crash leaves
+// either the old file or the new one, never half of one
+func Save(path string, t *Teller) error {
+	snap := snapshot{Accounts: t.Bank().Accounts(), History: []record{}}
+	for _, tx := range t.History() {
+		r, err := toRecord(tx)
+		if err != nil {
+			return err
+		}
+		snap.History = append(snap.History, r)
+	}
+	data, err := json.MarshalIndent(snap, "", "  ")
+	if err != nil {
+		return err
+	}
+
+	f, err := os.CreateTemp(filepath.Dir(path), filepath.Base(path)+".*.tmp")
+	if err != nil {
+		return err
+	}
+	defer os.Remove(f.Name()) // fails harmlessly after the rename
+	if _, err := f.Write(append(data, '\n')); err != nil {
+		f.Close()
+		return err
+	}
+	if err := f.Close(); err != nil {
+		return err
+	}
+	return os.Rename(f.Name(), path)
+}
+
+// Load reads a teller saved by Save. A missing file gives a teller for an
+// empty bank, so the first run starts from scratch
+func Load(path string) (*Teller, error) {
+	data, err := os.ReadFile(path)
+	if errors.Is(err, fs.ErrNotExist) {
+		return NewTeller(NewBank()), nil
+	}
+	if err != nil {
+		return nil, err
+	}
+	var snap snapshot
+	if err := json.Unmarshal(data, &snap); err != nil {
+		return nil, fmt.Errorf("%s: %w", path, err)
+	}
+
+	b := NewBank()
+	for _, a := range snap.Accounts {
+		if err := b.Open(a.ID, a.Owner); err != nil {
+			return nil, fmt.Errorf("%s: %w", path, err)
+		}
+		if a.Balance < 0 {
+			return nil, fmt.Errorf("%s: account %q has a negative balance", path, a.ID)
+		}
+		b.accounts[a.ID].Balance = a.Balance
+	}
+	t := NewTeller(b)
+	for i, r := range snap.History {
+		tx, err := r.transaction()
+		if err != nil {
+			return nil, fmt.Errorf("%s: history entry %d: %w", path, i+1, err)
+		}
+		t.history.Push(tx)
+	}
+	return t, nil
+}
@@ -0,0 +1,130 @@
+package main
+
+import (
+	"errors"
+	"fmt"
+
+	"github.com/NutProhmpiriya/go-basic/02-data-structures/datastructures"
+)
+
+var (
+	// ErrNothingToUndo is returned by Teller.Undo when the history is empty
+	ErrNothingToUndo = errors.New("nothing to undo")
+	// ErrSameAccount is returned for a transfer from an account to itself
+	ErrSameAccount = errors.New("cannot transfer to the same account")
+)
+
+// Transaction is the Command pattern: each change to the bank is an object
+// that knows how to apply itself and how to take itself back
+// Undo is only called on the most recent transaction still applied, so it
+// sees the bank exactly as Execute left it
+type Transaction interface {
+	Execute(b *Bank) error
+	Undo(b *Bank) error
+	String() string
+}
+
+// OpenAccount opens a new, empty account
+type OpenAccount struct {
+	ID    string
+	Owner string
+}
+
+func (t OpenAccount) Execute(b *Bank) error { return b.Open(t.ID, t.Owner) }
+func (t OpenAccount) Undo(b *Bank) error    { return b.Close(t.ID) }
+func (t OpenAccount) String() string        { return fmt.Sprintf("open %s for %s", t.ID, t.Owner) }
+
+// Deposit puts money into an account
+type Deposit struct {
+	ID     string
+	Amount Money
+}
+
+func (t Deposit) Execute(b *Bank) error { return b.Deposit(t.ID, t.Amount) }
+func (t Deposit) Undo(b *Bank) error    { return b.Withdraw(t.ID, t.Amount) }
+func (t Deposit) String() string        { return fmt.Sprintf("deposit %s into %s", t.Amount, t.ID) }
+
+// Withdraw takes money out of an account
+type Withdraw struct {
+	ID     string
+	Amount Money
+}
+
+func (t Withdraw) Execute(b *Bank) error { return b.Withdraw(t.ID, t.Amount) }
+func (t Withdraw) Undo(b *Bank) error    { return b.Deposit(t.ID, t.Amount) }
+func (t Withdraw) String() string        { return fmt.Sprintf("withdraw %s from %s", t.Amount, t.ID) }
+
+// Transfer moves money between two accounts
+type Transfer struct {
+	From   string
+	To     string
+	Amount Money
+}
+
+// Execute checks the receiving account before it takes the money, so a
+// failed transfer changes nothing
+func (t Transfer) Execute(b *Bank) error {
+	if t.From == t.To {
+		return fmt.Errorf("%w: %s", ErrSameAccount, t.From)
+	}
+	if _, err := b.Balance(t.To); err != nil {
+		return err
+	}
+	if err := b.Withdraw(t.From, t.Amount); err != nil {
+		return err
+	}
+	return b.Deposit(t.To, t.Amount)
+}
+
+func (t Transfer) Undo(b *Bank) error {
+	return Transfer{From: t.To, To: t.From, Amount: t.Amount}.Execute(b)
+}
+
+func (t Transfer) String() string {
+	return fmt.Sprintf("transfer %s from %s to %s", t.Amount, t.From, t.To)
+}
+
+// Teller is the invoker of the Command pattern: it runs the transactions
+// against its bank and keeps the ones that succeeded on a stack, so the
+// last one can be undone
+type Teller struct {
+	bank    *Bank
+	history datastructures.StackOf[Transaction]
+}
+
+// NewTeller creates a teller for b with an empty history
+func NewTeller(b *Bank) *Teller {
+	return &Teller{bank: b}
+}
+
+// Bank returns the bank the teller works on
+func (t *Teller) Bank() *Bank {
+	return t.bank
+}
+
+// Do executes tx and records it if it succeeds
+func (t *Teller) Do(tx Transaction) error {
+	if err := tx.Execute(t.bank); err != nil {
+		return err
+	}
+	t.history.Push(tx)
+	return nil
+}
+
+// Undo takes back the most recent transaction and returns it
+func (t *Teller) Undo() (Transaction, error) {
+	tx, err := t.history.Peek()
+	if err != nil {
+		return nil, ErrNothingToUndo
+	}
+	if err := tx.Undo(t.bank); err != nil {
+		return nil, fmt.Errorf("undo %s: %w", tx, err)
+	}
+	t.history.Pop()
+	return tx, nil
+}
+
+// History returns the applied transactions, oldest first
+func (t *Teller) History() []Transaction {
+	return t.history.ToSlice()
+}
@@ -0,0 +1,66 @@
+package main
+
+import (
+	"flag"
+	"fmt"
+	"io"
+	"os"
+	"os/exec"
+	"path"
+	"strconv"
+	"strings"
+)
+
+// benchPackages are the 03-algorithms packages with benchmark suites
+// catalog benchmarks every algorithm in the registry
+var benchPackages = []string{"sorting", "searching", "stringalgo", "catalog"}
+
+// runBench implements "gobasic bench [-o file] [-count n] [-bench regexp] [package ...]"
+// Run it from the repository root. The results are written in the standard
+// go test format, which benchstat reads
+func runBench(args []string) error {
+	flags := flag.NewFlagSet("bench", flag.ContinueOnError)
+	output := flags.String("o", "bench.txt", "file to write the results to")
+	count := flags.Int("count", 6, "run each benchmark n times so benchstat can measure the variance")
+	pattern := flags.String("bench", ".", "run only the benchmarks matching this regexp")
+	benchtime := flags.String("benchtime", "", "time or iterations per benchmark, e.g. 200ms or 100x")
+	dir := flags.String("dir", "03-algorithms", "directory containing the algorithm packages")
+	if err := flags.Parse(args); err != nil {
+		return err
+	}
+	packages := flags.Args()
+	if len(packages) == 0 {
+		packages = benchPackages
+	}
+
+	testArgs := []string{"test", "-run=^$", "-bench=" + *pattern, "-benchmem", "-count=" + strconv.Itoa(*count)}
+	if *benchtime != "" {
+		testArgs = append(testArgs, "-benchtime="+*benchtime)
+	}
+	for _, name := range packages {
+		testArgs = append(testArgs, "./"+path.Join(*dir, name))
+	}
+
+	f, err := os.Create(*output)
+	if err != nil {
+		return err
+	}
+	// Show progress while the results are saved
+	out := io.MultiWriter(os.Stdout, f)
+
+	cmd := exec.Command("go", testArgs...)
+	cmd.Stdout = out
+	cmd.Stderr = os.Stderr
+	if err := cmd.Run(); err != nil {
+		f.Close()
+		return fmt.Errorf("benchmarking %s: %w", strings.Join(packages, ", "), err)
+	}
+	if err := f.Close(); err != nil {
+		return err
+	}
+
+	fmt.Printf("\nResults written to %s\n", *output)
+	fmt.Printf("Compare algorithms:  benchstat -col /algo %s\n", *output)
+	fmt.Printf("Compare two runs:    benchstat old.txt %s\n", *output)
+	return nil
+}
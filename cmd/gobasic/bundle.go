@@ -0,0 +1,143 @@
+package main
+
+import (
+	"archive/tar"
+	"archive/zip"
+	"compress/gzip"
+	"flag"
+	"fmt"
+	"io"
+	"io/fs"
+	"os"
+	"path/filepath"
+	"strings"
+)
+
+// defaultDatasets are bundled when no directories are given
+var defaultDatasets = []string{"03-algorithms/data"}
+
+// runBundle implements "gobasic bundle [-o archive] [dir ...]"
+// The archive format is chosen from the output file extension
+func runBundle(args []string) error {
+	flags := flag.NewFlagSet("bundle", flag.ContinueOnError)
+	output := flags.String("o", "datasets.zip", "output archive (.zip, .tar.gz or .tgz)")
+	if err := flags.Parse(args); err != nil {
+		return err
+	}
+	dirs := flags.Args()
+	if len(dirs) == 0 {
+		dirs = defaultDatasets
+	}
+
+	var write func(io.Writer, []string) (int, error)
+	switch {
+	case strings.HasSuffix(*output, ".zip"):
+		write = bundleZip
+	case strings.HasSuffix(*output, ".tar.gz"), strings.HasSuffix(*output, ".tgz"):
+		write = bundleTarGz
+	default:
+		return fmt.Errorf("unsupported archive type %q (want .zip, .tar.gz or .tgz)", *output)
+	}
+
+	f, err := os.Create(*output)
+	if err != nil {
+		return err
+	}
+	count, err := write(f, dirs)
+	if closeErr := f.Close(); err == nil {
+		err = closeErr
+	}
+	if err != nil {
+		os.Remove(*output)
+		return err
+	}
+
+	fmt.Printf("Bundled %d files from %v into %s\n", count, dirs, *output)
+	return nil
+}
+
+// walkFiles calls fn for every regular file under dirs
+// name is the slash-separated path used inside the archive
+func walkFiles(dirs []string, fn func(path, name string, info fs.FileInfo) error) error {
+	for _, dir := range dirs {
+		err := filepath.WalkDir(dir, func(path string, d fs.DirEntry, err error) error {
+			if err != nil || !d.Type().IsRegular() {
+				return err
+			}
+			info, err := d.Info()
+			if err != nil {
+				return err
+			}
+			name := filepath.ToSlash(filepath.Clean(path))
+			if !filepath.IsLocal(name) {
+				return fmt.Errorf("refusing to bundle %q: path must be inside the current directory", path)
+			}
+			return fn(path, name, info)
+		})
+		if err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// copyFile streams the file at path into w
+func copyFile(w io.Writer, path string) error {
+	f, err := os.Open(path)
+	if err != nil {
+		return err
+	}
+	defer f.Close()
+	_, err = io.Copy(w, f)
+	return err
+}
+
+// bundleZip writes the files under dirs as a zip archive
+func bundleZip(w io.Writer, dirs []string) (int, error) {
+	zw := zip.NewWriter(w)
+	count := 0
+	err := walkFiles(dirs, func(path, name string, info fs.FileInfo) error {
+		header, err := zip.FileInfoHeader(info)
+		if err != nil {
+			return err
+		}
+		header.Name = name
+		header.Method = zip.Deflate
+		entry, err := zw.CreateHeader(header)
+		if err != nil {
+			return err
+		}
+		count++
+		return copyFile(entry, path)
+	})
+	if err != nil {
+		return count, err
+	}
+	return count, zw.Close()
+}
+
+// bundleTarGz writes the files under dirs as a gzip-compressed tar stream
+func bundleTarGz(w io.Writer, dirs []string) (int, error) {
+	gz := gzip.NewWriter(w)
+	tw := tar.NewWriter(gz)
+	count := 0
+	err := walkFiles(dirs, func(path, name string, info fs.FileInfo) error {
+		header, err := tar.FileInfoHeader(info, "")
+		if err != nil {
+			return err
+		}
+		header.Name = name
+		if err := tw.WriteHeader(header); err != nil {
+			return err
+		}
+		count++
+		return copyFile(tw, path)
+	})
+	if err != nil {
+		return count, err
+	}
+	if err := tw.Close(); err != nil {
+		return count, err
+	}
+	return count, gz.Close()
+}
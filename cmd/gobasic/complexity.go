@@ -0,0 +1,134 @@
+package main
+
+import (
+	"flag"
+	"fmt"
+	"strconv"
+	"strings"
+	"time"
+	"unicode/utf8"
+
+	"github.com/NutProhmpiriya/go-basic/complexity"
+)
+
+// runComplexity implements "gobasic complexity [-list] [-v] [-sizes n,n,...] [algorithm ...]"
+// It times each algorithm at growing input sizes and prints the complexity
+// class that fits the timings best next to the one its code claims
+func runComplexity(args []string) error {
+	flags := flag.NewFlagSet("complexity", flag.ContinueOnError)
+	list := flags.Bool("list", false, "list the algorithms and their claimed complexity")
+	verbose := flags.Bool("v", false, "print the timings and the error of every class")
+	sizeList := flags.String("sizes", "", "comma-separated input sizes, instead of each algorithm's own")
+	minTime := flags.Duration("mintime", 5*time.Millisecond, "repeat fast operations until one timing takes this long")
+	seed := flags.Int64("seed", 1, "seed for the generated inputs")
+	if err := flags.Parse(args); err != nil {
+		return err
+	}
+
+	algorithms := complexity.Algorithms()
+	if flags.NArg() > 0 {
+		algorithms = algorithms[:0:0]
+		for _, name := range flags.Args() {
+			a, err := complexity.Lookup(name)
+			if err != nil {
+				return err
+			}
+			algorithms = append(algorithms, a)
+		}
+	}
+
+	if *list {
+		for _, a := range algorithms {
+			fmt.Printf("%-36s %s\n", a.Name, a.Claim)
+		}
+		return nil
+	}
+
+	var sizes []int
+	if *sizeList != "" {
+		for _, field := range strings.Split(*sizeList, ",") {
+			n, err := strconv.Atoi(strings.TrimSpace(field))
+			if err != nil || n < 1 {
+				return fmt.Errorf("bad size %q", field)
+			}
+			sizes = append(sizes, n)
+		}
+		if len(sizes) < 2 {
+			return fmt.Errorf("need at least two sizes to fit a curve")
+		}
+	}
+
+	width := len("algorithm")
+	for _, a := range algorithms {
+		width = max(width, len(a.Name))
+	}
+	// Rows are printed as they are measured, so the columns have fixed widths
+	row := func(name, claim, estimate, errPct, slope, verdict string) {
+		fmt.Printf("%s  %s  %s  %6s  %5s  %s\n", pad(name, width), pad(claim, 10), pad(estimate, 10), errPct, slope, verdict)
+	}
+
+	opts := complexity.Options{Seed: *seed, MinTime: *minTime}
+	if !*verbose {
+		row("algorithm", "claimed", "estimated", "error", "slope", "")
+	}
+	for _, a := range algorithms {
+		n := sizes
+		if n == nil {
+			n = a.Sizes
+		}
+		samples := complexity.Measure(a, n, opts)
+		estimates := complexity.Fit(samples)
+		if *verbose {
+			printFit(a, samples, estimates)
+			row("algorithm", "claimed", "estimated", "error", "slope", "")
+		}
+
+		best := estimates[0]
+		row(a.Name, a.Claim.Name, best.Class.Name, fmt.Sprintf("%.1f%%", 100*best.Error),
+			fmt.Sprintf("%.2f", complexity.Slope(samples)), verdict(a.Claim, estimates))
+		if *verbose {
+			fmt.Println()
+		}
+	}
+
+	fmt.Println("\nerror: how far the timings are from the estimated curve")
+	fmt.Println("slope: k in time ≈ c·n^k (1 for O(n), 2 for O(n²), a little over 1 for O(n log n))")
+	fmt.Println("close: the claim fits almost as well as the estimate")
+	fmt.Println("n and n log n are easily confused: inputs that outgrow the CPU caches get slower per element, and timings are noisy")
+	return nil
+}
+
+// pad fills s with spaces to width characters; Printf's %-10s counts bytes,
+// and the class names have multi-byte characters like ² and √
+func pad(s string, width int) string {
+	return s + strings.Repeat(" ", max(0, width-utf8.RuneCountInString(s)))
+}
+
+// closeFit is how much worse than the best estimate the claim may fit and
+// still be called close, in percentage points of error
+const closeFit = 0.05
+
+// verdict compares the claimed class with the estimates
+func verdict(claim complexity.Class, estimates []complexity.Estimate) string {
+	if estimates[0].Class.Name == claim.Name {
+		return "matches"
+	}
+	for _, e := range estimates {
+		if e.Class.Name == claim.Name && e.Error-estimates[0].Error <= closeFit {
+			return "close"
+		}
+	}
+	return "differs"
+}
+
+// printFit shows the timings of one algorithm and how well each class fits them
+func printFit(a complexity.Algorithm, samples []complexity.Sample, estimates []complexity.Estimate) {
+	fmt.Printf("=== %s (claimed %s) ===\n", a.Name, a.Claim)
+	for _, s := range samples {
+		fmt.Printf("  n=%-9d %12v ± %v\n", s.N, s.Time, s.Spread)
+	}
+	for _, e := range estimates {
+		fmt.Printf("  %s error %6.1f%%\n", pad(e.Class.Name, 11), 100*e.Error)
+	}
+	fmt.Println()
+}
@@ -0,0 +1,58 @@
+// Command gobasic provides helper commands for working with this repository
+//
+// Usage:
+//
+//	go run ./cmd/gobasic <command> [arguments]
+//
+// Run it from the repository root so the default paths resolve.
+package main
+
+import (
+	"fmt"
+	"os"
+)
+
+// command is a gobasic subcommand
+type command struct {
+	name    string
+	summary string
+	run     func(args []string) error
+}
+
+var commands = []command{
+	{"bench", "run the algorithm benchmarks and save benchstat-ready results", runBench},
+	{"bundle", "package the sample datasets into a zip or tar.gz archive", runBundle},
+	{"complexity", "measure how running times grow and estimate each algorithm's Big-O class", runComplexity},
+	{"race", "run the tests and the stress tests under the race detector", runRace},
+	{"tour", "walk through the examples as an interactive tour", runTour},
+}
+
+func usage() {
+	fmt.Fprintln(os.Stderr, "Usage: gobasic <command> [arguments]")
+	fmt.Fprintln(os.Stderr, "\nCommands:")
+	for _, c := range commands {
+		fmt.Fprintf(os.Stderr, "  %-11s %s\n", c.name, c.summary)
+	}
+}
+
+func main() {
+	if len(os.Args) < 2 {
+		usage()
+		os.Exit(2)
+	}
+
+	name := os.Args[1]
+	for _, c := range commands {
+		if c.name == name {
+			if err := c.run(os.Args[2:]); err != nil {
+				fmt.Fprintf(os.Stderr, "gobasic %s: %v\n", name, err)
+				os.Exit(1)
+			}
+			return
+		}
+	}
+
+	fmt.Fprintf(os.Stderr, "gobasic: unknown command %q\n\n", name)
+	usage()
+	os.Exit(2)
+}
@@ -0,0 +1,40 @@
+package main
+
+import (
+	"flag"
+	"fmt"
+	"os"
+	"os/exec"
+	"strconv"
+)
+
+// runRace implements "gobasic race [-count n] [-run regexp] [package ...]"
+// It runs the tests under the race detector together with the stress tests,
+// which are behind the stress build tag because they start hundreds of
+// goroutines and are slow with -race
+func runRace(args []string) error {
+	flags := flag.NewFlagSet("race", flag.ContinueOnError)
+	count := flags.Int("count", 1, "run each test n times; more runs find rarer races")
+	pattern := flags.String("run", "", "run only the tests matching this regexp")
+	if err := flags.Parse(args); err != nil {
+		return err
+	}
+	packages := flags.Args()
+	if len(packages) == 0 {
+		packages = []string{"./..."}
+	}
+
+	testArgs := []string{"test", "-race", "-tags=stress", "-count=" + strconv.Itoa(*count)}
+	if *pattern != "" {
+		testArgs = append(testArgs, "-run="+*pattern)
+	}
+	testArgs = append(testArgs, packages...)
+
+	cmd := exec.Command("go", testArgs...)
+	cmd.Stdout = os.Stdout
+	cmd.Stderr = os.Stderr
+	if err := cmd.Run(); err != nil {
+		return fmt.Errorf("race tests: %w", err)
+	}
+	return nil
+}
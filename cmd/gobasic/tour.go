@@ -0,0 +1,42 @@
+package main
+
+import (
+	"flag"
+	"fmt"
+	"os"
+
+	"github.com/NutProhmpiriya/go-basic/learn"
+)
+
+// runTour implements "gobasic tour [-list] [-no-pause] [topic ...]"
+// With no topics given the whole curriculum runs in order
+func runTour(args []string) error {
+	flags := flag.NewFlagSet("tour", flag.ContinueOnError)
+	list := flags.Bool("list", false, "list the lessons and exit")
+	noPause := flags.Bool("no-pause", false, "run every step without waiting for Enter")
+	if err := flags.Parse(args); err != nil {
+		return err
+	}
+
+	if *list {
+		for _, l := range learn.Tour {
+			fmt.Printf("%-16s %s (%d steps)\n", l.Topic, l.Title, len(l.Steps))
+		}
+		return nil
+	}
+
+	lessons := learn.Tour
+	if flags.NArg() > 0 {
+		lessons = nil
+		for _, topic := range flags.Args() {
+			l, err := learn.TourLesson(topic)
+			if err != nil {
+				return fmt.Errorf("%w (see gobasic tour -list)", err)
+			}
+			lessons = append(lessons, l)
+		}
+	}
+
+	runner := &learn.Runner{In: os.Stdin, Out: os.Stdout, Pause: !*noPause, Seed: 1}
+	return runner.Run(lessons)
+}
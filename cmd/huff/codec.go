@@ -0,0 +1,257 @@
+package main
+
+import (
+	"bufio"
+	"encoding/binary"
+	"errors"
+	"fmt"
+	"io"
+	"slices"
+
+	"github.com/NutProhmpiriya/go-basic/03-algorithms/greedy"
+	"github.com/NutProhmpiriya/go-basic/bitio"
+)
+
+// magic starts every compressed file; the last byte is the format version
+const magic = "HUF\x01"
+
+// maxCodeLen is the longest code the format allows. A Huffman code only
+// gets this long when the counts grow like the Fibonacci numbers, which
+// would take a file of some 10¹³ bytes
+const maxCodeLen = 64
+
+// errFormat marks input that is not a file written by compress
+var errFormat = errors.New("not a huff file")
+
+// code is a canonical Huffman code for bytes
+// Only the length of each byte's code comes from the Huffman tree. The
+// codes themselves are then handed out in order, shortest first and by
+// byte within a length, so the lengths alone describe the whole code and
+// the header needn't store the tree
+type code struct {
+	// length of the code of every byte, 0 for bytes that don't occur
+	length [256]int
+	// bits of the code of every byte, in the low length bits
+	bits [256]uint64
+	// symbols are the bytes that occur, in canonical order
+	symbols []byte
+}
+
+// newCode returns the Huffman code for bytes with the given counts
+func newCode(counts *[256]int) (*code, error) {
+	freq := make(map[rune]int)
+	for b, n := range counts {
+		if n > 0 {
+			freq[rune(b)] = n
+		}
+	}
+	c := &code{}
+	var walk func(n *greedy.HuffmanNode, depth int)
+	walk = func(n *greedy.HuffmanNode, depth int) {
+		if n.Left == nil && n.Right == nil {
+			// A lone byte is the root of its tree, but still needs a bit
+			c.length[n.Char] = max(depth, 1)
+			return
+		}
+		walk(n.Left, depth+1)
+		walk(n.Right, depth+1)
+	}
+	if root := greedy.BuildHuffmanTreeFreq(freq); root != nil {
+		walk(root, 0)
+	}
+	if slices.Max(c.length[:]) > maxCodeLen {
+		return nil, fmt.Errorf("a code is longer than %d bits", maxCodeLen)
+	}
+	c.assign()
+	return c, nil
+}
+
+// assign lists the symbols in canonical order and gives them their codes:
+// each code is the one before it plus one, shifted left when the length
+// grows, so that no code is the start of another
+func (c *code) assign() {
+	c.symbols = c.symbols[:0]
+	for b, l := range c.length {
+		if l > 0 {
+			c.symbols = append(c.symbols, byte(b))
+		}
+	}
+	slices.SortStableFunc(c.symbols, func(a, b byte) int { return c.length[a] - c.length[b] })
+	var next uint64
+	prev := 0
+	for _, s := range c.symbols {
+		next <<= c.length[s] - prev
+		c.bits[s] = next
+		next++
+		prev = c.length[s]
+	}
+}
+
+// compress writes data to w in the huff format:
+//
+//	"HUF\x01"                   magic and version
+//	uvarint                     length of the original data
+//
+// and unless the data is empty
+//
+//	byte L                      length of the longest code
+//	L uvarints                  how many codes there are of each length 1..L
+//	one byte per code           the bytes, in canonical order
+//	the codes of the data       packed most significant bit first, the last
+//	                            byte padded with zeros
+//
+// Huffman coding needs the counts before it can write the first code, so
+// data is held in memory whole
+func compress(w io.Writer, data []byte) error {
+	var counts [256]int
+	for _, b := range data {
+		counts[b]++
+	}
+	c, err := newCode(&counts)
+	if err != nil {
+		return err
+	}
+
+	bw := bufio.NewWriter(w)
+	bw.WriteString(magic)
+	bw.Write(binary.AppendUvarint(nil, uint64(len(data))))
+	if len(data) > 0 {
+		longest := c.length[c.symbols[len(c.symbols)-1]]
+		bw.WriteByte(byte(longest))
+		perLength := make([]uint64, longest+1)
+		for _, s := range c.symbols {
+			perLength[c.length[s]]++
+		}
+		for _, n := range perLength[1:] {
+			bw.Write(binary.AppendUvarint(nil, n))
+		}
+		bw.Write(c.symbols)
+
+		bits := bitio.NewWriter(bw)
+		for _, b := range data {
+			bits.WriteBits(c.bits[b], c.length[b])
+		}
+		if err := bits.Flush(); err != nil {
+			return err
+		}
+	}
+	return bw.Flush()
+}
+
+// decoder reads the codes of a canonical code back
+// The codes of one length are consecutive numbers, so a code of length l
+// is found by subtracting the first code of that length: what is left is
+// its position among them
+type decoder struct {
+	longest int
+	// first code, number of codes and index of the first symbol in
+	// symbols, for every length
+	first, count, offset []uint64
+	symbols              []byte
+}
+
+// readHeader reads the header after the magic and returns the length of
+// the data and its decoder
+func readHeader(r *bufio.Reader) (uint64, *decoder, error) {
+	size, err := binary.ReadUvarint(r)
+	if err != nil || size == 0 {
+		return 0, nil, err
+	}
+	longest, err := r.ReadByte()
+	if err != nil {
+		return 0, nil, err
+	}
+	if longest == 0 || longest > maxCodeLen {
+		return 0, nil, fmt.Errorf("%w: codes of up to %d bits", errFormat, longest)
+	}
+	d := &decoder{
+		longest: int(longest),
+		first:   make([]uint64, longest+1),
+		count:   make([]uint64, longest+1),
+		offset:  make([]uint64, longest+1),
+	}
+	var code, total uint64
+	// unused counts the codes of the current length not yet taken, which
+	// halves as a prefix of every longer code; more codes than that can't
+	// be told apart
+	unused := uint64(1)
+	for l := 1; l <= d.longest; l++ {
+		n, err := binary.ReadUvarint(r)
+		if err != nil {
+			return 0, nil, err
+		}
+		if unused <= 1<<62 {
+			unused *= 2
+		}
+		if n > unused {
+			return 0, nil, fmt.Errorf("%w: %d codes of %d bits", errFormat, n, l)
+		}
+		unused -= n
+		d.first[l], d.count[l], d.offset[l] = code, n, total
+		code = (code + n) << 1
+		total += n
+	}
+	if total == 0 || total > 256 {
+		return 0, nil, fmt.Errorf("%w: %d symbols", errFormat, total)
+	}
+	d.symbols = make([]byte, total)
+	if _, err := io.ReadFull(r, d.symbols); err != nil {
+		return 0, nil, err
+	}
+	return size, d, nil
+}
+
+// next reads one code and returns its byte
+func (d *decoder) next(bits *bitio.Reader) (byte, error) {
+	var code uint64
+	for l := 1; l <= d.longest; l++ {
+		bit, err := bits.ReadBits(1)
+		if err != nil {
+			return 0, err
+		}
+		code = code<<1 | bit
+		if code >= d.first[l] && code-d.first[l] < d.count[l] {
+			return d.symbols[d.offset[l]+code-d.first[l]], nil
+		}
+	}
+	return 0, fmt.Errorf("%w: invalid code", errFormat)
+}
+
+// decompress reads a file written by compress from r and writes the
+// original data to w. Unlike compress it streams, holding only the header
+func decompress(w io.Writer, r io.Reader) error {
+	br := bufio.NewReader(r)
+	head := make([]byte, len(magic))
+	if _, err := io.ReadFull(br, head); err != nil || string(head) != magic {
+		return errFormat
+	}
+	size, d, err := readHeader(br)
+	if err != nil {
+		return truncated(err)
+	}
+
+	bw := bufio.NewWriter(w)
+	// The bit reader shares br, so what it hasn't read is still there for
+	// the check for trailing data
+	bits := bitio.NewReader(br)
+	for range size {
+		b, err := d.next(bits)
+		if err != nil {
+			return truncated(err)
+		}
+		bw.WriteByte(b)
+	}
+	if _, err := br.ReadByte(); err != io.EOF {
+		return fmt.Errorf("%w: data after the end", errFormat)
+	}
+	return bw.Flush()
+}
+
+// truncated reports running out of input in the middle of a file as the
+// format error it is
+func truncated(err error) error {
+	if err == io.EOF || err == io.ErrUnexpectedEOF {
+		return fmt.Errorf("%w: truncated", errFormat)
+	}
+	return err
+}
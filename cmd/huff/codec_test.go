@@ -0,0 +1,158 @@
+package main
+
+import (
+	"bytes"
+	"errors"
+	"math/rand"
+	"strings"
+	"testing"
+	"testing/quick"
+)
+
+// roundTrip compresses data and decompresses the result
+func roundTrip(t *testing.T, data []byte) []byte {
+	t.Helper()
+	var packed, unpacked bytes.Buffer
+	if err := compress(&packed, data); err != nil {
+		t.Fatal(err)
+	}
+	if err := decompress(&unpacked, &packed); err != nil {
+		t.Fatal(err)
+	}
+	if !bytes.Equal(unpacked.Bytes(), data) {
+		t.Fatalf("round trip of %d bytes gave back %d different bytes", len(data), unpacked.Len())
+	}
+	return packed.Bytes()
+}
+
+// fibonacci returns data in which byte i occurs fib(i+1) times, the counts
+// that make the deepest Huffman tree
+func fibonacci(n int) []byte {
+	var data []byte
+	a, b := 1, 1
+	for i := range n {
+		data = append(data, bytes.Repeat([]byte{byte(i)}, a)...)
+		a, b = b, a+b
+	}
+	rand.New(rand.NewSource(1)).Shuffle(len(data), func(i, j int) { data[i], data[j] = data[j], data[i] })
+	return data
+}
+
+func TestRoundTrip(t *testing.T) {
+	rng := rand.New(rand.NewSource(1))
+	random := make([]byte, 100_000)
+	rng.Read(random)
+	every := make([]byte, 256*3)
+	for i := range every {
+		every[i] = byte(i)
+	}
+	text := []byte(strings.Repeat("It was the best of times, it was the worst of times. ", 200))
+
+	tests := []struct {
+		name string
+		data []byte
+		// most is the largest size the compressed data may have
+		most int
+	}{
+		{"empty", nil, 5},
+		{"one byte", []byte{0}, 9},
+		{"one value", bytes.Repeat([]byte{0xff}, 8000), 1010},
+		{"every value", every, 1100},
+		{"random", random, 100_300},
+		{"text", text, len(text) * 6 / 10},
+		{"deep tree", fibonacci(25), 40_000},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if packed := roundTrip(t, tt.data); len(packed) > tt.most {
+				t.Errorf("%d bytes compressed to %d, want at most %d", len(tt.data), len(packed), tt.most)
+			}
+		})
+	}
+
+	// The same data always compresses to the same bytes
+	if a, b := roundTrip(t, text), roundTrip(t, text); !bytes.Equal(a, b) {
+		t.Error("compressing the same text twice gave different output")
+	}
+}
+
+func TestRoundTripProperty(t *testing.T) {
+	property := func(data []byte, skew uint8) bool {
+		// Folding the bytes onto fewer values gives codes of many lengths
+		for i := range data {
+			data[i] %= skew | 1
+		}
+		var packed, unpacked bytes.Buffer
+		return compress(&packed, data) == nil &&
+			decompress(&unpacked, &packed) == nil &&
+			bytes.Equal(unpacked.Bytes(), data)
+	}
+	if err := quick.Check(property, nil); err != nil {
+		t.Error(err)
+	}
+}
+
+func TestCanonicalCode(t *testing.T) {
+	// a:5 r:2 b:2 c:1 d:1, as in abracadabra
+	var counts [256]int
+	for _, b := range []byte("abracadabra") {
+		counts[b]++
+	}
+	c, err := newCode(&counts)
+	if err != nil {
+		t.Fatal(err)
+	}
+	want := map[byte]string{'a': "0", 'r': "10", 'b': "110", 'c': "1110", 'd': "1111"}
+	for b, code := range want {
+		if got := formatBits(c.bits[b], c.length[b]); got != code {
+			t.Errorf("code of %c = %s, want %s", b, got, code)
+		}
+	}
+	if string(c.symbols) != "arbcd" {
+		t.Errorf("canonical order %q, want arbcd", c.symbols)
+	}
+}
+
+// formatBits returns the low n bits of v as 0s and 1s
+func formatBits(v uint64, n int) string {
+	var sb strings.Builder
+	for i := n - 1; i >= 0; i-- {
+		sb.WriteByte('0' + byte(v>>i&1))
+	}
+	return sb.String()
+}
+
+func TestDecompressErrors(t *testing.T) {
+	var good bytes.Buffer
+	compress(&good, []byte("abracadabra"))
+	packed := good.Bytes()
+
+	tests := []struct {
+		name string
+		data []byte
+		want string
+	}{
+		{"empty", nil, "not a huff file"},
+		{"wrong magic", []byte("PK\x03\x04 a zip file"), "not a huff file"},
+		{"data after the end", append(bytes.Clone(packed), 0), "data after the end"},
+		{"too long codes", []byte(magic + "\x05\x41"), "codes of up to 65 bits"},
+		{"three codes of one bit", []byte(magic + "\x05\x01\x03abc"), "3 codes of 1 bits"},
+		{"no symbols", []byte(magic + "\x05\x02\x00\x00"), "0 symbols"},
+		// One symbol gets the code 0; a 1 matches no code
+		{"invalid code", []byte(magic + "\x02\x01\x01a\x80"), "invalid code"},
+	}
+	// Cutting the file short anywhere is caught
+	for n := len(magic); n < len(packed); n++ {
+		tests = append(tests, struct {
+			name string
+			data []byte
+			want string
+		}{"truncated", packed[:n], "truncated"})
+	}
+	for _, tt := range tests {
+		err := decompress(&bytes.Buffer{}, bytes.NewReader(tt.data))
+		if !errors.Is(err, errFormat) || !strings.Contains(err.Error(), tt.want) {
+			t.Errorf("%s (%d bytes): err = %v, want %q", tt.name, len(tt.data), err, tt.want)
+		}
+	}
+}
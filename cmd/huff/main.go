@@ -0,0 +1,244 @@
+// Command huff compresses files with Huffman coding, the greedy algorithm
+// of 03-algorithms/greedy put to work on real files:
+//   - the bytes are counted and greedy.BuildHuffmanTreeFreq builds the tree,
+//     which gives frequent bytes short codes and rare ones long codes
+//   - only the length of every code is kept: the codes are made canonical,
+//     so the header stores a few dozen bytes instead of the tree
+//   - the bitio package packs the codes into bytes and takes them apart
+//     again
+//
+// Usage:
+//
+//	go run ./cmd/huff compress [-o file] [-f] [file]
+//	go run ./cmd/huff decompress [-o file] [-f] [file]
+//	go run ./cmd/huff codes [file]
+//
+// compress writes file.huff and decompress turns it back into file; with
+// no file they read standard input and write standard output, so they fit
+// in a pipeline. They don't overwrite a file unless given -f. codes prints
+// the code of every byte
+//
+// Any file can be compressed, text or binary. Huffman coding shrinks text
+// to a little over half its size, but data that is already compressed,
+// like a JPEG or a zip file, comes out slightly larger
+package main
+
+import (
+	"errors"
+	"flag"
+	"fmt"
+	"io"
+	"os"
+	"strings"
+	"text/tabwriter"
+	"unicode"
+)
+
+// errUsage marks errors caused by bad arguments, which exit with code 2
+var errUsage = errors.New("usage error")
+
+// ext is added to the names of compressed files
+const ext = ".huff"
+
+// options are the flags of compress and decompress
+type options struct {
+	out   string
+	force bool
+}
+
+// runCompress compresses a file, or standard input
+func runCompress(opts options, args []string, stdin io.Reader, stdout, stderr io.Writer) error {
+	return convert(opts, args, stdin, stdout, stderr, true, func(name string) (string, error) {
+		return name + ext, nil
+	}, func(w io.Writer, r io.Reader) error {
+		data, err := io.ReadAll(r)
+		if err != nil {
+			return err
+		}
+		return compress(w, data)
+	})
+}
+
+// runDecompress decompresses a file, or standard input
+func runDecompress(opts options, args []string, stdin io.Reader, stdout, stderr io.Writer) error {
+	return convert(opts, args, stdin, stdout, stderr, false, func(name string) (string, error) {
+		base, ok := strings.CutSuffix(name, ext)
+		if !ok || base == "" {
+			return "", fmt.Errorf("%w: %s doesn't end in %s; name the output with -o", errUsage, name, ext)
+		}
+		return base, nil
+	}, decompress)
+}
+
+// convert runs f from the input to the output that args and opts name
+// When the output is a file, it reports the sizes on stderr, and with
+// ratio the output's size as a percentage of the input's
+// An output file is only created if it doesn't exist, unless -f is given,
+// and is removed again if f fails, so no half-written file is left behind
+func convert(opts options, args []string, stdin io.Reader, stdout, stderr io.Writer, ratio bool,
+	output func(name string) (string, error), f func(w io.Writer, r io.Reader) error) error {
+	if len(args) > 1 {
+		return fmt.Errorf("%w: one file at a time", errUsage)
+	}
+	in, inName := stdin, "standard input"
+	out, outName := stdout, opts.out
+	if len(args) == 1 {
+		file, err := os.Open(args[0])
+		if err != nil {
+			return err
+		}
+		defer file.Close()
+		in, inName = file, args[0]
+		if outName == "" {
+			if outName, err = output(args[0]); err != nil {
+				return err
+			}
+		}
+	}
+
+	counted := &countingReader{r: in}
+	if outName == "" || outName == "-" {
+		return f(out, counted)
+	}
+	flags := os.O_WRONLY | os.O_CREATE | os.O_EXCL
+	if opts.force {
+		flags = os.O_WRONLY | os.O_CREATE | os.O_TRUNC
+	}
+	file, err := os.OpenFile(outName, flags, 0o644)
+	if errors.Is(err, os.ErrExist) {
+		return fmt.Errorf("%s already exists; use -f to overwrite it", outName)
+	}
+	if err != nil {
+		return err
+	}
+	err = f(file, counted)
+	if cerr := file.Close(); err == nil {
+		err = cerr
+	}
+	if err != nil {
+		os.Remove(outName)
+		return fmt.Errorf("%s: %w", inName, err)
+	}
+	if info, err := os.Stat(outName); err == nil {
+		fmt.Fprintf(stderr, "%s: %d → %d bytes", inName, counted.n, info.Size())
+		if ratio && counted.n > 0 {
+			fmt.Fprintf(stderr, " (%.1f%%)", 100*float64(info.Size())/float64(counted.n))
+		}
+		fmt.Fprintln(stderr)
+	}
+	return nil
+}
+
+// countingReader counts the bytes read through it
+type countingReader struct {
+	r io.Reader
+	n int64
+}
+
+func (c *countingReader) Read(p []byte) (int, error) {
+	n, err := c.r.Read(p)
+	c.n += int64(n)
+	return n, err
+}
+
+// runCodes prints the code of every byte of a file, shortest first
+func runCodes(args []string, stdin io.Reader, stdout io.Writer) error {
+	in := stdin
+	if len(args) > 1 {
+		return fmt.Errorf("%w: one file at a time", errUsage)
+	}
+	if len(args) == 1 {
+		file, err := os.Open(args[0])
+		if err != nil {
+			return err
+		}
+		defer file.Close()
+		in = file
+	}
+	data, err := io.ReadAll(in)
+	if err != nil {
+		return err
+	}
+	var counts [256]int
+	for _, b := range data {
+		counts[b]++
+	}
+	c, err := newCode(&counts)
+	if err != nil {
+		return err
+	}
+
+	tw := tabwriter.NewWriter(stdout, 0, 0, 2, ' ', tabwriter.AlignRight)
+	fmt.Fprintln(tw, "byte\tcount\tbits\tcode\t")
+	var bits int
+	for _, s := range c.symbols {
+		l := c.length[s]
+		bits += counts[s] * l
+		fmt.Fprintf(tw, "%s\t%d\t%d\t%0*b\t\n", show(s), counts[s], l, l, c.bits[s])
+	}
+	tw.Flush()
+	fmt.Fprintf(stdout, "%d bytes in %d distinct values: %d bits of codes, %d bytes\n", len(data), len(c.symbols), bits, (bits+7)/8)
+	return nil
+}
+
+// show returns a byte as a quoted character if it is printable ASCII, and
+// in hex otherwise
+func show(b byte) string {
+	if b < unicode.MaxASCII && unicode.IsPrint(rune(b)) {
+		return fmt.Sprintf("%q", rune(b))
+	}
+	return fmt.Sprintf("0x%02x", b)
+}
+
+// run parses a command line and runs the command
+// Keeping os.Exit out of run makes it easy to test
+func run(args []string, stdin io.Reader, stdout, stderr io.Writer) error {
+	usage := func() error {
+		fmt.Fprintln(stderr, "Usage:")
+		fmt.Fprintln(stderr, "  huff compress [-o file] [-f] [file]    compress file to file.huff")
+		fmt.Fprintln(stderr, "  huff decompress [-o file] [-f] [file]  decompress file.huff to file")
+		fmt.Fprintln(stderr, "  huff codes [file]                      print the code of every byte")
+		fmt.Fprintln(stderr, "Without a file they read standard input and write standard output")
+		return errUsage
+	}
+	if len(args) == 0 || args[0] == "-h" || args[0] == "help" {
+		return usage()
+	}
+
+	fs := flag.NewFlagSet(args[0], flag.ContinueOnError)
+	fs.SetOutput(stderr)
+	var opts options
+	if args[0] != "codes" {
+		fs.StringVar(&opts.out, "o", "", "output file, - for standard output")
+		fs.BoolVar(&opts.force, "f", false, "overwrite the output file if it exists")
+	}
+	if err := fs.Parse(args[1:]); err != nil {
+		return fmt.Errorf("%w: %w", errUsage, err)
+	}
+	switch args[0] {
+	case "compress":
+		return runCompress(opts, fs.Args(), stdin, stdout, stderr)
+	case "decompress":
+		return runDecompress(opts, fs.Args(), stdin, stdout, stderr)
+	case "codes":
+		return runCodes(fs.Args(), stdin, stdout)
+	}
+	usage()
+	return fmt.Errorf("%w: unknown command %q", errUsage, args[0])
+}
+
+func main() {
+	err := run(os.Args[1:], os.Stdin, os.Stdout, os.Stderr)
+	switch {
+	case err == nil, errors.Is(err, flag.ErrHelp):
+	case errors.Is(err, errUsage):
+		// A bare errUsage follows the usage text, which says it all
+		if err != errUsage {
+			fmt.Fprintln(os.Stderr, "huff:", err)
+		}
+		os.Exit(2)
+	default:
+		fmt.Fprintln(os.Stderr, "huff:", err)
+		os.Exit(1)
+	}
+}
@@ -0,0 +1,108 @@
+package main
+
+import (
+	"bytes"
+	"errors"
+	"io"
+	"math/rand"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+)
+
+func TestCompressDecompressFiles(t *testing.T) {
+	dir := t.TempDir()
+	path := filepath.Join(dir, "data.bin")
+	data := make([]byte, 50_000)
+	rand.New(rand.NewSource(1)).Read(data[:10_000]) // random, then zeros
+	if err := os.WriteFile(path, data, 0o644); err != nil {
+		t.Fatal(err)
+	}
+
+	var stderr bytes.Buffer
+	if err := run([]string{"compress", path}, nil, io.Discard, &stderr); err != nil {
+		t.Fatal(err)
+	}
+	if !strings.HasPrefix(stderr.String(), path+": 50000 → ") || !strings.HasSuffix(stderr.String(), "%)\n") {
+		t.Errorf("report = %q", stderr.String())
+	}
+	// The original is still there, so decompressing next to it needs -f
+	err := run([]string{"decompress", path + ext}, nil, io.Discard, io.Discard)
+	if err == nil || !strings.Contains(err.Error(), "already exists") {
+		t.Fatalf("decompress over the original = %v", err)
+	}
+	os.Remove(path)
+	if err := run([]string{"decompress", path + ext}, nil, io.Discard, io.Discard); err != nil {
+		t.Fatal(err)
+	}
+	if got, _ := os.ReadFile(path); !bytes.Equal(got, data) {
+		t.Error("decompressed file differs from the original")
+	}
+	if err := run([]string{"compress", "-f", path}, nil, io.Discard, io.Discard); err != nil {
+		t.Errorf("compress -f = %v", err)
+	}
+}
+
+func TestPipeline(t *testing.T) {
+	text := "she sells sea shells by the sea shore"
+	var packed, unpacked bytes.Buffer
+	if err := run([]string{"compress"}, strings.NewReader(text), &packed, io.Discard); err != nil {
+		t.Fatal(err)
+	}
+	if err := run([]string{"decompress", "-o", "-"}, &packed, &unpacked, io.Discard); err != nil {
+		t.Fatal(err)
+	}
+	if unpacked.String() != text {
+		t.Errorf("pipeline gave %q", unpacked.String())
+	}
+}
+
+func TestCodes(t *testing.T) {
+	var out bytes.Buffer
+	if err := run([]string{"codes"}, strings.NewReader("abracadabra\n"), &out, io.Discard); err != nil {
+		t.Fatal(err)
+	}
+	want := `  byte  count  bits  code
+   'a'      5     1     0
+   'b'      2     3   100
+   'd'      1     3   101
+   'r'      2     3   110
+  0x0a      1     4  1110
+   'c'      1     4  1111
+12 bytes in 6 distinct values: 28 bits of codes, 4 bytes
+`
+	if out.String() != want {
+		t.Errorf("codes:\n%s\nwant:\n%s", out.String(), want)
+	}
+}
+
+func TestErrors(t *testing.T) {
+	dir := t.TempDir()
+	notHuff := filepath.Join(dir, "notes.txt")
+	os.WriteFile(notHuff, []byte("plain text"), 0o644)
+	os.WriteFile(notHuff+ext, []byte("plain text"), 0o644)
+
+	for _, tt := range []struct {
+		args  []string
+		usage bool
+		want  string
+	}{
+		{nil, true, "usage error"},
+		{[]string{"zip"}, true, `unknown command "zip"`},
+		{[]string{"compress", "a", "b"}, true, "one file at a time"},
+		{[]string{"decompress", notHuff}, true, "doesn't end in .huff"},
+		{[]string{"compress", "-level", "9"}, true, "flag provided but not defined"},
+		{[]string{"compress", filepath.Join(dir, "missing")}, false, "no such file"},
+		{[]string{"decompress", "-o", filepath.Join(dir, "out"), notHuff + ext}, false, "not a huff file"},
+	} {
+		err := run(tt.args, nil, io.Discard, io.Discard)
+		if err == nil || errors.Is(err, errUsage) != tt.usage || !strings.Contains(err.Error(), tt.want) {
+			t.Errorf("run(%q) = %v, want %q", tt.args, err, tt.want)
+		}
+	}
+	// A failed decompression leaves no output behind
+	if _, err := os.Stat(filepath.Join(dir, "out")); !errors.Is(err, os.ErrNotExist) {
+		t.Errorf("failed output was left: %v", err)
+	}
+}
@@ -0,0 +1,150 @@
+package main
+
+import (
+	"bufio"
+	"errors"
+	"fmt"
+	"io"
+	"os"
+	"path/filepath"
+	"strconv"
+	"strings"
+)
+
+// errNoAOF is returned by Rewrite on a store without an append-only file
+var errNoAOF = errors.New("no append-only file")
+
+// appendLog is an append-only file (AOF) of commands, one per line, in the
+// syntax clients use with every argument quoted:
+//
+//	"SET" "greeting" "hello" "PXAT" "1767225600000"
+//	"DEL" "greeting"
+//
+// Replaying the lines in order rebuilds the data
+type appendLog struct {
+	path string
+	f    *os.File
+	w    *bufio.Writer
+}
+
+// openLog replays the file at path through apply, creating it if needed,
+// and opens it for appending
+// A crash can leave half a line at the end; it is cut off, as the write it
+// belonged to never finished. A bad line anywhere else is an error
+func openLog(path string, apply func(args []string) error) (*appendLog, error) {
+	f, err := os.OpenFile(path, os.O_RDWR|os.O_CREATE, 0o644)
+	if err != nil {
+		return nil, err
+	}
+	good, err := replay(f, apply)
+	if errors.Is(err, io.ErrUnexpectedEOF) {
+		err = f.Truncate(good)
+	}
+	if err == nil {
+		_, err = f.Seek(good, io.SeekStart)
+	}
+	if err != nil {
+		f.Close()
+		return nil, fmt.Errorf("%s: %w", path, err)
+	}
+	return &appendLog{path: path, f: f, w: bufio.NewWriter(f)}, nil
+}
+
+// replay applies every complete line of r and returns the length of those
+// lines, with io.ErrUnexpectedEOF if an incomplete line follows them
+func replay(r io.Reader, apply func(args []string) error) (int64, error) {
+	br := bufio.NewReader(r)
+	var good int64
+	for lineNo := 1; ; lineNo++ {
+		line, err := br.ReadString('\n')
+		if err == io.EOF {
+			if line != "" {
+				return good, io.ErrUnexpectedEOF
+			}
+			return good, nil
+		}
+		if err != nil {
+			return good, err
+		}
+		args, err := splitArgs(strings.TrimSuffix(line, "\n"))
+		if err == nil && len(args) > 0 {
+			err = apply(args)
+		}
+		if err != nil {
+			return good, fmt.Errorf("line %d: %w", lineNo, err)
+		}
+		good += int64(len(line))
+	}
+}
+
+// formatCommand quotes every argument, so keys and values can hold spaces,
+// quotes and newlines and still fit on one line
+func formatCommand(args []string) string {
+	quoted := make([]string, len(args))
+	for i, arg := range args {
+		quoted[i] = strconv.Quote(arg)
+	}
+	return strings.Join(quoted, " ") + "\n"
+}
+
+// append writes a command and hands it to the operating system
+// The data reaches the disk on the next sync, so a crashed process loses
+// nothing, while a crashed machine can lose the writes since the last sync
+func (l *appendLog) append(args []string) error {
+	if _, err := l.w.WriteString(formatCommand(args)); err != nil {
+		return err
+	}
+	return l.w.Flush()
+}
+
+// sync flushes the file to disk
+func (l *appendLog) sync() error {
+	return l.f.Sync()
+}
+
+// rewrite replaces the file with commands
+// The new log is written next to the old one and renamed over it, so a
+// crash halfway leaves the old log whole rather than a half-written new one
+func (l *appendLog) rewrite(commands [][]string) error {
+	tmp, err := os.CreateTemp(filepath.Dir(l.path), ".aof-rewrite-*")
+	if err != nil {
+		return err
+	}
+	defer os.Remove(tmp.Name()) // fails harmlessly once renamed
+
+	w := bufio.NewWriter(tmp)
+	for _, args := range commands {
+		w.WriteString(formatCommand(args))
+	}
+	err = w.Flush()
+	if err == nil {
+		err = tmp.Sync()
+	}
+	if closeErr := tmp.Close(); err == nil {
+		err = closeErr
+	}
+	if err == nil {
+		err = os.Rename(tmp.Name(), l.path)
+	}
+	if err != nil {
+		return err
+	}
+
+	// Switch the appends to the new file
+	f, err := os.OpenFile(l.path, os.O_WRONLY|os.O_APPEND, 0o644)
+	if err != nil {
+		return err
+	}
+	l.f.Close()
+	l.f, l.w = f, bufio.NewWriter(f)
+	return nil
+}
+
+// close syncs and closes the file
+func (l *appendLog) close() error {
+	err := l.sync()
+	if closeErr := l.f.Close(); err == nil {
+		err = closeErr
+	}
+	return err
+}
@@ -0,0 +1,305 @@
+package main
+
+import (
+	"bufio"
+	"errors"
+	"fmt"
+	"io"
+	"math"
+	"path"
+	"slices"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// errSyntax is returned for a command that can't be run as written
+var errSyntax = errors.New("syntax error")
+
+// command is a command clients can send
+// minArgs and maxArgs don't count the name; maxArgs -1 means any number
+type command struct {
+	usage            string
+	minArgs, maxArgs int
+	run              func(s *Store, args []string) (string, error)
+}
+
+// commands are the supported commands, a small subset of Redis's with the
+// same names and replies
+var commands = map[string]command{
+	"PING":      {"PING [message]", 0, 1, cmdPing},
+	"SET":       {"SET key value [EX seconds|PX milliseconds|PXAT unix-time-milliseconds]", 2, 4, cmdSet},
+	"GET":       {"GET key", 1, 1, cmdGet},
+	"DEL":       {"DEL key [key ...]", 1, -1, cmdDel},
+	"EXISTS":    {"EXISTS key [key ...]", 1, -1, cmdExists},
+	"EXPIRE":    {"EXPIRE key seconds", 2, 2, cmdExpire},
+	"PEXPIREAT": {"PEXPIREAT key unix-time-milliseconds", 2, 2, cmdPExpireAt},
+	"TTL":       {"TTL key", 1, 1, cmdTTL},
+	"KEYS":      {"KEYS [pattern]", 0, 1, cmdKeys},
+	"DBSIZE":    {"DBSIZE", 0, 0, cmdDBSize},
+	"INFO":      {"INFO", 0, 0, cmdInfo},
+	"REWRITEAOF": {"REWRITEAOF", 0, 0, func(s *Store, args []string) (string, error) {
+		return "OK", s.Rewrite()
+	}},
+	"DEBUG": {"DEBUG SLEEP seconds", 2, 2, cmdDebug},
+}
+
+// execute runs one command and returns the reply
+func execute(s *Store, args []string) (string, error) {
+	name := strings.ToUpper(args[0])
+	cmd, ok := commands[name]
+	if !ok {
+		return "", fmt.Errorf("unknown command %q, try HELP", args[0])
+	}
+	if n := len(args) - 1; n < cmd.minArgs || cmd.maxArgs >= 0 && n > cmd.maxArgs {
+		return "", fmt.Errorf("%w: usage: %s", errSyntax, cmd.usage)
+	}
+	return cmd.run(s, args[1:])
+}
+
+// help lists the usage of every command
+func help() string {
+	usages := []string{"HELP", "QUIT"}
+	for _, cmd := range commands {
+		usages = append(usages, cmd.usage)
+	}
+	slices.Sort(usages)
+	return strings.Join(usages, "\n")
+}
+
+// splitArgs splits a command line at spaces
+// An argument in double quotes is a Go string literal, so it can hold
+// spaces and escapes like \n and \"
+func splitArgs(line string) ([]string, error) {
+	var args []string
+	for {
+		line = strings.TrimLeft(line, " \t")
+		if line == "" {
+			return args, nil
+		}
+		if line[0] == '"' {
+			quoted, err := strconv.QuotedPrefix(line)
+			if err != nil {
+				return nil, fmt.Errorf("%w: bad quoted argument %s", errSyntax, line)
+			}
+			arg, _ := strconv.Unquote(quoted)
+			args = append(args, arg)
+			line = line[len(quoted):]
+			if line != "" && line[0] != ' ' && line[0] != '\t' {
+				return nil, fmt.Errorf("%w: no space after the quoted argument %s", errSyntax, quoted)
+			}
+			continue
+		}
+		end := strings.IndexAny(line, " \t")
+		if end < 0 {
+			end = len(line)
+		}
+		args = append(args, line[:end])
+		line = line[end:]
+	}
+}
+
+// Replies are formatted the way redis-cli prints them
+
+func integer(n int) string { return fmt.Sprintf("(integer) %d", n) }
+
+func bulk(s string) string { return strconv.Quote(s) }
+
+func array(items []string) string {
+	if len(items) == 0 {
+		return "(empty array)"
+	}
+	lines := make([]string, len(items))
+	for i, item := range items {
+		lines[i] = fmt.Sprintf("%d) %s", i+1, bulk(item))
+	}
+	return strings.Join(lines, "\n")
+}
+
+func boolInt(b bool) string {
+	if b {
+		return integer(1)
+	}
+	return integer(0)
+}
+
+// parseInt parses a whole number argument
+func parseInt(name, arg string) (int64, error) {
+	n, err := strconv.ParseInt(arg, 10, 64)
+	if err != nil {
+		return 0, fmt.Errorf("%w: %s %q is not a whole number", errSyntax, name, arg)
+	}
+	return n, nil
+}
+
+func cmdPing(s *Store, args []string) (string, error) {
+	if len(args) == 1 {
+		return bulk(args[0]), nil
+	}
+	return "PONG", nil
+}
+
+func cmdSet(s *Store, args []string) (string, error) {
+	key, value := args[0], args[1]
+	if len(args) == 2 {
+		return "OK", s.Set(key, value, 0)
+	}
+	if len(args) != 4 {
+		return "", fmt.Errorf("%w: expected EX, PX or PXAT and a number after the value", errSyntax)
+	}
+	n, err := parseInt(args[2], args[3])
+	if err != nil {
+		return "", err
+	}
+	switch option := strings.ToUpper(args[2]); {
+	case option == "PXAT":
+		return "OK", s.SetUntil(key, value, time.UnixMilli(n))
+	case n <= 0:
+		return "", fmt.Errorf("%w: %s must be positive", errSyntax, option)
+	case option == "EX":
+		return "OK", s.Set(key, value, time.Duration(n)*time.Second)
+	case option == "PX":
+		return "OK", s.Set(key, value, time.Duration(n)*time.Millisecond)
+	}
+	return "", fmt.Errorf("%w: unknown option %q", errSyntax, args[2])
+}
+
+func cmdGet(s *Store, args []string) (string, error) {
+	value, ok := s.Get(args[0])
+	if !ok {
+		return "(nil)", nil
+	}
+	return bulk(value), nil
+}
+
+func cmdDel(s *Store, args []string) (string, error) {
+	deleted := 0
+	for _, key := range args {
+		ok, err := s.Delete(key)
+		if err != nil {
+			return "", err
+		}
+		if ok {
+			deleted++
+		}
+	}
+	return integer(deleted), nil
+}
+
+func cmdExists(s *Store, args []string) (string, error) {
+	found := 0
+	for _, key := range args {
+		if _, ok := s.Get(key); ok {
+			found++
+		}
+	}
+	return integer(found), nil
+}
+
+func cmdExpire(s *Store, args []string) (string, error) {
+	n, err := parseInt("seconds", args[1])
+	if err != nil {
+		return "", err
+	}
+	ok, err := s.ExpireAt(args[0], s.clock.Now().Add(time.Duration(n)*time.Second))
+	return boolInt(ok), err
+}
+
+func cmdPExpireAt(s *Store, args []string) (string, error) {
+	n, err := parseInt("time", args[1])
+	if err != nil {
+		return "", err
+	}
+	ok, err := s.ExpireAt(args[0], time.UnixMilli(n))
+	return boolInt(ok), err
+}
+
+// cmdTTL replies with the seconds left, rounded, -1 for a key without
+// expiry and -2 for a missing key
+func cmdTTL(s *Store, args []string) (string, error) {
+	ttl, ok := s.TTL(args[0])
+	switch {
+	case !ok:
+		return integer(-2), nil
+	case ttl == NoExpiry:
+		return integer(-1), nil
+	}
+	return integer(int(math.Round(ttl.Seconds()))), nil
+}
+
+func cmdKeys(s *Store, args []string) (string, error) {
+	pattern := "*"
+	if len(args) == 1 {
+		pattern = args[0]
+	}
+	if _, err := path.Match(pattern, ""); err != nil {
+		return "", fmt.Errorf("%w: bad pattern %q", errSyntax, pattern)
+	}
+	var matched []string
+	for _, key := range s.Keys() {
+		if ok, _ := path.Match(pattern, key); ok {
+			matched = append(matched, key)
+		}
+	}
+	return array(matched), nil
+}
+
+func cmdDBSize(s *Store, args []string) (string, error) {
+	return integer(s.Stats().Keys), nil
+}
+
+func cmdInfo(s *Store, args []string) (string, error) {
+	st := s.Stats()
+	return fmt.Sprintf("keys:%d\nexpired_keys:%d\nevicted_keys:%d\nmaxkeys:%d", st.Keys, st.Expired, st.Evicted, s.maxKeys), nil
+}
+
+// cmdDebug supports DEBUG SLEEP, which waits on the store's clock, so a
+// script can let keys expire
+func cmdDebug(s *Store, args []string) (string, error) {
+	if !strings.EqualFold(args[0], "SLEEP") {
+		return "", fmt.Errorf("%w: DEBUG only supports SLEEP", errSyntax)
+	}
+	seconds, err := strconv.ParseFloat(args[1], 64)
+	if err != nil || seconds < 0 {
+		return "", fmt.Errorf("%w: seconds %q is not a number of seconds", errSyntax, args[1])
+	}
+	<-s.clock.After(time.Duration(seconds * float64(time.Second)))
+	return "OK", nil
+}
+
+// session runs the commands read from r, one per line, and writes the
+// replies to w until QUIT or the end of the input
+// Blank lines and lines starting with # are skipped; with echo, each
+// command is written before its reply, so a script's output reads like a
+// transcript
+func session(r io.Reader, w io.Writer, s *Store, echo bool) error {
+	lines := bufio.NewScanner(r)
+	for lines.Scan() {
+		line := strings.TrimSpace(lines.Text())
+		if line == "" || strings.HasPrefix(line, "#") {
+			continue
+		}
+		if echo {
+			fmt.Fprintf(w, "> %s\n", line)
+		}
+		args, err := splitArgs(line)
+		var reply string
+		switch {
+		case err != nil:
+		case strings.EqualFold(args[0], "QUIT"):
+			_, err := fmt.Fprintln(w, "OK")
+			return err
+		case strings.EqualFold(args[0], "HELP"):
+			reply = help()
+		default:
+			reply, err = execute(s, args)
+		}
+		if err != nil {
+			reply = "(error) ERR " + err.Error()
+		}
+		if _, err := fmt.Fprintln(w, reply); err != nil {
+			return err
+		}
+	}
+	return lines.Err()
+}
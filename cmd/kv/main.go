@@ -0,0 +1,168 @@
+// Command kv is a small Redis-like key-value store, a systems exercise
+// built from the repository's own pieces:
+//   - a map behind a mutex holds the data, with an expiry time per key
+//   - expired keys are deleted lazily when read and by a sweeper goroutine
+//     that wakes up every -sweep interval
+//   - with -max-keys, a datastructures.LRU picks the key to evict when the
+//     store is full
+//   - with -aof, every write is appended to a file that is replayed on
+//     startup, so the data survives a restart; REWRITEAOF compacts it
+//
+// Usage:
+//
+//	go run ./cmd/kv [-addr localhost:6380] [-aof file] [-max-keys n]    serve over TCP
+//	go run ./cmd/kv [flags] script...                                   run command files, - for stdin
+//
+// The server speaks a line protocol: one command per line, answered the way
+// redis-cli prints replies, so nc or telnet is enough as a client:
+//
+//	$ nc localhost 6380
+//	SET greeting "hello, world" EX 60
+//	OK
+//	GET greeting
+//	"hello, world"
+package main
+
+import (
+	"context"
+	"errors"
+	"flag"
+	"fmt"
+	"io"
+	"log"
+	"net"
+	"os"
+	"os/signal"
+	"sync"
+	"syscall"
+	"time"
+)
+
+// errUsage marks errors caused by bad arguments, which exit with code 2
+var errUsage = errors.New("usage error")
+
+// serve answers the connections accepted by ln until ctx is done, then
+// closes them and waits for their sessions to end
+func serve(ctx context.Context, ln net.Listener, s *Store) error {
+	var (
+		wg    sync.WaitGroup
+		mu    sync.Mutex
+		conns = make(map[net.Conn]bool)
+	)
+	stop := context.AfterFunc(ctx, func() {
+		ln.Close()
+		mu.Lock()
+		defer mu.Unlock()
+		for conn := range conns {
+			conn.Close()
+		}
+	})
+	defer stop()
+
+	for {
+		conn, err := ln.Accept()
+		if err != nil {
+			wg.Wait()
+			if ctx.Err() != nil {
+				return nil
+			}
+			return err
+		}
+		mu.Lock()
+		conns[conn] = true
+		mu.Unlock()
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			defer func() {
+				mu.Lock()
+				delete(conns, conn)
+				mu.Unlock()
+				conn.Close()
+			}()
+			if err := session(conn, conn, s, false); err != nil && ctx.Err() == nil {
+				log.Printf("%s: %v", conn.RemoteAddr(), err)
+			}
+		}()
+	}
+}
+
+// runScripts runs the commands of each file, or of stdin for "-"
+func runScripts(paths []string, stdin io.Reader, stdout io.Writer, s *Store) error {
+	for _, path := range paths {
+		if path == "-" {
+			if err := session(stdin, stdout, s, true); err != nil {
+				return err
+			}
+			continue
+		}
+		f, err := os.Open(path)
+		if err != nil {
+			return err
+		}
+		err = session(f, stdout, s, true)
+		f.Close()
+		if err != nil {
+			return fmt.Errorf("%s: %w", path, err)
+		}
+	}
+	return nil
+}
+
+// run parses the flags, opens the store and serves it or runs the scripts
+// Keeping os.Exit out of run makes it easy to test
+func run(ctx context.Context, args []string, stdin io.Reader, stdout, stderr io.Writer) (err error) {
+	fs := flag.NewFlagSet("kv", flag.ContinueOnError)
+	fs.SetOutput(stderr)
+	fs.Usage = func() {
+		fmt.Fprintln(stderr, "Usage: kv [-addr host:port] [-aof file] [-max-keys n] [-sweep interval] [script ...]")
+		fs.PrintDefaults()
+	}
+	addr := fs.String("addr", "localhost:6380", "address to listen on")
+	var opts Options
+	fs.StringVar(&opts.AOF, "aof", "", "append-only file to replay and log writes to (default: memory only)")
+	fs.IntVar(&opts.MaxKeys, "max-keys", 0, "evict the least recently used key beyond this many (0: no limit)")
+	sweep := fs.Duration("sweep", time.Second, "how often to delete expired keys and sync the append-only file")
+	if err := fs.Parse(args); err != nil {
+		return fmt.Errorf("%w: %w", errUsage, err)
+	}
+	if opts.MaxKeys < 0 || *sweep <= 0 {
+		return fmt.Errorf("%w: -max-keys must not be negative and -sweep must be positive", errUsage)
+	}
+
+	s, err := Open(opts)
+	if err != nil {
+		return err
+	}
+	defer func() {
+		if closeErr := s.Close(); err == nil {
+			err = closeErr
+		}
+	}()
+	s.StartSweeper(*sweep)
+
+	if fs.NArg() > 0 {
+		return runScripts(fs.Args(), stdin, stdout, s)
+	}
+	ln, err := net.Listen("tcp", *addr)
+	if err != nil {
+		return err
+	}
+	log.Printf("kv listening on %s", ln.Addr())
+	return serve(ctx, ln, s)
+}
+
+func main() {
+	ctx, stop := signal.NotifyContext(context.Background(), os.Interrupt, syscall.SIGTERM)
+	err := run(ctx, os.Args[1:], os.Stdin, os.Stdout, os.Stderr)
+	stop()
+	switch {
+	case err == nil, errors.Is(err, flag.ErrHelp):
+	case errors.Is(err, errUsage):
+		fmt.Fprintln(os.Stderr, "kv:", err)
+		os.Exit(2)
+	default:
+		fmt.Fprintln(os.Stderr, "kv:", err)
+		os.Exit(1)
+	}
+}
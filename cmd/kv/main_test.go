@@ -0,0 +1,176 @@
+package main
+
+import (
+	"bufio"
+	"bytes"
+	"context"
+	"errors"
+	"fmt"
+	"io"
+	"net"
+	"os"
+	"slices"
+	"strings"
+	"testing"
+	"time"
+)
+
+func TestSplitArgs(t *testing.T) {
+	tests := []struct {
+		line string
+		want []string
+	}{
+		{"GET key", []string{"GET", "key"}},
+		{"  SET\tk   v  ", []string{"SET", "k", "v"}},
+		{`SET k "two words"`, []string{"SET", "k", "two words"}},
+		{`SET "" "a\"b\n"`, []string{"SET", "", "a\"b\n"}},
+		{`SET k a"b`, []string{"SET", "k", `a"b`}},
+		{"", nil},
+	}
+	for _, tt := range tests {
+		got, err := splitArgs(tt.line)
+		if err != nil || !slices.Equal(got, tt.want) {
+			t.Errorf("splitArgs(%q) = %q, %v, want %q", tt.line, got, err, tt.want)
+		}
+	}
+	for _, line := range []string{`SET k "open`, `SET k "a"b`, `SET k "\q"`} {
+		if _, err := splitArgs(line); !errors.Is(err, errSyntax) {
+			t.Errorf("splitArgs(%q) error = %v, want errSyntax", line, err)
+		}
+	}
+}
+
+// The sample script gives the same transcript every time: DEBUG SLEEP waits
+// on the fake clock
+func TestSessionScript(t *testing.T) {
+	script, err := os.ReadFile("testdata/session.txt")
+	if err != nil {
+		t.Fatal(err)
+	}
+	s, _ := openStore(t, Options{})
+	var out bytes.Buffer
+	if err := session(bytes.NewReader(script), &out, s, true); err != nil {
+		t.Fatal(err)
+	}
+	for _, want := range []string{
+		"> GET greeting\n\"hello, world\\n\"\n",
+		"> TTL session:42\n(integer) 60\n",
+		"> GET otp\n(nil)\n",
+		"> EXISTS language greeting session:42\n(integer) 2\n",
+		"> KEYS session:*\n1) \"session:42\"\n",
+		"> INFO\nkeys:1\nexpired_keys:1\n",
+		"> FLUSHALL\n(error) ERR unknown command",
+	} {
+		if !strings.Contains(out.String(), want) {
+			t.Errorf("transcript lacks %q:\n%s", want, out.String())
+		}
+	}
+}
+
+func TestCommands(t *testing.T) {
+	s, clock := openStore(t, Options{})
+	tests := []struct{ command, want string }{
+		{"ping", "PONG"},
+		{"PING hi", `"hi"`},
+		{"SET k v PX 1500", "OK"},
+		{"TTL k", "(integer) 2"},
+		{"SET k v EX 0", "(error) ERR syntax error: EX must be positive"},
+		{"SET k v EX soon", `(error) ERR syntax error: EX "soon" is not a whole number`},
+		{"SET k v KEEPTTL 1", `(error) ERR syntax error: unknown option "KEEPTTL"`},
+		{"SET k v EX", "(error) ERR syntax error: expected EX, PX or PXAT and a number after the value"},
+		{fmt.Sprintf("SET old v PXAT %d", clock.Now().UnixMilli()), "OK"},
+		{"EXISTS old", "(integer) 0"},
+		{"EXPIRE nope 10", "(integer) 0"},
+		{fmt.Sprintf("PEXPIREAT k %d", clock.Now().Add(time.Minute).UnixMilli()), "(integer) 1"},
+		{"TTL k", "(integer) 60"},
+		{"TTL nope", "(integer) -2"},
+		{"KEYS [", `(error) ERR syntax error: bad pattern "["`},
+		{"KEYS x*", "(empty array)"},
+		{"DEL k k", "(integer) 1"},
+		{"DBSIZE", "(integer) 0"},
+		{"REWRITEAOF", "(error) ERR no append-only file"},
+		{"DEBUG CRASH 1", "(error) ERR syntax error: DEBUG only supports SLEEP"},
+		{"GET", "(error) ERR syntax error: usage: GET key"},
+		{`GET "`, `(error) ERR syntax error: bad quoted argument "`},
+	}
+	for _, tt := range tests {
+		var out bytes.Buffer
+		if err := session(strings.NewReader(tt.command), &out, s, false); err != nil {
+			t.Fatal(err)
+		}
+		if got := strings.TrimSuffix(out.String(), "\n"); got != tt.want {
+			t.Errorf("%s: got %q, want %q", tt.command, got, tt.want)
+		}
+	}
+}
+
+// Two clients of one server see each other's writes, and cancelling the
+// context closes their connections and stops the server
+func TestServe(t *testing.T) {
+	ln, err := net.Listen("tcp", "127.0.0.1:0")
+	if err != nil {
+		t.Skip("no network:", err)
+	}
+	s, _ := openStore(t, Options{})
+	ctx, cancel := context.WithCancel(context.Background())
+	served := make(chan error, 1)
+	go func() { served <- serve(ctx, ln, s) }()
+
+	dial := func() (net.Conn, *bufio.Reader) {
+		t.Helper()
+		conn, err := net.Dial("tcp", ln.Addr().String())
+		if err != nil {
+			t.Fatal(err)
+		}
+		t.Cleanup(func() { conn.Close() })
+		return conn, bufio.NewReader(conn)
+	}
+	send := func(conn net.Conn, r *bufio.Reader, command string) string {
+		t.Helper()
+		fmt.Fprintln(conn, command)
+		reply, err := r.ReadString('\n')
+		if err != nil {
+			t.Fatal(err)
+		}
+		return strings.TrimSuffix(reply, "\n")
+	}
+
+	c1, r1 := dial()
+	c2, r2 := dial()
+	if got := send(c1, r1, `SET shared "from one"`); got != "OK" {
+		t.Errorf("SET reply %q", got)
+	}
+	if got := send(c2, r2, "GET shared"); got != `"from one"` {
+		t.Errorf("the second client read %s", got)
+	}
+	if got := send(c2, r2, "QUIT"); got != "OK" {
+		t.Errorf("QUIT reply %q", got)
+	}
+	if _, err := r2.ReadByte(); err != io.EOF {
+		t.Errorf("after QUIT the connection gave %v, want EOF", err)
+	}
+
+	cancel()
+	select {
+	case err := <-served:
+		if err != nil {
+			t.Errorf("serve returned %v", err)
+		}
+	case <-time.After(5 * time.Second):
+		t.Fatal("serve did not return after the context was cancelled")
+	}
+	if _, err := r1.ReadByte(); err == nil {
+		t.Error("the first connection is still open after shutdown")
+	}
+}
+
+func TestRunErrors(t *testing.T) {
+	for _, args := range [][]string{{"-max-keys", "-1"}, {"-sweep", "0s"}, {"-unknown"}} {
+		if err := run(context.Background(), args, nil, io.Discard, io.Discard); !errors.Is(err, errUsage) {
+			t.Errorf("run(%q) error = %v, want a usage error", args, err)
+		}
+	}
+	if err := run(context.Background(), []string{"testdata/missing.txt"}, nil, io.Discard, io.Discard); !errors.Is(err, os.ErrNotExist) {
+		t.Errorf("run on a missing script error = %v, want os.ErrNotExist", err)
+	}
+}
@@ -0,0 +1,374 @@
+package main
+
+import (
+	"fmt"
+	"slices"
+	"sync"
+	"time"
+
+	"github.com/NutProhmpiriya/go-basic/02-data-structures/datastructures"
+	"github.com/NutProhmpiriya/go-basic/04-design-patterns/resilience"
+)
+
+// NoExpiry is the TTL of a key that never expires
+const NoExpiry time.Duration = -1
+
+// entry is a value and when it expires; the zero time means never
+type entry struct {
+	value   string
+	expires time.Time
+}
+
+// expired reports whether the entry is past its expiry time at now
+func (e entry) expired(now time.Time) bool {
+	return !e.expires.IsZero() && !now.Before(e.expires)
+}
+
+// Options configure a Store
+type Options struct {
+	// MaxKeys turns on LRU eviction: adding a key to a full store drops the
+	// least recently used one. 0 means no limit
+	MaxKeys int
+	// AOF is the append-only file to replay on Open and log writes to; ""
+	// keeps the data in memory only
+	AOF string
+	// Clock tells the time for expiry; nil means the real clock
+	Clock resilience.Clock
+}
+
+// Stats count what the store did besides what it was told
+type Stats struct {
+	Keys    int
+	Expired int // keys removed because their TTL ran out
+	Evicted int // keys dropped by the LRU limit
+}
+
+// Store is a key-value store with per-key expiry, safe for concurrent use
+//
+// Expired keys are removed in two ways, like Redis does:
+//   - lazily: a read of an expired key deletes it and reports it missing
+//   - actively: the sweeper goroutine started by StartSweeper deletes the
+//     expired keys nobody reads, which would otherwise use memory forever
+//
+// Every write is appended to the AOF, if there is one, as the command that
+// redoes it, with expiry times made absolute so a replay after a restart
+// drops the keys that expired while the store was down
+type Store struct {
+	// mu is a plain Mutex, not an RWMutex: with eviction on, Get moves the
+	// key in the LRU order, so even reads write
+	mu      sync.Mutex
+	clock   resilience.Clock
+	data    map[string]entry
+	lru     *datastructures.LRU[string, struct{}] // nil without MaxKeys
+	aof     *appendLog                            // nil without a file
+	maxKeys int
+	expired int
+	evicted int
+
+	stop chan struct{} // closed by Close to stop the sweeper
+	done chan struct{} // closed by the sweeper when it has stopped
+}
+
+// Open creates a store, replaying the AOF if it exists
+func Open(opts Options) (*Store, error) {
+	if opts.MaxKeys < 0 {
+		return nil, fmt.Errorf("MaxKeys is %d, need 0 or more", opts.MaxKeys)
+	}
+	s := &Store{clock: opts.Clock, data: make(map[string]entry), maxKeys: opts.MaxKeys}
+	if s.clock == nil {
+		s.clock = resilience.RealClock{}
+	}
+	if opts.MaxKeys > 0 {
+		s.lru = datastructures.NewLRU[string, struct{}](opts.MaxKeys)
+	}
+	if opts.AOF != "" {
+		// Replaying goes through the same commands as clients, with s.aof
+		// still nil so nothing is logged twice
+		aof, err := openLog(opts.AOF, func(args []string) error {
+			_, err := execute(s, args)
+			return err
+		})
+		if err != nil {
+			return nil, err
+		}
+		s.aof = aof
+	}
+	return s, nil
+}
+
+// log appends a command to the AOF; the caller must hold s.mu, so the file
+// has the writes in the order they were applied
+func (s *Store) log(args ...string) error {
+	if s.aof == nil {
+		return nil
+	}
+	return s.aof.append(args)
+}
+
+// lookup returns the live entry for key, deleting it if it has expired
+// The caller must hold s.mu
+func (s *Store) lookup(key string) (entry, bool) {
+	e, ok := s.data[key]
+	if !ok {
+		return entry{}, false
+	}
+	if e.expired(s.clock.Now()) {
+		s.remove(key)
+		s.expired++
+		return entry{}, false
+	}
+	return e, true
+}
+
+// remove deletes key from the map and the LRU order
+// The caller must hold s.mu
+func (s *Store) remove(key string) {
+	delete(s.data, key)
+	if s.lru != nil {
+		s.lru.Remove(key)
+	}
+}
+
+// Get returns the value of key
+func (s *Store) Get(key string) (string, bool) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	e, ok := s.lookup(key)
+	if ok && s.lru != nil {
+		s.lru.Get(key)
+	}
+	return e.value, ok
+}
+
+// Set stores value under key, expiring after ttl; a ttl of 0 keeps it until
+// it is deleted
+func (s *Store) Set(key, value string, ttl time.Duration) error {
+	var expires time.Time
+	if ttl != 0 {
+		expires = s.clock.Now().Add(ttl)
+	}
+	return s.SetUntil(key, value, expires)
+}
+
+// SetUntil stores value under key until the time expires; the zero time
+// keeps it until it is deleted, and a time in the past deletes it
+func (s *Store) SetUntil(key, value string, expires time.Time) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	if !expires.IsZero() && !expires.After(s.clock.Now()) {
+		return s.deleteLocked(key)
+	}
+
+	// A new key in a full store evicts the least recently used one
+	// The eviction is logged before the SET, as a DEL: Gets are not logged,
+	// so a replay could pick another key to evict
+	var victim string
+	evicting := false
+	if _, exists := s.data[key]; !exists && s.lru != nil && s.lru.Len() == s.maxKeys {
+		victim, _, evicting = s.lru.Oldest()
+		if err := s.log("DEL", victim); err != nil {
+			return err
+		}
+	}
+	args := []string{"SET", key, value}
+	if !expires.IsZero() {
+		args = append(args, "PXAT", fmt.Sprint(expires.UnixMilli()))
+	}
+	if err := s.log(args...); err != nil {
+		return err
+	}
+
+	if evicting {
+		s.remove(victim)
+		s.evicted++
+	}
+	s.data[key] = entry{value, expires}
+	if s.lru != nil {
+		s.lru.Put(key, struct{}{})
+	}
+	return nil
+}
+
+// deleteLocked removes key and logs it; the caller must hold s.mu
+func (s *Store) deleteLocked(key string) error {
+	if _, ok := s.data[key]; !ok {
+		return nil
+	}
+	if err := s.log("DEL", key); err != nil {
+		return err
+	}
+	s.remove(key)
+	return nil
+}
+
+// Delete removes key and reports whether it was there
+func (s *Store) Delete(key string) (bool, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	if _, ok := s.lookup(key); !ok {
+		return false, nil
+	}
+	return true, s.deleteLocked(key)
+}
+
+// ExpireAt sets when key expires and reports whether the key exists
+// A time in the past deletes the key at once
+func (s *Store) ExpireAt(key string, expires time.Time) (bool, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	e, ok := s.lookup(key)
+	if !ok {
+		return false, nil
+	}
+	if !expires.After(s.clock.Now()) {
+		return true, s.deleteLocked(key)
+	}
+	if err := s.log("PEXPIREAT", key, fmt.Sprint(expires.UnixMilli())); err != nil {
+		return false, err
+	}
+	e.expires = expires
+	s.data[key] = e
+	return true, nil
+}
+
+// TTL returns how long key has left, or NoExpiry, and whether it exists
+func (s *Store) TTL(key string) (time.Duration, bool) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	e, ok := s.lookup(key)
+	switch {
+	case !ok:
+		return 0, false
+	case e.expires.IsZero():
+		return NoExpiry, true
+	}
+	return e.expires.Sub(s.clock.Now()), true
+}
+
+// Keys returns the live keys, sorted
+func (s *Store) Keys() []string {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	now := s.clock.Now()
+	keys := make([]string, 0, len(s.data))
+	for key, e := range s.data {
+		if !e.expired(now) {
+			keys = append(keys, key)
+		}
+	}
+	slices.Sort(keys)
+	return keys
+}
+
+// Stats returns the number of live keys and the expiry and eviction counts
+func (s *Store) Stats() Stats {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	now := s.clock.Now()
+	live := 0
+	for _, e := range s.data {
+		if !e.expired(now) {
+			live++
+		}
+	}
+	return Stats{Keys: live, Expired: s.expired, Evicted: s.evicted}
+}
+
+// Sweep deletes every expired key and returns how many there were
+// It scans the whole map while holding the lock, which is fine for a small
+// store; Redis instead samples 20 keys with a TTL at a time and keeps going
+// while more than a quarter of them had expired
+func (s *Store) Sweep() int {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	now := s.clock.Now()
+	n := 0
+	for key, e := range s.data {
+		if e.expired(now) {
+			s.remove(key)
+			n++
+		}
+	}
+	s.expired += n
+	return n
+}
+
+// StartSweeper starts a goroutine that calls Sweep and flushes the AOF to
+// disk every interval, until Close
+func (s *Store) StartSweeper(interval time.Duration) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	if s.stop != nil {
+		return
+	}
+	stop, done := make(chan struct{}), make(chan struct{})
+	s.stop, s.done = stop, done
+	go func() {
+		defer close(done)
+		ticker := time.NewTicker(interval)
+		defer ticker.Stop()
+		for {
+			select {
+			case <-stop:
+				return
+			case <-ticker.C:
+				s.Sweep()
+				s.mu.Lock()
+				if s.aof != nil {
+					// Like Redis's "appendfsync everysec": at most one
+					// interval of writes is lost if the machine crashes
+					s.aof.sync()
+				}
+				s.mu.Unlock()
+			}
+		}
+	}()
+}
+
+// Rewrite replaces the AOF with the shortest log that rebuilds the current
+// data: one SET per live key, where the old log had every write ever made
+func (s *Store) Rewrite() error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	if s.aof == nil {
+		return errNoAOF
+	}
+	now := s.clock.Now()
+	keys := make([]string, 0, len(s.data))
+	for key, e := range s.data {
+		if !e.expired(now) {
+			keys = append(keys, key)
+		}
+	}
+	slices.Sort(keys)
+	commands := make([][]string, len(keys))
+	for i, key := range keys {
+		e := s.data[key]
+		commands[i] = []string{"SET", key, e.value}
+		if !e.expires.IsZero() {
+			commands[i] = append(commands[i], "PXAT", fmt.Sprint(e.expires.UnixMilli()))
+		}
+	}
+	return s.aof.rewrite(commands)
+}
+
+// Close stops the sweeper and closes the AOF
+func (s *Store) Close() error {
+	s.mu.Lock()
+	stop, done := s.stop, s.done
+	s.stop = nil
+	s.mu.Unlock()
+	if stop != nil {
+		close(stop)
+		<-done
+	}
+
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	if s.aof == nil {
+		return nil
+	}
+	err := s.aof.close()
+	s.aof = nil
+	return err
+}
@@ -0,0 +1,249 @@
+package main
+
+import (
+	"maps"
+	"os"
+	"path/filepath"
+	"slices"
+	"strings"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/NutProhmpiriya/go-basic/04-design-patterns/resilience"
+)
+
+// start is the fake clock's starting time, 2026-01-01 UTC
+var start = time.Date(2026, 1, 1, 0, 0, 0, 0, time.UTC)
+
+// openStore opens a store on a fake clock and closes it at the end of the
+// test
+func openStore(t *testing.T, opts Options) (*Store, *resilience.FakeClock) {
+	t.Helper()
+	clock := resilience.NewFakeClock(start)
+	opts.Clock = clock
+	s, err := Open(opts)
+	if err != nil {
+		t.Fatal(err)
+	}
+	t.Cleanup(func() { s.Close() })
+	return s, clock
+}
+
+// advance moves a fake clock forward
+func advance(clock *resilience.FakeClock, d time.Duration) {
+	<-clock.After(d)
+}
+
+func TestExpiry(t *testing.T) {
+	s, clock := openStore(t, Options{})
+	s.Set("forever", "1", 0)
+	s.Set("short", "2", 10*time.Second)
+	s.Set("long", "3", time.Minute)
+
+	if ttl, ok := s.TTL("short"); !ok || ttl != 10*time.Second {
+		t.Errorf("TTL(short) = %v, %v, want 10s", ttl, ok)
+	}
+	if ttl, ok := s.TTL("forever"); !ok || ttl != NoExpiry {
+		t.Errorf("TTL(forever) = %v, %v, want NoExpiry", ttl, ok)
+	}
+
+	advance(clock, 10*time.Second)
+	// Reading an expired key deletes it
+	if _, ok := s.Get("short"); ok {
+		t.Error("short is still there after its TTL")
+	}
+	if got := s.Keys(); !slices.Equal(got, []string{"forever", "long"}) {
+		t.Errorf("Keys() = %v, want [forever long]", got)
+	}
+
+	// Nobody reads long, so only the sweeper deletes it
+	advance(clock, time.Minute)
+	if n := s.Sweep(); n != 1 {
+		t.Errorf("Sweep() = %d, want 1", n)
+	}
+	if got := s.Stats(); got != (Stats{Keys: 1, Expired: 2}) {
+		t.Errorf("Stats() = %+v, want 1 key and 2 expired", got)
+	}
+
+	// ExpireAt in the past deletes the key; on a missing key it does nothing
+	if ok, err := s.ExpireAt("forever", start); !ok || err != nil {
+		t.Errorf("ExpireAt(forever) = %v, %v", ok, err)
+	}
+	if ok, _ := s.ExpireAt("forever", clock.Now().Add(time.Hour)); ok {
+		t.Error("ExpireAt on a deleted key reported it exists")
+	}
+	if _, ok := s.TTL("forever"); ok {
+		t.Error("forever is still there after expiring it")
+	}
+}
+
+func TestEviction(t *testing.T) {
+	s, _ := openStore(t, Options{MaxKeys: 2})
+	s.Set("a", "1", 0)
+	s.Set("b", "2", 0)
+	s.Get("a") // b is now the least recently used
+	s.Set("c", "3", 0)
+	s.Set("a", "4", 0) // updating a key evicts nothing
+	if got := s.Keys(); !slices.Equal(got, []string{"a", "c"}) {
+		t.Errorf("Keys() = %v, want [a c]", got)
+	}
+	if got := s.Stats(); got.Evicted != 1 {
+		t.Errorf("Evicted = %d, want 1", got.Evicted)
+	}
+
+	// A deleted key frees its slot
+	s.Delete("c")
+	s.Set("d", "5", 0)
+	if got := s.Keys(); !slices.Equal(got, []string{"a", "d"}) {
+		t.Errorf("Keys() = %v, want [a d]", got)
+	}
+}
+
+// The AOF rebuilds the same data after a restart, evictions and expiry
+// included
+func TestAOFReplay(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "kv.aof")
+	clock := resilience.NewFakeClock(start)
+	s, err := Open(Options{AOF: path, MaxKeys: 3, Clock: clock})
+	if err != nil {
+		t.Fatal(err)
+	}
+	s.Set("a", "1", 0)
+	s.Set("b", "with spaces\nand a newline", 0)
+	s.Set("c", "3", time.Minute)
+	s.Get("a")
+	s.Set("d", "4", 0) // evicts b, not a
+	s.Set("e", "5", 0) // evicts c
+	s.Delete("d")
+	s.ExpireAt("a", start.Add(time.Hour))
+	s.Set("f", `"quoted"`, 0)
+	want := map[string]string{}
+	for _, key := range s.Keys() {
+		want[key], _ = s.Get(key)
+	}
+	if err := s.Close(); err != nil {
+		t.Fatal(err)
+	}
+
+	reopen := func() *Store {
+		t.Helper()
+		s, err := Open(Options{AOF: path, MaxKeys: 3, Clock: clock})
+		if err != nil {
+			t.Fatal(err)
+		}
+		return s
+	}
+	s = reopen()
+	// In key order, which also sets the LRU order for the eviction below
+	for _, key := range slices.Sorted(maps.Keys(want)) {
+		if got, ok := s.Get(key); !ok || got != want[key] {
+			t.Errorf("after replay Get(%q) = %q, %v, want %q", key, got, ok, want[key])
+		}
+	}
+	if got := s.Keys(); len(got) != len(want) {
+		t.Errorf("after replay Keys() = %q, want %d keys", got, len(want))
+	}
+	if ttl, _ := s.TTL("a"); ttl != time.Hour {
+		t.Errorf("after replay TTL(a) = %v, want 1h", ttl)
+	}
+
+	// Rewriting keeps the data in one SET per key
+	if err := s.Rewrite(); err != nil {
+		t.Fatal(err)
+	}
+	s.Get("a")
+	s.Set("g", "7", 0) // evicts e
+	s.Close()
+	data, err := os.ReadFile(path)
+	if err != nil {
+		t.Fatal(err)
+	}
+	wantLog := `"SET" "a" "1" "PXAT" "1767229200000"
+"SET" "e" "5"
+"SET" "f" "\"quoted\""
+"DEL" "e"
+"SET" "g" "7"
+`
+	if string(data) != wantLog {
+		t.Errorf("rewritten log:\n%s\nwant:\n%s", data, wantLog)
+	}
+
+	// Keys that expire while the store is down are gone after the replay
+	advance(clock, 2*time.Hour)
+	s = reopen()
+	defer s.Close()
+	if got := s.Keys(); !slices.Equal(got, []string{"f", "g"}) {
+		t.Errorf("after expiry Keys() = %v, want [f g]", got)
+	}
+}
+
+// A half-written last line is cut off; a bad line before it is an error
+func TestAOFDamage(t *testing.T) {
+	dir := t.TempDir()
+	torn := filepath.Join(dir, "torn.aof")
+	os.WriteFile(torn, []byte("\"SET\" \"a\" \"1\"\n\"SET\" \"b"), 0o644)
+	s, err := Open(Options{AOF: torn})
+	if err != nil {
+		t.Fatal(err)
+	}
+	s.Set("c", "3", 0)
+	s.Close()
+	if data, _ := os.ReadFile(torn); string(data) != "\"SET\" \"a\" \"1\"\n\"SET\" \"c\" \"3\"\n" {
+		t.Errorf("log after cutting the torn line:\n%s", data)
+	}
+
+	bad := filepath.Join(dir, "bad.aof")
+	os.WriteFile(bad, []byte("\"SET\" \"a\" \"1\"\nFROB a\n\"SET\" \"b\" \"2\"\n"), 0o644)
+	if _, err := Open(Options{AOF: bad}); err == nil || !strings.Contains(err.Error(), "line 2") {
+		t.Errorf("Open on a bad log error = %v, want one about line 2", err)
+	}
+}
+
+func TestSweeper(t *testing.T) {
+	s, err := Open(Options{})
+	if err != nil {
+		t.Fatal(err)
+	}
+	s.Set("a", "1", time.Millisecond)
+	s.StartSweeper(5 * time.Millisecond)
+	deadline := time.Now().Add(5 * time.Second)
+	for s.Stats().Expired == 0 {
+		if time.Now().After(deadline) {
+			t.Fatal("the sweeper did not delete the expired key")
+		}
+		time.Sleep(5 * time.Millisecond)
+	}
+	if err := s.Close(); err != nil {
+		t.Fatal(err)
+	}
+}
+
+// Run with -race: readers, writers and the sweeper at the same time
+func TestConcurrentUse(t *testing.T) {
+	s, err := Open(Options{MaxKeys: 50, AOF: filepath.Join(t.TempDir(), "kv.aof")})
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer s.Close()
+	s.StartSweeper(time.Millisecond)
+	var wg sync.WaitGroup
+	for g := range 8 {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			for i := range 200 {
+				key := string(rune('a' + (g*200+i)%60))
+				s.Set(key, "v", time.Duration(i%3)*time.Millisecond)
+				s.Get(key)
+				if i%10 == 0 {
+					s.Delete(key)
+				}
+			}
+		}()
+	}
+	wg.Wait()
+	if n := len(s.Keys()); n > 50 {
+		t.Errorf("%d keys, more than MaxKeys", n)
+	}
+}
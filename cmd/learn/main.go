@@ -0,0 +1,122 @@
+// Command learn lists and runs every example in this repository by name
+//
+// Usage:
+//
+//	go run ./cmd/learn list [topic ...]
+//	go run ./cmd/learn run <example> [--size n] [--seed n] [program arguments]
+//
+// For example:
+//
+//	go run ./cmd/learn list algorithms
+//	go run ./cmd/learn run algorithms/sorting/quicksort --size 1000
+//	go run ./cmd/learn run basics/cli greet -name=Nok
+//
+// Run it from the repository root: the standalone examples are started with
+// go run and their package paths are relative to the root.
+package main
+
+import (
+	"errors"
+	"flag"
+	"fmt"
+	"os"
+	"slices"
+	"strings"
+
+	"github.com/NutProhmpiriya/go-basic/learn"
+)
+
+// command is a learn subcommand
+type command struct {
+	name    string
+	summary string
+	run     func(args []string) error
+}
+
+var commands = []command{
+	{"list", "list the examples, optionally only those of some topics", runList},
+	{"run", "run one example by name", runExample},
+}
+
+func usage() {
+	fmt.Fprintln(os.Stderr, "Usage: learn <command> [arguments]")
+	fmt.Fprintln(os.Stderr, "\nCommands:")
+	for _, c := range commands {
+		fmt.Fprintf(os.Stderr, "  %-6s %s\n", c.name, c.summary)
+	}
+	fmt.Fprintf(os.Stderr, "\nTopics: %s\n", strings.Join(learn.Topics, ", "))
+}
+
+// runList implements "learn list [topic ...]"
+func runList(args []string) error {
+	for _, topic := range args {
+		if !slices.Contains(learn.Topics, topic) {
+			return fmt.Errorf("unknown topic %q (topics: %s)", topic, strings.Join(learn.Topics, ", "))
+		}
+	}
+
+	topic := ""
+	for _, e := range learn.Examples(args...) {
+		if e.Topic() != topic {
+			if topic != "" {
+				fmt.Println()
+			}
+			topic = e.Topic()
+			fmt.Printf("%s:\n", topic)
+		}
+		fmt.Printf("  %-34s %s\n", e.ID, e.Title)
+	}
+	return nil
+}
+
+// runExample implements "learn run <example> [--size n] [--seed n] [args ...]"
+// The example name may come before or after the flags
+func runExample(args []string) error {
+	flags := flag.NewFlagSet("run", flag.ContinueOnError)
+	size := flags.Int("size", 0, "input size for examples that generate data (0: the example's default)")
+	seed := flags.Int64("seed", 1, "seed for generated input")
+
+	id := ""
+	if len(args) > 0 && !strings.HasPrefix(args[0], "-") {
+		id, args = args[0], args[1:]
+	}
+	if err := flags.Parse(args); err != nil {
+		return err
+	}
+	rest := flags.Args()
+	if id == "" {
+		if len(rest) == 0 {
+			return errors.New("missing example name (see learn list)")
+		}
+		id, rest = rest[0], rest[1:]
+	}
+
+	e, err := learn.Lookup(id)
+	if err != nil {
+		return fmt.Errorf("%w (see learn list)", err)
+	}
+	fmt.Printf("=== %s (%s) ===\n", e.Title, e.Source)
+	return e.Run(&learn.Env{Out: os.Stdout, Size: *size, Seed: *seed, Args: rest})
+}
+
+func main() {
+	if len(os.Args) < 2 {
+		usage()
+		os.Exit(2)
+	}
+
+	name := os.Args[1]
+	for _, c := range commands {
+		if c.name == name {
+			if err := c.run(os.Args[2:]); err != nil {
+				fmt.Fprintf(os.Stderr, "learn %s: %v\n", name, err)
+				os.Exit(1)
+			}
+			return
+		}
+	}
+
+	fmt.Fprintf(os.Stderr, "learn: unknown command %q\n\n", name)
+	usage()
+	os.Exit(2)
+}
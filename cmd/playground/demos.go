@@ -0,0 +1,510 @@
+package main
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"slices"
+	"strings"
+
+	"github.com/NutProhmpiriya/go-basic/02-data-structures/datastructures"
+	"github.com/NutProhmpiriya/go-basic/03-algorithms/dp"
+	"github.com/NutProhmpiriya/go-basic/03-algorithms/graph"
+	"github.com/NutProhmpiriya/go-basic/03-algorithms/searching"
+	"github.com/NutProhmpiriya/go-basic/03-algorithms/sorting"
+	"github.com/NutProhmpiriya/go-basic/03-algorithms/stats"
+	"github.com/NutProhmpiriya/go-basic/03-algorithms/stringalgo"
+	"github.com/NutProhmpiriya/go-basic/trace"
+)
+
+// errBadInput marks errors caused by the request rather than the server
+var errBadInput = errors.New("bad input")
+
+// Limits keep a single request from tying up the server
+const (
+	maxValues    = 100_000
+	maxQuadratic = 5_000 // for the O(n²) sorts and the unbalanced tree
+	maxCapacity  = 100_000
+	maxTable     = 4_000_000 // cells in a dynamic programming table
+	maxTraceLen  = 1_000     // searches longer than this return no steps
+	maxDrawn     = 200       // bigger trees aren't drawn: a drawing grows with width × depth
+)
+
+// Demo is one endpoint of the playground: POST /api/run/{name} decodes the
+// request body into the demo's input, runs it and encodes the result
+type Demo struct {
+	Name        string `json:"name"`
+	Description string `json:"description"`
+	// Example is a valid request body, shown on the page as a starting point
+	Example any `json:"example"`
+	run     func(body io.Reader) (any, error)
+}
+
+// newDemo builds a Demo from a typed run function
+// Unknown fields in the request are rejected, so typos don't pass silently
+func newDemo[In, Out any](name, description string, example In, run func(In) (Out, error)) Demo {
+	return Demo{
+		Name:        name,
+		Description: description,
+		Example:     example,
+		run: func(body io.Reader) (any, error) {
+			var in In
+			decoder := json.NewDecoder(body)
+			decoder.DisallowUnknownFields()
+			if err := decoder.Decode(&in); err != nil {
+				return nil, fmt.Errorf("%w: %w", errBadInput, err)
+			}
+			return run(in)
+		},
+	}
+}
+
+// badInput returns an errBadInput error with a formatted message
+func badInput(format string, args ...any) error {
+	return fmt.Errorf("%w: %s", errBadInput, fmt.Sprintf(format, args...))
+}
+
+// demos lists every demo in the order the page shows them
+var demos = []Demo{
+	newDemo("sort", "Sort integers with one of the sorting algorithms and measure how presorted the input was",
+		sortInput{Algorithm: "quick", Values: []int{5, 2, 8, 1, 9, 3}}, runSort),
+	newDemo("search", "Find a target in a slice, which must be sorted for all but linear search; linear and binary search also return their steps",
+		searchInput{Algorithm: "binary", Values: []int{1, 3, 5, 7, 9, 11, 13}, Target: 11}, runSearch),
+	newDemo("string-search", "Find every occurrence of a pattern with KMP or Rabin-Karp",
+		stringSearchInput{Algorithm: "kmp", Text: "AABAACAADAABAAABAA", Pattern: "AABA"}, runStringSearch),
+	newDemo("edit-distance", "Levenshtein distance between two strings",
+		pairInput{A: "kitten", B: "sitting"}, runEditDistance),
+	newDemo("lcs", "Length of the longest common subsequence of two strings",
+		pairInput{A: "abcde", B: "ace"}, runLCS),
+	newDemo("palindrome", "Longest palindromic substring",
+		textInput{Text: "babad"}, runPalindrome),
+	newDemo("knapsack", "0/1 knapsack: the best total value that fits in the capacity",
+		knapsackInput{Values: []int{60, 100, 120}, Weights: []int{10, 20, 30}, Capacity: 50}, runKnapsack),
+	newDemo("coin-change", "Fewest coins that make up the amount (-1 when impossible)",
+		coinChangeInput{Coins: []int{1, 2, 5}, Amount: 11}, runCoinChange),
+	newDemo("brackets", `Check that the (), [] and {} in source code are balanced and nested, skipping strings and the comments of the language ("", "go" or "python"), and find the first mismatch`,
+		bracketsInput{Text: "f(a[i], {b: \")\"}) // :)", Language: "go"}, runBrackets),
+	newDemo("expression", "Convert an arithmetic expression to postfix with the shunting-yard algorithm and evaluate it",
+		textInput{Text: "3 + 4 * (2 - 1) ^ 2"}, runExpression),
+	newDemo("bst", "Insert values into a binary search tree, draw it if it is small and traverse it",
+		valuesInput{Values: []int{5, 3, 7, 1, 4, 6, 8}}, runBST),
+	newDemo("graph-traversal", "Breadth- or depth-first traversal of an undirected graph",
+		traversalInput{Order: "bfs", Edges: [][2]int{{0, 1}, {1, 2}, {0, 3}, {1, 4}, {2, 5}, {3, 4}, {4, 5}}}, runTraversal),
+	newDemo("build-order", `Build order and parallel levels for "target: dep dep" lines, or the cycle that prevents them`,
+		buildOrderInput{Deps: "app: lib util\nlib: util\nutil:"}, runBuildOrder),
+	newDemo("fuzzy-search", "Words within a distance of the query, using a BK-tree",
+		fuzzyInput{Words: []string{"book", "books", "cake", "boo", "cook", "cape"}, Query: "bok", Radius: 1}, runFuzzySearch),
+	newDemo("stats", "Streaming mean, standard deviation and median of a list of numbers",
+		floatsInput{Values: []float64{2, 4, 4, 4, 5, 5, 7, 9}}, runStats),
+}
+
+// findDemo looks a demo up by name
+func findDemo(name string) (Demo, bool) {
+	for _, d := range demos {
+		if d.Name == name {
+			return d, true
+		}
+	}
+	return Demo{}, false
+}
+
+// choose returns the entry of options named by name, or an error listing the choices
+func choose[T any](kind, name string, options map[string]T) (T, error) {
+	if v, ok := options[name]; ok {
+		return v, nil
+	}
+	names := make([]string, 0, len(options))
+	for n := range options {
+		names = append(names, n)
+	}
+	slices.Sort(names)
+	var zero T
+	return zero, badInput("unknown %s %q (choose from %s)", kind, name, strings.Join(names, ", "))
+}
+
+func checkLen(what string, n, limit int) error {
+	if n > limit {
+		return badInput("%s has %d elements, the limit is %d", what, n, limit)
+	}
+	return nil
+}
+
+type sortInput struct {
+	Algorithm string `json:"algorithm"`
+	Values    []int  `json:"values"`
+}
+
+type sortOutput struct {
+	Sorted []int `json:"sorted"`
+	// Runs and Inversions measure how sorted the input already was
+	Runs       int   `json:"runs"`
+	Inversions int64 `json:"inversions"`
+}
+
+var sortAlgorithms = map[string]struct {
+	sort      func([]int) []int
+	quadratic bool
+}{
+	"bubble":        {inPlace(sorting.BubbleSort), true},
+	"insertion":     {inPlace(sorting.InsertionSort), true},
+	"quick":         {inPlace(sorting.QuickSort), false},
+	"lomuto-quick":  {inPlace(sorting.LomutoQuickSort), true}, // O(n²) on duplicates
+	"merge":         {sorting.MergeSort, false},
+	"natural-merge": {sorting.NaturalMergeSort, false},
+}
+
+// inPlace adapts an in-place sort to return its input
+func inPlace(sort func([]int)) func([]int) []int {
+	return func(arr []int) []int {
+		sort(arr)
+		return arr
+	}
+}
+
+func runSort(in sortInput) (sortOutput, error) {
+	algorithm, err := choose("algorithm", in.Algorithm, sortAlgorithms)
+	if err != nil {
+		return sortOutput{}, err
+	}
+	limit := maxValues
+	if algorithm.quadratic {
+		limit = maxQuadratic
+	}
+	if err := checkLen("values", len(in.Values), limit); err != nil {
+		return sortOutput{}, err
+	}
+	out := sortOutput{Runs: sorting.CountRuns(in.Values), Inversions: sorting.CountInversions(in.Values)}
+	out.Sorted = algorithm.sort(slices.Clone(in.Values))
+	if out.Sorted == nil {
+		out.Sorted = []int{}
+	}
+	return out, nil
+}
+
+type searchInput struct {
+	Algorithm string `json:"algorithm"`
+	Values    []int  `json:"values"`
+	Target    int    `json:"target"`
+}
+
+type searchOutput struct {
+	Index int      `json:"index"`
+	Steps []string `json:"steps,omitempty"`
+}
+
+var searchAlgorithms = map[string]struct {
+	search func([]int, int) int
+	traced func([]int, int, trace.Tracer) int
+}{
+	"linear":        {searching.LinearSearch, searching.LinearSearchTrace},
+	"binary":        {searching.BinarySearch, searching.BinarySearchTrace},
+	"jump":          {searching.JumpSearch, nil},
+	"interpolation": {searching.InterpolationSearch, nil},
+}
+
+func runSearch(in searchInput) (searchOutput, error) {
+	algorithm, err := choose("algorithm", in.Algorithm, searchAlgorithms)
+	if err != nil {
+		return searchOutput{}, err
+	}
+	if err := checkLen("values", len(in.Values), maxValues); err != nil {
+		return searchOutput{}, err
+	}
+	if in.Algorithm != "linear" && !sorting.IsSorted(in.Values) {
+		return searchOutput{}, badInput("%s search needs sorted values", in.Algorithm)
+	}
+
+	if algorithm.traced == nil || len(in.Values) > maxTraceLen {
+		return searchOutput{Index: algorithm.search(in.Values, in.Target)}, nil
+	}
+	var steps []string
+	tracer := trace.TracerFunc(func(e trace.Event) {
+		steps = append(steps, e.String())
+	})
+	return searchOutput{Index: algorithm.traced(in.Values, in.Target, tracer), Steps: steps}, nil
+}
+
+type stringSearchInput struct {
+	Algorithm string `json:"algorithm"`
+	Text      string `json:"text"`
+	Pattern   string `json:"pattern"`
+}
+
+type matchesOutput struct {
+	Matches []int `json:"matches"`
+}
+
+func runStringSearch(in stringSearchInput) (matchesOutput, error) {
+	search, err := choose("algorithm", in.Algorithm, map[string]func(string, string) []int{
+		"kmp":        stringalgo.KMPSearch,
+		"rabin-karp": stringalgo.RabinKarp,
+	})
+	if err != nil {
+		return matchesOutput{}, err
+	}
+	matches := search(in.Text, in.Pattern)
+	if matches == nil {
+		matches = []int{}
+	}
+	return matchesOutput{Matches: matches}, nil
+}
+
+type pairInput struct {
+	A string `json:"a"`
+	B string `json:"b"`
+}
+
+type textInput struct {
+	Text string `json:"text"`
+}
+
+// checkTable rejects inputs whose dynamic programming table would be too big
+func checkTable(rows, cols int) error {
+	if rows > 0 && cols > maxTable/rows {
+		return badInput("input needs a %dx%d table, the limit is %d cells", rows, cols, maxTable)
+	}
+	return nil
+}
+
+func runEditDistance(in pairInput) (map[string]int, error) {
+	if err := checkTable(len(in.A)+1, len(in.B)+1); err != nil {
+		return nil, err
+	}
+	return map[string]int{"distance": stringalgo.LevenshteinDistance(in.A, in.B)}, nil
+}
+
+func runLCS(in pairInput) (map[string]int, error) {
+	if err := checkTable(len(in.A)+1, len(in.B)+1); err != nil {
+		return nil, err
+	}
+	return map[string]int{"length": dp.LongestCommonSubsequence(in.A, in.B)}, nil
+}
+
+func runPalindrome(in textInput) (map[string]string, error) {
+	if err := checkTable(len(in.Text), len(in.Text)); err != nil {
+		return nil, err
+	}
+	return map[string]string{"longest": stringalgo.LongestPalindromicSubstring(in.Text)}, nil
+}
+
+type knapsackInput struct {
+	Values   []int `json:"values"`
+	Weights  []int `json:"weights"`
+	Capacity int   `json:"capacity"`
+}
+
+func runKnapsack(in knapsackInput) (map[string]int, error) {
+	switch {
+	case len(in.Values) != len(in.Weights):
+		return nil, badInput("got %d values but %d weights", len(in.Values), len(in.Weights))
+	case in.Capacity < 0 || in.Capacity > maxCapacity:
+		return nil, badInput("capacity must be between 0 and %d", maxCapacity)
+	case slices.ContainsFunc(in.Weights, func(w int) bool { return w < 0 }):
+		return nil, badInput("weights must not be negative")
+	}
+	if err := checkTable(len(in.Values)+1, in.Capacity+1); err != nil {
+		return nil, err
+	}
+	return map[string]int{"maxValue": dp.KnapsackProblem(in.Values, in.Weights, in.Capacity)}, nil
+}
+
+type coinChangeInput struct {
+	Coins  []int `json:"coins"`
+	Amount int   `json:"amount"`
+}
+
+func runCoinChange(in coinChangeInput) (map[string]int, error) {
+	switch {
+	case in.Amount < 0 || in.Amount > maxCapacity:
+		return nil, badInput("amount must be between 0 and %d", maxCapacity)
+	case slices.ContainsFunc(in.Coins, func(c int) bool { return c <= 0 }):
+		return nil, badInput("coins must be positive")
+	}
+	// Not a table, but the same number of steps
+	if err := checkTable(len(in.Coins), in.Amount+1); err != nil {
+		return nil, err
+	}
+	return map[string]int{"minCoins": dp.CoinChange(in.Coins, in.Amount)}, nil
+}
+
+type bracketsInput struct {
+	Text     string `json:"text"`
+	Language string `json:"language"`
+}
+
+type bracketsOutput struct {
+	Valid   bool   `json:"valid"`
+	Line    int    `json:"line,omitempty"`
+	Column  int    `json:"column,omitempty"`
+	Message string `json:"message,omitempty"`
+}
+
+func runBrackets(in bracketsInput) (bracketsOutput, error) {
+	var matcher *datastructures.Matcher
+	switch in.Language {
+	case "":
+		matcher = datastructures.NewMatcher()
+	case "go":
+		matcher = datastructures.GoMatcher()
+	case "python":
+		matcher = datastructures.PythonMatcher()
+	default:
+		return bracketsOutput{}, badInput("unknown language %q", in.Language)
+	}
+	var mismatch *datastructures.MismatchError
+	if err := matcher.Check(in.Text); errors.As(err, &mismatch) {
+		return bracketsOutput{Line: mismatch.Line, Column: mismatch.Col, Message: mismatch.Msg}, nil
+	}
+	return bracketsOutput{Valid: true}, nil
+}
+
+type expressionOutput struct {
+	Postfix string  `json:"postfix"`
+	Value   float64 `json:"value"`
+}
+
+func runExpression(in textInput) (expressionOutput, error) {
+	tokens, err := datastructures.ToPostfix(in.Text)
+	if err != nil {
+		return expressionOutput{}, fmt.Errorf("%w: %v", errBadInput, err)
+	}
+	value, err := datastructures.EvaluatePostfix(tokens)
+	if err != nil {
+		return expressionOutput{}, fmt.Errorf("%w: %v", errBadInput, err)
+	}
+	return expressionOutput{Postfix: datastructures.FormatPostfix(tokens), Value: value}, nil
+}
+
+type valuesInput struct {
+	Values []int `json:"values"`
+}
+
+type bstOutput struct {
+	Layered   string `json:"layered,omitempty"`
+	Inorder   []int  `json:"inorder"`
+	Preorder  []int  `json:"preorder"`
+	Postorder []int  `json:"postorder"`
+}
+
+func runBST(in valuesInput) (bstOutput, error) {
+	// The tree is unbalanced, so sorted input makes it a linked list of depth n
+	if err := checkLen("values", len(in.Values), maxQuadratic); err != nil {
+		return bstOutput{}, err
+	}
+	tree := &datastructures.BinaryTree{}
+	for _, v := range in.Values {
+		tree.Insert(v)
+	}
+	out := bstOutput{
+		Inorder:   tree.InorderTraversal(),
+		Preorder:  tree.PreorderTraversal(),
+		Postorder: tree.PostorderTraversal(),
+	}
+	if len(in.Values) <= maxDrawn {
+		out.Layered = tree.Layered()
+	}
+	return out, nil
+}
+
+type traversalInput struct {
+	Order string   `json:"order"`
+	Edges [][2]int `json:"edges"`
+	Start int      `json:"start"`
+}
+
+func runTraversal(in traversalInput) (map[string][]int, error) {
+	if err := checkLen("edges", len(in.Edges), maxValues); err != nil {
+		return nil, err
+	}
+	g := datastructures.NewGraph()
+	g.AddVertex(in.Start)
+	for _, e := range in.Edges {
+		g.AddEdge(e[0], e[1])
+	}
+	traverse, err := choose("order", in.Order, map[string]func(int) []int{"bfs": g.BFS, "dfs": g.DFS})
+	if err != nil {
+		return nil, err
+	}
+	return map[string][]int{"order": traverse(in.Start)}, nil
+}
+
+type buildOrderInput struct {
+	Deps string `json:"deps"`
+}
+
+type buildOrderOutput struct {
+	Order  []string   `json:"order"`
+	Levels [][]string `json:"levels"`
+}
+
+func runBuildOrder(in buildOrderInput) (buildOrderOutput, error) {
+	g, err := graph.ParseDependencies(strings.NewReader(in.Deps))
+	if err != nil {
+		return buildOrderOutput{}, fmt.Errorf("%w: %v", errBadInput, err)
+	}
+	order, err := g.BuildOrder()
+	if err != nil {
+		return buildOrderOutput{}, fmt.Errorf("%w: %v", errBadInput, err)
+	}
+	levels, _ := g.BuildLevels()
+	return buildOrderOutput{Order: order, Levels: levels}, nil
+}
+
+type fuzzyInput struct {
+	Words  []string `json:"words"`
+	Query  string   `json:"query"`
+	Radius int      `json:"radius"`
+	// Metric is "edit" (the default) or "hamming"
+	Metric string `json:"metric,omitempty"`
+}
+
+func runFuzzySearch(in fuzzyInput) (map[string][]string, error) {
+	if in.Metric == "" {
+		in.Metric = "edit"
+	}
+	metric, err := choose("metric", in.Metric, map[string]datastructures.Metric{
+		"edit":    datastructures.EditDistance,
+		"hamming": datastructures.HammingDistance,
+	})
+	if err != nil {
+		return nil, err
+	}
+	if err := checkLen("words", len(in.Words), maxQuadratic); err != nil {
+		return nil, err
+	}
+	tree := datastructures.NewBKTree(metric)
+	for _, w := range in.Words {
+		tree.Add(w)
+	}
+	return map[string][]string{"matches": tree.RangeSearch(in.Query, in.Radius)}, nil
+}
+
+type floatsInput struct {
+	Values []float64 `json:"values"`
+}
+
+type statsOutput struct {
+	Count  int     `json:"count"`
+	Mean   float64 `json:"mean"`
+	StdDev float64 `json:"stdDev"`
+	Min    float64 `json:"min"`
+	Max    float64 `json:"max"`
+	Median float64 `json:"median"`
+}
+
+func runStats(in floatsInput) (statsOutput, error) {
+	if err := checkLen("values", len(in.Values), maxValues); err != nil {
+		return statsOutput{}, err
+	}
+	var s stats.RunningStats
+	median := stats.NewP2Quantile(0.5)
+	for _, x := range in.Values {
+		s.Add(x)
+		median.Add(x)
+	}
+	return statsOutput{
+		Count: s.Count(), Mean: s.Mean(), StdDev: s.StdDev(),
+		Min: s.Min(), Max: s.Max(), Median: median.Value(),
+	}, nil
+}
@@ -0,0 +1,61 @@
+// Command playground serves a web page for running the algorithm and data
+// structure demos on your own input, without editing any Go files
+//
+// Usage:
+//
+//	go run ./cmd/playground [-addr localhost:8080]
+//
+// Then open http://localhost:8080. Every demo is also a JSON endpoint:
+//
+//	curl localhost:8080/api/demos
+//	curl -d '{"algorithm":"merge","values":[3,1,2]}' localhost:8080/api/run/sort
+//
+// Inputs are size-limited so one request can't tie up the server.
+package main
+
+import (
+	"context"
+	"errors"
+	"flag"
+	"log"
+	"net/http"
+	"os"
+	"os/signal"
+	"syscall"
+	"time"
+)
+
+func main() {
+	addr := flag.String("addr", "localhost:8080", "address to listen on")
+	flag.Parse()
+
+	// Always set timeouts on public servers
+	server := &http.Server{
+		Addr:              *addr,
+		Handler:           newHandler(),
+		ReadHeaderTimeout: 5 * time.Second,
+		ReadTimeout:       10 * time.Second,
+		WriteTimeout:      30 * time.Second,
+	}
+
+	ctx, stop := signal.NotifyContext(context.Background(), os.Interrupt, syscall.SIGTERM)
+	defer stop()
+
+	errc := make(chan error, 1)
+	go func() {
+		log.Printf("playground listening on http://%s", *addr)
+		errc <- server.ListenAndServe()
+	}()
+
+	select {
+	case err := <-errc:
+		log.Fatal(err)
+	case <-ctx.Done():
+	}
+
+	shutdownCtx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+	defer cancel()
+	if err := server.Shutdown(shutdownCtx); err != nil && !errors.Is(err, http.ErrServerClosed) {
+		log.Fatal(err)
+	}
+}
@@ -0,0 +1,68 @@
+package main
+
+import (
+	_ "embed"
+	"encoding/json"
+	"errors"
+	"log"
+	"net/http"
+)
+
+// maxBodyBytes caps the size of a request body
+const maxBodyBytes = 1 << 20
+
+//go:embed index.html
+var indexHTML []byte
+
+// newHandler returns the playground's routes
+//
+//	GET  /                the HTML page
+//	GET  /api/demos       every demo with an example input
+//	POST /api/run/{name}  run a demo on the JSON input in the body
+func newHandler() http.Handler {
+	mux := http.NewServeMux()
+	mux.HandleFunc("GET /{$}", func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Content-Type", "text/html; charset=utf-8")
+		w.Write(indexHTML)
+	})
+	mux.HandleFunc("GET /api/demos", func(w http.ResponseWriter, r *http.Request) {
+		writeJSON(w, http.StatusOK, demos)
+	})
+	mux.HandleFunc("POST /api/run/{name}", handleRun)
+	return mux
+}
+
+func handleRun(w http.ResponseWriter, r *http.Request) {
+	demo, ok := findDemo(r.PathValue("name"))
+	if !ok {
+		writeError(w, http.StatusNotFound, "unknown demo "+r.PathValue("name"))
+		return
+	}
+
+	result, err := demo.run(http.MaxBytesReader(w, r.Body, maxBodyBytes))
+	var tooLarge *http.MaxBytesError
+	switch {
+	case errors.As(err, &tooLarge):
+		writeError(w, http.StatusRequestEntityTooLarge, err.Error())
+	case errors.Is(err, errBadInput):
+		writeError(w, http.StatusBadRequest, err.Error())
+	case err != nil:
+		log.Printf("%s: %v", demo.Name, err)
+		writeError(w, http.StatusInternalServerError, "internal error")
+	default:
+		writeJSON(w, http.StatusOK, result)
+	}
+}
+
+func writeJSON(w http.ResponseWriter, status int, v any) {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(status)
+	if err := json.NewEncoder(w).Encode(v); err != nil {
+		log.Printf("writing response: %v", err)
+	}
+}
+
+// writeError sends {"error": message}
+func writeError(w http.ResponseWriter, status int, message string) {
+	writeJSON(w, status, map[string]string{"error": message})
+}
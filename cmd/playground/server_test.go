@@ -0,0 +1,119 @@
+package main
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+)
+
+func post(t *testing.T, handler http.Handler, path, body string) *httptest.ResponseRecorder {
+	t.Helper()
+	w := httptest.NewRecorder()
+	handler.ServeHTTP(w, httptest.NewRequest(http.MethodPost, path, strings.NewReader(body)))
+	return w
+}
+
+// The example shown on the page must work for every demo
+func TestExamplesRun(t *testing.T) {
+	handler := newHandler()
+	for _, d := range demos {
+		t.Run(d.Name, func(t *testing.T) {
+			example, err := json.Marshal(d.Example)
+			if err != nil {
+				t.Fatal(err)
+			}
+			w := post(t, handler, "/api/run/"+d.Name, string(example))
+			if w.Code != http.StatusOK {
+				t.Fatalf("status %d: %s", w.Code, w.Body)
+			}
+		})
+	}
+}
+
+func TestRun(t *testing.T) {
+	handler := newHandler()
+	tests := []struct {
+		demo, body string
+		want       string
+	}{
+		{"sort", `{"algorithm":"merge","values":[3,1,2]}`, `{"sorted":[1,2,3],"runs":2,"inversions":2}`},
+		{"sort", `{"algorithm":"bubble","values":[]}`, `{"sorted":[],"runs":0,"inversions":0}`},
+		{"search", `{"algorithm":"jump","values":[1,3,5],"target":5}`, `{"index":2}`},
+		{"string-search", `{"algorithm":"rabin-karp","text":"abab","pattern":"ab"}`, `{"matches":[0,2]}`},
+		{"edit-distance", `{"a":"kitten","b":"sitting"}`, `{"distance":3}`},
+		{"coin-change", `{"coins":[2],"amount":3}`, `{"minCoins":-1}`},
+		{"brackets", `{"text":"(()"}`, `{"valid":false,"line":1,"column":1,"message":"'(' is not closed"}`},
+		{"brackets", `{"text":"f() // )","language":"go"}`, `{"valid":true}`},
+		{"expression", `{"text":"-2 ^ 2"}`, `{"postfix":"2 2 ^ neg","value":-4}`},
+		{"graph-traversal", `{"order":"dfs","edges":[[0,1],[0,2]],"start":0}`, `{"order":[0,1,2]}`},
+		{"build-order", `{"deps":"a: b\nb:"}`, `{"order":["b","a"],"levels":[["b"],["a"]]}`},
+	}
+	for _, tt := range tests {
+		t.Run(tt.demo, func(t *testing.T) {
+			w := post(t, handler, "/api/run/"+tt.demo, tt.body)
+			if got := strings.TrimSpace(w.Body.String()); w.Code != http.StatusOK || got != tt.want {
+				t.Errorf("status %d, body %s; want %s", w.Code, got, tt.want)
+			}
+		})
+	}
+}
+
+func TestRunErrors(t *testing.T) {
+	handler := newHandler()
+	tests := []struct {
+		name, path, body string
+		status           int
+	}{
+		{"unknown demo", "/api/run/bogosort", `{}`, http.StatusNotFound},
+		{"malformed JSON", "/api/run/sort", `{"values":[1,`, http.StatusBadRequest},
+		{"unknown field", "/api/run/sort", `{"algorithm":"quick","vals":[1]}`, http.StatusBadRequest},
+		{"unknown algorithm", "/api/run/sort", `{"algorithm":"bogo","values":[1]}`, http.StatusBadRequest},
+		{"unknown language", "/api/run/brackets", `{"text":"()","language":"cobol"}`, http.StatusBadRequest},
+		{"unsorted binary search", "/api/run/search", `{"algorithm":"binary","values":[3,1],"target":1}`, http.StatusBadRequest},
+		{"cycle", "/api/run/build-order", `{"deps":"a: b\nb: a"}`, http.StatusBadRequest},
+		{"table too big", "/api/run/edit-distance", `{"a":"` + strings.Repeat("a", 3000) + `","b":"` + strings.Repeat("b", 3000) + `"}`, http.StatusBadRequest},
+		{"negative capacity", "/api/run/knapsack", `{"values":[1],"weights":[1],"capacity":-1}`, http.StatusBadRequest},
+		{"too large", "/api/run/sort", `{"algorithm":"quick","values":[` + strings.Repeat("1,", maxBodyBytes) + `1]}`, http.StatusRequestEntityTooLarge},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			w := post(t, handler, tt.path, tt.body)
+			if w.Code != tt.status {
+				t.Fatalf("status %d, want %d: %s", w.Code, tt.status, w.Body)
+			}
+			if w.Code != http.StatusOK && !bytes.Contains(w.Body.Bytes(), []byte(`"error"`)) {
+				t.Errorf("body %s has no error field", w.Body)
+			}
+		})
+	}
+}
+
+func TestIndex(t *testing.T) {
+	w := httptest.NewRecorder()
+	newHandler().ServeHTTP(w, httptest.NewRequest(http.MethodGet, "/", nil))
+	if w.Code != http.StatusOK || !strings.Contains(w.Body.String(), "/api/demos") {
+		t.Errorf("GET / = %d", w.Code)
+	}
+}
+
+func TestBSTDrawing(t *testing.T) {
+	handler := newHandler()
+	for _, n := range []int{maxDrawn, maxDrawn + 1} {
+		values := make([]string, n)
+		for i := range values {
+			values[i] = fmt.Sprint(i) // sorted, so the tree is n levels deep
+		}
+		w := post(t, handler, "/api/run/bst", `{"values":[`+strings.Join(values, ",")+`]}`)
+		if w.Code != http.StatusOK {
+			t.Fatalf("%d values: status %d", n, w.Code)
+		}
+		drawn := strings.Contains(w.Body.String(), `"layered"`)
+		if drawn != (n <= maxDrawn) {
+			t.Errorf("%d values: drawn = %v", n, drawn)
+		}
+	}
+}
@@ -0,0 +1,214 @@
+// Command profile walks through profiling the slowest algorithms in this
+// repository: it captures a CPU profile, a heap profile and an execution
+// trace, and summarizes the profiles itself before pointing at the go tool
+// commands that explore them further
+//
+// Usage:
+//
+//	go run ./cmd/profile [-o dir] [-top n] [workload ...]
+//	go run ./cmd/profile -serve localhost:6060
+//
+// With -serve it starts an HTTP server with the net/http/pprof endpoints
+// instead, and POST /run/{workload} runs a workload to profile.
+package main
+
+import (
+	"context"
+	"errors"
+	"flag"
+	"fmt"
+	"io"
+	"log"
+	"net/http"
+	"os"
+	"os/signal"
+	"path/filepath"
+	"runtime"
+	"strings"
+	"time"
+
+	"github.com/NutProhmpiriya/go-basic/profiling"
+)
+
+func main() {
+	// Record every allocation in the heap profile; the default records one
+	// every 512 KiB on average. Set it before anything worth seeing allocates
+	runtime.MemProfileRate = 1
+
+	outDir := flag.String("o", "profiles", "directory to write the profiles to")
+	top := flag.Int("top", 8, "number of functions to show for each profile")
+	serve := flag.String("serve", "", "serve the pprof endpoints on this address instead of walking through")
+	flag.Parse()
+
+	if *serve != "" {
+		if err := runServer(*serve); err != nil {
+			log.Fatal(err)
+		}
+		return
+	}
+
+	workloads := profiling.Workloads
+	if flag.NArg() > 0 {
+		workloads = nil
+		for _, name := range flag.Args() {
+			w, err := profiling.Lookup(name)
+			if err != nil {
+				log.Fatal(err)
+			}
+			workloads = append(workloads, w)
+		}
+	}
+	if err := walkthrough(workloads, *outDir, *top); err != nil {
+		log.Fatal(err)
+	}
+}
+
+func walkthrough(workloads []profiling.Workload, dir string, top int) error {
+	if err := os.MkdirAll(dir, 0o755); err != nil {
+		return err
+	}
+	fmt.Println("Workloads:")
+	for _, w := range workloads {
+		fmt.Printf("  %-10s %s\n", w.Name, w.Description)
+	}
+
+	fmt.Println("\n=== 1. CPU profile ===")
+	fmt.Println("The profiler interrupts the program 100 times a second and records the running stack.")
+	cpuFile := filepath.Join(dir, "cpu.pprof")
+	err := writeFile(cpuFile, func(f io.Writer) error {
+		return profiling.CPUProfile(f, func() {
+			for _, w := range workloads {
+				start := time.Now()
+				w.Run()
+				fmt.Printf("  %-10s %v\n", w.Name, time.Since(start).Round(time.Millisecond))
+			}
+		})
+	})
+	if err != nil {
+		return err
+	}
+	if err := summarize(cpuFile, "cpu", top, func(v int64) string {
+		return time.Duration(v).Round(10 * time.Millisecond).String()
+	}); err != nil {
+		return err
+	}
+	fmt.Println("flat is time spent in the function itself, cum includes the functions it called.")
+
+	fmt.Println("\n=== 2. Heap profile ===")
+	fmt.Println("Every allocation since the program started, by the function that made it.")
+	heapFile := filepath.Join(dir, "heap.pprof")
+	if err := writeFile(heapFile, profiling.HeapProfile); err != nil {
+		return err
+	}
+	if err := summarize(heapFile, "alloc_space", top, formatBytes); err != nil {
+		return err
+	}
+	fmt.Println("inuse_space would show only what is still live after the last garbage collection.")
+
+	fmt.Println("\n=== 3. Execution trace ===")
+	fmt.Println("A trace records every goroutine, GC and scheduler event instead of samples.")
+	traceFile := filepath.Join(dir, "trace.out")
+	err = writeFile(traceFile, func(f io.Writer) error {
+		return profiling.Trace(f, workloads...)
+	})
+	if err != nil {
+		return err
+	}
+	info, err := os.Stat(traceFile)
+	if err != nil {
+		return err
+	}
+	fmt.Printf("Wrote %s (%s), one task per workload\n", traceFile, formatBytes(info.Size()))
+
+	fmt.Println("\n=== Next steps ===")
+	fmt.Printf("go tool pprof -top %s           # the full table\n", cpuFile)
+	fmt.Printf("go tool pprof -http=: %s        # flame graph in the browser\n", cpuFile)
+	fmt.Printf("go tool pprof -sample_index=alloc_space -list LongestPalindromicSubstring %s\n", heapFile)
+	fmt.Printf("go tool trace %s\n", traceFile)
+	return nil
+}
+
+// writeFile creates name and lets write fill it
+func writeFile(name string, write func(w io.Writer) error) error {
+	f, err := os.Create(name)
+	if err != nil {
+		return err
+	}
+	if err := write(f); err != nil {
+		f.Close()
+		return err
+	}
+	return f.Close()
+}
+
+// summarize prints the top functions of a profile file by one sample type
+func summarize(name, sampleType string, n int, format func(int64) string) error {
+	f, err := os.Open(name)
+	if err != nil {
+		return err
+	}
+	defer f.Close()
+	p, err := profiling.Parse(f)
+	if err != nil {
+		return fmt.Errorf("%s: %w", name, err)
+	}
+	value, err := p.ValueIndex(sampleType)
+	if err != nil {
+		return fmt.Errorf("%s: %w", name, err)
+	}
+
+	total := p.Total(value)
+	fmt.Printf("Wrote %s: %d samples, %s of %s in total\n", name, len(p.Samples), format(total), sampleType)
+	if total == 0 {
+		fmt.Println("Nothing was sampled; the workloads may be too fast for the profiler")
+		return nil
+	}
+	fmt.Printf("  %10s %6s %10s %6s  %s\n", "flat", "flat%", "cum", "cum%", "function")
+	for _, e := range p.Top(value, n) {
+		fmt.Printf("  %10s %5.1f%% %10s %5.1f%%  %s\n",
+			format(e.Flat), 100*float64(e.Flat)/float64(total),
+			format(e.Cum), 100*float64(e.Cum)/float64(total), shortName(e.Function))
+	}
+	return nil
+}
+
+// shortName drops the package path, e.g. github.com/x/y/dp.Fib becomes dp.Fib
+func shortName(fn string) string {
+	return fn[strings.LastIndex(fn, "/")+1:]
+}
+
+func formatBytes(n int64) string {
+	switch {
+	case n >= 1<<20:
+		return fmt.Sprintf("%.1fMB", float64(n)/(1<<20))
+	case n >= 1<<10:
+		return fmt.Sprintf("%.1fKB", float64(n)/(1<<10))
+	}
+	return fmt.Sprintf("%dB", n)
+}
+
+// runServer serves profiling.Handler until interrupted
+func runServer(addr string) error {
+	srv := &http.Server{
+		Addr:              addr,
+		Handler:           profiling.Handler(),
+		ReadHeaderTimeout: 5 * time.Second,
+	}
+
+	ctx, stop := signal.NotifyContext(context.Background(), os.Interrupt)
+	defer stop()
+	go func() {
+		<-ctx.Done()
+		shutdownCtx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+		defer cancel()
+		srv.Shutdown(shutdownCtx)
+	}()
+
+	log.Printf("pprof on http://%s/debug/pprof/", addr)
+	log.Printf("run a workload:  curl -X POST http://%s/run/fibonacci", addr)
+	log.Printf("profile it:      go tool pprof 'http://%s/debug/pprof/profile?seconds=10'", addr)
+	if err := srv.ListenAndServe(); !errors.Is(err, http.ErrServerClosed) {
+		return err
+	}
+	return nil
+}
@@ -0,0 +1,244 @@
+package main
+
+import (
+	"errors"
+	"fmt"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// ErrInvalidSchedule is returned for a schedule that can't be parsed
+var ErrInvalidSchedule = errors.New("invalid schedule")
+
+// Schedule says when a job runs
+type Schedule interface {
+	// Next returns the first run time strictly after after, or the zero
+	// time if the job never runs again
+	Next(after time.Time) time.Time
+}
+
+// onceSchedule runs a job a single time
+type onceSchedule time.Time
+
+// At returns a schedule that runs once, at t
+func At(t time.Time) Schedule { return onceSchedule(t) }
+
+func (o onceSchedule) Next(after time.Time) time.Time {
+	if t := time.Time(o); t.After(after) {
+		return t
+	}
+	return time.Time{}
+}
+
+func (o onceSchedule) String() string { return "@at " + time.Time(o).Format(time.RFC3339) }
+
+// everySchedule runs a job at a fixed interval
+type everySchedule time.Duration
+
+// Every returns a schedule that runs every d, counted from the previous
+// run; d is rounded down to whole seconds, with a minimum of one second
+func Every(d time.Duration) Schedule {
+	return everySchedule(max(d.Truncate(time.Second), time.Second))
+}
+
+func (e everySchedule) Next(after time.Time) time.Time {
+	return after.Add(time.Duration(e))
+}
+
+func (e everySchedule) String() string { return "@every " + time.Duration(e).String() }
+
+// cronField describes one of the five fields of a cron expression
+type cronField struct {
+	name     string
+	min, max int
+}
+
+var cronFields = [5]cronField{
+	{"minute", 0, 59},
+	{"hour", 0, 23},
+	{"day of month", 1, 31},
+	{"month", 1, 12},
+	{"day of week", 0, 7}, // 0 and 7 are both Sunday
+}
+
+// cronShortcuts are the named schedules cron accepts
+var cronShortcuts = map[string]string{
+	"@yearly":   "0 0 1 1 *",
+	"@annually": "0 0 1 1 *",
+	"@monthly":  "0 0 1 * *",
+	"@weekly":   "0 0 * * 0",
+	"@daily":    "0 0 * * *",
+	"@midnight": "0 0 * * *",
+	"@hourly":   "0 * * * *",
+}
+
+// CronSchedule is a parsed cron expression
+// Each field is a bit set: bit i is set when the value i matches
+type CronSchedule struct {
+	expr                     string
+	minute, hour, dom, month uint64
+	dow                      uint64
+	// domStar and dowStar record a "*" day field: when both day fields are
+	// restricted, cron runs on a day matching either of them
+	domStar, dowStar bool
+}
+
+// ParseSchedule parses a cron expression, a shortcut like @daily or an
+// interval like "@every 90s"
+func ParseSchedule(spec string) (Schedule, error) {
+	spec = strings.TrimSpace(spec)
+	if rest, ok := strings.CutPrefix(spec, "@every "); ok {
+		d, err := time.ParseDuration(strings.TrimSpace(rest))
+		if err != nil || d <= 0 {
+			return nil, fmt.Errorf("%w: %q: need a positive duration like 90s or 1h30m", ErrInvalidSchedule, spec)
+		}
+		return Every(d), nil
+	}
+	return ParseCron(spec)
+}
+
+// ParseCron parses a simplified cron expression: five fields
+//
+//	minute hour day-of-month month day-of-week
+//
+// each a comma-separated list of a number, a range a-b or "*", optionally
+// followed by a step /n ("*/15" is every 15 minutes), or a shortcut such as
+// @hourly or @daily. Names like JAN or MON are not supported
+func ParseCron(expr string) (*CronSchedule, error) {
+	expr = strings.TrimSpace(expr)
+	fields := strings.Fields(expr)
+	if len(fields) == 1 && strings.HasPrefix(fields[0], "@") {
+		full, ok := cronShortcuts[fields[0]]
+		if !ok {
+			return nil, fmt.Errorf("%w: unknown shortcut %q", ErrInvalidSchedule, fields[0])
+		}
+		fields = strings.Fields(full)
+	}
+	if len(fields) != len(cronFields) {
+		return nil, fmt.Errorf("%w: %q has %d fields, need 5: minute hour day-of-month month day-of-week", ErrInvalidSchedule, expr, len(fields))
+	}
+
+	c := &CronSchedule{expr: expr, domStar: fields[2] == "*", dowStar: fields[4] == "*"}
+	sets := [5]*uint64{&c.minute, &c.hour, &c.dom, &c.month, &c.dow}
+	for i, text := range fields {
+		set, err := parseCronField(text, cronFields[i])
+		if err != nil {
+			return nil, err
+		}
+		*sets[i] = set
+	}
+	// Sunday can be written 7; fold it onto 0
+	if c.dow&(1<<7) != 0 {
+		c.dow = c.dow&^(1<<7) | 1
+	}
+	return c, nil
+}
+
+// parseCronField parses one field into a bit set of the values it matches
+func parseCronField(text string, f cronField) (uint64, error) {
+	fail := func(format string, args ...any) error {
+		return fmt.Errorf("%w: %s field %q: %s", ErrInvalidSchedule, f.name, text, fmt.Sprintf(format, args...))
+	}
+	var set uint64
+	for _, part := range strings.Split(text, ",") {
+		rangeText, stepText, hasStep := strings.Cut(part, "/")
+		step := 1
+		if hasStep {
+			n, err := strconv.Atoi(stepText)
+			if err != nil || n < 1 {
+				return 0, fail("step %q is not a positive number", stepText)
+			}
+			step = n
+		}
+
+		lo, hi := f.min, f.max
+		switch loText, hiText, isRange := strings.Cut(rangeText, "-"); {
+		case rangeText == "*":
+		case isRange:
+			var err error
+			if lo, err = cronValue(loText, f); err != nil {
+				return 0, fail("%v", err)
+			}
+			if hi, err = cronValue(hiText, f); err != nil {
+				return 0, fail("%v", err)
+			}
+			if lo > hi {
+				return 0, fail("range %d-%d goes backwards", lo, hi)
+			}
+		default:
+			n, err := cronValue(rangeText, f)
+			if err != nil {
+				return 0, fail("%v", err)
+			}
+			// "5/10" means from 5 to the end in steps of 10, as in Vixie cron
+			lo = n
+			if !hasStep {
+				hi = n
+			}
+		}
+		for v := lo; v <= hi; v += step {
+			set |= 1 << v
+		}
+	}
+	return set, nil
+}
+
+// cronValue parses a number within the field's bounds
+func cronValue(text string, f cronField) (int, error) {
+	n, err := strconv.Atoi(text)
+	if err != nil {
+		return 0, fmt.Errorf("%q is not a number", text)
+	}
+	if n < f.min || n > f.max {
+		return 0, fmt.Errorf("%d is outside %d-%d", n, f.min, f.max)
+	}
+	return n, nil
+}
+
+// has reports whether bit v of set is set
+func has(set uint64, v int) bool {
+	return set&(1<<v) != 0
+}
+
+// dayMatches applies cron's rule for the two day fields: if both are
+// restricted, a day matching either runs the job ("0 0 1 * 1" is midnight
+// on the 1st and on every Monday); otherwise the restricted one decides
+func (c *CronSchedule) dayMatches(t time.Time) bool {
+	dom, dow := has(c.dom, t.Day()), has(c.dow, int(t.Weekday()))
+	if !c.domStar && !c.dowStar {
+		return dom || dow
+	}
+	return dom && dow
+}
+
+// Next returns the first matching minute after after, in after's time zone
+// It moves forward a field at a time, skipping a whole month, day or hour
+// when it doesn't match, so it takes at most a few hundred steps. A time a
+// daylight saving change skips is not run that day
+// An expression that can never match, like "0 0 30 2 *", gives the zero
+// time after searching five years ahead
+func (c *CronSchedule) Next(after time.Time) time.Time {
+	loc := after.Location()
+	t := time.Date(after.Year(), after.Month(), after.Day(), after.Hour(), after.Minute(), 0, 0, loc).Add(time.Minute)
+	limit := t.AddDate(5, 0, 0)
+	for t.Before(limit) {
+		y, m, d := t.Date()
+		switch {
+		case !has(c.month, int(m)):
+			t = time.Date(y, m+1, 1, 0, 0, 0, 0, loc)
+		case !c.dayMatches(t):
+			t = time.Date(y, m, d+1, 0, 0, 0, 0, loc)
+		case !has(c.hour, t.Hour()):
+			t = time.Date(y, m, d, t.Hour()+1, 0, 0, 0, loc)
+		case !has(c.minute, t.Minute()):
+			t = t.Add(time.Minute)
+		default:
+			return t
+		}
+	}
+	return time.Time{}
+}
+
+// String returns the expression the schedule was parsed from
+func (c *CronSchedule) String() string { return c.expr }
@@ -0,0 +1,99 @@
+package main
+
+import (
+	"errors"
+	"testing"
+	"time"
+)
+
+// at is a UTC time on 2026-01-01 (a Thursday) plus days, hours and minutes
+func at(days, hour, minute int) time.Time {
+	return time.Date(2026, 1, 1+days, hour, minute, 0, 0, time.UTC)
+}
+
+func TestCronNext(t *testing.T) {
+	tests := []struct {
+		expr  string
+		after time.Time
+		want  time.Time
+	}{
+		{"* * * * *", at(0, 10, 0), at(0, 10, 1)},
+		{"* * * * *", at(0, 10, 0).Add(59 * time.Second), at(0, 10, 1)},
+		{"*/15 * * * *", at(0, 10, 7), at(0, 10, 15)},
+		{"*/15 * * * *", at(0, 10, 45), at(0, 11, 0)},
+		{"5/20 * * * *", at(0, 10, 30), at(0, 10, 45)},
+		{"0,30 9-17 * * *", at(0, 17, 30), at(1, 9, 0)},
+		{"30 12 * * *", at(0, 12, 30), at(1, 12, 30)},
+		{"0 9 * * 1-5", at(1, 9, 0), at(4, 9, 0)}, // Friday to Monday
+		{"0 0 * * 7", at(0, 0, 0), at(3, 0, 0)},   // 7 is Sunday
+		{"0 0 1 * *", at(0, 0, 0), at(31, 0, 0)},
+		{"0 0 31 * *", at(31, 0, 0), at(89, 0, 0)}, // skips February
+		{"0 0 29 2 *", at(0, 0, 0), time.Date(2028, 2, 29, 0, 0, 0, 0, time.UTC)},
+		// Both day fields restricted: the 15th or any Monday
+		{"0 0 15 * 1", at(0, 0, 0), at(4, 0, 0)},
+		{"0 0 15 * 1", at(11, 0, 0), at(14, 0, 0)},
+		{"@hourly", at(0, 10, 0), at(0, 11, 0)},
+		{"@weekly", at(0, 0, 0), at(3, 0, 0)},
+		{"@yearly", at(0, 0, 0), time.Date(2027, 1, 1, 0, 0, 0, 0, time.UTC)},
+		// February 30th never comes
+		{"0 0 30 2 *", at(0, 0, 0), time.Time{}},
+	}
+	for _, tt := range tests {
+		c, err := ParseCron(tt.expr)
+		if err != nil {
+			t.Errorf("ParseCron(%q): %v", tt.expr, err)
+			continue
+		}
+		if got := c.Next(tt.after); !got.Equal(tt.want) {
+			t.Errorf("%q.Next(%s) = %s, want %s", tt.expr, tt.after.Format(time.DateTime), got, tt.want)
+		}
+	}
+}
+
+func TestParseScheduleErrors(t *testing.T) {
+	for _, spec := range []string{
+		"",
+		"* * * *",
+		"* * * * * *",
+		"60 * * * *",
+		"* 24 * * *",
+		"* * 0 * *",
+		"* * * 13 *",
+		"* * * * 8",
+		"5-1 * * * *",
+		"*/0 * * * *",
+		"a * * * *",
+		"1,,2 * * * *",
+		"* * * JAN *",
+		"@fortnightly",
+		"@every",
+		"@every soon",
+		"@every -5s",
+	} {
+		if _, err := ParseSchedule(spec); !errors.Is(err, ErrInvalidSchedule) {
+			t.Errorf("ParseSchedule(%q) error = %v, want ErrInvalidSchedule", spec, err)
+		}
+	}
+}
+
+func TestEveryAndAt(t *testing.T) {
+	s, err := ParseSchedule("@every 1m30s")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if got := s.Next(at(0, 10, 0)); !got.Equal(at(0, 10, 1).Add(30 * time.Second)) {
+		t.Errorf("@every 1m30s Next = %s", got)
+	}
+	// Intervals under a second are rounded up to one
+	if got := Every(time.Millisecond).Next(at(0, 0, 0)); !got.Equal(at(0, 0, 0).Add(time.Second)) {
+		t.Errorf("Every(1ms) Next = %s, want a second later", got)
+	}
+
+	once := At(at(0, 12, 0))
+	if got := once.Next(at(0, 11, 0)); !got.Equal(at(0, 12, 0)) {
+		t.Errorf("At Next before = %s", got)
+	}
+	if got := once.Next(at(0, 12, 0)); !got.IsZero() {
+		t.Errorf("At Next at the time = %s, want zero", got)
+	}
+}
@@ -0,0 +1,261 @@
+// Command scheduler is a small cron: it reads a file of jobs, each a
+// schedule and a command, and runs the commands when they are due. It is an
+// applied example of heaps, goroutines and time handling:
+//   - the Scheduler keeps the jobs in a datastructures.Heap ordered by their
+//     next run time and sleeps until the earliest one
+//   - every run gets its own goroutine, so a slow job doesn't hold up the
+//     others
+//   - on Ctrl+C it stops starting jobs and gives the running ones -grace to
+//     finish before they are killed
+//
+// Usage:
+//
+//	go run ./cmd/scheduler jobs.txt                  run the jobs until Ctrl+C
+//	go run ./cmd/scheduler -plan 10 jobs.txt         print the next 10 runs and exit
+//
+// A jobs file has one job per line, a schedule followed by a command. The
+// command is split on spaces, with no quoting, and run directly rather than
+// through a shell; for pipes or quotes, put them in a script:
+//
+//	# minute hour day-of-month month day-of-week  command
+//	*/15 9-17 * * 1-5   echo stand up and stretch
+//	@daily              ./backup.sh
+//	@every 30s          date
+package main
+
+import (
+	"bufio"
+	"bytes"
+	"context"
+	"errors"
+	"flag"
+	"fmt"
+	"io"
+	"os"
+	"os/exec"
+	"os/signal"
+	"strings"
+	"sync"
+	"syscall"
+	"time"
+
+	"github.com/NutProhmpiriya/go-basic/02-data-structures/datastructures"
+	"github.com/NutProhmpiriya/go-basic/ordering"
+)
+
+// errUsage marks errors caused by bad arguments, which exit with code 2
+var errUsage = errors.New("usage error")
+
+// jobSpec is a line of a jobs file
+type jobSpec struct {
+	line     int
+	spec     string
+	schedule Schedule
+	command  []string
+}
+
+// name is how the job is shown in the output
+func (j jobSpec) name() string {
+	return strings.Join(j.command, " ")
+}
+
+// parseJobs reads a jobs file; blank lines and lines starting with # are
+// skipped
+func parseJobs(r io.Reader) ([]jobSpec, error) {
+	var jobs []jobSpec
+	scanner := bufio.NewScanner(r)
+	for lineNo := 1; scanner.Scan(); lineNo++ {
+		fields := strings.Fields(scanner.Text())
+		if len(fields) == 0 || strings.HasPrefix(fields[0], "#") {
+			continue
+		}
+		// The schedule is "@every d", another @ shortcut or five fields
+		n := 5
+		switch {
+		case fields[0] == "@every":
+			n = 2
+		case strings.HasPrefix(fields[0], "@"):
+			n = 1
+		}
+		if len(fields) <= n {
+			return nil, fmt.Errorf("line %d: need a schedule and a command", lineNo)
+		}
+		spec := strings.Join(fields[:n], " ")
+		schedule, err := ParseSchedule(spec)
+		if err != nil {
+			return nil, fmt.Errorf("line %d: %w", lineNo, err)
+		}
+		jobs = append(jobs, jobSpec{lineNo, spec, schedule, fields[n:]})
+	}
+	if err := scanner.Err(); err != nil {
+		return nil, err
+	}
+	if len(jobs) == 0 {
+		return nil, errors.New("no jobs")
+	}
+	return jobs, nil
+}
+
+// plannedRun is one upcoming run of a job
+type plannedRun struct {
+	at  time.Time
+	job int // index into the jobs
+}
+
+// plan returns the next n runs of all the jobs after from, in order
+// It merges the jobs' run times with a heap holding one upcoming run per
+// job, the same way the Scheduler picks the next job to run
+func plan(jobs []jobSpec, from time.Time, n int) []plannedRun {
+	queue := datastructures.NewHeapFunc(
+		ordering.ByFunc(func(r plannedRun) time.Time { return r.at }, time.Time.Compare).
+			ThenComparing(ordering.By(func(r plannedRun) int { return r.job })))
+	for i, j := range jobs {
+		if at := j.schedule.Next(from); !at.IsZero() {
+			queue.Push(plannedRun{at, i})
+		}
+	}
+	var runs []plannedRun
+	for len(runs) < n {
+		r, err := queue.Pop()
+		if err != nil {
+			break // no job runs again
+		}
+		runs = append(runs, r)
+		if at := jobs[r.job].schedule.Next(r.at); !at.IsZero() {
+			queue.Push(plannedRun{at, r.job})
+		}
+	}
+	return runs
+}
+
+// printPlan writes the runs as a timeline
+func printPlan(w io.Writer, jobs []jobSpec, runs []plannedRun) {
+	width := 0
+	for _, j := range jobs {
+		width = max(width, len(j.spec))
+	}
+	for _, r := range runs {
+		j := jobs[r.job]
+		fmt.Fprintf(w, "%s  %-*s  %s\n", r.at.Format("Mon 2006-01-02 15:04:05"), width, j.spec, j.name())
+	}
+}
+
+// lockedWriter serializes writes from the jobs' goroutines
+type lockedWriter struct {
+	mu sync.Mutex
+	w  io.Writer
+}
+
+func (l *lockedWriter) Write(p []byte) (int, error) {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+	return l.w.Write(p)
+}
+
+// commandJob runs a command and writes its output, each line prefixed with
+// the time and the job's name
+func commandJob(j jobSpec, out io.Writer) Job {
+	return func(ctx context.Context, at time.Time) error {
+		cmd := exec.CommandContext(ctx, j.command[0], j.command[1:]...)
+		output, err := cmd.CombinedOutput()
+		var b bytes.Buffer
+		for _, line := range strings.Split(strings.TrimRight(string(output), "\n"), "\n") {
+			if line != "" {
+				fmt.Fprintf(&b, "%s [%s] %s\n", at.Format(time.TimeOnly), j.name(), line)
+			}
+		}
+		out.Write(b.Bytes())
+		return err
+	}
+}
+
+// serve runs the jobs until ctx is done, then shuts the scheduler down,
+// giving running jobs grace to finish
+func serve(ctx context.Context, jobs []jobSpec, grace time.Duration, stdout, stderr io.Writer) error {
+	s := NewScheduler(nil)
+	out := &lockedWriter{w: stdout}
+	s.OnError = func(name string, err error) {
+		fmt.Fprintf(stderr, "%s [%s] failed: %v\n", time.Now().Format(time.TimeOnly), name, err)
+	}
+	for _, j := range jobs {
+		if _, err := s.Add(j.name(), j.schedule, commandJob(j, out)); err != nil {
+			fmt.Fprintf(stderr, "line %d: %v, skipping it\n", j.line, err)
+		}
+	}
+	for _, info := range s.Jobs() {
+		fmt.Fprintf(stderr, "next %s: %s\n", info.Name, info.Next.Format(time.DateTime))
+	}
+
+	runErr := make(chan error, 1)
+	go func() { runErr <- s.Run() }()
+	<-ctx.Done()
+	fmt.Fprintf(stderr, "shutting down, waiting up to %v for running jobs\n", grace)
+
+	shutdownCtx, cancel := context.WithTimeout(context.Background(), grace)
+	defer cancel()
+	err := s.Shutdown(shutdownCtx)
+	<-runErr
+	if errors.Is(err, context.DeadlineExceeded) {
+		return fmt.Errorf("jobs still running after %v were killed", grace)
+	}
+	return err
+}
+
+// run parses the flags and the jobs file, then plans or runs the jobs
+// Keeping os.Exit out of run makes it easy to test
+func run(ctx context.Context, args []string, stdout, stderr io.Writer) error {
+	fs := flag.NewFlagSet("scheduler", flag.ContinueOnError)
+	fs.SetOutput(stderr)
+	fs.Usage = func() {
+		fmt.Fprintln(stderr, "Usage: scheduler [-plan n [-from time]] [-grace duration] jobs-file")
+		fs.PrintDefaults()
+	}
+	planRuns := fs.Int("plan", 0, "print the next n runs instead of running the jobs")
+	from := fs.String("from", "", "with -plan, start from this RFC 3339 time instead of now")
+	grace := fs.Duration("grace", 10*time.Second, "how long to wait for running jobs on shutdown")
+	if err := fs.Parse(args); err != nil {
+		return fmt.Errorf("%w: %w", errUsage, err)
+	}
+	if fs.NArg() != 1 || *planRuns < 0 || *grace < 0 {
+		return fmt.Errorf("%w: need one jobs file, and -plan and -grace must not be negative", errUsage)
+	}
+	start := time.Now()
+	if *from != "" {
+		t, err := time.Parse(time.RFC3339, *from)
+		if err != nil {
+			return fmt.Errorf("%w: -from: %w", errUsage, err)
+		}
+		start = t
+	}
+
+	f, err := os.Open(fs.Arg(0))
+	if err != nil {
+		return err
+	}
+	jobs, err := parseJobs(f)
+	f.Close()
+	if err != nil {
+		return fmt.Errorf("%s: %w", fs.Arg(0), err)
+	}
+
+	if *planRuns > 0 {
+		printPlan(stdout, jobs, plan(jobs, start, *planRuns))
+		return nil
+	}
+	return serve(ctx, jobs, *grace, stdout, stderr)
+}
+
+func main() {
+	ctx, stop := signal.NotifyContext(context.Background(), os.Interrupt, syscall.SIGTERM)
+	err := run(ctx, os.Args[1:], os.Stdout, os.Stderr)
+	stop()
+	switch {
+	case err == nil, errors.Is(err, flag.ErrHelp):
+	case errors.Is(err, errUsage):
+		fmt.Fprintln(os.Stderr, "scheduler:", err)
+		os.Exit(2)
+	default:
+		fmt.Fprintln(os.Stderr, "scheduler:", err)
+		os.Exit(1)
+	}
+}
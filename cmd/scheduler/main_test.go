@@ -0,0 +1,90 @@
+package main
+
+import (
+	"bytes"
+	"context"
+	"errors"
+	"io"
+	"os"
+	"strings"
+	"testing"
+	"time"
+)
+
+// The plan merges every job's runs into one timeline
+func TestRunPlan(t *testing.T) {
+	var out bytes.Buffer
+	args := []string{"-plan", "8", "-from", "2026-01-01T08:50:00Z", "testdata/jobs.txt"}
+	if err := run(context.Background(), args, &out, io.Discard); err != nil {
+		t.Fatal(err)
+	}
+	want := `Thu 2026-01-01 09:00:00  */15 9-17 * * 1-5  echo stand up and stretch
+Thu 2026-01-01 09:00:00  @hourly            echo on the hour
+Thu 2026-01-01 09:10:00  @every 20m         date
+Thu 2026-01-01 09:15:00  */15 9-17 * * 1-5  echo stand up and stretch
+Thu 2026-01-01 09:30:00  */15 9-17 * * 1-5  echo stand up and stretch
+Thu 2026-01-01 09:30:00  @every 20m         date
+Thu 2026-01-01 09:45:00  */15 9-17 * * 1-5  echo stand up and stretch
+Thu 2026-01-01 09:50:00  @every 20m         date
+`
+	if out.String() != want {
+		t.Errorf("plan:\n%s\nwant:\n%s", out.String(), want)
+	}
+}
+
+// A one-shot schedule ends the plan early once it has run
+func TestPlanEnds(t *testing.T) {
+	jobs := []jobSpec{{schedule: At(at(0, 12, 0)), command: []string{"true"}}}
+	if runs := plan(jobs, at(0, 0, 0), 5); len(runs) != 1 || !runs[0].at.Equal(at(0, 12, 0)) {
+		t.Errorf("plan = %v, want the one run", runs)
+	}
+}
+
+func TestParseJobsErrors(t *testing.T) {
+	tests := []struct{ file, want string }{
+		{"# only a comment\n\n", "no jobs"},
+		{"* * * * *\n", "line 1: need a schedule and a command"},
+		{"\n@every 5s\n", "line 2: need a schedule and a command"},
+		{"@every never echo hi\n", "line 1: invalid schedule"},
+		{"61 * * * * echo hi\n", "line 1: invalid schedule"},
+	}
+	for _, tt := range tests {
+		_, err := parseJobs(strings.NewReader(tt.file))
+		if err == nil || !strings.Contains(err.Error(), tt.want) {
+			t.Errorf("parseJobs(%q) error = %v, want one containing %q", tt.file, err, tt.want)
+		}
+	}
+}
+
+// The job's output is prefixed with its scheduled time and name
+func TestCommandJob(t *testing.T) {
+	jobs, err := parseJobs(strings.NewReader("@hourly echo hello\n"))
+	if err != nil {
+		t.Fatal(err)
+	}
+	var out bytes.Buffer
+	when := time.Date(2026, 1, 1, 9, 0, 0, 0, time.UTC)
+	if err := commandJob(jobs[0], &out)(context.Background(), when); err != nil {
+		t.Skip("can't run echo:", err)
+	}
+	if got, want := out.String(), "09:00:00 [echo hello] hello\n"; got != want {
+		t.Errorf("output %q, want %q", got, want)
+	}
+}
+
+func TestRunErrors(t *testing.T) {
+	for _, args := range [][]string{
+		{},
+		{"a.txt", "b.txt"},
+		{"-plan", "-1", "testdata/jobs.txt"},
+		{"-from", "tomorrow", "testdata/jobs.txt"},
+		{"-unknown"},
+	} {
+		if err := run(context.Background(), args, io.Discard, io.Discard); !errors.Is(err, errUsage) {
+			t.Errorf("run(%q) error = %v, want a usage error", args, err)
+		}
+	}
+	if err := run(context.Background(), []string{"testdata/missing.txt"}, io.Discard, io.Discard); !errors.Is(err, os.ErrNotExist) {
+		t.Errorf("run on a missing file error = %v, want os.ErrNotExist", err)
+	}
+}
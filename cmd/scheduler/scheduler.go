@@ -0,0 +1,272 @@
+package main
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"log"
+	"slices"
+	"sync"
+	"time"
+
+	"github.com/NutProhmpiriya/go-basic/02-data-structures/datastructures"
+	"github.com/NutProhmpiriya/go-basic/04-design-patterns/resilience"
+	"github.com/NutProhmpiriya/go-basic/ordering"
+)
+
+var (
+	// ErrSchedulerClosed is returned by Run after Shutdown, and by Add on a
+	// scheduler that was shut down
+	ErrSchedulerClosed = errors.New("scheduler closed")
+	// ErrNeverRuns is returned by Add for a schedule with no run time left
+	ErrNeverRuns = errors.New("schedule never runs")
+)
+
+// Job is the work a scheduled job does; at is the time it was scheduled
+// for, which can be a little earlier than when it starts
+// ctx is cancelled when Shutdown gives up waiting for running jobs
+type Job func(ctx context.Context, at time.Time) error
+
+// entry is a job in the scheduler
+type entry struct {
+	id       int
+	name     string
+	schedule Schedule
+	job      Job
+	next     time.Time
+	seq      int // breaks ties in next, so jobs due together start in order added
+
+	// The fields below are guarded by Scheduler.mu
+	running bool
+	removed bool
+	runs    int
+	skipped int
+}
+
+// JobInfo describes a scheduled job
+type JobInfo struct {
+	ID      int
+	Name    string
+	Next    time.Time
+	Runs    int // runs started
+	Skipped int // runs skipped because the previous one was still going
+}
+
+// Scheduler runs jobs at the times their schedules give
+//
+// The jobs wait in a min-heap keyed by their next run time, so the loop
+// only ever looks at the top: it sleeps until that time, starts every job
+// that is due in its own goroutine, asks each one's schedule for its next
+// time and pushes it back. Adding a job that is due sooner wakes the loop
+// so it can sleep for less
+//
+// Like cron, a job whose previous run is still going is skipped rather than
+// started twice, and runs missed while the process was busy or the machine
+// asleep are not caught up on
+type Scheduler struct {
+	// OnError is called with the error of a failed run; the default logs it
+	// Set it before calling Run
+	OnError func(name string, err error)
+
+	mu      sync.Mutex
+	clock   resilience.Clock
+	queue   *datastructures.Heap[*entry]
+	entries map[int]*entry
+	nextID  int
+	seq     int
+	closed  bool
+
+	wake    chan struct{} // a buffered slot: "the top of the queue changed"
+	closing chan struct{} // closed by Shutdown to stop Run
+
+	running    sync.WaitGroup
+	jobCtx     context.Context
+	cancelJobs context.CancelFunc
+}
+
+// NewScheduler creates a scheduler that tells the time with clock, or the
+// real clock if it is nil
+func NewScheduler(clock resilience.Clock) *Scheduler {
+	if clock == nil {
+		clock = resilience.RealClock{}
+	}
+	s := &Scheduler{
+		clock:   clock,
+		entries: make(map[int]*entry),
+		wake:    make(chan struct{}, 1),
+		closing: make(chan struct{}),
+		queue: datastructures.NewHeapFunc(
+			ordering.ByFunc(func(e *entry) time.Time { return e.next }, time.Time.Compare).
+				ThenComparing(ordering.By(func(e *entry) int { return e.seq }))),
+	}
+	s.jobCtx, s.cancelJobs = context.WithCancel(context.Background())
+	return s
+}
+
+// Add schedules a job and returns its ID
+func (s *Scheduler) Add(name string, schedule Schedule, job Job) (int, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	if s.closed {
+		return 0, ErrSchedulerClosed
+	}
+	next := schedule.Next(s.clock.Now())
+	if next.IsZero() {
+		return 0, fmt.Errorf("%w: %s", ErrNeverRuns, name)
+	}
+	s.nextID++
+	e := &entry{id: s.nextID, name: name, schedule: schedule, job: job, next: next}
+	s.entries[e.id] = e
+	s.push(e)
+	// Run may be sleeping until a later job; wake it if this one is first
+	if top, _ := s.queue.Peek(); top == e {
+		select {
+		case s.wake <- struct{}{}:
+		default: // a wake-up is already pending
+		}
+	}
+	return e.id, nil
+}
+
+// push queues e; the caller must hold s.mu
+func (s *Scheduler) push(e *entry) {
+	s.seq++
+	e.seq = s.seq
+	s.queue.Push(e)
+}
+
+// Remove unschedules a job and reports whether it was scheduled
+// A run already going carries on
+func (s *Scheduler) Remove(id int) bool {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	e, ok := s.entries[id]
+	if !ok {
+		return false
+	}
+	// The heap can't remove from the middle, so the entry is marked and
+	// dropped when it reaches the top
+	e.removed = true
+	delete(s.entries, id)
+	return true
+}
+
+// Jobs returns the scheduled jobs, soonest first
+func (s *Scheduler) Jobs() []JobInfo {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	jobs := make([]JobInfo, 0, len(s.entries))
+	for _, e := range s.entries {
+		jobs = append(jobs, JobInfo{ID: e.id, Name: e.name, Next: e.next, Runs: e.runs, Skipped: e.skipped})
+	}
+	slices.SortFunc(jobs, ordering.ByFunc(func(j JobInfo) time.Time { return j.Next }, time.Time.Compare).
+		ThenComparing(ordering.By(func(j JobInfo) int { return j.ID })))
+	return jobs
+}
+
+// runDue starts every job due at now, schedules their next runs and
+// returns when the next job is due, or the zero time if none is left
+func (s *Scheduler) runDue(now time.Time) time.Time {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	for !s.closed {
+		e, err := s.queue.Peek()
+		if err != nil {
+			return time.Time{}
+		}
+		if e.removed {
+			s.queue.Pop()
+			continue
+		}
+		if e.next.After(now) {
+			return e.next
+		}
+		s.queue.Pop()
+
+		if e.running {
+			e.skipped++
+		} else {
+			e.running = true
+			e.runs++
+			s.running.Add(1)
+			go s.run(e, e.next)
+		}
+
+		// The next run is counted from now, not from e.next, so a job that
+		// was due long ago runs once instead of once per missed time
+		if e.next = e.schedule.Next(now); e.next.IsZero() {
+			delete(s.entries, e.id)
+			continue
+		}
+		s.push(e)
+	}
+	return time.Time{}
+}
+
+// run runs one job, turning a panic into an error
+func (s *Scheduler) run(e *entry, at time.Time) {
+	defer s.running.Done()
+	err := func() (err error) {
+		defer func() {
+			if r := recover(); r != nil {
+				err = fmt.Errorf("panic: %v", r)
+			}
+		}()
+		return e.job(s.jobCtx, at)
+	}()
+
+	s.mu.Lock()
+	e.running = false
+	s.mu.Unlock()
+	switch {
+	case err == nil:
+	case s.OnError != nil:
+		s.OnError(e.name, err)
+	default:
+		log.Printf("job %s (run at %s): %v", e.name, at.Format(time.TimeOnly), err)
+	}
+}
+
+// Run starts jobs as they come due until Shutdown, then returns
+// ErrSchedulerClosed, like http.Server.ListenAndServe
+func (s *Scheduler) Run() error {
+	for {
+		next := s.runDue(s.clock.Now())
+		var due <-chan time.Time // nil, never ready, when no job is left
+		if !next.IsZero() {
+			due = s.clock.After(next.Sub(s.clock.Now()))
+		}
+		select {
+		case <-due:
+		case <-s.wake:
+		case <-s.closing:
+			return ErrSchedulerClosed
+		}
+	}
+}
+
+// Shutdown stops starting jobs and waits for the running ones to finish
+// If ctx ends first, the jobs' context is cancelled and Shutdown returns
+// ctx.Err() without waiting any longer
+func (s *Scheduler) Shutdown(ctx context.Context) error {
+	s.mu.Lock()
+	if !s.closed {
+		s.closed = true
+		close(s.closing)
+	}
+	s.mu.Unlock()
+
+	done := make(chan struct{})
+	go func() {
+		s.running.Wait()
+		close(done)
+	}()
+	select {
+	case <-done:
+		s.cancelJobs()
+		return nil
+	case <-ctx.Done():
+		s.cancelJobs()
+		return ctx.Err()
+	}
+}
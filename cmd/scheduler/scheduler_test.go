@@ -0,0 +1,240 @@
+package main
+
+import (
+	"context"
+	"errors"
+	"slices"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/NutProhmpiriya/go-basic/04-design-patterns/resilience"
+)
+
+// recorder is a job that notes each run's scheduled time
+type recorder struct {
+	mu   sync.Mutex
+	runs []time.Time
+}
+
+func (r *recorder) job(ctx context.Context, at time.Time) error {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	r.runs = append(r.runs, at)
+	return nil
+}
+
+func (r *recorder) times() []time.Time {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	return slices.Clone(r.runs)
+}
+
+// newTestScheduler creates a scheduler whose clock reads at(0, 0, 0); the
+// tests then call runDue with the times they want, without Run
+func newTestScheduler(t *testing.T) *Scheduler {
+	t.Helper()
+	s := NewScheduler(resilience.NewFakeClock(at(0, 0, 0)))
+	s.OnError = func(name string, err error) { t.Errorf("job %s: %v", name, err) }
+	t.Cleanup(func() { s.Shutdown(context.Background()) })
+	return s
+}
+
+func TestRunDue(t *testing.T) {
+	s := newTestScheduler(t)
+	var every, once, cron recorder
+	s.Add("every", Every(10*time.Minute), every.job)
+	s.Add("once", At(at(0, 0, 25)), once.job)
+	hourly, _ := ParseCron("@hourly")
+	s.Add("cron", hourly, cron.job)
+
+	// Each call runs what is due and says when to wake up next
+	steps := []struct{ now, next time.Time }{
+		{at(0, 0, 5), at(0, 0, 10)},
+		{at(0, 0, 10), at(0, 0, 20)},
+		{at(0, 0, 20), at(0, 0, 25)},
+		{at(0, 0, 25), at(0, 0, 30)},
+		// Waking late runs each due job once and counts on from now
+		{at(0, 1, 2), at(0, 1, 12)},
+	}
+	for _, step := range steps {
+		if next := s.runDue(step.now); !next.Equal(step.next) {
+			t.Errorf("runDue(%s) = %s, want %s", step.now.Format(time.TimeOnly), next.Format(time.TimeOnly), step.next.Format(time.TimeOnly))
+		}
+		s.running.Wait()
+	}
+
+	if got, want := every.times(), []time.Time{at(0, 0, 10), at(0, 0, 20), at(0, 0, 30)}; !slices.Equal(got, want) {
+		t.Errorf("every ran at %v, want %v", got, want)
+	}
+	if got, want := once.times(), []time.Time{at(0, 0, 25)}; !slices.Equal(got, want) {
+		t.Errorf("once ran at %v, want %v", got, want)
+	}
+	if got, want := cron.times(), []time.Time{at(0, 1, 0)}; !slices.Equal(got, want) {
+		t.Errorf("cron ran at %v, want %v", got, want)
+	}
+
+	// The one-shot job is gone once it has run
+	var names []string
+	for _, info := range s.Jobs() {
+		names = append(names, info.Name)
+	}
+	if want := []string{"every", "cron"}; !slices.Equal(names, want) {
+		t.Errorf("Jobs() = %v, want %v", names, want)
+	}
+}
+
+func TestAddErrors(t *testing.T) {
+	s := newTestScheduler(t)
+	if _, err := s.Add("past", At(at(-1, 0, 0)), (&recorder{}).job); !errors.Is(err, ErrNeverRuns) {
+		t.Errorf("Add with a past time error = %v, want ErrNeverRuns", err)
+	}
+	s.Shutdown(context.Background())
+	if _, err := s.Add("late", Every(time.Minute), (&recorder{}).job); !errors.Is(err, ErrSchedulerClosed) {
+		t.Errorf("Add after Shutdown error = %v, want ErrSchedulerClosed", err)
+	}
+}
+
+// A job still running when it is due again is skipped, not started twice
+func TestOverlapSkipped(t *testing.T) {
+	s := newTestScheduler(t)
+	release := make(chan struct{})
+	started := make(chan struct{}, 10)
+	id, _ := s.Add("slow", Every(time.Minute), func(ctx context.Context, at time.Time) error {
+		started <- struct{}{}
+		<-release
+		return nil
+	})
+
+	s.runDue(at(0, 0, 1))
+	<-started
+	s.runDue(at(0, 0, 2))
+	s.runDue(at(0, 0, 3))
+	close(release)
+	s.running.Wait()
+	s.runDue(at(0, 0, 4))
+	s.running.Wait()
+
+	info := s.Jobs()[0]
+	if info.ID != id || info.Runs != 2 || info.Skipped != 2 {
+		t.Errorf("Jobs()[0] = %+v, want 2 runs and 2 skipped", info)
+	}
+}
+
+func TestRemove(t *testing.T) {
+	s := newTestScheduler(t)
+	var kept, removed recorder
+	s.Add("kept", Every(2*time.Minute), kept.job)
+	id, _ := s.Add("removed", Every(time.Minute), removed.job)
+	if !s.Remove(id) {
+		t.Fatal("Remove returned false for a scheduled job")
+	}
+	if s.Remove(id) {
+		t.Error("Remove returned true for a job already removed")
+	}
+	// The removed entry is dropped from the top of the queue
+	if next := s.runDue(at(0, 0, 1)); !next.Equal(at(0, 0, 2)) {
+		t.Errorf("runDue = %s, want the kept job's time", next.Format(time.TimeOnly))
+	}
+	s.runDue(at(0, 0, 2))
+	s.running.Wait()
+	if len(removed.times()) != 0 || len(kept.times()) != 1 {
+		t.Errorf("removed ran %d times, kept %d, want 0 and 1", len(removed.times()), len(kept.times()))
+	}
+}
+
+// Errors and panics go to OnError, and don't stop the job's schedule
+func TestJobErrors(t *testing.T) {
+	s := NewScheduler(resilience.NewFakeClock(at(0, 0, 0)))
+	defer s.Shutdown(context.Background())
+	var mu sync.Mutex
+	var got []string
+	s.OnError = func(name string, err error) {
+		mu.Lock()
+		defer mu.Unlock()
+		got = append(got, name+": "+err.Error())
+	}
+	s.Add("fails", Every(time.Minute), func(ctx context.Context, at time.Time) error {
+		return errors.New("disk full")
+	})
+	s.Add("panics", Every(time.Minute), func(ctx context.Context, at time.Time) error {
+		panic("oops")
+	})
+	for minute := range 2 {
+		s.runDue(at(0, 0, minute+1))
+		s.running.Wait()
+	}
+	slices.Sort(got)
+	want := []string{"fails: disk full", "fails: disk full", "panics: panic: oops", "panics: panic: oops"}
+	if !slices.Equal(got, want) {
+		t.Errorf("OnError calls = %q, want %q", got, want)
+	}
+}
+
+// Shutdown waits for running jobs, and cancels their context when its own
+// runs out first
+func TestShutdown(t *testing.T) {
+	s := newTestScheduler(t)
+	started := make(chan struct{})
+	cancelled := make(chan struct{})
+	s.Add("stubborn", Every(time.Minute), func(ctx context.Context, at time.Time) error {
+		close(started)
+		<-ctx.Done()
+		close(cancelled)
+		return nil
+	})
+	s.runDue(at(0, 0, 1))
+	<-started
+
+	ctx, cancel := context.WithTimeout(context.Background(), 20*time.Millisecond)
+	defer cancel()
+	if err := s.Shutdown(ctx); !errors.Is(err, context.DeadlineExceeded) {
+		t.Errorf("Shutdown error = %v, want DeadlineExceeded", err)
+	}
+	select {
+	case <-cancelled:
+	case <-time.After(5 * time.Second):
+		t.Fatal("the job's context was not cancelled")
+	}
+	// Nothing more starts after Shutdown
+	if next := s.runDue(at(0, 1, 0)); !next.IsZero() {
+		t.Errorf("runDue after Shutdown = %s, want zero", next)
+	}
+}
+
+// Run on the real clock: a job added while Run sleeps with nothing to do
+// wakes it, and Shutdown makes Run return
+func TestRun(t *testing.T) {
+	s := NewScheduler(nil)
+	runErr := make(chan error, 1)
+	go func() { runErr <- s.Run() }()
+
+	ran := make(chan time.Time, 1)
+	when := time.Now().Add(20 * time.Millisecond)
+	if _, err := s.Add("soon", At(when), func(ctx context.Context, at time.Time) error {
+		ran <- at
+		return nil
+	}); err != nil {
+		t.Fatal(err)
+	}
+	select {
+	case at := <-ran:
+		if !at.Equal(when) {
+			t.Errorf("job ran for %s, want %s", at, when)
+		}
+	case <-time.After(5 * time.Second):
+		t.Fatal("the job did not run")
+	}
+
+	if err := s.Shutdown(context.Background()); err != nil {
+		t.Errorf("Shutdown error = %v", err)
+	}
+	select {
+	case err := <-runErr:
+		if !errors.Is(err, ErrSchedulerClosed) {
+			t.Errorf("Run returned %v, want ErrSchedulerClosed", err)
+		}
+	case <-time.After(5 * time.Second):
+		t.Fatal("Run did not return after Shutdown")
+	}
+}
@@ -0,0 +1,79 @@
+package main
+
+import (
+	"fmt"
+	"os"
+	"slices"
+	"strconv"
+
+	"github.com/NutProhmpiriya/go-basic/04-design-patterns/creational"
+	"github.com/NutProhmpiriya/go-basic/04-design-patterns/plugins"
+)
+
+// Config is the shortener's configuration, read from environment variables:
+//
+//	SHORTENER_ADDR        address to listen on (default localhost:8080)
+//	SHORTENER_BASE_URL    prefix of the short links (default http:// + the address)
+//	SHORTENER_STORAGE     storage driver: memory or file (default memory)
+//	SHORTENER_DSN         driver data source, the file path for file
+//	SHORTENER_IDS         ID strategy: counter, random or hash (default counter)
+//	SHORTENER_CACHE_SIZE  links kept in the LRU cache (default 1000)
+type Config struct {
+	Addr      string
+	BaseURL   string
+	Storage   string
+	DSN       string
+	IDs       string
+	CacheSize int
+
+	// err records a variable that could not be parsed, reported by Validate
+	err error
+}
+
+// config is the Singleton holding the Config: the environment is read once,
+// on first use, and every part of the program sees the same settings
+var config = creational.NewLazySingleton(loadConfig)
+
+// loadConfig reads the Config from the environment
+func loadConfig() Config {
+	c := Config{
+		Addr:      getenv("SHORTENER_ADDR", "localhost:8080"),
+		Storage:   getenv("SHORTENER_STORAGE", "memory"),
+		DSN:       os.Getenv("SHORTENER_DSN"),
+		IDs:       getenv("SHORTENER_IDS", "counter"),
+		CacheSize: 1000,
+	}
+	c.BaseURL = getenv("SHORTENER_BASE_URL", "http://"+c.Addr)
+	if s := os.Getenv("SHORTENER_CACHE_SIZE"); s != "" {
+		n, err := strconv.Atoi(s)
+		if err != nil {
+			c.err = fmt.Errorf("SHORTENER_CACHE_SIZE: %w", err)
+		}
+		c.CacheSize = n
+	}
+	return c
+}
+
+// getenv returns the environment variable key, or fallback if it is unset
+// or empty
+func getenv(key, fallback string) string {
+	if v := os.Getenv(key); v != "" {
+		return v
+	}
+	return fallback
+}
+
+// Validate reports the first setting the shortener can't start with
+func (c Config) Validate() error {
+	switch {
+	case c.err != nil:
+		return c.err
+	case c.CacheSize < 1:
+		return fmt.Errorf("SHORTENER_CACHE_SIZE is %d, need at least 1", c.CacheSize)
+	case !slices.Contains(plugins.Drivers(), c.Storage):
+		return fmt.Errorf("SHORTENER_STORAGE %q is not one of %v", c.Storage, plugins.Drivers())
+	case !slices.Contains(idStrategies, c.IDs):
+		return fmt.Errorf("SHORTENER_IDS %q is not one of %v", c.IDs, idStrategies)
+	}
+	return nil
+}
@@ -0,0 +1,119 @@
+package main
+
+import (
+	"crypto/sha256"
+	"encoding/binary"
+	"fmt"
+	"math/rand"
+	"strconv"
+	"sync"
+	"time"
+)
+
+// base62 are the characters of a code: letters and digits need no escaping
+// in a URL
+const base62 = "0123456789abcdefghijklmnopqrstuvwxyzABCDEFGHIJKLMNOPQRSTUVWXYZ"
+
+// codeLength is the length of random and hash codes: 62^7 is about 3.5
+// trillion codes
+const codeLength = 7
+
+// IDStrategy is the Strategy for making the short code of a URL
+// attempt counts from 0 and goes up each time the code returned for this
+// URL was already taken, so a strategy that would give the same code again
+// can vary it
+type IDStrategy interface {
+	NewID(url string, attempt int) (string, error)
+}
+
+// idStrategies are the names newIDStrategy accepts
+var idStrategies = []string{"counter", "random", "hash"}
+
+// newIDStrategy creates the named strategy; counter keeps its sequence in
+// links
+func newIDStrategy(name string, links *LinkRepository) (IDStrategy, error) {
+	switch name {
+	case "counter":
+		return CounterIDs{next: links.NextSequence}, nil
+	case "random":
+		return newRandomIDs(time.Now().UnixNano()), nil
+	case "hash":
+		return HashIDs{}, nil
+	}
+	return nil, fmt.Errorf("unknown ID strategy %q", name)
+}
+
+// encodeBase62 writes n in base 62
+func encodeBase62(n uint64) string {
+	if n == 0 {
+		return base62[:1]
+	}
+	var buf [11]byte // 62^11 > 2^64
+	i := len(buf)
+	for n > 0 {
+		i--
+		buf[i] = base62[n%62]
+		n /= 62
+	}
+	return string(buf[i:])
+}
+
+// CounterIDs numbers the links 1, 2, 3, ... in base 62
+// Codes are as short as they can be, but anyone can guess the next one and
+// count the links
+type CounterIDs struct {
+	next func() (uint64, error)
+}
+
+// NewID returns the next number; the URL is not used
+func (c CounterIDs) NewID(url string, attempt int) (string, error) {
+	n, err := c.next()
+	if err != nil {
+		return "", err
+	}
+	return encodeBase62(n), nil
+}
+
+// RandomIDs picks codeLength random characters
+// Codes can't be guessed from one another, and shortening the same URL
+// twice gives two links
+type RandomIDs struct {
+	mu  sync.Mutex
+	rng *rand.Rand
+}
+
+// newRandomIDs creates a RandomIDs; the seed makes the codes repeatable
+func newRandomIDs(seed int64) *RandomIDs {
+	return &RandomIDs{rng: rand.New(rand.NewSource(seed))}
+}
+
+// NewID returns a random code; a retry simply draws again
+func (r *RandomIDs) NewID(url string, attempt int) (string, error) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	code := make([]byte, codeLength)
+	for i := range code {
+		code[i] = base62[r.rng.Intn(len(base62))]
+	}
+	return string(code), nil
+}
+
+// HashIDs derives the code from a SHA-256 hash of the URL
+// The same URL always gets the same code, so shortening it again returns
+// the existing link instead of a new one
+type HashIDs struct{}
+
+// NewID hashes the URL, with the attempt appended after a collision so two
+// URLs with the same code get different ones
+func (HashIDs) NewID(url string, attempt int) (string, error) {
+	if attempt > 0 {
+		url += "#" + strconv.Itoa(attempt)
+	}
+	sum := sha256.Sum256([]byte(url))
+	code := encodeBase62(binary.BigEndian.Uint64(sum[:8]))
+	// Pad short values so every code has the same length
+	for len(code) < codeLength {
+		code = base62[:1] + code
+	}
+	return code[:codeLength], nil
+}
@@ -0,0 +1,176 @@
+// Command shortener is a URL shortener, a small project that shows how the
+// design patterns and data structures of this repository fit together:
+//   - Singleton: the configuration is read from the environment once, by
+//     creational.LazySingleton, and shared by the whole program
+//   - Factory: plugins.Open creates the storage backend from a driver name
+//     in the configuration, memory or file
+//   - Strategy: the short codes come from an IDStrategy, a counter, random
+//     characters or a hash of the URL
+//   - Repository: LinkRepository turns the key-value store into Links, so
+//     nothing else depends on how they are stored
+//   - LRU cache: datastructures.LRU keeps the most used links in memory in
+//     front of the store
+//
+// Usage:
+//
+//	go run ./cmd/shortener          serve the API on SHORTENER_ADDR
+//	go run ./cmd/shortener -demo    run a scripted session and exit
+//
+// The environment variables are listed on Config, for example:
+//
+//	SHORTENER_STORAGE=file SHORTENER_DSN=links.txt SHORTENER_IDS=hash go run ./cmd/shortener
+//	curl -d '{"url":"https://go.dev/doc"}' localhost:8080/api/links
+//	curl -i localhost:8080/<code>
+package main
+
+import (
+	"context"
+	"errors"
+	"flag"
+	"fmt"
+	"io"
+	"log"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"os/signal"
+	"strings"
+	"syscall"
+	"time"
+)
+
+// serve runs the API until an interrupt, then shuts down gracefully
+func serve(s *Shortener, cfg Config) error {
+	// Always set timeouts on public servers
+	server := &http.Server{
+		Addr:              cfg.Addr,
+		Handler:           newHandler(s, cfg.BaseURL),
+		ReadHeaderTimeout: 5 * time.Second,
+		ReadTimeout:       10 * time.Second,
+		WriteTimeout:      10 * time.Second,
+	}
+
+	ctx, stop := signal.NotifyContext(context.Background(), os.Interrupt, syscall.SIGTERM)
+	defer stop()
+
+	errc := make(chan error, 1)
+	go func() {
+		log.Printf("shortener listening on http://%s (storage %s, %s IDs, cache of %d)", cfg.Addr, cfg.Storage, cfg.IDs, cfg.CacheSize)
+		errc <- server.ListenAndServe()
+	}()
+
+	select {
+	case err := <-errc:
+		return err
+	case <-ctx.Done():
+	}
+
+	shutdownCtx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+	defer cancel()
+	if err := server.Shutdown(shutdownCtx); err != nil && !errors.Is(err, http.ErrServerClosed) {
+		return err
+	}
+	return nil
+}
+
+// demo shortens the same URLs with every ID strategy, then runs a session
+// against the API of s
+func demo(w io.Writer, s *Shortener, cfg Config) error {
+	fmt.Fprintln(w, "ID strategies, shortening three URLs, the first one twice:")
+	urls := []string{"https://go.dev", "https://go.dev/doc", "https://pkg.go.dev", "https://go.dev"}
+	for _, name := range idStrategies {
+		links, err := openLinks("memory", "")
+		if err != nil {
+			return err
+		}
+		var ids IDStrategy = HashIDs{}
+		switch name {
+		case "counter":
+			ids = CounterIDs{next: links.NextSequence}
+		case "random":
+			// A fixed seed so the demo prints the same codes every time
+			ids = newRandomIDs(1)
+		}
+		shortener := NewShortener(links, ids, len(urls))
+		var codes []string
+		for _, u := range urls {
+			link, err := shortener.Shorten(u)
+			if err != nil {
+				return err
+			}
+			codes = append(codes, link.Code)
+		}
+		fmt.Fprintf(w, "  %-8s %s\n", name, strings.Join(codes, " "))
+	}
+
+	fmt.Fprintf(w, "\nSession with storage %s, %s IDs and a cache of %d:\n", cfg.Storage, cfg.IDs, cfg.CacheSize)
+	server := httptest.NewServer(newHandler(s, cfg.BaseURL))
+	defer server.Close()
+	// Show the redirects instead of following them
+	client := server.Client()
+	client.CheckRedirect = func(*http.Request, []*http.Request) error { return http.ErrUseLastResponse }
+
+	send := func(method, path, body string) error {
+		req, err := http.NewRequest(method, server.URL+path, strings.NewReader(body))
+		if err != nil {
+			return err
+		}
+		resp, err := client.Do(req)
+		if err != nil {
+			return err
+		}
+		defer resp.Body.Close()
+		reply, err := io.ReadAll(resp.Body)
+		if err != nil {
+			return err
+		}
+		fmt.Fprintf(w, "  %s %s -> %s", method, path, resp.Status)
+		if location := resp.Header.Get("Location"); location != "" {
+			fmt.Fprintf(w, ", Location: %s", location)
+		}
+		if len(reply) > 0 && resp.Header.Get("Content-Type") == "application/json" {
+			fmt.Fprintf(w, "\n      %s", strings.TrimSpace(string(reply)))
+		}
+		fmt.Fprintln(w)
+		return nil
+	}
+
+	link, err := s.Shorten("https://go.dev/doc/effective_go")
+	if err != nil {
+		return err
+	}
+	for _, r := range []struct{ method, path, body string }{
+		{"POST", "/api/links", `{"url":"https://go.dev/tour"}`},
+		{"POST", "/api/links", `{"url":"ftp://example.com"}`},
+		{"GET", "/" + link.Code, ""},
+		{"GET", "/" + link.Code, ""},
+		{"GET", "/api/links/nope", ""},
+		{"GET", "/api/stats", ""},
+	} {
+		if err := send(r.method, r.path, r.body); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+func main() {
+	runDemo := flag.Bool("demo", false, "run a scripted session against an in-process server and exit")
+	flag.Parse()
+
+	cfg := config.Get()
+	s, err := newShortenerFromConfig(cfg)
+	if err != nil {
+		log.Fatal(err)
+	}
+	defer s.Close()
+
+	if *runDemo {
+		err = demo(os.Stdout, s, cfg)
+	} else {
+		err = serve(s, cfg)
+	}
+	if err != nil {
+		log.Fatal(err)
+	}
+}
@@ -0,0 +1,237 @@
+package main
+
+import (
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"path/filepath"
+	"strings"
+	"testing"
+)
+
+// newTestShortener returns a shortener on a fresh memory store
+func newTestShortener(t *testing.T, ids string, cacheSize int) *Shortener {
+	t.Helper()
+	s, err := newShortenerFromConfig(Config{Storage: "memory", IDs: ids, CacheSize: cacheSize})
+	if err != nil {
+		t.Fatal(err)
+	}
+	t.Cleanup(func() { s.Close() })
+	return s
+}
+
+func TestConfig(t *testing.T) {
+	t.Cleanup(config.ResetForTesting)
+
+	t.Setenv("SHORTENER_ADDR", "localhost:9000")
+	t.Setenv("SHORTENER_IDS", "hash")
+	config.ResetForTesting()
+	cfg := config.Get()
+	want := Config{Addr: "localhost:9000", BaseURL: "http://localhost:9000", Storage: "memory", IDs: "hash", CacheSize: 1000}
+	if cfg != want {
+		t.Errorf("config = %+v, want %+v", cfg, want)
+	}
+	if err := cfg.Validate(); err != nil {
+		t.Error(err)
+	}
+
+	// The environment is read once: later changes are not seen until a reset
+	t.Setenv("SHORTENER_IDS", "counter")
+	if got := config.Get().IDs; got != "hash" {
+		t.Errorf("IDs = %q after changing the environment, want the first value", got)
+	}
+
+	for _, tt := range []struct{ key, value, want string }{
+		{"SHORTENER_CACHE_SIZE", "lots", "SHORTENER_CACHE_SIZE"},
+		{"SHORTENER_CACHE_SIZE", "0", "at least 1"},
+		{"SHORTENER_STORAGE", "postgres", "SHORTENER_STORAGE"},
+		{"SHORTENER_IDS", "uuid", "SHORTENER_IDS"},
+	} {
+		t.Run(tt.key+"="+tt.value, func(t *testing.T) {
+			t.Setenv(tt.key, tt.value)
+			config.ResetForTesting()
+			if err := config.Get().Validate(); err == nil || !strings.Contains(err.Error(), tt.want) {
+				t.Errorf("Validate() = %v, want an error about %s", err, tt.want)
+			}
+		})
+	}
+}
+
+func TestEncodeBase62(t *testing.T) {
+	for n, want := range map[uint64]string{0: "0", 9: "9", 10: "a", 61: "Z", 62: "10", 3843: "ZZ", 1<<64 - 1: "lYGhA16ahyf"} {
+		if got := encodeBase62(n); got != want {
+			t.Errorf("encodeBase62(%d) = %q, want %q", n, got, want)
+		}
+	}
+}
+
+func TestIDStrategies(t *testing.T) {
+	tests := []struct {
+		ids string
+		// sameURLSameCode says whether shortening a URL again returns the
+		// first link
+		sameURLSameCode bool
+		codeLength      int
+	}{
+		{"counter", false, 1},
+		{"random", false, codeLength},
+		{"hash", true, codeLength},
+	}
+	for _, tt := range tests {
+		t.Run(tt.ids, func(t *testing.T) {
+			s := newTestShortener(t, tt.ids, 10)
+			codes := make(map[string]string)
+			for _, u := range []string{"https://a.example", "https://b.example", "http://c.example/x?y=1"} {
+				link, err := s.Shorten(u)
+				if err != nil {
+					t.Fatal(err)
+				}
+				if len(link.Code) != tt.codeLength || strings.Trim(link.Code, base62) != "" {
+					t.Errorf("code %q is not %d base 62 characters", link.Code, tt.codeLength)
+				}
+				if other, dup := codes[link.Code]; dup {
+					t.Errorf("%s and %s share the code %s", other, u, link.Code)
+				}
+				codes[link.Code] = u
+			}
+			again, err := s.Shorten("https://a.example")
+			if err != nil {
+				t.Fatal(err)
+			}
+			if _, seen := codes[again.Code]; seen != tt.sameURLSameCode {
+				t.Errorf("shortening a URL again gave code %s, reused: %v, want %v", again.Code, seen, tt.sameURLSameCode)
+			}
+		})
+	}
+}
+
+// collidingIDs gives the same code on the first attempt for every URL
+type collidingIDs struct{}
+
+func (collidingIDs) NewID(url string, attempt int) (string, error) {
+	if attempt == 0 {
+		return "same", nil
+	}
+	return HashIDs{}.NewID(url, attempt)
+}
+
+func TestShortenRetriesTakenCodes(t *testing.T) {
+	s := newTestShortener(t, "counter", 10)
+	s.ids = collidingIDs{}
+	first, err := s.Shorten("https://a.example")
+	if err != nil {
+		t.Fatal(err)
+	}
+	second, err := s.Shorten("https://b.example")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if first.Code != "same" || second.Code == "same" {
+		t.Errorf("codes %s and %s, want same and a retried one", first.Code, second.Code)
+	}
+	if link, err := s.Resolve(second.Code); err != nil || link.URL != "https://b.example" {
+		t.Errorf("Resolve(%s) = %+v, %v", second.Code, link, err)
+	}
+}
+
+func TestShortenRejectsBadURLs(t *testing.T) {
+	s := newTestShortener(t, "counter", 10)
+	for _, u := range []string{"", "go.dev", "ftp://go.dev", "https://", "http://a b.example", "https://go.dev/\n"} {
+		if _, err := s.Shorten(u); !errors.Is(err, errInvalidURL) {
+			t.Errorf("Shorten(%q) error = %v, want errInvalidURL", u, err)
+		}
+	}
+}
+
+func TestCache(t *testing.T) {
+	s := newTestShortener(t, "counter", 2)
+	var codes []string
+	for _, u := range []string{"https://a.example", "https://b.example", "https://c.example"} {
+		link, err := s.Shorten(u)
+		if err != nil {
+			t.Fatal(err)
+		}
+		codes = append(codes, link.Code)
+	}
+	// The cache holds the two newest links, so the first is read from the
+	// store and then cached, pushing out the second
+	for _, code := range []string{codes[2], codes[0], codes[0], codes[1]} {
+		if _, err := s.Resolve(code); err != nil {
+			t.Fatal(err)
+		}
+	}
+	if _, err := s.Resolve("missing"); !errors.Is(err, ErrLinkNotFound) {
+		t.Errorf("Resolve(missing) error = %v, want ErrLinkNotFound", err)
+	}
+	want := CacheStats{Size: 2, Capacity: 2, Hits: 2, Misses: 3}
+	if got := s.Stats(); got != want {
+		t.Errorf("Stats() = %+v, want %+v", got, want)
+	}
+}
+
+// Links and the counter survive a restart with the file driver
+func TestFileStorage(t *testing.T) {
+	cfg := Config{Storage: "file", DSN: filepath.Join(t.TempDir(), "links.txt"), IDs: "counter", CacheSize: 10}
+	s, err := newShortenerFromConfig(cfg)
+	if err != nil {
+		t.Fatal(err)
+	}
+	first, err := s.Shorten("https://go.dev/?q=a=b")
+	if err != nil {
+		t.Fatal(err)
+	}
+	s.Close()
+
+	s, err = newShortenerFromConfig(cfg)
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer s.Close()
+	if link, err := s.Resolve(first.Code); err != nil || link != first {
+		t.Errorf("after a restart Resolve(%s) = %+v, %v, want %+v", first.Code, link, err, first)
+	}
+	second, err := s.Shorten("https://go.dev/doc")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if first.Code != "1" || second.Code != "2" {
+		t.Errorf("codes %s and %s, want 1 and 2", first.Code, second.Code)
+	}
+}
+
+func TestHandler(t *testing.T) {
+	handler := newHandler(newTestShortener(t, "counter", 10), "https://sho.rt/")
+	do := func(method, path, body string) *httptest.ResponseRecorder {
+		w := httptest.NewRecorder()
+		handler.ServeHTTP(w, httptest.NewRequest(method, path, strings.NewReader(body)))
+		return w
+	}
+
+	tests := []struct {
+		method, path, body string
+		status             int
+		want               string
+	}{
+		{"POST", "/api/links", `{"url":"https://go.dev"}`, http.StatusCreated, `{"code":"1","url":"https://go.dev","short_url":"https://sho.rt/1"}`},
+		{"POST", "/api/links", `{"url":"go.dev"}`, http.StatusBadRequest, `{"error":"invalid URL: \"go.dev\" needs to start with http:// or https://"}`},
+		{"POST", "/api/links", `url=https://go.dev`, http.StatusBadRequest, `{"error":"body must be JSON like {\"url\": \"https://example.com\"}"}`},
+		{"GET", "/api/links/1", "", http.StatusOK, `{"code":"1","url":"https://go.dev","short_url":"https://sho.rt/1"}`},
+		{"GET", "/api/links/2", "", http.StatusNotFound, `{"error":"link not found: 2"}`},
+		{"GET", "/api/stats", "", http.StatusOK, `{"size":1,"capacity":10,"hits":1,"misses":1}`},
+		{"DELETE", "/api/links/1", "", http.StatusMethodNotAllowed, "Method Not Allowed"},
+	}
+	for _, tt := range tests {
+		w := do(tt.method, tt.path, tt.body)
+		if got := strings.TrimSpace(w.Body.String()); w.Code != tt.status || got != tt.want {
+			t.Errorf("%s %s: status %d, body %s; want %d, %s", tt.method, tt.path, w.Code, got, tt.status, tt.want)
+		}
+	}
+
+	w := do("GET", "/1", "")
+	if w.Code != http.StatusFound || w.Header().Get("Location") != "https://go.dev" {
+		t.Errorf("GET /1: status %d, Location %q; want a redirect to https://go.dev", w.Code, w.Header().Get("Location"))
+	}
+	if w := do("GET", "/nope", ""); w.Code != http.StatusNotFound {
+		t.Errorf("GET /nope: status %d, want 404", w.Code)
+	}
+}
@@ -0,0 +1,102 @@
+package main
+
+import (
+	"errors"
+	"fmt"
+	"strconv"
+	"sync"
+
+	"github.com/NutProhmpiriya/go-basic/04-design-patterns/plugins"
+	// The storage drivers register themselves with the plugins factory
+	_ "github.com/NutProhmpiriya/go-basic/04-design-patterns/plugins/filestore"
+	_ "github.com/NutProhmpiriya/go-basic/04-design-patterns/plugins/memory"
+)
+
+var (
+	// ErrLinkNotFound is returned for a code that no link has
+	ErrLinkNotFound = errors.New("link not found")
+	// ErrCodeTaken is returned when creating a link whose code is in use
+	ErrCodeTaken = errors.New("code already taken")
+)
+
+// Link is a short code and the URL it redirects to
+type Link struct {
+	Code string `json:"code"`
+	URL  string `json:"url"`
+}
+
+// LinkRepository stores links in a key-value plugins.Store
+// It is the only code that knows how links are laid out in the store, so
+// the rest of the program works with Links whatever the driver:
+//
+//	link:<code>  the URL
+//	seq          the last number handed out by NextSequence
+type LinkRepository struct {
+	// mu makes the read-then-write of Create and NextSequence atomic; the
+	// Store only makes single calls safe
+	mu    sync.Mutex
+	store plugins.Store
+}
+
+// openLinks creates the repository on a store made by the storage factory,
+// plugins.Open, from the driver name and dsn in the configuration
+func openLinks(driver, dsn string) (*LinkRepository, error) {
+	store, err := plugins.Open(driver, dsn)
+	if err != nil {
+		return nil, err
+	}
+	return &LinkRepository{store: store}, nil
+}
+
+// Find returns the link with code
+func (r *LinkRepository) Find(code string) (Link, error) {
+	url, err := r.store.Get("link:" + code)
+	if errors.Is(err, plugins.ErrKeyNotFound) {
+		return Link{}, fmt.Errorf("%w: %s", ErrLinkNotFound, code)
+	}
+	if err != nil {
+		return Link{}, err
+	}
+	return Link{Code: code, URL: url}, nil
+}
+
+// Create stores a new link, or returns ErrCodeTaken if its code is in use
+func (r *LinkRepository) Create(link Link) error {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	_, err := r.store.Get("link:" + link.Code)
+	switch {
+	case err == nil:
+		return fmt.Errorf("%w: %s", ErrCodeTaken, link.Code)
+	case !errors.Is(err, plugins.ErrKeyNotFound):
+		return err
+	}
+	return r.store.Set("link:"+link.Code, link.URL)
+}
+
+// NextSequence returns 1, 2, 3, ... on successive calls
+// The last number is kept in the store, so a file store carries on where
+// it left off after a restart
+func (r *LinkRepository) NextSequence() (uint64, error) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	var last uint64
+	s, err := r.store.Get("seq")
+	switch {
+	case err == nil:
+		if last, err = strconv.ParseUint(s, 10, 64); err != nil {
+			return 0, fmt.Errorf("stored sequence: %w", err)
+		}
+	case !errors.Is(err, plugins.ErrKeyNotFound):
+		return 0, err
+	}
+	if err := r.store.Set("seq", strconv.FormatUint(last+1, 10)); err != nil {
+		return 0, err
+	}
+	return last + 1, nil
+}
+
+// Close closes the store
+func (r *LinkRepository) Close() error {
+	return r.store.Close()
+}
@@ -0,0 +1,95 @@
+package main
+
+import (
+	"encoding/json"
+	"errors"
+	"log"
+	"net/http"
+	"strings"
+)
+
+// maxBodyBytes caps the size of a request body
+const maxBodyBytes = 64 << 10
+
+// linkResponse is a link as the API returns it
+type linkResponse struct {
+	Link
+	ShortURL string `json:"short_url"`
+}
+
+// newHandler returns the shortener's routes; baseURL prefixes the short
+// links in responses
+//
+//	POST /api/links         shorten the URL in the JSON body {"url": "..."}
+//	GET  /api/links/{code}  the link with code
+//	GET  /api/stats         the cache statistics
+//	GET  /{code}            redirect to the link's URL
+func newHandler(s *Shortener, baseURL string) http.Handler {
+	baseURL = strings.TrimSuffix(baseURL, "/")
+	respond := func(w http.ResponseWriter, status int, link Link) {
+		writeJSON(w, status, linkResponse{link, baseURL + "/" + link.Code})
+	}
+
+	mux := http.NewServeMux()
+	mux.HandleFunc("POST /api/links", func(w http.ResponseWriter, r *http.Request) {
+		var req struct {
+			URL string `json:"url"`
+		}
+		if err := json.NewDecoder(http.MaxBytesReader(w, r.Body, maxBodyBytes)).Decode(&req); err != nil {
+			writeError(w, http.StatusBadRequest, "body must be JSON like {\"url\": \"https://example.com\"}")
+			return
+		}
+		link, err := s.Shorten(req.URL)
+		if err != nil {
+			writeServiceError(w, err)
+			return
+		}
+		respond(w, http.StatusCreated, link)
+	})
+	mux.HandleFunc("GET /api/links/{code}", func(w http.ResponseWriter, r *http.Request) {
+		link, err := s.Resolve(r.PathValue("code"))
+		if err != nil {
+			writeServiceError(w, err)
+			return
+		}
+		respond(w, http.StatusOK, link)
+	})
+	mux.HandleFunc("GET /api/stats", func(w http.ResponseWriter, r *http.Request) {
+		writeJSON(w, http.StatusOK, s.Stats())
+	})
+	mux.HandleFunc("GET /{code}", func(w http.ResponseWriter, r *http.Request) {
+		link, err := s.Resolve(r.PathValue("code"))
+		if err != nil {
+			writeServiceError(w, err)
+			return
+		}
+		http.Redirect(w, r, link.URL, http.StatusFound)
+	})
+	return mux
+}
+
+// writeServiceError turns a Shortener error into a status code
+func writeServiceError(w http.ResponseWriter, err error) {
+	switch {
+	case errors.Is(err, ErrLinkNotFound):
+		writeError(w, http.StatusNotFound, err.Error())
+	case errors.Is(err, errInvalidURL):
+		writeError(w, http.StatusBadRequest, err.Error())
+	default:
+		log.Print(err)
+		writeError(w, http.StatusInternalServerError, "internal error")
+	}
+}
+
+func writeJSON(w http.ResponseWriter, status int, v any) {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(status)
+	if err := json.NewEncoder(w).Encode(v); err != nil {
+		log.Printf("writing response: %v", err)
+	}
+}
+
+// writeError sends {"error": message}
+func writeError(w http.ResponseWriter, status int, message string) {
+	writeJSON(w, status, map[string]string{"error": message})
+}
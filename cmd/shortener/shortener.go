@@ -0,0 +1,156 @@
+package main
+
+import (
+	"errors"
+	"fmt"
+	"net/url"
+	"sync"
+
+	"github.com/NutProhmpiriya/go-basic/02-data-structures/datastructures"
+)
+
+// maxAttempts bounds the codes tried for one URL before giving up
+const maxAttempts = 5
+
+var (
+	// errInvalidURL is returned for anything but an absolute http(s) URL
+	errInvalidURL = errors.New("invalid URL")
+	// errNoFreeCode is returned when every attempt hit a code in use
+	errNoFreeCode = errors.New("no free code found")
+)
+
+// Shortener creates short links and resolves them
+// Each part is swappable without touching the others:
+//   - links, the Repository, hides which storage driver the factory chose
+//   - ids, the Strategy, decides what the codes look like
+//   - cache, an LRU, keeps the most used links in memory so popular
+//     redirects don't reach the store
+type Shortener struct {
+	links *LinkRepository
+	ids   IDStrategy
+
+	mu           sync.Mutex // guards the cache and the counters
+	cache        *datastructures.LRU[string, string]
+	hits, misses int
+}
+
+// CacheStats describes how well the cache is doing
+type CacheStats struct {
+	Size     int `json:"size"`
+	Capacity int `json:"capacity"`
+	Hits     int `json:"hits"`
+	Misses   int `json:"misses"`
+}
+
+// NewShortener assembles a shortener from its parts
+func NewShortener(links *LinkRepository, ids IDStrategy, cacheSize int) *Shortener {
+	return &Shortener{
+		links: links,
+		ids:   ids,
+		cache: datastructures.NewLRU[string, string](cacheSize),
+	}
+}
+
+// newShortenerFromConfig builds a shortener from the configuration: the
+// storage driver, the ID strategy and the cache size
+func newShortenerFromConfig(cfg Config) (*Shortener, error) {
+	if err := cfg.Validate(); err != nil {
+		return nil, err
+	}
+	links, err := openLinks(cfg.Storage, cfg.DSN)
+	if err != nil {
+		return nil, err
+	}
+	ids, err := newIDStrategy(cfg.IDs, links)
+	if err != nil {
+		links.Close()
+		return nil, err
+	}
+	return NewShortener(links, ids, cfg.CacheSize), nil
+}
+
+// validateURL accepts absolute http and https URLs
+func validateURL(raw string) error {
+	u, err := url.Parse(raw)
+	if err != nil {
+		return fmt.Errorf("%w: %w", errInvalidURL, err)
+	}
+	if u.Scheme != "http" && u.Scheme != "https" || u.Host == "" {
+		return fmt.Errorf("%w: %q needs to start with http:// or https://", errInvalidURL, raw)
+	}
+	return nil
+}
+
+// Shorten creates a link for rawURL
+// If the strategy's code is taken by the same URL, as happens with hash
+// codes, that link is returned; if another URL has it, the strategy is
+// asked again
+func (s *Shortener) Shorten(rawURL string) (Link, error) {
+	if err := validateURL(rawURL); err != nil {
+		return Link{}, err
+	}
+	for attempt := range maxAttempts {
+		code, err := s.ids.NewID(rawURL, attempt)
+		if err != nil {
+			return Link{}, err
+		}
+		link := Link{Code: code, URL: rawURL}
+		err = s.links.Create(link)
+		if errors.Is(err, ErrCodeTaken) {
+			if existing, err := s.links.Find(code); err == nil && existing.URL == rawURL {
+				return existing, nil
+			}
+			continue
+		}
+		if err != nil {
+			return Link{}, err
+		}
+		// Write-through: a new link is likely to be opened soon
+		s.remember(link)
+		return link, nil
+	}
+	return Link{}, fmt.Errorf("%w after %d attempts", errNoFreeCode, maxAttempts)
+}
+
+// remember puts a link in the cache
+func (s *Shortener) remember(link Link) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.cache.Put(link.Code, link.URL)
+}
+
+// Resolve returns the link with code, from the cache when it can
+func (s *Shortener) Resolve(code string) (Link, error) {
+	s.mu.Lock()
+	target, ok := s.cache.Get(code)
+	if ok {
+		s.hits++
+	} else {
+		s.misses++
+	}
+	s.mu.Unlock()
+	if ok {
+		return Link{Code: code, URL: target}, nil
+	}
+
+	// The store is read without the lock, so a slow store doesn't hold up
+	// the cache hits of other requests
+	link, err := s.links.Find(code)
+	if err != nil {
+		return Link{}, err
+	}
+	s.remember(link)
+	return link, nil
+}
+
+// Stats returns the cache statistics
+func (s *Shortener) Stats() CacheStats {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	return CacheStats{Size: s.cache.Len(), Capacity: s.cache.Cap(), Hits: s.hits, Misses: s.misses}
+}
+
+// Close closes the store
+func (s *Shortener) Close() error {
+	return s.links.Close()
+}
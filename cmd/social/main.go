@@ -0,0 +1,259 @@
+// Command social answers questions about a small social network, an applied
+// example of the Graph type where the users are vertices and friendships
+// are edges:
+//   - friends and mutual friends are neighbour lists and their intersection
+//   - friend suggestions are the friends of friends, the second level of a
+//     breadth-first search, ranked by mutual friends
+//   - the degrees of separation between two users are the length of the
+//     shortest path, which BFS finds
+//   - influencers are the users of highest degree
+//   - squaring the adjacency matrix counts the mutual friends of every pair
+//
+// Usage:
+//
+//	go run ./cmd/social friends <user>...
+//	go run ./cmd/social mutual <user> <user>
+//	go run ./cmd/social suggest [-n 3] <user>...
+//	go run ./cmd/social path <user> <user>
+//	go run ./cmd/social influencers [-n 3]
+//	go run ./cmd/social matrix
+//	go run ./cmd/social report <user>
+//
+// Every command takes -data to load a network from a JSON file in place of
+// the built-in one; see network.json for the format
+package main
+
+import (
+	_ "embed"
+	"errors"
+	"flag"
+	"fmt"
+	"io"
+	"os"
+	"strings"
+)
+
+// network is the built-in network
+//
+//go:embed network.json
+var network string
+
+// errUsage marks errors caused by bad arguments, which exit with code 2
+var errUsage = errors.New("usage error")
+
+// command is a social subcommand
+type command struct {
+	name    string
+	usage   string
+	summary string
+	nargs   int // the number of arguments, or -1 for one or more
+	run     func(n *Network, opts options, args []string, stdout io.Writer) error
+}
+
+var commands = []command{
+	{"friends", "friends <user>...", "list the friends of users", -1, runFriends},
+	{"mutual", "mutual <user> <user>", "list the friends two users share", 2, runMutual},
+	{"suggest", "suggest [-n 3] <user>...", "suggest friends of friends", -1, runSuggest},
+	{"path", "path <user> <user>", "show the degrees of separation between two users", 2, runPath},
+	{"influencers", "influencers [-n 3]", "list the users with the most friends", 0, runInfluencers},
+	{"matrix", "matrix", "print the adjacency matrix and the mutual friend counts", 0, runMatrix},
+	{"report", "report <user>", "everything about one user", 1, runReport},
+}
+
+// options are the flags every command shares
+type options struct {
+	data string
+	n    int
+}
+
+func runFriends(n *Network, opts options, args []string, stdout io.Writer) error {
+	for _, handle := range args {
+		friends, err := n.Friends(handle)
+		if err != nil {
+			return err
+		}
+		fmt.Fprintf(stdout, "%s: %s\n", handle, list(friends))
+	}
+	return nil
+}
+
+func runMutual(n *Network, opts options, args []string, stdout io.Writer) error {
+	mutual, err := n.MutualFriends(args[0], args[1])
+	if err != nil {
+		return err
+	}
+	fmt.Fprintf(stdout, "%s and %s: %s\n", args[0], args[1], list(mutual))
+	return nil
+}
+
+func runSuggest(n *Network, opts options, args []string, stdout io.Writer) error {
+	for _, handle := range args {
+		suggestions, err := n.SuggestFriends(handle, opts.n)
+		if err != nil {
+			return err
+		}
+		fmt.Fprintf(stdout, "%s:\n", handle)
+		if len(suggestions) == 0 {
+			fmt.Fprintln(stdout, "  no suggestions")
+		}
+		for _, s := range suggestions {
+			fmt.Fprintf(stdout, "  %-10s %d mutual: %s\n", s.Handle, len(s.Mutual), list(s.Mutual))
+		}
+	}
+	return nil
+}
+
+func runPath(n *Network, opts options, args []string, stdout io.Writer) error {
+	path, err := n.Path(args[0], args[1])
+	if err != nil {
+		return err
+	}
+	if path == nil {
+		fmt.Fprintf(stdout, "%s and %s are not connected\n", args[0], args[1])
+		return nil
+	}
+	degrees := "degrees"
+	if len(path) == 2 {
+		degrees = "degree"
+	}
+	fmt.Fprintf(stdout, "%d %s: %s\n", len(path)-1, degrees, strings.Join(path, " -> "))
+	return nil
+}
+
+func runInfluencers(n *Network, opts options, args []string, stdout io.Writer) error {
+	for i, r := range n.Influencers(opts.n) {
+		fmt.Fprintf(stdout, "%d. %-10s %d friends\n", i+1, r.Handle, r.Friends)
+	}
+	return nil
+}
+
+func runMatrix(n *Network, opts options, args []string, stdout io.Writer) error {
+	users := n.Users()
+	printMatrix := func(title string, m [][]int) {
+		fmt.Fprintf(stdout, "%s\n%8s", title, "")
+		for _, u := range users {
+			fmt.Fprintf(stdout, " %3.3s", u.Handle)
+		}
+		fmt.Fprintln(stdout)
+		for i, row := range m {
+			fmt.Fprintf(stdout, "%-8s", users[i].Handle)
+			for _, v := range row {
+				fmt.Fprintf(stdout, " %3d", v)
+			}
+			fmt.Fprintln(stdout)
+		}
+	}
+	printMatrix("Friends (A):", n.AdjacencyMatrix())
+	fmt.Fprintln(stdout)
+	printMatrix("Mutual friends (A²), friend counts on the diagonal:", n.MutualCounts())
+	return nil
+}
+
+func runReport(n *Network, opts options, args []string, stdout io.Writer) error {
+	u, err := n.User(args[0])
+	if err != nil {
+		return err
+	}
+	fmt.Fprintf(stdout, "%s (%s)\n\n", u.Name, u.Handle)
+	if err := runFriends(n, opts, args, stdout); err != nil {
+		return err
+	}
+	fmt.Fprintln(stdout, "\nPeople you may know:")
+	if err := runSuggest(n, opts, args, stdout); err != nil {
+		return err
+	}
+	fmt.Fprintln(stdout, "\nDegrees of separation:")
+	for _, other := range n.Users() {
+		if other.Handle == u.Handle {
+			continue
+		}
+		fmt.Fprintf(stdout, "  %-10s ", other.Handle)
+		if err := runPath(n, opts, []string{u.Handle, other.Handle}, stdout); err != nil {
+			return err
+		}
+	}
+	fmt.Fprintln(stdout, "\nInfluencers:")
+	return runInfluencers(n, opts, nil, stdout)
+}
+
+// list joins handles, or says there are none
+func list(handles []string) string {
+	if len(handles) == 0 {
+		return "(none)"
+	}
+	return strings.Join(handles, ", ")
+}
+
+// loadNetwork reads the -data file, or the built-in network
+func loadNetwork(path string) (*Network, error) {
+	if path == "" {
+		return LoadNetwork(strings.NewReader(network))
+	}
+	f, err := os.Open(path)
+	if err != nil {
+		return nil, err
+	}
+	defer f.Close()
+	n, err := LoadNetwork(f)
+	if err != nil {
+		return nil, fmt.Errorf("%s: %w", path, err)
+	}
+	return n, nil
+}
+
+// run parses a command line and runs the command
+// Keeping os.Exit out of run makes it easy to test
+func run(args []string, stdout, stderr io.Writer) error {
+	if len(args) == 0 || args[0] == "-h" || args[0] == "help" {
+		fmt.Fprintln(stderr, "Usage: social <command> [-data file] [flags] [arguments]")
+		for _, cmd := range commands {
+			fmt.Fprintf(stderr, "  %-26s %s\n", cmd.usage, cmd.summary)
+		}
+		return errUsage
+	}
+	for _, cmd := range commands {
+		if cmd.name != args[0] {
+			continue
+		}
+		fs := flag.NewFlagSet(cmd.name, flag.ContinueOnError)
+		fs.SetOutput(stderr)
+		var opts options
+		fs.StringVar(&opts.data, "data", "", "JSON file with the network (default: the built-in network)")
+		fs.IntVar(&opts.n, "n", 3, "number of suggestions or influencers")
+		if err := fs.Parse(args[1:]); err != nil {
+			return fmt.Errorf("%w: %w", errUsage, err)
+		}
+		if opts.n < 0 {
+			return fmt.Errorf("%w: -n must not be negative", errUsage)
+		}
+		switch {
+		case cmd.nargs < 0 && fs.NArg() == 0:
+			return fmt.Errorf("%w: %s needs at least one user", errUsage, cmd.name)
+		case cmd.nargs >= 0 && fs.NArg() != cmd.nargs:
+			return fmt.Errorf("%w: usage: %s", errUsage, cmd.usage)
+		}
+
+		n, err := loadNetwork(opts.data)
+		if err != nil {
+			return err
+		}
+		return cmd.run(n, opts, fs.Args(), stdout)
+	}
+	return fmt.Errorf("%w: unknown command %q", errUsage, args[0])
+}
+
+func main() {
+	err := run(os.Args[1:], os.Stdout, os.Stderr)
+	switch {
+	case err == nil, errors.Is(err, flag.ErrHelp):
+	case errors.Is(err, errUsage):
+		// A bare errUsage follows the usage text, which says it all
+		if err != errUsage {
+			fmt.Fprintln(os.Stderr, "social:", err)
+		}
+		os.Exit(2)
+	default:
+		fmt.Fprintln(os.Stderr, "social:", err)
+		os.Exit(1)
+	}
+}
@@ -0,0 +1,70 @@
+package main
+
+import (
+	"bytes"
+	"errors"
+	"io"
+	"strings"
+	"testing"
+)
+
+func TestRunCommands(t *testing.T) {
+	tests := []struct {
+		args []string
+		want string
+	}{
+		{[]string{"friends", "alice", "mallory"}, "alice: bob, carol, dave\nmallory: (none)\n"},
+		{[]string{"mutual", "bob", "dave"}, "bob and dave: alice, carol\n"},
+		{[]string{"suggest", "-n", "1", "alice"}, "alice:\n  erin       2 mutual: bob, carol\n"},
+		{[]string{"path", "alice", "bob"}, "1 degree: alice -> bob\n"},
+		{[]string{"path", "heidi", "mallory"}, "heidi and mallory are not connected\n"},
+		{[]string{"influencers", "-n", "2"}, "1. carol      5 friends\n2. alice      3 friends\n"},
+	}
+	for _, tt := range tests {
+		var out bytes.Buffer
+		if err := run(tt.args, &out, io.Discard); err != nil {
+			t.Errorf("%v: %v", tt.args, err)
+			continue
+		}
+		if out.String() != tt.want {
+			t.Errorf("%v printed\n%s\nwant\n%s", tt.args, out.String(), tt.want)
+		}
+	}
+}
+
+func TestRunReport(t *testing.T) {
+	var out bytes.Buffer
+	if err := run([]string{"report", "alice"}, &out, io.Discard); err != nil {
+		t.Fatal(err)
+	}
+	for _, want := range []string{
+		"Alice Martin (alice)\n",
+		"  frank      2 mutual: carol, dave\n",
+		"  judy       6 degrees: alice -> bob -> erin -> grace -> heidi -> ivan -> judy\n",
+	} {
+		if !strings.Contains(out.String(), want) {
+			t.Errorf("report is missing %q:\n%s", want, out.String())
+		}
+	}
+}
+
+func TestRunErrors(t *testing.T) {
+	for _, args := range [][]string{
+		{},
+		{"follow", "alice"},
+		{"friends"},
+		{"mutual", "alice"},
+		{"influencers", "alice"},
+		{"suggest", "-n", "-1", "alice"},
+	} {
+		if err := run(args, io.Discard, io.Discard); !errors.Is(err, errUsage) {
+			t.Errorf("%v: error = %v, want errUsage", args, err)
+		}
+	}
+	if err := run([]string{"friends", "zed"}, io.Discard, io.Discard); !errors.Is(err, ErrUnknownUser) {
+		t.Errorf("unknown user error = %v, want ErrUnknownUser", err)
+	}
+	if err := run([]string{"matrix", "-data", "testdata/missing.json"}, io.Discard, io.Discard); err == nil {
+		t.Error("missing -data file succeeded")
+	}
+}
@@ -0,0 +1,312 @@
+package main
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"slices"
+
+	"github.com/NutProhmpiriya/go-basic/02-data-structures/datastructures"
+	"github.com/NutProhmpiriya/go-basic/ordering"
+)
+
+// ErrUnknownUser is returned for a handle that isn't in the network
+var ErrUnknownUser = errors.New("unknown user")
+
+// User is a member of the network
+type User struct {
+	Handle string `json:"handle"`
+	Name   string `json:"name"`
+}
+
+// fixture is the JSON form of a network: the users and the pairs of
+// handles that are friends
+type fixture struct {
+	Users       []User      `json:"users"`
+	Friendships [][2]string `json:"friendships"`
+}
+
+// Network is a friendship graph. The users are the vertices of a
+// datastructures.Graph, numbered in the order they were added, and a
+// friendship is an undirected edge
+type Network struct {
+	graph *datastructures.Graph
+	users []User         // by vertex
+	ids   map[string]int // handle to vertex
+}
+
+// NewNetwork creates a network without users
+func NewNetwork() *Network {
+	return &Network{graph: datastructures.NewGraph(), ids: make(map[string]int)}
+}
+
+// LoadNetwork reads a network from JSON of the form
+//
+//	{"users": [{"handle": "alice", "name": "Alice"}, ...],
+//	 "friendships": [["alice", "bob"], ...]}
+func LoadNetwork(r io.Reader) (*Network, error) {
+	var f fixture
+	dec := json.NewDecoder(r)
+	dec.DisallowUnknownFields()
+	if err := dec.Decode(&f); err != nil {
+		return nil, err
+	}
+	n := NewNetwork()
+	for _, u := range f.Users {
+		if err := n.AddUser(u); err != nil {
+			return nil, err
+		}
+	}
+	for _, pair := range f.Friendships {
+		if err := n.Befriend(pair[0], pair[1]); err != nil {
+			return nil, err
+		}
+	}
+	return n, nil
+}
+
+// AddUser adds a user without friends
+func (n *Network) AddUser(u User) error {
+	if u.Handle == "" {
+		return errors.New("user without a handle")
+	}
+	if _, ok := n.ids[u.Handle]; ok {
+		return fmt.Errorf("duplicate user %q", u.Handle)
+	}
+	id := len(n.users)
+	n.users = append(n.users, u)
+	n.ids[u.Handle] = id
+	n.graph.AddVertex(id)
+	return nil
+}
+
+// Befriend makes two users friends. Befriending friends again changes
+// nothing, so the graph never holds an edge twice
+func (n *Network) Befriend(a, b string) error {
+	x, err := n.id(a)
+	if err != nil {
+		return err
+	}
+	y, err := n.id(b)
+	if err != nil {
+		return err
+	}
+	if x == y {
+		return fmt.Errorf("%s can't befriend themselves", a)
+	}
+	if !slices.Contains(n.graph.GetNeighbors(x), y) {
+		n.graph.AddEdge(x, y)
+	}
+	return nil
+}
+
+// User returns the user with a handle
+func (n *Network) User(handle string) (User, error) {
+	id, err := n.id(handle)
+	if err != nil {
+		return User{}, err
+	}
+	return n.users[id], nil
+}
+
+// Users returns every user in the order they were added
+func (n *Network) Users() []User {
+	return slices.Clone(n.users)
+}
+
+// Friends returns the handles of a user's friends, sorted
+// Time Complexity: O(d log d) for d friends
+func (n *Network) Friends(handle string) ([]string, error) {
+	id, err := n.id(handle)
+	if err != nil {
+		return nil, err
+	}
+	return n.handles(n.graph.GetNeighbors(id)), nil
+}
+
+// MutualFriends returns the handles of the friends two users share, sorted
+// Time Complexity: O(d) for d friends of the two users together
+func (n *Network) MutualFriends(a, b string) ([]string, error) {
+	x, err := n.id(a)
+	if err != nil {
+		return nil, err
+	}
+	y, err := n.id(b)
+	if err != nil {
+		return nil, err
+	}
+	return n.handles(n.mutual(x, y)), nil
+}
+
+func (n *Network) mutual(x, y int) []int {
+	friendsOfX := make(map[int]bool)
+	for _, f := range n.graph.GetNeighbors(x) {
+		friendsOfX[f] = true
+	}
+	var shared []int
+	for _, f := range n.graph.GetNeighbors(y) {
+		if friendsOfX[f] {
+			shared = append(shared, f)
+		}
+	}
+	return shared
+}
+
+// Suggestion is a friend of a friend, with the friends they have in common
+type Suggestion struct {
+	Handle string
+	Mutual []string
+}
+
+// SuggestFriends returns up to k people a user isn't friends with but their
+// friends are: the vertices two steps away in a breadth-first search. The
+// ones with the most mutual friends come first, ties by handle
+// Time Complexity: O(d² + s log s) for d friends per user and s suggestions
+func (n *Network) SuggestFriends(handle string, k int) ([]Suggestion, error) {
+	id, err := n.id(handle)
+	if err != nil {
+		return nil, err
+	}
+	// A BFS cut off after the second level: the friends are level 1, and
+	// every new vertex found from them is level 2
+	level := map[int]int{id: 0}
+	var candidates []int
+	for _, friend := range n.graph.GetNeighbors(id) {
+		level[friend] = 1
+	}
+	for _, friend := range n.graph.GetNeighbors(id) {
+		for _, fof := range n.graph.GetNeighbors(friend) {
+			if _, seen := level[fof]; !seen {
+				level[fof] = 2
+				candidates = append(candidates, fof)
+			}
+		}
+	}
+
+	suggestions := make([]Suggestion, len(candidates))
+	for i, c := range candidates {
+		suggestions[i] = Suggestion{Handle: n.users[c].Handle, Mutual: n.handles(n.mutual(id, c))}
+	}
+	slices.SortFunc(suggestions, ordering.By(func(s Suggestion) int { return len(s.Mutual) }).Reversed().
+		ThenComparing(ordering.By(func(s Suggestion) string { return s.Handle })))
+	return suggestions[:min(k, len(suggestions))], nil
+}
+
+// Path returns a shortest chain of friendships from a to b, both included,
+// so its degrees of separation are len(path)-1. It returns nil if no chain
+// connects them
+// BFS finds the shortest path because it reaches every vertex at distance
+// d before any at distance d+1; the vertex each one was reached from leads
+// back to the start
+// Time Complexity: O(V + E)
+func (n *Network) Path(a, b string) ([]string, error) {
+	from, err := n.id(a)
+	if err != nil {
+		return nil, err
+	}
+	to, err := n.id(b)
+	if err != nil {
+		return nil, err
+	}
+	parent := map[int]int{from: from}
+	queue := []int{from}
+	for len(queue) > 0 && queue[0] != to {
+		v := queue[0]
+		queue = queue[1:]
+		for _, next := range n.graph.GetNeighbors(v) {
+			if _, seen := parent[next]; !seen {
+				parent[next] = v
+				queue = append(queue, next)
+			}
+		}
+	}
+	if _, reached := parent[to]; !reached {
+		return nil, nil
+	}
+	var path []int
+	for v := to; v != from; v = parent[v] {
+		path = append(path, v)
+	}
+	path = append(path, from)
+	slices.Reverse(path)
+	return n.handlesInOrder(path), nil
+}
+
+// Ranked is a user with their number of friends
+type Ranked struct {
+	Handle  string
+	Friends int
+}
+
+// Influencers returns the k users with the most friends, the vertices of
+// highest degree, ties by handle
+// Time Complexity: O(V log V)
+func (n *Network) Influencers(k int) []Ranked {
+	ranked := make([]Ranked, len(n.users))
+	for id, u := range n.users {
+		ranked[id] = Ranked{Handle: u.Handle, Friends: len(n.graph.GetNeighbors(id))}
+	}
+	slices.SortFunc(ranked, ordering.By(func(r Ranked) int { return r.Friends }).Reversed().
+		ThenComparing(ordering.By(func(r Ranked) string { return r.Handle })))
+	return ranked[:min(k, len(ranked))]
+}
+
+// AdjacencyMatrix returns the network as a matrix: m[i][j] is 1 if the ith
+// and jth users, in the order of Users, are friends
+// Time Complexity: O(V²)
+func (n *Network) AdjacencyMatrix() [][]int {
+	m := make([][]int, len(n.users))
+	for i := range m {
+		m[i] = make([]int, len(n.users))
+		for _, j := range n.graph.GetNeighbors(i) {
+			m[i][j] = 1
+		}
+	}
+	return m
+}
+
+// MutualCounts returns the square of the adjacency matrix. A path of two
+// friendships from i to j goes through one mutual friend, and (A²)[i][j]
+// counts those paths, so it is the number of friends i and j share; the
+// diagonal is each user's number of friends
+// Time Complexity: O(V³)
+func (n *Network) MutualCounts() [][]int {
+	a := n.AdjacencyMatrix()
+	sq := make([][]int, len(a))
+	for i := range a {
+		sq[i] = make([]int, len(a))
+		for k := range a {
+			if a[i][k] == 0 {
+				continue
+			}
+			for j := range a {
+				sq[i][j] += a[k][j]
+			}
+		}
+	}
+	return sq
+}
+
+func (n *Network) id(handle string) (int, error) {
+	id, ok := n.ids[handle]
+	if !ok {
+		return 0, fmt.Errorf("%w %q", ErrUnknownUser, handle)
+	}
+	return id, nil
+}
+
+// handles returns the sorted handles of vertices
+func (n *Network) handles(ids []int) []string {
+	handles := n.handlesInOrder(ids)
+	slices.Sort(handles)
+	return handles
+}
+
+func (n *Network) handlesInOrder(ids []int) []string {
+	handles := make([]string, len(ids))
+	for i, id := range ids {
+		handles[i] = n.users[id].Handle
+	}
+	return handles
+}
@@ -0,0 +1,164 @@
+package main
+
+import (
+	"errors"
+	"math/rand"
+	"slices"
+	"strings"
+	"testing"
+)
+
+func builtIn(t *testing.T) *Network {
+	t.Helper()
+	n, err := LoadNetwork(strings.NewReader(network))
+	if err != nil {
+		t.Fatal(err)
+	}
+	return n
+}
+
+func TestFriends(t *testing.T) {
+	n := builtIn(t)
+	if got, _ := n.Friends("carol"); !slices.Equal(got, []string{"alice", "bob", "dave", "erin", "frank"}) {
+		t.Errorf("Friends(carol) = %v", got)
+	}
+	if got, _ := n.MutualFriends("alice", "frank"); !slices.Equal(got, []string{"carol", "dave"}) {
+		t.Errorf("MutualFriends(alice, frank) = %v", got)
+	}
+	if got, _ := n.MutualFriends("alice", "mallory"); len(got) != 0 {
+		t.Errorf("MutualFriends(alice, mallory) = %v, want none", got)
+	}
+	if _, err := n.Friends("zed"); !errors.Is(err, ErrUnknownUser) {
+		t.Errorf("Friends(zed) error = %v, want ErrUnknownUser", err)
+	}
+}
+
+// Suggestions are exactly two steps away and ranked by mutual friends
+func TestSuggestFriends(t *testing.T) {
+	n := builtIn(t)
+	got, err := n.SuggestFriends("grace", 10)
+	if err != nil {
+		t.Fatal(err)
+	}
+	want := []Suggestion{
+		{"carol", []string{"erin", "frank"}},
+		{"bob", []string{"erin"}},
+		{"dave", []string{"frank"}},
+		{"ivan", []string{"heidi"}},
+	}
+	if len(got) != len(want) {
+		t.Fatalf("SuggestFriends(grace) = %v, want %v", got, want)
+	}
+	for i := range got {
+		if got[i].Handle != want[i].Handle || !slices.Equal(got[i].Mutual, want[i].Mutual) {
+			t.Errorf("suggestion %d = %v, want %v", i, got[i], want[i])
+		}
+	}
+	if got, _ := n.SuggestFriends("grace", 1); len(got) != 1 || got[0].Handle != "carol" {
+		t.Errorf("SuggestFriends(grace, 1) = %v, want carol", got)
+	}
+	if got, _ := n.SuggestFriends("mallory", 3); len(got) != 0 {
+		t.Errorf("SuggestFriends(mallory) = %v, want none", got)
+	}
+}
+
+func TestPath(t *testing.T) {
+	n := builtIn(t)
+	tests := []struct {
+		a, b string
+		want int // degrees of separation, -1 if not connected
+	}{
+		{"alice", "alice", 0},
+		{"alice", "bob", 1},
+		{"alice", "grace", 3},
+		{"judy", "alice", 6},
+		{"alice", "mallory", -1},
+	}
+	for _, tt := range tests {
+		path, err := n.Path(tt.a, tt.b)
+		if err != nil {
+			t.Fatal(err)
+		}
+		if len(path)-1 != tt.want {
+			t.Errorf("Path(%s, %s) = %v, want %d degrees", tt.a, tt.b, path, tt.want)
+			continue
+		}
+		if path == nil {
+			continue
+		}
+		if path[0] != tt.a || path[len(path)-1] != tt.b {
+			t.Errorf("Path(%s, %s) = %v, want it to start and end there", tt.a, tt.b, path)
+		}
+		for i := 1; i < len(path); i++ {
+			if friends, _ := n.Friends(path[i-1]); !slices.Contains(friends, path[i]) {
+				t.Errorf("Path(%s, %s) = %v: %s and %s aren't friends", tt.a, tt.b, path, path[i-1], path[i])
+			}
+		}
+	}
+}
+
+func TestInfluencers(t *testing.T) {
+	got := builtIn(t).Influencers(4)
+	want := []Ranked{{"carol", 5}, {"alice", 3}, {"bob", 3}, {"dave", 3}}
+	if !slices.Equal(got, want) {
+		t.Errorf("Influencers(4) = %v, want %v", got, want)
+	}
+}
+
+// The square of the adjacency matrix agrees with MutualFriends on a random
+// network
+func TestMutualCounts(t *testing.T) {
+	rng := rand.New(rand.NewSource(1))
+	n := NewNetwork()
+	const size = 30
+	handles := make([]string, size)
+	for i := range handles {
+		handles[i] = string(rune('A'+i/26)) + string(rune('a'+i%26))
+		n.AddUser(User{Handle: handles[i]})
+	}
+	for range 120 {
+		a, b := rng.Intn(size), rng.Intn(size)
+		if a != b {
+			n.Befriend(handles[a], handles[b])
+		}
+	}
+	counts := n.MutualCounts()
+	for i := range handles {
+		for j := range handles {
+			want := 0
+			if i == j {
+				friends, _ := n.Friends(handles[i])
+				want = len(friends)
+			} else {
+				mutual, _ := n.MutualFriends(handles[i], handles[j])
+				want = len(mutual)
+			}
+			if counts[i][j] != want {
+				t.Errorf("counts[%s][%s] = %d, want %d", handles[i], handles[j], counts[i][j], want)
+			}
+		}
+	}
+}
+
+func TestLoadNetworkErrors(t *testing.T) {
+	for _, data := range []string{
+		`{"users": [{"handle": "a"}, {"handle": "a"}]}`,
+		`{"users": [{"name": "no handle"}]}`,
+		`{"users": [{"handle": "a"}], "friendships": [["a", "b"]]}`,
+		`{"users": [{"handle": "a"}], "friendships": [["a", "a"]]}`,
+		`{"users": [], "followers": []}`,
+		`{"users": [`,
+	} {
+		if _, err := LoadNetwork(strings.NewReader(data)); err == nil {
+			t.Errorf("LoadNetwork(%s) succeeded", data)
+		}
+	}
+	// A friendship listed twice is one edge
+	n, err := LoadNetwork(strings.NewReader(`{"users": [{"handle": "a"}, {"handle": "b"}], "friendships": [["a", "b"], ["b", "a"]]}`))
+	if err != nil {
+		t.Fatal(err)
+	}
+	if friends, _ := n.Friends("a"); len(friends) != 1 {
+		t.Errorf("Friends(a) = %v, want b once", friends)
+	}
+}
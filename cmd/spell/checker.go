@@ -0,0 +1,176 @@
+package main
+
+import (
+	"bufio"
+	"cmp"
+	"errors"
+	"fmt"
+	"io"
+	"slices"
+	"strings"
+	"unicode"
+	"unicode/utf8"
+
+	"github.com/NutProhmpiriya/go-basic/02-data-structures/datastructures"
+	"github.com/NutProhmpiriya/go-basic/03-algorithms/stringalgo"
+)
+
+// errEmptyDictionary is returned for a word list with no words in it
+var errEmptyDictionary = errors.New("dictionary has no words")
+
+// Checker checks words against a dictionary and suggests corrections
+// The dictionary is kept twice, each structure answering what it is good at:
+//   - a Trie for "is this a word?" and completions, in O(length of the word)
+//   - a BK-tree for "which words are within edit distance d?", which skips
+//     most of the dictionary where comparing against every word would not
+type Checker struct {
+	words datastructures.Trie
+	index *datastructures.BKTree
+}
+
+// newChecker reads a dictionary with one word per line
+// Blank lines and lines starting with # are skipped, and words are
+// lower-cased, so a capitalized word in the text matches too
+func newChecker(dict io.Reader) (*Checker, error) {
+	c := &Checker{index: datastructures.NewBKTree(datastructures.EditDistance)}
+	scanner := bufio.NewScanner(dict)
+	for scanner.Scan() {
+		word := strings.ToLower(strings.TrimSpace(scanner.Text()))
+		if word == "" || strings.HasPrefix(word, "#") {
+			continue
+		}
+		if c.words.Insert(word) {
+			c.index.Add(word)
+		}
+	}
+	if err := scanner.Err(); err != nil {
+		return nil, err
+	}
+	if c.words.Len() == 0 {
+		return nil, errEmptyDictionary
+	}
+	return c, nil
+}
+
+// normalize lower-cases a word and turns typographic apostrophes into
+// plain ones, the form the dictionary uses
+func normalize(word string) string {
+	return strings.ReplaceAll(strings.ToLower(word), "’", "'")
+}
+
+// known reports whether a word is in the dictionary, either as it is or
+// as the possessive of a word in it ("checker's")
+func (c *Checker) known(word string) bool {
+	word = normalize(word)
+	if c.words.Contains(word) {
+		return true
+	}
+	stem, ok := strings.CutSuffix(word, "'s")
+	return ok && c.words.Contains(stem)
+}
+
+// suggest returns up to n dictionary words within maxDist edits of word,
+// best first
+// The BK-tree finds the candidates by Levenshtein distance, the metric it
+// needs, and they are ranked by:
+//  1. Damerau-Levenshtein distance, where swapping two letters is a single
+//     edit, so "teh" is as close to "the" as to "ten"
+//  2. words with the same letters first: a swap keeps every letter typed,
+//     where a substitution means a wrong key was hit
+//  3. the Levenshtein distance, then alphabetical order, as the tree gives
+//     them
+func (c *Checker) suggest(word string, maxDist, n int) []string {
+	word = normalize(word)
+	type candidate struct {
+		word        string
+		distance    int
+		sameLetters bool
+	}
+	var candidates []candidate
+	for _, w := range c.index.RangeSearch(word, maxDist) {
+		candidates = append(candidates, candidate{w, stringalgo.DamerauLevenshteinDistance(word, w), sameLetters(word, w)})
+	}
+	slices.SortStableFunc(candidates, func(a, b candidate) int {
+		if a.distance != b.distance {
+			return cmp.Compare(a.distance, b.distance)
+		}
+		if a.sameLetters != b.sameLetters {
+			if a.sameLetters {
+				return -1
+			}
+			return 1
+		}
+		return 0
+	})
+
+	suggestions := make([]string, min(n, len(candidates)))
+	for i := range suggestions {
+		suggestions[i] = candidates[i].word
+	}
+	return suggestions
+}
+
+// sameLetters reports whether a and b are made of the same letters
+func sameLetters(a, b string) bool {
+	ra, rb := []rune(a), []rune(b)
+	slices.Sort(ra)
+	slices.Sort(rb)
+	return slices.Equal(ra, rb)
+}
+
+// complete returns the dictionary words that start with prefix
+func (c *Checker) complete(prefix string) []string {
+	return c.words.WithPrefix(normalize(prefix))
+}
+
+// misspelling is a word that is not in the dictionary and where it is
+type misspelling struct {
+	line, col   int // 1-based; col counts runes
+	word        string
+	suggestions []string
+}
+
+func (m misspelling) String() string {
+	if len(m.suggestions) == 0 {
+		return fmt.Sprintf("%d:%d: %s (no suggestions)", m.line, m.col, m.word)
+	}
+	return fmt.Sprintf("%d:%d: %s (%s)", m.line, m.col, m.word, strings.Join(m.suggestions, ", "))
+}
+
+// hasDigit reports whether s contains a digit; such tokens ("v2", "1926")
+// are numbers or identifiers rather than words to check
+func hasDigit(s string) bool {
+	return strings.IndexFunc(s, unicode.IsDigit) >= 0
+}
+
+// check reads text from r and calls report for every misspelled word, in
+// the order they appear, with up to n suggestions within maxDist edits
+// It returns the number of words checked
+func (c *Checker) check(r io.Reader, maxDist, n int, report func(misspelling)) (int, error) {
+	lines := bufio.NewScanner(r)
+	checked := 0
+	for lineNo := 1; lines.Scan(); lineNo++ {
+		line := lines.Text()
+		// Tokens come in order and start with a letter, digit or mark, which
+		// separators never contain, so the next occurrence after the previous
+		// token is where the token is
+		offset := 0
+		for _, word := range stringalgo.Tokenize(line) {
+			at := offset + strings.Index(line[offset:], word)
+			offset = at + len(word)
+			if hasDigit(word) {
+				continue
+			}
+			checked++
+			if !c.known(word) {
+				report(misspelling{
+					line:        lineNo,
+					col:         utf8.RuneCountInString(line[:at]) + 1,
+					word:        word,
+					suggestions: c.suggest(word, maxDist, n),
+				})
+			}
+		}
+	}
+	return checked, lines.Err()
+}
@@ -0,0 +1,183 @@
+package main
+
+import (
+	"bytes"
+	"errors"
+	"io"
+	"io/fs"
+	"os"
+	"path/filepath"
+	"slices"
+	"strings"
+	"testing"
+
+	"github.com/NutProhmpiriya/go-basic/02-data-structures/datastructures"
+)
+
+func testChecker(t testing.TB) *Checker {
+	t.Helper()
+	c, err := newChecker(strings.NewReader(words))
+	if err != nil {
+		t.Fatal(err)
+	}
+	return c
+}
+
+func TestNewChecker(t *testing.T) {
+	c, err := newChecker(strings.NewReader("# comment\n\n  Apple \nbanana\napple\n"))
+	if err != nil {
+		t.Fatal(err)
+	}
+	if c.words.Len() != 2 || c.index.Size() != 2 {
+		t.Errorf("dictionary has %d and %d words, want 2", c.words.Len(), c.index.Size())
+	}
+	if _, err := newChecker(strings.NewReader("# only a comment\n")); !errors.Is(err, errEmptyDictionary) {
+		t.Errorf("newChecker of an empty list error = %v, want errEmptyDictionary", err)
+	}
+}
+
+func TestKnown(t *testing.T) {
+	c := testChecker(t)
+	for _, w := range []string{"the", "The", "THE", "don't", "don’t", "checker's", "word’s"} {
+		if !c.known(w) {
+			t.Errorf("known(%q) = false", w)
+		}
+	}
+	for _, w := range []string{"teh", "wordz", "'s", "qwzxv"} {
+		if c.known(w) {
+			t.Errorf("known(%q) = true", w)
+		}
+	}
+}
+
+func TestSuggest(t *testing.T) {
+	c := testChecker(t)
+	tests := []struct {
+		word string
+		n    int
+		want []string
+	}{
+		// A swap beats a substitution at the same distance
+		{"teh", 2, []string{"the", "ten"}},
+		{"Teh", 1, []string{"the"}},
+		{"recieve", 1, []string{"receive"}},
+		{"misspeled", 3, []string{"misspelled"}},
+		{"wrod", 1, []string{"word"}},
+		{"qwzxv", 3, nil},
+		{"teh", 0, nil},
+	}
+	for _, tt := range tests {
+		if got := c.suggest(tt.word, 2, tt.n); !slices.Equal(got, tt.want) {
+			t.Errorf("suggest(%q, n=%d) = %q, want %q", tt.word, tt.n, got, tt.want)
+		}
+	}
+	if got := c.suggest("tehx", 1, 5); len(got) != 0 {
+		t.Errorf("suggest(tehx) within 1 = %q, want none", got)
+	}
+}
+
+func TestCheck(t *testing.T) {
+	c := testChecker(t)
+	text := "The wrod is here.\n  Naïve teh, v2 1926 dont’s\n"
+	var got []string
+	checked, err := c.check(strings.NewReader(text), 2, 1, func(m misspelling) {
+		got = append(got, m.String())
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+	want := []string{"1:5: wrod (word)", "2:3: Naïve (gave)", "2:9: teh (the)", "2:22: dont’s (don't)"}
+	if !slices.Equal(got, want) || checked != 7 {
+		t.Errorf("check() reported %q and checked %d words, want %q and 7", got, checked, want)
+	}
+}
+
+func TestRun(t *testing.T) {
+	var out bytes.Buffer
+	if err := run([]string{"check", "testdata/sample.txt"}, &out, io.Discard); err != nil {
+		t.Fatal(err)
+	}
+	want := `testdata/sample.txt:2:22: misspeled (misspelled)
+testdata/sample.txt:2:64: teh (the, ten, be)
+testdata/sample.txt:3:23: recieve (receive, believe)
+testdata/sample.txt:6:16: Qwzxv (no suggestions)
+testdata/sample.txt: 4 misspelled out of 65 words
+`
+	if out.String() != want {
+		t.Errorf("check output:\n%s\nwant:\n%s", out.String(), want)
+	}
+
+	out.Reset()
+	if err := run([]string{"suggest", "-n", "2", "teh", "word", "qwzxv"}, &out, io.Discard); err != nil {
+		t.Fatal(err)
+	}
+	if want := "teh: the, ten\nword: ok\nqwzxv: no suggestions\n"; out.String() != want {
+		t.Errorf("suggest output = %q, want %q", out.String(), want)
+	}
+
+	// A dictionary of our own
+	dict := filepath.Join(t.TempDir(), "words.txt")
+	if err := os.WriteFile(dict, []byte("gopher\ngoroutine\ngo\n"), 0o644); err != nil {
+		t.Fatal(err)
+	}
+	out.Reset()
+	if err := run([]string{"complete", "-dict", dict, "gop", "gor", "x"}, &out, io.Discard); err != nil {
+		t.Fatal(err)
+	}
+	if want := "gop: gopher\ngor: goroutine\nx: \n"; out.String() != want {
+		t.Errorf("complete output = %q, want %q", out.String(), want)
+	}
+}
+
+func TestRunErrors(t *testing.T) {
+	for _, args := range [][]string{nil, {"help"}, {"spellcheck"}, {"check"}, {"suggest"}, {"complete"}, {"check", "-max", "-1", "x"}, {"suggest", "-bogus"}} {
+		if err := run(args, io.Discard, io.Discard); !errors.Is(err, errUsage) {
+			t.Errorf("run(%q) error = %v, want a usage error", args, err)
+		}
+	}
+	for _, args := range [][]string{{"check", "testdata/missing.txt"}, {"suggest", "-dict", "testdata/missing.txt", "x"}} {
+		if err := run(args, io.Discard, io.Discard); !errors.Is(err, fs.ErrNotExist) {
+			t.Errorf("run(%q) error = %v, want fs.ErrNotExist", args, err)
+		}
+	}
+}
+
+// BenchmarkSuggestions compares finding the words within distance 2 with the
+// BK-tree against computing the distance to every word in the dictionary
+func BenchmarkSuggestions(b *testing.B) {
+	c := testChecker(b)
+	dict := c.words.WithPrefix("")
+	typos := []string{"teh", "recieve", "misspeled", "wrod", "qwzxv", "bekause"}
+
+	b.Run("impl=bktree", func(b *testing.B) {
+		for i := range b.N {
+			c.index.RangeSearch(typos[i%len(typos)], 2)
+		}
+	})
+	b.Run("impl=scan", func(b *testing.B) {
+		for i := range b.N {
+			typo := typos[i%len(typos)]
+			var found []string
+			for _, w := range dict {
+				if datastructures.EditDistance(typo, w) <= 2 {
+					found = append(found, w)
+				}
+			}
+		}
+	})
+
+	// How many distances each one computes per lookup
+	computed := 0
+	counting := datastructures.NewBKTree(func(a, b string) int {
+		computed++
+		return datastructures.EditDistance(a, b)
+	})
+	for _, w := range dict {
+		counting.Add(w)
+	}
+	computed = 0
+	for _, typo := range typos {
+		counting.RangeSearch(typo, 2)
+	}
+	b.Logf("BK-tree: %d distances per lookup, scan: %d", computed/len(typos), len(dict))
+}
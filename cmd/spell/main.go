@@ -0,0 +1,184 @@
+// Command spell is a small spell checker, a capstone for the dictionary
+// data structures and edit distance:
+//   - a Trie holds the dictionary, answering "is this a word?" and
+//     completing prefixes
+//   - the Levenshtein edit distance measures how far a typo is from a word
+//   - a BK-tree finds the words within distance 2 without comparing the typo
+//     against the whole dictionary
+//
+// Usage:
+//
+//	go run ./cmd/spell check [-max 2] [-n 3] <file>...
+//	go run ./cmd/spell suggest [-max 2] [-n 5] <word>...
+//	go run ./cmd/spell complete <prefix>...
+//
+// Every command takes -dict to use a word list, one word per line, in place
+// of the small built-in list of common English words:
+//
+//	go run ./cmd/spell check -dict /usr/share/dict/words README.md
+package main
+
+import (
+	_ "embed"
+	"errors"
+	"flag"
+	"fmt"
+	"io"
+	"os"
+	"strings"
+)
+
+// words is the built-in dictionary
+//
+//go:embed words.txt
+var words string
+
+// errUsage marks errors caused by bad arguments, which exit with code 2
+var errUsage = errors.New("usage error")
+
+// command is a spell subcommand
+type command struct {
+	name    string
+	usage   string
+	summary string
+	run     func(c *Checker, opts options, args []string, stdout io.Writer) error
+}
+
+var commands = []command{
+	{"check", "check [-max 2] [-n 3] <file>...", "list the misspelled words of files with suggestions", runCheck},
+	{"suggest", "suggest [-max 2] [-n 5] <word>...", "suggest corrections for words", runSuggest},
+	{"complete", "complete <prefix>...", "list the dictionary words starting with a prefix", runComplete},
+}
+
+// options are the flags every command shares
+type options struct {
+	dict    string
+	maxDist int
+	n       int
+}
+
+// runCheck prints file:line:col for every misspelled word, then a summary
+func runCheck(c *Checker, opts options, args []string, stdout io.Writer) error {
+	if len(args) == 0 {
+		return fmt.Errorf("%w: check needs at least one file", errUsage)
+	}
+	for _, path := range args {
+		f, err := os.Open(path)
+		if err != nil {
+			return err
+		}
+		misspelled := 0
+		checked, err := c.check(f, opts.maxDist, opts.n, func(m misspelling) {
+			misspelled++
+			fmt.Fprintf(stdout, "%s:%v\n", path, m)
+		})
+		f.Close()
+		if err != nil {
+			return fmt.Errorf("%s: %w", path, err)
+		}
+		fmt.Fprintf(stdout, "%s: %d misspelled out of %d words\n", path, misspelled, checked)
+	}
+	return nil
+}
+
+// runSuggest prints each word with its suggestions, or "ok" if it is known
+func runSuggest(c *Checker, opts options, args []string, stdout io.Writer) error {
+	if len(args) == 0 {
+		return fmt.Errorf("%w: suggest needs at least one word", errUsage)
+	}
+	for _, word := range args {
+		switch suggestions := c.suggest(word, opts.maxDist, opts.n); {
+		case c.known(word):
+			fmt.Fprintf(stdout, "%s: ok\n", word)
+		case len(suggestions) == 0:
+			fmt.Fprintf(stdout, "%s: no suggestions\n", word)
+		default:
+			fmt.Fprintf(stdout, "%s: %s\n", word, strings.Join(suggestions, ", "))
+		}
+	}
+	return nil
+}
+
+// runComplete prints the words starting with each prefix
+func runComplete(c *Checker, opts options, args []string, stdout io.Writer) error {
+	if len(args) == 0 {
+		return fmt.Errorf("%w: complete needs at least one prefix", errUsage)
+	}
+	for _, prefix := range args {
+		fmt.Fprintf(stdout, "%s: %s\n", prefix, strings.Join(c.complete(prefix), " "))
+	}
+	return nil
+}
+
+// loadChecker builds a Checker from the -dict file, or the built-in words
+func loadChecker(path string) (*Checker, error) {
+	if path == "" {
+		return newChecker(strings.NewReader(words))
+	}
+	f, err := os.Open(path)
+	if err != nil {
+		return nil, err
+	}
+	defer f.Close()
+	c, err := newChecker(f)
+	if err != nil {
+		return nil, fmt.Errorf("%s: %w", path, err)
+	}
+	return c, nil
+}
+
+// run parses a command line and runs the command
+// Keeping os.Exit out of run makes it easy to test
+func run(args []string, stdout, stderr io.Writer) error {
+	if len(args) == 0 || args[0] == "-h" || args[0] == "help" {
+		fmt.Fprintln(stderr, "Usage: spell <command> [-dict file] [flags] [arguments]")
+		for _, cmd := range commands {
+			fmt.Fprintf(stderr, "  %-36s %s\n", cmd.usage, cmd.summary)
+		}
+		return errUsage
+	}
+	for _, cmd := range commands {
+		if cmd.name != args[0] {
+			continue
+		}
+		fs := flag.NewFlagSet(cmd.name, flag.ContinueOnError)
+		fs.SetOutput(stderr)
+		var opts options
+		fs.StringVar(&opts.dict, "dict", "", "word list to use, one word per line (default: built-in common English words)")
+		fs.IntVar(&opts.maxDist, "max", 2, "largest edit distance for suggestions")
+		defaultN := 3
+		if cmd.name == "suggest" {
+			defaultN = 5
+		}
+		fs.IntVar(&opts.n, "n", defaultN, "number of suggestions per word")
+		if err := fs.Parse(args[1:]); err != nil {
+			return fmt.Errorf("%w: %w", errUsage, err)
+		}
+		if opts.maxDist < 0 || opts.n < 0 {
+			return fmt.Errorf("%w: -max and -n must not be negative", errUsage)
+		}
+
+		c, err := loadChecker(opts.dict)
+		if err != nil {
+			return err
+		}
+		return cmd.run(c, opts, fs.Args(), stdout)
+	}
+	return fmt.Errorf("%w: unknown command %q", errUsage, args[0])
+}
+
+func main() {
+	err := run(os.Args[1:], os.Stdout, os.Stderr)
+	switch {
+	case err == nil, errors.Is(err, flag.ErrHelp):
+	case errors.Is(err, errUsage):
+		// A bare errUsage follows the usage text, which says it all
+		if err != errUsage {
+			fmt.Fprintln(os.Stderr, "spell:", err)
+		}
+		os.Exit(2)
+	default:
+		fmt.Fprintln(os.Stderr, "spell:", err)
+		os.Exit(1)
+	}
+}
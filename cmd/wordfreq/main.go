@@ -0,0 +1,194 @@
+// Command wordfreq reports the most frequent words in text files
+// It is an applied example that puts the three sections together:
+//   - I/O basics: reading files or stdin through a bufio.Scanner, flags,
+//     and a tabwriter report
+//   - Data structures: a Multiset counts the words
+//   - Algorithms: stringalgo.ScanTokens splits Unicode text into words and
+//     heapalgo.TopK picks the most frequent with a heap of k entries
+//
+// Usage:
+//
+//	go run ./cmd/wordfreq [-k n] [-min n] [-stop] [file ...]
+//
+// With no files it reads standard input, so it can sit in a pipeline:
+//
+//	cat *.md | go run ./cmd/wordfreq -stop -k 20
+package main
+
+import (
+	"bufio"
+	"errors"
+	"flag"
+	"fmt"
+	"io"
+	"iter"
+	"os"
+	"strings"
+	"text/tabwriter"
+	"unicode/utf8"
+
+	"github.com/NutProhmpiriya/go-basic/02-data-structures/datastructures"
+	"github.com/NutProhmpiriya/go-basic/03-algorithms/heapalgo"
+	"github.com/NutProhmpiriya/go-basic/03-algorithms/stringalgo"
+)
+
+// errUsage marks errors caused by bad arguments, which exit with code 2
+var errUsage = errors.New("usage error")
+
+// stopWords are common English words that -stop leaves out, since they
+// top the counts of almost any English text
+var stopWords = map[string]bool{
+	"a": true, "an": true, "and": true, "are": true, "as": true, "at": true,
+	"be": true, "but": true, "by": true, "for": true, "from": true, "has": true,
+	"have": true, "he": true, "her": true, "his": true, "i": true, "in": true,
+	"is": true, "it": true, "its": true, "of": true, "on": true, "or": true,
+	"she": true, "that": true, "the": true, "their": true, "they": true,
+	"this": true, "to": true, "was": true, "were": true, "with": true, "you": true,
+}
+
+// options are the settings taken from the flags
+type options struct {
+	k        int
+	minRunes int
+	stop     bool
+}
+
+// entry is a word and how often it occurs
+type entry struct {
+	word  string
+	count int
+}
+
+// moreFrequent orders entries by count, most first, with ties in
+// alphabetical order so the report is the same on every run
+func moreFrequent(a, b entry) bool {
+	if a.count != b.count {
+		return a.count > b.count
+	}
+	return a.word < b.word
+}
+
+// countWords adds the words read from r to words
+// Words are lower-cased, so "The" and "the" count as one; words shorter
+// than opts.minRunes and, with opts.stop, stop words are skipped
+func countWords(r io.Reader, words *datastructures.Multiset[string], opts options) error {
+	scanner := bufio.NewScanner(r)
+	scanner.Split(stringalgo.ScanTokens)
+	for scanner.Scan() {
+		word := strings.ToLower(scanner.Text())
+		if utf8.RuneCountInString(word) < opts.minRunes || opts.stop && stopWords[word] {
+			continue
+		}
+		words.Add(word)
+	}
+	// bufio.ErrTooLong for a "word" over 64 KiB, or a read error
+	return scanner.Err()
+}
+
+// countFile counts the words of one file
+func countFile(path string, words *datastructures.Multiset[string], opts options) error {
+	f, err := os.Open(path)
+	if err != nil {
+		return err
+	}
+	defer f.Close()
+	if err := countWords(f, words, opts); err != nil {
+		return fmt.Errorf("%s: %w", path, err)
+	}
+	return nil
+}
+
+// entries yields the distinct words of a Multiset with their counts
+func entries(words *datastructures.Multiset[string]) iter.Seq[entry] {
+	return func(yield func(entry) bool) {
+		for word, count := range words.All() {
+			if !yield(entry{word, count}) {
+				return
+			}
+		}
+	}
+}
+
+// report writes the summary and the top words as a table with a bar for
+// each count, scaled to the most frequent word
+func report(w io.Writer, words *datastructures.Multiset[string], sources []string, k int) error {
+	const barWidth = 30
+
+	onceOnly, longest := 0, ""
+	for word, count := range words.All() {
+		if count == 1 {
+			onceOnly++
+		}
+		if n, m := utf8.RuneCountInString(word), utf8.RuneCountInString(longest); n > m || n == m && word < longest {
+			longest = word
+		}
+	}
+	fmt.Fprintf(w, "Read %s: %d words, %d distinct, %d used only once\n",
+		strings.Join(sources, ", "), words.Len(), words.Distinct(), onceOnly)
+	if words.Len() == 0 {
+		return nil
+	}
+	fmt.Fprintf(w, "Longest word: %s\n\n", longest)
+
+	top := heapalgo.TopK(entries(words), k, moreFrequent)
+	fmt.Fprintf(w, "Top %d words:\n", len(top))
+	// tabwriter lines up the columns; it counts runes, so a word with
+	// combining marks or wide characters can still be off by a little
+	tw := tabwriter.NewWriter(w, 0, 0, 2, ' ', tabwriter.AlignRight)
+	fmt.Fprintln(tw, "rank\tword\tcount\tshare\t")
+	for i, e := range top {
+		bar := strings.Repeat("█", max(1, e.count*barWidth/top[0].count))
+		share := 100 * float64(e.count) / float64(words.Len())
+		fmt.Fprintf(tw, "%d\t%s\t%d\t%.1f%%\t  %s\n", i+1, e.word, e.count, share, bar)
+	}
+	return tw.Flush()
+}
+
+// run parses the flags, counts the words of every file and writes the report
+// Keeping os.Exit out of run makes it easy to test
+func run(args []string, stdin io.Reader, stdout, stderr io.Writer) error {
+	fs := flag.NewFlagSet("wordfreq", flag.ContinueOnError)
+	fs.SetOutput(stderr)
+	fs.Usage = func() {
+		fmt.Fprintln(stderr, "Usage: wordfreq [-k n] [-min n] [-stop] [file ...]")
+		fs.PrintDefaults()
+	}
+	var opts options
+	fs.IntVar(&opts.k, "k", 10, "number of top words to list")
+	fs.IntVar(&opts.minRunes, "min", 1, "skip words with fewer characters")
+	fs.BoolVar(&opts.stop, "stop", false, "skip common English words such as \"the\"")
+	if err := fs.Parse(args); err != nil {
+		return fmt.Errorf("%w: %w", errUsage, err)
+	}
+	if opts.k < 1 {
+		return fmt.Errorf("%w: -k must be at least 1", errUsage)
+	}
+
+	var words datastructures.Multiset[string]
+	sources := fs.Args()
+	if len(sources) == 0 {
+		if err := countWords(stdin, &words, opts); err != nil {
+			return err
+		}
+		sources = []string{"standard input"}
+	}
+	for _, path := range fs.Args() {
+		if err := countFile(path, &words, opts); err != nil {
+			return err
+		}
+	}
+	return report(stdout, &words, sources, opts.k)
+}
+
+func main() {
+	err := run(os.Args[1:], os.Stdin, os.Stdout, os.Stderr)
+	switch {
+	case err == nil, errors.Is(err, flag.ErrHelp):
+	case errors.Is(err, errUsage):
+		fmt.Fprintln(os.Stderr, "wordfreq:", err)
+		os.Exit(2)
+	default:
+		fmt.Fprintln(os.Stderr, "wordfreq:", err)
+		os.Exit(1)
+	}
+}
@@ -0,0 +1,73 @@
+package main
+
+import (
+	"bytes"
+	"errors"
+	"io"
+	"io/fs"
+	"strings"
+	"testing"
+)
+
+func TestReport(t *testing.T) {
+	text := `The cat saw the dog. The dog didn't see the CAT; naïve dog!`
+	var out bytes.Buffer
+	if err := run([]string{"-k", "3"}, strings.NewReader(text), &out, io.Discard); err != nil {
+		t.Fatal(err)
+	}
+	want := `Read standard input: 13 words, 7 distinct, 4 used only once
+Longest word: didn't
+
+Top 3 words:
+  rank  word  count  share
+     1   the      4  30.8%  ██████████████████████████████
+     2   dog      3  23.1%  ██████████████████████
+     3   cat      2  15.4%  ███████████████
+`
+	if got := out.String(); got != want {
+		t.Errorf("report:\n%s\nwant:\n%s", got, want)
+	}
+}
+
+func TestFiltersAndFiles(t *testing.T) {
+	var out bytes.Buffer
+	args := []string{"-stop", "-min", "4", "-k", "2", "testdata/sample.txt", "testdata/sample.txt"}
+	if err := run(args, nil, &out, io.Discard); err != nil {
+		t.Fatal(err)
+	}
+	// Reading the file twice doubles every count
+	if want := "Read testdata/sample.txt, testdata/sample.txt: 220 words, 82 distinct, 0 used only once"; !strings.HasPrefix(out.String(), want) {
+		t.Errorf("report does not start with %q:\n%s", want, out.String())
+	}
+	lines := strings.Split(strings.TrimSpace(out.String()), "\n")
+	for i, want := range []string{"1 words 12 5.5%", "2 text 10 4.5%"} {
+		row := lines[len(lines)-2+i]
+		if got := strings.Join(strings.Fields(row)[:4], " "); got != want {
+			t.Errorf("row %d = %q, want %q", i+1, got, want)
+		}
+	}
+	if strings.Contains(out.String(), " the ") {
+		t.Errorf("-stop did not skip \"the\":\n%s", out.String())
+	}
+}
+
+func TestEmptyInput(t *testing.T) {
+	var out bytes.Buffer
+	if err := run(nil, strings.NewReader(" ... "), &out, io.Discard); err != nil {
+		t.Fatal(err)
+	}
+	if want := "Read standard input: 0 words, 0 distinct, 0 used only once\n"; out.String() != want {
+		t.Errorf("report = %q, want %q", out.String(), want)
+	}
+}
+
+func TestErrors(t *testing.T) {
+	for _, args := range [][]string{{"-k", "0"}, {"-k", "many"}, {"-unknown"}} {
+		if err := run(args, strings.NewReader(""), io.Discard, io.Discard); !errors.Is(err, errUsage) {
+			t.Errorf("run(%q) error = %v, want a usage error", args, err)
+		}
+	}
+	if err := run([]string{"testdata/missing.txt"}, nil, io.Discard, io.Discard); !errors.Is(err, fs.ErrNotExist) {
+		t.Errorf("run on a missing file error = %v, want fs.ErrNotExist", err)
+	}
+}
@@ -0,0 +1,148 @@
+package complexity
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/NutProhmpiriya/go-basic/02-data-structures/datastructures"
+	_ "github.com/NutProhmpiriya/go-basic/03-algorithms/catalog"
+	algorithms "github.com/NutProhmpiriya/go-basic/03-algorithms/registry"
+	"github.com/NutProhmpiriya/go-basic/testdata/generator"
+)
+
+// Sizes for the algorithms too slow for DefaultSizes, and for the searches,
+// which need a much wider range to tell O(log n) from a constant
+var (
+	quadraticSizes = []int{250, 500, 1_000, 2_000, 4_000, 8_000}
+	searchSizes    = []int{1_000, 4_000, 16_000, 64_000, 256_000, 1_024_000}
+	tableSizes     = []int{100, 200, 400, 800, 1_600}
+)
+
+// claims maps the complexities the algorithms of the registry claim to a Class
+// The generated strings of a string pair have the same length and the
+// patterns of a text are short, so m counts as n; a natural merge sort of
+// random input finds about n/2 runs, so r does too
+var claims = map[string]Class{
+	"O(log n)":   Log,
+	"O(√n)":      Sqrt,
+	"O(n)":       Linear,
+	"O(n + m)":   Linear,
+	"O(n log n)": Linearithmic,
+	"O(n log r)": Linearithmic,
+	"O(n²)":      Quadratic,
+	"O(mn)":      Quadratic,
+}
+
+// ClassOf returns the Class of a complexity as the registry writes it, e.g.
+// "O(n log n) average, O(n²) worst": the first one given, the case a
+// generated input brings out. It reports false for a complexity without a
+// Class, like O(log log n)
+func ClassOf(claim string) (Class, bool) {
+	first, _, _ := strings.Cut(claim, ")")
+	c, ok := claims[first+")"]
+	return c, ok
+}
+
+func init() {
+	// Every algorithm of the registry, on the input it generates
+	for _, a := range algorithms.All() {
+		claim, ok := ClassOf(a.Time)
+		if !ok {
+			continue
+		}
+		var sizes []int
+		switch {
+		case a.Input == algorithms.SortedInts:
+			sizes = searchSizes
+		case claim.Name == Quadratic.Name && a.Input == algorithms.Ints:
+			sizes = quadraticSizes
+		case claim.Name == Quadratic.Name:
+			// The string tables take O(n²) memory too
+			sizes = tableSizes
+		}
+		Register(Algorithm{
+			Name:  a.ID(),
+			Claim: claim,
+			Sizes: sizes,
+			Setup: setup(a),
+		})
+	}
+
+	// Inputs that bring out a sort's best or worst case, under names of
+	// their own
+	for _, a := range []struct {
+		name, sort string
+		claim      Class
+		sizes      []int
+		input      func(n int, gen *generator.Generator) []int
+	}{
+		{"insertionsort-sorted", "insertionsort", Linear, nil, sortedInts},
+		{"natural-mergesort-sorted", "natural-mergesort", Linear, nil, sortedInts},
+		// Three distinct values: three-way partitioning stays fast, Lomuto's does not
+		{"quicksort-duplicates", "quicksort", Linearithmic, nil, fewDistinctInts},
+		{"lomuto-quicksort-duplicates", "lomuto-quicksort", Quadratic, quadraticSizes, fewDistinctInts},
+		// The worst case, built against the middle pivot
+		{"quicksort-killer", "quicksort", Quadratic, quadraticSizes, killer},
+	} {
+		sort, err := algorithms.Lookup("sorting/" + a.sort)
+		if err != nil {
+			panic(fmt.Sprintf("complexity: %s: %v", a.name, err))
+		}
+		Register(Algorithm{
+			Name:  "sorting/" + a.name,
+			Claim: a.claim,
+			Sizes: a.sizes,
+			Setup: func(n int, gen *generator.Generator) func() {
+				in := algorithms.Input{Ints: a.input(n, gen)}
+				return func() { sort.Run(in) }
+			},
+		})
+	}
+
+	Register(Algorithm{
+		Name:  "data-structures/bst-insert",
+		Claim: Linearithmic, // n inserts into a tree of random values, O(log n) deep on average
+		Setup: func(n int, gen *generator.Generator) func() {
+			values := gen.Ints(n, 0)
+			return func() {
+				tree := &datastructures.BinaryTree{}
+				for _, v := range values {
+					tree.Insert(v)
+				}
+			}
+		},
+	})
+}
+
+// setup generates the input of a registered algorithm
+// A sort runs on a fresh copy of its input, which adds O(n) to every
+// timing; that never changes the class of a sort
+func setup(a algorithms.Algorithm) func(n int, gen *generator.Generator) func() {
+	if a.Input != algorithms.SortedInts {
+		return func(n int, gen *generator.Generator) func() {
+			in := algorithms.NewInput(a.Input, n, gen)
+			return func() { a.Run(in) }
+		}
+	}
+	return func(n int, gen *generator.Generator) func() {
+		arr := algorithms.NewInput(a.Input, n, gen).Ints
+		// Cycle through random targets that are all present, so each
+		// timing averages over positions instead of hitting one
+		inputs := make([]algorithms.Input, 1024)
+		for i := range inputs {
+			inputs[i] = algorithms.Input{Ints: arr, Target: arr[gen.Rand().Intn(n)]}
+		}
+		i := 0
+		return func() {
+			a.Run(inputs[i%len(inputs)])
+			i++
+		}
+	}
+}
+
+func sortedInts(n int, _ *generator.Generator) []int { return generator.Sorted(n) }
+
+// fewDistinctInts has three distinct values
+func fewDistinctInts(n int, gen *generator.Generator) []int { return gen.FewUnique(n, 3) }
+
+func killer(n int, _ *generator.Generator) []int { return generator.MiddlePivotKiller(n) }
@@ -0,0 +1,248 @@
+// Package complexity measures how the running time of an algorithm grows with
+// the size of its input, so the Big-O comments in the code can be checked
+// rather than taken on trust
+//
+// Algorithms register themselves with the complexity their code claims.
+// Measure times one at growing sizes and Fit compares the timings with each
+// Class, best match first:
+//
+//	a, _ := complexity.Lookup("sorting/quicksort")
+//	samples := complexity.Measure(a, a.Sizes, complexity.Options{})
+//	best := complexity.Fit(samples)[0] // O(n log n), hopefully
+//
+// Telling n from n log n apart takes a wide range of sizes: log n only
+// doubles between 1000 and 1000000. The gobasic complexity command prints
+// the estimates for every registered algorithm as a table.
+package complexity
+
+import (
+	"cmp"
+	"errors"
+	"fmt"
+	"math"
+	"runtime"
+	"slices"
+	"strings"
+	"time"
+
+	"github.com/NutProhmpiriya/go-basic/03-algorithms/stats"
+	"github.com/NutProhmpiriya/go-basic/testdata/generator"
+)
+
+// ErrUnknownAlgorithm is returned when looking up an algorithm that was never registered
+var ErrUnknownAlgorithm = errors.New("unknown algorithm")
+
+// Class is a complexity class, e.g. O(n log n)
+type Class struct {
+	Name string
+	// F is the growth function; only its shape matters, not its constant
+	F func(n float64) float64
+}
+
+func (c Class) String() string { return c.Name }
+
+// The classes Fit chooses from
+var (
+	Log          = Class{"O(log n)", func(n float64) float64 { return math.Log2(n) }}
+	Sqrt         = Class{"O(√n)", math.Sqrt}
+	Linear       = Class{"O(n)", func(n float64) float64 { return n }}
+	Linearithmic = Class{"O(n log n)", func(n float64) float64 { return n * math.Log2(n) }}
+	Quadratic    = Class{"O(n²)", func(n float64) float64 { return n * n }}
+
+	Classes = []Class{Log, Sqrt, Linear, Linearithmic, Quadratic}
+)
+
+// DefaultSizes are used for an algorithm that does not set its own
+// They start well above a few thousand elements: the same input is timed
+// over and over, and the CPU's branch predictor learns small inputs by
+// heart, which makes the small sizes look faster than they are
+var DefaultSizes = []int{16_000, 32_000, 64_000, 128_000, 256_000, 512_000}
+
+// Algorithm is something to time
+type Algorithm struct {
+	// Name is "topic/name", e.g. "sorting/quicksort"
+	Name string
+	// Claim is the complexity stated in the algorithm's doc comment
+	Claim Class
+	// Sizes are the input sizes to measure at; nil means DefaultSizes
+	Sizes []int
+	// Setup builds an input of size n and returns the operation to time
+	// The operation is called many times, so it must leave the input as it
+	// found it, e.g. by sorting a copy
+	Setup func(n int, gen *generator.Generator) func()
+}
+
+var (
+	registry = make(map[string]Algorithm)
+	order    []string
+)
+
+// Register adds an algorithm to the registry
+// It is meant to be called from init and panics on a duplicate or malformed
+// name, like database/sql.Register does for drivers
+func Register(a Algorithm) {
+	if topic, name, _ := strings.Cut(a.Name, "/"); topic == "" || name == "" {
+		panic("complexity: algorithm name " + a.Name + " is not of the form topic/name")
+	}
+	if _, dup := registry[a.Name]; dup {
+		panic("complexity: Register called twice for " + a.Name)
+	}
+	if a.Setup == nil || a.Claim.F == nil {
+		panic("complexity: " + a.Name + " has no Setup or Claim")
+	}
+	if a.Sizes == nil {
+		a.Sizes = DefaultSizes
+	}
+	registry[a.Name] = a
+	order = append(order, a.Name)
+}
+
+// Algorithms returns the registered algorithms in registration order
+func Algorithms() []Algorithm {
+	all := make([]Algorithm, len(order))
+	for i, name := range order {
+		all[i] = registry[name]
+	}
+	return all
+}
+
+// Lookup returns the algorithm registered under name
+func Lookup(name string) (Algorithm, error) {
+	a, ok := registry[name]
+	if !ok {
+		return Algorithm{}, fmt.Errorf("%w %q", ErrUnknownAlgorithm, name)
+	}
+	return a, nil
+}
+
+// Options control a measurement
+// The zero value measures with seed 0, 5ms per timing and 3 rounds
+type Options struct {
+	Seed int64
+	// MinTime is how long a single timing runs for; fast operations are
+	// repeated until it is reached, so the clock's resolution doesn't matter
+	MinTime time.Duration
+	// Rounds is how many timings are taken per size; the fastest one is
+	// kept, as the others were slowed down by something else
+	Rounds int
+}
+
+// Sample is the time one operation took on an input of size N
+type Sample struct {
+	N    int
+	Time time.Duration
+	// Spread is the standard deviation of the rounds' timings per
+	// operation: how far to trust Time
+	Spread time.Duration
+}
+
+// Measure times a on inputs of the given sizes
+func Measure(a Algorithm, sizes []int, opts Options) []Sample {
+	if opts.MinTime <= 0 {
+		opts.MinTime = 5 * time.Millisecond
+	}
+	if opts.Rounds <= 0 {
+		opts.Rounds = 3
+	}
+
+	samples := make([]Sample, 0, len(sizes))
+	for _, n := range sizes {
+		op := a.Setup(n, generator.New(opts.Seed))
+		op() // Warm up caches and let any lazy setup happen
+		// Collect the setup's garbage now rather than during the timings
+		runtime.GC()
+
+		// Double the repetitions until one timing takes MinTime
+		reps := 1
+		elapsed := timeReps(op, reps)
+		for elapsed < opts.MinTime && reps < 1<<30 {
+			reps *= 2
+			elapsed = timeReps(op, reps)
+		}
+		// The rounds are summarized as they come, without keeping them
+		var rounds stats.RunningStats
+		rounds.Add(float64(elapsed))
+		for range opts.Rounds - 1 {
+			rounds.Add(float64(timeReps(op, reps)))
+		}
+		samples = append(samples, Sample{
+			N:      n,
+			Time:   time.Duration(rounds.Min()) / time.Duration(reps),
+			Spread: time.Duration(rounds.StdDev()) / time.Duration(reps),
+		})
+	}
+	return samples
+}
+
+func timeReps(op func(), reps int) time.Duration {
+	start := time.Now()
+	for range reps {
+		op()
+	}
+	return time.Since(start)
+}
+
+// Estimate is how well one class explains a set of samples
+type Estimate struct {
+	Class Class
+	// Scale is the constant c in time ≈ c·F(n), in nanoseconds
+	Scale float64
+	// Error is the root mean square of the relative differences between the
+	// samples and c·F(n); 0.1 means the curve is off by about 10%
+	Error float64
+}
+
+// Fit matches the samples against every class and returns the estimates,
+// best first
+//
+// Each class is scaled to the samples by least squares on the relative
+// error, so the small sizes count as much as the large ones.
+func Fit(samples []Sample) []Estimate {
+	estimates := make([]Estimate, 0, len(Classes))
+	for _, c := range Classes {
+		// Minimising Σ(1 - c·f/t)² gives c = Σ(f/t) / Σ(f/t)²
+		var sum, sumSq float64
+		for _, s := range samples {
+			r := c.F(float64(s.N)) / nanos(s)
+			sum += r
+			sumSq += r * r
+		}
+		scale := sum / sumSq
+
+		var residual float64
+		for _, s := range samples {
+			d := 1 - scale*c.F(float64(s.N))/nanos(s)
+			residual += d * d
+		}
+		estimates = append(estimates, Estimate{
+			Class: c,
+			Scale: scale,
+			Error: math.Sqrt(residual / float64(len(samples))),
+		})
+	}
+	slices.SortStableFunc(estimates, func(a, b Estimate) int {
+		return cmp.Compare(a.Error, b.Error)
+	})
+	return estimates
+}
+
+// Slope is the exponent k of the best fitting power law time ≈ c·n^k,
+// found by linear regression on a log-log scale
+// O(n) gives about 1 and O(n²) about 2; O(n log n) lands a little above 1
+func Slope(samples []Sample) float64 {
+	var sx, sy, sxx, sxy float64
+	for _, s := range samples {
+		x, y := math.Log(float64(s.N)), math.Log(nanos(s))
+		sx += x
+		sy += y
+		sxx += x * x
+		sxy += x * y
+	}
+	n := float64(len(samples))
+	return (n*sxy - sx*sy) / (n*sxx - sx*sx)
+}
+
+// nanos returns the sample's time in nanoseconds, at least 1 so ratios stay finite
+func nanos(s Sample) float64 {
+	return max(float64(s.Time.Nanoseconds()), 1)
+}
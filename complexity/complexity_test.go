@@ -0,0 +1,131 @@
+package complexity
+
+import (
+	"errors"
+	"math"
+	"testing"
+	"time"
+
+	algorithms "github.com/NutProhmpiriya/go-basic/03-algorithms/registry"
+	"github.com/NutProhmpiriya/go-basic/testdata/generator"
+)
+
+// synthetic returns exact timings of c·F(n) for the given class
+func synthetic(c Class, sizes []int) []Sample {
+	samples := make([]Sample, len(sizes))
+	for i, n := range sizes {
+		samples[i] = Sample{N: n, Time: time.Duration(3 * c.F(float64(n)))}
+	}
+	return samples
+}
+
+func TestFitFindsClass(t *testing.T) {
+	sizes := []int{1_000, 4_000, 16_000, 64_000, 256_000, 1_024_000}
+	for _, c := range Classes {
+		t.Run(c.Name, func(t *testing.T) {
+			estimates := Fit(synthetic(c, sizes))
+			if best := estimates[0]; best.Class.Name != c.Name || best.Error > 0.01 {
+				t.Errorf("best fit %s with error %.3f", best.Class, best.Error)
+			}
+			if math.Abs(estimates[0].Scale-3) > 0.1 {
+				t.Errorf("scale %.2f, want 3", estimates[0].Scale)
+			}
+			for i := 1; i < len(estimates); i++ {
+				if estimates[i].Error < estimates[i-1].Error {
+					t.Errorf("estimates not sorted by error: %v", estimates)
+				}
+			}
+		})
+	}
+}
+
+func TestSlope(t *testing.T) {
+	sizes := []int{1_000, 2_000, 4_000, 8_000}
+	tests := []struct {
+		class Class
+		want  float64
+	}{
+		{Sqrt, 0.5},
+		{Linear, 1},
+		{Quadratic, 2},
+	}
+	for _, tt := range tests {
+		if got := Slope(synthetic(tt.class, sizes)); math.Abs(got-tt.want) > 0.01 {
+			t.Errorf("Slope(%s) = %.3f, want %.1f", tt.class, got, tt.want)
+		}
+	}
+}
+
+func TestLookup(t *testing.T) {
+	if a, err := Lookup("sorting/quicksort"); err != nil || a.Claim.Name != Linearithmic.Name {
+		t.Errorf("Lookup(quicksort) = %+v, %v", a, err)
+	}
+	if _, err := Lookup("sorting/bogosort"); !errors.Is(err, ErrUnknownAlgorithm) {
+		t.Errorf("Lookup(bogosort) error = %v, want ErrUnknownAlgorithm", err)
+	}
+}
+
+func TestClassOf(t *testing.T) {
+	for claim, want := range map[string]string{
+		"O(n²), O(n) if nearly sorted":     Quadratic.Name,
+		"O(n log n) average, O(n²) worst":  Linearithmic.Name,
+		"O(n + m) average, O(nm) worst":    Linear.Name,
+		"O(log n)":                         Log.Name,
+		"O(√n)":                            Sqrt.Name,
+		"O(log log n) average, O(n) worst": "",
+		"linear":                           "",
+	} {
+		c, ok := ClassOf(claim)
+		if ok != (want != "") || ok && c.Name != want {
+			t.Errorf("ClassOf(%q) = %s, %v, want %q", claim, c, ok, want)
+		}
+	}
+}
+
+// Every algorithm of the registry whose claim has a class is measured
+func TestRegistryAlgorithms(t *testing.T) {
+	for _, a := range algorithms.All() {
+		c, ok := ClassOf(a.Time)
+		if !ok {
+			continue
+		}
+		if got, err := Lookup(a.ID()); err != nil || got.Claim.Name != c.Name {
+			t.Errorf("Lookup(%s) = %+v, %v, want claim %s", a.ID(), got, err, c)
+		}
+	}
+}
+
+func TestRegisterRejectsBadAlgorithms(t *testing.T) {
+	setup := func(int, *generator.Generator) func() { return func() {} }
+	for _, a := range []Algorithm{
+		{Name: "", Claim: Linear, Setup: setup},
+		{Name: "quicksort", Claim: Linear, Setup: setup},
+		{Name: "sorting/quicksort", Claim: Linear, Setup: setup},
+		{Name: "sorting/new", Setup: setup},
+		{Name: "sorting/new", Claim: Linear},
+	} {
+		func() {
+			defer func() {
+				if recover() == nil {
+					t.Errorf("Register(%q) did not panic", a.Name)
+				}
+			}()
+			Register(a)
+		}()
+	}
+}
+
+// Every registered algorithm can be set up and run, down to tiny inputs
+func TestAlgorithmsRun(t *testing.T) {
+	for _, a := range Algorithms() {
+		samples := Measure(a, []int{1, 2, 50}, Options{MinTime: time.Microsecond, Rounds: 1})
+		if len(samples) != 3 {
+			t.Errorf("%s: got %d samples", a.Name, len(samples))
+		}
+		for _, s := range samples {
+			if s.Time < 0 || s.Spread < 0 {
+				t.Errorf("%s: sample %+v", a.Name, s)
+			}
+		}
+	}
+}
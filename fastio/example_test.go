@@ -0,0 +1,25 @@
+package fastio_test
+
+import (
+	"os"
+	"strings"
+
+	"github.com/NutProhmpiriya/go-basic/fastio"
+)
+
+func ExampleReader() {
+	// A judge-style input: the size, then the numbers
+	in := fastio.NewReader(strings.NewReader("4\n7 -2 5 0\n"))
+	out := fastio.NewWriter(os.Stdout)
+	defer out.Flush()
+
+	a := in.Ints(in.Int())
+	for i, j := 0, len(a)-1; i < j; i, j = i+1, j-1 {
+		a[i], a[j] = a[j], a[i]
+	}
+	out.Ints(a)
+	if err := in.Err(); err != nil {
+		out.Println(err)
+	}
+	// Output: 0 5 -2 7
+}
@@ -0,0 +1,294 @@
+// Package fastio reads and writes input in the format of programming contest
+// judges: whitespace-separated numbers and words, with the sizes given
+// before the data, like
+//
+//	3
+//	5 1 4
+//
+// fmt.Scan is easy to use but slow, because it parses a format for every
+// value; with a few hundred thousand numbers it takes most of a judge's time
+// limit. A Reader keeps a large buffer and parses numbers byte by byte,
+// and a Writer buffers the output until Flush:
+//
+//	in, out := fastio.NewReader(os.Stdin), fastio.NewWriter(os.Stdout)
+//	defer out.Flush()
+//	n := in.Int()
+//	a := in.Ints(n)
+//	out.Ints(a)
+//
+// Reading never returns an error by itself. Like bufio.Scanner, a Reader
+// keeps the first error, returns zero values from then on and reports it
+// from Err, so a solution reads everything and checks once at the end.
+//
+// The practice directory has a template for solving problems with it and
+// solutions that use the repository's algorithms, all run by Main.
+package fastio
+
+import (
+	"bufio"
+	"errors"
+	"fmt"
+	"io"
+	"math"
+	"os"
+	"strconv"
+)
+
+// bufferSize is big enough that a large input takes few reads
+const bufferSize = 1 << 16
+
+// ErrSyntax is returned by Err when a value is not what was asked for,
+// like a word where an int was expected
+var ErrSyntax = errors.New("fastio: syntax error")
+
+// Reader reads whitespace-separated values
+type Reader struct {
+	r   *bufio.Reader
+	err error
+	buf []byte // reused by the methods that return a word
+}
+
+// NewReader returns a Reader that buffers r
+func NewReader(r io.Reader) *Reader {
+	return &Reader{r: bufio.NewReaderSize(r, bufferSize)}
+}
+
+// Err returns the first error the Reader met, or nil. Running out of input
+// before a value is io.ErrUnexpectedEOF
+func (r *Reader) Err() error {
+	return r.err
+}
+
+func (r *Reader) fail(err error) {
+	if r.err == nil {
+		r.err = err
+	}
+}
+
+// isSpace reports whether c separates values; judges only use ASCII
+func isSpace(c byte) bool {
+	return c == ' ' || c == '\n' || c == '\r' || c == '\t' || c == '\v' || c == '\f'
+}
+
+// token reads the next run of non-space bytes into r.buf and returns it,
+// or nil after an error
+// The slice is only valid until the next read
+func (r *Reader) token() []byte {
+	if r.err != nil {
+		return nil
+	}
+	c, err := r.r.ReadByte()
+	for err == nil && isSpace(c) {
+		c, err = r.r.ReadByte()
+	}
+	if err != nil {
+		if err == io.EOF {
+			err = io.ErrUnexpectedEOF
+		}
+		r.fail(err)
+		return nil
+	}
+	r.buf = r.buf[:0]
+	for err == nil && !isSpace(c) {
+		r.buf = append(r.buf, c)
+		c, err = r.r.ReadByte()
+	}
+	switch {
+	case err == nil:
+		// Leave the space for Line, which reads to the end of the line
+		r.r.UnreadByte()
+	case err != io.EOF:
+		r.fail(err)
+		return nil
+	}
+	return r.buf
+}
+
+// More reports whether there is another value to read, for inputs that end
+// without saying how many values they have
+func (r *Reader) More() bool {
+	if r.err != nil {
+		return false
+	}
+	for {
+		c, err := r.r.ReadByte()
+		if err != nil {
+			if err != io.EOF {
+				r.fail(err)
+			}
+			return false
+		}
+		if !isSpace(c) {
+			r.r.UnreadByte()
+			return true
+		}
+	}
+}
+
+// Int reads an int
+// It parses the digits itself: strconv.Atoi would need a string, which
+// costs an allocation per number
+func (r *Reader) Int() int {
+	tok := r.token()
+	if tok == nil {
+		return 0
+	}
+	digits, negative := tok, false
+	if digits[0] == '-' || digits[0] == '+' {
+		negative, digits = digits[0] == '-', digits[1:]
+	}
+	if len(digits) == 0 {
+		r.fail(fmt.Errorf("%w: %q is not an int", ErrSyntax, tok))
+		return 0
+	}
+	// Accumulate the negative value, which has room for math.MinInt
+	n := 0
+	for _, c := range digits {
+		d := int(c - '0')
+		if c < '0' || c > '9' {
+			r.fail(fmt.Errorf("%w: %q is not an int", ErrSyntax, tok))
+			return 0
+		}
+		if n < (math.MinInt+d)/10 {
+			r.fail(fmt.Errorf("%w: %s is out of range", ErrSyntax, tok))
+			return 0
+		}
+		n = n*10 - d
+	}
+	if !negative {
+		if n == math.MinInt {
+			r.fail(fmt.Errorf("%w: %s is out of range", ErrSyntax, tok))
+			return 0
+		}
+		n = -n
+	}
+	return n
+}
+
+// Ints reads n ints
+func (r *Reader) Ints(n int) []int {
+	a := make([]int, n)
+	for i := range a {
+		a[i] = r.Int()
+	}
+	return a
+}
+
+// Float reads a float64
+func (r *Reader) Float() float64 {
+	tok := r.token()
+	if tok == nil {
+		return 0
+	}
+	f, err := strconv.ParseFloat(string(tok), 64)
+	if err != nil {
+		r.fail(fmt.Errorf("%w: %q is not a number", ErrSyntax, tok))
+		return 0
+	}
+	return f
+}
+
+// Word reads the next whitespace-separated word
+func (r *Reader) Word() string {
+	return string(r.token())
+}
+
+// Words reads n words
+func (r *Reader) Words(n int) []string {
+	words := make([]string, n)
+	for i := range words {
+		words[i] = r.Word()
+	}
+	return words
+}
+
+// Line reads the rest of the current line, without the line break. After a
+// value at the end of a line, that is the empty rest of that line, so read
+// a line after the numbers before it with a second Line call
+func (r *Reader) Line() string {
+	if r.err != nil {
+		return ""
+	}
+	line, err := r.r.ReadString('\n')
+	if err != nil && (err != io.EOF || line == "") {
+		if err == io.EOF {
+			err = io.ErrUnexpectedEOF
+		}
+		r.fail(err)
+		return ""
+	}
+	if n := len(line); n > 0 && line[n-1] == '\n' {
+		line = line[:n-1]
+	}
+	if n := len(line); n > 0 && line[n-1] == '\r' {
+		line = line[:n-1]
+	}
+	return line
+}
+
+// Grid reads a grid of rows words of equal length, like a maze of '#' and
+// '.', as rows of bytes indexed grid[row][column]
+func (r *Reader) Grid(rows int) [][]byte {
+	grid := make([][]byte, rows)
+	for i := range grid {
+		grid[i] = []byte(r.Word())
+		if r.err == nil && len(grid[i]) != len(grid[0]) {
+			r.fail(fmt.Errorf("%w: grid row %d has %d columns, row 1 has %d", ErrSyntax, i+1, len(grid[i]), len(grid[0])))
+		}
+	}
+	return grid
+}
+
+// Writer buffers output; call Flush when done, or nothing may be written
+type Writer struct {
+	*bufio.Writer
+	scratch []byte
+}
+
+// NewWriter returns a Writer that buffers w
+func NewWriter(w io.Writer) *Writer {
+	return &Writer{Writer: bufio.NewWriterSize(w, bufferSize)}
+}
+
+// Int writes n and a line break
+func (w *Writer) Int(n int) {
+	w.scratch = strconv.AppendInt(w.scratch[:0], int64(n), 10)
+	w.Write(append(w.scratch, '\n'))
+}
+
+// Ints writes the ints on one line, separated by spaces
+func (w *Writer) Ints(a []int) {
+	w.scratch = w.scratch[:0]
+	for i, n := range a {
+		if i > 0 {
+			w.scratch = append(w.scratch, ' ')
+		}
+		w.scratch = strconv.AppendInt(w.scratch, int64(n), 10)
+	}
+	w.Write(append(w.scratch, '\n'))
+}
+
+// Println writes its operands like fmt.Println
+func (w *Writer) Println(a ...any) {
+	fmt.Fprintln(w.Writer, a...)
+}
+
+// Printf writes like fmt.Printf
+func (w *Writer) Printf(format string, a ...any) {
+	fmt.Fprintf(w.Writer, format, a...)
+}
+
+// Main runs a solution on standard input and output, the way a judge does:
+// it flushes the output when solve returns and exits with status 1 if solve
+// or the input failed
+func Main(solve func(in *Reader, out *Writer) error) {
+	in, out := NewReader(os.Stdin), NewWriter(os.Stdout)
+	err := solve(in, out)
+	if flushErr := out.Flush(); err == nil {
+		err = flushErr
+	}
+	if err != nil {
+		fmt.Fprintln(os.Stderr, err)
+		os.Exit(1)
+	}
+}
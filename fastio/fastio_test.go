@@ -0,0 +1,152 @@
+package fastio
+
+import (
+	"bufio"
+	"bytes"
+	"errors"
+	"fmt"
+	"io"
+	"math"
+	"slices"
+	"strconv"
+	"strings"
+	"testing"
+	"testing/iotest"
+)
+
+func TestReader(t *testing.T) {
+	in := NewReader(strings.NewReader("3\r\n-5 +12\t0\n\n  hello 2.5\n2\n#.#\n...\nlast line\n"))
+	if got := in.Ints(in.Int()); !slices.Equal(got, []int{-5, 12, 0}) {
+		t.Errorf("Ints = %v", got)
+	}
+	if got := in.Word(); got != "hello" {
+		t.Errorf("Word = %q", got)
+	}
+	if got := in.Float(); got != 2.5 {
+		t.Errorf("Float = %v", got)
+	}
+	grid := in.Grid(in.Int())
+	if len(grid) != 2 || string(grid[0]) != "#.#" || string(grid[1]) != "..." {
+		t.Errorf("Grid = %q", grid)
+	}
+	// The first Line is the rest of the grid's last row
+	if rest, got := in.Line(), in.Line(); rest != "" || got != "last line" {
+		t.Errorf("Line = %q, %q", rest, got)
+	}
+	if in.More() {
+		t.Error("More at the end of the input")
+	}
+	if err := in.Err(); err != nil {
+		t.Fatal(err)
+	}
+}
+
+func TestReaderIntLimits(t *testing.T) {
+	for _, n := range []int{0, 1, -1, math.MaxInt, math.MinInt, math.MaxInt / 10, math.MinInt / 10} {
+		in := NewReader(strings.NewReader(strconv.Itoa(n)))
+		if got := in.Int(); got != n || in.Err() != nil {
+			t.Errorf("Int(%d) = %d, %v", n, got, in.Err())
+		}
+	}
+}
+
+func TestReaderErrors(t *testing.T) {
+	tests := []struct {
+		input string
+		read  func(in *Reader)
+		want  error
+	}{
+		{"12x", func(in *Reader) { in.Int() }, ErrSyntax},
+		{"-", func(in *Reader) { in.Int() }, ErrSyntax},
+		{"9223372036854775808", func(in *Reader) { in.Int() }, ErrSyntax},
+		{"-9223372036854775809", func(in *Reader) { in.Int() }, ErrSyntax},
+		{"1.2.3", func(in *Reader) { in.Float() }, ErrSyntax},
+		{"3 1 2", func(in *Reader) { in.Ints(in.Int()) }, io.ErrUnexpectedEOF},
+		{"  \n", func(in *Reader) { in.Word() }, io.ErrUnexpectedEOF},
+		{"", func(in *Reader) { in.Line() }, io.ErrUnexpectedEOF},
+		{"ab abc", func(in *Reader) { in.Grid(2) }, ErrSyntax},
+	}
+	for _, tt := range tests {
+		in := NewReader(strings.NewReader(tt.input))
+		tt.read(in)
+		if err := in.Err(); !errors.Is(err, tt.want) {
+			t.Errorf("reading %q: err = %v, want %v", tt.input, err, tt.want)
+		}
+	}
+}
+
+// TestReaderKeepsFirstError checks that reads after an error return zero
+// values and leave the error alone
+func TestReaderKeepsFirstError(t *testing.T) {
+	in := NewReader(strings.NewReader("x 1 2"))
+	if got := in.Int(); got != 0 {
+		t.Errorf("Int = %d after a syntax error", got)
+	}
+	if got := in.Int(); got != 0 || !errors.Is(in.Err(), ErrSyntax) || in.More() {
+		t.Errorf("Int = %d, Err = %v after an error", got, in.Err())
+	}
+}
+
+func TestReaderSmallReads(t *testing.T) {
+	// One byte at a time, so values are split across reads
+	in := NewReader(iotest.OneByteReader(strings.NewReader("123456 -789\nword")))
+	if a, b, w := in.Int(), in.Int(), in.Word(); a != 123456 || b != -789 || w != "word" || in.Err() != nil {
+		t.Errorf("got %d %d %q, %v", a, b, w, in.Err())
+	}
+}
+
+func TestWriter(t *testing.T) {
+	var buf bytes.Buffer
+	out := NewWriter(&buf)
+	out.Int(-42)
+	out.Ints([]int{1, 2, 3})
+	out.Ints(nil)
+	out.Println("yes", 7)
+	out.Printf("%.2f\n", 0.5)
+	if buf.Len() != 0 {
+		t.Errorf("wrote %q before Flush", buf.String())
+	}
+	if err := out.Flush(); err != nil {
+		t.Fatal(err)
+	}
+	if want := "-42\n1 2 3\n\nyes 7\n0.50\n"; buf.String() != want {
+		t.Errorf("got %q, want %q", buf.String(), want)
+	}
+}
+
+// numbers returns n ints of up to 9 digits, separated by spaces
+func numbers(n int) string {
+	var sb strings.Builder
+	for i := range n {
+		sb.WriteString(strconv.Itoa(i*7919 - n))
+		sb.WriteByte(' ')
+	}
+	return sb.String()
+}
+
+func BenchmarkRead(b *testing.B) {
+	const n = 100_000
+	input := numbers(n)
+	b.SetBytes(int64(len(input)))
+	b.ResetTimer()
+	for range b.N {
+		in := NewReader(strings.NewReader(input))
+		in.Ints(n)
+	}
+}
+
+// BenchmarkFscan reads the same input as BenchmarkRead with fmt.Fscan on a
+// bufio.Reader, for comparison
+func BenchmarkFscan(b *testing.B) {
+	const n = 100_000
+	input := numbers(n)
+	b.SetBytes(int64(len(input)))
+	b.ResetTimer()
+	for range b.N {
+		in := bufio.NewReader(strings.NewReader(input))
+		a := make([]int, n)
+		for i := range a {
+			fmt.Fscan(in, &a[i])
+		}
+	}
+}
@@ -0,0 +1,84 @@
+// Command grid-paths finds the shortest way through a maze with a
+// breadth-first search over a datastructures.QueueOf.
+//
+// Input: the number of rows and columns, then the rows, made of '.' for
+// open cells, '#' for walls, one 'S' for the start and one 'T' for the
+// target.
+// Output: the fewest steps up, down, left or right from S to T, or -1 if T
+// can't be reached.
+//
+// Each open cell is a vertex with edges to its open neighbours. BFS reaches
+// every cell at distance d before any at distance d+1, so the first time it
+// reaches T is along a shortest path: O(rows × columns).
+package main
+
+import (
+	"fmt"
+
+	"github.com/NutProhmpiriya/go-basic/02-data-structures/datastructures"
+	"github.com/NutProhmpiriya/go-basic/fastio"
+)
+
+type cell struct{ row, col int }
+
+// find returns the cell holding c
+func find(grid [][]byte, c byte) (cell, error) {
+	for r, row := range grid {
+		for col, v := range row {
+			if v == c {
+				return cell{r, col}, nil
+			}
+		}
+	}
+	return cell{}, fmt.Errorf("the grid has no %c", c)
+}
+
+// shortestPath returns the number of steps from start to target, or -1
+func shortestPath(grid [][]byte, start, target cell) int {
+	dist := make(map[cell]int)
+	dist[start] = 0
+	var queue datastructures.QueueOf[cell]
+	queue.Enqueue(start)
+	for !queue.IsEmpty() {
+		c, _ := queue.Dequeue()
+		if c == target {
+			return dist[c]
+		}
+		for _, next := range []cell{{c.row - 1, c.col}, {c.row + 1, c.col}, {c.row, c.col - 1}, {c.row, c.col + 1}} {
+			if next.row < 0 || next.row >= len(grid) || next.col < 0 || next.col >= len(grid[0]) ||
+				grid[next.row][next.col] == '#' {
+				continue
+			}
+			if _, seen := dist[next]; !seen {
+				dist[next] = dist[c] + 1
+				queue.Enqueue(next)
+			}
+		}
+	}
+	return -1
+}
+
+func solve(in *fastio.Reader, out *fastio.Writer) error {
+	rows, cols := in.Int(), in.Int()
+	grid := in.Grid(rows)
+	if err := in.Err(); err != nil {
+		return err
+	}
+	if rows == 0 || len(grid[0]) != cols {
+		return fmt.Errorf("the grid should have %d columns", cols)
+	}
+	start, err := find(grid, 'S')
+	if err != nil {
+		return err
+	}
+	target, err := find(grid, 'T')
+	if err != nil {
+		return err
+	}
+	out.Int(shortestPath(grid, start, target))
+	return nil
+}
+
+func main() {
+	fastio.Main(solve)
+}
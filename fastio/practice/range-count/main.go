@@ -0,0 +1,56 @@
+// Command range-count solves a classic practice problem with
+// sorting.QuickSort and binary search.
+//
+// Input: n and q, then n integers a, then q queries l r.
+// Output: for each query, how many of the a are in [l, r].
+//
+// Counting the numbers for each query takes O(nq). Sorting a once makes
+// the numbers in [l, r] a contiguous run, and two binary searches find
+// where it starts and ends: O((n + q) log n) in all.
+package main
+
+import (
+	"github.com/NutProhmpiriya/go-basic/03-algorithms/sorting"
+	"github.com/NutProhmpiriya/go-basic/fastio"
+)
+
+// lowerBound returns the index of the first element of sorted that is at
+// least x, or len(sorted) if there is none
+// searching.BinarySearch only reports whether x is present; counting needs
+// the position where x would go
+// Time Complexity: O(log n)
+func lowerBound(sorted []int, x int) int {
+	lo, hi := 0, len(sorted)
+	for lo < hi {
+		mid := lo + (hi-lo)/2
+		if sorted[mid] < x {
+			lo = mid + 1
+		} else {
+			hi = mid
+		}
+	}
+	return lo
+}
+
+func solve(in *fastio.Reader, out *fastio.Writer) error {
+	n, q := in.Int(), in.Int()
+	a := in.Ints(n)
+	sorting.QuickSort(a)
+	for range q {
+		l, r := in.Int(), in.Int()
+		if in.Err() != nil {
+			break
+		}
+		// The numbers in [l, r] are those at least l but not at least r+1
+		count := 0
+		if l <= r {
+			count = lowerBound(a, r+1) - lowerBound(a, l)
+		}
+		out.Int(count)
+	}
+	return in.Err()
+}
+
+func main() {
+	fastio.Main(solve)
+}
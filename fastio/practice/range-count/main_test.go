@@ -0,0 +1,41 @@
+package main
+
+import (
+	"bytes"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/NutProhmpiriya/go-basic/fastio"
+)
+
+// TestSamples runs solve on every testdata/*.in file and compares the
+// output with the .out file next to it
+func TestSamples(t *testing.T) {
+	inputs, err := filepath.Glob("testdata/*.in")
+	if err != nil || len(inputs) == 0 {
+		t.Fatalf("no samples in testdata: %v", err)
+	}
+	for _, name := range inputs {
+		t.Run(filepath.Base(name), func(t *testing.T) {
+			input, err := os.ReadFile(name)
+			if err != nil {
+				t.Fatal(err)
+			}
+			want, err := os.ReadFile(strings.TrimSuffix(name, ".in") + ".out")
+			if err != nil {
+				t.Fatal(err)
+			}
+			var got bytes.Buffer
+			out := fastio.NewWriter(&got)
+			if err := solve(fastio.NewReader(bytes.NewReader(input)), out); err != nil {
+				t.Fatal(err)
+			}
+			out.Flush()
+			if got.String() != string(want) {
+				t.Errorf("got:\n%s\nwant:\n%s", got.String(), want)
+			}
+		})
+	}
+}
@@ -0,0 +1,32 @@
+// Command template is the starting point for a judge-style problem. Copy the
+// directory, write the solution in solve, put the sample input and expected
+// output from the problem statement in testdata/sample.in and sample.out,
+// and go test checks them:
+//
+//	cp -r fastio/practice/template fastio/practice/my-problem
+//	go test ./fastio/practice/my-problem
+//	go run ./fastio/practice/my-problem < input.txt
+//
+// The placeholder problem reads n and n numbers and prints their sum.
+package main
+
+import (
+	"github.com/NutProhmpiriya/go-basic/fastio"
+)
+
+// solve reads one test from in and writes the answer to out
+// Returning in.Err() reports input that ended early or had a word where
+// a number belonged
+func solve(in *fastio.Reader, out *fastio.Writer) error {
+	n := in.Int()
+	sum := 0
+	for _, x := range in.Ints(n) {
+		sum += x
+	}
+	out.Int(sum)
+	return in.Err()
+}
+
+func main() {
+	fastio.Main(solve)
+}
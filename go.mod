@@ -0,0 +1,3 @@
+module github.com/NutProhmpiriya/go-basic
+
+go 1.23
@@ -0,0 +1,285 @@
+package learn
+
+import (
+	"errors"
+	"fmt"
+	"math/rand"
+	"time"
+
+	_ "github.com/NutProhmpiriya/go-basic/03-algorithms/catalog"
+	"github.com/NutProhmpiriya/go-basic/03-algorithms/dp"
+	algorithms "github.com/NutProhmpiriya/go-basic/03-algorithms/registry"
+	"github.com/NutProhmpiriya/go-basic/03-algorithms/searching"
+	"github.com/NutProhmpiriya/go-basic/03-algorithms/sorting"
+	"github.com/NutProhmpiriya/go-basic/testdata/generator"
+	"github.com/NutProhmpiriya/go-basic/trace"
+)
+
+// printLimit is the longest input or result printed in full
+const printLimit = 20
+
+func init() {
+	// The 03-algorithms demos, one per package
+	for _, e := range []struct{ name, title, pkg string }{
+		{"sorting", "Sorting", "sorting"},
+		{"stability", "Sorting Stability", "sorting"},
+		{"searching", "Searching", "searching"},
+		{"strings", "String Algorithms", "stringalgo"},
+		{"dp", "Dynamic Programming", "dp"},
+		{"greedy", "Greedy Algorithms", "greedy"},
+		{"trees", "Tree Algorithms", "treealgo"},
+		{"lists", "Linked List Algorithms", "listalgo"},
+		{"heaps", "Heap Algorithms", "heapalgo"},
+		{"intmath", "Integer Math", "intmath"},
+		{"stats", "Streaming Statistics", "stats"},
+		{"sampling", "Weighted Sampling", "sampling"},
+		{"build-resolver", "Build Dependency Resolver", "graph"},
+		{"road-network", "Road Network", "graph"},
+		{"parallel-graph", "Parallel Graph Processing", "graph"},
+	} {
+		Register(Example{
+			ID:     "algorithms/" + e.name,
+			Title:  e.title,
+			Source: "03-algorithms/" + e.pkg,
+			Run:    program("./03-algorithms", "-demo="+e.name),
+		})
+	}
+
+	// An applied example using the heap, the Multiset and the tokenizer;
+	// without files of its own it reports on the sample text
+	Register(Example{
+		ID:     "algorithms/word-frequency",
+		Title:  "Word Frequency Report",
+		Source: "cmd/wordfreq",
+		Run: func(env *Env) error {
+			if len(env.Args) == 0 {
+				env = &Env{Out: env.Out, Args: []string{"cmd/wordfreq/testdata/sample.txt"}}
+			}
+			return program("./cmd/wordfreq")(env)
+		},
+	})
+
+	// The spell checker capstone: the Trie, edit distance and the BK-tree
+	Register(Example{
+		ID:     "algorithms/spell-check",
+		Title:  "Spell Checker",
+		Source: "cmd/spell",
+		Run: func(env *Env) error {
+			if len(env.Args) == 0 {
+				env = &Env{Out: env.Out, Args: []string{"check", "cmd/spell/testdata/sample.txt"}}
+			}
+			return program("./cmd/spell")(env)
+		},
+	})
+
+	// The social network: BFS, shortest paths and degrees on the Graph
+	Register(Example{
+		ID:     "algorithms/social-network",
+		Title:  "Social Network Graph",
+		Source: "cmd/social",
+		Run: func(env *Env) error {
+			if len(env.Args) == 0 {
+				env = &Env{Out: env.Out, Args: []string{"report", "alice"}}
+			}
+			return program("./cmd/social")(env)
+		},
+	})
+
+	// The job scheduler: a heap of run times, cron parsing and goroutines;
+	// without arguments it prints the sample jobs' next runs
+	Register(Example{
+		ID:     "algorithms/job-scheduler",
+		Title:  "Job Scheduler with Cron Expressions",
+		Source: "cmd/scheduler",
+		Run: func(env *Env) error {
+			if len(env.Args) == 0 {
+				env = &Env{Out: env.Out, Args: []string{"-plan", "12", "-from", "2026-01-01T08:50:00Z", "cmd/scheduler/testdata/jobs.txt"}}
+			}
+			return program("./cmd/scheduler")(env)
+		},
+	})
+
+	// The Huffman compressor: the greedy tree, canonical codes and bit I/O;
+	// without arguments it prints the codes of the sample text
+	Register(Example{
+		ID:     "algorithms/huffman-compressor",
+		Title:  "Huffman File Compressor",
+		Source: "cmd/huff",
+		Run: func(env *Env) error {
+			if len(env.Args) == 0 {
+				env = &Env{Out: env.Out, Args: []string{"codes", "cmd/huff/testdata/sample.txt"}}
+			}
+			return program("./cmd/huff")(env)
+		},
+	})
+
+	// Every algorithm of the registry on a generated input, sized with --size
+	for _, a := range algorithms.All() {
+		Register(Example{
+			ID:     "algorithms/" + a.ID(),
+			Title:  a.Name + ": " + a.Summary,
+			Source: "03-algorithms/" + sourceDir(a.Category),
+			Run:    algorithmExample(a),
+		})
+	}
+
+	// Step-by-step traces drawn as ASCII bars
+	for _, e := range []struct {
+		name, title string
+		sort        func([]int, trace.Tracer)
+	}{
+		{"bubblesort", "Bubble Sort, step by step", sorting.BubbleSortTrace},
+		{"insertionsort", "Insertion Sort, step by step", sorting.InsertionSortTrace},
+		{"quicksort", "Quick Sort, step by step", sorting.QuickSortTrace},
+	} {
+		Register(Example{
+			ID:     "algorithms/trace/" + e.name,
+			Title:  e.title,
+			Source: "03-algorithms/sorting/trace.go",
+			Run:    sortTraceExample(e.sort),
+		})
+	}
+	for _, e := range []struct {
+		name, title string
+		search      func([]int, int, trace.Tracer) int
+	}{
+		{"linear-search", "Linear Search, step by step", searching.LinearSearchTrace},
+		{"binary-search", "Binary Search, step by step", searching.BinarySearchTrace},
+	} {
+		Register(Example{
+			ID:     "algorithms/trace/" + e.name,
+			Title:  e.title,
+			Source: "03-algorithms/searching/trace.go",
+			Run:    searchTraceExample(e.search),
+		})
+	}
+}
+
+// The quick example of the tour
+func init() {
+	Register(Example{
+		ID:     "algorithms/tour/fibonacci",
+		Title:  "Fibonacci with dynamic programming",
+		Source: "03-algorithms/dp/dynamic_programming.go",
+		Run: func(env *Env) error {
+			for _, n := range []int{10, 25} {
+				start := time.Now()
+				slow := dp.FibonacciRecursive(n)
+				recursive := time.Since(start)
+				start = time.Now()
+				fast := dp.FibonacciDP(n)
+				table := time.Since(start)
+				fmt.Fprintf(env.Out, "fib(%d) = %d: recursion %v, table %v\n", n, fast, recursive, table)
+				if slow != fast {
+					return fmt.Errorf("fib(%d): recursion says %d, table %d", n, slow, fast)
+				}
+			}
+			return nil
+		},
+	})
+}
+
+// sourceDir returns the package directory of a registry category
+func sourceDir(category string) string {
+	if category == "strings" {
+		return "stringalgo"
+	}
+	return category
+}
+
+// defaultSizes are the input sizes when no --size is given: sorts and edit
+// distances small enough to print, searches big enough to be worth it
+var defaultSizes = map[algorithms.Kind]int{
+	algorithms.Ints:       10,
+	algorithms.SortedInts: 1000,
+	algorithms.Text:       40,
+	algorithms.StringPair: 12,
+}
+
+// algorithmExample runs a registered algorithm on env.Size generated values
+// and reports its result, its complexity and the time taken
+func algorithmExample(a algorithms.Algorithm) func(env *Env) error {
+	return func(env *Env) error {
+		n := env.size(defaultSizes[a.Input])
+		in := algorithms.NewInput(a.Input, n, generator.New(env.Seed))
+		fmt.Fprintf(env.Out, "Time %s, space %s\n", a.Time, a.Space)
+		switch a.Input {
+		case algorithms.Ints:
+			fmt.Fprintf(env.Out, "Input:  %s\n", show(in.Ints))
+		case algorithms.SortedInts:
+			fmt.Fprintf(env.Out, "Input:  %s, target %d\n", show(in.Ints), in.Target)
+		default:
+			fmt.Fprintf(env.Out, "Input:  %s, %s\n", show(in.Text), show(in.Pattern))
+		}
+
+		start := time.Now()
+		result := a.Run(in)
+		elapsed := time.Since(start)
+
+		fmt.Fprintf(env.Out, "Result: %s\n", show(result))
+		fmt.Fprintf(env.Out, "Took %v for size %d (seed %d)\n", elapsed, n, env.Seed)
+		return check(a.Input, in, result)
+	}
+}
+
+// check verifies the results that are cheap to verify: a sort returns its
+// input in order and a search finds its target
+func check(k algorithms.Kind, in algorithms.Input, result any) error {
+	switch k {
+	case algorithms.Ints:
+		if sorted, ok := result.([]int); !ok || len(sorted) != len(in.Ints) || !sorting.IsSorted(sorted) {
+			return errors.New("output is not sorted")
+		}
+	case algorithms.SortedInts:
+		if i, ok := result.(int); !ok || len(in.Ints) > 0 && (i < 0 || i >= len(in.Ints) || in.Ints[i] != in.Target) {
+			return fmt.Errorf("search for %d returned %v", in.Target, result)
+		}
+	}
+	return nil
+}
+
+// show formats v, cut short after printLimit values or characters
+func show(v any) string {
+	switch v := v.(type) {
+	case []int:
+		if len(v) > printLimit {
+			return fmt.Sprintf("%v... (%d values)", v[:printLimit], len(v))
+		}
+	case string:
+		if len(v) > printLimit {
+			return fmt.Sprintf("%q... (%d characters)", v[:printLimit], len(v))
+		}
+		return fmt.Sprintf("%q", v)
+	}
+	return fmt.Sprint(v)
+}
+
+// sortTraceExample draws every step of sorting env.Size random values (default 8)
+func sortTraceExample(sort func([]int, trace.Tracer)) func(env *Env) error {
+	return func(env *Env) error {
+		arr := generator.New(env.Seed).Perm(env.size(8))
+		sort(arr, trace.NewBars(env.Out, arr))
+		if !sorting.IsSorted(arr) {
+			return errors.New("output is not sorted")
+		}
+		return nil
+	}
+}
+
+// searchTraceExample draws every step of searching a sorted slice of
+// env.Size values (default 16) for one of them
+func searchTraceExample(search func([]int, int, trace.Tracer) int) func(env *Env) error {
+	return func(env *Env) error {
+		n := env.size(16)
+		arr := make([]int, n)
+		for i := range arr {
+			arr[i] = i + 1
+		}
+		target := rand.New(rand.NewSource(env.Seed)).Intn(n) + 1
+		fmt.Fprintf(env.Out, "Looking for %d\n\n", target)
+		if index := search(arr, target, trace.NewBars(env.Out, arr)); index != target-1 {
+			return fmt.Errorf("search for %d returned %d", target, index)
+		}
+		return nil
+	}
+}
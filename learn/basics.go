@@ -0,0 +1,148 @@
+package learn
+
+import (
+	"errors"
+	"fmt"
+	"strings"
+	"sync"
+)
+
+// The 01-basics examples are standalone programs; anything after the
+// example name on the command line is passed to them, e.g.
+//
+//	learn run basics/cli greet -name=Nok
+func init() {
+	for _, e := range []struct{ name, title string }{
+		{"variables", "Variables and Types"},
+		{"control-flow", "Control Flow"},
+		{"functions", "Functions"},
+		{"pointers", "Pointers"},
+		{"structs", "Structs"},
+		{"interfaces", "Interfaces"},
+		{"arrays-slices", "Arrays and Slices"},
+		{"slices-advanced", "Slices in Depth"},
+		{"maps", "Maps"},
+		{"error-handling", "Error Handling"},
+		{"defer-panic", "Defer, Panic and Recover"},
+		{"generics", "Generics"},
+		{"packages", "Packages"},
+		{"formatting", "Formatting with fmt"},
+		{"regexp", "Regular Expressions"},
+		{"json", "JSON"},
+		{"file-io", "File I/O"},
+		{"io-interfaces", "io.Reader and io.Writer"},
+		{"archives", "Archives"},
+		{"cli", "Command-line Programs"},
+		{"testing", "Testing"},
+		{"concurrency", "Goroutines and Channels"},
+		{"channels-advanced", "Channels in Depth"},
+		{"timers", "Timers and Tickers"},
+		{"context", "Context"},
+		{"goroutine-leaks", "Goroutine Leaks"},
+		{"graceful-shutdown", "Graceful Shutdown"},
+		{"crawler", "Concurrent Web Crawler"},
+	} {
+		Register(Example{
+			ID:     "basics/" + e.name,
+			Title:  e.title,
+			Source: "01-basics/" + e.name,
+			Run:    program("./01-basics/" + e.name),
+		})
+	}
+}
+
+// The quick examples of the tour run in-process: a few lines each, showing
+// the idea the program named by Source covers in depth
+func init() {
+	Register(Example{
+		ID:     "basics/tour/variables",
+		Title:  "Variables and zero values",
+		Source: "01-basics/variables/variables.go",
+		Run: func(env *Env) error {
+			name := "Gopher" // type inferred as string
+			var age int
+			var price float64
+			var label string
+			fmt.Fprintf(env.Out, "name=%q (%T)\n", name, name)
+			fmt.Fprintf(env.Out, "zero values: int=%d float64=%v string=%q\n", age, price, label)
+			return nil
+		},
+	})
+	Register(Example{
+		ID:     "basics/tour/control-flow",
+		Title:  "Control flow",
+		Source: "01-basics/control-flow/control-flow.go",
+		Run: func(env *Env) error {
+			for i := 1; i <= 5; i++ {
+				switch {
+				case i%2 == 0:
+					fmt.Fprintf(env.Out, "%d is even\n", i)
+				default:
+					fmt.Fprintf(env.Out, "%d is odd\n", i)
+				}
+			}
+			return nil
+		},
+	})
+	Register(Example{
+		ID:     "basics/tour/closures",
+		Title:  "Functions and closures",
+		Source: "01-basics/functions/functions.go",
+		Run: func(env *Env) error {
+			counter := func() func() int {
+				count := 0
+				return func() int {
+					count++
+					return count
+				}
+			}()
+			fmt.Fprintln(env.Out, counter(), counter(), counter())
+			return nil
+		},
+	})
+	Register(Example{
+		ID:     "basics/tour/errors",
+		Title:  "Errors are values",
+		Source: "01-basics/error-handling/error-handling.go",
+		Run: func(env *Env) error {
+			errDivideByZero := errors.New("division by zero")
+			divide := func(a, b int) (int, error) {
+				if b == 0 {
+					return 0, fmt.Errorf("divide %d by %d: %w", a, b, errDivideByZero)
+				}
+				return a / b, nil
+			}
+			for _, b := range []int{2, 0} {
+				result, err := divide(10, b)
+				fmt.Fprintf(env.Out, "10 / %d = %d, err = %v, is divide by zero? %v\n",
+					b, result, err, errors.Is(err, errDivideByZero))
+			}
+			return nil
+		},
+	})
+	Register(Example{
+		ID:     "basics/tour/goroutines",
+		Title:  "Goroutines and channels",
+		Source: "01-basics/concurrency/concurrency.go",
+		Run: func(env *Env) error {
+			words := []string{"goroutines", "talk", "over", "channels"}
+			results := make(chan string, len(words))
+			var wg sync.WaitGroup
+			for _, word := range words {
+				wg.Add(1)
+				go func() {
+					defer wg.Done()
+					results <- strings.ToUpper(word)
+				}()
+			}
+			wg.Wait()
+			close(results)
+			count := 0
+			for range results {
+				count++
+			}
+			fmt.Fprintf(env.Out, "%d goroutines sent their results\n", count)
+			return nil
+		},
+	})
+}
@@ -0,0 +1,161 @@
+package learn
+
+import (
+	"fmt"
+	"path/filepath"
+
+	"github.com/NutProhmpiriya/go-basic/02-data-structures/datastructures"
+	"github.com/NutProhmpiriya/go-basic/trace"
+)
+
+func init() {
+	for _, e := range []struct{ name, title string }{
+		{"stack", "Stack"},
+		{"expression", "Expression Evaluator"},
+		{"brackets", "Bracket Matcher"},
+		{"queue", "Queue"},
+		{"heap", "Heap"},
+		{"linkedlist", "Linked List"},
+		{"lru", "LRU Cache"},
+		{"tree", "Binary Search Tree"},
+		{"orderstat", "Order-statistics Tree"},
+		{"trie", "Trie"},
+		{"graph", "Graph"},
+		{"bktree", "BK-tree"},
+	} {
+		Register(Example{
+			ID:     "data-structures/" + e.name,
+			Title:  e.title,
+			Source: "02-data-structures/datastructures",
+			Run:    program("./02-data-structures", "-demo="+e.name),
+		})
+	}
+}
+
+func init() {
+	for _, e := range []struct {
+		name, title string
+		traverse    func(g *datastructures.Graph, start int, t trace.Tracer) []int
+	}{
+		{"bfs", "Breadth-first Search, step by step", (*datastructures.Graph).BFSTrace},
+		{"dfs", "Depth-first Search, step by step", (*datastructures.Graph).DFSTrace},
+	} {
+		Register(Example{
+			ID:     "data-structures/trace/" + e.name,
+			Title:  e.title,
+			Source: "02-data-structures/datastructures/graph_trace.go",
+			Run:    traversalExample(e.traverse),
+		})
+	}
+}
+
+func init() {
+	// The key-value store project: expiry, LRU eviction and an append-only
+	// file
+	Register(Example{
+		ID:     "data-structures/key-value-store",
+		Title:  "Key-value Store with TTL",
+		Source: "cmd/kv",
+		Run: func(env *Env) error {
+			if len(env.Args) == 0 {
+				env = &Env{Out: env.Out, Args: []string{"cmd/kv/testdata/session.txt"}}
+			}
+			return program("./cmd/kv")(env)
+		},
+	})
+}
+
+func init() {
+	Register(Example{
+		ID:     "data-structures/trace/bfs-dot",
+		Title:  "Breadth-first Search as Graphviz frames",
+		Source: "trace/dot.go",
+		Run:    bfsFramesExample,
+	})
+}
+
+// bfsFramesExample traces BFS on the grid graph into one DOT picture per
+// step. With a directory argument it writes them there as files to render
+// with Graphviz; without one it prints them
+func bfsFramesExample(env *Env) error {
+	g := gridGraph()
+	frames := trace.NewDOTFrames(g.Vertices(), g.Edges())
+	order := g.BFSTrace(0, frames)
+
+	if len(env.Args) == 0 {
+		for _, frame := range frames.Frames() {
+			fmt.Fprintln(env.Out, frame)
+		}
+		fmt.Fprintf(env.Out, "Result: %v\n", order)
+		return nil
+	}
+	paths, err := frames.WriteFiles(env.Args[0])
+	if err != nil {
+		return err
+	}
+	fmt.Fprintf(env.Out, "Wrote %d frames to %s, from %s to %s\n", len(paths), env.Args[0], paths[0], paths[len(paths)-1])
+	fmt.Fprintf(env.Out, "Render them with: for f in %s; do dot -Tpng \"$f\" -o \"${f%%.dot}.png\"; done\n",
+		filepath.Join(env.Args[0], "*.dot"))
+	return nil
+}
+
+// gridGraph builds the graph from the graph demo:
+//
+//	0 -- 1 -- 2
+//	|    |    |
+//	3 -- 4 -- 5
+func gridGraph() *datastructures.Graph {
+	g := datastructures.NewGraph()
+	for _, edge := range [][2]int{{0, 1}, {1, 2}, {0, 3}, {1, 4}, {2, 5}, {3, 4}, {4, 5}} {
+		g.AddEdge(edge[0], edge[1])
+	}
+	return g
+}
+
+// traversalExample traces a traversal of the grid graph from the graph demo
+func traversalExample(traverse func(*datastructures.Graph, int, trace.Tracer) []int) func(env *Env) error {
+	return func(env *Env) error {
+		g := gridGraph()
+		fmt.Fprintln(env.Out, "0 -- 1 -- 2\n|    |    |\n3 -- 4 -- 5")
+		fmt.Fprintln(env.Out)
+		order := traverse(g, 0, trace.NewGraphView(env.Out, g.Vertices()))
+		fmt.Fprintf(env.Out, "\nResult: %v\n", order)
+		return nil
+	}
+}
+
+// The quick examples of the tour
+func init() {
+	Register(Example{
+		ID:     "data-structures/tour/stack",
+		Title:  "Stack: last in, first out",
+		Source: "02-data-structures/datastructures/stack.go",
+		Run: func(env *Env) error {
+			var stack datastructures.Stack
+			for i := 1; i <= 3; i++ {
+				stack.Push(i)
+			}
+			for !stack.IsEmpty() {
+				top, _ := stack.Pop()
+				fmt.Fprintf(env.Out, "pop %d\n", top)
+			}
+			return nil
+		},
+	})
+	Register(Example{
+		ID:     "data-structures/tour/queue",
+		Title:  "Queue: first in, first out",
+		Source: "02-data-structures/datastructures/queue.go",
+		Run: func(env *Env) error {
+			var queue datastructures.QueueOf[string]
+			for _, job := range []string{"first", "second", "third"} {
+				queue.Enqueue(job)
+			}
+			for !queue.IsEmpty() {
+				front, _ := queue.Dequeue()
+				fmt.Fprintf(env.Out, "dequeue %s\n", front)
+			}
+			return nil
+		},
+	})
+}
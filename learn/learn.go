@@ -0,0 +1,135 @@
+// Package learn is a registry of every runnable example in this repository
+//
+// Each example registers itself from an init function under an ID of the
+// form topic/name, e.g. "basics/variables" or "algorithms/sorting/quicksort".
+// Examples backed by a library package run in-process and take their input
+// size and seed from Env; the standalone programs (the 01-basics examples
+// and the demo runners of the other sections) are started with go run.
+// The learn command lists and runs them by name:
+//
+//	go run ./cmd/learn list
+//	go run ./cmd/learn run algorithms/sorting/quicksort --size 1000
+//
+// Tour picks a path through the examples for a first read, a few quick
+// in-process ones per topic, which the gobasic tour command walks through
+// one step at a time:
+//
+//	go run ./cmd/gobasic tour
+package learn
+
+import (
+	"errors"
+	"fmt"
+	"io"
+	"os"
+	"os/exec"
+	"slices"
+	"sort"
+	"strings"
+)
+
+// ErrUnknownExample is returned when looking up an example that was never registered
+var ErrUnknownExample = errors.New("unknown example")
+
+// Topics are the sections of the repository, in learning order
+// Every example ID starts with one of them
+var Topics = []string{"basics", "data-structures", "algorithms", "patterns"}
+
+// Env is what an example runs with
+type Env struct {
+	Out io.Writer
+	// Size is the input size for examples that generate data; 0 picks the example's default
+	Size int
+	// Seed makes generated inputs reproducible
+	Seed int64
+	// Args are passed on to examples that are standalone programs
+	Args []string
+}
+
+// size returns env.Size, or def when no size was given
+func (env *Env) size(def int) int {
+	if env.Size > 0 {
+		return env.Size
+	}
+	return def
+}
+
+// Example is one runnable example
+type Example struct {
+	// ID is "topic/name", e.g. "algorithms/sorting/quicksort"
+	ID    string
+	Title string
+	// Source is the file or directory holding the example's code
+	Source string
+	Run    func(env *Env) error
+}
+
+// Topic returns the first segment of the example's ID
+func (e Example) Topic() string {
+	topic, _, _ := strings.Cut(e.ID, "/")
+	return topic
+}
+
+var (
+	registry = make(map[string]Example)
+	// order keeps registration order, so examples list in the order their file declares them
+	order []string
+)
+
+// Register adds an example to the registry
+// It is meant to be called from init and panics on a duplicate ID or an ID
+// outside the known topics, like database/sql.Register does for drivers
+func Register(e Example) {
+	topic, name, _ := strings.Cut(e.ID, "/")
+	if name == "" || topicIndex(topic) < 0 {
+		panic("learn: example ID " + e.ID + " is not of the form topic/name with a known topic")
+	}
+	if _, dup := registry[e.ID]; dup {
+		panic("learn: Register called twice for example " + e.ID)
+	}
+	registry[e.ID] = e
+	order = append(order, e.ID)
+}
+
+func topicIndex(topic string) int {
+	return slices.Index(Topics, topic)
+}
+
+// Examples returns the registered examples of the given topics, grouped by
+// topic in learning order. With no topics it returns every example
+func Examples(topics ...string) []Example {
+	examples := make([]Example, 0, len(order))
+	for _, id := range order {
+		e := registry[id]
+		if len(topics) == 0 || slices.Contains(topics, e.Topic()) {
+			examples = append(examples, e)
+		}
+	}
+	sort.SliceStable(examples, func(i, j int) bool {
+		return topicIndex(examples[i].Topic()) < topicIndex(examples[j].Topic())
+	})
+	return examples
+}
+
+// Lookup returns the example registered under id
+func Lookup(id string) (Example, error) {
+	e, ok := registry[id]
+	if !ok {
+		return Example{}, fmt.Errorf("%w %q", ErrUnknownExample, id)
+	}
+	return e, nil
+}
+
+// program returns a Run function that starts a main package with go run,
+// passing flags first and then the user's arguments
+// It must be called from the repository root, where the package paths resolve
+func program(pkg string, flags ...string) func(env *Env) error {
+	return func(env *Env) error {
+		args := append([]string{"run", pkg}, flags...)
+		cmd := exec.Command("go", append(args, env.Args...)...)
+		cmd.Stdin = os.Stdin
+		cmd.Stdout = env.Out
+		cmd.Stderr = os.Stderr
+		return cmd.Run()
+	}
+}
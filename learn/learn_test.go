@@ -0,0 +1,121 @@
+package learn
+
+import (
+	"errors"
+	"io"
+	"os"
+	"path/filepath"
+	"slices"
+	"strings"
+	"testing"
+)
+
+func TestExamplesGroupedByTopic(t *testing.T) {
+	last := 0
+	for _, e := range Examples() {
+		i := topicIndex(e.Topic())
+		if i < last {
+			t.Fatalf("%s listed after a later topic", e.ID)
+		}
+		last = i
+	}
+
+	for _, e := range Examples("patterns") {
+		if e.Topic() != "patterns" {
+			t.Errorf("Examples(patterns) returned %s", e.ID)
+		}
+	}
+}
+
+func TestLookup(t *testing.T) {
+	if e, err := Lookup("algorithms/sorting/quicksort"); err != nil || e.Run == nil {
+		t.Errorf("Lookup(quicksort) = %+v, %v", e, err)
+	}
+	if _, err := Lookup("algorithms/sorting/bogosort"); !errors.Is(err, ErrUnknownExample) {
+		t.Errorf("Lookup(bogosort) error = %v, want ErrUnknownExample", err)
+	}
+}
+
+func TestRegisterRejectsBadIDs(t *testing.T) {
+	for _, id := range []string{"", "basics", "cooking/pasta", "basics/variables"} {
+		func() {
+			defer func() {
+				if recover() == nil {
+					t.Errorf("Register(%q) did not panic", id)
+				}
+			}()
+			Register(Example{ID: id})
+		}()
+	}
+}
+
+// Every example points at code that exists, relative to the repository root
+func TestSourcesExist(t *testing.T) {
+	for _, e := range Examples() {
+		if _, err := os.Stat(filepath.Join("..", e.Source)); err != nil {
+			t.Errorf("%s: %v", e.ID, err)
+		}
+	}
+}
+
+// The in-process examples run without the go tool, so they can all be run here
+func TestInProcessExamples(t *testing.T) {
+	for _, e := range Examples("algorithms") {
+		if strings.Count(e.ID, "/") != 2 || strings.HasPrefix(e.ID, "algorithms/trace/") || strings.HasPrefix(e.ID, "algorithms/tour/") {
+			continue
+		}
+		for _, size := range []int{0, 1, 257} {
+			if err := e.Run(&Env{Out: io.Discard, Size: size, Seed: 7}); err != nil {
+				t.Errorf("%s with size %d: %v", e.ID, size, err)
+			}
+		}
+	}
+}
+
+// Every step of the tour is a registered example of its lesson's topic
+// that runs in-process
+func TestTour(t *testing.T) {
+	var topics []string
+	for _, l := range Tour {
+		topics = append(topics, l.Topic)
+		for _, step := range l.Steps {
+			e, err := Lookup(step.ID)
+			if err != nil {
+				t.Fatalf("lesson %s: %v", l.Topic, err)
+			}
+			if e.Topic() != l.Topic {
+				t.Errorf("lesson %s has a step from %s", l.Topic, e.Topic())
+			}
+		}
+	}
+	if !slices.Equal(topics, Topics) {
+		t.Errorf("the tour's lessons are %v, want one per topic: %v", topics, Topics)
+	}
+
+	var out strings.Builder
+	r := &Runner{In: strings.NewReader(""), Out: &out, Seed: 1}
+	if err := r.Run(Tour); err != nil {
+		t.Fatal(err)
+	}
+	if !strings.Contains(out.String(), "Tour complete") {
+		t.Errorf("the tour didn't finish:\n%s", out.String())
+	}
+}
+
+func TestTourStops(t *testing.T) {
+	l, err := TourLesson("basics")
+	if err != nil {
+		t.Fatal(err)
+	}
+	var out strings.Builder
+	r := &Runner{In: strings.NewReader("\nq\n"), Out: &out, Pause: true}
+	if err := r.Run([]Lesson{l}); err != nil {
+		t.Fatal(err)
+	}
+	if got := strings.Count(out.String(), "Full example:"); got != 2 {
+		t.Errorf("ran %d steps before q, want 2", got)
+	}
+	if _, err := TourLesson("cooking"); err == nil {
+		t.Error("TourLesson(cooking) succeeded")
+	}
+}
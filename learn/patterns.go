@@ -0,0 +1,166 @@
+package learn
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"github.com/NutProhmpiriya/go-basic/04-design-patterns/behavioral"
+	"github.com/NutProhmpiriya/go-basic/04-design-patterns/creational"
+	"github.com/NutProhmpiriya/go-basic/04-design-patterns/resilience"
+	"github.com/NutProhmpiriya/go-basic/04-design-patterns/structural"
+)
+
+func init() {
+	for _, e := range []struct{ name, title, category string }{
+		{"singleton", "Singleton Pattern", "creational"},
+		{"factory", "Factory Pattern", "creational"},
+		{"builder", "Builder Pattern", "creational"},
+		{"plugin", "Plugin Registration Pattern", "creational"},
+		{"adapter", "Adapter Pattern", "structural"},
+		{"decorator", "Decorator Pattern", "structural"},
+		{"middleware", "Middleware (Decorator) Pattern", "structural"},
+		{"facade", "Facade Pattern", "structural"},
+		{"observer", "Observer Pattern", "behavioral"},
+		{"event-bus", "Event Bus (Observer) Pattern", "behavioral"},
+		{"pubsub", "Publish-Subscribe Pattern", "behavioral"},
+		{"strategy", "Strategy Pattern", "behavioral"},
+		{"chain", "Chain of Responsibility Pattern", "behavioral"},
+		{"null-object", "Null Object Pattern", "behavioral"},
+		{"retry", "Retry Pattern", "resilience"},
+		{"semaphore", "Semaphore Pattern", "concurrency"},
+		{"rate-limit", "Rate Limiting Pattern", "concurrency"},
+		{"future", "Future Pattern", "concurrency"},
+		{"singleflight", "Single-flight Pattern", "concurrency"},
+		{"actor", "Actor Pattern", "concurrency"},
+		{"cqrs", "CQRS + Event Sourcing", "architectural"},
+		{"unit-of-work", "Unit of Work Pattern", "architectural"},
+	} {
+		Register(Example{
+			ID:     "patterns/" + e.name,
+			Title:  e.title,
+			Source: "04-design-patterns/" + e.category,
+			Run:    program("./04-design-patterns", "-pattern="+e.name),
+		})
+	}
+
+	// The structured logger: Chain, Strategy, Composite and Singleton in
+	// one package
+	Register(Example{
+		ID:     "patterns/logging",
+		Title:  "Structured Logger (Chain + Strategy + Singleton)",
+		Source: "04-design-patterns/logging",
+		Run:    program("./04-design-patterns", "-pattern=logging"),
+	})
+
+	// The configuration loader: the Builder and the Singleton, with reload
+	Register(Example{
+		ID:     "patterns/config",
+		Title:  "Configuration Loader (Builder + Singleton)",
+		Source: "04-design-patterns/config",
+		Run:    program("./04-design-patterns", "-pattern=config"),
+	})
+
+	// The report generator: the Template Method and the Strategy
+	Register(Example{
+		ID:     "patterns/report",
+		Title:  "Report Generator (Template Method + Strategy)",
+		Source: "04-design-patterns/report",
+		Run:    program("./04-design-patterns", "-pattern=report"),
+	})
+
+	// The state machine DSL: the State pattern as a table of transitions
+	Register(Example{
+		ID:     "patterns/fsm",
+		Title:  "State Machine DSL (State as a table)",
+		Source: "04-design-patterns/fsm",
+		Run:    program("./04-design-patterns", "-pattern=fsm"),
+	})
+
+	// The URL shortener project: Singleton, Factory, Strategy, Repository
+	// and the LRU cache working together
+	Register(Example{
+		ID:     "patterns/url-shortener",
+		Title:  "URL Shortener",
+		Source: "cmd/shortener",
+		Run: func(env *Env) error {
+			if len(env.Args) == 0 {
+				env = &Env{Out: env.Out, Args: []string{"-demo"}}
+			}
+			return program("./cmd/shortener")(env)
+		},
+	})
+
+	// The ATM: error types checked with errors.Is and errors.As, and
+	// Command-pattern transactions that can be undone
+	Register(Example{
+		ID:     "patterns/atm",
+		Title:  "Bank Teller (ATM)",
+		Source: "cmd/atm",
+		Run: func(env *Env) error {
+			if len(env.Args) == 0 {
+				env = &Env{Out: env.Out, Args: []string{"cmd/atm/testdata/session.txt"}}
+			}
+			return program("./cmd/atm")(env)
+		},
+	})
+}
+
+// The quick examples of the tour
+func init() {
+	Register(Example{
+		ID:     "patterns/tour/singleton",
+		Title:  "Singleton",
+		Source: "04-design-patterns/creational/singleton.go",
+		Run: func(env *Env) error {
+			a, b := creational.GetInstance(), creational.GetInstance()
+			fmt.Fprintf(env.Out, "same instance? %v\n", a == b)
+			return nil
+		},
+	})
+	Register(Example{
+		ID:     "patterns/tour/decorator",
+		Title:  "Decorator",
+		Source: "04-design-patterns/structural/decorator.go",
+		Run: func(env *Env) error {
+			coffee := structural.Decorate[structural.Coffee](&structural.SimpleCoffee{},
+				structural.NewMilkDecorator, structural.NewSugarDecorator)
+			fmt.Fprintf(env.Out, "%s costs %.2f\n", coffee.GetDescription(), coffee.GetCost())
+			return nil
+		},
+	})
+	Register(Example{
+		ID:     "patterns/tour/strategy",
+		Title:  "Strategy",
+		Source: "04-design-patterns/behavioral/strategy.go",
+		Run: func(env *Env) error {
+			cart := behavioral.NewShoppingCart(behavioral.NewCreditCardStrategy("1234", "123"))
+			fmt.Fprintln(env.Out, cart.Checkout(20))
+			cart.SetPaymentStrategy(behavioral.NewPayPalStrategy("gopher@example.com", "secret"))
+			fmt.Fprintln(env.Out, cart.Checkout(20))
+			return nil
+		},
+	})
+	Register(Example{
+		ID:     "patterns/tour/retry",
+		Title:  "Retry with backoff",
+		Source: "04-design-patterns/resilience/retry.go",
+		Run: func(env *Env) error {
+			clock := resilience.NewFakeClock(time.Time{})
+			calls := 0
+			err := resilience.Retrier{
+				MaxAttempts: 4,
+				Backoff:     resilience.ExponentialBackoff{Initial: 100 * time.Millisecond},
+				Clock:       clock,
+			}.Do(context.Background(), func(context.Context) error {
+				calls++
+				if calls < 3 {
+					return fmt.Errorf("attempt %d failed", calls)
+				}
+				return nil
+			})
+			fmt.Fprintf(env.Out, "err=%v after %d calls, waited %v\n", err, calls, clock.Waits())
+			return err
+		},
+	})
+}
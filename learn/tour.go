@@ -0,0 +1,120 @@
+package learn
+
+import (
+	"bufio"
+	"fmt"
+	"io"
+	"strings"
+)
+
+// Lesson is one topic of the tour: examples from the registry to run in
+// order, each introduced by an explanation
+type Lesson struct {
+	// Topic is one of Topics, and names the lesson on the command line
+	Topic string
+	Title string
+	Steps []Step
+}
+
+// Step is an example of the tour and what to read before it runs
+type Step struct {
+	ID          string
+	Explanation string
+}
+
+// Tour is the curriculum, in learning order: a first pass over the
+// repository through a few examples per topic
+// Every step is an in-process example, so the tour needs neither the go
+// tool nor the repository root
+var Tour = []Lesson{
+	{"basics", "Go Basics", []Step{
+		{"basics/tour/variables", "Variables are declared with var or :=. A variable that is never assigned holds its type's zero value."},
+		{"basics/tour/control-flow", "Go has a single loop keyword, for, and a switch that does not fall through."},
+		{"basics/tour/closures", "Functions are values. A closure keeps the variables it captures alive between calls."},
+		{"basics/tour/errors", "Functions return an error as their last result; callers check it and can match it with errors.Is."},
+		{"basics/tour/goroutines", "go starts a goroutine; channels pass values between them and sync.WaitGroup waits for them to finish."},
+	}},
+	{"data-structures", "Data Structures", []Step{
+		{"data-structures/tour/stack", "Push and Pop work on the end of a slice: a LIFO stack."},
+		{"data-structures/tour/queue", "Enqueue adds at the back and Dequeue takes from the front: a FIFO queue."},
+		{"data-structures/trace/bfs", "A graph kept as adjacency lists; breadth-first search visits it level by level, using a queue."},
+	}},
+	{"algorithms", "Algorithms", []Step{
+		{"algorithms/sorting/insertionsort", "Each element is moved left until it is in place. O(n²) in general but O(n) on nearly sorted input."},
+		{"algorithms/searching/binary", "Halving a sorted slice finds a value in O(log n)."},
+		{"algorithms/tour/fibonacci", "Storing answers to subproblems turns the exponential recursive Fibonacci into a linear loop."},
+	}},
+	{"patterns", "Design Patterns", []Step{
+		{"patterns/tour/singleton", "Every call to GetInstance returns the same object, created once even under concurrency."},
+		{"patterns/tour/decorator", "Decorators wrap an object with the same interface and add behaviour on top."},
+		{"patterns/tour/strategy", "The payment algorithm is chosen at runtime without changing the shopping cart."},
+		{"patterns/tour/retry", "A failing call is retried with growing delays. The fake clock records the delays instead of sleeping."},
+	}},
+}
+
+// TourLesson returns the lesson of the tour for topic
+func TourLesson(topic string) (Lesson, error) {
+	for _, l := range Tour {
+		if l.Topic == topic {
+			return l, nil
+		}
+	}
+	return Lesson{}, fmt.Errorf("no lesson for topic %q (topics: %s)", topic, strings.Join(Topics, ", "))
+}
+
+// Runner walks a user through lessons
+type Runner struct {
+	In  io.Reader
+	Out io.Writer
+	// Pause waits for Enter after every step; without it all steps run straight through
+	Pause bool
+	// Seed is passed on to the examples
+	Seed int64
+}
+
+// Run presents every step of the lessons in order
+// When pausing, typing "q" (or closing the input) ends the tour early
+func (r *Runner) Run(lessons []Lesson) error {
+	in := bufio.NewReader(r.In)
+	total := 0
+	for _, l := range lessons {
+		total += len(l.Steps)
+	}
+
+	done := 0
+	for _, l := range lessons {
+		fmt.Fprintf(r.Out, "\n##### %s #####\n", l.Title)
+		for i, step := range l.Steps {
+			done++
+			e, err := Lookup(step.ID)
+			if err != nil {
+				return err
+			}
+			fmt.Fprintf(r.Out, "\n[%d/%d] %s (step %d of %d)\n", done, total, e.Title, i+1, len(l.Steps))
+			if step.Explanation != "" {
+				fmt.Fprintln(r.Out, step.Explanation)
+			}
+			fmt.Fprintf(r.Out, "Full example: %s\n", e.Source)
+			fmt.Fprintln(r.Out, "---")
+			if err := e.Run(&Env{Out: r.Out, Seed: r.Seed}); err != nil {
+				return fmt.Errorf("%s: %w", e.ID, err)
+			}
+
+			if !r.Pause || done == total {
+				continue
+			}
+			fmt.Fprint(r.Out, "\nPress Enter to continue (q to quit)... ")
+			line, err := in.ReadString('\n')
+			if strings.TrimSpace(line) == "q" || err == io.EOF {
+				fmt.Fprintln(r.Out, "\nTour stopped.")
+				return nil
+			}
+			if err != nil {
+				return err
+			}
+		}
+	}
+
+	fmt.Fprintf(r.Out, "\nTour complete: %d step(s) in %d lesson(s).\n", total, len(lessons))
+	return nil
+}
@@ -0,0 +1,23 @@
+package ordering_test
+
+import (
+	"fmt"
+	"slices"
+
+	"github.com/NutProhmpiriya/go-basic/ordering"
+)
+
+func ExampleComparator_ThenComparing() {
+	type player struct {
+		name  string
+		score int
+	}
+	players := []player{{"cleo", 90}, {"ava", 85}, {"ben", 90}, {"dan", 70}}
+
+	// Highest score first, ties in alphabetical order
+	byScore := ordering.By(func(p player) int { return p.score }).Reversed()
+	byName := ordering.By(func(p player) string { return p.name })
+	slices.SortFunc(players, byScore.ThenComparing(byName))
+	fmt.Println(players)
+	// Output: [{ben 90} {cleo 90} {ava 85} {dan 70}]
+}
@@ -0,0 +1,74 @@
+// Package ordering builds comparison functions out of smaller ones
+//
+// A Comparator is a function in the style of cmp.Compare: negative when a
+// comes before b, positive when it comes after, zero when they are equal.
+// That is the order the repository's sorts, trees and heaps take, so one
+// comparator orders a slice, an OrderStatTree and a Heap the same way:
+//
+//	byScore := ordering.By(func(p Player) int { return p.Score }).Reversed()
+//	byName := ordering.By(func(p Player) string { return p.Name })
+//	ranking := byScore.ThenComparing(byName)
+//
+//	sorting.MergeSortFunc(players, ranking)
+//	datastructures.NewOrderStatTree(ranking)
+//	datastructures.NewHeapFunc(ranking)
+//
+// A Comparator is a plain function type, so it can be passed wherever a
+// func(a, b T) int is expected, slices.SortFunc included, and a function
+// literal can be used wherever a Comparator is.
+package ordering
+
+import "cmp"
+
+// Comparator orders values of type T the way cmp.Compare orders numbers
+type Comparator[T any] func(a, b T) int
+
+// Natural orders values with <, so strings alphabetically and numbers from
+// the smallest up
+func Natural[T cmp.Ordered]() Comparator[T] {
+	return cmp.Compare[T]
+}
+
+// By orders values by a key taken from each of them, usually a field
+//
+//	ordering.By(func(p Player) string { return p.Name })
+func By[T any, K cmp.Ordered](key func(T) K) Comparator[T] {
+	return func(a, b T) int { return cmp.Compare(key(a), key(b)) }
+}
+
+// ByFunc orders values by a key that isn't cmp.Ordered, with compare
+// ordering the keys, e.g. ByFunc(dueTime, time.Time.Compare)
+func ByFunc[T, K any](key func(T) K, compare func(a, b K) int) Comparator[T] {
+	return func(a, b T) int { return compare(key(a), key(b)) }
+}
+
+// Chain orders by the first comparator, breaking its ties with the second,
+// and so on, like cmp.Or does with the results. It orders everything as
+// equal when there are no comparators
+func Chain[T any](comparators ...Comparator[T]) Comparator[T] {
+	return func(a, b T) int {
+		for _, c := range comparators {
+			if r := c(a, b); r != 0 {
+				return r
+			}
+		}
+		return 0
+	}
+}
+
+// Reversed returns the opposite order, largest first where c puts the
+// smallest first
+func (c Comparator[T]) Reversed() Comparator[T] {
+	return func(a, b T) int { return c(b, a) }
+}
+
+// ThenComparing orders by c and breaks its ties with next
+func (c Comparator[T]) ThenComparing(next Comparator[T]) Comparator[T] {
+	return Chain(c, next)
+}
+
+// Less reports whether a comes before b, for the APIs that take a less
+// function, like sort.Slice
+func (c Comparator[T]) Less(a, b T) bool {
+	return c(a, b) < 0
+}
@@ -0,0 +1,85 @@
+package ordering
+
+import (
+	"slices"
+	"strings"
+	"testing"
+	"testing/quick"
+	"time"
+)
+
+type player struct {
+	name  string
+	score int
+}
+
+var players = []player{
+	{"cleo", 90}, {"ava", 85}, {"ben", 90}, {"dan", 70}, {"eve", 85},
+}
+
+func names(ps []player) string {
+	var s []string
+	for _, p := range ps {
+		s = append(s, p.name)
+	}
+	return strings.Join(s, " ")
+}
+
+func TestComparators(t *testing.T) {
+	byName := By(func(p player) string { return p.name })
+	byScore := By(func(p player) int { return p.score })
+	tests := []struct {
+		name string
+		c    Comparator[player]
+		want string
+	}{
+		{"By", byName, "ava ben cleo dan eve"},
+		{"Reversed", byName.Reversed(), "eve dan cleo ben ava"},
+		{"ThenComparing", byScore.ThenComparing(byName), "dan ava eve ben cleo"},
+		{"Reversed ThenComparing", byScore.Reversed().ThenComparing(byName), "ben cleo ava eve dan"},
+		{"Reversed chain", byScore.ThenComparing(byName).Reversed(), "cleo ben eve ava dan"},
+		{"Chain", Chain(byScore.Reversed(), byName.Reversed()), "cleo ben eve ava dan"},
+	}
+	for _, tt := range tests {
+		ps := slices.Clone(players)
+		slices.SortFunc(ps, tt.c)
+		if got := names(ps); got != tt.want {
+			t.Errorf("%s: sorted %s, want %s", tt.name, got, tt.want)
+		}
+	}
+}
+
+// An empty chain finds everything equal, so a stable sort keeps the order
+func TestEmptyChain(t *testing.T) {
+	ps := slices.Clone(players)
+	slices.SortStableFunc(ps, Chain[player]())
+	if !slices.Equal(ps, players) {
+		t.Errorf("sorted with an empty chain: %v", ps)
+	}
+}
+
+func TestByFunc(t *testing.T) {
+	type job struct {
+		name string
+		due  time.Time
+	}
+	base := time.Date(2026, 1, 1, 0, 0, 0, 0, time.UTC)
+	jobs := []job{{"c", base.Add(time.Hour)}, {"a", base}, {"b", base.Add(time.Minute)}}
+	slices.SortFunc(jobs, ByFunc(func(j job) time.Time { return j.due }, time.Time.Compare))
+	if got := jobs[0].name + jobs[1].name + jobs[2].name; got != "abc" {
+		t.Errorf("sorted by due time: %s, want abc", got)
+	}
+}
+
+// Property: Natural agrees with <, Reversed with >, and Less with c < 0
+func TestNaturalProperty(t *testing.T) {
+	c := Natural[int]()
+	property := func(a, b int) bool {
+		return c.Less(a, b) == (a < b) &&
+			c.Reversed().Less(a, b) == (a > b) &&
+			(c(a, b) == 0) == (a == b)
+	}
+	if err := quick.Check(property, nil); err != nil {
+		t.Error(err)
+	}
+}
@@ -0,0 +1,49 @@
+package profiling
+
+import (
+	"context"
+	"io"
+	"runtime"
+	"runtime/pprof"
+	"runtime/trace"
+)
+
+// CPUProfile runs fn while the CPU profiler samples it and writes the profile to w
+// Only one CPU profile can be active at a time in a process
+func CPUProfile(w io.Writer, fn func()) error {
+	if err := pprof.StartCPUProfile(w); err != nil {
+		return err
+	}
+	fn()
+	pprof.StopCPUProfile()
+	return nil
+}
+
+// HeapProfile writes a heap profile to w
+// The profile only counts allocations up to the last garbage collection, so
+// it runs one first to include everything allocated so far
+//
+// The profiler records about one allocation per runtime.MemProfileRate bytes;
+// set that to 1 as early as possible to see every allocation
+func HeapProfile(w io.Writer) error {
+	runtime.GC()
+	return pprof.Lookup("heap").WriteTo(w, 0)
+}
+
+// Trace runs the workloads one after the other while recording an execution
+// trace to w
+// Each workload is a task in the trace, so go tool trace can show them
+// separately under "User-defined tasks"
+func Trace(w io.Writer, workloads ...Workload) error {
+	if err := trace.Start(w); err != nil {
+		return err
+	}
+	defer trace.Stop()
+
+	for _, wl := range workloads {
+		ctx, task := trace.NewTask(context.Background(), wl.Name)
+		trace.WithRegion(ctx, "run", wl.Run)
+		task.End()
+	}
+	return nil
+}
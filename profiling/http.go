@@ -0,0 +1,41 @@
+package profiling
+
+import (
+	"errors"
+	"fmt"
+	"net/http"
+	"net/http/pprof"
+	"time"
+)
+
+// Handler serves the net/http/pprof endpoints under /debug/pprof/ and runs
+// workloads on POST /run/{name}
+//
+// Importing net/http/pprof also registers the endpoints on
+// http.DefaultServeMux; Handler registers them on its own mux instead, so they
+// are only exposed where it is mounted. Profile a running server with e.g.
+//
+//	go tool pprof 'http://localhost:6060/debug/pprof/profile?seconds=10'
+//
+// while workloads run in a loop
+func Handler() http.Handler {
+	mux := http.NewServeMux()
+	mux.HandleFunc("GET /debug/pprof/", pprof.Index)
+	mux.HandleFunc("GET /debug/pprof/cmdline", pprof.Cmdline)
+	mux.HandleFunc("GET /debug/pprof/profile", pprof.Profile)
+	mux.HandleFunc("GET /debug/pprof/symbol", pprof.Symbol)
+	mux.HandleFunc("POST /debug/pprof/symbol", pprof.Symbol)
+	mux.HandleFunc("GET /debug/pprof/trace", pprof.Trace)
+
+	mux.HandleFunc("POST /run/{name}", func(w http.ResponseWriter, r *http.Request) {
+		wl, err := Lookup(r.PathValue("name"))
+		if errors.Is(err, ErrUnknownWorkload) {
+			http.Error(w, err.Error(), http.StatusNotFound)
+			return
+		}
+		start := time.Now()
+		wl.Run()
+		fmt.Fprintf(w, "%s took %v\n", wl.Name, time.Since(start).Round(time.Millisecond))
+	})
+	return mux
+}
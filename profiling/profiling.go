@@ -0,0 +1,64 @@
+// Package profiling shows how to find out where a Go program spends its time
+// and memory, using the slowest algorithms in this repository as workloads
+//
+// CPUProfile and HeapProfile capture runtime/pprof profiles, Trace records an
+// execution trace with runtime/trace, and Handler serves the net/http/pprof
+// endpoints next to a way of starting the workloads, so a running server can
+// be profiled:
+//
+//	var buf bytes.Buffer
+//	w, _ := profiling.Lookup("fibonacci")
+//	profiling.CPUProfile(&buf, w.Run)
+//	p, _ := profiling.Parse(&buf)
+//	top := p.Top(0, 10) // the ten functions with the most CPU time
+//
+// Parse reads the profiles without go tool pprof, so a program can summarize
+// its own profile; the files it writes open in go tool pprof as usual.
+package profiling
+
+import (
+	"errors"
+	"fmt"
+
+	"github.com/NutProhmpiriya/go-basic/03-algorithms/dp"
+	"github.com/NutProhmpiriya/go-basic/03-algorithms/stringalgo"
+	"github.com/NutProhmpiriya/go-basic/testdata/generator"
+)
+
+// ErrUnknownWorkload is returned when looking up a workload that does not exist
+var ErrUnknownWorkload = errors.New("unknown workload")
+
+// Workload is a slow piece of code worth profiling
+type Workload struct {
+	Name        string
+	Description string
+	Run         func()
+}
+
+// Workloads are sized to run for a few hundred milliseconds, long enough for
+// the CPU profiler, which samples 100 times a second, to see them
+var Workloads = []Workload{
+	{
+		Name:        "fibonacci",
+		Description: "recursive Fibonacci of 38: O(2^n) calls that all do almost nothing",
+		Run:         func() { dp.FibonacciRecursive(38) },
+	},
+	{
+		Name:        "palindrome",
+		Description: "longest palindromic substring of 8000 letters: an O(n²) time and memory table",
+		Run: func() {
+			// The same seed every run, so profiles can be compared
+			stringalgo.LongestPalindromicSubstring(generator.New(1).String(8000, "ab"))
+		},
+	},
+}
+
+// Lookup returns the workload called name
+func Lookup(name string) (Workload, error) {
+	for _, w := range Workloads {
+		if w.Name == name {
+			return w, nil
+		}
+	}
+	return Workload{}, fmt.Errorf("%w %q", ErrUnknownWorkload, name)
+}
@@ -0,0 +1,116 @@
+package profiling
+
+import (
+	"bytes"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"runtime"
+	"slices"
+	"testing"
+)
+
+var sink []byte
+
+//go:noinline
+func allocateMegabytes(n int) {
+	for range n {
+		sink = make([]byte, 1<<20)
+	}
+}
+
+// A heap profile written by the runtime parses, and its top entry is the
+// function that allocated the most
+func TestParseHeapProfile(t *testing.T) {
+	defer func(rate int) { runtime.MemProfileRate = rate }(runtime.MemProfileRate)
+	runtime.MemProfileRate = 1
+	allocateMegabytes(8)
+
+	var buf bytes.Buffer
+	if err := HeapProfile(&buf); err != nil {
+		t.Fatal(err)
+	}
+	p, err := Parse(&buf)
+	if err != nil {
+		t.Fatal(err)
+	}
+	value, err := p.ValueIndex("alloc_space")
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	top := p.Top(value, 1)
+	if len(top) != 1 || top[0].Function != "github.com/NutProhmpiriya/go-basic/profiling.allocateMegabytes" {
+		t.Fatalf("Top = %+v, want allocateMegabytes first", top)
+	}
+	if top[0].Flat < 8<<20 {
+		t.Errorf("allocateMegabytes allocated %d bytes, want at least %d", top[0].Flat, 8<<20)
+	}
+	if _, err := p.ValueIndex("cpu"); err == nil {
+		t.Error("ValueIndex(cpu) of a heap profile succeeded")
+	}
+}
+
+func TestParseRejectsGarbage(t *testing.T) {
+	for _, data := range [][]byte{
+		{0x0a},             // field 1 with its length missing
+		{0x0a, 0x05, 0x08}, // field 1 longer than the data
+		{0x0f},             // wire type 7
+	} {
+		if _, err := Parse(bytes.NewReader(data)); !errors.Is(err, ErrBadProfile) {
+			t.Errorf("Parse(%x) error = %v, want ErrBadProfile", data, err)
+		}
+	}
+}
+
+func TestTop(t *testing.T) {
+	p := &Profile{
+		SampleTypes: []ValueType{{"samples", "count"}},
+		Samples: []Sample{
+			{Stack: []string{"fib", "fib", "fib", "main"}, Values: []int64{5}},
+			{Stack: []string{"fib", "main"}, Values: []int64{2}},
+			{Stack: []string{"sort", "main"}, Values: []int64{3}},
+			{Stack: []string{"idle"}, Values: []int64{0}},
+		},
+	}
+	want := []Entry{
+		{"fib", 7, 7}, // recursion is only counted once per sample
+		{"sort", 3, 3},
+		{"main", 0, 10},
+	}
+	if got := p.Top(0, 0); !slices.Equal(got, want) {
+		t.Errorf("Top = %+v, want %+v", got, want)
+	}
+	if got := p.Top(0, 1); !slices.Equal(got, want[:1]) {
+		t.Errorf("Top(1) = %+v, want %+v", got, want[:1])
+	}
+	if got := p.Total(0); got != 10 {
+		t.Errorf("Total = %d, want 10", got)
+	}
+}
+
+func TestHandler(t *testing.T) {
+	srv := httptest.NewServer(Handler())
+	defer srv.Close()
+
+	tests := []struct {
+		method, path string
+		want         int
+	}{
+		{http.MethodGet, "/debug/pprof/", http.StatusOK},
+		{http.MethodGet, "/debug/pprof/heap", http.StatusOK},
+		{http.MethodPost, "/run/nonexistent", http.StatusNotFound},
+		{http.MethodGet, "/run/fibonacci", http.StatusMethodNotAllowed},
+	}
+	for _, tt := range tests {
+		req, _ := http.NewRequest(tt.method, srv.URL+tt.path, nil)
+		resp, err := http.DefaultClient.Do(req)
+		if err != nil {
+			t.Fatal(err)
+		}
+		resp.Body.Close()
+		if resp.StatusCode != tt.want {
+			t.Errorf("%s %s = %d, want %d", tt.method, tt.path, resp.StatusCode, tt.want)
+		}
+	}
+}
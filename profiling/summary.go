@@ -0,0 +1,380 @@
+package profiling
+
+import (
+	"bufio"
+	"bytes"
+	"cmp"
+	"compress/gzip"
+	"encoding/binary"
+	"errors"
+	"fmt"
+	"io"
+	"slices"
+	"time"
+)
+
+// ErrBadProfile is returned by Parse for data that is not a pprof profile
+var ErrBadProfile = errors.New("malformed profile")
+
+// ValueType describes one of the values every sample carries,
+// e.g. {"cpu", "nanoseconds"} or {"alloc_space", "bytes"}
+type ValueType struct {
+	Type string
+	Unit string
+}
+
+// Sample is one stack the profiler saw, with its values
+type Sample struct {
+	// Stack holds function names, the function that was running first
+	Stack []string
+	// Values line up with Profile.SampleTypes
+	Values []int64
+}
+
+// Profile is the part of a pprof profile needed to summarize it
+type Profile struct {
+	SampleTypes []ValueType
+	Samples     []Sample
+	Duration    time.Duration
+}
+
+// Entry is one row of Top
+// Flat counts samples taken in the function itself and Cum those taken in
+// it or in anything it called
+type Entry struct {
+	Function string
+	Flat     int64
+	Cum      int64
+}
+
+// Parse reads a profile in the format runtime/pprof writes: a gzipped
+// protocol buffer described by profile.proto in github.com/google/pprof
+// Only the fields Profile needs are decoded; the rest are skipped
+func Parse(r io.Reader) (*Profile, error) {
+	br := bufio.NewReader(r)
+	var src io.Reader = br
+	if magic, _ := br.Peek(2); bytes.Equal(magic, []byte{0x1f, 0x8b}) {
+		gz, err := gzip.NewReader(br)
+		if err != nil {
+			return nil, err
+		}
+		defer gz.Close()
+		src = gz
+	}
+	data, err := io.ReadAll(src)
+	if err != nil {
+		return nil, err
+	}
+
+	p, err := decodeProfile(data)
+	if err != nil {
+		return nil, fmt.Errorf("%w: %w", ErrBadProfile, err)
+	}
+	return p, nil
+}
+
+// ValueIndex returns the position of the sample type called typ in Values
+func (p *Profile) ValueIndex(typ string) (int, error) {
+	for i, vt := range p.SampleTypes {
+		if vt.Type == typ {
+			return i, nil
+		}
+	}
+	return 0, fmt.Errorf("profile has no %q values", typ)
+}
+
+// Total adds up value index value over all samples
+func (p *Profile) Total(value int) int64 {
+	var total int64
+	for _, s := range p.Samples {
+		total += s.Values[value]
+	}
+	return total
+}
+
+// Top returns the n functions with the highest flat value, like the top
+// command of go tool pprof; n <= 0 returns every function
+// A recursive function is counted once per sample in its Cum
+func (p *Profile) Top(value, n int) []Entry {
+	entries := make(map[string]*Entry)
+	entry := func(name string) *Entry {
+		e, ok := entries[name]
+		if !ok {
+			e = &Entry{Function: name}
+			entries[name] = e
+		}
+		return e
+	}
+
+	seen := make(map[string]bool)
+	for _, s := range p.Samples {
+		v := s.Values[value]
+		if v == 0 || len(s.Stack) == 0 {
+			continue
+		}
+		entry(s.Stack[0]).Flat += v
+		clear(seen)
+		for _, name := range s.Stack {
+			if !seen[name] {
+				seen[name] = true
+				entry(name).Cum += v
+			}
+		}
+	}
+
+	top := make([]Entry, 0, len(entries))
+	for _, e := range entries {
+		top = append(top, *e)
+	}
+	slices.SortFunc(top, func(a, b Entry) int {
+		return cmp.Or(cmp.Compare(b.Flat, a.Flat), cmp.Compare(b.Cum, a.Cum), cmp.Compare(a.Function, b.Function))
+	})
+	if n > 0 && len(top) > n {
+		top = top[:n]
+	}
+	return top
+}
+
+// Field numbers from profile.proto
+const (
+	profileSampleType = 1
+	profileSample     = 2
+	profileLocation   = 4
+	profileFunction   = 5
+	profileStrings    = 6
+	profileDuration   = 10
+
+	valueTypeType = 1
+	valueTypeUnit = 2
+
+	sampleLocation = 1
+	sampleValue    = 2
+
+	locationID      = 1
+	locationAddress = 3
+	locationLine    = 4
+
+	lineFunction = 1
+
+	functionID   = 1
+	functionName = 2
+)
+
+// Protocol buffer wire types
+const (
+	wireVarint  = 0
+	wireFixed64 = 1
+	wireBytes   = 2
+	wireFixed32 = 5
+)
+
+// decodeProfile decodes the profile message
+// Samples, locations and functions refer to each other and to the string
+// table by number, and may come in any order, so everything is collected
+// before the names are resolved
+func decodeProfile(data []byte) (*Profile, error) {
+	type rawSample struct {
+		locations []uint64
+		values    []uint64
+	}
+	type location struct {
+		address   uint64
+		functions []uint64
+	}
+	var (
+		sampleTypes [][2]uint64
+		samples     []rawSample
+		locations   = make(map[uint64]location)
+		functions   = make(map[uint64]uint64) // id to name
+		strs        []string
+		duration    int64
+	)
+
+	err := eachField(data, func(num, wire int, v uint64, b []byte) error {
+		switch num {
+		case profileSampleType:
+			var vt [2]uint64
+			err := eachField(b, func(num, _ int, v uint64, _ []byte) error {
+				switch num {
+				case valueTypeType:
+					vt[0] = v
+				case valueTypeUnit:
+					vt[1] = v
+				}
+				return nil
+			})
+			sampleTypes = append(sampleTypes, vt)
+			return err
+		case profileSample:
+			var s rawSample
+			err := eachField(b, func(num, wire int, v uint64, b []byte) (err error) {
+				switch num {
+				case sampleLocation:
+					s.locations, err = appendInts(s.locations, wire, v, b)
+				case sampleValue:
+					s.values, err = appendInts(s.values, wire, v, b)
+				}
+				return err
+			})
+			samples = append(samples, s)
+			return err
+		case profileLocation:
+			var id uint64
+			var loc location
+			err := eachField(b, func(num, _ int, v uint64, b []byte) error {
+				switch num {
+				case locationID:
+					id = v
+				case locationAddress:
+					loc.address = v
+				case locationLine:
+					return eachField(b, func(num, _ int, v uint64, _ []byte) error {
+						if num == lineFunction {
+							loc.functions = append(loc.functions, v)
+						}
+						return nil
+					})
+				}
+				return nil
+			})
+			locations[id] = loc
+			return err
+		case profileFunction:
+			var id, name uint64
+			err := eachField(b, func(num, _ int, v uint64, _ []byte) error {
+				switch num {
+				case functionID:
+					id = v
+				case functionName:
+					name = v
+				}
+				return nil
+			})
+			functions[id] = name
+			return err
+		case profileStrings:
+			strs = append(strs, string(b))
+		case profileDuration:
+			duration = int64(v)
+		}
+		return nil
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	str := func(i uint64) (string, error) {
+		if i >= uint64(len(strs)) {
+			return "", fmt.Errorf("string %d out of range", i)
+		}
+		return strs[i], nil
+	}
+
+	p := &Profile{Duration: time.Duration(duration)}
+	for _, vt := range sampleTypes {
+		typ, err := str(vt[0])
+		if err != nil {
+			return nil, err
+		}
+		unit, err := str(vt[1])
+		if err != nil {
+			return nil, err
+		}
+		p.SampleTypes = append(p.SampleTypes, ValueType{typ, unit})
+	}
+
+	for _, raw := range samples {
+		if len(raw.values) != len(p.SampleTypes) {
+			return nil, fmt.Errorf("sample has %d values for %d types", len(raw.values), len(p.SampleTypes))
+		}
+		s := Sample{Values: make([]int64, len(raw.values))}
+		for i, v := range raw.values {
+			s.Values[i] = int64(v)
+		}
+		for _, id := range raw.locations {
+			loc, ok := locations[id]
+			if !ok {
+				return nil, fmt.Errorf("sample refers to unknown location %d", id)
+			}
+			if len(loc.functions) == 0 {
+				// Not symbolized: all that is known is the address
+				s.Stack = append(s.Stack, fmt.Sprintf("%#x", loc.address))
+			}
+			// Inlined functions come first, then the function they were inlined into
+			for _, fn := range loc.functions {
+				name, err := str(functions[fn])
+				if err != nil {
+					return nil, err
+				}
+				s.Stack = append(s.Stack, name)
+			}
+		}
+		p.Samples = append(p.Samples, s)
+	}
+	return p, nil
+}
+
+// eachField calls fn with every field of an encoded message
+// v holds the value of varint and fixed-size fields, b the contents of
+// length-delimited ones: strings, nested messages and packed numbers
+func eachField(data []byte, fn func(num, wire int, v uint64, b []byte) error) error {
+	for len(data) > 0 {
+		key, n := binary.Uvarint(data)
+		if n <= 0 {
+			return errors.New("bad field key")
+		}
+		data = data[n:]
+
+		num, wire := int(key>>3), int(key&7)
+		var v uint64
+		var b []byte
+		switch wire {
+		case wireVarint:
+			v, n = binary.Uvarint(data)
+			if n <= 0 {
+				return errors.New("bad varint")
+			}
+			data = data[n:]
+		case wireFixed64:
+			if len(data) < 8 {
+				return io.ErrUnexpectedEOF
+			}
+			v, data = binary.LittleEndian.Uint64(data), data[8:]
+		case wireFixed32:
+			if len(data) < 4 {
+				return io.ErrUnexpectedEOF
+			}
+			v, data = uint64(binary.LittleEndian.Uint32(data)), data[4:]
+		case wireBytes:
+			size, n := binary.Uvarint(data)
+			if n <= 0 || size > uint64(len(data)-n) {
+				return errors.New("bad length")
+			}
+			b, data = data[n:n+int(size)], data[n+int(size):]
+		default:
+			return fmt.Errorf("unsupported wire type %d", wire)
+		}
+
+		if err := fn(num, wire, v, b); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// appendInts appends a repeated integer field, which is either a single
+// varint or, packed, a run of varints
+func appendInts(dst []uint64, wire int, v uint64, b []byte) ([]uint64, error) {
+	if wire != wireBytes {
+		return append(dst, v), nil
+	}
+	for len(b) > 0 {
+		v, n := binary.Uvarint(b)
+		if n <= 0 {
+			return nil, errors.New("bad packed varint")
+		}
+		dst = append(dst, v)
+		b = b[n:]
+	}
+	return dst, nil
+}
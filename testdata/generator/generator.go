@@ -0,0 +1,161 @@
+// Package generator builds reproducible inputs for the examples, tests and
+// benchmarks: the same seed always produces the same data
+//
+//	gen := generator.New(1)
+//	random := gen.Ints(1000, 100) // 1000 values in [0, 100)
+//	killer := generator.MiddlePivotKiller(1000)
+//
+// Inputs are chosen to favour or defeat different algorithms: sorted and
+// reversed slices are the best and worst cases of insertion sort, few
+// unique values break two-way partitioning, and the killers make a
+// quicksort take quadratic time.
+//
+// The package lives under testdata because it only exists to feed other
+// code. The go tool leaves testdata directories out of ./..., so its own
+// tests run with
+//
+//	go test ./testdata/generator
+package generator
+
+import (
+	"math/rand"
+	"strings"
+)
+
+// Generator produces random inputs from a fixed seed
+// It is not safe for concurrent use
+type Generator struct {
+	rng *rand.Rand
+}
+
+// New returns a Generator seeded with seed
+func New(seed int64) *Generator {
+	return &Generator{rng: rand.New(rand.NewSource(seed))}
+}
+
+// Rand exposes the underlying source for anything the helpers don't cover
+func (g *Generator) Rand() *rand.Rand {
+	return g.rng
+}
+
+// Ints returns n values in [0, limit)
+// A limit of 0 or less allows any non-negative int
+func (g *Generator) Ints(n, limit int) []int {
+	arr := make([]int, n)
+	for i := range arr {
+		if limit > 0 {
+			arr[i] = g.rng.Intn(limit)
+		} else {
+			arr[i] = g.rng.Int()
+		}
+	}
+	return arr
+}
+
+// Perm returns a random permutation of 1..n
+func (g *Generator) Perm(n int) []int {
+	arr := g.rng.Perm(n)
+	for i := range arr {
+		arr[i]++
+	}
+	return arr
+}
+
+// FewUnique returns n values in [0, distinct)
+// With only a handful of distinct values, most elements equal the pivot
+func (g *Generator) FewUnique(n, distinct int) []int {
+	return g.Ints(n, max(distinct, 1))
+}
+
+// NearlySorted returns 0..n-1 with swaps random pairs exchanged
+func (g *Generator) NearlySorted(n, swaps int) []int {
+	arr := Sorted(n)
+	if n == 0 {
+		return arr
+	}
+	for range swaps {
+		a, b := g.rng.Intn(n), g.rng.Intn(n)
+		arr[a], arr[b] = arr[b], arr[a]
+	}
+	return arr
+}
+
+// String returns n bytes drawn from alphabet
+func (g *Generator) String(n int, alphabet string) string {
+	var sb strings.Builder
+	sb.Grow(n)
+	for range n {
+		sb.WriteByte(alphabet[g.rng.Intn(len(alphabet))])
+	}
+	return sb.String()
+}
+
+// Edges returns m random edges between the vertices 0..n-1, a random graph
+// for the graph algorithms. Self-loops and repeated edges are left in, as
+// real data has them; with m a few times n most vertices end up in one
+// large component with a small diameter
+func (g *Generator) Edges(n, m int) [][2]int {
+	edges := make([][2]int, m)
+	if n == 0 {
+		return edges[:0]
+	}
+	for i := range edges {
+		edges[i] = [2]int{g.rng.Intn(n), g.rng.Intn(n)}
+	}
+	return edges
+}
+
+// Sorted returns 0..n-1
+// It kills a quicksort that takes the last element as the pivot, like
+// sorting.LomutoQuickSort: every partition splits off a single element
+func Sorted(n int) []int {
+	arr := make([]int, n)
+	for i := range arr {
+		arr[i] = i
+	}
+	return arr
+}
+
+// Reversed returns n-1..0
+func Reversed(n int) []int {
+	arr := make([]int, n)
+	for i := range arr {
+		arr[i] = n - 1 - i
+	}
+	return arr
+}
+
+// MiddlePivotKiller returns a permutation of 0..n-1 that makes a quicksort
+// taking the middle element as its pivot and partitioning with Dijkstra's
+// three-way partition, like sorting.QuickSort, run in O(n²) time
+//
+// It is built by running that quicksort on the positions of the input and
+// giving the pivot the smallest value not handed out yet every time. Each
+// partition then only splits off the pivot. Building it takes O(n²) time,
+// as long as the sort it defeats
+func MiddlePivotKiller(n int) []int {
+	// slots[i] is the input position whose element is at i after the
+	// partitions so far
+	slots := Sorted(n)
+	killer := make([]int, n)
+	for low, next := 0, 0; low < n; low, next = low+1, next+1 {
+		high := n - 1
+		pivot := slots[low+(high-low)/2]
+		killer[pivot] = next
+
+		// Every other element is bigger than the pivot and gets swapped to
+		// the end, exactly as the partition does
+		part := slots[low:]
+		i, gt := 0, len(part)
+		for i < gt {
+			if part[i] == pivot {
+				i++
+				continue
+			}
+			gt--
+			part[i], part[gt] = part[gt], part[i]
+		}
+		// The pivot is now at low and the rest, bigger, in low+1..high
+	}
+	return killer
+}
@@ -0,0 +1,75 @@
+package generator
+
+import (
+	"slices"
+	"strings"
+	"testing"
+)
+
+func TestSameSeedSameData(t *testing.T) {
+	a, b := New(42), New(42)
+	if !slices.Equal(a.Ints(100, 0), b.Ints(100, 0)) {
+		t.Error("Ints differs between generators with the same seed")
+	}
+	if a.String(50, "xyz") != b.String(50, "xyz") {
+		t.Error("String differs between generators with the same seed")
+	}
+	if slices.Equal(New(1).Ints(100, 0), New(2).Ints(100, 0)) {
+		t.Error("different seeds gave the same data")
+	}
+}
+
+// isPermutation reports whether arr holds each of first..first+len(arr)-1 once
+func isPermutation(arr []int, first int) bool {
+	sorted := slices.Clone(arr)
+	slices.Sort(sorted)
+	for i, v := range sorted {
+		if v != first+i {
+			return false
+		}
+	}
+	return true
+}
+
+func TestShapes(t *testing.T) {
+	gen := New(1)
+	for _, n := range []int{0, 1, 2, 7, 100} {
+		if arr := gen.Ints(n, 10); len(arr) != n || (n > 0 && (slices.Min(arr) < 0 || slices.Max(arr) >= 10)) {
+			t.Errorf("Ints(%d, 10) = %v", n, arr)
+		}
+		if arr := gen.FewUnique(n, 3); len(arr) != n || (n > 0 && slices.Max(arr) > 2) {
+			t.Errorf("FewUnique(%d, 3) = %v", n, arr)
+		}
+		if arr := gen.Perm(n); !isPermutation(arr, 1) {
+			t.Errorf("Perm(%d) = %v", n, arr)
+		}
+		if arr := gen.NearlySorted(n, 2); !isPermutation(arr, 0) {
+			t.Errorf("NearlySorted(%d, 2) = %v", n, arr)
+		}
+		if arr := Sorted(n); !isPermutation(arr, 0) || !slices.IsSorted(arr) {
+			t.Errorf("Sorted(%d) = %v", n, arr)
+		}
+		if arr := Reversed(n); !isPermutation(arr, 0) || (n > 1 && arr[0] != n-1) {
+			t.Errorf("Reversed(%d) = %v", n, arr)
+		}
+		if arr := MiddlePivotKiller(n); !isPermutation(arr, 0) {
+			t.Errorf("MiddlePivotKiller(%d) = %v", n, arr)
+		}
+		if s := gen.String(n, "ab"); len(s) != n || strings.Trim(s, "ab") != "" {
+			t.Errorf("String(%d, ab) = %q", n, s)
+		}
+		for _, e := range gen.Edges(n, 2*n) {
+			if min(e[0], e[1]) < 0 || max(e[0], e[1]) >= n {
+				t.Errorf("Edges(%d, %d) has edge %v", n, 2*n, e)
+			}
+		}
+	}
+}
+
+// The middle element of the killer is its minimum, and so on for every
+// subarray the sort recurses into
+func TestMiddlePivotKiller(t *testing.T) {
+	if got, want := MiddlePivotKiller(5), []int{2, 3, 0, 1, 4}; !slices.Equal(got, want) {
+		t.Errorf("MiddlePivotKiller(5) = %v, want %v", got, want)
+	}
+}
@@ -0,0 +1,124 @@
+package trace
+
+import (
+	"fmt"
+	"io"
+	"slices"
+	"strconv"
+	"strings"
+)
+
+// maxBarHeight is the number of rows used for the tallest bar
+const maxBarHeight = 8
+
+// Bars draws an array as vertical ASCII bars after every step
+//
+//	step 3: swap [1] and [2]
+//	     #
+//	 #   #
+//	 #   # #
+//	 # # # #
+//	 3 1 4 2
+//	   * *
+//
+// Markers under the values show the positions of the step: ^ for a
+// comparison, * for a swap or write and = for a search result. Bars outside
+// the latest Range are drawn with dots
+type Bars struct {
+	out       io.Writer
+	values    []int
+	low, high int
+	steps     int
+}
+
+// NewBars draws the initial state of values and returns a Tracer that keeps
+// its own copy of the array up to date from the Swap and Set events
+func NewBars(w io.Writer, values []int) *Bars {
+	b := &Bars{out: w, values: slices.Clone(values), low: 0, high: len(values) - 1}
+	b.draw("start", nil, 0)
+	return b
+}
+
+// Values returns the array as the tracer has seen it change
+func (b *Bars) Values() []int {
+	return slices.Clone(b.values)
+}
+
+// Step applies e to the array and draws the result
+func (b *Bars) Step(e Event) {
+	b.steps++
+	marks := []int{e.I, e.J}
+	marker := byte('^')
+	switch e.Op {
+	case Swap:
+		b.values[e.I], b.values[e.J] = b.values[e.J], b.values[e.I]
+		marker = '*'
+	case Set:
+		b.values[e.I] = e.Value
+		marks, marker = []int{e.I}, '*'
+	case Range:
+		b.low, b.high = e.I, e.J
+		marks = nil
+	case Found:
+		marks, marker = []int{e.I}, '='
+	}
+	b.draw(fmt.Sprintf("step %d: %v", b.steps, e), marks, marker)
+}
+
+func (b *Bars) draw(title string, marks []int, marker byte) {
+	var sb strings.Builder
+	sb.WriteString(title + "\n")
+	if len(b.values) == 0 {
+		sb.WriteString("(empty)\n\n")
+		io.WriteString(b.out, sb.String())
+		return
+	}
+
+	lo, hi := slices.Min(b.values), slices.Max(b.values)
+	width := 1
+	for _, v := range b.values {
+		width = max(width, len(strconv.Itoa(v)))
+	}
+	width++ // One space between columns
+
+	// Scale the values to between 1 and rows rows, so even the smallest shows
+	rows := min(maxBarHeight, hi-lo+1)
+	height := func(v int) int {
+		if hi == lo {
+			return 1
+		}
+		return 1 + (v-lo)*(rows-1)/(hi-lo)
+	}
+
+	for row := rows; row >= 1; row-- {
+		line := make([]byte, 0, width*len(b.values))
+		for i, v := range b.values {
+			cell := byte(' ')
+			if height(v) >= row {
+				cell = '#'
+				if i < b.low || i > b.high {
+					cell = '.'
+				}
+			}
+			line = append(line, strings.Repeat(" ", width-1)...)
+			line = append(line, cell)
+		}
+		sb.WriteString(strings.TrimRight(string(line), " ") + "\n")
+	}
+	for _, v := range b.values {
+		fmt.Fprintf(&sb, "%*d", width, v)
+	}
+	sb.WriteByte('\n')
+
+	if len(marks) > 0 {
+		line := []byte(strings.Repeat(" ", width*len(b.values)))
+		for _, i := range marks {
+			if i >= 0 && i < len(b.values) {
+				line[width*(i+1)-1] = marker
+			}
+		}
+		sb.WriteString(strings.TrimRight(string(line), " ") + "\n")
+	}
+	sb.WriteByte('\n')
+	io.WriteString(b.out, sb.String())
+}
@@ -0,0 +1,122 @@
+package trace
+
+import (
+	"fmt"
+	"os"
+	"path/filepath"
+	"slices"
+	"strconv"
+	"strings"
+)
+
+// DOTFrames turns a graph traversal into an animation: after every step it
+// draws the whole graph in the DOT language of Graphviz, so each frame can
+// be rendered with dot -Tpng and the pictures played in order
+//
+//   - the vertex just visited is gold, earlier visits gray
+//   - vertices in the frontier are light blue
+//   - the edges the traversal followed are bold, the one just followed red
+//
+// The vertices and edges are listed in the same order in every frame,
+// which keeps Graphviz from moving them around between frames
+type DOTFrames struct {
+	vertices []int
+	edges    [][2]int
+	visited  map[int]bool
+	frontier map[int]bool
+	followed map[[2]int]bool
+	frames   []string
+}
+
+// NewDOTFrames returns a Tracer for a traversal of the undirected graph with
+// the given vertices and edges
+func NewDOTFrames(vertices []int, edges [][2]int) *DOTFrames {
+	v := slices.Clone(vertices)
+	slices.Sort(v)
+	return &DOTFrames{
+		vertices: v,
+		edges:    slices.Clone(edges),
+		visited:  make(map[int]bool),
+		frontier: make(map[int]bool),
+		followed: make(map[[2]int]bool),
+	}
+}
+
+// undirected returns the edge between a and b with the smaller end first,
+// so both directions of an edge look the same
+func undirected(a, b int) [2]int {
+	return [2]int{min(a, b), max(a, b)}
+}
+
+// Step records e and draws the graph as it is after it
+func (d *DOTFrames) Step(e Event) {
+	current, edge := -1, [2]int{-1, -1}
+	switch e.Op {
+	case Visit:
+		d.visited[e.I] = true
+		delete(d.frontier, e.I)
+		current = e.I
+	case Enqueue:
+		d.frontier[e.I] = true
+	case Edge:
+		edge = undirected(e.I, e.J)
+		d.followed[edge] = true
+	}
+
+	var sb strings.Builder
+	step := len(d.frames) + 1
+	fmt.Fprintf(&sb, "graph step%d {\n", step)
+	fmt.Fprintf(&sb, "\tlabel=%q;\n\tlabelloc=t;\n", fmt.Sprintf("step %d: %v", step, e))
+	sb.WriteString("\tnode [shape=circle, style=filled, fillcolor=white];\n")
+	for _, v := range d.vertices {
+		switch {
+		case v == current:
+			fmt.Fprintf(&sb, "\t%d [fillcolor=gold, penwidth=2];\n", v)
+		case d.visited[v]:
+			fmt.Fprintf(&sb, "\t%d [fillcolor=gray];\n", v)
+		case d.frontier[v]:
+			fmt.Fprintf(&sb, "\t%d [fillcolor=lightblue];\n", v)
+		default:
+			fmt.Fprintf(&sb, "\t%d;\n", v)
+		}
+	}
+	for _, ed := range d.edges {
+		key := undirected(ed[0], ed[1])
+		switch {
+		case key == edge:
+			fmt.Fprintf(&sb, "\t%d -- %d [color=red, penwidth=3];\n", ed[0], ed[1])
+		case d.followed[key]:
+			fmt.Fprintf(&sb, "\t%d -- %d [penwidth=3];\n", ed[0], ed[1])
+		default:
+			fmt.Fprintf(&sb, "\t%d -- %d;\n", ed[0], ed[1])
+		}
+	}
+	sb.WriteString("}\n")
+	d.frames = append(d.frames, sb.String())
+}
+
+// Frames returns the DOT source of every frame so far, one per step
+func (d *DOTFrames) Frames() []string {
+	return slices.Clone(d.frames)
+}
+
+// WriteFiles writes each frame to its own file in dir, creating dir if
+// needed, and returns the paths in order. The file names are numbered with
+// leading zeros so they sort in step order:
+//
+//	for f in dir/*.dot; do dot -Tpng "$f" -o "${f%.dot}.png"; done
+func (d *DOTFrames) WriteFiles(dir string) ([]string, error) {
+	if err := os.MkdirAll(dir, 0o755); err != nil {
+		return nil, err
+	}
+	width := len(strconv.Itoa(len(d.frames)))
+	paths := make([]string, 0, len(d.frames))
+	for i, frame := range d.frames {
+		path := filepath.Join(dir, fmt.Sprintf("step-%0*d.dot", width, i+1))
+		if err := os.WriteFile(path, []byte(frame), 0o644); err != nil {
+			return paths, err
+		}
+		paths = append(paths, path)
+	}
+	return paths, nil
+}
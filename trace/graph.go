@@ -0,0 +1,72 @@
+package trace
+
+import (
+	"fmt"
+	"io"
+	"slices"
+	"strings"
+)
+
+// GraphView shows the progress of a graph traversal after every step
+//
+//	step 12: visit 3
+//	  (0) (1) <2> [3] <4>  5
+//	  order: 0 1 3
+//
+// The vertex just visited is in brackets, earlier visits in parentheses,
+// vertices waiting in the frontier in angle brackets and vertices not
+// reached yet are bare. Edge steps print the edge only
+type GraphView struct {
+	out      io.Writer
+	vertices []int
+	visited  map[int]bool
+	frontier map[int]bool
+	order    []int
+	steps    int
+}
+
+// NewGraphView returns a Tracer for a traversal over the given vertices
+func NewGraphView(w io.Writer, vertices []int) *GraphView {
+	v := slices.Clone(vertices)
+	slices.Sort(v)
+	return &GraphView{out: w, vertices: v, visited: make(map[int]bool), frontier: make(map[int]bool)}
+}
+
+// Order returns the vertices in the order they were visited
+func (g *GraphView) Order() []int {
+	return slices.Clone(g.order)
+}
+
+// Step records e and prints the state of the traversal
+func (g *GraphView) Step(e Event) {
+	g.steps++
+	fmt.Fprintf(g.out, "step %d: %v\n", g.steps, e)
+	switch e.Op {
+	case Visit:
+		g.visited[e.I] = true
+		delete(g.frontier, e.I)
+		g.order = append(g.order, e.I)
+	case Enqueue:
+		g.frontier[e.I] = true
+	default:
+		return
+	}
+
+	var sb strings.Builder
+	for _, v := range g.vertices {
+		switch {
+		case v == e.I && e.Op == Visit:
+			fmt.Fprintf(&sb, " [%d]", v)
+		case g.visited[v]:
+			fmt.Fprintf(&sb, " (%d)", v)
+		case g.frontier[v]:
+			fmt.Fprintf(&sb, " <%d>", v)
+		default:
+			fmt.Fprintf(&sb, "  %d ", v)
+		}
+	}
+	fmt.Fprintf(g.out, " %s\n", strings.TrimRight(sb.String(), " "))
+	if e.Op == Visit {
+		fmt.Fprintf(g.out, "  order: %s\n", strings.Trim(fmt.Sprint(g.order), "[]"))
+	}
+}
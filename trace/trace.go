@@ -0,0 +1,114 @@
+// Package trace lets an algorithm report what it does, one step at a time
+//
+// A traced algorithm takes a Tracer and calls Step for every comparison,
+// swap or visit it makes. The tracer decides what to do with the events:
+// Recorder keeps them for tests, Bars draws an array as ASCII bars after
+// every step, GraphView shows which vertices a traversal has reached and
+// DOTFrames turns a traversal into a Graphviz picture per step.
+//
+//	bars := trace.NewBars(os.Stdout, arr)
+//	sorting.BubbleSortTrace(arr, bars)
+//
+// The traced variants live next to the plain algorithms, e.g.
+// sorting.BubbleSortTrace and datastructures.Graph.BFSTrace. The plain
+// versions stay untouched, so tracing costs nothing when it is not used.
+package trace
+
+import "fmt"
+
+// Op is the kind of step an algorithm took
+type Op int
+
+const (
+	// Compare looks at the values at I and J
+	// J is -1 when I is compared with a value held aside, like a search target or a pivot
+	Compare Op = iota
+	// Swap exchanges the values at I and J
+	Swap
+	// Set writes Value at I
+	Set
+	// Range narrows the part of the array still being worked on to [I, J]
+	Range
+	// Found reports the result of a search at I
+	Found
+	// Visit reaches vertex I of a graph
+	Visit
+	// Edge follows the edge from vertex I to vertex J
+	Edge
+	// Enqueue adds vertex I to the frontier: found, but not visited yet
+	Enqueue
+)
+
+var opNames = [...]string{"compare", "swap", "set", "range", "found", "visit", "edge", "enqueue"}
+
+func (op Op) String() string {
+	if op < 0 || int(op) >= len(opNames) {
+		return fmt.Sprintf("Op(%d)", int(op))
+	}
+	return opNames[op]
+}
+
+// Event is one step of an algorithm
+type Event struct {
+	Op    Op
+	I, J  int
+	Value int
+}
+
+func (e Event) String() string {
+	switch e.Op {
+	case Compare:
+		if e.J < 0 {
+			return fmt.Sprintf("compare [%d] with the key", e.I)
+		}
+		return fmt.Sprintf("compare [%d] and [%d]", e.I, e.J)
+	case Swap:
+		return fmt.Sprintf("swap [%d] and [%d]", e.I, e.J)
+	case Set:
+		return fmt.Sprintf("set [%d] = %d", e.I, e.Value)
+	case Range:
+		return fmt.Sprintf("work on [%d..%d]", e.I, e.J)
+	case Found:
+		if e.I < 0 {
+			return "not found"
+		}
+		return fmt.Sprintf("found at [%d]", e.I)
+	case Visit:
+		return fmt.Sprintf("visit %d", e.I)
+	case Edge:
+		return fmt.Sprintf("edge %d -> %d", e.I, e.J)
+	case Enqueue:
+		return fmt.Sprintf("enqueue %d", e.I)
+	}
+	return e.Op.String()
+}
+
+// Tracer receives the steps of an algorithm as they happen
+type Tracer interface {
+	Step(e Event)
+}
+
+// TracerFunc adapts an ordinary function to a Tracer
+type TracerFunc func(e Event)
+
+// Step calls f(e)
+func (f TracerFunc) Step(e Event) { f(e) }
+
+// Recorder keeps every event it receives
+type Recorder struct {
+	Events []Event
+}
+
+// Step appends e to the recorded events
+func (r *Recorder) Step(e Event) { r.Events = append(r.Events, e) }
+
+// Count returns how many recorded events have the given op
+func (r *Recorder) Count(op Op) int {
+	n := 0
+	for _, e := range r.Events {
+		if e.Op == op {
+			n++
+		}
+	}
+	return n
+}
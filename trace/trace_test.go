@@ -0,0 +1,181 @@
+package trace
+
+import (
+	"os"
+	"path/filepath"
+	"slices"
+	"strings"
+	"testing"
+)
+
+func TestEventString(t *testing.T) {
+	tests := []struct {
+		e    Event
+		want string
+	}{
+		{Event{Op: Compare, I: 1, J: 2}, "compare [1] and [2]"},
+		{Event{Op: Compare, I: 1, J: -1}, "compare [1] with the key"},
+		{Event{Op: Swap, I: 0, J: 3}, "swap [0] and [3]"},
+		{Event{Op: Set, I: 2, Value: 9}, "set [2] = 9"},
+		{Event{Op: Range, I: 4, J: 7}, "work on [4..7]"},
+		{Event{Op: Found, I: 5}, "found at [5]"},
+		{Event{Op: Found, I: -1}, "not found"},
+		{Event{Op: Visit, I: 3}, "visit 3"},
+		{Event{Op: Edge, I: 3, J: 4}, "edge 3 -> 4"},
+		{Event{Op: Enqueue, I: 4}, "enqueue 4"},
+		{Event{Op: Op(42)}, "Op(42)"},
+	}
+	for _, tt := range tests {
+		if got := tt.e.String(); got != tt.want {
+			t.Errorf("%#v.String() = %q, want %q", tt.e, got, tt.want)
+		}
+	}
+}
+
+func TestRecorderAndTracerFunc(t *testing.T) {
+	var rec Recorder
+	var tracer Tracer = &rec
+	tracer.Step(Event{Op: Compare})
+	tracer.Step(Event{Op: Swap})
+	tracer.Step(Event{Op: Compare})
+	if rec.Count(Compare) != 2 || rec.Count(Swap) != 1 || rec.Count(Visit) != 0 {
+		t.Errorf("Count = %d, %d, %d; want 2, 1, 0", rec.Count(Compare), rec.Count(Swap), rec.Count(Visit))
+	}
+
+	calls := 0
+	tracer = TracerFunc(func(Event) { calls++ })
+	tracer.Step(Event{})
+	if calls != 1 {
+		t.Errorf("TracerFunc called %d times, want 1", calls)
+	}
+}
+
+// The example drawn in the Bars documentation
+func TestBars(t *testing.T) {
+	var sb strings.Builder
+	b := NewBars(&sb, []int{3, 4, 1, 2})
+	b.Step(Event{Op: Compare, I: 0, J: 1})
+	b.Step(Event{Op: Compare, I: 1, J: 2})
+	sb.Reset()
+	b.Step(Event{Op: Swap, I: 1, J: 2})
+
+	want := "step 3: swap [1] and [2]\n" +
+		"     #\n" +
+		" #   #\n" +
+		" #   # #\n" +
+		" # # # #\n" +
+		" 3 1 4 2\n" +
+		"   * *\n\n"
+	if got := sb.String(); got != want {
+		t.Errorf("got:\n%s\nwant:\n%s", got, want)
+	}
+	if got := b.Values(); !slices.Equal(got, []int{3, 1, 4, 2}) {
+		t.Errorf("Values() = %v, want [3 1 4 2]", got)
+	}
+}
+
+func TestBarsRangeSetAndFound(t *testing.T) {
+	var sb strings.Builder
+	b := NewBars(&sb, []int{10, 20, 30})
+	sb.Reset()
+	b.Step(Event{Op: Range, I: 1, J: 2})
+	b.Step(Event{Op: Set, I: 0, Value: 5})
+	b.Step(Event{Op: Found, I: 2})
+	b.Step(Event{Op: Found, I: -1})
+
+	out := sb.String()
+	// Bars outside the range are dots, the rest are #
+	if !strings.Contains(out, "step 1: work on [1..2]\n") || !strings.Contains(out, " .  #  #\n") {
+		t.Errorf("range not drawn:\n%s", out)
+	}
+	if !strings.Contains(out, "  *\n") || !slices.Equal(b.Values(), []int{5, 20, 30}) {
+		t.Errorf("set not applied, Values() = %v:\n%s", b.Values(), out)
+	}
+	if !strings.Contains(out, "        =\n") {
+		t.Errorf("found marker missing:\n%s", out)
+	}
+
+	// Empty arrays and equal values draw without dividing by zero
+	sb.Reset()
+	NewBars(&sb, nil)
+	NewBars(&sb, []int{7, 7})
+	if got := sb.String(); got != "start\n(empty)\n\nstart\n # #\n 7 7\n\n" {
+		t.Errorf("got %q", got)
+	}
+}
+
+func TestGraphView(t *testing.T) {
+	var sb strings.Builder
+	g := NewGraphView(&sb, []int{2, 0, 1})
+	g.Step(Event{Op: Visit, I: 0})
+	g.Step(Event{Op: Edge, I: 0, J: 2})
+	g.Step(Event{Op: Enqueue, I: 2})
+	g.Step(Event{Op: Visit, I: 2})
+
+	want := "step 1: visit 0\n" +
+		"  [0]  1   2\n" +
+		"  order: 0\n" +
+		"step 2: edge 0 -> 2\n" +
+		"step 3: enqueue 2\n" +
+		"  (0)  1  <2>\n" +
+		"step 4: visit 2\n" +
+		"  (0)  1  [2]\n" +
+		"  order: 0 2\n"
+	if got := sb.String(); got != want {
+		t.Errorf("got:\n%s\nwant:\n%s", got, want)
+	}
+	if got := g.Order(); !slices.Equal(got, []int{0, 2}) {
+		t.Errorf("Order() = %v, want [0 2]", got)
+	}
+}
+
+func TestDOTFrames(t *testing.T) {
+	// 0 -- 1, 0 -- 2, traversed breadth first from 0
+	d := NewDOTFrames([]int{2, 0, 1}, [][2]int{{0, 1}, {2, 0}})
+	for _, e := range []Event{
+		{Op: Visit, I: 0},
+		{Op: Edge, I: 0, J: 2},
+		{Op: Enqueue, I: 2},
+		{Op: Visit, I: 2},
+	} {
+		d.Step(e)
+	}
+	frames := d.Frames()
+	if len(frames) != 4 {
+		t.Fatalf("got %d frames, want 4", len(frames))
+	}
+
+	// The edge 2 -- 0 was listed the other way round but still matches
+	want := "graph step2 {\n" +
+		"\tlabel=\"step 2: edge 0 -> 2\";\n" +
+		"\tlabelloc=t;\n" +
+		"\tnode [shape=circle, style=filled, fillcolor=white];\n" +
+		"\t0 [fillcolor=gray];\n" +
+		"\t1;\n" +
+		"\t2;\n" +
+		"\t0 -- 1;\n" +
+		"\t2 -- 0 [color=red, penwidth=3];\n" +
+		"}\n"
+	if frames[1] != want {
+		t.Errorf("frame 2:\n%s\nwant:\n%s", frames[1], want)
+	}
+	for _, line := range []string{"\t2 [fillcolor=lightblue];\n", "\t2 -- 0 [penwidth=3];\n"} {
+		if !strings.Contains(frames[2], line) {
+			t.Errorf("frame 3 is missing %q:\n%s", line, frames[2])
+		}
+	}
+	if !strings.Contains(frames[3], "\t2 [fillcolor=gold, penwidth=2];\n") {
+		t.Errorf("frame 4 does not highlight the visited vertex:\n%s", frames[3])
+	}
+
+	paths, err := d.WriteFiles(filepath.Join(t.TempDir(), "bfs"))
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(paths) != 4 || filepath.Base(paths[3]) != "step-4.dot" {
+		t.Fatalf("WriteFiles() = %v", paths)
+	}
+	if data, err := os.ReadFile(paths[1]); err != nil || string(data) != want {
+		t.Errorf("%s holds %q, %v", paths[1], data, err)
+	}
+}